@@ -0,0 +1,108 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ansys/aali-sharedtypes/pkg/typeconverters"
+)
+
+// GoTypeDef is a supported Go type and the name of its constant
+type GoTypeDef struct {
+	Name  string // constant name without the GoType prefix, e.g. "SliceDbResponse"
+	Value string // Go type name, e.g. "[]DbResponse"
+}
+
+// constName derives the constant name of a Go type name
+// "*" becomes "Ptr", "[]" becomes "Slice", "map[K]V" becomes "Map" K V, "chan " becomes "Chan",
+// "interface{}" becomes "Interface" and identifiers are capitalized.
+func constName(goType string) string {
+	switch {
+	case goType == "":
+		return ""
+	case strings.HasPrefix(goType, "*"):
+		return "Ptr" + constName(goType[1:])
+	case strings.HasPrefix(goType, "[]"):
+		return "Slice" + constName(goType[2:])
+	case strings.HasPrefix(goType, "chan "):
+		return "Chan" + constName(goType[len("chan "):])
+	case strings.HasPrefix(goType, "interface{}"):
+		return "Interface" + constName(goType[len("interface{}"):])
+	case strings.HasPrefix(goType, "map["):
+		end := strings.Index(goType, "]")
+		return "Map" + constName(goType[len("map["):end]) + constName(goType[end+1:])
+	default:
+		return strings.ToUpper(goType[:1]) + goType[1:]
+	}
+}
+
+func main() {
+	supported := typeconverters.GetSupportedTypes()
+	sort.Strings(supported)
+
+	names := map[string]string{}
+	var data []GoTypeDef
+	for _, goType := range supported {
+		name := constName(goType)
+		if other, ok := names[name]; ok {
+			panic(fmt.Sprintf("Go types %q and %q both map to constant GoType%s", other, goType, name))
+		}
+		names[name] = goType
+		data = append(data, GoTypeDef{Name: name, Value: goType})
+	}
+
+	_, thisFile, _, _ := runtime.Caller(0)
+	genDir := filepath.Dir(thisFile)
+	tmplFile := filepath.Join(genDir, "gotype.gotmpl")
+	outFile := filepath.Join(genDir, "../../../pkg/typeconverters/gotypes.go")
+
+	tmpl := template.Must(template.New("").ParseFiles(tmplFile))
+
+	// execute template w/ data
+	var buf bytes.Buffer
+	err := tmpl.ExecuteTemplate(&buf, "gotype.gotmpl", data)
+	if err != nil {
+		panic(fmt.Sprintf("unable to execute template: %v", err))
+	}
+
+	// format the generated code
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		panic(fmt.Sprintf("unable to format generated code: %v\n\n%v", err, buf.String()))
+	}
+
+	// write to file
+	err = os.WriteFile(outFile, formatted, 0644)
+	if err != nil {
+		panic(fmt.Sprintf("unable to write generated code to file: %v", err))
+	}
+}
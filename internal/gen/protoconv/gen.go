@@ -0,0 +1,216 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"text/template"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// Conversion defines the converters between a proto message and a sharedtypes struct
+type Conversion struct {
+	Name    string            // base name of the generated functions
+	Proto   any               // the proto message
+	Shared  any               // the sharedtypes struct
+	Renames map[string]string // proto field names by sharedtypes field name, if they differ
+	NonNil  []string          // sharedtypes slice fields set to an empty slice instead of nil
+	Skip    []string          // sharedtypes fields without proto field, left empty by FromProto
+}
+
+// FieldConversion is the conversion of a single field
+type FieldConversion struct {
+	Shared     string // name of the sharedtypes field
+	Proto      string // name of the proto field
+	Kind       string // "direct", "cast", "slice" or "message"
+	SharedType string // Go type of the sharedtypes field, or of its elements for slices and messages
+	ProtoType  string // Go type of the proto field, or of its elements for slices and messages
+	Converter  string // base name of the element converters for slices and messages
+	NonNil     bool   // true to set an empty slice instead of nil
+}
+
+// ConversionData is the template data of a conversion
+type ConversionData struct {
+	Name       string
+	ProtoType  string
+	SharedType string
+	Fields     []FieldConversion
+	Skip       []string
+}
+
+// exportedFields returns the exported fields of a struct type by name, in declaration order
+func exportedFields(typ reflect.Type) (names []string, fields map[string]reflect.StructField) {
+	fields = map[string]reflect.StructField{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.IsExported() {
+			names = append(names, field.Name)
+			fields[field.Name] = field
+		}
+	}
+	return names, fields
+}
+
+// resolve computes the field conversions of a conversion, failing on unmapped or incompatible fields
+func resolve(conversion Conversion, converters map[[2]reflect.Type]string) ConversionData {
+	protoType := reflect.TypeOf(conversion.Proto)
+	sharedType := reflect.TypeOf(conversion.Shared)
+	data := ConversionData{
+		Name:       conversion.Name,
+		ProtoType:  protoType.String(),
+		SharedType: sharedType.String(),
+		Skip:       conversion.Skip,
+	}
+
+	sharedNames, sharedFields := exportedFields(sharedType)
+	_, protoFields := exportedFields(protoType)
+	mapped := map[string]bool{}
+	for _, name := range sharedNames {
+		if slices.Contains(conversion.Skip, name) {
+			continue
+		}
+		protoName := name
+		if renamed, ok := conversion.Renames[name]; ok {
+			protoName = renamed
+		}
+		protoField, ok := protoFields[protoName]
+		if !ok {
+			panic(fmt.Sprintf("%s: field %s has no proto field %s; add it to Renames or Skip", conversion.Name, name, protoName))
+		}
+		mapped[protoName] = true
+
+		sharedField := sharedFields[name]
+		field := FieldConversion{
+			Shared:     name,
+			Proto:      protoName,
+			SharedType: sharedField.Type.String(),
+			ProtoType:  protoField.Type.String(),
+			NonNil:     slices.Contains(conversion.NonNil, name),
+		}
+		switch {
+		case sharedField.Type == protoField.Type:
+			field.Kind = "direct"
+		case sharedField.Type.ConvertibleTo(protoField.Type) && sharedField.Type.Kind() != reflect.Slice:
+			field.Kind = "cast"
+		case sharedField.Type.Kind() == reflect.Slice && protoField.Type.Kind() == reflect.Slice:
+			elemConverter, ok := converters[[2]reflect.Type{protoField.Type.Elem().Elem(), sharedField.Type.Elem()}]
+			if !ok {
+				panic(fmt.Sprintf("%s: no conversion between elements of %s and %s", conversion.Name, protoField.Type, sharedField.Type))
+			}
+			field.Kind = "slice"
+			field.SharedType = sharedField.Type.Elem().String()
+			field.ProtoType = protoField.Type.Elem().String()
+			field.Converter = elemConverter
+		case sharedField.Type.Kind() == reflect.Pointer && protoField.Type.Kind() == reflect.Pointer:
+			converter, ok := converters[[2]reflect.Type{protoField.Type.Elem(), sharedField.Type.Elem()}]
+			if !ok {
+				panic(fmt.Sprintf("%s: no conversion between %s and %s", conversion.Name, protoField.Type, sharedField.Type))
+			}
+			field.Kind = "message"
+			field.SharedType = sharedField.Type.Elem().String()
+			field.ProtoType = protoField.Type.Elem().String()
+			field.Converter = converter
+		default:
+			panic(fmt.Sprintf("%s: field %s of type %s cannot be converted to %s", conversion.Name, name, sharedField.Type, protoField.Type))
+		}
+		data.Fields = append(data.Fields, field)
+	}
+
+	for name := range protoFields {
+		if !mapped[name] {
+			panic(fmt.Sprintf("%s: proto field %s has no sharedtypes field; add it to %s", conversion.Name, name, sharedType))
+		}
+	}
+	return data
+}
+
+func main() {
+	conversions := []Conversion{
+		{
+			Name:   "ResourceLimits",
+			Proto:  aaliflowkitgrpc.ResourceLimits{},
+			Shared: sharedtypes.ResourceLimits{},
+		},
+		{
+			Name:   "FunctionInput",
+			Proto:  aaliflowkitgrpc.FunctionInputDefinition{},
+			Shared: sharedtypes.FunctionInput{},
+			NonNil: []string{"Options"},
+		},
+		{
+			Name:   "FunctionOutput",
+			Proto:  aaliflowkitgrpc.FunctionOutputDefinition{},
+			Shared: sharedtypes.FunctionOutput{},
+		},
+		{
+			Name:    "FunctionDefinition",
+			Proto:   aaliflowkitgrpc.FunctionDefinition{},
+			Shared:  sharedtypes.FunctionDefinition{},
+			Renames: map[string]string{"Inputs": "Input", "Outputs": "Output"},
+			NonNil:  []string{"Inputs", "Outputs"},
+			Skip:    []string{"FlowkitUrl", "ApiKey", "Type", "Path"},
+		},
+	}
+
+	converters := map[[2]reflect.Type]string{}
+	var data []ConversionData
+	for _, conversion := range conversions {
+		data = append(data, resolve(conversion, converters))
+		converters[[2]reflect.Type{reflect.TypeOf(conversion.Proto), reflect.TypeOf(conversion.Shared)}] = conversion.Name
+	}
+
+	_, thisFile, _, _ := runtime.Caller(0)
+	genDir := filepath.Dir(thisFile)
+	tmplFile := filepath.Join(genDir, "protoconv.gotmpl")
+	outFile := filepath.Join(genDir, "../../../pkg/protoconv/protoconv.go")
+
+	tmpl := template.Must(template.New("").ParseFiles(tmplFile))
+
+	// execute template w/ data
+	var buf bytes.Buffer
+	err := tmpl.ExecuteTemplate(&buf, "protoconv.gotmpl", data)
+	if err != nil {
+		panic(fmt.Sprintf("unable to execute template: %v", err))
+	}
+
+	// format the generated code
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		panic(fmt.Sprintf("unable to format generated code: %v\n\n%v", err, buf.String()))
+	}
+
+	// write to file
+	err = os.WriteFile(outFile, formatted, 0644)
+	if err != nil {
+		panic(fmt.Sprintf("unable to write generated code to file: %v", err))
+	}
+}
@@ -42,6 +42,7 @@ type Client struct {
 	apiKey     string
 	logger     *zap.Logger
 	httpClient *http.Client
+	decrypter  PayloadDecrypter
 }
 
 func NewClient(address string, apiKey string, httpClient *http.Client) (*Client, error) {
@@ -50,7 +51,7 @@ func NewClient(address string, apiKey string, httpClient *http.Client) (*Client,
 		return nil, err
 	}
 	defer logger.Sync() //nolint:errcheck
-	return &Client{address, apiKey, logger, httpClient}, nil
+	return &Client{address: address, apiKey: apiKey, logger: logger, httpClient: httpClient}, nil
 }
 
 func DefaultClient(address string, apiKey string) (*Client, error) {
@@ -76,7 +77,7 @@ func (client Client) get(u string) (*http.Response, error) {
 		req.Header.Set("api-key", client.apiKey)
 	}
 
-	return client.httpClient.Do(req)
+	return client.do(req)
 }
 
 func (client Client) post(u string, body any) (*http.Response, error) {
@@ -95,7 +96,7 @@ func (client Client) post(u string, body any) (*http.Response, error) {
 		return nil, err
 	}
 
-	return client.httpClient.Do(req)
+	return client.do(req)
 }
 
 func (client Client) GetHealth() (bool, error) {
@@ -240,7 +241,7 @@ func (client Client) createDatabasePut(name string) error {
 		req.Header.Set("api-key", client.apiKey)
 	}
 
-	resp, err := client.httpClient.Do(req)
+	resp, err := client.do(req)
 	if err != nil {
 		return err
 	}
@@ -268,7 +269,7 @@ func (client Client) DeleteDatabase(name string) error {
 	if client.apiKey != "" {
 		req.Header.Set("api-key", client.apiKey)
 	}
-	resp, err := client.httpClient.Do(req)
+	resp, err := client.do(req)
 	if err != nil {
 		return err
 	}
@@ -532,7 +533,7 @@ func (client *Client) ImportDatabase(name string, src io.Reader) error {
 		req.Header.Set("api-key", client.apiKey)
 	}
 	req.Header.Set("Content-Type", w.FormDataContentType())
-	resp, err := client.httpClient.Do(req)
+	resp, err := client.do(req)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,106 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package aali_graphdb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ansys/aali-sharedtypes/pkg/clients"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+// PayloadDecrypter decrypts the response payloads of the data-shield proxy.
+type PayloadDecrypter interface {
+	Decrypt(payload []byte) ([]byte, error)
+}
+
+// WithDecrypter makes the client decrypt the bodies of successful responses,
+// as required when talking to aali-graphdb through the data-shield proxy.
+//
+// Parameters:
+//   - decrypter: The decrypter to use, nil to disable decryption.
+//
+// Returns:
+//   - *Client: The client.
+func (client *Client) WithDecrypter(decrypter PayloadDecrypter) *Client {
+	client.decrypter = decrypter
+	return client
+}
+
+// DefaultProxyClient creates a client routed through the data-shield proxy
+// configured by GRAPHDB_ADDRESS_ENCRYPTED in the global config.
+//
+// Parameters:
+//   - decrypter: The decrypter of the proxy payloads.
+//
+// Returns:
+//   - *Client: The client.
+//   - error: An error if the proxy is not configured.
+func DefaultProxyClient(decrypter PayloadDecrypter) (*Client, error) {
+	if decrypter == nil {
+		return nil, fmt.Errorf("a payload decrypter is required for the graphdb proxy")
+	}
+	connection, ok := config.EncryptedGraphDbConnection(config.Current())
+	if !ok {
+		return nil, fmt.Errorf("GRAPHDB_ADDRESS_ENCRYPTED is not configured")
+	}
+	httpClient, err := clients.GetHttpClient()
+	if err != nil {
+		return nil, fmt.Errorf("error getting HTTP client with cert: %v", err)
+	}
+	client, err := NewClient(connection.ADDRESS, connection.API_KEY, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	return client.WithDecrypter(decrypter), nil
+}
+
+// do sends a request and decrypts the body of a successful response if the client has a decrypter.
+// Error responses are generated by the proxy itself and are left as they are.
+func (client Client) do(req *http.Request) (*http.Response, error) {
+	resp, err := client.httpClient.Do(req)
+	if err != nil || client.decrypter == nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, err
+	}
+
+	payload, err := io.ReadAll(resp.Body)
+	if e := resp.Body.Close(); e != nil {
+		client.logger.Warn("could not close body")
+	}
+	if err != nil {
+		return nil, err
+	}
+	body, err := client.decrypter.Decrypt(payload)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt proxy response: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return resp, nil
+}
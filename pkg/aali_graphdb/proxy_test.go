@@ -0,0 +1,109 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package aali_graphdb
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reverseDecrypter "decrypts" payloads by reversing them.
+type reverseDecrypter struct{}
+
+func (reverseDecrypter) Decrypt(payload []byte) ([]byte, error) {
+	if bytes.HasPrefix(payload, []byte("bad")) {
+		return nil, fmt.Errorf("invalid payload")
+	}
+	out := make([]byte, len(payload))
+	for i, b := range payload {
+		out[len(payload)-1-i] = b
+	}
+	return out, nil
+}
+
+func reverse(s string) string {
+	out, _ := reverseDecrypter{}.Decrypt([]byte(s))
+	return string(out)
+}
+
+func TestClientDecryptsProxyPayloads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key", r.Header.Get("api-key"))
+		switch r.URL.Path {
+		case "/databases":
+			_, _ = w.Write([]byte(reverse(`{"databases":["docs","products"]}`)))
+		case "/databases/corrupt/schema":
+			_, _ = w.Write([]byte("bad payload"))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "key", server.Client())
+	require.NoError(t, err)
+	client.WithDecrypter(reverseDecrypter{})
+
+	databases, err := client.GetDatabases()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs", "products"}, databases)
+
+	err = client.DeleteDatabase("missing")
+	assert.ErrorContains(t, err, "404", "error responses are not decrypted")
+
+	_, err = client.do(mustRequest(t, server.URL+"/databases/corrupt/schema"))
+	assert.ErrorContains(t, err, "could not decrypt proxy response")
+}
+
+func mustRequest(t *testing.T, u string) *http.Request {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	require.NoError(t, err)
+	req.Header.Set("api-key", "key")
+	return req
+}
+
+func TestDefaultProxyClient(t *testing.T) {
+	original := config.GlobalConfig
+	defer func() { config.GlobalConfig = original }()
+
+	config.GlobalConfig = &config.Config{}
+	_, err := DefaultProxyClient(reverseDecrypter{})
+	assert.ErrorContains(t, err, "GRAPHDB_ADDRESS_ENCRYPTED")
+
+	_, err = DefaultProxyClient(nil)
+	assert.ErrorContains(t, err, "decrypter")
+
+	config.GlobalConfig = &config.Config{GRAPHDB_ADDRESS_ENCRYPTED: "http://proxy:8081", GRAPHDB_API_KEY: "key"}
+	client, err := DefaultProxyClient(reverseDecrypter{})
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy:8081", client.address)
+	assert.Equal(t, "key", client.apiKey)
+	assert.NotNil(t, client.decrypter)
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package aali_graphdb
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ansys/aali-sharedtypes/pkg/clients"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+// Router routes requests to one of several aali-graphdb servers, by connection name or by database name prefix.
+type Router struct {
+	connections config.DatabaseConnections
+	clients     map[routerKey]*Client
+}
+
+type routerKey struct {
+	address string
+	apiKey  string
+}
+
+// NewRouter creates a router with one client per connection.
+func NewRouter(connections config.DatabaseConnections, httpClient *http.Client) (*Router, error) {
+	if len(connections) == 0 {
+		return nil, fmt.Errorf("no graphdb connections configured")
+	}
+	if err := connections.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid graphdb connections: %w", err)
+	}
+
+	router := &Router{connections: connections, clients: map[routerKey]*Client{}}
+	for _, connection := range connections {
+		key := routerKey{connection.ADDRESS, connection.API_KEY}
+		if _, ok := router.clients[key]; ok {
+			continue
+		}
+		client, err := NewClient(connection.ADDRESS, connection.API_KEY, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		router.clients[key] = client
+	}
+	return router, nil
+}
+
+// DefaultRouter creates a router for the GraphDB connections of the global config,
+// falling back to GRAPHDB_ADDRESS if GRAPHDB_CONNECTIONS is empty.
+func DefaultRouter() (*Router, error) {
+	client, err := clients.GetHttpClient()
+	if err != nil {
+		return nil, fmt.Errorf("error getting HTTP client with cert: %v", err)
+	}
+	return NewRouter(config.GraphDbConnections(config.Current()), client)
+}
+
+// ForName returns the client of the connection with the given name.
+func (router *Router) ForName(name string) (*Client, error) {
+	connection, err := router.connections.ByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return router.clients[routerKey{connection.ADDRESS, connection.API_KEY}], nil
+}
+
+// ForDatabase returns the client of the connection serving read or write requests to a database.
+func (router *Router) ForDatabase(db string, write bool) (*Client, error) {
+	connection, err := router.connections.Route(db, write)
+	if err != nil {
+		return nil, err
+	}
+	return router.clients[routerKey{connection.ADDRESS, connection.API_KEY}], nil
+}
+
+// CypherQueryRead runs a read query on the connection serving reads of the database.
+func (router *Router) CypherQueryRead(db string, cypher string, parameters Parameters) ([]map[string]any, error) {
+	client, err := router.ForDatabase(db, false)
+	if err != nil {
+		return nil, err
+	}
+	return client.CypherQueryRead(db, cypher, parameters)
+}
+
+// CypherQueryWrite runs a write query on the connection serving writes of the database.
+func (router *Router) CypherQueryWrite(db string, cypher string, parameters Parameters) ([]map[string]any, error) {
+	client, err := router.ForDatabase(db, true)
+	if err != nil {
+		return nil, err
+	}
+	return client.CypherQueryWrite(db, cypher, parameters)
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package aali_graphdb
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter(t *testing.T) {
+	router, err := NewRouter(config.DatabaseConnections{
+		{NAME: "main", ADDRESS: "http://main:8080", API_KEY: "main-key"},
+		{NAME: "products", ADDRESS: "http://products:8080", PREFIXES: []string{"product_"}},
+		{NAME: "replica", ADDRESS: "http://products:8080", ROLE: config.ConnectionRoleRead, PREFIXES: []string{"product_fluent_"}},
+	}, http.DefaultClient)
+	require.NoError(t, err)
+
+	client, err := router.ForDatabase("docs", true)
+	require.NoError(t, err)
+	assert.Equal(t, "http://main:8080", client.address)
+	assert.Equal(t, "main-key", client.apiKey)
+
+	client, err = router.ForDatabase("product_fluent_v2", false)
+	require.NoError(t, err)
+	assert.Equal(t, "http://products:8080", client.address)
+
+	writer, err := router.ForDatabase("product_fluent_v2", true)
+	require.NoError(t, err)
+	assert.Same(t, client, writer, "connections with the same address and API key share a client")
+
+	client, err = router.ForName("main")
+	require.NoError(t, err)
+	assert.Equal(t, "http://main:8080", client.address)
+
+	_, err = router.ForName("unknown")
+	assert.Error(t, err)
+}
+
+func TestNewRouterInvalidConnections(t *testing.T) {
+	_, err := NewRouter(nil, http.DefaultClient)
+	assert.Error(t, err)
+
+	_, err = NewRouter(config.DatabaseConnections{{NAME: "main", ADDRESS: "http://main:8080", ROLE: "admin"}}, http.DefaultClient)
+	assert.ErrorContains(t, err, "invalid role")
+}
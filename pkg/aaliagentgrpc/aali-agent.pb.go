@@ -115,7 +115,9 @@ type ServerMessage struct {
 	//	*ServerMessage_ConnectionStatus
 	//	*ServerMessage_AuthenticationStatus
 	//	*ServerMessage_ClientResponse
-	MessageType   isServerMessage_MessageType `protobuf_oneof:"message_type"`
+	MessageType isServerMessage_MessageType `protobuf_oneof:"message_type"`
+	// Sequence number of the message within the workflow run, starting at 1; 0 if the server does not support resuming
+	Sequence      uint64 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -184,6 +186,13 @@ func (x *ServerMessage) GetClientResponse() *ClientResponse {
 	return nil
 }
 
+func (x *ServerMessage) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
 type isServerMessage_MessageType interface {
 	isServerMessage_MessageType()
 }
@@ -231,9 +240,13 @@ type SessionContext struct {
 	// API key for authentication
 	ApiKey string `protobuf:"bytes,9,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
 	// Chat model ID; if defined, the given chat model will be used for the workflow run
-	ChatModelId   string `protobuf:"bytes,10,opt,name=chat_model_id,json=chatModelId,proto3" json:"chat_model_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	ChatModelId string `protobuf:"bytes,10,opt,name=chat_model_id,json=chatModelId,proto3" json:"chat_model_id,omitempty"`
+	// Resume token of a connection status message; if defined, the workflow run of the token is resumed after a reconnect
+	ResumeToken string `protobuf:"bytes,11,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// Sequence number of the last server message received before the reconnect; later messages are sent again when resuming
+	LastReceivedSequence uint64 `protobuf:"varint,12,opt,name=last_received_sequence,json=lastReceivedSequence,proto3" json:"last_received_sequence,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *SessionContext) Reset() {
@@ -336,6 +349,20 @@ func (x *SessionContext) GetChatModelId() string {
 	return ""
 }
 
+func (x *SessionContext) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *SessionContext) GetLastReceivedSequence() uint64 {
+	if x != nil {
+		return x.LastReceivedSequence
+	}
+	return 0
+}
+
 // ClientRequest is the message to send a request to the server.
 type ClientRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -560,8 +587,10 @@ type ConnectionStatus struct {
 	WorkflowRunId string `protobuf:"bytes,2,opt,name=workflow_run_id,json=workflowRunId,proto3" json:"workflow_run_id,omitempty"`
 	// Maximum number of snapshots that can be stored in the database per workflow run
 	MaxNumberOfSnapshots int32 `protobuf:"varint,3,opt,name=max_number_of_snapshots,json=maxNumberOfSnapshots,proto3" json:"max_number_of_snapshots,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	// Token to resume the workflow run after a reconnect; empty if the server does not support resuming
+	ResumeToken   string `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ConnectionStatus) Reset() {
@@ -615,6 +644,13 @@ func (x *ConnectionStatus) GetMaxNumberOfSnapshots() int32 {
 	return 0
 }
 
+func (x *ConnectionStatus) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 // AuthenticationStatus is the message to indicate failing authentication after client sends a session context message with authentication enabled.
 type AuthenticationStatus struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1118,12 +1154,13 @@ const file_pkg_aaliagentgrpc_aali_agent_proto_rawDesc = "" +
 	"\rClientMessage\x12H\n" +
 	"\x0fsession_context\x18\x01 \x01(\v2\x1d.aaliagentgrpc.SessionContextH\x00R\x0esessionContext\x12E\n" +
 	"\x0eclient_request\x18\x02 \x01(\v2\x1c.aaliagentgrpc.ClientRequestH\x00R\rclientRequestB\x0e\n" +
-	"\fmessage_type\"\x95\x02\n" +
+	"\fmessage_type\"\xb1\x02\n" +
 	"\rServerMessage\x12N\n" +
 	"\x11connection_status\x18\x01 \x01(\v2\x1f.aaliagentgrpc.ConnectionStatusH\x00R\x10connectionStatus\x12Z\n" +
 	"\x15authentication_status\x18\x02 \x01(\v2#.aaliagentgrpc.AuthenticationStatusH\x00R\x14authenticationStatus\x12H\n" +
-	"\x0fclient_response\x18\x03 \x01(\v2\x1d.aaliagentgrpc.ClientResponseH\x00R\x0eclientResponseB\x0e\n" +
-	"\fmessage_type\"\xbf\x03\n" +
+	"\x0fclient_response\x18\x03 \x01(\v2\x1d.aaliagentgrpc.ClientResponseH\x00R\x0eclientResponse\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x04R\bsequenceB\x0e\n" +
+	"\fmessage_type\"\x98\x04\n" +
 	"\x0eSessionContext\x12\x1b\n" +
 	"\tjwt_token\x18\x01 \x01(\tR\bjwtToken\x12\x1f\n" +
 	"\vworkflow_id\x18\x02 \x01(\tR\n" +
@@ -1138,7 +1175,9 @@ const file_pkg_aaliagentgrpc_aali_agent_proto_rawDesc = "" +
 	"ip_address\x18\b \x01(\tR\tipAddress\x12\x17\n" +
 	"\aapi_key\x18\t \x01(\tR\x06apiKey\x12\"\n" +
 	"\rchat_model_id\x18\n" +
-	" \x01(\tR\vchatModelId\x1a<\n" +
+	" \x01(\tR\vchatModelId\x12!\n" +
+	"\fresume_token\x18\v \x01(\tR\vresumeToken\x124\n" +
+	"\x16last_received_sequence\x18\f \x01(\x04R\x14lastReceivedSequence\x1a<\n" +
 	"\x0eVariablesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x91\x04\n" +
@@ -1166,11 +1205,12 @@ const file_pkg_aaliagentgrpc_aali_agent_proto_rawDesc = "" +
 	"\fadd_positive\x18\x02 \x01(\bR\vaddPositive\x12!\n" +
 	"\fadd_negative\x18\x03 \x01(\bR\vaddNegative\x12'\n" +
 	"\x0fremove_positive\x18\x04 \x01(\bR\x0eremovePositive\x12'\n" +
-	"\x0fremove_negative\x18\x05 \x01(\bR\x0eremoveNegative\"\x9d\x01\n" +
+	"\x0fremove_negative\x18\x05 \x01(\bR\x0eremoveNegative\"\xc0\x01\n" +
 	"\x10ConnectionStatus\x12*\n" +
 	"\x10connectionStatus\x18\x01 \x01(\tR\x10connectionStatus\x12&\n" +
 	"\x0fworkflow_run_id\x18\x02 \x01(\tR\rworkflowRunId\x125\n" +
-	"\x17max_number_of_snapshots\x18\x03 \x01(\x05R\x14maxNumberOfSnapshots\"J\n" +
+	"\x17max_number_of_snapshots\x18\x03 \x01(\x05R\x14maxNumberOfSnapshots\x12!\n" +
+	"\fresume_token\x18\x04 \x01(\tR\vresumeToken\"J\n" +
 	"\x14AuthenticationStatus\x122\n" +
 	"\x14authenticationStatus\x18\x01 \x01(\tR\x14authenticationStatus\"\xb1\a\n" +
 	"\x0eClientResponse\x12%\n" +
@@ -0,0 +1,260 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package aalierrors provides errors classified by a code, carrying the workflow context they occurred in,
+// with conversion to and from gRPC statuses and helpers to decide whether an operation can be retried.
+package aalierrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Code classifies an error
+type Code string
+
+// Error codes
+const (
+	CodeValidation Code = "validation" // invalid input; retrying with the same input fails again
+	CodeNotFound   Code = "not_found"  // the requested resource does not exist
+	CodeAuth       Code = "auth"       // missing or insufficient credentials
+	CodeTransient  Code = "transient"  // temporary failure, e.g. unavailable service or exceeded deadline; can be retried
+	CodeInternal   Code = "internal"   // unexpected failure, e.g. a bug or a panic
+)
+
+// contextKeys are the ContextMap keys attached to errors as details
+var contextKeys = []logging.ContextKey{
+	logging.WorkflowId,
+	logging.InstructionGuid,
+}
+
+// Error is an error with a code and details about the context it occurred in
+type Error struct {
+	Code    Code
+	Message string
+	Details map[string]string // e.g. workflowId and instructionGuid
+	Err     error             // wrapped error; can be nil
+
+	grpcCode codes.Code // gRPC code of an error received from a gRPC call; OK if not set
+}
+
+// Error returns the message followed by the message of the wrapped error
+//
+// Returns:
+//   - string: the error message
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	if e.Message == "" {
+		return e.Err.Error()
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+// Unwrap returns the wrapped error
+//
+// Returns:
+//   - error: the wrapped error; nil if none
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus returns the gRPC status of the error, so it can be returned by gRPC servers as is
+//
+// Returns:
+//   - *status.Status: the gRPC status
+func (e *Error) GRPCStatus() *status.Status {
+	return ToGRPCStatus(e)
+}
+
+// New creates an error with the workflow context of ctx
+//
+// Parameters:
+//   - ctx: the context of the operation; can be nil
+//   - code: the error code
+//   - message: the error message
+//
+// Returns:
+//   - *Error: the error
+func New(ctx *logging.ContextMap, code Code, message string) *Error {
+	return &Error{
+		Code:    code,
+		Message: message,
+		Details: contextDetails(ctx, nil),
+	}
+}
+
+// Newf creates an error with a formatted message and the workflow context of ctx
+//
+// Parameters:
+//   - ctx: the context of the operation; can be nil
+//   - code: the error code
+//   - format: the format of the error message
+//   - args: the format arguments
+//
+// Returns:
+//   - *Error: the error
+func Newf(ctx *logging.ContextMap, code Code, format string, args ...interface{}) *Error {
+	return New(ctx, code, fmt.Sprintf(format, args...))
+}
+
+// Wrap wraps an error with a code, a message and the workflow context of ctx
+// Details of a wrapped *Error are kept.
+//
+// Parameters:
+//   - ctx: the context of the operation; can be nil
+//   - code: the error code
+//   - err: the error to wrap
+//   - message: the error message
+//
+// Returns:
+//   - error: the wrapped error; nil if err is nil
+func Wrap(ctx *logging.ContextMap, code Code, err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	var inner *Error
+	var details map[string]string
+	if errors.As(err, &inner) {
+		details = inner.Details
+	}
+	return &Error{
+		Code:    code,
+		Message: message,
+		Details: contextDetails(ctx, details),
+		Err:     err,
+	}
+}
+
+// Wrapf wraps an error with a code, a formatted message and the workflow context of ctx
+//
+// Parameters:
+//   - ctx: the context of the operation; can be nil
+//   - code: the error code
+//   - err: the error to wrap
+//   - format: the format of the error message
+//   - args: the format arguments
+//
+// Returns:
+//   - error: the wrapped error; nil if err is nil
+func Wrapf(ctx *logging.ContextMap, code Code, err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return Wrap(ctx, code, err, fmt.Sprintf(format, args...))
+}
+
+// CodeOf returns the code of an error
+// Errors without code are classified by their type: exceeded deadlines are transient,
+// gRPC statuses are mapped with FromGRPCCode and all other errors are internal.
+//
+// Parameters:
+//   - err: the error
+//
+// Returns:
+//   - Code: the error code; empty if err is nil
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return CodeTransient
+	}
+	if st, ok := status.FromError(err); ok {
+		return FromGRPCCode(st.Code())
+	}
+	return CodeInternal
+}
+
+// IsRetryable checks if the operation failing with err can be retried
+// Transient errors are retryable, unless the operation was cancelled.
+//
+// Parameters:
+//   - err: the error
+//
+// Returns:
+//   - bool: true if the operation can be retried
+func IsRetryable(err error) bool {
+	return CodeOf(err) == CodeTransient && !errors.Is(err, context.Canceled)
+}
+
+// FromHTTPStatus returns the code of a failed HTTP response
+//
+// Parameters:
+//   - statusCode: the HTTP status code
+//
+// Returns:
+//   - Code: the error code
+func FromHTTPStatus(statusCode int) Code {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusConflict, http.StatusPreconditionFailed,
+		http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		return CodeValidation
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return CodeAuth
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return CodeTransient
+	default:
+		return CodeInternal
+	}
+}
+
+// contextDetails returns the details of an error with the workflow context of ctx
+//
+// Parameters:
+//   - ctx: the context of the operation; can be nil
+//   - details: existing details, taking precedence over the context
+//
+// Returns:
+//   - map[string]string: the details; nil if empty
+func contextDetails(ctx *logging.ContextMap, details map[string]string) map[string]string {
+	result := map[string]string{}
+	if ctx != nil {
+		for _, key := range contextKeys {
+			if value, ok := ctx.Get(key); ok && value != nil && fmt.Sprint(value) != "" {
+				result[string(key)] = fmt.Sprint(value)
+			}
+		}
+	}
+	for key, value := range details {
+		result[key] = value
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package aalierrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func workflowContext() *logging.ContextMap {
+	ctx := &logging.ContextMap{}
+	ctx.Set(logging.WorkflowId, "workflow-1")
+	ctx.Set(logging.InstructionGuid, "instruction-1")
+	ctx.Set(logging.UserId, "user-1")
+	return ctx
+}
+
+func TestNew(t *testing.T) {
+	err := Newf(workflowContext(), CodeValidation, "input '%s' is missing", "query")
+	if err.Error() != "input 'query' is missing" || err.Code != CodeValidation {
+		t.Errorf("Newf() = %q with code %q", err.Error(), err.Code)
+	}
+	want := map[string]string{"workflowId": "workflow-1", "instructionGuid": "instruction-1"}
+	if !reflect.DeepEqual(err.Details, want) {
+		t.Errorf("Details = %v, want %v", err.Details, want)
+	}
+
+	if err := New(nil, CodeInternal, "no context"); err.Details != nil {
+		t.Errorf("Details without context = %v, want nil", err.Details)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	if Wrap(nil, CodeInternal, nil, "message") != nil || Wrapf(nil, CodeInternal, nil, "%s", "message") != nil {
+		t.Error("Wrap() of nil error is not nil")
+	}
+
+	cause := errors.New("connection refused")
+	err := Wrapf(workflowContext(), CodeTransient, cause, "error calling function '%s'", "embed")
+	if err.Error() != "error calling function 'embed': connection refused" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if !errors.Is(err, cause) {
+		t.Error("wrapped error does not match its cause")
+	}
+
+	// details of wrapped errors are kept
+	inner := &Error{Code: CodeValidation, Message: "inner", Details: map[string]string{"workflowId": "inner-workflow", "field": "query"}}
+	outer := Wrap(workflowContext(), CodeInternal, fmt.Errorf("context: %w", inner), "outer")
+	var e *Error
+	if !errors.As(outer, &e) {
+		t.Fatal("Wrap() did not return *Error")
+	}
+	want := map[string]string{"workflowId": "inner-workflow", "instructionGuid": "instruction-1", "field": "query"}
+	if !reflect.DeepEqual(e.Details, want) || e.Code != CodeInternal {
+		t.Errorf("Wrap() = %+v, want details %v", e, want)
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{"nil", nil, ""},
+		{"plain", errors.New("failed"), CodeInternal},
+		{"error", New(nil, CodeAuth, "no key"), CodeAuth},
+		{"wrapped error", fmt.Errorf("call: %w", New(nil, CodeValidation, "bad")), CodeValidation},
+		{"deadline", fmt.Errorf("call: %w", context.DeadlineExceeded), CodeTransient},
+		{"grpc unavailable", status.Error(codes.Unavailable, "down"), CodeTransient},
+		{"grpc permission", status.Error(codes.PermissionDenied, "denied"), CodeAuth},
+		{"grpc not found", status.Error(codes.NotFound, "missing"), CodeNotFound},
+		{"grpc unknown", status.Error(codes.Unknown, "?"), CodeInternal},
+		{"panic", logging.NewPanicError("op", "boom"), CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.want {
+				t.Errorf("CodeOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"transient", New(nil, CodeTransient, "busy"), true},
+		{"deadline", context.DeadlineExceeded, true},
+		{"grpc resource exhausted", status.Error(codes.ResourceExhausted, "rate limited"), true},
+		{"validation", New(nil, CodeValidation, "bad"), false},
+		{"internal", errors.New("bug"), false},
+		{"cancelled", Wrap(nil, CodeTransient, context.Canceled, "stopped"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromHTTPStatus(t *testing.T) {
+	tests := map[int]Code{
+		http.StatusBadRequest:          CodeValidation,
+		http.StatusNotFound:            CodeNotFound,
+		http.StatusUnauthorized:        CodeAuth,
+		http.StatusForbidden:           CodeAuth,
+		http.StatusTooManyRequests:     CodeTransient,
+		http.StatusServiceUnavailable:  CodeTransient,
+		http.StatusGatewayTimeout:      CodeTransient,
+		http.StatusInternalServerError: CodeInternal,
+		http.StatusTeapot:              CodeInternal,
+	}
+	for statusCode, want := range tests {
+		if got := FromHTTPStatus(statusCode); got != want {
+			t.Errorf("FromHTTPStatus(%d) = %q, want %q", statusCode, got, want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package aalierrors
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorInfoDomain is the domain of the ErrorInfo details attached to gRPC statuses
+const ErrorInfoDomain = "aali"
+
+// ToGRPCCode returns the gRPC code of an error code
+//
+// Parameters:
+//   - code: the error code
+//
+// Returns:
+//   - codes.Code: the gRPC code
+func ToGRPCCode(code Code) codes.Code {
+	switch code {
+	case CodeValidation:
+		return codes.InvalidArgument
+	case CodeNotFound:
+		return codes.NotFound
+	case CodeAuth:
+		return codes.Unauthenticated
+	case CodeTransient:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// FromGRPCCode returns the error code of a gRPC code
+//
+// Parameters:
+//   - code: the gRPC code
+//
+// Returns:
+//   - Code: the error code; empty for codes.OK
+func FromGRPCCode(code codes.Code) Code {
+	switch code {
+	case codes.OK:
+		return ""
+	case codes.InvalidArgument, codes.AlreadyExists, codes.FailedPrecondition, codes.OutOfRange:
+		return CodeValidation
+	case codes.NotFound:
+		return CodeNotFound
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return CodeAuth
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return CodeTransient
+	default:
+		return CodeInternal
+	}
+}
+
+// ToGRPCStatus converts an error to a gRPC status
+// The code and details of an *Error are sent in an ErrorInfo with domain ErrorInfoDomain, so they can be
+// restored with FromGRPCError. Errors without *Error in their chain keep their own gRPC status if they have one.
+//
+// Parameters:
+//   - err: the error
+//
+// Returns:
+//   - *status.Status: the gRPC status; OK if err is nil
+func ToGRPCStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		if st, ok := status.FromError(err); ok {
+			return st
+		}
+		return status.New(grpcCode(err, CodeOf(err)), err.Error())
+	}
+
+	code := e.grpcCode
+	if code == codes.OK {
+		code = grpcCode(err, e.Code)
+	}
+	st := status.New(code, err.Error())
+	withDetails, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   strings.ToUpper(string(e.Code)),
+		Domain:   ErrorInfoDomain,
+		Metadata: e.Details,
+	})
+	if detailsErr != nil {
+		return st
+	}
+	return withDetails
+}
+
+// FromGRPCError converts an error returned by a gRPC call to an *Error
+// The code and details are restored from the ErrorInfo sent by ToGRPCStatus, or derived from the gRPC code.
+//
+// Parameters:
+//   - err: the error of the gRPC call
+//
+// Returns:
+//   - error: the converted *Error; err unchanged if it is nil, an *Error or not a gRPC status
+func FromGRPCError(err error) error {
+	var e *Error
+	if err == nil || errors.As(err, &e) {
+		return err
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	converted := &Error{
+		Code:     FromGRPCCode(st.Code()),
+		Message:  st.Message(),
+		grpcCode: st.Code(),
+	}
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.Domain != ErrorInfoDomain {
+			continue
+		}
+		converted.Code = Code(strings.ToLower(info.Reason))
+		converted.Details = info.Metadata
+	}
+	return converted
+}
+
+// grpcCode returns the gRPC code of an error, keeping cancellations and exceeded deadlines
+//
+// Parameters:
+//   - err: the error
+//   - code: the error code of err
+//
+// Returns:
+//   - codes.Code: the gRPC code
+func grpcCode(err error, code Code) codes.Code {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	default:
+		return ToGRPCCode(code)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package aalierrors
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCCodes(t *testing.T) {
+	for _, code := range []Code{CodeValidation, CodeNotFound, CodeAuth, CodeTransient, CodeInternal} {
+		if got := FromGRPCCode(ToGRPCCode(code)); got != code {
+			t.Errorf("FromGRPCCode(ToGRPCCode(%q)) = %q", code, got)
+		}
+	}
+	if got := FromGRPCCode(codes.OK); got != "" {
+		t.Errorf("FromGRPCCode(OK) = %q, want empty", got)
+	}
+}
+
+func TestToGRPCStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"nil", nil, codes.OK},
+		{"validation", New(workflowContext(), CodeValidation, "bad input"), codes.InvalidArgument},
+		{"auth", New(nil, CodeAuth, "no key"), codes.Unauthenticated},
+		{"transient", New(nil, CodeTransient, "busy"), codes.Unavailable},
+		{"deadline", Wrap(nil, CodeTransient, context.DeadlineExceeded, "slow"), codes.DeadlineExceeded},
+		{"cancelled", Wrap(nil, CodeTransient, context.Canceled, "stopped"), codes.Canceled},
+		{"plain", errors.New("bug"), codes.Internal},
+		{"grpc status kept", status.Error(codes.NotFound, "missing"), codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToGRPCStatus(tt.err).Code(); got != tt.want {
+				t.Errorf("ToGRPCStatus().Code() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// *Error is converted by the gRPC status package directly
+	if got := status.Code(New(nil, CodeAuth, "no key")); got != codes.Unauthenticated {
+		t.Errorf("status.Code() = %v, want Unauthenticated", got)
+	}
+}
+
+func TestGRPCRoundTrip(t *testing.T) {
+	sent := New(workflowContext(), CodeValidation, "input 'query' is missing")
+	received := FromGRPCError(ToGRPCStatus(sent).Err())
+
+	var e *Error
+	if !errors.As(received, &e) {
+		t.Fatalf("FromGRPCError() = %T, want *Error", received)
+	}
+	if e.Code != CodeValidation || e.Message != sent.Message || !reflect.DeepEqual(e.Details, sent.Details) {
+		t.Errorf("FromGRPCError() = %+v, want %+v", e, sent)
+	}
+	if got := status.Code(received); got != codes.InvalidArgument {
+		t.Errorf("status.Code() of received error = %v, want InvalidArgument", got)
+	}
+}
+
+func TestFromGRPCError(t *testing.T) {
+	if FromGRPCError(nil) != nil {
+		t.Error("FromGRPCError(nil) is not nil")
+	}
+	plain := errors.New("not a status")
+	if FromGRPCError(plain) != plain {
+		t.Error("FromGRPCError() changed a non-status error")
+	}
+
+	// statuses without ErrorInfo keep their gRPC code
+	received := FromGRPCError(status.Error(codes.NotFound, "no such function"))
+	if CodeOf(received) != CodeNotFound || received.Error() != "no such function" {
+		t.Errorf("FromGRPCError() = %v with code %q", received, CodeOf(received))
+	}
+	if got := status.Code(received); got != codes.NotFound {
+		t.Errorf("status.Code() = %v, want NotFound", got)
+	}
+
+	// exceeded deadlines of the remote call are transient
+	if !IsRetryable(FromGRPCError(status.Error(codes.DeadlineExceeded, "slow"))) {
+		t.Error("remote deadline exceeded is not retryable")
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package aalierrors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, http.StatusOK},
+		{"validation", New(nil, CodeValidation, "bad input"), http.StatusBadRequest},
+		{"auth", New(nil, CodeAuth, "no key"), http.StatusUnauthorized},
+		{"transient", New(nil, CodeTransient, "busy"), http.StatusServiceUnavailable},
+		{"internal", New(nil, CodeInternal, "bug"), http.StatusInternalServerError},
+		{"deadline", Wrap(nil, CodeTransient, context.DeadlineExceeded, "slow"), http.StatusGatewayTimeout},
+		{"plain", errors.New("bug"), http.StatusInternalServerError},
+		{"grpc status", status.Error(codes.NotFound, "missing"), http.StatusNotFound},
+		{"converted grpc status", FromGRPCError(status.Error(codes.PermissionDenied, "denied")), http.StatusForbidden},
+		{"resource exhausted", status.Error(codes.ResourceExhausted, "slow down"), http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToHTTPStatus(tt.err); got != tt.want {
+				t.Errorf("ToHTTPStatus() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPStatusRoundTrip(t *testing.T) {
+	for _, code := range []Code{CodeValidation, CodeAuth, CodeTransient, CodeInternal} {
+		if got := FromHTTPStatus(ToHTTPStatus(New(nil, code, "error"))); got != code {
+			t.Errorf("FromHTTPStatus(ToHTTPStatus(%q)) = %q", code, got)
+		}
+	}
+}
@@ -0,0 +1,1064 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v4.25.9
+// source: pkg/aaliexecgrpc/aali-exec.proto
+
+package aaliexecgrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HealthRequest is the input message for the HealthCheck method.
+type HealthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthRequest) Reset() {
+	*x = HealthRequest{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthRequest) ProtoMessage() {}
+
+func (x *HealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{0}
+}
+
+// HealthResponse is the output message for the HealthCheck method.
+type HealthResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Status of the health check, always "OK" for a healthy service.
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Semantic version of the service, e.g. "1.4.2".
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Semantic version of the gRPC protocol implemented by the service, e.g. "1.0.0".
+	ProtocolVersion string `protobuf:"bytes,3,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HealthResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetProtocolVersion() string {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return ""
+}
+
+// ExecuteRequest is the input message for the Execute method.
+type ExecuteRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	InstructionGuid string                 `protobuf:"bytes,1,opt,name=instruction_guid,json=instructionGuid,proto3" json:"instruction_guid,omitempty"`
+	// "python", "bash"
+	CodeType string `protobuf:"bytes,2,opt,name=code_type,json=codeType,proto3" json:"code_type,omitempty"`
+	// Code to be executed.
+	Code []string `protobuf:"bytes,3,rep,name=code,proto3" json:"code,omitempty"`
+	// Executable of the virtual environment to use.
+	VenvExecutable string `protobuf:"bytes,4,opt,name=venv_executable,json=venvExecutable,proto3" json:"venv_executable,omitempty"`
+	// Input files written to the working directory before the execution.
+	Files []*ExecutionFile `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`
+	// Additional environment variables.
+	Env map[string]string `protobuf:"bytes,6,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Relative to the exec file store; a new directory is used if empty.
+	WorkingDirectory string `protobuf:"bytes,7,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	// Resource limits; the aali-exec defaults apply if not set.
+	Limits *ResourceLimits `protobuf:"bytes,8,opt,name=limits,proto3" json:"limits,omitempty"`
+	// Glob patterns of the produced files returned as artifacts, e.g. "*.png".
+	ArtifactPatterns []string `protobuf:"bytes,9,rep,name=artifact_patterns,json=artifactPatterns,proto3" json:"artifact_patterns,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ExecuteRequest) Reset() {
+	*x = ExecuteRequest{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteRequest) ProtoMessage() {}
+
+func (x *ExecuteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExecuteRequest) GetInstructionGuid() string {
+	if x != nil {
+		return x.InstructionGuid
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetCodeType() string {
+	if x != nil {
+		return x.CodeType
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetCode() []string {
+	if x != nil {
+		return x.Code
+	}
+	return nil
+}
+
+func (x *ExecuteRequest) GetVenvExecutable() string {
+	if x != nil {
+		return x.VenvExecutable
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetFiles() []*ExecutionFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ExecuteRequest) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *ExecuteRequest) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+func (x *ExecuteRequest) GetLimits() *ResourceLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *ExecuteRequest) GetArtifactPatterns() []string {
+	if x != nil {
+		return x.ArtifactPatterns
+	}
+	return nil
+}
+
+// ExecutionFile represents an input file of an execution.
+type ExecutionFile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Relative to the working directory.
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Content       []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutionFile) Reset() {
+	*x = ExecutionFile{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionFile) ProtoMessage() {}
+
+func (x *ExecutionFile) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionFile.ProtoReflect.Descriptor instead.
+func (*ExecutionFile) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExecutionFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ExecutionFile) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// ResourceLimits represents the resource limits of an execution; zero values mean no limit.
+type ResourceLimits struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TimeoutSeconds int32                  `protobuf:"varint,1,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	MemoryMb       int32                  `protobuf:"varint,2,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	CpuCores       int32                  `protobuf:"varint,3,opt,name=cpu_cores,json=cpuCores,proto3" json:"cpu_cores,omitempty"`
+	// Maximum size of stdout and stderr each; the output is truncated beyond.
+	MaxOutputBytes int64 `protobuf:"varint,4,opt,name=max_output_bytes,json=maxOutputBytes,proto3" json:"max_output_bytes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ResourceLimits) Reset() {
+	*x = ResourceLimits{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceLimits) ProtoMessage() {}
+
+func (x *ResourceLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceLimits.ProtoReflect.Descriptor instead.
+func (*ResourceLimits) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ResourceLimits) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetMemoryMb() int32 {
+	if x != nil {
+		return x.MemoryMb
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetCpuCores() int32 {
+	if x != nil {
+		return x.CpuCores
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetMaxOutputBytes() int64 {
+	if x != nil {
+		return x.MaxOutputBytes
+	}
+	return 0
+}
+
+// ExecuteResponse is a message of the output stream of the Execute method.
+type ExecuteResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	InstructionGuid string                 `protobuf:"bytes,1,opt,name=instruction_guid,json=instructionGuid,proto3" json:"instruction_guid,omitempty"`
+	// Types that are valid to be assigned to MessageType:
+	//
+	//	*ExecuteResponse_Output
+	//	*ExecuteResponse_Result
+	MessageType   isExecuteResponse_MessageType `protobuf_oneof:"message_type"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteResponse) Reset() {
+	*x = ExecuteResponse{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteResponse) ProtoMessage() {}
+
+func (x *ExecuteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExecuteResponse) GetInstructionGuid() string {
+	if x != nil {
+		return x.InstructionGuid
+	}
+	return ""
+}
+
+func (x *ExecuteResponse) GetMessageType() isExecuteResponse_MessageType {
+	if x != nil {
+		return x.MessageType
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetOutput() *OutputChunk {
+	if x != nil {
+		if x, ok := x.MessageType.(*ExecuteResponse_Output); ok {
+			return x.Output
+		}
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetResult() *ExecutionResult {
+	if x != nil {
+		if x, ok := x.MessageType.(*ExecuteResponse_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isExecuteResponse_MessageType interface {
+	isExecuteResponse_MessageType()
+}
+
+type ExecuteResponse_Output struct {
+	// Part of the output of the running execution.
+	Output *OutputChunk `protobuf:"bytes,2,opt,name=output,proto3,oneof"`
+}
+
+type ExecuteResponse_Result struct {
+	// Result of the finished execution; always the last message of the stream.
+	Result *ExecutionResult `protobuf:"bytes,3,opt,name=result,proto3,oneof"`
+}
+
+func (*ExecuteResponse_Output) isExecuteResponse_MessageType() {}
+
+func (*ExecuteResponse_Result) isExecuteResponse_MessageType() {}
+
+// OutputChunk represents a part of the output of a running execution.
+type OutputChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "stdout", "stderr"
+	Stream string `protobuf:"bytes,1,opt,name=stream,proto3" json:"stream,omitempty"`
+	// Output since the previous chunk of the stream.
+	Data string `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Increasing over all chunks of the execution, to restore the order of stdout and stderr.
+	Sequence      int64 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutputChunk) Reset() {
+	*x = OutputChunk{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutputChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputChunk) ProtoMessage() {}
+
+func (x *OutputChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputChunk.ProtoReflect.Descriptor instead.
+func (*OutputChunk) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OutputChunk) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *OutputChunk) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *OutputChunk) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+// ExecutionResult represents the result of a finished execution.
+type ExecutionResult struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ExitCode int32                  `protobuf:"varint,1,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Stdout   string                 `protobuf:"bytes,2,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr   string                 `protobuf:"bytes,3,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	// True if the output exceeded max_output_bytes.
+	OutputTruncated bool                 `protobuf:"varint,4,opt,name=output_truncated,json=outputTruncated,proto3" json:"output_truncated,omitempty"`
+	TimedOut        bool                 `protobuf:"varint,5,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	Duration        *durationpb.Duration `protobuf:"bytes,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	Artifacts       []*Artifact          `protobuf:"bytes,7,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExecutionResult) Reset() {
+	*x = ExecutionResult{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionResult) ProtoMessage() {}
+
+func (x *ExecutionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionResult.ProtoReflect.Descriptor instead.
+func (*ExecutionResult) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExecutionResult) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ExecutionResult) GetStdout() string {
+	if x != nil {
+		return x.Stdout
+	}
+	return ""
+}
+
+func (x *ExecutionResult) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+func (x *ExecutionResult) GetOutputTruncated() bool {
+	if x != nil {
+		return x.OutputTruncated
+	}
+	return false
+}
+
+func (x *ExecutionResult) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+func (x *ExecutionResult) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *ExecutionResult) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+// Artifact represents a file produced by an execution.
+type Artifact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Relative to the working directory.
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Size          int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	MimeType      string `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Artifact) Reset() {
+	*x = Artifact{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Artifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artifact) ProtoMessage() {}
+
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Artifact) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Artifact) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Artifact) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+// CancelRequest is the input message for the Cancel method.
+type CancelRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	InstructionGuid string                 `protobuf:"bytes,1,opt,name=instruction_guid,json=instructionGuid,proto3" json:"instruction_guid,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CancelRequest) Reset() {
+	*x = CancelRequest{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRequest) ProtoMessage() {}
+
+func (x *CancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRequest.ProtoReflect.Descriptor instead.
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelRequest) GetInstructionGuid() string {
+	if x != nil {
+		return x.InstructionGuid
+	}
+	return ""
+}
+
+// CancelResponse is the output message for the Cancel method.
+type CancelResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether a running execution was cancelled.
+	Cancelled     bool `protobuf:"varint,1,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelResponse) Reset() {
+	*x = CancelResponse{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelResponse) ProtoMessage() {}
+
+func (x *CancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelResponse.ProtoReflect.Descriptor instead.
+func (*CancelResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelResponse) GetCancelled() bool {
+	if x != nil {
+		return x.Cancelled
+	}
+	return false
+}
+
+// DownloadArtifactRequest is the input message for the DownloadArtifact method.
+type DownloadArtifactRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	InstructionGuid string                 `protobuf:"bytes,1,opt,name=instruction_guid,json=instructionGuid,proto3" json:"instruction_guid,omitempty"`
+	// Path of the artifact as returned in the ExecutionResult.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Maximum size of a chunk in bytes; a server default applies if 0.
+	ChunkSize     int32 `protobuf:"varint,3,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadArtifactRequest) Reset() {
+	*x = DownloadArtifactRequest{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadArtifactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadArtifactRequest) ProtoMessage() {}
+
+func (x *DownloadArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadArtifactRequest.ProtoReflect.Descriptor instead.
+func (*DownloadArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DownloadArtifactRequest) GetInstructionGuid() string {
+	if x != nil {
+		return x.InstructionGuid
+	}
+	return ""
+}
+
+func (x *DownloadArtifactRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DownloadArtifactRequest) GetChunkSize() int32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+// FileChunk contains a part of a file that is being sent.
+type FileChunk struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	FileName        string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileSize        int64                  `protobuf:"varint,2,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	FileChunkNumber int32                  `protobuf:"varint,3,opt,name=file_chunk_number,json=fileChunkNumber,proto3" json:"file_chunk_number,omitempty"`
+	FileChunk       []byte                 `protobuf:"bytes,4,opt,name=file_chunk,json=fileChunk,proto3" json:"file_chunk,omitempty"`
+	IsLastChunk     bool                   `protobuf:"varint,5,opt,name=is_last_chunk,json=isLastChunk,proto3" json:"is_last_chunk,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FileChunk) Reset() {
+	*x = FileChunk{}
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChunk) ProtoMessage() {}
+
+func (x *FileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChunk.ProtoReflect.Descriptor instead.
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FileChunk) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *FileChunk) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *FileChunk) GetFileChunkNumber() int32 {
+	if x != nil {
+		return x.FileChunkNumber
+	}
+	return 0
+}
+
+func (x *FileChunk) GetFileChunk() []byte {
+	if x != nil {
+		return x.FileChunk
+	}
+	return nil
+}
+
+func (x *FileChunk) GetIsLastChunk() bool {
+	if x != nil {
+		return x.IsLastChunk
+	}
+	return false
+}
+
+var File_pkg_aaliexecgrpc_aali_exec_proto protoreflect.FileDescriptor
+
+const file_pkg_aaliexecgrpc_aali_exec_proto_rawDesc = "" +
+	"\n" +
+	" pkg/aaliexecgrpc/aali-exec.proto\x12\faaliexecgrpc\x1a\x1egoogle/protobuf/duration.proto\"\x0f\n" +
+	"\rHealthRequest\"m\n" +
+	"\x0eHealthResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12)\n" +
+	"\x10protocol_version\x18\x03 \x01(\tR\x0fprotocolVersion\"\xc9\x03\n" +
+	"\x0eExecuteRequest\x12)\n" +
+	"\x10instruction_guid\x18\x01 \x01(\tR\x0finstructionGuid\x12\x1b\n" +
+	"\tcode_type\x18\x02 \x01(\tR\bcodeType\x12\x12\n" +
+	"\x04code\x18\x03 \x03(\tR\x04code\x12'\n" +
+	"\x0fvenv_executable\x18\x04 \x01(\tR\x0evenvExecutable\x121\n" +
+	"\x05files\x18\x05 \x03(\v2\x1b.aaliexecgrpc.ExecutionFileR\x05files\x127\n" +
+	"\x03env\x18\x06 \x03(\v2%.aaliexecgrpc.ExecuteRequest.EnvEntryR\x03env\x12+\n" +
+	"\x11working_directory\x18\a \x01(\tR\x10workingDirectory\x124\n" +
+	"\x06limits\x18\b \x01(\v2\x1c.aaliexecgrpc.ResourceLimitsR\x06limits\x12+\n" +
+	"\x11artifact_patterns\x18\t \x03(\tR\x10artifactPatterns\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"=\n" +
+	"\rExecutionFile\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\"\x9d\x01\n" +
+	"\x0eResourceLimits\x12'\n" +
+	"\x0ftimeout_seconds\x18\x01 \x01(\x05R\x0etimeoutSeconds\x12\x1b\n" +
+	"\tmemory_mb\x18\x02 \x01(\x05R\bmemoryMb\x12\x1b\n" +
+	"\tcpu_cores\x18\x03 \x01(\x05R\bcpuCores\x12(\n" +
+	"\x10max_output_bytes\x18\x04 \x01(\x03R\x0emaxOutputBytes\"\xba\x01\n" +
+	"\x0fExecuteResponse\x12)\n" +
+	"\x10instruction_guid\x18\x01 \x01(\tR\x0finstructionGuid\x123\n" +
+	"\x06output\x18\x02 \x01(\v2\x19.aaliexecgrpc.OutputChunkH\x00R\x06output\x127\n" +
+	"\x06result\x18\x03 \x01(\v2\x1d.aaliexecgrpc.ExecutionResultH\x00R\x06resultB\x0e\n" +
+	"\fmessage_type\"U\n" +
+	"\vOutputChunk\x12\x16\n" +
+	"\x06stream\x18\x01 \x01(\tR\x06stream\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\tR\x04data\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x03R\bsequence\"\x93\x02\n" +
+	"\x0fExecutionResult\x12\x1b\n" +
+	"\texit_code\x18\x01 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06stdout\x18\x02 \x01(\tR\x06stdout\x12\x16\n" +
+	"\x06stderr\x18\x03 \x01(\tR\x06stderr\x12)\n" +
+	"\x10output_truncated\x18\x04 \x01(\bR\x0foutputTruncated\x12\x1b\n" +
+	"\ttimed_out\x18\x05 \x01(\bR\btimedOut\x125\n" +
+	"\bduration\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\bduration\x124\n" +
+	"\tartifacts\x18\a \x03(\v2\x16.aaliexecgrpc.ArtifactR\tartifacts\"O\n" +
+	"\bArtifact\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\":\n" +
+	"\rCancelRequest\x12)\n" +
+	"\x10instruction_guid\x18\x01 \x01(\tR\x0finstructionGuid\".\n" +
+	"\x0eCancelResponse\x12\x1c\n" +
+	"\tcancelled\x18\x01 \x01(\bR\tcancelled\"w\n" +
+	"\x17DownloadArtifactRequest\x12)\n" +
+	"\x10instruction_guid\x18\x01 \x01(\tR\x0finstructionGuid\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x03 \x01(\x05R\tchunkSize\"\xb4\x01\n" +
+	"\tFileChunk\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x1b\n" +
+	"\tfile_size\x18\x02 \x01(\x03R\bfileSize\x12*\n" +
+	"\x11file_chunk_number\x18\x03 \x01(\x05R\x0ffileChunkNumber\x12\x1d\n" +
+	"\n" +
+	"file_chunk\x18\x04 \x01(\fR\tfileChunk\x12\"\n" +
+	"\ris_last_chunk\x18\x05 \x01(\bR\visLastChunk2\xbd\x02\n" +
+	"\x04Exec\x12J\n" +
+	"\vHealthCheck\x12\x1b.aaliexecgrpc.HealthRequest\x1a\x1c.aaliexecgrpc.HealthResponse\"\x00\x12J\n" +
+	"\aExecute\x12\x1c.aaliexecgrpc.ExecuteRequest\x1a\x1d.aaliexecgrpc.ExecuteResponse\"\x000\x01\x12E\n" +
+	"\x06Cancel\x12\x1b.aaliexecgrpc.CancelRequest\x1a\x1c.aaliexecgrpc.CancelResponse\"\x00\x12V\n" +
+	"\x10DownloadArtifact\x12%.aaliexecgrpc.DownloadArtifactRequest\x1a\x17.aaliexecgrpc.FileChunk\"\x000\x01B\x10Z\x0e./aaliexecgrpcb\x06proto3"
+
+var (
+	file_pkg_aaliexecgrpc_aali_exec_proto_rawDescOnce sync.Once
+	file_pkg_aaliexecgrpc_aali_exec_proto_rawDescData []byte
+)
+
+func file_pkg_aaliexecgrpc_aali_exec_proto_rawDescGZIP() []byte {
+	file_pkg_aaliexecgrpc_aali_exec_proto_rawDescOnce.Do(func() {
+		file_pkg_aaliexecgrpc_aali_exec_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_aaliexecgrpc_aali_exec_proto_rawDesc), len(file_pkg_aaliexecgrpc_aali_exec_proto_rawDesc)))
+	})
+	return file_pkg_aaliexecgrpc_aali_exec_proto_rawDescData
+}
+
+var file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_pkg_aaliexecgrpc_aali_exec_proto_goTypes = []any{
+	(*HealthRequest)(nil),           // 0: aaliexecgrpc.HealthRequest
+	(*HealthResponse)(nil),          // 1: aaliexecgrpc.HealthResponse
+	(*ExecuteRequest)(nil),          // 2: aaliexecgrpc.ExecuteRequest
+	(*ExecutionFile)(nil),           // 3: aaliexecgrpc.ExecutionFile
+	(*ResourceLimits)(nil),          // 4: aaliexecgrpc.ResourceLimits
+	(*ExecuteResponse)(nil),         // 5: aaliexecgrpc.ExecuteResponse
+	(*OutputChunk)(nil),             // 6: aaliexecgrpc.OutputChunk
+	(*ExecutionResult)(nil),         // 7: aaliexecgrpc.ExecutionResult
+	(*Artifact)(nil),                // 8: aaliexecgrpc.Artifact
+	(*CancelRequest)(nil),           // 9: aaliexecgrpc.CancelRequest
+	(*CancelResponse)(nil),          // 10: aaliexecgrpc.CancelResponse
+	(*DownloadArtifactRequest)(nil), // 11: aaliexecgrpc.DownloadArtifactRequest
+	(*FileChunk)(nil),               // 12: aaliexecgrpc.FileChunk
+	nil,                             // 13: aaliexecgrpc.ExecuteRequest.EnvEntry
+	(*durationpb.Duration)(nil),     // 14: google.protobuf.Duration
+}
+var file_pkg_aaliexecgrpc_aali_exec_proto_depIdxs = []int32{
+	3,  // 0: aaliexecgrpc.ExecuteRequest.files:type_name -> aaliexecgrpc.ExecutionFile
+	13, // 1: aaliexecgrpc.ExecuteRequest.env:type_name -> aaliexecgrpc.ExecuteRequest.EnvEntry
+	4,  // 2: aaliexecgrpc.ExecuteRequest.limits:type_name -> aaliexecgrpc.ResourceLimits
+	6,  // 3: aaliexecgrpc.ExecuteResponse.output:type_name -> aaliexecgrpc.OutputChunk
+	7,  // 4: aaliexecgrpc.ExecuteResponse.result:type_name -> aaliexecgrpc.ExecutionResult
+	14, // 5: aaliexecgrpc.ExecutionResult.duration:type_name -> google.protobuf.Duration
+	8,  // 6: aaliexecgrpc.ExecutionResult.artifacts:type_name -> aaliexecgrpc.Artifact
+	0,  // 7: aaliexecgrpc.Exec.HealthCheck:input_type -> aaliexecgrpc.HealthRequest
+	2,  // 8: aaliexecgrpc.Exec.Execute:input_type -> aaliexecgrpc.ExecuteRequest
+	9,  // 9: aaliexecgrpc.Exec.Cancel:input_type -> aaliexecgrpc.CancelRequest
+	11, // 10: aaliexecgrpc.Exec.DownloadArtifact:input_type -> aaliexecgrpc.DownloadArtifactRequest
+	1,  // 11: aaliexecgrpc.Exec.HealthCheck:output_type -> aaliexecgrpc.HealthResponse
+	5,  // 12: aaliexecgrpc.Exec.Execute:output_type -> aaliexecgrpc.ExecuteResponse
+	10, // 13: aaliexecgrpc.Exec.Cancel:output_type -> aaliexecgrpc.CancelResponse
+	12, // 14: aaliexecgrpc.Exec.DownloadArtifact:output_type -> aaliexecgrpc.FileChunk
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_pkg_aaliexecgrpc_aali_exec_proto_init() }
+func file_pkg_aaliexecgrpc_aali_exec_proto_init() {
+	if File_pkg_aaliexecgrpc_aali_exec_proto != nil {
+		return
+	}
+	file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes[5].OneofWrappers = []any{
+		(*ExecuteResponse_Output)(nil),
+		(*ExecuteResponse_Result)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_aaliexecgrpc_aali_exec_proto_rawDesc), len(file_pkg_aaliexecgrpc_aali_exec_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_aaliexecgrpc_aali_exec_proto_goTypes,
+		DependencyIndexes: file_pkg_aaliexecgrpc_aali_exec_proto_depIdxs,
+		MessageInfos:      file_pkg_aaliexecgrpc_aali_exec_proto_msgTypes,
+	}.Build()
+	File_pkg_aaliexecgrpc_aali_exec_proto = out.File
+	file_pkg_aaliexecgrpc_aali_exec_proto_goTypes = nil
+	file_pkg_aaliexecgrpc_aali_exec_proto_depIdxs = nil
+}
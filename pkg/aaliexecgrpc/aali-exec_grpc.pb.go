@@ -0,0 +1,254 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v4.25.9
+// source: pkg/aaliexecgrpc/aali-exec.proto
+
+package aaliexecgrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Exec_HealthCheck_FullMethodName      = "/aaliexecgrpc.Exec/HealthCheck"
+	Exec_Execute_FullMethodName          = "/aaliexecgrpc.Exec/Execute"
+	Exec_Cancel_FullMethodName           = "/aaliexecgrpc.Exec/Cancel"
+	Exec_DownloadArtifact_FullMethodName = "/aaliexecgrpc.Exec/DownloadArtifact"
+)
+
+// ExecClient is the client API for Exec service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Exec is a gRPC service that allows for executing code in aali-exec.
+type ExecClient interface {
+	// HealthCheck is a simple health check method that returns an empty response.
+	HealthCheck(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	// Executes code and streams its output; the last message contains the result of the execution.
+	Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecuteResponse], error)
+	// Cancels a running execution.
+	Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error)
+	// Downloads an artifact produced by an execution in chunks.
+	DownloadArtifact(ctx context.Context, in *DownloadArtifactRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error)
+}
+
+type execClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecClient(cc grpc.ClientConnInterface) ExecClient {
+	return &execClient{cc}
+}
+
+func (c *execClient) HealthCheck(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, Exec_HealthCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *execClient) Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecuteResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Exec_ServiceDesc.Streams[0], Exec_Execute_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExecuteRequest, ExecuteResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Exec_ExecuteClient = grpc.ServerStreamingClient[ExecuteResponse]
+
+func (c *execClient) Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelResponse)
+	err := c.cc.Invoke(ctx, Exec_Cancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *execClient) DownloadArtifact(ctx context.Context, in *DownloadArtifactRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Exec_ServiceDesc.Streams[1], Exec_DownloadArtifact_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadArtifactRequest, FileChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Exec_DownloadArtifactClient = grpc.ServerStreamingClient[FileChunk]
+
+// ExecServer is the server API for Exec service.
+// All implementations must embed UnimplementedExecServer
+// for forward compatibility.
+//
+// Exec is a gRPC service that allows for executing code in aali-exec.
+type ExecServer interface {
+	// HealthCheck is a simple health check method that returns an empty response.
+	HealthCheck(context.Context, *HealthRequest) (*HealthResponse, error)
+	// Executes code and streams its output; the last message contains the result of the execution.
+	Execute(*ExecuteRequest, grpc.ServerStreamingServer[ExecuteResponse]) error
+	// Cancels a running execution.
+	Cancel(context.Context, *CancelRequest) (*CancelResponse, error)
+	// Downloads an artifact produced by an execution in chunks.
+	DownloadArtifact(*DownloadArtifactRequest, grpc.ServerStreamingServer[FileChunk]) error
+	mustEmbedUnimplementedExecServer()
+}
+
+// UnimplementedExecServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExecServer struct{}
+
+func (UnimplementedExecServer) HealthCheck(context.Context, *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method HealthCheck not implemented")
+}
+func (UnimplementedExecServer) Execute(*ExecuteRequest, grpc.ServerStreamingServer[ExecuteResponse]) error {
+	return status.Error(codes.Unimplemented, "method Execute not implemented")
+}
+func (UnimplementedExecServer) Cancel(context.Context, *CancelRequest) (*CancelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Cancel not implemented")
+}
+func (UnimplementedExecServer) DownloadArtifact(*DownloadArtifactRequest, grpc.ServerStreamingServer[FileChunk]) error {
+	return status.Error(codes.Unimplemented, "method DownloadArtifact not implemented")
+}
+func (UnimplementedExecServer) mustEmbedUnimplementedExecServer() {}
+func (UnimplementedExecServer) testEmbeddedByValue()              {}
+
+// UnsafeExecServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExecServer will
+// result in compilation errors.
+type UnsafeExecServer interface {
+	mustEmbedUnimplementedExecServer()
+}
+
+func RegisterExecServer(s grpc.ServiceRegistrar, srv ExecServer) {
+	// If the following call panics, it indicates UnimplementedExecServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Exec_ServiceDesc, srv)
+}
+
+func _Exec_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecServer).HealthCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Exec_HealthCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecServer).HealthCheck(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Exec_Execute_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecuteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExecServer).Execute(m, &grpc.GenericServerStream[ExecuteRequest, ExecuteResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Exec_ExecuteServer = grpc.ServerStreamingServer[ExecuteResponse]
+
+func _Exec_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Exec_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecServer).Cancel(ctx, req.(*CancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Exec_DownloadArtifact_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadArtifactRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExecServer).DownloadArtifact(m, &grpc.GenericServerStream[DownloadArtifactRequest, FileChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Exec_DownloadArtifactServer = grpc.ServerStreamingServer[FileChunk]
+
+// Exec_ServiceDesc is the grpc.ServiceDesc for Exec service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Exec_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "aaliexecgrpc.Exec",
+	HandlerType: (*ExecServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "HealthCheck",
+			Handler:    _Exec_HealthCheck_Handler,
+		},
+		{
+			MethodName: "Cancel",
+			Handler:    _Exec_Cancel_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Execute",
+			Handler:       _Exec_Execute_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DownloadArtifact",
+			Handler:       _Exec_DownloadArtifact_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/aaliexecgrpc/aali-exec.proto",
+}
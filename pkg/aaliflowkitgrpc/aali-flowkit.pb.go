@@ -62,9 +62,13 @@ func (*HealthRequest) Descriptor() ([]byte, []int) {
 type HealthResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Status of the health check, always "OK" for a healthy service.
-	Status        string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Semantic version of the service, e.g. "1.4.2".
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Semantic version of the gRPC protocol implemented by the service, e.g. "1.0.0".
+	ProtocolVersion string `protobuf:"bytes,3,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *HealthResponse) Reset() {
@@ -104,6 +108,20 @@ func (x *HealthResponse) GetStatus() string {
 	return ""
 }
 
+func (x *HealthResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetProtocolVersion() string {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return ""
+}
+
 // VersionRequest is the input message for the GetVersion method.
 type VersionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -145,9 +163,11 @@ func (*VersionRequest) Descriptor() ([]byte, []int) {
 type VersionResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Version of the service.
-	Version       string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Semantic version of the gRPC protocol implemented by the service, e.g. "1.0.0".
+	ProtocolVersion string `protobuf:"bytes,2,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *VersionResponse) Reset() {
@@ -187,6 +207,13 @@ func (x *VersionResponse) GetVersion() string {
 	return ""
 }
 
+func (x *VersionResponse) GetProtocolVersion() string {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return ""
+}
+
 // ListFunctionsRequest is the input message for the ListFunctions method.
 // As no input is required, this message is empty.
 type ListFunctionsRequest struct {
@@ -290,8 +317,18 @@ type FunctionDefinition struct {
 	Output []*FunctionOutputDefinition `protobuf:"bytes,6,rep,name=output,proto3" json:"output,omitempty"`
 	// List of deprecated parameters for the function.
 	DeprecatedParams []string `protobuf:"bytes,7,rep,name=deprecatedParams,proto3" json:"deprecatedParams,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Usage examples for the function.
+	Examples []string `protobuf:"bytes,8,rep,name=examples,proto3" json:"examples,omitempty"`
+	// Maximum execution time of the function in seconds; 0 means no timeout.
+	TimeoutSeconds int32 `protobuf:"varint,9,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// Indicates if the function can safely be called multiple times with the same inputs.
+	Idempotent bool `protobuf:"varint,10,opt,name=idempotent,proto3" json:"idempotent,omitempty"`
+	// Maximum number of concurrent executions of the function; 0 means unlimited.
+	MaxConcurrency int32 `protobuf:"varint,11,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	// Resource limits for the execution of the function; not sandboxed if unset.
+	ResourceLimits *ResourceLimits `protobuf:"bytes,12,opt,name=resource_limits,json=resourceLimits,proto3" json:"resource_limits,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *FunctionDefinition) Reset() {
@@ -373,6 +410,115 @@ func (x *FunctionDefinition) GetDeprecatedParams() []string {
 	return nil
 }
 
+func (x *FunctionDefinition) GetExamples() []string {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+func (x *FunctionDefinition) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *FunctionDefinition) GetIdempotent() bool {
+	if x != nil {
+		return x.Idempotent
+	}
+	return false
+}
+
+func (x *FunctionDefinition) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *FunctionDefinition) GetResourceLimits() *ResourceLimits {
+	if x != nil {
+		return x.ResourceLimits
+	}
+	return nil
+}
+
+// ResourceLimits are the limits enforced by the FlowKit server on the execution of a function.
+// A limit of 0 means unlimited.
+type ResourceLimits struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum CPU time in seconds.
+	CpuSeconds int32 `protobuf:"varint,1,opt,name=cpu_seconds,json=cpuSeconds,proto3" json:"cpu_seconds,omitempty"`
+	// Maximum memory in megabytes.
+	MemoryMb int32 `protobuf:"varint,2,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	// Maximum wall-clock time in seconds.
+	TimeoutSeconds int32 `protobuf:"varint,3,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// Indicates if the function may access the network.
+	NetworkAllowed bool `protobuf:"varint,4,opt,name=network_allowed,json=networkAllowed,proto3" json:"network_allowed,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ResourceLimits) Reset() {
+	*x = ResourceLimits{}
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceLimits) ProtoMessage() {}
+
+func (x *ResourceLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceLimits.ProtoReflect.Descriptor instead.
+func (*ResourceLimits) Descriptor() ([]byte, []int) {
+	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ResourceLimits) GetCpuSeconds() int32 {
+	if x != nil {
+		return x.CpuSeconds
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetMemoryMb() int32 {
+	if x != nil {
+		return x.MemoryMb
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetNetworkAllowed() bool {
+	if x != nil {
+		return x.NetworkAllowed
+	}
+	return false
+}
+
 // FunctionInputDefinition is the definition of an input for a function.
 // It contains the name, type, Go language type and options for the input.
 type FunctionInputDefinition struct {
@@ -384,14 +530,18 @@ type FunctionInputDefinition struct {
 	// Go language type of the input.
 	GoType string `protobuf:"bytes,3,opt,name=go_type,json=goType,proto3" json:"go_type,omitempty"`
 	// List of options for the input, if applicable.
-	Options       []string `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	Options []string `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	// Default value of the input, used if no value is provided.
+	DefaultValue string `protobuf:"bytes,5,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	// Indicates if a value must be provided for the input; calls omitting it fail before reaching the function.
+	Required      bool `protobuf:"varint,6,opt,name=required,proto3" json:"required,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FunctionInputDefinition) Reset() {
 	*x = FunctionInputDefinition{}
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[7]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -403,7 +553,7 @@ func (x *FunctionInputDefinition) String() string {
 func (*FunctionInputDefinition) ProtoMessage() {}
 
 func (x *FunctionInputDefinition) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[7]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -416,7 +566,7 @@ func (x *FunctionInputDefinition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionInputDefinition.ProtoReflect.Descriptor instead.
 func (*FunctionInputDefinition) Descriptor() ([]byte, []int) {
-	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{7}
+	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FunctionInputDefinition) GetName() string {
@@ -447,6 +597,20 @@ func (x *FunctionInputDefinition) GetOptions() []string {
 	return nil
 }
 
+func (x *FunctionInputDefinition) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
+}
+
+func (x *FunctionInputDefinition) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
 // FunctionOutputDefinition is the definition of an output for a function.
 // It contains the name, type and Go language type of the output.
 type FunctionOutputDefinition struct {
@@ -463,7 +627,7 @@ type FunctionOutputDefinition struct {
 
 func (x *FunctionOutputDefinition) Reset() {
 	*x = FunctionOutputDefinition{}
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[8]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -475,7 +639,7 @@ func (x *FunctionOutputDefinition) String() string {
 func (*FunctionOutputDefinition) ProtoMessage() {}
 
 func (x *FunctionOutputDefinition) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[8]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -488,7 +652,7 @@ func (x *FunctionOutputDefinition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionOutputDefinition.ProtoReflect.Descriptor instead.
 func (*FunctionOutputDefinition) Descriptor() ([]byte, []int) {
-	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{8}
+	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *FunctionOutputDefinition) GetName() string {
@@ -519,14 +683,21 @@ type FunctionInputs struct {
 	// Name of the function to run.
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// List of inputs for the function.
-	Inputs        []*FunctionInput `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Inputs []*FunctionInput `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// If true, the server only validates the inputs and returns the declared outputs with their
+	// Go types and empty values, without running the function. Only sent to servers announcing
+	// the "dry-run" capability.
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Resource limits to enforce for this call, taking precedence over the limits of the function
+	// definition; not sandboxed if unset.
+	ResourceLimits *ResourceLimits `protobuf:"bytes,4,opt,name=resource_limits,json=resourceLimits,proto3" json:"resource_limits,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *FunctionInputs) Reset() {
 	*x = FunctionInputs{}
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[9]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -538,7 +709,7 @@ func (x *FunctionInputs) String() string {
 func (*FunctionInputs) ProtoMessage() {}
 
 func (x *FunctionInputs) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[9]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -551,7 +722,7 @@ func (x *FunctionInputs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionInputs.ProtoReflect.Descriptor instead.
 func (*FunctionInputs) Descriptor() ([]byte, []int) {
-	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{9}
+	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *FunctionInputs) GetName() string {
@@ -568,6 +739,20 @@ func (x *FunctionInputs) GetInputs() []*FunctionInput {
 	return nil
 }
 
+func (x *FunctionInputs) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *FunctionInputs) GetResourceLimits() *ResourceLimits {
+	if x != nil {
+		return x.ResourceLimits
+	}
+	return nil
+}
+
 // Single input for a function.
 type FunctionInput struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -576,14 +761,24 @@ type FunctionInput struct {
 	// Go language type of the input.
 	GoType string `protobuf:"bytes,2,opt,name=go_type,json=goType,proto3" json:"go_type,omitempty"`
 	// Value of the input.
-	Value         string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Value string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// Binary value of the input, used instead of value if not empty. Only sent to servers announcing
+	// the "binary-values" capability. For go_type "[]byte" it holds the raw bytes, otherwise the
+	// serialized value as in the value field.
+	ValueBytes []byte `protobuf:"bytes,4,opt,name=value_bytes,json=valueBytes,proto3" json:"value_bytes,omitempty"`
+	// Compression applied to value_bytes, "gzip" or "zstd"; empty if not compressed. Only used with
+	// the "binary-values" capability and the matching "compression-gzip" or "compression-zstd" capability.
+	ContentEncoding string `protobuf:"bytes,5,opt,name=content_encoding,json=contentEncoding,proto3" json:"content_encoding,omitempty"`
+	// Indicates that no value was provided for the input, so the server can tell an omitted input from an
+	// empty one and apply the default value of the input instead of converting the empty value.
+	IsNull        bool `protobuf:"varint,6,opt,name=is_null,json=isNull,proto3" json:"is_null,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FunctionInput) Reset() {
 	*x = FunctionInput{}
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[10]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -595,7 +790,7 @@ func (x *FunctionInput) String() string {
 func (*FunctionInput) ProtoMessage() {}
 
 func (x *FunctionInput) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[10]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -608,7 +803,7 @@ func (x *FunctionInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionInput.ProtoReflect.Descriptor instead.
 func (*FunctionInput) Descriptor() ([]byte, []int) {
-	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{10}
+	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *FunctionInput) GetName() string {
@@ -632,6 +827,27 @@ func (x *FunctionInput) GetValue() string {
 	return ""
 }
 
+func (x *FunctionInput) GetValueBytes() []byte {
+	if x != nil {
+		return x.ValueBytes
+	}
+	return nil
+}
+
+func (x *FunctionInput) GetContentEncoding() string {
+	if x != nil {
+		return x.ContentEncoding
+	}
+	return ""
+}
+
+func (x *FunctionInput) GetIsNull() bool {
+	if x != nil {
+		return x.IsNull
+	}
+	return false
+}
+
 // FunctionOutputs is the output message for the RunFunction method.
 // It contains the name of the function that was run and a list of outputs.
 type FunctionOutputs struct {
@@ -646,7 +862,7 @@ type FunctionOutputs struct {
 
 func (x *FunctionOutputs) Reset() {
 	*x = FunctionOutputs{}
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[11]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -658,7 +874,7 @@ func (x *FunctionOutputs) String() string {
 func (*FunctionOutputs) ProtoMessage() {}
 
 func (x *FunctionOutputs) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[11]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -671,7 +887,7 @@ func (x *FunctionOutputs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionOutputs.ProtoReflect.Descriptor instead.
 func (*FunctionOutputs) Descriptor() ([]byte, []int) {
-	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{11}
+	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *FunctionOutputs) GetName() string {
@@ -700,13 +916,20 @@ type FunctionOutput struct {
 	Value string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
 	// Code validation of the output. Only relevant for code generation.
 	CodeValidation string `protobuf:"bytes,4,opt,name=code_validation,json=codeValidation,proto3" json:"code_validation,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Binary value of the output, used instead of value if not empty. Only sent to clients announcing
+	// the "binary-values" capability. For go_type "[]byte" it holds the raw bytes, otherwise the
+	// serialized value as in the value field.
+	ValueBytes []byte `protobuf:"bytes,5,opt,name=value_bytes,json=valueBytes,proto3" json:"value_bytes,omitempty"`
+	// Compression applied to value_bytes, "gzip" or "zstd"; empty if not compressed. Only used with
+	// the "binary-values" capability and the matching "compression-gzip" or "compression-zstd" capability.
+	ContentEncoding string `protobuf:"bytes,6,opt,name=content_encoding,json=contentEncoding,proto3" json:"content_encoding,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *FunctionOutput) Reset() {
 	*x = FunctionOutput{}
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[12]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -718,7 +941,7 @@ func (x *FunctionOutput) String() string {
 func (*FunctionOutput) ProtoMessage() {}
 
 func (x *FunctionOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[12]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -731,7 +954,7 @@ func (x *FunctionOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionOutput.ProtoReflect.Descriptor instead.
 func (*FunctionOutput) Descriptor() ([]byte, []int) {
-	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{12}
+	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *FunctionOutput) GetName() string {
@@ -762,6 +985,20 @@ func (x *FunctionOutput) GetCodeValidation() string {
 	return ""
 }
 
+func (x *FunctionOutput) GetValueBytes() []byte {
+	if x != nil {
+		return x.ValueBytes
+	}
+	return nil
+}
+
+func (x *FunctionOutput) GetContentEncoding() string {
+	if x != nil {
+		return x.ContentEncoding
+	}
+	return ""
+}
+
 // StreamInput is the input message for the bidirectional StreamFunction method.
 // The first message should contain the function inputs. Subsequent messages can be used for interrupts.
 type StreamInput struct {
@@ -778,7 +1015,7 @@ type StreamInput struct {
 
 func (x *StreamInput) Reset() {
 	*x = StreamInput{}
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[13]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -790,7 +1027,7 @@ func (x *StreamInput) String() string {
 func (*StreamInput) ProtoMessage() {}
 
 func (x *StreamInput) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[13]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -803,7 +1040,7 @@ func (x *StreamInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamInput.ProtoReflect.Descriptor instead.
 func (*StreamInput) Descriptor() ([]byte, []int) {
-	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{13}
+	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *StreamInput) GetName() string {
@@ -845,7 +1082,7 @@ type StreamOutput struct {
 
 func (x *StreamOutput) Reset() {
 	*x = StreamOutput{}
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[14]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -857,7 +1094,7 @@ func (x *StreamOutput) String() string {
 func (*StreamOutput) ProtoMessage() {}
 
 func (x *StreamOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[14]
+	mi := &file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -870,7 +1107,7 @@ func (x *StreamOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamOutput.ProtoReflect.Descriptor instead.
 func (*StreamOutput) Descriptor() ([]byte, []int) {
-	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{14}
+	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *StreamOutput) GetMessageCounter() int32 {
@@ -906,18 +1143,21 @@ var File_pkg_aaliflowkitgrpc_aali_flowkit_proto protoreflect.FileDescriptor
 const file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDesc = "" +
 	"\n" +
 	"&pkg/aaliflowkitgrpc/aali-flowkit.proto\x12\x0faaliflowkitgrpc\"\x0f\n" +
-	"\rHealthRequest\"(\n" +
+	"\rHealthRequest\"m\n" +
 	"\x0eHealthResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"\x10\n" +
-	"\x0eVersionRequest\"+\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12)\n" +
+	"\x10protocol_version\x18\x03 \x01(\tR\x0fprotocolVersion\"\x10\n" +
+	"\x0eVersionRequest\"V\n" +
 	"\x0fVersionResponse\x12\x18\n" +
-	"\aversion\x18\x01 \x01(\tR\aversion\"\x16\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12)\n" +
+	"\x10protocol_version\x18\x02 \x01(\tR\x0fprotocolVersion\"\x16\n" +
 	"\x14ListFunctionsRequest\"\xcf\x01\n" +
 	"\x15ListFunctionsResponse\x12S\n" +
 	"\tfunctions\x18\x01 \x03(\v25.aaliflowkitgrpc.ListFunctionsResponse.FunctionsEntryR\tfunctions\x1aa\n" +
 	"\x0eFunctionsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x129\n" +
-	"\x05value\x18\x02 \x01(\v2#.aaliflowkitgrpc.FunctionDefinitionR\x05value:\x028\x01\"\xb7\x02\n" +
+	"\x05value\x18\x02 \x01(\v2#.aaliflowkitgrpc.FunctionDefinitionR\x05value:\x028\x01\"\x8f\x04\n" +
 	"\x12FunctionDefinition\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
@@ -925,31 +1165,56 @@ const file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDesc = "" +
 	"\vdisplayName\x18\x04 \x01(\tR\vdisplayName\x12>\n" +
 	"\x05input\x18\x05 \x03(\v2(.aaliflowkitgrpc.FunctionInputDefinitionR\x05input\x12A\n" +
 	"\x06output\x18\x06 \x03(\v2).aaliflowkitgrpc.FunctionOutputDefinitionR\x06output\x12*\n" +
-	"\x10deprecatedParams\x18\a \x03(\tR\x10deprecatedParams\"t\n" +
+	"\x10deprecatedParams\x18\a \x03(\tR\x10deprecatedParams\x12\x1a\n" +
+	"\bexamples\x18\b \x03(\tR\bexamples\x12'\n" +
+	"\x0ftimeout_seconds\x18\t \x01(\x05R\x0etimeoutSeconds\x12\x1e\n" +
+	"\n" +
+	"idempotent\x18\n" +
+	" \x01(\bR\n" +
+	"idempotent\x12'\n" +
+	"\x0fmax_concurrency\x18\v \x01(\x05R\x0emaxConcurrency\x12H\n" +
+	"\x0fresource_limits\x18\f \x01(\v2\x1f.aaliflowkitgrpc.ResourceLimitsR\x0eresourceLimits\"\xa0\x01\n" +
+	"\x0eResourceLimits\x12\x1f\n" +
+	"\vcpu_seconds\x18\x01 \x01(\x05R\n" +
+	"cpuSeconds\x12\x1b\n" +
+	"\tmemory_mb\x18\x02 \x01(\x05R\bmemoryMb\x12'\n" +
+	"\x0ftimeout_seconds\x18\x03 \x01(\x05R\x0etimeoutSeconds\x12'\n" +
+	"\x0fnetwork_allowed\x18\x04 \x01(\bR\x0enetworkAllowed\"\xb5\x01\n" +
 	"\x17FunctionInputDefinition\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x17\n" +
 	"\ago_type\x18\x03 \x01(\tR\x06goType\x12\x18\n" +
-	"\aoptions\x18\x04 \x03(\tR\aoptions\"[\n" +
+	"\aoptions\x18\x04 \x03(\tR\aoptions\x12#\n" +
+	"\rdefault_value\x18\x05 \x01(\tR\fdefaultValue\x12\x1a\n" +
+	"\brequired\x18\x06 \x01(\bR\brequired\"[\n" +
 	"\x18FunctionOutputDefinition\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x17\n" +
-	"\ago_type\x18\x03 \x01(\tR\x06goType\"\\\n" +
+	"\ago_type\x18\x03 \x01(\tR\x06goType\"\xbf\x01\n" +
 	"\x0eFunctionInputs\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x126\n" +
-	"\x06inputs\x18\x02 \x03(\v2\x1e.aaliflowkitgrpc.FunctionInputR\x06inputs\"R\n" +
+	"\x06inputs\x18\x02 \x03(\v2\x1e.aaliflowkitgrpc.FunctionInputR\x06inputs\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12H\n" +
+	"\x0fresource_limits\x18\x04 \x01(\v2\x1f.aaliflowkitgrpc.ResourceLimitsR\x0eresourceLimits\"\xb7\x01\n" +
 	"\rFunctionInput\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x17\n" +
 	"\ago_type\x18\x02 \x01(\tR\x06goType\x12\x14\n" +
-	"\x05value\x18\x03 \x01(\tR\x05value\"`\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x1f\n" +
+	"\vvalue_bytes\x18\x04 \x01(\fR\n" +
+	"valueBytes\x12)\n" +
+	"\x10content_encoding\x18\x05 \x01(\tR\x0fcontentEncoding\x12\x17\n" +
+	"\ais_null\x18\x06 \x01(\bR\x06isNull\"`\n" +
 	"\x0fFunctionOutputs\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x129\n" +
-	"\aoutputs\x18\x02 \x03(\v2\x1f.aaliflowkitgrpc.FunctionOutputR\aoutputs\"|\n" +
+	"\aoutputs\x18\x02 \x03(\v2\x1f.aaliflowkitgrpc.FunctionOutputR\aoutputs\"\xc8\x01\n" +
 	"\x0eFunctionOutput\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x17\n" +
 	"\ago_type\x18\x02 \x01(\tR\x06goType\x12\x14\n" +
 	"\x05value\x18\x03 \x01(\tR\x05value\x12'\n" +
-	"\x0fcode_validation\x18\x04 \x01(\tR\x0ecodeValidation\"w\n" +
+	"\x0fcode_validation\x18\x04 \x01(\tR\x0ecodeValidation\x12\x1f\n" +
+	"\vvalue_bytes\x18\x05 \x01(\fR\n" +
+	"valueBytes\x12)\n" +
+	"\x10content_encoding\x18\x06 \x01(\tR\x0fcontentEncoding\"w\n" +
 	"\vStreamInput\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x126\n" +
 	"\x06inputs\x18\x02 \x03(\v2\x1e.aaliflowkitgrpc.FunctionInputR\x06inputs\x12\x1c\n" +
@@ -979,7 +1244,7 @@ func file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescGZIP() []byte {
 	return file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDescData
 }
 
-var file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_pkg_aaliflowkitgrpc_aali_flowkit_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_pkg_aaliflowkitgrpc_aali_flowkit_proto_goTypes = []any{
 	(*HealthRequest)(nil),            // 0: aaliflowkitgrpc.HealthRequest
 	(*HealthResponse)(nil),           // 1: aaliflowkitgrpc.HealthResponse
@@ -988,39 +1253,42 @@ var file_pkg_aaliflowkitgrpc_aali_flowkit_proto_goTypes = []any{
 	(*ListFunctionsRequest)(nil),     // 4: aaliflowkitgrpc.ListFunctionsRequest
 	(*ListFunctionsResponse)(nil),    // 5: aaliflowkitgrpc.ListFunctionsResponse
 	(*FunctionDefinition)(nil),       // 6: aaliflowkitgrpc.FunctionDefinition
-	(*FunctionInputDefinition)(nil),  // 7: aaliflowkitgrpc.FunctionInputDefinition
-	(*FunctionOutputDefinition)(nil), // 8: aaliflowkitgrpc.FunctionOutputDefinition
-	(*FunctionInputs)(nil),           // 9: aaliflowkitgrpc.FunctionInputs
-	(*FunctionInput)(nil),            // 10: aaliflowkitgrpc.FunctionInput
-	(*FunctionOutputs)(nil),          // 11: aaliflowkitgrpc.FunctionOutputs
-	(*FunctionOutput)(nil),           // 12: aaliflowkitgrpc.FunctionOutput
-	(*StreamInput)(nil),              // 13: aaliflowkitgrpc.StreamInput
-	(*StreamOutput)(nil),             // 14: aaliflowkitgrpc.StreamOutput
-	nil,                              // 15: aaliflowkitgrpc.ListFunctionsResponse.FunctionsEntry
+	(*ResourceLimits)(nil),           // 7: aaliflowkitgrpc.ResourceLimits
+	(*FunctionInputDefinition)(nil),  // 8: aaliflowkitgrpc.FunctionInputDefinition
+	(*FunctionOutputDefinition)(nil), // 9: aaliflowkitgrpc.FunctionOutputDefinition
+	(*FunctionInputs)(nil),           // 10: aaliflowkitgrpc.FunctionInputs
+	(*FunctionInput)(nil),            // 11: aaliflowkitgrpc.FunctionInput
+	(*FunctionOutputs)(nil),          // 12: aaliflowkitgrpc.FunctionOutputs
+	(*FunctionOutput)(nil),           // 13: aaliflowkitgrpc.FunctionOutput
+	(*StreamInput)(nil),              // 14: aaliflowkitgrpc.StreamInput
+	(*StreamOutput)(nil),             // 15: aaliflowkitgrpc.StreamOutput
+	nil,                              // 16: aaliflowkitgrpc.ListFunctionsResponse.FunctionsEntry
 }
 var file_pkg_aaliflowkitgrpc_aali_flowkit_proto_depIdxs = []int32{
-	15, // 0: aaliflowkitgrpc.ListFunctionsResponse.functions:type_name -> aaliflowkitgrpc.ListFunctionsResponse.FunctionsEntry
-	7,  // 1: aaliflowkitgrpc.FunctionDefinition.input:type_name -> aaliflowkitgrpc.FunctionInputDefinition
-	8,  // 2: aaliflowkitgrpc.FunctionDefinition.output:type_name -> aaliflowkitgrpc.FunctionOutputDefinition
-	10, // 3: aaliflowkitgrpc.FunctionInputs.inputs:type_name -> aaliflowkitgrpc.FunctionInput
-	12, // 4: aaliflowkitgrpc.FunctionOutputs.outputs:type_name -> aaliflowkitgrpc.FunctionOutput
-	10, // 5: aaliflowkitgrpc.StreamInput.inputs:type_name -> aaliflowkitgrpc.FunctionInput
-	6,  // 6: aaliflowkitgrpc.ListFunctionsResponse.FunctionsEntry.value:type_name -> aaliflowkitgrpc.FunctionDefinition
-	0,  // 7: aaliflowkitgrpc.ExternalFunctions.HealthCheck:input_type -> aaliflowkitgrpc.HealthRequest
-	2,  // 8: aaliflowkitgrpc.ExternalFunctions.GetVersion:input_type -> aaliflowkitgrpc.VersionRequest
-	4,  // 9: aaliflowkitgrpc.ExternalFunctions.ListFunctions:input_type -> aaliflowkitgrpc.ListFunctionsRequest
-	9,  // 10: aaliflowkitgrpc.ExternalFunctions.RunFunction:input_type -> aaliflowkitgrpc.FunctionInputs
-	13, // 11: aaliflowkitgrpc.ExternalFunctions.StreamFunction:input_type -> aaliflowkitgrpc.StreamInput
-	1,  // 12: aaliflowkitgrpc.ExternalFunctions.HealthCheck:output_type -> aaliflowkitgrpc.HealthResponse
-	3,  // 13: aaliflowkitgrpc.ExternalFunctions.GetVersion:output_type -> aaliflowkitgrpc.VersionResponse
-	5,  // 14: aaliflowkitgrpc.ExternalFunctions.ListFunctions:output_type -> aaliflowkitgrpc.ListFunctionsResponse
-	11, // 15: aaliflowkitgrpc.ExternalFunctions.RunFunction:output_type -> aaliflowkitgrpc.FunctionOutputs
-	14, // 16: aaliflowkitgrpc.ExternalFunctions.StreamFunction:output_type -> aaliflowkitgrpc.StreamOutput
-	12, // [12:17] is the sub-list for method output_type
-	7,  // [7:12] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	16, // 0: aaliflowkitgrpc.ListFunctionsResponse.functions:type_name -> aaliflowkitgrpc.ListFunctionsResponse.FunctionsEntry
+	8,  // 1: aaliflowkitgrpc.FunctionDefinition.input:type_name -> aaliflowkitgrpc.FunctionInputDefinition
+	9,  // 2: aaliflowkitgrpc.FunctionDefinition.output:type_name -> aaliflowkitgrpc.FunctionOutputDefinition
+	7,  // 3: aaliflowkitgrpc.FunctionDefinition.resource_limits:type_name -> aaliflowkitgrpc.ResourceLimits
+	11, // 4: aaliflowkitgrpc.FunctionInputs.inputs:type_name -> aaliflowkitgrpc.FunctionInput
+	7,  // 5: aaliflowkitgrpc.FunctionInputs.resource_limits:type_name -> aaliflowkitgrpc.ResourceLimits
+	13, // 6: aaliflowkitgrpc.FunctionOutputs.outputs:type_name -> aaliflowkitgrpc.FunctionOutput
+	11, // 7: aaliflowkitgrpc.StreamInput.inputs:type_name -> aaliflowkitgrpc.FunctionInput
+	6,  // 8: aaliflowkitgrpc.ListFunctionsResponse.FunctionsEntry.value:type_name -> aaliflowkitgrpc.FunctionDefinition
+	0,  // 9: aaliflowkitgrpc.ExternalFunctions.HealthCheck:input_type -> aaliflowkitgrpc.HealthRequest
+	2,  // 10: aaliflowkitgrpc.ExternalFunctions.GetVersion:input_type -> aaliflowkitgrpc.VersionRequest
+	4,  // 11: aaliflowkitgrpc.ExternalFunctions.ListFunctions:input_type -> aaliflowkitgrpc.ListFunctionsRequest
+	10, // 12: aaliflowkitgrpc.ExternalFunctions.RunFunction:input_type -> aaliflowkitgrpc.FunctionInputs
+	14, // 13: aaliflowkitgrpc.ExternalFunctions.StreamFunction:input_type -> aaliflowkitgrpc.StreamInput
+	1,  // 14: aaliflowkitgrpc.ExternalFunctions.HealthCheck:output_type -> aaliflowkitgrpc.HealthResponse
+	3,  // 15: aaliflowkitgrpc.ExternalFunctions.GetVersion:output_type -> aaliflowkitgrpc.VersionResponse
+	5,  // 16: aaliflowkitgrpc.ExternalFunctions.ListFunctions:output_type -> aaliflowkitgrpc.ListFunctionsResponse
+	12, // 17: aaliflowkitgrpc.ExternalFunctions.RunFunction:output_type -> aaliflowkitgrpc.FunctionOutputs
+	15, // 18: aaliflowkitgrpc.ExternalFunctions.StreamFunction:output_type -> aaliflowkitgrpc.StreamOutput
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_pkg_aaliflowkitgrpc_aali_flowkit_proto_init() }
@@ -1034,7 +1302,7 @@ func file_pkg_aaliflowkitgrpc_aali_flowkit_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDesc), len(file_pkg_aaliflowkitgrpc_aali_flowkit_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,1716 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v4.25.9
+// source: pkg/aalikdbgrpc/aali-kdb.proto
+
+package aalikdbgrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HealthRequest is the input message for the HealthCheck method.
+type HealthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthRequest) Reset() {
+	*x = HealthRequest{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthRequest) ProtoMessage() {}
+
+func (x *HealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{0}
+}
+
+// HealthResponse is the output message for the HealthCheck method.
+type HealthResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Status of the health check, always "OK" for a healthy service.
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Semantic version of the service, e.g. "1.4.2".
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Semantic version of the gRPC protocol implemented by the service, e.g. "1.0.0".
+	ProtocolVersion string `protobuf:"bytes,3,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HealthResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetProtocolVersion() string {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return ""
+}
+
+// ListCollectionsRequest is the input message for the ListCollections method.
+type ListCollectionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectionsRequest) Reset() {
+	*x = ListCollectionsRequest{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionsRequest) ProtoMessage() {}
+
+func (x *ListCollectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCollectionsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{2}
+}
+
+// ListCollectionsResponse is the output message for the ListCollections method.
+type ListCollectionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Names of the collections.
+	Collections   []string `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectionsResponse) Reset() {
+	*x = ListCollectionsResponse{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionsResponse) ProtoMessage() {}
+
+func (x *ListCollectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCollectionsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListCollectionsResponse) GetCollections() []string {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+// CreateCollectionRequest is the input message for the CreateCollection method.
+type CreateCollectionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the collection.
+	CollectionName string `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	// Dimension of the dense vectors stored in the collection.
+	VectorSize int32 `protobuf:"varint,2,opt,name=vector_size,json=vectorSize,proto3" json:"vector_size,omitempty"`
+	// Distance metric of the collection, e.g. "cosine", "dot" or "euclid".
+	DistanceMetric string `protobuf:"bytes,3,opt,name=distance_metric,json=distanceMetric,proto3" json:"distance_metric,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateCollectionRequest) Reset() {
+	*x = CreateCollectionRequest{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionRequest) ProtoMessage() {}
+
+func (x *CreateCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionRequest.ProtoReflect.Descriptor instead.
+func (*CreateCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateCollectionRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *CreateCollectionRequest) GetVectorSize() int32 {
+	if x != nil {
+		return x.VectorSize
+	}
+	return 0
+}
+
+func (x *CreateCollectionRequest) GetDistanceMetric() string {
+	if x != nil {
+		return x.DistanceMetric
+	}
+	return ""
+}
+
+// CreateCollectionResponse is the output message for the CreateCollection method.
+type CreateCollectionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the collection was created; false if it already existed.
+	Created       bool `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCollectionResponse) Reset() {
+	*x = CreateCollectionResponse{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionResponse) ProtoMessage() {}
+
+func (x *CreateCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionResponse.ProtoReflect.Descriptor instead.
+func (*CreateCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateCollectionResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+// DeleteCollectionRequest is the input message for the DeleteCollection method.
+type DeleteCollectionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the collection.
+	CollectionName string `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteCollectionRequest) Reset() {
+	*x = DeleteCollectionRequest{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionRequest) ProtoMessage() {}
+
+func (x *DeleteCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteCollectionRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+// DeleteCollectionResponse is the output message for the DeleteCollection method.
+type DeleteCollectionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the collection was deleted; false if it did not exist.
+	Deleted       bool `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCollectionResponse) Reset() {
+	*x = DeleteCollectionResponse{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionResponse) ProtoMessage() {}
+
+func (x *DeleteCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteCollectionResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// UpsertRequest is the input message for the Upsert method.
+type UpsertRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the collection.
+	CollectionName string `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	// Entries to insert or update, identified by their guid.
+	Data          []*DbData `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertRequest) Reset() {
+	*x = UpsertRequest{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertRequest) ProtoMessage() {}
+
+func (x *UpsertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertRequest.ProtoReflect.Descriptor instead.
+func (*UpsertRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpsertRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *UpsertRequest) GetData() []*DbData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// UpsertResponse is the output message for the Upsert method.
+type UpsertResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of entries written.
+	UpsertedCount int64 `protobuf:"varint,1,opt,name=upserted_count,json=upsertedCount,proto3" json:"upserted_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertResponse) Reset() {
+	*x = UpsertResponse{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertResponse) ProtoMessage() {}
+
+func (x *UpsertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertResponse.ProtoReflect.Descriptor instead.
+func (*UpsertResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpsertResponse) GetUpsertedCount() int64 {
+	if x != nil {
+		return x.UpsertedCount
+	}
+	return 0
+}
+
+// SearchRequest is the input message for the Search method.
+type SearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the collection.
+	CollectionName string `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	// Dense query vector.
+	Embedding []float32 `protobuf:"fixed32,2,rep,packed,name=embedding,proto3" json:"embedding,omitempty"`
+	// Filters restricting the search.
+	Filters *DbFilters `protobuf:"bytes,3,opt,name=filters,proto3" json:"filters,omitempty"`
+	// Maximum number of results; the page size if a cursor based page is requested.
+	TopK int32 `protobuf:"varint,4,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	// Minimum score of the results.
+	MinScore *float64 `protobuf:"fixed64,5,opt,name=min_score,json=minScore,proto3,oneof" json:"min_score,omitempty"`
+	// Opaque cursor from a previous SearchResponse to get the next page.
+	Cursor string `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Whether the parent, children, leaf nodes and siblings of the results are returned.
+	GetRelatives  bool `protobuf:"varint,7,opt,name=get_relatives,json=getRelatives,proto3" json:"get_relatives,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetEmbedding() []float32 {
+	if x != nil {
+		return x.Embedding
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetFilters() *DbFilters {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetTopK() int32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetMinScore() float64 {
+	if x != nil && x.MinScore != nil {
+		return *x.MinScore
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetGetRelatives() bool {
+	if x != nil {
+		return x.GetRelatives
+	}
+	return false
+}
+
+// SearchResponse is the output message for the Search method.
+type SearchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Results ordered by descending score.
+	Results []*DbResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Cursor for the next page; empty if this is the last page.
+	NextCursor string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// Estimated total number of results; -1 if unknown.
+	TotalEstimate int64 `protobuf:"varint,3,opt,name=total_estimate,json=totalEstimate,proto3" json:"total_estimate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchResponse) GetResults() []*DbResponse {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetTotalEstimate() int64 {
+	if x != nil {
+		return x.TotalEstimate
+	}
+	return 0
+}
+
+// BulkIngestRequest is a batch of entries sent to the BulkIngest method.
+type BulkIngestRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the collection; only read from the first message of the stream.
+	CollectionName string `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	// Entries of the batch.
+	Data          []*DbData `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkIngestRequest) Reset() {
+	*x = BulkIngestRequest{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkIngestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkIngestRequest) ProtoMessage() {}
+
+func (x *BulkIngestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkIngestRequest.ProtoReflect.Descriptor instead.
+func (*BulkIngestRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BulkIngestRequest) GetCollectionName() string {
+	if x != nil {
+		return x.CollectionName
+	}
+	return ""
+}
+
+func (x *BulkIngestRequest) GetData() []*DbData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// BulkIngestResponse is the output message for the BulkIngest method.
+type BulkIngestResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of entries written.
+	IngestedCount int64 `protobuf:"varint,1,opt,name=ingested_count,json=ingestedCount,proto3" json:"ingested_count,omitempty"`
+	// Number of entries skipped because their content hash did not change.
+	SkippedCount int64 `protobuf:"varint,2,opt,name=skipped_count,json=skippedCount,proto3" json:"skipped_count,omitempty"`
+	// Errors of entries that could not be written.
+	Errors        []*IngestError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkIngestResponse) Reset() {
+	*x = BulkIngestResponse{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkIngestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkIngestResponse) ProtoMessage() {}
+
+func (x *BulkIngestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkIngestResponse.ProtoReflect.Descriptor instead.
+func (*BulkIngestResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BulkIngestResponse) GetIngestedCount() int64 {
+	if x != nil {
+		return x.IngestedCount
+	}
+	return 0
+}
+
+func (x *BulkIngestResponse) GetSkippedCount() int64 {
+	if x != nil {
+		return x.SkippedCount
+	}
+	return 0
+}
+
+func (x *BulkIngestResponse) GetErrors() []*IngestError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// IngestError describes an entry that could not be written.
+type IngestError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Guid of the entry.
+	Guid string `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
+	// Error message.
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestError) Reset() {
+	*x = IngestError{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestError) ProtoMessage() {}
+
+func (x *IngestError) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestError.ProtoReflect.Descriptor instead.
+func (*IngestError) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *IngestError) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *IngestError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// DbData represents an entry stored in the database.
+type DbData struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Guid              string                 `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
+	DocumentId        string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	DocumentName      string                 `protobuf:"bytes,3,opt,name=document_name,json=documentName,proto3" json:"document_name,omitempty"`
+	Text              string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Keywords          []string               `protobuf:"bytes,5,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	Summary           string                 `protobuf:"bytes,6,opt,name=summary,proto3" json:"summary,omitempty"`
+	Embedding         []float32              `protobuf:"fixed32,7,rep,packed,name=embedding,proto3" json:"embedding,omitempty"`
+	Tags              []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Metadata          *structpb.Struct       `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	ParentId          string                 `protobuf:"bytes,10,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	ChildIds          []string               `protobuf:"bytes,11,rep,name=child_ids,json=childIds,proto3" json:"child_ids,omitempty"`
+	PreviousSiblingId string                 `protobuf:"bytes,12,opt,name=previous_sibling_id,json=previousSiblingId,proto3" json:"previous_sibling_id,omitempty"`
+	NextSiblingId     string                 `protobuf:"bytes,13,opt,name=next_sibling_id,json=nextSiblingId,proto3" json:"next_sibling_id,omitempty"`
+	LastChildId       string                 `protobuf:"bytes,14,opt,name=last_child_id,json=lastChildId,proto3" json:"last_child_id,omitempty"`
+	FirstChildId      string                 `protobuf:"bytes,15,opt,name=first_child_id,json=firstChildId,proto3" json:"first_child_id,omitempty"`
+	Level             int32                  `protobuf:"varint,16,opt,name=level,proto3" json:"level,omitempty"`
+	HasNeo4JEntry     bool                   `protobuf:"varint,17,opt,name=has_neo4j_entry,json=hasNeo4jEntry,proto3" json:"has_neo4j_entry,omitempty"`
+	// Provenance
+	SourceUri       string                 `protobuf:"bytes,18,opt,name=source_uri,json=sourceUri,proto3" json:"source_uri,omitempty"`
+	IngestedAt      *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=ingested_at,json=ingestedAt,proto3" json:"ingested_at,omitempty"`
+	PipelineVersion string                 `protobuf:"bytes,20,opt,name=pipeline_version,json=pipelineVersion,proto3" json:"pipeline_version,omitempty"`
+	ContentHash     string                 `protobuf:"bytes,21,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DbData) Reset() {
+	*x = DbData{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DbData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DbData) ProtoMessage() {}
+
+func (x *DbData) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DbData.ProtoReflect.Descriptor instead.
+func (*DbData) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DbData) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *DbData) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *DbData) GetDocumentName() string {
+	if x != nil {
+		return x.DocumentName
+	}
+	return ""
+}
+
+func (x *DbData) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *DbData) GetKeywords() []string {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+func (x *DbData) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *DbData) GetEmbedding() []float32 {
+	if x != nil {
+		return x.Embedding
+	}
+	return nil
+}
+
+func (x *DbData) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *DbData) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DbData) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *DbData) GetChildIds() []string {
+	if x != nil {
+		return x.ChildIds
+	}
+	return nil
+}
+
+func (x *DbData) GetPreviousSiblingId() string {
+	if x != nil {
+		return x.PreviousSiblingId
+	}
+	return ""
+}
+
+func (x *DbData) GetNextSiblingId() string {
+	if x != nil {
+		return x.NextSiblingId
+	}
+	return ""
+}
+
+func (x *DbData) GetLastChildId() string {
+	if x != nil {
+		return x.LastChildId
+	}
+	return ""
+}
+
+func (x *DbData) GetFirstChildId() string {
+	if x != nil {
+		return x.FirstChildId
+	}
+	return ""
+}
+
+func (x *DbData) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *DbData) GetHasNeo4JEntry() bool {
+	if x != nil {
+		return x.HasNeo4JEntry
+	}
+	return false
+}
+
+func (x *DbData) GetSourceUri() string {
+	if x != nil {
+		return x.SourceUri
+	}
+	return ""
+}
+
+func (x *DbData) GetIngestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IngestedAt
+	}
+	return nil
+}
+
+func (x *DbData) GetPipelineVersion() string {
+	if x != nil {
+		return x.PipelineVersion
+	}
+	return ""
+}
+
+func (x *DbData) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+// DbResponse represents a search result.
+type DbResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The entry found.
+	Data *DbData `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Score of the result, higher is better.
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// Relatives of the entry, only set if requested.
+	Parent        *DbData   `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
+	Children      []*DbData `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	LeafNodes     []*DbData `protobuf:"bytes,5,rep,name=leaf_nodes,json=leafNodes,proto3" json:"leaf_nodes,omitempty"`
+	Siblings      []*DbData `protobuf:"bytes,6,rep,name=siblings,proto3" json:"siblings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DbResponse) Reset() {
+	*x = DbResponse{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DbResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DbResponse) ProtoMessage() {}
+
+func (x *DbResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DbResponse.ProtoReflect.Descriptor instead.
+func (*DbResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DbResponse) GetData() *DbData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DbResponse) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *DbResponse) GetParent() *DbData {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *DbResponse) GetChildren() []*DbData {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *DbResponse) GetLeafNodes() []*DbData {
+	if x != nil {
+		return x.LeafNodes
+	}
+	return nil
+}
+
+func (x *DbResponse) GetSiblings() []*DbData {
+	if x != nil {
+		return x.Siblings
+	}
+	return nil
+}
+
+// DbFilters represents the filters of a search; all conditions are combined with AND.
+type DbFilters struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Filters for string fields
+	GuidFilter         []string `protobuf:"bytes,1,rep,name=guid_filter,json=guidFilter,proto3" json:"guid_filter,omitempty"`
+	DocumentIdFilter   []string `protobuf:"bytes,2,rep,name=document_id_filter,json=documentIdFilter,proto3" json:"document_id_filter,omitempty"`
+	DocumentNameFilter []string `protobuf:"bytes,3,rep,name=document_name_filter,json=documentNameFilter,proto3" json:"document_name_filter,omitempty"`
+	LevelFilter        []string `protobuf:"bytes,4,rep,name=level_filter,json=levelFilter,proto3" json:"level_filter,omitempty"`
+	// Filters for array fields
+	TagsFilter     *DbArrayFilter `protobuf:"bytes,5,opt,name=tags_filter,json=tagsFilter,proto3" json:"tags_filter,omitempty"`
+	KeywordsFilter *DbArrayFilter `protobuf:"bytes,6,opt,name=keywords_filter,json=keywordsFilter,proto3" json:"keywords_filter,omitempty"`
+	// Filters for JSON fields
+	MetadataFilter []*DbJsonFilter `protobuf:"bytes,7,rep,name=metadata_filter,json=metadataFilter,proto3" json:"metadata_filter,omitempty"`
+	// Filters for numeric and date ranges of JSON fields
+	RangeFilter []*DbRangeFilter `protobuf:"bytes,8,rep,name=range_filter,json=rangeFilter,proto3" json:"range_filter,omitempty"`
+	// Boolean composition
+	And           []*DbFilters `protobuf:"bytes,9,rep,name=and,proto3" json:"and,omitempty"`
+	Or            []*DbFilters `protobuf:"bytes,10,rep,name=or,proto3" json:"or,omitempty"`
+	Not           *DbFilters   `protobuf:"bytes,11,opt,name=not,proto3" json:"not,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DbFilters) Reset() {
+	*x = DbFilters{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DbFilters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DbFilters) ProtoMessage() {}
+
+func (x *DbFilters) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DbFilters.ProtoReflect.Descriptor instead.
+func (*DbFilters) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DbFilters) GetGuidFilter() []string {
+	if x != nil {
+		return x.GuidFilter
+	}
+	return nil
+}
+
+func (x *DbFilters) GetDocumentIdFilter() []string {
+	if x != nil {
+		return x.DocumentIdFilter
+	}
+	return nil
+}
+
+func (x *DbFilters) GetDocumentNameFilter() []string {
+	if x != nil {
+		return x.DocumentNameFilter
+	}
+	return nil
+}
+
+func (x *DbFilters) GetLevelFilter() []string {
+	if x != nil {
+		return x.LevelFilter
+	}
+	return nil
+}
+
+func (x *DbFilters) GetTagsFilter() *DbArrayFilter {
+	if x != nil {
+		return x.TagsFilter
+	}
+	return nil
+}
+
+func (x *DbFilters) GetKeywordsFilter() *DbArrayFilter {
+	if x != nil {
+		return x.KeywordsFilter
+	}
+	return nil
+}
+
+func (x *DbFilters) GetMetadataFilter() []*DbJsonFilter {
+	if x != nil {
+		return x.MetadataFilter
+	}
+	return nil
+}
+
+func (x *DbFilters) GetRangeFilter() []*DbRangeFilter {
+	if x != nil {
+		return x.RangeFilter
+	}
+	return nil
+}
+
+func (x *DbFilters) GetAnd() []*DbFilters {
+	if x != nil {
+		return x.And
+	}
+	return nil
+}
+
+func (x *DbFilters) GetOr() []*DbFilters {
+	if x != nil {
+		return x.Or
+	}
+	return nil
+}
+
+func (x *DbFilters) GetNot() *DbFilters {
+	if x != nil {
+		return x.Not
+	}
+	return nil
+}
+
+// DbArrayFilter represents the filter for an array field.
+type DbArrayFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NeedAll       bool                   `protobuf:"varint,1,opt,name=need_all,json=needAll,proto3" json:"need_all,omitempty"`
+	FilterData    []string               `protobuf:"bytes,2,rep,name=filter_data,json=filterData,proto3" json:"filter_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DbArrayFilter) Reset() {
+	*x = DbArrayFilter{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DbArrayFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DbArrayFilter) ProtoMessage() {}
+
+func (x *DbArrayFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DbArrayFilter.ProtoReflect.Descriptor instead.
+func (*DbArrayFilter) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DbArrayFilter) GetNeedAll() bool {
+	if x != nil {
+		return x.NeedAll
+	}
+	return false
+}
+
+func (x *DbArrayFilter) GetFilterData() []string {
+	if x != nil {
+		return x.FilterData
+	}
+	return nil
+}
+
+// DbJsonFilter represents the filter for a JSON field.
+type DbJsonFilter struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	FieldName string                 `protobuf:"bytes,1,opt,name=field_name,json=fieldName,proto3" json:"field_name,omitempty"`
+	// "string" or "array"
+	FieldType  string   `protobuf:"bytes,2,opt,name=field_type,json=fieldType,proto3" json:"field_type,omitempty"`
+	FilterData []string `protobuf:"bytes,3,rep,name=filter_data,json=filterData,proto3" json:"filter_data,omitempty"`
+	// Only needed for array fields
+	NeedAll       bool `protobuf:"varint,4,opt,name=need_all,json=needAll,proto3" json:"need_all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DbJsonFilter) Reset() {
+	*x = DbJsonFilter{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DbJsonFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DbJsonFilter) ProtoMessage() {}
+
+func (x *DbJsonFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DbJsonFilter.ProtoReflect.Descriptor instead.
+func (*DbJsonFilter) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DbJsonFilter) GetFieldName() string {
+	if x != nil {
+		return x.FieldName
+	}
+	return ""
+}
+
+func (x *DbJsonFilter) GetFieldType() string {
+	if x != nil {
+		return x.FieldType
+	}
+	return ""
+}
+
+func (x *DbJsonFilter) GetFilterData() []string {
+	if x != nil {
+		return x.FilterData
+	}
+	return nil
+}
+
+func (x *DbJsonFilter) GetNeedAll() bool {
+	if x != nil {
+		return x.NeedAll
+	}
+	return false
+}
+
+// DbRangeFilter represents an inclusive range filter for a numeric or date JSON field.
+type DbRangeFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FieldName     string                 `protobuf:"bytes,1,opt,name=field_name,json=fieldName,proto3" json:"field_name,omitempty"`
+	Min           *float64               `protobuf:"fixed64,2,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max           *float64               `protobuf:"fixed64,3,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	MinDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=min_date,json=minDate,proto3" json:"min_date,omitempty"`
+	MaxDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=max_date,json=maxDate,proto3" json:"max_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DbRangeFilter) Reset() {
+	*x = DbRangeFilter{}
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DbRangeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DbRangeFilter) ProtoMessage() {}
+
+func (x *DbRangeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DbRangeFilter.ProtoReflect.Descriptor instead.
+func (*DbRangeFilter) Descriptor() ([]byte, []int) {
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DbRangeFilter) GetFieldName() string {
+	if x != nil {
+		return x.FieldName
+	}
+	return ""
+}
+
+func (x *DbRangeFilter) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *DbRangeFilter) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *DbRangeFilter) GetMinDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MinDate
+	}
+	return nil
+}
+
+func (x *DbRangeFilter) GetMaxDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MaxDate
+	}
+	return nil
+}
+
+var File_pkg_aalikdbgrpc_aali_kdb_proto protoreflect.FileDescriptor
+
+const file_pkg_aalikdbgrpc_aali_kdb_proto_rawDesc = "" +
+	"\n" +
+	"\x1epkg/aalikdbgrpc/aali-kdb.proto\x12\vaalikdbgrpc\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x0f\n" +
+	"\rHealthRequest\"m\n" +
+	"\x0eHealthResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12)\n" +
+	"\x10protocol_version\x18\x03 \x01(\tR\x0fprotocolVersion\"\x18\n" +
+	"\x16ListCollectionsRequest\";\n" +
+	"\x17ListCollectionsResponse\x12 \n" +
+	"\vcollections\x18\x01 \x03(\tR\vcollections\"\x8c\x01\n" +
+	"\x17CreateCollectionRequest\x12'\n" +
+	"\x0fcollection_name\x18\x01 \x01(\tR\x0ecollectionName\x12\x1f\n" +
+	"\vvector_size\x18\x02 \x01(\x05R\n" +
+	"vectorSize\x12'\n" +
+	"\x0fdistance_metric\x18\x03 \x01(\tR\x0edistanceMetric\"4\n" +
+	"\x18CreateCollectionResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\bR\acreated\"B\n" +
+	"\x17DeleteCollectionRequest\x12'\n" +
+	"\x0fcollection_name\x18\x01 \x01(\tR\x0ecollectionName\"4\n" +
+	"\x18DeleteCollectionResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"a\n" +
+	"\rUpsertRequest\x12'\n" +
+	"\x0fcollection_name\x18\x01 \x01(\tR\x0ecollectionName\x12'\n" +
+	"\x04data\x18\x02 \x03(\v2\x13.aalikdbgrpc.DbDataR\x04data\"7\n" +
+	"\x0eUpsertResponse\x12%\n" +
+	"\x0eupserted_count\x18\x01 \x01(\x03R\rupsertedCount\"\x8a\x02\n" +
+	"\rSearchRequest\x12'\n" +
+	"\x0fcollection_name\x18\x01 \x01(\tR\x0ecollectionName\x12\x1c\n" +
+	"\tembedding\x18\x02 \x03(\x02R\tembedding\x120\n" +
+	"\afilters\x18\x03 \x01(\v2\x16.aalikdbgrpc.DbFiltersR\afilters\x12\x13\n" +
+	"\x05top_k\x18\x04 \x01(\x05R\x04topK\x12 \n" +
+	"\tmin_score\x18\x05 \x01(\x01H\x00R\bminScore\x88\x01\x01\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12#\n" +
+	"\rget_relatives\x18\a \x01(\bR\fgetRelativesB\f\n" +
+	"\n" +
+	"_min_score\"\x8b\x01\n" +
+	"\x0eSearchResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.aalikdbgrpc.DbResponseR\aresults\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12%\n" +
+	"\x0etotal_estimate\x18\x03 \x01(\x03R\rtotalEstimate\"e\n" +
+	"\x11BulkIngestRequest\x12'\n" +
+	"\x0fcollection_name\x18\x01 \x01(\tR\x0ecollectionName\x12'\n" +
+	"\x04data\x18\x02 \x03(\v2\x13.aalikdbgrpc.DbDataR\x04data\"\x92\x01\n" +
+	"\x12BulkIngestResponse\x12%\n" +
+	"\x0eingested_count\x18\x01 \x01(\x03R\ringestedCount\x12#\n" +
+	"\rskipped_count\x18\x02 \x01(\x03R\fskippedCount\x120\n" +
+	"\x06errors\x18\x03 \x03(\v2\x18.aalikdbgrpc.IngestErrorR\x06errors\";\n" +
+	"\vIngestError\x12\x12\n" +
+	"\x04guid\x18\x01 \x01(\tR\x04guid\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xd7\x05\n" +
+	"\x06DbData\x12\x12\n" +
+	"\x04guid\x18\x01 \x01(\tR\x04guid\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\x12#\n" +
+	"\rdocument_name\x18\x03 \x01(\tR\fdocumentName\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12\x1a\n" +
+	"\bkeywords\x18\x05 \x03(\tR\bkeywords\x12\x18\n" +
+	"\asummary\x18\x06 \x01(\tR\asummary\x12\x1c\n" +
+	"\tembedding\x18\a \x03(\x02R\tembedding\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x123\n" +
+	"\bmetadata\x18\t \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12\x1b\n" +
+	"\tparent_id\x18\n" +
+	" \x01(\tR\bparentId\x12\x1b\n" +
+	"\tchild_ids\x18\v \x03(\tR\bchildIds\x12.\n" +
+	"\x13previous_sibling_id\x18\f \x01(\tR\x11previousSiblingId\x12&\n" +
+	"\x0fnext_sibling_id\x18\r \x01(\tR\rnextSiblingId\x12\"\n" +
+	"\rlast_child_id\x18\x0e \x01(\tR\vlastChildId\x12$\n" +
+	"\x0efirst_child_id\x18\x0f \x01(\tR\ffirstChildId\x12\x14\n" +
+	"\x05level\x18\x10 \x01(\x05R\x05level\x12&\n" +
+	"\x0fhas_neo4j_entry\x18\x11 \x01(\bR\rhasNeo4jEntry\x12\x1d\n" +
+	"\n" +
+	"source_uri\x18\x12 \x01(\tR\tsourceUri\x12;\n" +
+	"\vingested_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"ingestedAt\x12)\n" +
+	"\x10pipeline_version\x18\x14 \x01(\tR\x0fpipelineVersion\x12!\n" +
+	"\fcontent_hash\x18\x15 \x01(\tR\vcontentHash\"\x8e\x02\n" +
+	"\n" +
+	"DbResponse\x12'\n" +
+	"\x04data\x18\x01 \x01(\v2\x13.aalikdbgrpc.DbDataR\x04data\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12+\n" +
+	"\x06parent\x18\x03 \x01(\v2\x13.aalikdbgrpc.DbDataR\x06parent\x12/\n" +
+	"\bchildren\x18\x04 \x03(\v2\x13.aalikdbgrpc.DbDataR\bchildren\x122\n" +
+	"\n" +
+	"leaf_nodes\x18\x05 \x03(\v2\x13.aalikdbgrpc.DbDataR\tleafNodes\x12/\n" +
+	"\bsiblings\x18\x06 \x03(\v2\x13.aalikdbgrpc.DbDataR\bsiblings\"\xb0\x04\n" +
+	"\tDbFilters\x12\x1f\n" +
+	"\vguid_filter\x18\x01 \x03(\tR\n" +
+	"guidFilter\x12,\n" +
+	"\x12document_id_filter\x18\x02 \x03(\tR\x10documentIdFilter\x120\n" +
+	"\x14document_name_filter\x18\x03 \x03(\tR\x12documentNameFilter\x12!\n" +
+	"\flevel_filter\x18\x04 \x03(\tR\vlevelFilter\x12;\n" +
+	"\vtags_filter\x18\x05 \x01(\v2\x1a.aalikdbgrpc.DbArrayFilterR\n" +
+	"tagsFilter\x12C\n" +
+	"\x0fkeywords_filter\x18\x06 \x01(\v2\x1a.aalikdbgrpc.DbArrayFilterR\x0ekeywordsFilter\x12B\n" +
+	"\x0fmetadata_filter\x18\a \x03(\v2\x19.aalikdbgrpc.DbJsonFilterR\x0emetadataFilter\x12=\n" +
+	"\frange_filter\x18\b \x03(\v2\x1a.aalikdbgrpc.DbRangeFilterR\vrangeFilter\x12(\n" +
+	"\x03and\x18\t \x03(\v2\x16.aalikdbgrpc.DbFiltersR\x03and\x12&\n" +
+	"\x02or\x18\n" +
+	" \x03(\v2\x16.aalikdbgrpc.DbFiltersR\x02or\x12(\n" +
+	"\x03not\x18\v \x01(\v2\x16.aalikdbgrpc.DbFiltersR\x03not\"K\n" +
+	"\rDbArrayFilter\x12\x19\n" +
+	"\bneed_all\x18\x01 \x01(\bR\aneedAll\x12\x1f\n" +
+	"\vfilter_data\x18\x02 \x03(\tR\n" +
+	"filterData\"\x88\x01\n" +
+	"\fDbJsonFilter\x12\x1d\n" +
+	"\n" +
+	"field_name\x18\x01 \x01(\tR\tfieldName\x12\x1d\n" +
+	"\n" +
+	"field_type\x18\x02 \x01(\tR\tfieldType\x12\x1f\n" +
+	"\vfilter_data\x18\x03 \x03(\tR\n" +
+	"filterData\x12\x19\n" +
+	"\bneed_all\x18\x04 \x01(\bR\aneedAll\"\xda\x01\n" +
+	"\rDbRangeFilter\x12\x1d\n" +
+	"\n" +
+	"field_name\x18\x01 \x01(\tR\tfieldName\x12\x15\n" +
+	"\x03min\x18\x02 \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x03 \x01(\x01H\x01R\x03max\x88\x01\x01\x125\n" +
+	"\bmin_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aminDate\x125\n" +
+	"\bmax_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\amaxDateB\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max2\xda\x04\n" +
+	"\vKnowledgeDb\x12H\n" +
+	"\vHealthCheck\x12\x1a.aalikdbgrpc.HealthRequest\x1a\x1b.aalikdbgrpc.HealthResponse\"\x00\x12^\n" +
+	"\x0fListCollections\x12#.aalikdbgrpc.ListCollectionsRequest\x1a$.aalikdbgrpc.ListCollectionsResponse\"\x00\x12a\n" +
+	"\x10CreateCollection\x12$.aalikdbgrpc.CreateCollectionRequest\x1a%.aalikdbgrpc.CreateCollectionResponse\"\x00\x12a\n" +
+	"\x10DeleteCollection\x12$.aalikdbgrpc.DeleteCollectionRequest\x1a%.aalikdbgrpc.DeleteCollectionResponse\"\x00\x12C\n" +
+	"\x06Upsert\x12\x1a.aalikdbgrpc.UpsertRequest\x1a\x1b.aalikdbgrpc.UpsertResponse\"\x00\x12C\n" +
+	"\x06Search\x12\x1a.aalikdbgrpc.SearchRequest\x1a\x1b.aalikdbgrpc.SearchResponse\"\x00\x12Q\n" +
+	"\n" +
+	"BulkIngest\x12\x1e.aalikdbgrpc.BulkIngestRequest\x1a\x1f.aalikdbgrpc.BulkIngestResponse\"\x00(\x01B\x0fZ\r./aalikdbgrpcb\x06proto3"
+
+var (
+	file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescOnce sync.Once
+	file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescData []byte
+)
+
+func file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescGZIP() []byte {
+	file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescOnce.Do(func() {
+		file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_aalikdbgrpc_aali_kdb_proto_rawDesc), len(file_pkg_aalikdbgrpc_aali_kdb_proto_rawDesc)))
+	})
+	return file_pkg_aalikdbgrpc_aali_kdb_proto_rawDescData
+}
+
+var file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_pkg_aalikdbgrpc_aali_kdb_proto_goTypes = []any{
+	(*HealthRequest)(nil),            // 0: aalikdbgrpc.HealthRequest
+	(*HealthResponse)(nil),           // 1: aalikdbgrpc.HealthResponse
+	(*ListCollectionsRequest)(nil),   // 2: aalikdbgrpc.ListCollectionsRequest
+	(*ListCollectionsResponse)(nil),  // 3: aalikdbgrpc.ListCollectionsResponse
+	(*CreateCollectionRequest)(nil),  // 4: aalikdbgrpc.CreateCollectionRequest
+	(*CreateCollectionResponse)(nil), // 5: aalikdbgrpc.CreateCollectionResponse
+	(*DeleteCollectionRequest)(nil),  // 6: aalikdbgrpc.DeleteCollectionRequest
+	(*DeleteCollectionResponse)(nil), // 7: aalikdbgrpc.DeleteCollectionResponse
+	(*UpsertRequest)(nil),            // 8: aalikdbgrpc.UpsertRequest
+	(*UpsertResponse)(nil),           // 9: aalikdbgrpc.UpsertResponse
+	(*SearchRequest)(nil),            // 10: aalikdbgrpc.SearchRequest
+	(*SearchResponse)(nil),           // 11: aalikdbgrpc.SearchResponse
+	(*BulkIngestRequest)(nil),        // 12: aalikdbgrpc.BulkIngestRequest
+	(*BulkIngestResponse)(nil),       // 13: aalikdbgrpc.BulkIngestResponse
+	(*IngestError)(nil),              // 14: aalikdbgrpc.IngestError
+	(*DbData)(nil),                   // 15: aalikdbgrpc.DbData
+	(*DbResponse)(nil),               // 16: aalikdbgrpc.DbResponse
+	(*DbFilters)(nil),                // 17: aalikdbgrpc.DbFilters
+	(*DbArrayFilter)(nil),            // 18: aalikdbgrpc.DbArrayFilter
+	(*DbJsonFilter)(nil),             // 19: aalikdbgrpc.DbJsonFilter
+	(*DbRangeFilter)(nil),            // 20: aalikdbgrpc.DbRangeFilter
+	(*structpb.Struct)(nil),          // 21: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),    // 22: google.protobuf.Timestamp
+}
+var file_pkg_aalikdbgrpc_aali_kdb_proto_depIdxs = []int32{
+	15, // 0: aalikdbgrpc.UpsertRequest.data:type_name -> aalikdbgrpc.DbData
+	17, // 1: aalikdbgrpc.SearchRequest.filters:type_name -> aalikdbgrpc.DbFilters
+	16, // 2: aalikdbgrpc.SearchResponse.results:type_name -> aalikdbgrpc.DbResponse
+	15, // 3: aalikdbgrpc.BulkIngestRequest.data:type_name -> aalikdbgrpc.DbData
+	14, // 4: aalikdbgrpc.BulkIngestResponse.errors:type_name -> aalikdbgrpc.IngestError
+	21, // 5: aalikdbgrpc.DbData.metadata:type_name -> google.protobuf.Struct
+	22, // 6: aalikdbgrpc.DbData.ingested_at:type_name -> google.protobuf.Timestamp
+	15, // 7: aalikdbgrpc.DbResponse.data:type_name -> aalikdbgrpc.DbData
+	15, // 8: aalikdbgrpc.DbResponse.parent:type_name -> aalikdbgrpc.DbData
+	15, // 9: aalikdbgrpc.DbResponse.children:type_name -> aalikdbgrpc.DbData
+	15, // 10: aalikdbgrpc.DbResponse.leaf_nodes:type_name -> aalikdbgrpc.DbData
+	15, // 11: aalikdbgrpc.DbResponse.siblings:type_name -> aalikdbgrpc.DbData
+	18, // 12: aalikdbgrpc.DbFilters.tags_filter:type_name -> aalikdbgrpc.DbArrayFilter
+	18, // 13: aalikdbgrpc.DbFilters.keywords_filter:type_name -> aalikdbgrpc.DbArrayFilter
+	19, // 14: aalikdbgrpc.DbFilters.metadata_filter:type_name -> aalikdbgrpc.DbJsonFilter
+	20, // 15: aalikdbgrpc.DbFilters.range_filter:type_name -> aalikdbgrpc.DbRangeFilter
+	17, // 16: aalikdbgrpc.DbFilters.and:type_name -> aalikdbgrpc.DbFilters
+	17, // 17: aalikdbgrpc.DbFilters.or:type_name -> aalikdbgrpc.DbFilters
+	17, // 18: aalikdbgrpc.DbFilters.not:type_name -> aalikdbgrpc.DbFilters
+	22, // 19: aalikdbgrpc.DbRangeFilter.min_date:type_name -> google.protobuf.Timestamp
+	22, // 20: aalikdbgrpc.DbRangeFilter.max_date:type_name -> google.protobuf.Timestamp
+	0,  // 21: aalikdbgrpc.KnowledgeDb.HealthCheck:input_type -> aalikdbgrpc.HealthRequest
+	2,  // 22: aalikdbgrpc.KnowledgeDb.ListCollections:input_type -> aalikdbgrpc.ListCollectionsRequest
+	4,  // 23: aalikdbgrpc.KnowledgeDb.CreateCollection:input_type -> aalikdbgrpc.CreateCollectionRequest
+	6,  // 24: aalikdbgrpc.KnowledgeDb.DeleteCollection:input_type -> aalikdbgrpc.DeleteCollectionRequest
+	8,  // 25: aalikdbgrpc.KnowledgeDb.Upsert:input_type -> aalikdbgrpc.UpsertRequest
+	10, // 26: aalikdbgrpc.KnowledgeDb.Search:input_type -> aalikdbgrpc.SearchRequest
+	12, // 27: aalikdbgrpc.KnowledgeDb.BulkIngest:input_type -> aalikdbgrpc.BulkIngestRequest
+	1,  // 28: aalikdbgrpc.KnowledgeDb.HealthCheck:output_type -> aalikdbgrpc.HealthResponse
+	3,  // 29: aalikdbgrpc.KnowledgeDb.ListCollections:output_type -> aalikdbgrpc.ListCollectionsResponse
+	5,  // 30: aalikdbgrpc.KnowledgeDb.CreateCollection:output_type -> aalikdbgrpc.CreateCollectionResponse
+	7,  // 31: aalikdbgrpc.KnowledgeDb.DeleteCollection:output_type -> aalikdbgrpc.DeleteCollectionResponse
+	9,  // 32: aalikdbgrpc.KnowledgeDb.Upsert:output_type -> aalikdbgrpc.UpsertResponse
+	11, // 33: aalikdbgrpc.KnowledgeDb.Search:output_type -> aalikdbgrpc.SearchResponse
+	13, // 34: aalikdbgrpc.KnowledgeDb.BulkIngest:output_type -> aalikdbgrpc.BulkIngestResponse
+	28, // [28:35] is the sub-list for method output_type
+	21, // [21:28] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_pkg_aalikdbgrpc_aali_kdb_proto_init() }
+func file_pkg_aalikdbgrpc_aali_kdb_proto_init() {
+	if File_pkg_aalikdbgrpc_aali_kdb_proto != nil {
+		return
+	}
+	file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[10].OneofWrappers = []any{}
+	file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes[20].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_aalikdbgrpc_aali_kdb_proto_rawDesc), len(file_pkg_aalikdbgrpc_aali_kdb_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_aalikdbgrpc_aali_kdb_proto_goTypes,
+		DependencyIndexes: file_pkg_aalikdbgrpc_aali_kdb_proto_depIdxs,
+		MessageInfos:      file_pkg_aalikdbgrpc_aali_kdb_proto_msgTypes,
+	}.Build()
+	File_pkg_aalikdbgrpc_aali_kdb_proto = out.File
+	file_pkg_aalikdbgrpc_aali_kdb_proto_goTypes = nil
+	file_pkg_aalikdbgrpc_aali_kdb_proto_depIdxs = nil
+}
@@ -0,0 +1,363 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v4.25.9
+// source: pkg/aalikdbgrpc/aali-kdb.proto
+
+package aalikdbgrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	KnowledgeDb_HealthCheck_FullMethodName      = "/aalikdbgrpc.KnowledgeDb/HealthCheck"
+	KnowledgeDb_ListCollections_FullMethodName  = "/aalikdbgrpc.KnowledgeDb/ListCollections"
+	KnowledgeDb_CreateCollection_FullMethodName = "/aalikdbgrpc.KnowledgeDb/CreateCollection"
+	KnowledgeDb_DeleteCollection_FullMethodName = "/aalikdbgrpc.KnowledgeDb/DeleteCollection"
+	KnowledgeDb_Upsert_FullMethodName           = "/aalikdbgrpc.KnowledgeDb/Upsert"
+	KnowledgeDb_Search_FullMethodName           = "/aalikdbgrpc.KnowledgeDb/Search"
+	KnowledgeDb_BulkIngest_FullMethodName       = "/aalikdbgrpc.KnowledgeDb/BulkIngest"
+)
+
+// KnowledgeDbClient is the client API for KnowledgeDb service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// KnowledgeDb is a gRPC service that provides backend independent access to the knowledge database.
+type KnowledgeDbClient interface {
+	// HealthCheck is a simple health check method that returns an empty response.
+	HealthCheck(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	// Lists all collections in the database.
+	ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
+	// Creates a new collection.
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error)
+	// Deletes a collection and all its entries.
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+	// Inserts or updates entries of a collection.
+	Upsert(ctx context.Context, in *UpsertRequest, opts ...grpc.CallOption) (*UpsertResponse, error)
+	// Searches a collection by vector similarity, optionally restricted by filters.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// Ingests a large number of entries; the client streams batches of entries
+	// and receives a summary once the stream is closed.
+	BulkIngest(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[BulkIngestRequest, BulkIngestResponse], error)
+}
+
+type knowledgeDbClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKnowledgeDbClient(cc grpc.ClientConnInterface) KnowledgeDbClient {
+	return &knowledgeDbClient{cc}
+}
+
+func (c *knowledgeDbClient) HealthCheck(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, KnowledgeDb_HealthCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *knowledgeDbClient) ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionsResponse)
+	err := c.cc.Invoke(ctx, KnowledgeDb_ListCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *knowledgeDbClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCollectionResponse)
+	err := c.cc.Invoke(ctx, KnowledgeDb_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *knowledgeDbClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCollectionResponse)
+	err := c.cc.Invoke(ctx, KnowledgeDb_DeleteCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *knowledgeDbClient) Upsert(ctx context.Context, in *UpsertRequest, opts ...grpc.CallOption) (*UpsertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertResponse)
+	err := c.cc.Invoke(ctx, KnowledgeDb_Upsert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *knowledgeDbClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, KnowledgeDb_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *knowledgeDbClient) BulkIngest(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[BulkIngestRequest, BulkIngestResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KnowledgeDb_ServiceDesc.Streams[0], KnowledgeDb_BulkIngest_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BulkIngestRequest, BulkIngestResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KnowledgeDb_BulkIngestClient = grpc.ClientStreamingClient[BulkIngestRequest, BulkIngestResponse]
+
+// KnowledgeDbServer is the server API for KnowledgeDb service.
+// All implementations must embed UnimplementedKnowledgeDbServer
+// for forward compatibility.
+//
+// KnowledgeDb is a gRPC service that provides backend independent access to the knowledge database.
+type KnowledgeDbServer interface {
+	// HealthCheck is a simple health check method that returns an empty response.
+	HealthCheck(context.Context, *HealthRequest) (*HealthResponse, error)
+	// Lists all collections in the database.
+	ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error)
+	// Creates a new collection.
+	CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error)
+	// Deletes a collection and all its entries.
+	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
+	// Inserts or updates entries of a collection.
+	Upsert(context.Context, *UpsertRequest) (*UpsertResponse, error)
+	// Searches a collection by vector similarity, optionally restricted by filters.
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// Ingests a large number of entries; the client streams batches of entries
+	// and receives a summary once the stream is closed.
+	BulkIngest(grpc.ClientStreamingServer[BulkIngestRequest, BulkIngestResponse]) error
+	mustEmbedUnimplementedKnowledgeDbServer()
+}
+
+// UnimplementedKnowledgeDbServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKnowledgeDbServer struct{}
+
+func (UnimplementedKnowledgeDbServer) HealthCheck(context.Context, *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method HealthCheck not implemented")
+}
+func (UnimplementedKnowledgeDbServer) ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedKnowledgeDbServer) CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedKnowledgeDbServer) DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedKnowledgeDbServer) Upsert(context.Context, *UpsertRequest) (*UpsertResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Upsert not implemented")
+}
+func (UnimplementedKnowledgeDbServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedKnowledgeDbServer) BulkIngest(grpc.ClientStreamingServer[BulkIngestRequest, BulkIngestResponse]) error {
+	return status.Error(codes.Unimplemented, "method BulkIngest not implemented")
+}
+func (UnimplementedKnowledgeDbServer) mustEmbedUnimplementedKnowledgeDbServer() {}
+func (UnimplementedKnowledgeDbServer) testEmbeddedByValue()                     {}
+
+// UnsafeKnowledgeDbServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KnowledgeDbServer will
+// result in compilation errors.
+type UnsafeKnowledgeDbServer interface {
+	mustEmbedUnimplementedKnowledgeDbServer()
+}
+
+func RegisterKnowledgeDbServer(s grpc.ServiceRegistrar, srv KnowledgeDbServer) {
+	// If the following call panics, it indicates UnimplementedKnowledgeDbServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KnowledgeDb_ServiceDesc, srv)
+}
+
+func _KnowledgeDb_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeDbServer).HealthCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeDb_HealthCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeDbServer).HealthCheck(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KnowledgeDb_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeDbServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeDb_ListCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeDbServer).ListCollections(ctx, req.(*ListCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KnowledgeDb_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeDbServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeDb_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeDbServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KnowledgeDb_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeDbServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeDb_DeleteCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeDbServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KnowledgeDb_Upsert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeDbServer).Upsert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeDb_Upsert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeDbServer).Upsert(ctx, req.(*UpsertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KnowledgeDb_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KnowledgeDbServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KnowledgeDb_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KnowledgeDbServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KnowledgeDb_BulkIngest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KnowledgeDbServer).BulkIngest(&grpc.GenericServerStream[BulkIngestRequest, BulkIngestResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KnowledgeDb_BulkIngestServer = grpc.ClientStreamingServer[BulkIngestRequest, BulkIngestResponse]
+
+// KnowledgeDb_ServiceDesc is the grpc.ServiceDesc for KnowledgeDb service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KnowledgeDb_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "aalikdbgrpc.KnowledgeDb",
+	HandlerType: (*KnowledgeDbServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "HealthCheck",
+			Handler:    _KnowledgeDb_HealthCheck_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _KnowledgeDb_ListCollections_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _KnowledgeDb_CreateCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _KnowledgeDb_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "Upsert",
+			Handler:    _KnowledgeDb_Upsert_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _KnowledgeDb_Search_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BulkIngest",
+			Handler:       _KnowledgeDb_BulkIngest_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/aalikdbgrpc/aali-kdb.proto",
+}
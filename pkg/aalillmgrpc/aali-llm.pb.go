@@ -0,0 +1,1769 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v4.25.9
+// source: pkg/aalillmgrpc/aali-llm.proto
+
+package aalillmgrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HealthRequest is the input message for the HealthCheck method.
+type HealthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthRequest) Reset() {
+	*x = HealthRequest{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthRequest) ProtoMessage() {}
+
+func (x *HealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{0}
+}
+
+// HealthResponse is the output message for the HealthCheck method.
+type HealthResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Status of the health check, always "OK" for a healthy service.
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Semantic version of the service, e.g. "1.4.2".
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Semantic version of the gRPC protocol implemented by the service, e.g. "1.0.0".
+	ProtocolVersion string `protobuf:"bytes,3,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HealthResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetProtocolVersion() string {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return ""
+}
+
+// ChatRequest is the input message for the Chat method.
+type ChatRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	InstructionGuid string                 `protobuf:"bytes,1,opt,name=instruction_guid,json=instructionGuid,proto3" json:"instruction_guid,omitempty"`
+	// Optional model ids to define a set of specific models to be used for this request.
+	ModelIds []string `protobuf:"bytes,2,rep,name=model_ids,json=modelIds,proto3" json:"model_ids,omitempty"`
+	// Optional model categories to filter models; models of the specified categories from first to last will be used if available.
+	ModelCategory []string `protobuf:"bytes,3,rep,name=model_category,json=modelCategory,proto3" json:"model_category,omitempty"`
+	// The user message.
+	Data string `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// List of images in base64 format.
+	Images []string `protobuf:"bytes,5,rep,name=images,proto3" json:"images,omitempty"`
+	// MCP tool definitions for tool calling support.
+	McpTools []*structpb.Struct `protobuf:"bytes,6,rep,name=mcp_tools,json=mcpTools,proto3" json:"mcp_tools,omitempty"`
+	// "summary", "code", "keywords", "general"
+	ChatRequestType string `protobuf:"bytes,7,opt,name=chat_request_type,json=chatRequestType,proto3" json:"chat_request_type,omitempty"`
+	// Whether the answer is streamed in several messages.
+	DataStream bool `protobuf:"varint,8,opt,name=data_stream,json=dataStream,proto3" json:"data_stream,omitempty"`
+	// Only relevant if "chat_request_type" is "keywords".
+	MaxNumberOfKeywords uint32 `protobuf:"varint,9,opt,name=max_number_of_keywords,json=maxNumberOfKeywords,proto3" json:"max_number_of_keywords,omitempty"`
+	// Only relevant if "chat_request_type" is "code".
+	IsConversation bool `protobuf:"varint,10,opt,name=is_conversation,json=isConversation,proto3" json:"is_conversation,omitempty"`
+	// Only relevant if "is_conversation" is true.
+	ConversationHistory []*HistoricMessage `protobuf:"bytes,11,rep,name=conversation_history,json=conversationHistory,proto3" json:"conversation_history,omitempty"`
+	// Any added context you might need.
+	GeneralContext string `protobuf:"bytes,12,opt,name=general_context,json=generalContext,proto3" json:"general_context,omitempty"`
+	MsgContext     string `protobuf:"bytes,13,opt,name=msg_context,json=msgContext,proto3" json:"msg_context,omitempty"`
+	// String or map of prompts; only relevant if "chat_request_type" is "general".
+	SystemPrompt  *structpb.Value `protobuf:"bytes,14,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	ModelOptions  *ModelOptions   `protobuf:"bytes,15,opt,name=model_options,json=modelOptions,proto3" json:"model_options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatRequest) Reset() {
+	*x = ChatRequest{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatRequest) ProtoMessage() {}
+
+func (x *ChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatRequest.ProtoReflect.Descriptor instead.
+func (*ChatRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ChatRequest) GetInstructionGuid() string {
+	if x != nil {
+		return x.InstructionGuid
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetModelIds() []string {
+	if x != nil {
+		return x.ModelIds
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetModelCategory() []string {
+	if x != nil {
+		return x.ModelCategory
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetMcpTools() []*structpb.Struct {
+	if x != nil {
+		return x.McpTools
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetChatRequestType() string {
+	if x != nil {
+		return x.ChatRequestType
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetDataStream() bool {
+	if x != nil {
+		return x.DataStream
+	}
+	return false
+}
+
+func (x *ChatRequest) GetMaxNumberOfKeywords() uint32 {
+	if x != nil {
+		return x.MaxNumberOfKeywords
+	}
+	return 0
+}
+
+func (x *ChatRequest) GetIsConversation() bool {
+	if x != nil {
+		return x.IsConversation
+	}
+	return false
+}
+
+func (x *ChatRequest) GetConversationHistory() []*HistoricMessage {
+	if x != nil {
+		return x.ConversationHistory
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetGeneralContext() string {
+	if x != nil {
+		return x.GeneralContext
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetMsgContext() string {
+	if x != nil {
+		return x.MsgContext
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetSystemPrompt() *structpb.Value {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetModelOptions() *ModelOptions {
+	if x != nil {
+		return x.ModelOptions
+	}
+	return nil
+}
+
+// ChatResponse is a message of the answer stream of the Chat method.
+type ChatResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	InstructionGuid string                 `protobuf:"bytes,1,opt,name=instruction_guid,json=instructionGuid,proto3" json:"instruction_guid,omitempty"`
+	// Whether this is the last message of the stream.
+	IsLast bool `protobuf:"varint,2,opt,name=is_last,json=isLast,proto3" json:"is_last,omitempty"`
+	// Position of the message within the stream.
+	Position uint32 `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	// Text of the answer; the next chunk of the answer for streamed requests.
+	ChatData string `protobuf:"bytes,4,opt,name=chat_data,json=chatData,proto3" json:"chat_data,omitempty"`
+	// Structured tool calls from the model.
+	ToolCalls []*ToolCall `protobuf:"bytes,5,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	// Token usage; only set on the last message.
+	InputTokenCount     *int64 `protobuf:"varint,6,opt,name=input_token_count,json=inputTokenCount,proto3,oneof" json:"input_token_count,omitempty"`
+	OutputTokenCount    *int64 `protobuf:"varint,7,opt,name=output_token_count,json=outputTokenCount,proto3,oneof" json:"output_token_count,omitempty"`
+	CachedTokenCount    *int64 `protobuf:"varint,8,opt,name=cached_token_count,json=cachedTokenCount,proto3,oneof" json:"cached_token_count,omitempty"`
+	ReasoningTokenCount *int64 `protobuf:"varint,9,opt,name=reasoning_token_count,json=reasoningTokenCount,proto3,oneof" json:"reasoning_token_count,omitempty"`
+	// Informational message, e.g. about a model fallback.
+	InfoMessage   string `protobuf:"bytes,10,opt,name=info_message,json=infoMessage,proto3" json:"info_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatResponse) Reset() {
+	*x = ChatResponse{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatResponse) ProtoMessage() {}
+
+func (x *ChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
+func (*ChatResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChatResponse) GetInstructionGuid() string {
+	if x != nil {
+		return x.InstructionGuid
+	}
+	return ""
+}
+
+func (x *ChatResponse) GetIsLast() bool {
+	if x != nil {
+		return x.IsLast
+	}
+	return false
+}
+
+func (x *ChatResponse) GetPosition() uint32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ChatResponse) GetChatData() string {
+	if x != nil {
+		return x.ChatData
+	}
+	return ""
+}
+
+func (x *ChatResponse) GetToolCalls() []*ToolCall {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetInputTokenCount() int64 {
+	if x != nil && x.InputTokenCount != nil {
+		return *x.InputTokenCount
+	}
+	return 0
+}
+
+func (x *ChatResponse) GetOutputTokenCount() int64 {
+	if x != nil && x.OutputTokenCount != nil {
+		return *x.OutputTokenCount
+	}
+	return 0
+}
+
+func (x *ChatResponse) GetCachedTokenCount() int64 {
+	if x != nil && x.CachedTokenCount != nil {
+		return *x.CachedTokenCount
+	}
+	return 0
+}
+
+func (x *ChatResponse) GetReasoningTokenCount() int64 {
+	if x != nil && x.ReasoningTokenCount != nil {
+		return *x.ReasoningTokenCount
+	}
+	return 0
+}
+
+func (x *ChatResponse) GetInfoMessage() string {
+	if x != nil {
+		return x.InfoMessage
+	}
+	return ""
+}
+
+// HistoricMessage represents a past chat message.
+type HistoricMessage struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Role    string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Content string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// Images in base64 format.
+	Images []string `protobuf:"bytes,3,rep,name=images,proto3" json:"images,omitempty"`
+	// Tool call ID for tool responses.
+	ToolCallId *string `protobuf:"bytes,4,opt,name=tool_call_id,json=toolCallId,proto3,oneof" json:"tool_call_id,omitempty"`
+	// Tool calls made by the assistant.
+	ToolCalls []*ToolCall `protobuf:"bytes,5,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
+	// Ordered multi-modal content; if set, takes precedence over content and images.
+	ContentParts  []*ContentPart `protobuf:"bytes,6,rep,name=content_parts,json=contentParts,proto3" json:"content_parts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoricMessage) Reset() {
+	*x = HistoricMessage{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoricMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoricMessage) ProtoMessage() {}
+
+func (x *HistoricMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoricMessage.ProtoReflect.Descriptor instead.
+func (*HistoricMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HistoricMessage) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *HistoricMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *HistoricMessage) GetImages() []string {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *HistoricMessage) GetToolCallId() string {
+	if x != nil && x.ToolCallId != nil {
+		return *x.ToolCallId
+	}
+	return ""
+}
+
+func (x *HistoricMessage) GetToolCalls() []*ToolCall {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
+}
+
+func (x *HistoricMessage) GetContentParts() []*ContentPart {
+	if x != nil {
+		return x.ContentParts
+	}
+	return nil
+}
+
+// ContentPart represents a single piece of content within a HistoricMessage.
+type ContentPart struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "text", "image", "file", "tool_use", "tool_result"
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// Base64 encoded content for type "image" and "file".
+	Data string `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Reference to the content instead of inline data for type "image" and "file".
+	Uri           string      `protobuf:"bytes,4,opt,name=uri,proto3" json:"uri,omitempty"`
+	MimeType      string      `protobuf:"bytes,5,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	FileName      string      `protobuf:"bytes,6,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ToolUse       *ToolCall   `protobuf:"bytes,7,opt,name=tool_use,json=toolUse,proto3" json:"tool_use,omitempty"`
+	ToolResult    *ToolResult `protobuf:"bytes,8,opt,name=tool_result,json=toolResult,proto3" json:"tool_result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContentPart) Reset() {
+	*x = ContentPart{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContentPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentPart) ProtoMessage() {}
+
+func (x *ContentPart) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentPart.ProtoReflect.Descriptor instead.
+func (*ContentPart) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ContentPart) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ContentPart) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ContentPart) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *ContentPart) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *ContentPart) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *ContentPart) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ContentPart) GetToolUse() *ToolCall {
+	if x != nil {
+		return x.ToolUse
+	}
+	return nil
+}
+
+func (x *ContentPart) GetToolResult() *ToolResult {
+	if x != nil {
+		return x.ToolResult
+	}
+	return nil
+}
+
+// ToolCall represents a tool invocation from the model.
+type ToolCall struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Input         *structpb.Struct       `protobuf:"bytes,4,opt,name=input,proto3" json:"input,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolCall) Reset() {
+	*x = ToolCall{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolCall) ProtoMessage() {}
+
+func (x *ToolCall) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolCall.ProtoReflect.Descriptor instead.
+func (*ToolCall) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ToolCall) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ToolCall) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ToolCall) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolCall) GetInput() *structpb.Struct {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+// ToolResult represents the result of a tool execution.
+type ToolResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matches the ID from the original tool call.
+	ToolCallId string `protobuf:"bytes,1,opt,name=tool_call_id,json=toolCallId,proto3" json:"tool_call_id,omitempty"`
+	// Primary text content.
+	Content string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// Content items for multi-modal support.
+	ContentItems []*structpb.Struct `protobuf:"bytes,3,rep,name=content_items,json=contentItems,proto3" json:"content_items,omitempty"`
+	// True if the tool execution failed.
+	IsError       bool `protobuf:"varint,4,opt,name=is_error,json=isError,proto3" json:"is_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolResult) Reset() {
+	*x = ToolResult{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolResult) ProtoMessage() {}
+
+func (x *ToolResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolResult.ProtoReflect.Descriptor instead.
+func (*ToolResult) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ToolResult) GetToolCallId() string {
+	if x != nil {
+		return x.ToolCallId
+	}
+	return ""
+}
+
+func (x *ToolResult) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ToolResult) GetContentItems() []*structpb.Struct {
+	if x != nil {
+		return x.ContentItems
+	}
+	return nil
+}
+
+func (x *ToolResult) GetIsError() bool {
+	if x != nil {
+		return x.IsError
+	}
+	return false
+}
+
+// ModelOptions represents options for provider-specific API calls.
+type ModelOptions struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	FrequencyPenalty *float32               `protobuf:"fixed32,1,opt,name=frequency_penalty,json=frequencyPenalty,proto3,oneof" json:"frequency_penalty,omitempty"`
+	MaxTokens        *int32                 `protobuf:"varint,2,opt,name=max_tokens,json=maxTokens,proto3,oneof" json:"max_tokens,omitempty"`
+	PresencePenalty  *float32               `protobuf:"fixed32,3,opt,name=presence_penalty,json=presencePenalty,proto3,oneof" json:"presence_penalty,omitempty"`
+	Stop             []string               `protobuf:"bytes,4,rep,name=stop,proto3" json:"stop,omitempty"`
+	Temperature      *float32               `protobuf:"fixed32,5,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	TopP             *float32               `protobuf:"fixed32,6,opt,name=top_p,json=topP,proto3,oneof" json:"top_p,omitempty"`
+	// Reasoning
+	ReasoningEffort  *string `protobuf:"bytes,7,opt,name=reasoning_effort,json=reasoningEffort,proto3,oneof" json:"reasoning_effort,omitempty"`
+	ReasoningSummary *string `protobuf:"bytes,8,opt,name=reasoning_summary,json=reasoningSummary,proto3,oneof" json:"reasoning_summary,omitempty"`
+	Verbosity        *string `protobuf:"bytes,9,opt,name=verbosity,proto3,oneof" json:"verbosity,omitempty"`
+	// Extended thinking
+	ThinkingMode         *string `protobuf:"bytes,10,opt,name=thinking_mode,json=thinkingMode,proto3,oneof" json:"thinking_mode,omitempty"`
+	ThinkingBudgetTokens *int64  `protobuf:"varint,11,opt,name=thinking_budget_tokens,json=thinkingBudgetTokens,proto3,oneof" json:"thinking_budget_tokens,omitempty"`
+	ThinkingDisplayMode  *string `protobuf:"bytes,12,opt,name=thinking_display_mode,json=thinkingDisplayMode,proto3,oneof" json:"thinking_display_mode,omitempty"`
+	// Deterministic sampling and structured output
+	Seed           *int64           `protobuf:"varint,13,opt,name=seed,proto3,oneof" json:"seed,omitempty"`
+	ResponseFormat *string          `protobuf:"bytes,14,opt,name=response_format,json=responseFormat,proto3,oneof" json:"response_format,omitempty"`
+	ResponseSchema *structpb.Struct `protobuf:"bytes,15,opt,name=response_schema,json=responseSchema,proto3" json:"response_schema,omitempty"`
+	// Log probabilities of the output tokens
+	Logprobs    *bool  `protobuf:"varint,16,opt,name=logprobs,proto3,oneof" json:"logprobs,omitempty"`
+	TopLogprobs *int32 `protobuf:"varint,17,opt,name=top_logprobs,json=topLogprobs,proto3,oneof" json:"top_logprobs,omitempty"`
+	// Tool calling
+	ParallelToolCalls *bool `protobuf:"varint,18,opt,name=parallel_tool_calls,json=parallelToolCalls,proto3,oneof" json:"parallel_tool_calls,omitempty"`
+	// Provider-specific parameters; passed through as-is.
+	ProviderOptions *structpb.Struct `protobuf:"bytes,19,opt,name=provider_options,json=providerOptions,proto3" json:"provider_options,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ModelOptions) Reset() {
+	*x = ModelOptions{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelOptions) ProtoMessage() {}
+
+func (x *ModelOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelOptions.ProtoReflect.Descriptor instead.
+func (*ModelOptions) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ModelOptions) GetFrequencyPenalty() float32 {
+	if x != nil && x.FrequencyPenalty != nil {
+		return *x.FrequencyPenalty
+	}
+	return 0
+}
+
+func (x *ModelOptions) GetMaxTokens() int32 {
+	if x != nil && x.MaxTokens != nil {
+		return *x.MaxTokens
+	}
+	return 0
+}
+
+func (x *ModelOptions) GetPresencePenalty() float32 {
+	if x != nil && x.PresencePenalty != nil {
+		return *x.PresencePenalty
+	}
+	return 0
+}
+
+func (x *ModelOptions) GetStop() []string {
+	if x != nil {
+		return x.Stop
+	}
+	return nil
+}
+
+func (x *ModelOptions) GetTemperature() float32 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *ModelOptions) GetTopP() float32 {
+	if x != nil && x.TopP != nil {
+		return *x.TopP
+	}
+	return 0
+}
+
+func (x *ModelOptions) GetReasoningEffort() string {
+	if x != nil && x.ReasoningEffort != nil {
+		return *x.ReasoningEffort
+	}
+	return ""
+}
+
+func (x *ModelOptions) GetReasoningSummary() string {
+	if x != nil && x.ReasoningSummary != nil {
+		return *x.ReasoningSummary
+	}
+	return ""
+}
+
+func (x *ModelOptions) GetVerbosity() string {
+	if x != nil && x.Verbosity != nil {
+		return *x.Verbosity
+	}
+	return ""
+}
+
+func (x *ModelOptions) GetThinkingMode() string {
+	if x != nil && x.ThinkingMode != nil {
+		return *x.ThinkingMode
+	}
+	return ""
+}
+
+func (x *ModelOptions) GetThinkingBudgetTokens() int64 {
+	if x != nil && x.ThinkingBudgetTokens != nil {
+		return *x.ThinkingBudgetTokens
+	}
+	return 0
+}
+
+func (x *ModelOptions) GetThinkingDisplayMode() string {
+	if x != nil && x.ThinkingDisplayMode != nil {
+		return *x.ThinkingDisplayMode
+	}
+	return ""
+}
+
+func (x *ModelOptions) GetSeed() int64 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+func (x *ModelOptions) GetResponseFormat() string {
+	if x != nil && x.ResponseFormat != nil {
+		return *x.ResponseFormat
+	}
+	return ""
+}
+
+func (x *ModelOptions) GetResponseSchema() *structpb.Struct {
+	if x != nil {
+		return x.ResponseSchema
+	}
+	return nil
+}
+
+func (x *ModelOptions) GetLogprobs() bool {
+	if x != nil && x.Logprobs != nil {
+		return *x.Logprobs
+	}
+	return false
+}
+
+func (x *ModelOptions) GetTopLogprobs() int32 {
+	if x != nil && x.TopLogprobs != nil {
+		return *x.TopLogprobs
+	}
+	return 0
+}
+
+func (x *ModelOptions) GetParallelToolCalls() bool {
+	if x != nil && x.ParallelToolCalls != nil {
+		return *x.ParallelToolCalls
+	}
+	return false
+}
+
+func (x *ModelOptions) GetProviderOptions() *structpb.Struct {
+	if x != nil {
+		return x.ProviderOptions
+	}
+	return nil
+}
+
+// EmbeddingsRequest is the input message for the Embeddings method.
+type EmbeddingsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	InstructionGuid string                 `protobuf:"bytes,1,opt,name=instruction_guid,json=instructionGuid,proto3" json:"instruction_guid,omitempty"`
+	// Optional model ids to define a set of specific models to be used for this request.
+	ModelIds []string `protobuf:"bytes,2,rep,name=model_ids,json=modelIds,proto3" json:"model_ids,omitempty"`
+	// Optional model categories to filter models.
+	ModelCategory []string `protobuf:"bytes,3,rep,name=model_category,json=modelCategory,proto3" json:"model_category,omitempty"`
+	// Texts to embed.
+	Texts            []string          `protobuf:"bytes,4,rep,name=texts,proto3" json:"texts,omitempty"`
+	EmbeddingOptions *EmbeddingOptions `protobuf:"bytes,5,opt,name=embedding_options,json=embeddingOptions,proto3" json:"embedding_options,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *EmbeddingsRequest) Reset() {
+	*x = EmbeddingsRequest{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddingsRequest) ProtoMessage() {}
+
+func (x *EmbeddingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddingsRequest.ProtoReflect.Descriptor instead.
+func (*EmbeddingsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EmbeddingsRequest) GetInstructionGuid() string {
+	if x != nil {
+		return x.InstructionGuid
+	}
+	return ""
+}
+
+func (x *EmbeddingsRequest) GetModelIds() []string {
+	if x != nil {
+		return x.ModelIds
+	}
+	return nil
+}
+
+func (x *EmbeddingsRequest) GetModelCategory() []string {
+	if x != nil {
+		return x.ModelCategory
+	}
+	return nil
+}
+
+func (x *EmbeddingsRequest) GetTexts() []string {
+	if x != nil {
+		return x.Texts
+	}
+	return nil
+}
+
+func (x *EmbeddingsRequest) GetEmbeddingOptions() *EmbeddingOptions {
+	if x != nil {
+		return x.EmbeddingOptions
+	}
+	return nil
+}
+
+// EmbeddingOptions represents the options for an embeddings request.
+type EmbeddingOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Include dense vectors in the response.
+	ReturnDense *bool `protobuf:"varint,1,opt,name=return_dense,json=returnDense,proto3,oneof" json:"return_dense,omitempty"`
+	// Include lexical weights in the response.
+	ReturnSparse *bool `protobuf:"varint,2,opt,name=return_sparse,json=returnSparse,proto3,oneof" json:"return_sparse,omitempty"`
+	// Include colbert vectors in the response.
+	ReturnColbert *bool `protobuf:"varint,3,opt,name=return_colbert,json=returnColbert,proto3,oneof" json:"return_colbert,omitempty"`
+	// Output dimensionality; only for models supporting it.
+	Dimensions *int32 `protobuf:"varint,4,opt,name=dimensions,proto3,oneof" json:"dimensions,omitempty"`
+	// L2 normalize the dense vectors.
+	Normalize     *bool `protobuf:"varint,5,opt,name=normalize,proto3,oneof" json:"normalize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbeddingOptions) Reset() {
+	*x = EmbeddingOptions{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddingOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddingOptions) ProtoMessage() {}
+
+func (x *EmbeddingOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddingOptions.ProtoReflect.Descriptor instead.
+func (*EmbeddingOptions) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EmbeddingOptions) GetReturnDense() bool {
+	if x != nil && x.ReturnDense != nil {
+		return *x.ReturnDense
+	}
+	return false
+}
+
+func (x *EmbeddingOptions) GetReturnSparse() bool {
+	if x != nil && x.ReturnSparse != nil {
+		return *x.ReturnSparse
+	}
+	return false
+}
+
+func (x *EmbeddingOptions) GetReturnColbert() bool {
+	if x != nil && x.ReturnColbert != nil {
+		return *x.ReturnColbert
+	}
+	return false
+}
+
+func (x *EmbeddingOptions) GetDimensions() int32 {
+	if x != nil && x.Dimensions != nil {
+		return *x.Dimensions
+	}
+	return 0
+}
+
+func (x *EmbeddingOptions) GetNormalize() bool {
+	if x != nil && x.Normalize != nil {
+		return *x.Normalize
+	}
+	return false
+}
+
+// EmbeddingsResponse is the output message for the Embeddings method.
+// Each list has one entry per input text if the corresponding output was requested.
+type EmbeddingsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	InstructionGuid string                 `protobuf:"bytes,1,opt,name=instruction_guid,json=instructionGuid,proto3" json:"instruction_guid,omitempty"`
+	Dense           []*DenseVector         `protobuf:"bytes,2,rep,name=dense,proto3" json:"dense,omitempty"`
+	Sparse          []*SparseVector        `protobuf:"bytes,3,rep,name=sparse,proto3" json:"sparse,omitempty"`
+	Colbert         []*ColbertVectors      `protobuf:"bytes,4,rep,name=colbert,proto3" json:"colbert,omitempty"`
+	// Model that computed the embeddings.
+	ModelId       string `protobuf:"bytes,5,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbeddingsResponse) Reset() {
+	*x = EmbeddingsResponse{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddingsResponse) ProtoMessage() {}
+
+func (x *EmbeddingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddingsResponse.ProtoReflect.Descriptor instead.
+func (*EmbeddingsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EmbeddingsResponse) GetInstructionGuid() string {
+	if x != nil {
+		return x.InstructionGuid
+	}
+	return ""
+}
+
+func (x *EmbeddingsResponse) GetDense() []*DenseVector {
+	if x != nil {
+		return x.Dense
+	}
+	return nil
+}
+
+func (x *EmbeddingsResponse) GetSparse() []*SparseVector {
+	if x != nil {
+		return x.Sparse
+	}
+	return nil
+}
+
+func (x *EmbeddingsResponse) GetColbert() []*ColbertVectors {
+	if x != nil {
+		return x.Colbert
+	}
+	return nil
+}
+
+func (x *EmbeddingsResponse) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+// DenseVector is a dense embedding vector.
+type DenseVector struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float32              `protobuf:"fixed32,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenseVector) Reset() {
+	*x = DenseVector{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenseVector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenseVector) ProtoMessage() {}
+
+func (x *DenseVector) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenseVector.ProtoReflect.Descriptor instead.
+func (*DenseVector) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DenseVector) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// SparseVector contains the lexical weights by token id.
+type SparseVector struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Weights       map[uint32]float32     `protobuf:"bytes,1,rep,name=weights,proto3" json:"weights,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"fixed32,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SparseVector) Reset() {
+	*x = SparseVector{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SparseVector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SparseVector) ProtoMessage() {}
+
+func (x *SparseVector) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SparseVector.ProtoReflect.Descriptor instead.
+func (*SparseVector) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SparseVector) GetWeights() map[uint32]float32 {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+// ColbertVectors contains the multi-vector representation of a text.
+type ColbertVectors struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vectors       []*DenseVector         `protobuf:"bytes,1,rep,name=vectors,proto3" json:"vectors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColbertVectors) Reset() {
+	*x = ColbertVectors{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColbertVectors) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColbertVectors) ProtoMessage() {}
+
+func (x *ColbertVectors) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColbertVectors.ProtoReflect.Descriptor instead.
+func (*ColbertVectors) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ColbertVectors) GetVectors() []*DenseVector {
+	if x != nil {
+		return x.Vectors
+	}
+	return nil
+}
+
+// ListModelsRequest is the input message for the ListModels method.
+type ListModelsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional model category to filter the models.
+	ModelCategory string `protobuf:"bytes,1,opt,name=model_category,json=modelCategory,proto3" json:"model_category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsRequest) Reset() {
+	*x = ListModelsRequest{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsRequest) ProtoMessage() {}
+
+func (x *ListModelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsRequest.ProtoReflect.Descriptor instead.
+func (*ListModelsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListModelsRequest) GetModelCategory() string {
+	if x != nil {
+		return x.ModelCategory
+	}
+	return ""
+}
+
+// ListModelsResponse is the output message for the ListModels method.
+type ListModelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Models        []*ModelInfo           `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsResponse) Reset() {
+	*x = ListModelsResponse{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsResponse) ProtoMessage() {}
+
+func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsResponse.ProtoReflect.Descriptor instead.
+func (*ListModelsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListModelsResponse) GetModels() []*ModelInfo {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+// ModelInfo describes a model available in the LLM handler.
+type ModelInfo struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ModelId string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	// Name of the model at the provider.
+	ModelName string `protobuf:"bytes,2,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	// Provider of the model, e.g. "azure-openai".
+	Provider string `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	// Categories of the model.
+	Categories []string `protobuf:"bytes,4,rep,name=categories,proto3" json:"categories,omitempty"`
+	// Adapters supported by the model: "chat", "embeddings".
+	Adapters      []string `protobuf:"bytes,5,rep,name=adapters,proto3" json:"adapters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelInfo) Reset() {
+	*x = ModelInfo{}
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelInfo) ProtoMessage() {}
+
+func (x *ModelInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelInfo.ProtoReflect.Descriptor instead.
+func (*ModelInfo) Descriptor() ([]byte, []int) {
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ModelInfo) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetAdapters() []string {
+	if x != nil {
+		return x.Adapters
+	}
+	return nil
+}
+
+var File_pkg_aalillmgrpc_aali_llm_proto protoreflect.FileDescriptor
+
+const file_pkg_aalillmgrpc_aali_llm_proto_rawDesc = "" +
+	"\n" +
+	"\x1epkg/aalillmgrpc/aali-llm.proto\x12\vaalillmgrpc\x1a\x1cgoogle/protobuf/struct.proto\"\x0f\n" +
+	"\rHealthRequest\"m\n" +
+	"\x0eHealthResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12)\n" +
+	"\x10protocol_version\x18\x03 \x01(\tR\x0fprotocolVersion\"\xa1\x05\n" +
+	"\vChatRequest\x12)\n" +
+	"\x10instruction_guid\x18\x01 \x01(\tR\x0finstructionGuid\x12\x1b\n" +
+	"\tmodel_ids\x18\x02 \x03(\tR\bmodelIds\x12%\n" +
+	"\x0emodel_category\x18\x03 \x03(\tR\rmodelCategory\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\tR\x04data\x12\x16\n" +
+	"\x06images\x18\x05 \x03(\tR\x06images\x124\n" +
+	"\tmcp_tools\x18\x06 \x03(\v2\x17.google.protobuf.StructR\bmcpTools\x12*\n" +
+	"\x11chat_request_type\x18\a \x01(\tR\x0fchatRequestType\x12\x1f\n" +
+	"\vdata_stream\x18\b \x01(\bR\n" +
+	"dataStream\x123\n" +
+	"\x16max_number_of_keywords\x18\t \x01(\rR\x13maxNumberOfKeywords\x12'\n" +
+	"\x0fis_conversation\x18\n" +
+	" \x01(\bR\x0eisConversation\x12O\n" +
+	"\x14conversation_history\x18\v \x03(\v2\x1c.aalillmgrpc.HistoricMessageR\x13conversationHistory\x12'\n" +
+	"\x0fgeneral_context\x18\f \x01(\tR\x0egeneralContext\x12\x1f\n" +
+	"\vmsg_context\x18\r \x01(\tR\n" +
+	"msgContext\x12;\n" +
+	"\rsystem_prompt\x18\x0e \x01(\v2\x16.google.protobuf.ValueR\fsystemPrompt\x12>\n" +
+	"\rmodel_options\x18\x0f \x01(\v2\x19.aalillmgrpc.ModelOptionsR\fmodelOptions\"\x92\x04\n" +
+	"\fChatResponse\x12)\n" +
+	"\x10instruction_guid\x18\x01 \x01(\tR\x0finstructionGuid\x12\x17\n" +
+	"\ais_last\x18\x02 \x01(\bR\x06isLast\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\rR\bposition\x12\x1b\n" +
+	"\tchat_data\x18\x04 \x01(\tR\bchatData\x124\n" +
+	"\n" +
+	"tool_calls\x18\x05 \x03(\v2\x15.aalillmgrpc.ToolCallR\ttoolCalls\x12/\n" +
+	"\x11input_token_count\x18\x06 \x01(\x03H\x00R\x0finputTokenCount\x88\x01\x01\x121\n" +
+	"\x12output_token_count\x18\a \x01(\x03H\x01R\x10outputTokenCount\x88\x01\x01\x121\n" +
+	"\x12cached_token_count\x18\b \x01(\x03H\x02R\x10cachedTokenCount\x88\x01\x01\x127\n" +
+	"\x15reasoning_token_count\x18\t \x01(\x03H\x03R\x13reasoningTokenCount\x88\x01\x01\x12!\n" +
+	"\finfo_message\x18\n" +
+	" \x01(\tR\vinfoMessageB\x14\n" +
+	"\x12_input_token_countB\x15\n" +
+	"\x13_output_token_countB\x15\n" +
+	"\x13_cached_token_countB\x18\n" +
+	"\x16_reasoning_token_count\"\x84\x02\n" +
+	"\x0fHistoricMessage\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x16\n" +
+	"\x06images\x18\x03 \x03(\tR\x06images\x12%\n" +
+	"\ftool_call_id\x18\x04 \x01(\tH\x00R\n" +
+	"toolCallId\x88\x01\x01\x124\n" +
+	"\n" +
+	"tool_calls\x18\x05 \x03(\v2\x15.aalillmgrpc.ToolCallR\ttoolCalls\x12=\n" +
+	"\rcontent_parts\x18\x06 \x03(\v2\x18.aalillmgrpc.ContentPartR\fcontentPartsB\x0f\n" +
+	"\r_tool_call_id\"\x81\x02\n" +
+	"\vContentPart\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\x12\x10\n" +
+	"\x03uri\x18\x04 \x01(\tR\x03uri\x12\x1b\n" +
+	"\tmime_type\x18\x05 \x01(\tR\bmimeType\x12\x1b\n" +
+	"\tfile_name\x18\x06 \x01(\tR\bfileName\x120\n" +
+	"\btool_use\x18\a \x01(\v2\x15.aalillmgrpc.ToolCallR\atoolUse\x128\n" +
+	"\vtool_result\x18\b \x01(\v2\x17.aalillmgrpc.ToolResultR\n" +
+	"toolResult\"q\n" +
+	"\bToolCall\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12-\n" +
+	"\x05input\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x05input\"\xa1\x01\n" +
+	"\n" +
+	"ToolResult\x12 \n" +
+	"\ftool_call_id\x18\x01 \x01(\tR\n" +
+	"toolCallId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12<\n" +
+	"\rcontent_items\x18\x03 \x03(\v2\x17.google.protobuf.StructR\fcontentItems\x12\x19\n" +
+	"\bis_error\x18\x04 \x01(\bR\aisError\"\xfe\b\n" +
+	"\fModelOptions\x120\n" +
+	"\x11frequency_penalty\x18\x01 \x01(\x02H\x00R\x10frequencyPenalty\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_tokens\x18\x02 \x01(\x05H\x01R\tmaxTokens\x88\x01\x01\x12.\n" +
+	"\x10presence_penalty\x18\x03 \x01(\x02H\x02R\x0fpresencePenalty\x88\x01\x01\x12\x12\n" +
+	"\x04stop\x18\x04 \x03(\tR\x04stop\x12%\n" +
+	"\vtemperature\x18\x05 \x01(\x02H\x03R\vtemperature\x88\x01\x01\x12\x18\n" +
+	"\x05top_p\x18\x06 \x01(\x02H\x04R\x04topP\x88\x01\x01\x12.\n" +
+	"\x10reasoning_effort\x18\a \x01(\tH\x05R\x0freasoningEffort\x88\x01\x01\x120\n" +
+	"\x11reasoning_summary\x18\b \x01(\tH\x06R\x10reasoningSummary\x88\x01\x01\x12!\n" +
+	"\tverbosity\x18\t \x01(\tH\aR\tverbosity\x88\x01\x01\x12(\n" +
+	"\rthinking_mode\x18\n" +
+	" \x01(\tH\bR\fthinkingMode\x88\x01\x01\x129\n" +
+	"\x16thinking_budget_tokens\x18\v \x01(\x03H\tR\x14thinkingBudgetTokens\x88\x01\x01\x127\n" +
+	"\x15thinking_display_mode\x18\f \x01(\tH\n" +
+	"R\x13thinkingDisplayMode\x88\x01\x01\x12\x17\n" +
+	"\x04seed\x18\r \x01(\x03H\vR\x04seed\x88\x01\x01\x12,\n" +
+	"\x0fresponse_format\x18\x0e \x01(\tH\fR\x0eresponseFormat\x88\x01\x01\x12@\n" +
+	"\x0fresponse_schema\x18\x0f \x01(\v2\x17.google.protobuf.StructR\x0eresponseSchema\x12\x1f\n" +
+	"\blogprobs\x18\x10 \x01(\bH\rR\blogprobs\x88\x01\x01\x12&\n" +
+	"\ftop_logprobs\x18\x11 \x01(\x05H\x0eR\vtopLogprobs\x88\x01\x01\x123\n" +
+	"\x13parallel_tool_calls\x18\x12 \x01(\bH\x0fR\x11parallelToolCalls\x88\x01\x01\x12B\n" +
+	"\x10provider_options\x18\x13 \x01(\v2\x17.google.protobuf.StructR\x0fproviderOptionsB\x14\n" +
+	"\x12_frequency_penaltyB\r\n" +
+	"\v_max_tokensB\x13\n" +
+	"\x11_presence_penaltyB\x0e\n" +
+	"\f_temperatureB\b\n" +
+	"\x06_top_pB\x13\n" +
+	"\x11_reasoning_effortB\x14\n" +
+	"\x12_reasoning_summaryB\f\n" +
+	"\n" +
+	"_verbosityB\x10\n" +
+	"\x0e_thinking_modeB\x19\n" +
+	"\x17_thinking_budget_tokensB\x18\n" +
+	"\x16_thinking_display_modeB\a\n" +
+	"\x05_seedB\x12\n" +
+	"\x10_response_formatB\v\n" +
+	"\t_logprobsB\x0f\n" +
+	"\r_top_logprobsB\x16\n" +
+	"\x14_parallel_tool_calls\"\xe4\x01\n" +
+	"\x11EmbeddingsRequest\x12)\n" +
+	"\x10instruction_guid\x18\x01 \x01(\tR\x0finstructionGuid\x12\x1b\n" +
+	"\tmodel_ids\x18\x02 \x03(\tR\bmodelIds\x12%\n" +
+	"\x0emodel_category\x18\x03 \x03(\tR\rmodelCategory\x12\x14\n" +
+	"\x05texts\x18\x04 \x03(\tR\x05texts\x12J\n" +
+	"\x11embedding_options\x18\x05 \x01(\v2\x1d.aalillmgrpc.EmbeddingOptionsR\x10embeddingOptions\"\xab\x02\n" +
+	"\x10EmbeddingOptions\x12&\n" +
+	"\freturn_dense\x18\x01 \x01(\bH\x00R\vreturnDense\x88\x01\x01\x12(\n" +
+	"\rreturn_sparse\x18\x02 \x01(\bH\x01R\freturnSparse\x88\x01\x01\x12*\n" +
+	"\x0ereturn_colbert\x18\x03 \x01(\bH\x02R\rreturnColbert\x88\x01\x01\x12#\n" +
+	"\n" +
+	"dimensions\x18\x04 \x01(\x05H\x03R\n" +
+	"dimensions\x88\x01\x01\x12!\n" +
+	"\tnormalize\x18\x05 \x01(\bH\x04R\tnormalize\x88\x01\x01B\x0f\n" +
+	"\r_return_denseB\x10\n" +
+	"\x0e_return_sparseB\x11\n" +
+	"\x0f_return_colbertB\r\n" +
+	"\v_dimensionsB\f\n" +
+	"\n" +
+	"_normalize\"\xf4\x01\n" +
+	"\x12EmbeddingsResponse\x12)\n" +
+	"\x10instruction_guid\x18\x01 \x01(\tR\x0finstructionGuid\x12.\n" +
+	"\x05dense\x18\x02 \x03(\v2\x18.aalillmgrpc.DenseVectorR\x05dense\x121\n" +
+	"\x06sparse\x18\x03 \x03(\v2\x19.aalillmgrpc.SparseVectorR\x06sparse\x125\n" +
+	"\acolbert\x18\x04 \x03(\v2\x1b.aalillmgrpc.ColbertVectorsR\acolbert\x12\x19\n" +
+	"\bmodel_id\x18\x05 \x01(\tR\amodelId\"%\n" +
+	"\vDenseVector\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x02R\x06values\"\x8c\x01\n" +
+	"\fSparseVector\x12@\n" +
+	"\aweights\x18\x01 \x03(\v2&.aalillmgrpc.SparseVector.WeightsEntryR\aweights\x1a:\n" +
+	"\fWeightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\rR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x02R\x05value:\x028\x01\"D\n" +
+	"\x0eColbertVectors\x122\n" +
+	"\avectors\x18\x01 \x03(\v2\x18.aalillmgrpc.DenseVectorR\avectors\":\n" +
+	"\x11ListModelsRequest\x12%\n" +
+	"\x0emodel_category\x18\x01 \x01(\tR\rmodelCategory\"D\n" +
+	"\x12ListModelsResponse\x12.\n" +
+	"\x06models\x18\x01 \x03(\v2\x16.aalillmgrpc.ModelInfoR\x06models\"\x9d\x01\n" +
+	"\tModelInfo\x12\x19\n" +
+	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x02 \x01(\tR\tmodelName\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x04 \x03(\tR\n" +
+	"categories\x12\x1a\n" +
+	"\badapters\x18\x05 \x03(\tR\badapters2\xb9\x02\n" +
+	"\n" +
+	"LlmHandler\x12H\n" +
+	"\vHealthCheck\x12\x1a.aalillmgrpc.HealthRequest\x1a\x1b.aalillmgrpc.HealthResponse\"\x00\x12?\n" +
+	"\x04Chat\x12\x18.aalillmgrpc.ChatRequest\x1a\x19.aalillmgrpc.ChatResponse\"\x000\x01\x12O\n" +
+	"\n" +
+	"Embeddings\x12\x1e.aalillmgrpc.EmbeddingsRequest\x1a\x1f.aalillmgrpc.EmbeddingsResponse\"\x00\x12O\n" +
+	"\n" +
+	"ListModels\x12\x1e.aalillmgrpc.ListModelsRequest\x1a\x1f.aalillmgrpc.ListModelsResponse\"\x00B\x0fZ\r./aalillmgrpcb\x06proto3"
+
+var (
+	file_pkg_aalillmgrpc_aali_llm_proto_rawDescOnce sync.Once
+	file_pkg_aalillmgrpc_aali_llm_proto_rawDescData []byte
+)
+
+func file_pkg_aalillmgrpc_aali_llm_proto_rawDescGZIP() []byte {
+	file_pkg_aalillmgrpc_aali_llm_proto_rawDescOnce.Do(func() {
+		file_pkg_aalillmgrpc_aali_llm_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_aalillmgrpc_aali_llm_proto_rawDesc), len(file_pkg_aalillmgrpc_aali_llm_proto_rawDesc)))
+	})
+	return file_pkg_aalillmgrpc_aali_llm_proto_rawDescData
+}
+
+var file_pkg_aalillmgrpc_aali_llm_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_pkg_aalillmgrpc_aali_llm_proto_goTypes = []any{
+	(*HealthRequest)(nil),      // 0: aalillmgrpc.HealthRequest
+	(*HealthResponse)(nil),     // 1: aalillmgrpc.HealthResponse
+	(*ChatRequest)(nil),        // 2: aalillmgrpc.ChatRequest
+	(*ChatResponse)(nil),       // 3: aalillmgrpc.ChatResponse
+	(*HistoricMessage)(nil),    // 4: aalillmgrpc.HistoricMessage
+	(*ContentPart)(nil),        // 5: aalillmgrpc.ContentPart
+	(*ToolCall)(nil),           // 6: aalillmgrpc.ToolCall
+	(*ToolResult)(nil),         // 7: aalillmgrpc.ToolResult
+	(*ModelOptions)(nil),       // 8: aalillmgrpc.ModelOptions
+	(*EmbeddingsRequest)(nil),  // 9: aalillmgrpc.EmbeddingsRequest
+	(*EmbeddingOptions)(nil),   // 10: aalillmgrpc.EmbeddingOptions
+	(*EmbeddingsResponse)(nil), // 11: aalillmgrpc.EmbeddingsResponse
+	(*DenseVector)(nil),        // 12: aalillmgrpc.DenseVector
+	(*SparseVector)(nil),       // 13: aalillmgrpc.SparseVector
+	(*ColbertVectors)(nil),     // 14: aalillmgrpc.ColbertVectors
+	(*ListModelsRequest)(nil),  // 15: aalillmgrpc.ListModelsRequest
+	(*ListModelsResponse)(nil), // 16: aalillmgrpc.ListModelsResponse
+	(*ModelInfo)(nil),          // 17: aalillmgrpc.ModelInfo
+	nil,                        // 18: aalillmgrpc.SparseVector.WeightsEntry
+	(*structpb.Struct)(nil),    // 19: google.protobuf.Struct
+	(*structpb.Value)(nil),     // 20: google.protobuf.Value
+}
+var file_pkg_aalillmgrpc_aali_llm_proto_depIdxs = []int32{
+	19, // 0: aalillmgrpc.ChatRequest.mcp_tools:type_name -> google.protobuf.Struct
+	4,  // 1: aalillmgrpc.ChatRequest.conversation_history:type_name -> aalillmgrpc.HistoricMessage
+	20, // 2: aalillmgrpc.ChatRequest.system_prompt:type_name -> google.protobuf.Value
+	8,  // 3: aalillmgrpc.ChatRequest.model_options:type_name -> aalillmgrpc.ModelOptions
+	6,  // 4: aalillmgrpc.ChatResponse.tool_calls:type_name -> aalillmgrpc.ToolCall
+	6,  // 5: aalillmgrpc.HistoricMessage.tool_calls:type_name -> aalillmgrpc.ToolCall
+	5,  // 6: aalillmgrpc.HistoricMessage.content_parts:type_name -> aalillmgrpc.ContentPart
+	6,  // 7: aalillmgrpc.ContentPart.tool_use:type_name -> aalillmgrpc.ToolCall
+	7,  // 8: aalillmgrpc.ContentPart.tool_result:type_name -> aalillmgrpc.ToolResult
+	19, // 9: aalillmgrpc.ToolCall.input:type_name -> google.protobuf.Struct
+	19, // 10: aalillmgrpc.ToolResult.content_items:type_name -> google.protobuf.Struct
+	19, // 11: aalillmgrpc.ModelOptions.response_schema:type_name -> google.protobuf.Struct
+	19, // 12: aalillmgrpc.ModelOptions.provider_options:type_name -> google.protobuf.Struct
+	10, // 13: aalillmgrpc.EmbeddingsRequest.embedding_options:type_name -> aalillmgrpc.EmbeddingOptions
+	12, // 14: aalillmgrpc.EmbeddingsResponse.dense:type_name -> aalillmgrpc.DenseVector
+	13, // 15: aalillmgrpc.EmbeddingsResponse.sparse:type_name -> aalillmgrpc.SparseVector
+	14, // 16: aalillmgrpc.EmbeddingsResponse.colbert:type_name -> aalillmgrpc.ColbertVectors
+	18, // 17: aalillmgrpc.SparseVector.weights:type_name -> aalillmgrpc.SparseVector.WeightsEntry
+	12, // 18: aalillmgrpc.ColbertVectors.vectors:type_name -> aalillmgrpc.DenseVector
+	17, // 19: aalillmgrpc.ListModelsResponse.models:type_name -> aalillmgrpc.ModelInfo
+	0,  // 20: aalillmgrpc.LlmHandler.HealthCheck:input_type -> aalillmgrpc.HealthRequest
+	2,  // 21: aalillmgrpc.LlmHandler.Chat:input_type -> aalillmgrpc.ChatRequest
+	9,  // 22: aalillmgrpc.LlmHandler.Embeddings:input_type -> aalillmgrpc.EmbeddingsRequest
+	15, // 23: aalillmgrpc.LlmHandler.ListModels:input_type -> aalillmgrpc.ListModelsRequest
+	1,  // 24: aalillmgrpc.LlmHandler.HealthCheck:output_type -> aalillmgrpc.HealthResponse
+	3,  // 25: aalillmgrpc.LlmHandler.Chat:output_type -> aalillmgrpc.ChatResponse
+	11, // 26: aalillmgrpc.LlmHandler.Embeddings:output_type -> aalillmgrpc.EmbeddingsResponse
+	16, // 27: aalillmgrpc.LlmHandler.ListModels:output_type -> aalillmgrpc.ListModelsResponse
+	24, // [24:28] is the sub-list for method output_type
+	20, // [20:24] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_pkg_aalillmgrpc_aali_llm_proto_init() }
+func file_pkg_aalillmgrpc_aali_llm_proto_init() {
+	if File_pkg_aalillmgrpc_aali_llm_proto != nil {
+		return
+	}
+	file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[3].OneofWrappers = []any{}
+	file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[4].OneofWrappers = []any{}
+	file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[8].OneofWrappers = []any{}
+	file_pkg_aalillmgrpc_aali_llm_proto_msgTypes[10].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_aalillmgrpc_aali_llm_proto_rawDesc), len(file_pkg_aalillmgrpc_aali_llm_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_aalillmgrpc_aali_llm_proto_goTypes,
+		DependencyIndexes: file_pkg_aalillmgrpc_aali_llm_proto_depIdxs,
+		MessageInfos:      file_pkg_aalillmgrpc_aali_llm_proto_msgTypes,
+	}.Build()
+	File_pkg_aalillmgrpc_aali_llm_proto = out.File
+	file_pkg_aalillmgrpc_aali_llm_proto_goTypes = nil
+	file_pkg_aalillmgrpc_aali_llm_proto_depIdxs = nil
+}
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v4.25.9
+// source: pkg/aalillmgrpc/aali-llm.proto
+
+package aalillmgrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LlmHandler_HealthCheck_FullMethodName = "/aalillmgrpc.LlmHandler/HealthCheck"
+	LlmHandler_Chat_FullMethodName        = "/aalillmgrpc.LlmHandler/Chat"
+	LlmHandler_Embeddings_FullMethodName  = "/aalillmgrpc.LlmHandler/Embeddings"
+	LlmHandler_ListModels_FullMethodName  = "/aalillmgrpc.LlmHandler/ListModels"
+)
+
+// LlmHandlerClient is the client API for LlmHandler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LlmHandler is a gRPC service that provides the chat and embeddings operations of the LLM handler.
+// It mirrors the HandlerRequest and HandlerResponse of the websocket protocol; errors are returned
+// as gRPC status instead of responses of type "error".
+type LlmHandlerClient interface {
+	// HealthCheck is a simple health check method that returns an empty response.
+	HealthCheck(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	// Runs a chat request and streams the answer of the model.
+	// Non-streaming requests receive a single message with is_last set.
+	Chat(ctx context.Context, in *ChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatResponse], error)
+	// Computes the embeddings of the given texts.
+	Embeddings(ctx context.Context, in *EmbeddingsRequest, opts ...grpc.CallOption) (*EmbeddingsResponse, error)
+	// Lists the models available in the LLM handler.
+	ListModels(ctx context.Context, in *ListModelsRequest, opts ...grpc.CallOption) (*ListModelsResponse, error)
+}
+
+type llmHandlerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLlmHandlerClient(cc grpc.ClientConnInterface) LlmHandlerClient {
+	return &llmHandlerClient{cc}
+}
+
+func (c *llmHandlerClient) HealthCheck(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, LlmHandler_HealthCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *llmHandlerClient) Chat(ctx context.Context, in *ChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LlmHandler_ServiceDesc.Streams[0], LlmHandler_Chat_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ChatRequest, ChatResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LlmHandler_ChatClient = grpc.ServerStreamingClient[ChatResponse]
+
+func (c *llmHandlerClient) Embeddings(ctx context.Context, in *EmbeddingsRequest, opts ...grpc.CallOption) (*EmbeddingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmbeddingsResponse)
+	err := c.cc.Invoke(ctx, LlmHandler_Embeddings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *llmHandlerClient) ListModels(ctx context.Context, in *ListModelsRequest, opts ...grpc.CallOption) (*ListModelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListModelsResponse)
+	err := c.cc.Invoke(ctx, LlmHandler_ListModels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LlmHandlerServer is the server API for LlmHandler service.
+// All implementations must embed UnimplementedLlmHandlerServer
+// for forward compatibility.
+//
+// LlmHandler is a gRPC service that provides the chat and embeddings operations of the LLM handler.
+// It mirrors the HandlerRequest and HandlerResponse of the websocket protocol; errors are returned
+// as gRPC status instead of responses of type "error".
+type LlmHandlerServer interface {
+	// HealthCheck is a simple health check method that returns an empty response.
+	HealthCheck(context.Context, *HealthRequest) (*HealthResponse, error)
+	// Runs a chat request and streams the answer of the model.
+	// Non-streaming requests receive a single message with is_last set.
+	Chat(*ChatRequest, grpc.ServerStreamingServer[ChatResponse]) error
+	// Computes the embeddings of the given texts.
+	Embeddings(context.Context, *EmbeddingsRequest) (*EmbeddingsResponse, error)
+	// Lists the models available in the LLM handler.
+	ListModels(context.Context, *ListModelsRequest) (*ListModelsResponse, error)
+	mustEmbedUnimplementedLlmHandlerServer()
+}
+
+// UnimplementedLlmHandlerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLlmHandlerServer struct{}
+
+func (UnimplementedLlmHandlerServer) HealthCheck(context.Context, *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method HealthCheck not implemented")
+}
+func (UnimplementedLlmHandlerServer) Chat(*ChatRequest, grpc.ServerStreamingServer[ChatResponse]) error {
+	return status.Error(codes.Unimplemented, "method Chat not implemented")
+}
+func (UnimplementedLlmHandlerServer) Embeddings(context.Context, *EmbeddingsRequest) (*EmbeddingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Embeddings not implemented")
+}
+func (UnimplementedLlmHandlerServer) ListModels(context.Context, *ListModelsRequest) (*ListModelsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListModels not implemented")
+}
+func (UnimplementedLlmHandlerServer) mustEmbedUnimplementedLlmHandlerServer() {}
+func (UnimplementedLlmHandlerServer) testEmbeddedByValue()                    {}
+
+// UnsafeLlmHandlerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LlmHandlerServer will
+// result in compilation errors.
+type UnsafeLlmHandlerServer interface {
+	mustEmbedUnimplementedLlmHandlerServer()
+}
+
+func RegisterLlmHandlerServer(s grpc.ServiceRegistrar, srv LlmHandlerServer) {
+	// If the following call panics, it indicates UnimplementedLlmHandlerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LlmHandler_ServiceDesc, srv)
+}
+
+func _LlmHandler_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LlmHandlerServer).HealthCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LlmHandler_HealthCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LlmHandlerServer).HealthCheck(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LlmHandler_Chat_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChatRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LlmHandlerServer).Chat(m, &grpc.GenericServerStream[ChatRequest, ChatResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LlmHandler_ChatServer = grpc.ServerStreamingServer[ChatResponse]
+
+func _LlmHandler_Embeddings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbeddingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LlmHandlerServer).Embeddings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LlmHandler_Embeddings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LlmHandlerServer).Embeddings(ctx, req.(*EmbeddingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LlmHandler_ListModels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LlmHandlerServer).ListModels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LlmHandler_ListModels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LlmHandlerServer).ListModels(ctx, req.(*ListModelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LlmHandler_ServiceDesc is the grpc.ServiceDesc for LlmHandler service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LlmHandler_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "aalillmgrpc.LlmHandler",
+	HandlerType: (*LlmHandlerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "HealthCheck",
+			Handler:    _LlmHandler_HealthCheck_Handler,
+		},
+		{
+			MethodName: "Embeddings",
+			Handler:    _LlmHandler_Embeddings_Handler,
+		},
+		{
+			MethodName: "ListModels",
+			Handler:    _LlmHandler_ListModels_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Chat",
+			Handler:       _LlmHandler_Chat_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/aalillmgrpc/aali-llm.proto",
+}
@@ -0,0 +1,341 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package adscrypto provides the encryption and signature primitives of the data-shield (ADS) proxy
+// protocol, so that the proxy and the services calling it share one implementation.
+//
+// Payloads are encrypted with a hybrid scheme: a random AES-256 key encrypts the data with AES-GCM,
+// and the AES key is wrapped with RSA-OAEP (SHA-256) for the recipient. The encrypted payload is
+//
+//	version (1 byte, 0x01) | wrapped key length (2 bytes, big endian) | wrapped key | nonce (12 bytes) | ciphertext and tag
+//
+// where the version byte and the wrapped key are authenticated as additional data of the AES-GCM seal.
+//
+// This package is the specification of version 1 of the format: the proxy and the services must produce
+// and accept exactly this layout. Payloads in any other layout, such as those of implementations predating
+// this package, are rejected with ErrInvalidPayload instead of being misread, and a change of the layout
+// must use a new version byte. Only standard primitives are used, so that the payloads can be checked with
+// other implementations; e.g. the wrapped key of a payload is unwrapped by
+// "openssl pkeyutl -decrypt -pkeyopt rsa_padding_mode:oaep -pkeyopt rsa_oaep_md:sha256".
+//
+// Signatures are checked against the signing public key: RSA keys use PKCS #1 v1.5 with SHA-256,
+// ECDSA keys use ASN.1 encoded signatures over SHA-256, and Ed25519 keys sign the message directly.
+//
+// The keys are read from ANSYS_DISCO_CRYPT_PRIVAT_KEY and ANSYS_DISOC_SIGN_PUBLIC_KEY, either as PEM
+// or as base64 encoded DER.
+package adscrypto
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+// PayloadVersion is the version byte of the encrypted payloads produced by Encrypt.
+const PayloadVersion byte = 0x01
+
+const (
+	aesKeySize  = 32
+	headerSize  = 3
+	gcmNonceLen = 12
+)
+
+// ErrInvalidPayload is returned if an encrypted payload is malformed, was tampered with or is not addressed to the key.
+var ErrInvalidPayload = errors.New("invalid encrypted payload")
+
+// ErrInvalidSignature is returned if a signature does not match the message.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Keys holds the keys of the data-shield protocol.
+type Keys struct {
+	CryptKey *rsa.PrivateKey  // decrypts the payloads sent to this service
+	SignKey  crypto.PublicKey // verifies the signatures of the data-shield proxy
+}
+
+// LoadKeys parses the keys configured in ANSYS_DISCO_CRYPT_PRIVAT_KEY and ANSYS_DISOC_SIGN_PUBLIC_KEY.
+// Keys that are not configured are left nil.
+//
+// Parameters:
+//   - cfg: The configuration.
+//
+// Returns:
+//   - *Keys: The keys.
+//   - error: An error if a configured key cannot be parsed.
+func LoadKeys(cfg *config.Config) (*Keys, error) {
+	keys := &Keys{}
+	var err error
+	if cfg.ANSYS_DISCO_CRYPT_PRIVAT_KEY != "" {
+		keys.CryptKey, err = ParseCryptPrivateKey(cfg.ANSYS_DISCO_CRYPT_PRIVAT_KEY)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ANSYS_DISCO_CRYPT_PRIVAT_KEY: %w", err)
+		}
+	}
+	if cfg.ANSYS_DISOC_SIGN_PUBLIC_KEY != "" {
+		keys.SignKey, err = ParseSignPublicKey(cfg.ANSYS_DISOC_SIGN_PUBLIC_KEY)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ANSYS_DISOC_SIGN_PUBLIC_KEY: %w", err)
+		}
+	}
+	return keys, nil
+}
+
+// ParseCryptPrivateKey parses an RSA private key in PKCS #1 or PKCS #8 form.
+//
+// Parameters:
+//   - encoded: The key as PEM or base64 encoded DER.
+//
+// Returns:
+//   - *rsa.PrivateKey: The key.
+//   - error: An error if the key cannot be decoded or is not an RSA key.
+func ParseCryptPrivateKey(encoded string) (*rsa.PrivateKey, error) {
+	der, err := decodeKey(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is a %T, not an RSA key", parsed)
+	}
+	return key, nil
+}
+
+// ParseSignPublicKey parses an RSA, ECDSA or Ed25519 public key in PKIX form.
+//
+// Parameters:
+//   - encoded: The key as PEM or base64 encoded DER.
+//
+// Returns:
+//   - crypto.PublicKey: The key.
+//   - error: An error if the key cannot be decoded or has an unsupported type.
+func ParseSignPublicKey(encoded string) (crypto.PublicKey, error) {
+	der, err := decodeKey(encoded)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse public key: %w", err)
+	}
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// decodeKey returns the DER bytes of a PEM or base64 encoded key.
+// Escaped newlines, as found in keys passed through environment variables, are accepted.
+func decodeKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(strings.ReplaceAll(encoded, `\n`, "\n"))
+	if strings.HasPrefix(encoded, "-----BEGIN") {
+		block, _ := pem.Decode([]byte(encoded))
+		if block == nil {
+			return nil, fmt.Errorf("could not decode PEM block")
+		}
+		return block.Bytes, nil
+	}
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("key is neither PEM nor base64: %w", err)
+	}
+	return der, nil
+}
+
+// Encrypt encrypts a payload for the holder of the private key matching the public key.
+//
+// Parameters:
+//   - key: The public key of the recipient.
+//   - plaintext: The data to encrypt.
+//
+// Returns:
+//   - []byte: The encrypted payload.
+//   - error: An error if the encryption fails.
+func Encrypt(key *rsa.PublicKey, plaintext []byte) ([]byte, error) {
+	aesKey := make([]byte, aesKeySize)
+	if _, err := rand.Read(aesKey); err != nil {
+		return nil, err
+	}
+	wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, key, aesKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not wrap payload key: %w", err)
+	}
+	gcm, err := newGCM(aesKey)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, headerSize, headerSize+len(wrapped)+gcmNonceLen+len(plaintext)+gcm.Overhead())
+	header[0] = PayloadVersion
+	binary.BigEndian.PutUint16(header[1:], uint16(len(wrapped)))
+	header = append(header, wrapped...)
+
+	nonce := make([]byte, gcmNonceLen)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	payload := append(header, nonce...)
+	return gcm.Seal(payload, nonce, plaintext, payload[:len(header)]), nil
+}
+
+// Decrypt decrypts a payload produced by Encrypt.
+//
+// Parameters:
+//   - key: The private key of the recipient.
+//   - payload: The encrypted payload.
+//
+// Returns:
+//   - []byte: The decrypted data.
+//   - error: An error wrapping ErrInvalidPayload if the payload cannot be decrypted.
+func Decrypt(key *rsa.PrivateKey, payload []byte) ([]byte, error) {
+	if len(payload) < headerSize {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidPayload)
+	}
+	if payload[0] != PayloadVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidPayload, payload[0])
+	}
+	wrappedLen := int(binary.BigEndian.Uint16(payload[1:headerSize]))
+	headerLen := headerSize + wrappedLen
+	if len(payload) < headerLen+gcmNonceLen {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidPayload)
+	}
+
+	aesKey, err := rsa.DecryptOAEP(sha256.New(), nil, key, payload[headerSize:headerLen], nil)
+	if err != nil || len(aesKey) != aesKeySize {
+		return nil, fmt.Errorf("%w: could not unwrap payload key", ErrInvalidPayload)
+	}
+	gcm, err := newGCM(aesKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := payload[headerLen : headerLen+gcmNonceLen]
+	plaintext, err := gcm.Open(nil, nonce, payload[headerLen+gcmNonceLen:], payload[:headerLen])
+	if err != nil {
+		return nil, fmt.Errorf("%w: authentication failed", ErrInvalidPayload)
+	}
+	return plaintext, nil
+}
+
+// newGCM creates the AES-GCM cipher of a payload key.
+func newGCM(aesKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// VerifySignature checks the signature of a message.
+//
+// Parameters:
+//   - key: The RSA, ECDSA or Ed25519 public key of the signer.
+//   - message: The signed message.
+//   - signature: The signature.
+//
+// Returns:
+//   - error: ErrInvalidSignature if the signature does not match, or an error if the key type is unsupported.
+func VerifySignature(key crypto.PublicKey, message []byte, signature []byte) error {
+	var valid bool
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(message)
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		valid = ecdsa.VerifyASN1(key, digest[:], signature)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, message, signature)
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Encrypt encrypts a payload for the holder of the crypt key, see Encrypt.
+//
+// Parameters:
+//   - plaintext: The data to encrypt.
+//
+// Returns:
+//   - []byte: The encrypted payload.
+//   - error: An error if no crypt key is configured or the encryption fails.
+func (keys *Keys) Encrypt(plaintext []byte) ([]byte, error) {
+	if keys.CryptKey == nil {
+		return nil, fmt.Errorf("no crypt key configured")
+	}
+	return Encrypt(&keys.CryptKey.PublicKey, plaintext)
+}
+
+// Decrypt decrypts a payload with the crypt key, see Decrypt.
+// Keys implements the PayloadDecrypter of the aali_graphdb proxy client.
+//
+// Parameters:
+//   - payload: The encrypted payload.
+//
+// Returns:
+//   - []byte: The decrypted data.
+//   - error: An error if no crypt key is configured or the payload cannot be decrypted.
+func (keys *Keys) Decrypt(payload []byte) ([]byte, error) {
+	if keys.CryptKey == nil {
+		return nil, fmt.Errorf("no crypt key configured")
+	}
+	return Decrypt(keys.CryptKey, payload)
+}
+
+// VerifySignature checks the signature of a message with the sign key, see VerifySignature.
+//
+// Parameters:
+//   - message: The signed message.
+//   - signature: The signature.
+//
+// Returns:
+//   - error: An error if no sign key is configured or the signature is invalid.
+func (keys *Keys) VerifySignature(message []byte, signature []byte) error {
+	if keys.SignKey == nil {
+		return fmt.Errorf("no sign key configured")
+	}
+	return VerifySignature(keys.SignKey, message, signature)
+}
@@ -0,0 +1,292 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package adscrypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aali_graphdb"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+// Keys implements the payload decrypter of the graphdb proxy client
+var _ aali_graphdb.PayloadDecrypter = (*Keys)(nil)
+
+// testVectors are the vectors of testdata/vectors.json, made with the keys in testdata.
+// The payloads were produced by Encrypt; their wrapped keys unwrap with OpenSSL (see the package documentation)
+// and TestPayloadLayout decrypts them from the documented layout without this package.
+// The signatures are independent of this package and verify with
+// "openssl dgst -sha256 -verify <key> -signature <signature> <message>" for the RSA and ECDSA keys and
+// "openssl pkeyutl -verify -pubin -inkey <key> -rawin -in <message> -sigfile <signature>" for the Ed25519 key.
+type testVectors struct {
+	Encryption []struct {
+		Name      string `json:"name"`
+		Plaintext string `json:"plaintext"`
+		Payload   string `json:"payload"`
+	} `json:"encryption"`
+	Signatures []struct {
+		Name      string `json:"name"`
+		Key       string `json:"key"`
+		Message   string `json:"message"`
+		Signature string `json:"signature"`
+	} `json:"signatures"`
+}
+
+func readTestdata(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func loadVectors(t *testing.T) testVectors {
+	t.Helper()
+	var vectors testVectors
+	if err := json.Unmarshal([]byte(readTestdata(t, "vectors.json")), &vectors); err != nil {
+		t.Fatal(err)
+	}
+	return vectors
+}
+
+func testCryptKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := ParseCryptPrivateKey(readTestdata(t, "crypt_private_key.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func decodeBase64(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestDecryptVectors(t *testing.T) {
+	key := testCryptKey(t)
+	for _, vector := range loadVectors(t).Encryption {
+		t.Run(vector.Name, func(t *testing.T) {
+			got, err := Decrypt(key, decodeBase64(t, vector.Payload))
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if string(got) != vector.Plaintext {
+				t.Errorf("Decrypt() = %q, want %q", got, vector.Plaintext)
+			}
+		})
+	}
+}
+
+func TestPayloadLayout(t *testing.T) {
+	key := testCryptKey(t)
+	for _, vector := range loadVectors(t).Encryption {
+		t.Run(vector.Name, func(t *testing.T) {
+			payload := decodeBase64(t, vector.Payload)
+			if payload[0] != 0x01 {
+				t.Fatalf("version byte = %d, want 1", payload[0])
+			}
+			wrappedEnd := 3 + int(binary.BigEndian.Uint16(payload[1:3]))
+			aesKey, err := rsa.DecryptOAEP(sha256.New(), nil, key, payload[3:wrappedEnd], nil)
+			if err != nil || len(aesKey) != 32 {
+				t.Fatalf("unwrapping the AES-256 key failed: %v", err)
+			}
+			block, err := aes.NewCipher(aesKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gcm, err := cipher.NewGCM(block)
+			if err != nil {
+				t.Fatal(err)
+			}
+			nonce := payload[wrappedEnd : wrappedEnd+12]
+			got, err := gcm.Open(nil, nonce, payload[wrappedEnd+12:], payload[:wrappedEnd])
+			if err != nil || string(got) != vector.Plaintext {
+				t.Errorf("AES-GCM open = %q, %v, want %q", got, err, vector.Plaintext)
+			}
+		})
+	}
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	key := testCryptKey(t)
+	plaintext := []byte(`{"databases":["docs"]}`)
+	first, err := Encrypt(&key.PublicKey, plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Encrypt(&key.PublicKey, plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(first) == string(second) {
+		t.Error("Encrypt() is deterministic, want a fresh key and nonce per payload")
+	}
+	if first[0] != PayloadVersion {
+		t.Errorf("version byte = %d, want %d", first[0], PayloadVersion)
+	}
+	got, err := Decrypt(key, first)
+	if err != nil || string(got) != string(plaintext) {
+		t.Errorf("Decrypt() = %q, %v, want %q", got, err, plaintext)
+	}
+}
+
+func TestDecryptInvalid(t *testing.T) {
+	key := testCryptKey(t)
+	payload := decodeBase64(t, loadVectors(t).Encryption[1].Payload)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tamper := func(index int) []byte {
+		p := append([]byte(nil), payload...)
+		p[index] ^= 0x01
+		return p
+	}
+	tests := []struct {
+		name    string
+		key     *rsa.PrivateKey
+		payload []byte
+	}{
+		{"empty", key, nil},
+		{"unknown version", key, tamper(0)},
+		{"truncated", key, payload[:100]},
+		{"tampered wrapped key", key, tamper(10)},
+		{"tampered nonce", key, tamper(headerSize + 256)},
+		{"tampered ciphertext", key, tamper(len(payload) - 1)},
+		{"wrong key", otherKey, payload},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decrypt(tt.key, tt.payload); !errors.Is(err, ErrInvalidPayload) {
+				t.Errorf("Decrypt() error = %v, want ErrInvalidPayload", err)
+			}
+		})
+	}
+}
+
+func TestVerifySignatureVectors(t *testing.T) {
+	for _, vector := range loadVectors(t).Signatures {
+		t.Run(vector.Name, func(t *testing.T) {
+			key, err := ParseSignPublicKey(readTestdata(t, vector.Key))
+			if err != nil {
+				t.Fatal(err)
+			}
+			signature := decodeBase64(t, vector.Signature)
+			if err := VerifySignature(key, []byte(vector.Message), signature); err != nil {
+				t.Errorf("VerifySignature() error = %v", err)
+			}
+			if err := VerifySignature(key, []byte(vector.Message+" "), signature); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("VerifySignature() of a modified message error = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+	if err := VerifySignature("not a key", nil, nil); err == nil || errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifySignature() with an unsupported key error = %v", err)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	pemKey := readTestdata(t, "crypt_private_key.pem")
+	block := strings.Join(strings.Split(strings.TrimSpace(pemKey), "\n")[1:], "")
+	block = strings.TrimSuffix(block, "-----END RSA PRIVATE KEY-----")
+	tests := []struct {
+		name    string
+		encoded string
+		wantErr bool
+	}{
+		{"pem", pemKey, false},
+		{"escaped newlines", strings.ReplaceAll(pemKey, "\n", `\n`), false},
+		{"base64 der", block, false},
+		{"public key", readTestdata(t, "sign_rsa_public_key.pem"), true},
+		{"garbage", "not a key", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCryptPrivateKey(tt.encoded)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCryptPrivateKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := ParseSignPublicKey(pemKey); err == nil {
+		t.Error("ParseSignPublicKey() of a private key succeeded")
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	keys, err := LoadKeys(&config.Config{})
+	if err != nil || keys.CryptKey != nil || keys.SignKey != nil {
+		t.Fatalf("LoadKeys() of an empty config = %+v, %v", keys, err)
+	}
+	if _, err := keys.Decrypt([]byte{PayloadVersion}); err == nil {
+		t.Error("Decrypt() without a crypt key succeeded")
+	}
+	if err := keys.VerifySignature(nil, nil); err == nil {
+		t.Error("VerifySignature() without a sign key succeeded")
+	}
+
+	_, err = LoadKeys(&config.Config{ANSYS_DISCO_CRYPT_PRIVAT_KEY: "not a key"})
+	if err == nil || !strings.Contains(err.Error(), "ANSYS_DISCO_CRYPT_PRIVAT_KEY") {
+		t.Errorf("LoadKeys() error = %v, want invalid ANSYS_DISCO_CRYPT_PRIVAT_KEY", err)
+	}
+
+	vectors := loadVectors(t)
+	keys, err = LoadKeys(&config.Config{
+		ANSYS_DISCO_CRYPT_PRIVAT_KEY: readTestdata(t, "crypt_private_key.pem"),
+		ANSYS_DISOC_SIGN_PUBLIC_KEY:  readTestdata(t, vectors.Signatures[2].Key),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := keys.Encrypt([]byte("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := keys.Decrypt(encrypted); err != nil || string(got) != "payload" {
+		t.Errorf("Decrypt() = %q, %v", got, err)
+	}
+	signature := decodeBase64(t, vectors.Signatures[2].Signature)
+	if err := keys.VerifySignature([]byte(vectors.Signatures[2].Message), signature); err != nil {
+		t.Errorf("VerifySignature() error = %v", err)
+	}
+}
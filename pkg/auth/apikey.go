@@ -0,0 +1,302 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package auth provides authentication and authorization helpers for AALI services.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/problem"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ErrInvalidAPIKey is returned if an API key is missing, unknown, revoked, expired or has a wrong secret.
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// ErrMissingScope is returned if an API key does not grant the required scope.
+var ErrMissingScope = errors.New("API key does not grant the required scope")
+
+// ErrAPIKeyNotFound is returned by an APIKeyStore if there is no key with the given ID.
+var ErrAPIKeyNotFound = errors.New("API key not found")
+
+// APIKeyStore looks up API keys by ID.
+type APIKeyStore interface {
+	GetAPIKey(ctx context.Context, id string) (*sharedtypes.APIKey, error)
+}
+
+// StaticAPIKeyStore is an in-memory APIKeyStore. It is safe for concurrent use.
+type StaticAPIKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string]sharedtypes.APIKey
+}
+
+// NewStaticAPIKeyStore creates an in-memory API key store.
+//
+// Parameters:
+//   - keys: the API keys
+//
+// Returns:
+//   - *StaticAPIKeyStore: the store
+func NewStaticAPIKeyStore(keys ...sharedtypes.APIKey) *StaticAPIKeyStore {
+	store := &StaticAPIKeyStore{keys: map[string]sharedtypes.APIKey{}}
+	for _, key := range keys {
+		store.Put(key)
+	}
+	return store
+}
+
+// Put adds or replaces an API key.
+//
+// Parameters:
+//   - key: the API key
+func (s *StaticAPIKeyStore) Put(key sharedtypes.APIKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key.Id] = key
+}
+
+// GetAPIKey returns the API key with the given ID.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - id: the ID of the API key
+//
+// Returns:
+//   - *sharedtypes.APIKey: a copy of the API key
+//   - error: ErrAPIKeyNotFound if there is no key with the ID
+func (s *StaticAPIKeyStore) GetAPIKey(ctx context.Context, id string) (*sharedtypes.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, ErrAPIKeyNotFound
+	}
+	return &key, nil
+}
+
+// AuthenticateAPIKey verifies an API key token.
+// Tokens without the "aali_" prefix are verified against the key with ID sharedtypes.LegacyAPIKeyId,
+// or against all legacy keys if the store is a LegacyAPIKeyVerifier.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - store: the API key store
+//   - token: the token sent by the client
+//   - scope: the required scope; empty if any valid key is accepted
+//
+// Returns:
+//   - *sharedtypes.APIKey: the verified API key
+//   - error: ErrInvalidAPIKey or ErrMissingScope if the token is rejected, or the error of the store
+func AuthenticateAPIKey(ctx context.Context, store APIKeyStore, token string, scope string) (*sharedtypes.APIKey, error) {
+	if token == "" {
+		return nil, ErrInvalidAPIKey
+	}
+	var key *sharedtypes.APIKey
+	id, secret, err := sharedtypes.ParseAPIKeyToken(token)
+	if verifier, ok := store.(LegacyAPIKeyVerifier); err != nil && ok {
+		key, err = verifier.VerifyLegacyAPIKey(ctx, token)
+		secret = token
+	} else {
+		if err != nil {
+			id, secret = sharedtypes.LegacyAPIKeyId, token
+		}
+		key, err = store.GetAPIKey(ctx, id)
+	}
+	if errors.Is(err, ErrAPIKeyNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error looking up API key: %w", err)
+	}
+	if !key.Verify(secret) || !key.IsActive(time.Now()) {
+		return nil, ErrInvalidAPIKey
+	}
+	if !key.HasScope(scope) {
+		return nil, ErrMissingScope
+	}
+	return key, nil
+}
+
+// apiKeyContextKey is the context key of the verified API key.
+type apiKeyContextKey struct{}
+
+// APIKeyFromContext returns the API key verified by the middleware.
+//
+// Parameters:
+//   - ctx: the context of the request
+//
+// Returns:
+//   - *sharedtypes.APIKey: the API key
+//   - bool: false if the request was not authenticated with an API key
+func APIKeyFromContext(ctx context.Context) (*sharedtypes.APIKey, bool) {
+	key, ok := ctx.Value(apiKeyContextKey{}).(*sharedtypes.APIKey)
+	return key, ok
+}
+
+// HTTPAPIKeyMiddleware authenticates HTTP requests with the API key in the "api-key" header.
+// Rejected requests are answered with 401 or 403 and a problem+json body; the verified key
+// is available to the handler via APIKeyFromContext, and its identity via logging.CreateCtxFromHeader.
+//
+// Parameters:
+//   - store: the API key store
+//   - scope: the required scope; empty if any valid key is accepted
+//
+// Returns:
+//   - func(http.Handler) http.Handler: the middleware
+func HTTPAPIKeyMiddleware(store APIKeyStore, scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, err := AuthenticateAPIKey(r.Context(), store, r.Header.Get("api-key"), scope)
+			logCtx, ctxErr := logging.CreateCtxFromHeader(r)
+			if ctxErr != nil {
+				logCtx = &logging.ContextMap{}
+			}
+			recordAuthAttempt(logCtx, r.URL.Path, key, err)
+			if err != nil {
+				logging.Log.Warnf(logCtx, "rejected request to %s: %v", r.URL.Path, err)
+				writeAuthError(w, r, err)
+				return
+			}
+			ApplyAPIKeyToContext(key, logCtx)
+			ctx := logging.ContextWithLogContext(r.Context(), logCtx)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, apiKeyContextKey{}, key)))
+		})
+	}
+}
+
+// UnaryAPIKeyInterceptor authenticates unary gRPC calls with the API key in the "x-api-key" metadata.
+//
+// Parameters:
+//   - store: the API key store
+//   - methodScopes: the required scope by full method name; methods without entry accept any valid key
+//
+// Returns:
+//   - grpc.UnaryServerInterceptor: the interceptor
+func UnaryAPIKeyInterceptor(store APIKeyStore, methodScopes map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticateGrpc(ctx, store, methodScopes[info.FullMethod], info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAPIKeyInterceptor authenticates streaming gRPC calls with the API key in the "x-api-key" metadata.
+//
+// Parameters:
+//   - store: the API key store
+//   - methodScopes: the required scope by full method name; methods without entry accept any valid key
+//
+// Returns:
+//   - grpc.StreamServerInterceptor: the interceptor
+func StreamAPIKeyInterceptor(store APIKeyStore, methodScopes map[string]string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticateGrpc(ss.Context(), store, methodScopes[info.FullMethod], info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream is a server stream with the context of the verified API key.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context with the verified API key.
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticateGrpc verifies the API key of a gRPC call and adds it to the context.
+func authenticateGrpc(ctx context.Context, store APIKeyStore, scope string, method string) (context.Context, error) {
+	token := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-api-key"); len(values) > 0 {
+			token = values[0]
+		}
+	}
+	key, err := AuthenticateAPIKey(ctx, store, token, scope)
+	logCtx, ctxErr := logging.CreateCtxFromMetaData(ctx)
+	if ctxErr != nil {
+		logCtx = &logging.ContextMap{}
+	}
+	recordAuthAttempt(logCtx, method, key, err)
+	if err != nil {
+		logging.Log.Warnf(logCtx, "rejected call to %s: %v", method, err)
+		switch {
+		case errors.Is(err, ErrMissingScope):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, ErrInvalidAPIKey):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	ApplyAPIKeyToContext(key, logCtx)
+	ctx = logging.ContextWithLogContext(ctx, logCtx)
+	return context.WithValue(ctx, apiKeyContextKey{}, key), nil
+}
+
+// recordAuthAttempt sends the auth attempt metric of an authentication result.
+func recordAuthAttempt(logCtx *logging.ContextMap, method string, key *sharedtypes.APIKey, err error) {
+	result, keyId := "success", ""
+	switch {
+	case errors.Is(err, ErrMissingScope):
+		result = "permission_denied"
+	case errors.Is(err, ErrInvalidAPIKey):
+		result = "unauthenticated"
+	case err != nil:
+		result = "error"
+	}
+	if key != nil {
+		keyId = key.Id
+	}
+	logging.RecordAuthAttempt(logCtx, method, result, keyId)
+}
+
+// writeAuthError writes a problem+json response for an authentication or authorization error.
+// Other errors, e.g. of the key store, are written with their own classification by problem.Write.
+func writeAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidAPIKey), errors.Is(err, ErrInvalidToken):
+		problem.WriteStatus(w, r, http.StatusUnauthorized, err)
+	case errors.Is(err, ErrMissingScope):
+		problem.WriteStatus(w, r, http.StatusForbidden, err)
+	default:
+		problem.Write(w, r, err)
+	}
+}
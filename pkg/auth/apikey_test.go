@@ -0,0 +1,167 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// newTestStore creates a store with a scoped key, a revoked key and a legacy key.
+func newTestStore(t *testing.T) (store *StaticAPIKeyStore, token string, revokedToken string) {
+	t.Helper()
+	key, token, err := sharedtypes.GenerateAPIKey("alice", []string{"workflows:run"}, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateAPIKey() error = %v", err)
+	}
+	revoked, revokedToken, err := sharedtypes.GenerateAPIKey("bob", []string{sharedtypes.APIKeyScopeAll}, 0)
+	if err != nil {
+		t.Fatalf("GenerateAPIKey() error = %v", err)
+	}
+	revoked.Revoked = true
+	legacy, err := sharedtypes.NewLegacyAPIKey("plain-secret", []string{sharedtypes.APIKeyScopeAll})
+	if err != nil {
+		t.Fatalf("NewLegacyAPIKey() error = %v", err)
+	}
+	return NewStaticAPIKeyStore(key, revoked, legacy), token, revokedToken
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	store, token, revokedToken := newTestStore(t)
+	tests := []struct {
+		name    string
+		token   string
+		scope   string
+		wantErr error
+	}{
+		{"valid", token, "workflows:run", nil},
+		{"no scope required", token, "", nil},
+		{"missing scope", token, "workflows:publish", ErrMissingScope},
+		{"wrong secret", token + "x", "", ErrInvalidAPIKey},
+		{"revoked", revokedToken, "", ErrInvalidAPIKey},
+		{"unknown id", "aali_unknown_secret", "", ErrInvalidAPIKey},
+		{"empty", "", "", ErrInvalidAPIKey},
+		{"legacy", "plain-secret", "workflows:run", nil},
+		{"wrong legacy secret", "other-secret", "", ErrInvalidAPIKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := AuthenticateAPIKey(context.Background(), store, tt.token, tt.scope)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AuthenticateAPIKey() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPAPIKeyMiddleware(t *testing.T) {
+	logging.InitForTest()
+	store, token, _ := newTestStore(t)
+	var owner string
+	handler := HTTPAPIKeyMiddleware(store, "workflows:run")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, _ := APIKeyFromContext(r.Context())
+		owner = key.Owner
+	}))
+
+	tests := []struct {
+		name     string
+		token    string
+		wantCode int
+	}{
+		{"valid", token, http.StatusOK},
+		{"invalid", "aali_x_y", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("api-key", tt.token)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			if recorder.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantCode)
+			}
+		})
+	}
+	if owner != "alice" {
+		t.Errorf("owner of API key in context = %q, want alice", owner)
+	}
+
+	forbidden := HTTPAPIKeyMiddleware(store, "workflows:publish")(handler)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("api-key", token)
+	recorder := httptest.NewRecorder()
+	forbidden.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("status without scope = %d, want %d", recorder.Code, http.StatusForbidden)
+	}
+	var problem sharedtypes.ProblemDetails
+	if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil || problem.Code != "auth" || problem.Instance != "/" ||
+		recorder.Header().Get("Content-Type") != sharedtypes.ProblemContentType {
+		t.Errorf("body without scope = %s, want problem+json", recorder.Body.String())
+	}
+}
+
+func TestUnaryAPIKeyInterceptor(t *testing.T) {
+	logging.InitForTest()
+	store, token, _ := newTestStore(t)
+	interceptor := UnaryAPIKeyInterceptor(store, map[string]string{"/svc/Publish": "workflows:publish"})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, ok := APIKeyFromContext(ctx)
+		return ok, nil
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		method   string
+		wantCode codes.Code
+	}{
+		{"valid", token, "/svc/Run", codes.OK},
+		{"missing scope", token, "/svc/Publish", codes.PermissionDenied},
+		{"invalid", "wrong", "/svc/Run", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", tt.token))
+			resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("error = %v, want code %v", err, tt.wantCode)
+			}
+			if tt.wantCode == codes.OK && resp != true {
+				t.Error("API key not available in handler context")
+			}
+		})
+	}
+}
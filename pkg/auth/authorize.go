@@ -0,0 +1,223 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ansys/aali-sharedtypes/pkg/clients"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// ErrForbidden is returned by Authorize if the subject may not perform the action.
+var ErrForbidden = errors.New("forbidden")
+
+// ErrNoAuthorizer is returned by Authorize if no authorizer is configured.
+var ErrNoAuthorizer = errors.New("no authorizer configured")
+
+// Authorizer decides if a subject may perform an action on a resource.
+type Authorizer interface {
+	Authorize(ctx context.Context, subject sharedtypes.Subject, action string, resource string) (bool, error)
+}
+
+// DefaultAuthorizer is the authorizer used by Authorize. Services set it at startup, e.g. to NewAuthorizerFromConfig().
+var DefaultAuthorizer Authorizer
+
+// Authorize checks with the DefaultAuthorizer if a subject may perform an action on a resource.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - subject: the subject
+//   - action: the action, e.g. sharedtypes.WorkflowActionRun
+//   - resource: the resource, e.g. sharedtypes.WorkflowResource(workflowId)
+//
+// Returns:
+//   - error: ErrForbidden if the action is not permitted, ErrNoAuthorizer if no authorizer is configured,
+//     or the error of the authorizer
+func Authorize(ctx context.Context, subject sharedtypes.Subject, action string, resource string) error {
+	if DefaultAuthorizer == nil {
+		return ErrNoAuthorizer
+	}
+	allowed, err := DefaultAuthorizer.Authorize(ctx, subject, action, resource)
+	if err != nil {
+		return fmt.Errorf("error authorizing '%s' on '%s': %w", action, resource, err)
+	}
+	if !allowed {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// SubjectFromContext creates a subject from the UserId, UserMail and ApiKeyId of a logging context.
+// Only values set by this service, e.g. by the API key or JWT middleware, are used;
+// values asserted by the caller in a received logging context are ignored.
+//
+// Parameters:
+//   - ctx: the logging context
+//
+// Returns:
+//   - sharedtypes.Subject: the subject; empty if the caller is not authenticated
+func SubjectFromContext(ctx *logging.ContextMap) sharedtypes.Subject {
+	subject := sharedtypes.Subject{}
+	subject.UserId, _ = ctx.GetAuthenticated(logging.UserId)
+	subject.UserMail, _ = ctx.GetAuthenticated(logging.UserMail)
+	subject.ApiKeyId, _ = ctx.GetAuthenticated(logging.ApiKeyId)
+	return subject
+}
+
+// WorkflowACLStore looks up workflow ACLs.
+type WorkflowACLStore interface {
+	GetWorkflowACL(ctx context.Context, workflowId string) (*sharedtypes.WorkflowACL, error)
+}
+
+// ACLAuthorizer authorizes workflow actions with the ACLs of a WorkflowACLStore.
+// Resources other than workflows are denied.
+type ACLAuthorizer struct {
+	Store WorkflowACLStore
+}
+
+// Authorize checks the ACL of the workflow.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - subject: the subject
+//   - action: the workflow action
+//   - resource: the resource in the format "workflow:<id>"
+//
+// Returns:
+//   - bool: true if the ACL permits the action
+//   - error: the error of the store
+func (a *ACLAuthorizer) Authorize(ctx context.Context, subject sharedtypes.Subject, action string, resource string) (bool, error) {
+	workflowId, ok := strings.CutPrefix(resource, sharedtypes.WorkflowResource(""))
+	if !ok || workflowId == "" {
+		return false, nil
+	}
+	acl, err := a.Store.GetWorkflowACL(ctx, workflowId)
+	if err != nil {
+		return false, err
+	}
+	if acl == nil {
+		return false, nil
+	}
+	return acl.Allows(subject, action), nil
+}
+
+// httpAuthorizationRequest is the body of a request to the authorization endpoint.
+type httpAuthorizationRequest struct {
+	Subject  sharedtypes.Subject `json:"subject"`
+	Action   string              `json:"action"`
+	Resource string              `json:"resource"`
+}
+
+// httpAuthorizationResponse is the response of the authorization endpoint.
+type httpAuthorizationResponse struct {
+	Allowed bool `json:"allowed"`
+}
+
+// HTTPAuthorizer delegates authorization decisions to an HTTP endpoint.
+// It POSTs {"subject": ..., "action": ..., "resource": ...} and expects {"allowed": true|false}.
+type HTTPAuthorizer struct {
+	URL        string
+	httpClient *http.Client
+}
+
+// NewHTTPAuthorizer creates an authorizer for an HTTP authorization endpoint.
+//
+// Parameters:
+//   - url: the URL of the endpoint
+//
+// Returns:
+//   - *HTTPAuthorizer: the authorizer
+//   - error: an error if the HTTP client cannot be created
+func NewHTTPAuthorizer(url string) (*HTTPAuthorizer, error) {
+	httpClient, err := clients.GetHttpClient()
+	if err != nil {
+		return nil, fmt.Errorf("error getting HTTP client: %w", err)
+	}
+	return &HTTPAuthorizer{URL: url, httpClient: httpClient}, nil
+}
+
+// NewAuthorizerFromConfig creates an authorizer for ANSYS_AUTHORIZATION_URL of the global config.
+//
+// Returns:
+//   - Authorizer: the authorizer; nil if ANSYS_AUTHORIZATION_URL is not set
+//   - error: an error if the HTTP client cannot be created
+func NewAuthorizerFromConfig() (Authorizer, error) {
+	if config.Current().ANSYS_AUTHORIZATION_URL == "" {
+		return nil, nil
+	}
+	return NewHTTPAuthorizer(config.Current().ANSYS_AUTHORIZATION_URL)
+}
+
+// Authorize asks the authorization endpoint.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - subject: the subject
+//   - action: the action
+//   - resource: the resource
+//
+// Returns:
+//   - bool: the decision of the endpoint
+//   - error: an error if the request fails
+func (a *HTTPAuthorizer) Authorize(ctx context.Context, subject sharedtypes.Subject, action string, resource string) (bool, error) {
+	body, err := json.Marshal(httpAuthorizationRequest{Subject: subject, Action: action, Resource: resource})
+	if err != nil {
+		return false, fmt.Errorf("error serializing authorization request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("error creating authorization request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("error making authorization request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusForbidden:
+		return false, nil
+	default:
+		responseBody, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("authorization request failed with status code %d: %s", resp.StatusCode, string(responseBody))
+	}
+
+	var response httpAuthorizationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return false, fmt.Errorf("error decoding authorization response: %w", err)
+	}
+	return response.Allowed, nil
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// mapACLStore is a WorkflowACLStore backed by a map.
+type mapACLStore map[string]*sharedtypes.WorkflowACL
+
+func (s mapACLStore) GetWorkflowACL(ctx context.Context, workflowId string) (*sharedtypes.WorkflowACL, error) {
+	return s[workflowId], nil
+}
+
+func TestAuthorizeWithACLAuthorizer(t *testing.T) {
+	previous := DefaultAuthorizer
+	defer func() { DefaultAuthorizer = previous }()
+
+	DefaultAuthorizer = nil
+	if err := Authorize(context.Background(), sharedtypes.Subject{}, sharedtypes.WorkflowActionRun, "workflow:wf"); !errors.Is(err, ErrNoAuthorizer) {
+		t.Fatalf("Authorize() without authorizer error = %v, want ErrNoAuthorizer", err)
+	}
+
+	DefaultAuthorizer = &ACLAuthorizer{Store: mapACLStore{
+		"wf": {WorkflowId: "wf", Owner: "alice", Entries: []sharedtypes.WorkflowACLEntry{{Principal: "user:bob", Role: sharedtypes.WorkflowRoleRunner}}},
+	}}
+	tests := []struct {
+		name     string
+		userId   string
+		action   string
+		resource string
+		wantErr  error
+	}{
+		{"owner publishes", "alice", sharedtypes.WorkflowActionPublish, sharedtypes.WorkflowResource("wf"), nil},
+		{"runner runs", "bob", sharedtypes.WorkflowActionRun, sharedtypes.WorkflowResource("wf"), nil},
+		{"runner cannot edit", "bob", sharedtypes.WorkflowActionEdit, sharedtypes.WorkflowResource("wf"), ErrForbidden},
+		{"unknown workflow", "alice", sharedtypes.WorkflowActionView, sharedtypes.WorkflowResource("other"), ErrForbidden},
+		{"other resource", "alice", sharedtypes.WorkflowActionView, "kvdb:key", ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &logging.ContextMap{}
+			ctx.Set(logging.UserId, tt.userId)
+			err := Authorize(context.Background(), SubjectFromContext(ctx), tt.action, tt.resource)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authorize() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSubjectFromContext(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("aali-logging-context", `[{"userId":"alice","userMail":"alice@example.com"}]`)
+	asserted, err := logging.CreateCtxFromHeader(request)
+	if err != nil {
+		t.Fatalf("CreateCtxFromHeader() error = %v", err)
+	}
+	if subject := SubjectFromContext(asserted); subject.UserId != "" || subject.UserMail != "" {
+		t.Errorf("SubjectFromContext() with client-asserted values = %+v, want empty subject", subject)
+	}
+
+	asserted.Set(logging.UserId, "bob")
+	if subject := SubjectFromContext(asserted); subject.UserId != "bob" || subject.UserMail != "" {
+		t.Errorf("SubjectFromContext() = %+v, want authenticated user bob only", subject)
+	}
+}
+
+func TestHTTPAuthorizer(t *testing.T) {
+	logging.InitForTest()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req httpAuthorizationRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch req.Subject.UserId {
+		case "alice":
+			_ = json.NewEncoder(w).Encode(httpAuthorizationResponse{Allowed: req.Action == sharedtypes.WorkflowActionRun})
+		case "mallory":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	authorizer, err := NewHTTPAuthorizer(server.URL)
+	if err != nil {
+		t.Fatalf("NewHTTPAuthorizer() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		userId  string
+		action  string
+		want    bool
+		wantErr bool
+	}{
+		{"allowed", "alice", sharedtypes.WorkflowActionRun, true, false},
+		{"denied", "alice", sharedtypes.WorkflowActionEdit, false, false},
+		{"forbidden status", "mallory", sharedtypes.WorkflowActionRun, false, false},
+		{"server error", "eve", sharedtypes.WorkflowActionRun, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := authorizer.Authorize(context.Background(), sharedtypes.Subject{UserId: tt.userId}, tt.action, "workflow:wf")
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("Authorize() = %v, %v, want %v, wantErr %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// LegacyAPIKeyVerifier is implemented by stores holding several keys for tokens without the "aali_" prefix.
+type LegacyAPIKeyVerifier interface {
+	// VerifyLegacyAPIKey returns the key matching a plain secret, or ErrAPIKeyNotFound.
+	VerifyLegacyAPIKey(ctx context.Context, secret string) (*sharedtypes.APIKey, error)
+}
+
+// ConfigAPIKeyStore holds the API keys configured for a server. Its keys grant every scope.
+type ConfigAPIKeyStore struct {
+	keys []sharedtypes.APIKey
+}
+
+// NewConfigAPIKeyStore creates a store of configured API keys.
+// The keys get the IDs "legacy", "legacy-2", "legacy-3", ... in the given order; empty keys are skipped.
+//
+// Parameters:
+//   - keys: the keys, as plain secrets or hashes in the "sha256:<salt>:<hash>" format of sharedtypes.HashAPIKeySecret
+//
+// Returns:
+//   - *ConfigAPIKeyStore: the store
+//   - error: an error if a plain secret cannot be hashed
+func NewConfigAPIKeyStore(keys ...string) (*ConfigAPIKeyStore, error) {
+	store := &ConfigAPIKeyStore{}
+	for _, secret := range keys {
+		if secret == "" {
+			continue
+		}
+		id := sharedtypes.LegacyAPIKeyId
+		if len(store.keys) > 0 {
+			id = fmt.Sprintf("%s-%d", sharedtypes.LegacyAPIKeyId, len(store.keys)+1)
+		}
+
+		var key sharedtypes.APIKey
+		if isHashedAPIKeySecret(secret) {
+			key = sharedtypes.APIKey{HashedSecret: secret, Scopes: []string{sharedtypes.APIKeyScopeAll}, CreatedAt: time.Now().UTC()}
+		} else {
+			var err error
+			key, err = sharedtypes.NewLegacyAPIKey(secret, []string{sharedtypes.APIKeyScopeAll})
+			if err != nil {
+				return nil, err
+			}
+		}
+		key.Id = id
+		store.keys = append(store.keys, key)
+	}
+	return store, nil
+}
+
+// NewAPIKeyStoreFromConfig creates a store of the API key of a server followed by the ACCEPTED_API_KEYS of the global config.
+//
+// Parameters:
+//   - apiKey: the API key of the server, e.g. FLOWKIT_API_KEY; may be empty
+//
+// Returns:
+//   - *ConfigAPIKeyStore: the store
+//   - error: an error if no key is configured or a key cannot be hashed
+func NewAPIKeyStoreFromConfig(apiKey string) (*ConfigAPIKeyStore, error) {
+	keys := []string{apiKey}
+	if config.Current() != nil {
+		keys = append(keys, config.Current().ACCEPTED_API_KEYS...)
+	}
+	store, err := NewConfigAPIKeyStore(keys...)
+	if err != nil {
+		return nil, err
+	}
+	if len(store.keys) == 0 {
+		return nil, fmt.Errorf("no API key configured")
+	}
+	return store, nil
+}
+
+// GetAPIKey returns the key with the given ID.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - id: the ID of the key
+//
+// Returns:
+//   - *sharedtypes.APIKey: a copy of the key
+//   - error: ErrAPIKeyNotFound if there is no key with this ID
+func (s *ConfigAPIKeyStore) GetAPIKey(ctx context.Context, id string) (*sharedtypes.APIKey, error) {
+	for _, key := range s.keys {
+		if key.Id == id {
+			return &key, nil
+		}
+	}
+	return nil, ErrAPIKeyNotFound
+}
+
+// VerifyLegacyAPIKey returns the first key matching a plain secret.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - secret: the secret sent by the client
+//
+// Returns:
+//   - *sharedtypes.APIKey: a copy of the matching key
+//   - error: ErrAPIKeyNotFound if no key matches
+func (s *ConfigAPIKeyStore) VerifyLegacyAPIKey(ctx context.Context, secret string) (*sharedtypes.APIKey, error) {
+	for _, key := range s.keys {
+		if key.Verify(secret) {
+			return &key, nil
+		}
+	}
+	return nil, ErrAPIKeyNotFound
+}
+
+// ApplyAPIKeyToContext sets the ApiKeyId and ApiKeyOwner of a logging context from a verified API key.
+// An owner received from the client is removed if the key has no owner, and so are the client-asserted
+// UserId and UserMail, which an API key does not authenticate.
+//
+// Parameters:
+//   - key: the API key
+//   - ctx: the logging context
+func ApplyAPIKeyToContext(key *sharedtypes.APIKey, ctx *logging.ContextMap) {
+	ctx.Set(logging.ApiKeyId, key.Id)
+	if key.Owner != "" {
+		ctx.Set(logging.ApiKeyOwner, key.Owner)
+	} else {
+		ctx.Delete(logging.ApiKeyOwner)
+	}
+	for _, identityKey := range []logging.ContextKey{logging.UserId, logging.UserMail} {
+		if ctx.IsClientAsserted(identityKey) {
+			ctx.Delete(identityKey)
+		}
+	}
+}
+
+// isHashedAPIKeySecret checks if a configured key is a hash of sharedtypes.HashAPIKeySecret.
+func isHashedAPIKeySecret(secret string) bool {
+	parts := strings.Split(secret, ":")
+	return len(parts) == 3 && parts[0] == "sha256"
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestConfigAPIKeyStore(t *testing.T) {
+	hashed, err := sharedtypes.HashAPIKeySecret("hashed-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewConfigAPIKeyStore("single-secret", "", "second-secret", hashed)
+	if err != nil {
+		t.Fatalf("NewConfigAPIKeyStore() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantId  string
+		wantErr error
+	}{
+		{"single key", "single-secret", "legacy", nil},
+		{"key list", "second-secret", "legacy-2", nil},
+		{"hashed key", "hashed-secret", "legacy-3", nil},
+		{"hash is not a secret", hashed, "", ErrInvalidAPIKey},
+		{"wrong secret", "other-secret", "", ErrInvalidAPIKey},
+		{"generated token", "aali_legacy_single-secret", "legacy", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := AuthenticateAPIKey(context.Background(), store, tt.token, "workflows:run")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AuthenticateAPIKey() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && key.Id != tt.wantId {
+				t.Errorf("AuthenticateAPIKey() key = %v, want %v", key.Id, tt.wantId)
+			}
+		})
+	}
+}
+
+func TestNewAPIKeyStoreFromConfig(t *testing.T) {
+	previous := config.GlobalConfig
+	t.Cleanup(func() { config.GlobalConfig = previous })
+
+	config.GlobalConfig = &config.Config{}
+	if _, err := NewAPIKeyStoreFromConfig(""); err == nil {
+		t.Error("NewAPIKeyStoreFromConfig() without keys succeeded")
+	}
+
+	config.GlobalConfig = &config.Config{ACCEPTED_API_KEYS: []string{"rotated-secret"}}
+	store, err := NewAPIKeyStoreFromConfig("current-secret")
+	if err != nil {
+		t.Fatalf("NewAPIKeyStoreFromConfig() error = %v", err)
+	}
+	for _, token := range []string{"current-secret", "rotated-secret"} {
+		if _, err := AuthenticateAPIKey(context.Background(), store, token, ""); err != nil {
+			t.Errorf("AuthenticateAPIKey(%q) error = %v", token, err)
+		}
+	}
+}
+
+func TestInterceptorAttachesKeyToLoggingContext(t *testing.T) {
+	logging.InitForTest()
+	key, token, err := sharedtypes.GenerateAPIKey("ci-pipeline", []string{sharedtypes.APIKeyScopeAll}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	interceptor := UnaryAPIKeyInterceptor(NewStaticAPIKeyStore(key), nil)
+
+	logCtx := &logging.ContextMap{}
+	logCtx.Set(logging.WorkflowId, "wf-1")
+	ctx, err := logging.CreateMetaDataFromCtx(logCtx, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md.Set("x-api-key", token)
+	ctx = metadata.NewIncomingContext(context.Background(), md)
+
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Run"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		got, err := logging.CreateCtxFromMetaData(ctx)
+		if err != nil {
+			t.Fatalf("CreateCtxFromMetaData() error = %v", err)
+		}
+		for contextKey, want := range map[logging.ContextKey]string{logging.ApiKeyId: key.Id, logging.ApiKeyOwner: "ci-pipeline", logging.WorkflowId: "wf-1"} {
+			if value, _ := got.Get(contextKey); value != want {
+				t.Errorf("context %s = %v, want %v", contextKey, value, want)
+			}
+		}
+		if md, _ := metadata.FromIncomingContext(ctx); len(md.Get("x-api-key")) != 1 {
+			t.Error("other metadata was not preserved")
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("interceptor error = %v", err)
+	}
+}
+
+func TestApplyAPIKeyToContext(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("aali-logging-context", `[{"userId":"alice","apiKeyOwner":"admin","workflowId":"wf-1"}]`)
+	ctx, err := logging.CreateCtxFromHeader(request)
+	if err != nil {
+		t.Fatalf("CreateCtxFromHeader() error = %v", err)
+	}
+	ctx.Set(logging.ApiKeyOwner, "previous-owner")
+
+	ApplyAPIKeyToContext(&sharedtypes.APIKey{Id: "key-1"}, ctx)
+	if id, _ := ctx.Get(logging.ApiKeyId); id != "key-1" {
+		t.Errorf("ApiKeyId = %v, want key-1", id)
+	}
+	for _, key := range []logging.ContextKey{logging.ApiKeyOwner, logging.UserId} {
+		if value, ok := ctx.Get(key); ok {
+			t.Errorf("%s = %v, want it removed", key, value)
+		}
+	}
+	if workflowId, _ := ctx.Get(logging.WorkflowId); workflowId != "wf-1" {
+		t.Errorf("WorkflowId = %v, want wf-1", workflowId)
+	}
+
+	ctx.Set(logging.UserId, "bob")
+	ApplyAPIKeyToContext(&sharedtypes.APIKey{Id: "key-2", Owner: "ci"}, ctx)
+	if owner, _ := ctx.Get(logging.ApiKeyOwner); owner != "ci" {
+		t.Errorf("ApiKeyOwner = %v, want ci", owner)
+	}
+	if userId, _ := ctx.Get(logging.UserId); userId != "bob" {
+		t.Errorf("UserId set by this service = %v, want bob", userId)
+	}
+}
@@ -0,0 +1,474 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/clients"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Default JWKS cache settings
+const (
+	DefaultJWKSCacheTTL        = time.Hour
+	DefaultJWKSRefreshInterval = time.Minute // minimum time between refreshes triggered by unknown key IDs
+)
+
+// ErrInvalidToken is returned if a token is malformed, expired, has an invalid signature or unexpected claims.
+var ErrInvalidToken = errors.New("invalid token")
+
+// jwtSigningMethods are the accepted signing algorithms; symmetric algorithms are not accepted for JWKS keys.
+var jwtSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// jsonWebKey is a key of a JSON Web Key Set.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// JWKSCache fetches and caches the signing keys of a JSON Web Key Set. It is safe for concurrent use.
+type JWKSCache struct {
+	url             string
+	httpClient      *http.Client
+	ttl             time.Duration
+	refreshInterval time.Duration
+
+	mu          sync.Mutex
+	keys        map[string]interface{}
+	fetchedAt   time.Time
+	lastAttempt time.Time
+	refreshing  chan struct{} // closed when the running refresh completes; nil if none is running
+	refreshErr  error         // error of the last refresh
+}
+
+// NewJWKSCache creates a cache for the JSON Web Key Set at the given URL. The keys are fetched on first use.
+//
+// Parameters:
+//   - url: the URL of the JWKS
+//   - ttl: the time after which the keys are fetched again; DefaultJWKSCacheTTL if 0
+//
+// Returns:
+//   - *JWKSCache: the cache
+//   - error: an error if the HTTP client cannot be created
+func NewJWKSCache(url string, ttl time.Duration) (*JWKSCache, error) {
+	httpClient, err := clients.GetHttpClient()
+	if err != nil {
+		return nil, fmt.Errorf("error getting HTTP client: %w", err)
+	}
+	if ttl == 0 {
+		ttl = DefaultJWKSCacheTTL
+	}
+	return &JWKSCache{
+		url:             url,
+		httpClient:      httpClient,
+		ttl:             ttl,
+		refreshInterval: DefaultJWKSRefreshInterval,
+		keys:            map[string]interface{}{},
+	}, nil
+}
+
+// Key returns the public key with the given key ID. The keys are fetched again if they are older than
+// the TTL, or if the key ID is unknown and the last fetch is older than DefaultJWKSRefreshInterval.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - kid: the key ID
+//
+// Returns:
+//   - interface{}: the public key, *rsa.PublicKey or *ecdsa.PublicKey
+//   - error: an error if the key is unknown or the keys cannot be fetched
+func (c *JWKSCache) Key(ctx context.Context, kid string) (interface{}, error) {
+	c.mu.Lock()
+	now := time.Now()
+	key, ok := c.keys[kid]
+	expired := now.Sub(c.fetchedAt) > c.ttl
+	if ok && !expired {
+		c.mu.Unlock()
+		return key, nil
+	}
+
+	// the keys are fetched without holding the lock; concurrent callers wait for the running refresh
+	done := c.refreshing
+	switch {
+	case done == nil && now.Sub(c.lastAttempt) > c.refreshInterval:
+		c.lastAttempt = now
+		done = make(chan struct{})
+		c.refreshing = done
+		c.mu.Unlock()
+		keys, err := c.fetch(ctx)
+		c.mu.Lock()
+		if err == nil {
+			c.keys = keys
+			c.fetchedAt = time.Now()
+		}
+		c.refreshErr = err
+		c.refreshing = nil
+		close(done)
+		c.mu.Unlock()
+	case done != nil && !ok:
+		c.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	default:
+		// keep using the cached key while it is refreshed or if refreshes are throttled
+		c.mu.Unlock()
+		if ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("unknown key ID '%s'", kid)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if refreshed, found := c.keys[kid]; found {
+		return refreshed, nil
+	}
+	if ok {
+		// keep using the cached key if the JWKS endpoint is temporarily unavailable
+		return key, nil
+	}
+	if c.refreshErr != nil {
+		return nil, c.refreshErr
+	}
+	return nil, fmt.Errorf("unknown key ID '%s'", kid)
+}
+
+// fetch fetches the keys of the JWKS; it must be called without holding c.mu.
+func (c *JWKSCache) fetch(ctx context.Context) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating JWKS request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching JWKS from %s: %w", c.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching JWKS from %s: status code %d", c.url, resp.StatusCode)
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, fmt.Errorf("error decoding JWKS from %s: %w", c.url, err)
+	}
+
+	keys := map[string]interface{}{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// skip unsupported keys, the set may contain keys for other purposes
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+// publicKey converts the JSON Web Key to a public key.
+func (jwk *jsonWebKey) publicKey() (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBase64URLInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URLInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, fmt.Errorf("RSA exponent of key '%s' is too large", jwk.Kid)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve '%s' of key '%s'", jwk.Crv, jwk.Kid)
+		}
+		x, err := decodeBase64URLInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URLInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type '%s' of key '%s'", jwk.Kty, jwk.Kid)
+}
+
+// decodeBase64URLInt decodes a base64url encoded big-endian integer.
+func decodeBase64URLInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding key parameter: %w", err)
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// Claims represents the claims of an access or ID token, including the Azure AD specific claims.
+type Claims struct {
+	jwt.RegisteredClaims
+	ObjectId          string   `json:"oid,omitempty"`                // Azure AD object ID of the user
+	Email             string   `json:"email,omitempty"`              // mail address of the user
+	PreferredUsername string   `json:"preferred_username,omitempty"` // usually the mail address in Azure AD
+	Upn               string   `json:"upn,omitempty"`                // user principal name
+	Name              string   `json:"name,omitempty"`
+	Roles             []string `json:"roles,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+}
+
+// UserId returns the ID of the user: the Azure AD object ID if present, the subject otherwise.
+//
+// Returns:
+//   - string: the user ID
+func (c *Claims) UserId() string {
+	if c.ObjectId != "" {
+		return c.ObjectId
+	}
+	return c.Subject
+}
+
+// UserMail returns the mail address of the user from the first present claim of email, preferred_username and upn.
+//
+// Returns:
+//   - string: the mail address; empty if not present
+func (c *Claims) UserMail() string {
+	for _, mail := range []string{c.Email, c.PreferredUsername, c.Upn} {
+		if strings.Contains(mail, "@") {
+			return mail
+		}
+	}
+	return ""
+}
+
+// ToSubject converts the claims to an authorization subject; roles and groups become the groups of the subject.
+//
+// Returns:
+//   - sharedtypes.Subject: the subject
+func (c *Claims) ToSubject() sharedtypes.Subject {
+	groups := append(slices.Clone(c.Roles), c.Groups...)
+	return sharedtypes.Subject{UserId: c.UserId(), UserMail: c.UserMail(), Groups: groups}
+}
+
+// ApplyToContext sets the UserId and UserMail of a logging context from the claims.
+// Values received from the client are removed if the claims do not contain them.
+//
+// Parameters:
+//   - ctx: the logging context
+func (c *Claims) ApplyToContext(ctx *logging.ContextMap) {
+	if userId := c.UserId(); userId != "" {
+		ctx.Set(logging.UserId, userId)
+	} else {
+		ctx.Delete(logging.UserId)
+	}
+	if userMail := c.UserMail(); userMail != "" {
+		ctx.Set(logging.UserMail, userMail)
+	} else {
+		ctx.Delete(logging.UserMail)
+	}
+}
+
+// TokenValidator validates JWTs signed with the keys of one or more JSON Web Key Sets.
+type TokenValidator struct {
+	jwks      []*JWKSCache
+	Issuers   []string      // accepted issuers; required, tokens are rejected if empty
+	Audiences []string      // accepted audiences; required, tokens are rejected if empty
+	Leeway    time.Duration // tolerated clock skew
+}
+
+// NewTokenValidator creates a validator for tokens signed with the keys at the given JWKS URLs.
+// Issuers and audiences are required: a token signed by a shared identity provider such as
+// Azure AD is otherwise accepted for any application registered there.
+//
+// Parameters:
+//   - issuers: the accepted issuers, e.g. one per Azure AD tenant
+//   - audiences: the accepted audiences, e.g. the application ID URI of the service
+//   - jwksUrls: the URLs of the JSON Web Key Sets, e.g. one per Azure AD tenant
+//
+// Returns:
+//   - *TokenValidator: the validator
+//   - error: an error if no issuer, audience or URL is given or the HTTP client cannot be created
+func NewTokenValidator(issuers []string, audiences []string, jwksUrls ...string) (*TokenValidator, error) {
+	if len(issuers) == 0 {
+		return nil, fmt.Errorf("no token issuer given")
+	}
+	if len(audiences) == 0 {
+		return nil, fmt.Errorf("no token audience given")
+	}
+	if len(jwksUrls) == 0 {
+		return nil, fmt.Errorf("no JWKS URL given")
+	}
+	validator := &TokenValidator{Issuers: issuers, Audiences: audiences, Leeway: time.Minute}
+	for _, url := range jwksUrls {
+		cache, err := NewJWKSCache(url, 0)
+		if err != nil {
+			return nil, err
+		}
+		validator.jwks = append(validator.jwks, cache)
+	}
+	return validator, nil
+}
+
+// NewTokenValidatorFromConfig creates a validator for the JWKS URLs in AZURE_AD_AUTHENTICATION_URLS,
+// or AZURE_AD_AUTHENTICATION_URL if the list is empty, accepting the issuers in AZURE_AD_TOKEN_ISSUERS
+// and the audiences in AZURE_AD_TOKEN_AUDIENCES.
+//
+// Returns:
+//   - *TokenValidator: the validator
+//   - error: an error if no issuer, audience or URL is configured or the HTTP client cannot be created
+func NewTokenValidatorFromConfig() (*TokenValidator, error) {
+	urls := config.Current().AZURE_AD_AUTHENTICATION_URLS
+	if len(urls) == 0 && config.Current().AZURE_AD_AUTHENTICATION_URL != "" {
+		urls = []string{config.Current().AZURE_AD_AUTHENTICATION_URL}
+	}
+	return NewTokenValidator(config.Current().AZURE_AD_TOKEN_ISSUERS, config.Current().AZURE_AD_TOKEN_AUDIENCES, urls...)
+}
+
+// Validate parses a token and checks its signature, expiry, issuer and audience.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - tokenString: the token, with or without "Bearer " prefix
+//
+// Returns:
+//   - *Claims: the claims of the token
+//   - error: an error wrapping ErrInvalidToken if the token is rejected
+func (v *TokenValidator) Validate(ctx context.Context, tokenString string) (*Claims, error) {
+	tokenString = strings.TrimSpace(tokenString)
+	if prefix := "bearer "; len(tokenString) > len(prefix) && strings.EqualFold(tokenString[:len(prefix)], prefix) {
+		tokenString = strings.TrimSpace(tokenString[len(prefix):])
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		var lastErr error
+		for _, cache := range v.jwks {
+			key, err := cache.Key(ctx, kid)
+			if err == nil {
+				return key, nil
+			}
+			lastErr = err
+		}
+		return nil, lastErr
+	}, jwt.WithValidMethods(jwtSigningMethods), jwt.WithExpirationRequired(), jwt.WithLeeway(v.Leeway))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if !slices.Contains(v.Issuers, claims.Issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer '%s'", ErrInvalidToken, claims.Issuer)
+	}
+	if !slices.ContainsFunc(claims.Audience, func(audience string) bool {
+		return slices.Contains(v.Audiences, audience)
+	}) {
+		return nil, fmt.Errorf("%w: unexpected audience %v", ErrInvalidToken, claims.Audience)
+	}
+	return claims, nil
+}
+
+// claimsContextKey is the context key of the validated claims.
+type claimsContextKey struct{}
+
+// ClaimsFromContext returns the claims validated by HTTPJWTMiddleware.
+//
+// Parameters:
+//   - ctx: the context of the request
+//
+// Returns:
+//   - *Claims: the claims
+//   - bool: false if the request was not authenticated with a token
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*Claims)
+	return claims, ok
+}
+
+// HTTPJWTMiddleware authenticates HTTP requests with the bearer token in the Authorization header.
+// Rejected requests are answered with 401 and a problem+json body; the claims are available to
+// the handler via ClaimsFromContext, and the user identity via logging.CreateCtxFromHeader.
+//
+// Parameters:
+//   - validator: the token validator
+//
+// Returns:
+//   - func(http.Handler) http.Handler: the middleware
+func HTTPJWTMiddleware(validator *TokenValidator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, err := validator.Validate(r.Context(), r.Header.Get("Authorization"))
+			logCtx, ctxErr := logging.CreateCtxFromHeader(r)
+			if ctxErr != nil {
+				logCtx = &logging.ContextMap{}
+			}
+			if err != nil {
+				logging.Log.Warnf(logCtx, "rejected request to %s: %v", r.URL.Path, err)
+				writeAuthError(w, r, ErrInvalidToken)
+				return
+			}
+			claims.ApplyToContext(logCtx)
+			ctx := logging.ContextWithLogContext(r.Context(), logCtx)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, claimsContextKey{}, claims)))
+		})
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// newTestJWKS serves a JWKS with one RSA key and returns the private key and the number of fetches.
+func newTestJWKS(t *testing.T, kid string) (*httptest.Server, *rsa.PrivateKey, *atomic.Int32) {
+	t.Helper()
+	logging.InitForTest()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	fetches := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []jsonWebKey{{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+		}}})
+	}))
+	t.Cleanup(server.Close)
+	return server, privateKey, fetches
+}
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, kid string, claims Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return signed
+}
+
+func TestTokenValidator(t *testing.T) {
+	server, privateKey, fetches := newTestJWKS(t, "key-1")
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	validator, err := NewTokenValidator([]string{"https://issuer"}, []string{"aali"}, server.URL)
+	if err != nil {
+		t.Fatalf("NewTokenValidator() error = %v", err)
+	}
+
+	valid := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "https://issuer",
+			Subject:   "sub-1",
+			Audience:  jwt.ClaimStrings{"aali"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		ObjectId:          "oid-1",
+		PreferredUsername: "alice@example.com",
+		Roles:             []string{"analysts"},
+	}
+	withIssuer := func(issuer string) Claims { c := valid; c.Issuer = issuer; return c }
+	withAudience := func(audience string) Claims { c := valid; c.Audience = jwt.ClaimStrings{audience}; return c }
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	noExpiry := valid
+	noExpiry.ExpiresAt = nil
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid", signTestToken(t, privateKey, "key-1", valid), false},
+		{"bearer prefix", "Bearer " + signTestToken(t, privateKey, "key-1", valid), false},
+		{"wrong issuer", signTestToken(t, privateKey, "key-1", withIssuer("https://other")), true},
+		{"wrong audience", signTestToken(t, privateKey, "key-1", withAudience("other")), true},
+		{"expired", signTestToken(t, privateKey, "key-1", expired), true},
+		{"no expiry", signTestToken(t, privateKey, "key-1", noExpiry), true},
+		{"wrong signature", signTestToken(t, otherKey, "key-1", valid), true},
+		{"unknown key", signTestToken(t, privateKey, "key-2", valid), true},
+		{"malformed", "not-a-token", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := validator.Validate(context.Background(), tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("Validate() error = %v, want ErrInvalidToken", err)
+			}
+			if err == nil && claims.UserId() != "oid-1" {
+				t.Errorf("UserId() = %q, want oid-1", claims.UserId())
+			}
+		})
+	}
+
+	// the unknown key triggers at most one refresh within the refresh interval
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", got)
+	}
+}
+
+func TestNewTokenValidatorRequiresIssuerAndAudience(t *testing.T) {
+	tests := []struct {
+		name      string
+		issuers   []string
+		audiences []string
+		urls      []string
+	}{
+		{"no issuer", nil, []string{"aali"}, []string{"https://jwks"}},
+		{"no audience", []string{"https://issuer"}, nil, []string{"https://jwks"}},
+		{"no JWKS URL", []string{"https://issuer"}, []string{"aali"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTokenValidator(tt.issuers, tt.audiences, tt.urls...); err == nil {
+				t.Error("NewTokenValidator() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestJWKSCacheFetchesWithoutLock(t *testing.T) {
+	server, _, _ := newTestJWKS(t, "key-1")
+	release := make(chan struct{})
+	blocking := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(blocking.Close)
+
+	cache, err := NewJWKSCache(server.URL, time.Hour)
+	if err != nil {
+		t.Fatalf("NewJWKSCache() error = %v", err)
+	}
+	if _, err := cache.Key(context.Background(), "key-1"); err != nil {
+		t.Fatalf("Key() error = %v", err)
+	}
+
+	// expire the keys and let the next refresh hang on the JWKS endpoint
+	cache.mu.Lock()
+	cache.url = blocking.URL
+	cache.fetchedAt = time.Time{}
+	cache.lastAttempt = time.Time{}
+	cache.mu.Unlock()
+	refreshed := make(chan error, 1)
+	go func() {
+		_, err := cache.Key(context.Background(), "key-1")
+		refreshed <- err
+	}()
+	for {
+		cache.mu.Lock()
+		running := cache.refreshing != nil
+		cache.mu.Unlock()
+		if running {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// the cached key is served while the refresh is running, and unknown keys wait for it
+	if _, err := cache.Key(context.Background(), "key-1"); err != nil {
+		t.Errorf("Key() during refresh error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := cache.Key(ctx, "key-2"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Key() of unknown key during refresh error = %v, want context.DeadlineExceeded", err)
+	}
+
+	close(release)
+	if err := <-refreshed; err != nil {
+		t.Errorf("Key() with refresh error = %v", err)
+	}
+}
+
+func TestClaimsMapping(t *testing.T) {
+	claims := Claims{
+		RegisteredClaims:  jwt.RegisteredClaims{Subject: "sub-1"},
+		PreferredUsername: "not-a-mail",
+		Upn:               "alice@example.com",
+		Roles:             []string{"admins"},
+		Groups:            []string{"g1"},
+	}
+	if claims.UserId() != "sub-1" {
+		t.Errorf("UserId() = %q, want sub-1", claims.UserId())
+	}
+	if claims.UserMail() != "alice@example.com" {
+		t.Errorf("UserMail() = %q, want alice@example.com", claims.UserMail())
+	}
+
+	ctx := &logging.ContextMap{}
+	claims.ApplyToContext(ctx)
+	if userId, _ := ctx.Get(logging.UserId); userId != "sub-1" {
+		t.Errorf("UserId in context = %v", userId)
+	}
+	if userMail, _ := ctx.Get(logging.UserMail); userMail != "alice@example.com" {
+		t.Errorf("UserMail in context = %v", userMail)
+	}
+
+	// values of a previous identity are not kept
+	(&Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "sub-2"}}).ApplyToContext(ctx)
+	if userMail, ok := ctx.Get(logging.UserMail); ok {
+		t.Errorf("UserMail in context = %v, want it removed", userMail)
+	}
+
+	subject := claims.ToSubject()
+	if subject.UserId != "sub-1" || len(subject.Groups) != 2 {
+		t.Errorf("ToSubject() = %+v", subject)
+	}
+}
+
+func TestHTTPJWTMiddleware(t *testing.T) {
+	server, privateKey, _ := newTestJWKS(t, "key-1")
+	validator, err := NewTokenValidator([]string{"https://issuer"}, []string{"aali"}, server.URL)
+	if err != nil {
+		t.Fatalf("NewTokenValidator() error = %v", err)
+	}
+	var userId string
+	handler := HTTPJWTMiddleware(validator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, _ := ClaimsFromContext(r.Context())
+		userId = claims.UserId()
+	}))
+
+	token := signTestToken(t, privateKey, "key-1", Claims{RegisteredClaims: jwt.RegisteredClaims{
+		Issuer:    "https://issuer",
+		Subject:   "alice",
+		Audience:  jwt.ClaimStrings{"aali"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}})
+	for _, tt := range []struct {
+		header   string
+		wantCode int
+	}{
+		{"Bearer " + token, http.StatusOK},
+		{"", http.StatusUnauthorized},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", tt.header)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		if recorder.Code != tt.wantCode {
+			t.Errorf("status with header %q = %d, want %d", tt.header, recorder.Code, tt.wantCode)
+		}
+	}
+	if userId != "alice" {
+		t.Errorf("user ID in handler = %q, want alice", userId)
+	}
+}
+
+func TestHTTPJWTMiddlewareOverridesAssertedUser(t *testing.T) {
+	server, privateKey, _ := newTestJWKS(t, "key-1")
+	validator, err := NewTokenValidator([]string{"https://issuer"}, []string{"aali"}, server.URL)
+	if err != nil {
+		t.Fatalf("NewTokenValidator() error = %v", err)
+	}
+	var logCtx *logging.ContextMap
+	handler := HTTPJWTMiddleware(validator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logCtx, _ = logging.CreateCtxFromHeader(r)
+	}))
+
+	token := signTestToken(t, privateKey, "key-1", Claims{RegisteredClaims: jwt.RegisteredClaims{
+		Issuer:    "https://issuer",
+		Subject:   "alice",
+		Audience:  jwt.ClaimStrings{"aali"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("aali-logging-context", `[{"userId":"mallory","workflowId":"wf-1"}]`)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if logCtx == nil {
+		t.Fatal("handler was not called")
+	}
+	if userId, _ := logCtx.GetString(logging.UserId); userId != "alice" || logCtx.IsClientAsserted(logging.UserId) {
+		t.Errorf("userId = %q, client-asserted = %v, want authenticated alice", userId, logCtx.IsClientAsserted(logging.UserId))
+	}
+	if !logCtx.IsClientAsserted(logging.WorkflowId) {
+		t.Error("workflowId received from the client is not client-asserted")
+	}
+}
@@ -0,0 +1,440 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package agentclient provides a client for the WorkflowRun gRPC service of aali-agent.
+//
+// WorkflowRunStream wraps the bidirectional RunWorkflow stream and reconnects it after transient
+// network errors. Servers supporting resumption send a resume token in the ConnectionStatus message
+// and number their messages; after a reconnect the client sends the token and the sequence number of
+// the last received message in a new SessionContext, and the server resumes the workflow run,
+// sending the messages the client missed. Streams of servers without resume tokens are not reconnected.
+package agentclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aaliagentgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/clients"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/netutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Default reconnect settings if AGENT_STREAM_RECONNECT_ATTEMPTS and AGENT_STREAM_RECONNECT_BACKOFF_MS are not set
+const (
+	DefaultReconnectAttempts = 5
+	DefaultReconnectBackoff  = 500 * time.Millisecond
+	maxReconnectBackoff      = 30 * time.Second
+)
+
+// ReconnectMetricName is the name of the metric sent for each reconnect of a WorkflowRun stream
+const ReconnectMetricName = "aali.agent.stream.reconnects"
+
+// ReconnectPolicy defines how a broken WorkflowRun stream is reconnected
+type ReconnectPolicy struct {
+	MaxAttempts    int           // reconnect attempts per broken stream; 0 disables reconnecting
+	InitialBackoff time.Duration // wait time before the first attempt; doubled for every further attempt
+	MaxBackoff     time.Duration // maximum wait time between attempts
+}
+
+// ReconnectPolicyFromConfig returns the reconnect policy of the global configuration
+//
+// Returns:
+//   - ReconnectPolicy: the policy from AGENT_STREAM_RECONNECT_ATTEMPTS and AGENT_STREAM_RECONNECT_BACKOFF_MS
+func ReconnectPolicyFromConfig() ReconnectPolicy {
+	policy := ReconnectPolicy{
+		MaxAttempts:    DefaultReconnectAttempts,
+		InitialBackoff: DefaultReconnectBackoff,
+		MaxBackoff:     maxReconnectBackoff,
+	}
+	if config.Current() == nil {
+		return policy
+	}
+	switch attempts := config.Current().AGENT_STREAM_RECONNECT_ATTEMPTS; {
+	case attempts < 0:
+		policy.MaxAttempts = 0
+	case attempts > 0:
+		policy.MaxAttempts = attempts
+	}
+	if config.Current().AGENT_STREAM_RECONNECT_BACKOFF_MS > 0 {
+		policy.InitialBackoff = time.Duration(config.Current().AGENT_STREAM_RECONNECT_BACKOFF_MS) * time.Millisecond
+	}
+	return policy
+}
+
+// backoff returns the wait time before a reconnect attempt
+//
+// Parameters:
+//   - attempt: the attempt, starting at 1
+//
+// Returns:
+//   - time.Duration: the wait time
+func (p ReconnectPolicy) backoff(attempt int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+		if p.MaxBackoff > 0 && wait >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return wait
+}
+
+// NewWorkflowRunClient creates a client for the WorkflowRun service at the given endpoint
+// The connection uses the shared gRPC dial options, including the keepalive settings and the global message size limits.
+//
+// Parameters:
+//   - endpoint: the URL of aali-agent, e.g. "http://localhost:50051"
+//
+// Returns:
+//   - client: the WorkflowRun client
+//   - connection: the connection; to be closed by the caller
+//   - err: an error if the endpoint is invalid or the connection cannot be created
+func NewWorkflowRunClient(endpoint string) (client aaliagentgrpc.WorkflowRunClient, connection *grpc.ClientConn, err error) {
+	parsed, err := netutil.ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, nil, aalierrors.Wrap(nil, aalierrors.CodeValidation, err, "invalid agent endpoint")
+	}
+	scheme := "http"
+	if parsed.TLS {
+		scheme = "https"
+	}
+
+	opts, err := clients.GetGrpcDialOptions(scheme)
+	if err != nil {
+		return nil, nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "unable to get gRPC dial options")
+	}
+	opts = append(opts, clients.GetGrpcMessageSizeDialOptions(clients.MessageSizeLimitsFromConfig(clients.MessageSizeLimits{}))...)
+
+	connection, err = grpc.NewClient(parsed.Address(), opts...)
+	if err != nil {
+		return nil, nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "unable to connect to agent gRPC")
+	}
+	return aaliagentgrpc.NewWorkflowRunClient(connection), connection, nil
+}
+
+// WorkflowRunStream is a RunWorkflow stream reconnecting after transient network errors
+// Send and Recv can be called concurrently from one sending and one receiving goroutine, or one after
+// the other from a single goroutine: the messages of the server are buffered until Recv is called,
+// so a Send waiting for a reconnect does not depend on a concurrent Recv.
+type WorkflowRunStream struct {
+	ctx      context.Context
+	client   aaliagentgrpc.WorkflowRunClient
+	session  *aaliagentgrpc.SessionContext
+	policy   ReconnectPolicy
+	callOpts []grpc.CallOption
+
+	mu         sync.Mutex
+	changed    *sync.Cond // signalled when a message is received, or the stream is replaced or fails
+	pending    []*aaliagentgrpc.ServerMessage
+	stream     aaliagentgrpc.WorkflowRun_RunWorkflowClient
+	generation int
+	err        error // terminal error, io.EOF if the server ended the stream
+
+	// owned by the receiving goroutine
+	resumeToken  string
+	lastSequence uint64
+	resumed      bool
+
+	sendMu     sync.Mutex
+	sendClosed bool
+}
+
+// OpenWorkflowRun opens a RunWorkflow stream and sends the session context
+// The ConnectionStatus answer of the server is returned by the first call of Recv.
+//
+// Parameters:
+//   - ctx: the context of the stream; cancelling it ends the stream without reconnecting
+//   - client: the WorkflowRun client
+//   - session: the session context
+//   - policy: the reconnect policy
+//   - opts: the call options of the stream
+//
+// Returns:
+//   - stream: the stream
+//   - err: an error if the stream cannot be opened
+func OpenWorkflowRun(ctx context.Context, client aaliagentgrpc.WorkflowRunClient, session *aaliagentgrpc.SessionContext, policy ReconnectPolicy, opts ...grpc.CallOption) (stream *WorkflowRunStream, err error) {
+	stream = &WorkflowRunStream{
+		ctx:      ctx,
+		client:   client,
+		session:  session,
+		policy:   policy,
+		callOpts: opts,
+	}
+	stream.changed = sync.NewCond(&stream.mu)
+
+	stream.stream, err = stream.open(session)
+	if err != nil {
+		return nil, err
+	}
+	go stream.receive()
+	return stream, nil
+}
+
+// open opens a RunWorkflow stream and sends a session context
+//
+// Parameters:
+//   - session: the session context
+//
+// Returns:
+//   - grpcStream: the stream
+//   - err: an error if the stream cannot be opened or the session context cannot be sent
+func (s *WorkflowRunStream) open(session *aaliagentgrpc.SessionContext) (grpcStream aaliagentgrpc.WorkflowRun_RunWorkflowClient, err error) {
+	grpcStream, err = s.client.RunWorkflow(s.ctx, s.callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	err = grpcStream.Send(&aaliagentgrpc.ClientMessage{
+		MessageType: &aaliagentgrpc.ClientMessage_SessionContext{SessionContext: session},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return grpcStream, nil
+}
+
+// Recv returns the next message of the server
+// Messages sent again by the server after a reconnect are skipped.
+//
+// Returns:
+//   - message: the message
+//   - err: io.EOF if the server ended the workflow run, or the error that ended the stream
+func (s *WorkflowRunStream) Recv() (message *aaliagentgrpc.ServerMessage, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.pending) == 0 && s.err == nil {
+		s.changed.Wait()
+	}
+	if len(s.pending) == 0 {
+		return nil, s.err
+	}
+	message = s.pending[0]
+	s.pending[0] = nil
+	s.pending = s.pending[1:]
+	return message, nil
+}
+
+// Send sends a request to the server
+// If the stream breaks, the request is sent again once the stream is reconnected.
+//
+// Parameters:
+//   - request: the request
+//
+// Returns:
+//   - err: an error if the request cannot be sent, io.EOF if the stream has ended
+func (s *WorkflowRunStream) Send(request *aaliagentgrpc.ClientRequest) (err error) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	message := &aaliagentgrpc.ClientMessage{
+		MessageType: &aaliagentgrpc.ClientMessage_ClientRequest{ClientRequest: request},
+	}
+	for {
+		s.mu.Lock()
+		grpcStream, generation, terminal := s.stream, s.generation, s.err
+		s.mu.Unlock()
+		if terminal != nil {
+			return terminal
+		}
+
+		err = grpcStream.Send(message)
+		// errors other than io.EOF are created by the client and not caused by the connection
+		if err == nil || (err != io.EOF && !isTransient(err)) {
+			return err
+		}
+
+		// the receiving goroutine discovers the status of the stream and reconnects it
+		s.mu.Lock()
+		for s.generation == generation && s.err == nil {
+			s.changed.Wait()
+		}
+		s.mu.Unlock()
+	}
+}
+
+// CloseSend closes the sending direction of the stream, also after reconnects
+//
+// Returns:
+//   - err: an error if the sending direction cannot be closed
+func (s *WorkflowRunStream) CloseSend() (err error) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sendClosed = true
+	return s.stream.CloseSend()
+}
+
+// receive receives the messages of the server, reconnecting the stream after transient errors
+// The messages are queued for Recv without waiting for it, so the stream is reconnected even while
+// the caller is blocked in Send.
+func (s *WorkflowRunStream) receive() {
+	s.mu.Lock()
+	grpcStream := s.stream
+	s.mu.Unlock()
+
+	for {
+		message, err := grpcStream.Recv()
+		if err != nil {
+			if !s.resumable(err) {
+				s.fail(err)
+				return
+			}
+			grpcStream, err = s.reconnect(err)
+			if err != nil {
+				s.fail(err)
+				return
+			}
+			continue
+		}
+
+		if !s.track(message) {
+			continue
+		}
+		s.mu.Lock()
+		s.pending = append(s.pending, message)
+		s.changed.Broadcast()
+		s.mu.Unlock()
+	}
+}
+
+// track records the resume token and sequence number of a message
+//
+// Parameters:
+//   - message: the message of the server
+//
+// Returns:
+//   - deliver: false if the message is a duplicate or the connection status of a resumed stream
+func (s *WorkflowRunStream) track(message *aaliagentgrpc.ServerMessage) (deliver bool) {
+	if connectionStatus := message.GetConnectionStatus(); connectionStatus != nil {
+		if connectionStatus.ResumeToken != "" {
+			s.resumeToken = connectionStatus.ResumeToken
+		}
+		if s.resumed {
+			s.resumed = false
+			if connectionStatus.ConnectionStatus == "success" {
+				return false
+			}
+		}
+	}
+
+	if message.Sequence != 0 {
+		if message.Sequence <= s.lastSequence {
+			return false
+		}
+		s.lastSequence = message.Sequence
+	}
+	return true
+}
+
+// resumable checks if the stream can be reconnected after an error
+//
+// Parameters:
+//   - err: the error of the stream
+//
+// Returns:
+//   - bool: true if the error is transient and the server supports resuming
+func (s *WorkflowRunStream) resumable(err error) bool {
+	return s.resumeToken != "" && s.policy.MaxAttempts > 0 && s.ctx.Err() == nil && isTransient(err)
+}
+
+// reconnect opens a new stream resuming the workflow run
+//
+// Parameters:
+//   - cause: the error of the broken stream
+//
+// Returns:
+//   - grpcStream: the new stream
+//   - err: the error of the last attempt if all attempts failed
+func (s *WorkflowRunStream) reconnect(cause error) (grpcStream aaliagentgrpc.WorkflowRun_RunWorkflowClient, err error) {
+	session := proto.Clone(s.session).(*aaliagentgrpc.SessionContext)
+	session.ResumeToken = s.resumeToken
+	session.LastReceivedSequence = s.lastSequence
+
+	err = cause
+	for attempt := 1; attempt <= s.policy.MaxAttempts; attempt++ {
+		wait := s.policy.backoff(attempt)
+		logging.Log.Warnf(&logging.ContextMap{}, "WorkflowRun stream broken (%v), reconnecting in %s (attempt %d/%d)", err, wait, attempt, s.policy.MaxAttempts)
+		select {
+		case <-time.After(wait):
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		}
+
+		grpcStream, err = s.open(session)
+		if err != nil {
+			if !isTransient(err) && err != io.EOF {
+				return nil, err
+			}
+			continue
+		}
+
+		s.mu.Lock()
+		if s.sendClosed {
+			grpcStream.CloseSend()
+		}
+		s.stream = grpcStream
+		s.generation++
+		s.changed.Broadcast()
+		s.mu.Unlock()
+
+		s.resumed = true
+		logging.Log.Metrics(ReconnectMetricName, 1)
+		return grpcStream, nil
+	}
+	return nil, err
+}
+
+// fail ends the stream with a terminal error, waking up a waiting sender and receiver
+//
+// Parameters:
+//   - err: the error
+func (s *WorkflowRunStream) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+	s.changed.Broadcast()
+}
+
+// isTransient checks if an error is caused by a transient network problem
+//
+// Parameters:
+//   - err: the error
+//
+// Returns:
+//   - bool: true if the error is transient
+func isTransient(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	return status.Code(err) == codes.Unavailable
+}
@@ -0,0 +1,280 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package agentclient
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aaliagentgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// resumingServer breaks the first stream after two messages and resumes the run on the next stream
+type resumingServer struct {
+	aaliagentgrpc.UnimplementedWorkflowRunServer
+	connections atomic.Int32
+	resumed     chan *aaliagentgrpc.SessionContext
+	requests    chan string
+}
+
+func (s *resumingServer) RunWorkflow(stream aaliagentgrpc.WorkflowRun_RunWorkflowServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	session := first.GetSessionContext()
+	output := func(sequence uint64) *aaliagentgrpc.ServerMessage {
+		return &aaliagentgrpc.ServerMessage{
+			Sequence:    sequence,
+			MessageType: &aaliagentgrpc.ServerMessage_ClientResponse{ClientResponse: &aaliagentgrpc.ClientResponse{Type: "output"}},
+		}
+	}
+	connectionStatus := &aaliagentgrpc.ServerMessage{
+		MessageType: &aaliagentgrpc.ServerMessage_ConnectionStatus{ConnectionStatus: &aaliagentgrpc.ConnectionStatus{ConnectionStatus: "success", ResumeToken: "token-1"}},
+	}
+
+	if s.connections.Add(1) == 1 {
+		for _, message := range []*aaliagentgrpc.ServerMessage{connectionStatus, output(1), output(2)} {
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+		}
+		return status.Error(codes.Unavailable, "connection lost")
+	}
+
+	s.resumed <- session
+	// the client re-sends requests that failed while the stream was broken
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	s.requests <- request.GetClientRequest().GetInstructionId()
+	// replay the last message the client has seen, which must be skipped
+	for _, message := range []*aaliagentgrpc.ServerMessage{connectionStatus, output(2), output(3)} {
+		if err := stream.Send(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startServer serves the WorkflowRun service on an in-memory connection
+func startServer(t *testing.T, server aaliagentgrpc.WorkflowRunServer) aaliagentgrpc.WorkflowRunClient {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	aaliagentgrpc.RegisterWorkflowRunServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	connection, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { connection.Close() })
+	return aaliagentgrpc.NewWorkflowRunClient(connection)
+}
+
+func TestWorkflowRunStreamResumes(t *testing.T) {
+	capture := logging.CaptureForTest(t)
+	server := &resumingServer{resumed: make(chan *aaliagentgrpc.SessionContext, 1), requests: make(chan string, 1)}
+	client := startServer(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	policy := ReconnectPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+	stream, err := OpenWorkflowRun(ctx, client, &aaliagentgrpc.SessionContext{WorkflowId: "workflow"}, policy)
+	if err != nil {
+		t.Fatalf("OpenWorkflowRun() error = %v", err)
+	}
+
+	message, err := stream.Recv()
+	if err != nil || message.GetConnectionStatus() == nil {
+		t.Fatalf("first Recv() = %v, %v, want connection status", message, err)
+	}
+	var sequences []uint64
+	for len(sequences) < 2 {
+		message, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		sequences = append(sequences, message.Sequence)
+	}
+
+	// the request reaches the server on the resumed stream
+	if err := stream.Send(&aaliagentgrpc.ClientRequest{InstructionId: "request-1"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	session := <-server.resumed
+	if session.WorkflowId != "workflow" || session.ResumeToken != "token-1" || session.LastReceivedSequence != 2 {
+		t.Errorf("resumed session = %v", session)
+	}
+	if got := <-server.requests; got != "request-1" {
+		t.Errorf("request after resume = %q, want request-1", got)
+	}
+
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		if message.GetConnectionStatus() != nil {
+			t.Errorf("connection status of resumed stream delivered: %v", message)
+		}
+		sequences = append(sequences, message.Sequence)
+	}
+	if len(sequences) != 3 || sequences[0] != 1 || sequences[1] != 2 || sequences[2] != 3 {
+		t.Errorf("received sequences %v, want [1 2 3]", sequences)
+	}
+	if !capture.Contains(zapcore.WarnLevel, "reconnecting") {
+		t.Errorf("warnings = %q, want reconnect warning", capture.Messages(zapcore.WarnLevel))
+	}
+}
+
+func TestWorkflowRunStreamSingleGoroutine(t *testing.T) {
+	logging.CaptureForTest(t)
+	server := &resumingServer{resumed: make(chan *aaliagentgrpc.SessionContext, 1), requests: make(chan string, 1)}
+	client := startServer(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	policy := ReconnectPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+	stream, err := OpenWorkflowRun(ctx, client, &aaliagentgrpc.SessionContext{WorkflowId: "workflow"}, policy)
+	if err != nil {
+		t.Fatalf("OpenWorkflowRun() error = %v", err)
+	}
+
+	// the stream is reconnected although no message of the broken stream has been received yet
+	select {
+	case <-server.resumed:
+	case <-ctx.Done():
+		t.Fatal("stream not reconnected while its messages were not received")
+	}
+	if err := stream.Send(&aaliagentgrpc.ClientRequest{InstructionId: "request-1"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if got := <-server.requests; got != "request-1" {
+		t.Errorf("request after resume = %q, want request-1", got)
+	}
+
+	var sequences []uint64
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		if message.Sequence != 0 {
+			sequences = append(sequences, message.Sequence)
+		}
+	}
+	if len(sequences) != 3 || sequences[0] != 1 || sequences[1] != 2 || sequences[2] != 3 {
+		t.Errorf("received sequences %v, want [1 2 3]", sequences)
+	}
+}
+
+// failingServer ends every stream with an error
+type failingServer struct {
+	aaliagentgrpc.UnimplementedWorkflowRunServer
+	connections atomic.Int32
+}
+
+func (s *failingServer) RunWorkflow(stream aaliagentgrpc.WorkflowRun_RunWorkflowServer) error {
+	s.connections.Add(1)
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	return status.Error(codes.Unavailable, "connection lost")
+}
+
+func TestWorkflowRunStreamWithoutResumeToken(t *testing.T) {
+	logging.CaptureForTest(t)
+	server := &failingServer{}
+	client := startServer(t, server)
+
+	policy := ReconnectPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	stream, err := OpenWorkflowRun(context.Background(), client, &aaliagentgrpc.SessionContext{}, policy)
+	if err != nil {
+		t.Fatalf("OpenWorkflowRun() error = %v", err)
+	}
+	_, err = stream.Recv()
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Recv() error = %v, want Unavailable", err)
+	}
+	if err := stream.Send(&aaliagentgrpc.ClientRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Send() after failure error = %v, want Unavailable", err)
+	}
+	if got := server.connections.Load(); got != 1 {
+		t.Errorf("server saw %d connections, want 1", got)
+	}
+}
+
+func TestReconnectPolicy(t *testing.T) {
+	previous := config.GlobalConfig
+	t.Cleanup(func() { config.GlobalConfig = previous })
+
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		want ReconnectPolicy
+	}{
+		{"no config", nil, ReconnectPolicy{DefaultReconnectAttempts, DefaultReconnectBackoff, maxReconnectBackoff}},
+		{"defaults", &config.Config{}, ReconnectPolicy{DefaultReconnectAttempts, DefaultReconnectBackoff, maxReconnectBackoff}},
+		{"disabled", &config.Config{AGENT_STREAM_RECONNECT_ATTEMPTS: -1}, ReconnectPolicy{0, DefaultReconnectBackoff, maxReconnectBackoff}},
+		{"custom", &config.Config{AGENT_STREAM_RECONNECT_ATTEMPTS: 2, AGENT_STREAM_RECONNECT_BACKOFF_MS: 100}, ReconnectPolicy{2, 100 * time.Millisecond, maxReconnectBackoff}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.GlobalConfig = tt.cfg
+			if got := ReconnectPolicyFromConfig(); got != tt.want {
+				t.Errorf("ReconnectPolicyFromConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	policy := ReconnectPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		if got := policy.backoff(attempt); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+}
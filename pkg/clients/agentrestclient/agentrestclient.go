@@ -0,0 +1,373 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package agentrestclient provides a client for the workflow run REST API of aali-agent.
+//
+// The client talks to these endpoints:
+//   - POST /v1/workflow-runs                 starts a run from a StartWorkflowRunRequest and returns its WorkflowRunStatusResponse
+//   - GET  /v1/workflow-runs/{id}            returns the WorkflowRunStatusResponse of a run
+//   - GET  /v1/workflow-runs                 returns a ListWorkflowRunsResponse; query parameters "workflow_id", "user_id", "status", "page_size" and "cursor"
+//   - GET  /v1/workflow-runs/{id}/history    returns the WorkflowRunHistoryResponse of a run
+//
+// Failed requests are answered with an ApiErrorResponse or a problem+json ProblemDetails; its ApiError or
+// ProblemDetails is returned wrapped in an aalierrors.Error, so callers can inspect it with errors.As.
+package agentrestclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/clients"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/netutil"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// Default settings of a new Client.
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryBackoff = 200 * time.Millisecond
+	DefaultPollInterval = time.Second
+)
+
+// workflowRunsPath is the API path of the workflow runs.
+const workflowRunsPath = "/v1/workflow-runs"
+
+// Client is a client for the workflow run REST API of aali-agent.
+// Reading requests failing with a network error, 429 or 5xx status are retried with exponential backoff.
+// Starting a run is only retried on 429, because the agent may have started the run before another failure.
+type Client struct {
+	endpoint    string
+	apiKey      string
+	bearerToken string
+	httpClient  *http.Client
+	logCtx      *logging.ContextMap
+
+	MaxRetries   int           // number of retries after the first attempt
+	RetryBackoff time.Duration // wait time before the first retry; doubled for every further retry; a longer Retry-After of the agent takes precedence
+}
+
+// NewClient creates a client for the agent at the given endpoint.
+//
+// Parameters:
+//   - endpoint: the URL of the agent REST API, e.g. "http://localhost:8080"; "http" is assumed if the scheme is omitted
+//   - apiKey: the API key sent in the "api-key" header; not sent if empty
+//
+// Returns:
+//   - *Client: the client
+//   - error: an error if the endpoint is invalid or the HTTP client cannot be created
+func NewClient(endpoint string, apiKey string) (*Client, error) {
+	if endpoint == "" {
+		return nil, aalierrors.New(nil, aalierrors.CodeValidation, "agent endpoint is empty")
+	}
+	parsed, err := netutil.ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, aalierrors.Wrap(nil, aalierrors.CodeValidation, err, "invalid agent endpoint")
+	}
+	httpClient, err := clients.GetHttpClient()
+	if err != nil {
+		return nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "error getting HTTP client")
+	}
+	return &Client{
+		endpoint:     parsed.URL(),
+		apiKey:       apiKey,
+		httpClient:   httpClient,
+		logCtx:       &logging.ContextMap{},
+		MaxRetries:   DefaultMaxRetries,
+		RetryBackoff: DefaultRetryBackoff,
+	}, nil
+}
+
+// NewClientFromConfig creates a client for the agent configured in AGENT_REST_ENDPOINT, or AGENT_ENDPOINT if empty,
+// with the API key of WORKFLOW_API_KEY.
+//
+// Returns:
+//   - *Client: the client
+//   - error: an error if the config is not initialized, the endpoint is invalid or the HTTP client cannot be created
+func NewClientFromConfig() (*Client, error) {
+	cfg := config.Current()
+	if cfg == nil {
+		return nil, aalierrors.New(nil, aalierrors.CodeValidation, "config is not initialized")
+	}
+	endpoint := cfg.AGENT_REST_ENDPOINT
+	if endpoint == "" {
+		endpoint = cfg.AGENT_ENDPOINT
+	}
+	return NewClient(endpoint, cfg.WORKFLOW_API_KEY)
+}
+
+// WithLogContext returns a copy of the client that logs with the given context
+// and forwards it to the agent in the "aali-logging-context" header.
+//
+// Parameters:
+//   - logCtx: the logging context
+//
+// Returns:
+//   - *Client: the copy of the client
+func (c *Client) WithLogContext(logCtx *logging.ContextMap) *Client {
+	copied := *c
+	copied.logCtx = logCtx
+	return &copied
+}
+
+// WithBearerToken returns a copy of the client that authenticates with a JWT in the "Authorization" header,
+// e.g. to start runs on behalf of a user.
+//
+// Parameters:
+//   - token: the token, without "Bearer " prefix
+//
+// Returns:
+//   - *Client: the copy of the client
+func (c *Client) WithBearerToken(token string) *Client {
+	copied := *c
+	copied.bearerToken = token
+	return &copied
+}
+
+// StartWorkflowRun starts a workflow run.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - request: the workflow and its inputs
+//
+// Returns:
+//   - sharedtypes.WorkflowRunStatusResponse: the status of the new run
+//   - error: an error if the request is invalid or fails
+func (c *Client) StartWorkflowRun(ctx context.Context, request sharedtypes.StartWorkflowRunRequest) (sharedtypes.WorkflowRunStatusResponse, error) {
+	if err := request.Validate(); err != nil {
+		return sharedtypes.WorkflowRunStatusResponse{}, aalierrors.Wrap(c.logCtx, aalierrors.CodeValidation, err, "invalid workflow run request")
+	}
+	var response sharedtypes.WorkflowRunStatusResponse
+	if err := c.do(ctx, http.MethodPost, workflowRunsPath, request, &response); err != nil {
+		return sharedtypes.WorkflowRunStatusResponse{}, err
+	}
+	return response, nil
+}
+
+// GetWorkflowRun reads the status of a workflow run.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - workflowRunId: the ID of the run
+//
+// Returns:
+//   - sharedtypes.WorkflowRunStatusResponse: the status of the run
+//   - error: an error if the request fails, with ApiError code "not_found" if the run does not exist
+func (c *Client) GetWorkflowRun(ctx context.Context, workflowRunId string) (sharedtypes.WorkflowRunStatusResponse, error) {
+	var response sharedtypes.WorkflowRunStatusResponse
+	if err := c.do(ctx, http.MethodGet, runPath(workflowRunId), nil, &response); err != nil {
+		return sharedtypes.WorkflowRunStatusResponse{}, err
+	}
+	return response, nil
+}
+
+// ListWorkflowRuns reads a page of workflow runs.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - request: the filters and the page
+//
+// Returns:
+//   - sharedtypes.ListWorkflowRunsResponse: the page of runs
+//   - error: an error if the request is invalid or fails
+func (c *Client) ListWorkflowRuns(ctx context.Context, request sharedtypes.ListWorkflowRunsRequest) (sharedtypes.ListWorkflowRunsResponse, error) {
+	if err := request.Validate(); err != nil {
+		return sharedtypes.ListWorkflowRunsResponse{}, aalierrors.Wrap(c.logCtx, aalierrors.CodeValidation, err, "invalid workflow run list request")
+	}
+	query := url.Values{}
+	for key, value := range map[string]string{"workflow_id": request.WorkflowId, "user_id": request.UserId, "status": request.Status, "cursor": request.Cursor} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	query.Set("page_size", strconv.Itoa(request.PageRequest.Normalize().PageSize))
+
+	var response sharedtypes.ListWorkflowRunsResponse
+	if err := c.do(ctx, http.MethodGet, workflowRunsPath+"?"+query.Encode(), nil, &response); err != nil {
+		return sharedtypes.ListWorkflowRunsResponse{}, err
+	}
+	return response, nil
+}
+
+// GetWorkflowRunHistory reads the conversation history of a workflow run.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - workflowRunId: the ID of the run
+//
+// Returns:
+//   - sharedtypes.WorkflowRunHistoryResponse: the messages of the run, oldest first
+//   - error: an error if the request fails, with ApiError code "not_found" if the run does not exist
+func (c *Client) GetWorkflowRunHistory(ctx context.Context, workflowRunId string) (sharedtypes.WorkflowRunHistoryResponse, error) {
+	var response sharedtypes.WorkflowRunHistoryResponse
+	if err := c.do(ctx, http.MethodGet, runPath(workflowRunId)+"/history", nil, &response); err != nil {
+		return sharedtypes.WorkflowRunHistoryResponse{}, err
+	}
+	return response, nil
+}
+
+// WaitForWorkflowRun polls the status of a workflow run until it has finished.
+//
+// Parameters:
+//   - ctx: the context; polling stops when it is done
+//   - workflowRunId: the ID of the run
+//   - pollInterval: the time between two polls; DefaultPollInterval if 0
+//
+// Returns:
+//   - sharedtypes.WorkflowRunStatusResponse: the final status of the run
+//   - error: an error if a poll fails or the context is done
+func (c *Client) WaitForWorkflowRun(ctx context.Context, workflowRunId string, pollInterval time.Duration) (sharedtypes.WorkflowRunStatusResponse, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	for {
+		status, err := c.GetWorkflowRun(ctx, workflowRunId)
+		if err != nil {
+			return sharedtypes.WorkflowRunStatusResponse{}, err
+		}
+		if status.IsTerminal() {
+			return status, nil
+		}
+		logging.Log.Debugf(c.logCtx, "workflow run %s is %s, polling again in %v", workflowRunId, status.Status, pollInterval)
+		select {
+		case <-ctx.Done():
+			return sharedtypes.WorkflowRunStatusResponse{}, aalierrors.Wrapf(c.logCtx, aalierrors.CodeTransient, ctx.Err(), "stopped waiting for workflow run %s", workflowRunId)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// do sends a request to the agent, retrying on failures as described for Client, and decodes the response into out.
+func (c *Client) do(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return aalierrors.Wrap(c.logCtx, aalierrors.CodeValidation, err, "error serializing agent request")
+		}
+	}
+
+	backoff := c.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		resp, responseBody, err := c.send(ctx, method, path, requestBody)
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		retryable := statusCode == http.StatusTooManyRequests
+		if method == http.MethodGet {
+			retryable = retryable || err != nil || statusCode >= http.StatusInternalServerError
+		}
+		if !retryable || attempt >= c.MaxRetries || ctx.Err() != nil {
+			if err != nil {
+				return aalierrors.Wrapf(c.logCtx, aalierrors.CodeTransient, err, "error sending agent request %s %s", method, path)
+			}
+			return c.decodeResponse(method, path, statusCode, responseBody, out)
+		}
+
+		wait := backoff
+		if resp != nil {
+			if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && time.Duration(seconds)*time.Second > wait {
+				wait = time.Duration(seconds) * time.Second
+			}
+		}
+		logging.Log.Warnf(c.logCtx, "agent request %s %s failed (status %d, error %v), retrying in %v", method, path, statusCode, err, wait)
+		select {
+		case <-ctx.Done():
+			return aalierrors.Wrapf(c.logCtx, aalierrors.CodeTransient, ctx.Err(), "stopped retrying agent request %s %s", method, path)
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// send sends a single request to the agent.
+func (c *Client) send(ctx context.Context, method string, path string, body []byte) (*http.Response, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.apiKey != "" {
+		req.Header.Set("api-key", c.apiKey)
+	}
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if err := logging.SetHTTPHeaderFromCtx(c.logCtx, req); err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, aalierrors.Wrap(nil, aalierrors.CodeTransient, err, "error reading response body")
+	}
+	return resp, responseBody, nil
+}
+
+// decodeResponse maps a failed response to an error with its ApiError or ProblemDetails and decodes a successful response into out.
+func (c *Client) decodeResponse(method string, path string, statusCode int, body []byte, out interface{}) error {
+	if statusCode < 200 || statusCode > 299 {
+		var apiError sharedtypes.ApiErrorResponse
+		if err := json.Unmarshal(body, &apiError); err == nil && apiError.Error.Code != "" {
+			return aalierrors.Wrapf(c.logCtx, aalierrors.FromHTTPStatus(statusCode), &apiError.Error, "agent request %s %s failed with status code %d", method, path, statusCode)
+		}
+		var problem sharedtypes.ProblemDetails
+		if err := json.Unmarshal(body, &problem); err == nil && problem.Status != 0 {
+			return aalierrors.Wrapf(c.logCtx, aalierrors.FromHTTPStatus(statusCode), &problem, "agent request %s %s failed with status code %d", method, path, statusCode)
+		}
+		return aalierrors.Newf(c.logCtx, aalierrors.FromHTTPStatus(statusCode), "agent request %s %s failed with status code %d: %s", method, path, statusCode, string(body))
+	}
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return aalierrors.Wrap(c.logCtx, aalierrors.CodeInternal, err, "error unmarshalling agent response")
+	}
+	return nil
+}
+
+// runPath returns the API path of a workflow run.
+func runPath(workflowRunId string) string {
+	return workflowRunsPath + "/" + url.PathEscape(workflowRunId)
+}
@@ -0,0 +1,308 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package agentrestclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// fakeAgent is an in-memory implementation of the workflow run REST API.
+type fakeAgent struct {
+	mu       sync.Mutex
+	runs     map[string]*sharedtypes.WorkflowRunStatusResponse
+	polls    int // number of status reads before a run succeeds
+	failures []int
+	requests []*http.Request
+}
+
+func (f *fakeAgent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Clone(context.Background()))
+	if len(f.failures) > 0 {
+		status := f.failures[0]
+		f.failures = f.failures[1:]
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, workflowRunsPath)
+	switch {
+	case r.Method == http.MethodPost && path == "":
+		var request sharedtypes.StartWorkflowRunRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, sharedtypes.ApiErrorCodeInvalidRequest, err.Error())
+			return
+		}
+		run := &sharedtypes.WorkflowRunStatusResponse{WorkflowRunId: "run-1", WorkflowId: request.WorkflowId, Status: sharedtypes.WorkflowRunStatusPending}
+		f.runs[run.WorkflowRunId] = run
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(run)
+	case r.Method == http.MethodGet && path == "":
+		response := sharedtypes.ListWorkflowRunsResponse{Runs: []sharedtypes.WorkflowRunStatusResponse{}}
+		for _, run := range f.runs {
+			if run.WorkflowId == r.URL.Query().Get("workflow_id") {
+				response.Runs = append(response.Runs, *run)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	case r.Method == http.MethodGet && strings.HasSuffix(path, "/history"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/history")
+		if f.runs[id] == nil {
+			writeError(w, http.StatusNotFound, sharedtypes.ApiErrorCodeNotFound, "workflow run not found")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(sharedtypes.WorkflowRunHistoryResponse{
+			WorkflowRunId: id,
+			Messages:      []sharedtypes.ConversationHistoryMessage{{MessageId: "m1", Role: "user", Content: "hello"}},
+		})
+	case r.Method == http.MethodGet:
+		run := f.runs[strings.TrimPrefix(path, "/")]
+		if run == nil {
+			writeError(w, http.StatusNotFound, sharedtypes.ApiErrorCodeNotFound, "workflow run not found")
+			return
+		}
+		if f.polls > 0 {
+			f.polls--
+			run.Status = sharedtypes.WorkflowRunStatusRunning
+		} else {
+			run.Status = sharedtypes.WorkflowRunStatusSucceeded
+		}
+		_ = json.NewEncoder(w).Encode(run)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(sharedtypes.NewApiErrorResponse(code, message))
+}
+
+func newTestClient(t *testing.T) (*Client, *fakeAgent) {
+	t.Helper()
+	logging.CaptureForTest(t)
+	if config.GlobalConfig == nil {
+		config.GlobalConfig = &config.Config{}
+	}
+
+	fake := &fakeAgent{runs: map[string]*sharedtypes.WorkflowRunStatusResponse{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "test-key")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	client.RetryBackoff = time.Millisecond
+	return client, fake
+}
+
+func TestWorkflowRunLifecycle(t *testing.T) {
+	client, fake := newTestClient(t)
+	fake.polls = 2
+	ctx := context.Background()
+
+	started, err := client.StartWorkflowRun(ctx, sharedtypes.StartWorkflowRunRequest{WorkflowId: "wf"})
+	if err != nil || started.WorkflowRunId != "run-1" || started.Status != sharedtypes.WorkflowRunStatusPending {
+		t.Fatalf("StartWorkflowRun() = %+v, %v", started, err)
+	}
+
+	final, err := client.WaitForWorkflowRun(ctx, started.WorkflowRunId, time.Millisecond)
+	if err != nil || final.Status != sharedtypes.WorkflowRunStatusSucceeded {
+		t.Fatalf("WaitForWorkflowRun() = %+v, %v", final, err)
+	}
+
+	runs, err := client.ListWorkflowRuns(ctx, sharedtypes.ListWorkflowRunsRequest{WorkflowId: "wf"})
+	if err != nil || len(runs.Runs) != 1 {
+		t.Fatalf("ListWorkflowRuns() = %+v, %v", runs, err)
+	}
+	query := fake.requests[len(fake.requests)-1].URL.Query()
+	if query.Get("workflow_id") != "wf" || query.Get("page_size") != strconv.Itoa(sharedtypes.DefaultPageSize) || query.Has("status") {
+		t.Errorf("list query = %v, want workflow_id and default page_size only", query)
+	}
+
+	history, err := client.GetWorkflowRunHistory(ctx, started.WorkflowRunId)
+	if err != nil || len(history.Messages) != 1 || history.Messages[0].Content != "hello" {
+		t.Fatalf("GetWorkflowRunHistory() = %+v, %v", history, err)
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	client, fake := newTestClient(t)
+	logCtx := &logging.ContextMap{}
+	logCtx.Set(logging.InstructionGuid, "guid-rest")
+
+	_, _ = client.WithLogContext(logCtx).WithBearerToken("jwt").GetWorkflowRun(context.Background(), "missing")
+
+	headers := fake.requests[0].Header
+	if headers.Get("api-key") != "test-key" || headers.Get("Authorization") != "Bearer jwt" {
+		t.Errorf("auth headers = %v", headers)
+	}
+	if !strings.Contains(headers.Get("aali-logging-context"), "guid-rest") {
+		t.Errorf("aali-logging-context = %q, want the instruction GUID", headers.Get("aali-logging-context"))
+	}
+	if client.bearerToken != "" {
+		t.Error("WithBearerToken() modified the original client")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	_, err := client.GetWorkflowRun(context.Background(), "missing")
+	var apiError *sharedtypes.ApiError
+	if !errors.As(err, &apiError) || apiError.Code != sharedtypes.ApiErrorCodeNotFound {
+		t.Errorf("GetWorkflowRun() error = %v, want ApiError not_found", err)
+	}
+	if aalierrors.CodeOf(err) != aalierrors.FromHTTPStatus(http.StatusNotFound) {
+		t.Errorf("CodeOf() = %v, want code of status 404", aalierrors.CodeOf(err))
+	}
+
+	if _, err := client.StartWorkflowRun(context.Background(), sharedtypes.StartWorkflowRunRequest{}); aalierrors.CodeOf(err) != aalierrors.CodeValidation {
+		t.Errorf("StartWorkflowRun() without workflow error = %v, want validation error", err)
+	}
+	if _, err := client.ListWorkflowRuns(context.Background(), sharedtypes.ListWorkflowRunsRequest{Status: "paused"}); aalierrors.CodeOf(err) != aalierrors.CodeValidation {
+		t.Errorf("ListWorkflowRuns() with unknown status error = %v, want validation error", err)
+	}
+	if _, err := NewClient("", ""); err == nil {
+		t.Error("NewClient() without endpoint succeeded, want error")
+	}
+}
+
+func TestProblemErrors(t *testing.T) {
+	logging.CaptureForTest(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", sharedtypes.ProblemContentType)
+		w.WriteHeader(http.StatusConflict)
+		problem := sharedtypes.NewProblemDetails(http.StatusConflict, "workflow run already finished")
+		_ = json.NewEncoder(w).Encode(problem)
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClient(server.URL, "test-key")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.GetWorkflowRun(context.Background(), "run-1")
+	var problem *sharedtypes.ProblemDetails
+	if !errors.As(err, &problem) || problem.Status != http.StatusConflict || problem.Detail != "workflow run already finished" {
+		t.Errorf("GetWorkflowRun() error = %v, want ProblemDetails with status 409", err)
+	}
+	if aalierrors.CodeOf(err) != aalierrors.CodeValidation {
+		t.Errorf("CodeOf() = %v, want validation", aalierrors.CodeOf(err))
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures []int
+		start    bool
+		requests int
+		success  bool
+	}{
+		{"get retried on 503", []int{503, 503}, false, 3, true},
+		{"get gives up after max retries", []int{503, 503, 503, 503}, false, 4, false},
+		{"start retried on 429", []int{429}, true, 2, true},
+		{"start not retried on 500", []int{500}, true, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newTestClient(t)
+			fake.runs["run-1"] = &sharedtypes.WorkflowRunStatusResponse{WorkflowRunId: "run-1"}
+			fake.failures = tt.failures
+
+			var err error
+			if tt.start {
+				_, err = client.StartWorkflowRun(context.Background(), sharedtypes.StartWorkflowRunRequest{WorkflowId: "wf"})
+			} else {
+				_, err = client.GetWorkflowRun(context.Background(), "run-1")
+			}
+			if (err == nil) != tt.success {
+				t.Errorf("error = %v, want success %v", err, tt.success)
+			}
+			if len(fake.requests) != tt.requests {
+				t.Errorf("sent %d requests, want %d", len(fake.requests), tt.requests)
+			}
+		})
+	}
+}
+
+func TestRetryCancelled(t *testing.T) {
+	client, fake := newTestClient(t)
+	fake.runs["run-1"] = &sharedtypes.WorkflowRunStatusResponse{WorkflowRunId: "run-1"}
+	fake.failures = []int{503}
+	client.RetryBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GetWorkflowRun(ctx, "run-1")
+	var aaliErr *aalierrors.Error
+	if !errors.As(err, &aaliErr) || aaliErr.Code != aalierrors.CodeTransient || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetWorkflowRun() error = %v, want a transient error wrapping the deadline", err)
+	}
+}
+
+func TestNewClientFromConfigWithoutConfig(t *testing.T) {
+	logging.CaptureForTest(t)
+	previous := config.GlobalConfig
+	t.Cleanup(func() { config.GlobalConfig = previous })
+	config.GlobalConfig = nil
+
+	_, err := NewClientFromConfig()
+	var aaliErr *aalierrors.Error
+	if !errors.As(err, &aaliErr) || aaliErr.Code != aalierrors.CodeValidation {
+		t.Errorf("NewClientFromConfig() error = %v, want a validation error", err)
+	}
+}
+
+func TestWaitForWorkflowRunCancelled(t *testing.T) {
+	client, fake := newTestClient(t)
+	fake.runs["run-1"] = &sharedtypes.WorkflowRunStatusResponse{WorkflowRunId: "run-1"}
+	fake.polls = 1000
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.WaitForWorkflowRun(ctx, "run-1", 5*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForWorkflowRun() error = %v, want deadline exceeded", err)
+	}
+}
@@ -23,31 +23,31 @@
 package clients
 
 import (
-	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"fmt"
-	"net"
 	"net/http"
 	"os"
 
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
 	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/netutil"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 // GetHttpClientWithCert creates an HTTP client configured with TLS using a custom SSL certificate.
+// The duration of each request is recorded in CallDurations, and requests slower than CLIENT_SLOW_CALL_THRESHOLD_MS are logged.
 //
 // Returns:
 //   - httpClient: Pointer to http.Client configured with TLS.
 //   - err: an error message if the setup fails.
 func GetHttpClient() (httpClient *http.Client, err error) {
-	if config.GlobalConfig.USE_SSL {
+	if config.Current().USE_SSL {
 		// attach custom certificate to HTTP client
 		tlsConfig, err := GetTlsConfigWithCert()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get TLS config with cert: %v", err)
+			return nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "failed to get TLS config with cert")
 		}
 
 		transport := &http.Transport{
@@ -55,16 +55,19 @@ func GetHttpClient() (httpClient *http.Client, err error) {
 		}
 
 		httpClient = &http.Client{
-			Transport: transport,
+			Transport: timingTransport{base: transport},
 		}
 	} else {
-		httpClient = &http.Client{}
+		httpClient = &http.Client{
+			Transport: timingTransport{base: http.DefaultTransport},
+		}
 	}
 
 	return httpClient, nil
 }
 
 // GetGrpcDialOptions creates gRPC dial options with custom dialing logic and transport credentials based on the scheme.
+// The duration of each unary call is recorded in CallDurations, and calls slower than CLIENT_SLOW_CALL_THRESHOLD_MS are logged.
 //
 // Parameters:
 //   - scheme: A string indicating the connection scheme ("http" or "https").
@@ -74,27 +77,22 @@ func GetHttpClient() (httpClient *http.Client, err error) {
 //   - err: an error message if the setup fails.
 func GetGrpcDialOptions(scheme string) (options []grpc.DialOption, err error) {
 	// Add custom dialer with IPv4 first, fallback to IPv6
-	options = append(options, grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
-		d := &net.Dialer{}
+	options = append(options, grpc.WithContextDialer(netutil.DialIPv4First))
 
-		// Try IPv4 first
-		conn, err := d.DialContext(ctx, "tcp4", addr)
-		if err == nil {
-			return conn, nil
-		}
+	// Measure the duration of each call
+	options = append(options, grpc.WithChainUnaryInterceptor(timingUnaryInterceptor))
 
-		// Fall back to IPv6 if IPv4 fails
-		return d.DialContext(ctx, "tcp6", addr)
-	}))
+	// Send keepalive pings if configured
+	options = append(options, GetGrpcKeepaliveDialOptions()...)
 
 	// Set up transport credentials based on the scheme
 	if scheme == "https" {
 		// Set up a secure connection
 		var tlsConfig *tls.Config
-		if config.GlobalConfig.USE_SSL {
+		if config.Current().USE_SSL {
 			tlsConfig, err = GetTlsConfigWithCert()
 			if err != nil {
-				return nil, fmt.Errorf("unable to set up TLS config with custom certificate: %v", err)
+				return nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "unable to set up TLS config with custom certificate")
 			}
 		}
 		creds := credentials.NewTLS(tlsConfig)
@@ -115,7 +113,7 @@ func GetGrpcDialOptions(scheme string) (options []grpc.DialOption, err error) {
 func GetTlsConfigWithCert() (tlsConfig *tls.Config, err error) {
 	certPool, err := GetCertPool()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get cert pool: %v", err)
+		return nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "failed to get cert pool")
 	}
 
 	tlsConfig = &tls.Config{
@@ -131,14 +129,14 @@ func GetTlsConfigWithCert() (tlsConfig *tls.Config, err error) {
 //   - certPool: Pointer to x509.CertPool containing the loaded certificate.
 //   - err: an error message if the setup fails.
 func GetCertPool() (certPool *x509.CertPool, err error) {
-	certPEM, err := os.ReadFile(config.GlobalConfig.SSL_CERT_PUBLIC_KEY_FILE)
+	certPEM, err := os.ReadFile(config.Current().SSL_CERT_PUBLIC_KEY_FILE)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read SSL certificate public key file: %v", err)
+		return nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "failed to read SSL certificate public key file")
 	}
 
 	certPool = x509.NewCertPool()
 	if !certPool.AppendCertsFromPEM(certPEM) {
-		return nil, fmt.Errorf("failed to append certificate to CA pool")
+		return nil, aalierrors.New(nil, aalierrors.CodeInternal, "failed to append certificate to CA pool")
 	}
 
 	return certPool, nil
@@ -0,0 +1,196 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// CatalogVersion is the version of the catalog format written by ExportCatalog
+const CatalogVersion = 1
+
+// defaultCatalogMaxAge is the age of an imported catalog above which a staleness warning is logged
+const defaultCatalogMaxAge = 24 * time.Hour
+
+// Catalog is a snapshot of the available functions, types and categories
+type Catalog struct {
+	Version    int                                        `json:"version"`
+	ExportedAt time.Time                                  `json:"exported_at"`
+	Functions  map[string]*sharedtypes.FunctionDefinition `json:"functions"`
+	Types      []string                                   `json:"types"`
+	Categories []string                                   `json:"categories"`
+}
+
+// catalogNow returns the current time; replaced in tests
+var catalogNow = time.Now
+
+// ExportCatalog serializes the available functions, types and categories to a versioned JSON catalog
+// API keys are not exported; ImportCatalog restores them from FLOWKIT_CONNECTIONS.
+//
+// Returns:
+//   - data: the JSON catalog
+//   - err: an error if the catalog cannot be serialized
+func ExportCatalog() (data []byte, err error) {
+	catalog := Catalog{
+		Version:    CatalogVersion,
+		ExportedAt: catalogNow().UTC(),
+		Functions:  make(map[string]*sharedtypes.FunctionDefinition, len(AvailableFunctions)),
+		Types:      sortedKeys(AvailableTypes),
+		Categories: sortedKeys(AvailableCategories),
+	}
+	for name, function := range AvailableFunctions {
+		exported := *function
+		exported.ApiKey = ""
+		catalog.Functions[name] = &exported
+	}
+
+	data, err = json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "unable to serialize function catalog")
+	}
+	return data, nil
+}
+
+// ImportCatalog loads a catalog written by ExportCatalog into the available functions, types and categories
+// This allows agents to start when the FlowKit servers are unreachable. Functions that are already
+// available are kept, as they are more recent than the catalog. A warning is logged if the catalog is
+// older than FLOWKIT_CATALOG_MAX_AGE_HOURS.
+//
+// Parameters:
+//   - data: the JSON catalog
+//
+// Returns:
+//   - err: an error if the catalog is invalid or has an unsupported version
+func ImportCatalog(data []byte) (err error) {
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return aalierrors.Wrap(nil, aalierrors.CodeValidation, err, "invalid function catalog")
+	}
+	if catalog.Version < 1 || catalog.Version > CatalogVersion {
+		return aalierrors.Newf(nil, aalierrors.CodeValidation, "unsupported function catalog version %d, supported up to %d", catalog.Version, CatalogVersion)
+	}
+
+	if AvailableFunctions == nil {
+		AvailableFunctions = make(map[string]*sharedtypes.FunctionDefinition)
+	}
+	if AvailableTypes == nil {
+		AvailableTypes = make(map[string]bool)
+	}
+	if AvailableCategories == nil {
+		AvailableCategories = make(map[string]bool)
+	}
+
+	imported := 0
+	for name, function := range catalog.Functions {
+		if function == nil {
+			continue
+		}
+		if _, exists := AvailableFunctions[name]; exists {
+			continue
+		}
+		function.ApiKey = connectionApiKey(function.FlowkitUrl)
+		AvailableFunctions[name] = function
+		imported++
+	}
+	for _, goType := range catalog.Types {
+		AvailableTypes[goType] = true
+	}
+	for _, category := range catalog.Categories {
+		AvailableCategories[category] = true
+	}
+
+	// warn about stale catalogs
+	age := catalogNow().Sub(catalog.ExportedAt)
+	if age > catalogMaxAge() {
+		logging.Log.Warnf(&logging.ContextMap{}, "imported function catalog is stale: exported at %s (%s ago); functions may have changed on the FlowKit servers", catalog.ExportedAt.Format(time.RFC3339), age.Round(time.Minute))
+	}
+	logging.Log.Infof(&logging.ContextMap{}, "imported %d functions from function catalog exported at %s", imported, catalog.ExportedAt.Format(time.RFC3339))
+
+	return nil
+}
+
+// catalogMaxAge returns the age of an imported catalog above which a staleness warning is logged
+//
+// Returns:
+//   - time.Duration: the maximum age
+func catalogMaxAge() time.Duration {
+	if config.Current() != nil && config.Current().FLOWKIT_CATALOG_MAX_AGE_HOURS > 0 {
+		return time.Duration(config.Current().FLOWKIT_CATALOG_MAX_AGE_HOURS) * time.Hour
+	}
+	return defaultCatalogMaxAge
+}
+
+// connectionApiKey returns the API key of a FlowKit server from FLOWKIT_CONNECTIONS
+//
+// Parameters:
+//   - url: the URL of the FlowKit server
+//
+// Returns:
+//   - string: the API key, or an empty string if the server is not configured
+func connectionApiKey(url string) string {
+	return flowkitConnection(url).API_KEY
+}
+
+// flowkitConnection returns the configuration of a FlowKit server from FLOWKIT_CONNECTIONS
+//
+// Parameters:
+//   - url: the URL of the FlowKit server
+//
+// Returns:
+//   - config.FlowkitConnection: the configuration, or an empty configuration if the server is not configured
+func flowkitConnection(url string) config.FlowkitConnection {
+	if config.Current() == nil {
+		return config.FlowkitConnection{}
+	}
+	for _, connection := range config.Current().FLOWKIT_CONNECTIONS {
+		if connection.URL == url {
+			return connection
+		}
+	}
+	return config.FlowkitConnection{}
+}
+
+// sortedKeys returns the keys of a set in sorted order
+//
+// Parameters:
+//   - set: the set
+//
+// Returns:
+//   - []string: the sorted keys
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key, ok := range set {
+		if ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
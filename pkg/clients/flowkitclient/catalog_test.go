@@ -0,0 +1,184 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"go.uber.org/zap/zapcore"
+)
+
+// withCatalogState replaces the available functions, types, categories and global config for the test
+func withCatalogState(t *testing.T, cfg *config.Config) {
+	t.Helper()
+	logging.InitForTest()
+	functions, types, categories, previousConfig := AvailableFunctions, AvailableTypes, AvailableCategories, config.GlobalConfig
+	t.Cleanup(func() {
+		AvailableFunctions, AvailableTypes, AvailableCategories, config.GlobalConfig = functions, types, categories, previousConfig
+		catalogNow = time.Now
+	})
+	AvailableFunctions = map[string]*sharedtypes.FunctionDefinition{}
+	AvailableTypes = map[string]bool{}
+	AvailableCategories = map[string]bool{}
+	config.GlobalConfig = cfg
+}
+
+func TestExportImportCatalog(t *testing.T) {
+	withCatalogState(t, &config.Config{
+		FLOWKIT_CONNECTIONS: []config.FlowkitConnection{{URL: "http://flowkit:50051", API_KEY: "secret"}},
+	})
+	exportedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	catalogNow = func() time.Time { return exportedAt }
+
+	AvailableFunctions["echo"] = &sharedtypes.FunctionDefinition{
+		Name:           "echo",
+		FlowkitUrl:     "http://flowkit:50051",
+		ApiKey:         "secret",
+		Category:       "generic",
+		Inputs:         []sharedtypes.FunctionInput{{Name: "text", Type: "string", GoType: "string", Options: []string{}}},
+		Outputs:        []sharedtypes.FunctionOutput{{Name: "text", Type: "string", GoType: "string"}},
+		Type:           "go",
+		TimeoutSeconds: 30,
+		Idempotent:     true,
+	}
+	AvailableTypes["string"] = true
+	AvailableTypes["int"] = true
+	AvailableCategories["generic"] = true
+
+	data, err := ExportCatalog()
+	if err != nil {
+		t.Fatalf("ExportCatalog() error = %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("exported catalog contains the API key:\n%s", data)
+	}
+
+	// import into empty state
+	AvailableFunctions, AvailableTypes, AvailableCategories = nil, nil, nil
+	if err := ImportCatalog(data); err != nil {
+		t.Fatalf("ImportCatalog() error = %v", err)
+	}
+
+	function, ok := AvailableFunctions["echo"]
+	if !ok {
+		t.Fatal("function echo not imported")
+	}
+	if function.ApiKey != "secret" {
+		t.Errorf("ApiKey = %q, want API key from FLOWKIT_CONNECTIONS", function.ApiKey)
+	}
+	if function.TimeoutSeconds != 30 || !function.Idempotent || len(function.Inputs) != 1 || function.Inputs[0].GoType != "string" {
+		t.Errorf("imported function = %+v", function)
+	}
+	if !AvailableTypes["string"] || !AvailableTypes["int"] || len(AvailableTypes) != 2 {
+		t.Errorf("AvailableTypes = %v", AvailableTypes)
+	}
+	if !AvailableCategories["generic"] || len(AvailableCategories) != 1 {
+		t.Errorf("AvailableCategories = %v", AvailableCategories)
+	}
+}
+
+func TestImportCatalogKeepsAvailableFunctions(t *testing.T) {
+	withCatalogState(t, &config.Config{})
+
+	data := `{"version": 1, "exported_at": "2026-01-01T00:00:00Z", "functions": {
+		"echo": {"name": "echo", "description": "from catalog"},
+		"reverse": {"name": "reverse", "description": "from catalog"}
+	}}`
+	AvailableFunctions["echo"] = &sharedtypes.FunctionDefinition{Name: "echo", Description: "from server"}
+
+	if err := ImportCatalog([]byte(data)); err != nil {
+		t.Fatalf("ImportCatalog() error = %v", err)
+	}
+	if got := AvailableFunctions["echo"].Description; got != "from server" {
+		t.Errorf("echo description = %q, want function from server to be kept", got)
+	}
+	if _, ok := AvailableFunctions["reverse"]; !ok {
+		t.Error("function reverse not imported")
+	}
+}
+
+func TestImportCatalogErrors(t *testing.T) {
+	withCatalogState(t, &config.Config{})
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "invalid json", data: `{"version":`},
+		{name: "missing version", data: `{"functions": {}}`},
+		{name: "future version", data: `{"version": 2, "functions": {}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ImportCatalog([]byte(tt.data))
+			if aalierrors.CodeOf(err) != aalierrors.CodeValidation {
+				t.Errorf("ImportCatalog() error = %v, want validation error", err)
+			}
+		})
+	}
+	if len(AvailableFunctions) != 0 {
+		t.Errorf("AvailableFunctions = %v, want no functions imported", AvailableFunctions)
+	}
+}
+
+func TestCatalogMaxAge(t *testing.T) {
+	withCatalogState(t, &config.Config{})
+	if got := catalogMaxAge(); got != defaultCatalogMaxAge {
+		t.Errorf("catalogMaxAge() = %v, want %v", got, defaultCatalogMaxAge)
+	}
+	config.GlobalConfig = &config.Config{FLOWKIT_CATALOG_MAX_AGE_HOURS: 2}
+	if got := catalogMaxAge(); got != 2*time.Hour {
+		t.Errorf("catalogMaxAge() = %v, want 2h", got)
+	}
+}
+
+func TestImportCatalogStalenessWarning(t *testing.T) {
+	withCatalogState(t, &config.Config{FLOWKIT_CATALOG_MAX_AGE_HOURS: 1})
+	previousLevel := logging.LOG_LEVEL
+	t.Cleanup(func() { logging.LOG_LEVEL = previousLevel })
+	logging.LOG_LEVEL = "warn"
+	capture := logging.CaptureForTest(t)
+
+	catalogNow = func() time.Time { return time.Date(2026, 1, 1, 0, 30, 0, 0, time.UTC) }
+	if err := ImportCatalog([]byte(`{"version": 1, "exported_at": "2026-01-01T00:00:00Z"}`)); err != nil {
+		t.Fatalf("ImportCatalog() error = %v", err)
+	}
+	if capture.Contains(zapcore.WarnLevel, "stale") {
+		t.Error("staleness warning logged for a recent catalog")
+	}
+
+	catalogNow = func() time.Time { return time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC) }
+	if err := ImportCatalog([]byte(`{"version": 1, "exported_at": "2026-01-01T00:00:00Z"}`)); err != nil {
+		t.Fatalf("ImportCatalog() error = %v", err)
+	}
+	if !capture.Contains(zapcore.WarnLevel, "imported function catalog is stale") {
+		t.Errorf("no staleness warning logged, messages = %q", capture.Messages(zapcore.DebugLevel))
+	}
+}
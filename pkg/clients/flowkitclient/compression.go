@@ -0,0 +1,131 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Content encodings of compressed function values
+const (
+	contentEncodingGzip = "gzip"
+	contentEncodingZstd = "zstd"
+)
+
+// defaultCompressionThreshold is the minimum size of a value to be compressed if not configured
+const defaultCompressionThreshold = 1 << 20
+
+// maxDecompressedSize limits the size of decompressed values, matching the default maximum gRPC message size
+const maxDecompressedSize = 1 << 30
+
+// zstd encoder and decoder are safe for concurrent use with EncodeAll and DecodeAll
+var (
+	zstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+		return zstd.NewWriter(nil)
+	})
+	zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+		return zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxDecompressedSize))
+	})
+)
+
+// compress compresses a value with the given content encoding
+//
+// Parameters:
+//   - data: the value to compress
+//   - encoding: the content encoding, "gzip" or "zstd"
+//
+// Returns:
+//   - compressed: the compressed value
+//   - err: an error if the encoding is not supported or compression fails
+func compress(data []byte, encoding string) (compressed []byte, err error) {
+	switch encoding {
+	case contentEncodingGzip:
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(data); err != nil {
+			return nil, fmt.Errorf("error compressing value with gzip: %w", err)
+		}
+		if err := writer.Close(); err != nil {
+			return nil, fmt.Errorf("error compressing value with gzip: %w", err)
+		}
+		return buf.Bytes(), nil
+
+	case contentEncodingZstd:
+		encoder, err := zstdEncoder()
+		if err != nil {
+			return nil, fmt.Errorf("error creating zstd encoder: %w", err)
+		}
+		return encoder.EncodeAll(data, nil), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported content encoding '%s'", encoding)
+	}
+}
+
+// decompress decompresses a value with the given content encoding
+//
+// Parameters:
+//   - data: the compressed value
+//   - encoding: the content encoding, "gzip" or "zstd"
+//
+// Returns:
+//   - decompressed: the decompressed value
+//   - err: an error if the encoding is not supported, the value is invalid or exceeds the maximum size
+func decompress(data []byte, encoding string) (decompressed []byte, err error) {
+	switch encoding {
+	case contentEncodingGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing gzip value: %w", err)
+		}
+		defer reader.Close()
+		decompressed, err = io.ReadAll(io.LimitReader(reader, maxDecompressedSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing gzip value: %w", err)
+		}
+		if len(decompressed) > maxDecompressedSize {
+			return nil, fmt.Errorf("decompressed gzip value exceeds %d bytes", maxDecompressedSize)
+		}
+		return decompressed, nil
+
+	case contentEncodingZstd:
+		decoder, err := zstdDecoder()
+		if err != nil {
+			return nil, fmt.Errorf("error creating zstd decoder: %w", err)
+		}
+		decompressed, err = decoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing zstd value: %w", err)
+		}
+		return decompressed, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported content encoding '%s'", encoding)
+	}
+}
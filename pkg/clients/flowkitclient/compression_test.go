@@ -0,0 +1,61 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompressDecompress(t *testing.T) {
+	data := bytes.Repeat([]byte("compressible value "), 100)
+	for _, encoding := range []string{contentEncodingGzip, contentEncodingZstd} {
+		t.Run(encoding, func(t *testing.T) {
+			compressed, err := compress(data, encoding)
+			if err != nil {
+				t.Fatalf("compress() error = %v", err)
+			}
+			if len(compressed) >= len(data) {
+				t.Errorf("compress() = %d bytes, want less than %d", len(compressed), len(data))
+			}
+			decompressed, err := decompress(compressed, encoding)
+			if err != nil {
+				t.Fatalf("decompress() error = %v", err)
+			}
+			if !bytes.Equal(decompressed, data) {
+				t.Error("decompress() does not match the original value")
+			}
+
+			if _, err := decompress([]byte("not compressed"), encoding); err == nil {
+				t.Error("decompress() of invalid data succeeded")
+			}
+		})
+	}
+
+	if _, err := compress(data, "br"); err == nil {
+		t.Error("compress() with unsupported encoding succeeded")
+	}
+	if _, err := decompress(data, "br"); err == nil {
+		t.Error("decompress() with unsupported encoding succeeded")
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dryRunFunction rejects an empty "text" input on dry runs and echoes it otherwise
+func dryRunFunction(ctx context.Context, inputs *aaliflowkitgrpc.FunctionInputs) (*aaliflowkitgrpc.FunctionOutputs, error) {
+	if !inputs.DryRun {
+		return echoFunction(ctx, inputs)
+	}
+	for _, input := range inputs.Inputs {
+		if input.Name == "text" && input.Value == "" && len(input.ValueBytes) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "input 'text' must not be empty")
+		}
+	}
+	return &aaliflowkitgrpc.FunctionOutputs{
+		Name:    inputs.Name,
+		Outputs: []*aaliflowkitgrpc.FunctionOutput{{Name: "text", GoType: "string"}},
+	}, nil
+}
+
+func TestDryRunFunction(t *testing.T) {
+	server := &fakeFlowkitServer{
+		capabilities: []string{sharedtypes.FlowkitCapabilityDryRun},
+		functions:    map[string]*aaliflowkitgrpc.FunctionDefinition{"echo": echoDefinition("echo", true)},
+		run:          dryRunFunction,
+	}
+	startFakeFlowkitServer(t, server)
+	config.GlobalConfig = &config.Config{FLOWKIT_RESULT_CACHE_TTL_SECONDS: 60}
+	ClearResultCache()
+	defer ClearResultCache()
+
+	traced := 0
+	defer AddNodeTraceHook(func(*logging.ContextMap, sharedtypes.NodeTrace) { traced++ })()
+
+	inputs := map[string]sharedtypes.FilledInputOutput{"text": {Name: "text", GoType: "string", Value: "hello"}}
+	outputs, err := DryRunFunction(&logging.ContextMap{}, "echo", inputs)
+	if err != nil {
+		t.Fatalf("DryRunFunction() error = %v", err)
+	}
+	if got := outputs["text"]; got.GoType != "string" || got.Value != nil {
+		t.Errorf("DryRunFunction() output = %+v, want GoType string without value", got)
+	}
+	if traced != 0 {
+		t.Errorf("dry run traced %d times, want 0", traced)
+	}
+
+	// dry runs neither use nor fill the result cache
+	if _, err := DryRunFunction(&logging.ContextMap{}, "echo", inputs); err != nil {
+		t.Fatalf("DryRunFunction() error = %v", err)
+	}
+	outputs, err = RunFunction(&logging.ContextMap{}, "echo", inputs)
+	if err != nil {
+		t.Fatalf("RunFunction() error = %v", err)
+	}
+	if outputs["text"].Value != "hello" {
+		t.Errorf("RunFunction() output = %v, want hello", outputs["text"].Value)
+	}
+	if calls := server.calls.Load(); calls != 3 {
+		t.Errorf("server calls = %d, want 3", calls)
+	}
+
+	// invalid inputs are reported by the server
+	_, err = DryRunFunction(&logging.ContextMap{}, "echo", map[string]sharedtypes.FilledInputOutput{"text": {Name: "text", GoType: "string", Value: ""}})
+	if err == nil {
+		t.Fatal("DryRunFunction() with invalid input error = nil")
+	}
+	if code := aalierrors.CodeOf(err); code != aalierrors.CodeValidation {
+		t.Errorf("DryRunFunction() error code = %v, want %v", code, aalierrors.CodeValidation)
+	}
+	if calls := server.calls.Load(); calls != 4 {
+		t.Errorf("server calls = %d, want 4", calls)
+	}
+}
+
+func TestDryRunFunctionUnsupported(t *testing.T) {
+	server := &fakeFlowkitServer{
+		functions: map[string]*aaliflowkitgrpc.FunctionDefinition{"echo": echoDefinition("echo", false)},
+		run:       dryRunFunction,
+	}
+	startFakeFlowkitServer(t, server)
+
+	_, err := DryRunFunction(&logging.ContextMap{}, "echo", map[string]sharedtypes.FilledInputOutput{"text": {Name: "text", GoType: "string", Value: "hello"}})
+	var aaliErr *aalierrors.Error
+	if !errors.As(err, &aaliErr) || aaliErr.Code != aalierrors.CodeValidation {
+		t.Errorf("DryRunFunction() error = %v, want validation error", err)
+	}
+	if calls := server.calls.Load(); calls != 0 {
+		t.Errorf("server calls = %d, want 0", calls)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeFlowkitServer is an in-process FlowKit server running the functions given in run
+type fakeFlowkitServer struct {
+	aaliflowkitgrpc.UnimplementedExternalFunctionsServer
+
+	capabilities []string
+	functions    map[string]*aaliflowkitgrpc.FunctionDefinition
+	run          func(ctx context.Context, inputs *aaliflowkitgrpc.FunctionInputs) (*aaliflowkitgrpc.FunctionOutputs, error)
+	calls        atomic.Int32
+}
+
+func (s *fakeFlowkitServer) ListFunctions(ctx context.Context, req *aaliflowkitgrpc.ListFunctionsRequest) (*aaliflowkitgrpc.ListFunctionsResponse, error) {
+	if err := s.announceCapabilities(ctx); err != nil {
+		return nil, err
+	}
+	return &aaliflowkitgrpc.ListFunctionsResponse{Functions: s.functions}, nil
+}
+
+func (s *fakeFlowkitServer) GetVersion(ctx context.Context, req *aaliflowkitgrpc.VersionRequest) (*aaliflowkitgrpc.VersionResponse, error) {
+	if err := s.announceCapabilities(ctx); err != nil {
+		return nil, err
+	}
+	return &aaliflowkitgrpc.VersionResponse{Version: "1.2.3", ProtocolVersion: sharedtypes.ProtocolVersion}, nil
+}
+
+// announceCapabilities sends the capabilities of the server in the response header
+func (s *fakeFlowkitServer) announceCapabilities(ctx context.Context) error {
+	if len(s.capabilities) == 0 {
+		return nil
+	}
+	return grpc.SetHeader(ctx, metadata.Pairs(sharedtypes.FlowkitCapabilitiesMetadataKey, strings.Join(s.capabilities, ",")))
+}
+
+func (s *fakeFlowkitServer) RunFunction(ctx context.Context, inputs *aaliflowkitgrpc.FunctionInputs) (*aaliflowkitgrpc.FunctionOutputs, error) {
+	s.calls.Add(1)
+	return s.run(ctx, inputs)
+}
+
+// echoFunction returns its "text" input as "text" output
+func echoFunction(ctx context.Context, inputs *aaliflowkitgrpc.FunctionInputs) (*aaliflowkitgrpc.FunctionOutputs, error) {
+	outputs := &aaliflowkitgrpc.FunctionOutputs{Name: inputs.Name}
+	for _, input := range inputs.Inputs {
+		outputs.Outputs = append(outputs.Outputs, &aaliflowkitgrpc.FunctionOutput{Name: input.Name, GoType: input.GoType, Value: input.Value})
+	}
+	return outputs, nil
+}
+
+// echoDefinition is the definition of a function taking and returning a "text" string
+func echoDefinition(name string, idempotent bool) *aaliflowkitgrpc.FunctionDefinition {
+	return &aaliflowkitgrpc.FunctionDefinition{
+		Name:       name,
+		Input:      []*aaliflowkitgrpc.FunctionInputDefinition{{Name: "text", Type: "string", GoType: "string"}},
+		Output:     []*aaliflowkitgrpc.FunctionOutputDefinition{{Name: "text", Type: "string", GoType: "string"}},
+		Idempotent: idempotent,
+	}
+}
+
+// startFakeFlowkitServer starts the server on a local port and loads its functions into AvailableFunctions
+// The global function states and config are restored when the test ends.
+func startFakeFlowkitServer(t *testing.T, server *fakeFlowkitServer) (url string) {
+	t.Helper()
+	logging.InitForTest()
+
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	aaliflowkitgrpc.RegisterExternalFunctionsServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	previousFunctions, previousConfig := AvailableFunctions, config.GlobalConfig
+	t.Cleanup(func() {
+		AvailableFunctions, config.GlobalConfig = previousFunctions, previousConfig
+	})
+	AvailableFunctions = map[string]*sharedtypes.FunctionDefinition{}
+	if config.GlobalConfig == nil {
+		config.GlobalConfig = &config.Config{}
+	}
+
+	url = "http://" + listener.Addr().String()
+	if err := ListFunctionsAndSaveToInteralStates(url, ""); err != nil {
+		t.Fatalf("ListFunctionsAndSaveToInteralStates() error = %v", err)
+	}
+	return url
+}
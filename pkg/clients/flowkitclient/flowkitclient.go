@@ -27,11 +27,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"strings"
+	"maps"
+	"slices"
+	"time"
 
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
 	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
 	"github.com/ansys/aali-sharedtypes/pkg/clients"
 	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/netutil"
+	"github.com/ansys/aali-sharedtypes/pkg/protoconv"
 	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
 	"github.com/ansys/aali-sharedtypes/pkg/typeconverters"
 
@@ -52,7 +57,7 @@ func HealthCheck(url string, apiKey string) (err error) {
 	// Set up a connection to the server.
 	c, conn, err := createClient(url, apiKey)
 	if err != nil {
-		return fmt.Errorf("unable to connect to external function gRPC: %v", err)
+		return aalierrors.Wrap(nil, aalierrors.CodeOf(err), err, "unable to connect to external function gRPC")
 	}
 	defer conn.Close()
 
@@ -63,7 +68,7 @@ func HealthCheck(url string, apiKey string) (err error) {
 	// Call HealthCheck
 	_, err = c.HealthCheck(ctxWithCancel, &aaliflowkitgrpc.HealthRequest{})
 	if err != nil {
-		return fmt.Errorf("error in external function gRPC HealthCheck: %v", err)
+		return wrapGrpcError(nil, err, "error in external function gRPC HealthCheck")
 	}
 
 	return nil
@@ -83,7 +88,7 @@ func GetVersion(url string, apiKey string) (version string, err error) {
 	// Set up a connection to the server.
 	c, conn, err := createClient(url, apiKey)
 	if err != nil {
-		return "", fmt.Errorf("unable to connect to external function gRPC: %v", err)
+		return "", aalierrors.Wrap(nil, aalierrors.CodeOf(err), err, "unable to connect to external function gRPC")
 	}
 	defer conn.Close()
 
@@ -94,12 +99,54 @@ func GetVersion(url string, apiKey string) (version string, err error) {
 	// Call GetVersion
 	resp, err := c.GetVersion(ctxWithCancel, &aaliflowkitgrpc.VersionRequest{})
 	if err != nil {
-		return "", fmt.Errorf("error in external function gRPC GetVersion: %v", err)
+		return "", wrapGrpcError(nil, err, "error in external function gRPC GetVersion")
 	}
 
 	return resp.Version, nil
 }
 
+// GetServiceVersion retrieves the version, protocol version and capabilities of the external function server
+// The capabilities are read from the response metadata and saved like for ListFunctions.
+// Servers predating version negotiation report an empty protocol version and no capabilities.
+//
+// Parameters:
+//   - url: the URL of the external function server
+//   - apiKey: the API key to authenticate with the external function server
+//
+// Returns:
+//   - version: the version information of the external function server
+//   - err: an error message if the gRPC call fails
+func GetServiceVersion(url string, apiKey string) (version sharedtypes.ServiceVersion, err error) {
+	// Set up a connection to the server.
+	c, conn, err := createClient(url, apiKey)
+	if err != nil {
+		return sharedtypes.ServiceVersion{}, aalierrors.Wrap(nil, aalierrors.CodeOf(err), err, "unable to connect to external function gRPC")
+	}
+	defer conn.Close()
+
+	// Create a context with a cancel
+	ctxWithCancel, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Call GetVersion, announcing the client capabilities
+	ctxWithCapabilities := withClientCapabilities(ctxWithCancel)
+	var responseHeader metadata.MD
+	resp, err := c.GetVersion(ctxWithCapabilities, &aaliflowkitgrpc.VersionRequest{}, grpc.Header(&responseHeader))
+	if err != nil {
+		return sharedtypes.ServiceVersion{}, wrapGrpcError(nil, err, "error in external function gRPC GetVersion")
+	}
+
+	// Save the capabilities announced by the server
+	saveServerCapabilities(url, responseHeader)
+	capabilities := slices.Sorted(maps.Keys(parseCapabilities(responseHeader.Get(sharedtypes.FlowkitCapabilitiesMetadataKey))))
+
+	return sharedtypes.ServiceVersion{
+		Version:         resp.Version,
+		ProtocolVersion: resp.ProtocolVersion,
+		Capabilities:    capabilities,
+	}, nil
+}
+
 // Global variable to store the available functions, types and categories
 var AvailableFunctions map[string]*sharedtypes.FunctionDefinition
 var AvailableTypes map[string]bool
@@ -116,17 +163,12 @@ var AvailableCategories map[string]bool
 // Returns:
 //   - error: an error message if the gRPC call fails
 func ListFunctionsAndSaveToInteralStates(url string, apiKey string) (err error) {
-	defer func() {
-		r := recover()
-		if r != nil {
-			err = fmt.Errorf("panic occurred in ListFunctionsAndSaveToInteralStates: %v", r)
-		}
-	}()
+	defer logging.RecoverPanic(nil, "ListFunctionsAndSaveToInteralStates", &err)
 
 	// Set up a connection to the server.
 	c, conn, err := createClient(url, apiKey)
 	if err != nil {
-		return fmt.Errorf("unable to connect to external function gRPC: %v", err)
+		return aalierrors.Wrap(nil, aalierrors.CodeOf(err), err, "unable to connect to external function gRPC")
 	}
 	defer conn.Close()
 
@@ -134,50 +176,25 @@ func ListFunctionsAndSaveToInteralStates(url string, apiKey string) (err error)
 	ctxWithCancel, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Call ListFunctions
-	listResp, err := c.ListFunctions(ctxWithCancel, &aaliflowkitgrpc.ListFunctionsRequest{})
+	// Call ListFunctions, announcing the client capabilities
+	ctxWithCapabilities := withClientCapabilities(ctxWithCancel)
+	var responseHeader metadata.MD
+	listResp, err := c.ListFunctions(ctxWithCapabilities, &aaliflowkitgrpc.ListFunctionsRequest{}, grpc.Header(&responseHeader))
 	if err != nil {
-		return fmt.Errorf("error in external function gRPC ListFunctions: %v", err)
+		return wrapGrpcError(nil, err, "error in external function gRPC ListFunctions")
 	}
 
+	// Save the capabilities announced by the server
+	saveServerCapabilities(url, responseHeader)
+
 	// Save the functions to internal states
 	for _, function := range listResp.Functions {
-		// convert inputs and outputs
-		inputs := []sharedtypes.FunctionInput{}
-		for _, inputParam := range function.Input {
-			// check if options is nil
-			if inputParam.Options == nil {
-				inputParam.Options = []string{}
-			}
-			inputs = append(inputs, sharedtypes.FunctionInput{
-				Name:    inputParam.Name,
-				Type:    inputParam.Type,
-				GoType:  inputParam.GoType,
-				Options: inputParam.Options,
-			})
-		}
-		outputs := []sharedtypes.FunctionOutput{}
-		for _, outputParam := range function.Output {
-			outputs = append(outputs, sharedtypes.FunctionOutput{
-				Name:   outputParam.Name,
-				Type:   outputParam.Type,
-				GoType: outputParam.GoType,
-			})
-		}
+		definition := protoconv.FunctionDefinitionFromProto(function)
+		definition.FlowkitUrl = url
+		definition.ApiKey = apiKey
+		definition.Type = "go"
+		AvailableFunctions[function.Name] = &definition
 
-		// Save the function to internal states
-		AvailableFunctions[function.Name] = &sharedtypes.FunctionDefinition{
-			Name:             function.Name,
-			FlowkitUrl:       url,
-			ApiKey:           apiKey,
-			DisplayName:      function.DisplayName,
-			Description:      function.Description,
-			Category:         function.Category,
-			DeprecatedParams: function.DeprecatedParams,
-			Inputs:           inputs,
-			Outputs:          outputs,
-			Type:             "go",
-		}
 		// add the category to available categories
 		if AvailableCategories != nil && function.Category != "" {
 			AvailableCategories[function.Category] = true
@@ -195,6 +212,9 @@ func ListFunctionsAndSaveToInteralStates(url string, apiKey string) (err error)
 
 // RunFunction calls the RunFunction gRPC and returns the outputs
 // This function is used to run an external function
+// Results of idempotent functions are cached if FLOWKIT_RESULT_CACHE_TTL_SECONDS is set.
+// Hooks registered with AddNodeTraceHook receive a trace of every call.
+// Omitted optional inputs are sent as null values; omitted required inputs fail the call.
 //
 // Parameters:
 //   - functionName: the name of the function to run
@@ -202,39 +222,134 @@ func ListFunctionsAndSaveToInteralStates(url string, apiKey string) (err error)
 //
 // Returns:
 //   - map[string]sharedtypes.FilledInputOutput: the outputs of the function
-//   - error: an error message if the gRPC call fails
+//   - error: an error message if the gRPC call fails; a *MissingInputsError if required inputs are missing
 func RunFunction(ctx *logging.ContextMap, functionName string, inputs map[string]sharedtypes.FilledInputOutput) (outputs map[string]sharedtypes.FilledInputOutput, err error) {
-	defer func() {
-		r := recover()
-		if r != nil {
-			err = fmt.Errorf("panic occurred in RunFunction of function '%v': %v", functionName, r)
-		}
-	}()
+	return runFunction(context.Background(), ctx, functionName, inputs, runOptions{decode: true})
+}
+
+// RunFunctionWithContext works like RunFunction, but the call is cancelled when callCtx is done.
+// A deadline set on callCtx takes precedence over the default timeout of the function.
+//
+// Parameters:
+//   - callCtx: the context of the call
+//   - functionName: the name of the function to run
+//   - inputs: the inputs to the function
+//
+// Returns:
+//   - map[string]sharedtypes.FilledInputOutput: the outputs of the function
+//   - error: an error message if the gRPC call fails; a *DeadlineExceededError if the deadline is exceeded
+func RunFunctionWithContext(callCtx context.Context, ctx *logging.ContextMap, functionName string, inputs map[string]sharedtypes.FilledInputOutput) (outputs map[string]sharedtypes.FilledInputOutput, err error) {
+	return runFunction(callCtx, ctx, functionName, inputs, runOptions{decode: true})
+}
+
+// RunFunctionLazy calls the RunFunction gRPC and returns the outputs without decoding them.
+// The outputs keep their serialized form, so passing them on to inputs of the same type avoids any conversion;
+// their values are decoded on demand with typeconverters.FilledValue.
+//
+// Parameters:
+//   - functionName: the name of the function to run
+//   - inputs: the inputs to the function
+//
+// Returns:
+//   - map[string]sharedtypes.FilledInputOutput: the outputs of the function, not decoded yet
+//   - error: an error message if the gRPC call fails
+func RunFunctionLazy(ctx *logging.ContextMap, functionName string, inputs map[string]sharedtypes.FilledInputOutput) (outputs map[string]sharedtypes.FilledInputOutput, err error) {
+	return runFunction(context.Background(), ctx, functionName, inputs, runOptions{})
+}
+
+// DryRunFunction asks the FlowKit server to validate the inputs of a function without running it
+// The server returns the declared outputs with their Go types but without values, so the types of a workflow
+// can be checked before a real run. Dry runs bypass the result cache and are not traced.
+//
+// Parameters:
+//   - functionName: the name of the function to validate
+//   - inputs: the inputs to the function
+//
+// Returns:
+//   - map[string]sharedtypes.FilledInputOutput: the declared outputs of the function, without values
+//   - error: a validation error if the inputs are invalid or the server does not support dry runs
+func DryRunFunction(ctx *logging.ContextMap, functionName string, inputs map[string]sharedtypes.FilledInputOutput) (outputs map[string]sharedtypes.FilledInputOutput, err error) {
+	return runFunction(context.Background(), ctx, functionName, inputs, runOptions{dryRun: true})
+}
+
+// runOptions are the options of a RunFunction call
+type runOptions struct {
+	decode bool // convert the outputs to their Go types
+	dryRun bool // only validate the inputs on the server; results are neither cached nor traced
+}
+
+// runFunction calls the RunFunction gRPC with the given options.
+func runFunction(callCtx context.Context, ctx *logging.ContextMap, functionName string, inputs map[string]sharedtypes.FilledInputOutput, options runOptions) (outputs map[string]sharedtypes.FilledInputOutput, err error) {
+	// deferred before RecoverPanic so the trace also records recovered panics
+	var trace *sharedtypes.NodeTrace
+	if !options.dryRun {
+		trace = startNodeTrace(ctx, functionName)
+	}
+	defer func() { finishNodeTrace(ctx, trace, err) }()
+	defer logging.RecoverPanic(ctx, fmt.Sprintf("RunFunction of function '%v'", functionName), &err)
 
 	// Get function definition
 	functionDef, ok := AvailableFunctions[functionName]
 	if !ok {
-		return nil, fmt.Errorf("function '%s' not found in available functions", functionName)
+		return nil, aalierrors.Newf(ctx, aalierrors.CodeValidation, "function '%s' not found in available functions", functionName)
+	}
+
+	// Dry runs must not reach servers that would run the function instead
+	if options.dryRun && !serverSupports(functionDef.FlowkitUrl, sharedtypes.FlowkitCapabilityDryRun) {
+		return nil, aalierrors.Newf(ctx, aalierrors.CodeValidation, "FlowKit server of function '%s' does not support dry runs", functionName)
+	}
+
+	// Fail fast if required inputs are missing instead of calling the function with null values
+	if err := checkRequiredInputs(functionDef, functionName, inputs); err != nil {
+		return nil, aalierrors.Wrap(ctx, aalierrors.CodeValidation, err, "")
+	}
+
+	// Return the cached result of idempotent functions if the result cache is enabled
+	cacheKey := ""
+	cacheTtl, cacheMaxEntries := resultCacheSettings()
+	if functionDef.Idempotent && cacheTtl > 0 && !options.dryRun {
+		cacheKey, err = resultCacheKey(functionDef, inputs)
+		if err != nil {
+			return nil, aalierrors.Wrap(ctx, aalierrors.CodeValidation, err, "error computing result cache key")
+		}
+		if cached, ok := results.get(cacheKey); ok {
+			logging.Log.Debugf(ctx, "using cached result of function '%v'", functionName)
+			if trace != nil {
+				trace.Cached = true
+			}
+			if options.decode {
+				for name, output := range cached {
+					if _, err := typeconverters.FilledValue(&output); err != nil {
+						return nil, aalierrors.Wrapf(ctx, aalierrors.CodeInternal, err, "error converting cached output '%s' for function '%v' to Go type", name, functionName)
+					}
+					cached[name] = output
+				}
+			}
+			return cached, nil
+		}
 	}
 
 	// Set up a connection to the server.
 	c, conn, err := createClient(functionDef.FlowkitUrl, functionDef.ApiKey)
 	if err != nil {
-		return nil, fmt.Errorf("unable to connect to external function gRPC: %v", err)
+		return nil, aalierrors.Wrap(ctx, aalierrors.CodeOf(err), err, "unable to connect to external function gRPC")
 	}
 	defer conn.Close()
 
-	// Create a context with a cancel
-	ctxWithCancel, cancel := context.WithCancel(context.Background())
+	// Create a context with a cancel, limited by the deadline of the call
+	ctxWithCancel, cancel, timeout := withFunctionDeadline(callCtx, functionDef)
 	defer cancel()
 
 	// get logging metadata from context
 	ctxWithMetadata, err := logging.CreateMetaDataFromCtx(ctx, ctxWithCancel)
 	if err != nil {
-		return nil, fmt.Errorf("error adding metadata: %v", err)
+		return nil, aalierrors.Wrap(ctx, aalierrors.CodeInternal, err, "error adding metadata")
 	}
 
+	ctxWithMetadata = withClientCapabilities(ctxWithMetadata)
+
 	// Convert inputs to gRPC format based on order from function definition
+	encoding := serverValueEncoding(functionDef.FlowkitUrl)
 	grpcInputs := []*aaliflowkitgrpc.FunctionInput{}
 	for _, inputDef := range functionDef.Inputs {
 		// create grpc input
@@ -246,33 +361,42 @@ func RunFunction(ctx *logging.ContextMap, functionName string, inputs map[string
 		// Get the input value
 		value, ok := inputs[inputDef.Name]
 		if ok {
-			// found: convert value to string
-			stringValue, exists, err := typeconverters.ConvertGivenTypeToString(value.Value, inputDef.GoType)
+			// found: convert value to bytes or string and compress it depending on the server capabilities
+			err := encodeInput(grpcInput, &value, encoding)
 			if err != nil {
-				return nil, fmt.Errorf("error converting input '%s' for function '%v' to string: %v", inputDef.Name, functionName, err)
+				return nil, aalierrors.Wrapf(ctx, aalierrors.CodeValidation, err, "error converting input '%s' for function '%v'", inputDef.Name, functionName)
 			}
-			if !exists {
-				return nil, fmt.Errorf("type '%s' does not exist in typeconverters.ConvertGivenTypeToString", inputDef.Name)
-			}
-			grpcInput.Value = stringValue
+			recordInputSize(trace, grpcInput)
 
 		} else {
-			// input discrepancy, set to null value
-			grpcInput.Value = ""
+			// omitted optional input: mark it as null, so the server applies its default value
+			grpcInput.IsNull = true
 		}
 
 		// Append the grpc input to the list
 		grpcInputs = append(grpcInputs, grpcInput)
 	}
 
+	// Send the resource limits of the definition with the call, so limits configured centrally
+	// in the function definitions of the agent override the ones announced by the server
+	var resourceLimits *aaliflowkitgrpc.ResourceLimits
+	if functionDef.ResourceLimits != nil {
+		resourceLimits = protoconv.ResourceLimitsToProto(*functionDef.ResourceLimits)
+	}
+
 	// Call RunFunction
 	var responseHeader metadata.MD
 	runResp, err := c.RunFunction(ctxWithMetadata, &aaliflowkitgrpc.FunctionInputs{
-		Name:   functionName,
-		Inputs: grpcInputs,
+		Name:           functionName,
+		Inputs:         grpcInputs,
+		DryRun:         options.dryRun,
+		ResourceLimits: resourceLimits,
 	}, grpc.Header(&responseHeader))
 	if err != nil {
-		return nil, fmt.Errorf("error in external function gRPC RunFunction for function '%v': %v", functionName, err)
+		if isDeadlineExceeded(ctxWithCancel, err) {
+			return nil, aalierrors.Wrap(ctx, aalierrors.CodeTransient, &DeadlineExceededError{FunctionName: functionName, Timeout: timeout}, "")
+		}
+		return nil, wrapGrpcError(ctx, err, "error in external function gRPC RunFunction for function '%v'", functionName)
 	}
 
 	// Update logging context with token counts from response headers
@@ -296,20 +420,26 @@ func RunFunction(ctx *logging.ContextMap, functionName string, inputs map[string
 	// convert outputs to map[string]sharedtypes.FilledInputOutput
 	outputs = map[string]sharedtypes.FilledInputOutput{}
 	for _, output := range runResp.Outputs {
-		// convert value to Go type
-		value, exists, err := typeconverters.ConvertStringToGivenType(output.Value, output.GoType)
-		if err != nil {
-			return nil, fmt.Errorf("error converting output '%s' with value '%v' for function '%v' to Go type: %v", output.Name, output.Value, functionName, err)
+		// dry runs only return the declared types
+		if options.dryRun {
+			outputs[output.Name] = sharedtypes.FilledInputOutput{Name: output.Name, GoType: output.GoType}
+			continue
 		}
-		if !exists {
-			return nil, fmt.Errorf("type '%s' does not exist in typeconverters.ConvertStringToGivenType", output.Name)
+
+		recordOutputSize(trace, output)
+		filled, err := decodeOutput(output, options.decode)
+		if err != nil {
+			return nil, aalierrors.Wrapf(ctx, aalierrors.CodeInternal, err, "error converting output '%s' for function '%v' to Go type", output.Name, functionName)
 		}
 
 		// Save the output to the map
-		outputs[output.Name] = sharedtypes.FilledInputOutput{
-			Name:   output.Name,
-			GoType: output.GoType,
-			Value:  value,
+		outputs[output.Name] = filled
+	}
+
+	// Cache the result of idempotent functions
+	if cacheKey != "" {
+		if err := results.put(cacheKey, outputs, cacheTtl, cacheMaxEntries); err != nil {
+			logging.Log.Warnf(ctx, "not caching the result of function '%v': %v", functionName, err)
 		}
 	}
 
@@ -319,6 +449,7 @@ func RunFunction(ctx *logging.ContextMap, functionName string, inputs map[string
 // StreamFunction calls the StreamFunction gRPC and returns a channel to stream the outputs
 // and an interrupt channel to send interrupts to the server.
 // This function is used to stream the outputs of an external function
+// Omitted optional inputs are sent as null values; omitted required inputs fail the call.
 //
 // Parameters:
 //   - functionName: the name of the function to run
@@ -327,39 +458,59 @@ func RunFunction(ctx *logging.ContextMap, functionName string, inputs map[string
 // Returns:
 //   - *chan string: a channel to stream the output from the server
 //   - *chan string: an interrupt channel to send messages to the server
-//   - error: an error message if the gRPC call fails
+//   - error: an error message if the gRPC call fails; a *MissingInputsError if required inputs are missing
 func StreamFunction(ctx *logging.ContextMap, functionName string, inputs map[string]sharedtypes.FilledInputOutput) (channel *chan string, interruptChannel *chan string, err error) {
-	defer func() {
-		r := recover()
-		if r != nil {
-			err = fmt.Errorf("panic occured in StreamFunction for function '%v': %v", functionName, r)
-		}
-	}()
+	return StreamFunctionWithContext(context.Background(), ctx, functionName, inputs)
+}
+
+// StreamFunctionWithContext works like StreamFunction, but the stream is cancelled when streamCtx is done.
+// The output channel is closed after cancellation, so the consumer can stop reading.
+// A deadline set on streamCtx takes precedence over the default timeout of the function.
+//
+// Parameters:
+//   - streamCtx: the context of the stream
+//   - functionName: the name of the function to run
+//   - inputs: the inputs to the function
+//
+// Returns:
+//   - *chan string: a channel to stream the output from the server
+//   - *chan string: an interrupt channel to send messages to the server
+//   - error: an error message if the gRPC call fails
+func StreamFunctionWithContext(streamCtx context.Context, ctx *logging.ContextMap, functionName string, inputs map[string]sharedtypes.FilledInputOutput) (channel *chan string, interruptChannel *chan string, err error) {
+	defer logging.RecoverPanic(ctx, fmt.Sprintf("StreamFunction for function '%v'", functionName), &err)
 
 	// Get function definition
 	functionDef, ok := AvailableFunctions[functionName]
 	if !ok {
-		return nil, nil, fmt.Errorf("function '%s' not found in available functions", functionName)
+		return nil, nil, aalierrors.Newf(ctx, aalierrors.CodeValidation, "function '%s' not found in available functions", functionName)
+	}
+
+	// Fail fast if required inputs are missing instead of streaming with null values
+	if err := checkRequiredInputs(functionDef, functionName, inputs); err != nil {
+		return nil, nil, aalierrors.Wrap(ctx, aalierrors.CodeValidation, err, "")
 	}
 
 	// Set up a connection to the server.
 	c, conn, err := createClient(functionDef.FlowkitUrl, functionDef.ApiKey)
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to connect to external function gRPC: %v", err)
+		return nil, nil, aalierrors.Wrap(ctx, aalierrors.CodeOf(err), err, "unable to connect to external function gRPC")
 	}
 
-	// Create a context with a cancel
-	ctxWithCancel, cancel := context.WithCancel(context.Background())
+	// Create a context with a cancel, limited by the deadline of the stream
+	ctxWithCancel, cancel, timeout := withFunctionDeadline(streamCtx, functionDef)
 
 	// get logging metadata from context
 	ctxWithMetadata, err := logging.CreateMetaDataFromCtx(ctx, ctxWithCancel)
 	if err != nil {
 		conn.Close()
 		cancel()
-		return nil, nil, fmt.Errorf("error adding metadata: %v", err)
+		return nil, nil, aalierrors.Wrap(ctx, aalierrors.CodeInternal, err, "error adding metadata")
 	}
 
+	ctxWithMetadata = withClientCapabilities(ctxWithMetadata)
+
 	// Convert inputs to gRPC format based on order from function definition
+	encoding := serverValueEncoding(functionDef.FlowkitUrl)
 	grpcInputs := []*aaliflowkitgrpc.FunctionInput{}
 	for _, inputDef := range functionDef.Inputs {
 		// create grpc input
@@ -371,23 +522,17 @@ func StreamFunction(ctx *logging.ContextMap, functionName string, inputs map[str
 		// Get the input value
 		value, ok := inputs[inputDef.Name]
 		if ok {
-			// found: convert value to string
-			stringValue, exists, err := typeconverters.ConvertGivenTypeToString(value.Value, inputDef.GoType)
+			// found: convert value to bytes or string and compress it depending on the server capabilities
+			err := encodeInput(grpcInput, &value, encoding)
 			if err != nil {
 				conn.Close()
 				cancel()
-				return nil, nil, fmt.Errorf("error converting input '%s' for function '%v' to string: %v", inputDef.Name, functionName, err)
-			}
-			if !exists {
-				conn.Close()
-				cancel()
-				return nil, nil, fmt.Errorf("type '%s' does not exist in typeconverters.ConvertGivenTypeToString", inputDef.Name)
+				return nil, nil, aalierrors.Wrapf(ctx, aalierrors.CodeValidation, err, "error converting input '%s' for function '%v'", inputDef.Name, functionName)
 			}
-			grpcInput.Value = stringValue
 
 		} else {
-			// input discrepancy, set to null value
-			grpcInput.Value = ""
+			// omitted optional input: mark it as null, so the server applies its default value
+			grpcInput.IsNull = true
 		}
 
 		// Append the grpc input to the list
@@ -399,7 +544,7 @@ func StreamFunction(ctx *logging.ContextMap, functionName string, inputs map[str
 	if err != nil {
 		conn.Close()
 		cancel()
-		return nil, nil, fmt.Errorf("error in external function gRPC StreamFunction for function '%v': %v", functionName, err)
+		return nil, nil, wrapGrpcError(ctx, err, "error in external function gRPC StreamFunction for function '%v'", functionName)
 	}
 
 	// Send the initial message with function inputs
@@ -410,7 +555,7 @@ func StreamFunction(ctx *logging.ContextMap, functionName string, inputs map[str
 	if err != nil {
 		conn.Close()
 		cancel()
-		return nil, nil, fmt.Errorf("error sending initial message in StreamFunction for function '%v': %v", functionName, err)
+		return nil, nil, wrapGrpcError(ctx, err, "error sending initial message in StreamFunction for function '%v'", functionName)
 	}
 
 	// Create channels
@@ -418,7 +563,7 @@ func StreamFunction(ctx *logging.ContextMap, functionName string, inputs map[str
 	interruptCh := make(chan string, 400)
 
 	// Receive the stream from the server
-	go receiveStreamFromServer(ctx, stream, &streamChannel, conn, cancel, functionName)
+	go receiveStreamFromServer(ctx, stream, &streamChannel, conn, cancel, functionName, timeout)
 
 	// Send interrupts to the server
 	go sendInterruptsToServer(ctx, stream, &interruptCh, functionName)
@@ -431,18 +576,17 @@ func StreamFunction(ctx *logging.ContextMap, functionName string, inputs map[str
 // Parameters:
 //   - stream: the stream from the server
 //   - streamChannel: the channel to send the stream to
-func receiveStreamFromServer(ctx *logging.ContextMap, stream aaliflowkitgrpc.ExternalFunctions_StreamFunctionClient, streamChannel *chan string, conn *grpc.ClientConn, cancel context.CancelFunc, functionName string) {
-	defer func() {
-		r := recover()
-		if r != nil {
-			logging.Log.Errorf(ctx, "Panic occured in receiveStreamFromServer for function '%v': %v", functionName, r)
-		}
-	}()
+//   - timeout: the timeout applied to the stream, reported if the deadline is exceeded
+func receiveStreamFromServer(ctx *logging.ContextMap, stream aaliflowkitgrpc.ExternalFunctions_StreamFunctionClient, streamChannel *chan string, conn *grpc.ClientConn, cancel context.CancelFunc, functionName string, timeout time.Duration) {
+	defer logging.RecoverPanic(ctx, fmt.Sprintf("receiveStreamFromServer for function '%v'", functionName), nil)
 
 	// Receive the stream from the server
 	for {
 		res, err := stream.Recv()
 		if err != nil && err != io.EOF {
+			if isDeadlineExceeded(stream.Context(), err) {
+				err = &DeadlineExceededError{FunctionName: functionName, Timeout: timeout}
+			}
 			logging.Log.Errorf(ctx, "error receiving stream for function '%v': %v", functionName, err)
 			*streamChannel <- fmt.Sprintf("$&$error$&$:$&$%v$&$", err)
 			break
@@ -470,12 +614,7 @@ func receiveStreamFromServer(ctx *logging.ContextMap, stream aaliflowkitgrpc.Ext
 //   - interruptChannel: the channel to receive interrupt messages from
 //   - functionName: the name of the function (for logging)
 func sendInterruptsToServer(ctx *logging.ContextMap, stream aaliflowkitgrpc.ExternalFunctions_StreamFunctionClient, interruptChannel *chan string, functionName string) {
-	defer func() {
-		r := recover()
-		if r != nil {
-			logging.Log.Errorf(ctx, "Panic occured in sendInterruptsToServer for function '%v': %v", functionName, r)
-		}
-	}()
+	defer logging.RecoverPanic(ctx, fmt.Sprintf("sendInterruptsToServer for function '%v'", functionName), nil)
 
 	// Listen to the interrupt channel and send messages to the server
 	for msg := range *interruptChannel {
@@ -499,26 +638,21 @@ func sendInterruptsToServer(ctx *logging.ContextMap, stream aaliflowkitgrpc.Exte
 //   - connection: the connection to the external functions gRPC
 //   - err: an error message if the client creation fails
 func createClient(url string, apiKey string) (client aaliflowkitgrpc.ExternalFunctionsClient, connection *grpc.ClientConn, err error) {
-	// Extract the scheme (http or https) from the EXTERNALFUNCTIONS_ENDPOINT
-	var scheme string
-	var address string
-	switch {
-	case strings.HasPrefix(url, "https://"):
+	// Extract the scheme (http or https) and address from the endpoint, which may omit the scheme
+	endpoint, err := netutil.ParseEndpoint(url)
+	if err != nil {
+		return nil, nil, aalierrors.Wrap(nil, aalierrors.CodeValidation, err, "invalid external function endpoint")
+	}
+	scheme := "http"
+	if endpoint.TLS {
 		scheme = "https"
-		address = strings.TrimPrefix(url, scheme+"://")
-	case strings.HasPrefix(url, "http://"):
-		scheme = "http"
-		address = strings.TrimPrefix(url, scheme+"://")
-	default:
-		// legacy support for endpoint definition without http or https in front
-		scheme = "http"
-		address = url
 	}
+	address := endpoint.Address()
 
 	// Get gRPC dial options
 	opts, err := clients.GetGrpcDialOptions(scheme)
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to get gRPC dial options: %v", err)
+		return nil, nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "unable to get gRPC dial options")
 	}
 
 	// Add the API key if it is set
@@ -526,13 +660,13 @@ func createClient(url string, apiKey string) (client aaliflowkitgrpc.ExternalFun
 		opts = append(opts, grpc.WithUnaryInterceptor(apiKeyInterceptor(apiKey)))
 	}
 
-	// Set max message size to 1GB
-	opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1024*1024*1024)))
+	// Apply the message size limits configured for the server
+	opts = append(opts, clients.GetGrpcMessageSizeDialOptions(messageSizeLimits(url))...)
 
 	// Set up a connection to the server
 	conn, err := grpc.NewClient(address, opts...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to connect to external function gRPC: %v", err)
+		return nil, nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "unable to connect to external function gRPC")
 	}
 
 	// Return the client
@@ -577,3 +711,18 @@ func apiKeyInterceptor(apiKey string) grpc.UnaryClientInterceptor {
 		return invoker(ctx, method, req, reply, cc, opts...)
 	}
 }
+
+// wrapGrpcError wraps the error of a gRPC call, keeping the code and details sent by the server
+//
+// Parameters:
+//   - ctx: the context of the call; can be nil
+//   - err: the error of the gRPC call
+//   - format: the format of the error message
+//   - args: the format arguments
+//
+// Returns:
+//   - error: the wrapped error
+func wrapGrpcError(ctx *logging.ContextMap, err error, format string, args ...interface{}) error {
+	err = aalierrors.FromGRPCError(err)
+	return aalierrors.Wrapf(ctx, aalierrors.CodeOf(err), err, format, args...)
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRunFunctionErrors(t *testing.T) {
+	server := &fakeFlowkitServer{
+		functions: map[string]*aaliflowkitgrpc.FunctionDefinition{
+			"invalid":     echoDefinition("invalid", false),
+			"unavailable": echoDefinition("unavailable", false),
+			"slow":        echoDefinition("slow", false),
+		},
+		run: func(ctx context.Context, inputs *aaliflowkitgrpc.FunctionInputs) (*aaliflowkitgrpc.FunctionOutputs, error) {
+			switch inputs.Name {
+			case "invalid":
+				remoteCtx := &logging.ContextMap{}
+				remoteCtx.Set(logging.InstructionGuid, "remote-instruction")
+				return nil, aalierrors.New(remoteCtx, aalierrors.CodeValidation, "text must not be empty")
+			case "unavailable":
+				return nil, status.Error(codes.Unavailable, "model is loading")
+			default:
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+		},
+	}
+	startFakeFlowkitServer(t, server)
+
+	ctx := &logging.ContextMap{}
+	ctx.Set(logging.WorkflowId, "workflow-1")
+	inputs := map[string]sharedtypes.FilledInputOutput{"text": {Name: "text", GoType: "string", Value: ""}}
+	callCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	tests := []struct {
+		name          string
+		function      string
+		wantCode      aalierrors.Code
+		wantRetryable bool
+		wantDetails   map[string]string
+	}{
+		{"not found", "missing", aalierrors.CodeValidation, false, map[string]string{"workflowId": "workflow-1"}},
+		{"server error with details", "invalid", aalierrors.CodeValidation, false, map[string]string{"workflowId": "workflow-1", "instructionGuid": "remote-instruction"}},
+		{"grpc status", "unavailable", aalierrors.CodeTransient, true, map[string]string{"workflowId": "workflow-1"}},
+		{"deadline", "slow", aalierrors.CodeTransient, true, map[string]string{"workflowId": "workflow-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RunFunctionWithContext(callCtx, ctx, tt.function, inputs)
+			var e *aalierrors.Error
+			if !errors.As(err, &e) {
+				t.Fatalf("RunFunctionWithContext() error = %v, want *aalierrors.Error", err)
+			}
+			if e.Code != tt.wantCode || aalierrors.IsRetryable(err) != tt.wantRetryable {
+				t.Errorf("RunFunctionWithContext() error code = %q, retryable %v", e.Code, aalierrors.IsRetryable(err))
+			}
+			if !reflect.DeepEqual(e.Details, tt.wantDetails) {
+				t.Errorf("RunFunctionWithContext() error details = %v, want %v", e.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestGetServiceVersionCapabilities(t *testing.T) {
+	server := &fakeFlowkitServer{capabilities: []string{sharedtypes.FlowkitCapabilityDryRun, sharedtypes.FlowkitCapabilityBinaryValues}}
+	url := startFakeFlowkitServer(t, server)
+
+	version, err := GetServiceVersion(url, "")
+	if err != nil {
+		t.Fatalf("GetServiceVersion() error = %v", err)
+	}
+	want := []string{sharedtypes.FlowkitCapabilityBinaryValues, sharedtypes.FlowkitCapabilityDryRun}
+	if version.Version != "1.2.3" || !reflect.DeepEqual(version.Capabilities, want) {
+		t.Errorf("GetServiceVersion() = %+v, want capabilities %v", version, want)
+	}
+	if !serverSupports(url, sharedtypes.FlowkitCapabilityDryRun) {
+		t.Error("capabilities announced in GetVersion not saved")
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/clients"
+)
+
+// messageSizeLimits returns the message size limits of a FlowKit server
+// The limits of the server in FLOWKIT_CONNECTIONS override the global gRPC message size limits.
+//
+// Parameters:
+//   - url: the URL of the FlowKit server
+//
+// Returns:
+//   - clients.MessageSizeLimits: the message size limits
+func messageSizeLimits(url string) clients.MessageSizeLimits {
+	connection := flowkitConnection(url)
+	return clients.MessageSizeLimitsFromConfig(clients.MessageSizeLimits{
+		MaxSend:   connection.MAX_SEND_MESSAGE_BYTES,
+		MaxRecv:   connection.MAX_RECV_MESSAGE_BYTES,
+		SoftLimit: connection.MESSAGE_SIZE_SOFT_LIMIT_BYTES,
+	})
+}
+
+// ChunkStreamOutput splits a stream output with a value larger than maxBytes into several stream outputs
+// FlowKit servers use it to stream outputs exceeding the message size limit of the client. The client
+// receives the chunks as consecutive stream messages, concatenating them restores the value.
+// The code validation and the is_last flag are only set on the last chunk.
+//
+// Parameters:
+//   - output: the stream output to split
+//   - maxBytes: the maximum size of the value of a chunk; at least 4
+//
+// Returns:
+//   - chunks: the stream outputs, numbered consecutively from the message counter of the output
+//   - err: an error if maxBytes is less than 4
+func ChunkStreamOutput(output *aaliflowkitgrpc.StreamOutput, maxBytes int) (chunks []*aaliflowkitgrpc.StreamOutput, err error) {
+	values, err := clients.ChunkString(output.Value, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks = make([]*aaliflowkitgrpc.StreamOutput, len(values))
+	for i, value := range values {
+		chunks[i] = &aaliflowkitgrpc.StreamOutput{
+			MessageCounter: output.MessageCounter + int32(i),
+			Value:          value,
+		}
+	}
+	chunks[len(chunks)-1].IsLast = output.IsLast
+	chunks[len(chunks)-1].CodeValidation = output.CodeValidation
+	return chunks, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/clients"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+func TestMessageSizeLimits(t *testing.T) {
+	previous := config.GlobalConfig
+	t.Cleanup(func() { config.GlobalConfig = previous })
+	config.GlobalConfig = &config.Config{
+		GRPC_MAX_RECV_MESSAGE_BYTES: 1000,
+		FLOWKIT_CONNECTIONS: []config.FlowkitConnection{
+			{URL: "http://flowkit:50051", MAX_RECV_MESSAGE_BYTES: 2000, MESSAGE_SIZE_SOFT_LIMIT_BYTES: 500},
+		},
+	}
+
+	want := clients.MessageSizeLimits{MaxSend: clients.DefaultMaxSendMessageBytes, MaxRecv: 2000, SoftLimit: 500}
+	if got := messageSizeLimits("http://flowkit:50051"); got != want {
+		t.Errorf("messageSizeLimits() of configured server = %+v, want %+v", got, want)
+	}
+	want = clients.MessageSizeLimits{MaxSend: clients.DefaultMaxSendMessageBytes, MaxRecv: 1000, SoftLimit: clients.DefaultMessageSizeSoftLimitBytes}
+	if got := messageSizeLimits("http://other:50051"); got != want {
+		t.Errorf("messageSizeLimits() of other server = %+v, want %+v", got, want)
+	}
+}
+
+func TestRunFunctionMessageSizeLimit(t *testing.T) {
+	server := &fakeFlowkitServer{
+		functions: map[string]*aaliflowkitgrpc.FunctionDefinition{"echo": echoDefinition("echo", false)},
+		run:       echoFunction,
+	}
+	url := startFakeFlowkitServer(t, server)
+	config.GlobalConfig = &config.Config{
+		FLOWKIT_CONNECTIONS: []config.FlowkitConnection{{URL: url, MAX_RECV_MESSAGE_BYTES: 1000}},
+	}
+
+	small := map[string]sharedtypes.FilledInputOutput{"text": {Name: "text", GoType: "string", Value: "hello"}}
+	if _, err := RunFunctionWithContext(context.Background(), &logging.ContextMap{}, "echo", small); err != nil {
+		t.Fatalf("RunFunction() of small value error = %v", err)
+	}
+
+	large := map[string]sharedtypes.FilledInputOutput{"text": {Name: "text", GoType: "string", Value: strings.Repeat("x", 2000)}}
+	if _, err := RunFunctionWithContext(context.Background(), &logging.ContextMap{}, "echo", large); err == nil {
+		t.Error("RunFunction() of value above the receive limit returned no error")
+	}
+}
+
+func TestChunkStreamOutput(t *testing.T) {
+	output := &aaliflowkitgrpc.StreamOutput{MessageCounter: 3, IsLast: true, Value: "abcdefghij", CodeValidation: "valid"}
+	chunks, err := ChunkStreamOutput(output, 4)
+	if err != nil {
+		t.Fatalf("ChunkStreamOutput() error = %v", err)
+	}
+	if len(chunks) != 3 {
+		t.Fatalf("ChunkStreamOutput() returned %d chunks, want 3", len(chunks))
+	}
+	var value strings.Builder
+	for i, chunk := range chunks {
+		value.WriteString(chunk.Value)
+		last := i == len(chunks)-1
+		if chunk.MessageCounter != output.MessageCounter+int32(i) || chunk.IsLast != last || (chunk.CodeValidation == "valid") != last {
+			t.Errorf("chunk %d = %v", i, chunk)
+		}
+	}
+	if value.String() != output.Value {
+		t.Errorf("joined chunks = %q, want %q", value.String(), output.Value)
+	}
+
+	chunks, err = ChunkStreamOutput(&aaliflowkitgrpc.StreamOutput{Value: "ab"}, 4)
+	if err != nil || len(chunks) != 1 || chunks[0].Value != "ab" || chunks[0].IsLast {
+		t.Errorf("ChunkStreamOutput() of small output = %v, %v", chunks, err)
+	}
+	if _, err := ChunkStreamOutput(output, 1); err == nil {
+		t.Error("ChunkStreamOutput() with chunk size 1 returned no error")
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// NodeTraceHook receives the trace of every RunFunction and RunFunctionLazy call once it returns.
+// Hooks are called synchronously from the calling goroutine, so they must be fast and safe for concurrent use.
+type NodeTraceHook func(ctx *logging.ContextMap, trace sharedtypes.NodeTrace)
+
+// nodeTraceHooks holds the registered hooks by registration ID
+var nodeTraceHooks = struct {
+	sync.RWMutex
+	nextId int
+	hooks  map[int]NodeTraceHook
+}{hooks: map[int]NodeTraceHook{}}
+
+// AddNodeTraceHook registers a hook receiving the trace of every function call
+// The workflow run ID, node ID and attempt of the trace are taken from the logging.WorkflowRunId,
+// logging.NodeId and logging.NodeAttempt values of the logging context.
+//
+// Parameters:
+//   - hook: the hook to register
+//
+// Returns:
+//   - remove: a function unregistering the hook
+func AddNodeTraceHook(hook NodeTraceHook) (remove func()) {
+	nodeTraceHooks.Lock()
+	defer nodeTraceHooks.Unlock()
+	id := nodeTraceHooks.nextId
+	nodeTraceHooks.nextId++
+	nodeTraceHooks.hooks[id] = hook
+
+	return func() {
+		nodeTraceHooks.Lock()
+		defer nodeTraceHooks.Unlock()
+		delete(nodeTraceHooks.hooks, id)
+	}
+}
+
+// startNodeTrace starts the trace of a function call
+// No trace is recorded if no hook is registered.
+//
+// Parameters:
+//   - ctx: the logging context of the call
+//   - functionName: the name of the function
+//
+// Returns:
+//   - *sharedtypes.NodeTrace: the started trace; nil if no hook is registered
+func startNodeTrace(ctx *logging.ContextMap, functionName string) *sharedtypes.NodeTrace {
+	nodeTraceHooks.RLock()
+	empty := len(nodeTraceHooks.hooks) == 0
+	nodeTraceHooks.RUnlock()
+	if empty {
+		return nil
+	}
+
+	trace := &sharedtypes.NodeTrace{
+		FunctionName: functionName,
+		StartedAt:    time.Now(),
+	}
+	if ctx != nil {
+		trace.WorkflowRunId = contextString(ctx, logging.WorkflowRunId)
+		trace.NodeId = contextString(ctx, logging.NodeId)
+		trace.Retries = contextInt(ctx, logging.NodeAttempt)
+	}
+	return trace
+}
+
+// recordInputSize records the size of an encoded input in the trace, if any
+func recordInputSize(trace *sharedtypes.NodeTrace, input *aaliflowkitgrpc.FunctionInput) {
+	if trace == nil {
+		return
+	}
+	if trace.InputSizes == nil {
+		trace.InputSizes = map[string]int{}
+	}
+	trace.InputSizes[input.Name] = len(input.Value) + len(input.ValueBytes)
+}
+
+// recordOutputSize records the size of a received output in the trace, if any
+func recordOutputSize(trace *sharedtypes.NodeTrace, output *aaliflowkitgrpc.FunctionOutput) {
+	if trace == nil {
+		return
+	}
+	if trace.OutputSizes == nil {
+		trace.OutputSizes = map[string]int{}
+	}
+	trace.OutputSizes[output.Name] = len(output.Value) + len(output.ValueBytes)
+}
+
+// finishNodeTrace completes the trace of a function call and passes it to the registered hooks
+//
+// Parameters:
+//   - ctx: the logging context of the call
+//   - trace: the trace; nothing is done if nil
+//   - err: the error returned by the call
+func finishNodeTrace(ctx *logging.ContextMap, trace *sharedtypes.NodeTrace, err error) {
+	if trace == nil {
+		return
+	}
+	trace.FinishedAt = time.Now()
+	if err != nil {
+		trace.Error = err.Error()
+		trace.ErrorCode = string(aalierrors.CodeOf(err))
+	}
+
+	nodeTraceHooks.RLock()
+	ids := make([]int, 0, len(nodeTraceHooks.hooks))
+	for id := range nodeTraceHooks.hooks {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	hooks := make([]NodeTraceHook, 0, len(ids))
+	for _, id := range ids {
+		hooks = append(hooks, nodeTraceHooks.hooks[id])
+	}
+	nodeTraceHooks.RUnlock()
+
+	// hooks run in registration order, each with its own copy of the size maps
+	for _, hook := range hooks {
+		hook(ctx, copyNodeTrace(*trace))
+	}
+}
+
+// copyNodeTrace returns a copy of the trace not sharing the size maps
+func copyNodeTrace(trace sharedtypes.NodeTrace) sharedtypes.NodeTrace {
+	trace.InputSizes = copySizes(trace.InputSizes)
+	trace.OutputSizes = copySizes(trace.OutputSizes)
+	return trace
+}
+
+// copySizes returns a copy of the given sizes
+func copySizes(sizes map[string]int) map[string]int {
+	if sizes == nil {
+		return nil
+	}
+	copied := make(map[string]int, len(sizes))
+	for name, size := range sizes {
+		copied[name] = size
+	}
+	return copied
+}
+
+// contextString returns the string value of a context key; empty if not set
+func contextString(ctx *logging.ContextMap, key logging.ContextKey) string {
+	value, _ := ctx.Get(key)
+	str, _ := value.(string)
+	return str
+}
+
+// contextInt returns the integer value of a context key; 0 if not set
+// Numbers set by other services arrive as float64 or string through the logging metadata.
+func contextInt(ctx *logging.ContextMap, key logging.ContextKey) int {
+	value, _ := ctx.Get(key)
+	switch v := value.(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRunFunctionNodeTrace(t *testing.T) {
+	server := &fakeFlowkitServer{
+		functions: map[string]*aaliflowkitgrpc.FunctionDefinition{
+			"echo":   echoDefinition("echo", true),
+			"failed": echoDefinition("failed", false),
+		},
+		run: func(ctx context.Context, inputs *aaliflowkitgrpc.FunctionInputs) (*aaliflowkitgrpc.FunctionOutputs, error) {
+			if inputs.Name == "failed" {
+				return nil, status.Error(codes.Unavailable, "model is loading")
+			}
+			return echoFunction(ctx, inputs)
+		},
+	}
+	startFakeFlowkitServer(t, server)
+	config.GlobalConfig = &config.Config{FLOWKIT_RESULT_CACHE_TTL_SECONDS: 60}
+	ClearResultCache()
+	defer ClearResultCache()
+
+	var traces []sharedtypes.NodeTrace
+	remove := AddNodeTraceHook(func(ctx *logging.ContextMap, trace sharedtypes.NodeTrace) {
+		traces = append(traces, trace)
+	})
+	defer remove()
+
+	ctx := &logging.ContextMap{}
+	ctx.Set(logging.WorkflowRunId, "run-1")
+	ctx.Set(logging.NodeId, "node-1")
+	ctx.Set(logging.NodeAttempt, float64(2))
+	inputs := map[string]sharedtypes.FilledInputOutput{"text": {Name: "text", GoType: "string", Value: "hello"}}
+
+	if _, err := RunFunction(ctx, "echo", inputs); err != nil {
+		t.Fatalf("RunFunction() error = %v", err)
+	}
+	if _, err := RunFunctionLazy(ctx, "echo", inputs); err != nil {
+		t.Fatalf("RunFunctionLazy() error = %v", err)
+	}
+	if _, err := RunFunction(ctx, "failed", inputs); err == nil {
+		t.Fatal("RunFunction() of failing function error = nil")
+	}
+
+	if len(traces) != 3 {
+		t.Fatalf("got %d traces, want 3", len(traces))
+	}
+
+	first := traces[0]
+	if first.WorkflowRunId != "run-1" || first.NodeId != "node-1" || first.Retries != 2 || first.FunctionName != "echo" {
+		t.Errorf("trace identity = %+v", first)
+	}
+	if first.StartedAt.IsZero() || first.FinishedAt.Before(first.StartedAt) {
+		t.Errorf("trace times = %v - %v", first.StartedAt, first.FinishedAt)
+	}
+	if first.InputSizes["text"] == 0 || first.OutputSizes["text"] == 0 {
+		t.Errorf("trace sizes = %v, %v", first.InputSizes, first.OutputSizes)
+	}
+	if !first.Succeeded() || first.Cached {
+		t.Errorf("first trace succeeded = %v, cached = %v", first.Succeeded(), first.Cached)
+	}
+
+	if !traces[1].Cached || !traces[1].Succeeded() {
+		t.Errorf("second trace cached = %v, succeeded = %v", traces[1].Cached, traces[1].Succeeded())
+	}
+
+	failed := traces[2]
+	if failed.Succeeded() || failed.ErrorCode != string(aalierrors.CodeTransient) {
+		t.Errorf("failed trace error = %q, code = %q", failed.Error, failed.ErrorCode)
+	}
+	if failed.OutputSizes != nil {
+		t.Errorf("failed trace output sizes = %v, want nil", failed.OutputSizes)
+	}
+
+	// no traces once the hook is removed
+	remove()
+	if _, err := RunFunction(ctx, "echo", inputs); err != nil {
+		t.Fatalf("RunFunction() error = %v", err)
+	}
+	if len(traces) != 3 {
+		t.Errorf("got %d traces after removing the hook, want 3", len(traces))
+	}
+}
+
+func TestContextInt(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  int
+	}{
+		{"int", 3, 3},
+		{"int64", int64(4), 4},
+		{"float64", float64(5), 5},
+		{"string", "6", 6},
+		{"invalid string", "x", 0},
+		{"other type", true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &logging.ContextMap{}
+			ctx.Set(logging.NodeAttempt, tt.value)
+			if got := contextInt(ctx, logging.NodeAttempt); got != tt.want {
+				t.Errorf("contextInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := contextInt(&logging.ContextMap{}, logging.NodeAttempt); got != 0 {
+		t.Errorf("contextInt() of unset key = %v, want 0", got)
+	}
+}
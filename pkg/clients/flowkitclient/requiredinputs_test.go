@@ -0,0 +1,99 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// nullInputsFunction returns the names of the inputs sent as null in its "text" output
+func nullInputsFunction(ctx context.Context, inputs *aaliflowkitgrpc.FunctionInputs) (*aaliflowkitgrpc.FunctionOutputs, error) {
+	nulls := ""
+	for _, input := range inputs.Inputs {
+		if input.IsNull {
+			nulls += input.Name + ";"
+		}
+	}
+	return &aaliflowkitgrpc.FunctionOutputs{
+		Name:    inputs.Name,
+		Outputs: []*aaliflowkitgrpc.FunctionOutput{{Name: "text", GoType: "string", Value: nulls}},
+	}, nil
+}
+
+func TestRunFunctionRequiredInputs(t *testing.T) {
+	definition := &aaliflowkitgrpc.FunctionDefinition{
+		Name: "f",
+		Input: []*aaliflowkitgrpc.FunctionInputDefinition{
+			{Name: "query", Type: "string", GoType: "string", Required: true},
+			{Name: "limit", Type: "number", GoType: "int", DefaultValue: "10"},
+			{Name: "filter", Type: "string", GoType: "string"},
+		},
+		Output: []*aaliflowkitgrpc.FunctionOutputDefinition{{Name: "text", Type: "string", GoType: "string"}},
+	}
+	server := &fakeFlowkitServer{functions: map[string]*aaliflowkitgrpc.FunctionDefinition{"f": definition}, run: nullInputsFunction}
+	startFakeFlowkitServer(t, server)
+
+	if !AvailableFunctions["f"].Inputs[0].Required {
+		t.Fatal("required flag of the function definition was not loaded")
+	}
+
+	// omitted optional inputs are sent as null, empty values are not
+	inputs := map[string]sharedtypes.FilledInputOutput{
+		"query":  {Name: "query", GoType: "string", Value: "hello"},
+		"filter": {Name: "filter", GoType: "string", Value: ""},
+	}
+	outputs, err := RunFunction(&logging.ContextMap{}, "f", inputs)
+	if err != nil {
+		t.Fatalf("RunFunction() error = %v", err)
+	}
+	if got := outputs["text"].Value; got != "limit;" {
+		t.Errorf("null inputs = %q, want %q", got, "limit;")
+	}
+
+	// omitted required inputs fail before calling the server
+	calls := server.calls.Load()
+	_, err = RunFunction(&logging.ContextMap{}, "f", map[string]sharedtypes.FilledInputOutput{})
+	var missing *MissingInputsError
+	if !errors.As(err, &missing) || !slices.Equal(missing.Inputs, []string{"query"}) || missing.FunctionName != "f" {
+		t.Fatalf("RunFunction() error = %v, want *MissingInputsError for query", err)
+	}
+	if code := aalierrors.CodeOf(err); code != aalierrors.CodeValidation {
+		t.Errorf("error code = %v, want %v", code, aalierrors.CodeValidation)
+	}
+	if server.calls.Load() != calls {
+		t.Error("server was called despite missing required inputs")
+	}
+
+	_, _, err = StreamFunction(&logging.ContextMap{}, "f", map[string]sharedtypes.FilledInputOutput{"limit": {Name: "limit", GoType: "int", Value: 1}})
+	if !errors.As(err, &missing) {
+		t.Errorf("StreamFunction() error = %v, want *MissingInputsError", err)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sync"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/ansys/aali-sharedtypes/pkg/typeconverters"
+)
+
+// defaultResultCacheMaxEntries is the maximum number of cached results if not configured
+const defaultResultCacheMaxEntries = 1000
+
+// resultCache is a LRU cache of function results with expiry
+type resultCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element // values are *resultCacheEntry
+	order   *list.List               // most recently used first
+	now     func() time.Time
+}
+
+// resultCacheEntry is a cached function result
+// The outputs are stored serialized, so callers modifying the values of a returned result,
+// e.g. appending to a slice or setting a map entry, cannot change the cached result.
+type resultCacheEntry struct {
+	key     string
+	outputs map[string]cachedOutput
+	expires time.Time
+}
+
+// cachedOutput is a serialized function output
+type cachedOutput struct {
+	goType     string
+	serialized string
+}
+
+// results caches the outputs of idempotent functions
+var results = newResultCache()
+
+// newResultCache creates an empty result cache
+//
+// Returns:
+//   - *resultCache: the result cache
+func newResultCache() *resultCache {
+	return &resultCache{
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// resultCacheSettings returns the result cache settings from the global config
+// The cache is opt-in: it is disabled unless FLOWKIT_RESULT_CACHE_TTL_SECONDS is set.
+//
+// Returns:
+//   - ttl: the time to live of cached results; 0 if the cache is disabled
+//   - maxEntries: the maximum number of cached results
+func resultCacheSettings() (ttl time.Duration, maxEntries int) {
+	if config.Current() == nil || config.Current().FLOWKIT_RESULT_CACHE_TTL_SECONDS <= 0 {
+		return 0, 0
+	}
+	maxEntries = config.Current().FLOWKIT_RESULT_CACHE_MAX_ENTRIES
+	if maxEntries <= 0 {
+		maxEntries = defaultResultCacheMaxEntries
+	}
+	return time.Duration(config.Current().FLOWKIT_RESULT_CACHE_TTL_SECONDS) * time.Second, maxEntries
+}
+
+// ClearResultCache removes all cached function results
+func ClearResultCache() {
+	results.clear()
+}
+
+// resultCacheKey computes the cache key of a function call from the function name and the serialized inputs
+// The inputs are serialized as their declared Go type, so the serialized forms are reused when sending them.
+//
+// Parameters:
+//   - functionDef: the function definition
+//   - inputs: the inputs to the function
+//
+// Returns:
+//   - key: the cache key
+//   - err: an error if an input cannot be serialized
+func resultCacheKey(functionDef *sharedtypes.FunctionDefinition, inputs map[string]sharedtypes.FilledInputOutput) (key string, err error) {
+	h := sha256.New()
+	writeHashField(h, functionDef.Name)
+	for _, inputDef := range functionDef.Inputs {
+		writeHashField(h, inputDef.Name)
+		writeHashField(h, inputDef.GoType)
+
+		value, ok := inputs[inputDef.Name]
+		if !ok {
+			h.Write([]byte{0})
+			continue
+		}
+		serialized, err := typeconverters.FilledValueToString(&value, inputDef.GoType)
+		if err != nil {
+			return "", fmt.Errorf("error converting input '%s' for function '%v' to string: %v", inputDef.Name, functionDef.Name, err)
+		}
+		h.Write([]byte{1})
+		writeHashField(h, serialized)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeHashField writes a length prefixed field to a hash, so that consecutive fields cannot be confused
+//
+// Parameters:
+//   - h: the hash
+//   - field: the field to write
+func writeHashField(h hash.Hash, field string) {
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(field)))
+	h.Write(length[:])
+	h.Write([]byte(field))
+}
+
+// get returns the cached outputs for a key
+//
+// Parameters:
+//   - key: the cache key
+//
+// Returns:
+//   - outputs: new outputs holding the serialized values of the cached outputs; decode them before use
+//   - ok: true if the key was found and has not expired
+func (c *resultCache) get(key string) (outputs map[string]sharedtypes.FilledInputOutput, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*resultCacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.removeElement(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+
+	outputs = make(map[string]sharedtypes.FilledInputOutput, len(entry.outputs))
+	for name, output := range entry.outputs {
+		outputs[name] = sharedtypes.NewSerializedFilledInputOutput(name, output.goType, output.serialized)
+	}
+	return outputs, true
+}
+
+// put caches the serialized outputs for a key, evicting the least recently used results above maxEntries
+//
+// Parameters:
+//   - key: the cache key
+//   - outputs: the outputs to cache
+//   - ttl: the time to live of the result
+//   - maxEntries: the maximum number of cached results
+//
+// Returns:
+//   - err: an error if an output cannot be serialized; the result is not cached in this case
+func (c *resultCache) put(key string, outputs map[string]sharedtypes.FilledInputOutput, ttl time.Duration, maxEntries int) (err error) {
+	stored := make(map[string]cachedOutput, len(outputs))
+	for name, output := range outputs {
+		serialized, err := typeconverters.FilledValueToString(&output, output.GoType)
+		if err != nil {
+			return fmt.Errorf("error converting output '%s' to string: %v", name, err)
+		}
+		stored[name] = cachedOutput{goType: output.GoType, serialized: serialized}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &resultCacheEntry{key: key, outputs: stored, expires: c.now().Add(ttl)}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+	} else {
+		c.entries[key] = c.order.PushFront(entry)
+	}
+
+	for c.order.Len() > maxEntries {
+		c.removeElement(c.order.Back())
+	}
+	return nil
+}
+
+// clear removes all cached results
+func (c *resultCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]*list.Element{}
+	c.order.Init()
+}
+
+// removeElement removes a cached result; the lock must be held
+//
+// Parameters:
+//   - element: the list element of the result
+func (c *resultCache) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*resultCacheEntry).key)
+}
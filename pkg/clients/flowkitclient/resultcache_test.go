@@ -0,0 +1,236 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/ansys/aali-sharedtypes/pkg/typeconverters"
+)
+
+func TestResultCacheKey(t *testing.T) {
+	functionDef := &sharedtypes.FunctionDefinition{
+		Name: "embed",
+		Inputs: []sharedtypes.FunctionInput{
+			{Name: "a", GoType: "string"},
+			{Name: "b", GoType: "string"},
+		},
+	}
+	key := func(inputs map[string]sharedtypes.FilledInputOutput) string {
+		t.Helper()
+		key, err := resultCacheKey(functionDef, inputs)
+		if err != nil {
+			t.Fatalf("resultCacheKey() error = %v", err)
+		}
+		return key
+	}
+	filled := func(value string) sharedtypes.FilledInputOutput {
+		return sharedtypes.FilledInputOutput{GoType: "string", Value: value}
+	}
+
+	base := key(map[string]sharedtypes.FilledInputOutput{"a": filled("x"), "b": filled("y")})
+	if got := key(map[string]sharedtypes.FilledInputOutput{"b": filled("y"), "a": filled("x")}); got != base {
+		t.Error("key depends on map order")
+	}
+	lazy := sharedtypes.NewSerializedFilledInputOutput("a", "string", "x")
+	if got := key(map[string]sharedtypes.FilledInputOutput{"a": lazy, "b": filled("y")}); got != base {
+		t.Error("key of lazy input differs from decoded input")
+	}
+
+	others := []map[string]sharedtypes.FilledInputOutput{
+		{"a": filled("xy"), "b": filled("")},
+		{"a": filled("x")},
+		{"a": filled("x"), "b": filled("")},
+		{"a": filled("y"), "b": filled("x")},
+	}
+	for _, inputs := range others {
+		if key(inputs) == base {
+			t.Errorf("key of %v equals key of different inputs", inputs)
+		}
+	}
+
+	functionDef.Name = "other"
+	if key(map[string]sharedtypes.FilledInputOutput{"a": filled("x"), "b": filled("y")}) == base {
+		t.Error("key does not depend on the function name")
+	}
+}
+
+func TestResultCache(t *testing.T) {
+	now := time.Unix(0, 0)
+	cache := newResultCache()
+	cache.now = func() time.Time { return now }
+	outputs := func(value string) map[string]sharedtypes.FilledInputOutput {
+		return map[string]sharedtypes.FilledInputOutput{"out": {Name: "out", GoType: "string", Value: value}}
+	}
+
+	value := func(outputs map[string]sharedtypes.FilledInputOutput) interface{} {
+		t.Helper()
+		output := outputs["out"]
+		decoded, err := typeconverters.FilledValue(&output)
+		if err != nil {
+			t.Fatalf("FilledValue() error = %v", err)
+		}
+		return decoded
+	}
+
+	if err := cache.put("a", outputs("1"), time.Minute, 2); err != nil {
+		t.Fatalf("put() error = %v", err)
+	}
+	got, ok := cache.get("a")
+	if !ok || value(got) != "1" {
+		t.Fatalf("get() = %v, %v", got, ok)
+	}
+
+	// returned maps are copies
+	got["out"] = sharedtypes.FilledInputOutput{Value: "changed"}
+	if got, _ := cache.get("a"); value(got) != "1" {
+		t.Error("modifying a returned map changed the cache")
+	}
+
+	// returned values are copies, including the elements of slices and maps
+	nested := map[string]sharedtypes.FilledInputOutput{"out": {Name: "out", GoType: "[]string", Value: []string{"x"}}}
+	if err := cache.put("nested", nested, time.Minute, 3); err != nil {
+		t.Fatalf("put() error = %v", err)
+	}
+	nested["out"].Value.([]string)[0] = "changed by the producer"
+	got, _ = cache.get("nested")
+	decoded := value(got).([]string)
+	decoded[0] = "changed by a consumer"
+	if got, _ := cache.get("nested"); value(got).([]string)[0] != "x" {
+		t.Error("modifying a cached slice changed the cache")
+	}
+	cache.clear()
+	if err := cache.put("a", outputs("1"), time.Minute, 2); err != nil {
+		t.Fatalf("put() error = %v", err)
+	}
+
+	// least recently used entries are evicted
+	_ = cache.put("b", outputs("2"), time.Minute, 2)
+	cache.get("a")
+	_ = cache.put("c", outputs("3"), time.Minute, 2)
+	if _, ok := cache.get("b"); ok {
+		t.Error("least recently used entry not evicted")
+	}
+	if _, ok := cache.get("a"); !ok {
+		t.Error("recently used entry evicted")
+	}
+
+	// entries expire
+	now = now.Add(time.Minute)
+	if _, ok := cache.get("a"); ok {
+		t.Error("expired entry returned")
+	}
+	if len(cache.entries) != 1 || cache.order.Len() != 1 {
+		t.Errorf("expired entry not removed: %d entries", len(cache.entries))
+	}
+
+	cache.clear()
+	if _, ok := cache.get("c"); ok {
+		t.Error("entry returned after clear")
+	}
+}
+
+func TestResultCacheSettings(t *testing.T) {
+	defer func(previous *config.Config) { config.GlobalConfig = previous }(config.GlobalConfig)
+
+	tests := []struct {
+		name           string
+		config         *config.Config
+		wantTtl        time.Duration
+		wantMaxEntries int
+	}{
+		{"no config", nil, 0, 0},
+		{"disabled", &config.Config{FLOWKIT_RESULT_CACHE_MAX_ENTRIES: 10}, 0, 0},
+		{"default max entries", &config.Config{FLOWKIT_RESULT_CACHE_TTL_SECONDS: 60}, time.Minute, defaultResultCacheMaxEntries},
+		{"max entries", &config.Config{FLOWKIT_RESULT_CACHE_TTL_SECONDS: 1, FLOWKIT_RESULT_CACHE_MAX_ENTRIES: 10}, time.Second, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.GlobalConfig = tt.config
+			ttl, maxEntries := resultCacheSettings()
+			if ttl != tt.wantTtl || maxEntries != tt.wantMaxEntries {
+				t.Errorf("resultCacheSettings() = %v, %d, want %v, %d", ttl, maxEntries, tt.wantTtl, tt.wantMaxEntries)
+			}
+		})
+	}
+}
+
+func TestRunFunctionResultCache(t *testing.T) {
+	server := &fakeFlowkitServer{
+		functions: map[string]*aaliflowkitgrpc.FunctionDefinition{
+			"cached":   echoDefinition("cached", true),
+			"uncached": echoDefinition("uncached", false),
+		},
+		run: echoFunction,
+	}
+	startFakeFlowkitServer(t, server)
+	config.GlobalConfig = &config.Config{FLOWKIT_RESULT_CACHE_TTL_SECONDS: 60}
+	ClearResultCache()
+	defer ClearResultCache()
+
+	run := func(run func(*logging.ContextMap, string, map[string]sharedtypes.FilledInputOutput) (map[string]sharedtypes.FilledInputOutput, error), function string, text string) interface{} {
+		t.Helper()
+		outputs, err := run(&logging.ContextMap{}, function, map[string]sharedtypes.FilledInputOutput{
+			"text": {Name: "text", GoType: "string", Value: text},
+		})
+		if err != nil {
+			t.Fatalf("%s error = %v", function, err)
+		}
+		return outputs["text"].Value
+	}
+
+	tests := []struct {
+		name      string
+		run       func(*logging.ContextMap, string, map[string]sharedtypes.FilledInputOutput) (map[string]sharedtypes.FilledInputOutput, error)
+		function  string
+		text      string
+		want      interface{}
+		wantCalls int32
+	}{
+		{"first call lazy", RunFunctionLazy, "cached", "hello", nil, 1},
+		{"cached lazy result decoded", RunFunction, "cached", "hello", "hello", 1},
+		{"cached decoded result", RunFunction, "cached", "hello", "hello", 1},
+		{"different inputs", RunFunction, "cached", "world", "world", 2},
+		{"not idempotent", RunFunction, "uncached", "hello", "hello", 3},
+		{"not idempotent again", RunFunction, "uncached", "hello", "hello", 4},
+	}
+	for _, tt := range tests {
+		if got := run(tt.run, tt.function, tt.text); got != tt.want {
+			t.Errorf("%s: output = %v, want %v", tt.name, got, tt.want)
+		}
+		if calls := server.calls.Load(); calls != tt.wantCalls {
+			t.Errorf("%s: server calls = %d, want %d", tt.name, calls, tt.wantCalls)
+		}
+	}
+	// the cache is opt-in
+	config.GlobalConfig = &config.Config{}
+	run(RunFunction, "cached", "hello")
+	if calls := server.calls.Load(); calls != 5 {
+		t.Errorf("server calls with disabled cache = %d, want 5", calls)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeadlineExceededError is returned when an external function does not finish before its deadline,
+// so that slow functions can be told apart from failing ones.
+// It matches context.DeadlineExceeded with errors.Is.
+type DeadlineExceededError struct {
+	FunctionName string
+	Timeout      time.Duration // timeout applied to the call; 0 if the deadline was set by the caller
+}
+
+// Error returns the error message
+//
+// Returns:
+//   - string: the error message
+func (e *DeadlineExceededError) Error() string {
+	if e.Timeout > 0 {
+		return fmt.Sprintf("function '%s' did not finish within %v", e.FunctionName, e.Timeout)
+	}
+	return fmt.Sprintf("function '%s' did not finish before the deadline", e.FunctionName)
+}
+
+// Unwrap returns context.DeadlineExceeded
+//
+// Returns:
+//   - error: context.DeadlineExceeded
+func (e *DeadlineExceededError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// functionTimeout returns the default timeout of a function call
+// The timeout of the function definition takes precedence over FLOWKIT_FUNCTION_TIMEOUT_SECONDS;
+// a shorter wall-clock limit in the resource limits of the definition shortens it.
+//
+// Parameters:
+//   - functionDef: the function definition
+//
+// Returns:
+//   - timeout: the timeout; 0 if calls are not limited
+func functionTimeout(functionDef *sharedtypes.FunctionDefinition) (timeout time.Duration) {
+	switch {
+	case functionDef.TimeoutSeconds > 0:
+		timeout = time.Duration(functionDef.TimeoutSeconds) * time.Second
+	case config.Current() != nil && config.Current().FLOWKIT_FUNCTION_TIMEOUT_SECONDS > 0:
+		timeout = time.Duration(config.Current().FLOWKIT_FUNCTION_TIMEOUT_SECONDS) * time.Second
+	}
+	if functionDef.ResourceLimits != nil {
+		if limit := functionDef.ResourceLimits.Timeout(); limit > 0 && (timeout == 0 || limit < timeout) {
+			timeout = limit
+		}
+	}
+	return timeout
+}
+
+// withFunctionDeadline returns a context limited by the deadline of a function call
+// A deadline already set on callCtx is a per-call deadline and takes precedence over the function timeout.
+//
+// Parameters:
+//   - callCtx: the context of the call
+//   - functionDef: the function definition
+//
+// Returns:
+//   - ctx: the context with the deadline
+//   - cancel: the cancel function of the context
+//   - timeout: the applied function timeout; 0 if the per-call deadline or no deadline is used
+func withFunctionDeadline(callCtx context.Context, functionDef *sharedtypes.FunctionDefinition) (ctx context.Context, cancel context.CancelFunc, timeout time.Duration) {
+	if _, ok := callCtx.Deadline(); !ok {
+		timeout = functionTimeout(functionDef)
+	}
+	if timeout <= 0 {
+		ctx, cancel = context.WithCancel(callCtx)
+		return ctx, cancel, 0
+	}
+	ctx, cancel = context.WithTimeout(callCtx, timeout)
+	return ctx, cancel, timeout
+}
+
+// isDeadlineExceeded checks if a gRPC call failed because its deadline was exceeded
+//
+// Parameters:
+//   - ctx: the context of the call
+//   - err: the error of the call
+//
+// Returns:
+//   - bool: true if the deadline was exceeded
+func isDeadlineExceeded(ctx context.Context, err error) bool {
+	return status.Code(err) == codes.DeadlineExceeded || errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
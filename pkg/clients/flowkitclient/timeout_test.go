@@ -0,0 +1,138 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFunctionTimeout(t *testing.T) {
+	defer func(previous *config.Config) { config.GlobalConfig = previous }(config.GlobalConfig)
+
+	tests := []struct {
+		name           string
+		config         *config.Config
+		timeoutSeconds int
+		limits         *sharedtypes.ResourceLimits
+		want           time.Duration
+	}{
+		{"no timeout", nil, 0, nil, 0},
+		{"function timeout", nil, 5, nil, 5 * time.Second},
+		{"config default", &config.Config{FLOWKIT_FUNCTION_TIMEOUT_SECONDS: 30}, 0, nil, 30 * time.Second},
+		{"function timeout over config", &config.Config{FLOWKIT_FUNCTION_TIMEOUT_SECONDS: 30}, 5, nil, 5 * time.Second},
+		{"resource limit", nil, 0, &sharedtypes.ResourceLimits{TimeoutSeconds: 7}, 7 * time.Second},
+		{"shorter resource limit", &config.Config{FLOWKIT_FUNCTION_TIMEOUT_SECONDS: 30}, 0, &sharedtypes.ResourceLimits{TimeoutSeconds: 7}, 7 * time.Second},
+		{"longer resource limit", nil, 5, &sharedtypes.ResourceLimits{TimeoutSeconds: 7}, 5 * time.Second},
+		{"resource limits without timeout", nil, 5, &sharedtypes.ResourceLimits{MemoryMb: 512}, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.GlobalConfig = tt.config
+			got := functionTimeout(&sharedtypes.FunctionDefinition{TimeoutSeconds: tt.timeoutSeconds, ResourceLimits: tt.limits})
+			if got != tt.want {
+				t.Errorf("functionTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithFunctionDeadline(t *testing.T) {
+	functionDef := &sharedtypes.FunctionDefinition{Name: "slow", TimeoutSeconds: 60}
+
+	ctx, cancel, timeout := withFunctionDeadline(context.Background(), functionDef)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || timeout != time.Minute || time.Until(deadline) > time.Minute {
+		t.Errorf("withFunctionDeadline() = %v, %v, want function timeout", deadline, timeout)
+	}
+
+	// a per-call deadline takes precedence, even if it is longer
+	callCtx, callCancel := context.WithTimeout(context.Background(), time.Hour)
+	defer callCancel()
+	ctx, cancel, timeout = withFunctionDeadline(callCtx, functionDef)
+	defer cancel()
+	callDeadline, _ := callCtx.Deadline()
+	if deadline, _ := ctx.Deadline(); timeout != 0 || !deadline.Equal(callDeadline) {
+		t.Errorf("withFunctionDeadline() = %v, %v, want per-call deadline", deadline, timeout)
+	}
+
+	ctx, cancel, timeout = withFunctionDeadline(context.Background(), &sharedtypes.FunctionDefinition{})
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok || timeout != 0 {
+		t.Errorf("withFunctionDeadline() without timeout set a deadline")
+	}
+}
+
+func TestDeadlineExceededError(t *testing.T) {
+	var err error = &DeadlineExceededError{FunctionName: "slow", Timeout: time.Second}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("DeadlineExceededError does not match context.DeadlineExceeded")
+	}
+	if got := err.Error(); got != "function 'slow' did not finish within 1s" {
+		t.Errorf("Error() = %q", got)
+	}
+	if got := (&DeadlineExceededError{FunctionName: "slow"}).Error(); got != "function 'slow' did not finish before the deadline" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestRunFunctionDeadline(t *testing.T) {
+	server := &fakeFlowkitServer{
+		functions: map[string]*aaliflowkitgrpc.FunctionDefinition{
+			"slow":    echoDefinition("slow", false),
+			"failing": echoDefinition("failing", false),
+		},
+		run: func(ctx context.Context, inputs *aaliflowkitgrpc.FunctionInputs) (*aaliflowkitgrpc.FunctionOutputs, error) {
+			if inputs.Name == "failing" {
+				return nil, status.Error(codes.Internal, "function crashed")
+			}
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	startFakeFlowkitServer(t, server)
+	inputs := map[string]sharedtypes.FilledInputOutput{"text": {Name: "text", GoType: "string", Value: "hello"}}
+
+	callCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := RunFunctionWithContext(callCtx, &logging.ContextMap{}, "slow", inputs)
+	var deadlineErr *DeadlineExceededError
+	if !errors.As(err, &deadlineErr) || deadlineErr.FunctionName != "slow" {
+		t.Errorf("RunFunctionWithContext() of slow function error = %v, want DeadlineExceededError", err)
+	}
+
+	_, err = RunFunction(&logging.ContextMap{}, "failing", inputs)
+	if err == nil || errors.As(err, &deadlineErr) {
+		t.Errorf("RunFunction() of failing function error = %v, want other error", err)
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/ansys/aali-sharedtypes/pkg/typeconverters"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// clientCapabilities are the capabilities announced by this client to the FlowKit server
+var clientCapabilities = []string{
+	sharedtypes.FlowkitCapabilityBinaryValues,
+	sharedtypes.FlowkitCapabilityCompressionGzip,
+	sharedtypes.FlowkitCapabilityCompressionZstd,
+}
+
+// valueEncoding describes how function input values are sent to a FlowKit server
+type valueEncoding struct {
+	binary      bool   // []byte values are sent as raw bytes
+	compression string // content encoding of large values; empty to disable compression
+	threshold   int    // minimum size in bytes of a value to be compressed
+}
+
+// serverCapabilities holds the capabilities announced by each FlowKit server, keyed by URL
+var serverCapabilities = struct {
+	sync.RWMutex
+	byUrl map[string]map[string]bool
+}{byUrl: map[string]map[string]bool{}}
+
+// parseCapabilities parses the capability header values into a set
+// Each value can contain several capabilities separated by commas
+//
+// Parameters:
+//   - values: the header values
+//
+// Returns:
+//   - capabilities: the set of capabilities
+func parseCapabilities(values []string) (capabilities map[string]bool) {
+	capabilities = map[string]bool{}
+	for _, value := range values {
+		for _, capability := range strings.Split(value, ",") {
+			capability = strings.TrimSpace(capability)
+			if capability != "" {
+				capabilities[capability] = true
+			}
+		}
+	}
+	return capabilities
+}
+
+// saveServerCapabilities saves the capabilities announced by a FlowKit server in its response header
+//
+// Parameters:
+//   - url: the URL of the FlowKit server
+//   - header: the response header of the server
+func saveServerCapabilities(url string, header metadata.MD) {
+	capabilities := parseCapabilities(header.Get(sharedtypes.FlowkitCapabilitiesMetadataKey))
+
+	serverCapabilities.Lock()
+	defer serverCapabilities.Unlock()
+	serverCapabilities.byUrl[url] = capabilities
+}
+
+// serverSupports checks if a FlowKit server announced a capability
+// Servers that did not announce any capabilities only support the string value fields.
+//
+// Parameters:
+//   - url: the URL of the FlowKit server
+//   - capability: the capability to check
+//
+// Returns:
+//   - supported: true if the server supports the capability
+func serverSupports(url string, capability string) (supported bool) {
+	serverCapabilities.RLock()
+	defer serverCapabilities.RUnlock()
+	return serverCapabilities.byUrl[url][capability]
+}
+
+// serverValueEncoding returns the value encoding to use for a FlowKit server
+// Compression is only used if configured in FLOWKIT_COMPRESSION and announced by the server
+// together with binary values.
+//
+// Parameters:
+//   - url: the URL of the FlowKit server
+//
+// Returns:
+//   - encoding: the value encoding
+func serverValueEncoding(url string) (encoding valueEncoding) {
+	encoding.binary = serverSupports(url, sharedtypes.FlowkitCapabilityBinaryValues)
+
+	// compressed values are sent in value_bytes, which requires binary value support
+	if !encoding.binary || config.Current() == nil || config.Current().FLOWKIT_COMPRESSION == "" {
+		return encoding
+	}
+	compression := config.Current().FLOWKIT_COMPRESSION
+	if !serverSupports(url, "compression-"+compression) {
+		return encoding
+	}
+	encoding.compression = compression
+	encoding.threshold = config.Current().FLOWKIT_COMPRESSION_THRESHOLD_BYTES
+	if encoding.threshold <= 0 {
+		encoding.threshold = defaultCompressionThreshold
+	}
+	return encoding
+}
+
+// encodeInput sets the value of a gRPC function input
+// []byte values are sent as raw bytes if the server supports binary values, all other values are
+// sent as their string representation. Values above the compression threshold are compressed into
+// value_bytes, with content_encoding set so the server can detect it.
+//
+// Parameters:
+//   - grpcInput: the gRPC input to fill, with name and go type already set
+//   - value: the input value
+//   - encoding: the value encoding supported by the server
+//
+// Returns:
+//   - err: an error if the value cannot be converted
+func encodeInput(grpcInput *aaliflowkitgrpc.FunctionInput, value *sharedtypes.FilledInputOutput, encoding valueEncoding) (err error) {
+	var data []byte
+	if encoding.binary && typeconverters.GoType(grpcInput.GoType) == typeconverters.GoTypeSliceByte {
+		raw, err := typeconverters.FilledValue(value)
+		if err != nil {
+			return err
+		}
+		data, _ = raw.([]byte)
+	}
+
+	// send the string representation, reusing the serialized form if the value has one for this type
+	if len(data) == 0 {
+		grpcInput.Value, err = typeconverters.FilledValueToString(value, grpcInput.GoType)
+		// value_bytes of []byte inputs always holds the raw bytes, so their string form is never compressed
+		if err != nil || encoding.compression == "" || typeconverters.GoType(grpcInput.GoType) == typeconverters.GoTypeSliceByte || len(grpcInput.Value) < encoding.threshold {
+			return err
+		}
+		data = []byte(grpcInput.Value)
+	}
+
+	// compress large values, keeping the original if compression does not reduce the size
+	if encoding.compression != "" && len(data) >= encoding.threshold {
+		compressed, err := compress(data, encoding.compression)
+		if err != nil {
+			return err
+		}
+		if len(compressed) < len(data) {
+			grpcInput.Value = ""
+			grpcInput.ValueBytes = compressed
+			grpcInput.ContentEncoding = encoding.compression
+			return nil
+		}
+	}
+
+	if grpcInput.Value == "" {
+		grpcInput.ValueBytes = data
+	}
+	return nil
+}
+
+// MissingInputsError is returned when a function is called without some of its required inputs,
+// so authoring errors in workflows fail before the function runs with null values.
+type MissingInputsError struct {
+	FunctionName string
+	Inputs       []string // names of the missing required inputs
+}
+
+// Error returns the error message
+//
+// Returns:
+//   - string: the error message
+func (e *MissingInputsError) Error() string {
+	return fmt.Sprintf("function '%s' is missing required inputs: %s", e.FunctionName, strings.Join(e.Inputs, ", "))
+}
+
+// checkRequiredInputs checks that all required inputs of a function are provided
+//
+// Parameters:
+//   - functionDef: the function definition
+//   - functionName: the name of the function
+//   - inputs: the provided inputs by name
+//
+// Returns:
+//   - err: a *MissingInputsError if required inputs are missing
+func checkRequiredInputs(functionDef *sharedtypes.FunctionDefinition, functionName string, inputs map[string]sharedtypes.FilledInputOutput) (err error) {
+	if missing := functionDef.MissingRequiredInputs(inputs); len(missing) > 0 {
+		return &MissingInputsError{FunctionName: functionName, Inputs: missing}
+	}
+	return nil
+}
+
+// decodeOutput converts a gRPC function output to a FilledInputOutput
+// Outputs with value_bytes set take precedence over the string value field, and are decompressed
+// according to their content_encoding.
+//
+// Parameters:
+//   - output: the gRPC output
+//   - decode: true to convert the value to its Go type, false to keep it serialized until first use
+//
+// Returns:
+//   - filled: the filled output
+//   - err: an error if the value cannot be converted
+func decodeOutput(output *aaliflowkitgrpc.FunctionOutput, decode bool) (filled sharedtypes.FilledInputOutput, err error) {
+	serialized := output.Value
+	if len(output.ValueBytes) > 0 {
+		data := output.ValueBytes
+		if output.ContentEncoding != "" {
+			data, err = decompress(data, output.ContentEncoding)
+			if err != nil {
+				return sharedtypes.FilledInputOutput{}, err
+			}
+		}
+
+		// raw bytes need no conversion
+		if typeconverters.GoType(output.GoType) == typeconverters.GoTypeSliceByte {
+			return sharedtypes.FilledInputOutput{
+				Name:   output.Name,
+				GoType: output.GoType,
+				Value:  data,
+			}, nil
+		}
+		serialized = string(data)
+	}
+
+	if !decode {
+		return sharedtypes.NewSerializedFilledInputOutput(output.Name, output.GoType, serialized), nil
+	}
+
+	// convert value to Go type, keeping the serialized form for the next function
+	return typeconverters.DecodeFilledInputOutput(output.Name, output.GoType, serialized)
+}
+
+// withClientCapabilities adds the client capabilities to the outgoing gRPC metadata
+//
+// Parameters:
+//   - ctx: the context of the gRPC call
+//
+// Returns:
+//   - context.Context: the context with the capabilities metadata
+func withClientCapabilities(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, sharedtypes.FlowkitCapabilitiesMetadataKey, strings.Join(clientCapabilities, ","))
+}
@@ -0,0 +1,276 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package flowkitclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/ansys/aali-sharedtypes/pkg/typeconverters"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseCapabilities(t *testing.T) {
+	got := parseCapabilities([]string{"binary-values, other", " ", "third"})
+	for _, capability := range []string{"binary-values", "other", "third"} {
+		if !got[capability] {
+			t.Errorf("parseCapabilities() missing %q in %v", capability, got)
+		}
+	}
+	if len(got) != 3 {
+		t.Errorf("parseCapabilities() = %v, want 3 entries", got)
+	}
+}
+
+func TestServerCapabilities(t *testing.T) {
+	url := "test-server:50051"
+	if serverSupports(url, sharedtypes.FlowkitCapabilityBinaryValues) {
+		t.Fatal("unknown server supports binary values")
+	}
+
+	saveServerCapabilities(url, metadata.Pairs(sharedtypes.FlowkitCapabilitiesMetadataKey, sharedtypes.FlowkitCapabilityBinaryValues))
+	if !serverSupports(url, sharedtypes.FlowkitCapabilityBinaryValues) {
+		t.Error("server announcing binary values does not support them")
+	}
+
+	// a server restarted without the capability falls back to strings
+	saveServerCapabilities(url, metadata.MD{})
+	if serverSupports(url, sharedtypes.FlowkitCapabilityBinaryValues) {
+		t.Error("server capabilities not replaced")
+	}
+}
+
+func TestWithClientCapabilities(t *testing.T) {
+	md, _ := metadata.FromOutgoingContext(withClientCapabilities(context.Background()))
+	got := parseCapabilities(md.Get(sharedtypes.FlowkitCapabilitiesMetadataKey))
+	if !got[sharedtypes.FlowkitCapabilityBinaryValues] {
+		t.Errorf("outgoing metadata = %v, want binary values capability", md)
+	}
+}
+
+func TestEncodeInput(t *testing.T) {
+	data := []byte{0, 1, 2, 255}
+	binaryEncoding := valueEncoding{binary: true}
+	tests := []struct {
+		name       string
+		value      sharedtypes.FilledInputOutput
+		encoding   valueEncoding
+		wantValue  string
+		wantBinary []byte
+	}{
+		{"bytes binary", sharedtypes.FilledInputOutput{GoType: "[]byte", Value: data}, binaryEncoding, "", data},
+		{"bytes fallback", sharedtypes.FilledInputOutput{GoType: "[]byte", Value: data}, valueEncoding{}, `"AAEC/w=="`, nil},
+		{"lazy bytes binary", sharedtypes.NewSerializedFilledInputOutput("", "[]byte", `"AAEC/w=="`), binaryEncoding, "", data},
+		{"empty bytes binary", sharedtypes.FilledInputOutput{GoType: "[]byte", Value: []byte{}}, binaryEncoding, `""`, nil},
+		{"string binary", sharedtypes.FilledInputOutput{GoType: "string", Value: "hello"}, binaryEncoding, "hello", nil},
+		{"string below threshold", sharedtypes.FilledInputOutput{GoType: "string", Value: "hello"}, valueEncoding{binary: true, compression: "gzip", threshold: 100}, "hello", nil},
+		{"incompressible bytes", sharedtypes.FilledInputOutput{GoType: "[]byte", Value: data}, valueEncoding{binary: true, compression: "gzip", threshold: 1}, "", data},
+		{"int binary", sharedtypes.FilledInputOutput{GoType: "int", Value: 42}, binaryEncoding, "42", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &aaliflowkitgrpc.FunctionInput{Name: "in", GoType: tt.value.GoType}
+			if err := encodeInput(input, &tt.value, tt.encoding); err != nil {
+				t.Fatalf("encodeInput() error = %v", err)
+			}
+			if input.Value != tt.wantValue || !bytes.Equal(input.ValueBytes, tt.wantBinary) {
+				t.Errorf("encodeInput() = %q / %v, want %q / %v", input.Value, input.ValueBytes, tt.wantValue, tt.wantBinary)
+			}
+		})
+	}
+}
+
+func TestDecodeOutput(t *testing.T) {
+	data := []byte{0, 1, 2, 255}
+	tests := []struct {
+		name    string
+		output  *aaliflowkitgrpc.FunctionOutput
+		want    interface{}
+		wantErr bool
+	}{
+		{"bytes binary", &aaliflowkitgrpc.FunctionOutput{GoType: "[]byte", ValueBytes: data}, data, false},
+		{"bytes string", &aaliflowkitgrpc.FunctionOutput{GoType: "[]byte", Value: `"AAEC/w=="`}, data, false},
+		{"int binary", &aaliflowkitgrpc.FunctionOutput{GoType: "int", ValueBytes: []byte("42")}, 42, false},
+		{"int string", &aaliflowkitgrpc.FunctionOutput{GoType: "int", Value: "42"}, 42, false},
+		{"bytes take precedence", &aaliflowkitgrpc.FunctionOutput{GoType: "int", Value: "1", ValueBytes: []byte("2")}, 2, false},
+		{"unknown encoding", &aaliflowkitgrpc.FunctionOutput{GoType: "[]byte", ValueBytes: data, ContentEncoding: "br"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, decode := range []bool{true, false} {
+				filled, err := decodeOutput(tt.output, decode)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("decodeOutput(decode=%v) error = %v, wantErr %v", decode, err, tt.wantErr)
+				}
+				if tt.wantErr {
+					continue
+				}
+				if data, ok := tt.want.([]byte); ok {
+					got, ok := filledValue(t, &filled).([]byte)
+					if !ok || !bytes.Equal(got, data) {
+						t.Errorf("decodeOutput(decode=%v) = %v, want %v", decode, got, data)
+					}
+				} else if got := filledValue(t, &filled); got != tt.want {
+					t.Errorf("decodeOutput(decode=%v) = %v, want %v", decode, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCompressedRoundTrip(t *testing.T) {
+	text := strings.Repeat("the same text over and over ", 1000)
+	data := bytes.Repeat([]byte{1, 2, 3, 4}, 1000)
+	values := []sharedtypes.FilledInputOutput{
+		{Name: "text", GoType: "string", Value: text},
+		{Name: "data", GoType: "[]byte", Value: data},
+	}
+	for _, compression := range []string{contentEncodingGzip, contentEncodingZstd} {
+		for _, value := range values {
+			t.Run(compression+"/"+value.Name, func(t *testing.T) {
+				input := &aaliflowkitgrpc.FunctionInput{Name: value.Name, GoType: value.GoType}
+				encoding := valueEncoding{binary: true, compression: compression, threshold: 1024}
+				if err := encodeInput(input, &value, encoding); err != nil {
+					t.Fatalf("encodeInput() error = %v", err)
+				}
+				if input.ContentEncoding != compression || input.Value != "" || len(input.ValueBytes) >= 1024 {
+					t.Fatalf("encodeInput() = %q, %d bytes with encoding %q, want compressed", input.Value, len(input.ValueBytes), input.ContentEncoding)
+				}
+
+				// the server answers with the same encoding
+				output := &aaliflowkitgrpc.FunctionOutput{Name: input.Name, GoType: input.GoType, ValueBytes: input.ValueBytes, ContentEncoding: input.ContentEncoding}
+				filled, err := decodeOutput(output, true)
+				if err != nil {
+					t.Fatalf("decodeOutput() error = %v", err)
+				}
+				if got, ok := filled.Value.([]byte); ok {
+					if !bytes.Equal(got, data) {
+						t.Errorf("decodeOutput() = %v, want %v", got, data)
+					}
+				} else if filled.Value != text {
+					t.Errorf("decodeOutput() = %v, want text", filled.Value)
+				}
+			})
+		}
+	}
+}
+
+func TestServerValueEncoding(t *testing.T) {
+	defer func(previous *config.Config) { config.GlobalConfig = previous }(config.GlobalConfig)
+
+	url := "compression-server:50051"
+	saveServerCapabilities(url, metadata.Pairs(sharedtypes.FlowkitCapabilitiesMetadataKey, "binary-values,compression-zstd"))
+	tests := []struct {
+		name   string
+		config *config.Config
+		want   valueEncoding
+	}{
+		{"no config", nil, valueEncoding{binary: true}},
+		{"disabled", &config.Config{}, valueEncoding{binary: true}},
+		{"default threshold", &config.Config{FLOWKIT_COMPRESSION: "zstd"}, valueEncoding{binary: true, compression: "zstd", threshold: defaultCompressionThreshold}},
+		{"threshold", &config.Config{FLOWKIT_COMPRESSION: "zstd", FLOWKIT_COMPRESSION_THRESHOLD_BYTES: 10}, valueEncoding{binary: true, compression: "zstd", threshold: 10}},
+		{"unsupported by server", &config.Config{FLOWKIT_COMPRESSION: "gzip"}, valueEncoding{binary: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.GlobalConfig = tt.config
+			if got := serverValueEncoding(url); got != tt.want {
+				t.Errorf("serverValueEncoding() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	// compression requires binary values
+	config.GlobalConfig = &config.Config{FLOWKIT_COMPRESSION: "gzip"}
+	saveServerCapabilities(url, metadata.Pairs(sharedtypes.FlowkitCapabilitiesMetadataKey, "compression-gzip"))
+	if got := serverValueEncoding(url); got != (valueEncoding{}) {
+		t.Errorf("serverValueEncoding() without binary values = %+v", got)
+	}
+}
+
+// TestCompressedPayloadSize measures the size of a large []DbResponse input sent as string versus compressed
+func TestCompressedPayloadSize(t *testing.T) {
+	responses := make([]sharedtypes.DbResponse, 200)
+	for i := range responses {
+		responses[i] = sharedtypes.DbResponse{
+			Guid:         uuid.New(),
+			DocumentId:   fmt.Sprintf("doc-%d", i%10),
+			DocumentName: fmt.Sprintf("manual-%d.pdf", i%10),
+			Text:         strings.Repeat(fmt.Sprintf("Section %d describes the boundary conditions of the solver. ", i), 20),
+			Keywords:     []string{"solver", "boundary", "mesh"},
+			Embedding:    make([]float32, 384),
+		}
+	}
+	value := sharedtypes.FilledInputOutput{Name: "responses", GoType: "[]DbResponse", Value: responses}
+
+	for _, compression := range []string{"", contentEncodingGzip, contentEncodingZstd} {
+		input := &aaliflowkitgrpc.FunctionInput{Name: value.Name, GoType: value.GoType}
+		encoding := valueEncoding{binary: true, compression: compression, threshold: 1 << 16}
+		if err := encodeInput(input, &value, encoding); err != nil {
+			t.Fatalf("encodeInput() error = %v", err)
+		}
+		t.Logf("%d DbResponses with compression %q: %d bytes", len(responses), compression, proto.Size(input))
+		if compression != "" && input.ContentEncoding != compression {
+			t.Errorf("value of %d bytes not compressed with %s", len(input.Value), compression)
+		}
+	}
+}
+
+// TestBinaryPayloadSize measures the size of a 1 MiB []byte input sent as bytes versus the base64 JSON string fallback
+func TestBinaryPayloadSize(t *testing.T) {
+	data := bytes.Repeat([]byte{0xde, 0xad, 0xbe, 0xef}, 1<<18)
+	value := sharedtypes.FilledInputOutput{Name: "file", GoType: "[]byte", Value: data}
+
+	sizes := map[bool]int{}
+	for _, binary := range []bool{false, true} {
+		input := &aaliflowkitgrpc.FunctionInput{Name: value.Name, GoType: value.GoType}
+		if err := encodeInput(input, &value, valueEncoding{binary: binary}); err != nil {
+			t.Fatalf("encodeInput() error = %v", err)
+		}
+		sizes[binary] = proto.Size(input)
+	}
+
+	reduction := 1 - float64(sizes[true])/float64(sizes[false])
+	t.Logf("payload for %d bytes: string %d bytes, binary %d bytes (%.1f%% smaller)", len(data), sizes[false], sizes[true], reduction*100)
+	if reduction < 0.2 {
+		t.Errorf("binary payload reduction = %.1f%%, want at least 20%%", reduction*100)
+	}
+}
+
+func filledValue(t *testing.T, filled *sharedtypes.FilledInputOutput) interface{} {
+	t.Helper()
+	value, err := typeconverters.FilledValue(filled)
+	if err != nil {
+		t.Fatalf("FilledValue() error = %v", err)
+	}
+	return value
+}
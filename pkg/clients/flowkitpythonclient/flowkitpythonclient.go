@@ -25,12 +25,13 @@ package flowkitpythonclient
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
 	"github.com/ansys/aali-sharedtypes/pkg/clients"
 	"github.com/ansys/aali-sharedtypes/pkg/clients/flowkitclient"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
 	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
 	"github.com/ansys/aali-sharedtypes/pkg/typeconverters"
 )
@@ -42,17 +43,12 @@ import (
 // Returns:
 //   - error: an error message if the API call fails
 func ListFunctionsAndSaveToInteralStates(url string, apiKey string) (err error) {
-	defer func() {
-		r := recover()
-		if r != nil {
-			err = fmt.Errorf("panic occured in ListFunctionsAndSaveToInteralStates: %v", r)
-		}
-	}()
+	defer logging.RecoverPanic(nil, "ListFunctionsAndSaveToInteralStates", &err)
 
 	// Create a new HTTP GET request
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		errorMessage := fmt.Errorf("error creating GET request: %v", err)
+		errorMessage := aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "error creating GET request")
 		return errorMessage
 	}
 
@@ -63,26 +59,26 @@ func ListFunctionsAndSaveToInteralStates(url string, apiKey string) (err error)
 	// Create a client and make the request
 	client, err := clients.GetHttpClient()
 	if err != nil {
-		errorMessage := fmt.Errorf("error getting HTTP client with cert: %v", err)
+		errorMessage := aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "error getting HTTP client with cert")
 		return errorMessage
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		errorMessage := fmt.Errorf("error making GET request: %v", err)
+		errorMessage := aalierrors.Wrap(nil, aalierrors.CodeTransient, err, "error making GET request")
 		return errorMessage
 	}
 	defer resp.Body.Close()
 
 	// Check if the status code is OK (200)
 	if resp.StatusCode != http.StatusOK {
-		errorMessage := fmt.Errorf("error: received non-200 status code: %d", resp.StatusCode)
+		errorMessage := aalierrors.Newf(nil, aalierrors.FromHTTPStatus(resp.StatusCode), "error: received non-200 status code: %d", resp.StatusCode)
 		return errorMessage
 	}
 
 	// Read the body of the response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		errorMessage := fmt.Errorf("error reading response body: %v", err)
+		errorMessage := aalierrors.Wrap(nil, aalierrors.CodeTransient, err, "error reading response body")
 		return errorMessage
 	}
 
@@ -90,7 +86,7 @@ func ListFunctionsAndSaveToInteralStates(url string, apiKey string) (err error)
 	var listResp []sharedtypes.FlowKitPythonFunction
 	err = json.Unmarshal(body, &listResp)
 	if err != nil {
-		errorMessage := fmt.Errorf("error unmarshalling JSON response: %v", err)
+		errorMessage := aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "error unmarshalling JSON response")
 		return errorMessage
 	}
 
@@ -112,10 +108,12 @@ func ListFunctionsAndSaveToInteralStates(url string, apiKey string) (err error)
 
 			// append the input to the list
 			inputs = append(inputs, sharedtypes.FunctionInput{
-				Name:    inputParam.Name,
-				Type:    inputParam.Type,
-				GoType:  inputParam.GoType,
-				Options: inputParam.Options,
+				Name:         inputParam.Name,
+				Type:         inputParam.Type,
+				GoType:       inputParam.GoType,
+				Options:      inputParam.Options,
+				DefaultValue: inputParam.DefaultValue,
+				Required:     inputParam.Required,
 			})
 		}
 		outputs := []sharedtypes.FunctionOutput{}
@@ -136,16 +134,21 @@ func ListFunctionsAndSaveToInteralStates(url string, apiKey string) (err error)
 
 		// Save the function to internal states
 		flowkitclient.AvailableFunctions[function.Name] = &sharedtypes.FunctionDefinition{
-			Name:        function.Name,
-			FlowkitUrl:  url,
-			ApiKey:      apiKey,
-			Description: function.Description,
-			DisplayName: function.DisplayName,
-			Category:    function.Category,
-			Inputs:      inputs,
-			Outputs:     outputs,
-			Type:        "python",
-			Path:        function.Path,
+			Name:           function.Name,
+			FlowkitUrl:     url,
+			ApiKey:         apiKey,
+			Description:    function.Description,
+			DisplayName:    function.DisplayName,
+			Category:       function.Category,
+			Inputs:         inputs,
+			Outputs:        outputs,
+			Type:           "python",
+			Path:           function.Path,
+			Examples:       function.Examples,
+			TimeoutSeconds: function.TimeoutSeconds,
+			Idempotent:     function.Idempotent,
+			MaxConcurrency: function.MaxConcurrency,
+			ResourceLimits: function.ResourceLimits,
 		}
 		// add the category to available categories
 		if flowkitclient.AvailableCategories != nil && function.Category != "" {
@@ -168,12 +171,7 @@ func ListFunctionsAndSaveToInteralStates(url string, apiKey string) (err error)
 //   - map[string]sharedtypes.FilledInputOutput: the outputs of the function
 //   - error: an error message if the API call fails
 func RunFunction(functionName string, inputs map[string]sharedtypes.FilledInputOutput) (outputs map[string]sharedtypes.FilledInputOutput, err error) {
-	defer func() {
-		r := recover()
-		if r != nil {
-			err = fmt.Errorf("panic occured in RunFunction: %v", r)
-		}
-	}()
+	defer logging.RecoverPanic(nil, "RunFunction", &err)
 
 	// Get function definition
 	functionDefinition := flowkitclient.AvailableFunctions[functionName]
@@ -181,7 +179,11 @@ func RunFunction(functionName string, inputs map[string]sharedtypes.FilledInputO
 	// Create input dict
 	inputDict := map[string]interface{}{}
 	for _, value := range inputs {
-		inputDict[value.Name] = value.Value
+		decoded, err := typeconverters.FilledValue(&value)
+		if err != nil {
+			return nil, aalierrors.Wrapf(nil, aalierrors.CodeValidation, err, "error decoding input '%s'", value.Name)
+		}
+		inputDict[value.Name] = decoded
 	}
 
 	// Create outputs go type map
@@ -193,14 +195,14 @@ func RunFunction(functionName string, inputs map[string]sharedtypes.FilledInputO
 	// Add inputDict as the request body
 	reqBody, err := json.Marshal(inputDict)
 	if err != nil {
-		errorMessage := fmt.Errorf("error marshalling inputDict: %v", err)
+		errorMessage := aalierrors.Wrap(nil, aalierrors.CodeValidation, err, "error marshalling inputDict")
 		return nil, errorMessage
 	}
 
 	// Create a new HTTP POST request
 	req, err := http.NewRequest("POST", functionDefinition.FlowkitUrl+functionDefinition.Path, bytes.NewBuffer(reqBody))
 	if err != nil {
-		errorMessage := fmt.Errorf("error creating POST request: %v", err)
+		errorMessage := aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "error creating POST request")
 		return nil, errorMessage
 	}
 
@@ -211,12 +213,12 @@ func RunFunction(functionName string, inputs map[string]sharedtypes.FilledInputO
 	// Create a client and make the request
 	client, err := clients.GetHttpClient()
 	if err != nil {
-		errorMessage := fmt.Errorf("error getting HTTP client with cert: %v", err)
+		errorMessage := aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "error getting HTTP client with cert")
 		return nil, errorMessage
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		errorMessage := fmt.Errorf("error making POST request: %v", err)
+		errorMessage := aalierrors.Wrap(nil, aalierrors.CodeTransient, err, "error making POST request")
 		return nil, errorMessage
 	}
 	defer resp.Body.Close()
@@ -226,17 +228,17 @@ func RunFunction(functionName string, inputs map[string]sharedtypes.FilledInputO
 		// Read the body of the response
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			errorMessage := fmt.Errorf("error reading response body: %v", err)
+			errorMessage := aalierrors.Wrap(nil, aalierrors.CodeTransient, err, "error reading response body")
 			return nil, errorMessage
 		}
-		errorMessage := fmt.Errorf("error: received non-200 status code: %d, error message: %v", resp.StatusCode, string(body))
+		errorMessage := aalierrors.Newf(nil, aalierrors.FromHTTPStatus(resp.StatusCode), "error: received non-200 status code: %d, error message: %v", resp.StatusCode, string(body))
 		return nil, errorMessage
 	}
 
 	// Read the body of the response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		errorMessage := fmt.Errorf("error reading response body: %v", err)
+		errorMessage := aalierrors.Wrap(nil, aalierrors.CodeTransient, err, "error reading response body")
 		return nil, errorMessage
 	}
 
@@ -244,7 +246,7 @@ func RunFunction(functionName string, inputs map[string]sharedtypes.FilledInputO
 	outputDict := map[string]interface{}{}
 	err = json.Unmarshal(body, &outputDict)
 	if err != nil {
-		errorMessage := fmt.Errorf("error unmarshalling JSON response: %v", err)
+		errorMessage := aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "error unmarshalling JSON response")
 		return nil, errorMessage
 	}
 
@@ -0,0 +1,374 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package kdbclient provides a client for the KnowledgeDb gRPC service.
+//
+// Searches are paginated with sharedtypes.PageRequest and sharedtypes.PageResponse, so callers can
+// process large result sets page by page instead of loading them into memory at once.
+package kdbclient
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/aalikdbgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/clients"
+	"github.com/ansys/aali-sharedtypes/pkg/netutil"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SearchQuery represents the query of a paginated knowledge DB search.
+type SearchQuery struct {
+	CollectionName string
+	Embedding      []float32
+	Filters        *sharedtypes.DbFilters // nil for no filters
+	MinScore       *float64               // minimum score of the results, higher is better; nil for no minimum
+	GetRelatives   bool                   // return the parent, children, leaf nodes and siblings of the results
+}
+
+// NewKnowledgeDbClient creates a client for the KnowledgeDb service at the given endpoint
+// The connection uses the shared gRPC dial options, including the keepalive settings and the global message size limits.
+//
+// Parameters:
+//   - endpoint: the URL of the knowledge DB service, e.g. "http://localhost:50052"
+//
+// Returns:
+//   - client: the KnowledgeDb client
+//   - connection: the connection; to be closed by the caller
+//   - err: an error if the endpoint is invalid or the connection cannot be created
+func NewKnowledgeDbClient(endpoint string) (client aalikdbgrpc.KnowledgeDbClient, connection *grpc.ClientConn, err error) {
+	parsed, err := netutil.ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, nil, aalierrors.Wrap(nil, aalierrors.CodeValidation, err, "invalid knowledge DB endpoint")
+	}
+	scheme := "http"
+	if parsed.TLS {
+		scheme = "https"
+	}
+
+	opts, err := clients.GetGrpcDialOptions(scheme)
+	if err != nil {
+		return nil, nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "unable to get gRPC dial options")
+	}
+	opts = append(opts, clients.GetGrpcMessageSizeDialOptions(clients.MessageSizeLimitsFromConfig(clients.MessageSizeLimits{}))...)
+
+	connection, err = grpc.NewClient(parsed.Address(), opts...)
+	if err != nil {
+		return nil, nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "unable to connect to knowledge DB gRPC")
+	}
+	return aalikdbgrpc.NewKnowledgeDbClient(connection), connection, nil
+}
+
+// SearchPage searches the knowledge DB and returns a single page of results
+// The page size of the request is sent as top_k and its cursor is passed through unchanged.
+//
+// Parameters:
+//   - ctx: the context of the call
+//   - client: the KnowledgeDb client
+//   - query: the search query
+//   - page: the page to get; an empty cursor gets the first page and a page size of 0 selects sharedtypes.DefaultPageSize
+//   - opts: the call options
+//
+// Returns:
+//   - sharedtypes.PageResponse: the results of the page and the cursor of the next page
+//   - error: an error if the page request is invalid or the search fails
+func SearchPage(ctx context.Context, client aalikdbgrpc.KnowledgeDbClient, query SearchQuery, page sharedtypes.PageRequest, opts ...grpc.CallOption) (sharedtypes.PageResponse, error) {
+	if err := page.Validate(); err != nil {
+		return sharedtypes.PageResponse{}, aalierrors.Wrap(nil, aalierrors.CodeValidation, err, "invalid page request")
+	}
+	page = page.Normalize()
+
+	request := &aalikdbgrpc.SearchRequest{
+		CollectionName: query.CollectionName,
+		Embedding:      query.Embedding,
+		TopK:           int32(page.PageSize),
+		MinScore:       query.MinScore,
+		Cursor:         page.Cursor,
+		GetRelatives:   query.GetRelatives,
+	}
+	if query.Filters != nil {
+		request.Filters = DbFiltersToProto(*query.Filters)
+	}
+
+	response, err := client.Search(ctx, request, opts...)
+	if err != nil {
+		return sharedtypes.PageResponse{}, aalierrors.FromGRPCError(err)
+	}
+
+	items := make([]sharedtypes.DbResponse, 0, len(response.GetResults()))
+	for _, result := range response.GetResults() {
+		item, err := DbResponseFromProto(result)
+		if err != nil {
+			return sharedtypes.PageResponse{}, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "invalid search result")
+		}
+		items = append(items, item)
+	}
+	return sharedtypes.PageResponse{
+		Items:         items,
+		NextCursor:    response.GetNextCursor(),
+		TotalEstimate: response.GetTotalEstimate(),
+	}, nil
+}
+
+// SearchAll searches the knowledge DB and passes the results to the handler page by page
+// Only one page is held in memory at a time; the iteration stops at the last page or the first handler error.
+//
+// Parameters:
+//   - ctx: the context of the calls
+//   - client: the KnowledgeDb client
+//   - query: the search query
+//   - pageSize: the number of results per page; 0 selects sharedtypes.DefaultPageSize
+//   - handle: the handler called for every page
+//   - opts: the call options
+//
+// Returns:
+//   - error: an error if a search fails or the handler returns an error
+func SearchAll(ctx context.Context, client aalikdbgrpc.KnowledgeDbClient, query SearchQuery, pageSize int, handle func(page sharedtypes.PageResponse) error, opts ...grpc.CallOption) error {
+	request := sharedtypes.PageRequest{PageSize: pageSize}
+	for {
+		page, err := SearchPage(ctx, client, query, request, opts...)
+		if err != nil {
+			return err
+		}
+		if err := handle(page); err != nil {
+			return err
+		}
+		if !page.HasMore() {
+			return nil
+		}
+		if page.NextCursor == request.Cursor {
+			return aalierrors.New(nil, aalierrors.CodeInternal, "knowledge DB returned the same cursor twice")
+		}
+		request.Cursor = page.NextCursor
+	}
+}
+
+// DbFiltersToProto converts the filters of a search to the gRPC message.
+//
+// Parameters:
+//   - filters: the filters
+//
+// Returns:
+//   - *aalikdbgrpc.DbFilters: the gRPC message
+func DbFiltersToProto(filters sharedtypes.DbFilters) *aalikdbgrpc.DbFilters {
+	message := &aalikdbgrpc.DbFilters{
+		GuidFilter:         filters.GuidFilter,
+		DocumentIdFilter:   filters.DocumentIdFilter,
+		DocumentNameFilter: filters.DocumentNameFilter,
+		LevelFilter:        filters.LevelFilter,
+	}
+	if len(filters.TagsFilter.FilterData) > 0 {
+		message.TagsFilter = &aalikdbgrpc.DbArrayFilter{NeedAll: filters.TagsFilter.NeedAll, FilterData: filters.TagsFilter.FilterData}
+	}
+	if len(filters.KeywordsFilter.FilterData) > 0 {
+		message.KeywordsFilter = &aalikdbgrpc.DbArrayFilter{NeedAll: filters.KeywordsFilter.NeedAll, FilterData: filters.KeywordsFilter.FilterData}
+	}
+	for _, filter := range filters.MetadataFilter {
+		message.MetadataFilter = append(message.MetadataFilter, &aalikdbgrpc.DbJsonFilter{
+			FieldName:  filter.FieldName,
+			FieldType:  filter.FieldType,
+			FilterData: filter.FilterData,
+			NeedAll:    filter.NeedAll,
+		})
+	}
+	for _, filter := range filters.RangeFilter {
+		rangeFilter := &aalikdbgrpc.DbRangeFilter{FieldName: filter.FieldName, Min: filter.Min, Max: filter.Max}
+		if filter.MinDate != nil {
+			rangeFilter.MinDate = timestamppb.New(*filter.MinDate)
+		}
+		if filter.MaxDate != nil {
+			rangeFilter.MaxDate = timestamppb.New(*filter.MaxDate)
+		}
+		message.RangeFilter = append(message.RangeFilter, rangeFilter)
+	}
+	for _, sub := range filters.And {
+		message.And = append(message.And, DbFiltersToProto(sub))
+	}
+	for _, sub := range filters.Or {
+		message.Or = append(message.Or, DbFiltersToProto(sub))
+	}
+	if filters.Not != nil && !filters.Not.IsEmpty() {
+		message.Not = DbFiltersToProto(*filters.Not)
+	}
+	return message
+}
+
+// DbResponseFromProto converts a search result of the gRPC service.
+// The score of the service (higher is better) is stored as the distance 1 - score, so results can be
+// converted to citations like the results of the other knowledge DB clients.
+//
+// Parameters:
+//   - message: the gRPC message
+//
+// Returns:
+//   - sharedtypes.DbResponse: the search result
+//   - error: an error if an id of the result is not a valid UUID
+func DbResponseFromProto(message *aalikdbgrpc.DbResponse) (sharedtypes.DbResponse, error) {
+	data, err := DbDataFromProto(message.GetData())
+	if err != nil {
+		return sharedtypes.DbResponse{}, err
+	}
+	response := sharedtypes.DbResponse{
+		Guid:              data.Guid,
+		DocumentId:        data.DocumentId,
+		DocumentName:      data.DocumentName,
+		Text:              data.Text,
+		Keywords:          data.Keywords,
+		Summary:           data.Summary,
+		Embedding:         data.Embedding,
+		Tags:              data.Tags,
+		Metadata:          data.Metadata,
+		ParentId:          data.ParentId,
+		ChildIds:          data.ChildIds,
+		PreviousSiblingId: data.PreviousSiblingId,
+		NextSiblingId:     data.NextSiblingId,
+		LastChildId:       data.LastChildId,
+		FirstChildId:      data.FirstChildId,
+		Distance:          math.Max(0, 1-message.GetScore()),
+		Level:             data.Level,
+		HasNeo4jEntry:     data.HasNeo4jEntry,
+		SourceUri:         data.SourceUri,
+		IngestedAt:        data.IngestedAt,
+		PipelineVersion:   data.PipelineVersion,
+		ContentHash:       data.ContentHash,
+	}
+	if message.GetParent() != nil {
+		parent, err := DbDataFromProto(message.GetParent())
+		if err != nil {
+			return sharedtypes.DbResponse{}, fmt.Errorf("invalid parent: %w", err)
+		}
+		response.Parent = &parent
+	}
+	if response.Children, err = dbDataListFromProto(message.GetChildren()); err != nil {
+		return sharedtypes.DbResponse{}, fmt.Errorf("invalid children: %w", err)
+	}
+	if response.LeafNodes, err = dbDataListFromProto(message.GetLeafNodes()); err != nil {
+		return sharedtypes.DbResponse{}, fmt.Errorf("invalid leaf nodes: %w", err)
+	}
+	if response.Siblings, err = dbDataListFromProto(message.GetSiblings()); err != nil {
+		return sharedtypes.DbResponse{}, fmt.Errorf("invalid siblings: %w", err)
+	}
+	return response, nil
+}
+
+// DbDataFromProto converts an entry of the gRPC service.
+//
+// Parameters:
+//   - message: the gRPC message; nil results in an empty entry
+//
+// Returns:
+//   - sharedtypes.DbData: the entry
+//   - error: an error if an id of the entry is not a valid UUID
+func DbDataFromProto(message *aalikdbgrpc.DbData) (sharedtypes.DbData, error) {
+	if message == nil {
+		return sharedtypes.DbData{}, nil
+	}
+	data := sharedtypes.DbData{
+		DocumentId:      message.GetDocumentId(),
+		DocumentName:    message.GetDocumentName(),
+		Text:            message.GetText(),
+		Keywords:        message.GetKeywords(),
+		Summary:         message.GetSummary(),
+		Embedding:       message.GetEmbedding(),
+		Tags:            message.GetTags(),
+		Level:           int(message.GetLevel()),
+		HasNeo4jEntry:   message.GetHasNeo4JEntry(),
+		SourceUri:       message.GetSourceUri(),
+		PipelineVersion: message.GetPipelineVersion(),
+		ContentHash:     message.GetContentHash(),
+	}
+	if message.GetMetadata() != nil {
+		data.Metadata = message.GetMetadata().AsMap()
+	}
+	if message.GetIngestedAt() != nil {
+		ingestedAt := message.GetIngestedAt().AsTime()
+		data.IngestedAt = &ingestedAt
+	}
+
+	var err error
+	if guid, err := parseUuid(message.GetGuid()); err != nil {
+		return sharedtypes.DbData{}, fmt.Errorf("invalid guid: %w", err)
+	} else if guid != nil {
+		data.Guid = *guid
+	}
+	if data.ParentId, err = parseUuid(message.GetParentId()); err != nil {
+		return sharedtypes.DbData{}, fmt.Errorf("invalid parent_id: %w", err)
+	}
+	if data.PreviousSiblingId, err = parseUuid(message.GetPreviousSiblingId()); err != nil {
+		return sharedtypes.DbData{}, fmt.Errorf("invalid previous_sibling_id: %w", err)
+	}
+	if data.NextSiblingId, err = parseUuid(message.GetNextSiblingId()); err != nil {
+		return sharedtypes.DbData{}, fmt.Errorf("invalid next_sibling_id: %w", err)
+	}
+	if data.LastChildId, err = parseUuid(message.GetLastChildId()); err != nil {
+		return sharedtypes.DbData{}, fmt.Errorf("invalid last_child_id: %w", err)
+	}
+	if data.FirstChildId, err = parseUuid(message.GetFirstChildId()); err != nil {
+		return sharedtypes.DbData{}, fmt.Errorf("invalid first_child_id: %w", err)
+	}
+	for _, value := range message.GetChildIds() {
+		id, err := parseUuid(value)
+		if err != nil {
+			return sharedtypes.DbData{}, fmt.Errorf("invalid child_ids: %w", err)
+		}
+		if id != nil {
+			data.ChildIds = append(data.ChildIds, *id)
+		}
+	}
+	return data, nil
+}
+
+// dbDataListFromProto converts a list of entries of the gRPC service.
+func dbDataListFromProto(messages []*aalikdbgrpc.DbData) ([]sharedtypes.DbData, error) {
+	if len(messages) == 0 {
+		return nil, nil
+	}
+	list := make([]sharedtypes.DbData, 0, len(messages))
+	for _, message := range messages {
+		data, err := DbDataFromProto(message)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, data)
+	}
+	return list, nil
+}
+
+// parseUuid parses an optional UUID; empty strings and nil UUIDs result in nil.
+func parseUuid(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if id == uuid.Nil {
+		return nil, nil
+	}
+	return &id, nil
+}
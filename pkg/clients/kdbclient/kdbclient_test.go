@@ -0,0 +1,165 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kdbclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/aalikdbgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// pagingServer serves a fixed number of results in pages of the requested size
+type pagingServer struct {
+	aalikdbgrpc.UnimplementedKnowledgeDbServer
+	total    int
+	requests []*aalikdbgrpc.SearchRequest
+}
+
+func (s *pagingServer) Search(_ context.Context, request *aalikdbgrpc.SearchRequest) (*aalikdbgrpc.SearchResponse, error) {
+	s.requests = append(s.requests, request)
+	offset := 0
+	if request.Cursor != "" {
+		offset, _ = strconv.Atoi(request.Cursor)
+	}
+	response := &aalikdbgrpc.SearchResponse{TotalEstimate: int64(s.total)}
+	for i := offset; i < s.total && i < offset+int(request.TopK); i++ {
+		response.Results = append(response.Results, &aalikdbgrpc.DbResponse{
+			Data:  &aalikdbgrpc.DbData{Guid: uuid.NewString(), DocumentId: fmt.Sprintf("doc-%d", i), ParentId: uuid.Nil.String()},
+			Score: 0.75,
+		})
+	}
+	if next := offset + int(request.TopK); next < s.total {
+		response.NextCursor = strconv.Itoa(next)
+	}
+	return response, nil
+}
+
+// startServer serves the KnowledgeDb service on an in-memory connection
+func startServer(t *testing.T, server aalikdbgrpc.KnowledgeDbServer) aalikdbgrpc.KnowledgeDbClient {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	aalikdbgrpc.RegisterKnowledgeDbServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	connection, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { connection.Close() })
+	return aalikdbgrpc.NewKnowledgeDbClient(connection)
+}
+
+func TestSearchPage(t *testing.T) {
+	server := &pagingServer{total: 5}
+	client := startServer(t, server)
+	query := SearchQuery{
+		CollectionName: "docs",
+		Embedding:      []float32{1, 0},
+		Filters:        &sharedtypes.DbFilters{DocumentIdFilter: []string{"doc-1"}, Not: &sharedtypes.DbFilters{}},
+	}
+
+	page, err := SearchPage(context.Background(), client, query, sharedtypes.PageRequest{PageSize: 2})
+	if err != nil {
+		t.Fatalf("SearchPage() error = %v", err)
+	}
+	if len(page.Items) != 2 || page.NextCursor != "2" || page.TotalEstimate != 5 {
+		t.Fatalf("unexpected page %+v", page)
+	}
+	if page.Items[0].DocumentId != "doc-0" || page.Items[0].ParentId != nil || page.Items[0].Distance != 0.25 {
+		t.Errorf("unexpected item %+v", page.Items[0])
+	}
+	request := server.requests[0]
+	if request.TopK != 2 || request.Cursor != "" || request.CollectionName != "docs" {
+		t.Errorf("unexpected request %v", request)
+	}
+	if request.Filters.GetNot() != nil || len(request.Filters.GetDocumentIdFilter()) != 1 {
+		t.Errorf("unexpected filters %v", request.Filters)
+	}
+
+	page, err = SearchPage(context.Background(), client, query, sharedtypes.PageRequest{Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("SearchPage() error = %v", err)
+	}
+	if server.requests[1].TopK != sharedtypes.DefaultPageSize || server.requests[1].Cursor != "2" {
+		t.Errorf("unexpected request %v", server.requests[1])
+	}
+	if len(page.Items) != 3 || page.HasMore() {
+		t.Errorf("unexpected last page %+v", page)
+	}
+
+	_, err = SearchPage(context.Background(), client, query, sharedtypes.PageRequest{PageSize: sharedtypes.MaxPageSize + 1})
+	if aalierrors.CodeOf(err) != aalierrors.CodeValidation {
+		t.Errorf("SearchPage() with too large page error = %v, expected validation error", err)
+	}
+	if len(server.requests) != 2 {
+		t.Errorf("invalid page request was sent to the server")
+	}
+}
+
+func TestSearchAll(t *testing.T) {
+	server := &pagingServer{total: 7}
+	client := startServer(t, server)
+
+	var pages, items int
+	err := SearchAll(context.Background(), client, SearchQuery{CollectionName: "docs"}, 3, func(page sharedtypes.PageResponse) error {
+		pages++
+		items += len(page.Items)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchAll() error = %v", err)
+	}
+	if pages != 3 || items != 7 {
+		t.Errorf("SearchAll() got %d pages with %d items, expected 3 pages with 7 items", pages, items)
+	}
+
+	stop := fmt.Errorf("stop")
+	pages = 0
+	err = SearchAll(context.Background(), client, SearchQuery{CollectionName: "docs"}, 3, func(sharedtypes.PageResponse) error {
+		pages++
+		return stop
+	})
+	if err != stop || pages != 1 {
+		t.Errorf("SearchAll() error = %v after %d pages, expected handler error after 1 page", err, pages)
+	}
+}
+
+func TestDbDataFromProtoInvalidUuid(t *testing.T) {
+	if _, err := DbDataFromProto(&aalikdbgrpc.DbData{Guid: "not-a-uuid"}); err == nil {
+		t.Error("DbDataFromProto() with invalid guid: expected error")
+	}
+}
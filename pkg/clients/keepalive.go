@@ -0,0 +1,92 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package clients
+
+import (
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// defaultKeepaliveTimeout is the time to wait for a keepalive ping acknowledgement if GRPC_KEEPALIVE_TIMEOUT_SECONDS is not set
+const defaultKeepaliveTimeout = 20 * time.Second
+
+// keepaliveSettings returns the keepalive settings of the global configuration
+//
+// Returns:
+//   - interval: the interval of keepalive pings, 0 if keepalive pings are disabled
+//   - timeout: the time to wait for a ping acknowledgement
+//   - permitWithoutStream: true if pings are also sent without active streams
+func keepaliveSettings() (interval time.Duration, timeout time.Duration, permitWithoutStream bool) {
+	if config.Current() == nil || config.Current().GRPC_KEEPALIVE_TIME_SECONDS <= 0 {
+		return 0, 0, false
+	}
+	interval = time.Duration(config.Current().GRPC_KEEPALIVE_TIME_SECONDS) * time.Second
+	timeout = defaultKeepaliveTimeout
+	if config.Current().GRPC_KEEPALIVE_TIMEOUT_SECONDS > 0 {
+		timeout = time.Duration(config.Current().GRPC_KEEPALIVE_TIMEOUT_SECONDS) * time.Second
+	}
+	return interval, timeout, config.Current().GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM
+}
+
+// GetGrpcKeepaliveDialOptions creates the gRPC dial options sending keepalive pings on idle connections,
+// so broken connections of long-lived streams are detected and intermediate proxies keep them open.
+//
+// Returns:
+//   - options: the dial options; empty if GRPC_KEEPALIVE_TIME_SECONDS is not set
+func GetGrpcKeepaliveDialOptions() (options []grpc.DialOption) {
+	interval, timeout, permitWithoutStream := keepaliveSettings()
+	if interval == 0 {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithKeepaliveParams(keepalive.ClientParameters{
+		Time:                interval,
+		Timeout:             timeout,
+		PermitWithoutStream: permitWithoutStream,
+	})}
+}
+
+// GetGrpcKeepaliveServerOptions creates the gRPC server options matching GetGrpcKeepaliveDialOptions
+// The server pings idle connections at the same interval and accepts client pings at that interval,
+// instead of closing the connection for too frequent pings.
+//
+// Returns:
+//   - options: the server options; empty if GRPC_KEEPALIVE_TIME_SECONDS is not set
+func GetGrpcKeepaliveServerOptions() (options []grpc.ServerOption) {
+	interval, timeout, permitWithoutStream := keepaliveSettings()
+	if interval == 0 {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    interval,
+			Timeout: timeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             interval,
+			PermitWithoutStream: permitWithoutStream,
+		}),
+	}
+}
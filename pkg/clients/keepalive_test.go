@@ -0,0 +1,69 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+func TestKeepaliveSettings(t *testing.T) {
+	tests := []struct {
+		name                string
+		cfg                 *config.Config
+		interval            time.Duration
+		timeout             time.Duration
+		permitWithoutStream bool
+	}{
+		{"no config", nil, 0, 0, false},
+		{"disabled", &config.Config{GRPC_KEEPALIVE_TIMEOUT_SECONDS: 5}, 0, 0, false},
+		{"default timeout", &config.Config{GRPC_KEEPALIVE_TIME_SECONDS: 30}, 30 * time.Second, defaultKeepaliveTimeout, false},
+		{"all set", &config.Config{GRPC_KEEPALIVE_TIME_SECONDS: 10, GRPC_KEEPALIVE_TIMEOUT_SECONDS: 3, GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM: true}, 10 * time.Second, 3 * time.Second, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withConfig(t, tt.cfg)
+			interval, timeout, permitWithoutStream := keepaliveSettings()
+			if interval != tt.interval || timeout != tt.timeout || permitWithoutStream != tt.permitWithoutStream {
+				t.Errorf("keepaliveSettings() = %s, %s, %v, want %s, %s, %v", interval, timeout, permitWithoutStream, tt.interval, tt.timeout, tt.permitWithoutStream)
+			}
+		})
+	}
+}
+
+func TestGetGrpcKeepaliveOptions(t *testing.T) {
+	withConfig(t, &config.Config{})
+	if len(GetGrpcKeepaliveDialOptions()) != 0 || len(GetGrpcKeepaliveServerOptions()) != 0 {
+		t.Error("keepalive options returned without GRPC_KEEPALIVE_TIME_SECONDS")
+	}
+
+	withConfig(t, &config.Config{GRPC_KEEPALIVE_TIME_SECONDS: 30})
+	if got := len(GetGrpcKeepaliveDialOptions()); got != 1 {
+		t.Errorf("len(GetGrpcKeepaliveDialOptions()) = %d, want 1", got)
+	}
+	if got := len(GetGrpcKeepaliveServerOptions()); got != 2 {
+		t.Errorf("len(GetGrpcKeepaliveServerOptions()) = %d, want 2", got)
+	}
+}
@@ -0,0 +1,445 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package kvdbclient provides a client for the aali-kvdb key-value database.
+//
+// The client talks to the REST API of aali-kvdb:
+//   - GET    /v1/keys/{key}        returns the Entry of the key
+//   - PUT    /v1/keys/{key}        writes a setRequest and returns the new version
+//   - DELETE /v1/keys/{key}        deletes the key; "expectedVersion" query parameter for compare-and-delete
+//   - GET    /v1/keys?prefix={p}   lists the keys with the given prefix
+//
+// A missing or expired key is answered with 404, a failed compare-and-swap with 409.
+package kvdbclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/clients"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/netutil"
+	"github.com/ansys/aali-sharedtypes/pkg/typeconverters"
+)
+
+// Default retry settings of a new Client.
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryBackoff = 200 * time.Millisecond
+)
+
+// ErrNotFound is returned if a key does not exist or has expired.
+var ErrNotFound error = aalierrors.New(nil, aalierrors.CodeNotFound, "kvdb: key not found")
+
+// ErrVersionConflict is returned by compare-and-swap operations if the stored version differs from the expected version.
+var ErrVersionConflict error = aalierrors.New(nil, aalierrors.CodeValidation, "kvdb: version conflict")
+
+// Entry represents a key-value pair stored in the KVDB.
+type Entry struct {
+	Key       string     `json:"key"`
+	Value     string     `json:"value"`
+	Version   int64      `json:"version"`             // incremented on every write; used for compare-and-swap
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"` // nil if the entry does not expire
+}
+
+// SetOptions represents the options of a write.
+type SetOptions struct {
+	TTL             time.Duration // the entry expires after the TTL; no expiry if 0
+	ExpectedVersion *int64        // compare-and-swap: only write if the stored version matches; 0 means the key must not exist
+}
+
+// setRequest is the body of a write.
+type setRequest struct {
+	Value           string `json:"value"`
+	TTLMilliseconds int64  `json:"ttlMilliseconds,omitempty"`
+	ExpectedVersion *int64 `json:"expectedVersion,omitempty"`
+}
+
+// setResponse is the response of a write.
+type setResponse struct {
+	Version int64 `json:"version"`
+}
+
+// listResponse is the response of a key listing.
+type listResponse struct {
+	Keys []string `json:"keys"`
+}
+
+// Client is a client for the aali-kvdb key-value database.
+// Requests failing with a network error, 429 or 5xx status are retried with exponential backoff.
+type Client struct {
+	endpoint   string
+	apiKey     string
+	httpClient *http.Client
+	logCtx     *logging.ContextMap
+
+	MaxRetries   int           // number of retries after the first attempt
+	RetryBackoff time.Duration // wait time before the first retry; doubled for every further retry
+}
+
+// NewClient creates a client for the KVDB at the given endpoint.
+//
+// Parameters:
+//   - endpoint: the URL of the KVDB, e.g. "http://localhost:50051"; "http" is assumed if the scheme is omitted
+//   - apiKey: the API key of the KVDB
+//
+// Returns:
+//   - *Client: the client
+//   - error: an error if the HTTP client cannot be created
+func NewClient(endpoint string, apiKey string) (*Client, error) {
+	if endpoint == "" {
+		return nil, aalierrors.New(nil, aalierrors.CodeValidation, "KVDB endpoint is empty")
+	}
+	parsed, err := netutil.ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, aalierrors.Wrap(nil, aalierrors.CodeValidation, err, "invalid KVDB endpoint")
+	}
+	httpClient, err := clients.GetHttpClient()
+	if err != nil {
+		return nil, aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "error getting HTTP client")
+	}
+	return &Client{
+		endpoint:     parsed.URL(),
+		apiKey:       apiKey,
+		httpClient:   httpClient,
+		logCtx:       &logging.ContextMap{},
+		MaxRetries:   DefaultMaxRetries,
+		RetryBackoff: DefaultRetryBackoff,
+	}, nil
+}
+
+// NewClientFromConfig creates a client for the KVDB configured in KVDB_ENDPOINT and KVDB_API_KEY.
+//
+// Returns:
+//   - *Client: the client
+//   - error: an error if the HTTP client cannot be created
+func NewClientFromConfig() (*Client, error) {
+	return NewClient(config.Current().KVDB_ENDPOINT, config.Current().KVDB_API_KEY)
+}
+
+// WithLogContext returns a copy of the client that logs with the given context
+// and forwards it to the KVDB in the "aali-logging-context" header.
+//
+// Parameters:
+//   - logCtx: the logging context
+//
+// Returns:
+//   - *Client: the copy of the client
+func (c *Client) WithLogContext(logCtx *logging.ContextMap) *Client {
+	copied := *c
+	copied.logCtx = logCtx
+	return &copied
+}
+
+// Get reads an entry.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - key: the key
+//
+// Returns:
+//   - Entry: the entry
+//   - error: ErrNotFound if the key does not exist, or another error if the request fails
+func (c *Client) Get(ctx context.Context, key string) (Entry, error) {
+	var entry Entry
+	if err := c.do(ctx, http.MethodGet, keyPath(key), nil, &entry, true); err != nil {
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+// Set writes a value.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - key: the key
+//   - value: the value
+//   - opts: the TTL and the expected version; may be nil
+//
+// Returns:
+//   - int64: the new version of the entry
+//   - error: ErrVersionConflict if an expected version is given and does not match, or another error if the request fails
+func (c *Client) Set(ctx context.Context, key string, value string, opts *SetOptions) (int64, error) {
+	body := setRequest{Value: value}
+	if opts != nil {
+		body.TTLMilliseconds = opts.TTL.Milliseconds()
+		body.ExpectedVersion = opts.ExpectedVersion
+	}
+	// a conditional write is not retried: if the first attempt was applied but its response was lost,
+	// the retry would fail with a version conflict or overwrite a newer write
+	var response setResponse
+	if err := c.do(ctx, http.MethodPut, keyPath(key), body, &response, body.ExpectedVersion == nil); err != nil {
+		return 0, err
+	}
+	return response.Version, nil
+}
+
+// CompareAndSwap writes a value only if the stored version matches the expected version.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - key: the key
+//   - expectedVersion: the expected version; 0 means the key must not exist
+//   - value: the value
+//   - ttl: the TTL of the entry; no expiry if 0
+//
+// Returns:
+//   - int64: the new version of the entry
+//   - error: ErrVersionConflict if the version does not match, or another error if the request fails
+func (c *Client) CompareAndSwap(ctx context.Context, key string, expectedVersion int64, value string, ttl time.Duration) (int64, error) {
+	return c.Set(ctx, key, value, &SetOptions{TTL: ttl, ExpectedVersion: &expectedVersion})
+}
+
+// Delete deletes a key.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - key: the key
+//
+// Returns:
+//   - error: ErrNotFound if the key does not exist, or another error if the request fails
+func (c *Client) Delete(ctx context.Context, key string) error {
+	return c.do(ctx, http.MethodDelete, keyPath(key), nil, nil, true)
+}
+
+// CompareAndDelete deletes a key only if the stored version matches the expected version.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - key: the key
+//   - expectedVersion: the expected version
+//
+// Returns:
+//   - error: ErrVersionConflict if the version does not match, ErrNotFound if the key does not exist,
+//     or another error if the request fails
+func (c *Client) CompareAndDelete(ctx context.Context, key string, expectedVersion int64) error {
+	path := keyPath(key) + "?expectedVersion=" + strconv.FormatInt(expectedVersion, 10)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, false)
+}
+
+// List lists the keys with the given prefix.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - prefix: the prefix; all keys if empty
+//
+// Returns:
+//   - []string: the keys
+//   - error: an error if the request fails
+func (c *Client) List(ctx context.Context, prefix string) ([]string, error) {
+	var response listResponse
+	if err := c.do(ctx, http.MethodGet, "/v1/keys?prefix="+url.QueryEscape(prefix), nil, &response, true); err != nil {
+		return nil, err
+	}
+	if response.Keys == nil {
+		response.Keys = []string{}
+	}
+	return response.Keys, nil
+}
+
+// GetJSON reads an entry and decodes its JSON value.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - client: the KVDB client
+//   - key: the key
+//
+// Returns:
+//   - T: the decoded value
+//   - int64: the version of the entry
+//   - error: ErrNotFound if the key does not exist, or another error if the request or the decoding fails
+func GetJSON[T any](ctx context.Context, client *Client, key string) (T, int64, error) {
+	var value T
+	entry, err := client.Get(ctx, key)
+	if err != nil {
+		return value, 0, err
+	}
+	if err := json.Unmarshal([]byte(entry.Value), &value); err != nil {
+		return value, 0, aalierrors.Wrapf(nil, aalierrors.CodeInternal, err, "error decoding value of key %q", key)
+	}
+	return value, entry.Version, nil
+}
+
+// SetJSON encodes a value as JSON and writes it.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - client: the KVDB client
+//   - key: the key
+//   - value: the value
+//   - opts: the TTL and the expected version; may be nil
+//
+// Returns:
+//   - int64: the new version of the entry
+//   - error: ErrVersionConflict if an expected version is given and does not match, or another error if the request fails
+func SetJSON[T any](ctx context.Context, client *Client, key string, value T, opts *SetOptions) (int64, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return 0, aalierrors.Wrapf(nil, aalierrors.CodeValidation, err, "error encoding value of key %q", key)
+	}
+	return client.Set(ctx, key, string(data), opts)
+}
+
+// GetTyped reads an entry and converts its value to the given Go type, see typeconverters.ConvertStringToGivenType.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - client: the KVDB client
+//   - key: the key
+//   - goType: the Go type, e.g. "[]string" or "DbResponse"
+//
+// Returns:
+//   - interface{}: the converted value
+//   - int64: the version of the entry
+//   - error: ErrNotFound if the key does not exist, or another error if the request or the conversion fails
+func GetTyped(ctx context.Context, client *Client, key string, goType string) (interface{}, int64, error) {
+	entry, err := client.Get(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	value, exists, err := typeconverters.ConvertStringToGivenType(entry.Value, goType)
+	if !exists {
+		return nil, 0, aalierrors.Newf(nil, aalierrors.CodeValidation, "type '%s' does not exist in typeconverters.ConvertStringToGivenType", goType)
+	}
+	if err != nil {
+		return nil, 0, aalierrors.Wrapf(nil, aalierrors.CodeInternal, err, "error converting value of key %q", key)
+	}
+	return value, entry.Version, nil
+}
+
+// SetTyped converts a value of the given Go type to string and writes it, see typeconverters.ConvertGivenTypeToString.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - client: the KVDB client
+//   - key: the key
+//   - value: the value
+//   - goType: the Go type of the value
+//   - opts: the TTL and the expected version; may be nil
+//
+// Returns:
+//   - int64: the new version of the entry
+//   - error: an error if the conversion or the request fails
+func SetTyped(ctx context.Context, client *Client, key string, value interface{}, goType string, opts *SetOptions) (int64, error) {
+	stringValue, exists, err := typeconverters.ConvertGivenTypeToString(value, goType)
+	if !exists {
+		return 0, aalierrors.Newf(nil, aalierrors.CodeValidation, "type '%s' does not exist in typeconverters.ConvertGivenTypeToString", goType)
+	}
+	if err != nil {
+		return 0, aalierrors.Wrapf(nil, aalierrors.CodeValidation, err, "error converting value of key %q", key)
+	}
+	return client.Set(ctx, key, stringValue, opts)
+}
+
+// do sends a request to the KVDB and decodes the response into out.
+// If retry is set, the request is retried on network errors, 429 and 5xx responses; version-conditioned
+// writes must not be retried, since their first attempt may have been applied.
+func (c *Client) do(ctx context.Context, method string, path string, body interface{}, out interface{}, retry bool) error {
+	var requestBody []byte
+	if body != nil {
+		var err error
+		requestBody, err = json.Marshal(body)
+		if err != nil {
+			return aalierrors.Wrap(c.logCtx, aalierrors.CodeValidation, err, "error serializing KVDB request")
+		}
+	}
+
+	backoff := c.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		statusCode, responseBody, err := c.send(ctx, method, path, requestBody)
+		retryable := retry && (err != nil || statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError)
+		if !retryable || attempt >= c.MaxRetries || ctx.Err() != nil {
+			if err != nil {
+				return aalierrors.Wrapf(c.logCtx, aalierrors.CodeTransient, err, "error sending KVDB request %s %s", method, path)
+			}
+			return decodeResponse(method, path, statusCode, responseBody, out)
+		}
+
+		logging.Log.Warnf(c.logCtx, "KVDB request %s %s failed (status %d, error %v), retrying in %v", method, path, statusCode, err, backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// send sends a single request to the KVDB.
+func (c *Client) send(ctx context.Context, method string, path string, body []byte) (statusCode int, responseBody []byte, err error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("api-key", c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	if err := logging.SetHTTPHeaderFromCtx(c.logCtx, req); err != nil {
+		return 0, nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	responseBody, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, aalierrors.Wrap(nil, aalierrors.CodeTransient, err, "error reading response body")
+	}
+	return resp.StatusCode, responseBody, nil
+}
+
+// decodeResponse maps the status code of a KVDB response to an error and decodes the body into out.
+func decodeResponse(method string, path string, statusCode int, body []byte, out interface{}) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusConflict || statusCode == http.StatusPreconditionFailed:
+		return ErrVersionConflict
+	case statusCode < 200 || statusCode > 299:
+		return aalierrors.Newf(nil, aalierrors.FromHTTPStatus(statusCode), "KVDB request %s %s failed with status code %d: %s", method, path, statusCode, string(body))
+	}
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return aalierrors.Wrap(nil, aalierrors.CodeInternal, err, "error unmarshalling KVDB response")
+	}
+	return nil
+}
+
+// keyPath returns the API path of a key.
+func keyPath(key string) string {
+	return "/v1/keys/" + url.PathEscape(key)
+}
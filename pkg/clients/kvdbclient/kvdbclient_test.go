@@ -0,0 +1,296 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kvdbclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// fakeKvdb is an in-memory implementation of the KVDB REST API.
+type fakeKvdb struct {
+	mu         sync.Mutex
+	entries    map[string]Entry
+	failures   int // number of requests to answer with 503 before serving
+	requests   int
+	versionGap int64 // added to the version increment of every write
+	headers    http.Header
+}
+
+func (f *fakeKvdb) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.headers = r.Header.Clone()
+	f.requests++
+	if f.failures > 0 {
+		f.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.URL.Path == "/v1/keys" {
+		keys := []string{}
+		for key := range f.entries {
+			if strings.HasPrefix(key, r.URL.Query().Get("prefix")) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		_ = json.NewEncoder(w).Encode(listResponse{Keys: keys})
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/v1/keys/")
+	current, exists := f.entries[key]
+	if exists && current.ExpiresAt != nil && time.Now().After(*current.ExpiresAt) {
+		delete(f.entries, key)
+		exists = false
+	}
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(current)
+	case http.MethodPut:
+		var body setRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.ExpectedVersion != nil && *body.ExpectedVersion != current.Version {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		entry := Entry{Key: key, Value: body.Value, Version: current.Version + 1 + f.versionGap}
+		if body.TTLMilliseconds > 0 {
+			expiresAt := time.Now().Add(time.Duration(body.TTLMilliseconds) * time.Millisecond)
+			entry.ExpiresAt = &expiresAt
+		}
+		f.entries[key] = entry
+		_ = json.NewEncoder(w).Encode(setResponse{Version: entry.Version})
+	case http.MethodDelete:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if expected := r.URL.Query().Get("expectedVersion"); expected != "" && expected != strconv.FormatInt(current.Version, 10) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		delete(f.entries, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestClient(t *testing.T) (*Client, *fakeKvdb) {
+	t.Helper()
+	logging.InitForTest()
+
+	fake := &fakeKvdb{entries: map[string]Entry{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "test-key")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	client.RetryBackoff = time.Millisecond
+	return client, fake
+}
+
+func TestClientGetSetDelete(t *testing.T) {
+	client, fake := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := client.Get(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() error = %v, want ErrNotFound", err)
+	}
+
+	version, err := client.Set(ctx, "session/1", "hello", nil)
+	if err != nil || version != 1 {
+		t.Fatalf("Set() = %d, %v, want 1, nil", version, err)
+	}
+	if fake.headers.Get("api-key") != "test-key" {
+		t.Errorf("api-key header = %q, want %q", fake.headers.Get("api-key"), "test-key")
+	}
+
+	entry, err := client.Get(ctx, "session/1")
+	if err != nil || entry.Value != "hello" || entry.Version != 1 {
+		t.Fatalf("Get() = %+v, %v", entry, err)
+	}
+
+	if _, err := client.Set(ctx, "other", "x", nil); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	keys, err := client.List(ctx, "session/")
+	if err != nil || len(keys) != 1 || keys[0] != "session/1" {
+		t.Fatalf("List() = %v, %v", keys, err)
+	}
+
+	if err := client.Delete(ctx, "session/1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := client.Delete(ctx, "session/1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Delete() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClientCompareAndSwap(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	version, err := client.CompareAndSwap(ctx, "lock", 0, "a", 0)
+	if err != nil || version != 1 {
+		t.Fatalf("CompareAndSwap() create = %d, %v", version, err)
+	}
+	if _, err := client.CompareAndSwap(ctx, "lock", 0, "b", 0); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("CompareAndSwap() on existing key error = %v, want ErrVersionConflict", err)
+	}
+	version, err = client.CompareAndSwap(ctx, "lock", 1, "b", 0)
+	if err != nil || version != 2 {
+		t.Fatalf("CompareAndSwap() update = %d, %v", version, err)
+	}
+	if err := client.CompareAndDelete(ctx, "lock", 1); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("CompareAndDelete() error = %v, want ErrVersionConflict", err)
+	}
+	if err := client.CompareAndDelete(ctx, "lock", 2); err != nil {
+		t.Fatalf("CompareAndDelete() error = %v", err)
+	}
+}
+
+func TestClientTTL(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := client.Set(ctx, "ephemeral", "x", &SetOptions{TTL: 20 * time.Millisecond}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	entry, err := client.Get(ctx, "ephemeral")
+	if err != nil || entry.ExpiresAt == nil {
+		t.Fatalf("Get() = %+v, %v, want entry with expiry", entry, err)
+	}
+	time.Sleep(40 * time.Millisecond)
+	if _, err := client.Get(ctx, "ephemeral"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() after expiry error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		failures   int
+		maxRetries int
+		wantErr    bool
+	}{
+		{"succeeds after retries", 2, 3, false},
+		{"gives up after max retries", 3, 2, true},
+		{"no retries", 1, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, fake := newTestClient(t)
+			client.MaxRetries = tt.maxRetries
+			fake.failures = tt.failures
+
+			_, err := client.Set(context.Background(), "key", "value", nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientConditionalWritesNotRetried(t *testing.T) {
+	client, fake := newTestClient(t)
+	ctx := context.Background()
+
+	fake.failures = 1
+	if _, err := client.CompareAndSwap(ctx, "lock", 0, "owner", 0); err == nil {
+		t.Error("CompareAndSwap() expected an error for a failed attempt")
+	}
+	if fake.requests != 1 {
+		t.Errorf("CompareAndSwap() sent %d requests, want 1", fake.requests)
+	}
+
+	if _, err := client.Set(ctx, "lock", "owner", nil); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	fake.failures, fake.requests = 1, 0
+	if err := client.CompareAndDelete(ctx, "lock", 1); err == nil {
+		t.Error("CompareAndDelete() expected an error for a failed attempt")
+	}
+	if fake.requests != 1 {
+		t.Errorf("CompareAndDelete() sent %d requests, want 1", fake.requests)
+	}
+}
+
+func TestErrNotFoundCode(t *testing.T) {
+	if code := aalierrors.CodeOf(ErrNotFound); code != aalierrors.CodeNotFound {
+		t.Errorf("CodeOf(ErrNotFound) = %q, want %q", code, aalierrors.CodeNotFound)
+	}
+}
+
+func TestJSONAndTypedHelpers(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	type sessionState struct {
+		User  string   `json:"user"`
+		Steps []string `json:"steps"`
+	}
+	want := sessionState{User: "alice", Steps: []string{"a", "b"}}
+	if _, err := SetJSON(ctx, client, "state", want, nil); err != nil {
+		t.Fatalf("SetJSON() error = %v", err)
+	}
+	got, version, err := GetJSON[sessionState](ctx, client, "state")
+	if err != nil || version != 1 || got.User != want.User || len(got.Steps) != 2 {
+		t.Fatalf("GetJSON() = %+v, %d, %v", got, version, err)
+	}
+
+	history := []sharedtypes.HistoricMessage{{Role: "user", Content: "hi"}}
+	if _, err := SetTyped(ctx, client, "history", history, "[]HistoricMessage", nil); err != nil {
+		t.Fatalf("SetTyped() error = %v", err)
+	}
+	value, _, err := GetTyped(ctx, client, "history", "[]HistoricMessage")
+	if err != nil {
+		t.Fatalf("GetTyped() error = %v", err)
+	}
+	gotHistory, ok := value.([]sharedtypes.HistoricMessage)
+	if !ok || len(gotHistory) != 1 || gotHistory[0].Content != "hi" {
+		t.Fatalf("GetTyped() = %#v", value)
+	}
+
+	if _, _, err := GetTyped(ctx, client, "history", "NoSuchType"); err == nil {
+		t.Error("GetTyped() with unknown type: expected error")
+	}
+}
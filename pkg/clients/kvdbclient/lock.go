@@ -0,0 +1,382 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kvdbclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/google/uuid"
+)
+
+// ErrLockHeld is returned by Acquire if the lock is held by another owner.
+var ErrLockHeld error = aalierrors.New(nil, aalierrors.CodeTransient, "kvdb: lock is held by another owner")
+
+// ErrLockLost is returned by Renew and Release if the lock expired and was taken over, or was released.
+var ErrLockLost error = aalierrors.New(nil, aalierrors.CodeInternal, "kvdb: lock was lost")
+
+// minRenewInterval is the lower bound of the renewal interval of a leader election.
+const minRenewInterval = 10 * time.Millisecond
+
+// lockRecord is the value stored under the key of a lock.
+//
+// A lock key is never deleted: releasing writes a record without owner. This keeps the version of the
+// key increasing, which is what the fencing tokens are derived from.
+type lockRecord struct {
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// isFree checks if the lock can be acquired.
+func (r *lockRecord) isFree(now time.Time) bool {
+	return r.Owner == "" || !now.Before(r.ExpiresAt)
+}
+
+// Lock is a lease on a KVDB key. The lease expires after its TTL unless it is renewed.
+//
+// Every acquisition gets a fencing token that is strictly greater than the tokens of all previous
+// acquisitions of the same key. Resources protected by the lock should reject writes carrying a
+// token lower than the highest token they have seen, so that a holder whose lease expired cannot
+// overwrite the work of the new holder.
+type Lock struct {
+	client       *Client
+	key          string
+	owner        string
+	ttl          time.Duration
+	fencingToken int64
+
+	mu        sync.Mutex
+	version   int64
+	expiresAt time.Time
+	released  bool
+}
+
+// Acquire acquires the lock on a key.
+// Expiry is evaluated with the local clock, so the clocks of the competing replicas should be synchronized.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - key: the key of the lock
+//   - ttl: the time after which the lock expires unless renewed
+//
+// Returns:
+//   - *Lock: the acquired lock
+//   - error: ErrLockHeld if the lock is held by another owner, or another error if the request fails
+func (c *Client) Acquire(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("lock TTL must be positive, got %v", ttl)
+	}
+
+	var currentVersion int64
+	entry, err := c.Get(ctx, key)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		currentVersion = 0
+	case err != nil:
+		return nil, fmt.Errorf("error reading lock %q: %w", key, err)
+	default:
+		var current lockRecord
+		if err := json.Unmarshal([]byte(entry.Value), &current); err != nil {
+			return nil, fmt.Errorf("error decoding lock %q: %w", key, err)
+		}
+		if !current.isFree(time.Now()) {
+			return nil, ErrLockHeld
+		}
+		currentVersion = entry.Version
+	}
+
+	lock := &Lock{
+		client: c,
+		key:    key,
+		owner:  uuid.NewString(),
+		ttl:    ttl,
+	}
+	record := lockRecord{Owner: lock.owner, ExpiresAt: time.Now().Add(ttl)}
+	version, err := SetJSON(ctx, c, key, record, &SetOptions{ExpectedVersion: &currentVersion})
+	if errors.Is(err, ErrVersionConflict) {
+		return nil, ErrLockHeld
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error acquiring lock %q: %w", key, err)
+	}
+	// the version assigned by the KVDB to the acquiring write is greater than every version written before
+	lock.fencingToken = version
+	lock.version = version
+	lock.expiresAt = record.ExpiresAt
+	logging.Log.Debugf(c.logCtx, "acquired lock %q with fencing token %d", key, lock.fencingToken)
+	return lock, nil
+}
+
+// Key returns the key of the lock.
+//
+// Returns:
+//   - string: the key
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Owner returns the unique owner ID of the lock.
+//
+// Returns:
+//   - string: the owner ID
+func (l *Lock) Owner() string {
+	return l.owner
+}
+
+// FencingToken returns the fencing token of the lock.
+//
+// Returns:
+//   - int64: the fencing token
+func (l *Lock) FencingToken() int64 {
+	return l.fencingToken
+}
+
+// ExpiresAt returns the time at which the lease expires unless renewed.
+//
+// Returns:
+//   - time.Time: the expiry time
+func (l *Lock) ExpiresAt() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.expiresAt
+}
+
+// Renew extends the lease of the lock by its TTL.
+//
+// Parameters:
+//   - ctx: the context of the request
+//
+// Returns:
+//   - error: ErrLockLost if the lock is no longer held, or another error if the request fails
+func (l *Lock) Renew(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.released {
+		return ErrLockLost
+	}
+
+	record := lockRecord{Owner: l.owner, ExpiresAt: time.Now().Add(l.ttl)}
+	version, err := SetJSON(ctx, l.client, l.key, record, &SetOptions{ExpectedVersion: &l.version})
+	if errors.Is(err, ErrVersionConflict) || errors.Is(err, ErrNotFound) {
+		return ErrLockLost
+	}
+	if err != nil {
+		return fmt.Errorf("error renewing lock %q: %w", l.key, err)
+	}
+	l.version = version
+	l.expiresAt = record.ExpiresAt
+	return nil
+}
+
+// Release releases the lock.
+//
+// Parameters:
+//   - ctx: the context of the request
+//
+// Returns:
+//   - error: ErrLockLost if the lock is no longer held, or another error if the request fails
+func (l *Lock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.released {
+		return ErrLockLost
+	}
+
+	record := lockRecord{}
+	_, err := SetJSON(ctx, l.client, l.key, record, &SetOptions{ExpectedVersion: &l.version})
+	if errors.Is(err, ErrVersionConflict) || errors.Is(err, ErrNotFound) {
+		return ErrLockLost
+	}
+	if err != nil {
+		return fmt.Errorf("error releasing lock %q: %w", l.key, err)
+	}
+	l.released = true
+	logging.Log.Debugf(l.client.logCtx, "released lock %q with fencing token %d", l.key, l.fencingToken)
+	return nil
+}
+
+// LeaderElection elects a single leader among replicas competing for the same KVDB key.
+type LeaderElection struct {
+	client *Client
+	key    string
+	ttl    time.Duration
+
+	RenewInterval time.Duration                                 // interval of lease renewals and acquisition attempts; defaults to a third of the TTL, at least 10ms
+	OnElected     func(ctx context.Context, fencingToken int64) // called in its own goroutine when this replica becomes leader; ctx is cancelled on demotion
+	OnDemoted     func()                                        // called when this replica stops being leader
+
+	mu     sync.RWMutex
+	lock   *Lock
+	cancel context.CancelFunc
+}
+
+// NewLeaderElection creates a leader election on a key.
+//
+// Parameters:
+//   - client: the KVDB client
+//   - key: the key of the leader lock
+//   - ttl: the lease TTL; a new leader is elected at most this long after the leader disappears
+//
+// Returns:
+//   - *LeaderElection: the leader election
+func NewLeaderElection(client *Client, key string, ttl time.Duration) *LeaderElection {
+	return &LeaderElection{
+		client:        client,
+		key:           key,
+		ttl:           ttl,
+		RenewInterval: ttl / 3,
+	}
+}
+
+// Run takes part in the election until the context is cancelled. The leadership is released on return.
+//
+// Parameters:
+//   - ctx: the context; cancel it to leave the election
+//
+// Returns:
+//   - error: an error if the TTL is not positive, or the error of the context
+func (e *LeaderElection) Run(ctx context.Context) error {
+	if e.ttl <= 0 {
+		return fmt.Errorf("leader election TTL must be positive, got %v", e.ttl)
+	}
+	interval := e.RenewInterval
+	if interval <= 0 {
+		interval = e.ttl / 3
+	}
+	ticker := time.NewTicker(max(interval, minRenewInterval))
+	defer ticker.Stop()
+
+	for {
+		e.step(ctx)
+		select {
+		case <-ctx.Done():
+			e.resign()
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// IsLeader checks if this replica is currently the leader.
+//
+// Returns:
+//   - bool: true if this replica is the leader
+func (e *LeaderElection) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.lock != nil
+}
+
+// FencingToken returns the fencing token of the current leadership.
+//
+// Returns:
+//   - int64: the fencing token
+//   - bool: false if this replica is not the leader
+func (e *LeaderElection) FencingToken() (int64, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.lock == nil {
+		return 0, false
+	}
+	return e.lock.FencingToken(), true
+}
+
+// step renews the lease if leader, or tries to acquire it otherwise.
+func (e *LeaderElection) step(ctx context.Context) {
+	e.mu.RLock()
+	lock := e.lock
+	e.mu.RUnlock()
+
+	if lock != nil {
+		err := lock.Renew(ctx)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		// keep the leadership on transient errors while the lease is still valid
+		if !errors.Is(err, ErrLockLost) && time.Now().Before(lock.ExpiresAt()) {
+			logging.Log.Warnf(e.client.logCtx, "error renewing leadership of %q: %v", e.key, err)
+			return
+		}
+		logging.Log.Warnf(e.client.logCtx, "lost leadership of %q: %v", e.key, err)
+		e.demote()
+		return
+	}
+
+	lock, err := e.client.Acquire(ctx, e.key, e.ttl)
+	if err != nil {
+		if !errors.Is(err, ErrLockHeld) && ctx.Err() == nil {
+			logging.Log.Warnf(e.client.logCtx, "error acquiring leadership of %q: %v", e.key, err)
+		}
+		return
+	}
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	e.mu.Lock()
+	e.lock = lock
+	e.cancel = cancel
+	e.mu.Unlock()
+	logging.Log.Infof(e.client.logCtx, "elected leader of %q with fencing token %d", e.key, lock.FencingToken())
+	// the callback runs in its own goroutine so a long-running leader task does not block the lease renewals
+	if e.OnElected != nil {
+		go e.OnElected(leaderCtx, lock.FencingToken())
+	}
+}
+
+// demote drops the leadership without releasing the lock.
+func (e *LeaderElection) demote() *Lock {
+	e.mu.Lock()
+	lock := e.lock
+	cancel := e.cancel
+	e.lock = nil
+	e.cancel = nil
+	e.mu.Unlock()
+
+	if lock == nil {
+		return nil
+	}
+	cancel()
+	if e.OnDemoted != nil {
+		e.OnDemoted()
+	}
+	return lock
+}
+
+// resign drops the leadership and releases the lock so another replica can take over immediately.
+func (e *LeaderElection) resign() {
+	lock := e.demote()
+	if lock == nil {
+		return
+	}
+	// the election context is already cancelled
+	releaseCtx, cancel := context.WithTimeout(context.Background(), e.ttl)
+	defer cancel()
+	if err := lock.Release(releaseCtx); err != nil {
+		logging.Log.Warnf(e.client.logCtx, "error releasing leadership of %q: %v", e.key, err)
+	}
+}
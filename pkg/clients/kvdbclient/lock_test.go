@@ -0,0 +1,192 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kvdbclient
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLockAcquireRenewRelease(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	lock, err := client.Acquire(ctx, "catalog-refresh", time.Minute)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if _, err := client.Acquire(ctx, "catalog-refresh", time.Minute); !errors.Is(err, ErrLockHeld) {
+		t.Fatalf("second Acquire() error = %v, want ErrLockHeld", err)
+	}
+	if err := lock.Renew(ctx); err != nil {
+		t.Fatalf("Renew() error = %v", err)
+	}
+	if err := lock.Release(ctx); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if err := lock.Renew(ctx); !errors.Is(err, ErrLockLost) {
+		t.Fatalf("Renew() after release error = %v, want ErrLockLost", err)
+	}
+
+	next, err := client.Acquire(ctx, "catalog-refresh", time.Minute)
+	if err != nil {
+		t.Fatalf("Acquire() after release error = %v", err)
+	}
+	if next.FencingToken() <= lock.FencingToken() {
+		t.Errorf("fencing token %d not greater than previous token %d", next.FencingToken(), lock.FencingToken())
+	}
+}
+
+func TestLockExpiryAndTakeover(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	stale, err := client.Acquire(ctx, "lock", 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	time.Sleep(40 * time.Millisecond)
+
+	current, err := client.Acquire(ctx, "lock", time.Minute)
+	if err != nil {
+		t.Fatalf("Acquire() of expired lock error = %v", err)
+	}
+	if current.FencingToken() <= stale.FencingToken() {
+		t.Errorf("fencing token %d not greater than stale token %d", current.FencingToken(), stale.FencingToken())
+	}
+	if err := stale.Renew(ctx); !errors.Is(err, ErrLockLost) {
+		t.Errorf("Renew() of stale lock error = %v, want ErrLockLost", err)
+	}
+	if err := stale.Release(ctx); !errors.Is(err, ErrLockLost) {
+		t.Errorf("Release() of stale lock error = %v, want ErrLockLost", err)
+	}
+	if err := current.Renew(ctx); err != nil {
+		t.Errorf("Renew() of current lock error = %v", err)
+	}
+}
+
+func TestLeaderElection(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	var elected, demoted atomic.Int32
+	newElection := func() *LeaderElection {
+		election := NewLeaderElection(client, "leader", 100*time.Millisecond)
+		election.RenewInterval = 5 * time.Millisecond
+		election.OnElected = func(ctx context.Context, fencingToken int64) { elected.Add(1) }
+		election.OnDemoted = func() { demoted.Add(1) }
+		return election
+	}
+	first, second := newElection(), newElection()
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	done1 := make(chan error)
+	done2 := make(chan error)
+	go func() { done1 <- first.Run(ctx1) }()
+	time.Sleep(20 * time.Millisecond)
+	go func() { done2 <- second.Run(ctx2) }()
+	time.Sleep(20 * time.Millisecond)
+
+	if !first.IsLeader() || second.IsLeader() {
+		t.Fatalf("IsLeader() = %v, %v, want true, false", first.IsLeader(), second.IsLeader())
+	}
+	firstToken, _ := first.FencingToken()
+
+	// resigning releases the lock so the second replica takes over without waiting for the TTL
+	cancel1()
+	if err := <-done1; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for !second.IsLeader() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !second.IsLeader() {
+		t.Fatal("second replica was not elected")
+	}
+	if secondToken, _ := second.FencingToken(); secondToken <= firstToken {
+		t.Errorf("fencing token %d not greater than previous token %d", secondToken, firstToken)
+	}
+	for elected.Load() != 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if elected.Load() != 2 || demoted.Load() != 1 {
+		t.Errorf("elected %d times, demoted %d times, want 2 and 1", elected.Load(), demoted.Load())
+	}
+
+	cancel2()
+	<-done2
+}
+
+func TestLeaderElectionBlockingCallback(t *testing.T) {
+	client, fake := newTestClient(t)
+
+	// a callback running for the whole leadership must not stop the lease renewals
+	election := NewLeaderElection(client, "leader", 60*time.Millisecond)
+	election.RenewInterval = 0
+	election.OnElected = func(ctx context.Context, fencingToken int64) { <-ctx.Done() }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- election.Run(ctx) }()
+	time.Sleep(150 * time.Millisecond)
+
+	if !election.IsLeader() {
+		t.Error("leader lost its leadership while OnElected was running")
+	}
+	fake.mu.Lock()
+	version := fake.entries["leader"].Version
+	fake.mu.Unlock()
+	if version < 3 {
+		t.Errorf("lease was written %d times, want renewals while OnElected is running", version)
+	}
+	cancel()
+	<-done
+}
+
+func TestLeaderElectionInvalidTTL(t *testing.T) {
+	client, _ := newTestClient(t)
+	if err := NewLeaderElection(client, "leader", 0).Run(context.Background()); err == nil {
+		t.Error("Run() with zero TTL: expected an error")
+	}
+}
+
+func TestFencingTokenIsWriteVersion(t *testing.T) {
+	client, fake := newTestClient(t)
+	ctx := context.Background()
+
+	// versions assigned by the KVDB need not be consecutive
+	fake.versionGap = 10
+	fake.entries["lock"] = Entry{Key: "lock", Value: `{"owner":""}`, Version: 41}
+	lock, err := client.Acquire(ctx, "lock", time.Minute)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if stored := fake.entries["lock"].Version; lock.FencingToken() != stored {
+		t.Errorf("FencingToken() = %d, want the version %d of the acquiring write", lock.FencingToken(), stored)
+	}
+}
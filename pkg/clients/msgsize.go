@@ -0,0 +1,202 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+)
+
+// Default message size limits if GRPC_MAX_SEND_MESSAGE_BYTES, GRPC_MAX_RECV_MESSAGE_BYTES
+// and GRPC_MESSAGE_SIZE_SOFT_LIMIT_BYTES are not set
+const (
+	DefaultMaxSendMessageBytes       = 1 << 30
+	DefaultMaxRecvMessageBytes       = 1 << 30
+	DefaultMessageSizeSoftLimitBytes = 16 << 20
+)
+
+// LargeMessageMetricName is the name of the metric sent with the size of each gRPC message exceeding the soft limit
+const LargeMessageMetricName = "aali.client.grpc.large_message_bytes"
+
+// MessageSizeLimits defines the size limits of the gRPC messages of a connection
+type MessageSizeLimits struct {
+	MaxSend   int // maximum size of a sent message; larger messages fail with codes.ResourceExhausted
+	MaxRecv   int // maximum size of a received message; larger messages fail with codes.ResourceExhausted
+	SoftLimit int // size above which a metric and a warning are emitted; 0 disables them
+}
+
+// MessageSizeLimitsFromConfig returns the message size limits of a connection
+// Limits not set in the overrides are taken from the global configuration, or the defaults.
+//
+// Parameters:
+//   - overrides: the limits configured for the connection; zero values are not overridden, a negative SoftLimit disables the soft limit
+//
+// Returns:
+//   - MessageSizeLimits: the limits of the connection
+func MessageSizeLimitsFromConfig(overrides MessageSizeLimits) MessageSizeLimits {
+	limits := MessageSizeLimits{
+		MaxSend:   DefaultMaxSendMessageBytes,
+		MaxRecv:   DefaultMaxRecvMessageBytes,
+		SoftLimit: DefaultMessageSizeSoftLimitBytes,
+	}
+	if config.Current() != nil {
+		limits = limits.override(MessageSizeLimits{
+			MaxSend:   config.Current().GRPC_MAX_SEND_MESSAGE_BYTES,
+			MaxRecv:   config.Current().GRPC_MAX_RECV_MESSAGE_BYTES,
+			SoftLimit: config.Current().GRPC_MESSAGE_SIZE_SOFT_LIMIT_BYTES,
+		})
+	}
+	limits = limits.override(overrides)
+	if limits.SoftLimit < 0 {
+		limits.SoftLimit = 0
+	}
+	return limits
+}
+
+// override replaces the limits set in the overrides
+//
+// Parameters:
+//   - overrides: the limits to set; zero values are ignored
+//
+// Returns:
+//   - MessageSizeLimits: the resulting limits
+func (l MessageSizeLimits) override(overrides MessageSizeLimits) MessageSizeLimits {
+	if overrides.MaxSend > 0 {
+		l.MaxSend = overrides.MaxSend
+	}
+	if overrides.MaxRecv > 0 {
+		l.MaxRecv = overrides.MaxRecv
+	}
+	if overrides.SoftLimit != 0 {
+		l.SoftLimit = overrides.SoftLimit
+	}
+	return l
+}
+
+// GetGrpcMessageSizeDialOptions creates the gRPC dial options applying message size limits
+// Messages above the soft limit are reported by the metric LargeMessageMetricName and a warning,
+// so oversized payloads are noticed before they reach the hard limits.
+//
+// Parameters:
+//   - limits: the message size limits
+//
+// Returns:
+//   - options: the dial options
+func GetGrpcMessageSizeDialOptions(limits MessageSizeLimits) (options []grpc.DialOption) {
+	options = []grpc.DialOption{grpc.WithDefaultCallOptions(
+		grpc.MaxCallSendMsgSize(limits.MaxSend),
+		grpc.MaxCallRecvMsgSize(limits.MaxRecv),
+	)}
+	if limits.SoftLimit > 0 {
+		options = append(options, grpc.WithStatsHandler(messageSizeHandler{limits: limits}))
+	}
+	return options
+}
+
+// messageSizeHandler is a gRPC stats handler reporting messages exceeding the soft limit
+type messageSizeHandler struct {
+	limits MessageSizeLimits
+}
+
+// messageSizeMethodKey is the context key of the method name of a call
+type messageSizeMethodKey struct{}
+
+// TagRPC stores the method name of the call in the context
+func (h messageSizeHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, messageSizeMethodKey{}, info.FullMethodName)
+}
+
+// HandleRPC reports the payloads exceeding the soft limit
+func (h messageSizeHandler) HandleRPC(ctx context.Context, rpcStats stats.RPCStats) {
+	switch payload := rpcStats.(type) {
+	case *stats.InPayload:
+		h.observe(ctx, "recv", payload.Length, h.limits.MaxRecv)
+	case *stats.OutPayload:
+		h.observe(ctx, "send", payload.Length, h.limits.MaxSend)
+	}
+}
+
+// TagConn returns the context unchanged
+func (h messageSizeHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn ignores connection events
+func (h messageSizeHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+// observe reports a message if it exceeds the soft limit
+//
+// Parameters:
+//   - ctx: the context of the call
+//   - direction: "send" or "recv"
+//   - size: the size of the message
+//   - hardLimit: the maximum size of messages in this direction
+func (h messageSizeHandler) observe(ctx context.Context, direction string, size int, hardLimit int) {
+	if size <= h.limits.SoftLimit {
+		return
+	}
+	method, _ := ctx.Value(messageSizeMethodKey{}).(string)
+	logging.Log.MetricsWithTags(LargeMessageMetricName, float64(size), "method:"+method, "direction:"+direction)
+	logging.Log.Warnf(&logging.ContextMap{}, "large gRPC message (%s) in %s: %d bytes exceed the soft limit of %d bytes (hard limit %d bytes)", direction, method, size, h.limits.SoftLimit, hardLimit)
+}
+
+// ChunkString splits a string into chunks of at most maxBytes bytes without splitting UTF-8 characters,
+// so every chunk can be sent in a proto string field. Concatenating the chunks restores the string.
+//
+// Parameters:
+//   - value: the string to split
+//   - maxBytes: the maximum size of a chunk; at least 4 to fit every UTF-8 character
+//
+// Returns:
+//   - chunks: the chunks; a single empty chunk for an empty string
+//   - err: an error if maxBytes is less than 4
+func ChunkString(value string, maxBytes int) (chunks []string, err error) {
+	if maxBytes < utf8.UTFMax {
+		return nil, fmt.Errorf("chunk size must be at least %d bytes, got %d", utf8.UTFMax, maxBytes)
+	}
+	if len(value) <= maxBytes {
+		return []string{value}, nil
+	}
+
+	chunks = make([]string, 0, (len(value)+maxBytes-1)/maxBytes)
+	for len(value) > maxBytes {
+		end := maxBytes
+		// move the end back to the start of a character
+		for end > 0 && !utf8.RuneStart(value[end]) {
+			end--
+		}
+		if end == 0 {
+			// not valid UTF-8, split at the byte limit
+			end = maxBytes
+		}
+		chunks = append(chunks, value[:end])
+		value = value[end:]
+	}
+	return append(chunks, value), nil
+}
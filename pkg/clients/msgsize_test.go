@@ -0,0 +1,148 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package clients
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestMessageSizeLimitsFromConfig(t *testing.T) {
+	defaults := MessageSizeLimits{DefaultMaxSendMessageBytes, DefaultMaxRecvMessageBytes, DefaultMessageSizeSoftLimitBytes}
+	tests := []struct {
+		name      string
+		cfg       *config.Config
+		overrides MessageSizeLimits
+		want      MessageSizeLimits
+	}{
+		{"no config", nil, MessageSizeLimits{}, defaults},
+		{"defaults", &config.Config{}, MessageSizeLimits{}, defaults},
+		{
+			"global",
+			&config.Config{GRPC_MAX_SEND_MESSAGE_BYTES: 100, GRPC_MAX_RECV_MESSAGE_BYTES: 200, GRPC_MESSAGE_SIZE_SOFT_LIMIT_BYTES: 50},
+			MessageSizeLimits{},
+			MessageSizeLimits{100, 200, 50},
+		},
+		{
+			"connection overrides global",
+			&config.Config{GRPC_MAX_SEND_MESSAGE_BYTES: 100, GRPC_MAX_RECV_MESSAGE_BYTES: 200},
+			MessageSizeLimits{MaxRecv: 300, SoftLimit: 10},
+			MessageSizeLimits{100, 300, 10},
+		},
+		{"soft limit disabled globally", &config.Config{GRPC_MESSAGE_SIZE_SOFT_LIMIT_BYTES: -1}, MessageSizeLimits{}, MessageSizeLimits{DefaultMaxSendMessageBytes, DefaultMaxRecvMessageBytes, 0}},
+		{"soft limit disabled for connection", &config.Config{}, MessageSizeLimits{SoftLimit: -1}, MessageSizeLimits{DefaultMaxSendMessageBytes, DefaultMaxRecvMessageBytes, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withConfig(t, tt.cfg)
+			if got := MessageSizeLimitsFromConfig(tt.overrides); got != tt.want {
+				t.Errorf("MessageSizeLimitsFromConfig(%+v) = %+v, want %+v", tt.overrides, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunkString(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		maxBytes int
+		want     []string
+	}{
+		{"empty", "", 4, []string{""}},
+		{"fits", "abcd", 4, []string{"abcd"}},
+		{"ascii", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"multi-byte characters", "aäöü", 4, []string{"aä", "öü"}},
+		{"four-byte character", "ab😀c", 4, []string{"ab", "😀", "c"}},
+		{"invalid UTF-8", "\x80\x80\x80\x80\x80", 4, []string{"\x80\x80\x80\x80", "\x80"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ChunkString(tt.value, tt.maxBytes)
+			if err != nil {
+				t.Fatalf("ChunkString() error = %v", err)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+				t.Errorf("ChunkString(%q, %d) = %q, want %q", tt.value, tt.maxBytes, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ChunkString("abc", 3); err == nil {
+		t.Error("ChunkString() with chunk size 3 returned no error")
+	}
+}
+
+func TestGetGrpcMessageSizeDialOptions(t *testing.T) {
+	withConfig(t, &config.Config{})
+	capture := logging.CaptureForTest(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(listener)
+	defer server.Stop()
+
+	dial := func(limits MessageSizeLimits) grpc_health_v1.HealthClient {
+		options, err := GetGrpcDialOptions("http")
+		if err != nil {
+			t.Fatalf("GetGrpcDialOptions() error = %v", err)
+		}
+		conn, err := grpc.NewClient(listener.Addr().String(), append(options, GetGrpcMessageSizeDialOptions(limits)...)...)
+		if err != nil {
+			t.Fatalf("grpc.NewClient() error = %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return grpc_health_v1.NewHealthClient(conn)
+	}
+	request := &grpc_health_v1.HealthCheckRequest{Service: strings.Repeat("x", 100)}
+
+	// messages above the soft limit are reported
+	client := dial(MessageSizeLimits{MaxSend: 1000, MaxRecv: 1000, SoftLimit: 50})
+	if _, err := client.Check(context.Background(), request); status.Code(err) != codes.NotFound {
+		t.Fatalf("Check() error = %v, want NotFound of the unknown service", err)
+	}
+	if !capture.Contains(zapcore.WarnLevel, "large gRPC message (send) in /grpc.health.v1.Health/Check") {
+		t.Errorf("warnings = %q", capture.Messages(zapcore.WarnLevel))
+	}
+
+	// messages above the hard limit are rejected
+	client = dial(MessageSizeLimits{MaxSend: 50, MaxRecv: 1000})
+	if _, err := client.Check(context.Background(), request); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Check() above the send limit error = %v, want ResourceExhausted", err)
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/netutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Defaults of WaitForDependencies if STARTUP_WAIT_TIMEOUT_SECONDS and STARTUP_WAIT_BACKOFF_MS are not set
+const (
+	defaultStartupWaitTimeout = 120 * time.Second
+	defaultStartupWaitBackoff = 500 * time.Millisecond
+	maxStartupWaitBackoff     = 10 * time.Second
+	startupProbeTimeout       = 5 * time.Second
+)
+
+// Dependency is a service that must be reachable before a service starts
+type Dependency struct {
+	Name    string                          // name used in logs, e.g. "kvdb"
+	Address string                          // address used in logs, e.g. "http://kvdb:8080"
+	Probe   func(ctx context.Context) error // returns nil once the dependency is reachable
+}
+
+// ConfiguredDependencies returns the dependencies configured in a configuration
+// Each dependency is probed with its own health check, so a dependency that accepts connections but
+// cannot serve requests yet is not reachable: FlowKit with its HealthCheck call, FlowKit-Python with
+// its function list, the LLM handler with a websocket handshake, the KVDB by reading a key, GraphDB
+// with its health endpoint and Qdrant with its HealthCheck call. Dependencies without an endpoint are skipped.
+//
+// Parameters:
+//   - cfg: the configuration
+//
+// Returns:
+//   - dependencies: the dependencies
+func ConfiguredDependencies(cfg *config.Config) (dependencies []Dependency) {
+	flowkit := cfg.FLOWKIT_CONNECTIONS
+	if len(flowkit) == 0 && cfg.EXTERNALFUNCTIONS_ENDPOINT != "" {
+		flowkit = []config.FlowkitConnection{{URL: cfg.EXTERNALFUNCTIONS_ENDPOINT, API_KEY: cfg.FLOWKIT_API_KEY}}
+	}
+	for _, connection := range flowkit {
+		dependencies = append(dependencies, flowkitDependency(connection))
+	}
+	flowkitPython := cfg.FLOWKIT_PYTHON_CONNECTIONS
+	if len(flowkitPython) == 0 && cfg.FLOWKIT_PYTHON_ENDPOINT != "" {
+		flowkitPython = []config.FlowkitConnection{{URL: cfg.FLOWKIT_PYTHON_ENDPOINT, API_KEY: cfg.FLOWKIT_PYTHON_API_KEY}}
+	}
+	for _, connection := range flowkitPython {
+		dependencies = append(dependencies, httpDependency("flowkit-python", connection.URL, connection.URL, connection.API_KEY, nil))
+	}
+	if cfg.LLM_HANDLER_ENDPOINT != "" {
+		dependencies = append(dependencies, websocketDependency("llm", cfg.LLM_HANDLER_ENDPOINT))
+	}
+	if cfg.KVDB_ENDPOINT != "" {
+		// a missing key is healthy, since the KVDB answered
+		healthKey := strings.TrimSuffix(cfg.KVDB_ENDPOINT, "/") + "/v1/keys/" + kvdbHealthKey
+		dependencies = append(dependencies, httpDependency("kvdb", cfg.KVDB_ENDPOINT, healthKey, cfg.KVDB_API_KEY, []int{http.StatusNotFound}))
+	}
+	for _, connection := range config.GraphDbConnections(cfg) {
+		health := strings.TrimSuffix(connection.ADDRESS, "/") + "/health"
+		dependencies = append(dependencies, httpDependency("graphdb", connection.ADDRESS, health, connection.API_KEY, nil))
+	}
+	for _, connection := range config.QdrantConnections(cfg) {
+		dependencies = append(dependencies, qdrantDependency(connection))
+	}
+	return dependencies
+}
+
+// kvdbHealthKey is the key read by the KVDB probe; it does not need to exist
+const kvdbHealthKey = "__aali_health__"
+
+// flowkitDependency creates a dependency that is reachable once the HealthCheck call of a FlowKit server succeeds
+func flowkitDependency(connection config.FlowkitConnection) Dependency {
+	return grpcDependency("flowkit", connection.URL, func(ctx context.Context, conn *grpc.ClientConn) error {
+		if connection.API_KEY != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", connection.API_KEY)
+		}
+		_, err := aaliflowkitgrpc.NewExternalFunctionsClient(conn).HealthCheck(ctx, &aaliflowkitgrpc.HealthRequest{})
+		return err
+	})
+}
+
+// qdrantDependency creates a dependency that is reachable once the qdrant.Qdrant/HealthCheck call of a Qdrant server succeeds
+// The request and reply are decoded as empty messages, since only the success of the call matters.
+func qdrantDependency(connection config.DatabaseConnection) Dependency {
+	return grpcDependency("qdrant", connection.ADDRESS, func(ctx context.Context, conn *grpc.ClientConn) error {
+		if connection.API_KEY != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "api-key", connection.API_KEY)
+		}
+		return conn.Invoke(ctx, "/qdrant.Qdrant/HealthCheck", &emptypb.Empty{}, &emptypb.Empty{})
+	})
+}
+
+// grpcDependency creates a dependency that is reachable once a gRPC call to its endpoint succeeds
+func grpcDependency(name string, endpoint string, call func(ctx context.Context, conn *grpc.ClientConn) error) Dependency {
+	return Dependency{
+		Name:    name,
+		Address: endpoint,
+		Probe: func(ctx context.Context) error {
+			parsed, err := netutil.ParseEndpoint(endpoint)
+			if err != nil {
+				return err
+			}
+			scheme := "http"
+			if parsed.TLS {
+				scheme = "https"
+			}
+			opts, err := GetGrpcDialOptions(scheme)
+			if err != nil {
+				return err
+			}
+			conn, err := grpc.NewClient(parsed.Address(), opts...)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			return call(ctx, conn)
+		},
+	}
+}
+
+// httpDependency creates a dependency that is reachable once a GET request to url answers with 2xx
+// or one of the accepted status codes
+func httpDependency(name string, address string, url string, apiKey string, accepted []int) Dependency {
+	return Dependency{
+		Name:    name,
+		Address: address,
+		Probe: func(ctx context.Context) error {
+			httpClient, err := GetHttpClient()
+			if err != nil {
+				return err
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return err
+			}
+			if apiKey != "" {
+				req.Header.Set("api-key", apiKey)
+			}
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if (resp.StatusCode < 200 || resp.StatusCode >= 300) && !slices.Contains(accepted, resp.StatusCode) {
+				return fmt.Errorf("unexpected status code: %v", resp.StatusCode)
+			}
+			return nil
+		},
+	}
+}
+
+// websocketDependency creates a dependency that is reachable once a websocket handshake with its endpoint succeeds
+func websocketDependency(name string, endpoint string) Dependency {
+	return Dependency{
+		Name:    name,
+		Address: endpoint,
+		Probe: func(ctx context.Context) error {
+			conn, resp, err := websocket.Dial(ctx, endpoint, nil)
+			if err != nil {
+				if resp != nil {
+					return fmt.Errorf("websocket handshake failed with status code %v: %w", resp.StatusCode, err)
+				}
+				return err
+			}
+			// the handshake is the check; the connection is not used
+			_ = conn.CloseNow()
+			return nil
+		},
+	}
+}
+
+// WaitForDependencies waits until all dependencies configured in a configuration are reachable
+// Services call it at startup, so dependencies that start later than the service do not cause a crash loop.
+// Each dependency is probed until it is reachable, with a backoff starting at STARTUP_WAIT_BACKOFF_MS
+// and doubled for every probe; waiting stops after STARTUP_WAIT_TIMEOUT_SECONDS or when ctx is done.
+//
+// Parameters:
+//   - ctx: the context of the startup
+//   - cfg: the configuration
+//
+// Returns:
+//   - err: a transient error naming the unreachable dependencies if they are not reachable in time
+func WaitForDependencies(ctx context.Context, cfg *config.Config) (err error) {
+	timeout := defaultStartupWaitTimeout
+	if cfg.STARTUP_WAIT_TIMEOUT_SECONDS > 0 {
+		timeout = time.Duration(cfg.STARTUP_WAIT_TIMEOUT_SECONDS) * time.Second
+	}
+	backoff := defaultStartupWaitBackoff
+	if cfg.STARTUP_WAIT_BACKOFF_MS > 0 {
+		backoff = time.Duration(cfg.STARTUP_WAIT_BACKOFF_MS) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return WaitFor(ctx, ConfiguredDependencies(cfg), backoff)
+}
+
+// WaitFor waits until all dependencies are reachable, probing them concurrently
+//
+// Parameters:
+//   - ctx: the context bounding the wait
+//   - dependencies: the dependencies
+//   - backoff: the wait time before the second probe of a dependency, doubled for every further probe up to 10 seconds
+//
+// Returns:
+//   - err: a transient error naming the unreachable dependencies if ctx is done before they are reachable
+func WaitFor(ctx context.Context, dependencies []Dependency, backoff time.Duration) (err error) {
+	logCtx := &logging.ContextMap{}
+	start := time.Now()
+
+	var mutex sync.Mutex
+	var unreachable []string
+	var wg sync.WaitGroup
+	for _, dependency := range dependencies {
+		wg.Add(1)
+		go func(dependency Dependency) {
+			defer wg.Done()
+			if err := waitForDependency(ctx, logCtx, dependency, backoff); err != nil {
+				mutex.Lock()
+				unreachable = append(unreachable, fmt.Sprintf("%s (%s): %v", dependency.Name, dependency.Address, err))
+				mutex.Unlock()
+			}
+		}(dependency)
+	}
+	wg.Wait()
+
+	if len(unreachable) > 0 {
+		return aalierrors.Newf(nil, aalierrors.CodeTransient, "dependencies not reachable after %v: %s", time.Since(start).Round(time.Millisecond), strings.Join(unreachable, "; "))
+	}
+	if len(dependencies) > 0 {
+		logging.Log.Infof(logCtx, "all %d dependencies reachable after %v", len(dependencies), time.Since(start).Round(time.Millisecond))
+	}
+	return nil
+}
+
+// waitForDependency probes a dependency until it is reachable or ctx is done
+//
+// Returns:
+//   - err: the error of the last probe if ctx is done first
+func waitForDependency(ctx context.Context, logCtx *logging.ContextMap, dependency Dependency, backoff time.Duration) (err error) {
+	for attempt := 1; ; attempt++ {
+		probeCtx, cancel := context.WithTimeout(ctx, startupProbeTimeout)
+		err = dependency.Probe(probeCtx)
+		cancel()
+		if err == nil {
+			logging.Log.Infof(logCtx, "dependency %s (%s) reachable after %d attempt(s)", dependency.Name, dependency.Address, attempt)
+			return nil
+		}
+
+		logging.Log.Warnf(logCtx, "dependency %s (%s) not reachable (attempt %d): %v; retrying in %v", dependency.Name, dependency.Address, attempt, err, backoff)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxStartupWaitBackoff)
+	}
+}
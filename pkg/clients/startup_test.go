@@ -0,0 +1,225 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestConfiguredDependencies(t *testing.T) {
+	cfg := &config.Config{
+		FLOWKIT_CONNECTIONS:     []config.FlowkitConnection{{URL: "flowkit-a:50051"}, {URL: "flowkit-b:50051"}},
+		FLOWKIT_PYTHON_ENDPOINT: "flowkit-python:50052",
+		LLM_HANDLER_ENDPOINT:    "ws://llm:9003",
+		KVDB_ENDPOINT:           "http://kvdb:8080",
+		GRAPHDB_ADDRESS:         "http://graphdb:8081",
+		QDRANT_HOST:             "qdrant",
+		QDRANT_PORT:             6334,
+	}
+	var got []string
+	for _, dependency := range ConfiguredDependencies(cfg) {
+		got = append(got, dependency.Name+"="+dependency.Address)
+	}
+	want := "flowkit=flowkit-a:50051 flowkit=flowkit-b:50051 flowkit-python=flowkit-python:50052 llm=ws://llm:9003 kvdb=http://kvdb:8080 graphdb=http://graphdb:8081 qdrant=qdrant:6334"
+	if strings.Join(got, " ") != want {
+		t.Errorf("ConfiguredDependencies() = %v, want %v", strings.Join(got, " "), want)
+	}
+
+	if got := ConfiguredDependencies(&config.Config{}); len(got) != 0 {
+		t.Errorf("ConfiguredDependencies(empty) = %v, want none", got)
+	}
+}
+
+func TestWaitForRetriesUntilReachable(t *testing.T) {
+	logging.CaptureForTest(t)
+	var attempts atomic.Int32
+	dependency := Dependency{Name: "kvdb", Address: "kvdb:8080", Probe: func(context.Context) error {
+		if attempts.Add(1) < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}}
+
+	if err := WaitFor(context.Background(), []Dependency{dependency}, time.Millisecond); err != nil {
+		t.Fatalf("WaitFor() error = %v", err)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestWaitForTimeout(t *testing.T) {
+	logging.CaptureForTest(t)
+	reachable := Dependency{Name: "kvdb", Address: "kvdb:8080", Probe: func(context.Context) error { return nil }}
+	unreachable := Dependency{Name: "graphdb", Address: "graphdb:8081", Probe: func(context.Context) error {
+		return errors.New("connection refused")
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := WaitFor(ctx, []Dependency{reachable, unreachable}, 5*time.Millisecond)
+	if err == nil {
+		t.Fatal("WaitFor() error = nil, want timeout")
+	}
+	if aalierrors.CodeOf(err) != aalierrors.CodeTransient {
+		t.Errorf("code = %v, want %v", aalierrors.CodeOf(err), aalierrors.CodeTransient)
+	}
+	if !strings.Contains(err.Error(), "graphdb (graphdb:8081): connection refused") || strings.Contains(err.Error(), "kvdb") {
+		t.Errorf("error = %v, want only graphdb", err)
+	}
+}
+
+// fakeFlowkitServer answers HealthCheck calls with the API key "secret" and rejects the others
+type fakeFlowkitServer struct {
+	aaliflowkitgrpc.UnimplementedExternalFunctionsServer
+}
+
+func (s *fakeFlowkitServer) HealthCheck(ctx context.Context, req *aaliflowkitgrpc.HealthRequest) (*aaliflowkitgrpc.HealthResponse, error) {
+	if md, _ := metadata.FromIncomingContext(ctx); len(md.Get("x-api-key")) != 1 || md.Get("x-api-key")[0] != "secret" {
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+	return &aaliflowkitgrpc.HealthResponse{Status: "OK"}, nil
+}
+
+// startGrpcServer serves the registered services on a local port and returns its address
+func startGrpcServer(t *testing.T, register func(server *grpc.Server)) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	register(server)
+	go server.Serve(listener) //nolint:errcheck
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestWaitForDependenciesProbes(t *testing.T) {
+	logging.CaptureForTest(t)
+	withConfig(t, &config.Config{})
+
+	flowkit := startGrpcServer(t, func(server *grpc.Server) {
+		aaliflowkitgrpc.RegisterExternalFunctionsServer(server, &fakeFlowkitServer{})
+	})
+	var qdrantCalls atomic.Int32
+	qdrant := startGrpcServer(t, func(server *grpc.Server) {
+		server.RegisterService(&grpc.ServiceDesc{
+			ServiceName: "qdrant.Qdrant",
+			HandlerType: (*any)(nil),
+			Methods: []grpc.MethodDesc{{MethodName: "HealthCheck", Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				qdrantCalls.Add(1)
+				return &emptypb.Empty{}, dec(&emptypb.Empty{})
+			}}},
+		}, struct{}{})
+	})
+
+	kvdb := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/keys/"+kvdbHealthKey || r.Header.Get("api-key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer kvdb.Close()
+
+	graphdb := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" || r.Header.Get("api-key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer graphdb.Close()
+
+	llm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.CloseNow() //nolint:errcheck
+	}))
+	defer llm.Close()
+
+	cfg := &config.Config{
+		FLOWKIT_CONNECTIONS:          []config.FlowkitConnection{{URL: "http://" + flowkit, API_KEY: "secret"}},
+		LLM_HANDLER_ENDPOINT:         "ws" + strings.TrimPrefix(llm.URL, "http"),
+		KVDB_ENDPOINT:                kvdb.URL,
+		KVDB_API_KEY:                 "secret",
+		GRAPHDB_ADDRESS:              graphdb.URL,
+		GRAPHDB_API_KEY:              "secret",
+		QDRANT_CONNECTIONS:           []config.DatabaseConnection{{ADDRESS: qdrant}},
+		STARTUP_WAIT_TIMEOUT_SECONDS: 5,
+		STARTUP_WAIT_BACKOFF_MS:      1,
+	}
+	if err := WaitForDependencies(context.Background(), cfg); err != nil {
+		t.Fatalf("WaitForDependencies() error = %v", err)
+	}
+	if qdrantCalls.Load() == 0 {
+		t.Error("Qdrant HealthCheck was not called")
+	}
+
+	tests := []struct {
+		name    string
+		change  func(cfg *config.Config)
+		wantErr string
+	}{
+		{"graphdb key", func(cfg *config.Config) { cfg.GRAPHDB_API_KEY = "wrong" }, "unexpected status code: 401"},
+		{"kvdb key", func(cfg *config.Config) { cfg.KVDB_API_KEY = "wrong" }, "kvdb (" + kvdb.URL + "): unexpected status code: 401"},
+		{"flowkit key", func(cfg *config.Config) { cfg.FLOWKIT_CONNECTIONS[0].API_KEY = "wrong" }, "invalid API key"},
+		{"llm without websocket", func(cfg *config.Config) { cfg.LLM_HANDLER_ENDPOINT = graphdb.URL }, "llm (" + graphdb.URL + "): websocket handshake failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the wait ends during the backoff after the first probe, which reports its error
+			changed := *cfg
+			changed.FLOWKIT_CONNECTIONS = slices.Clone(cfg.FLOWKIT_CONNECTIONS)
+			changed.STARTUP_WAIT_BACKOFF_MS = 10000
+			tt.change(&changed)
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			if err := WaitForDependencies(ctx, &changed); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("WaitForDependencies() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"google.golang.org/grpc"
+)
+
+// defaultSlowCallThreshold is the duration of a call above which a warning is logged if CLIENT_SLOW_CALL_THRESHOLD_MS is not set
+const defaultSlowCallThreshold = 5 * time.Second
+
+// SlowCallMetricName is the name of the metric sent for each call exceeding its slow call threshold
+const SlowCallMetricName = "aali.client.slow_calls"
+
+// callDurationBounds are the upper bounds of the buckets of the call duration histograms
+var callDurationBounds = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+}
+
+// CallKey identifies the calls recorded in the same histogram
+type CallKey struct {
+	Protocol string // "grpc" or "http"
+	Target   string // host:port of the server
+	Method   string // full gRPC method name or HTTP method
+}
+
+// DurationHistogram is a histogram of call durations
+type DurationHistogram struct {
+	Bounds []time.Duration // upper bounds of the buckets
+	Counts []uint64        // number of calls per bucket; the last bucket counts the calls above the last bound
+	Count  uint64          // total number of calls
+	Sum    time.Duration   // total duration of the calls
+	Max    time.Duration   // longest call
+}
+
+// Mean returns the mean duration of the recorded calls
+//
+// Returns:
+//   - time.Duration: the mean duration, 0 if no call was recorded
+func (h DurationHistogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// observe adds a duration to the histogram
+//
+// Parameters:
+//   - duration: the duration of the call
+func (h *DurationHistogram) observe(duration time.Duration) {
+	bucket := len(h.Bounds)
+	for i, bound := range h.Bounds {
+		if duration <= bound {
+			bucket = i
+			break
+		}
+	}
+	h.Counts[bucket]++
+	h.Count++
+	h.Sum += duration
+	if duration > h.Max {
+		h.Max = duration
+	}
+}
+
+// callDurations holds the duration histograms of the calls made through the shared clients
+var callDurations = struct {
+	sync.Mutex
+	byKey map[CallKey]*DurationHistogram
+}{byKey: map[CallKey]*DurationHistogram{}}
+
+// CallDurations returns a snapshot of the duration histograms of the gRPC and HTTP calls
+// made through the clients created by GetGrpcDialOptions and GetHttpClient
+//
+// Returns:
+//   - map[CallKey]DurationHistogram: the histograms, keyed by protocol, target and method
+func CallDurations() map[CallKey]DurationHistogram {
+	callDurations.Lock()
+	defer callDurations.Unlock()
+
+	snapshot := make(map[CallKey]DurationHistogram, len(callDurations.byKey))
+	for key, histogram := range callDurations.byKey {
+		copied := *histogram
+		copied.Counts = append([]uint64(nil), histogram.Counts...)
+		snapshot[key] = copied
+	}
+	return snapshot
+}
+
+// slowCallThreshold returns the duration above which a call to a target is logged as slow
+// The thresholds are read from CLIENT_SLOW_CALL_THRESHOLDS_MS and CLIENT_SLOW_CALL_THRESHOLD_MS.
+//
+// Parameters:
+//   - target: the host:port of the server
+//
+// Returns:
+//   - threshold: the threshold, 0 if slow calls are not logged
+func slowCallThreshold(target string) (threshold time.Duration) {
+	if config.Current() == nil {
+		return defaultSlowCallThreshold
+	}
+
+	milliseconds, ok := config.Current().CLIENT_SLOW_CALL_THRESHOLDS_MS[target]
+	if !ok {
+		milliseconds = config.Current().CLIENT_SLOW_CALL_THRESHOLD_MS
+	}
+	switch {
+	case milliseconds < 0:
+		return 0
+	case milliseconds == 0:
+		return defaultSlowCallThreshold
+	default:
+		return time.Duration(milliseconds) * time.Millisecond
+	}
+}
+
+// recordCall records the duration of a call in its histogram and the duration metric,
+// and logs a warning if the call exceeded its slow call threshold
+//
+// Parameters:
+//   - key: the protocol, target and method of the call
+//   - duration: the duration of the call
+func recordCall(key CallKey, duration time.Duration) {
+	callDurations.Lock()
+	histogram, ok := callDurations.byKey[key]
+	if !ok {
+		histogram = &DurationHistogram{Bounds: callDurationBounds, Counts: make([]uint64, len(callDurationBounds)+1)}
+		callDurations.byKey[key] = histogram
+	}
+	histogram.observe(duration)
+	callDurations.Unlock()
+
+	logging.Log.Metrics("aali.client."+key.Protocol+".duration_ms", float64(duration.Milliseconds()))
+
+	threshold := slowCallThreshold(key.Target)
+	if threshold > 0 && duration > threshold {
+		logging.Log.Metrics(SlowCallMetricName, 1)
+		logging.Log.Warnf(&logging.ContextMap{}, "slow %s call %s to %s took %s (threshold %s)", key.Protocol, key.Method, key.Target, duration, threshold)
+	}
+}
+
+// timingUnaryInterceptor is a gRPC client interceptor measuring the duration of unary calls
+// Streaming calls are not measured, as their duration depends on the consumer of the stream.
+func timingUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	started := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	recordCall(CallKey{Protocol: "grpc", Target: cc.Target(), Method: method}, time.Since(started))
+	return err
+}
+
+// timingTransport is an HTTP transport measuring the duration of requests until the response headers are received
+type timingTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip sends the request with the base transport and records its duration
+func (t timingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	recordCall(CallKey{Protocol: "http", Target: req.URL.Host, Method: req.Method}, time.Since(started))
+	return resp, err
+}
@@ -0,0 +1,199 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package clients
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// withConfig sets the global configuration for the duration of the test
+func withConfig(t *testing.T, cfg *config.Config) {
+	previous := config.GlobalConfig
+	config.GlobalConfig = cfg
+	t.Cleanup(func() { config.GlobalConfig = previous })
+}
+
+func TestDurationHistogramObserve(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		bucket   int
+	}{
+		{0, 0},
+		{5 * time.Millisecond, 0},
+		{6 * time.Millisecond, 1},
+		{time.Second, 7},
+		{time.Minute, len(callDurationBounds) - 1},
+		{time.Hour, len(callDurationBounds)},
+	}
+	for _, tt := range tests {
+		histogram := DurationHistogram{Bounds: callDurationBounds, Counts: make([]uint64, len(callDurationBounds)+1)}
+		histogram.observe(tt.duration)
+		if histogram.Counts[tt.bucket] != 1 {
+			t.Errorf("observe(%s) counts = %v, want bucket %d", tt.duration, histogram.Counts, tt.bucket)
+		}
+	}
+
+	histogram := DurationHistogram{Bounds: callDurationBounds, Counts: make([]uint64, len(callDurationBounds)+1)}
+	histogram.observe(10 * time.Millisecond)
+	histogram.observe(30 * time.Millisecond)
+	if histogram.Count != 2 || histogram.Max != 30*time.Millisecond || histogram.Mean() != 20*time.Millisecond {
+		t.Errorf("histogram = %+v, mean %s", histogram, histogram.Mean())
+	}
+	if (DurationHistogram{}).Mean() != 0 {
+		t.Error("Mean() of empty histogram != 0")
+	}
+}
+
+func TestSlowCallThreshold(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *config.Config
+		want   time.Duration
+	}{
+		{"no config", nil, defaultSlowCallThreshold},
+		{"default", &config.Config{}, defaultSlowCallThreshold},
+		{"global", &config.Config{CLIENT_SLOW_CALL_THRESHOLD_MS: 200}, 200 * time.Millisecond},
+		{"disabled", &config.Config{CLIENT_SLOW_CALL_THRESHOLD_MS: -1}, 0},
+		{"target", &config.Config{CLIENT_SLOW_CALL_THRESHOLD_MS: 200, CLIENT_SLOW_CALL_THRESHOLDS_MS: map[string]int{"llm:9003": 30000}}, 30 * time.Second},
+		{"target disabled", &config.Config{CLIENT_SLOW_CALL_THRESHOLDS_MS: map[string]int{"llm:9003": -1}}, 0},
+		{"other target", &config.Config{CLIENT_SLOW_CALL_THRESHOLD_MS: 200, CLIENT_SLOW_CALL_THRESHOLDS_MS: map[string]int{"flowkit:50051": 1000}}, 200 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withConfig(t, tt.config)
+			if got := slowCallThreshold("llm:9003"); got != tt.want {
+				t.Errorf("slowCallThreshold() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHttpClientRecordsSlowCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(50 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+	host := server.Listener.Addr().String()
+	withConfig(t, &config.Config{CLIENT_SLOW_CALL_THRESHOLDS_MS: map[string]int{host: 20}})
+	capture := logging.CaptureForTest(t)
+
+	client, err := GetHttpClient()
+	if err != nil {
+		t.Fatalf("GetHttpClient() error = %v", err)
+	}
+	for _, path := range []string{"/fast", "/slow"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s error = %v", path, err)
+		}
+		resp.Body.Close()
+	}
+
+	histogram := CallDurations()[CallKey{Protocol: "http", Target: host, Method: http.MethodGet}]
+	if histogram.Count != 2 || histogram.Max < 50*time.Millisecond {
+		t.Errorf("histogram = %+v, want 2 calls with max >= 50ms", histogram)
+	}
+	if got := capture.Messages(zapcore.WarnLevel); len(got) != 1 {
+		t.Errorf("warnings = %q, want one slow call warning", got)
+	}
+	if !capture.Contains(zapcore.WarnLevel, "slow http call GET to "+host) {
+		t.Errorf("warnings = %q", capture.Messages(zapcore.WarnLevel))
+	}
+}
+
+func TestTimingUnaryInterceptor(t *testing.T) {
+	withConfig(t, &config.Config{CLIENT_SLOW_CALL_THRESHOLD_MS: 20})
+	capture := logging.CaptureForTest(t)
+
+	target := "127.0.0.1:1"
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	defer conn.Close()
+
+	method := "/aaliflowkitgrpc.ExternalFunctions/ListFunctions"
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		time.Sleep(30 * time.Millisecond)
+		return context.DeadlineExceeded
+	}
+	err = timingUnaryInterceptor(context.Background(), method, nil, nil, conn, invoker)
+	if err != context.DeadlineExceeded {
+		t.Errorf("interceptor error = %v, want the error of the invoker", err)
+	}
+
+	histogram := CallDurations()[CallKey{Protocol: "grpc", Target: target, Method: method}]
+	if histogram.Count != 1 || histogram.Max < 30*time.Millisecond {
+		t.Errorf("histogram = %+v, want 1 call of at least 30ms", histogram)
+	}
+	if !capture.Contains(zapcore.WarnLevel, "slow grpc call "+method+" to "+target) {
+		t.Errorf("warnings = %q", capture.Messages(zapcore.WarnLevel))
+	}
+}
+
+func TestGetGrpcDialOptionsMeasuresCalls(t *testing.T) {
+	withConfig(t, &config.Config{})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(listener)
+	defer server.Stop()
+
+	options, err := GetGrpcDialOptions("http")
+	if err != nil {
+		t.Fatalf("GetGrpcDialOptions() error = %v", err)
+	}
+	target := listener.Addr().String()
+	conn, err := grpc.NewClient(target, options...)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	histogram := CallDurations()[CallKey{Protocol: "grpc", Target: target, Method: "/grpc.health.v1.Health/Check"}]
+	if histogram.Count != 1 {
+		t.Errorf("histogram = %+v, want 1 call", histogram)
+	}
+}
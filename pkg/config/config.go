@@ -38,6 +38,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/ansys/aali-sharedtypes/pkg/netutil"
 	"gopkg.in/yaml.v2"
 )
 
@@ -390,16 +391,50 @@ func InitGlobalConfigFromAzureKeyVault() (err error) {
 	// log
 	log.Println("Extracting configuration from Azure Key Vault...")
 
+	source, err := NewAzureKeyVaultSecretSource()
+	if err != nil {
+		return err
+	}
+
+	// get all secrets matching a config field
+	secrets, err := source.FetchSecrets(context.TODO())
+	if err != nil {
+		return err
+	}
+
+	// Apply secret values to config fields
+	for name, value := range secrets {
+		err = applySecretValueToConfig(GlobalConfig, name, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// azureKeyVaultSecretSource fetches the config secrets from Azure Key Vault.
+type azureKeyVaultSecretSource struct {
+	client *azsecrets.Client
+}
+
+// NewAzureKeyVaultSecretSource creates a secret source for the Azure Key Vault named in the environment variable
+// AZURE_KEY_VAULT_NAME, using the managed identity named in the environment variable AZURE_MANAGED_IDENTITY_ID.
+//
+// Returns:
+//   - SecretSource: the secret source
+//   - err: An error if the environment variables are missing or the credential cannot be created.
+func NewAzureKeyVaultSecretSource() (source SecretSource, err error) {
 	// get environment variables
 	azureManagedIdentity := os.Getenv(GlobalConfig.AZURE_MANAGED_IDENTITY_ID)
 	azureKeyVaultName := os.Getenv(GlobalConfig.AZURE_KEY_VAULT_NAME)
 
 	// check if all required environment variables are set
 	if azureManagedIdentity == "" {
-		return fmt.Errorf("environment variable for %v is not set", azureManagedIdentity)
+		return nil, fmt.Errorf("environment variable for %v is not set", azureManagedIdentity)
 	}
 	if azureKeyVaultName == "" {
-		return fmt.Errorf("environment variable for %v is not set", azureKeyVaultName)
+		return nil, fmt.Errorf("environment variable for %v is not set", azureKeyVaultName)
 	}
 
 	// create key vault URL
@@ -410,7 +445,7 @@ func InitGlobalConfigFromAzureKeyVault() (err error) {
 		ID: azidentity.ClientID(azureManagedIdentity),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to get Managed Identity credential: %w", err)
+		return nil, fmt.Errorf("failed to get Managed Identity credential: %w", err)
 	}
 
 	// Test the managed id by getting a token
@@ -419,52 +454,58 @@ func InitGlobalConfigFromAzureKeyVault() (err error) {
 		Scopes: []string{scope},
 	})
 	if err != nil {
-		return fmt.Errorf("failed to get token from managed ID: %w", err)
+		return nil, fmt.Errorf("failed to get token from managed ID: %w", err)
 	}
 
-	// Reflect on the struct
-	GlobalConfigValue := reflect.ValueOf(GlobalConfig).Elem()
-	GlobalConfigType := GlobalConfigValue.Type()
-
 	// create azsecrets client
 	clientSecrets, err := azsecrets.NewClient(keyVaultUrl, cred, nil)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return &azureKeyVaultSecretSource{client: clientSecrets}, nil
+}
+
+// FetchSecrets fetches all secrets whose name matches the JSON tag of a Config field.
+//
+// Parameters:
+//   - ctx: The context of the requests.
+//
+// Returns:
+//   - secrets: The secret values by secret name.
+//   - err: An error if the secrets cannot be listed or fetched.
+func (s *azureKeyVaultSecretSource) FetchSecrets(ctx context.Context) (secrets map[string]string, err error) {
+	// collect the JSON tags of all config fields
+	configFields := map[string]bool{}
+	configType := reflect.TypeOf(Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		configFields[configType.Field(i).Tag.Get("json")] = true
 	}
 
+	secrets = map[string]string{}
+
 	// list all secrets
-	pagerSecerts := clientSecrets.NewListSecretPropertiesPager(nil)
+	pagerSecerts := s.client.NewListSecretPropertiesPager(nil)
 	// iterate over all secrets
 	for pagerSecerts.More() {
-		page, err := pagerSecerts.NextPage(context.TODO())
+		page, err := pagerSecerts.NextPage(ctx)
 		if err != nil {
-			return fmt.Errorf("error, when iterating over Azure key vault secrets: %v", err)
+			return nil, fmt.Errorf("error, when iterating over Azure key vault secrets: %v", err)
 		}
 		for _, secret := range page.Value {
-			// iterate over all fields in the struct
-			for i := 0; i < GlobalConfigValue.NumField(); i++ {
-				// Get the YAML tag
-				fieldType := GlobalConfigType.Field(i)
-				yamlTag := fieldType.Tag.Get("json")
-
-				// Check if the field name matches the target field name
-				if yamlTag == secret.ID.Name() {
-					// Get the key value
-					resp, err := clientSecrets.GetSecret(context.TODO(), secret.ID.Name(), "", nil)
-					if err != nil {
-						return fmt.Errorf("error while getting value for secret '%v': %v", yamlTag, err)
-					}
-					// Apply secret value to config field
-					err = applySecretValueToConfig(GlobalConfig, yamlTag, *resp.Value)
-					if err != nil {
-						return err
-					}
-				}
+			name := secret.ID.Name()
+			if !configFields[name] {
+				continue
+			}
+			// Get the key value
+			resp, err := s.client.GetSecret(ctx, name, "", nil)
+			if err != nil {
+				return nil, fmt.Errorf("error while getting value for secret '%v': %v", name, err)
 			}
+			secrets[name] = *resp.Value
 		}
 	}
 
-	return nil
+	return secrets, nil
 }
 
 // applySecretValueToConfig applies a secret value (identified by its JSON tag) to the corresponding field in the Config struct.
@@ -541,20 +582,27 @@ func applySecretValueToConfig(config *Config, secretName string, secretValue str
 					return fmt.Errorf("error in json.Unmarshal []int for secret '%v' with value '%v': %v", secretName, secretValue, err)
 				}
 				field.Set(reflect.ValueOf(value))
+			case reflect.Struct:
+				value := reflect.New(field.Type())
+				err := json.Unmarshal([]byte(secretValue), value.Interface())
+				if err != nil {
+					return fmt.Errorf("error in json.Unmarshal %v for secret '%v' with value '%v': %v", field.Type(), secretName, secretValue, err)
+				}
+				field.Set(value.Elem())
 			default:
 				return fmt.Errorf("unsupported slice element type '%v' for secret '%v' with value '%v'", field.Type().Elem().Kind(), secretName, secretValue)
 			}
 		case reflect.Map:
 			if secretValue == "" {
-				field.Set(reflect.ValueOf(map[string]string{}))
+				field.Set(reflect.MakeMap(field.Type()))
 				break
 			}
-			var value map[string]string
-			err := json.Unmarshal([]byte(secretValue), &value)
+			value := reflect.New(field.Type())
+			err := json.Unmarshal([]byte(secretValue), value.Interface())
 			if err != nil {
-				return fmt.Errorf("error in json.Unmarshal map[string]string for secret '%v' with value '%v': %v", secretName, secretValue, err)
+				return fmt.Errorf("error in json.Unmarshal %v for secret '%v' with value '%v': %v", field.Type(), secretName, secretValue, err)
 			}
-			field.Set(reflect.ValueOf(value))
+			field.Set(value.Elem())
 		default:
 			return fmt.Errorf("unsupported field type '%v' for secret '%v' with value '%v'", field.Kind(), secretName, secretValue)
 		}
@@ -597,7 +645,7 @@ func ValidateConfig(config Config, requiredProperties []string) (err error) {
 // Returns:
 //   - string: The global configuration as a JSON string.
 func GetGlobalConfigAsJSON() string {
-	jsonData, err := json.Marshal(GlobalConfig)
+	jsonData, err := json.Marshal(Current())
 	if err != nil {
 		return ""
 	}
@@ -716,6 +764,8 @@ func timeToString(t time.Time) string {
 // HandleLegacyPortDefinition checks if the address is set, and if not, uses the legacy port to define the web server address.
 // If both are empty, it returns an error.
 //
+// Deprecated: Use netutil.ListenAddress instead.
+//
 // Parameters:
 //   - address: The address to use for the web server.
 //   - legacyPort: The legacy port to use if the address is not set.
@@ -724,11 +774,5 @@ func timeToString(t time.Time) string {
 //   - webserverAddress: The web server address to use.
 //   - err: An error if both address and legacy port are empty.
 func HandleLegacyPortDefinition(configAddress string, legacyPort string) (webserverAddress string, err error) {
-	if configAddress != "" {
-		return configAddress, nil
-	}
-	if legacyPort != "" {
-		return "0.0.0.0:" + legacyPort, nil
-	}
-	return "", fmt.Errorf("both address and legacy port are empty")
+	return netutil.ListenAddress(configAddress, legacyPort)
 }
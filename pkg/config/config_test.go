@@ -921,6 +921,26 @@ func TestApplySecretValueToConfig(t *testing.T) {
 			expectError:   true,
 			errorContains: "json.Unmarshal []int",
 		},
+		{
+			name:        "Set struct slice field",
+			secretName:  "GRAPHDBCONNECTIONS",
+			secretValue: `[{"NAME":"kb","ADDRESS":"http://kb:8080","APIKEY":"secret","PREFIXES":["product_"]}]`,
+			setupConfig: func() *Config { return &Config{} },
+			validate: func(t *testing.T, config *Config) {
+				expected := []DatabaseConnection{{NAME: "kb", ADDRESS: "http://kb:8080", API_KEY: "secret", PREFIXES: []string{"product_"}}}
+				if !reflect.DeepEqual(config.GRAPHDB_CONNECTIONS, expected) {
+					t.Errorf("Expected GRAPHDB_CONNECTIONS %v, got %v", expected, config.GRAPHDB_CONNECTIONS)
+				}
+			},
+		},
+		{
+			name:          "Set struct slice field invalid JSON",
+			secretName:    "GRAPHDBCONNECTIONS",
+			secretValue:   `{"NAME":"kb"}`,
+			setupConfig:   func() *Config { return &Config{} },
+			expectError:   true,
+			errorContains: "json.Unmarshal []config.DatabaseConnection",
+		},
 		// map[string]string type
 		{
 			name:        "Set map field",
@@ -945,6 +965,26 @@ func TestApplySecretValueToConfig(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:        "Set map field with int values",
+			secretName:  "CLIENTSLOWCALLTHRESHOLDSMS",
+			secretValue: `{"llm:9003":30000}`,
+			setupConfig: func() *Config { return &Config{} },
+			validate: func(t *testing.T, config *Config) {
+				expected := map[string]int{"llm:9003": 30000}
+				if !reflect.DeepEqual(config.CLIENT_SLOW_CALL_THRESHOLDS_MS, expected) {
+					t.Errorf("Expected CLIENT_SLOW_CALL_THRESHOLDS_MS %v, got %v", expected, config.CLIENT_SLOW_CALL_THRESHOLDS_MS)
+				}
+			},
+		},
+		{
+			name:          "Set map field with int values invalid JSON",
+			secretName:    "CLIENTSLOWCALLTHRESHOLDSMS",
+			secretValue:   `{"llm:9003":"slow"}`,
+			setupConfig:   func() *Config { return &Config{} },
+			expectError:   true,
+			errorContains: "json.Unmarshal map[string]int",
+		},
 		// Non-matching secret name
 		{
 			name:        "Non-matching secret name leaves config unchanged",
@@ -0,0 +1,221 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Roles of a DatabaseConnection
+const (
+	ConnectionRoleRead      = "read"
+	ConnectionRoleWrite     = "write"
+	ConnectionRoleReadWrite = "readwrite"
+)
+
+// DefaultConnectionName is the name of the connection created from the single address settings
+const DefaultConnectionName = "default"
+
+// EncryptedConnectionName is the name of the connections routed through the data-shield proxy
+const EncryptedConnectionName = "encrypted"
+
+// DatabaseConnections is a list of connections to routed GraphDB or Qdrant endpoints.
+type DatabaseConnections []DatabaseConnection
+
+// GraphDbConnections returns the GraphDB connections of the configuration
+// If GRAPHDB_CONNECTIONS is empty, a single read-write connection to GRAPHDB_ADDRESS is returned.
+//
+// Parameters:
+//   - config: The configuration.
+//
+// Returns:
+//   - connections: The GraphDB connections, empty if no GraphDB is configured.
+func GraphDbConnections(config *Config) (connections DatabaseConnections) {
+	if len(config.GRAPHDB_CONNECTIONS) > 0 {
+		return config.GRAPHDB_CONNECTIONS
+	}
+	if config.GRAPHDB_ADDRESS == "" {
+		return nil
+	}
+	return DatabaseConnections{{NAME: DefaultConnectionName, ADDRESS: config.GRAPHDB_ADDRESS, API_KEY: config.GRAPHDB_API_KEY}}
+}
+
+// QdrantConnections returns the Qdrant connections of the configuration
+// If QDRANT_CONNECTIONS is empty, a single read-write connection to QDRANT_HOST and QDRANT_PORT is returned.
+//
+// Parameters:
+//   - config: The configuration.
+//
+// Returns:
+//   - connections: The Qdrant connections, empty if no Qdrant is configured.
+func QdrantConnections(config *Config) (connections DatabaseConnections) {
+	if len(config.QDRANT_CONNECTIONS) > 0 {
+		return config.QDRANT_CONNECTIONS
+	}
+	if config.QDRANT_HOST == "" {
+		return nil
+	}
+	address := config.QDRANT_HOST
+	if config.QDRANT_PORT != 0 {
+		address += ":" + strconv.Itoa(config.QDRANT_PORT)
+	}
+	return DatabaseConnections{{NAME: DefaultConnectionName, ADDRESS: address, API_KEY: config.QDRANT_API_KEY}}
+}
+
+// EncryptedGraphDbConnection returns the connection to the GraphDB data-shield proxy
+// The proxy serves the GraphDB API with encrypted response payloads; the API key of GRAPHDB_API_KEY is forwarded.
+//
+// Parameters:
+//   - config: The configuration.
+//
+// Returns:
+//   - connection: The proxy connection.
+//   - ok: False if GRAPHDB_ADDRESS_ENCRYPTED is not set.
+func EncryptedGraphDbConnection(config *Config) (connection DatabaseConnection, ok bool) {
+	if config.GRAPHDB_ADDRESS_ENCRYPTED == "" {
+		return DatabaseConnection{}, false
+	}
+	return DatabaseConnection{NAME: EncryptedConnectionName, ADDRESS: config.GRAPHDB_ADDRESS_ENCRYPTED, API_KEY: config.GRAPHDB_API_KEY}, true
+}
+
+// EncryptedQdrantConnection returns the connection to the Qdrant data-shield proxy
+// The proxy serves the Qdrant API with encrypted response payloads; the API key of QDRANT_API_KEY is forwarded.
+//
+// Parameters:
+//   - config: The configuration.
+//
+// Returns:
+//   - connection: The proxy connection, with QDRANT_HOST_ENCRYPTED and QDRANT_PORT_ENCRYPTED as address.
+//   - ok: False if QDRANT_HOST_ENCRYPTED is not set.
+func EncryptedQdrantConnection(config *Config) (connection DatabaseConnection, ok bool) {
+	if config.QDRANT_HOST_ENCRYPTED == "" {
+		return DatabaseConnection{}, false
+	}
+	address := config.QDRANT_HOST_ENCRYPTED
+	if config.QDRANT_PORT_ENCRYPTED != 0 {
+		address += ":" + strconv.Itoa(config.QDRANT_PORT_ENCRYPTED)
+	}
+	return DatabaseConnection{NAME: EncryptedConnectionName, ADDRESS: address, API_KEY: config.QDRANT_API_KEY}, true
+}
+
+// Validate checks that the connections have an address, a known role and unique names.
+//
+// Returns:
+//   - err: An error describing the first invalid connection.
+func (connections DatabaseConnections) Validate() (err error) {
+	names := map[string]bool{}
+	for i, connection := range connections {
+		if connection.ADDRESS == "" {
+			return fmt.Errorf("connection %d '%v' has no address", i, connection.NAME)
+		}
+		switch connection.ROLE {
+		case "", ConnectionRoleRead, ConnectionRoleWrite, ConnectionRoleReadWrite:
+		default:
+			return fmt.Errorf("connection %d '%v' has invalid role '%v'", i, connection.NAME, connection.ROLE)
+		}
+		if connection.NAME != "" {
+			if names[connection.NAME] {
+				return fmt.Errorf("connection name '%v' is used more than once", connection.NAME)
+			}
+			names[connection.NAME] = true
+		}
+	}
+	return nil
+}
+
+// ByName returns the connection with the given name.
+//
+// Parameters:
+//   - name: The name of the connection.
+//
+// Returns:
+//   - connection: The connection.
+//   - err: An error if no connection has this name.
+func (connections DatabaseConnections) ByName(name string) (connection DatabaseConnection, err error) {
+	for _, connection := range connections {
+		if connection.NAME == name {
+			return connection, nil
+		}
+	}
+	return DatabaseConnection{}, fmt.Errorf("no database connection named '%v'", name)
+}
+
+// Route returns the connection serving a database or collection
+// Among the connections allowing the operation, the one with the longest prefix of the name is chosen.
+// If no prefix matches, the first connection without prefixes is chosen.
+//
+// Parameters:
+//   - name: The name of the database or collection.
+//   - write: True for write operations, false for read operations.
+//
+// Returns:
+//   - connection: The connection.
+//   - err: An error if no connection serves the database or collection.
+func (connections DatabaseConnections) Route(name string, write bool) (connection DatabaseConnection, err error) {
+	found := false
+	matched := -1
+	for _, candidate := range connections {
+		if !candidate.allows(write) {
+			continue
+		}
+		if len(candidate.PREFIXES) == 0 {
+			if !found {
+				connection, found = candidate, true
+			}
+			continue
+		}
+		for _, prefix := range candidate.PREFIXES {
+			if strings.HasPrefix(name, prefix) && len(prefix) > matched {
+				connection, found, matched = candidate, true, len(prefix)
+			}
+		}
+	}
+	if !found {
+		operation := "read"
+		if write {
+			operation = "write"
+		}
+		return DatabaseConnection{}, fmt.Errorf("no database connection to %v '%v'", operation, name)
+	}
+	return connection, nil
+}
+
+// allows checks if the role of the connection allows an operation.
+//
+// Parameters:
+//   - write: True for write operations, false for read operations.
+//
+// Returns:
+//   - bool: True if the operation is allowed.
+func (connection DatabaseConnection) allows(write bool) bool {
+	switch connection.ROLE {
+	case ConnectionRoleRead:
+		return !write
+	case ConnectionRoleWrite:
+		return write
+	default:
+		return true
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDatabaseConnectionsRoute(t *testing.T) {
+	connections := DatabaseConnections{
+		{NAME: "main", ADDRESS: "http://main:8080"},
+		{NAME: "products", ADDRESS: "http://products:8080", PREFIXES: []string{"product_"}},
+		{NAME: "fluent", ADDRESS: "http://fluent:8080", PREFIXES: []string{"product_fluent_"}},
+		{NAME: "replica", ADDRESS: "http://replica:8080", ROLE: ConnectionRoleRead, PREFIXES: []string{"docs_"}},
+		{NAME: "ingest", ADDRESS: "http://ingest:8080", ROLE: ConnectionRoleWrite, PREFIXES: []string{"docs_"}},
+	}
+
+	tests := []struct {
+		name  string
+		db    string
+		write bool
+		want  string
+	}{
+		{"default connection", "other", false, "main"},
+		{"prefix", "product_mechanical", false, "products"},
+		{"longest prefix", "product_fluent_v2", true, "fluent"},
+		{"read role", "docs_api", false, "replica"},
+		{"write role", "docs_api", true, "ingest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connection, err := connections.Route(tt.db, tt.write)
+			if err != nil {
+				t.Fatalf("Route(%q, %v) error = %v", tt.db, tt.write, err)
+			}
+			if connection.NAME != tt.want {
+				t.Errorf("Route(%q, %v) = %q, want %q", tt.db, tt.write, connection.NAME, tt.want)
+			}
+		})
+	}
+
+	readOnly := DatabaseConnections{{NAME: "replica", ADDRESS: "http://replica:8080", ROLE: ConnectionRoleRead}}
+	if _, err := readOnly.Route("docs", true); err == nil || !strings.Contains(err.Error(), "no database connection to write 'docs'") {
+		t.Errorf("Route() on read-only connections error = %v", err)
+	}
+	prefixed := DatabaseConnections{{NAME: "products", ADDRESS: "http://products:8080", PREFIXES: []string{"product_"}}}
+	if _, err := prefixed.Route("docs", false); err == nil {
+		t.Error("Route() without matching prefix and default connection returned no error")
+	}
+}
+
+func TestDatabaseConnectionsByName(t *testing.T) {
+	connections := DatabaseConnections{{NAME: "main", ADDRESS: "http://main:8080"}, {NAME: "eu", ADDRESS: "http://eu:8080"}}
+	connection, err := connections.ByName("eu")
+	if err != nil || connection.ADDRESS != "http://eu:8080" {
+		t.Errorf("ByName(eu) = %+v, %v", connection, err)
+	}
+	if _, err := connections.ByName("us"); err == nil {
+		t.Error("ByName(us) returned no error")
+	}
+}
+
+func TestDatabaseConnectionsValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		connections DatabaseConnections
+		wantErr     string
+	}{
+		{"valid", DatabaseConnections{{NAME: "a", ADDRESS: "x", ROLE: ConnectionRoleReadWrite}, {ADDRESS: "y"}, {ADDRESS: "z"}}, ""},
+		{"missing address", DatabaseConnections{{NAME: "a"}}, "has no address"},
+		{"invalid role", DatabaseConnections{{NAME: "a", ADDRESS: "x", ROLE: "admin"}}, "invalid role 'admin'"},
+		{"duplicate name", DatabaseConnections{{NAME: "a", ADDRESS: "x"}, {NAME: "a", ADDRESS: "y"}}, "used more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.connections.Validate()
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDatabaseConnectionsFromConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *Config
+		graphDb DatabaseConnections
+		qdrant  DatabaseConnections
+	}{
+		{
+			name:   "not configured",
+			config: &Config{},
+		},
+		{
+			name:    "single address settings",
+			config:  &Config{GRAPHDB_ADDRESS: "http://graphdb:8080", GRAPHDB_API_KEY: "g", QDRANT_HOST: "qdrant", QDRANT_PORT: 6334, QDRANT_API_KEY: "q"},
+			graphDb: DatabaseConnections{{NAME: DefaultConnectionName, ADDRESS: "http://graphdb:8080", API_KEY: "g"}},
+			qdrant:  DatabaseConnections{{NAME: DefaultConnectionName, ADDRESS: "qdrant:6334", API_KEY: "q"}},
+		},
+		{
+			name: "connection lists",
+			config: &Config{
+				GRAPHDB_ADDRESS:     "http://ignored:8080",
+				QDRANT_HOST:         "ignored",
+				GRAPHDB_CONNECTIONS: []DatabaseConnection{{NAME: "kb", ADDRESS: "http://kb:8080"}},
+				QDRANT_CONNECTIONS:  []DatabaseConnection{{NAME: "kb", ADDRESS: "kb:6334", ROLE: ConnectionRoleRead}},
+			},
+			graphDb: DatabaseConnections{{NAME: "kb", ADDRESS: "http://kb:8080"}},
+			qdrant:  DatabaseConnections{{NAME: "kb", ADDRESS: "kb:6334", ROLE: ConnectionRoleRead}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GraphDbConnections(tt.config); !reflect.DeepEqual(got, tt.graphDb) {
+				t.Errorf("GraphDbConnections() = %+v, want %+v", got, tt.graphDb)
+			}
+			if got := QdrantConnections(tt.config); !reflect.DeepEqual(got, tt.qdrant) {
+				t.Errorf("QdrantConnections() = %+v, want %+v", got, tt.qdrant)
+			}
+		})
+	}
+}
+
+func TestEncryptedConnections(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *Config
+		graphDb  DatabaseConnection
+		graphOk  bool
+		qdrant   DatabaseConnection
+		qdrantOk bool
+	}{
+		{
+			name:   "not configured",
+			config: &Config{GRAPHDB_ADDRESS: "http://graphdb:8080", QDRANT_HOST: "qdrant"},
+		},
+		{
+			name: "proxy addresses",
+			config: &Config{
+				GRAPHDB_ADDRESS_ENCRYPTED: "http://proxy:8081", GRAPHDB_API_KEY: "g",
+				QDRANT_HOST_ENCRYPTED: "proxy", QDRANT_PORT_ENCRYPTED: 8082, QDRANT_API_KEY: "q",
+			},
+			graphDb:  DatabaseConnection{NAME: EncryptedConnectionName, ADDRESS: "http://proxy:8081", API_KEY: "g"},
+			graphOk:  true,
+			qdrant:   DatabaseConnection{NAME: EncryptedConnectionName, ADDRESS: "proxy:8082", API_KEY: "q"},
+			qdrantOk: true,
+		},
+		{
+			name:     "qdrant host without port",
+			config:   &Config{QDRANT_HOST_ENCRYPTED: "proxy"},
+			qdrant:   DatabaseConnection{NAME: EncryptedConnectionName, ADDRESS: "proxy"},
+			qdrantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := EncryptedGraphDbConnection(tt.config)
+			if ok != tt.graphOk || !reflect.DeepEqual(got, tt.graphDb) {
+				t.Errorf("EncryptedGraphDbConnection() = %+v, %v, want %+v, %v", got, ok, tt.graphDb, tt.graphOk)
+			}
+			got, ok = EncryptedQdrantConnection(tt.config)
+			if ok != tt.qdrantOk || !reflect.DeepEqual(got, tt.qdrant) {
+				t.Errorf("EncryptedQdrantConnection() = %+v, %v, want %+v, %v", got, ok, tt.qdrant, tt.qdrantOk)
+			}
+		})
+	}
+}
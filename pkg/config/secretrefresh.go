@@ -0,0 +1,325 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SecretSource fetches config secrets by name, the name being the JSON tag of the Config field.
+type SecretSource interface {
+	FetchSecrets(ctx context.Context) (secrets map[string]string, err error)
+}
+
+// SecretChangeCallback is called after changed secrets have been published to Current.
+type SecretChangeCallback func(changed []string, oldConfig *Config, newConfig *Config)
+
+// SecretRefreshStats represents the state of a SecretRefresher.
+type SecretRefreshStats struct {
+	FetchFailures int64     // number of failed fetches since start
+	LastSuccess   time.Time // time of the last successful fetch
+	LastError     string    // error of the last failed fetch; empty if the last fetch succeeded
+}
+
+// SecretRefresher periodically fetches the config secrets and publishes a config with the changed values.
+//
+// No config is modified in place: changed secrets are applied to a copy of Current, which is then
+// published atomically, so readers of Current always see a consistent config.
+type SecretRefresher struct {
+	source       SecretSource
+	interval     time.Duration
+	onFetchError func(err error) // called on every failed fetch, see WithFetchErrorHandler
+
+	mu        sync.Mutex
+	values    map[string]string
+	callbacks []SecretChangeCallback
+	stats     SecretRefreshStats
+}
+
+// publishedConfig is the config published by secret refreshes; nil until the first refresh.
+var publishedConfig atomic.Pointer[Config]
+
+// publishMu serializes secret refreshes from reading Current to publishing the updated config.
+var publishMu sync.Mutex
+
+// publishListeners are the functions registered with OnConfigPublished.
+var publishListeners struct {
+	sync.Mutex
+	listeners []func(cfg *Config)
+}
+
+// OnConfigPublished registers a function called with Current after every secret refresh publishing
+// a config, including the first one, e.g. to update state derived from the secrets. Unlike the callbacks
+// registered with SecretRefresher.OnChange, it is called for the refreshes of all refreshers.
+//
+// Parameters:
+//   - listener: The function; it must not block.
+func OnConfigPublished(listener func(cfg *Config)) {
+	publishListeners.Lock()
+	defer publishListeners.Unlock()
+	publishListeners.listeners = append(publishListeners.listeners, listener)
+}
+
+// fetchErrorListeners are the functions registered with OnSecretFetchError.
+var fetchErrorListeners struct {
+	sync.Mutex
+	listeners []func(err error)
+}
+
+// OnSecretFetchError registers a function called on every failed secret refresh of all refreshers,
+// e.g. to send a metric. The logging package registers one sending the fetch failure metric.
+//
+// Parameters:
+//   - listener: The function; it must not block.
+func OnSecretFetchError(listener func(err error)) {
+	fetchErrorListeners.Lock()
+	defer fetchErrorListeners.Unlock()
+	fetchErrorListeners.listeners = append(fetchErrorListeners.listeners, listener)
+}
+
+// SecretRefresherOption configures a SecretRefresher.
+type SecretRefresherOption func(*SecretRefresher)
+
+// WithFetchErrorHandler sets a function called on every failed fetch of the refresher,
+// in addition to the listeners registered with OnSecretFetchError.
+//
+// Parameters:
+//   - handler: The function; it must not block.
+//
+// Returns:
+//   - SecretRefresherOption: The option.
+func WithFetchErrorHandler(handler func(err error)) SecretRefresherOption {
+	return func(r *SecretRefresher) {
+		r.onFetchError = handler
+	}
+}
+
+// Current returns the current config: the config published by the last secret refresh,
+// or GlobalConfig if no secrets have been refreshed. Code running after initialization must read
+// the config through Current, since GlobalConfig is not updated by secret refreshes.
+// The returned config must not be modified.
+//
+// Returns:
+//   - *Config: The current config.
+func Current() *Config {
+	if cfg := publishedConfig.Load(); cfg != nil {
+		return cfg
+	}
+	return GlobalConfig
+}
+
+// NewSecretRefresher creates a secret refresher.
+//
+// Parameters:
+//   - source: The secret source.
+//   - interval: The refresh interval.
+//   - opts: The options, e.g. WithFetchErrorHandler.
+//
+// Returns:
+//   - *SecretRefresher: The secret refresher.
+func NewSecretRefresher(source SecretSource, interval time.Duration, opts ...SecretRefresherOption) *SecretRefresher {
+	r := &SecretRefresher{
+		source:   source,
+		interval: interval,
+		values:   map[string]string{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// StartAzureKeyVaultRefresh starts refreshing the secrets from Azure Key Vault every AZURE_KEY_VAULT_REFRESH_SECONDS.
+//
+// Parameters:
+//   - ctx: The context; cancel it to stop refreshing.
+//   - opts: The options of the refresher, applied before the first refresh.
+//
+// Returns:
+//   - *SecretRefresher: The running secret refresher; nil if the refresh is disabled.
+//   - err: An error if the Key Vault cannot be accessed.
+func StartAzureKeyVaultRefresh(ctx context.Context, opts ...SecretRefresherOption) (*SecretRefresher, error) {
+	cfg := Current()
+	if !cfg.EXTRACT_CONFIG_FROM_AZURE_KEY_VAULT || cfg.AZURE_KEY_VAULT_REFRESH_SECONDS <= 0 {
+		return nil, nil
+	}
+	source, err := NewAzureKeyVaultSecretSource()
+	if err != nil {
+		return nil, err
+	}
+	refresher := NewSecretRefresher(source, time.Duration(cfg.AZURE_KEY_VAULT_REFRESH_SECONDS)*time.Second, opts...)
+	if _, err := refresher.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	go refresher.Run(ctx)
+	return refresher, nil
+}
+
+// OnChange registers a callback for changed secrets.
+//
+// Parameters:
+//   - callback: The callback.
+func (r *SecretRefresher) OnChange(callback SecretChangeCallback) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.callbacks = append(r.callbacks, callback)
+}
+
+// Run refreshes the secrets every interval until the context is cancelled.
+//
+// Parameters:
+//   - ctx: The context; cancel it to stop refreshing.
+func (r *SecretRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Refresh(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Error refreshing secrets: %v", err)
+			}
+		}
+	}
+}
+
+// Refresh fetches the secrets and publishes a config with the changed values, see Current.
+// The first refresh applies all secrets without calling the change callbacks.
+//
+// Parameters:
+//   - ctx: The context of the fetch.
+//
+// Returns:
+//   - changed: The names of the changed secrets, sorted.
+//   - err: An error if the fetch fails or a value cannot be applied; the config is unchanged in this case.
+func (r *SecretRefresher) Refresh(ctx context.Context) (changed []string, err error) {
+	secrets, err := r.source.FetchSecrets(ctx)
+	if err != nil {
+		r.recordFailure(err)
+		return nil, err
+	}
+
+	// the lock is held from comparing the secrets to publishing the config, so concurrent
+	// refreshes cannot publish a config derived from an outdated one
+	publishMu.Lock()
+	r.mu.Lock()
+	initial := r.stats.LastSuccess.IsZero()
+	for name, value := range secrets {
+		if previous, ok := r.values[name]; !ok || previous != value {
+			changed = append(changed, name)
+		}
+	}
+	callbacks := append([]SecretChangeCallback{}, r.callbacks...)
+	r.mu.Unlock()
+	sort.Strings(changed)
+
+	var oldConfig, newConfig *Config
+	if len(changed) > 0 {
+		oldConfig = Current()
+		updated := *oldConfig
+		for _, name := range changed {
+			if err := applySecretValueToConfig(&updated, name, secrets[name]); err != nil {
+				publishMu.Unlock()
+				r.recordFailure(err)
+				return nil, err
+			}
+		}
+		newConfig = &updated
+		publishedConfig.Store(newConfig)
+	}
+
+	r.mu.Lock()
+	r.values = secrets
+	r.stats.LastSuccess = time.Now()
+	r.stats.LastError = ""
+	r.mu.Unlock()
+	publishMu.Unlock()
+
+	if newConfig != nil {
+		publishListeners.Lock()
+		listeners := append([]func(cfg *Config){}, publishListeners.listeners...)
+		publishListeners.Unlock()
+		// Current rather than newConfig, so a concurrent refresh cannot leave a listener on an older config
+		for _, listener := range listeners {
+			listener(Current())
+		}
+	}
+	if initial || len(changed) == 0 {
+		return changed, nil
+	}
+	for _, callback := range callbacks {
+		callback(changed, oldConfig, newConfig)
+	}
+	return changed, nil
+}
+
+// Secret returns the cached value of a secret.
+//
+// Parameters:
+//   - name: The name of the secret.
+//
+// Returns:
+//   - value: The cached value.
+//   - ok: False if the secret has not been fetched.
+func (r *SecretRefresher) Secret(name string) (value string, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok = r.values[name]
+	return value, ok
+}
+
+// Stats returns the fetch statistics.
+//
+// Returns:
+//   - SecretRefreshStats: The statistics.
+func (r *SecretRefresher) Stats() SecretRefreshStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// recordFailure counts a failed fetch and calls the fetch error handler and listeners.
+func (r *SecretRefresher) recordFailure(err error) {
+	r.mu.Lock()
+	r.stats.FetchFailures++
+	r.stats.LastError = err.Error()
+	r.mu.Unlock()
+
+	err = fmt.Errorf("error refreshing secrets: %w", err)
+	if r.onFetchError != nil {
+		r.onFetchError(err)
+	}
+	fetchErrorListeners.Lock()
+	listeners := append([]func(err error){}, fetchErrorListeners.listeners...)
+	fetchErrorListeners.Unlock()
+	for _, listener := range listeners {
+		listener(err)
+	}
+}
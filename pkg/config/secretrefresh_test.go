@@ -0,0 +1,180 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// fakeSecretSource returns the configured secrets or error.
+type fakeSecretSource struct {
+	mu      sync.Mutex
+	secrets map[string]string
+	err     error
+}
+
+func (s *fakeSecretSource) FetchSecrets(ctx context.Context) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	secrets := map[string]string{}
+	for name, value := range s.secrets {
+		secrets[name] = value
+	}
+	return secrets, nil
+}
+
+// TestSecretRefresher tests applying changed secrets, change callbacks and failure statistics
+func TestSecretRefresher(t *testing.T) {
+	previous := GlobalConfig
+	defer func() { GlobalConfig = previous; publishedConfig.Store(nil) }()
+	GlobalConfig = &Config{LOG_LEVEL: "info"}
+
+	source := &fakeSecretSource{secrets: map[string]string{"LLMAPIKEY": "key-1", "KVDBAPIKEY": "kvdb-1"}}
+	var failures []error
+	refresher := NewSecretRefresher(source, 0, WithFetchErrorHandler(func(err error) { failures = append(failures, err) }))
+	var callbackChanged []string
+	var callbackOld, callbackNew *Config
+	refresher.OnChange(func(changed []string, oldConfig *Config, newConfig *Config) {
+		callbackChanged, callbackOld, callbackNew = changed, oldConfig, newConfig
+	})
+
+	// the first refresh applies all secrets without calling the callbacks
+	changed, err := refresher.Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if !reflect.DeepEqual(changed, []string{"KVDBAPIKEY", "LLMAPIKEY"}) {
+		t.Errorf("Refresh() changed = %v", changed)
+	}
+	if Current().LLM_API_KEY != "key-1" || Current().KVDB_API_KEY != "kvdb-1" || Current().LOG_LEVEL != "info" {
+		t.Errorf("Current() not updated: %+v", Current())
+	}
+	if GlobalConfig.LLM_API_KEY != "" {
+		t.Error("GlobalConfig was modified")
+	}
+	if callbackChanged != nil {
+		t.Errorf("callback called on first refresh with %v", callbackChanged)
+	}
+
+	// a rotated secret publishes a new config and calls the callbacks
+	beforeRotation := Current()
+	source.secrets["LLMAPIKEY"] = "key-2"
+	changed, err = refresher.Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if !reflect.DeepEqual(changed, []string{"LLMAPIKEY"}) || !reflect.DeepEqual(callbackChanged, changed) {
+		t.Errorf("Refresh() changed = %v, callback changed = %v", changed, callbackChanged)
+	}
+	if callbackOld != beforeRotation || callbackNew != Current() || Current().LLM_API_KEY != "key-2" {
+		t.Errorf("callback configs or Current() not as expected")
+	}
+	if beforeRotation.LLM_API_KEY != "key-1" {
+		t.Error("previous config was modified in place")
+	}
+	if value, _ := refresher.Secret("LLMAPIKEY"); value != "key-2" {
+		t.Errorf("Secret() = %q, want key-2", value)
+	}
+
+	// a failed fetch keeps the config and is counted
+	source.err = errors.New("vault unavailable")
+	if _, err := refresher.Refresh(context.Background()); err == nil {
+		t.Fatal("Refresh() with failing source: expected error")
+	}
+	stats := refresher.Stats()
+	if stats.FetchFailures != 1 || stats.LastError != "vault unavailable" || len(failures) != 1 {
+		t.Errorf("Stats() = %+v, failures = %v", stats, failures)
+	}
+	if Current().LLM_API_KEY != "key-2" {
+		t.Error("Current() changed by failed refresh")
+	}
+
+	// an invalid value is rejected without changing the config
+	source.err = nil
+	source.secrets["NUMBEROFWORKFLOWWORKERS"] = "many"
+	if _, err := refresher.Refresh(context.Background()); err == nil {
+		t.Fatal("Refresh() with invalid value: expected error")
+	}
+	if refresher.Stats().FetchFailures != 2 {
+		t.Errorf("FetchFailures = %d, want 2", refresher.Stats().FetchFailures)
+	}
+}
+
+// TestSecretRefresherConcurrentRefreshes tests that concurrent refreshes of different secrets keep all changes
+func TestSecretRefresherConcurrentRefreshes(t *testing.T) {
+	previous := GlobalConfig
+	defer func() { GlobalConfig = previous; publishedConfig.Store(nil) }()
+	GlobalConfig = &Config{}
+
+	llm := NewSecretRefresher(&fakeSecretSource{secrets: map[string]string{"LLMAPIKEY": "llm"}}, 0)
+	kvdb := NewSecretRefresher(&fakeSecretSource{secrets: map[string]string{"KVDBAPIKEY": "kvdb"}}, 0)
+	var wg sync.WaitGroup
+	for _, refresher := range []*SecretRefresher{llm, kvdb} {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := refresher.Refresh(context.Background()); err != nil {
+				t.Errorf("Refresh() error = %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_ = Current().LLM_API_KEY
+		}()
+	}
+	wg.Wait()
+
+	if cfg := Current(); cfg.LLM_API_KEY != "llm" || cfg.KVDB_API_KEY != "kvdb" {
+		t.Errorf("Current() = %+v, want both refreshed secrets", cfg)
+	}
+}
+
+// TestOnSecretFetchError tests that the registered listeners are called for failed refreshes of all refreshers
+func TestOnSecretFetchError(t *testing.T) {
+	fetchErrorListeners.Lock()
+	previous := fetchErrorListeners.listeners
+	fetchErrorListeners.Unlock()
+	t.Cleanup(func() {
+		fetchErrorListeners.Lock()
+		fetchErrorListeners.listeners = previous
+		fetchErrorListeners.Unlock()
+	})
+
+	var failures []error
+	OnSecretFetchError(func(err error) { failures = append(failures, err) })
+	vaultErr := errors.New("vault unavailable")
+	refresher := NewSecretRefresher(&fakeSecretSource{err: vaultErr}, 0)
+	if _, err := refresher.Refresh(context.Background()); !errors.Is(err, vaultErr) {
+		t.Fatalf("Refresh() error = %v, want %v", err, vaultErr)
+	}
+	if len(failures) != 1 || !errors.Is(failures[0], vaultErr) {
+		t.Errorf("listener failures = %v, want one wrapping %v", failures, vaultErr)
+	}
+}
@@ -45,6 +45,8 @@ type Config struct {
 	LOGGING_URL         string `yaml:"LOGGING_URL" json:"LOGGINGURL"`
 	LOGGING_API_KEY     string `yaml:"LOGGING_API_KEY" json:"LOGGINGAPIKEY"`
 	DATADOG_SOURCE      string `yaml:"DATADOG_SOURCE" json:"DATADOGSOURCE"`
+	// Log Redaction
+	LOG_REDACTION_PATTERNS []string `yaml:"LOG_REDACTION_PATTERNS" json:"LOGREDACTIONPATTERNS"` // Additional regular expressions whose matches are redacted from log output
 	// Datadog Metrics
 	DATADOG_METRICS bool   `yaml:"DATADOG_METRICS" json:"DATADOGMETRICS"`
 	METRICS_URL     string `yaml:"METRICS_URL" json:"METRICSURL"`
@@ -60,6 +62,11 @@ type Config struct {
 	EXTRACT_CONFIG_FROM_AZURE_KEY_VAULT bool   `yaml:"EXTRACT_CONFIG_FROM_AZURE_KEY_VAULT" json:"EXTRACTCONFIGFROMAZUREKEYVAULT"`
 	AZURE_KEY_VAULT_NAME                string `yaml:"AZURE_KEY_VAULT_NAME" json:"AZUREKEYVAULTNAME"`
 	AZURE_MANAGED_IDENTITY_ID           string `yaml:"AZURE_MANAGED_IDENTITY_ID" json:"AZUREMANAGEDIDENTITYID"`
+	AZURE_KEY_VAULT_REFRESH_SECONDS     int    `yaml:"AZURE_KEY_VAULT_REFRESH_SECONDS" json:"AZUREKEYVAULTREFRESHSECONDS"` // Interval of refreshing the secrets from Azure Key Vault; no refresh if 0
+
+	// Feature Flags
+	//////////////////
+	FEATURE_FLAGS map[string]string `yaml:"FEATURE_FLAGS" json:"FEATUREFLAGS"` // Feature flag states by flag name: a plain value, e.g. "true", or a JSON featureflags.State
 
 	// Aali Chat
 	///////////////
@@ -76,16 +83,47 @@ type Config struct {
 	DISABLE_WORKFLOW_RUN_REST_API              bool `yaml:"DISABLE_WORKFLOW_RUN_REST_API" json:"DISABLEWORKFLOWRUNRESTAPI"`
 	ENFORCE_WORKFLOW_API_KEY_FOR_WORKFLOW_RUNS bool `yaml:"ENFORCE_WORKFLOW_API_KEY_FOR_WORKFLOW_RUNS" json:"ENFORCEWORKFLOWAPIKEYFORWORKFLOWRUNS"`
 	// Workflow Files
-	WORKFLOW_STORE_PATH       string   `yaml:"WORKFLOW_STORE_PATH" json:"WORKFLOWSTOREPATH"`
-	BINARY_STORE_PATH         string   `yaml:"BINARY_STORE_PATH" json:"BINARYSTOREPATH"`
-	DISABLE_PUBLIC_WORKFLOWS  bool     `yaml:"DISABLE_PUBLIC_WORKFLOWS" json:"DISABLEPUBLICWORKFLOWS"`
-	LOAD_PRIVATE_WORKFLOWS    bool     `yaml:"LOAD_PRIVATE_WORKFLOWS" json:"LOADPRIVATEWORKFLOWS"`
-	GITHUB_USER               string   `yaml:"GITHUB_USER" json:"GITHUBUSER"`
-	GITHUB_TOKEN              string   `yaml:"GITHUB_TOKEN" json:"GITHUBTOKEN"`
-	PRIVATE_WORKFLOWS_FOLDERS []string `yaml:"PRIVATE_WORKFLOWS_FOLDERS" json:"PRIVATEWORKFLOWSFOLDERS"`
+	WORKFLOW_STORE_PATH          string   `yaml:"WORKFLOW_STORE_PATH" json:"WORKFLOWSTOREPATH"`
+	BINARY_STORE_PATH            string   `yaml:"BINARY_STORE_PATH" json:"BINARYSTOREPATH"`
+	DISABLE_PUBLIC_WORKFLOWS     bool     `yaml:"DISABLE_PUBLIC_WORKFLOWS" json:"DISABLEPUBLICWORKFLOWS"`
+	LOAD_PRIVATE_WORKFLOWS       bool     `yaml:"LOAD_PRIVATE_WORKFLOWS" json:"LOADPRIVATEWORKFLOWS"`
+	GITHUB_USER                  string   `yaml:"GITHUB_USER" json:"GITHUBUSER"`
+	GITHUB_TOKEN                 string   `yaml:"GITHUB_TOKEN" json:"GITHUBTOKEN"`
+	PRIVATE_WORKFLOWS_FOLDERS    []string `yaml:"PRIVATE_WORKFLOWS_FOLDERS" json:"PRIVATEWORKFLOWSFOLDERS"`
+	PRIVATE_WORKFLOWS_REPOSITORY string   `yaml:"PRIVATE_WORKFLOWS_REPOSITORY" json:"PRIVATEWORKFLOWSREPOSITORY"` // URL of the Git repository of the private workflows
+	WORKFLOW_PACKAGE_PUBLIC_KEYS []string `yaml:"WORKFLOW_PACKAGE_PUBLIC_KEYS" json:"WORKFLOWPACKAGEPUBLICKEYS"`  // Base64 encoded Ed25519 public keys trusted to sign workflow packages
 	// Flowkit Connection
 	FLOWKIT_CONNECTIONS        []FlowkitConnection `yaml:"FLOWKIT_CONNECTIONS" json:"FLOWKITCONNECTIONS"`              // Contains the URL and API key for the FlowKit server
 	FLOWKIT_PYTHON_CONNECTIONS []FlowkitConnection `yaml:"FLOWKIT_PYTHON_CONNECTIONS" json:"FLOWKITPYTHONCONNECTIONS"` // Contains the URL and API key for the FlowKit-Python server
+	// Flowkit Value Compression
+	FLOWKIT_COMPRESSION                 string `yaml:"FLOWKIT_COMPRESSION" json:"FLOWKITCOMPRESSION"`                               // Compression of large function values: "gzip", "zstd" or empty to disable
+	FLOWKIT_COMPRESSION_THRESHOLD_BYTES int    `yaml:"FLOWKIT_COMPRESSION_THRESHOLD_BYTES" json:"FLOWKITCOMPRESSIONTHRESHOLDBYTES"` // Minimum size of a value to be compressed; defaults to 1 MiB
+	// Embedding Encoding
+	EMBEDDING_ENCODING string `yaml:"EMBEDDING_ENCODING" json:"EMBEDDINGENCODING"` // JSON encoding of the embeddings of DbData and DbResponse: "json" or "base64"; applied with typeconverters.SetEmbeddingEncodingFromConfig, defaults to "json"
+	// Flowkit Result Cache
+	FLOWKIT_RESULT_CACHE_TTL_SECONDS int `yaml:"FLOWKIT_RESULT_CACHE_TTL_SECONDS" json:"FLOWKITRESULTCACHETTLSECONDS"` // Time to live of cached results of idempotent functions; 0 disables the cache
+	FLOWKIT_RESULT_CACHE_MAX_ENTRIES int `yaml:"FLOWKIT_RESULT_CACHE_MAX_ENTRIES" json:"FLOWKITRESULTCACHEMAXENTRIES"` // Maximum number of cached results; defaults to 1000
+	// Flowkit Function Timeout
+	FLOWKIT_FUNCTION_TIMEOUT_SECONDS int `yaml:"FLOWKIT_FUNCTION_TIMEOUT_SECONDS" json:"FLOWKITFUNCTIONTIMEOUTSECONDS"` // Default timeout of function calls without timeout in their definition; 0 means no timeout
+	// Flowkit Catalog Snapshot
+	FLOWKIT_CATALOG_MAX_AGE_HOURS int `yaml:"FLOWKIT_CATALOG_MAX_AGE_HOURS" json:"FLOWKITCATALOGMAXAGEHOURS"` // Age of an imported function catalog above which a staleness warning is logged; defaults to 24
+	// Client Call Timing
+	CLIENT_SLOW_CALL_THRESHOLD_MS  int            `yaml:"CLIENT_SLOW_CALL_THRESHOLD_MS" json:"CLIENTSLOWCALLTHRESHOLDMS"`   // Duration of a gRPC/HTTP call above which a warning is logged; defaults to 5000, negative disables the warning
+	CLIENT_SLOW_CALL_THRESHOLDS_MS map[string]int `yaml:"CLIENT_SLOW_CALL_THRESHOLDS_MS" json:"CLIENTSLOWCALLTHRESHOLDSMS"` // Slow call thresholds of specific targets, keyed by host:port
+	// gRPC Keepalive
+	GRPC_KEEPALIVE_TIME_SECONDS          int  `yaml:"GRPC_KEEPALIVE_TIME_SECONDS" json:"GRPCKEEPALIVETIMESECONDS"`                  // Interval of keepalive pings on idle gRPC connections; 0 disables keepalive pings
+	GRPC_KEEPALIVE_TIMEOUT_SECONDS       int  `yaml:"GRPC_KEEPALIVE_TIMEOUT_SECONDS" json:"GRPCKEEPALIVETIMEOUTSECONDS"`            // Time to wait for a keepalive ping acknowledgement before closing the connection; defaults to 20
+	GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM bool `yaml:"GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM" json:"GRPCKEEPALIVEPERMITWITHOUTSTREAM"` // If true, keepalive pings are also sent without active streams
+	// Agent Stream Reconnect
+	AGENT_STREAM_RECONNECT_ATTEMPTS   int `yaml:"AGENT_STREAM_RECONNECT_ATTEMPTS" json:"AGENTSTREAMRECONNECTATTEMPTS"`    // Reconnect attempts of a broken WorkflowRun stream; defaults to 5, negative disables reconnecting
+	AGENT_STREAM_RECONNECT_BACKOFF_MS int `yaml:"AGENT_STREAM_RECONNECT_BACKOFF_MS" json:"AGENTSTREAMRECONNECTBACKOFFMS"` // Wait time before the first reconnect, doubled for every further attempt; defaults to 500
+	// Startup Dependency Wait
+	STARTUP_WAIT_TIMEOUT_SECONDS int `yaml:"STARTUP_WAIT_TIMEOUT_SECONDS" json:"STARTUPWAITTIMEOUTSECONDS"` // Maximum time clients.WaitForDependencies waits for the dependencies; defaults to 120
+	STARTUP_WAIT_BACKOFF_MS      int `yaml:"STARTUP_WAIT_BACKOFF_MS" json:"STARTUPWAITBACKOFFMS"`           // Wait time before the second probe of a dependency, doubled for every further probe up to 10 seconds; defaults to 500
+	// gRPC Message Size
+	GRPC_MAX_SEND_MESSAGE_BYTES        int `yaml:"GRPC_MAX_SEND_MESSAGE_BYTES" json:"GRPCMAXSENDMESSAGEBYTES"`              // Maximum size of a sent gRPC message; defaults to 1 GiB
+	GRPC_MAX_RECV_MESSAGE_BYTES        int `yaml:"GRPC_MAX_RECV_MESSAGE_BYTES" json:"GRPCMAXRECVMESSAGEBYTES"`              // Maximum size of a received gRPC message; defaults to 1 GiB
+	GRPC_MESSAGE_SIZE_SOFT_LIMIT_BYTES int `yaml:"GRPC_MESSAGE_SIZE_SOFT_LIMIT_BYTES" json:"GRPCMESSAGESIZESOFTLIMITBYTES"` // Size of a gRPC message above which a metric and a warning are emitted; defaults to 16 MiB, negative disables them
 	// External Function Endpoints (Legacy)
 	EXTERNALFUNCTIONS_ENDPOINT string `yaml:"EXTERNALFUNCTIONS_ENDPOINT" json:"EXTERNALFUNCTIONSENDPOINT"`
 	FLOWKIT_PYTHON_ENDPOINT    string `yaml:"FLOWKIT_PYTHON_ENDPOINT" json:"FLOWKITPYTHONENDPOINT"`
@@ -104,6 +142,8 @@ type Config struct {
 	ENABLE_AUTH                            bool     `yaml:"ENABLE_AUTH" json:"ENABLEAUTH"` // If true, the agent will require authentication/authorization for workflows
 	AZURE_AD_AUTHENTICATION_URL            string   `yaml:"AZURE_AD_AUTHENTICATION_URL" json:"AZUREADAUTHENTICATIONURL"`
 	AZURE_AD_AUTHENTICATION_URLS           []string `yaml:"AZURE_AD_AUTHENTICATION_URLS" json:"AZUREADAUTHENTICATIONURLS"` // List of Azure AD authentication URLs for multi-tenant support; overwrites AZURE_AD_AUTHENTICATION_URL if provided
+	AZURE_AD_TOKEN_ISSUERS                 []string `yaml:"AZURE_AD_TOKEN_ISSUERS" json:"AZUREADTOKENISSUERS"`             // Accepted issuers of Azure AD tokens; required for token authentication
+	AZURE_AD_TOKEN_AUDIENCES               []string `yaml:"AZURE_AD_TOKEN_AUDIENCES" json:"AZUREADTOKENAUDIENCES"`         // Accepted audiences of Azure AD tokens, e.g. the application ID URI; required for token authentication
 	ANSYS_AUTHORIZATION_URL                string   `yaml:"ANSYS_AUTHORIZATION_URL" json:"ANSYSAUTHORIZATIONURL"`
 	ANSYS_GATING_AND_ENTITLEMENT_URL       string   `yaml:"ANSYS_GATING_AND_ENTITLEMENT_URL" json:"ANSYSGATINGANDENTITLEMENTURL"`
 	ANSYS_AUTHORIZATION_CRYPT_KEY          string   `yaml:"ANSYS_AUTHORIZATION_CRYPT_KEY" json:"ANSYSAUTHORIZATIONCRYPTKEY"`
@@ -111,6 +151,7 @@ type Config struct {
 	ANSYS_AUTHORIZATION_SECRET_KEY_2       string   `yaml:"ANSYS_AUTHORIZATION_SECRET_KEY_2" json:"ANSYSAUTHORIZATIONSECRETKEY2"`
 	ANSYS_AUTHORIZATION_SECRET_KEY_2_VALUE string   `yaml:"ANSYS_AUTHORIZATION_SECRET_KEY_2_VALUE" json:"ANSYSAUTHORIZATIONSECRETKEY2VALUE"`
 	ANSYS_AUTHORIZATION_ACCEPTED_PERSONAS  []int    `yaml:"ANSYS_AUTHORIZATION_ACCEPTED_PERSONAS" json:"ANSYSAUTHORIZATIONACCEPTEDPERSONAS"` // List of Ansys personas that are allowed to access the agent
+	ACCEPTED_API_KEYS                      []string `yaml:"ACCEPTED_API_KEYS" json:"ACCEPTEDAPIKEYS"`                                        // Additional API keys accepted by the server, as plain secrets or "sha256:<salt>:<hash>" hashes
 	ANSYS_DISCO_CRYPT_PRIVAT_KEY           string   `yaml:"ANSYS_DISCO_CRYPT_PRIVAT_KEY" json:"ANSYSDISCOCRYPTPRIVATKEY"`
 	ANSYS_DISOC_SIGN_PUBLIC_KEY            string   `yaml:"ANSYS_DISOC_SIGN_PUBLIC_KEY" json:"ANSYSDISOCSIGNPUBLICKEY"`
 	// OKTA Authentication URL
@@ -136,7 +177,8 @@ type Config struct {
 	WATCH_FOLDER_PATH              string `yaml:"WATCH_FOLDER_PATH" json:"WATCHFOLDERPATH"`
 	MILLISECONDS_SINCE_LAST_CHANGE int    `yaml:"MILLISECONDS_SINCE_LAST_CHANGE" json:"MILLISECONDSSINCELASTCHANGE"`
 	// Agent connection
-	AGENT_ENDPOINT string `yaml:"AGENT_ENDPOINT" json:"AGENTENDPOINT"`
+	AGENT_ENDPOINT      string `yaml:"AGENT_ENDPOINT" json:"AGENTENDPOINT"`
+	AGENT_REST_ENDPOINT string `yaml:"AGENT_REST_ENDPOINT" json:"AGENTRESTENDPOINT"` // REST endpoint of the agent used by agentrestclient; AGENT_ENDPOINT if empty
 
 	// Aali KVDB
 	/////////////////
@@ -157,9 +199,35 @@ type Config struct {
 	QDRANT_HOST           string `yaml:"QDRANT_HOST" json:"QDRANTHOST"`
 	QDRANT_PORT           int    `yaml:"QDRANT_PORT" json:"QDRANTPORT"`
 	QDRANT_API_KEY        string `yaml:"QDRANT_API_KEY" json:"QDRANTAPIKEY"`
+	// Knowledge Base Connections
+	GRAPHDB_CONNECTIONS []DatabaseConnection `yaml:"GRAPHDB_CONNECTIONS" json:"GRAPHDBCONNECTIONS"` // GraphDB endpoints with routing; GRAPHDB_ADDRESS is used if empty
+	QDRANT_CONNECTIONS  []DatabaseConnection `yaml:"QDRANT_CONNECTIONS" json:"QDRANTCONNECTIONS"`   // Qdrant endpoints with routing; QDRANT_HOST and QDRANT_PORT are used if empty
 	// Connections to external services
 	MONGODB_CS string `yaml:"MONGODB_CS" json:"MONGODBCS"`
 
+	// Object Storage
+	////////////////////
+	STORAGE_BACKEND              string `yaml:"STORAGE_BACKEND" json:"STORAGEBACKEND"`                        // "local" (default), "azureblob" or "s3"
+	STORAGE_LOCAL_PATH           string `yaml:"STORAGE_LOCAL_PATH" json:"STORAGELOCALPATH"`                   // Root directory of the local store; defaults to BINARY_STORE_PATH
+	STORAGE_AZURE_ACCOUNT_NAME   string `yaml:"STORAGE_AZURE_ACCOUNT_NAME" json:"STORAGEAZUREACCOUNTNAME"`    // Azure storage account name
+	STORAGE_AZURE_ACCOUNT_KEY    string `yaml:"STORAGE_AZURE_ACCOUNT_KEY" json:"STORAGEAZUREACCOUNTKEY"`      // Azure storage account key (base64)
+	STORAGE_AZURE_CONTAINER      string `yaml:"STORAGE_AZURE_CONTAINER" json:"STORAGEAZURECONTAINER"`         // Azure blob container
+	STORAGE_AZURE_ENDPOINT       string `yaml:"STORAGE_AZURE_ENDPOINT" json:"STORAGEAZUREENDPOINT"`           // Blob service URL; defaults to https://<account>.blob.core.windows.net
+	STORAGE_S3_BUCKET            string `yaml:"STORAGE_S3_BUCKET" json:"STORAGES3BUCKET"`                     // S3 bucket
+	STORAGE_S3_REGION            string `yaml:"STORAGE_S3_REGION" json:"STORAGES3REGION"`                     // S3 region, e.g. "eu-central-1"
+	STORAGE_S3_ENDPOINT          string `yaml:"STORAGE_S3_ENDPOINT" json:"STORAGES3ENDPOINT"`                 // S3 endpoint for S3 compatible stores; defaults to https://s3.<region>.amazonaws.com
+	STORAGE_S3_ACCESS_KEY_ID     string `yaml:"STORAGE_S3_ACCESS_KEY_ID" json:"STORAGES3ACCESSKEYID"`         // S3 access key ID
+	STORAGE_S3_SECRET_ACCESS_KEY string `yaml:"STORAGE_S3_SECRET_ACCESS_KEY" json:"STORAGES3SECRETACCESSKEY"` // S3 secret access key
+	STORAGE_S3_PATH_STYLE        bool   `yaml:"STORAGE_S3_PATH_STYLE" json:"STORAGES3PATHSTYLE"`              // If true, path-style URLs are used, e.g. for MinIO
+
+	// Rate Limiting
+	//////////////////
+	RATE_LIMIT_ENABLED             bool                `yaml:"RATE_LIMIT_ENABLED" json:"RATELIMITENABLED"`                       // If true, requests are limited per tenant (user or API key)
+	RATE_LIMIT_REQUESTS_PER_MINUTE int                 `yaml:"RATE_LIMIT_REQUESTS_PER_MINUTE" json:"RATELIMITREQUESTSPERMINUTE"` // Sustained request rate per tenant; 0 means unlimited
+	RATE_LIMIT_BURST               int                 `yaml:"RATE_LIMIT_BURST" json:"RATELIMITBURST"`                           // Maximum burst of requests per tenant; defaults to the requests per minute
+	RATE_LIMIT_MAX_CONCURRENT      int                 `yaml:"RATE_LIMIT_MAX_CONCURRENT" json:"RATELIMITMAXCONCURRENT"`          // Maximum concurrent requests per tenant; 0 means unlimited
+	RATE_LIMIT_TENANT_OVERRIDES    []RateLimitOverride `yaml:"RATE_LIMIT_TENANT_OVERRIDES" json:"RATELIMITTENANTOVERRIDES"`      // Limits for specific tenants, overriding the defaults above
+
 	// Aali Flowkit Python
 	//////////////////////
 	FLOWKIT_PYTHON_ADDRESS string `yaml:"FLOWKIT_PYTHON_ADDRESS" json:"FLOWKITPYTHONADDRESS"`
@@ -192,8 +260,28 @@ type Config struct {
 
 // FlowkitConnections contains the configuration for connecting to the FlowKit server.
 type FlowkitConnection struct {
-	URL     string `yaml:"URL" json:"URL"`        // URL of the FlowKit server
-	API_KEY string `yaml:"API_KEY" json:"APIKEY"` // API key for the FlowKit server
+	URL                           string `yaml:"URL" json:"URL"`                                                 // URL of the FlowKit server
+	API_KEY                       string `yaml:"API_KEY" json:"APIKEY"`                                          // API key for the FlowKit server
+	MAX_SEND_MESSAGE_BYTES        int    `yaml:"MAX_SEND_MESSAGE_BYTES" json:"MAXSENDMESSAGEBYTES"`              // Overrides GRPC_MAX_SEND_MESSAGE_BYTES for this server
+	MAX_RECV_MESSAGE_BYTES        int    `yaml:"MAX_RECV_MESSAGE_BYTES" json:"MAXRECVMESSAGEBYTES"`              // Overrides GRPC_MAX_RECV_MESSAGE_BYTES for this server
+	MESSAGE_SIZE_SOFT_LIMIT_BYTES int    `yaml:"MESSAGE_SIZE_SOFT_LIMIT_BYTES" json:"MESSAGESIZESOFTLIMITBYTES"` // Overrides GRPC_MESSAGE_SIZE_SOFT_LIMIT_BYTES for this server
+}
+
+// DatabaseConnection contains the configuration for connecting to one GraphDB or Qdrant endpoint.
+type DatabaseConnection struct {
+	NAME     string   `yaml:"NAME" json:"NAME"`         // Name of the connection, used for explicit routing
+	ADDRESS  string   `yaml:"ADDRESS" json:"ADDRESS"`   // URL of the GraphDB server or host:port of the Qdrant server
+	API_KEY  string   `yaml:"API_KEY" json:"APIKEY"`    // API key for the server
+	ROLE     string   `yaml:"ROLE" json:"ROLE"`         // "read", "write" or "readwrite" (default)
+	PREFIXES []string `yaml:"PREFIXES" json:"PREFIXES"` // Database or collection name prefixes routed to this connection; none for the default connection
+}
+
+// RateLimitOverride contains the rate limits of a specific tenant.
+type RateLimitOverride struct {
+	TENANT              string `yaml:"TENANT" json:"TENANT"`                         // User ID or API key ID of the tenant
+	REQUESTS_PER_MINUTE int    `yaml:"REQUESTS_PER_MINUTE" json:"REQUESTSPERMINUTE"` // Sustained request rate; 0 means unlimited
+	BURST               int    `yaml:"BURST" json:"BURST"`                           // Maximum burst of requests; defaults to the requests per minute
+	MAX_CONCURRENT      int    `yaml:"MAX_CONCURRENT" json:"MAXCONCURRENT"`          // Maximum concurrent requests; 0 means unlimited
 }
 
 // Initialize conifg dict
@@ -0,0 +1,284 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package embeddingcache caches the embeddings of texts by content hash, so ingestion and query
+// pipelines do not recompute the embeddings of repeated content.
+//
+// Entries are keyed by the SHA-256 hash of the model and the text, see Key, and stored in a Store:
+// an in-memory LRU store for a single service, or a KVDB store shared by all services. Failing stores
+// never fail the pipeline: lookups fall back to computing the embeddings and failed writes are logged.
+package embeddingcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// Metric names of the cache lookups, tagged with the cache name and the model.
+const (
+	HitMetricName  = "aali.embeddingcache.hits"
+	MissMetricName = "aali.embeddingcache.misses"
+)
+
+// DefaultTTL is the time to live of cached embeddings if not set with WithTTL.
+const DefaultTTL = 7 * 24 * time.Hour
+
+// Entry holds the cached embeddings of a text.
+type Entry struct {
+	Dense  sharedtypes.Embedding `json:"dense,omitempty"`  // dense vector
+	Sparse map[uint]float32      `json:"sparse,omitempty"` // lexical weights; only for models producing them
+}
+
+// clone returns a copy of the entry not sharing its vector and weights,
+// so callers modifying their embeddings do not change the cached ones.
+func (e Entry) clone() Entry {
+	return Entry{Dense: slices.Clone(e.Dense), Sparse: maps.Clone(e.Sparse)}
+}
+
+// Store stores cache entries by key.
+type Store interface {
+	// Get returns the entry of a key; found is false if the key does not exist or has expired.
+	Get(ctx context.Context, key string) (entry Entry, found bool, err error)
+	// Set stores the entry of a key; the entry does not expire if ttl is 0.
+	Set(ctx context.Context, key string, entry Entry, ttl time.Duration) error
+}
+
+// Stats are the lookup counters of a cache.
+type Stats struct {
+	Hits   int64
+	Misses int64
+}
+
+// HitRate returns the share of lookups answered from the cache.
+//
+// Returns:
+//   - float64: the hit rate in [0, 1]; 0 if there were no lookups
+func (s Stats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// Cache caches embeddings in a store.
+type Cache struct {
+	store Store
+	name  string
+	ttl   time.Duration
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// Option configures a Cache.
+type Option func(*Cache)
+
+// WithTTL sets the time to live of cached embeddings; 0 keeps them until evicted by the store.
+//
+// Parameters:
+//   - ttl: the time to live
+//
+// Returns:
+//   - Option: the option
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Cache) { c.ttl = ttl }
+}
+
+// WithName sets the name of the cache, used as "cache" tag of the metrics.
+//
+// Parameters:
+//   - name: the name, e.g. "ingestion"
+//
+// Returns:
+//   - Option: the option
+func WithName(name string) Option {
+	return func(c *Cache) { c.name = name }
+}
+
+// New creates a cache.
+//
+// Parameters:
+//   - store: the store of the entries, e.g. NewMemoryStore or NewKVDBStore
+//   - options: the options
+//
+// Returns:
+//   - *Cache: the cache
+func New(store Store, options ...Option) *Cache {
+	c := &Cache{store: store, name: "default", ttl: DefaultTTL}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Key returns the cache key of the embeddings of a text computed by a model.
+//
+// Parameters:
+//   - text: the embedded text
+//   - model: the embedding model
+//
+// Returns:
+//   - string: the hex encoded SHA-256 hash of the length prefixed model and text
+func Key(text string, model string) string {
+	h := sha256.New()
+	for _, field := range []string{model, text} {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(field)))
+		h.Write(length[:])
+		h.Write([]byte(field))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get returns the cached embeddings of a text.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - text: the embedded text
+//   - model: the embedding model
+//
+// Returns:
+//   - Entry: the cached embeddings
+//   - bool: true if the embeddings were found; false on store errors, which are logged
+func (c *Cache) Get(ctx context.Context, text string, model string) (Entry, bool) {
+	entry, found := c.lookup(ctx, Key(text, model))
+	if found {
+		c.record(model, 1, 0)
+	} else {
+		c.record(model, 0, 1)
+	}
+	return entry, found
+}
+
+// Set caches the embeddings of a text.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - text: the embedded text
+//   - model: the embedding model
+//   - entry: the embeddings
+//
+// Returns:
+//   - error: an error if the store fails
+func (c *Cache) Set(ctx context.Context, text string, model string, entry Entry) error {
+	if err := c.store.Set(ctx, Key(text, model), entry, c.ttl); err != nil {
+		return fmt.Errorf("error caching embeddings: %w", err)
+	}
+	return nil
+}
+
+// GetOrCompute returns the embeddings of texts, computing only the ones missing in the cache.
+// Repeated texts are computed once. The computed embeddings are cached; failed writes are logged.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - texts: the texts to embed
+//   - model: the embedding model
+//   - compute: computes the embeddings of the missing texts, one entry per text in the same order
+//
+// Returns:
+//   - []Entry: the embeddings, one entry per text in the order of texts
+//   - error: the error of compute, or an error if it returns a wrong number of entries
+func (c *Cache) GetOrCompute(ctx context.Context, texts []string, model string, compute func(ctx context.Context, texts []string) ([]Entry, error)) ([]Entry, error) {
+	entries := make([]Entry, len(texts))
+	first := map[string]int{} // index of the first occurrence of each text
+	missing := []string{}
+	hits := 0
+	for i, text := range texts {
+		if _, ok := first[text]; ok {
+			continue
+		}
+		first[text] = i
+		if entry, found := c.lookup(ctx, Key(text, model)); found {
+			entries[i] = entry
+			hits++
+		} else {
+			missing = append(missing, text)
+		}
+	}
+	c.record(model, hits, len(missing))
+
+	if len(missing) > 0 {
+		computed, err := compute(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		if len(computed) != len(missing) {
+			return nil, fmt.Errorf("computed %d embeddings for %d texts", len(computed), len(missing))
+		}
+		for i, text := range missing {
+			entries[first[text]] = computed[i]
+			if err := c.Set(ctx, text, model, computed[i]); err != nil {
+				logging.Log.Warnf(&logging.ContextMap{}, "%v", err)
+			}
+		}
+	}
+
+	// repeated texts get a copy of the entry of their first occurrence
+	for i, text := range texts {
+		if first[text] != i {
+			entries[i] = entries[first[text]].clone()
+		}
+	}
+	return entries, nil
+}
+
+// Stats returns the lookup counters of the cache.
+//
+// Returns:
+//   - Stats: the counters
+func (c *Cache) Stats() Stats {
+	return Stats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+// lookup reads an entry from the store, treating store errors as misses
+func (c *Cache) lookup(ctx context.Context, key string) (Entry, bool) {
+	entry, found, err := c.store.Get(ctx, key)
+	if err != nil {
+		logging.Log.Warnf(&logging.ContextMap{}, "error reading cached embeddings: %v", err)
+		return Entry{}, false
+	}
+	return entry, found
+}
+
+// record counts the hits and misses of a lookup and sends them as metrics
+func (c *Cache) record(model string, hits int, misses int) {
+	c.hits.Add(int64(hits))
+	c.misses.Add(int64(misses))
+	tags := []string{"cache:" + c.name, "model:" + model}
+	if hits > 0 {
+		logging.Log.MetricsWithTags(HitMetricName, float64(hits), tags...)
+	}
+	if misses > 0 {
+		logging.Log.MetricsWithTags(MissMetricName, float64(misses), tags...)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embeddingcache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"go.uber.org/zap/zapcore"
+)
+
+// failingStore fails every request
+type failingStore struct{}
+
+func (failingStore) Get(ctx context.Context, key string) (Entry, bool, error) {
+	return Entry{}, false, errors.New("store unavailable")
+}
+
+func (failingStore) Set(ctx context.Context, key string, entry Entry, ttl time.Duration) error {
+	return errors.New("store unavailable")
+}
+
+// countingCompute returns an entry with the length of each text and records the computed texts
+func countingCompute(computed *[]string) func(ctx context.Context, texts []string) ([]Entry, error) {
+	return func(ctx context.Context, texts []string) ([]Entry, error) {
+		*computed = append(*computed, texts...)
+		entries := make([]Entry, len(texts))
+		for i, text := range texts {
+			entries[i] = Entry{Dense: []float32{float32(len(text))}}
+		}
+		return entries, nil
+	}
+}
+
+func TestKey(t *testing.T) {
+	key := Key("hello", "bge-m3")
+	if len(key) != 64 || key != Key("hello", "bge-m3") {
+		t.Errorf("Key() = %q, want a stable SHA-256 hex digest", key)
+	}
+	for _, other := range []string{Key("hello", "ada-002"), Key("hellobge-m3", ""), Key("", "hellobge-m3"), Key("bge-m3", "hello")} {
+		if other == key {
+			t.Errorf("Key() collision for different text and model")
+		}
+	}
+}
+
+func TestCacheGetSet(t *testing.T) {
+	logging.CaptureForTest(t)
+	cache := New(NewMemoryStore(10))
+	ctx := context.Background()
+
+	if _, found := cache.Get(ctx, "text", "model"); found {
+		t.Fatal("Get() found an entry in an empty cache")
+	}
+	entry := Entry{Dense: []float32{1, 2}, Sparse: map[uint]float32{7: 0.5}}
+	if err := cache.Set(ctx, "text", "model", entry); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	got, found := cache.Get(ctx, "text", "model")
+	if !found || !reflect.DeepEqual(got, entry) {
+		t.Errorf("Get() = %+v, %v, want %+v", got, found, entry)
+	}
+	if _, found := cache.Get(ctx, "text", "other-model"); found {
+		t.Error("Get() found the entry of another model")
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.HitRate() != 1.0/3 {
+		t.Errorf("Stats() = %+v, hit rate %v, want 1 hit and 2 misses", stats, stats.HitRate())
+	}
+	if rate := (Stats{}).HitRate(); rate != 0 {
+		t.Errorf("HitRate() without lookups = %v, want 0", rate)
+	}
+}
+
+func TestCacheGetOrCompute(t *testing.T) {
+	logging.CaptureForTest(t)
+	cache := New(NewMemoryStore(10), WithName("test"))
+	ctx := context.Background()
+	computed := []string{}
+
+	entries, err := cache.GetOrCompute(ctx, []string{"a", "bb", "a"}, "model", countingCompute(&computed))
+	if err != nil {
+		t.Fatalf("GetOrCompute() error = %v", err)
+	}
+	if !slices.Equal(computed, []string{"a", "bb"}) {
+		t.Errorf("computed %v, want each text once", computed)
+	}
+	if len(entries) != 3 || entries[0].Dense[0] != 1 || entries[1].Dense[0] != 2 || entries[2].Dense[0] != 1 {
+		t.Errorf("GetOrCompute() = %+v, want entries in the order of the texts", entries)
+	}
+
+	// repeated texts do not share their vector
+	entries[2].Dense[0] = 9
+	if entries[0].Dense[0] != 1 {
+		t.Errorf("changing the entry of a repeated text changed its first occurrence to %v", entries[0].Dense[0])
+	}
+
+	// cached texts are not computed again
+	computed = nil
+	entries, err = cache.GetOrCompute(ctx, []string{"bb", "ccc", "bb"}, "model", countingCompute(&computed))
+	if err != nil {
+		t.Fatalf("GetOrCompute() error = %v", err)
+	}
+	if !slices.Equal(computed, []string{"ccc"}) {
+		t.Errorf("computed %v, want only the missing text", computed)
+	}
+	if entries[0].Dense[0] != 2 || entries[1].Dense[0] != 3 || entries[2].Dense[0] != 2 {
+		t.Errorf("GetOrCompute() = %+v", entries)
+	}
+	if stats := cache.Stats(); stats.Hits != 1 || stats.Misses != 3 {
+		t.Errorf("Stats() = %+v, want 1 hit and 3 misses", stats)
+	}
+
+	// nothing to compute
+	computed = nil
+	if _, err := cache.GetOrCompute(ctx, []string{"a"}, "model", countingCompute(&computed)); err != nil || len(computed) != 0 {
+		t.Errorf("GetOrCompute() of cached text = %v, computed %v", err, computed)
+	}
+}
+
+func TestCacheGetOrComputeErrors(t *testing.T) {
+	logging.CaptureForTest(t)
+	cache := New(NewMemoryStore(10))
+	ctx := context.Background()
+
+	failure := errors.New("model unavailable")
+	_, err := cache.GetOrCompute(ctx, []string{"a"}, "model", func(context.Context, []string) ([]Entry, error) { return nil, failure })
+	if !errors.Is(err, failure) {
+		t.Errorf("GetOrCompute() error = %v, want the compute error", err)
+	}
+	_, err = cache.GetOrCompute(ctx, []string{"a", "b"}, "model", func(context.Context, []string) ([]Entry, error) { return []Entry{{}}, nil })
+	if err == nil {
+		t.Error("expected an error for a wrong number of computed entries")
+	}
+	if _, found := cache.Get(ctx, "a", "model"); found {
+		t.Error("failed computations must not be cached")
+	}
+}
+
+func TestCacheFailingStore(t *testing.T) {
+	capture := logging.CaptureForTest(t)
+	cache := New(failingStore{})
+	computed := []string{}
+
+	entries, err := cache.GetOrCompute(context.Background(), []string{"a"}, "model", countingCompute(&computed))
+	if err != nil || len(entries) != 1 || len(computed) != 1 {
+		t.Fatalf("GetOrCompute() = %+v, %v, want the computed entry", entries, err)
+	}
+	if !capture.Contains(zapcore.WarnLevel, "error reading cached embeddings") || !capture.Contains(zapcore.WarnLevel, "error caching embeddings") {
+		t.Errorf("store errors were not logged: %v", capture.Messages(zapcore.WarnLevel))
+	}
+	if err := cache.Set(context.Background(), "a", "model", Entry{}); err == nil {
+		t.Error("Set() should return the store error")
+	}
+}
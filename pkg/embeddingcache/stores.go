@@ -0,0 +1,212 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embeddingcache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/clients/kvdbclient"
+)
+
+// DefaultMemoryMaxEntries is the maximum number of entries of a MemoryStore if not set.
+const DefaultMemoryMaxEntries = 10000
+
+// MemoryStore is an in-memory LRU store with expiry.
+// Entries are copied when set and returned, so they are never shared with the callers.
+type MemoryStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element // values are *memoryEntry
+	order      *list.List               // most recently used first
+	now        func() time.Time
+}
+
+// memoryEntry is an entry of a MemoryStore
+type memoryEntry struct {
+	key     string
+	entry   Entry
+	expires time.Time // zero if the entry does not expire
+}
+
+// NewMemoryStore creates an in-memory store evicting the least recently used entries above maxEntries.
+//
+// Parameters:
+//   - maxEntries: the maximum number of entries; DefaultMemoryMaxEntries if not positive
+//
+// Returns:
+//   - *MemoryStore: the store
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryMaxEntries
+	}
+	return &MemoryStore{
+		maxEntries: maxEntries,
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get returns the entry of a key.
+//
+// Parameters:
+//   - ctx: unused
+//   - key: the key
+//
+// Returns:
+//   - entry: the entry
+//   - found: true if the key exists and has not expired
+//   - err: always nil
+func (s *MemoryStore) Get(ctx context.Context, key string) (entry Entry, found bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[key]
+	if !ok {
+		return Entry{}, false, nil
+	}
+	stored := element.Value.(*memoryEntry)
+	if !stored.expires.IsZero() && !s.now().Before(stored.expires) {
+		s.order.Remove(element)
+		delete(s.entries, key)
+		return Entry{}, false, nil
+	}
+	s.order.MoveToFront(element)
+	return stored.entry.clone(), true, nil
+}
+
+// Set stores the entry of a key, evicting the least recently used entries above the maximum.
+//
+// Parameters:
+//   - ctx: unused
+//   - key: the key
+//   - entry: the entry
+//   - ttl: the time to live; no expiry if 0
+//
+// Returns:
+//   - error: always nil
+func (s *MemoryStore) Set(ctx context.Context, key string, entry Entry, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := &memoryEntry{key: key, entry: entry.clone()}
+	if ttl > 0 {
+		stored.expires = s.now().Add(ttl)
+	}
+	if element, ok := s.entries[key]; ok {
+		element.Value = stored
+		s.order.MoveToFront(element)
+	} else {
+		s.entries[key] = s.order.PushFront(stored)
+	}
+
+	for s.order.Len() > s.maxEntries {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of entries, including expired entries not evicted yet.
+//
+// Returns:
+//   - int: the number of entries
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+// DefaultKVDBPrefix is the key prefix of cached embeddings in KVDB.
+const DefaultKVDBPrefix = "embeddingcache/"
+
+// KVDBStore stores the entries in aali-kvdb, so they are shared by all services.
+// KVDB expires the entries; the number of entries is bounded by the TTL only.
+type KVDBStore struct {
+	client *kvdbclient.Client
+	prefix string
+}
+
+// NewKVDBStore creates a store writing the entries to the keys "embeddingcache/<key>".
+//
+// Parameters:
+//   - client: the KVDB client
+//
+// Returns:
+//   - *KVDBStore: the store
+func NewKVDBStore(client *kvdbclient.Client) *KVDBStore {
+	return &KVDBStore{client: client, prefix: DefaultKVDBPrefix}
+}
+
+// WithPrefix sets the key prefix of the entries.
+//
+// Parameters:
+//   - prefix: the key prefix
+//
+// Returns:
+//   - *KVDBStore: the store
+func (s *KVDBStore) WithPrefix(prefix string) *KVDBStore {
+	s.prefix = prefix
+	return s
+}
+
+// Get reads the entry of a key from KVDB.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - key: the key
+//
+// Returns:
+//   - entry: the entry
+//   - found: true if the key exists
+//   - err: an error if the request or the decoding fails
+func (s *KVDBStore) Get(ctx context.Context, key string) (entry Entry, found bool, err error) {
+	entry, _, err = kvdbclient.GetJSON[Entry](ctx, s.client, s.prefix+key)
+	if errors.Is(err, kvdbclient.ErrNotFound) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, err
+	}
+	return entry, true, nil
+}
+
+// Set writes the entry of a key to KVDB.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - key: the key
+//   - entry: the entry
+//   - ttl: the time to live; no expiry if 0
+//
+// Returns:
+//   - error: an error if the request fails
+func (s *KVDBStore) Set(ctx context.Context, key string, entry Entry, ttl time.Duration) error {
+	_, err := kvdbclient.SetJSON(ctx, s.client, s.prefix+key, entry, &kvdbclient.SetOptions{TTL: ttl})
+	return err
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package embeddingcache
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/clients/kvdbclient"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+)
+
+func TestMemoryStoreEviction(t *testing.T) {
+	store := NewMemoryStore(2)
+	ctx := context.Background()
+
+	store.Set(ctx, "a", Entry{Dense: []float32{1}}, 0)
+	store.Set(ctx, "b", Entry{Dense: []float32{2}}, 0)
+	store.Get(ctx, "a") // "b" is now the least recently used entry
+	store.Set(ctx, "c", Entry{Dense: []float32{3}}, 0)
+
+	if _, found, _ := store.Get(ctx, "b"); found {
+		t.Error("least recently used entry was not evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, found, _ := store.Get(ctx, key); !found {
+			t.Errorf("entry %q was evicted", key)
+		}
+	}
+	if store.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", store.Len())
+	}
+
+	// overwriting does not grow the store
+	store.Set(ctx, "a", Entry{Dense: []float32{4}}, 0)
+	if entry, _, _ := store.Get(ctx, "a"); store.Len() != 2 || entry.Dense[0] != 4 {
+		t.Errorf("overwritten entry = %+v, Len() = %d", entry, store.Len())
+	}
+
+	if NewMemoryStore(0).maxEntries != DefaultMemoryMaxEntries {
+		t.Error("NewMemoryStore(0) does not use the default maximum")
+	}
+}
+
+func TestMemoryStoreCopiesEntries(t *testing.T) {
+	store := NewMemoryStore(10)
+	ctx := context.Background()
+
+	entry := Entry{Dense: []float32{1, 2}, Sparse: map[uint]float32{7: 0.5}}
+	store.Set(ctx, "a", entry, 0)
+	entry.Dense[0], entry.Sparse[7] = 9, 9
+
+	got, _, _ := store.Get(ctx, "a")
+	got.Dense[1], got.Sparse[8] = 9, 9
+
+	want := Entry{Dense: []float32{1, 2}, Sparse: map[uint]float32{7: 0.5}}
+	if again, _, _ := store.Get(ctx, "a"); !reflect.DeepEqual(again, want) {
+		t.Errorf("stored entry = %+v after changing the set and returned entries, want %+v", again, want)
+	}
+}
+
+func TestMemoryStoreTTL(t *testing.T) {
+	store := NewMemoryStore(10)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	store.Set(ctx, "short", Entry{}, time.Minute)
+	store.Set(ctx, "forever", Entry{}, 0)
+	now = now.Add(time.Hour)
+
+	if _, found, _ := store.Get(ctx, "short"); found {
+		t.Error("expired entry was returned")
+	}
+	if _, found, _ := store.Get(ctx, "forever"); !found {
+		t.Error("entry without TTL expired")
+	}
+	if store.Len() != 1 {
+		t.Errorf("Len() = %d, want the expired entry removed", store.Len())
+	}
+}
+
+// fakeKVDB is a minimal aali-kvdb REST API keeping the values and TTLs in memory
+type fakeKVDB struct {
+	mu     sync.Mutex
+	values map[string]string
+	ttls   map[string]int64
+}
+
+func (f *fakeKVDB) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := strings.TrimPrefix(r.URL.Path, "/v1/keys/")
+	switch r.Method {
+	case http.MethodGet:
+		value, ok := f.values[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(kvdbclient.Entry{Key: key, Value: value, Version: 1})
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		var request struct {
+			Value           string `json:"value"`
+			TTLMilliseconds int64  `json:"ttlMilliseconds"`
+		}
+		json.Unmarshal(body, &request)
+		f.values[key], f.ttls[key] = request.Value, request.TTLMilliseconds
+		json.NewEncoder(w).Encode(map[string]int64{"version": 1})
+	}
+}
+
+func TestKVDBStore(t *testing.T) {
+	logging.CaptureForTest(t)
+	defer func(previous *config.Config) { config.GlobalConfig = previous }(config.GlobalConfig)
+	config.GlobalConfig = &config.Config{}
+
+	fake := &fakeKVDB{values: map[string]string{}, ttls: map[string]int64{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client, err := kvdbclient.NewClient(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewKVDBStore(client)
+	ctx := context.Background()
+
+	if _, found, err := store.Get(ctx, "missing"); found || err != nil {
+		t.Errorf("Get() of missing key = %v, %v", found, err)
+	}
+
+	entry := Entry{Dense: []float32{0.5, -1}, Sparse: map[uint]float32{3: 0.25}}
+	if err := store.Set(ctx, "key", entry, time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if _, ok := fake.values["embeddingcache/key"]; !ok || fake.ttls["embeddingcache/key"] != time.Hour.Milliseconds() {
+		t.Errorf("stored keys = %v, ttls = %v", fake.values, fake.ttls)
+	}
+	got, found, err := store.Get(ctx, "key")
+	if !found || err != nil || !reflect.DeepEqual(got, entry) {
+		t.Errorf("Get() = %+v, %v, %v, want %+v", got, found, err, entry)
+	}
+
+	fake.values["custom/broken"] = "{"
+	if _, found, err := store.WithPrefix("custom/").Get(ctx, "broken"); found || err == nil {
+		t.Errorf("Get() of invalid entry = %v, %v, want error", found, err)
+	}
+}
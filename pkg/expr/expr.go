@@ -0,0 +1,716 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package expr evaluates the conditions of conditional edges in workflow definitions.
+//
+// Expressions are side-effect free and support:
+//   - literals: 42, 1.5, "text", 'text', true, false, null, [1, 2]
+//   - variables and access: answer, answer.score, items[0], scores["key"]
+//   - operators: || && ! == != < <= > >= in + - * / %
+//   - functions: len, contains, startsWith, endsWith, lower, upper, trim, string, number
+//
+// Variables are usually the filled inputs and outputs of the previous nodes, converted according to their GoType
+// with VariablesFromFilledInputOutputs.
+package expr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/ansys/aali-sharedtypes/pkg/typeconverters"
+)
+
+// EvalError describes an expression that failed to evaluate, e.g. because of mismatching types.
+type EvalError struct {
+	Offset  int // byte offset of the failing part of the expression
+	Message string
+}
+
+// Error implements the error interface.
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("evaluation error at position %d: %s", e.Offset+1, e.Message)
+}
+
+// Expression is a compiled expression that can be evaluated repeatedly and concurrently.
+type Expression struct {
+	source string
+	root   node
+}
+
+// Compile parses an expression.
+//
+// Parameters:
+//   - source: the expression
+//
+// Returns:
+//   - *Expression: the compiled expression
+//   - error: a *SyntaxError if the expression is invalid
+func Compile(source string) (*Expression, error) {
+	root, err := parse(source)
+	if err != nil {
+		return nil, err
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Evaluate evaluates the expression. The result is nil, a bool, an int64, a float64, a string,
+// a []interface{} or a map[string]interface{}.
+//
+// Parameters:
+//   - variables: the variables by name; values are normalized, see VariablesFromFilledInputOutputs
+//
+// Returns:
+//   - interface{}: the result
+//   - error: an *EvalError if the expression cannot be evaluated
+func (e *Expression) Evaluate(variables map[string]interface{}) (interface{}, error) {
+	return evaluate(e.root, variables)
+}
+
+// EvaluateBool evaluates the expression and requires a boolean result.
+//
+// Parameters:
+//   - variables: the variables by name
+//
+// Returns:
+//   - bool: the result
+//   - error: an *EvalError if the expression cannot be evaluated or its result is not a bool
+func (e *Expression) EvaluateBool(variables map[string]interface{}) (bool, error) {
+	result, err := e.Evaluate(variables)
+	if err != nil {
+		return false, err
+	}
+	b, ok := result.(bool)
+	if !ok {
+		return false, &EvalError{Offset: 0, Message: fmt.Sprintf("condition must be a bool, got %s", typeName(result))}
+	}
+	return b, nil
+}
+
+// EvaluateCondition compiles and evaluates a condition over filled inputs and outputs.
+//
+// Parameters:
+//   - condition: the condition
+//   - values: the filled inputs and outputs by name
+//
+// Returns:
+//   - bool: the result of the condition
+//   - error: an error if the condition is invalid, a value cannot be converted or the result is not a bool
+func EvaluateCondition(condition string, values map[string]sharedtypes.FilledInputOutput) (bool, error) {
+	expression, err := Compile(condition)
+	if err != nil {
+		return false, err
+	}
+	variables, err := VariablesFromFilledInputOutputs(values)
+	if err != nil {
+		return false, err
+	}
+	return expression.EvaluateBool(variables)
+}
+
+// VariablesFromFilledInputOutputs converts filled inputs and outputs into expression variables.
+// Values serialized as string are converted to their GoType first, so an "int" value "3" compares as a number;
+// structs are converted to maps with their JSON field names.
+//
+// Parameters:
+//   - values: the filled inputs and outputs by name
+//
+// Returns:
+//   - map[string]interface{}: the variables by name
+//   - error: an error if a value cannot be converted
+func VariablesFromFilledInputOutputs(values map[string]sharedtypes.FilledInputOutput) (map[string]interface{}, error) {
+	variables := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		converted := value.Value
+		if s, ok := converted.(string); ok && value.GoType != "" && value.GoType != "string" {
+			result, exists, err := typeconverters.ConvertStringToGivenType(s, value.GoType)
+			if err != nil {
+				return nil, fmt.Errorf("error converting '%s' to %s: %w", name, value.GoType, err)
+			}
+			if exists {
+				converted = result
+			}
+		}
+		normalized, err := normalize(converted)
+		if err != nil {
+			return nil, fmt.Errorf("error converting '%s': %w", name, err)
+		}
+		variables[name] = normalized
+	}
+	return variables, nil
+}
+
+// normalize converts a Go value into the value types of the evaluator.
+func normalize(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, bool, int64, float64, string:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint:
+		if uint64(v) <= math.MaxInt64 {
+			return int64(v), nil
+		}
+		return float64(v), nil
+	case uint64:
+		if v <= math.MaxInt64 {
+			return int64(v), nil
+		}
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized, err := normalize(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = normalized
+		}
+		return items, nil
+	case map[string]interface{}:
+		fields := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized, err := normalize(item)
+			if err != nil {
+				return nil, err
+			}
+			fields[key] = normalized
+		}
+		return fields, nil
+	}
+
+	// everything else is converted through its JSON form
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported value of type %T: %w", value, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("unsupported value of type %T: %w", value, err)
+	}
+	return normalize(generic)
+}
+
+// evaluate evaluates a node.
+func evaluate(n node, variables map[string]interface{}) (interface{}, error) {
+	switch n := n.(type) {
+	case *literalNode:
+		return n.value, nil
+	case *variableNode:
+		value, ok := variables[n.name]
+		if !ok {
+			return nil, &EvalError{Offset: n.offset, Message: fmt.Sprintf("undefined variable '%s'", n.name)}
+		}
+		return normalize(value)
+	case *listNode:
+		items := make([]interface{}, len(n.items))
+		for i, item := range n.items {
+			value, err := evaluate(item, variables)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = value
+		}
+		return items, nil
+	case *unaryNode:
+		operand, err := evaluate(n.operand, variables)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "!":
+			if b, ok := operand.(bool); ok {
+				return !b, nil
+			}
+		case "-":
+			switch v := operand.(type) {
+			case int64:
+				return -v, nil
+			case float64:
+				return -v, nil
+			}
+		}
+		return nil, &EvalError{Offset: n.offset, Message: fmt.Sprintf("operator '%s' not defined for %s", n.op, typeName(operand))}
+	case *binaryNode:
+		return evaluateBinary(n, variables)
+	case *memberNode:
+		object, err := evaluate(n.object, variables)
+		if err != nil {
+			return nil, err
+		}
+		return member(n.offset, object, n.name)
+	case *indexNode:
+		object, err := evaluate(n.object, variables)
+		if err != nil {
+			return nil, err
+		}
+		index, err := evaluate(n.index, variables)
+		if err != nil {
+			return nil, err
+		}
+		return indexValue(n.offset, object, index)
+	case *callNode:
+		args := make([]interface{}, len(n.args))
+		for i, arg := range n.args {
+			value, err := evaluate(arg, variables)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = value
+		}
+		return call(n, args)
+	}
+	return nil, fmt.Errorf("unknown node %T", n)
+}
+
+// evaluateBinary evaluates a binary operator; && and || short-circuit.
+func evaluateBinary(n *binaryNode, variables map[string]interface{}) (interface{}, error) {
+	left, err := evaluate(n.left, variables)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "&&" || n.op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, &EvalError{Offset: n.offset, Message: fmt.Sprintf("operator '%s' requires bool operands, got %s", n.op, typeName(left))}
+		}
+		if (n.op == "&&" && !l) || (n.op == "||" && l) {
+			return l, nil
+		}
+		right, err := evaluate(n.right, variables)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, &EvalError{Offset: n.offset, Message: fmt.Sprintf("operator '%s' requires bool operands, got %s", n.op, typeName(right))}
+		}
+		return r, nil
+	}
+
+	right, err := evaluate(n.right, variables)
+	if err != nil {
+		return nil, err
+	}
+	mismatch := &EvalError{Offset: n.offset, Message: fmt.Sprintf("operator '%s' not defined for %s and %s", n.op, typeName(left), typeName(right))}
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "<", "<=", ">", ">=":
+		cmp, ok := compare(left, right)
+		if !ok {
+			return nil, mismatch
+		}
+		switch n.op {
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		}
+		return cmp >= 0, nil
+	case "in":
+		switch container := right.(type) {
+		case []interface{}:
+			for _, item := range container {
+				if equal(left, item) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case map[string]interface{}:
+			if key, ok := left.(string); ok {
+				_, found := container[key]
+				return found, nil
+			}
+		case string:
+			if s, ok := left.(string); ok {
+				return strings.Contains(container, s), nil
+			}
+		}
+		return nil, mismatch
+	case "+":
+		if l, ok := left.(string); ok {
+			if r, ok := right.(string); ok {
+				return l + r, nil
+			}
+			return nil, mismatch
+		}
+		fallthrough
+	case "-", "*", "/", "%":
+		return arithmetic(n, left, right, mismatch)
+	}
+	return nil, mismatch
+}
+
+// arithmetic evaluates + - * / % on numbers; integer operations stay integers except for inexact division.
+func arithmetic(n *binaryNode, left interface{}, right interface{}, mismatch error) (interface{}, error) {
+	li, lInt := left.(int64)
+	ri, rInt := right.(int64)
+	if lInt && rInt {
+		switch n.op {
+		case "+":
+			return li + ri, nil
+		case "-":
+			return li - ri, nil
+		case "*":
+			return li * ri, nil
+		case "/", "%":
+			if ri == 0 {
+				return nil, &EvalError{Offset: n.offset, Message: "division by zero"}
+			}
+			if ri == -1 {
+				// avoid the overflow of MinInt64 / -1
+				if n.op == "%" {
+					return int64(0), nil
+				}
+				return -li, nil
+			}
+			if n.op == "%" {
+				return li % ri, nil
+			}
+			if li%ri == 0 {
+				return li / ri, nil
+			}
+			return float64(li) / float64(ri), nil
+		}
+	}
+	lf, lOk := toFloat(left)
+	rf, rOk := toFloat(right)
+	if !lOk || !rOk {
+		return nil, mismatch
+	}
+	switch n.op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	case "/":
+		if rf == 0 {
+			return nil, &EvalError{Offset: n.offset, Message: "division by zero"}
+		}
+		return lf / rf, nil
+	case "%":
+		if rf == 0 {
+			return nil, &EvalError{Offset: n.offset, Message: "division by zero"}
+		}
+		return math.Mod(lf, rf), nil
+	}
+	return nil, mismatch
+}
+
+// member accesses a field of a map.
+func member(offset int, object interface{}, name string) (interface{}, error) {
+	fields, ok := object.(map[string]interface{})
+	if !ok {
+		return nil, &EvalError{Offset: offset, Message: fmt.Sprintf("cannot access field '%s' of %s", name, typeName(object))}
+	}
+	value, ok := fields[name]
+	if !ok {
+		return nil, &EvalError{Offset: offset, Message: fmt.Sprintf("undefined field '%s'", name)}
+	}
+	return value, nil
+}
+
+// indexValue accesses an element of a list, a field of a map or a character of a string.
+func indexValue(offset int, object interface{}, index interface{}) (interface{}, error) {
+	switch container := object.(type) {
+	case map[string]interface{}:
+		if key, ok := index.(string); ok {
+			return member(offset, container, key)
+		}
+	case []interface{}:
+		if i, ok := index.(int64); ok {
+			if i < 0 || i >= int64(len(container)) {
+				return nil, &EvalError{Offset: offset, Message: fmt.Sprintf("index %d out of range for list of length %d", i, len(container))}
+			}
+			return container[i], nil
+		}
+	case string:
+		if i, ok := index.(int64); ok {
+			runes := []rune(container)
+			if i < 0 || i >= int64(len(runes)) {
+				return nil, &EvalError{Offset: offset, Message: fmt.Sprintf("index %d out of range for string of length %d", i, len(runes))}
+			}
+			return string(runes[i]), nil
+		}
+	}
+	return nil, &EvalError{Offset: offset, Message: fmt.Sprintf("cannot index %s with %s", typeName(object), typeName(index))}
+}
+
+// functionArity is the number of arguments of the builtin functions by name.
+var functionArity = map[string]int{
+	"len":        1,
+	"contains":   2,
+	"startsWith": 2,
+	"endsWith":   2,
+	"lower":      1,
+	"upper":      1,
+	"trim":       1,
+	"string":     1,
+	"number":     1,
+}
+
+// call evaluates a builtin function.
+func call(n *callNode, args []interface{}) (interface{}, error) {
+	fail := func(format string, a ...interface{}) error {
+		return &EvalError{Offset: n.offset, Message: n.name + ": " + fmt.Sprintf(format, a...)}
+	}
+	expected, known := functionArity[n.name]
+	if !known {
+		return nil, &EvalError{Offset: n.offset, Message: fmt.Sprintf("unknown function '%s'", n.name)}
+	}
+	if len(args) != expected {
+		return nil, fail("expected %d arguments, got %d", expected, len(args))
+	}
+
+	switch n.name {
+	case "len":
+		switch v := args[0].(type) {
+		case string:
+			return int64(utf8.RuneCountInString(v)), nil
+		case []interface{}:
+			return int64(len(v)), nil
+		case map[string]interface{}:
+			return int64(len(v)), nil
+		}
+		return nil, fail("not defined for %s", typeName(args[0]))
+	case "contains":
+		switch v := args[0].(type) {
+		case string:
+			if sub, ok := args[1].(string); ok {
+				return strings.Contains(v, sub), nil
+			}
+		case []interface{}:
+			for _, item := range v {
+				if equal(item, args[1]) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+		return nil, fail("not defined for %s and %s", typeName(args[0]), typeName(args[1]))
+	case "startsWith", "endsWith":
+		s, ok1 := args[0].(string)
+		affix, ok2 := args[1].(string)
+		if !ok1 || !ok2 {
+			return nil, fail("requires strings, got %s and %s", typeName(args[0]), typeName(args[1]))
+		}
+		if n.name == "startsWith" {
+			return strings.HasPrefix(s, affix), nil
+		}
+		return strings.HasSuffix(s, affix), nil
+	case "lower", "upper", "trim":
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fail("requires a string, got %s", typeName(args[0]))
+		}
+		switch n.name {
+		case "lower":
+			return strings.ToLower(s), nil
+		case "upper":
+			return strings.ToUpper(s), nil
+		}
+		return strings.TrimSpace(s), nil
+	case "string":
+		switch v := args[0].(type) {
+		case string:
+			return v, nil
+		case int64:
+			return strconv.FormatInt(v, 10), nil
+		case float64:
+			return strconv.FormatFloat(v, 'g', -1, 64), nil
+		}
+		data, err := json.Marshal(args[0])
+		if err != nil {
+			return nil, fail("%v", err)
+		}
+		return string(data), nil
+	case "number":
+		switch v := args[0].(type) {
+		case int64, float64:
+			return v, nil
+		case string:
+			if i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+				return i, nil
+			}
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return f, nil
+			}
+			return nil, fail("cannot convert %q to a number", v)
+		}
+		return nil, fail("cannot convert %s to a number", typeName(args[0]))
+	}
+	return nil, fail("not implemented")
+}
+
+// equal compares values; numbers compare by value regardless of int64 or float64.
+func equal(a interface{}, b interface{}) bool {
+	if af, ok := toFloat(a); ok {
+		if bf, ok := toFloat(b); ok {
+			ai, aInt := a.(int64)
+			bi, bInt := b.(int64)
+			if aInt && bInt {
+				return ai == bi
+			}
+			return af == bf
+		}
+	}
+	switch av := a.(type) {
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, item := range av {
+			other, found := bv[key]
+			if !found || !equal(item, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compare orders numbers and strings; ok is false for other types.
+func compare(a interface{}, b interface{}) (int, bool) {
+	ai, aInt := a.(int64)
+	bi, bInt := b.(int64)
+	if aInt && bInt {
+		switch {
+		case ai < bi:
+			return -1, true
+		case ai > bi:
+			return 1, true
+		}
+		return 0, true
+	}
+	if af, ok := toFloat(a); ok {
+		if bf, ok := toFloat(b); ok {
+			switch {
+			case af < bf:
+				return -1, true
+			case af > bf:
+				return 1, true
+			case af == bf:
+				return 0, true
+			}
+			return 0, false // NaN
+		}
+	}
+	as, aStr := a.(string)
+	bs, bStr := b.(string)
+	if aStr && bStr {
+		return strings.Compare(as, bs), true
+	}
+	return 0, false
+}
+
+// toFloat converts a number to float64.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// typeName returns the expression type name of a value for error messages.
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case int64, float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// FunctionNames returns the names of the builtin functions.
+func FunctionNames() []string {
+	names := make([]string, 0, len(functionArity))
+	for name := range functionArity {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
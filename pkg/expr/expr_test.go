@@ -0,0 +1,276 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package expr
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+var testVariables = map[string]interface{}{
+	"score":   0.75,
+	"count":   3,
+	"name":    "Mesh Result",
+	"empty":   "",
+	"ok":      true,
+	"nothing": nil,
+	"tags":    []interface{}{"cfd", "mesh"},
+	"answer":  map[string]interface{}{"score": 0.9, "labels": []interface{}{1, 2, 3}},
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       interface{}
+	}{
+		{"true", true},
+		{"42", int64(42)},
+		{"1.5e2", 150.0},
+		{`"a\"b"`, `a"b`},
+		{"'single'", "single"},
+		{"null", nil},
+		{"score > 0.5", true},
+		{"count == 3", true},
+		{"count == 3.0", true},
+		{"count != 4 && ok", true},
+		{"!ok || count < 0", false},
+		{"score >= 0.75 && score <= 0.75", true},
+		{"name == 'Mesh Result'", true},
+		{"'a' < 'b'", true},
+		{"'mesh' in tags", true},
+		{"'fea' in tags", false},
+		{"'Mesh' in name", true},
+		{"'score' in answer", true},
+		{"answer.score > score", true},
+		{"answer.labels[2]", int64(3)},
+		{`answer["score"]`, 0.9},
+		{"tags[0] + '-' + tags[1]", "cfd-mesh"},
+		{"name[0]", "M"},
+		{"count * 2 + 1", int64(7)},
+		{"count / 2", 1.5},
+		{"6 / 2", int64(3)},
+		{"7 % 4", int64(3)},
+		{"-count", int64(-3)},
+		{"(1 + 2) * 3", int64(9)},
+		{"1 + 2 * 3", int64(7)},
+		{"len(tags) == 2 && len(name) == 11 && len(answer) == 2", true},
+		{"contains(name, 'Result') && contains(tags, 'cfd')", true},
+		{"startsWith(lower(name), 'mesh') && endsWith(upper(name), 'RESULT')", true},
+		{"trim('  x ')", "x"},
+		{"string(count) + string(score)", "30.75"},
+		{"number('12') + number('0.5')", 12.5},
+		{"nothing == null", true},
+		{"[1, 2] == [1, 2.0]", true},
+		{"answer.labels == [1, 2, 3]", true},
+		{"[]", []interface{}{}},
+		{"ok == 1", false},
+		{"false && undefinedVariable", false},
+		{"true || undefinedVariable", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expression, err := Compile(tt.expression)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			got, err := expression.Evaluate(testVariables)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantSyntax bool
+		wantOffset int
+	}{
+		{"", true, 0},
+		{"1 +", true, 3},
+		{"(1", true, 2},
+		{"a.", true, 2},
+		{"'unterminated", true, 0},
+		{`"bad \q"`, true, 5},
+		{"1 # 2", true, 2},
+		{"a b", true, 2},
+		{"[1, 2", true, 5},
+		{"in", true, 0},
+		{"undefinedVariable", false, 0},
+		{"count + 'x'", false, 6},
+		{"name < 3", false, 5},
+		{"ok && 1", false, 3},
+		{"!count", false, 0},
+		{"count / 0", false, 6},
+		{"count % 0", false, 6},
+		{"score / 0", false, 6},
+		{"tags[5]", false, 4},
+		{"tags['x']", false, 4},
+		{"answer.missing", false, 6},
+		{"count.field", false, 5},
+		{"unknown(1)", false, 0},
+		{"len(1, 2)", false, 0},
+		{"len(count)", false, 0},
+		{"number('abc')", false, 0},
+		{"'x' in count", false, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expression, err := Compile(tt.expression)
+			if err == nil {
+				_, err = expression.Evaluate(testVariables)
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			var syntaxErr *SyntaxError
+			var evalErr *EvalError
+			switch {
+			case tt.wantSyntax && errors.As(err, &syntaxErr):
+				if syntaxErr.Offset != tt.wantOffset {
+					t.Errorf("SyntaxError offset = %d, want %d (%v)", syntaxErr.Offset, tt.wantOffset, err)
+				}
+			case !tt.wantSyntax && errors.As(err, &evalErr):
+				if evalErr.Offset != tt.wantOffset {
+					t.Errorf("EvalError offset = %d, want %d (%v)", evalErr.Offset, tt.wantOffset, err)
+				}
+			default:
+				t.Errorf("unexpected error type %T: %v", err, err)
+			}
+		})
+	}
+}
+
+func TestLimits(t *testing.T) {
+	if _, err := Compile(strings.Repeat("1+", MaxExpressionLength) + "1"); err == nil {
+		t.Error("Compile() accepted an expression longer than the limit")
+	}
+	if _, err := Compile(strings.Repeat("(", MaxNestingDepth+1) + "1" + strings.Repeat(")", MaxNestingDepth+1)); err == nil {
+		t.Error("Compile() accepted an expression nested deeper than the limit")
+	}
+	if _, err := Compile(strings.Repeat("!", MaxNestingDepth+1) + "true"); err == nil {
+		t.Error("Compile() accepted unary operators nested deeper than the limit")
+	}
+	if _, err := Compile(strings.Repeat("(", MaxNestingDepth-1) + "1" + strings.Repeat(")", MaxNestingDepth-1)); err != nil {
+		t.Errorf("Compile() rejected an expression within the limit: %v", err)
+	}
+	if _, err := Compile(strings.Repeat("1 + ", 500) + "1"); err != nil {
+		t.Errorf("Compile() rejected a long flat expression: %v", err)
+	}
+}
+
+func TestEvaluateCondition(t *testing.T) {
+	values := map[string]sharedtypes.FilledInputOutput{
+		"count":     {Name: "count", GoType: "int", Value: "3"},
+		"threshold": {Name: "threshold", GoType: "float64", Value: 0.5},
+		"text":      {Name: "text", GoType: "string", Value: "42"},
+		"results": {Name: "results", GoType: "[]DbResponse", Value: []sharedtypes.DbResponse{
+			{DocumentName: "a.pdf", Keywords: []string{"mesh"}},
+			{DocumentName: "b.pdf"},
+		}},
+	}
+	tests := []struct {
+		condition string
+		want      bool
+		wantErr   bool
+	}{
+		{"count > 2", true, false},
+		{"count > threshold", true, false},
+		{"text == '42'", true, false},
+		{"text == 42", false, false},
+		{"len(results) == 2 && results[0].document_name == 'a.pdf'", true, false},
+		{"'mesh' in results[0].keywords", true, false},
+		{"count", false, true},
+		{"count >", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			got, err := EvaluateCondition(tt.condition, values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EvaluateCondition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("EvaluateCondition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	_, err := EvaluateCondition("true", map[string]sharedtypes.FilledInputOutput{"bad": {GoType: "int", Value: "abc"}})
+	if err == nil {
+		t.Error("EvaluateCondition() with unconvertible value succeeded")
+	}
+}
+
+func TestFunctionNames(t *testing.T) {
+	names := FunctionNames()
+	if len(names) != len(functionArity) || names[0] != "contains" {
+		t.Errorf("FunctionNames() = %v", names)
+	}
+}
+
+// FuzzEvaluate checks that arbitrary expressions never panic and that errors are typed.
+func FuzzEvaluate(f *testing.F) {
+	seeds := []string{
+		"score > 0.5 && 'mesh' in tags",
+		"answer.labels[1] * -2 / 3 % 5",
+		"len(name) + number(string(count))",
+		"[1, [2, 'x'], null] == [1, [2, 'x'], null]",
+		`contains("a\\b", '\'')`,
+		"((((((((((1))))))))))",
+		"!!!!ok",
+		"tags[-1]",
+		"-9223372036854775807 - 1 / -1",
+		"1e999 > 1",
+		"'\xff'",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, source string) {
+		expression, err := Compile(source)
+		if err != nil {
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Compile(%q) returned untyped error %v", source, err)
+			}
+			return
+		}
+		if expression.String() != source {
+			t.Fatalf("String() = %q, want %q", expression.String(), source)
+		}
+		if _, err := expression.Evaluate(testVariables); err != nil {
+			var evalErr *EvalError
+			if !errors.As(err, &evalErr) {
+				t.Fatalf("Evaluate(%q) returned untyped error %v", source, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,461 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits of the parser, protecting evaluation against oversized or deeply nested expressions.
+const (
+	MaxExpressionLength = 4096
+	MaxNestingDepth     = 64
+)
+
+// SyntaxError describes an invalid expression.
+type SyntaxError struct {
+	Offset  int // byte offset in the expression
+	Message string
+}
+
+// Error implements the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Offset+1, e.Message)
+}
+
+// tokenKind is the kind of a lexical token.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+// token is a lexical token.
+type token struct {
+	kind   tokenKind
+	text   string // operator or identifier text, unquoted string value, or number literal
+	offset int
+}
+
+// operators sorted so that longer operators are matched first.
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ".", ","}
+
+// lex splits an expression into tokens.
+func lex(source string) ([]token, error) {
+	tokens := []token{}
+	for i := 0; i < len(source); {
+		c, size := utf8.DecodeRuneInString(source[i:])
+		switch {
+		case c == utf8.RuneError && size == 1:
+			return nil, &SyntaxError{Offset: i, Message: "invalid UTF-8"}
+		case unicode.IsSpace(c):
+			i += size
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(source) && (isDigit(source[i]) || source[i] == '.' || source[i] == 'e' || source[i] == 'E' ||
+				((source[i] == '+' || source[i] == '-') && (source[i-1] == 'e' || source[i-1] == 'E'))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: source[start:i], offset: start})
+		case c == '"' || c == '\'':
+			value, end, err := lexString(source, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: value, offset: i})
+			i = end
+		case c == '_' || unicode.IsLetter(c):
+			start := i
+			for i < len(source) {
+				r, n := utf8.DecodeRuneInString(source[i:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				i += n
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: source[start:i], offset: start})
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(source[i:], op) {
+					tokens = append(tokens, token{kind: tokenOperator, text: op, offset: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, &SyntaxError{Offset: i, Message: fmt.Sprintf("unexpected character %q", c)}
+			}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, offset: len(source)}), nil
+}
+
+// lexString reads a quoted string starting at offset and returns its value and the offset after the closing quote.
+func lexString(source string, offset int) (string, int, error) {
+	quote := source[offset]
+	var value strings.Builder
+	for i := offset + 1; i < len(source); i++ {
+		switch source[i] {
+		case quote:
+			return value.String(), i + 1, nil
+		case '\\':
+			if i+1 >= len(source) {
+				return "", 0, &SyntaxError{Offset: i, Message: "unterminated escape sequence"}
+			}
+			i++
+			switch source[i] {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			case '\\', '"', '\'':
+				value.WriteByte(source[i])
+			default:
+				return "", 0, &SyntaxError{Offset: i - 1, Message: fmt.Sprintf("invalid escape sequence '\\%c'", source[i])}
+			}
+		default:
+			value.WriteByte(source[i])
+		}
+	}
+	return "", 0, &SyntaxError{Offset: offset, Message: "unterminated string"}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// node is a node of the syntax tree.
+type node interface {
+	position() int
+}
+
+type literalNode struct {
+	offset int
+	value  interface{}
+}
+
+type variableNode struct {
+	offset int
+	name   string
+}
+
+type unaryNode struct {
+	offset  int
+	op      string
+	operand node
+}
+
+type binaryNode struct {
+	offset int
+	op     string
+	left   node
+	right  node
+}
+
+type memberNode struct {
+	offset int
+	object node
+	name   string
+}
+
+type indexNode struct {
+	offset int
+	object node
+	index  node
+}
+
+type callNode struct {
+	offset int
+	name   string
+	args   []node
+}
+
+type listNode struct {
+	offset int
+	items  []node
+}
+
+func (n *literalNode) position() int  { return n.offset }
+func (n *variableNode) position() int { return n.offset }
+func (n *unaryNode) position() int    { return n.offset }
+func (n *binaryNode) position() int   { return n.offset }
+func (n *memberNode) position() int   { return n.offset }
+func (n *indexNode) position() int    { return n.offset }
+func (n *callNode) position() int     { return n.offset }
+func (n *listNode) position() int     { return n.offset }
+
+// parser is a recursive descent parser. Precedence from lowest to highest:
+// ||, &&, == !=, < <= > >= in, + -, * / %, unary ! -, member access, index and call.
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// parse parses an expression into its syntax tree.
+func parse(source string) (node, error) {
+	if len(source) > MaxExpressionLength {
+		return nil, &SyntaxError{Offset: MaxExpressionLength, Message: fmt.Sprintf("expression longer than %d bytes", MaxExpressionLength)}
+	}
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, &SyntaxError{Offset: next.offset, Message: fmt.Sprintf("unexpected '%s'", next.text)}
+	}
+	return root, nil
+}
+
+// binaryLevels are the binary operators by precedence level, lowest first.
+var binaryLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">=", "in"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isOperator checks if the token is one of the operators; "in" is lexed as an identifier.
+func isOperator(t token, ops ...string) bool {
+	if t.kind != tokenOperator && !(t.kind == tokenIdent && t.text == "in") {
+		return false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	t := p.next()
+	if !isOperator(t, op) {
+		return &SyntaxError{Offset: t.offset, Message: fmt.Sprintf("expected '%s'", op)}
+	}
+	return nil
+}
+
+func (p *parser) enter(offset int) error {
+	p.depth++
+	if p.depth > MaxNestingDepth {
+		return &SyntaxError{Offset: offset, Message: fmt.Sprintf("expression nested deeper than %d levels", MaxNestingDepth)}
+	}
+	return nil
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for isOperator(p.peek(), binaryLevels[level]...) {
+		op := p.next()
+		if err := p.enter(op.offset); err != nil {
+			return nil, err
+		}
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		p.depth--
+		left = &binaryNode{offset: op.offset, op: op.text, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if t := p.peek(); isOperator(t, "!", "-") {
+		p.next()
+		if err := p.enter(t.offset); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		p.depth--
+		return &unaryNode{offset: t.offset, op: t.text, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	result, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case isOperator(t, "."):
+			p.next()
+			name := p.next()
+			if name.kind != tokenIdent {
+				return nil, &SyntaxError{Offset: name.offset, Message: "expected field name after '.'"}
+			}
+			result = &memberNode{offset: t.offset, object: result, name: name.text}
+		case isOperator(t, "["):
+			p.next()
+			if err := p.enter(t.offset); err != nil {
+				return nil, err
+			}
+			index, err := p.parseBinary(0)
+			if err != nil {
+				return nil, err
+			}
+			p.depth--
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			result = &indexNode{offset: t.offset, object: result, index: index}
+		default:
+			return result, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		if i, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return &literalNode{offset: t.offset, value: i}, nil
+		}
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, &SyntaxError{Offset: t.offset, Message: fmt.Sprintf("invalid number '%s'", t.text)}
+		}
+		return &literalNode{offset: t.offset, value: f}, nil
+	case tokenString:
+		return &literalNode{offset: t.offset, value: t.text}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &literalNode{offset: t.offset, value: true}, nil
+		case "false":
+			return &literalNode{offset: t.offset, value: false}, nil
+		case "null":
+			return &literalNode{offset: t.offset, value: nil}, nil
+		case "in":
+			return nil, &SyntaxError{Offset: t.offset, Message: "unexpected 'in'"}
+		}
+		if isOperator(p.peek(), "(") {
+			p.next()
+			if err := p.enter(t.offset); err != nil {
+				return nil, err
+			}
+			args, err := p.parseList(")")
+			if err != nil {
+				return nil, err
+			}
+			p.depth--
+			return &callNode{offset: t.offset, name: t.text, args: args}, nil
+		}
+		return &variableNode{offset: t.offset, name: t.text}, nil
+	case tokenOperator:
+		switch t.text {
+		case "(":
+			if err := p.enter(t.offset); err != nil {
+				return nil, err
+			}
+			inner, err := p.parseBinary(0)
+			if err != nil {
+				return nil, err
+			}
+			p.depth--
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		case "[":
+			if err := p.enter(t.offset); err != nil {
+				return nil, err
+			}
+			items, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			p.depth--
+			return &listNode{offset: t.offset, items: items}, nil
+		}
+	case tokenEOF:
+		return nil, &SyntaxError{Offset: t.offset, Message: "unexpected end of expression"}
+	}
+	return nil, &SyntaxError{Offset: t.offset, Message: fmt.Sprintf("unexpected '%s'", t.text)}
+}
+
+// parseList parses comma separated expressions up to the closing operator.
+func (p *parser) parseList(closing string) ([]node, error) {
+	items := []node{}
+	if isOperator(p.peek(), closing) {
+		p.next()
+		return items, nil
+	}
+	for {
+		item, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		t := p.next()
+		if isOperator(t, closing) {
+			return items, nil
+		}
+		if !isOperator(t, ",") {
+			return nil, &SyntaxError{Offset: t.offset, Message: fmt.Sprintf("expected ',' or '%s'", closing)}
+		}
+	}
+}
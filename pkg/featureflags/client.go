@@ -0,0 +1,170 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+)
+
+// ChangeCallback is called with the names of the flags whose state changed in a refresh
+type ChangeCallback func(changed []string)
+
+// Client caches the states of the defined flags, read from its providers
+// Evaluating a flag never calls the providers; call Refresh or Run to update the states.
+type Client struct {
+	providers []Provider
+
+	mu        sync.RWMutex
+	states    map[string]State
+	refreshed bool
+	callbacks []ChangeCallback
+}
+
+// defaultClient is the client used by Flag.Get
+var defaultClient atomic.Pointer[Client]
+
+// New creates a client
+//
+// Parameters:
+//   - providers: the providers, by priority; the first provider setting a flag wins
+//
+// Returns:
+//   - *Client: the client
+func New(providers ...Provider) *Client {
+	return &Client{providers: providers, states: map[string]State{}}
+}
+
+// SetDefault sets the client used by Flag.Get
+//
+// Parameters:
+//   - client: the client; nil to use the default values of the flags
+func SetDefault(client *Client) {
+	defaultClient.Store(client)
+}
+
+// OnChange registers a callback for changed flags
+//
+// Parameters:
+//   - callback: the callback
+func (c *Client) OnChange(callback ChangeCallback) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.callbacks = append(c.callbacks, callback)
+}
+
+// Refresh reads the states of all defined flags from the providers.
+// The first refresh loads the states without calling the change callbacks.
+// The previous state of a flag is kept if its lookup fails.
+//
+// Parameters:
+//   - ctx: the context of the lookups
+//
+// Returns:
+//   - changed: the names of the changed flags, sorted
+//   - err: the errors of the failed lookups
+func (c *Client) Refresh(ctx context.Context) (changed []string, err error) {
+	c.mu.RLock()
+	previous := c.states
+	initial := !c.refreshed
+	c.mu.RUnlock()
+
+	states := make(map[string]State, len(previous))
+	var errs []error
+	for _, name := range definedNames() {
+		state, found, err := c.lookup(ctx, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("feature flag %q: %w", name, err))
+			if old, ok := previous[name]; ok {
+				states[name] = old
+			}
+			continue
+		}
+		if found {
+			states[name] = state
+		}
+		old, hadOld := previous[name]
+		if found != hadOld || !reflect.DeepEqual(old, state) {
+			changed = append(changed, name)
+		}
+	}
+
+	c.mu.Lock()
+	c.states = states
+	c.refreshed = true
+	callbacks := append([]ChangeCallback{}, c.callbacks...)
+	c.mu.Unlock()
+
+	if !initial && len(changed) > 0 {
+		for _, callback := range callbacks {
+			callback(changed)
+		}
+	}
+	return changed, errors.Join(errs...)
+}
+
+// lookup returns the state of a flag from the first provider setting it
+func (c *Client) lookup(ctx context.Context, name string) (state State, found bool, err error) {
+	for _, provider := range c.providers {
+		state, found, err = provider.Lookup(ctx, name)
+		if err != nil || found {
+			return state, found, err
+		}
+	}
+	return State{}, false, nil
+}
+
+// Run refreshes the flags every interval until the context is cancelled
+//
+// Parameters:
+//   - ctx: the context; cancel it to stop refreshing
+//   - interval: the refresh interval
+func (c *Client) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := c.Refresh(ctx); err != nil && ctx.Err() == nil {
+				logging.Log.Warnf(&logging.ContextMap{}, "error refreshing feature flags: %v", err)
+			}
+		}
+	}
+}
+
+// state returns the cached state of a flag
+func (c *Client) state(name string) (State, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	state, ok := c.states[name]
+	return state, ok
+}
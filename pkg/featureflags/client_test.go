@@ -0,0 +1,152 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// switchableProvider sets the flags of its map, which can be changed by the test, or fails
+type switchableProvider struct {
+	mu     sync.Mutex
+	values map[string]string
+	err    error
+}
+
+func (p *switchableProvider) set(values map[string]string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.values, p.err = values, err
+}
+
+func (p *switchableProvider) Lookup(ctx context.Context, name string) (State, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return State{}, false, p.err
+	}
+	return staticProvider(p.values).Lookup(ctx, name)
+}
+
+func TestClientProviderPriority(t *testing.T) {
+	flag := flagPriority
+	client := refreshedClient(t,
+		staticProvider{},
+		staticProvider{"test-priority": "second"},
+		staticProvider{"test-priority": "third"},
+	)
+	if got := flag.GetFrom(client, nil); got != "second" {
+		t.Errorf("flag = %v, want the value of the first provider setting it", got)
+	}
+}
+
+func TestClientRefresh(t *testing.T) {
+	flag := flagRefresh
+	provider := &switchableProvider{values: map[string]string{"test-refresh": "1"}}
+	client := New(provider)
+
+	var notified [][]string
+	client.OnChange(func(changed []string) { notified = append(notified, changed) })
+
+	// the initial refresh does not notify
+	changed, err := client.Refresh(context.Background())
+	if err != nil || !contains(changed, "test-refresh") || len(notified) != 0 {
+		t.Fatalf("initial Refresh() = %v, %v; notified %v", changed, err, notified)
+	}
+	if got := flag.GetFrom(client, nil); got != 1 {
+		t.Errorf("flag = %v, want 1", got)
+	}
+
+	// unchanged flags do not notify
+	if changed, _ := client.Refresh(context.Background()); len(changed) != 0 || len(notified) != 0 {
+		t.Errorf("unchanged Refresh() = %v; notified %v", changed, notified)
+	}
+
+	provider.set(map[string]string{"test-refresh": "2"}, nil)
+	if changed, _ := client.Refresh(context.Background()); !reflect.DeepEqual(changed, []string{"test-refresh"}) {
+		t.Errorf("Refresh() = %v, want [test-refresh]", changed)
+	}
+	if len(notified) != 1 || !reflect.DeepEqual(notified[0], []string{"test-refresh"}) {
+		t.Errorf("notified %v", notified)
+	}
+	if got := flag.GetFrom(client, nil); got != 2 {
+		t.Errorf("flag = %v, want 2", got)
+	}
+
+	// failed lookups keep the previous state
+	provider.set(nil, errors.New("unavailable"))
+	if _, err := client.Refresh(context.Background()); err == nil {
+		t.Error("Refresh() with failing provider error = nil")
+	}
+	if got := flag.GetFrom(client, nil); got != 2 {
+		t.Errorf("flag after failed refresh = %v, want 2", got)
+	}
+
+	// removed flags fall back to the default
+	provider.set(map[string]string{}, nil)
+	client.Refresh(context.Background())
+	if got := flag.GetFrom(client, nil); got != 0 {
+		t.Errorf("removed flag = %v, want default 0", got)
+	}
+	if len(notified) != 2 {
+		t.Errorf("removal notified %d times in total, want 2", len(notified))
+	}
+}
+
+func TestClientRun(t *testing.T) {
+	flag := flagRun
+	provider := &switchableProvider{values: map[string]string{}}
+	client := refreshedClient(t, provider)
+
+	changes := make(chan []string, 10)
+	client.OnChange(func(changed []string) { changes <- changed })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go client.Run(ctx, 10*time.Millisecond)
+
+	provider.set(map[string]string{"test-run": "true"}, nil)
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change notification")
+	}
+	if !flag.GetFrom(client, nil) {
+		t.Error("flag not updated by Run")
+	}
+}
+
+// contains checks if a slice contains a value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
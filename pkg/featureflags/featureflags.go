@@ -0,0 +1,307 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package featureflags provides feature flags shared by the Aali services, so experimental behaviors
+// can be rolled out consistently across the agent, FlowKit and the LLM handler.
+//
+// Flags are defined once with Define and evaluated with Flag.Get. Their state is read from providers
+// (config, environment variables or KVDB) by a Client, which caches it and notifies about changes:
+//
+//	var NewRetriever = featureflags.Define("new-retriever", false, "use the hybrid retriever")
+//
+//	client := featureflags.New(featureflags.EnvProvider{}, featureflags.NewKVDBProvider(kvdb), featureflags.ConfigProvider{})
+//	client.Refresh(ctx)
+//	go client.Run(ctx, 30*time.Second)
+//	featureflags.SetDefault(client)
+//
+//	if NewRetriever.Get(logCtx) { ... }
+//
+// A state is either a plain value, e.g. "true", or a JSON State with rules targeting users by their
+// logging.UserId, either by ID or by a stable percentage of all users.
+package featureflags
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+)
+
+// Value is the constraint of the supported flag value types
+type Value interface {
+	bool | string | int | float64
+}
+
+// Definition describes a defined flag independently of its value type
+type Definition struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	Type         string `json:"type"`         // Go type of the value: "bool", "string", "int" or "float64"
+	DefaultValue string `json:"defaultValue"` // serialized default value
+}
+
+// Flag is a typed feature flag
+type Flag[T Value] struct {
+	name         string
+	description  string
+	defaultValue T
+}
+
+// registry holds the definitions of all defined flags by name
+var registry = struct {
+	sync.RWMutex
+	definitions map[string]Definition
+}{definitions: map[string]Definition{}}
+
+// Define defines a flag; it panics if a flag with the same name is already defined, like the flag package
+//
+// Parameters:
+//   - name: the unique name of the flag, e.g. "new-retriever"
+//   - defaultValue: the value used if no provider sets the flag
+//   - description: the description of the flag
+//
+// Returns:
+//   - *Flag[T]: the flag
+func Define[T Value](name string, defaultValue T, description string) *Flag[T] {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.definitions[name]; ok {
+		panic(fmt.Sprintf("featureflags: flag %q defined twice", name))
+	}
+	registry.definitions[name] = Definition{
+		Name:         name,
+		Description:  description,
+		Type:         fmt.Sprintf("%T", defaultValue),
+		DefaultValue: formatValue(defaultValue),
+	}
+	return &Flag[T]{name: name, description: description, defaultValue: defaultValue}
+}
+
+// Definitions returns the definitions of all defined flags
+//
+// Returns:
+//   - []Definition: the definitions, sorted by name
+func Definitions() []Definition {
+	registry.RLock()
+	defer registry.RUnlock()
+	definitions := make([]Definition, 0, len(registry.definitions))
+	for _, definition := range registry.definitions {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Name < definitions[j].Name })
+	return definitions
+}
+
+// definedNames returns the names of all defined flags, sorted
+func definedNames() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.definitions))
+	for name := range registry.definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Name returns the name of the flag
+//
+// Returns:
+//   - string: the name
+func (f *Flag[T]) Name() string {
+	return f.name
+}
+
+// Default returns the default value of the flag
+//
+// Returns:
+//   - T: the default value
+func (f *Flag[T]) Default() T {
+	return f.defaultValue
+}
+
+// Get evaluates the flag with the client set by SetDefault
+//
+// Parameters:
+//   - ctx: the logging context, whose logging.UserId is used for targeting; can be nil
+//
+// Returns:
+//   - T: the value of the flag; the default value if no client is set
+func (f *Flag[T]) Get(ctx *logging.ContextMap) T {
+	return f.GetFrom(defaultClient.Load(), ctx)
+}
+
+// GetFrom evaluates the flag with the given client
+// Invalid values set by a provider are logged and the default value is used instead.
+//
+// Parameters:
+//   - client: the client holding the flag states; the default value is returned if nil
+//   - ctx: the logging context, whose logging.UserId is used for targeting; can be nil
+//
+// Returns:
+//   - T: the value of the flag
+func (f *Flag[T]) GetFrom(client *Client, ctx *logging.ContextMap) T {
+	if client == nil {
+		return f.defaultValue
+	}
+	state, ok := client.state(f.name)
+	if !ok {
+		return f.defaultValue
+	}
+	raw, ok := state.Evaluate(f.name, userId(ctx))
+	if !ok {
+		return f.defaultValue
+	}
+	value, err := parseValue[T](raw)
+	if err != nil {
+		logCtx := ctx
+		if logCtx == nil {
+			logCtx = &logging.ContextMap{}
+		}
+		logging.Log.Warnf(logCtx, "invalid value of feature flag %q: %v", f.name, err)
+		return f.defaultValue
+	}
+	return value
+}
+
+// userId returns the user ID of the logging context; empty if not set
+func userId(ctx *logging.ContextMap) string {
+	if ctx == nil {
+		return ""
+	}
+	value, _ := ctx.Get(logging.UserId)
+	id, _ := value.(string)
+	return id
+}
+
+// State is the state of a flag as stored by a provider
+type State struct {
+	Value string `json:"value,omitempty"` // serialized value for users not matching any rule; the default value of the flag if empty
+	Rules []Rule `json:"rules,omitempty"` // targeting rules, evaluated in order; the first matching rule wins
+}
+
+// Rule assigns a value to a set of users
+type Rule struct {
+	Value      string   `json:"value"`                // serialized value for the matching users
+	Users      []string `json:"users,omitempty"`      // IDs of the matching users
+	Percentage int      `json:"percentage,omitempty"` // share of all users matching the rule, from 0 to 100; stable per user and flag
+}
+
+// ParseState parses the raw state of a flag: either a JSON State or a plain value
+//
+// Parameters:
+//   - raw: the raw state, e.g. "true" or {"value": "false", "rules": [{"value": "true", "percentage": 10}]}
+//
+// Returns:
+//   - State: the state
+//   - error: an error if the JSON state is invalid
+func ParseState(raw string) (State, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.HasPrefix(raw, "{") {
+		return State{Value: raw}, nil
+	}
+	var state State
+	if err := json.Unmarshal([]byte(raw), &state); err != nil {
+		return State{}, fmt.Errorf("invalid feature flag state: %w", err)
+	}
+	return state, state.Validate()
+}
+
+// Validate checks that every rule targets users and has a valid percentage
+//
+// Returns:
+//   - error: an error listing the invalid rules; nil if valid
+func (s State) Validate() error {
+	var errs []error
+	for i, rule := range s.Rules {
+		if rule.Percentage < 0 || rule.Percentage > 100 {
+			errs = append(errs, fmt.Errorf("rule %d: percentage %d is not between 0 and 100", i, rule.Percentage))
+		}
+		if len(rule.Users) == 0 && rule.Percentage == 0 {
+			errs = append(errs, fmt.Errorf("rule %d: no users and no percentage", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Evaluate returns the serialized value of a flag for a user
+//
+// Parameters:
+//   - name: the name of the flag, used to assign users to percentages
+//   - userId: the ID of the user; rules never match if empty
+//
+// Returns:
+//   - string: the serialized value
+//   - bool: false if the state sets no value for the user, i.e. the default value applies
+func (s State) Evaluate(name string, userId string) (string, bool) {
+	if userId != "" {
+		for _, rule := range s.Rules {
+			if slices.Contains(rule.Users, userId) || (rule.Percentage > 0 && bucket(name, userId) < rule.Percentage) {
+				return rule.Value, true
+			}
+		}
+	}
+	return s.Value, s.Value != ""
+}
+
+// bucket assigns a user to one of 100 buckets, independently for every flag
+func bucket(name string, userId string) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	h.Write([]byte{0})
+	h.Write([]byte(userId))
+	return int(h.Sum32() % 100)
+}
+
+// formatValue serializes a flag value
+func formatValue[T Value](value T) string {
+	switch v := any(value).(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parseValue parses a serialized flag value
+func parseValue[T Value](raw string) (T, error) {
+	var value T
+	var err error
+	switch target := any(&value).(type) {
+	case *bool:
+		*target, err = strconv.ParseBool(raw)
+	case *string:
+		*target = raw
+	case *int:
+		*target, err = strconv.Atoi(raw)
+	case *float64:
+		*target, err = strconv.ParseFloat(raw, 64)
+	}
+	return value, err
+}
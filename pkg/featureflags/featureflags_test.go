@@ -0,0 +1,226 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package featureflags
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"go.uber.org/zap/zapcore"
+)
+
+// flags of the tests; flags can only be defined once, also if the tests run repeatedly
+var (
+	flagDefine     = Define("test-define", 3, "a test flag")
+	flagGetBool    = Define("test-get-bool", false, "")
+	flagGetString  = Define("test-get-string", "old", "")
+	flagGetInt     = Define("test-get-int", 1, "")
+	flagGetFloat   = Define("test-get-float", 0.5, "")
+	flagGetInvalid = Define("test-get-invalid", 7, "")
+	flagGetUnset   = Define("test-get-unset", true, "")
+	flagTargeting  = Define("test-targeting", "control", "")
+	flagPriority   = Define("test-priority", "default", "")
+	flagRefresh    = Define("test-refresh", 0, "")
+	flagRun        = Define("test-run", false, "")
+)
+
+// staticProvider sets the flags of its map
+type staticProvider map[string]string
+
+func (p staticProvider) Lookup(ctx context.Context, name string) (State, bool, error) {
+	raw, ok := p[name]
+	if !ok {
+		return State{}, false, nil
+	}
+	state, err := ParseState(raw)
+	return state, err == nil, err
+}
+
+// refreshedClient returns a client with the given providers, refreshed once
+func refreshedClient(t *testing.T, providers ...Provider) *Client {
+	t.Helper()
+	client := New(providers...)
+	if _, err := client.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	return client
+}
+
+// userContext returns a logging context with the given user ID
+func userContext(userId string) *logging.ContextMap {
+	ctx := &logging.ContextMap{}
+	ctx.Set(logging.UserId, userId)
+	return ctx
+}
+
+func TestDefine(t *testing.T) {
+	flag := flagDefine
+	if flag.Name() != "test-define" || flag.Default() != 3 {
+		t.Errorf("flag = %q, %v", flag.Name(), flag.Default())
+	}
+
+	found := false
+	for _, definition := range Definitions() {
+		if definition.Name == "test-define" {
+			found = true
+			if definition.Type != "int" || definition.DefaultValue != "3" || definition.Description != "a test flag" {
+				t.Errorf("definition = %+v", definition)
+			}
+		}
+	}
+	if !found {
+		t.Error("Definitions() does not contain test-define")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Define() of a duplicate flag did not panic")
+		}
+	}()
+	Define("test-define", false, "")
+}
+
+func TestFlagGet(t *testing.T) {
+	logs := logging.CaptureForTest(t)
+
+	client := refreshedClient(t, staticProvider{
+		"test-get-bool":    "true",
+		"test-get-string":  "new",
+		"test-get-int":     "42",
+		"test-get-float":   "0.25",
+		"test-get-invalid": "many",
+	})
+
+	if got := flagGetBool.GetFrom(client, nil); got != true {
+		t.Errorf("bool flag = %v, want true", got)
+	}
+	if got := flagGetString.GetFrom(client, nil); got != "new" {
+		t.Errorf("string flag = %v, want new", got)
+	}
+	if got := flagGetInt.GetFrom(client, nil); got != 42 {
+		t.Errorf("int flag = %v, want 42", got)
+	}
+	if got := flagGetFloat.GetFrom(client, nil); got != 0.25 {
+		t.Errorf("float flag = %v, want 0.25", got)
+	}
+	if got := flagGetUnset.GetFrom(client, nil); got != true {
+		t.Errorf("unset flag = %v, want default true", got)
+	}
+	if got := flagGetInvalid.GetFrom(client, nil); got != 7 {
+		t.Errorf("invalid flag = %v, want default 7", got)
+	}
+	if !logs.Contains(zapcore.WarnLevel, "test-get-invalid") {
+		t.Error("invalid value not logged")
+	}
+	if got := flagGetBool.GetFrom(nil, nil); got != false {
+		t.Errorf("flag without client = %v, want default false", got)
+	}
+
+	// the default client is used by Get
+	defer SetDefault(nil)
+	if got := flagGetBool.Get(nil); got != false {
+		t.Errorf("Get() without default client = %v, want false", got)
+	}
+	SetDefault(client)
+	if got := flagGetBool.Get(nil); got != true {
+		t.Errorf("Get() with default client = %v, want true", got)
+	}
+}
+
+func TestFlagTargeting(t *testing.T) {
+	flag := flagTargeting
+	client := refreshedClient(t, staticProvider{
+		"test-targeting": `{"rules": [{"value": "beta", "users": ["alice"]}, {"value": "canary", "percentage": 30}]}`,
+	})
+
+	if got := flag.GetFrom(client, userContext("alice")); got != "beta" {
+		t.Errorf("targeted user = %v, want beta", got)
+	}
+	if got := flag.GetFrom(client, nil); got != "control" {
+		t.Errorf("no user = %v, want control", got)
+	}
+
+	// about 30% of the users get the canary value, always the same ones
+	canary := 0
+	for i := 0; i < 1000; i++ {
+		ctx := userContext(fmt.Sprintf("user-%d", i))
+		value := flag.GetFrom(client, ctx)
+		if value == "canary" {
+			canary++
+		}
+		if again := flag.GetFrom(client, ctx); again != value {
+			t.Fatalf("user-%d got %v, then %v", i, value, again)
+		}
+	}
+	if canary < 250 || canary > 350 {
+		t.Errorf("%d of 1000 users got the canary value, want about 300", canary)
+	}
+}
+
+func TestParseState(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"plain value", " true ", "true", false},
+		{"json state", `{"value": "false", "rules": [{"value": "true", "percentage": 10}]}`, "false", false},
+		{"invalid json", `{"value": `, "", true},
+		{"percentage out of range", `{"rules": [{"value": "true", "percentage": 101}]}`, "", true},
+		{"rule without target", `{"rules": [{"value": "true"}]}`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := ParseState(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && state.Value != tt.want {
+				t.Errorf("ParseState() value = %q, want %q", state.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestStateEvaluate(t *testing.T) {
+	state := State{Rules: []Rule{{Value: "on", Users: []string{"bob"}}}}
+	if value, ok := state.Evaluate("flag", "bob"); !ok || value != "on" {
+		t.Errorf("Evaluate(bob) = %q, %v", value, ok)
+	}
+	if _, ok := state.Evaluate("flag", "carol"); ok {
+		t.Error("Evaluate(carol) without value should use the default")
+	}
+
+	// buckets are independent per flag
+	differs := false
+	for i := 0; i < 100 && !differs; i++ {
+		user := fmt.Sprintf("user-%d", i)
+		differs = bucket("flag-a", user) != bucket("flag-b", user)
+	}
+	if !differs {
+		t.Error("buckets of different flags are identical")
+	}
+}
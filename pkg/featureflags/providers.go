@@ -0,0 +1,169 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/ansys/aali-sharedtypes/pkg/clients/kvdbclient"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+// Provider reads the state of flags from a source
+type Provider interface {
+	// Lookup returns the state of a flag; found is false if the source does not set the flag
+	Lookup(ctx context.Context, name string) (state State, found bool, err error)
+}
+
+// ConfigProvider reads flags from the FEATURE_FLAGS map of config.Current()
+type ConfigProvider struct{}
+
+// Lookup returns the state of a flag from FEATURE_FLAGS
+//
+// Parameters:
+//   - ctx: the context; unused
+//   - name: the name of the flag
+//
+// Returns:
+//   - state: the state of the flag
+//   - found: true if FEATURE_FLAGS contains the flag
+//   - err: an error if the state is invalid
+func (ConfigProvider) Lookup(ctx context.Context, name string) (state State, found bool, err error) {
+	if config.Current() == nil {
+		return State{}, false, nil
+	}
+	raw, ok := config.Current().FEATURE_FLAGS[name]
+	if !ok {
+		return State{}, false, nil
+	}
+	state, err = ParseState(raw)
+	return state, err == nil, err
+}
+
+// DefaultEnvPrefix is the prefix of the environment variables read by an EnvProvider without prefix
+const DefaultEnvPrefix = "AALI_FEATURE_"
+
+// EnvProvider reads flags from environment variables named after the flag,
+// e.g. AALI_FEATURE_NEW_RETRIEVER for the flag "new-retriever"
+type EnvProvider struct {
+	Prefix string // prefix of the variables; DefaultEnvPrefix if empty
+}
+
+// Lookup returns the state of a flag from its environment variable
+//
+// Parameters:
+//   - ctx: the context; unused
+//   - name: the name of the flag
+//
+// Returns:
+//   - state: the state of the flag
+//   - found: true if the variable is set
+//   - err: an error if the state is invalid
+func (p EnvProvider) Lookup(ctx context.Context, name string) (state State, found bool, err error) {
+	raw, ok := os.LookupEnv(p.VariableName(name))
+	if !ok {
+		return State{}, false, nil
+	}
+	state, err = ParseState(raw)
+	return state, err == nil, err
+}
+
+// VariableName returns the name of the environment variable of a flag
+//
+// Parameters:
+//   - name: the name of the flag
+//
+// Returns:
+//   - string: the name of the variable; characters other than letters and digits are replaced by "_"
+func (p EnvProvider) VariableName(name string) string {
+	prefix := p.Prefix
+	if prefix == "" {
+		prefix = DefaultEnvPrefix
+	}
+	return prefix + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// DefaultKVDBPrefix is the key prefix of flags in KVDB
+const DefaultKVDBPrefix = "featureflags/"
+
+// KVDBProvider reads flags from aali-kvdb, so they can be changed at runtime for all services at once
+type KVDBProvider struct {
+	client *kvdbclient.Client
+	prefix string
+}
+
+// NewKVDBProvider creates a provider reading the flags from the keys "featureflags/<name>"
+//
+// Parameters:
+//   - client: the KVDB client
+//
+// Returns:
+//   - *KVDBProvider: the provider
+func NewKVDBProvider(client *kvdbclient.Client) *KVDBProvider {
+	return &KVDBProvider{client: client, prefix: DefaultKVDBPrefix}
+}
+
+// WithPrefix sets the key prefix of the flags
+//
+// Parameters:
+//   - prefix: the key prefix
+//
+// Returns:
+//   - *KVDBProvider: the provider
+func (p *KVDBProvider) WithPrefix(prefix string) *KVDBProvider {
+	p.prefix = prefix
+	return p
+}
+
+// Lookup returns the state of a flag from KVDB
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - name: the name of the flag
+//
+// Returns:
+//   - state: the state of the flag
+//   - found: true if the key of the flag exists
+//   - err: an error if the request fails or the state is invalid
+func (p *KVDBProvider) Lookup(ctx context.Context, name string) (state State, found bool, err error) {
+	entry, err := p.client.Get(ctx, p.prefix+name)
+	if errors.Is(err, kvdbclient.ErrNotFound) {
+		return State{}, false, nil
+	}
+	if err != nil {
+		return State{}, false, err
+	}
+	state, err = ParseState(entry.Value)
+	return state, err == nil, err
+}
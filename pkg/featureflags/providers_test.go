@@ -0,0 +1,128 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package featureflags
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/clients/kvdbclient"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+)
+
+func TestConfigProvider(t *testing.T) {
+	defer func(previous *config.Config) { config.GlobalConfig = previous }(config.GlobalConfig)
+
+	config.GlobalConfig = nil
+	if _, found, err := (ConfigProvider{}).Lookup(context.Background(), "flag"); found || err != nil {
+		t.Errorf("Lookup() without config = %v, %v", found, err)
+	}
+
+	config.GlobalConfig = &config.Config{FEATURE_FLAGS: map[string]string{"flag": "true", "broken": "{"}}
+	state, found, err := (ConfigProvider{}).Lookup(context.Background(), "flag")
+	if !found || err != nil || state.Value != "true" {
+		t.Errorf("Lookup() = %+v, %v, %v", state, found, err)
+	}
+	if _, found, _ := (ConfigProvider{}).Lookup(context.Background(), "missing"); found {
+		t.Error("Lookup() of missing flag found")
+	}
+	if _, found, err := (ConfigProvider{}).Lookup(context.Background(), "broken"); found || err == nil {
+		t.Errorf("Lookup() of invalid state = %v, %v, want error", found, err)
+	}
+}
+
+func TestEnvProvider(t *testing.T) {
+	if got := (EnvProvider{}).VariableName("new-retriever.v2"); got != "AALI_FEATURE_NEW_RETRIEVER_V2" {
+		t.Errorf("VariableName() = %q", got)
+	}
+
+	t.Setenv("AALI_FEATURE_NEW_RETRIEVER", "true")
+	t.Setenv("CUSTOM_NEW_RETRIEVER", "false")
+
+	state, found, err := (EnvProvider{}).Lookup(context.Background(), "new-retriever")
+	if !found || err != nil || state.Value != "true" {
+		t.Errorf("Lookup() = %+v, %v, %v", state, found, err)
+	}
+	state, found, err = (EnvProvider{Prefix: "CUSTOM_"}).Lookup(context.Background(), "new-retriever")
+	if !found || err != nil || state.Value != "false" {
+		t.Errorf("Lookup() with prefix = %+v, %v, %v", state, found, err)
+	}
+	if _, found, _ := (EnvProvider{}).Lookup(context.Background(), "unset-flag"); found {
+		t.Error("Lookup() of unset variable found")
+	}
+}
+
+func TestKVDBProvider(t *testing.T) {
+	logging.CaptureForTest(t)
+	if config.GlobalConfig == nil {
+		config.GlobalConfig = &config.Config{}
+	}
+	values := map[string]string{
+		"featureflags/flag":   `{"value": "on", "rules": [{"value": "off", "users": ["bob"]}]}`,
+		"custom/flag":         "custom",
+		"featureflags/broken": "{",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/v1/keys/")
+		if key == "featureflags/failing" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		value, ok := values[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(kvdbclient.Entry{Key: key, Value: value, Version: 1})
+	}))
+	defer server.Close()
+	client, err := kvdbclient.NewClient(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := NewKVDBProvider(client)
+
+	state, found, err := provider.Lookup(context.Background(), "flag")
+	if !found || err != nil || state.Value != "on" || len(state.Rules) != 1 {
+		t.Errorf("Lookup() = %+v, %v, %v", state, found, err)
+	}
+	if _, found, err := provider.Lookup(context.Background(), "missing"); found || err != nil {
+		t.Errorf("Lookup() of missing key = %v, %v", found, err)
+	}
+	if _, found, err := provider.Lookup(context.Background(), "broken"); found || err == nil {
+		t.Errorf("Lookup() of invalid state = %v, %v, want error", found, err)
+	}
+	if _, found, err := provider.Lookup(context.Background(), "failing"); found || err == nil {
+		t.Errorf("Lookup() of failing request = %v, %v, want error", found, err)
+	}
+
+	state, found, err = provider.WithPrefix("custom/").Lookup(context.Background(), "flag")
+	if !found || err != nil || state.Value != "custom" {
+		t.Errorf("Lookup() with prefix = %+v, %v, %v", state, found, err)
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package hashing computes stable hashes of values and definitions, for caching, change detection and audit logs.
+//
+// Values are hashed in a canonical JSON form: object keys are sorted, insignificant whitespace is
+// removed, HTML characters are not escaped and numbers are normalized, so 1, 1.0 and 1e0 hash the same.
+// Integers are written in decimal, other numbers in the shortest form of strconv.FormatFloat with
+// format 'g'. Hashes have the format "sha256:<hex digest>" of sharedtypes.ComputeContentHash.
+//
+// The canonical form is part of the contract of this package: hashes stored by one version must
+// be reproduced by every later version.
+package hashing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/ansys/aali-sharedtypes/pkg/typeconverters"
+)
+
+// maxExactInteger is the largest integer that float64 represents exactly.
+const maxExactInteger = 1 << 53
+
+// Canonicalize returns the canonical JSON form of a value.
+//
+// Parameters:
+//   - value: the value; it is encoded with encoding/json first
+//
+// Returns:
+//   - []byte: the canonical JSON
+//   - error: an error if the value cannot be encoded
+func Canonicalize(value interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding value: %w", err)
+	}
+	return CanonicalizeJSON(encoded)
+}
+
+// CanonicalizeJSON returns the canonical form of a JSON document.
+//
+// Parameters:
+//   - document: the JSON document
+//
+// Returns:
+//   - []byte: the canonical JSON
+//   - error: an error if the document is not valid JSON
+func CanonicalizeJSON(document []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("error decoding JSON: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("error decoding JSON: unexpected data after the document")
+	}
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, decoded); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Hash returns the hash of the canonical JSON form of a value.
+//
+// Parameters:
+//   - value: the value
+//
+// Returns:
+//   - string: the hash in the format "sha256:<hex digest>"
+//   - error: an error if the value cannot be encoded
+func Hash(value interface{}) (string, error) {
+	canonical, err := Canonicalize(value)
+	if err != nil {
+		return "", err
+	}
+	return sharedtypes.ComputeContentHash(canonical), nil
+}
+
+// HashJSON returns the hash of the canonical form of a JSON document.
+//
+// Parameters:
+//   - document: the JSON document
+//
+// Returns:
+//   - string: the hash in the format "sha256:<hex digest>"
+//   - error: an error if the document is not valid JSON
+func HashJSON(document []byte) (string, error) {
+	canonical, err := CanonicalizeJSON(document)
+	if err != nil {
+		return "", err
+	}
+	return sharedtypes.ComputeContentHash(canonical), nil
+}
+
+// HashFilledValue returns the hash of a filled input/output, covering its name, Go type and value.
+// Values received in serialized form are decoded first, so the hash does not depend on how the value arrived.
+//
+// Parameters:
+//   - value: the filled input/output
+//
+// Returns:
+//   - string: the hash
+//   - error: an error if the value cannot be decoded or encoded
+func HashFilledValue(value sharedtypes.FilledInputOutput) (string, error) {
+	decoded, err := typeconverters.FilledValue(&value)
+	if err != nil {
+		return "", fmt.Errorf("error decoding value of '%s': %w", value.Name, err)
+	}
+	return Hash(map[string]interface{}{"name": value.Name, "go_type": value.GoType, "value": decoded})
+}
+
+// HashFilledValues returns the hash of filled inputs/outputs by name, e.g. the inputs of a function call.
+//
+// Parameters:
+//   - values: the filled inputs/outputs by name
+//
+// Returns:
+//   - string: the hash
+//   - error: an error if a value cannot be decoded or encoded
+func HashFilledValues(values map[string]sharedtypes.FilledInputOutput) (string, error) {
+	hashes := make(map[string]string, len(values))
+	for name, value := range values {
+		hash, err := HashFilledValue(value)
+		if err != nil {
+			return "", err
+		}
+		hashes[name] = hash
+	}
+	return Hash(hashes)
+}
+
+// HashFunctionDefinition returns the hash of a function definition.
+// The deployment specific FlowkitUrl and ApiKey are excluded, so the same function hashes the same on every
+// server and no secret ends up in audit logs. Fields added to FunctionDefinition must be omitempty to keep
+// the hashes of existing definitions stable.
+//
+// Parameters:
+//   - definition: the function definition
+//
+// Returns:
+//   - string: the hash
+//   - error: an error if the definition cannot be encoded
+func HashFunctionDefinition(definition sharedtypes.FunctionDefinition) (string, error) {
+	definition.FlowkitUrl = ""
+	definition.ApiKey = ""
+	return Hash(definition)
+}
+
+// HashWorkflowDefinition returns the hash of a workflow definition document.
+//
+// Parameters:
+//   - definition: the JSON workflow definition, e.g. the Definition of a workflowstore.Workflow
+//
+// Returns:
+//   - string: the hash
+//   - error: an error if the definition is not valid JSON
+func HashWorkflowDefinition(definition []byte) (string, error) {
+	return HashJSON(definition)
+}
+
+// writeCanonical writes the canonical form of a value decoded with json.Decoder.UseNumber.
+func writeCanonical(buf *bytes.Buffer, value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(value))
+	case json.Number:
+		number, err := canonicalNumber(value)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(number)
+	case string:
+		writeString(buf, value)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, element := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, element); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeString(buf, key)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, value[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected JSON value of type %T", value)
+	}
+	return nil
+}
+
+// canonicalNumber normalizes a JSON number.
+func canonicalNumber(number json.Number) (string, error) {
+	if integer, err := strconv.ParseInt(string(number), 10, 64); err == nil {
+		return strconv.FormatInt(integer, 10), nil
+	}
+	float, err := strconv.ParseFloat(string(number), 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid number '%s': %w", number, err)
+	}
+	if float == math.Trunc(float) && math.Abs(float) < maxExactInteger {
+		return strconv.FormatInt(int64(float), 10), nil
+	}
+	return strconv.FormatFloat(float, 'g', -1, 64), nil
+}
+
+// writeString writes a JSON string without HTML escaping.
+func writeString(buf *bytes.Buffer, s string) {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	buf.Truncate(buf.Len() - 1) // Encode appends a newline
+}
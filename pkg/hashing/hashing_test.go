@@ -0,0 +1,179 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package hashing
+
+import (
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+func TestCanonicalizeJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     string
+	}{
+		{"sorted keys", `{"b":1,"a":{"d":[3,2],"c":null}}`, `{"a":{"c":null,"d":[3,2]},"b":1}`},
+		{"whitespace", " {\n\t\"a\" : [ true , false ] } ", `{"a":[true,false]}`},
+		{"integers", `[1, 1.0, 1e0, 10E-1, -0, -0.0, 9007199254740993]`, `[1,1,1,1,0,0,9007199254740993]`},
+		{"floats", `[0.1, 1.50, 2.5e-3, 1e21, 1.0e300]`, `[0.1,1.5,0.0025,1e+21,1e+300]`},
+		{"strings", `"<a & b> é \"q\""`, `"<a & b> é \"q\""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalizeJSON([]byte(tt.document))
+			if err != nil {
+				t.Fatalf("CanonicalizeJSON() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("CanonicalizeJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	for _, invalid := range []string{``, `{`, `{"a":1} {}`} {
+		if _, err := CanonicalizeJSON([]byte(invalid)); err == nil {
+			t.Errorf("CanonicalizeJSON(%q) succeeded", invalid)
+		}
+	}
+}
+
+func TestHashEquivalentValues(t *testing.T) {
+	type point struct {
+		Y float64 `json:"y"`
+		X int     `json:"x"`
+	}
+	fromStruct, err := Hash(point{X: 1, Y: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromMap, err := Hash(map[string]interface{}{"x": 1.0, "y": int64(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := HashJSON([]byte(`{ "y": 2.0, "x": 1 }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromStruct != fromMap || fromStruct != fromJSON {
+		t.Errorf("hashes differ: %s, %s, %s", fromStruct, fromMap, fromJSON)
+	}
+	if other, _ := Hash(point{X: 1, Y: 3}); other == fromStruct {
+		t.Error("different values have the same hash")
+	}
+}
+
+func TestHashFilledValue(t *testing.T) {
+	decoded := sharedtypes.FilledInputOutput{Name: "values", GoType: "[]int", Value: []int{1, 2, 3}}
+	serialized := sharedtypes.NewSerializedFilledInputOutput("values", "[]int", "[1,2,3]")
+
+	decodedHash, err := HashFilledValue(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serializedHash, err := HashFilledValue(serialized)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decodedHash != serializedHash {
+		t.Errorf("decoded and serialized values hash differently: %s, %s", decodedHash, serializedHash)
+	}
+	if renamed, _ := HashFilledValue(sharedtypes.FilledInputOutput{Name: "other", GoType: "[]int", Value: []int{1, 2, 3}}); renamed == decodedHash {
+		t.Error("the name is not part of the hash")
+	}
+
+	inputs := map[string]sharedtypes.FilledInputOutput{"values": decoded, "query": {Name: "query", GoType: "string", Value: "mesh"}}
+	first, err := HashFilledValues(inputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs["values"] = serialized
+	if second, _ := HashFilledValues(inputs); second != first {
+		t.Errorf("HashFilledValues() = %s, want %s", second, first)
+	}
+}
+
+func TestHashFunctionDefinition(t *testing.T) {
+	definition := sharedtypes.FunctionDefinition{
+		Name:       "search",
+		FlowkitUrl: "http://flowkit-a:50051",
+		ApiKey:     "secret",
+		Inputs:     []sharedtypes.FunctionInput{{Name: "query", GoType: "string"}},
+	}
+	first, err := HashFunctionDefinition(definition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	definition.FlowkitUrl, definition.ApiKey = "http://flowkit-b:50051", "other"
+	if second, _ := HashFunctionDefinition(definition); second != first {
+		t.Error("the hash depends on the server of the function")
+	}
+	definition.Inputs[0].GoType = "[]string"
+	if third, _ := HashFunctionDefinition(definition); third == first {
+		t.Error("the hash does not depend on the inputs")
+	}
+}
+
+// TestHashStability pins hashes computed by earlier versions. A failure means stored hashes are invalidated;
+// never update the expected values, fix the canonical form instead.
+func TestHashStability(t *testing.T) {
+	tests := []struct {
+		name string
+		hash func() (string, error)
+		want string
+	}{
+		{"json", func() (string, error) {
+			return HashJSON([]byte(`{"b":[1.0,"x",null,true],"a":{"z":0.5,"y":-2}}`))
+		}, "sha256:f2f72fa0a57117de7b56657b6264aedad25cb63932214781d974773ffa23fda6"},
+		{"filled value", func() (string, error) {
+			return HashFilledValue(sharedtypes.FilledInputOutput{Name: "query", GoType: "string", Value: "mesh quality"})
+		}, "sha256:f30928c6f195872965b9041dbca545c24635500ffeaa09b04114abda6d137c81"},
+		{"function definition", func() (string, error) {
+			return HashFunctionDefinition(sharedtypes.FunctionDefinition{
+				Name:           "search",
+				Description:    "Search the knowledge base",
+				Category:       "knowledge_db",
+				Type:           "go",
+				Inputs:         []sharedtypes.FunctionInput{{Name: "query", Type: "string", GoType: "string"}},
+				Outputs:        []sharedtypes.FunctionOutput{{Name: "results", Type: "json", GoType: "[]string"}},
+				TimeoutSeconds: 30,
+				Idempotent:     true,
+			})
+		}, "sha256:ce3791aa9530c8b7803fcfb3ab6936e15855db9602ac559c48502f4fec40aef4"},
+		{"workflow definition", func() (string, error) {
+			return HashWorkflowDefinition([]byte(`{"id":"chat","nodes":[{"id":"n1","function":"search","inputs":{"query":"{{input}}"}}]}`))
+		}, "sha256:b330f807fd838ee895582443f711639ebbb90e3263f9ff58aee1f20c143cc4ea"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.hash()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("hash = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aali_graphdb"
+	"github.com/ansys/aali-sharedtypes/pkg/clients/flowkitclient"
+	"github.com/ansys/aali-sharedtypes/pkg/clients/kvdbclient"
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+// kvdbHealthKey is the key read by the KVDB check; it does not need to exist
+const kvdbHealthKey = "__aali_health__"
+
+// FlowkitChecker checks that a FlowKit server is reachable and answers its health endpoint
+//
+// Parameters:
+//   - url: the URL of the FlowKit server
+//   - apiKey: the API key of the FlowKit server
+//
+// Returns:
+//   - Checker: the checker
+func FlowkitChecker(url string, apiKey string) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		// the client call has no context; the registry bounds its duration
+		return flowkitclient.HealthCheck(url, apiKey)
+	})
+}
+
+// KVDBChecker checks that the KVDB is reachable by reading a key
+// A missing key is healthy, since the KVDB answered.
+//
+// Parameters:
+//   - client: the KVDB client
+//
+// Returns:
+//   - Checker: the checker
+func KVDBChecker(client *kvdbclient.Client) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		_, err := client.Get(ctx, kvdbHealthKey)
+		if err != nil && !errors.Is(err, kvdbclient.ErrNotFound) {
+			return err
+		}
+		return nil
+	})
+}
+
+// GraphDBChecker checks that the GraphDB answers its health endpoint
+//
+// Parameters:
+//   - client: the GraphDB client
+//
+// Returns:
+//   - Checker: the checker
+func GraphDBChecker(client *aali_graphdb.Client) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		healthy, err := client.GetHealth()
+		if err != nil {
+			return err
+		}
+		if !healthy {
+			return fmt.Errorf("graphdb is not healthy")
+		}
+		return nil
+	})
+}
+
+// RegisterFromConfig registers a check for every dependency configured in the global configuration
+// Checks are named "flowkit" (or "flowkit-<n>" for several servers), "kvdb" and "graphdb";
+// dependencies without an endpoint are skipped.
+//
+// Parameters:
+//   - registry: the registry to add the checks to
+//   - critical: true if the service cannot serve requests without its dependencies
+//
+// Returns:
+//   - error: an error if a client cannot be created
+func RegisterFromConfig(registry *Registry, critical bool) error {
+	connections := config.Current().FLOWKIT_CONNECTIONS
+	if len(connections) == 0 && config.Current().EXTERNALFUNCTIONS_ENDPOINT != "" {
+		connections = []config.FlowkitConnection{{
+			URL:     config.Current().EXTERNALFUNCTIONS_ENDPOINT,
+			API_KEY: config.Current().FLOWKIT_API_KEY,
+		}}
+	}
+	for i, connection := range connections {
+		name := "flowkit"
+		if len(connections) > 1 {
+			name = fmt.Sprintf("flowkit-%d", i)
+		}
+		registry.Register(name, FlowkitChecker(connection.URL, connection.API_KEY), critical)
+	}
+
+	if config.Current().KVDB_ENDPOINT != "" {
+		client, err := kvdbclient.NewClientFromConfig()
+		if err != nil {
+			return fmt.Errorf("unable to create kvdb client: %w", err)
+		}
+		registry.Register("kvdb", KVDBChecker(client), critical)
+	}
+
+	if config.Current().GRAPHDB_ADDRESS != "" {
+		client, err := aali_graphdb.DefaultClient(config.Current().GRAPHDB_ADDRESS, config.Current().GRAPHDB_API_KEY)
+		if err != nil {
+			return fmt.Errorf("unable to create graphdb client: %w", err)
+		}
+		registry.Register("graphdb", GraphDBChecker(client), critical)
+	}
+	return nil
+}
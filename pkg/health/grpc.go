@@ -0,0 +1,157 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultWatchInterval is the interval at which Watch re-evaluates the checks
+const DefaultWatchInterval = 10 * time.Second
+
+// GRPCServer serves a Registry as the standard gRPC health service
+// The empty service name is the overall health of the service; any other name is a registered check.
+type GRPCServer struct {
+	healthpb.UnimplementedHealthServer
+
+	registry      *Registry
+	watchInterval time.Duration
+}
+
+// NewGRPCServer creates a gRPC health service for a registry
+//
+// Parameters:
+//   - registry: the registry
+//
+// Returns:
+//   - *GRPCServer: the service, to register with healthpb.RegisterHealthServer
+func NewGRPCServer(registry *Registry) *GRPCServer {
+	return &GRPCServer{registry: registry, watchInterval: DefaultWatchInterval}
+}
+
+// Register registers the health service of a registry on a gRPC server
+//
+// Parameters:
+//   - server: the gRPC server
+//   - registry: the registry
+func Register(server *grpc.Server, registry *Registry) {
+	healthpb.RegisterHealthServer(server, NewGRPCServer(registry))
+}
+
+// Check returns the health of the service or of one check
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - req: the request; an empty service is the whole service
+//
+// Returns:
+//   - *healthpb.HealthCheckResponse: the serving status
+//   - error: a NotFound error if no check has the requested name
+func (s *GRPCServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	servingStatus, ok := s.status(ctx, req.GetService())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+// List returns the health of the service and of every check
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - req: the request
+//
+// Returns:
+//   - *healthpb.HealthListResponse: the serving status per service
+//   - error: always nil
+func (s *GRPCServer) List(ctx context.Context, req *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	report := s.registry.Check(ctx)
+	statuses := map[string]*healthpb.HealthCheckResponse{
+		"": {Status: servingStatus(report.Status)},
+	}
+	for _, result := range report.Checks {
+		statuses[result.Name] = &healthpb.HealthCheckResponse{Status: servingStatus(result.Status)}
+	}
+	return &healthpb.HealthListResponse{Statuses: statuses}, nil
+}
+
+// Watch streams the health of the service or of one check whenever it changes
+// Unknown services are reported as SERVICE_UNKNOWN, as the health protocol requires.
+//
+// Parameters:
+//   - req: the request; an empty service is the whole service
+//   - stream: the stream to send the serving status to
+//
+// Returns:
+//   - error: the error of the stream, or nil when the client cancels
+func (s *GRPCServer) Watch(req *healthpb.HealthCheckRequest, stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse]) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		servingStatus, ok := s.status(ctx, req.GetService())
+		if !ok {
+			servingStatus = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if servingStatus != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: servingStatus}); err != nil {
+				return err
+			}
+			last = servingStatus
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// status returns the serving status of the service or of one check
+func (s *GRPCServer) status(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	if service == "" {
+		return servingStatus(s.registry.Check(ctx).Status), true
+	}
+	result, ok := s.registry.CheckOne(ctx, service)
+	if !ok {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, false
+	}
+	return servingStatus(result.Status), true
+}
+
+// servingStatus converts a status to a gRPC serving status; a degraded service still serves requests
+func servingStatus(s Status) healthpb.HealthCheckResponse_ServingStatus {
+	if s == StatusDown {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
@@ -0,0 +1,341 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package health aggregates the health of a service and its dependencies, so every service exposes
+// the same /healthz report over HTTP and the standard gRPC health service.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status is the health of a check or of the whole service
+type Status string
+
+// Health states
+const (
+	StatusUp       Status = "up"       // all checks pass
+	StatusDegraded Status = "degraded" // a non-critical check fails; the service still serves requests
+	StatusDown     Status = "down"     // a critical check fails
+)
+
+// Default settings of a Registry
+const (
+	DefaultCacheTTL = 5 * time.Second
+	DefaultTimeout  = 3 * time.Second
+)
+
+// Checker checks the health of a dependency
+type Checker interface {
+	// Check returns nil if the dependency is healthy
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc is a function implementing Checker
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls the function
+//
+// Parameters:
+//   - ctx: the context of the check, cancelled after the timeout of the registry
+//
+// Returns:
+//   - error: nil if the dependency is healthy
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Result is the result of a single check
+type Result struct {
+	Name       string    `json:"name"`
+	Status     Status    `json:"status"` // StatusUp or StatusDown
+	Critical   bool      `json:"critical"`
+	Error      string    `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checkedAt"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+// Report is the aggregated health of a service
+type Report struct {
+	Status Status   `json:"status"`
+	Checks []Result `json:"checks"` // ordered by name
+}
+
+// Registry holds the named checks of a service and caches their results
+type Registry struct {
+	mutex    sync.Mutex
+	checks   map[string]*check
+	cacheTTL time.Duration
+	timeout  time.Duration
+}
+
+// check is a registered checker with its cached result
+type check struct {
+	checker  Checker
+	critical bool
+
+	mutex   sync.Mutex // guards result and running
+	result  Result
+	running chan struct{} // closed when the current run ends, so concurrent requests share one result; nil if not running
+}
+
+// Option configures a Registry
+type Option func(*Registry)
+
+// WithCacheTTL sets the duration results are reused before a check runs again
+//
+// Parameters:
+//   - ttl: the duration; 0 disables caching
+//
+// Returns:
+//   - Option: the option
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(r *Registry) {
+		r.cacheTTL = ttl
+	}
+}
+
+// WithTimeout sets the maximum duration of a check; slower checks are reported down
+//
+// Parameters:
+//   - timeout: the maximum duration
+//
+// Returns:
+//   - Option: the option
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *Registry) {
+		r.timeout = timeout
+	}
+}
+
+// NewRegistry creates an empty registry
+//
+// Parameters:
+//   - options: the options; DefaultCacheTTL and DefaultTimeout apply if not set
+//
+// Returns:
+//   - *Registry: the registry
+func NewRegistry(options ...Option) *Registry {
+	registry := &Registry{
+		checks:   map[string]*check{},
+		cacheTTL: DefaultCacheTTL,
+		timeout:  DefaultTimeout,
+	}
+	for _, option := range options {
+		option(registry)
+	}
+	return registry
+}
+
+// Register adds a check, replacing a check with the same name
+// A failing critical check makes the service down; a failing non-critical check makes it degraded.
+//
+// Parameters:
+//   - name: the name of the check, e.g. "kvdb"
+//   - checker: the checker
+//   - critical: true if the service cannot serve requests without the dependency
+func (r *Registry) Register(name string, checker Checker, critical bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.checks[name] = &check{checker: checker, critical: critical}
+}
+
+// Names returns the names of the registered checks
+//
+// Returns:
+//   - []string: the names in ascending order
+func (r *Registry) Names() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check runs all checks concurrently, reusing results younger than the cache TTL
+//
+// Parameters:
+//   - ctx: the context of the request
+//
+// Returns:
+//   - Report: the aggregated health
+func (r *Registry) Check(ctx context.Context) Report {
+	names := r.Names()
+	results := make([]Result, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			results[i], _ = r.CheckOne(ctx, name)
+		}(i, name)
+	}
+	wg.Wait()
+	return Report{Status: aggregate(results), Checks: results}
+}
+
+// CheckOne runs a single check, reusing its result if younger than the cache TTL
+// The check runs detached from ctx with the timeout of the registry, so a request cancelled during
+// the check is answered with a down result without caching it for the following requests.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - name: the name of the check
+//
+// Returns:
+//   - Result: the result of the check
+//   - bool: false if no check has this name
+func (r *Registry) CheckOne(ctx context.Context, name string) (Result, bool) {
+	r.mutex.Lock()
+	c, ok := r.checks[name]
+	r.mutex.Unlock()
+	if !ok {
+		return Result{}, false
+	}
+
+	c.mutex.Lock()
+	if !c.result.CheckedAt.IsZero() && time.Since(c.result.CheckedAt) < r.cacheTTL {
+		defer c.mutex.Unlock()
+		return c.result, true
+	}
+	running := c.running
+	if running == nil {
+		running = make(chan struct{})
+		c.running = running
+		go func() {
+			result := r.run(context.WithoutCancel(ctx), name, c)
+			c.mutex.Lock()
+			c.result, c.running = result, nil
+			c.mutex.Unlock()
+			close(running)
+		}()
+	}
+	c.mutex.Unlock()
+
+	select {
+	case <-running:
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		return c.result, true
+	case <-ctx.Done():
+		return Result{
+			Name:      name,
+			Status:    StatusDown,
+			Critical:  c.critical,
+			Error:     fmt.Sprintf("request ended before the check: %v", ctx.Err()),
+			CheckedAt: time.Now(),
+		}, true
+	}
+}
+
+// run runs a check with the timeout of the registry
+// The checker runs in its own goroutine, so checkers ignoring their context cannot block the report.
+func (r *Registry) run(ctx context.Context, name string, c *check) Result {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- fmt.Errorf("check panicked: %v", recovered)
+			}
+		}()
+		done <- c.checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("check timed out after %v", r.timeout)
+	}
+
+	result := Result{
+		Name:       name,
+		Status:     StatusUp,
+		Critical:   c.critical,
+		CheckedAt:  time.Now(),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// aggregate returns the status of a service from the results of its checks
+func aggregate(results []Result) Status {
+	status := StatusUp
+	for _, result := range results {
+		if result.Status == StatusUp {
+			continue
+		}
+		if result.Critical {
+			return StatusDown
+		}
+		status = StatusDegraded
+	}
+	return status
+}
+
+// Handler returns an HTTP handler serving the health report as JSON, e.g. on /healthz
+// The status code is 200 if the service is up or degraded and 503 if it is down.
+// The "check" query parameter limits the report to one check; an unknown check is reported down with status 404.
+//
+// Returns:
+//   - http.Handler: the handler
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var report Report
+		statusCode := http.StatusOK
+		if name := req.URL.Query().Get("check"); name != "" {
+			if result, ok := r.CheckOne(req.Context(), name); ok {
+				report = Report{Status: aggregate([]Result{result}), Checks: []Result{result}}
+			} else {
+				result = Result{Name: name, Status: StatusDown, Error: "unknown check", CheckedAt: time.Now()}
+				report, statusCode = Report{Status: StatusDown, Checks: []Result{result}}, http.StatusNotFound
+			}
+		} else {
+			report = r.Check(req.Context())
+		}
+
+		if report.Status == StatusDown && statusCode == http.StatusOK {
+			statusCode = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
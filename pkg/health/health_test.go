@@ -0,0 +1,246 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func healthy(context.Context) error { return nil }
+
+func failing(context.Context) error { return errors.New("connection refused") }
+
+func TestRegistryAggregation(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(r *Registry)
+		want     Status
+	}{
+		{"no checks", func(r *Registry) {}, StatusUp},
+		{"all up", func(r *Registry) {
+			r.Register("kvdb", CheckerFunc(healthy), true)
+			r.Register("graphdb", CheckerFunc(healthy), false)
+		}, StatusUp},
+		{"non-critical down", func(r *Registry) {
+			r.Register("kvdb", CheckerFunc(healthy), true)
+			r.Register("graphdb", CheckerFunc(failing), false)
+		}, StatusDegraded},
+		{"critical down", func(r *Registry) {
+			r.Register("kvdb", CheckerFunc(failing), true)
+			r.Register("graphdb", CheckerFunc(failing), false)
+		}, StatusDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			tt.register(registry)
+			report := registry.Check(context.Background())
+			if report.Status != tt.want {
+				t.Errorf("Status = %q, want %q", report.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistryReportOrderAndErrors(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("kvdb", CheckerFunc(failing), true)
+	registry.Register("flowkit", CheckerFunc(healthy), true)
+
+	report := registry.Check(context.Background())
+	if len(report.Checks) != 2 || report.Checks[0].Name != "flowkit" || report.Checks[1].Name != "kvdb" {
+		t.Fatalf("Checks = %+v, want flowkit then kvdb", report.Checks)
+	}
+	if report.Checks[0].Status != StatusUp || report.Checks[0].Error != "" {
+		t.Errorf("flowkit = %+v, want up without error", report.Checks[0])
+	}
+	if report.Checks[1].Status != StatusDown || report.Checks[1].Error != "connection refused" || !report.Checks[1].Critical {
+		t.Errorf("kvdb = %+v, want critical down with error", report.Checks[1])
+	}
+}
+
+func TestRegistryCache(t *testing.T) {
+	var calls atomic.Int32
+	counting := CheckerFunc(func(context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+
+	registry := NewRegistry(WithCacheTTL(time.Hour))
+	registry.Register("kvdb", counting, true)
+	registry.Check(context.Background())
+	registry.Check(context.Background())
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls with cache = %d, want 1", got)
+	}
+
+	calls.Store(0)
+	registry = NewRegistry(WithCacheTTL(0))
+	registry.Register("kvdb", counting, true)
+	registry.Check(context.Background())
+	registry.Check(context.Background())
+	if got := calls.Load(); got != 2 {
+		t.Errorf("calls without cache = %d, want 2", got)
+	}
+}
+
+func TestRegistryTimeoutAndPanic(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	registry := NewRegistry(WithTimeout(20 * time.Millisecond))
+	registry.Register("stuck", CheckerFunc(func(context.Context) error {
+		<-block // ignores its context
+		return nil
+	}), true)
+	registry.Register("panics", CheckerFunc(func(context.Context) error {
+		panic("boom")
+	}), false)
+
+	report := registry.Check(context.Background())
+	if report.Status != StatusDown {
+		t.Errorf("Status = %q, want down", report.Status)
+	}
+	for _, result := range report.Checks {
+		if result.Status != StatusDown || result.Error == "" {
+			t.Errorf("%s = %+v, want down with error", result.Name, result)
+		}
+	}
+}
+
+func TestCheckOneCancelledRequest(t *testing.T) {
+	registry := NewRegistry(WithCacheTTL(time.Hour))
+	registry.Register("kvdb", CheckerFunc(func(ctx context.Context) error {
+		select {
+		case <-time.After(50 * time.Millisecond):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}), true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if result, _ := registry.CheckOne(ctx, "kvdb"); result.Status != StatusDown {
+		t.Errorf("CheckOne() of a cancelled request = %+v, want down", result)
+	}
+
+	// the check was not cancelled with the request and its result is cached
+	result, _ := registry.CheckOne(context.Background(), "kvdb")
+	if result.Status != StatusUp {
+		t.Errorf("CheckOne() after a cancelled request = %+v, want up", result)
+	}
+	if again, _ := registry.CheckOne(ctx, "kvdb"); again != result {
+		t.Errorf("CheckOne() = %+v, want the cached result %+v", again, result)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("kvdb", CheckerFunc(healthy), true)
+	registry.Register("graphdb", CheckerFunc(failing), false)
+
+	tests := []struct {
+		name       string
+		target     string
+		wantCode   int
+		wantStatus Status
+		wantChecks int
+	}{
+		{"all", "/healthz", http.StatusOK, StatusDegraded, 2},
+		{"one check", "/healthz?check=kvdb", http.StatusOK, StatusUp, 1},
+		{"unknown check", "/healthz?check=qdrant", http.StatusNotFound, StatusDown, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			registry.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if rec.Code != tt.wantCode || rec.Header().Get("Content-Type") != "application/json" {
+				t.Fatalf("code = %d, Content-Type = %q, want %d with JSON", rec.Code, rec.Header().Get("Content-Type"), tt.wantCode)
+			}
+			var report Report
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				t.Fatalf("invalid body: %v", err)
+			}
+			if report.Status != tt.wantStatus || len(report.Checks) != tt.wantChecks {
+				t.Errorf("report = %+v, want status %q with %d checks", report, tt.wantStatus, tt.wantChecks)
+			}
+		})
+	}
+
+	registry.Register("kvdb", CheckerFunc(failing), true)
+	rec := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("code when down = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestGRPCServer(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("kvdb", CheckerFunc(failing), true)
+	registry.Register("graphdb", CheckerFunc(healthy), false)
+	server := NewGRPCServer(registry)
+	ctx := context.Background()
+
+	tests := []struct {
+		service string
+		want    healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{"", healthpb.HealthCheckResponse_NOT_SERVING},
+		{"kvdb", healthpb.HealthCheckResponse_NOT_SERVING},
+		{"graphdb", healthpb.HealthCheckResponse_SERVING},
+	}
+	for _, tt := range tests {
+		resp, err := server.Check(ctx, &healthpb.HealthCheckRequest{Service: tt.service})
+		if err != nil {
+			t.Fatalf("Check(%q) error: %v", tt.service, err)
+		}
+		if resp.GetStatus() != tt.want {
+			t.Errorf("Check(%q) = %v, want %v", tt.service, resp.GetStatus(), tt.want)
+		}
+	}
+
+	if _, err := server.Check(ctx, &healthpb.HealthCheckRequest{Service: "qdrant"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Check(unknown) error = %v, want NotFound", err)
+	}
+
+	list, err := server.List(ctx, &healthpb.HealthListRequest{})
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(list.GetStatuses()) != 3 || list.GetStatuses()["graphdb"].GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("List = %v, want overall, kvdb and graphdb", list.GetStatuses())
+	}
+}
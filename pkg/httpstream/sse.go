@@ -0,0 +1,214 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package httpstream serves streamed function output over HTTP.
+package httpstream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+)
+
+// Default SSE settings
+const (
+	DefaultHeartbeatInterval = 15 * time.Second
+)
+
+// SSE event names sent besides the data events
+const (
+	SSEEventError = "error" // the stream failed; data is the error message
+	SSEEventDone  = "done"  // the stream has ended
+)
+
+// streamErrorPrefix and streamErrorSuffix enclose an error sent in a stream channel by flowkitclient.StreamFunction.
+const (
+	streamErrorPrefix = "$&$error$&$:$&$"
+	streamErrorSuffix = "$&$"
+)
+
+// StreamFunc starts a stream bound to ctx and returns its output channel, e.g. a wrapper around
+// flowkitclient.StreamFunctionWithContext. The stream must close the channel when ctx is done.
+type StreamFunc func(ctx context.Context, r *http.Request) (channel *chan string, err error)
+
+// SSEOptions represents the options of an SSE handler.
+type SSEOptions struct {
+	EventName         string        // name of the data events; unnamed "message" events if empty
+	HeartbeatInterval time.Duration // interval of heartbeat comments on an idle stream; DefaultHeartbeatInterval if 0, disabled if negative
+	FlushInterval     time.Duration // interval of flushing buffered events; every event is flushed immediately if 0
+}
+
+// NewSSEHandler creates an HTTP handler that streams the output of a stream as Server-Sent Events.
+//
+// Every message of the stream is sent as a data event with an increasing ID; an error sent by
+// flowkitclient.StreamFunction is sent as SSEEventError event and ends the stream. When the stream
+// ends, an SSEEventDone event is sent. When the client disconnects, the stream context is cancelled.
+//
+// Parameters:
+//   - ctx: the logging context
+//   - stream: the function starting the stream
+//   - opts: the options; may be nil
+//
+// Returns:
+//   - http.Handler: the handler
+func NewSSEHandler(ctx *logging.ContextMap, stream StreamFunc, opts *SSEOptions) http.Handler {
+	options := SSEOptions{}
+	if opts != nil {
+		options = *opts
+	}
+	if options.HeartbeatInterval == 0 {
+		options.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		streamCtx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		channel, err := stream(streamCtx, r)
+		if err != nil {
+			logging.Log.Errorf(ctx, "error starting stream: %v", err)
+			http.Error(w, fmt.Sprintf("error starting stream: %v", err), http.StatusBadGateway)
+			return
+		}
+
+		header := w.Header()
+		header.Set("Content-Type", "text/event-stream")
+		header.Set("Cache-Control", "no-cache")
+		header.Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		// the response controller also reaches the flusher of wrapped writers implementing Unwrap
+		controller := http.NewResponseController(w)
+		sse := &sseWriter{w: w, controller: controller, immediate: options.FlushInterval <= 0}
+		if err = sse.flush(); err == nil {
+			err = sse.run(streamCtx, *channel, options)
+		}
+		if err != nil {
+			logging.Log.Debugf(ctx, "SSE stream ended: %v", err)
+			// drain the channel so the stream goroutine is not blocked until it closes the channel
+			go func() {
+				for range *channel {
+				}
+			}()
+		}
+	})
+}
+
+// sseWriter writes Server-Sent Events to a response.
+type sseWriter struct {
+	w          http.ResponseWriter
+	controller *http.ResponseController
+	immediate  bool
+	pending    bool
+	eventId    int
+}
+
+// run forwards the channel to the response until the channel is closed, the stream fails or the context is done.
+func (s *sseWriter) run(ctx context.Context, channel chan string, options SSEOptions) error {
+	var heartbeat <-chan time.Time
+	if options.HeartbeatInterval > 0 {
+		ticker := time.NewTicker(options.HeartbeatInterval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+	var flush <-chan time.Time
+	if !s.immediate {
+		ticker := time.NewTicker(options.FlushInterval)
+		defer ticker.Stop()
+		flush = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case message, ok := <-channel:
+			if !ok {
+				return s.write(SSEEventDone, "", false)
+			}
+			if strings.HasPrefix(message, streamErrorPrefix) && strings.HasSuffix(message, streamErrorSuffix) {
+				errorMessage := strings.TrimSuffix(strings.TrimPrefix(message, streamErrorPrefix), streamErrorSuffix)
+				if err := s.write(SSEEventError, errorMessage, false); err != nil {
+					return err
+				}
+				return fmt.Errorf("stream failed: %s", errorMessage)
+			}
+			if err := s.write(options.EventName, message, true); err != nil {
+				return err
+			}
+
+		case <-heartbeat:
+			if _, err := fmt.Fprint(s.w, ": heartbeat\n\n"); err != nil {
+				return err
+			}
+			if err := s.flush(); err != nil {
+				return err
+			}
+
+		case <-flush:
+			if s.pending {
+				if err := s.flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// write writes an event; control events are always flushed immediately.
+func (s *sseWriter) write(event string, data string, withId bool) error {
+	var b strings.Builder
+	if withId {
+		s.eventId++
+		fmt.Fprintf(&b, "id: %d\n", s.eventId)
+	}
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	for _, line := range strings.Split(data, "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+	if _, err := fmt.Fprint(s.w, b.String()); err != nil {
+		return err
+	}
+
+	if s.immediate || !withId {
+		return s.flush()
+	}
+	s.pending = true
+	return nil
+}
+
+// flush sends the buffered events to the client.
+func (s *sseWriter) flush() error {
+	if err := s.controller.Flush(); err != nil {
+		return fmt.Errorf("error flushing events: %w", err)
+	}
+	s.pending = false
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package httpstream
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+)
+
+func newTestLogContext() *logging.ContextMap {
+	logging.InitForTest()
+	return &logging.ContextMap{}
+}
+
+// sliceStream returns a StreamFunc sending the given messages and closing the channel.
+func sliceStream(messages ...string) StreamFunc {
+	return func(ctx context.Context, r *http.Request) (*chan string, error) {
+		channel := make(chan string, len(messages))
+		for _, message := range messages {
+			channel <- message
+		}
+		close(channel)
+		return &channel, nil
+	}
+}
+
+func TestSSEHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		stream   StreamFunc
+		opts     *SSEOptions
+		wantBody string
+		wantCode int
+	}{
+		{
+			name:     "messages and done",
+			stream:   sliceStream("hello", "multi\nline"),
+			wantBody: "id: 1\ndata: hello\n\nid: 2\ndata: multi\ndata: line\n\nevent: done\ndata: \n\n",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "named events with batched flushing",
+			stream:   sliceStream("a"),
+			opts:     &SSEOptions{EventName: "output", FlushInterval: time.Millisecond},
+			wantBody: "id: 1\nevent: output\ndata: a\n\nevent: done\ndata: \n\n",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "stream error",
+			stream:   sliceStream("a", "$&$error$&$:$&$boom$&$", "ignored"),
+			wantBody: "id: 1\ndata: a\n\nevent: error\ndata: boom\n\n",
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "start error",
+			stream: func(ctx context.Context, r *http.Request) (*chan string, error) {
+				return nil, errors.New("unavailable")
+			},
+			wantBody: "error starting stream: unavailable\n",
+			wantCode: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler := NewSSEHandler(newTestLogContext(), tt.stream, tt.opts)
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+			if recorder.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantCode)
+			}
+			if recorder.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", recorder.Body.String(), tt.wantBody)
+			}
+			if tt.wantCode == http.StatusOK && recorder.Header().Get("Content-Type") != "text/event-stream" {
+				t.Errorf("Content-Type = %q", recorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestSSEHandlerHeartbeatAndDisconnect(t *testing.T) {
+	cancelled := make(chan struct{})
+	stream := func(ctx context.Context, r *http.Request) (*chan string, error) {
+		channel := make(chan string)
+		go func() {
+			<-ctx.Done()
+			close(cancelled)
+			close(channel)
+		}()
+		return &channel, nil
+	}
+	server := httptest.NewServer(NewSSEHandler(newTestLogContext(), stream, &SSEOptions{HeartbeatInterval: 5 * time.Millisecond}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil && err != io.EOF {
+		t.Fatalf("read error = %v", err)
+	}
+	if !strings.HasPrefix(line, ": heartbeat") {
+		t.Errorf("first line = %q, want heartbeat", line)
+	}
+
+	cancel()
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("stream context was not cancelled after client disconnect")
+	}
+}
+
+// wrappedWriter hides the http.Flusher of the underlying writer, like many middlewares do
+type wrappedWriter struct {
+	http.ResponseWriter
+}
+
+func (w *wrappedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func TestSSEHandlerWrappedWriter(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	handler := NewSSEHandler(newTestLogContext(), sliceStream("hello"), nil)
+	handler.ServeHTTP(&wrappedWriter{recorder}, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+	if want := "id: 1\ndata: hello\n\nevent: done\ndata: \n\n"; recorder.Body.String() != want {
+		t.Errorf("body = %q, want %q", recorder.Body.String(), want)
+	}
+	if !recorder.Flushed {
+		t.Error("events were not flushed through the wrapped writer")
+	}
+	// hop-by-hop headers are managed by the server and forbidden in HTTP/2
+	if connection := recorder.Header().Get("Connection"); connection != "" {
+		t.Errorf("Connection = %q, want no Connection header", connection)
+	}
+}
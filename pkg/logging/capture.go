@@ -0,0 +1,173 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logging
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogEntry is a log entry recorded by CaptureForTest
+type LogEntry struct {
+	Time    time.Time
+	Level   zapcore.Level
+	Message string
+	Fields  map[string]interface{}
+}
+
+// LogCapture records the log entries written during a test
+type LogCapture struct {
+	mu      sync.Mutex
+	entries []LogEntry
+}
+
+// initForTest initializes the logger of the tests of a package only once; logs are sent
+// asynchronously, so re-initializing the logger would race with running tests
+var initForTest sync.Once
+
+// InitForTest initializes the logger and the global config for the tests of a package
+// The first call sets config.GlobalConfig to an empty config with debug logging; later calls do nothing.
+func InitForTest() {
+	initForTest.Do(func() {
+		testConfig := &config.Config{LOG_LEVEL: "debug"}
+		config.GlobalConfig = testConfig
+		InitLogger(testConfig)
+	})
+}
+
+// CaptureForTest records the log entries written until the end of the test
+// Entries are recorded synchronously, so they can be asserted right after the logging call returns.
+// If the logger is not initialized, it is initialized without console output.
+//
+// Parameters:
+//   - t: the test
+//
+// Returns:
+//   - *LogCapture: the capture of the log entries
+func CaptureForTest(t testing.TB) *LogCapture {
+	t.Helper()
+	if Log.lw == nil {
+		Log = loggerWrapper{lw: zap.New(zapcore.NewNopCore(), zap.AddCallerSkip(1), withSinks, withRedaction)}
+	}
+
+	capture := &LogCapture{}
+	remove := AddSink(captureCore{capture: capture})
+	t.Cleanup(remove)
+	return capture
+}
+
+// Entries returns the recorded entries in order
+//
+// Returns:
+//   - []LogEntry: the entries
+func (c *LogCapture) Entries() []LogEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]LogEntry(nil), c.entries...)
+}
+
+// Messages returns the messages of the recorded entries with at least the given level
+//
+// Parameters:
+//   - level: the minimum level
+//
+// Returns:
+//   - []string: the messages
+func (c *LogCapture) Messages(level zapcore.Level) []string {
+	var messages []string
+	for _, entry := range c.Entries() {
+		if entry.Level >= level {
+			messages = append(messages, entry.Message)
+		}
+	}
+	return messages
+}
+
+// Contains checks if a recorded entry with at least the given level contains a text in its message
+//
+// Parameters:
+//   - level: the minimum level
+//   - text: the text to search
+//
+// Returns:
+//   - bool: true if an entry contains the text
+func (c *LogCapture) Contains(level zapcore.Level, text string) bool {
+	for _, message := range c.Messages(level) {
+		if strings.Contains(message, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reset discards the recorded entries
+func (c *LogCapture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+}
+
+// captureCore is the sink of a LogCapture
+type captureCore struct {
+	capture *LogCapture
+	fields  []zapcore.Field
+}
+
+func (c captureCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+func (c captureCore) With(fields []zapcore.Field) zapcore.Core {
+	return captureCore{capture: c.capture, fields: append(append([]zapcore.Field(nil), c.fields...), fields...)}
+}
+
+func (c captureCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checked.AddCore(entry, c)
+}
+
+func (c captureCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, field := range append(append([]zapcore.Field(nil), c.fields...), fields...) {
+		field.AddTo(encoder)
+	}
+
+	c.capture.mu.Lock()
+	defer c.capture.mu.Unlock()
+	c.capture.entries = append(c.capture.entries, LogEntry{
+		Time:    entry.Time,
+		Level:   entry.Level,
+		Message: entry.Message,
+		Fields:  encoder.Fields,
+	})
+	return nil
+}
+
+func (c captureCore) Sync() error {
+	return nil
+}
@@ -30,7 +30,9 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/coder/websocket"
@@ -48,6 +50,16 @@ import (
 // Set function sets ContextKeys equal to any value
 func (ctx *ContextMap) Set(key ContextKey, value interface{}) {
 	ctx.data.Store(key, value)
+	ctx.clientAsserted.Delete(key)
+}
+
+// Delete function removes a ContextKey and its value
+//
+// Parameters:
+//   - key: The ContextKey to remove.
+func (ctx *ContextMap) Delete(key ContextKey) {
+	ctx.data.Delete(key)
+	ctx.clientAsserted.Delete(key)
 }
 
 // Get function retrieves the value for a ContextKey
@@ -72,9 +84,110 @@ func (ctx *ContextMap) Copy() *ContextMap {
 		newCtx.data.Store(key, value)
 		return true
 	})
+	ctx.clientAsserted.Range(func(key, value interface{}) bool {
+		newCtx.clientAsserted.Store(key, value)
+		return true
+	})
 	return newCtx
 }
 
+// GetString function retrieves the value for a ContextKey as a string
+//
+// Parameters:
+//   - key: The ContextKey for which to retrieve the value.
+//
+// Returns:
+//   - string: The value associated with the specified ContextKey, empty if not set or not a string.
+//   - bool: A boolean indicating whether the ContextKey exists and holds a string.
+func (ctx *ContextMap) GetString(key ContextKey) (string, bool) {
+	value, ok := ctx.data.Load(key)
+	if !ok {
+		return "", false
+	}
+	str, ok := value.(string)
+	return str, ok
+}
+
+// Range function calls fn for each key and value of the ContextMap, in ascending key order.
+// Iteration stops when fn returns false. The values are snapshotted before iterating,
+// so fn may safely call Set on the same ContextMap.
+//
+// Parameters:
+//   - fn: The function called for each key and value.
+func (ctx *ContextMap) Range(fn func(key ContextKey, value interface{}) bool) {
+	if ctx == nil {
+		return
+	}
+	values := ctx.ToMap()
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !fn(ContextKey(key), values[key]) {
+			return
+		}
+	}
+}
+
+// ToMap function returns the content of the ContextMap as a plain map
+//
+// Returns:
+//   - map[string]interface{}: A new map holding all keys and values, empty if ctx is nil.
+func (ctx *ContextMap) ToMap() map[string]interface{} {
+	values := map[string]interface{}{}
+	if ctx == nil {
+		return values
+	}
+	ctx.data.Range(func(key, value interface{}) bool {
+		values[string(key.(ContextKey))] = value
+		return true
+	})
+	return values
+}
+
+// FromMap function sets all keys and values of the given map on the ContextMap,
+// overwriting existing values
+//
+// Parameters:
+//   - values: The keys and values to set.
+//
+// Returns:
+//   - *ContextMap: The ContextMap itself, for chaining.
+func (ctx *ContextMap) FromMap(values map[string]interface{}) *ContextMap {
+	for key, value := range values {
+		ctx.Set(ContextKey(key), value)
+	}
+	return ctx
+}
+
+// MarshalJSON serializes the ContextMap as a JSON object with keys in ascending order,
+// so identical contexts always produce identical payloads.
+//
+// Returns:
+//   - []byte: The JSON object.
+//   - error: An error if a value cannot be serialized.
+func (ctx *ContextMap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ctx.ToMap())
+}
+
+// UnmarshalJSON sets the keys and values of a JSON object on the ContextMap.
+//
+// Parameters:
+//   - data: The JSON object.
+//
+// Returns:
+//   - error: An error if data is not a JSON object.
+func (ctx *ContextMap) UnmarshalJSON(data []byte) error {
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	ctx.FromMap(values)
+	return nil
+}
+
 ///////////////////////////////////
 // Create Logger
 ///////////////////////////////////
@@ -91,16 +204,10 @@ func InitLogger(GlobalConfig *config.Config) {
 	config := zap.NewProductionConfig()
 	config.Level.SetLevel(TraceLevel)
 	option := zap.AddCallerSkip(1)
-	config.EncoderConfig.FunctionKey = "func"
-	config.EncoderConfig.EncodeLevel = func(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
-		if l == TraceLevel {
-			enc.AppendString("trace")
-		} else {
-			zapcore.LowercaseLevelEncoder(l, enc)
-		}
-	}
-	temp, _ := config.Build(option)
+	config.EncoderConfig = encoderConfig()
+	temp, _ := config.Build(option, withSinks, withRedaction)
 	Log = loggerWrapper{lw: temp}
+	invalidPatterns := configureRedaction(GlobalConfig)
 
 	// Set the global configuration variables for the logging package
 	initLoggerConfig(Config{
@@ -118,6 +225,35 @@ func InitLogger(GlobalConfig *config.Config) {
 		DatadogMetrics:    GlobalConfig.DATADOG_METRICS,
 		DatadogMetricsURL: GlobalConfig.METRICS_URL,
 	})
+
+	// Report invalid redaction patterns once the logger is usable
+	for _, err := range invalidPatterns {
+		Log.Warnf(&ContextMap{}, "%v", err)
+	}
+}
+
+// publishedDatadogAPIKey is the LOGGING_API_KEY of the last config published by a secret refresh;
+// nil until a refresh publishes a config with a key
+var publishedDatadogAPIKey atomic.Pointer[string]
+
+func init() {
+	// a rotated Datadog key is used as soon as a secret refresh publishes it
+	config.OnConfigPublished(func(cfg *config.Config) {
+		if key := cfg.LOGGING_API_KEY; key != "" {
+			publishedDatadogAPIKey.Store(&key)
+		}
+	})
+}
+
+// datadogAPIKey returns the Datadog API key: the key of the last published config, or DATADOG_API_KEY
+//
+// Returns:
+//   - string: the API key
+func datadogAPIKey() string {
+	if key := publishedDatadogAPIKey.Load(); key != nil {
+		return *key
+	}
+	return DATADOG_API_KEY
 }
 
 // initLoggerConfig initializes the global configuration variables for the logging package.
@@ -177,7 +313,7 @@ func (logger *loggerWrapper) Fatal(ctx *ContextMap, args ...interface{}) {
 	if pan != nil {
 		panic(pan)
 	}
-	pan = writeInterfaceToFile(ERROR_FILE_LOCATION, fmt.Sprint(args...))
+	pan = writeInterfaceToFile(ERROR_FILE_LOCATION, Redact(fmt.Sprint(args...)))
 	if pan != nil {
 		panic(pan)
 	}
@@ -212,7 +348,7 @@ func (logger *loggerWrapper) Fatalf(ctx *ContextMap, format string, args ...inte
 	if pan != nil {
 		panic(pan)
 	}
-	pan = writeInterfaceToFile(ERROR_FILE_LOCATION, fmt.Sprintf(format, args...))
+	pan = writeInterfaceToFile(ERROR_FILE_LOCATION, Redact(fmt.Sprintf(format, args...)))
 	if pan != nil {
 		panic(pan)
 	}
@@ -454,6 +590,20 @@ func (logger *loggerWrapper) Metrics(name string, count float64) {
 	go sendMetrics(name, count)
 }
 
+// MetricsWithTags sends a metric event with the specified name, value and tags to Datadog if Datadog metrics are enabled.
+//
+// Parameters:
+//   - name: The name of the metric.
+//   - value: The value of the metric.
+//   - tags: The tags of the metric in the "key:value" format, in addition to the env, version and service tags.
+func (logger *loggerWrapper) MetricsWithTags(name string, value float64, tags ...string) {
+	if !DATADOG_METRICS {
+		return
+	}
+
+	go sendMetrics(name, value, tags...)
+}
+
 ///////////////////////////////////
 // Datadog logging helper functions
 ///////////////////////////////////
@@ -502,17 +652,21 @@ func sendLogs(ctx *ContextMap, level zapcore.Level, time time.Time, message stri
 	timeString := timeToString(time)
 	callerString := entryCallerToString(caller)
 
+	// Redact sensitive values before they reach the local files or Datadog
+	message = Redact(message)
+	stack = Redact(stack)
+
 	// Convert arguments to string representations to ensure JSON serializability
 	stringArgs := make([]string, len(arguments))
 	for i, arg := range arguments {
-		stringArgs[i] = fmt.Sprintf("%v", arg)
+		stringArgs[i] = Redact(fmt.Sprintf("%v", arg))
 	}
 
 	// Create rest API call body structure
 	body := []map[string]interface{}{
 		{
 			"ddsource":  DATADOG_SOURCE,
-			"ddtags":    "env:" + DATADOG_STAGE + ",version:" + DATADOG_VERSION,
+			"ddtags":    datadogLogTags(ctx),
 			"message":   message,
 			"time":      timeString,
 			"service":   DATADOG_SERVICE_NAME,
@@ -525,11 +679,19 @@ func sendLogs(ctx *ContextMap, level zapcore.Level, time time.Time, message stri
 	}
 
 	// Append body with context
-	ctx.data.Range(func(key, value interface{}) bool {
-		body[0][string(key.(ContextKey))] = value
+	ctx.Range(func(key ContextKey, value interface{}) bool {
+		if str, ok := value.(string); ok {
+			value = Redact(str)
+		}
+		body[0][string(key)] = value
 		return true
 	})
 
+	// Correlate the entry with its Datadog trace
+	if DATADOG_LOGS {
+		addTraceCorrelation(body[0], ctx)
+	}
+
 	// Convert body to JSON
 	bodyJSON, err := mapsToJSONBytes(body)
 	if err != nil {
@@ -563,7 +725,7 @@ func sendLogs(ctx *ContextMap, level zapcore.Level, time time.Time, message stri
 	}
 
 	if DATADOG_LOGS {
-		if DATADOG_API_KEY == "" || DATADOG_LOGS_URL == "" {
+		if datadogAPIKey() == "" || DATADOG_LOGS_URL == "" {
 			message := "'DATADOG_LOGS' set to 'true' in 'config.yaml' file but 'DATADOG_API_KEY' and/or 'DATADOG_LOGS_URL' were not defined"
 			pan := writeStringToFile(ERROR_FILE_LOCATION, message)
 			if pan != nil {
@@ -572,7 +734,7 @@ func sendLogs(ctx *ContextMap, level zapcore.Level, time time.Time, message stri
 			panic(message)
 		}
 		// Send POST call to datadog
-		_, err2 := sendPostRequestToDatadog(DATADOG_LOGS_URL, bodyJSON, DATADOG_API_KEY)
+		_, err2 := sendPostRequestToDatadog(DATADOG_LOGS_URL, bodyJSON, datadogAPIKey())
 		if err2 != nil {
 			message := "Error occurred during sendPostRequestToDatadog in sendLogs:"
 			pan := writeStringToFile(ERROR_FILE_LOCATION, message)
@@ -594,7 +756,8 @@ func sendLogs(ctx *ContextMap, level zapcore.Level, time time.Time, message stri
 // Parameters:
 //   - name: The name of the metric.
 //   - count: The value of the metric.
-func sendMetrics(name string, count float64) {
+//   - tags: Additional tags of the metric.
+func sendMetrics(name string, count float64, tags ...string) {
 	defer func() {
 		r := recover()
 		if r != nil {
@@ -628,6 +791,7 @@ func sendMetrics(name string, count float64) {
 						Type: "host",
 					},
 				},
+				Tags: append(standardMetricTags(), tags...),
 			},
 		},
 	}
@@ -640,7 +804,7 @@ func sendMetrics(name string, count float64) {
 	}
 
 	// Send POST call to datadog
-	_, err2 := sendPostRequestToDatadog(DATADOG_METRICS_URL, jsonBody, DATADOG_API_KEY)
+	_, err2 := sendPostRequestToDatadog(DATADOG_METRICS_URL, jsonBody, datadogAPIKey())
 	if err2 != nil {
 		message := "Error occurred during sendPostRequestToDatadog in sendMetrics:"
 		pan := writeStringToFile(ERROR_FILE_LOCATION, message)
@@ -711,6 +875,32 @@ func mapsToJSONBytes(maps []map[string]interface{}) ([]byte, error) {
 	return jsonBytes, nil
 }
 
+// datadogLogTags returns the "ddtags" of a Datadog log entry
+// Besides the environment and version, the tenant, model and tool of the context are added as tags
+// so logs can be filtered by them; unset values are omitted.
+//
+// Parameters:
+//   - ctx: the context of the log entry, can be nil
+//
+// Returns:
+//   - string: the comma separated tags
+func datadogLogTags(ctx *ContextMap) string {
+	tags := "env:" + DATADOG_STAGE + ",version:" + DATADOG_VERSION
+	for _, tag := range []struct {
+		key        string
+		contextKey ContextKey
+	}{
+		{"tenant_id", TenantId},
+		{"model_id", ModelId},
+		{"tool_name", ToolName},
+	} {
+		if value := contextString(ctx, tag.contextKey); value != "" {
+			tags += "," + metricTag(tag.key, value)
+		}
+	}
+	return tags
+}
+
 // levelToString converts a zapcore.Level to its string representation.
 //
 // Parameters:
@@ -948,8 +1138,8 @@ func writeFormattedLogToFile(filename, app, timeStr, level, function, caller, me
 	ctxCol := ""
 	if ctx != nil {
 		var parts []string
-		ctx.data.Range(func(key, value interface{}) bool {
-			parts = append(parts, fmt.Sprintf("%s=%v", key, value))
+		ctx.Range(func(key ContextKey, value interface{}) bool {
+			parts = append(parts, Redact(fmt.Sprintf("%s=%v", key, value)))
 			return true
 		})
 		ctxCol = strings.Join(parts, ", ")
@@ -1138,13 +1328,7 @@ func writeStringToFile(filename string, data string) error {
 //   - err: an error if the metadata creation or attachment fails
 func CreateMetaDataFromCtx(ctx *ContextMap, ctxWithCancel context.Context) (ctxWithMetaData context.Context, err error) {
 	// Append body with context
-	body := []map[string]interface{}{
-		{},
-	}
-	ctx.data.Range(func(key, value interface{}) bool {
-		body[0][string(key.(ContextKey))] = value
-		return true
-	})
+	body := []map[string]interface{}{ctx.ToMap()}
 
 	// Serialize struct to JSON
 	jsonData, err := json.Marshal(&body)
@@ -1160,14 +1344,21 @@ func CreateMetaDataFromCtx(ctx *ContextMap, ctxWithCancel context.Context) (ctxW
 }
 
 // CreateCtxFromMetaData creates a ContextMap from gRPC metadata in the provided context.
+// Only keys on the allow-list of AllowPropagatedContextKeys are read and the values are client-asserted,
+// unless a server interceptor attached a logging context with ContextWithLogContext.
 //
 // Parameters:
 //   - ctxWithMetaData: the gRPC context containing the metadata
 //
 // Returns:
 //   - ctx: the logging context map created from the metadata
-//   - err: an error if the metadata extraction or deserialization fails
+//   - err: an error if the metadata is larger than MaxPropagatedContextSize or cannot be deserialized
 func CreateCtxFromMetaData(ctxWithMetaData context.Context) (ctx *ContextMap, err error) {
+	// Use the logging context attached by a server interceptor
+	if attached, ok := attachedLogContext(ctxWithMetaData); ok {
+		return attached, nil
+	}
+
 	// Create new ContextMap
 	ctx = &ContextMap{}
 
@@ -1184,23 +1375,7 @@ func CreateCtxFromMetaData(ctxWithMetaData context.Context) (ctx *ContextMap, er
 	}
 
 	// Take the first value (there should only be one)
-	jsonData := metadataValues[0]
-
-	// Deserialize JSON to body
-	var body []map[string]interface{}
-	err = json.Unmarshal([]byte(jsonData), &body)
-	if err != nil {
-		return nil, fmt.Errorf("error deserializing JSON to metadata: %v", err)
-	}
-
-	// Populate the ContextMap with data from body
-	if len(body) > 0 && body[0] != nil {
-		for key, value := range body[0] {
-			ctx.data.Store(ContextKey(key), value)
-		}
-	}
-
-	return ctx, nil
+	return parsePropagatedContext(metadataValues[0])
 }
 
 // CreateDialOptionsFromCtx creates websocket dial options from the given ContextMap.
@@ -1212,37 +1387,76 @@ func CreateCtxFromMetaData(ctxWithMetaData context.Context) (ctx *ContextMap, er
 //   - opts: the websocket dial options with the attached metadata
 //   - err: an error if the metadata creation fails
 func CreateDialOptionsFromCtx(ctx *ContextMap) (opts *websocket.DialOptions, err error) {
-	// Append body with context
-	body := []map[string]interface{}{
-		{},
+	header, err := CreateHTTPHeaderFromCtx(ctx)
+	if err != nil {
+		return nil, err
 	}
-	ctx.data.Range(func(key, value interface{}) bool {
-		body[0][string(key.(ContextKey))] = value
-		return true
-	})
+	return &websocket.DialOptions{HTTPHeader: header}, nil
+}
+
+// CreateHTTPHeaderFromCtx creates the "aali-logging-context" HTTP header from the given ContextMap,
+// the counterpart of CreateCtxFromHeader.
+//
+// Parameters:
+//   - ctx: the logging context map containing metadata values
+//
+// Returns:
+//   - header: the HTTP header with the attached metadata
+//   - err: an error if the metadata creation fails
+func CreateHTTPHeaderFromCtx(ctx *ContextMap) (header http.Header, err error) {
+	// Append body with context
+	body := []map[string]interface{}{ctx.ToMap()}
 
 	// Serialize struct to JSON
 	jsonData, err := json.Marshal(&body)
 	if err != nil {
 		return nil, fmt.Errorf("error serializing metadata struct to JSON: %v", err)
 	}
-	opts = &websocket.DialOptions{
-		HTTPHeader: http.Header{
-			"aali-logging-context": []string{string(jsonData)},
-		},
+	return http.Header{
+		"aali-logging-context": []string{string(jsonData)},
+	}, nil
+}
+
+// SetHTTPHeaderFromCtx sets the "aali-logging-context" header of an outgoing HTTP request from the given ContextMap.
+//
+// Parameters:
+//   - ctx: the logging context map containing metadata values; nothing is set if nil
+//   - request: the HTTP request
+//
+// Returns:
+//   - err: an error if the metadata creation fails
+func SetHTTPHeaderFromCtx(ctx *ContextMap, request *http.Request) (err error) {
+	if ctx == nil {
+		return nil
 	}
-	return opts, nil
+	header, err := CreateHTTPHeaderFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+	return nil
 }
 
 // CreateCtxFromHeader creates a ContextMap from HTTP request headers.
+// Only keys on the allow-list of AllowPropagatedContextKeys are read and the values are client-asserted,
+// unless a server middleware attached a logging context with ContextWithLogContext.
 //
 // Parameters:
 //   - request: the HTTP request containing the headers
 //
 // Returns:
 //   - ctx: the logging context map created from the headers
-//   - err: an error if the header extraction or deserialization fails
+//   - err: an error if the header is larger than MaxPropagatedContextSize or cannot be deserialized
 func CreateCtxFromHeader(request *http.Request) (ctx *ContextMap, err error) {
+	// Use the logging context attached by a server middleware
+	if attached, ok := attachedLogContext(request.Context()); ok {
+		return attached, nil
+	}
+
 	// Create new ContextMap
 	ctx = &ContextMap{}
 
@@ -1251,19 +1465,5 @@ func CreateCtxFromHeader(request *http.Request) (ctx *ContextMap, err error) {
 	if meta == "" {
 		return ctx, nil
 	}
-
-	// Deserialize JSON to body
-	var body []map[string]interface{}
-	err = json.Unmarshal([]byte(meta), &body)
-	if err != nil {
-		return nil, fmt.Errorf("error deserializing JSON to metadata: %v", err)
-	}
-
-	// Populate the ContextMap with data from body
-	if len(body) > 0 && body[0] != nil {
-		for key, value := range body[0] {
-			ctx.data.Store(ContextKey(key), value)
-		}
-	}
-	return ctx, nil
+	return parsePropagatedContext(meta)
 }
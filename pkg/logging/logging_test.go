@@ -31,6 +31,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -117,6 +118,109 @@ func TestContextMap_Copy(t *testing.T) {
 	}
 }
 
+// TestContextMap_GetString tests the GetString method of ContextMap
+func TestContextMap_GetString(t *testing.T) {
+	ctx := &ContextMap{}
+	ctx.Set(UserId, "user-1")
+	ctx.Set(NodeAttempt, 2)
+
+	if value, ok := ctx.GetString(UserId); !ok || value != "user-1" {
+		t.Errorf("GetString(UserId) = %q, %v", value, ok)
+	}
+	if value, ok := ctx.GetString(NodeAttempt); ok || value != "" {
+		t.Errorf("GetString(NodeAttempt) = %q, %v, want non-string to be reported as missing", value, ok)
+	}
+	if _, ok := ctx.GetString(Action); ok {
+		t.Error("GetString(Action) found a value that was never set")
+	}
+}
+
+// TestContextMap_Range tests that Range visits keys in order and stops early
+func TestContextMap_Range(t *testing.T) {
+	ctx := &ContextMap{}
+	ctx.Set(WorkflowId, "workflow-1")
+	ctx.Set(Action, "action-1")
+	ctx.Set(UserId, "user-1")
+
+	var keys []ContextKey
+	ctx.Range(func(key ContextKey, value interface{}) bool {
+		keys = append(keys, key)
+		// Setting while ranging must not deadlock or change the iteration
+		ctx.Set(InstructionGuid, "guid-1")
+		return true
+	})
+	if want := []ContextKey{Action, UserId, WorkflowId}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Range visited %v, want %v", keys, want)
+	}
+
+	count := 0
+	ctx.Range(func(key ContextKey, value interface{}) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Errorf("Range continued after fn returned false, visited %d keys", count)
+	}
+
+	var nilCtx *ContextMap
+	nilCtx.Range(func(key ContextKey, value interface{}) bool {
+		t.Error("Range on nil ContextMap called fn")
+		return true
+	})
+}
+
+// TestContextMap_ToMapFromMap tests the round trip between ContextMap and plain maps
+func TestContextMap_ToMapFromMap(t *testing.T) {
+	values := map[string]interface{}{"userId": "user-1", "nodeAttempt": 3}
+	ctx := (&ContextMap{}).FromMap(values)
+
+	if got := ctx.ToMap(); !reflect.DeepEqual(got, values) {
+		t.Errorf("ToMap() = %v, want %v", got, values)
+	}
+
+	// The returned map is a copy
+	ctx.ToMap()["userId"] = "changed"
+	if value, _ := ctx.GetString(UserId); value != "user-1" {
+		t.Errorf("modifying ToMap() result changed the ContextMap, userId = %q", value)
+	}
+
+	var nilCtx *ContextMap
+	if got := nilCtx.ToMap(); len(got) != 0 {
+		t.Errorf("ToMap() on nil ContextMap = %v, want empty", got)
+	}
+}
+
+// TestContextMap_JSON tests that ContextMap serialization is deterministic and round trips
+func TestContextMap_JSON(t *testing.T) {
+	ctx := &ContextMap{}
+	ctx.Set(WorkflowId, "workflow-1")
+	ctx.Set(UserId, "user-1")
+	ctx.Set(Action, "action-1")
+
+	want := `{"action":"action-1","userId":"user-1","workflowId":"workflow-1"}`
+	for i := 0; i < 10; i++ {
+		data, err := json.Marshal(ctx)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if string(data) != want {
+			t.Fatalf("Marshal() = %s, want %s", data, want)
+		}
+	}
+
+	decoded := &ContextMap{}
+	if err := json.Unmarshal([]byte(want), decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.ToMap(), ctx.ToMap()) {
+		t.Errorf("round trip = %v, want %v", decoded.ToMap(), ctx.ToMap())
+	}
+
+	if err := json.Unmarshal([]byte(`["not", "an", "object"]`), decoded); err == nil {
+		t.Error("expected an error unmarshalling a JSON array")
+	}
+}
+
 // TestInitLoggerConfig tests the initLoggerConfig function
 func TestInitLoggerConfig(t *testing.T) {
 	testConfig := Config{
@@ -716,6 +820,32 @@ func TestCreateDialOptionsFromCtx(t *testing.T) {
 	}
 }
 
+// TestSetHTTPHeaderFromCtx tests the SetHTTPHeaderFromCtx function
+func TestSetHTTPHeaderFromCtx(t *testing.T) {
+	ctx := &ContextMap{}
+	ctx.Set(InstructionGuid, "guid-http-client")
+
+	req := httptest.NewRequest("GET", "http://example.com", nil)
+	if err := SetHTTPHeaderFromCtx(ctx, req); err != nil {
+		t.Fatalf("SetHTTPHeaderFromCtx failed: %v", err)
+	}
+
+	// The header must be readable by the receiving side
+	received, err := CreateCtxFromHeader(req)
+	if err != nil {
+		t.Fatalf("CreateCtxFromHeader failed: %v", err)
+	}
+	if value, _ := received.Get(InstructionGuid); value != "guid-http-client" {
+		t.Errorf("Expected instructionGuid to be 'guid-http-client', got '%v'", value)
+	}
+
+	// A nil context sets nothing
+	req = httptest.NewRequest("GET", "http://example.com", nil)
+	if err := SetHTTPHeaderFromCtx(nil, req); err != nil || len(req.Header) != 0 {
+		t.Errorf("Expected no header for nil context, got %v (error %v)", req.Header, err)
+	}
+}
+
 // TestCreateCtxFromHeader tests the CreateCtxFromHeader function
 func TestCreateCtxFromHeader(t *testing.T) {
 	// Create HTTP request with header
@@ -966,6 +1096,72 @@ func TestRoundtripMetadata(t *testing.T) {
 	}
 }
 
+// TestRoundtripTenantContextKeys tests that the model, tool, tenant, session and trace keys
+// survive the gRPC metadata and HTTP header roundtrips
+func TestRoundtripTenantContextKeys(t *testing.T) {
+	ctx := &ContextMap{}
+	ctx.Set(ModelId, "gpt-4o")
+	ctx.Set(ToolName, "search")
+	ctx.Set(TenantId, "tenant-1")
+	ctx.Set(SessionId, "session-1")
+	ctx.Set(TraceId, "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	ctxWithMetadata, err := CreateMetaDataFromCtx(ctx, context.Background())
+	if err != nil {
+		t.Fatalf("Failed to create metadata: %v", err)
+	}
+	md, _ := metadata.FromOutgoingContext(ctxWithMetadata)
+	fromMetadata, err := CreateCtxFromMetaData(metadata.NewIncomingContext(context.Background(), md))
+	if err != nil {
+		t.Fatalf("Failed to extract metadata: %v", err)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := SetHTTPHeaderFromCtx(ctx, request); err != nil {
+		t.Fatalf("Failed to set header: %v", err)
+	}
+	fromHeader, err := CreateCtxFromHeader(request)
+	if err != nil {
+		t.Fatalf("Failed to extract header: %v", err)
+	}
+
+	for _, extracted := range []*ContextMap{fromMetadata, fromHeader} {
+		if !reflect.DeepEqual(extracted.ToMap(), ctx.ToMap()) {
+			t.Errorf("extracted context = %v, want %v", extracted.ToMap(), ctx.ToMap())
+		}
+	}
+}
+
+// TestDatadogLogTags tests the tags added to the Datadog log entries
+func TestDatadogLogTags(t *testing.T) {
+	previousStage, previousVersion := DATADOG_STAGE, DATADOG_VERSION
+	t.Cleanup(func() { DATADOG_STAGE, DATADOG_VERSION = previousStage, previousVersion })
+	DATADOG_STAGE, DATADOG_VERSION = "test", "1.0.0"
+
+	withTenant := &ContextMap{}
+	withTenant.Set(TenantId, "Tenant One")
+	withTenant.Set(ModelId, "gpt-4o")
+	withTenant.Set(ToolName, "search")
+	withTenant.Set(SessionId, "session-1")
+
+	tests := []struct {
+		name string
+		ctx  *ContextMap
+		want string
+	}{
+		{"nil context", nil, "env:test,version:1.0.0"},
+		{"no tenant values", &ContextMap{}, "env:test,version:1.0.0"},
+		{"tenant values", withTenant, "env:test,version:1.0.0,tenant_id:tenant_one,model_id:gpt-4o,tool_name:search"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := datadogLogTags(tt.ctx); got != tt.want {
+				t.Errorf("datadogLogTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestMultipleLogLevels tests that different log levels work correctly
 func TestMultipleLogLevels(t *testing.T) {
 	testCases := []struct {
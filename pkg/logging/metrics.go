@@ -0,0 +1,183 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logging
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+// Names of the metrics sent by the metrics helpers, shared by all services so dashboards line up
+const (
+	WorkflowRunCountMetricName    = "aali.workflow.run.count"
+	WorkflowRunDurationMetricName = "aali.workflow.run.duration_ms"
+	LLMCallCountMetricName        = "aali.llm.call.count"
+	LLMCallLatencyMetricName      = "aali.llm.call.latency_ms"
+	LLMCallTokensMetricName       = "aali.llm.call.tokens"
+	AuthAttemptCountMetricName    = "aali.auth.attempt.count"
+	SecretFetchFailureMetricName  = "aali.secrets.fetch_failure.count"
+)
+
+func init() {
+	config.OnSecretFetchError(RecordSecretFetchFailure)
+}
+
+// LLMTokens contains the token counts of an LLM call
+type LLMTokens struct {
+	Input     int64
+	Output    int64
+	Cached    int64
+	Reasoning int64
+}
+
+// RecordWorkflowRun sends the count and duration metrics of a finished workflow run,
+// tagged with the workflow ID and the status of the run
+//
+// Parameters:
+//   - ctx: the logging context of the run
+//   - workflowId: the ID of the workflow; the WorkflowId context value is used if empty
+//   - duration: the duration of the run
+//   - status: the final status of the run, e.g. "success" or "failed"
+func RecordWorkflowRun(ctx *ContextMap, workflowId string, duration time.Duration, status string) {
+	if workflowId == "" {
+		workflowId = contextString(ctx, WorkflowId)
+	}
+	tags := []string{metricTag("workflow_id", workflowId), metricTag("status", status)}
+
+	Log.MetricsWithTags(WorkflowRunCountMetricName, 1, tags...)
+	Log.MetricsWithTags(WorkflowRunDurationMetricName, float64(duration.Milliseconds()), tags...)
+}
+
+// RecordLLMCall sends the count, latency and token metrics of an LLM call, tagged with the model
+// The token metric is sent once per token type with a count above 0, tagged with the type.
+//
+// Parameters:
+//   - ctx: the logging context of the call
+//   - model: the ID of the model; the ChatModelId, then the ModelId context value is used if empty
+//   - tokens: the token counts of the call
+//   - latency: the duration of the call
+func RecordLLMCall(ctx *ContextMap, model string, tokens LLMTokens, latency time.Duration) {
+	if model == "" {
+		model = contextString(ctx, ChatModelId)
+	}
+	if model == "" {
+		model = contextString(ctx, ModelId)
+	}
+	modelTag := metricTag("model", model)
+
+	Log.MetricsWithTags(LLMCallCountMetricName, 1, modelTag)
+	Log.MetricsWithTags(LLMCallLatencyMetricName, float64(latency.Milliseconds()), modelTag)
+	for _, count := range []struct {
+		tokenType string
+		value     int64
+	}{
+		{"input", tokens.Input},
+		{"output", tokens.Output},
+		{"cached", tokens.Cached},
+		{"reasoning", tokens.Reasoning},
+	} {
+		if count.value > 0 {
+			Log.MetricsWithTags(LLMCallTokensMetricName, float64(count.value), modelTag, metricTag("token_type", count.tokenType))
+		}
+	}
+}
+
+// RecordAuthAttempt sends the count metric of an authentication attempt,
+// tagged with the called method, the result and the ID of the presented API key
+//
+// Parameters:
+//   - ctx: the logging context of the call
+//   - method: the called gRPC method or HTTP path
+//   - result: the result of the attempt, e.g. "success" or "unauthenticated"
+//   - apiKeyId: the ID of the API key; the ApiKeyId context value is used if empty
+func RecordAuthAttempt(ctx *ContextMap, method string, result string, apiKeyId string) {
+	if apiKeyId == "" {
+		apiKeyId = contextString(ctx, ApiKeyId)
+	}
+	Log.MetricsWithTags(AuthAttemptCountMetricName, 1, metricTag("method", method), metricTag("result", result), metricTag("api_key_id", apiKeyId))
+}
+
+// RecordSecretFetchFailure sends the count metric of a failed secret refresh
+// It is registered with config.OnSecretFetchError, so the failures of all secret refreshers are counted.
+//
+// Parameters:
+//   - err: the error of the refresh
+func RecordSecretFetchFailure(err error) {
+	Log.MetricsWithTags(SecretFetchFailureMetricName, 1)
+}
+
+// standardMetricTags returns the env, version and service tags added to every metric
+//
+// Returns:
+//   - tags: the tags of the configured values
+func standardMetricTags() (tags []string) {
+	for _, tag := range []struct{ key, value string }{
+		{"env", DATADOG_STAGE},
+		{"version", DATADOG_VERSION},
+		{"service", DATADOG_SERVICE_NAME},
+	} {
+		if tag.value != "" {
+			tags = append(tags, metricTag(tag.key, tag.value))
+		}
+	}
+	return tags
+}
+
+// metricTag formats a Datadog tag, replacing the characters separating tags in the value
+//
+// Parameters:
+//   - key: the key of the tag
+//   - value: the value of the tag; "unknown" if empty
+//
+// Returns:
+//   - string: the tag in the "key:value" format
+func metricTag(key string, value string) string {
+	if value == "" {
+		value = "unknown"
+	}
+	value = strings.Map(func(r rune) rune {
+		if r == ',' || r == ' ' || r == '\t' || r == '\n' {
+			return '_'
+		}
+		return r
+	}, strings.ToLower(value))
+	return key + ":" + value
+}
+
+// contextString returns a context value as a string
+//
+// Parameters:
+//   - ctx: the logging context, can be nil
+//   - key: the context key
+//
+// Returns:
+//   - string: the value, empty if not set or not a string
+func contextString(ctx *ContextMap, key ContextKey) string {
+	if ctx == nil {
+		return ""
+	}
+	str, _ := ctx.GetString(key)
+	return str
+}
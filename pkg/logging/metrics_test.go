@@ -0,0 +1,213 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+// captureMetrics enables Datadog metrics with a test server for the duration of the test
+// and returns a channel receiving the sent metrics.
+func captureMetrics(t *testing.T) <-chan Metric {
+	received := make(chan Metric, 16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var metrics Metrics
+		if err := json.Unmarshal(body, &metrics); err != nil {
+			t.Errorf("Failed to unmarshal metrics: %v", err)
+		}
+		for _, metric := range metrics.Series {
+			received <- metric
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	previousMetrics, previousUrl, previousStage, previousVersion, previousService := DATADOG_METRICS, DATADOG_METRICS_URL, DATADOG_STAGE, DATADOG_VERSION, DATADOG_SERVICE_NAME
+	t.Cleanup(func() {
+		DATADOG_METRICS, DATADOG_METRICS_URL, DATADOG_STAGE, DATADOG_VERSION, DATADOG_SERVICE_NAME = previousMetrics, previousUrl, previousStage, previousVersion, previousService
+	})
+	DATADOG_METRICS, DATADOG_METRICS_URL, DATADOG_STAGE, DATADOG_VERSION, DATADOG_SERVICE_NAME = true, server.URL, "test", "1.0.0", ""
+	return received
+}
+
+// receiveMetrics receives a number of metrics, sorted by name and value
+func receiveMetrics(t *testing.T, received <-chan Metric, count int) []Metric {
+	var metrics []Metric
+	for len(metrics) < count {
+		select {
+		case metric := <-received:
+			metrics = append(metrics, metric)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d metrics, want %d", len(metrics), count)
+		}
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Metric != metrics[j].Metric {
+			return metrics[i].Metric < metrics[j].Metric
+		}
+		return metrics[i].Points[0].Value < metrics[j].Points[0].Value
+	})
+	return metrics
+}
+
+func TestRecordWorkflowRun(t *testing.T) {
+	received := captureMetrics(t)
+	ctx := &ContextMap{}
+	ctx.Set(WorkflowId, "wf-from-context")
+
+	RecordWorkflowRun(ctx, "", 1500*time.Millisecond, "Success")
+
+	metrics := receiveMetrics(t, received, 2)
+	want := []struct {
+		name  string
+		value float64
+	}{
+		{WorkflowRunCountMetricName, 1},
+		{WorkflowRunDurationMetricName, 1500},
+	}
+	for i, metric := range metrics {
+		if metric.Metric != want[i].name || metric.Points[0].Value != want[i].value {
+			t.Errorf("metric %d = %s %v, want %s %v", i, metric.Metric, metric.Points[0].Value, want[i].name, want[i].value)
+		}
+		wantTags := []string{"env:test", "version:1.0.0", "workflow_id:wf-from-context", "status:success"}
+		if !slices.Equal(metric.Tags, wantTags) {
+			t.Errorf("metric %s tags = %q, want %q", metric.Metric, metric.Tags, wantTags)
+		}
+	}
+}
+
+func TestRecordLLMCall(t *testing.T) {
+	received := captureMetrics(t)
+
+	RecordLLMCall(&ContextMap{}, "gpt-4o", LLMTokens{Input: 120, Output: 30}, 800*time.Millisecond)
+
+	metrics := receiveMetrics(t, received, 4)
+	want := []struct {
+		name  string
+		value float64
+		tag   string
+	}{
+		{LLMCallCountMetricName, 1, ""},
+		{LLMCallLatencyMetricName, 800, ""},
+		{LLMCallTokensMetricName, 30, "token_type:output"},
+		{LLMCallTokensMetricName, 120, "token_type:input"},
+	}
+	for i, metric := range metrics {
+		if metric.Metric != want[i].name || metric.Points[0].Value != want[i].value {
+			t.Errorf("metric %d = %s %v, want %s %v", i, metric.Metric, metric.Points[0].Value, want[i].name, want[i].value)
+		}
+		if !slices.Contains(metric.Tags, "model:gpt-4o") {
+			t.Errorf("metric %s tags = %q, want model tag", metric.Metric, metric.Tags)
+		}
+		if want[i].tag != "" && !slices.Contains(metric.Tags, want[i].tag) {
+			t.Errorf("metric %s tags = %q, want %s", metric.Metric, metric.Tags, want[i].tag)
+		}
+	}
+
+	select {
+	case metric := <-received:
+		t.Errorf("unexpected metric %+v for token types without tokens", metric)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRecordLLMCallModelFromContext(t *testing.T) {
+	received := captureMetrics(t)
+
+	ctx := &ContextMap{}
+	ctx.Set(ModelId, "mistral-large")
+	RecordLLMCall(ctx, "", LLMTokens{}, time.Second)
+
+	for _, metric := range receiveMetrics(t, received, 2) {
+		if !slices.Contains(metric.Tags, "model:mistral-large") {
+			t.Errorf("metric %s tags = %q, want model from the ModelId context value", metric.Metric, metric.Tags)
+		}
+	}
+}
+
+func TestMetricTag(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+		want  string
+	}{
+		{"model", "gpt-4o", "model:gpt-4o"},
+		{"status", "Failed", "status:failed"},
+		{"workflow_id", "", "workflow_id:unknown"},
+		{"model", "my model,v2", "model:my_model_v2"},
+	}
+	for _, tt := range tests {
+		if got := metricTag(tt.key, tt.value); got != tt.want {
+			t.Errorf("metricTag(%q, %q) = %q, want %q", tt.key, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRecordAuthAttempt(t *testing.T) {
+	received := captureMetrics(t)
+
+	RecordAuthAttempt(&ContextMap{}, "/aaliflowkitgrpc.ExternalFunctions/RunFunction", "Unauthenticated", "")
+
+	metric := receiveMetrics(t, received, 1)[0]
+	if metric.Metric != AuthAttemptCountMetricName || metric.Points[0].Value != 1 {
+		t.Errorf("metric = %s %v, want %s 1", metric.Metric, metric.Points[0].Value, AuthAttemptCountMetricName)
+	}
+	wantTags := []string{"env:test", "version:1.0.0", "method:/aaliflowkitgrpc.externalfunctions/runfunction", "result:unauthenticated", "api_key_id:unknown"}
+	if !slices.Equal(metric.Tags, wantTags) {
+		t.Errorf("metric tags = %q, want %q", metric.Tags, wantTags)
+	}
+}
+
+func TestRecordSecretFetchFailure(t *testing.T) {
+	received := captureMetrics(t)
+
+	// registered with config.OnSecretFetchError, so any failing refresher sends the metric
+	refresher := config.NewSecretRefresher(failingSecretSource{}, 0)
+	if _, err := refresher.Refresh(context.Background()); err == nil {
+		t.Fatal("Refresh() error = nil, want the fetch error")
+	}
+
+	metric := receiveMetrics(t, received, 1)[0]
+	if metric.Metric != SecretFetchFailureMetricName || metric.Points[0].Value != 1 {
+		t.Errorf("metric = %s %v, want %s 1", metric.Metric, metric.Points[0].Value, SecretFetchFailureMetricName)
+	}
+}
+
+// failingSecretSource is a config.SecretSource failing every fetch
+type failingSecretSource struct{}
+
+func (failingSecretSource) FetchSecrets(ctx context.Context) (map[string]string, error) {
+	return nil, errors.New("vault unavailable")
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logging
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicMetricName is the name of the metric sent for each recovered panic
+const PanicMetricName = "aali.panic.recovered"
+
+// PanicError is an error created from a recovered panic, keeping the stack of the panicking goroutine.
+type PanicError struct {
+	Operation string      // description of the operation that panicked
+	Value     interface{} // value passed to panic
+	Stack     []byte      // stack trace captured when the panic was recovered
+}
+
+// NewPanicError creates a PanicError, capturing the stack of the current goroutine.
+// It must be called from the deferred function recovering the panic, so the stack includes the panicking code.
+//
+// Parameters:
+//   - operation: description of the operation that panicked
+//   - value: the value returned by recover
+//
+// Returns:
+//   - *PanicError: the panic error
+func NewPanicError(operation string, value interface{}) *PanicError {
+	return &PanicError{
+		Operation: operation,
+		Value:     value,
+		Stack:     debug.Stack(),
+	}
+}
+
+// Error returns the error message, without the stack
+//
+// Returns:
+//   - string: the error message
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic occurred in %s: %v", e.Operation, e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, e.g. a runtime error
+//
+// Returns:
+//   - error: the panic value if it is an error, nil otherwise
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// RecoverPanic recovers a panic, logs it with its stack, sends the PanicMetricName metric and stores a
+// *PanicError in err. It must be deferred directly:
+//
+//	defer logging.RecoverPanic(ctx, "RunFunction", &err)
+//
+// Parameters:
+//   - ctx: the context of the operation; can be nil
+//   - operation: description of the operation, used in the error message
+//   - err: the error result of the function to set; can be nil if the function has no error result
+func RecoverPanic(ctx *ContextMap, operation string, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	panicErr := NewPanicError(operation, r)
+	if Log.lw != nil {
+		if ctx == nil {
+			ctx = &ContextMap{}
+		}
+		Log.Errorf(ctx, "%v\n%s", panicErr, panicErr.Stack)
+		Log.Metrics(PanicMetricName, 1)
+	}
+
+	if err != nil {
+		*err = panicErr
+	}
+}
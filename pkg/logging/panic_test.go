@@ -0,0 +1,87 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logging
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func panickingOperation(ctx *ContextMap, value interface{}) (err error) {
+	defer RecoverPanic(ctx, "panickingOperation", &err)
+	panic(value)
+}
+
+func TestRecoverPanic(t *testing.T) {
+	initTestLogger(t, "fatal")
+	err := panickingOperation(&ContextMap{}, "boom")
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("error = %v, want *PanicError", err)
+	}
+	if got := err.Error(); got != "panic occurred in panickingOperation: boom" {
+		t.Errorf("Error() = %q", got)
+	}
+	if panicErr.Value != "boom" {
+		t.Errorf("Value = %v, want boom", panicErr.Value)
+	}
+	if !strings.Contains(string(panicErr.Stack), "panickingOperation") {
+		t.Errorf("Stack does not contain the panicking function:\n%s", panicErr.Stack)
+	}
+	if errors.Unwrap(err) != nil {
+		t.Errorf("Unwrap() of non-error panic = %v, want nil", errors.Unwrap(err))
+	}
+}
+
+func TestRecoverPanicRuntimeError(t *testing.T) {
+	initTestLogger(t, "fatal")
+	var values []int
+	err := func() (err error) {
+		defer RecoverPanic(nil, "index", &err)
+		_ = values[1]
+		return nil
+	}()
+	var runtimeErr runtime.Error
+	if !errors.As(err, &runtimeErr) {
+		t.Errorf("error = %v, want wrapped runtime.Error", err)
+	}
+}
+
+func TestRecoverPanicWithoutPanic(t *testing.T) {
+	initTestLogger(t, "fatal")
+	err := func() (err error) {
+		defer RecoverPanic(nil, "noop", &err)
+		return errors.New("regular error")
+	}()
+	if err == nil || err.Error() != "regular error" {
+		t.Errorf("error = %v, want regular error", err)
+	}
+
+	// no error result
+	func() {
+		defer RecoverPanic(nil, "no error result", nil)
+		panic("ignored")
+	}()
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MaxPropagatedContextSize is the maximum size in bytes of a received "aali-logging-context";
+// larger contexts are rejected by CreateCtxFromMetaData and CreateCtxFromHeader
+var MaxPropagatedContextSize = 16 * 1024
+
+// ErrContextTooLarge is returned when a received logging context exceeds MaxPropagatedContextSize
+var ErrContextTooLarge = errors.New("logging context exceeds the maximum size")
+
+// propagatedContextKeys is the allow-list of the context keys accepted from a received logging context
+var propagatedContextKeys = struct {
+	sync.RWMutex
+	keys map[ContextKey]bool
+}{keys: map[ContextKey]bool{}}
+
+// The identity keys UserId, UserMail, ApiKeyId, ApiKeyOwner and TenantId are propagated for log
+// correlation only: like every received value they are client-asserted, so authorization and rate
+// limiting read them with GetAuthenticated.
+func init() {
+	AllowPropagatedContextKeys(
+		InstructionGuid, WorkflowId, WorkflowRunId, UserId, AdapterType, WatchFolderPath, WatchFilePath,
+		ReaderGuid, ClientGuid, Action, Rest_Call_Id, Rest_Call, UserMail, InputTokenCount, OutputTokenCount,
+		CachedTokenCount, ReasoningTokenCount, ChatModelId, NodeId, NodeAttempt, ApiKeyId, ApiKeyOwner,
+		ModelId, ToolName, TenantId, SessionId, TraceId, DatadogTraceId, DatadogSpanId,
+	)
+}
+
+// AllowPropagatedContextKeys adds context keys to the allow-list of keys accepted from a received
+// logging context. All keys defined by this package are allowed by default; services propagating
+// their own keys register them at startup.
+//
+// Parameters:
+//   - keys: the context keys to allow
+func AllowPropagatedContextKeys(keys ...ContextKey) {
+	propagatedContextKeys.Lock()
+	defer propagatedContextKeys.Unlock()
+	for _, key := range keys {
+		propagatedContextKeys.keys[key] = true
+	}
+}
+
+// IsPropagatedContextKey checks if a context key is accepted from a received logging context
+//
+// Parameters:
+//   - key: the context key
+//
+// Returns:
+//   - bool: true if the key is on the allow-list
+func IsPropagatedContextKey(key ContextKey) bool {
+	propagatedContextKeys.RLock()
+	defer propagatedContextKeys.RUnlock()
+	return propagatedContextKeys.keys[key]
+}
+
+// IsClientAsserted checks if a value was received from the caller rather than set by this service.
+// Values read by CreateCtxFromMetaData and CreateCtxFromHeader are client-asserted until they are
+// overwritten with Set, e.g. by an authentication interceptor after verifying the caller.
+// Identity values such as UserId must not be trusted for authorization while client-asserted.
+//
+// Parameters:
+//   - key: the context key
+//
+// Returns:
+//   - bool: true if the value of the key was received from the caller
+func (ctx *ContextMap) IsClientAsserted(key ContextKey) bool {
+	_, ok := ctx.clientAsserted.Load(key)
+	return ok
+}
+
+// GetAuthenticated retrieves the value of a context key unless it was asserted by the caller.
+// Use it instead of Get for identity values, e.g. UserId, that are trusted for authorization.
+//
+// Parameters:
+//   - key: the context key
+//
+// Returns:
+//   - string: the value as a string, empty if not set or client-asserted
+//   - bool: true if the key holds a value set by this service
+func (ctx *ContextMap) GetAuthenticated(key ContextKey) (string, bool) {
+	if ctx == nil || ctx.IsClientAsserted(key) {
+		return "", false
+	}
+	value, ok := ctx.data.Load(key)
+	if !ok || value == nil {
+		return "", false
+	}
+	return fmt.Sprint(value), true
+}
+
+// logContextKey is the context.Context key of the logging context attached by ContextWithLogContext
+type logContextKey struct{}
+
+// ContextWithLogContext attaches a logging context to a request context, so CreateCtxFromMetaData
+// and CreateCtxFromHeader return it, with its authenticated values, instead of parsing the received one.
+// Server interceptors use it to hand the values they verified to the handlers.
+//
+// Parameters:
+//   - ctx: the request context
+//   - logCtx: the logging context; a copy is attached
+//
+// Returns:
+//   - context.Context: the request context with the attached logging context
+func ContextWithLogContext(ctx context.Context, logCtx *ContextMap) context.Context {
+	return context.WithValue(ctx, logContextKey{}, logCtx.Copy())
+}
+
+// attachedLogContext returns a copy of the logging context attached by ContextWithLogContext
+//
+// Parameters:
+//   - ctx: the request context
+//
+// Returns:
+//   - *ContextMap: the copy of the attached logging context
+//   - bool: false if no logging context is attached
+func attachedLogContext(ctx context.Context) (*ContextMap, bool) {
+	logCtx, ok := ctx.Value(logContextKey{}).(*ContextMap)
+	if !ok {
+		return nil, false
+	}
+	return logCtx.Copy(), true
+}
+
+// parsePropagatedContext creates a ContextMap from a received "aali-logging-context" value
+// Keys missing from the allow-list are dropped and all values are marked as client-asserted.
+//
+// Parameters:
+//   - payload: the received value
+//
+// Returns:
+//   - ctx: the logging context
+//   - err: an error if the value is too large or cannot be deserialized
+func parsePropagatedContext(payload string) (ctx *ContextMap, err error) {
+	if len(payload) > MaxPropagatedContextSize {
+		return nil, fmt.Errorf("%w: %d bytes, limit %d", ErrContextTooLarge, len(payload), MaxPropagatedContextSize)
+	}
+
+	// Deserialize JSON to body
+	var body []map[string]interface{}
+	err = json.Unmarshal([]byte(payload), &body)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing JSON to metadata: %v", err)
+	}
+
+	// Populate the ContextMap with the allowed data from body
+	ctx = &ContextMap{}
+	if len(body) > 0 {
+		for key, value := range body[0] {
+			if !IsPropagatedContextKey(ContextKey(key)) {
+				continue
+			}
+			ctx.data.Store(ContextKey(key), value)
+			ctx.clientAsserted.Store(ContextKey(key), true)
+		}
+	}
+	return ctx, nil
+}
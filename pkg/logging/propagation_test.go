@@ -0,0 +1,176 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logging
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParsePropagatedContextAllowList(t *testing.T) {
+	ctx, err := parsePropagatedContext(`[{"userId":"user-1","customKey":"value","workflowId":"wf-1"}]`)
+	if err != nil {
+		t.Fatalf("parsePropagatedContext() error = %v", err)
+	}
+	if _, ok := ctx.Get("customKey"); ok {
+		t.Error("key missing from the allow-list was accepted")
+	}
+	for _, key := range []ContextKey{UserId, WorkflowId} {
+		if _, ok := ctx.Get(key); !ok {
+			t.Errorf("allowed key %s was dropped", key)
+		}
+		if !ctx.IsClientAsserted(key) {
+			t.Errorf("received key %s is not client-asserted", key)
+		}
+	}
+}
+
+func TestAllowPropagatedContextKeys(t *testing.T) {
+	const customKey ContextKey = "propagationTestKey"
+	if IsPropagatedContextKey(customKey) {
+		t.Fatalf("%s is allowed before registration", customKey)
+	}
+	AllowPropagatedContextKeys(customKey)
+	t.Cleanup(func() {
+		propagatedContextKeys.Lock()
+		delete(propagatedContextKeys.keys, customKey)
+		propagatedContextKeys.Unlock()
+	})
+
+	ctx, err := parsePropagatedContext(`[{"propagationTestKey":"value"}]`)
+	if err != nil {
+		t.Fatalf("parsePropagatedContext() error = %v", err)
+	}
+	if value, _ := ctx.GetString(customKey); value != "value" {
+		t.Errorf("registered key = %q, want value", value)
+	}
+}
+
+func TestParsePropagatedContextSizeLimit(t *testing.T) {
+	payload := `[{"action":"` + strings.Repeat("a", MaxPropagatedContextSize) + `"}]`
+	if _, err := parsePropagatedContext(payload); !errors.Is(err, ErrContextTooLarge) {
+		t.Errorf("error = %v, want ErrContextTooLarge", err)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("aali-logging-context", payload)
+	if _, err := CreateCtxFromHeader(request); !errors.Is(err, ErrContextTooLarge) {
+		t.Errorf("CreateCtxFromHeader() error = %v, want ErrContextTooLarge", err)
+	}
+
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs("aali-logging-context", payload))
+	if _, err := CreateCtxFromMetaData(incoming); !errors.Is(err, ErrContextTooLarge) {
+		t.Errorf("CreateCtxFromMetaData() error = %v, want ErrContextTooLarge", err)
+	}
+}
+
+func TestClientAssertedValues(t *testing.T) {
+	ctx, err := parsePropagatedContext(`[{"userId":"asserted","action":"run"}]`)
+	if err != nil {
+		t.Fatalf("parsePropagatedContext() error = %v", err)
+	}
+
+	ctx.Set(UserId, "verified")
+	if ctx.IsClientAsserted(UserId) {
+		t.Error("value overwritten with Set is still client-asserted")
+	}
+
+	copied := ctx.Copy()
+	if !copied.IsClientAsserted(Action) || copied.IsClientAsserted(UserId) {
+		t.Error("Copy did not preserve the client-asserted flags")
+	}
+
+	local := &ContextMap{}
+	local.Set(UserId, "local")
+	if local.IsClientAsserted(UserId) {
+		t.Error("locally set value is client-asserted")
+	}
+
+	if value, ok := ctx.GetAuthenticated(UserId); !ok || value != "verified" {
+		t.Errorf("GetAuthenticated(UserId) = %q, %v, want verified, true", value, ok)
+	}
+	if value, ok := ctx.GetAuthenticated(Action); ok || value != "" {
+		t.Errorf("GetAuthenticated(Action) = %q, %v, want client-asserted value ignored", value, ok)
+	}
+
+	ctx.Delete(Action)
+	if _, ok := ctx.Get(Action); ok || ctx.IsClientAsserted(Action) {
+		t.Error("Delete did not remove the value and its client-asserted flag")
+	}
+}
+
+func TestPropagatedTenantIdIsClientAsserted(t *testing.T) {
+	incoming := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("aali-logging-context", `[{"tenantId":"tenant-1","userId":"user-1"}]`))
+	ctx, err := CreateCtxFromMetaData(incoming)
+	if err != nil {
+		t.Fatalf("CreateCtxFromMetaData() error = %v", err)
+	}
+
+	if value, _ := ctx.GetString(TenantId); value != "tenant-1" {
+		t.Errorf("tenantId = %q, want tenant-1 in the logging context", value)
+	}
+	for _, key := range []ContextKey{TenantId, UserId} {
+		if value, ok := ctx.GetAuthenticated(key); ok || value != "" {
+			t.Errorf("GetAuthenticated(%s) = %q, %v, want propagated value ignored", key, value, ok)
+		}
+	}
+}
+
+func TestContextWithLogContext(t *testing.T) {
+	logCtx := &ContextMap{}
+	logCtx.Set(UserId, "verified")
+	ctx := ContextWithLogContext(context.Background(), logCtx)
+
+	// The received context is ignored once a logging context is attached
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("aali-logging-context", `[{"userId":"asserted"}]`))
+	got, err := CreateCtxFromMetaData(ctx)
+	if err != nil {
+		t.Fatalf("CreateCtxFromMetaData() error = %v", err)
+	}
+	if value, _ := got.GetString(UserId); value != "verified" || got.IsClientAsserted(UserId) {
+		t.Errorf("userId = %q, client-asserted = %v, want authenticated value", value, got.IsClientAsserted(UserId))
+	}
+
+	// Each call returns a copy, so handlers do not share their changes
+	got.Set(Action, "changed")
+	again, _ := CreateCtxFromMetaData(ctx)
+	if _, ok := again.Get(Action); ok {
+		t.Error("changes to a returned logging context leaked into the attached one")
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	fromHeader, err := CreateCtxFromHeader(request)
+	if err != nil {
+		t.Fatalf("CreateCtxFromHeader() error = %v", err)
+	}
+	if value, _ := fromHeader.GetString(UserId); value != "verified" {
+		t.Errorf("userId from header = %q, want verified", value)
+	}
+}
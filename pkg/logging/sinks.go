@@ -0,0 +1,146 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logging
+
+import (
+	"io"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// sinks holds the additional cores receiving every log entry, keyed by registration id
+var sinks = struct {
+	sync.RWMutex
+	nextId int
+	cores  map[int]zapcore.Core
+}{cores: map[int]zapcore.Core{}}
+
+// AddSink registers a core receiving every log entry in addition to the console, file and Datadog outputs
+// Entries are filtered by LOG_LEVEL before reaching the sink.
+//
+// Parameters:
+//   - core: the core to register
+//
+// Returns:
+//   - remove: a function unregistering the core
+func AddSink(core zapcore.Core) (remove func()) {
+	sinks.Lock()
+	defer sinks.Unlock()
+	id := sinks.nextId
+	sinks.nextId++
+	sinks.cores[id] = core
+
+	return func() {
+		sinks.Lock()
+		defer sinks.Unlock()
+		delete(sinks.cores, id)
+	}
+}
+
+// AddWriterSink registers a writer receiving every log entry as a JSON line, encoded like the console output
+//
+// Parameters:
+//   - writer: the writer; it must be safe for concurrent use or is locked by the sink
+//
+// Returns:
+//   - remove: a function unregistering the writer
+func AddWriterSink(writer io.Writer) (remove func()) {
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig()), zapcore.Lock(zapcore.AddSync(writer)), TraceLevel)
+	return AddSink(core)
+}
+
+// encoderConfig returns the encoder configuration of the logger, with the custom trace level name
+//
+// Returns:
+//   - zapcore.EncoderConfig: the encoder configuration
+func encoderConfig() zapcore.EncoderConfig {
+	config := zap.NewProductionEncoderConfig()
+	config.FunctionKey = "func"
+	config.EncodeLevel = func(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+		if l == TraceLevel {
+			enc.AppendString("trace")
+		} else {
+			zapcore.LowercaseLevelEncoder(l, enc)
+		}
+	}
+	return config
+}
+
+// sinkCore wraps the core of the logger and passes its entries to the registered sinks
+type sinkCore struct {
+	zapcore.Core
+}
+
+// Enabled reports if the wrapped core or one of the sinks accepts the level
+func (c sinkCore) Enabled(level zapcore.Level) bool {
+	if c.Core.Enabled(level) {
+		return true
+	}
+
+	sinks.RLock()
+	defer sinks.RUnlock()
+	for _, core := range sinks.cores {
+		if core.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+// With adds fields to the wrapped core, keeping the sinks
+func (c sinkCore) With(fields []zapcore.Field) zapcore.Core {
+	return sinkCore{c.Core.With(fields)}
+}
+
+// Check adds the wrapped core and the sinks accepting the entry
+func (c sinkCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	checked = c.Core.Check(entry, checked)
+
+	sinks.RLock()
+	defer sinks.RUnlock()
+	for _, core := range sinks.cores {
+		checked = core.Check(entry, checked)
+	}
+	return checked
+}
+
+// Sync flushes the wrapped core and the sinks
+func (c sinkCore) Sync() error {
+	err := c.Core.Sync()
+
+	sinks.RLock()
+	defer sinks.RUnlock()
+	for _, core := range sinks.cores {
+		if syncErr := core.Sync(); syncErr != nil && err == nil {
+			err = syncErr
+		}
+	}
+	return err
+}
+
+// withSinks wraps the core of a logger so its entries are also passed to the registered sinks
+var withSinks = zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+	return sinkCore{core}
+})
@@ -0,0 +1,136 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"go.uber.org/zap/zapcore"
+)
+
+// initTestLogger initializes the logger with a log level, restoring the previous logger at the end of the test
+// Pending asynchronous log writes are awaited, as they read the logger configuration.
+func initTestLogger(t *testing.T, level string) {
+	pendingLogs.Wait()
+	previousLog, previousLevel := Log, LOG_LEVEL
+	t.Cleanup(func() {
+		pendingLogs.Wait()
+		Log, LOG_LEVEL = previousLog, previousLevel
+	})
+	InitLogger(&config.Config{LOG_LEVEL: level})
+}
+
+func TestCaptureForTest(t *testing.T) {
+	initTestLogger(t, "info")
+	capture := CaptureForTest(t)
+
+	ctx := &ContextMap{}
+	Log.Errorf(ctx, "request %s failed with %d", "abc", 500)
+	Log.Info(ctx, "started")
+	Log.Debugf(ctx, "filtered by LOG_LEVEL")
+
+	entries := capture.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Entries() = %+v, want 2 entries", entries)
+	}
+	if entries[0].Level != zapcore.ErrorLevel || entries[0].Message != "request abc failed with 500" {
+		t.Errorf("first entry = %+v", entries[0])
+	}
+	if _, ok := entries[0].Fields["Arguments"]; !ok {
+		t.Errorf("first entry fields = %v, want Arguments", entries[0].Fields)
+	}
+	if !capture.Contains(zapcore.ErrorLevel, "failed with 500") {
+		t.Error("Contains(error, failed with 500) = false")
+	}
+	if capture.Contains(zapcore.WarnLevel, "started") {
+		t.Error("Contains(warn, started) = true for info entry")
+	}
+	if got := capture.Messages(zapcore.InfoLevel); len(got) != 2 {
+		t.Errorf("Messages(info) = %q", got)
+	}
+
+	capture.Reset()
+	if got := capture.Entries(); len(got) != 0 {
+		t.Errorf("Entries() after Reset() = %+v", got)
+	}
+}
+
+func TestCaptureForTestRemovedAtCleanup(t *testing.T) {
+	initTestLogger(t, "info")
+
+	var capture *LogCapture
+	t.Run("capture", func(t *testing.T) {
+		capture = CaptureForTest(t)
+		Log.Info(&ContextMap{}, "inside")
+	})
+	Log.Info(&ContextMap{}, "outside")
+
+	if got := capture.Messages(zapcore.InfoLevel); len(got) != 1 || got[0] != "inside" {
+		t.Errorf("Messages() = %q, want only the entry logged during the subtest", got)
+	}
+}
+
+func TestCaptureForTestUninitializedLogger(t *testing.T) {
+	pendingLogs.Wait()
+	previousLog := Log
+	t.Cleanup(func() {
+		pendingLogs.Wait()
+		Log = previousLog
+	})
+	Log = loggerWrapper{}
+
+	capture := CaptureForTest(t)
+	Log.Warn(&ContextMap{}, "no logger")
+	if !capture.Contains(zapcore.WarnLevel, "no logger") {
+		t.Errorf("Entries() = %+v", capture.Entries())
+	}
+}
+
+func TestAddWriterSink(t *testing.T) {
+	initTestLogger(t, "trace")
+
+	var buffer bytes.Buffer
+	remove := AddWriterSink(&buffer)
+	Log.Warnf(&ContextMap{}, "disk %d%% full", 90)
+	Log.Tracef(&ContextMap{}, "trace details")
+	remove()
+	Log.Warn(&ContextMap{}, "after removal")
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("writer output = %q, want 2 lines", buffer.String())
+	}
+	for i, want := range []struct{ level, msg string }{{"warn", "disk 90% full"}, {"trace", "trace details"}} {
+		var line map[string]interface{}
+		if err := json.Unmarshal([]byte(lines[i]), &line); err != nil {
+			t.Fatalf("line %d is not JSON: %v", i, err)
+		}
+		if line["level"] != want.level || line["msg"] != want.msg {
+			t.Errorf("line %d = %v, want level %s and msg %q", i, line, want.level, want.msg)
+		}
+	}
+}
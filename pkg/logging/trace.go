@@ -0,0 +1,104 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logging
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// TraceCorrelationHook returns the Datadog trace and span IDs of the operation a log entry belongs to
+// Services running the Datadog tracer register a hook returning the IDs of the active span, in decimal
+// as formatted by the tracer; empty IDs are ignored.
+type TraceCorrelationHook func(ctx *ContextMap) (traceId string, spanId string)
+
+// traceCorrelationHook is the hook registered by SetTraceCorrelationHook
+var traceCorrelationHook atomic.Pointer[TraceCorrelationHook]
+
+// SetTraceCorrelationHook registers the hook providing the trace and span IDs added to the Datadog log entries
+//
+// Parameters:
+//   - hook: the hook; nil removes the registered hook
+func SetTraceCorrelationHook(hook TraceCorrelationHook) {
+	if hook == nil {
+		traceCorrelationHook.Store(nil)
+		return
+	}
+	traceCorrelationHook.Store(&hook)
+}
+
+// traceIds returns the trace and span IDs of a log entry
+// The IDs of the registered hook take precedence over the DatadogTraceId and DatadogSpanId context values,
+// as the context may have been received from an upstream service.
+//
+// Parameters:
+//   - ctx: the context of the log entry
+//
+// Returns:
+//   - traceId: the trace ID, empty if unknown
+//   - spanId: the span ID, empty if unknown
+func traceIds(ctx *ContextMap) (traceId string, spanId string) {
+	if hook := traceCorrelationHook.Load(); hook != nil {
+		traceId, spanId = (*hook)(ctx)
+		if traceId != "" {
+			return traceId, spanId
+		}
+	}
+
+	if ctx == nil {
+		return "", ""
+	}
+	if value, ok := ctx.Get(DatadogTraceId); ok {
+		traceId = fmt.Sprint(value)
+	}
+	if value, ok := ctx.Get(DatadogSpanId); ok {
+		spanId = fmt.Sprint(value)
+	}
+	return traceId, spanId
+}
+
+// addTraceCorrelation adds the Datadog trace correlation attributes to a log entry
+// The attributes are only added if a trace ID is known, together with the service, environment and
+// version that Datadog uses to link the entry to its trace.
+//
+// Parameters:
+//   - entry: the Datadog log entry
+//   - ctx: the context of the log entry
+func addTraceCorrelation(entry map[string]interface{}, ctx *ContextMap) {
+	traceId, spanId := traceIds(ctx)
+	if traceId == "" {
+		delete(entry, string(DatadogTraceId))
+		delete(entry, string(DatadogSpanId))
+		return
+	}
+
+	entry[string(DatadogTraceId)] = traceId
+	if spanId != "" {
+		entry[string(DatadogSpanId)] = spanId
+	} else {
+		delete(entry, string(DatadogSpanId))
+	}
+	entry["dd.service"] = DATADOG_SERVICE_NAME
+	entry["dd.env"] = DATADOG_STAGE
+	entry["dd.version"] = DATADOG_VERSION
+}
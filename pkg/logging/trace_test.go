@@ -0,0 +1,141 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logging
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// withTraceCorrelationHook registers a hook for the duration of the test
+func withTraceCorrelationHook(t *testing.T, hook TraceCorrelationHook) {
+	SetTraceCorrelationHook(hook)
+	t.Cleanup(func() { SetTraceCorrelationHook(nil) })
+}
+
+func TestAddTraceCorrelation(t *testing.T) {
+	previous := []string{DATADOG_SERVICE_NAME, DATADOG_STAGE, DATADOG_VERSION}
+	t.Cleanup(func() { DATADOG_SERVICE_NAME, DATADOG_STAGE, DATADOG_VERSION = previous[0], previous[1], previous[2] })
+	DATADOG_SERVICE_NAME, DATADOG_STAGE, DATADOG_VERSION = "aali-agent", "prod", "1.2.3"
+
+	hook := func(ctx *ContextMap) (string, string) {
+		if value, ok := ctx.Get(UserId); ok && value == "traced" {
+			return "111", "222"
+		}
+		return "", ""
+	}
+
+	tests := []struct {
+		name    string
+		hook    TraceCorrelationHook
+		context map[ContextKey]interface{}
+		want    map[string]interface{}
+	}{
+		{
+			name: "no trace",
+			want: map[string]interface{}{},
+		},
+		{
+			name:    "context values",
+			context: map[ContextKey]interface{}{DatadogTraceId: uint64(333), DatadogSpanId: "444"},
+			want:    map[string]interface{}{"dd.trace_id": "333", "dd.span_id": "444", "dd.service": "aali-agent", "dd.env": "prod", "dd.version": "1.2.3"},
+		},
+		{
+			name:    "hook takes precedence",
+			hook:    hook,
+			context: map[ContextKey]interface{}{UserId: "traced", DatadogTraceId: "333", DatadogSpanId: "444"},
+			want:    map[string]interface{}{"dd.trace_id": "111", "dd.span_id": "222", "dd.service": "aali-agent", "dd.env": "prod", "dd.version": "1.2.3"},
+		},
+		{
+			name:    "hook without active span",
+			hook:    hook,
+			context: map[ContextKey]interface{}{DatadogTraceId: "333"},
+			want:    map[string]interface{}{"dd.trace_id": "333", "dd.service": "aali-agent", "dd.env": "prod", "dd.version": "1.2.3"},
+		},
+		{
+			name:    "span without trace",
+			context: map[ContextKey]interface{}{DatadogSpanId: "444"},
+			want:    map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTraceCorrelationHook(t, tt.hook)
+			ctx := &ContextMap{}
+			entry := map[string]interface{}{}
+			for key, value := range tt.context {
+				ctx.Set(key, value)
+				entry[string(key)] = value
+			}
+			delete(entry, string(UserId))
+
+			addTraceCorrelation(entry, ctx)
+			if len(entry) != len(tt.want) {
+				t.Fatalf("entry = %v, want %v", entry, tt.want)
+			}
+			for key, value := range tt.want {
+				if entry[key] != value {
+					t.Errorf("entry[%q] = %v, want %v", key, entry[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestSendLogsTraceCorrelation(t *testing.T) {
+	received := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var entries []map[string]interface{}
+		if err := json.Unmarshal(body, &entries); err != nil || len(entries) != 1 {
+			t.Errorf("Datadog body = %s, error = %v", body, err)
+		} else {
+			received <- entries[0]
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	pendingLogs.Wait()
+	previousLogs, previousUrl, previousKey := DATADOG_LOGS, DATADOG_LOGS_URL, DATADOG_API_KEY
+	t.Cleanup(func() { DATADOG_LOGS, DATADOG_LOGS_URL, DATADOG_API_KEY = previousLogs, previousUrl, previousKey })
+	DATADOG_LOGS, DATADOG_LOGS_URL, DATADOG_API_KEY = true, server.URL, "test-key"
+	withTraceCorrelationHook(t, func(*ContextMap) (string, string) { return "5555", "6666" })
+
+	sendLogs(&ContextMap{}, zapcore.InfoLevel, time.Now(), "traced entry", zapcore.EntryCaller{}, "", "test")
+
+	select {
+	case entry := <-received:
+		if entry["dd.trace_id"] != "5555" || entry["dd.span_id"] != "6666" || entry["message"] != "traced entry" {
+			t.Errorf("Datadog entry = %v, want trace 5555 and span 6666", entry)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no entry sent to Datadog")
+	}
+}
@@ -56,6 +56,17 @@ const (
 	CachedTokenCount    ContextKey = "cachedTokenCount"
 	ReasoningTokenCount ContextKey = "reasoningTokenCount"
 	ChatModelId         ContextKey = "chatModelId"
+	NodeId              ContextKey = "nodeId"
+	NodeAttempt         ContextKey = "nodeAttempt"
+	ApiKeyId            ContextKey = "apiKeyId"
+	ApiKeyOwner         ContextKey = "apiKeyOwner"
+	ModelId             ContextKey = "modelId"
+	ToolName            ContextKey = "toolName"
+	TenantId            ContextKey = "tenantId"
+	SessionId           ContextKey = "sessionId"
+	TraceId             ContextKey = "traceId"
+	DatadogTraceId      ContextKey = "dd.trace_id"
+	DatadogSpanId       ContextKey = "dd.span_id"
 )
 
 // Initialize the global logger variable.
@@ -100,7 +111,8 @@ type Config struct {
 // ContextMap represents a context for managing key-value pairs with specific context keys. It allows setting, retrieving,
 // and copying context data associated with various keys.
 type ContextMap struct {
-	data sync.Map
+	data           sync.Map
+	clientAsserted sync.Map
 }
 
 // loggerWrapper represents a wrapper for the zap.Logger to provide custom logging functionality.
@@ -126,6 +138,7 @@ type Metric struct {
 	Type      int        `json:"type"`
 	Points    []Point    `json:"points"`
 	Resources []Resource `json:"resources"`
+	Tags      []string   `json:"tags,omitempty"`
 }
 
 // Metrics represents a collection of metrics.
@@ -0,0 +1,280 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// MemoryDatabase is an in-memory Database for tests. Documents are stored as their JSON
+// representation, so the JSON field names must match the BSON field names.
+// Filters support equality on top-level fields; unique indexes other than _id are not enforced.
+type MemoryDatabase struct {
+	mu          sync.Mutex
+	collections map[string]*memoryCollection
+}
+
+// NewMemoryDatabase creates an empty in-memory database.
+//
+// Returns:
+//   - *MemoryDatabase: the database
+func NewMemoryDatabase() *MemoryDatabase {
+	return &MemoryDatabase{collections: map[string]*memoryCollection{}}
+}
+
+// Collection returns the collection with the name, creating it if needed.
+func (d *MemoryDatabase) Collection(name string) Collection {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	collection, ok := d.collections[name]
+	if !ok {
+		collection = &memoryCollection{documents: map[string]map[string]interface{}{}}
+		d.collections[name] = collection
+	}
+	return collection
+}
+
+// Indexes returns the indexes created on a collection.
+func (d *MemoryDatabase) Indexes(name string) []sharedtypes.MongoIndex {
+	collection := d.Collection(name).(*memoryCollection)
+	collection.mu.Lock()
+	defer collection.mu.Unlock()
+	return append([]sharedtypes.MongoIndex{}, collection.indexes...)
+}
+
+// memoryCollection is a collection of MemoryDatabase.
+type memoryCollection struct {
+	mu        sync.Mutex
+	documents map[string]map[string]interface{}
+	indexes   []sharedtypes.MongoIndex
+}
+
+func (c *memoryCollection) InsertOne(ctx context.Context, document interface{}) error {
+	doc, err := toMap(document)
+	if err != nil {
+		return err
+	}
+	id := fmt.Sprint(doc["_id"])
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.documents[id]; exists {
+		return fmt.Errorf("%w: _id '%s'", ErrDuplicateKey, id)
+	}
+	c.documents[id] = doc
+	return nil
+}
+
+func (c *memoryCollection) FindOne(ctx context.Context, filter Filter, result interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	matches, err := c.match(filter)
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		return ErrNotFound
+	}
+	return fromValue(matches[0], result)
+}
+
+func (c *memoryCollection) Find(ctx context.Context, filter Filter, opts FindOptions, results interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	matches, err := c.match(filter)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		for _, key := range opts.Sort {
+			if cmp := compareValues(matches[i][key.Field], matches[j][key.Field]); cmp != 0 {
+				return (cmp < 0) == (key.Order >= 0)
+			}
+		}
+		return false
+	})
+	if opts.Skip > 0 {
+		if opts.Skip >= int64(len(matches)) {
+			matches = nil
+		} else {
+			matches = matches[opts.Skip:]
+		}
+	}
+	if opts.Limit > 0 && opts.Limit < int64(len(matches)) {
+		matches = matches[:opts.Limit]
+	}
+	if matches == nil {
+		matches = []map[string]interface{}{}
+	}
+	return fromValue(matches, results)
+}
+
+func (c *memoryCollection) ReplaceOne(ctx context.Context, filter Filter, document interface{}, upsert bool) (int64, error) {
+	doc, err := toMap(document)
+	if err != nil {
+		return 0, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	matches, err := c.match(filter)
+	if err != nil {
+		return 0, err
+	}
+	if len(matches) == 0 {
+		if upsert {
+			c.documents[fmt.Sprint(doc["_id"])] = doc
+		}
+		return 0, nil
+	}
+	id := fmt.Sprint(matches[0]["_id"])
+	if newId := fmt.Sprint(doc["_id"]); newId != id {
+		return 0, fmt.Errorf("cannot change _id from '%s' to '%s'", id, newId)
+	}
+	c.documents[id] = doc
+	return 1, nil
+}
+
+func (c *memoryCollection) DeleteOne(ctx context.Context, filter Filter) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	matches, err := c.match(filter)
+	if err != nil {
+		return 0, err
+	}
+	if len(matches) == 0 {
+		return 0, nil
+	}
+	delete(c.documents, fmt.Sprint(matches[0]["_id"]))
+	return 1, nil
+}
+
+func (c *memoryCollection) CreateIndexes(ctx context.Context, indexes []sharedtypes.MongoIndex) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, index := range indexes {
+		exists := false
+		for _, existing := range c.indexes {
+			exists = exists || existing.Name == index.Name
+		}
+		if !exists {
+			c.indexes = append(c.indexes, index)
+		}
+	}
+	return nil
+}
+
+// match returns the documents matching the filter, sorted by _id.
+func (c *memoryCollection) match(filter Filter) ([]map[string]interface{}, error) {
+	normalized, err := toMap(filter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(c.documents))
+	for id := range c.documents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	matches := []map[string]interface{}{}
+	for _, id := range ids {
+		doc := c.documents[id]
+		ok := true
+		for field, value := range normalized {
+			if compareValues(doc[field], value) != 0 {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matches = append(matches, doc)
+		}
+	}
+	return matches, nil
+}
+
+// compareValues compares JSON values; strings holding RFC 3339 timestamps are compared as times.
+func compareValues(a interface{}, b interface{}) int {
+	switch av := a.(type) {
+	case float64:
+		if bv, ok := b.(float64); ok {
+			switch {
+			case av < bv:
+				return -1
+			case av > bv:
+				return 1
+			}
+			return 0
+		}
+	case string:
+		if bv, ok := b.(string); ok {
+			at, aErr := time.Parse(time.RFC3339Nano, av)
+			bt, bErr := time.Parse(time.RFC3339Nano, bv)
+			if aErr == nil && bErr == nil {
+				return at.Compare(bt)
+			}
+			switch {
+			case av < bv:
+				return -1
+			case av > bv:
+				return 1
+			}
+			return 0
+		}
+	}
+	if reflect.DeepEqual(a, b) {
+		return 0
+	}
+	// values of different types are ordered by their type and representation
+	return compareValues(fmt.Sprintf("%T:%v", a, a), fmt.Sprintf("%T:%v", b, b))
+}
+
+// toMap converts a document or filter into its JSON object representation.
+func toMap(value interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding document: %w", err)
+	}
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("error encoding document: %w", err)
+	}
+	return result, nil
+}
+
+// fromValue decodes a JSON value into a result.
+func fromValue(value interface{}, result interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error decoding document: %w", err)
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("error decoding document: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package mongodb provides typed repositories for the MongoDB collections used in multi-agent mode.
+//
+// The repositories work on the Database and Collection interfaces, which are implemented by a thin
+// adapter around the MongoDB driver in the services and by MemoryDatabase for tests.
+// Documents are defined in sharedtypes (ConversationDocument, WorkflowRunDocument, FeedbackDocument)
+// together with their indexes in sharedtypes.MongoCollectionIndexes.
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// ErrNotFound is returned if no document matches.
+var ErrNotFound = errors.New("document not found")
+
+// ErrDuplicateKey is returned if a document with the same ID already exists.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// ErrConflict is returned if a document was modified concurrently.
+var ErrConflict = errors.New("document was modified concurrently")
+
+// Filter is an equality filter on document fields, compatible with bson.M.
+type Filter map[string]interface{}
+
+// FindOptions represents the sort order and paging of a find query.
+type FindOptions struct {
+	Sort  []sharedtypes.MongoIndexKey
+	Skip  int64
+	Limit int64 // 0 means no limit
+}
+
+// Collection is the subset of a MongoDB collection used by the repositories.
+type Collection interface {
+	// InsertOne inserts a document; returns ErrDuplicateKey if the ID exists.
+	InsertOne(ctx context.Context, document interface{}) error
+	// FindOne decodes the first matching document into result; returns ErrNotFound if none matches.
+	FindOne(ctx context.Context, filter Filter, result interface{}) error
+	// Find decodes all matching documents into results, a pointer to a slice.
+	Find(ctx context.Context, filter Filter, opts FindOptions, results interface{}) error
+	// ReplaceOne replaces the first matching document and returns the number of matched documents.
+	ReplaceOne(ctx context.Context, filter Filter, document interface{}, upsert bool) (int64, error)
+	// DeleteOne deletes the first matching document and returns the number of deleted documents.
+	DeleteOne(ctx context.Context, filter Filter) (int64, error)
+	// CreateIndexes creates the indexes if they do not exist.
+	CreateIndexes(ctx context.Context, indexes []sharedtypes.MongoIndex) error
+}
+
+// Database is the subset of a MongoDB database used by the repositories.
+type Database interface {
+	Collection(name string) Collection
+}
+
+// validator is implemented by documents that can be validated before writing.
+type validator interface {
+	Validate() error
+}
+
+// EnsureIndexes creates the indexes of all multi-agent collections.
+//
+// Parameters:
+//   - ctx: the context
+//   - db: the database
+//
+// Returns:
+//   - error: an error if an index could not be created
+func EnsureIndexes(ctx context.Context, db Database) error {
+	for name, indexes := range sharedtypes.MongoCollectionIndexes {
+		if err := db.Collection(name).CreateIndexes(ctx, indexes); err != nil {
+			return fmt.Errorf("error creating indexes of collection '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Repository provides typed access to the documents of one collection.
+type Repository[T any] struct {
+	collection Collection
+	name       string
+}
+
+// NewRepository creates a repository for a collection.
+//
+// Parameters:
+//   - db: the database
+//   - name: the collection name
+//
+// Returns:
+//   - *Repository[T]: the repository
+func NewRepository[T any](db Database, name string) *Repository[T] {
+	return &Repository[T]{collection: db.Collection(name), name: name}
+}
+
+// Insert validates and inserts a document.
+func (r *Repository[T]) Insert(ctx context.Context, document *T) error {
+	if err := validate(document); err != nil {
+		return err
+	}
+	if err := r.collection.InsertOne(ctx, document); err != nil {
+		return fmt.Errorf("error inserting into '%s': %w", r.name, err)
+	}
+	return nil
+}
+
+// Get returns the document with the ID.
+func (r *Repository[T]) Get(ctx context.Context, id string) (*T, error) {
+	var document T
+	if err := r.collection.FindOne(ctx, Filter{"_id": id}, &document); err != nil {
+		return nil, fmt.Errorf("error getting '%s' from '%s': %w", id, r.name, err)
+	}
+	return &document, nil
+}
+
+// Replace validates and replaces the document matching the filter; returns ErrNotFound if none matches.
+func (r *Repository[T]) Replace(ctx context.Context, filter Filter, document *T) error {
+	if err := validate(document); err != nil {
+		return err
+	}
+	matched, err := r.collection.ReplaceOne(ctx, filter, document, false)
+	if err != nil {
+		return fmt.Errorf("error replacing in '%s': %w", r.name, err)
+	}
+	if matched == 0 {
+		return fmt.Errorf("error replacing in '%s': %w", r.name, ErrNotFound)
+	}
+	return nil
+}
+
+// Upsert validates and inserts or replaces the document with the ID.
+func (r *Repository[T]) Upsert(ctx context.Context, id string, document *T) error {
+	if err := validate(document); err != nil {
+		return err
+	}
+	if _, err := r.collection.ReplaceOne(ctx, Filter{"_id": id}, document, true); err != nil {
+		return fmt.Errorf("error upserting '%s' in '%s': %w", id, r.name, err)
+	}
+	return nil
+}
+
+// Delete deletes the document with the ID; returns ErrNotFound if it does not exist.
+func (r *Repository[T]) Delete(ctx context.Context, id string) error {
+	deleted, err := r.collection.DeleteOne(ctx, Filter{"_id": id})
+	if err != nil {
+		return fmt.Errorf("error deleting '%s' from '%s': %w", id, r.name, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("error deleting '%s' from '%s': %w", id, r.name, ErrNotFound)
+	}
+	return nil
+}
+
+// Find returns the documents matching the filter.
+func (r *Repository[T]) Find(ctx context.Context, filter Filter, opts FindOptions) ([]T, error) {
+	documents := []T{}
+	if err := r.collection.Find(ctx, filter, opts, &documents); err != nil {
+		return nil, fmt.Errorf("error finding in '%s': %w", r.name, err)
+	}
+	return documents, nil
+}
+
+// validate validates a document if it implements Validate.
+func validate(document interface{}) error {
+	if v, ok := document.(validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// maxConflictRetries is the number of attempts of optimistic read-modify-write updates.
+const maxConflictRetries = 5
+
+// ConversationRepository stores conversations.
+type ConversationRepository struct {
+	*Repository[sharedtypes.ConversationDocument]
+	now func() time.Time
+}
+
+// NewConversationRepository creates a repository for the conversations collection.
+//
+// Parameters:
+//   - db: the database
+//
+// Returns:
+//   - *ConversationRepository: the repository
+func NewConversationRepository(db Database) *ConversationRepository {
+	return &ConversationRepository{
+		Repository: NewRepository[sharedtypes.ConversationDocument](db, sharedtypes.MongoCollectionConversations),
+		now:        time.Now,
+	}
+}
+
+// Create inserts a new conversation and sets its timestamps.
+func (r *ConversationRepository) Create(ctx context.Context, conversation *sharedtypes.ConversationDocument) error {
+	now := timestamp(r.now)
+	conversation.CreatedAt, conversation.UpdatedAt = now, now
+	if conversation.Messages == nil {
+		conversation.Messages = []sharedtypes.ConversationHistoryMessage{}
+	}
+	return r.Insert(ctx, conversation)
+}
+
+// AppendMessages appends messages to a conversation. Concurrent appends are detected with the
+// updated_at timestamp and retried.
+//
+// Parameters:
+//   - ctx: the context
+//   - id: the conversation ID
+//   - messages: the messages to append
+//
+// Returns:
+//   - *sharedtypes.ConversationDocument: the updated conversation
+//   - error: ErrNotFound if the conversation does not exist, ErrConflict if retries are exhausted
+func (r *ConversationRepository) AppendMessages(ctx context.Context, id string, messages ...sharedtypes.ConversationHistoryMessage) (*sharedtypes.ConversationDocument, error) {
+	for attempt := 0; attempt < maxConflictRetries; attempt++ {
+		conversation, err := r.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		previous := conversation.UpdatedAt
+		conversation.Messages = append(conversation.Messages, messages...)
+		conversation.UpdatedAt = timestamp(r.now)
+		if !conversation.UpdatedAt.After(previous) {
+			conversation.UpdatedAt = previous.Add(time.Millisecond)
+		}
+		err = r.Replace(ctx, Filter{"_id": id, "updated_at": previous}, conversation)
+		if err == nil {
+			return conversation, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("error appending to conversation '%s': %w", id, ErrConflict)
+}
+
+// ListByUser returns the most recently updated conversations of a user.
+func (r *ConversationRepository) ListByUser(ctx context.Context, userId string, limit int64) ([]sharedtypes.ConversationDocument, error) {
+	return r.Find(ctx, Filter{"user_id": userId}, FindOptions{
+		Sort:  []sharedtypes.MongoIndexKey{{Field: "updated_at", Order: -1}},
+		Limit: limit,
+	})
+}
+
+// WorkflowRunRepository stores workflow runs.
+type WorkflowRunRepository struct {
+	*Repository[sharedtypes.WorkflowRunDocument]
+	now func() time.Time
+}
+
+// NewWorkflowRunRepository creates a repository for the workflow_runs collection.
+//
+// Parameters:
+//   - db: the database
+//
+// Returns:
+//   - *WorkflowRunRepository: the repository
+func NewWorkflowRunRepository(db Database) *WorkflowRunRepository {
+	return &WorkflowRunRepository{
+		Repository: NewRepository[sharedtypes.WorkflowRunDocument](db, sharedtypes.MongoCollectionWorkflowRuns),
+		now:        time.Now,
+	}
+}
+
+// Create inserts a new run, sets its timestamps and defaults the status to pending.
+func (r *WorkflowRunRepository) Create(ctx context.Context, run *sharedtypes.WorkflowRunDocument) error {
+	now := timestamp(r.now)
+	run.CreatedAt, run.UpdatedAt = now, now
+	if run.Status == "" {
+		run.Status = sharedtypes.WorkflowRunStatusPending
+	}
+	return r.Insert(ctx, run)
+}
+
+// UpdateStatus sets the status of a run; outputs and the error are stored if given.
+// FinishedAt is set when the run reaches a terminal state.
+//
+// Parameters:
+//   - ctx: the context
+//   - id: the workflow run ID
+//   - status: the new status, one of sharedtypes.WorkflowRunStatuses
+//   - outputs: the outputs of the run, nil to keep the current outputs
+//   - runErr: the error of a failed run, nil to keep the current error
+//
+// Returns:
+//   - *sharedtypes.WorkflowRunDocument: the updated run
+//   - error: ErrNotFound if the run does not exist, ErrConflict if retries are exhausted
+func (r *WorkflowRunRepository) UpdateStatus(ctx context.Context, id string, status string, outputs map[string]sharedtypes.WorkflowRunValue, runErr *sharedtypes.ApiError) (*sharedtypes.WorkflowRunDocument, error) {
+	for attempt := 0; attempt < maxConflictRetries; attempt++ {
+		run, err := r.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		previous := run.UpdatedAt
+		run.Status = status
+		if outputs != nil {
+			run.Outputs = outputs
+		}
+		if runErr != nil {
+			run.Error = runErr
+		}
+		run.UpdatedAt = timestamp(r.now)
+		if !run.UpdatedAt.After(previous) {
+			run.UpdatedAt = previous.Add(time.Millisecond)
+		}
+		if (&sharedtypes.WorkflowRunStatusResponse{Status: status}).IsTerminal() && run.FinishedAt == nil {
+			finishedAt := run.UpdatedAt
+			run.FinishedAt = &finishedAt
+		}
+		err = r.Replace(ctx, Filter{"_id": id, "updated_at": previous}, run)
+		if err == nil {
+			return run, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("error updating workflow run '%s': %w", id, ErrConflict)
+}
+
+// ListByWorkflow returns the most recent runs of a workflow.
+func (r *WorkflowRunRepository) ListByWorkflow(ctx context.Context, workflowId string, limit int64) ([]sharedtypes.WorkflowRunDocument, error) {
+	return r.Find(ctx, Filter{"workflow_id": workflowId}, FindOptions{
+		Sort:  []sharedtypes.MongoIndexKey{{Field: "created_at", Order: -1}},
+		Limit: limit,
+	})
+}
+
+// ListByConversation returns the runs of a conversation in creation order.
+func (r *WorkflowRunRepository) ListByConversation(ctx context.Context, conversationId string) ([]sharedtypes.WorkflowRunDocument, error) {
+	return r.Find(ctx, Filter{"conversation_id": conversationId}, FindOptions{
+		Sort: []sharedtypes.MongoIndexKey{{Field: "created_at", Order: 1}},
+	})
+}
+
+// FeedbackRepository stores message feedback, one document per user and message.
+type FeedbackRepository struct {
+	*Repository[sharedtypes.FeedbackDocument]
+	now func() time.Time
+}
+
+// NewFeedbackRepository creates a repository for the feedback collection.
+//
+// Parameters:
+//   - db: the database
+//
+// Returns:
+//   - *FeedbackRepository: the repository
+func NewFeedbackRepository(db Database) *FeedbackRepository {
+	return &FeedbackRepository{
+		Repository: NewRepository[sharedtypes.FeedbackDocument](db, sharedtypes.MongoCollectionFeedback),
+		now:        time.Now,
+	}
+}
+
+// Apply applies a feedback request of a user to the stored feedback of the message.
+//
+// Parameters:
+//   - ctx: the context
+//   - conversationId: the conversation ID
+//   - workflowId: the workflow ID
+//   - userId: the user ID
+//   - feedback: the feedback request
+//
+// Returns:
+//   - *sharedtypes.FeedbackDocument: the stored feedback
+//   - error: an error if the feedback could not be stored
+func (r *FeedbackRepository) Apply(ctx context.Context, conversationId string, workflowId string, userId string, feedback sharedtypes.Feedback) (*sharedtypes.FeedbackDocument, error) {
+	id := sharedtypes.FeedbackDocumentId(conversationId, feedback.MessageId, userId)
+	now := timestamp(r.now)
+	document, err := r.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		document = &sharedtypes.FeedbackDocument{
+			Id:             id,
+			ConversationId: conversationId,
+			MessageId:      feedback.MessageId,
+			UserId:         userId,
+			WorkflowId:     workflowId,
+			CreatedAt:      now,
+		}
+	}
+	switch {
+	case feedback.AddPositive:
+		document.Positive, document.Negative = true, false
+	case feedback.AddNegative:
+		document.Positive, document.Negative = false, true
+	}
+	if feedback.RemovePositive {
+		document.Positive = false
+	}
+	if feedback.RemoveNegative {
+		document.Negative = false
+	}
+	if feedback.FeedbackText != "" {
+		document.Text = feedback.FeedbackText
+	}
+	document.UpdatedAt = now
+	if err := r.Upsert(ctx, id, document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// ListByConversation returns the feedback of a conversation.
+func (r *FeedbackRepository) ListByConversation(ctx context.Context, conversationId string) ([]sharedtypes.FeedbackDocument, error) {
+	return r.Find(ctx, Filter{"conversation_id": conversationId}, FindOptions{
+		Sort: []sharedtypes.MongoIndexKey{{Field: "created_at", Order: 1}},
+	})
+}
+
+// timestamp returns the current time truncated to milliseconds, the precision of BSON dates,
+// so timestamps read back from the database compare equal to the ones written.
+func timestamp(now func() time.Time) time.Time {
+	return now().UTC().Truncate(time.Millisecond)
+}
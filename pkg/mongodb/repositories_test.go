@@ -0,0 +1,214 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// fakeClock returns a clock advancing one second per call.
+func fakeClock() func() time.Time {
+	current := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		current = current.Add(time.Second)
+		return current
+	}
+}
+
+func TestEnsureIndexes(t *testing.T) {
+	db := NewMemoryDatabase()
+	if err := EnsureIndexes(context.Background(), db); err != nil {
+		t.Fatalf("EnsureIndexes() error = %v", err)
+	}
+	if err := EnsureIndexes(context.Background(), db); err != nil {
+		t.Fatalf("EnsureIndexes() twice error = %v", err)
+	}
+	for name, indexes := range sharedtypes.MongoCollectionIndexes {
+		if got := db.Indexes(name); len(got) != len(indexes) {
+			t.Errorf("Indexes(%s) = %d indexes, want %d", name, len(got), len(indexes))
+		}
+	}
+}
+
+func TestConversationRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewConversationRepository(NewMemoryDatabase())
+	repo.now = fakeClock()
+
+	for _, id := range []string{"c1", "c2", "c3"} {
+		userId := "alice"
+		if id == "c3" {
+			userId = "bob"
+		}
+		if err := repo.Create(ctx, &sharedtypes.ConversationDocument{Id: id, UserId: userId, WorkflowId: "wf"}); err != nil {
+			t.Fatalf("Create(%s) error = %v", id, err)
+		}
+	}
+	if err := repo.Create(ctx, &sharedtypes.ConversationDocument{Id: "c1", UserId: "alice"}); !errors.Is(err, ErrDuplicateKey) {
+		t.Errorf("Create() duplicate error = %v, want ErrDuplicateKey", err)
+	}
+	if err := repo.Create(ctx, &sharedtypes.ConversationDocument{Id: "c4"}); err == nil {
+		t.Error("Create() without user succeeded")
+	}
+
+	updated, err := repo.AppendMessages(ctx, "c1",
+		sharedtypes.ConversationHistoryMessage{MessageId: "m1", Role: "user", Content: "hello"},
+		sharedtypes.ConversationHistoryMessage{MessageId: "m2", Role: "assistant", Content: "hi"})
+	if err != nil {
+		t.Fatalf("AppendMessages() error = %v", err)
+	}
+	if len(updated.Messages) != 2 || !updated.UpdatedAt.After(updated.CreatedAt) {
+		t.Errorf("AppendMessages() = %+v", updated)
+	}
+	stored, err := repo.Get(ctx, "c1")
+	if err != nil || len(stored.Messages) != 2 || stored.Messages[1].Content != "hi" {
+		t.Fatalf("Get() = %+v, %v", stored, err)
+	}
+	if _, err := repo.AppendMessages(ctx, "missing", sharedtypes.ConversationHistoryMessage{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("AppendMessages(missing) error = %v, want ErrNotFound", err)
+	}
+
+	list, err := repo.ListByUser(ctx, "alice", 0)
+	if err != nil {
+		t.Fatalf("ListByUser() error = %v", err)
+	}
+	if len(list) != 2 || list[0].Id != "c1" || list[1].Id != "c2" {
+		t.Errorf("ListByUser() = %+v, want c1 (most recently updated) then c2", list)
+	}
+	if list, _ := repo.ListByUser(ctx, "alice", 1); len(list) != 1 {
+		t.Errorf("ListByUser() with limit = %d documents, want 1", len(list))
+	}
+
+	if err := repo.Delete(ctx, "c1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.Delete(ctx, "c1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() twice error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestConversationRepositoryConflict(t *testing.T) {
+	ctx := context.Background()
+	repo := NewConversationRepository(NewMemoryDatabase())
+	repo.now = fakeClock()
+	if err := repo.Create(ctx, &sharedtypes.ConversationDocument{Id: "c", UserId: "u"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// a stale replace must not overwrite a newer document
+	stale, _ := repo.Get(ctx, "c")
+	if _, err := repo.AppendMessages(ctx, "c", sharedtypes.ConversationHistoryMessage{MessageId: "m1"}); err != nil {
+		t.Fatalf("AppendMessages() error = %v", err)
+	}
+	stale.Title = "stale"
+	if err := repo.Replace(ctx, Filter{"_id": "c", "updated_at": stale.UpdatedAt}, stale); !errors.Is(err, ErrNotFound) {
+		t.Errorf("stale Replace() error = %v, want ErrNotFound", err)
+	}
+	stored, _ := repo.Get(ctx, "c")
+	if len(stored.Messages) != 1 || stored.Title != "" {
+		t.Errorf("stale Replace() overwrote the document: %+v", stored)
+	}
+}
+
+func TestWorkflowRunRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWorkflowRunRepository(NewMemoryDatabase())
+	repo.now = fakeClock()
+
+	for _, id := range []string{"r1", "r2"} {
+		if err := repo.Create(ctx, &sharedtypes.WorkflowRunDocument{Id: id, WorkflowId: "wf", UserId: "u", ConversationId: "c"}); err != nil {
+			t.Fatalf("Create(%s) error = %v", id, err)
+		}
+	}
+	run, err := repo.Get(ctx, "r1")
+	if err != nil || run.Status != sharedtypes.WorkflowRunStatusPending {
+		t.Fatalf("Get() = %+v, %v", run, err)
+	}
+
+	run, err = repo.UpdateStatus(ctx, "r1", sharedtypes.WorkflowRunStatusRunning, nil, nil)
+	if err != nil || run.FinishedAt != nil {
+		t.Fatalf("UpdateStatus(running) = %+v, %v", run, err)
+	}
+	outputs := map[string]sharedtypes.WorkflowRunValue{"answer": {Value: "42", GoType: "int"}}
+	run, err = repo.UpdateStatus(ctx, "r1", sharedtypes.WorkflowRunStatusSucceeded, outputs, nil)
+	if err != nil || run.FinishedAt == nil || run.Outputs["answer"].Value != "42" {
+		t.Fatalf("UpdateStatus(succeeded) = %+v, %v", run, err)
+	}
+	if _, err := repo.UpdateStatus(ctx, "r1", "done", nil, nil); err == nil {
+		t.Error("UpdateStatus() with unknown status succeeded")
+	}
+
+	list, err := repo.ListByWorkflow(ctx, "wf", 0)
+	if err != nil || len(list) != 2 || list[0].Id != "r2" {
+		t.Errorf("ListByWorkflow() = %+v, %v, want r2 first", list, err)
+	}
+	list, err = repo.ListByConversation(ctx, "c")
+	if err != nil || len(list) != 2 || list[0].Id != "r1" {
+		t.Errorf("ListByConversation() = %+v, %v, want r1 first", list, err)
+	}
+}
+
+func TestFeedbackRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFeedbackRepository(NewMemoryDatabase())
+	repo.now = fakeClock()
+
+	tests := []struct {
+		name         string
+		feedback     sharedtypes.Feedback
+		wantPositive bool
+		wantNegative bool
+		wantText     string
+	}{
+		{"add positive", sharedtypes.Feedback{MessageId: "m", AddPositive: true}, true, false, ""},
+		{"switch to negative", sharedtypes.Feedback{MessageId: "m", AddNegative: true, FeedbackText: "wrong"}, false, true, "wrong"},
+		{"remove negative", sharedtypes.Feedback{MessageId: "m", RemoveNegative: true}, false, false, "wrong"},
+	}
+	var createdAt time.Time
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := repo.Apply(ctx, "c", "wf", "u", tt.feedback)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if doc.Positive != tt.wantPositive || doc.Negative != tt.wantNegative || doc.Text != tt.wantText {
+				t.Errorf("Apply() = %+v", doc)
+			}
+			if createdAt.IsZero() {
+				createdAt = doc.CreatedAt
+			} else if !doc.CreatedAt.Equal(createdAt) {
+				t.Errorf("Apply() changed CreatedAt to %s", doc.CreatedAt)
+			}
+		})
+	}
+
+	list, err := repo.ListByConversation(ctx, "c")
+	if err != nil || len(list) != 1 || list[0].Id != sharedtypes.FeedbackDocumentId("c", "m", "u") {
+		t.Errorf("ListByConversation() = %+v, %v", list, err)
+	}
+}
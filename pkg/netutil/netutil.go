@@ -0,0 +1,183 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package netutil parses and normalizes the endpoints of the Aali services.
+//
+// Endpoints are configured in several forms: full URLs ("https://flowkit:50051"), bare
+// host:port pairs without a scheme ("flowkit:50051") and legacy port settings ("8080").
+// ParseEndpoint and ListenAddress handle these forms in one place, and DialIPv4First is the
+// dialer shared by the gRPC and HTTP clients.
+package netutil
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// tlsSchemes are the schemes of endpoints using TLS
+var tlsSchemes = map[string]bool{
+	"https": true,
+	"wss":   true,
+	"grpcs": true,
+}
+
+// plainSchemes are the schemes of endpoints without TLS
+var plainSchemes = map[string]bool{
+	"http": true,
+	"ws":   true,
+	"grpc": true,
+}
+
+// defaultPorts are the ports used by Address for endpoints without a port
+var defaultPorts = map[string]int{
+	"http":  80,
+	"ws":    80,
+	"grpc":  80,
+	"https": 443,
+	"wss":   443,
+	"grpcs": 443,
+}
+
+// Endpoint is a parsed service endpoint
+type Endpoint struct {
+	Scheme string // lowercase scheme; "http" for endpoints configured without a scheme
+	Host   string // host name or IP address, without brackets for IPv6 addresses
+	Port   int    // port, 0 if not set; Address uses the default port of the scheme in this case
+	Path   string // path, empty if not set
+	TLS    bool   // true if the scheme uses TLS
+}
+
+// ParseEndpoint parses an endpoint URL
+// Endpoints without a scheme, like "localhost:50051", are parsed as "http" endpoints for
+// compatibility with the legacy endpoint settings.
+//
+// Parameters:
+//   - raw: the endpoint
+//
+// Returns:
+//   - endpoint: the parsed endpoint
+//   - err: an error if the endpoint is empty, has an unsupported scheme or an invalid port
+func ParseEndpoint(raw string) (endpoint Endpoint, err error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return Endpoint{}, fmt.Errorf("endpoint is empty")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("invalid endpoint '%v': %w", raw, err)
+	}
+
+	endpoint.Scheme = strings.ToLower(parsed.Scheme)
+	endpoint.TLS = tlsSchemes[endpoint.Scheme]
+	if !endpoint.TLS && !plainSchemes[endpoint.Scheme] {
+		return Endpoint{}, fmt.Errorf("unsupported scheme '%v' in endpoint '%v'", parsed.Scheme, raw)
+	}
+
+	endpoint.Host = parsed.Hostname()
+	if port := parsed.Port(); port != "" {
+		endpoint.Port, err = strconv.Atoi(port)
+		if err != nil || endpoint.Port > 65535 {
+			return Endpoint{}, fmt.Errorf("invalid port '%v' in endpoint '%v'", port, raw)
+		}
+	}
+	endpoint.Path = strings.TrimSuffix(parsed.Path, "/")
+	return endpoint, nil
+}
+
+// Address returns the host:port of the endpoint, with the default port of the scheme if no port is set
+//
+// Returns:
+//   - string: the address, e.g. "flowkit:50051" or "flowkit.example.com:443"
+func (endpoint Endpoint) Address() string {
+	port := endpoint.Port
+	if port == 0 {
+		port = defaultPorts[endpoint.Scheme]
+	}
+	return net.JoinHostPort(endpoint.Host, strconv.Itoa(port))
+}
+
+// URL returns the normalized URL of the endpoint
+// The port is only included if it is set, so the URL of an endpoint without a port uses the default port of the scheme.
+//
+// Returns:
+//   - string: the URL, e.g. "https://flowkit:50051"
+func (endpoint Endpoint) URL() string {
+	host := endpoint.Host
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if endpoint.Port != 0 {
+		host = net.JoinHostPort(endpoint.Host, strconv.Itoa(endpoint.Port))
+	}
+	return endpoint.Scheme + "://" + host + endpoint.Path
+}
+
+// ListenAddress returns the address a server listens on
+// The configured address takes precedence over the legacy port setting, which listens on all interfaces.
+//
+// Parameters:
+//   - configAddress: the configured address, e.g. "0.0.0.0:8080"
+//   - legacyPort: the legacy port setting, e.g. "8080" or ":8080"
+//
+// Returns:
+//   - address: the address to listen on
+//   - err: an error if both the address and the legacy port are empty
+func ListenAddress(configAddress string, legacyPort string) (address string, err error) {
+	if configAddress != "" {
+		return configAddress, nil
+	}
+	if legacyPort = strings.TrimPrefix(strings.TrimSpace(legacyPort), ":"); legacyPort != "" {
+		return "0.0.0.0:" + legacyPort, nil
+	}
+	return "", fmt.Errorf("both address and legacy port are empty")
+}
+
+// DialIPv4First connects to an address over IPv4, falling back to IPv6 if IPv4 fails
+// It has the signature of grpc.WithContextDialer.
+//
+// Parameters:
+//   - ctx: the context of the connection attempt
+//   - addr: the host:port to connect to
+//
+// Returns:
+//   - net.Conn: the connection
+//   - error: the error of the IPv6 attempt if both attempts fail
+func DialIPv4First(ctx context.Context, addr string) (net.Conn, error) {
+	d := &net.Dialer{}
+
+	// Try IPv4 first
+	conn, err := d.DialContext(ctx, "tcp4", addr)
+	if err == nil {
+		return conn, nil
+	}
+
+	// Fall back to IPv6 if IPv4 fails
+	return d.DialContext(ctx, "tcp6", addr)
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package netutil
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    Endpoint
+		address string
+		url     string
+		wantErr string
+	}{
+		{
+			name:    "http url",
+			raw:     "http://localhost:50051",
+			want:    Endpoint{Scheme: "http", Host: "localhost", Port: 50051},
+			address: "localhost:50051",
+			url:     "http://localhost:50051",
+		},
+		{
+			name:    "https url with path",
+			raw:     " HTTPS://flowkit.example.com/api/ ",
+			want:    Endpoint{Scheme: "https", Host: "flowkit.example.com", Path: "/api", TLS: true},
+			address: "flowkit.example.com:443",
+			url:     "https://flowkit.example.com/api",
+		},
+		{
+			name:    "bare host and port",
+			raw:     "aali-flowkit:50051",
+			want:    Endpoint{Scheme: "http", Host: "aali-flowkit", Port: 50051},
+			address: "aali-flowkit:50051",
+			url:     "http://aali-flowkit:50051",
+		},
+		{
+			name:    "ipv6",
+			raw:     "wss://[::1]:9003",
+			want:    Endpoint{Scheme: "wss", Host: "::1", Port: 9003, TLS: true},
+			address: "[::1]:9003",
+			url:     "wss://[::1]:9003",
+		},
+		{
+			name:    "ipv6 without port",
+			raw:     "grpc://[fe80::1]",
+			want:    Endpoint{Scheme: "grpc", Host: "fe80::1"},
+			address: "[fe80::1]:80",
+			url:     "grpc://[fe80::1]",
+		},
+		{
+			name:    "http without port",
+			raw:     "kvdb",
+			want:    Endpoint{Scheme: "http", Host: "kvdb"},
+			address: "kvdb:80",
+			url:     "http://kvdb",
+		},
+		{name: "empty", raw: " ", wantErr: "endpoint is empty"},
+		{name: "unsupported scheme", raw: "ftp://files:21", wantErr: "unsupported scheme 'ftp'"},
+		{name: "invalid port", raw: "http://localhost:99999", wantErr: "invalid port '99999'"},
+		{name: "non numeric port", raw: "localhost:http", wantErr: "invalid endpoint"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, err := ParseEndpoint(tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseEndpoint(%q) error = %v, want %q", tt.raw, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseEndpoint(%q) error = %v", tt.raw, err)
+			}
+			if endpoint != tt.want {
+				t.Errorf("ParseEndpoint(%q) = %+v, want %+v", tt.raw, endpoint, tt.want)
+			}
+			if got := endpoint.Address(); got != tt.address {
+				t.Errorf("Address() = %q, want %q", got, tt.address)
+			}
+			if got := endpoint.URL(); got != tt.url {
+				t.Errorf("URL() = %q, want %q", got, tt.url)
+			}
+		})
+	}
+}
+
+func TestListenAddress(t *testing.T) {
+	tests := []struct {
+		name       string
+		address    string
+		legacyPort string
+		want       string
+		wantErr    bool
+	}{
+		{"address", "127.0.0.1:3000", "9090", "127.0.0.1:3000", false},
+		{"legacy port", "", "9090", "0.0.0.0:9090", false},
+		{"legacy port with colon", "", ":9090", "0.0.0.0:9090", false},
+		{"neither", "", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ListenAddress(tt.address, tt.legacyPort)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ListenAddress(%q, %q) = %q, %v, want %q", tt.address, tt.legacyPort, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialIPv4First(t *testing.T) {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	defer listener.Close()
+
+	conn, err := DialIPv4First(context.Background(), listener.Addr().String())
+	if err != nil {
+		t.Fatalf("DialIPv4First() error = %v", err)
+	}
+	defer conn.Close()
+	if remote := conn.RemoteAddr().(*net.TCPAddr); remote.IP.To4() == nil {
+		t.Errorf("RemoteAddr() = %v, want IPv4", remote)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package problem writes the errors of the REST APIs as RFC 7807 problem+json responses,
+// so all services return the same error body regardless of where the error originated.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/google/uuid"
+)
+
+// internalDetail replaces the message of internal errors, so unexpected failures
+// do not leak implementation details to clients
+const internalDetail = "an internal error occurred; the correlation ID identifies it in the logs"
+
+// FromError converts an error to a problem
+// The status is derived with aalierrors.ToHTTPStatus, so *aalierrors.Error and gRPC status errors keep their
+// classification. The message of internal errors is replaced with a generic detail; they are expected
+// to be logged with the correlation ID.
+//
+// Parameters:
+//   - err: the error
+//   - instance: the URI of the failed request, e.g. its path
+//   - correlationId: the ID correlating the request with its logs
+//
+// Returns:
+//   - sharedtypes.ProblemDetails: the problem
+func FromError(err error, instance string, correlationId string) sharedtypes.ProblemDetails {
+	var problem *sharedtypes.ProblemDetails
+	if errors.As(err, &problem) {
+		// Forward a problem received from another service unchanged
+		forwarded := *problem
+		forwarded.Instance = instance
+		forwarded.CorrelationId = correlationId
+		return forwarded
+	}
+
+	code := aalierrors.CodeOf(err)
+	details := sharedtypes.NewProblemDetails(aalierrors.ToHTTPStatus(err), err.Error())
+	details.Type = sharedtypes.ProblemTypePrefix + string(code)
+	details.Instance = instance
+	details.CorrelationId = correlationId
+	details.Code = string(code)
+	if code == aalierrors.CodeInternal {
+		details.Detail = internalDetail
+	}
+
+	var e *aalierrors.Error
+	if errors.As(err, &e) {
+		details.Details = e.Details
+	}
+	return details
+}
+
+// Write writes an error as problem+json response
+// The correlation ID is taken from the sharedtypes.CorrelationIdHeader of the request, or generated,
+// and returned in the same header. Errors with status 500 and above are logged with it.
+//
+// Parameters:
+//   - w: the response writer
+//   - r: the failed request
+//   - err: the error
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	correlationId := CorrelationId(r)
+	details := FromError(err, r.URL.Path, correlationId)
+
+	if details.Status >= http.StatusInternalServerError {
+		logCtx, ctxErr := logging.CreateCtxFromHeader(r)
+		if ctxErr != nil {
+			logCtx = &logging.ContextMap{}
+		}
+		logging.Log.Errorf(logCtx, "request %s %s failed (correlation ID %s): %v", r.Method, r.URL.Path, correlationId, err)
+	}
+
+	w.Header().Set("Content-Type", sharedtypes.ProblemContentType)
+	w.Header().Set(sharedtypes.CorrelationIdHeader, correlationId)
+	w.WriteHeader(details.Status)
+	_ = json.NewEncoder(w).Encode(details)
+}
+
+// WriteStatus writes an error as problem+json response with an explicit status,
+// for errors that do not carry an aalierrors code, e.g. rejected credentials or exceeded rate limits.
+// The code of the problem is derived from the status with aalierrors.FromHTTPStatus.
+//
+// Parameters:
+//   - w: the response writer
+//   - r: the failed request
+//   - status: the HTTP status code
+//   - err: the error; its message is the detail of the problem
+func WriteStatus(w http.ResponseWriter, r *http.Request, status int, err error) {
+	code := aalierrors.FromHTTPStatus(status)
+	details := sharedtypes.NewProblemDetails(status, err.Error())
+	details.Type = sharedtypes.ProblemTypePrefix + string(code)
+	details.Code = string(code)
+	Write(w, r, &details)
+}
+
+// CorrelationId returns the correlation ID of a request
+//
+// Parameters:
+//   - r: the request
+//
+// Returns:
+//   - string: the sharedtypes.CorrelationIdHeader of the request; a new UUID if not set
+func CorrelationId(r *http.Request) string {
+	if correlationId := r.Header.Get(sharedtypes.CorrelationIdHeader); correlationId != "" {
+		return correlationId
+	}
+	return uuid.NewString()
+}
+
+// HandlerFunc is an HTTP handler returning an error, written by ServeHTTP as problem+json response.
+// The handler must not have written a response when it returns an error.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP calls the handler and writes its error, or the error of a recovered panic, with Write.
+//
+// Parameters:
+//   - w: the response writer
+//   - r: the request
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f.serve(w, r); err != nil {
+		Write(w, r, err)
+	}
+}
+
+// serve calls the handler, converting a panic to a *logging.PanicError, which is an internal error
+func (f HandlerFunc) serve(w http.ResponseWriter, r *http.Request) (err error) {
+	defer logging.RecoverPanic(&logging.ContextMap{}, r.Method+" "+r.URL.Path, &err)
+	return f(w, r)
+}
+
+// Middleware writes a problem+json response for panics of the next handler,
+// so plain http.Handlers answer with the same error body as HandlerFunc.
+//
+// Parameters:
+//   - next: the handler
+//
+// Returns:
+//   - http.Handler: the handler recovering panics
+func Middleware(next http.Handler) http.Handler {
+	return HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		next.ServeHTTP(w, r)
+		return nil
+	})
+}
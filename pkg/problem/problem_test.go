@@ -0,0 +1,152 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aalierrors"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromError(t *testing.T) {
+	workflowCtx := &logging.ContextMap{}
+	workflowCtx.Set(logging.WorkflowId, "wf-1")
+
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantDetail  string
+		wantDetails map[string]string
+	}{
+		{"validation", aalierrors.New(workflowCtx, aalierrors.CodeValidation, "name is required"), http.StatusBadRequest, "validation", "name is required", map[string]string{"workflowId": "wf-1"}},
+		{"grpc status", status.Error(codes.NotFound, "workflow not found"), http.StatusNotFound, "not_found", "rpc error: code = NotFound desc = workflow not found", nil},
+		{"internal hides message", errors.New("nil pointer in resolver"), http.StatusInternalServerError, "internal", internalDetail, nil},
+		{"forwarded problem", &sharedtypes.ProblemDetails{Type: "about:blank", Title: "Conflict", Status: http.StatusConflict, Detail: "already finished"}, http.StatusConflict, "", "already finished", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromError(tt.err, "/v1/runs", "corr-1")
+			if got.Status != tt.wantStatus || got.Code != tt.wantCode || got.Detail != tt.wantDetail {
+				t.Errorf("FromError() = %+v, want status %d, code %q, detail %q", got, tt.wantStatus, tt.wantCode, tt.wantDetail)
+			}
+			if got.Instance != "/v1/runs" || got.CorrelationId != "corr-1" || got.Title == "" {
+				t.Errorf("FromError() = %+v, want instance, correlation ID and title", got)
+			}
+			if len(got.Details) != len(tt.wantDetails) || got.Details["workflowId"] != tt.wantDetails["workflowId"] {
+				t.Errorf("Details = %v, want %v", got.Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestHandlerFunc(t *testing.T) {
+	logging.CaptureForTest(t)
+	tests := []struct {
+		name       string
+		handler    HandlerFunc
+		wantStatus int
+	}{
+		{"success", func(w http.ResponseWriter, r *http.Request) error { w.WriteHeader(http.StatusNoContent); return nil }, http.StatusNoContent},
+		{"error", func(w http.ResponseWriter, r *http.Request) error {
+			return aalierrors.New(nil, aalierrors.CodeAuth, "no key")
+		}, http.StatusUnauthorized},
+		{"panic", func(w http.ResponseWriter, r *http.Request) error { panic("boom") }, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/v1/runs", nil)
+			request.Header.Set(sharedtypes.CorrelationIdHeader, "corr-1")
+			recorder := httptest.NewRecorder()
+			tt.handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantStatus < 400 {
+				return
+			}
+			if got := recorder.Header().Get("Content-Type"); got != sharedtypes.ProblemContentType {
+				t.Errorf("Content-Type = %q", got)
+			}
+			if got := recorder.Header().Get(sharedtypes.CorrelationIdHeader); got != "corr-1" {
+				t.Errorf("correlation ID header = %q, want corr-1", got)
+			}
+			var body sharedtypes.ProblemDetails
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil || body.Status != tt.wantStatus || body.CorrelationId != "corr-1" {
+				t.Errorf("body = %s, error = %v", recorder.Body.String(), err)
+			}
+		})
+	}
+}
+
+func TestWriteStatus(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/v1/runs", nil)
+	request.Header.Set(sharedtypes.CorrelationIdHeader, "corr-1")
+	recorder := httptest.NewRecorder()
+	WriteStatus(recorder, request, http.StatusTooManyRequests, errors.New("too many requests of tenant alice"))
+
+	if recorder.Code != http.StatusTooManyRequests || recorder.Header().Get("Content-Type") != sharedtypes.ProblemContentType {
+		t.Fatalf("status = %d, Content-Type = %q", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	var body sharedtypes.ProblemDetails
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	want := sharedtypes.ProblemDetails{
+		Type:          sharedtypes.ProblemTypePrefix + "transient",
+		Title:         "Too Many Requests",
+		Status:        http.StatusTooManyRequests,
+		Detail:        "too many requests of tenant alice",
+		Instance:      "/v1/runs",
+		CorrelationId: "corr-1",
+		Code:          "transient",
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("body = %+v, want %+v", body, want)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	logging.CaptureForTest(t)
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { panic("boom") }))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", recorder.Code)
+	}
+	if recorder.Header().Get(sharedtypes.CorrelationIdHeader) == "" {
+		t.Error("no correlation ID was generated")
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Code generated by internal/gen/protoconv/gen.go; DO NOT EDIT.
+
+package protoconv
+
+import (
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// ResourceLimitsFromProto converts aaliflowkitgrpc.ResourceLimits to sharedtypes.ResourceLimits
+func ResourceLimitsFromProto(in *aaliflowkitgrpc.ResourceLimits) (out sharedtypes.ResourceLimits) {
+	if in == nil {
+		return out
+	}
+	out.CpuSeconds = int(in.CpuSeconds)
+	out.MemoryMb = int(in.MemoryMb)
+	out.TimeoutSeconds = int(in.TimeoutSeconds)
+	out.NetworkAllowed = in.NetworkAllowed
+	return out
+}
+
+// ResourceLimitsToProto converts sharedtypes.ResourceLimits to aaliflowkitgrpc.ResourceLimits
+func ResourceLimitsToProto(in sharedtypes.ResourceLimits) *aaliflowkitgrpc.ResourceLimits {
+	out := &aaliflowkitgrpc.ResourceLimits{}
+	out.CpuSeconds = int32(in.CpuSeconds)
+	out.MemoryMb = int32(in.MemoryMb)
+	out.TimeoutSeconds = int32(in.TimeoutSeconds)
+	out.NetworkAllowed = in.NetworkAllowed
+	return out
+}
+
+// FunctionInputFromProto converts aaliflowkitgrpc.FunctionInputDefinition to sharedtypes.FunctionInput
+func FunctionInputFromProto(in *aaliflowkitgrpc.FunctionInputDefinition) (out sharedtypes.FunctionInput) {
+	if in == nil {
+		return out
+	}
+	out.Name = in.Name
+	out.Type = in.Type
+	out.GoType = in.GoType
+	out.Options = in.Options
+	if out.Options == nil {
+		out.Options = []string{}
+	}
+	out.DefaultValue = in.DefaultValue
+	out.Required = in.Required
+	return out
+}
+
+// FunctionInputToProto converts sharedtypes.FunctionInput to aaliflowkitgrpc.FunctionInputDefinition
+func FunctionInputToProto(in sharedtypes.FunctionInput) *aaliflowkitgrpc.FunctionInputDefinition {
+	out := &aaliflowkitgrpc.FunctionInputDefinition{}
+	out.Name = in.Name
+	out.Type = in.Type
+	out.GoType = in.GoType
+	out.Options = in.Options
+	out.DefaultValue = in.DefaultValue
+	out.Required = in.Required
+	return out
+}
+
+// FunctionOutputFromProto converts aaliflowkitgrpc.FunctionOutputDefinition to sharedtypes.FunctionOutput
+func FunctionOutputFromProto(in *aaliflowkitgrpc.FunctionOutputDefinition) (out sharedtypes.FunctionOutput) {
+	if in == nil {
+		return out
+	}
+	out.Name = in.Name
+	out.Type = in.Type
+	out.GoType = in.GoType
+	return out
+}
+
+// FunctionOutputToProto converts sharedtypes.FunctionOutput to aaliflowkitgrpc.FunctionOutputDefinition
+func FunctionOutputToProto(in sharedtypes.FunctionOutput) *aaliflowkitgrpc.FunctionOutputDefinition {
+	out := &aaliflowkitgrpc.FunctionOutputDefinition{}
+	out.Name = in.Name
+	out.Type = in.Type
+	out.GoType = in.GoType
+	return out
+}
+
+// FunctionDefinitionFromProto converts aaliflowkitgrpc.FunctionDefinition to sharedtypes.FunctionDefinition
+// The fields FlowkitUrl, ApiKey, Type, Path are not part of the proto message and are left empty.
+func FunctionDefinitionFromProto(in *aaliflowkitgrpc.FunctionDefinition) (out sharedtypes.FunctionDefinition) {
+	if in == nil {
+		return out
+	}
+	out.Name = in.Name
+	out.DisplayName = in.DisplayName
+	out.Description = in.Description
+	out.Category = in.Category
+	out.Inputs = make([]sharedtypes.FunctionInput, len(in.Input))
+	for i, item := range in.Input {
+		out.Inputs[i] = FunctionInputFromProto(item)
+	}
+	out.Outputs = make([]sharedtypes.FunctionOutput, len(in.Output))
+	for i, item := range in.Output {
+		out.Outputs[i] = FunctionOutputFromProto(item)
+	}
+	out.DeprecatedParams = in.DeprecatedParams
+	out.Examples = in.Examples
+	out.TimeoutSeconds = int(in.TimeoutSeconds)
+	out.Idempotent = in.Idempotent
+	out.MaxConcurrency = int(in.MaxConcurrency)
+	if in.ResourceLimits != nil {
+		value := ResourceLimitsFromProto(in.ResourceLimits)
+		out.ResourceLimits = &value
+	}
+	return out
+}
+
+// FunctionDefinitionToProto converts sharedtypes.FunctionDefinition to aaliflowkitgrpc.FunctionDefinition
+func FunctionDefinitionToProto(in sharedtypes.FunctionDefinition) *aaliflowkitgrpc.FunctionDefinition {
+	out := &aaliflowkitgrpc.FunctionDefinition{}
+	out.Name = in.Name
+	out.DisplayName = in.DisplayName
+	out.Description = in.Description
+	out.Category = in.Category
+	if in.Inputs != nil {
+		out.Input = make([]*aaliflowkitgrpc.FunctionInputDefinition, len(in.Inputs))
+		for i, item := range in.Inputs {
+			out.Input[i] = FunctionInputToProto(item)
+		}
+	}
+	if in.Outputs != nil {
+		out.Output = make([]*aaliflowkitgrpc.FunctionOutputDefinition, len(in.Outputs))
+		for i, item := range in.Outputs {
+			out.Output[i] = FunctionOutputToProto(item)
+		}
+	}
+	out.DeprecatedParams = in.DeprecatedParams
+	out.Examples = in.Examples
+	out.TimeoutSeconds = int32(in.TimeoutSeconds)
+	out.Idempotent = in.Idempotent
+	out.MaxConcurrency = int32(in.MaxConcurrency)
+	if in.ResourceLimits != nil {
+		out.ResourceLimits = ResourceLimitsToProto(*in.ResourceLimits)
+	}
+	return out
+}
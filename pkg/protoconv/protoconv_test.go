@@ -0,0 +1,82 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package protoconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/ansys/aali-sharedtypes/pkg/testutil"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFunctionDefinitionRoundTrip(t *testing.T) {
+	// every field is set, so fields missing in the generated converters are detected
+	definition := testutil.Sample[sharedtypes.FunctionDefinition]()
+	definition.FlowkitUrl, definition.ApiKey, definition.Type, definition.Path = "", "", "", ""
+
+	got := FunctionDefinitionFromProto(FunctionDefinitionToProto(definition))
+	if !reflect.DeepEqual(got, definition) {
+		t.Errorf("round trip = %+v, want %+v", got, definition)
+	}
+}
+
+func TestFunctionDefinitionProtoRoundTrip(t *testing.T) {
+	message := testutil.Sample[aaliflowkitgrpc.FunctionDefinition]()
+
+	got := FunctionDefinitionToProto(FunctionDefinitionFromProto(&message))
+	if !proto.Equal(got, &message) {
+		t.Errorf("round trip = %v, want %v", got, &message)
+	}
+}
+
+func TestFunctionDefinitionFromProtoEmpty(t *testing.T) {
+	if got := FunctionDefinitionFromProto(nil); !reflect.DeepEqual(got, sharedtypes.FunctionDefinition{}) {
+		t.Errorf("FunctionDefinitionFromProto(nil) = %+v, want zero value", got)
+	}
+
+	got := FunctionDefinitionFromProto(&aaliflowkitgrpc.FunctionDefinition{
+		Name:  "f",
+		Input: []*aaliflowkitgrpc.FunctionInputDefinition{{Name: "a"}},
+	})
+	if got.Inputs[0].Options == nil || len(got.Inputs[0].Options) != 0 {
+		t.Errorf("Options = %#v, want empty slice", got.Inputs[0].Options)
+	}
+	if got.Outputs == nil || len(got.Outputs) != 0 {
+		t.Errorf("Outputs = %#v, want empty slice", got.Outputs)
+	}
+}
+
+func TestFunctionInputOutputRoundTrip(t *testing.T) {
+	input := testutil.Sample[sharedtypes.FunctionInput]()
+	if got := FunctionInputFromProto(FunctionInputToProto(input)); !reflect.DeepEqual(got, input) {
+		t.Errorf("FunctionInput round trip = %+v, want %+v", got, input)
+	}
+
+	output := testutil.Sample[sharedtypes.FunctionOutput]()
+	if got := FunctionOutputFromProto(FunctionOutputToProto(output)); !reflect.DeepEqual(got, output) {
+		t.Errorf("FunctionOutput round trip = %+v, want %+v", got, output)
+	}
+}
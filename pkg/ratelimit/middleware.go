@@ -0,0 +1,143 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/problem"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// HTTPMiddleware rejects HTTP requests exceeding the limits of their tenant with status 429,
+// a Retry-After header and a problem+json body.
+// The tenant is the identity verified by the authentication middleware, see TenantKeyFromContext,
+// so the rate limit middleware must be installed after it.
+//
+// Parameters:
+//   - limiter: the limiter; requests are not limited if nil
+//
+// Returns:
+//   - func(http.Handler) http.Handler: the middleware
+func HTTPMiddleware(limiter *Limiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limiter == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logCtx, err := logging.CreateCtxFromHeader(r)
+			if err != nil {
+				logCtx = &logging.ContextMap{}
+			}
+			tenant := TenantKeyFromContext(logCtx)
+
+			release, retryAfter, err := limiter.Acquire(tenant)
+			defer release()
+			if err != nil {
+				logging.Log.Warnf(logCtx, "rejected request of tenant '%s': %v", tenant, err)
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(retryAfter)))
+				problem.WriteStatus(w, r, http.StatusTooManyRequests, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// UnaryServerInterceptor rejects unary gRPC calls exceeding the limits of their tenant with codes.ResourceExhausted.
+// The tenant is the identity verified by the authentication interceptor, see TenantKeyFromContext,
+// so the rate limit interceptor must be chained after it.
+//
+// Parameters:
+//   - limiter: the limiter; calls are not limited if nil
+//
+// Returns:
+//   - grpc.UnaryServerInterceptor: the interceptor
+func UnaryServerInterceptor(limiter *Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if limiter == nil {
+			return handler(ctx, req)
+		}
+		release, err := acquireGrpc(ctx, limiter, info.FullMethod)
+		defer release()
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects streaming gRPC calls exceeding the limits of their tenant with codes.ResourceExhausted.
+// A stream counts as one request and occupies one concurrency slot until it ends.
+//
+// Parameters:
+//   - limiter: the limiter; calls are not limited if nil
+//
+// Returns:
+//   - grpc.StreamServerInterceptor: the interceptor
+func StreamServerInterceptor(limiter *Limiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if limiter == nil {
+			return handler(srv, ss)
+		}
+		release, err := acquireGrpc(ss.Context(), limiter, info.FullMethod)
+		defer release()
+		if err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// acquireGrpc admits a gRPC call and converts a rejection to a gRPC status error.
+func acquireGrpc(ctx context.Context, limiter *Limiter, method string) (func(), error) {
+	logCtx, err := logging.CreateCtxFromMetaData(ctx)
+	if err != nil {
+		logCtx = &logging.ContextMap{}
+	}
+	tenant := TenantKeyFromContext(logCtx)
+
+	release, retryAfter, err := limiter.Acquire(tenant)
+	if err == nil {
+		return release, nil
+	}
+	logging.Log.Warnf(logCtx, "rejected call to %s of tenant '%s': %v", method, tenant, err)
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(retryAfterSeconds(retryAfter))))
+	return release, status.Error(codes.ResourceExhausted, err.Error())
+}
+
+// retryAfterSeconds rounds a retry duration up to whole seconds, between 1 second and 1 day.
+func retryAfterSeconds(retryAfter time.Duration) int {
+	if retryAfter > 24*time.Hour {
+		retryAfter = 24 * time.Hour
+	}
+	return max(1, int(math.Ceil(retryAfter.Seconds())))
+}
@@ -0,0 +1,372 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package ratelimit provides per-tenant request rate and concurrency limits for AALI services.
+package ratelimit
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+)
+
+// AnonymousTenant is the tenant of requests without authenticated user or API key.
+const AnonymousTenant = "anonymous"
+
+// minPruneThreshold is the number of tenants above which the limiter first drops idle tenant states.
+const minPruneThreshold = 1024
+
+// ErrRateLimited is returned if a tenant exceeds its request rate.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// ErrTooManyConcurrent is returned if a tenant exceeds its concurrent requests.
+var ErrTooManyConcurrent = errors.New("too many concurrent requests")
+
+// Limits represents the limits of a tenant.
+type Limits struct {
+	RequestsPerMinute int // sustained request rate; 0 means unlimited
+	Burst             int // maximum burst of requests; defaults to RequestsPerMinute
+	MaxConcurrent     int // maximum concurrent requests; 0 means unlimited
+}
+
+// TokenBucket is a token bucket rate limiter. It is safe for concurrent use.
+type TokenBucket struct {
+	mu       sync.Mutex
+	rate     float64 // tokens per second
+	capacity float64
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+// NewTokenBucket creates a full token bucket.
+//
+// Parameters:
+//   - ratePerSecond: the rate at which tokens are refilled
+//   - burst: the capacity of the bucket
+//
+// Returns:
+//   - *TokenBucket: the token bucket
+func NewTokenBucket(ratePerSecond float64, burst int) *TokenBucket {
+	return newTokenBucket(ratePerSecond, burst, time.Now)
+}
+
+// newTokenBucket creates a full token bucket with the given clock.
+func newTokenBucket(ratePerSecond float64, burst int, now func() time.Time) *TokenBucket {
+	return &TokenBucket{
+		rate:     ratePerSecond,
+		capacity: float64(burst),
+		tokens:   float64(burst),
+		last:     now(),
+		now:      now,
+	}
+}
+
+// Allow takes a token from the bucket if one is available.
+//
+// Returns:
+//   - bool: true if a token was taken
+//   - time.Duration: the time until the next token is available if no token was taken
+func (tb *TokenBucket) Allow() (bool, time.Duration) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill()
+	if tb.tokens >= 1 {
+		tb.tokens--
+		return true, 0
+	}
+	if tb.rate <= 0 {
+		return false, time.Duration(math.MaxInt64)
+	}
+	return false, time.Duration((1 - tb.tokens) / tb.rate * float64(time.Second))
+}
+
+// isFull checks if the bucket is refilled to its capacity, which makes it equivalent to a new bucket.
+func (tb *TokenBucket) isFull() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.refill()
+	return tb.tokens >= tb.capacity
+}
+
+// refill adds the tokens accumulated since the last refill; the lock must be held.
+func (tb *TokenBucket) refill() {
+	now := tb.now()
+	tb.tokens = math.Min(tb.capacity, tb.tokens+now.Sub(tb.last).Seconds()*tb.rate)
+	tb.last = now
+}
+
+// ConcurrencyLimiter limits the number of concurrent operations. It is safe for concurrent use.
+type ConcurrencyLimiter struct {
+	mu       sync.Mutex
+	max      int
+	inFlight int
+}
+
+// NewConcurrencyLimiter creates a concurrency limiter.
+//
+// Parameters:
+//   - max: the maximum number of concurrent operations
+//
+// Returns:
+//   - *ConcurrencyLimiter: the concurrency limiter
+func NewConcurrencyLimiter(max int) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{max: max}
+}
+
+// TryAcquire starts an operation if the limit is not reached. Every successful call must be followed by Release.
+//
+// Returns:
+//   - bool: true if the operation may start
+func (cl *ConcurrencyLimiter) TryAcquire() bool {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	if cl.inFlight >= cl.max {
+		return false
+	}
+	cl.inFlight++
+	return true
+}
+
+// Release ends an operation started by TryAcquire.
+func (cl *ConcurrencyLimiter) Release() {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	if cl.inFlight > 0 {
+		cl.inFlight--
+	}
+}
+
+// InFlight returns the number of running operations.
+//
+// Returns:
+//   - int: the number of running operations
+func (cl *ConcurrencyLimiter) InFlight() int {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	return cl.inFlight
+}
+
+// tenantState holds the limiters of a tenant.
+type tenantState struct {
+	bucket      *TokenBucket
+	concurrency *ConcurrencyLimiter
+	lastUsed    time.Time
+}
+
+// isIdle checks if the state is equivalent to a new state, so it can be dropped without resetting any limit.
+func (s *tenantState) isIdle() bool {
+	return (s.bucket == nil || s.bucket.isFull()) && (s.concurrency == nil || s.concurrency.InFlight() == 0)
+}
+
+// Limiter enforces per-tenant limits. It is safe for concurrent use.
+//
+// The states of idle tenants are dropped when the number of tenants doubles, so the memory used by
+// the limiter stays proportional to the number of recently active tenants.
+type Limiter struct {
+	mu         sync.Mutex
+	defaults   Limits
+	overrides  map[string]Limits
+	tenants    map[string]*tenantState
+	pruneAbove int // number of tenants above which idle tenants are dropped
+	now        func() time.Time
+}
+
+// NewLimiter creates a limiter.
+//
+// Parameters:
+//   - defaults: the limits of tenants without override
+//   - overrides: the limits of specific tenants by tenant key; may be nil
+//
+// Returns:
+//   - *Limiter: the limiter
+func NewLimiter(defaults Limits, overrides map[string]Limits) *Limiter {
+	return &Limiter{
+		defaults:   defaults,
+		overrides:  overrides,
+		tenants:    map[string]*tenantState{},
+		pruneAbove: minPruneThreshold,
+		now:        time.Now,
+	}
+}
+
+// NewLimiterFromConfig creates a limiter from the RATE_LIMIT_* properties of the global config.
+//
+// Returns:
+//   - *Limiter: the limiter; nil if RATE_LIMIT_ENABLED is false
+func NewLimiterFromConfig() *Limiter {
+	cfg := config.Current()
+	if !cfg.RATE_LIMIT_ENABLED {
+		return nil
+	}
+	overrides := make(map[string]Limits, len(cfg.RATE_LIMIT_TENANT_OVERRIDES))
+	for _, override := range cfg.RATE_LIMIT_TENANT_OVERRIDES {
+		limits := Limits{
+			RequestsPerMinute: override.REQUESTS_PER_MINUTE,
+			Burst:             override.BURST,
+			MaxConcurrent:     override.MAX_CONCURRENT,
+		}
+		// the tenant of an override may be a user ID or an API key ID
+		overrides[TenantKey(override.TENANT, "")] = limits
+		overrides[TenantKey("", override.TENANT)] = limits
+	}
+	return NewLimiter(Limits{
+		RequestsPerMinute: cfg.RATE_LIMIT_REQUESTS_PER_MINUTE,
+		Burst:             cfg.RATE_LIMIT_BURST,
+		MaxConcurrent:     cfg.RATE_LIMIT_MAX_CONCURRENT,
+	}, overrides)
+}
+
+// Acquire admits a request of a tenant.
+// The concurrency limit is checked first, so requests rejected for concurrency do not use up the request rate.
+//
+// Parameters:
+//   - tenant: the tenant key, see TenantKey
+//
+// Returns:
+//   - release: the function to call when the request has finished; never nil
+//   - retryAfter: the time after which the request may be retried if it was rejected
+//   - err: ErrRateLimited or ErrTooManyConcurrent if the request was rejected
+func (l *Limiter) Acquire(tenant string) (release func(), retryAfter time.Duration, err error) {
+	// the slot and the token are taken under the lock, so the state cannot be pruned before it is in use
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	state := l.tenant(tenant)
+
+	release = func() {}
+	if state.concurrency != nil {
+		if !state.concurrency.TryAcquire() {
+			return release, time.Second, ErrTooManyConcurrent
+		}
+		var once sync.Once
+		release = func() { once.Do(state.concurrency.Release) }
+	}
+	if state.bucket != nil {
+		if ok, wait := state.bucket.Allow(); !ok {
+			release()
+			return func() {}, wait, ErrRateLimited
+		}
+	}
+	return release, 0, nil
+}
+
+// Prune removes the state of tenants idle for longer than maxIdle and without running requests.
+//
+// Parameters:
+//   - maxIdle: the maximum idle time
+//
+// Returns:
+//   - int: the number of removed tenants
+func (l *Limiter) Prune(maxIdle time.Duration) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	removed := 0
+	cutoff := l.now().Add(-maxIdle)
+	for key, state := range l.tenants {
+		if state.lastUsed.Before(cutoff) && (state.concurrency == nil || state.concurrency.InFlight() == 0) {
+			delete(l.tenants, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// pruneIdle drops the states of all idle tenants; the lock must be held.
+func (l *Limiter) pruneIdle() {
+	for key, state := range l.tenants {
+		if state.isIdle() {
+			delete(l.tenants, key)
+		}
+	}
+}
+
+// tenant returns the state of a tenant, creating it on first use; the lock must be held.
+func (l *Limiter) tenant(tenant string) *tenantState {
+	state, ok := l.tenants[tenant]
+	if !ok {
+		if len(l.tenants) >= l.pruneAbove {
+			l.pruneIdle()
+			l.pruneAbove = max(2*len(l.tenants), minPruneThreshold)
+		}
+		limits, hasOverride := l.overrides[tenant]
+		if !hasOverride {
+			limits = l.defaults
+		}
+		state = &tenantState{}
+		if limits.RequestsPerMinute > 0 {
+			burst := limits.Burst
+			if burst <= 0 {
+				burst = limits.RequestsPerMinute
+			}
+			state.bucket = newTokenBucket(float64(limits.RequestsPerMinute)/60, burst, l.now)
+		}
+		if limits.MaxConcurrent > 0 {
+			state.concurrency = NewConcurrencyLimiter(limits.MaxConcurrent)
+		}
+		l.tenants[tenant] = state
+	}
+	state.lastUsed = l.now()
+	return state
+}
+
+// TenantKey returns the key of the tenant of a request. The user ID takes precedence over the API key ID.
+//
+// Parameters:
+//   - userId: the authenticated user ID; may be empty
+//   - apiKeyId: the ID of the verified API key; may be empty
+//
+// Returns:
+//   - string: the tenant key
+func TenantKey(userId string, apiKeyId string) string {
+	switch {
+	case userId != "":
+		return "user:" + userId
+	case apiKeyId != "":
+		return "apikey:" + apiKeyId
+	default:
+		return AnonymousTenant
+	}
+}
+
+// TenantKeyFromContext returns the key of the tenant of a request from the identity set by the
+// authentication middleware: the UserId of a validated token or the ApiKeyId of a verified API key.
+// Client-asserted values of a received logging context are ignored, so callers cannot pick their
+// tenant; requests without authenticated identity share the AnonymousTenant.
+//
+// Parameters:
+//   - ctx: the logging context; may be nil
+//
+// Returns:
+//   - string: the tenant key
+func TenantKeyFromContext(ctx *logging.ContextMap) string {
+	if ctx == nil {
+		return AnonymousTenant
+	}
+	userId, _ := ctx.GetAuthenticated(logging.UserId)
+	apiKeyId, _ := ctx.GetAuthenticated(logging.ApiKeyId)
+	return TenantKey(userId, apiKeyId)
+}
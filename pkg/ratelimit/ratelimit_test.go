@@ -0,0 +1,403 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeClock is a manually advanced clock.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func TestTokenBucket(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	bucket := newTokenBucket(2, 3, clock.now)
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := bucket.Allow(); !ok {
+			t.Fatalf("request %d of burst rejected", i)
+		}
+	}
+	ok, wait := bucket.Allow()
+	if ok {
+		t.Fatal("request after burst allowed")
+	}
+	if wait != 500*time.Millisecond {
+		t.Errorf("retry after = %v, want 500ms", wait)
+	}
+
+	clock.t = clock.t.Add(500 * time.Millisecond)
+	if ok, _ := bucket.Allow(); !ok {
+		t.Error("request after refill rejected")
+	}
+
+	// the bucket does not fill beyond its capacity
+	clock.t = clock.t.Add(time.Hour)
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		if ok, _ := bucket.Allow(); ok {
+			allowed++
+		}
+	}
+	if allowed != 3 {
+		t.Errorf("allowed %d requests after idle time, want 3", allowed)
+	}
+}
+
+func TestConcurrencyLimiter(t *testing.T) {
+	limiter := NewConcurrencyLimiter(2)
+	if !limiter.TryAcquire() || !limiter.TryAcquire() {
+		t.Fatal("TryAcquire() within limit failed")
+	}
+	if limiter.TryAcquire() {
+		t.Fatal("TryAcquire() beyond limit succeeded")
+	}
+	limiter.Release()
+	if limiter.InFlight() != 1 || !limiter.TryAcquire() {
+		t.Error("TryAcquire() after Release() failed")
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	limiter := NewLimiter(Limits{RequestsPerMinute: 60, Burst: 1, MaxConcurrent: 1}, map[string]Limits{
+		TenantKey("vip", ""): {},
+	})
+	limiter.now = clock.now
+
+	first, _, err := limiter.Acquire(TenantKey("alice", ""))
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	first()
+	if _, retryAfter, err := limiter.Acquire(TenantKey("alice", "")); !errors.Is(err, ErrRateLimited) || retryAfter != time.Second {
+		t.Errorf("Acquire() = %v, %v, want ErrRateLimited after 1s", retryAfter, err)
+	}
+	clock.t = clock.t.Add(time.Second)
+	release, _, err := limiter.Acquire(TenantKey("alice", ""))
+	if err != nil {
+		t.Fatalf("Acquire() after refill error = %v", err)
+	}
+
+	// tenants are limited independently
+	releaseBob, _, err := limiter.Acquire(TenantKey("bob", ""))
+	if err != nil {
+		t.Fatalf("Acquire() of other tenant error = %v", err)
+	}
+	releaseBob()
+
+	clock.t = clock.t.Add(time.Second)
+	if _, _, err := limiter.Acquire(TenantKey("alice", "")); !errors.Is(err, ErrTooManyConcurrent) {
+		t.Errorf("Acquire() error = %v, want ErrTooManyConcurrent", err)
+	}
+	release()
+	release() // releasing twice must not free a second slot
+	clock.t = clock.t.Add(time.Second)
+	if _, _, err := limiter.Acquire(TenantKey("alice", "")); err != nil {
+		t.Errorf("Acquire() after release error = %v", err)
+	}
+
+	// overrides without limits are unlimited
+	for i := 0; i < 100; i++ {
+		if _, _, err := limiter.Acquire(TenantKey("vip", "")); err != nil {
+			t.Fatalf("Acquire() of unlimited tenant error = %v", err)
+		}
+	}
+
+	clock.t = clock.t.Add(time.Hour)
+	if removed := limiter.Prune(time.Minute); removed != 2 {
+		t.Errorf("Prune() removed %d tenants, want 2 (alice still has a running request)", removed)
+	}
+}
+
+func TestTenantKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		userId   string
+		apiKeyId string
+		want     string
+	}{
+		{"user", "alice", "ci-key", "user:alice"},
+		{"api key", "", "ci-key", "apikey:ci-key"},
+		{"anonymous", "", "", AnonymousTenant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TenantKey(tt.userId, tt.apiKeyId); got != tt.want {
+				t.Errorf("TenantKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTenantKeyFromContext(t *testing.T) {
+	authenticated := &logging.ContextMap{}
+	authenticated.Set(logging.ApiKeyId, "ci-key")
+	if got := TenantKeyFromContext(authenticated); got != "apikey:ci-key" {
+		t.Errorf("TenantKeyFromContext() of authenticated context = %q", got)
+	}
+
+	// identities received from the caller are not trusted
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("aali-logging-context", `[{"userId":"vip","apiKeyId":"ci-key"}]`)
+	asserted, err := logging.CreateCtxFromHeader(req)
+	if err != nil {
+		t.Fatalf("CreateCtxFromHeader() error = %v", err)
+	}
+	if got := TenantKeyFromContext(asserted); got != AnonymousTenant {
+		t.Errorf("TenantKeyFromContext() of client-asserted context = %q, want %q", got, AnonymousTenant)
+	}
+	if got := TenantKeyFromContext(nil); got != AnonymousTenant {
+		t.Errorf("TenantKeyFromContext(nil) = %q, want %q", got, AnonymousTenant)
+	}
+}
+
+func TestLimiterConcurrencyRejectionKeepsTokens(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	limiter := NewLimiter(Limits{RequestsPerMinute: 60, Burst: 2, MaxConcurrent: 1}, nil)
+	limiter.now = clock.now
+
+	release, _, err := limiter.Acquire("alice")
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, _, err := limiter.Acquire("alice"); !errors.Is(err, ErrTooManyConcurrent) {
+			t.Fatalf("Acquire() error = %v, want ErrTooManyConcurrent", err)
+		}
+	}
+	release()
+	// the rejected requests did not take the second token of the burst
+	if _, _, err := limiter.Acquire("alice"); err != nil {
+		t.Errorf("Acquire() after concurrency rejections error = %v", err)
+	}
+}
+
+func TestLimiterRateRejectionReleasesSlot(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	limiter := NewLimiter(Limits{RequestsPerMinute: 60, Burst: 1, MaxConcurrent: 1}, nil)
+	limiter.now = clock.now
+
+	release, _, _ := limiter.Acquire("alice")
+	release()
+	if _, _, err := limiter.Acquire("alice"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Acquire() error = %v, want ErrRateLimited", err)
+	}
+	clock.t = clock.t.Add(time.Second)
+	if _, _, err := limiter.Acquire("alice"); err != nil {
+		t.Errorf("Acquire() after rate limit rejection error = %v, want the concurrency slot to be free", err)
+	}
+}
+
+func TestLimiterDropsIdleTenants(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	limiter := NewLimiter(Limits{RequestsPerMinute: 60, Burst: 1}, nil)
+	limiter.now = clock.now
+
+	for i := 0; i < 10*minPruneThreshold; i++ {
+		limiter.Acquire(fmt.Sprintf("tenant-%d", i))
+		clock.t = clock.t.Add(10 * time.Millisecond)
+	}
+	limiter.mu.Lock()
+	tenants := len(limiter.tenants)
+	limiter.mu.Unlock()
+	if tenants > 2*minPruneThreshold {
+		t.Errorf("limiter holds %d tenants, want at most %d", tenants, 2*minPruneThreshold)
+	}
+
+	// a tenant with a partially used bucket is kept, so its limit is not reset
+	if _, _, err := limiter.Acquire(fmt.Sprintf("tenant-%d", 10*minPruneThreshold-1)); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Acquire() of recent tenant error = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestLimiterPruneDuringAcquire(t *testing.T) {
+	limiter := NewLimiter(Limits{MaxConcurrent: 1}, nil)
+
+	var running, exceeded atomic.Int32
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				// a negative idle time prunes every tenant without running requests
+				limiter.Prune(-time.Minute)
+				runtime.Gosched()
+			}
+		}
+	}()
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 2000; j++ {
+				release, _, err := limiter.Acquire("alice")
+				if err != nil {
+					continue
+				}
+				if running.Add(1) > 1 {
+					exceeded.Add(1)
+				}
+				runtime.Gosched()
+				running.Add(-1)
+				release()
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+
+	if n := exceeded.Load(); n > 0 {
+		t.Errorf("MaxConcurrent exceeded %d times while pruning", n)
+	}
+}
+
+func TestNewLimiterFromConfig(t *testing.T) {
+	logging.InitForTest()
+	previous := config.GlobalConfig
+	defer func() { config.GlobalConfig = previous }()
+
+	config.GlobalConfig = &config.Config{}
+	if NewLimiterFromConfig() != nil {
+		t.Error("NewLimiterFromConfig() with rate limiting disabled returned a limiter")
+	}
+
+	config.GlobalConfig = &config.Config{
+		RATE_LIMIT_ENABLED:             true,
+		RATE_LIMIT_REQUESTS_PER_MINUTE: 1,
+		RATE_LIMIT_TENANT_OVERRIDES:    []config.RateLimitOverride{{TENANT: "ci-key", REQUESTS_PER_MINUTE: 600, BURST: 10}},
+	}
+	limiter := NewLimiterFromConfig()
+	for i := 0; i < 10; i++ {
+		if _, _, err := limiter.Acquire(TenantKey("", "ci-key")); err != nil {
+			t.Fatalf("Acquire() of overridden tenant error = %v", err)
+		}
+	}
+	limiter.Acquire(TenantKey("alice", ""))
+	if _, _, err := limiter.Acquire(TenantKey("alice", "")); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Acquire() error = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	logging.InitForTest()
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := HTTPMiddleware(NewLimiter(Limits{RequestsPerMinute: 1}, nil))(ok)
+
+	// send simulates the authentication middleware, which attaches the verified API key ID
+	send := func(apiKeyId string) *httptest.ResponseRecorder {
+		logCtx := &logging.ContextMap{}
+		logCtx.Set(logging.ApiKeyId, apiKeyId)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(logging.ContextWithLogContext(req.Context(), logCtx))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	if code := send("a").Code; code != http.StatusOK {
+		t.Fatalf("first request status = %d", code)
+	}
+	rejected := send("a")
+	if rejected.Code != http.StatusTooManyRequests || rejected.Header().Get("Retry-After") != "60" {
+		t.Errorf("second request status = %d, Retry-After = %q", rejected.Code, rejected.Header().Get("Retry-After"))
+	}
+	var problem sharedtypes.ProblemDetails
+	if err := json.Unmarshal(rejected.Body.Bytes(), &problem); err != nil || problem.Status != http.StatusTooManyRequests ||
+		rejected.Header().Get("Content-Type") != sharedtypes.ProblemContentType {
+		t.Errorf("rejected body = %s, want problem+json", rejected.Body.String())
+	}
+	if code := send("b").Code; code != http.StatusOK {
+		t.Errorf("request of other tenant status = %d", code)
+	}
+
+	// unauthenticated requests cannot escape their limit by asserting another identity
+	for i, userId := range []string{"x", "y"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("aali-logging-context", fmt.Sprintf(`[{"userId":%q}]`, userId))
+		req.Header.Set("api-key", userId)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		if want := []int{http.StatusOK, http.StatusTooManyRequests}[i]; recorder.Code != want {
+			t.Errorf("unauthenticated request %d status = %d, want %d", i, recorder.Code, want)
+		}
+	}
+
+	unlimited := HTTPMiddleware(nil)(ok)
+	for i := 0; i < 3; i++ {
+		recorder := httptest.NewRecorder()
+		unlimited.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("request without limiter status = %d", recorder.Code)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	logging.InitForTest()
+	interceptor := UnaryServerInterceptor(NewLimiter(Limits{MaxConcurrent: 1}, nil))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "k"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Method"}
+
+	var inner error
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		// a second call of the same tenant while the first is running is rejected
+		_, inner = interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("outer call error = %v", err)
+	}
+	if status.Code(inner) != codes.ResourceExhausted {
+		t.Errorf("inner call error = %v, want ResourceExhausted", inner)
+	}
+}
@@ -23,6 +23,8 @@
 package sharedtypes
 
 import (
+	"fmt"
+
 	"github.com/google/uuid"
 )
 
@@ -51,11 +53,44 @@ type AedtCodeGenerationElement struct {
 	Example    XMLMemberExample `json:"example"`
 	// Only for type "enum"
 	EnumValues []string `json:"enum_values"`
+	// Compatibility metadata
+	Language           string   `json:"language,omitempty"`            // Target language, e.g. "python"
+	Imports            []string `json:"imports,omitempty"`             // Import statements required to use the element
+	RequiredPackages   []string `json:"required_packages,omitempty"`   // Packages required to use the element, e.g. "pyaedt>=0.9"
+	MinVersion         string   `json:"min_version,omitempty"`         // First AEDT version supporting the element, e.g. "2023 R2"
+	MaxVersion         string   `json:"max_version,omitempty"`         // Last AEDT version supporting the element
+	Deprecated         bool     `json:"deprecated,omitempty"`          // Whether the element is deprecated
+	DeprecationMessage string   `json:"deprecation_message,omitempty"` // Reason for the deprecation and migration hints
+	ReplacedBy         string   `json:"replaced_by,omitempty"`         // Name of the element replacing the deprecated element
 	// Metadata for databases
 	VectorDBMetadata any `json:"vector_db_metadata,omitempty"` // Optional metadata for vector databases
 	GraphDBMetadata  any `json:"graph_db_metadata,omitempty"`  // Optional metadata for graph databases
 }
 
+// Validate checks the type and the compatibility metadata of the element.
+//
+// Returns:
+//   - error: an error if the element is invalid
+func (element *AedtCodeGenerationElement) Validate() error {
+	if element.Name == "" {
+		return fmt.Errorf("code generation element has no name")
+	}
+	return validateCodeGenerationCompatibility(element.Type, element.MinVersion, element.MaxVersion, element.Deprecated, element.ReplacedBy)
+}
+
+// IsCompatibleWith reports whether the element is supported by the given AEDT version.
+// Elements without version range are compatible with all versions.
+//
+// Parameters:
+//   - version: the AEDT version, e.g. "2024 R2"
+//
+// Returns:
+//   - bool: true if the version is within the range of the element
+//   - error: an error if a version cannot be parsed
+func (element *AedtCodeGenerationElement) IsCompatibleWith(version string) (bool, error) {
+	return isVersionInRange(version, element.MinVersion, element.MaxVersion)
+}
+
 // AedtApiDbResponse represents the response from the database.
 // ApiDbResponse is now AedtApiDbResponse
 // for remaining DbResponse, use the standard DbResponse
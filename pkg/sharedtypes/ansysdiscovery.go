@@ -22,8 +22,21 @@
 
 package sharedtypes
 
-// SimulationInput captures the schema for simulation validation payloads.
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// DiscoverySimulationInputSchemaVersion is the current schema version of DiscoverySimulationInput.
+// Version 1 are the payloads sent before the schema was versioned, they carry no "schemaVersion" field.
+const DiscoverySimulationInputSchemaVersion = 2
+
+// DiscoverySimulationInput captures the schema for simulation validation payloads.
 type DiscoverySimulationInput struct {
+	SchemaVersion      int                          `json:"schemaVersion,omitempty"`
 	SimulationName     string                       `json:"simulationName"`
 	SimulationType     string                       `json:"simulationType"`
 	Model              string                       `json:"model"`
@@ -35,9 +48,136 @@ type DiscoverySimulationInput struct {
 	BoundaryConditions []DiscoveryBoundaryCondition `json:"boundaryConditions"`
 	Attachments        []DiscoveryAttachment        `json:"attachments,omitempty"`
 	Monitors           []DiscoveryMonitors          `json:"monitors,omitempty"`
+
+	// Fields sent by the plugin that are not part of this schema; preserved when the input is marshaled again
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// discoverySimulationInputMigrations contains the migration from each schema version to the next one.
+var discoverySimulationInputMigrations = map[int]func(payload map[string]json.RawMessage) error{
+	1: migrateDiscoverySimulationInputV1ToV2,
+}
+
+// migrateDiscoverySimulationInputV1ToV2 migrates an unversioned payload to version 2.
+// Version 2 only introduced the "schemaVersion" field, so the payload is kept as is.
+func migrateDiscoverySimulationInputV1ToV2(payload map[string]json.RawMessage) error {
+	return nil
+}
+
+// MigrateDiscoverySimulationInput migrates a raw simulation input payload to the current schema version.
+// Payloads from newer schema versions are returned unchanged.
+//
+// Parameters:
+//   - payload: the JSON object of the simulation input
+//
+// Returns:
+//   - map[string]json.RawMessage: the migrated payload
+//   - error: an error if the schema version is invalid or a migration fails
+func MigrateDiscoverySimulationInput(payload map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	version := 1
+	if raw, ok := payload["schemaVersion"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, fmt.Errorf("invalid schemaVersion: %w", err)
+		}
+		if version < 1 {
+			return nil, fmt.Errorf("invalid schemaVersion %d", version)
+		}
+	}
+
+	for ; version < DiscoverySimulationInputSchemaVersion; version++ {
+		migrate, ok := discoverySimulationInputMigrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from schema version %d", version)
+		}
+		if err := migrate(payload); err != nil {
+			return nil, fmt.Errorf("error migrating simulation input from schema version %d: %w", version, err)
+		}
+		payload["schemaVersion"] = json.RawMessage(fmt.Sprint(version + 1))
+	}
+	return payload, nil
+}
+
+// discoverySimulationInputAlias prevents recursion in the DiscoverySimulationInput JSON methods.
+type discoverySimulationInputAlias DiscoverySimulationInput
+
+// UnmarshalJSON migrates the payload to the current schema version and keeps unknown fields in Extra,
+// so payloads from newer plugin versions can still be consumed.
+func (input *DiscoverySimulationInput) UnmarshalJSON(data []byte) error {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	payload, err := MigrateDiscoverySimulationInput(payload)
+	if err != nil {
+		return err
+	}
+	migrated, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	var alias discoverySimulationInputAlias
+	if err := json.Unmarshal(migrated, &alias); err != nil {
+		return err
+	}
+	*input = DiscoverySimulationInput(alias)
+
+	known := jsonFieldNames(reflect.TypeOf(alias))
+	for key, value := range payload {
+		if known[key] {
+			continue
+		}
+		if input.Extra == nil {
+			input.Extra = map[string]json.RawMessage{}
+		}
+		input.Extra[key] = value
+	}
+	return nil
+}
+
+// MarshalJSON writes the current schema version if none is set and adds the fields preserved in Extra.
+func (input DiscoverySimulationInput) MarshalJSON() ([]byte, error) {
+	if input.SchemaVersion == 0 {
+		input.SchemaVersion = DiscoverySimulationInputSchemaVersion
+	}
+	data, err := json.Marshal(discoverySimulationInputAlias(input))
+	if err != nil || len(input.Extra) == 0 {
+		return data, err
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	for key, value := range input.Extra {
+		if _, ok := payload[key]; !ok {
+			payload[key] = value
+		}
+	}
+	return json.Marshal(payload)
+}
+
+// jsonFieldNames returns the JSON names of the fields of a struct type.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
 }
 
-// Dimensions defines spatial extents and their units.
+// DiscoveryDimensions defines spatial extents and their units.
 type DiscoveryDimensions struct {
 	X     float64 `json:"x"`
 	Y     float64 `json:"y"`
@@ -45,7 +185,7 @@ type DiscoveryDimensions struct {
 	Units string  `json:"units"`
 }
 
-// Material describes a labeled material state.
+// DiscoveryMaterial describes a labeled material state.
 type DiscoveryMaterial struct {
 	Guid    string                 `json:"guid"`
 	Label   string                 `json:"label"`
@@ -53,7 +193,7 @@ type DiscoveryMaterial struct {
 	Details map[string]interface{} `json:"details,omitempty"`
 }
 
-// BoundaryCondition represents physics constraints for the simulation.
+// DiscoveryBoundaryCondition represents physics constraints for the simulation.
 type DiscoveryBoundaryCondition struct {
 	ProxyGuid  string                 `json:"proxyGuid"`
 	ProxyLabel string                 `json:"proxyLabel"`
@@ -61,13 +201,67 @@ type DiscoveryBoundaryCondition struct {
 	Details    map[string]interface{} `json:"details,omitempty"`
 }
 
-// Attachment holds auxiliary binary payloads (e.g., base64-encoded uploads).
+// DiscoveryAttachment holds auxiliary binary payloads (e.g., base64-encoded uploads).
 type DiscoveryAttachment struct {
 	FileName string `json:"fileName"`
 	Data     []byte `json:"data"`
 }
+
+// DiscoveryMonitors describes a result monitored during the simulation.
 type DiscoveryMonitors struct {
 	ProxyGuid  string                 `json:"proxyGuid"`
 	ProxyLabel string                 `json:"proxyLabel"`
 	Details    map[string]interface{} `json:"details"`
 }
+
+// DiscoveryLengthUnits are the length units supported in DiscoveryDimensions.
+var DiscoveryLengthUnits = []string{"m", "cm", "mm", "um", "in", "ft"}
+
+// Validate checks that the simulation input is complete enough to be validated by the agent.
+//
+// Returns:
+//   - error: an error if the input is invalid
+func (input *DiscoverySimulationInput) Validate() error {
+	if input.SimulationName == "" {
+		return fmt.Errorf("simulation name is missing")
+	}
+	if err := input.Dimensions.Validate(); err != nil {
+		return fmt.Errorf("invalid dimensions: %w", err)
+	}
+	if len(input.Materials) == 0 {
+		return fmt.Errorf("at least one material is required")
+	}
+	for i, material := range input.Materials {
+		if material.Guid == "" && material.Label == "" {
+			return fmt.Errorf("material %d has neither guid nor label", i)
+		}
+	}
+	for i, condition := range input.BoundaryConditions {
+		if condition.ProxyGuid == "" {
+			return fmt.Errorf("boundary condition %d has no proxy guid", i)
+		}
+		if condition.Type == "" {
+			return fmt.Errorf("boundary condition %d has no type", i)
+		}
+	}
+	for i, attachment := range input.Attachments {
+		if attachment.FileName == "" {
+			return fmt.Errorf("attachment %d has no file name", i)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the dimensions are non-negative and use a supported length unit.
+//
+// Returns:
+//   - error: an error if the dimensions are invalid
+func (dimensions *DiscoveryDimensions) Validate() error {
+	if !slices.Contains(DiscoveryLengthUnits, dimensions.Units) {
+		return fmt.Errorf("unsupported units %q, expected one of %v", dimensions.Units, DiscoveryLengthUnits)
+	}
+	if dimensions.X < 0 || dimensions.Y < 0 || dimensions.Z < 0 {
+		return fmt.Errorf("dimensions must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiscoverySimulationInputLegacyPayload(t *testing.T) {
+	legacy := `{"simulationName": "Pipe flow", "simulationType": "fluid", "dimensions": {"x": 1, "y": 2, "z": 3, "units": "m"}}`
+
+	var input DiscoverySimulationInput
+	if err := json.Unmarshal([]byte(legacy), &input); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if input.SchemaVersion != DiscoverySimulationInputSchemaVersion {
+		t.Errorf("SchemaVersion = %d, expected %d", input.SchemaVersion, DiscoverySimulationInputSchemaVersion)
+	}
+	if input.SimulationName != "Pipe flow" || input.Dimensions.Z != 3 {
+		t.Errorf("unexpected input: %+v", input)
+	}
+	if len(input.Extra) != 0 {
+		t.Errorf("Extra = %v, expected no unknown fields", input.Extra)
+	}
+}
+
+func TestDiscoverySimulationInputPreservesUnknownFields(t *testing.T) {
+	newer := `{"schemaVersion": 3, "simulationName": "Heat sink", "meshSettings": {"size": 0.01}, "solverHints": ["steady"]}`
+
+	var input DiscoverySimulationInput
+	if err := json.Unmarshal([]byte(newer), &input); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if input.SchemaVersion != 3 || input.SimulationName != "Heat sink" {
+		t.Errorf("unexpected input: %+v", input)
+	}
+	if string(input.Extra["meshSettings"]) != `{"size": 0.01}` || len(input.Extra) != 2 {
+		t.Errorf("Extra = %v", input.Extra)
+	}
+
+	output, err := json.Marshal(input)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var roundTrip map[string]interface{}
+	if err := json.Unmarshal(output, &roundTrip); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if roundTrip["schemaVersion"] != float64(3) || roundTrip["meshSettings"] == nil || roundTrip["solverHints"] == nil {
+		t.Errorf("round trip lost fields: %s", output)
+	}
+}
+
+func TestMigrateDiscoverySimulationInputInvalidVersion(t *testing.T) {
+	for _, version := range []string{`0`, `"two"`} {
+		payload := map[string]json.RawMessage{"schemaVersion": json.RawMessage(version)}
+		if _, err := MigrateDiscoverySimulationInput(payload); err == nil {
+			t.Errorf("MigrateDiscoverySimulationInput() expected an error for schemaVersion %s", version)
+		}
+	}
+}
+
+func TestDiscoverySimulationInputValidate(t *testing.T) {
+	valid := func() DiscoverySimulationInput {
+		return DiscoverySimulationInput{
+			SimulationName:     "Pipe flow",
+			Dimensions:         DiscoveryDimensions{X: 1, Y: 0.2, Z: 0.2, Units: "m"},
+			Materials:          []DiscoveryMaterial{{Guid: "m1", Label: "Water", State: "liquid"}},
+			BoundaryConditions: []DiscoveryBoundaryCondition{{ProxyGuid: "f1", ProxyLabel: "Inlet", Type: "velocity"}},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(input *DiscoverySimulationInput)
+		expectErr bool
+	}{
+		{"valid", func(input *DiscoverySimulationInput) {}, false},
+		{"missing name", func(input *DiscoverySimulationInput) { input.SimulationName = "" }, true},
+		{"unsupported units", func(input *DiscoverySimulationInput) { input.Dimensions.Units = "furlong" }, true},
+		{"negative dimension", func(input *DiscoverySimulationInput) { input.Dimensions.Y = -1 }, true},
+		{"no materials", func(input *DiscoverySimulationInput) { input.Materials = nil }, true},
+		{"unnamed material", func(input *DiscoverySimulationInput) { input.Materials[0] = DiscoveryMaterial{State: "solid"} }, true},
+		{"boundary condition without type", func(input *DiscoverySimulationInput) { input.BoundaryConditions[0].Type = "" }, true},
+		{"attachment without name", func(input *DiscoverySimulationInput) {
+			input.Attachments = []DiscoveryAttachment{{Data: []byte("x")}}
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := valid()
+			tt.modify(&input)
+			err := input.Validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestDiscoverySimulationInputJsonRoundTrip(t *testing.T) {
+	input := DiscoverySimulationInput{
+		SchemaVersion:      DiscoverySimulationInputSchemaVersion,
+		SimulationName:     "Bracket",
+		SimulationType:     "structural",
+		Model:              "bracket.dsco",
+		Objective:          "max stress",
+		UserID:             "user-1",
+		Dimensions:         DiscoveryDimensions{X: 120, Y: 40, Z: 8, Units: "mm"},
+		Materials:          []DiscoveryMaterial{{Guid: "m1", Label: "Steel", State: "solid", Details: map[string]interface{}{"density": 7850.0}}},
+		BoundaryConditions: []DiscoveryBoundaryCondition{{ProxyGuid: "f1", ProxyLabel: "Hole", Type: "fixed"}},
+		Attachments:        []DiscoveryAttachment{{FileName: "load.csv", Data: []byte("1,2,3")}},
+		Monitors:           []DiscoveryMonitors{{ProxyGuid: "b1", ProxyLabel: "Body", Details: map[string]interface{}{"quantity": "stress"}}},
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded DiscoverySimulationInput
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(input, decoded) {
+		t.Errorf("round trip mismatch:\n got  %+v\n want %+v", decoded, input)
+	}
+}
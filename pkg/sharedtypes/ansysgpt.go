@@ -73,3 +73,219 @@ type AnsysGPTRetrieverModuleChunk struct {
 	Version         string  `json:"version"`
 	Weight          float64 `json:"weight"`
 }
+
+// ACSRerankerScoreMax is the maximum semantic reranker score returned by Azure Cognitive Search.
+const ACSRerankerScoreMax = 4.0
+
+// NormalizeACSScore normalizes the scores of an ACS search result to the range [0, 1].
+// The semantic reranker score is used if set, since it is comparable across queries;
+// otherwise the unbounded search score s is mapped to s / (1 + s).
+//
+// Parameters:
+//   - rerankerScore: the semantic reranker score, between 0 and ACSRerankerScoreMax
+//   - searchScore: the search score
+//
+// Returns:
+//   - float64: the normalized score
+func NormalizeACSScore(rerankerScore float64, searchScore float64) float64 {
+	if rerankerScore > 0 {
+		return min(rerankerScore/ACSRerankerScoreMax, 1)
+	}
+	if searchScore <= 0 {
+		return 0
+	}
+	return searchScore / (1 + searchScore)
+}
+
+// NewDbResponseFromACSSearchResponse converts an ACS search result to a DbResponse, so it can be merged
+// and re-ranked with the results of other retrievers.
+// The level 2 source is used as document, the level 3 source as section; the normalized score is stored
+// in Distance and the ACS specific fields in Metadata.
+//
+// Parameters:
+//   - response: the ACS search result
+//
+// Returns:
+//   - DbResponse: the generic search result
+func NewDbResponseFromACSSearchResponse(response ACSSearchResponse) DbResponse {
+	return newDbResponseFromAnsysGPTSource(acsSource{
+		content:         response.Content,
+		sourceTitleLvl2: response.SourceTitleLvl2,
+		sourceTitleLvl3: response.SourceTitleLvl3,
+		sourceUrlLvl2:   response.SourceURLLvl2,
+		sourceUrlLvl3:   response.SourceURLLvl3,
+		physics:         response.Physics,
+		product:         response.Product,
+		version:         response.Version,
+		typeOfAsset:     response.TypeOFasset,
+		indexName:       response.IndexName,
+		tokenSize:       response.TokenSize,
+		weight:          response.Weight,
+		searchScore:     response.SearchScore,
+		rerankerScore:   response.SearchRerankerScore,
+	})
+}
+
+// NewDbResponseFromAnsysGPTRetrieverModuleChunk converts a retriever module chunk to a DbResponse,
+// with the same field mapping as NewDbResponseFromACSSearchResponse.
+//
+// Parameters:
+//   - chunk: the retriever module chunk
+//
+// Returns:
+//   - DbResponse: the generic search result
+func NewDbResponseFromAnsysGPTRetrieverModuleChunk(chunk AnsysGPTRetrieverModuleChunk) DbResponse {
+	return newDbResponseFromAnsysGPTSource(acsSource{
+		content:         chunk.Content,
+		sourceTitleLvl2: chunk.SourceTitleLvl2,
+		sourceTitleLvl3: chunk.SourceTitleLvl3,
+		sourceUrlLvl2:   chunk.SourceUrlLvl2,
+		sourceUrlLvl3:   chunk.SourceUrlLvl3,
+		physics:         chunk.Physics,
+		product:         chunk.Product,
+		version:         chunk.Version,
+		typeOfAsset:     chunk.TypeOfAsset,
+		indexName:       chunk.IndexName,
+		tokenSize:       chunk.TokenSize,
+		weight:          chunk.Weight,
+		searchScore:     chunk.Score,
+		rerankerScore:   chunk.RerankerScore,
+	})
+}
+
+// NewACSSearchResponseFromDbResponse converts a DbResponse created by NewDbResponseFromACSSearchResponse back to an ACS search result.
+// The original search scores are restored from the metadata if present.
+//
+// Parameters:
+//   - response: the generic search result
+//
+// Returns:
+//   - ACSSearchResponse: the ACS search result
+func NewACSSearchResponseFromDbResponse(response DbResponse) ACSSearchResponse {
+	metadataString := func(key string) string {
+		value, _ := response.Metadata[key].(string)
+		return value
+	}
+	metadataFloat := func(key string) float64 {
+		switch value := response.Metadata[key].(type) {
+		case float64:
+			return value
+		case int:
+			return float64(value)
+		}
+		return 0
+	}
+
+	result := ACSSearchResponse{
+		Content:             response.Text,
+		SourceTitleLvl2:     response.DocumentName,
+		SourceTitleLvl3:     response.Title,
+		SourceURLLvl2:       response.DocumentId,
+		SourceURLLvl3:       metadataString("source_url"),
+		Physics:             metadataString("physics"),
+		Product:             metadataString("product"),
+		Version:             metadataString("version"),
+		TypeOFasset:         metadataString("type_of_asset"),
+		IndexName:           metadataString("index_name"),
+		TokenSize:           int(metadataFloat("token_size")),
+		Weight:              metadataFloat("weight"),
+		SearchScore:         metadataFloat("search_score"),
+		SearchRerankerScore: metadataFloat("reranker_score"),
+	}
+	if result.SearchScore == 0 && result.SearchRerankerScore == 0 {
+		result.SearchRerankerScore = response.Distance * ACSRerankerScoreMax
+	}
+	if result.SourceURLLvl3 == result.SourceURLLvl2 {
+		result.SourceURLLvl3 = ""
+	}
+	return result
+}
+
+// NewCitationFromACSSearchResponse converts an ACS search result to a Citation with normalized score.
+// The most specific source (level 3 if set, otherwise level 2) is cited.
+//
+// Parameters:
+//   - response: the ACS search result
+//
+// Returns:
+//   - Citation: the generic citation
+func NewCitationFromACSSearchResponse(response ACSSearchResponse) Citation {
+	dbResponse := NewDbResponseFromACSSearchResponse(response)
+	citation := NewCitationFromDbResponse(dbResponse)
+	citation.SourceType = CitationSourceAnsysGPT
+	// the Distance of converted ACS results holds the normalized relevance, not a distance
+	citation.Score, citation.Distance = dbResponse.Distance, nil
+	if response.SourceTitleLvl3 != "" {
+		citation.Title = response.SourceTitleLvl3
+	}
+	return citation
+}
+
+// NewCitationFromAnsysGPTRetrieverModuleChunk converts a retriever module chunk to a Citation with normalized score.
+// The most specific source (level 3 if set, otherwise level 2) is cited.
+//
+// Parameters:
+//   - chunk: the retriever module chunk
+//
+// Returns:
+//   - Citation: the generic citation
+func NewCitationFromAnsysGPTRetrieverModuleChunk(chunk AnsysGPTRetrieverModuleChunk) Citation {
+	dbResponse := NewDbResponseFromAnsysGPTRetrieverModuleChunk(chunk)
+	citation := NewCitationFromDbResponse(dbResponse)
+	citation.SourceType = CitationSourceAnsysGPT
+	// the Distance of converted ACS results holds the normalized relevance, not a distance
+	citation.Score, citation.Distance = dbResponse.Distance, nil
+	if chunk.SourceTitleLvl3 != "" {
+		citation.Title = chunk.SourceTitleLvl3
+	}
+	return citation
+}
+
+// acsSource contains the fields shared by ACSSearchResponse and AnsysGPTRetrieverModuleChunk.
+type acsSource struct {
+	content         string
+	sourceTitleLvl2 string
+	sourceTitleLvl3 string
+	sourceUrlLvl2   string
+	sourceUrlLvl3   string
+	physics         string
+	product         string
+	version         string
+	typeOfAsset     string
+	indexName       string
+	tokenSize       int
+	weight          float64
+	searchScore     float64
+	rerankerScore   float64
+}
+
+// newDbResponseFromAnsysGPTSource converts the shared fields of an ACS result to a DbResponse.
+func newDbResponseFromAnsysGPTSource(source acsSource) DbResponse {
+	sourceUrl := source.sourceUrlLvl3
+	if sourceUrl == "" {
+		sourceUrl = source.sourceUrlLvl2
+	}
+	documentName := source.sourceTitleLvl2
+	if documentName == "" {
+		documentName = source.sourceTitleLvl3
+	}
+	return DbResponse{
+		DocumentId:   source.sourceUrlLvl2,
+		DocumentName: documentName,
+		Title:        source.sourceTitleLvl3,
+		Text:         source.content,
+		Distance:     NormalizeACSScore(source.rerankerScore, source.searchScore),
+		Metadata: map[string]interface{}{
+			"source_url":     sourceUrl,
+			"physics":        source.physics,
+			"product":        source.product,
+			"version":        source.version,
+			"type_of_asset":  source.typeOfAsset,
+			"index_name":     source.indexName,
+			"token_size":     source.tokenSize,
+			"weight":         source.weight,
+			"search_score":   source.searchScore,
+			"reranker_score": source.rerankerScore,
+		},
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizeACSScore(t *testing.T) {
+	tests := []struct {
+		name          string
+		rerankerScore float64
+		searchScore   float64
+		expected      float64
+	}{
+		{"reranker score", 3, 12.5, 0.75},
+		{"reranker score above max", 4.2, 0, 1},
+		{"search score only", 0, 1, 0.5},
+		{"no score", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := NormalizeACSScore(tt.rerankerScore, tt.searchScore); math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("NormalizeACSScore() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestACSSearchResponseConversion(t *testing.T) {
+	response := ACSSearchResponse{
+		Content:             "Use a swept mesh for thin bodies.",
+		SourceTitleLvl2:     "Meshing User's Guide",
+		SourceURLLvl2:       "https://ansyshelp.ansys.com/meshing",
+		SourceTitleLvl3:     "Sweep Method",
+		SourceURLLvl3:       "https://ansyshelp.ansys.com/meshing/sweep",
+		Product:             "Mechanical",
+		Physics:             "structures",
+		Version:             "2025 R1",
+		IndexName:           "ansysgpt-docs",
+		TokenSize:           120,
+		SearchScore:         8.1,
+		SearchRerankerScore: 2,
+	}
+
+	dbResponse := NewDbResponseFromACSSearchResponse(response)
+	if dbResponse.Text != response.Content || dbResponse.DocumentName != "Meshing User's Guide" || dbResponse.Title != "Sweep Method" {
+		t.Errorf("unexpected DbResponse: %+v", dbResponse)
+	}
+	if dbResponse.Distance != 0.5 {
+		t.Errorf("Distance = %v, expected 0.5", dbResponse.Distance)
+	}
+	if dbResponse.Metadata["source_url"] != response.SourceURLLvl3 || dbResponse.Metadata["product"] != "Mechanical" {
+		t.Errorf("unexpected metadata: %v", dbResponse.Metadata)
+	}
+
+	if roundTrip := NewACSSearchResponseFromDbResponse(dbResponse); roundTrip != response {
+		t.Errorf("round trip mismatch:\n got  %+v\n want %+v", roundTrip, response)
+	}
+
+	citation := NewCitationFromACSSearchResponse(response)
+	if citation.SourceType != CitationSourceAnsysGPT || citation.Title != "Sweep Method" || citation.URL != response.SourceURLLvl3 || citation.Score != 0.5 {
+		t.Errorf("unexpected citation: %+v", citation)
+	}
+}
+
+func TestAnsysGPTRetrieverModuleChunkConversion(t *testing.T) {
+	chunk := AnsysGPTRetrieverModuleChunk{
+		Content:         "Fluent supports overset meshes.",
+		SourceTitleLvl2: "Fluent User's Guide",
+		SourceUrlLvl2:   "https://ansyshelp.ansys.com/fluent",
+		Score:           3,
+	}
+
+	dbResponse := NewDbResponseFromAnsysGPTRetrieverModuleChunk(chunk)
+	if dbResponse.Distance != 0.75 || dbResponse.Metadata["source_url"] != chunk.SourceUrlLvl2 {
+		t.Errorf("unexpected DbResponse: %+v", dbResponse)
+	}
+
+	citation := NewCitationFromAnsysGPTRetrieverModuleChunk(chunk)
+	if citation.Title != "Fluent User's Guide" || citation.URL != chunk.SourceUrlLvl2 || citation.DocumentId != chunk.SourceUrlLvl2 {
+		t.Errorf("unexpected citation: %+v", citation)
+	}
+}
@@ -22,6 +22,8 @@
 
 package sharedtypes
 
+import "sort"
+
 // Represents a criterion returned from the llm
 type MaterialLlmCriterion struct {
 	AttributeName string `json:"attributeName"`
@@ -42,3 +44,58 @@ type MaterialAttribute struct {
 	Name string `json:"name"`
 	Guid string `json:"guid"`
 }
+
+// Represents a material returned by a material search, with the criteria it matched
+type MaterialSearchResult struct {
+	Name            string                      `json:"name"`
+	Guid            string                      `json:"guid"`
+	Score           float64                     `json:"score"` // overall score, higher is better
+	Rank            int                         `json:"rank"`  // 1-based rank within the search results
+	MatchedCriteria []MaterialMatchedCriterion  `json:"matchedCriteria"`
+	Properties      []MaterialPropertyValue     `json:"properties,omitempty"`
+	Explanation     *MaterialRankingExplanation `json:"explanation,omitempty"`
+}
+
+// Represents a criterion evaluated for a material, with the score the material got for it
+type MaterialMatchedCriterion struct {
+	AttributeName string  `json:"attributeName"`
+	AttributeGuid string  `json:"attributeGuid"`
+	Satisfied     bool    `json:"satisfied"`
+	Score         float64 `json:"score"`  // score between 0 and 1
+	Weight        float64 `json:"weight"` // weight of the criterion in the overall score
+	Explanation   string  `json:"explanation,omitempty"`
+}
+
+// Represents the value of a material property with its unit.
+// Point values use Value, ranges use Min and Max, non-numeric values use Text.
+type MaterialPropertyValue struct {
+	AttributeName string   `json:"attributeName"`
+	AttributeGuid string   `json:"attributeGuid"`
+	Value         *float64 `json:"value,omitempty"`
+	Min           *float64 `json:"min,omitempty"`
+	Max           *float64 `json:"max,omitempty"`
+	Text          string   `json:"text,omitempty"`
+	Unit          string   `json:"unit,omitempty"`
+}
+
+// Represents the explanation of why a material got its rank
+type MaterialRankingExplanation struct {
+	Summary    string   `json:"summary"`
+	Strengths  []string `json:"strengths,omitempty"`
+	Weaknesses []string `json:"weaknesses,omitempty"`
+	Tradeoffs  []string `json:"tradeoffs,omitempty"`
+}
+
+// RankMaterialSearchResults sorts the results by descending score and sets their rank.
+// Results with the same score keep their order and get consecutive ranks.
+//
+// Parameters:
+//   - results: the search results to rank
+func RankMaterialSearchResults(results []MaterialSearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	for i := range results {
+		results[i].Rank = i + 1
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import "testing"
+
+func TestRankMaterialSearchResults(t *testing.T) {
+	results := []MaterialSearchResult{
+		{Name: "Aluminum 6061", Score: 0.7},
+		{Name: "Titanium Ti-6Al-4V", Score: 0.9},
+		{Name: "Steel 1020", Score: 0.7},
+	}
+
+	RankMaterialSearchResults(results)
+
+	expected := []string{"Titanium Ti-6Al-4V", "Aluminum 6061", "Steel 1020"}
+	for i, name := range expected {
+		if results[i].Name != name || results[i].Rank != i+1 {
+			t.Errorf("results[%d] = %s (rank %d), expected %s (rank %d)", i, results[i].Name, results[i].Rank, name, i+1)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// API key constants
+const (
+	APIKeyTokenPrefix = "aali_"  // prefix of generated API key tokens: "aali_<id>_<secret>"
+	APIKeyScopeAll    = "*"      // scope granting every permission
+	LegacyAPIKeyId    = "legacy" // ID of an API key created from a plain secret in the config
+	apiKeyHashScheme  = "sha256" // scheme of HashedSecret: "sha256:<salt>:<hash>"
+	apiKeySecretBytes = 32       // random bytes of a generated secret
+	apiKeySaltBytes   = 16       // random bytes of the salt of a hashed secret
+)
+
+// APIKey represents a scoped API key. Only the hash of the secret is stored.
+type APIKey struct {
+	Id           string     `json:"id"`
+	Name         string     `json:"name,omitempty"`       // human-readable name
+	HashedSecret string     `json:"hashed_secret"`        // salted hash of the secret, see HashAPIKeySecret
+	Scopes       []string   `json:"scopes"`               // granted scopes, e.g. "workflows:run"; "*" and "workflows:*" are wildcards
+	Owner        string     `json:"owner"`                // user or service owning the key
+	CreatedAt    time.Time  `json:"created_at"`           // creation time
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"` // nil if the key does not expire
+	Revoked      bool       `json:"revoked,omitempty"`    // if true, the key is no longer accepted
+}
+
+// GenerateAPIKey creates a new API key with a random secret.
+//
+// Parameters:
+//   - owner: the owner of the key
+//   - scopes: the granted scopes
+//   - ttl: the lifetime of the key; the key does not expire if 0
+//
+// Returns:
+//   - APIKey: the API key with the hashed secret
+//   - string: the token to hand out to the owner; it cannot be recovered later
+//   - error: an error if no random secret can be generated
+func GenerateAPIKey(owner string, scopes []string, ttl time.Duration) (APIKey, string, error) {
+	secretBytes := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return APIKey{}, "", fmt.Errorf("error generating API key secret: %w", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	hashedSecret, err := HashAPIKeySecret(secret)
+	if err != nil {
+		return APIKey{}, "", err
+	}
+
+	now := time.Now().UTC()
+	key := APIKey{
+		Id:           strings.ReplaceAll(uuid.NewString(), "-", ""),
+		HashedSecret: hashedSecret,
+		Scopes:       scopes,
+		Owner:        owner,
+		CreatedAt:    now,
+	}
+	if ttl > 0 {
+		expiresAt := now.Add(ttl)
+		key.ExpiresAt = &expiresAt
+	}
+	return key, APIKeyTokenPrefix + key.Id + "_" + secret, nil
+}
+
+// NewLegacyAPIKey creates an API key from a plain secret, e.g. FLOWKIT_API_KEY of the config,
+// so single-key services can use the same verification as scoped keys.
+//
+// Parameters:
+//   - secret: the plain secret
+//   - scopes: the granted scopes
+//
+// Returns:
+//   - APIKey: the API key with ID LegacyAPIKeyId
+//   - error: an error if the secret cannot be hashed
+func NewLegacyAPIKey(secret string, scopes []string) (APIKey, error) {
+	hashedSecret, err := HashAPIKeySecret(secret)
+	if err != nil {
+		return APIKey{}, err
+	}
+	return APIKey{Id: LegacyAPIKeyId, HashedSecret: hashedSecret, Scopes: scopes, CreatedAt: time.Now().UTC()}, nil
+}
+
+// ParseAPIKeyToken splits a token created by GenerateAPIKey into ID and secret.
+//
+// Parameters:
+//   - token: the token
+//
+// Returns:
+//   - id: the ID of the API key
+//   - secret: the secret
+//   - err: an error if the token has not the format "aali_<id>_<secret>"
+func ParseAPIKeyToken(token string) (id string, secret string, err error) {
+	rest, ok := strings.CutPrefix(token, APIKeyTokenPrefix)
+	if !ok {
+		return "", "", fmt.Errorf("API key token has no '%s' prefix", APIKeyTokenPrefix)
+	}
+	id, secret, ok = strings.Cut(rest, "_")
+	if !ok || id == "" || secret == "" {
+		return "", "", fmt.Errorf("API key token has not the format '%s<id>_<secret>'", APIKeyTokenPrefix)
+	}
+	return id, secret, nil
+}
+
+// HashAPIKeySecret hashes a secret with a random salt.
+//
+// Parameters:
+//   - secret: the secret
+//
+// Returns:
+//   - string: the hash in the format "sha256:<salt>:<hash>"
+//   - error: an error if no random salt can be generated
+func HashAPIKeySecret(secret string) (string, error) {
+	salt := make([]byte, apiKeySaltBytes)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("error generating API key salt: %w", err)
+	}
+	return apiKeyHashScheme + ":" + hex.EncodeToString(salt) + ":" + hex.EncodeToString(hashAPIKeySecret(salt, secret)), nil
+}
+
+// Verify checks the secret against the hashed secret in constant time.
+//
+// Parameters:
+//   - secret: the secret
+//
+// Returns:
+//   - bool: true if the secret matches
+func (k *APIKey) Verify(secret string) bool {
+	parts := strings.Split(k.HashedSecret, ":")
+	if len(parts) != 3 || parts[0] != apiKeyHashScheme {
+		return false
+	}
+	salt, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	expected, err := hex.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(hashAPIKeySecret(salt, secret), expected) == 1
+}
+
+// IsActive checks that the key is neither revoked nor expired.
+//
+// Parameters:
+//   - now: the current time
+//
+// Returns:
+//   - bool: true if the key may be used
+func (k *APIKey) IsActive(now time.Time) bool {
+	return !k.Revoked && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// HasScope checks if the key grants a scope. The scope "*" grants every scope and
+// a scope ending in ":*" grants every scope with the same prefix, e.g. "workflows:*" grants "workflows:run".
+//
+// Parameters:
+//   - scope: the required scope; an empty scope is always granted
+//
+// Returns:
+//   - bool: true if the scope is granted
+func (k *APIKey) HasScope(scope string) bool {
+	if scope == "" {
+		return true
+	}
+	for _, granted := range k.Scopes {
+		if granted == APIKeyScopeAll || granted == scope {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(granted, "*"); ok && strings.HasSuffix(prefix, ":") && strings.HasPrefix(scope, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hashAPIKeySecret hashes a secret with a salt.
+func hashAPIKeySecret(salt []byte, secret string) []byte {
+	hash := sha256.New()
+	hash.Write(salt)
+	hash.Write([]byte(secret))
+	return hash.Sum(nil)
+}
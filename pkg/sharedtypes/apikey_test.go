@@ -0,0 +1,118 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, token, err := GenerateAPIKey("alice", []string{"workflows:run"}, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateAPIKey() error = %v", err)
+	}
+	if strings.Contains(key.HashedSecret, token) {
+		t.Error("hashed secret contains the token")
+	}
+
+	id, secret, err := ParseAPIKeyToken(token)
+	if err != nil {
+		t.Fatalf("ParseAPIKeyToken() error = %v", err)
+	}
+	if id != key.Id {
+		t.Errorf("ParseAPIKeyToken() id = %q, want %q", id, key.Id)
+	}
+	if !key.Verify(secret) {
+		t.Error("Verify() of the generated secret failed")
+	}
+	if key.Verify(secret + "x") {
+		t.Error("Verify() of a wrong secret succeeded")
+	}
+	if !key.IsActive(time.Now()) || key.IsActive(time.Now().Add(2*time.Hour)) {
+		t.Error("IsActive() does not respect the expiry")
+	}
+}
+
+func TestParseAPIKeyToken(t *testing.T) {
+	tests := []struct {
+		token   string
+		wantErr bool
+	}{
+		{"aali_abc_def", false},
+		{"aali_abc_de_f", false},
+		{"abc_def", true},
+		{"aali_abc", true},
+		{"aali__def", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			if _, _, err := ParseAPIKeyToken(tt.token); (err != nil) != tt.wantErr {
+				t.Errorf("ParseAPIKeyToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAPIKeyVerifyLegacyAndMalformed(t *testing.T) {
+	key, err := NewLegacyAPIKey("plain-secret", nil)
+	if err != nil {
+		t.Fatalf("NewLegacyAPIKey() error = %v", err)
+	}
+	if key.Id != LegacyAPIKeyId || !key.Verify("plain-secret") {
+		t.Errorf("legacy key %+v does not verify its secret", key)
+	}
+
+	for _, hashed := range []string{"", "plain-secret", "md5:00:00", "sha256:zz:00"} {
+		malformed := APIKey{HashedSecret: hashed}
+		if malformed.Verify("plain-secret") {
+			t.Errorf("Verify() with hashed secret %q succeeded", hashed)
+		}
+	}
+}
+
+func TestAPIKeyHasScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+		scope  string
+		want   bool
+	}{
+		{"exact", []string{"workflows:run"}, "workflows:run", true},
+		{"other scope", []string{"workflows:run"}, "workflows:edit", false},
+		{"all", []string{APIKeyScopeAll}, "kvdb:write", true},
+		{"prefix wildcard", []string{"workflows:*"}, "workflows:publish", true},
+		{"prefix wildcard other resource", []string{"workflows:*"}, "kvdb:write", false},
+		{"no scopes", nil, "workflows:run", false},
+		{"empty scope", nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := APIKey{Scopes: tt.scopes}
+			if got := key.HasScope(tt.scope); got != tt.want {
+				t.Errorf("HasScope(%q) = %v, want %v", tt.scope, got, tt.want)
+			}
+		})
+	}
+}
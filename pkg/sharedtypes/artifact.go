@@ -0,0 +1,93 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Retention classes of artifacts, telling cleanup jobs how long to keep them
+const (
+	ArtifactRetentionTemporary = "temporary" // removed after the workflow run
+	ArtifactRetentionStandard  = "standard"  // kept for the retention period of the deployment
+	ArtifactRetentionPermanent = "permanent" // never removed automatically
+)
+
+// artifactKeyPrefix is the prefix of the content-addressed storage keys of artifacts.
+const artifactKeyPrefix = "artifacts/sha256/"
+
+// Artifact references binary content in the object store by its SHA-256 hash, so large workflow
+// outputs are passed between nodes as a small reference instead of inline in the function output.
+type Artifact struct {
+	Id             string    `json:"id"`
+	Sha256         string    `json:"sha256"` // hex encoded hash of the content
+	Size           int64     `json:"size"`
+	MimeType       string    `json:"mimeType,omitempty"`
+	ProducerNodeId string    `json:"producerNodeId,omitempty"` // ID of the workflow node that produced the content
+	RetentionClass string    `json:"retentionClass,omitempty"` // ArtifactRetention* constant; standard if empty
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// Validate checks the hash, size and retention class of the artifact.
+//
+// Returns:
+//   - error: an error describing the first invalid field
+func (a *Artifact) Validate() error {
+	if hash, err := hex.DecodeString(a.Sha256); err != nil || len(hash) != 32 {
+		return fmt.Errorf("artifact %q has invalid sha256 %q", a.Id, a.Sha256)
+	}
+	if a.Size < 0 {
+		return fmt.Errorf("artifact %q has negative size %d", a.Id, a.Size)
+	}
+	switch a.RetentionClass {
+	case "", ArtifactRetentionTemporary, ArtifactRetentionStandard, ArtifactRetentionPermanent:
+	default:
+		return fmt.Errorf("artifact %q has unknown retention class %q", a.Id, a.RetentionClass)
+	}
+	return nil
+}
+
+// StorageKey returns the content-addressed key of the artifact content in the object store,
+// "artifacts/sha256/<first two hex digits>/<hash>". Artifacts with the same content share the key.
+//
+// Returns:
+//   - string: the storage key
+func (a *Artifact) StorageKey() string {
+	return ArtifactStorageKey(a.Sha256)
+}
+
+// ArtifactStorageKey returns the content-addressed storage key of content with the given hash.
+//
+// Parameters:
+//   - sha256: the hex encoded SHA-256 hash of the content
+//
+// Returns:
+//   - string: the storage key
+func ArtifactStorageKey(sha256 string) string {
+	if len(sha256) < 2 {
+		return artifactKeyPrefix + sha256
+	}
+	return artifactKeyPrefix + sha256[:2] + "/" + sha256
+}
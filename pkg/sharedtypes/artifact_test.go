@@ -0,0 +1,58 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArtifactValidate(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	tests := []struct {
+		name     string
+		artifact Artifact
+		wantErr  bool
+	}{
+		{"valid", Artifact{Id: "a", Sha256: hash, Size: 10, RetentionClass: ArtifactRetentionPermanent}, false},
+		{"default retention", Artifact{Id: "a", Sha256: hash}, false},
+		{"short hash", Artifact{Id: "a", Sha256: "abcd"}, true},
+		{"not hex", Artifact{Id: "a", Sha256: strings.Repeat("zz", 32)}, true},
+		{"negative size", Artifact{Id: "a", Sha256: hash, Size: -1}, true},
+		{"unknown retention", Artifact{Id: "a", Sha256: hash, RetentionClass: "forever"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.artifact.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArtifactStorageKey(t *testing.T) {
+	artifact := Artifact{Sha256: "492d5ea496056f1a6a6592241032fab764c321596317930b4fa0e1e8bc3b7470"}
+	if got, want := artifact.StorageKey(), "artifacts/sha256/49/"+artifact.Sha256; got != want {
+		t.Errorf("StorageKey() = %s, want %s", got, want)
+	}
+}
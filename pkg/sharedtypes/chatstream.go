@@ -0,0 +1,219 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxChatChunks is the maximum number of chunks of a chat stream; chunks at higher positions are rejected
+var MaxChatChunks uint32 = 1 << 20
+
+// MaxChatPositionGap is the maximum distance of a chunk position beyond the highest position received so far;
+// together with MaxChatChunks it bounds the memory used to track the missing chunks
+var MaxChatPositionGap uint32 = 1024
+
+// ChatAssembler assembles the chat responses streamed by the LLM handler for one request into the final response.
+// Chunks are ordered by their Position and can arrive out of order; chunks without Position are appended.
+// Duplicate chunks, chunks after the last chunk and gaps in the positions are detected.
+type ChatAssembler struct {
+	instructionGuid string
+	chunks          map[uint32]HandlerResponse
+	nextPosition    uint32  // position assigned to chunks without Position
+	lastPosition    *uint32 // position of the chunk marked with IsLast
+	err             *ErrorResponse
+}
+
+// NewChatAssembler creates an assembler for the responses to a request.
+//
+// Parameters:
+//   - instructionGuid: the instruction GUID of the request; responses to other requests are rejected. If empty, the GUID of the first response is used
+//
+// Returns:
+//   - *ChatAssembler: the assembler
+func NewChatAssembler(instructionGuid string) *ChatAssembler {
+	return &ChatAssembler{instructionGuid: instructionGuid, chunks: map[uint32]HandlerResponse{}}
+}
+
+// Add adds a response of the stream. Info responses are ignored, error responses end the stream.
+//
+// Parameters:
+//   - response: the response
+//
+// Returns:
+//   - error: an error if the response is an error response, belongs to another request, is a duplicate, follows the last chunk
+//     or its position exceeds MaxChatChunks or MaxChatPositionGap
+func (ca *ChatAssembler) Add(response HandlerResponse) error {
+	if ca.instructionGuid != "" && response.InstructionGuid != "" && response.InstructionGuid != ca.instructionGuid {
+		return fmt.Errorf("response for instruction %q does not belong to instruction %q", response.InstructionGuid, ca.instructionGuid)
+	}
+	if ca.instructionGuid == "" {
+		ca.instructionGuid = response.InstructionGuid
+	}
+
+	switch response.Type {
+	case "info":
+		return nil
+	case "error":
+		ca.err = response.Error
+		if ca.err == nil {
+			ca.err = &ErrorResponse{Message: "unknown error"}
+		}
+		return ca.Err()
+	case "chat":
+	default:
+		return fmt.Errorf("unexpected response of type %q in chat stream", response.Type)
+	}
+	if ca.err != nil {
+		return fmt.Errorf("chat response after error: %w", ca.Err())
+	}
+
+	position := ca.nextPosition
+	if response.Position != nil {
+		position = *response.Position
+	}
+	if position >= MaxChatChunks {
+		return fmt.Errorf("chat response at position %d exceeds the maximum of %d responses", position, MaxChatChunks)
+	}
+	if position >= ca.nextPosition && position-ca.nextPosition >= MaxChatPositionGap {
+		return fmt.Errorf("chat response at position %d is more than %d positions ahead of position %d", position, MaxChatPositionGap, ca.nextPosition)
+	}
+	if _, ok := ca.chunks[position]; ok {
+		return fmt.Errorf("duplicate chat response at position %d", position)
+	}
+	if ca.lastPosition != nil && position > *ca.lastPosition {
+		return fmt.Errorf("chat response at position %d after the last response at position %d", position, *ca.lastPosition)
+	}
+	if response.IsLast != nil && *response.IsLast {
+		if ca.lastPosition != nil {
+			return fmt.Errorf("chat response at position %d marked as last after the last response at position %d", position, *ca.lastPosition)
+		}
+		if position+1 < ca.nextPosition {
+			return fmt.Errorf("chat response at position %d marked as last after a response at position %d", position, ca.nextPosition-1)
+		}
+		ca.lastPosition = &position
+	}
+
+	ca.chunks[position] = response
+	ca.nextPosition = max(ca.nextPosition, position+1)
+	return nil
+}
+
+// Err returns the error sent by the LLM handler, nil if no error response was received.
+func (ca *ChatAssembler) Err() error {
+	if ca.err == nil {
+		return nil
+	}
+	return fmt.Errorf("LLM handler error %d: %s", ca.err.Code, ca.err.Message)
+}
+
+// IsComplete returns true if the last chunk and all chunks before it have been received.
+func (ca *ChatAssembler) IsComplete() bool {
+	return ca.err == nil && ca.lastPosition != nil && len(ca.chunks) == int(*ca.lastPosition)+1
+}
+
+// MissingPositions returns the positions of the chunks not received yet, up to the last chunk or the highest position received.
+//
+// Returns:
+//   - []uint32: the missing positions in ascending order
+func (ca *ChatAssembler) MissingPositions() []uint32 {
+	missing := []uint32{}
+	for position := uint32(0); position < ca.nextPosition; position++ {
+		if _, ok := ca.chunks[position]; !ok {
+			missing = append(missing, position)
+		}
+	}
+	return missing
+}
+
+// Response returns the assembled response: the concatenated chat data, the tool calls of all chunks and the
+// last token counts sent. Tool calls sent again with the same ID replace the earlier ones.
+//
+// Returns:
+//   - HandlerResponse: a single chat response marked as last
+//   - error: the error of the LLM handler, or an error if chunks are missing
+func (ca *ChatAssembler) Response() (HandlerResponse, error) {
+	if ca.err != nil {
+		return HandlerResponse{}, ca.Err()
+	}
+	if missing := ca.MissingPositions(); len(missing) > 0 {
+		return HandlerResponse{}, fmt.Errorf("chat stream is missing the responses at positions %v", missing)
+	}
+	if ca.lastPosition == nil {
+		return HandlerResponse{}, fmt.Errorf("chat stream has not received its last response after %d responses", len(ca.chunks))
+	}
+
+	var content strings.Builder
+	isLast := true
+	position := uint32(0)
+	assembled := HandlerResponse{InstructionGuid: ca.instructionGuid, Type: "chat", IsLast: &isLast, Position: &position}
+	toolCallIndex := map[string]int{}
+	for position := uint32(0); position <= *ca.lastPosition; position++ {
+		chunk := ca.chunks[position]
+		if chunk.ChatData != nil {
+			content.WriteString(*chunk.ChatData)
+		}
+		for _, toolCall := range chunk.ToolCalls {
+			if index, ok := toolCallIndex[toolCall.ID]; ok && toolCall.ID != "" {
+				assembled.ToolCalls[index] = toolCall
+				continue
+			}
+			toolCallIndex[toolCall.ID] = len(assembled.ToolCalls)
+			assembled.ToolCalls = append(assembled.ToolCalls, toolCall)
+		}
+		assembled.InputTokenCount = lastSet(assembled.InputTokenCount, chunk.InputTokenCount)
+		assembled.OutputTokenCount = lastSet(assembled.OutputTokenCount, chunk.OutputTokenCount)
+		assembled.CachedTokenCount = lastSet(assembled.CachedTokenCount, chunk.CachedTokenCount)
+		assembled.ReasoningTokenCount = lastSet(assembled.ReasoningTokenCount, chunk.ReasoningTokenCount)
+	}
+	chatData := content.String()
+	assembled.ChatData = &chatData
+	return assembled, nil
+}
+
+// lastSet returns the new value if it is set, the current value otherwise.
+func lastSet(current *int, value *int) *int {
+	if value != nil {
+		return value
+	}
+	return current
+}
+
+// AssembleChatResponses assembles the complete list of streamed chat responses to a request.
+//
+// Parameters:
+//   - responses: the responses in the order they were received
+//
+// Returns:
+//   - HandlerResponse: the assembled response, see ChatAssembler.Response
+//   - error: an error if a response is invalid or the stream is incomplete
+func AssembleChatResponses(responses []HandlerResponse) (HandlerResponse, error) {
+	assembler := NewChatAssembler("")
+	for _, response := range responses {
+		if err := assembler.Add(response); err != nil {
+			return HandlerResponse{}, err
+		}
+	}
+	return assembler.Response()
+}
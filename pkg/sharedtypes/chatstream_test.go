@@ -0,0 +1,152 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// chatChunk builds a streamed chat response
+func chatChunk(position uint32, data string, isLast bool) HandlerResponse {
+	return HandlerResponse{InstructionGuid: "guid-1", Type: "chat", Position: &position, ChatData: &data, IsLast: &isLast}
+}
+
+func TestChatAssemblerInOrder(t *testing.T) {
+	last := chatChunk(2, "!", true)
+	last.InputTokenCount, last.OutputTokenCount = ptr(10), ptr(3)
+	last.ToolCalls = []ToolCall{{ID: "call-2", Name: "search"}}
+	middle := chatChunk(1, " world", false)
+	middle.OutputTokenCount = ptr(2)
+	middle.ToolCalls = []ToolCall{{ID: "call-1", Name: "lookup", Input: map[string]interface{}{"q": "partial"}}}
+	updated := []ToolCall{{ID: "call-1", Name: "lookup", Input: map[string]interface{}{"q": "complete"}}}
+	info := HandlerResponse{InstructionGuid: "guid-1", Type: "info", InfoMessage: ptr("model selected")}
+
+	assembler := NewChatAssembler("guid-1")
+	first := chatChunk(0, "hello", false)
+	for _, response := range []HandlerResponse{first, info, middle} {
+		if err := assembler.Add(response); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if assembler.IsComplete() {
+		t.Error("IsComplete() before last chunk = true")
+	}
+	if _, err := assembler.Response(); err == nil {
+		t.Error("Response() before last chunk returned no error")
+	}
+	last.ToolCalls = append(last.ToolCalls, updated...)
+	if err := assembler.Add(last); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if !assembler.IsComplete() {
+		t.Error("IsComplete() after last chunk = false")
+	}
+
+	response, err := assembler.Response()
+	if err != nil {
+		t.Fatalf("Response() error = %v", err)
+	}
+	if *response.ChatData != "hello world!" || response.InstructionGuid != "guid-1" || !*response.IsLast {
+		t.Errorf("Response() = %+v, chat data %q", response, *response.ChatData)
+	}
+	if *response.InputTokenCount != 10 || *response.OutputTokenCount != 3 || response.CachedTokenCount != nil {
+		t.Errorf("token counts = %v/%v/%v", response.InputTokenCount, response.OutputTokenCount, response.CachedTokenCount)
+	}
+	wantToolCalls := []ToolCall{updated[0], {ID: "call-2", Name: "search"}}
+	if !reflect.DeepEqual(response.ToolCalls, wantToolCalls) {
+		t.Errorf("ToolCalls = %+v, want %+v", response.ToolCalls, wantToolCalls)
+	}
+}
+
+func TestChatAssemblerOutOfOrder(t *testing.T) {
+	assembler := NewChatAssembler("")
+	for _, response := range []HandlerResponse{chatChunk(2, "c", true), chatChunk(0, "a", false)} {
+		if err := assembler.Add(response); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if missing := assembler.MissingPositions(); !reflect.DeepEqual(missing, []uint32{1}) {
+		t.Errorf("MissingPositions() = %v, want [1]", missing)
+	}
+	if _, err := assembler.Response(); err == nil || !strings.Contains(err.Error(), "[1]") {
+		t.Errorf("Response() with gap error = %v", err)
+	}
+
+	if err := assembler.Add(chatChunk(1, "b", false)); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	response, err := assembler.Response()
+	if err != nil || *response.ChatData != "abc" {
+		t.Errorf("Response() = %v, %v, want abc", response.ChatData, err)
+	}
+}
+
+func TestChatAssemblerWithoutPositions(t *testing.T) {
+	chunks := []HandlerResponse{
+		{Type: "chat", ChatData: ptr("a")},
+		{Type: "chat", ChatData: ptr("b")},
+		{Type: "chat", ChatData: ptr("c"), IsLast: ptr(true)},
+	}
+	response, err := AssembleChatResponses(chunks)
+	if err != nil || *response.ChatData != "abc" {
+		t.Errorf("AssembleChatResponses() = %v, %v, want abc", response.ChatData, err)
+	}
+}
+
+func TestChatAssemblerErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []HandlerResponse
+		errText   string
+	}{
+		{"duplicate", []HandlerResponse{chatChunk(0, "a", false), chatChunk(0, "a", false)}, "duplicate"},
+		{"after last", []HandlerResponse{chatChunk(0, "a", true), chatChunk(1, "b", false)}, "after the last"},
+		{"last before received", []HandlerResponse{chatChunk(0, "a", false), chatChunk(2, "c", false), chatChunk(1, "b", true)}, "marked as last"},
+		{"other instruction", []HandlerResponse{chatChunk(0, "a", false), {InstructionGuid: "guid-2", Type: "chat"}}, "does not belong"},
+		{"unexpected type", []HandlerResponse{{Type: "embeddings"}}, "unexpected response"},
+		{"handler error", []HandlerResponse{chatChunk(0, "a", false), {Type: "error", Error: &ErrorResponse{Code: 500, Message: "model unavailable"}}}, "model unavailable"},
+		{"incomplete", []HandlerResponse{chatChunk(0, "a", false)}, "last response"},
+		{"position too far ahead", []HandlerResponse{chatChunk(0, "a", false), chatChunk(1025, "b", true)}, "positions ahead"},
+		{"maximum position", []HandlerResponse{chatChunk(^uint32(0), "a", true)}, "exceeds the maximum"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := AssembleChatResponses(tt.responses)
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("AssembleChatResponses() error = %v, want error containing %q", err, tt.errText)
+			}
+		})
+	}
+
+	assembler := NewChatAssembler("guid-1")
+	_ = assembler.Add(HandlerResponse{Type: "error"})
+	if assembler.Err() == nil || assembler.IsComplete() {
+		t.Errorf("Err() = %v, IsComplete() = %v after error response", assembler.Err(), assembler.IsComplete())
+	}
+	if err := assembler.Add(chatChunk(0, "a", true)); err == nil {
+		t.Error("Add() after error returned no error")
+	}
+}
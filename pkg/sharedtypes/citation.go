@@ -0,0 +1,142 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// Citation source types, identifying the retriever that produced a citation.
+const (
+	CitationSourceAnsysGPT    = "ansys_gpt"
+	CitationSourceKnowledgeDb = "knowledge_db"
+)
+
+// Citation represents a retriever-independent reference to the source of a piece of generated content.
+type Citation struct {
+	SourceType string     `json:"source_type"`           // "ansys_gpt", "knowledge_db"
+	DocumentId string     `json:"document_id,omitempty"` // ID of the cited document
+	Title      string     `json:"title,omitempty"`       // Human-readable title of the cited document
+	ChunkGuid  *uuid.UUID `json:"chunk_guid,omitempty"`  // GUID of the cited chunk, if the retriever works on chunks
+	SpanStart  *int       `json:"span_start,omitempty"`  // Start offset of the cited text within the chunk
+	SpanEnd    *int       `json:"span_end,omitempty"`    // End offset (exclusive) of the cited text within the chunk
+	Score      float64    `json:"score"`                 // Relevance score; higher is more relevant for every source type
+	Distance   *float64   `json:"distance,omitempty"`    // Raw distance of a knowledge DB result; lower is more relevant
+	URL        string     `json:"url,omitempty"`         // Link to the cited source
+}
+
+// citationUrlMetadataKeys are the DbResponse metadata keys checked, in order, for the URL of a source.
+var citationUrlMetadataKeys = []string{"url", "source_url", "sourceURL", "link"}
+
+// NewCitationFromAnsysGPTCitation converts an AnsysGPTCitation to a Citation.
+//
+// Parameters:
+//   - citation: the AnsysGPT citation
+//
+// Returns:
+//   - Citation: the generic citation
+func NewCitationFromAnsysGPTCitation(citation AnsysGPTCitation) Citation {
+	return Citation{
+		SourceType: CitationSourceAnsysGPT,
+		Title:      citation.Title,
+		Score:      citation.Relevance,
+		URL:        citation.URL,
+	}
+}
+
+// NewCitationFromDbResponse converts a knowledge DB search result to a Citation.
+// The distance of the result is kept in Distance and converted to the relevance score 1 / (1 + distance),
+// so citations of all source types are ranked the same way. The URL from the "url", "source_url", "sourceURL" or "link" metadata field.
+//
+// Parameters:
+//   - response: the knowledge DB search result
+//
+// Returns:
+//   - Citation: the generic citation
+func NewCitationFromDbResponse(response DbResponse) Citation {
+	citation := Citation{
+		SourceType: CitationSourceKnowledgeDb,
+		DocumentId: response.DocumentId,
+		Title:      response.DocumentName,
+		Score:      RelevanceFromDistance(response.Distance),
+	}
+	distance := response.Distance
+	citation.Distance = &distance
+	if response.Guid != uuid.Nil {
+		guid := response.Guid
+		citation.ChunkGuid = &guid
+	}
+	if citation.Title == "" {
+		citation.Title = response.Title
+	}
+	for _, key := range citationUrlMetadataKeys {
+		if url, ok := response.Metadata[key].(string); ok && url != "" {
+			citation.URL = url
+			break
+		}
+	}
+	return citation
+}
+
+// RelevanceFromDistance converts a non-negative distance to a relevance score in (0, 1], where higher is more relevant.
+//
+// Parameters:
+//   - distance: the distance; negative distances are treated as 0
+//
+// Returns:
+//   - float64: the relevance score 1 / (1 + distance)
+func RelevanceFromDistance(distance float64) float64 {
+	return 1 / (1 + math.Max(0, distance))
+}
+
+// NewCitationsFromAnsysGPTCitations converts a list of AnsysGPTCitation to a list of Citation.
+//
+// Parameters:
+//   - citations: the AnsysGPT citations
+//
+// Returns:
+//   - []Citation: the generic citations
+func NewCitationsFromAnsysGPTCitations(citations []AnsysGPTCitation) []Citation {
+	result := make([]Citation, 0, len(citations))
+	for _, citation := range citations {
+		result = append(result, NewCitationFromAnsysGPTCitation(citation))
+	}
+	return result
+}
+
+// NewCitationsFromDbResponses converts a list of knowledge DB search results to a list of Citation.
+//
+// Parameters:
+//   - responses: the knowledge DB search results
+//
+// Returns:
+//   - []Citation: the generic citations
+func NewCitationsFromDbResponses(responses []DbResponse) []Citation {
+	result := make([]Citation, 0, len(responses))
+	for _, response := range responses {
+		result = append(result, NewCitationFromDbResponse(response))
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewCitationFromAnsysGPTCitation(t *testing.T) {
+	citation := NewCitationFromAnsysGPTCitation(AnsysGPTCitation{Title: "Meshing", URL: "https://ansys.help/meshing", Relevance: 0.82})
+
+	if citation.SourceType != CitationSourceAnsysGPT || citation.Title != "Meshing" || citation.URL != "https://ansys.help/meshing" || citation.Score != 0.82 {
+		t.Errorf("unexpected citation: %+v", citation)
+	}
+	if citation.ChunkGuid != nil {
+		t.Error("ChunkGuid should not be set for AnsysGPT citations")
+	}
+}
+
+func TestNewCitationFromDbResponse(t *testing.T) {
+	guid := uuid.New()
+	response := DbResponse{
+		Guid:         guid,
+		DocumentId:   "doc-1",
+		DocumentName: "user_guide.pdf",
+		Distance:     0.91,
+		Metadata:     map[string]interface{}{"source_url": "https://example.com/user_guide.pdf"},
+	}
+
+	citation := NewCitationFromDbResponse(response)
+	if citation.SourceType != CitationSourceKnowledgeDb || citation.DocumentId != "doc-1" || citation.Title != "user_guide.pdf" {
+		t.Errorf("unexpected citation: %+v", citation)
+	}
+	if citation.Distance == nil || *citation.Distance != 0.91 || citation.Score != 1/1.91 {
+		t.Errorf("Distance = %v, Score = %v, want distance 0.91 converted to relevance", citation.Distance, citation.Score)
+	}
+	if citation.ChunkGuid == nil || *citation.ChunkGuid != guid {
+		t.Errorf("ChunkGuid = %v, want %v", citation.ChunkGuid, guid)
+	}
+	if citation.URL != "https://example.com/user_guide.pdf" {
+		t.Errorf("URL = %q", citation.URL)
+	}
+
+	citations := NewCitationsFromDbResponses([]DbResponse{{Title: "Section 1"}})
+	if len(citations) != 1 || citations[0].ChunkGuid != nil || citations[0].Title != "Section 1" {
+		t.Errorf("unexpected citations: %+v", citations)
+	}
+}
+
+func TestCitationScoreDirection(t *testing.T) {
+	near := NewCitationFromDbResponse(DbResponse{Distance: 0.1})
+	far := NewCitationFromDbResponse(DbResponse{Distance: 2})
+	if near.Score <= far.Score {
+		t.Errorf("Score of the nearer result = %v, want higher than %v", near.Score, far.Score)
+	}
+	if got := RelevanceFromDistance(0); got != 1 {
+		t.Errorf("RelevanceFromDistance(0) = %v, want 1", got)
+	}
+	if got := RelevanceFromDistance(-1); got != 1 {
+		t.Errorf("RelevanceFromDistance(-1) = %v, want 1", got)
+	}
+	if gpt := NewCitationFromAnsysGPTCitation(AnsysGPTCitation{Relevance: 0.8}); gpt.Distance != nil || gpt.Score != 0.8 {
+		t.Errorf("AnsysGPT citation = %+v, want relevance as score without distance", gpt)
+	}
+}
@@ -22,7 +22,14 @@
 
 package sharedtypes
 
-import "github.com/google/uuid"
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
 
 // DataExtractionDocumentData represents the data extracted from a document.
 type DataExtractionDocumentData struct {
@@ -42,6 +49,41 @@ type DataExtractionDocumentData struct {
 	Embedding         []float32 `json:"embedding"`
 }
 
+// Chunking strategies supported in ChunkingConfig.
+const (
+	ChunkingStrategyFixed     = "fixed"     // split after a fixed number of characters or tokens
+	ChunkingStrategySentence  = "sentence"  // split on sentence boundaries, merging sentences up to the chunk size
+	ChunkingStrategyParagraph = "paragraph" // split on paragraph boundaries, merging paragraphs up to the chunk size
+	ChunkingStrategyRecursive = "recursive" // split on the coarsest separator that keeps chunks below the chunk size
+)
+
+// ChunkingConfig describes how documents are split into chunks during knowledge ingestion.
+type ChunkingConfig struct {
+	Strategy     string `json:"strategy"`            // "fixed", "sentence", "paragraph", "recursive"
+	ChunkSize    int    `json:"chunk_size"`          // maximum size of a chunk
+	ChunkOverlap int    `json:"chunk_overlap"`       // size of the overlap between consecutive chunks
+	Tokenizer    string `json:"tokenizer,omitempty"` // tokenizer used to measure sizes, e.g. "cl100k_base"; if empty, sizes are in characters
+}
+
+// Validate checks that the chunking configuration has a supported strategy and consistent sizes.
+//
+// Returns:
+//   - error: an error if the configuration is invalid
+func (cc *ChunkingConfig) Validate() error {
+	switch cc.Strategy {
+	case ChunkingStrategyFixed, ChunkingStrategySentence, ChunkingStrategyParagraph, ChunkingStrategyRecursive:
+	default:
+		return fmt.Errorf("unsupported chunking strategy %q", cc.Strategy)
+	}
+	if cc.ChunkSize <= 0 {
+		return fmt.Errorf("chunk size must be positive, got %d", cc.ChunkSize)
+	}
+	if cc.ChunkOverlap < 0 || cc.ChunkOverlap >= cc.ChunkSize {
+		return fmt.Errorf("chunk overlap must be between 0 and the chunk size (%d), got %d", cc.ChunkSize, cc.ChunkOverlap)
+	}
+	return nil
+}
+
 type CodeGenerationElement struct {
 	Guid uuid.UUID          `json:"guid"`
 	Type CodeGenerationType `json:"type"`
@@ -65,11 +107,181 @@ type CodeGenerationElement struct {
 	// Only for type "enum"
 	EnumValues []string `json:"enum_values"`
 
+	// Compatibility metadata
+	Language           string   `json:"language,omitempty"`            // Target language, e.g. "python"
+	Imports            []string `json:"imports,omitempty"`             // Import statements required to use the element
+	RequiredPackages   []string `json:"required_packages,omitempty"`   // Packages required to use the element, e.g. "ansys-mapdl-core>=0.68"
+	MinVersion         string   `json:"min_version,omitempty"`         // First product version supporting the element, e.g. "2023 R2"
+	MaxVersion         string   `json:"max_version,omitempty"`         // Last product version supporting the element
+	Deprecated         bool     `json:"deprecated,omitempty"`          // Whether the element is deprecated
+	DeprecationMessage string   `json:"deprecation_message,omitempty"` // Reason for the deprecation and migration hints
+	ReplacedBy         string   `json:"replaced_by,omitempty"`         // Name of the element replacing the deprecated element
+
 	// Metadata for databases
 	VectorDBMetadata any `json:"vector_db_metadata,omitempty"` // Optional metadata for vector databases
 	GraphDBMetadata  any `json:"graph_db_metadata,omitempty"`  // Optional metadata for graph databases
 }
 
+// Validate checks the type and the compatibility metadata of the element.
+//
+// Returns:
+//   - error: an error if the element is invalid
+func (element *CodeGenerationElement) Validate() error {
+	if element.Name == "" {
+		return fmt.Errorf("code generation element has no name")
+	}
+	return validateCodeGenerationCompatibility(element.Type, element.MinVersion, element.MaxVersion, element.Deprecated, element.ReplacedBy)
+}
+
+// IsCompatibleWith reports whether the element is supported by the given product version.
+// Elements without version range are compatible with all versions.
+//
+// Parameters:
+//   - version: the product version, e.g. "2024 R2", "2025R1" or "0.9.3"
+//
+// Returns:
+//   - bool: true if the version is within the range of the element
+//   - error: an error if a version cannot be parsed
+func (element *CodeGenerationElement) IsCompatibleWith(version string) (bool, error) {
+	return isVersionInRange(version, element.MinVersion, element.MaxVersion)
+}
+
+// FilterCodeGenerationElementsByVersion returns the elements that are supported by the given product version.
+//
+// Parameters:
+//   - elements: the elements to filter
+//   - version: the product version, e.g. "2024 R2"
+//   - includeDeprecated: whether deprecated elements are kept
+//
+// Returns:
+//   - []CodeGenerationElement: the compatible elements
+//   - error: an error if a version cannot be parsed
+func FilterCodeGenerationElementsByVersion(elements []CodeGenerationElement, version string, includeDeprecated bool) ([]CodeGenerationElement, error) {
+	filtered := []CodeGenerationElement{}
+	for i := range elements {
+		if elements[i].Deprecated && !includeDeprecated {
+			continue
+		}
+		compatible, err := elements[i].IsCompatibleWith(version)
+		if err != nil {
+			return nil, fmt.Errorf("element %q: %w", elements[i].Name, err)
+		}
+		if compatible {
+			filtered = append(filtered, elements[i])
+		}
+	}
+	return filtered, nil
+}
+
+// validateCodeGenerationCompatibility checks the type, the version range and the deprecation status of a code generation element.
+func validateCodeGenerationCompatibility(elementType CodeGenerationType, minVersion string, maxVersion string, deprecated bool, replacedBy string) error {
+	switch elementType {
+	case Function, Method, Class, Parameter, Enum, Module:
+	default:
+		return fmt.Errorf("unsupported code generation type %q", elementType)
+	}
+	if minVersion != "" && maxVersion != "" {
+		cmp, err := CompareProductVersions(minVersion, maxVersion)
+		if err != nil {
+			return err
+		}
+		if cmp > 0 {
+			return fmt.Errorf("min version %q is greater than max version %q", minVersion, maxVersion)
+		}
+	} else {
+		for _, version := range []string{minVersion, maxVersion} {
+			if version == "" {
+				continue
+			}
+			if _, err := parseProductVersion(version); err != nil {
+				return err
+			}
+		}
+	}
+	if replacedBy != "" && !deprecated {
+		return fmt.Errorf("replaced_by is set but the element is not deprecated")
+	}
+	return nil
+}
+
+// isVersionInRange reports whether the version is within the inclusive range; empty bounds are open.
+func isVersionInRange(version string, minVersion string, maxVersion string) (bool, error) {
+	if minVersion != "" {
+		cmp, err := CompareProductVersions(version, minVersion)
+		if err != nil {
+			return false, err
+		}
+		if cmp < 0 {
+			return false, nil
+		}
+	}
+	if maxVersion != "" {
+		cmp, err := CompareProductVersions(version, maxVersion)
+		if err != nil {
+			return false, err
+		}
+		if cmp > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// CompareProductVersions compares two product versions component by component.
+// Release versions like "2024 R2" or "2024R2" and package versions like "0.9.3" are supported;
+// missing components are treated as 0.
+//
+// Parameters:
+//   - a: the first version
+//   - b: the second version
+//
+// Returns:
+//   - int: -1 if a < b, 0 if a == b, 1 if a > b
+//   - error: an error if a version cannot be parsed
+func CompareProductVersions(a string, b string) (int, error) {
+	componentsA, err := parseProductVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	componentsB, err := parseProductVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < max(len(componentsA), len(componentsB)); i++ {
+		var x, y int
+		if i < len(componentsA) {
+			x = componentsA[i]
+		}
+		if i < len(componentsB) {
+			y = componentsB[i]
+		}
+		if x != y {
+			return cmp.Compare(x, y), nil
+		}
+	}
+	return 0, nil
+}
+
+// parseProductVersion splits a version like "2024 R2", "v2024R2" or "0.9.3" into its numeric components.
+func parseProductVersion(version string) ([]int, error) {
+	trimmed := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(version)), "v")
+	fields := strings.FieldsFunc(trimmed, func(r rune) bool {
+		return r == '.' || r == ' ' || r == 'r' || r == '-' || r == '_'
+	})
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid version %q", version)
+	}
+	components := make([]int, 0, len(fields))
+	for _, field := range fields {
+		component, err := strconv.Atoi(field)
+		if err != nil || component < 0 {
+			return nil, fmt.Errorf("invalid version %q", version)
+		}
+		components = append(components, component)
+	}
+	return components, nil
+}
+
 // Enum values for CodeGenerationType
 type CodeGenerationType string
 
@@ -0,0 +1,136 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import "testing"
+
+func TestChunkingConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    ChunkingConfig
+		expectErr bool
+	}{
+		{"valid", ChunkingConfig{Strategy: ChunkingStrategyRecursive, ChunkSize: 512, ChunkOverlap: 64, Tokenizer: "cl100k_base"}, false},
+		{"no overlap", ChunkingConfig{Strategy: ChunkingStrategyFixed, ChunkSize: 1000}, false},
+		{"unknown strategy", ChunkingConfig{Strategy: "magic", ChunkSize: 512}, true},
+		{"zero size", ChunkingConfig{Strategy: ChunkingStrategySentence}, true},
+		{"overlap equals size", ChunkingConfig{Strategy: ChunkingStrategyParagraph, ChunkSize: 100, ChunkOverlap: 100}, true},
+		{"negative overlap", ChunkingConfig{Strategy: ChunkingStrategyFixed, ChunkSize: 100, ChunkOverlap: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestDbDataIsUnchanged(t *testing.T) {
+	hash := ComputeContentHash([]byte("content"))
+	if hash != "sha256:ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73" {
+		t.Errorf("ComputeContentHash() = %q", hash)
+	}
+
+	data := DbData{ContentHash: hash}
+	if !data.IsUnchanged(ComputeContentHash([]byte("content"))) {
+		t.Error("IsUnchanged() should be true for the same content")
+	}
+	if data.IsUnchanged(ComputeContentHash([]byte("changed"))) {
+		t.Error("IsUnchanged() should be false for changed content")
+	}
+	if (&DbData{}).IsUnchanged("") {
+		t.Error("IsUnchanged() should be false if no hash is stored")
+	}
+}
+
+func TestCompareProductVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"2024 R2", "2024R2", 0},
+		{"2024 R1", "2024 R2", -1},
+		{"v2025R1", "2024 R2", 1},
+		{"0.9.3", "0.10", -1},
+		{"1.0", "1", 0},
+	}
+
+	for _, tt := range tests {
+		result, err := CompareProductVersions(tt.a, tt.b)
+		if err != nil {
+			t.Errorf("CompareProductVersions(%q, %q) error = %v", tt.a, tt.b, err)
+		} else if result != tt.expected {
+			t.Errorf("CompareProductVersions(%q, %q) = %d, expected %d", tt.a, tt.b, result, tt.expected)
+		}
+	}
+
+	if _, err := CompareProductVersions("latest", "2024 R2"); err == nil {
+		t.Error("CompareProductVersions() expected an error for an invalid version")
+	}
+}
+
+func TestCodeGenerationElementCompatibility(t *testing.T) {
+	tests := []struct {
+		name      string
+		element   CodeGenerationElement
+		expectErr bool
+	}{
+		{"no metadata", CodeGenerationElement{Name: "mapdl.prep7", Type: Method}, false},
+		{"valid range", CodeGenerationElement{Name: "mapdl.prep7", Type: Method, MinVersion: "2023 R1", MaxVersion: "2025 R1"}, false},
+		{"inverted range", CodeGenerationElement{Name: "mapdl.prep7", Type: Method, MinVersion: "2025 R1", MaxVersion: "2023 R1"}, true},
+		{"invalid version", CodeGenerationElement{Name: "mapdl.prep7", Type: Method, MinVersion: "next"}, true},
+		{"unknown type", CodeGenerationElement{Name: "mapdl.prep7", Type: "Macro"}, true},
+		{"missing name", CodeGenerationElement{Type: Function}, true},
+		{"replaced but not deprecated", CodeGenerationElement{Name: "mapdl.prep7", Type: Method, ReplacedBy: "mapdl.preprocessor"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.element.Validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+
+	elements := []CodeGenerationElement{
+		{Name: "always", Type: Function},
+		{Name: "new", Type: Function, MinVersion: "2025 R1"},
+		{Name: "old", Type: Function, MaxVersion: "2023 R2"},
+		{Name: "deprecated", Type: Function, Deprecated: true},
+	}
+	filtered, err := FilterCodeGenerationElementsByVersion(elements, "2024 R2", false)
+	if err != nil {
+		t.Fatalf("FilterCodeGenerationElementsByVersion() error = %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].Name != "always" {
+		t.Errorf("FilterCodeGenerationElementsByVersion() = %v", filtered)
+	}
+	filtered, _ = FilterCodeGenerationElementsByVersion(elements, "2025R2", true)
+	if len(filtered) != 3 {
+		t.Errorf("FilterCodeGenerationElementsByVersion() returned %d elements, expected 3", len(filtered))
+	}
+}
@@ -0,0 +1,357 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Validate checks that the range filter has a field name and consistent bounds.
+//
+// Returns:
+//   - error: an error if the range filter is invalid
+func (rf *DbRangeFilter) Validate() error {
+	if rf.FieldName == "" {
+		return fmt.Errorf("range filter is missing the field name")
+	}
+	isNumeric := rf.Min != nil || rf.Max != nil
+	isDate := rf.MinDate != nil || rf.MaxDate != nil
+	switch {
+	case isNumeric && isDate:
+		return fmt.Errorf("range filter for field %q mixes numeric and date bounds", rf.FieldName)
+	case !isNumeric && !isDate:
+		return fmt.Errorf("range filter for field %q has no bounds", rf.FieldName)
+	case rf.Min != nil && rf.Max != nil && *rf.Min > *rf.Max:
+		return fmt.Errorf("range filter for field %q has min %v greater than max %v", rf.FieldName, *rf.Min, *rf.Max)
+	case rf.MinDate != nil && rf.MaxDate != nil && rf.MinDate.After(*rf.MaxDate):
+		return fmt.Errorf("range filter for field %q has minDate after maxDate", rf.FieldName)
+	}
+	return nil
+}
+
+// IsEmpty returns true if the filters do not contain any condition.
+//
+// Returns:
+//   - bool: true if no condition is defined
+func (filters *DbFilters) IsEmpty() bool {
+	return len(filters.GuidFilter) == 0 &&
+		len(filters.DocumentIdFilter) == 0 &&
+		len(filters.DocumentNameFilter) == 0 &&
+		len(filters.LevelFilter) == 0 &&
+		len(filters.TagsFilter.FilterData) == 0 &&
+		len(filters.KeywordsFilter.FilterData) == 0 &&
+		len(filters.MetadataFilter) == 0 &&
+		len(filters.RangeFilter) == 0 &&
+		len(filters.And) == 0 &&
+		len(filters.Or) == 0 &&
+		(filters.Not == nil || filters.Not.IsEmpty())
+}
+
+///////////////////////////////////
+// Qdrant
+///////////////////////////////////
+
+// qdrantMetadataPrefix is the payload path under which the metadata of DbData is stored in Qdrant.
+const qdrantMetadataPrefix = "metadata."
+
+// ToQdrantFilter translates the filters to a Qdrant filter object, ready to be serialized to JSON.
+// Guid filters are applied to the point IDs, all other fields to the payload.
+//
+// Returns:
+//   - map[string]interface{}: the Qdrant filter with "must", "should" and "must_not" conditions
+//   - error: an error if a range filter is invalid
+func (filters *DbFilters) ToQdrantFilter() (map[string]interface{}, error) {
+	must := []interface{}{}
+	should := []interface{}{}
+	mustNot := []interface{}{}
+
+	if len(filters.GuidFilter) > 0 {
+		must = append(must, map[string]interface{}{"has_id": filters.GuidFilter})
+	}
+	for _, field := range []struct {
+		key    string
+		values []string
+	}{
+		{"document_id", filters.DocumentIdFilter},
+		{"document_name", filters.DocumentNameFilter},
+		{"level", filters.LevelFilter},
+	} {
+		if len(field.values) > 0 {
+			must = append(must, qdrantMatchAny(field.key, field.values))
+		}
+	}
+	must = append(must, qdrantArrayConditions("tags", filters.TagsFilter.FilterData, filters.TagsFilter.NeedAll)...)
+	must = append(must, qdrantArrayConditions("keywords", filters.KeywordsFilter.FilterData, filters.KeywordsFilter.NeedAll)...)
+
+	for _, jsonFilter := range filters.MetadataFilter {
+		if len(jsonFilter.FilterData) == 0 {
+			continue
+		}
+		key := qdrantMetadataPrefix + jsonFilter.FieldName
+		if jsonFilter.FieldType == "array" {
+			must = append(must, qdrantArrayConditions(key, jsonFilter.FilterData, jsonFilter.NeedAll)...)
+		} else {
+			must = append(must, qdrantMatchAny(key, jsonFilter.FilterData))
+		}
+	}
+
+	for _, rangeFilter := range filters.RangeFilter {
+		if err := rangeFilter.Validate(); err != nil {
+			return nil, err
+		}
+		bounds := map[string]interface{}{}
+		if rangeFilter.Min != nil {
+			bounds["gte"] = *rangeFilter.Min
+		}
+		if rangeFilter.Max != nil {
+			bounds["lte"] = *rangeFilter.Max
+		}
+		if rangeFilter.MinDate != nil {
+			bounds["gte"] = rangeFilter.MinDate.Format(time.RFC3339Nano)
+		}
+		if rangeFilter.MaxDate != nil {
+			bounds["lte"] = rangeFilter.MaxDate.Format(time.RFC3339Nano)
+		}
+		must = append(must, map[string]interface{}{
+			"key":   qdrantMetadataPrefix + rangeFilter.FieldName,
+			"range": bounds,
+		})
+	}
+
+	for _, subFilters := range filters.And {
+		nested, err := subFilters.ToQdrantFilter()
+		if err != nil {
+			return nil, err
+		}
+		// an empty sub-filter matches everything, as in the Cypher translation
+		if len(nested) > 0 {
+			must = append(must, nested)
+		}
+	}
+	for _, subFilters := range filters.Or {
+		nested, err := subFilters.ToQdrantFilter()
+		if err != nil {
+			return nil, err
+		}
+		should = append(should, nested)
+	}
+	if filters.Not != nil {
+		nested, err := filters.Not.ToQdrantFilter()
+		if err != nil {
+			return nil, err
+		}
+		// an empty negation is a no-op, as in the Cypher translation, instead of excluding everything
+		if len(nested) > 0 {
+			mustNot = append(mustNot, nested)
+		}
+	}
+
+	qdrantFilter := map[string]interface{}{}
+	if len(must) > 0 {
+		qdrantFilter["must"] = must
+	}
+	if len(should) > 0 {
+		qdrantFilter["should"] = should
+	}
+	if len(mustNot) > 0 {
+		qdrantFilter["must_not"] = mustNot
+	}
+	return qdrantFilter, nil
+}
+
+// qdrantMatchAny creates a Qdrant condition matching any of the given values.
+func qdrantMatchAny(key string, values []string) map[string]interface{} {
+	return map[string]interface{}{
+		"key":   key,
+		"match": map[string]interface{}{"any": values},
+	}
+}
+
+// qdrantArrayConditions creates the Qdrant conditions for an array field; if needAll is true, every value must be present.
+func qdrantArrayConditions(key string, values []string, needAll bool) []interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	if !needAll {
+		return []interface{}{qdrantMatchAny(key, values)}
+	}
+	conditions := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		conditions = append(conditions, map[string]interface{}{
+			"key":   key,
+			"match": map[string]interface{}{"value": value},
+		})
+	}
+	return conditions
+}
+
+///////////////////////////////////
+// Cypher
+///////////////////////////////////
+
+// cypherWhereBuilder collects the parameters of a Cypher WHERE clause while it is being built.
+type cypherWhereBuilder struct {
+	node   string
+	params map[string]interface{}
+}
+
+// ToCypherWhereClause translates the filters to a parameterized Cypher WHERE clause (without the WHERE keyword).
+// Node properties are named like the JSON fields of DbData, metadata fields are expected as node properties of the same name.
+//
+// Parameters:
+//   - nodeVariable: the variable of the node in the MATCH clause, e.g. "n"
+//
+// Returns:
+//   - string: the condition, empty if the filters are empty
+//   - map[string]interface{}: the query parameters referenced by the condition
+//   - error: an error if a range filter is invalid
+func (filters *DbFilters) ToCypherWhereClause(nodeVariable string) (string, map[string]interface{}, error) {
+	builder := &cypherWhereBuilder{node: nodeVariable, params: map[string]interface{}{}}
+	clause, err := builder.build(filters)
+	if err != nil {
+		return "", nil, err
+	}
+	return clause, builder.params, nil
+}
+
+// build creates the condition for the given filters, combining all conditions with AND.
+func (b *cypherWhereBuilder) build(filters *DbFilters) (string, error) {
+	conditions := []string{}
+
+	for _, field := range []struct {
+		name   string
+		values []string
+	}{
+		{"guid", filters.GuidFilter},
+		{"document_id", filters.DocumentIdFilter},
+		{"document_name", filters.DocumentNameFilter},
+		{"level", filters.LevelFilter},
+	} {
+		if len(field.values) > 0 {
+			conditions = append(conditions, fmt.Sprintf("%s IN %s", b.property(field.name), b.param(field.values)))
+		}
+	}
+	if condition := b.arrayCondition("tags", filters.TagsFilter.FilterData, filters.TagsFilter.NeedAll); condition != "" {
+		conditions = append(conditions, condition)
+	}
+	if condition := b.arrayCondition("keywords", filters.KeywordsFilter.FilterData, filters.KeywordsFilter.NeedAll); condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	for _, jsonFilter := range filters.MetadataFilter {
+		if len(jsonFilter.FilterData) == 0 {
+			continue
+		}
+		if jsonFilter.FieldType == "array" {
+			conditions = append(conditions, b.arrayCondition(jsonFilter.FieldName, jsonFilter.FilterData, jsonFilter.NeedAll))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("%s IN %s", b.property(jsonFilter.FieldName), b.param(jsonFilter.FilterData)))
+		}
+	}
+
+	for _, rangeFilter := range filters.RangeFilter {
+		if err := rangeFilter.Validate(); err != nil {
+			return "", err
+		}
+		property := b.property(rangeFilter.FieldName)
+		if rangeFilter.Min != nil {
+			conditions = append(conditions, fmt.Sprintf("%s >= %s", property, b.param(*rangeFilter.Min)))
+		}
+		if rangeFilter.Max != nil {
+			conditions = append(conditions, fmt.Sprintf("%s <= %s", property, b.param(*rangeFilter.Max)))
+		}
+		if rangeFilter.MinDate != nil {
+			conditions = append(conditions, fmt.Sprintf("%s >= %s", property, b.param(*rangeFilter.MinDate)))
+		}
+		if rangeFilter.MaxDate != nil {
+			conditions = append(conditions, fmt.Sprintf("%s <= %s", property, b.param(*rangeFilter.MaxDate)))
+		}
+	}
+
+	for i := range filters.And {
+		condition, err := b.build(&filters.And[i])
+		if err != nil {
+			return "", err
+		}
+		if condition != "" {
+			conditions = append(conditions, "("+condition+")")
+		}
+	}
+
+	if len(filters.Or) > 0 {
+		alternatives := []string{}
+		for i := range filters.Or {
+			condition, err := b.build(&filters.Or[i])
+			if err != nil {
+				return "", err
+			}
+			if condition == "" {
+				// an empty alternative matches everything
+				alternatives = nil
+				break
+			}
+			alternatives = append(alternatives, "("+condition+")")
+		}
+		if len(alternatives) > 0 {
+			conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+		}
+	}
+
+	if filters.Not != nil {
+		condition, err := b.build(filters.Not)
+		if err != nil {
+			return "", err
+		}
+		if condition != "" {
+			conditions = append(conditions, "NOT ("+condition+")")
+		}
+	}
+
+	return strings.Join(conditions, " AND "), nil
+}
+
+// arrayCondition creates the condition for a list property; if needAll is true, every value must be present.
+func (b *cypherWhereBuilder) arrayCondition(name string, values []string, needAll bool) string {
+	if len(values) == 0 {
+		return ""
+	}
+	quantifier := "any"
+	if needAll {
+		quantifier = "all"
+	}
+	return fmt.Sprintf("%s(x IN %s WHERE x IN %s)", quantifier, b.param(values), b.property(name))
+}
+
+// property returns the escaped reference to a node property.
+func (b *cypherWhereBuilder) property(name string) string {
+	return fmt.Sprintf("%s.`%s`", b.node, strings.ReplaceAll(name, "`", "``"))
+}
+
+// param registers a query parameter and returns its reference.
+func (b *cypherWhereBuilder) param(value interface{}) string {
+	name := fmt.Sprintf("filter_%d", len(b.params))
+	b.params[name] = value
+	return "$" + name
+}
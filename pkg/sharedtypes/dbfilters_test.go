@@ -0,0 +1,187 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDbFiltersToQdrantFilter(t *testing.T) {
+	minPages := 10.0
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	filters := DbFilters{
+		DocumentIdFilter: []string{"doc-1", "doc-2"},
+		TagsFilter:       DbArrayFilter{NeedAll: true, FilterData: []string{"fluids", "meshing"}},
+		RangeFilter: []DbRangeFilter{
+			{FieldName: "pages", Min: &minPages},
+			{FieldName: "created", MinDate: &after},
+		},
+		Or: []DbFilters{
+			{DocumentNameFilter: []string{"a.pdf"}},
+			{DocumentNameFilter: []string{"b.pdf"}},
+		},
+		Not: &DbFilters{LevelFilter: []string{"root"}},
+	}
+
+	qdrantFilter, err := filters.ToQdrantFilter()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := json.Marshal(qdrantFilter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{` +
+		`"must":[` +
+		`{"key":"document_id","match":{"any":["doc-1","doc-2"]}},` +
+		`{"key":"tags","match":{"value":"fluids"}},` +
+		`{"key":"tags","match":{"value":"meshing"}},` +
+		`{"key":"metadata.pages","range":{"gte":10}},` +
+		`{"key":"metadata.created","range":{"gte":"2025-01-01T00:00:00Z"}}],` +
+		`"must_not":[{"must":[{"key":"level","match":{"any":["root"]}}]}],` +
+		`"should":[` +
+		`{"must":[{"key":"document_name","match":{"any":["a.pdf"]}}]},` +
+		`{"must":[{"key":"document_name","match":{"any":["b.pdf"]}}]}]` +
+		`}`
+	if string(got) != expected {
+		t.Errorf("ToQdrantFilter() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestDbFiltersToCypherWhereClause(t *testing.T) {
+	maxVersion := 3.0
+	filters := DbFilters{
+		GuidFilter:     []string{"guid-1"},
+		KeywordsFilter: DbArrayFilter{FilterData: []string{"solver"}},
+		MetadataFilter: []DbJsonFilter{{FieldName: "product", FieldType: "string", FilterData: []string{"Fluent"}}},
+		RangeFilter:    []DbRangeFilter{{FieldName: "version", Max: &maxVersion}},
+		Or: []DbFilters{
+			{DocumentIdFilter: []string{"doc-1"}},
+			{DocumentIdFilter: []string{"doc-2"}},
+		},
+		Not: &DbFilters{TagsFilter: DbArrayFilter{NeedAll: true, FilterData: []string{"draft"}}},
+	}
+
+	clause, params, err := filters.ToCypherWhereClause("n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedClause := "n.`guid` IN $filter_0" +
+		" AND any(x IN $filter_1 WHERE x IN n.`keywords`)" +
+		" AND n.`product` IN $filter_2" +
+		" AND n.`version` <= $filter_3" +
+		" AND ((n.`document_id` IN $filter_4) OR (n.`document_id` IN $filter_5))" +
+		" AND NOT (all(x IN $filter_6 WHERE x IN n.`tags`))"
+	if clause != expectedClause {
+		t.Errorf("ToCypherWhereClause() =\n%s\nwant\n%s", clause, expectedClause)
+	}
+
+	expectedParams := map[string]interface{}{
+		"filter_0": []string{"guid-1"},
+		"filter_1": []string{"solver"},
+		"filter_2": []string{"Fluent"},
+		"filter_3": 3.0,
+		"filter_4": []string{"doc-1"},
+		"filter_5": []string{"doc-2"},
+		"filter_6": []string{"draft"},
+	}
+	if !reflect.DeepEqual(params, expectedParams) {
+		t.Errorf("params = %v, want %v", params, expectedParams)
+	}
+}
+
+func TestDbFiltersEmpty(t *testing.T) {
+	filters := DbFilters{}
+	if !filters.IsEmpty() {
+		t.Error("IsEmpty() should be true for empty filters")
+	}
+
+	clause, params, err := filters.ToCypherWhereClause("n")
+	if err != nil || clause != "" || len(params) != 0 {
+		t.Errorf("ToCypherWhereClause() = %q, %v, %v", clause, params, err)
+	}
+
+	qdrantFilter, err := filters.ToQdrantFilter()
+	if err != nil || len(qdrantFilter) != 0 {
+		t.Errorf("ToQdrantFilter() = %v, %v", qdrantFilter, err)
+	}
+}
+
+func TestDbFiltersEmptyNot(t *testing.T) {
+	level := DbFilters{LevelFilter: []string{"leaf"}}
+	tests := []struct {
+		name    string
+		filters DbFilters
+	}{
+		{"empty not", DbFilters{LevelFilter: level.LevelFilter, Not: &DbFilters{}}},
+		{"not of empty and", DbFilters{LevelFilter: level.LevelFilter, Not: &DbFilters{And: []DbFilters{{}}}}},
+		{"empty and", DbFilters{LevelFilter: level.LevelFilter, And: []DbFilters{{}}}},
+	}
+
+	// an empty negation is a no-op in both translations: the filters translate like the level filter alone
+	expectedQdrant, _ := level.ToQdrantFilter()
+	expectedCypher, _, _ := level.ToCypherWhereClause("n")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qdrantFilter, err := tt.filters.ToQdrantFilter()
+			if err != nil || !reflect.DeepEqual(qdrantFilter, expectedQdrant) {
+				t.Errorf("ToQdrantFilter() = %v, %v, want %v", qdrantFilter, err, expectedQdrant)
+			}
+			clause, _, err := tt.filters.ToCypherWhereClause("n")
+			if err != nil || clause != expectedCypher {
+				t.Errorf("ToCypherWhereClause() = %q, %v, want %q", clause, err, expectedCypher)
+			}
+		})
+	}
+
+	if filters := (DbFilters{Not: &DbFilters{}}); !filters.IsEmpty() {
+		t.Error("IsEmpty() should be true for an empty negation")
+	}
+}
+
+func TestDbRangeFilterValidate(t *testing.T) {
+	low, high := 1.0, 2.0
+	now := time.Now()
+
+	invalid := []DbRangeFilter{
+		{Min: &low},
+		{FieldName: "size"},
+		{FieldName: "size", Min: &high, Max: &low},
+		{FieldName: "size", Min: &low, MaxDate: &now},
+	}
+	for _, rangeFilter := range invalid {
+		if err := rangeFilter.Validate(); err == nil {
+			t.Errorf("Validate() for %+v should fail", rangeFilter)
+		}
+	}
+
+	filters := DbFilters{And: []DbFilters{{RangeFilter: invalid[:1]}}}
+	if _, err := filters.ToQdrantFilter(); err == nil {
+		t.Error("ToQdrantFilter() should propagate range filter errors")
+	}
+}
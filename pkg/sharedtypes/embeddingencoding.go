@@ -0,0 +1,164 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sync/atomic"
+)
+
+// EmbeddingEncoding is the JSON encoding of Embedding values.
+type EmbeddingEncoding string
+
+const (
+	EmbeddingEncodingJSON   EmbeddingEncoding = "json"   // array of numbers; the default
+	EmbeddingEncodingBase64 EmbeddingEncoding = "base64" // base64 string of the little-endian float32 values, about half the size
+)
+
+// EmbeddingEncodings lists all supported embedding encodings.
+var EmbeddingEncodings = []EmbeddingEncoding{EmbeddingEncodingJSON, EmbeddingEncodingBase64}
+
+// Validate checks that the encoding is supported; the empty encoding stands for EmbeddingEncodingJSON.
+//
+// Returns:
+//   - error: an error if the encoding is not supported
+func (e EmbeddingEncoding) Validate() error {
+	if e != "" && !slices.Contains(EmbeddingEncodings, e) {
+		return fmt.Errorf("unsupported embedding encoding %q, must be one of %v", e, EmbeddingEncodings)
+	}
+	return nil
+}
+
+// binaryEmbeddings is true if Embedding values are marshaled with EmbeddingEncodingBase64
+var binaryEmbeddings atomic.Bool
+
+// SetEmbeddingEncoding sets the encoding used to marshal Embedding values, e.g. the embeddings of DbData
+// and DbResponse. Both encodings are always accepted when unmarshaling, so services can switch to the
+// binary encoding one at a time.
+//
+// Parameters:
+//   - encoding: the encoding; empty for EmbeddingEncodingJSON
+//
+// Returns:
+//   - error: an error if the encoding is not supported
+func SetEmbeddingEncoding(encoding EmbeddingEncoding) error {
+	if err := encoding.Validate(); err != nil {
+		return err
+	}
+	binaryEmbeddings.Store(encoding == EmbeddingEncodingBase64)
+	return nil
+}
+
+// CurrentEmbeddingEncoding returns the encoding used to marshal Embedding values.
+//
+// Returns:
+//   - EmbeddingEncoding: the current encoding
+func CurrentEmbeddingEncoding() EmbeddingEncoding {
+	if binaryEmbeddings.Load() {
+		return EmbeddingEncodingBase64
+	}
+	return EmbeddingEncodingJSON
+}
+
+// Embedding is a dense embedding vector.
+// It is marshaled with the encoding set by SetEmbeddingEncoding and unmarshaled from either encoding.
+type Embedding []float32
+
+// MarshalJSON writes the embedding as an array of numbers or, with EmbeddingEncodingBase64, as a base64 string.
+// Nil embeddings are written as null in both encodings.
+func (e Embedding) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	if !binaryEmbeddings.Load() {
+		return json.Marshal([]float32(e))
+	}
+	return json.Marshal(EncodeEmbeddingBase64(e))
+}
+
+// UnmarshalJSON reads an embedding written as an array of numbers or as a base64 string.
+func (e *Embedding) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '"' {
+		var encoded string
+		if err := json.Unmarshal(trimmed, &encoded); err != nil {
+			return err
+		}
+		values, err := DecodeEmbeddingBase64(encoded)
+		if err != nil {
+			return err
+		}
+		*e = values
+		return nil
+	}
+
+	var values []float32
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*e = values
+	return nil
+}
+
+// EncodeEmbeddingBase64 encodes an embedding as the base64 string of its little-endian float32 values.
+//
+// Parameters:
+//   - values: the embedding
+//
+// Returns:
+//   - string: the encoded embedding
+func EncodeEmbeddingBase64(values []float32) string {
+	raw := make([]byte, 4*len(values))
+	for i, value := range values {
+		binary.LittleEndian.PutUint32(raw[4*i:], math.Float32bits(value))
+	}
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+// DecodeEmbeddingBase64 decodes an embedding encoded with EncodeEmbeddingBase64.
+//
+// Parameters:
+//   - encoded: the encoded embedding
+//
+// Returns:
+//   - Embedding: the embedding; empty, not nil, for an empty string
+//   - error: an error if the string is not valid base64 or not a whole number of float32 values
+func DecodeEmbeddingBase64(encoded string) (Embedding, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 embedding: %w", err)
+	}
+	if len(raw)%4 != 0 {
+		return nil, fmt.Errorf("invalid base64 embedding: %d bytes is not a multiple of 4", len(raw))
+	}
+	values := make(Embedding, len(raw)/4)
+	for i := range values {
+		values[i] = math.Float32frombits(binary.LittleEndian.Uint32(raw[4*i:]))
+	}
+	return values, nil
+}
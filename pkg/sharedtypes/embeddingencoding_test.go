@@ -0,0 +1,187 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// withEmbeddingEncoding sets the embedding encoding for the duration of a test or benchmark
+func withEmbeddingEncoding(tb testing.TB, encoding EmbeddingEncoding) {
+	tb.Helper()
+	previous := CurrentEmbeddingEncoding()
+	if err := SetEmbeddingEncoding(encoding); err != nil {
+		tb.Fatalf("SetEmbeddingEncoding() error = %v", err)
+	}
+	tb.Cleanup(func() { SetEmbeddingEncoding(previous) })
+}
+
+func TestEmbeddingEncodingValidate(t *testing.T) {
+	for _, encoding := range append(EmbeddingEncodings, "") {
+		if err := encoding.Validate(); err != nil {
+			t.Errorf("Validate(%q) error = %v", encoding, err)
+		}
+	}
+	if err := EmbeddingEncoding("float16").Validate(); err == nil {
+		t.Error("expected an error for an unsupported encoding")
+	}
+	if err := SetEmbeddingEncoding("float16"); err == nil {
+		t.Error("SetEmbeddingEncoding() accepted an unsupported encoding")
+	}
+}
+
+func TestEmbeddingMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		encoding  EmbeddingEncoding
+		embedding Embedding
+		expected  string
+	}{
+		{"json", EmbeddingEncodingJSON, Embedding{1, 0.5}, `[1,0.5]`},
+		{"json nil", EmbeddingEncodingJSON, nil, `null`},
+		{"json empty", EmbeddingEncodingJSON, Embedding{}, `[]`},
+		{"base64", EmbeddingEncodingBase64, Embedding{1, 2}, `"AACAPwAAAEA="`},
+		{"base64 nil", EmbeddingEncodingBase64, nil, `null`},
+		{"base64 empty", EmbeddingEncodingBase64, Embedding{}, `""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withEmbeddingEncoding(t, tt.encoding)
+			got, err := json.Marshal(tt.embedding)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("Marshal() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEmbeddingUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  Embedding
+		expectErr bool
+	}{
+		{"array", `[1, 0.5]`, Embedding{1, 0.5}, false},
+		{"null", `null`, nil, false},
+		{"base64", `"AACAPwAAAEA="`, Embedding{1, 2}, false},
+		{"empty base64", `""`, Embedding{}, false},
+		{"invalid base64", `"not base64!"`, nil, true},
+		{"truncated value", `"AACAPwAA"`, nil, true},
+		{"wrong type", `{"a": 1}`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Embedding
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Unmarshal() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if !tt.expectErr && !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Unmarshal() = %#v, want %#v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEmbeddingBase64RoundTrip(t *testing.T) {
+	values := []float32{0, -0.25, math.MaxFloat32, math.SmallestNonzeroFloat32, float32(math.Inf(-1))}
+	got, err := DecodeEmbeddingBase64(EncodeEmbeddingBase64(values))
+	if err != nil {
+		t.Fatalf("DecodeEmbeddingBase64() error = %v", err)
+	}
+	if !reflect.DeepEqual([]float32(got), values) {
+		t.Errorf("round trip = %v, want %v", got, values)
+	}
+}
+
+func TestDbDataEmbeddingEncoding(t *testing.T) {
+	data := DbData{Text: "text", Embedding: Embedding{0.1, -0.2, 0.3}}
+	response := DbResponse{Text: "text", Embedding: Embedding{0.4}}
+
+	// values written with either encoding are read back by services using the other one
+	for _, write := range EmbeddingEncodings {
+		withEmbeddingEncoding(t, write)
+		rawData, err := json.Marshal(data)
+		if err != nil {
+			t.Fatalf("%s: Marshal(DbData) error = %v", write, err)
+		}
+		rawResponse, err := json.Marshal(response)
+		if err != nil {
+			t.Fatalf("%s: Marshal(DbResponse) error = %v", write, err)
+		}
+
+		for _, read := range EmbeddingEncodings {
+			withEmbeddingEncoding(t, read)
+			var gotData DbData
+			if err := json.Unmarshal(rawData, &gotData); err != nil || !reflect.DeepEqual(gotData.Embedding, data.Embedding) {
+				t.Errorf("%s -> %s: DbData embedding = %v (%v), want %v", write, read, gotData.Embedding, err, data.Embedding)
+			}
+			var gotResponse DbResponse
+			if err := json.Unmarshal(rawResponse, &gotResponse); err != nil || !reflect.DeepEqual(gotResponse.Embedding, response.Embedding) {
+				t.Errorf("%s -> %s: DbResponse embedding = %v (%v), want %v", write, read, gotResponse.Embedding, err, response.Embedding)
+			}
+		}
+	}
+}
+
+// benchmarkEmbeddingEncoding marshals and unmarshals DbData with a 1024-dimensional embedding,
+// reporting the size of the JSON per entry
+func benchmarkEmbeddingEncoding(b *testing.B, encoding EmbeddingEncoding) {
+	withEmbeddingEncoding(b, encoding)
+	random := rand.New(rand.NewSource(1))
+	data := DbData{Text: "text", Embedding: make(Embedding, 1024)}
+	for i := range data.Embedding {
+		data.Embedding[i] = random.Float32()*2 - 1
+	}
+
+	size := 0
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var decoded DbData
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			b.Fatal(err)
+		}
+		size = len(raw)
+	}
+	b.ReportMetric(float64(size), "bytes/entry")
+}
+
+func BenchmarkEmbeddingEncodingJSON(b *testing.B) {
+	benchmarkEmbeddingEncoding(b, EmbeddingEncodingJSON)
+}
+
+func BenchmarkEmbeddingEncodingBase64(b *testing.B) {
+	benchmarkEmbeddingEncoding(b, EmbeddingEncodingBase64)
+}
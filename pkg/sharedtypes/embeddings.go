@@ -0,0 +1,632 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// EmbeddingRequest represents a typed request for embeddings of one or more texts.
+type EmbeddingRequest struct {
+	InstructionGuid string           `json:"instructionGuid"`
+	ModelId         string           `json:"modelId,omitempty"`    // optional model id; if empty, the default embeddings model is used
+	Texts           []string         `json:"texts"`                // texts to embed
+	Dimensions      *int             `json:"dimensions,omitempty"` // optional output dimensionality; only for models supporting it
+	Normalize       *bool            `json:"normalize,omitempty"`  // if true, dense vectors are L2 normalized
+	Options         EmbeddingOptions `json:"options"`
+}
+
+// EmbeddingResponse represents a typed embeddings response; all vectors are in the order of the request texts.
+type EmbeddingResponse struct {
+	InstructionGuid string             `json:"instructionGuid"`
+	ModelId         string             `json:"modelId,omitempty"`
+	Dimensions      int                `json:"dimensions"`        // dimensionality of the dense vectors
+	Normalized      bool               `json:"normalized"`        // true if the dense vectors are L2 normalized
+	Dense           [][]float32        `json:"dense,omitempty"`   // one dense vector per text
+	Sparse          []map[uint]float32 `json:"sparse,omitempty"`  // one lexical weight map per text; only for BAAI/bge-m3
+	Colbert         [][][]float32      `json:"colbert,omitempty"` // one set of colbert vectors per text; only for BAAI/bge-m3
+}
+
+// ToHandlerRequest converts the embedding request to the legacy HandlerRequest shape.
+//
+// Returns:
+//   - HandlerRequest: the request to send to the LLM handler
+func (er *EmbeddingRequest) ToHandlerRequest() HandlerRequest {
+	request := HandlerRequest{
+		Adapter:          AdapterEmbeddings,
+		InstructionGuid:  er.InstructionGuid,
+		Data:             er.Texts,
+		EmbeddingOptions: er.Options,
+	}
+	if er.ModelId != "" {
+		request.ModelIds = []string{er.ModelId}
+	}
+	if er.Dimensions != nil {
+		request.EmbeddingOptions.Dimensions = er.Dimensions
+	}
+	if er.Normalize != nil {
+		request.EmbeddingOptions.Normalize = er.Normalize
+	}
+	return request
+}
+
+// NewEmbeddingRequestFromHandlerRequest converts a legacy embeddings HandlerRequest to an EmbeddingRequest.
+//
+// Parameters:
+//   - request: the legacy request; Data must be a string or a list of strings
+//
+// Returns:
+//   - EmbeddingRequest: the typed request
+//   - error: an error if the data has an unsupported type
+func NewEmbeddingRequestFromHandlerRequest(request HandlerRequest) (EmbeddingRequest, error) {
+	embeddingRequest := EmbeddingRequest{
+		InstructionGuid: request.InstructionGuid,
+		Dimensions:      request.EmbeddingOptions.Dimensions,
+		Normalize:       request.EmbeddingOptions.Normalize,
+		Options:         request.EmbeddingOptions,
+	}
+	if len(request.ModelIds) > 0 {
+		embeddingRequest.ModelId = request.ModelIds[0]
+	}
+
+	switch data := request.Data.(type) {
+	case string:
+		embeddingRequest.Texts = []string{data}
+	case []string:
+		embeddingRequest.Texts = data
+	case []interface{}:
+		for i, item := range data {
+			text, ok := item.(string)
+			if !ok {
+				return EmbeddingRequest{}, fmt.Errorf("embeddings data element %d is of type %T, expected string", i, item)
+			}
+			embeddingRequest.Texts = append(embeddingRequest.Texts, text)
+		}
+	default:
+		return EmbeddingRequest{}, fmt.Errorf("embeddings data is of type %T, expected string or []string", request.Data)
+	}
+
+	return embeddingRequest, nil
+}
+
+// ToHandlerResponse converts the embedding response to the legacy HandlerResponse shape.
+// The batch shape ([][]float32, []map[uint]float32, [][][]float32) is used for the embeddings fields.
+//
+// Returns:
+//   - HandlerResponse: the response to send to the client
+func (er *EmbeddingResponse) ToHandlerResponse() HandlerResponse {
+	response := HandlerResponse{
+		InstructionGuid: er.InstructionGuid,
+		Type:            "embeddings",
+	}
+	if er.Dense != nil {
+		response.EmbeddedData = er.Dense
+	}
+	if er.Sparse != nil {
+		response.LexicalWeights = er.Sparse
+	}
+	if er.Colbert != nil {
+		response.ColbertVecs = er.Colbert
+	}
+	return response
+}
+
+// NewEmbeddingResponseFromHandlerResponse converts a legacy embeddings HandlerResponse to an EmbeddingResponse.
+// Both the single and batch shapes are supported, either as Go types, as embedding unions or as decoded JSON.
+//
+// Parameters:
+//   - response: the legacy response
+//
+// Returns:
+//   - EmbeddingResponse: the typed response
+//   - error: an error if one of the embeddings fields has an unsupported type
+func NewEmbeddingResponseFromHandlerResponse(response HandlerResponse) (EmbeddingResponse, error) {
+	embeddingResponse := EmbeddingResponse{InstructionGuid: response.InstructionGuid}
+
+	dense, err := response.Dense()
+	if err != nil {
+		return EmbeddingResponse{}, fmt.Errorf("error converting embedded data: %w", err)
+	}
+	embeddingResponse.Dense = dense.Vectors
+	embeddingResponse.Dimensions = dense.Dimensions()
+
+	sparse, err := response.Sparse()
+	if err != nil {
+		return EmbeddingResponse{}, fmt.Errorf("error converting lexical weights: %w", err)
+	}
+	embeddingResponse.Sparse = sparse.Vectors
+
+	colbert, err := response.Colbert()
+	if err != nil {
+		return EmbeddingResponse{}, fmt.Errorf("error converting colbert vectors: %w", err)
+	}
+	embeddingResponse.Colbert = colbert.Vectors
+
+	return embeddingResponse, nil
+}
+
+// toFloat32 converts a numeric value to float32.
+func toFloat32(value interface{}) (float32, error) {
+	switch v := value.(type) {
+	case float32:
+		return v, nil
+	case float64:
+		return float32(v), nil
+	case int:
+		return float32(v), nil
+	case json.Number:
+		f, err := v.Float64()
+		return float32(f), err
+	default:
+		return 0, fmt.Errorf("value is of type %T, expected a number", value)
+	}
+}
+
+// toFloat32Vector converts []float32 or a decoded JSON array to []float32.
+func toFloat32Vector(value interface{}) ([]float32, error) {
+	switch v := value.(type) {
+	case []float32:
+		return v, nil
+	case []float64:
+		vector := make([]float32, len(v))
+		for i, f := range v {
+			vector[i] = float32(f)
+		}
+		return vector, nil
+	case []interface{}:
+		vector := make([]float32, len(v))
+		for i, item := range v {
+			f, err := toFloat32(item)
+			if err != nil {
+				return nil, err
+			}
+			vector[i] = f
+		}
+		return vector, nil
+	default:
+		return nil, fmt.Errorf("value is of type %T, expected a vector", value)
+	}
+}
+
+// toFloat32Matrix converts a single vector or a list of vectors to [][]float32.
+func toFloat32Matrix(value interface{}) ([][]float32, error) {
+	switch v := value.(type) {
+	case [][]float32:
+		return v, nil
+	case []float32, []float64:
+		vector, err := toFloat32Vector(v)
+		if err != nil {
+			return nil, err
+		}
+		return [][]float32{vector}, nil
+	case []interface{}:
+		// a list of numbers is a single vector, a list of lists is a batch
+		if len(v) > 0 {
+			if _, isList := v[0].([]interface{}); !isList {
+				vector, err := toFloat32Vector(v)
+				if err != nil {
+					return nil, err
+				}
+				return [][]float32{vector}, nil
+			}
+		}
+		matrix := make([][]float32, len(v))
+		for i, item := range v {
+			vector, err := toFloat32Vector(item)
+			if err != nil {
+				return nil, err
+			}
+			matrix[i] = vector
+		}
+		return matrix, nil
+	default:
+		return nil, fmt.Errorf("value is of type %T, expected a vector or a list of vectors", value)
+	}
+}
+
+// toSparseVector converts map[uint]float32 or a decoded JSON object to map[uint]float32.
+func toSparseVector(value interface{}) (map[uint]float32, error) {
+	switch v := value.(type) {
+	case map[uint]float32:
+		return v, nil
+	case map[string]interface{}:
+		sparse := make(map[uint]float32, len(v))
+		for key, item := range v {
+			index, err := strconv.ParseUint(key, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sparse vector index %q: %w", key, err)
+			}
+			f, err := toFloat32(item)
+			if err != nil {
+				return nil, err
+			}
+			sparse[uint(index)] = f
+		}
+		return sparse, nil
+	default:
+		return nil, fmt.Errorf("value is of type %T, expected a sparse vector", value)
+	}
+}
+
+// toSparseVectors converts a single sparse vector or a list of sparse vectors to []map[uint]float32.
+func toSparseVectors(value interface{}) ([]map[uint]float32, error) {
+	switch v := value.(type) {
+	case []map[uint]float32:
+		return v, nil
+	case map[uint]float32, map[string]interface{}:
+		sparse, err := toSparseVector(v)
+		if err != nil {
+			return nil, err
+		}
+		return []map[uint]float32{sparse}, nil
+	case []interface{}:
+		vectors := make([]map[uint]float32, len(v))
+		for i, item := range v {
+			sparse, err := toSparseVector(item)
+			if err != nil {
+				return nil, err
+			}
+			vectors[i] = sparse
+		}
+		return vectors, nil
+	default:
+		return nil, fmt.Errorf("value is of type %T, expected a sparse vector or a list of sparse vectors", value)
+	}
+}
+
+// toColbertVectors converts the colbert vectors of a single text or of a list of texts to [][][]float32.
+func toColbertVectors(value interface{}) ([][][]float32, error) {
+	switch v := value.(type) {
+	case [][][]float32:
+		return v, nil
+	case [][]float32:
+		return [][][]float32{v}, nil
+	case []interface{}:
+		// [][]float32 decoded from JSON has numbers at depth two, [][][]float32 has lists
+		isBatch := false
+		if len(v) > 0 {
+			if inner, ok := v[0].([]interface{}); ok && len(inner) > 0 {
+				_, isBatch = inner[0].([]interface{})
+			}
+		}
+		if !isBatch {
+			matrix, err := toFloat32Matrix(v)
+			if err != nil {
+				return nil, err
+			}
+			return [][][]float32{matrix}, nil
+		}
+		batch := make([][][]float32, len(v))
+		for i, item := range v {
+			matrix, err := toFloat32Matrix(item)
+			if err != nil {
+				return nil, err
+			}
+			batch[i] = matrix
+		}
+		return batch, nil
+	default:
+		return nil, fmt.Errorf("value is of type %T, expected colbert vectors", value)
+	}
+}
+
+// EmbeddingShape tells whether an embeddings field of a HandlerResponse holds the embedding of a single text or a batch.
+type EmbeddingShape string
+
+// Shapes of the embeddings fields of a HandlerResponse.
+const (
+	EmbeddingShapeSingle EmbeddingShape = "single"
+	EmbeddingShapeBatch  EmbeddingShape = "batch"
+)
+
+// DenseEmbedding is the typed content of HandlerResponse.EmbeddedData.
+// Vectors holds one vector per text; a single embedding has exactly one vector.
+// It is encoded as []float32 or [][]float32 depending on its shape, so it can be assigned to EmbeddedData.
+type DenseEmbedding struct {
+	Shape   EmbeddingShape
+	Vectors [][]float32
+}
+
+// SparseEmbedding is the typed content of HandlerResponse.LexicalWeights.
+// Vectors holds one lexical weight map per text; a single embedding has exactly one map.
+// It is encoded as map[uint]float32 or []map[uint]float32 depending on its shape, so it can be assigned to LexicalWeights.
+type SparseEmbedding struct {
+	Shape   EmbeddingShape
+	Vectors []map[uint]float32
+}
+
+// ColbertEmbedding is the typed content of HandlerResponse.ColbertVecs.
+// Vectors holds one set of colbert vectors per text; a single embedding has exactly one set.
+// It is encoded as [][]float32 or [][][]float32 depending on its shape, so it can be assigned to ColbertVecs.
+type ColbertEmbedding struct {
+	Shape   EmbeddingShape
+	Vectors [][][]float32
+}
+
+// NewDenseEmbedding resolves the shape of the value of HandlerResponse.EmbeddedData.
+//
+// Parameters:
+//   - value: a DenseEmbedding, []float32, [][]float32 or a decoded JSON array
+//
+// Returns:
+//   - DenseEmbedding: the typed embedding
+//   - error: an error if the value has an unsupported type
+func NewDenseEmbedding(value interface{}) (DenseEmbedding, error) {
+	shape := EmbeddingShapeBatch
+	switch v := value.(type) {
+	case DenseEmbedding:
+		return v, nil
+	case *DenseEmbedding:
+		return *v, nil
+	case []float32, []float64:
+		shape = EmbeddingShapeSingle
+	case []interface{}:
+		if len(v) > 0 {
+			if _, isList := v[0].([]interface{}); !isList {
+				shape = EmbeddingShapeSingle
+			}
+		}
+	}
+	vectors, err := toFloat32Matrix(value)
+	if err != nil {
+		return DenseEmbedding{}, err
+	}
+	return DenseEmbedding{Shape: shape, Vectors: vectors}, nil
+}
+
+// NewSparseEmbedding resolves the shape of the value of HandlerResponse.LexicalWeights.
+//
+// Parameters:
+//   - value: a SparseEmbedding, map[uint]float32, []map[uint]float32 or a decoded JSON object or array
+//
+// Returns:
+//   - SparseEmbedding: the typed embedding
+//   - error: an error if the value has an unsupported type
+func NewSparseEmbedding(value interface{}) (SparseEmbedding, error) {
+	shape := EmbeddingShapeBatch
+	switch v := value.(type) {
+	case SparseEmbedding:
+		return v, nil
+	case *SparseEmbedding:
+		return *v, nil
+	case map[uint]float32, map[string]interface{}:
+		shape = EmbeddingShapeSingle
+	}
+	vectors, err := toSparseVectors(value)
+	if err != nil {
+		return SparseEmbedding{}, err
+	}
+	return SparseEmbedding{Shape: shape, Vectors: vectors}, nil
+}
+
+// NewColbertEmbedding resolves the shape of the value of HandlerResponse.ColbertVecs.
+//
+// Parameters:
+//   - value: a ColbertEmbedding, [][]float32, [][][]float32 or a decoded JSON array
+//
+// Returns:
+//   - ColbertEmbedding: the typed embedding
+//   - error: an error if the value has an unsupported type
+func NewColbertEmbedding(value interface{}) (ColbertEmbedding, error) {
+	shape := EmbeddingShapeBatch
+	switch v := value.(type) {
+	case ColbertEmbedding:
+		return v, nil
+	case *ColbertEmbedding:
+		return *v, nil
+	case [][]float32:
+		shape = EmbeddingShapeSingle
+	case []interface{}:
+		// [][]float32 decoded from JSON has numbers at depth two, [][][]float32 has lists
+		if len(v) > 0 {
+			if inner, ok := v[0].([]interface{}); ok && len(inner) > 0 {
+				if _, isList := inner[0].([]interface{}); !isList {
+					shape = EmbeddingShapeSingle
+				}
+			}
+		}
+	}
+	vectors, err := toColbertVectors(value)
+	if err != nil {
+		return ColbertEmbedding{}, err
+	}
+	return ColbertEmbedding{Shape: shape, Vectors: vectors}, nil
+}
+
+// Single returns the vector of a single embedding.
+//
+// Returns:
+//   - []float32: the vector
+//   - error: an error if the embedding does not have exactly one vector
+func (e DenseEmbedding) Single() ([]float32, error) {
+	if len(e.Vectors) != 1 {
+		return nil, fmt.Errorf("expected a single dense vector, got %d", len(e.Vectors))
+	}
+	return e.Vectors[0], nil
+}
+
+// Dimensions returns the dimensionality of the vectors, 0 if there are no vectors.
+func (e DenseEmbedding) Dimensions() int {
+	if len(e.Vectors) == 0 {
+		return 0
+	}
+	return len(e.Vectors[0])
+}
+
+// MarshalJSON encodes a single embedding as vector and a batch as list of vectors.
+func (e DenseEmbedding) MarshalJSON() ([]byte, error) {
+	if e.Shape == EmbeddingShapeSingle {
+		vector, err := e.Single()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(vector)
+	}
+	return json.Marshal(e.Vectors)
+}
+
+// UnmarshalJSON resolves the shape from the nesting of the JSON array.
+func (e *DenseEmbedding) UnmarshalJSON(data []byte) error {
+	return unmarshalEmbedding(data, e, NewDenseEmbedding)
+}
+
+// Single returns the lexical weights of a single embedding.
+//
+// Returns:
+//   - map[uint]float32: the lexical weights
+//   - error: an error if the embedding does not have exactly one map
+func (e SparseEmbedding) Single() (map[uint]float32, error) {
+	if len(e.Vectors) != 1 {
+		return nil, fmt.Errorf("expected a single sparse vector, got %d", len(e.Vectors))
+	}
+	return e.Vectors[0], nil
+}
+
+// MarshalJSON encodes a single embedding as object and a batch as list of objects.
+func (e SparseEmbedding) MarshalJSON() ([]byte, error) {
+	if e.Shape == EmbeddingShapeSingle {
+		vector, err := e.Single()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(vector)
+	}
+	return json.Marshal(e.Vectors)
+}
+
+// UnmarshalJSON resolves the shape from the JSON value: an object is a single embedding, an array a batch.
+func (e *SparseEmbedding) UnmarshalJSON(data []byte) error {
+	return unmarshalEmbedding(data, e, NewSparseEmbedding)
+}
+
+// Single returns the colbert vectors of a single embedding.
+//
+// Returns:
+//   - [][]float32: the colbert vectors
+//   - error: an error if the embedding does not have exactly one set of vectors
+func (e ColbertEmbedding) Single() ([][]float32, error) {
+	if len(e.Vectors) != 1 {
+		return nil, fmt.Errorf("expected colbert vectors of a single text, got %d", len(e.Vectors))
+	}
+	return e.Vectors[0], nil
+}
+
+// MarshalJSON encodes a single embedding as list of vectors and a batch as list of lists of vectors.
+func (e ColbertEmbedding) MarshalJSON() ([]byte, error) {
+	if e.Shape == EmbeddingShapeSingle {
+		vectors, err := e.Single()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(vectors)
+	}
+	return json.Marshal(e.Vectors)
+}
+
+// UnmarshalJSON resolves the shape from the nesting of the JSON array.
+func (e *ColbertEmbedding) UnmarshalJSON(data []byte) error {
+	return unmarshalEmbedding(data, e, NewColbertEmbedding)
+}
+
+// unmarshalEmbedding decodes JSON into an embedding union, resolving its shape with the given constructor.
+//
+// Parameters:
+//   - data: the JSON value
+//   - target: the embedding to set
+//   - resolve: the constructor resolving the shape of the decoded value
+//
+// Returns:
+//   - error: an error if the JSON is invalid or has an unsupported shape
+func unmarshalEmbedding[E any](data []byte, target *E, resolve func(interface{}) (E, error)) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	embedding, err := resolve(value)
+	if err != nil {
+		return err
+	}
+	*target = embedding
+	return nil
+}
+
+// Dense returns the typed dense embedding of the response.
+//
+// Returns:
+//   - DenseEmbedding: the embedding; empty if EmbeddedData is not set
+//   - error: an error if EmbeddedData has an unsupported type
+func (r *HandlerResponse) Dense() (DenseEmbedding, error) {
+	if r.EmbeddedData == nil {
+		return DenseEmbedding{}, nil
+	}
+	return NewDenseEmbedding(r.EmbeddedData)
+}
+
+// Sparse returns the typed lexical weights of the response.
+//
+// Returns:
+//   - SparseEmbedding: the embedding; empty if LexicalWeights is not set
+//   - error: an error if LexicalWeights has an unsupported type
+func (r *HandlerResponse) Sparse() (SparseEmbedding, error) {
+	if r.LexicalWeights == nil {
+		return SparseEmbedding{}, nil
+	}
+	return NewSparseEmbedding(r.LexicalWeights)
+}
+
+// Colbert returns the typed colbert vectors of the response.
+//
+// Returns:
+//   - ColbertEmbedding: the embedding; empty if ColbertVecs is not set
+//   - error: an error if ColbertVecs has an unsupported type
+func (r *HandlerResponse) Colbert() (ColbertEmbedding, error) {
+	if r.ColbertVecs == nil {
+		return ColbertEmbedding{}, nil
+	}
+	return NewColbertEmbedding(r.ColbertVecs)
+}
+
+// SparseVectorToIndicesValues converts lexical weights to the index and value lists used to store sparse vectors.
+// The indices are sorted, so equal weights always produce equal lists.
+//
+// Parameters:
+//   - weights: the lexical weights
+//
+// Returns:
+//   - indices: the token indices in ascending order
+//   - values: the weights of the indices
+func SparseVectorToIndicesValues(weights map[uint]float32) (indices []uint32, values []float32) {
+	indices = make([]uint32, 0, len(weights))
+	for index := range weights {
+		indices = append(indices, uint32(index))
+	}
+	slices.Sort(indices)
+	values = make([]float32, len(indices))
+	for i, index := range indices {
+		values[i] = weights[uint(index)]
+	}
+	return indices, values
+}
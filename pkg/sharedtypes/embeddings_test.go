@@ -0,0 +1,256 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNewEmbeddingResponseFromHandlerResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response HandlerResponse
+		expected EmbeddingResponse
+	}{
+		{
+			name: "single go types",
+			response: HandlerResponse{
+				InstructionGuid: "guid",
+				EmbeddedData:    []float32{0.1, 0.2},
+				LexicalWeights:  map[uint]float32{7: 0.5},
+				ColbertVecs:     [][]float32{{0.3}, {0.4}},
+			},
+			expected: EmbeddingResponse{
+				InstructionGuid: "guid",
+				Dimensions:      2,
+				Dense:           [][]float32{{0.1, 0.2}},
+				Sparse:          []map[uint]float32{{7: 0.5}},
+				Colbert:         [][][]float32{{{0.3}, {0.4}}},
+			},
+		},
+		{
+			name: "batch go types",
+			response: HandlerResponse{
+				EmbeddedData:   [][]float32{{1, 2, 3}, {4, 5, 6}},
+				LexicalWeights: []map[uint]float32{{1: 1}, {2: 2}},
+				ColbertVecs:    [][][]float32{{{1}}, {{2}}},
+			},
+			expected: EmbeddingResponse{
+				Dimensions: 3,
+				Dense:      [][]float32{{1, 2, 3}, {4, 5, 6}},
+				Sparse:     []map[uint]float32{{1: 1}, {2: 2}},
+				Colbert:    [][][]float32{{{1}}, {{2}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewEmbeddingResponseFromHandlerResponse(tt.response)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %+v, want %+v", got, tt.expected)
+			}
+
+			// the same data must convert identically after a JSON round trip
+			data, err := json.Marshal(tt.response)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var decoded HandlerResponse
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err = NewEmbeddingResponseFromHandlerResponse(decoded)
+			if err != nil {
+				t.Fatalf("unexpected error after JSON round trip: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("after JSON round trip got %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewEmbeddingResponseFromHandlerResponseInvalid(t *testing.T) {
+	_, err := NewEmbeddingResponseFromHandlerResponse(HandlerResponse{EmbeddedData: "not a vector"})
+	if err == nil {
+		t.Error("expected an error for invalid embedded data")
+	}
+}
+
+func TestEmbeddingRequestRoundTrip(t *testing.T) {
+	dimensions := 256
+	request := EmbeddingRequest{
+		InstructionGuid: "guid",
+		ModelId:         "bge-m3",
+		Texts:           []string{"a", "b"},
+		Dimensions:      &dimensions,
+	}
+
+	handlerRequest := request.ToHandlerRequest()
+	if handlerRequest.Adapter != "embeddings" || !reflect.DeepEqual(handlerRequest.ModelIds, []string{"bge-m3"}) || *handlerRequest.EmbeddingOptions.Dimensions != 256 {
+		t.Errorf("unexpected handler request: %+v", handlerRequest)
+	}
+
+	converted, err := NewEmbeddingRequestFromHandlerRequest(handlerRequest)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if converted.ModelId != "bge-m3" || !reflect.DeepEqual(converted.Texts, request.Texts) || *converted.Dimensions != 256 {
+		t.Errorf("unexpected embedding request: %+v", converted)
+	}
+
+	converted, err = NewEmbeddingRequestFromHandlerRequest(HandlerRequest{Data: "single"})
+	if err != nil || !reflect.DeepEqual(converted.Texts, []string{"single"}) {
+		t.Errorf("unexpected result for single string: %+v, %v", converted, err)
+	}
+}
+
+func TestEmbeddingUnionsResolveShape(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		dense   DenseEmbedding
+		sparse  SparseEmbedding
+		colbert ColbertEmbedding
+	}{
+		{
+			name:    "single",
+			json:    `{"embeddedData":[0.5,1],"lexicalWeights":{"7":0.25},"colbertVecs":[[1],[2]]}`,
+			dense:   DenseEmbedding{Shape: EmbeddingShapeSingle, Vectors: [][]float32{{0.5, 1}}},
+			sparse:  SparseEmbedding{Shape: EmbeddingShapeSingle, Vectors: []map[uint]float32{{7: 0.25}}},
+			colbert: ColbertEmbedding{Shape: EmbeddingShapeSingle, Vectors: [][][]float32{{{1}, {2}}}},
+		},
+		{
+			name:    "batch",
+			json:    `{"embeddedData":[[0.5],[1]],"lexicalWeights":[{"1":1},{"2":2}],"colbertVecs":[[[1]],[[2]]]}`,
+			dense:   DenseEmbedding{Shape: EmbeddingShapeBatch, Vectors: [][]float32{{0.5}, {1}}},
+			sparse:  SparseEmbedding{Shape: EmbeddingShapeBatch, Vectors: []map[uint]float32{{1: 1}, {2: 2}}},
+			colbert: ColbertEmbedding{Shape: EmbeddingShapeBatch, Vectors: [][][]float32{{{1}}, {{2}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// accessors on the decoded legacy response
+			var response HandlerResponse
+			if err := json.Unmarshal([]byte(tt.json), &response); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+			dense, err := response.Dense()
+			if err != nil || !reflect.DeepEqual(dense, tt.dense) {
+				t.Errorf("Dense() = %+v, %v, want %+v", dense, err, tt.dense)
+			}
+			sparse, err := response.Sparse()
+			if err != nil || !reflect.DeepEqual(sparse, tt.sparse) {
+				t.Errorf("Sparse() = %+v, %v, want %+v", sparse, err, tt.sparse)
+			}
+			colbert, err := response.Colbert()
+			if err != nil || !reflect.DeepEqual(colbert, tt.colbert) {
+				t.Errorf("Colbert() = %+v, %v, want %+v", colbert, err, tt.colbert)
+			}
+
+			// direct decoding into the unions
+			var typed struct {
+				Dense   DenseEmbedding   `json:"embeddedData"`
+				Sparse  SparseEmbedding  `json:"lexicalWeights"`
+				Colbert ColbertEmbedding `json:"colbertVecs"`
+			}
+			if err := json.Unmarshal([]byte(tt.json), &typed); err != nil {
+				t.Fatalf("unmarshal into unions failed: %v", err)
+			}
+			if !reflect.DeepEqual(typed.Dense, tt.dense) || !reflect.DeepEqual(typed.Sparse, tt.sparse) || !reflect.DeepEqual(typed.Colbert, tt.colbert) {
+				t.Errorf("decoded unions = %+v", typed)
+			}
+
+			// unions assigned to the legacy fields keep the wire shape
+			data, err := json.Marshal(HandlerResponse{EmbeddedData: tt.dense, LexicalWeights: tt.sparse, ColbertVecs: tt.colbert})
+			if err != nil {
+				t.Fatalf("marshal failed: %v", err)
+			}
+			var roundTrip HandlerResponse
+			if err := json.Unmarshal(data, &roundTrip); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+			if dense, _ := roundTrip.Dense(); !reflect.DeepEqual(dense, tt.dense) {
+				t.Errorf("round trip Dense() = %+v, want %+v", dense, tt.dense)
+			}
+			if sparse, _ := roundTrip.Sparse(); !reflect.DeepEqual(sparse, tt.sparse) {
+				t.Errorf("round trip Sparse() = %+v, want %+v", sparse, tt.sparse)
+			}
+			if colbert, _ := roundTrip.Colbert(); !reflect.DeepEqual(colbert, tt.colbert) {
+				t.Errorf("round trip Colbert() = %+v, want %+v", colbert, tt.colbert)
+			}
+		})
+	}
+}
+
+func TestEmbeddingUnionsFromGoTypes(t *testing.T) {
+	response := HandlerResponse{EmbeddedData: []float32{1, 2, 3}, ColbertVecs: [][][]float32{{{1}}}}
+	dense, err := response.Dense()
+	if err != nil {
+		t.Fatalf("Dense() error = %v", err)
+	}
+	vector, err := dense.Single()
+	if err != nil || len(vector) != 3 || dense.Dimensions() != 3 {
+		t.Errorf("Single() = %v, %v, dimensions %d", vector, err, dense.Dimensions())
+	}
+	colbert, err := response.Colbert()
+	if err != nil || colbert.Shape != EmbeddingShapeBatch {
+		t.Errorf("Colbert() = %+v, %v, want batch", colbert, err)
+	}
+	if sparse, err := response.Sparse(); err != nil || sparse.Vectors != nil {
+		t.Errorf("Sparse() of unset field = %+v, %v", sparse, err)
+	}
+
+	batch := DenseEmbedding{Shape: EmbeddingShapeBatch, Vectors: [][]float32{{1}, {2}}}
+	if _, err := batch.Single(); err == nil {
+		t.Error("Single() of batch with two vectors returned no error")
+	}
+	if _, err := json.Marshal(DenseEmbedding{Shape: EmbeddingShapeSingle}); err == nil {
+		t.Error("marshaling single embedding without vector returned no error")
+	}
+	if _, err := (&HandlerResponse{EmbeddedData: "not a vector"}).Dense(); err == nil {
+		t.Error("Dense() of string returned no error")
+	}
+	var invalid DenseEmbedding
+	if err := json.Unmarshal([]byte(`"text"`), &invalid); err == nil {
+		t.Error("unmarshaling string into DenseEmbedding returned no error")
+	}
+}
+
+func TestSparseVectorToIndicesValues(t *testing.T) {
+	indices, values := SparseVectorToIndicesValues(map[uint]float32{42: 0.5, 3: 1, 17: 0.25})
+	if !reflect.DeepEqual(indices, []uint32{3, 17, 42}) || !reflect.DeepEqual(values, []float32{1, 0.25, 0.5}) {
+		t.Errorf("SparseVectorToIndicesValues() = %v, %v", indices, values)
+	}
+	indices, values = SparseVectorToIndicesValues(nil)
+	if len(indices) != 0 || len(values) != 0 {
+		t.Errorf("SparseVectorToIndicesValues(nil) = %v, %v", indices, values)
+	}
+}
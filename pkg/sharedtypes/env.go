@@ -0,0 +1,97 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// EnvExpansionError is returned by ExpandEnv in strict mode for references that cannot be resolved.
+type EnvExpansionError struct {
+	Unset        []string // names of the referenced variables that are not set, in order of appearance
+	Unterminated bool     // true if a "${" has no closing "}"
+}
+
+// Error lists the problems of the expansion.
+func (e *EnvExpansionError) Error() string {
+	var problems []string
+	if len(e.Unset) > 0 {
+		problems = append(problems, fmt.Sprintf("environment variables not set: %s", strings.Join(e.Unset, ", ")))
+	}
+	if e.Unterminated {
+		problems = append(problems, `unterminated "${"`)
+	}
+	return strings.Join(problems, "; ")
+}
+
+// ExpandEnv replaces the ${VAR} references of a value with the values of the environment variables.
+// "\${" produces a literal "${". Unterminated references are kept as they are.
+//
+// Parameters:
+//   - value: the value, e.g. "https://${MCP_HOST}/mcp"
+//   - strict: if true, unset variables and unterminated references are an error; otherwise unset variables expand to ""
+//
+// Returns:
+//   - string: the expanded value
+//   - error: an *EnvExpansionError in strict mode if a reference cannot be resolved
+func ExpandEnv(value string, strict bool) (string, error) {
+	return expandEnvWith(value, strict, os.LookupEnv)
+}
+
+// expandEnvWith implements ExpandEnv with a custom lookup of variables.
+func expandEnvWith(value string, strict bool, lookup func(name string) (string, bool)) (string, error) {
+	var out strings.Builder
+	expansionErr := &EnvExpansionError{}
+	for i := 0; i < len(value); {
+		switch {
+		case strings.HasPrefix(value[i:], `\${`):
+			out.WriteString("${")
+			i += 3
+		case strings.HasPrefix(value[i:], "${"):
+			end := strings.IndexByte(value[i+2:], '}')
+			if end < 0 {
+				expansionErr.Unterminated = true
+				out.WriteString(value[i:])
+				i = len(value)
+				continue
+			}
+			name := value[i+2 : i+2+end]
+			resolved, ok := lookup(name)
+			if !ok && !slices.Contains(expansionErr.Unset, name) {
+				expansionErr.Unset = append(expansionErr.Unset, name)
+			}
+			out.WriteString(resolved)
+			i += 2 + end + 1
+		default:
+			out.WriteByte(value[i])
+			i++
+		}
+	}
+	if strict && (len(expansionErr.Unset) > 0 || expansionErr.Unterminated) {
+		return "", expansionErr
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("AALI_TEST_HOST", "mcp.example.com")
+	t.Setenv("AALI_TEST_EMPTY", "")
+
+	tests := []struct {
+		name      string
+		value     string
+		strict    bool
+		expected  string
+		expectErr bool
+	}{
+		{"plain", "http://localhost:8080", true, "http://localhost:8080", false},
+		{"embedded", "https://${AALI_TEST_HOST}/mcp", true, "https://mcp.example.com/mcp", false},
+		{"set but empty", "a${AALI_TEST_EMPTY}b", true, "ab", false},
+		{"escaped", `price \${AALI_TEST_HOST}`, true, "price ${AALI_TEST_HOST}", false},
+		{"dollar without brace", "$AALI_TEST_HOST and $5", true, "$AALI_TEST_HOST and $5", false},
+		{"unset lenient", "https://${AALI_TEST_UNSET}/mcp", false, "https:///mcp", false},
+		{"unset strict", "https://${AALI_TEST_UNSET}/mcp", true, "", true},
+		{"unterminated lenient", "${AALI_TEST_HOST", false, "${AALI_TEST_HOST", false},
+		{"unterminated strict", "${AALI_TEST_HOST", true, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExpandEnv(tt.value, tt.strict)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ExpandEnv() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ExpandEnv() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExpandEnvErrorListsUnsetVariables(t *testing.T) {
+	_, err := ExpandEnv("${AALI_TEST_A}/${AALI_TEST_B}/${AALI_TEST_A}", true)
+	var expansionErr *EnvExpansionError
+	if !errors.As(err, &expansionErr) {
+		t.Fatalf("error = %v, want *EnvExpansionError", err)
+	}
+	if strings.Join(expansionErr.Unset, ",") != "AALI_TEST_A,AALI_TEST_B" {
+		t.Errorf("Unset = %v, want AALI_TEST_A and AALI_TEST_B once", expansionErr.Unset)
+	}
+}
+
+func TestMCPConfigExpand(t *testing.T) {
+	t.Setenv("AALI_TEST_HOST", "mcp.example.com")
+	t.Setenv("AALI_TEST_TOKEN", "secret")
+	config := MCPConfig{ServerURL: "https://${AALI_TEST_HOST}/mcp", Transport: "http", AuthToken: "Bearer ${AALI_TEST_TOKEN}", Timeout: 30}
+
+	expanded, err := config.Expand(true)
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+	want := MCPConfig{ServerURL: "https://mcp.example.com/mcp", Transport: "http", AuthToken: "Bearer secret", Timeout: 30}
+	if expanded != want {
+		t.Errorf("Expand() = %+v, want %+v", expanded, want)
+	}
+	if config.ServerURL != "https://${AALI_TEST_HOST}/mcp" {
+		t.Error("Expand() modified the original configuration")
+	}
+	if got := config.GetServerURL(); got != want.ServerURL {
+		t.Errorf("GetServerURL() = %q, want %q", got, want.ServerURL)
+	}
+
+	config.ServerURL = "https://${AALI_TEST_UNSET}/mcp"
+	if _, err := config.Expand(true); err == nil || !strings.Contains(err.Error(), "serverURL") || !strings.Contains(err.Error(), "AALI_TEST_UNSET") {
+		t.Errorf("Expand() error = %v, want serverURL and AALI_TEST_UNSET", err)
+	}
+}
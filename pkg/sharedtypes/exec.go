@@ -23,6 +23,9 @@
 package sharedtypes
 
 import (
+	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -35,21 +38,147 @@ type ExecRequest struct {
 	Inputs               map[string]FilledInputOutput `json:"inputs"`               // only for type "flowkit"
 }
 
+// Code types supported in ExecutionInstruction
+const (
+	ExecCodeTypePython = "python"
+	ExecCodeTypeBash   = "bash"
+)
+
 // ExecutionInstruction contain an array of strings that represent the code to be executed in aali-exec
 type ExecutionInstruction struct {
-	CodeType       string   `json:"codeType"` // "python", "bash"
-	Code           []string `json:"code"`
-	VenvExecutable string   `json:"venvExecutable"`
+	CodeType         string                   `json:"codeType"` // "python", "bash"
+	Code             []string                 `json:"code"`
+	VenvExecutable   string                   `json:"venvExecutable"`
+	Files            []ExecutionFile          `json:"files,omitempty"`            // input files written to the working directory before the execution
+	Env              map[string]string        `json:"env,omitempty"`              // additional environment variables
+	WorkingDirectory string                   `json:"workingDirectory,omitempty"` // relative to the exec file store; a new directory is used if empty
+	Limits           *ExecutionResourceLimits `json:"limits,omitempty"`           // resource limits; the aali-exec defaults apply if nil
+	ArtifactPatterns []string                 `json:"artifactPatterns,omitempty"` // glob patterns of the produced files returned as artifacts, e.g. "*.png"
+}
+
+// ExecutionFile represents an input file of an execution
+type ExecutionFile struct {
+	Path    string `json:"path"`    // relative to the working directory
+	Content []byte `json:"content"` // base64 encoded in JSON
+}
+
+// ExecutionResourceLimits represents the resource limits of an execution; zero values mean no limit
+type ExecutionResourceLimits struct {
+	TimeoutSeconds int   `json:"timeoutSeconds,omitempty"`
+	MemoryMB       int   `json:"memoryMB,omitempty"`
+	CPUCores       int   `json:"cpuCores,omitempty"`
+	MaxOutputBytes int64 `json:"maxOutputBytes,omitempty"` // maximum size of stdout and stderr each; the output is truncated beyond
+}
+
+// Validate checks the code type, the input files and the resource limits of the execution instruction.
+//
+// Returns:
+//   - error: an error if the instruction is invalid
+func (ei *ExecutionInstruction) Validate() error {
+	switch ei.CodeType {
+	case ExecCodeTypePython, ExecCodeTypeBash:
+	default:
+		return fmt.Errorf("unsupported code type %q", ei.CodeType)
+	}
+	if len(ei.Code) == 0 {
+		return fmt.Errorf("no code to execute")
+	}
+	for _, file := range append([]string{ei.WorkingDirectory}, filePaths(ei.Files)...) {
+		if !isLocalPath(file) {
+			return fmt.Errorf("path %q must be relative and stay within the working directory", file)
+		}
+	}
+	for _, file := range ei.Files {
+		if file.Path == "" {
+			return fmt.Errorf("input file has no path")
+		}
+	}
+	for key := range ei.Env {
+		if key == "" || strings.ContainsAny(key, "= ") {
+			return fmt.Errorf("invalid environment variable name %q", key)
+		}
+	}
+	if ei.Limits != nil {
+		if ei.Limits.TimeoutSeconds < 0 || ei.Limits.MemoryMB < 0 || ei.Limits.CPUCores < 0 || ei.Limits.MaxOutputBytes < 0 {
+			return fmt.Errorf("resource limits must not be negative")
+		}
+	}
+	return nil
+}
+
+// isLocalPath checks that a path is relative and stays within the working directory on every platform.
+// Backslashes and drive letters are rejected explicitly, since aali-exec may run on Windows while the
+// request is validated on Linux. An empty path refers to the working directory itself.
+func isLocalPath(file string) bool {
+	if file == "" {
+		return true
+	}
+	if strings.Contains(file, "\\") || (len(file) >= 2 && file[1] == ':' && isASCIILetter(file[0])) {
+		return false
+	}
+	return filepath.IsLocal(filepath.FromSlash(file))
+}
+
+// isASCIILetter checks if the byte is an ASCII letter.
+func isASCIILetter(b byte) bool {
+	return ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+// filePaths returns the paths of the files.
+func filePaths(files []ExecutionFile) []string {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	return paths
 }
 
 // ExecResponse represents the response that aali-exec sends back
 type ExecResponse struct {
-	Type             string                       `json:"type"` // "status", "flowkit", "file", "error"
+	Type             string                       `json:"type"` // "status", "flowkit", "file", "output", "result", "error"
 	InstructionGuid  string                       `json:"instructionGuid"`
 	Error            *ErrorResponse               `json:"error,omitempty"`
 	ExecutionDetails *ExecutionDetails            `json:"executionDetails,omitempty"`
 	FileDetails      *FileDetails                 `json:"fileDetails,omitempty"`
-	Outputs          map[string]FilledInputOutput `json:"outputs"` // only for type "flowkit"
+	Outputs          map[string]FilledInputOutput `json:"outputs"`                   // only for type "flowkit"
+	OutputChunk      *ExecutionOutputChunk        `json:"outputChunk,omitempty"`     // only for type "output"
+	ExecutionResult  *ExecutionResult             `json:"executionResult,omitempty"` // only for type "result"
+}
+
+// Output streams of an execution
+const (
+	ExecStreamStdout = "stdout"
+	ExecStreamStderr = "stderr"
+)
+
+// ExecutionOutputChunk represents a part of the output of a running execution
+type ExecutionOutputChunk struct {
+	Stream   string `json:"stream"`   // "stdout", "stderr"
+	Data     string `json:"data"`     // output since the previous chunk of the stream
+	Sequence int64  `json:"sequence"` // increasing over all chunks of the execution, to restore the order of stdout and stderr
+}
+
+// ExecutionResult represents the result of a finished execution
+type ExecutionResult struct {
+	ExitCode        int                 `json:"exitCode"`
+	Stdout          string              `json:"stdout"`
+	Stderr          string              `json:"stderr"`
+	OutputTruncated bool                `json:"outputTruncated,omitempty"` // true if the output exceeded MaxOutputBytes
+	TimedOut        bool                `json:"timedOut,omitempty"`
+	Duration        time.Duration       `json:"duration"`
+	Artifacts       []ExecutionArtifact `json:"artifacts,omitempty"`
+}
+
+// Succeeded returns true if the execution finished in time with exit code 0.
+func (er *ExecutionResult) Succeeded() bool {
+	return er.ExitCode == 0 && !er.TimedOut
+}
+
+// ExecutionArtifact represents a file produced by an execution; the content is sent as FileDetails chunks
+type ExecutionArtifact struct {
+	Path     string `json:"path"` // relative to the working directory
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType,omitempty"`
 }
 
 // ExecutionDetails represents the details of the execution
@@ -0,0 +1,63 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import "testing"
+
+func TestExecutionInstructionValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		instruction ExecutionInstruction
+		expectErr   bool
+	}{
+		{"minimal", ExecutionInstruction{CodeType: ExecCodeTypePython, Code: []string{"print(1)"}}, false},
+		{"complete", ExecutionInstruction{
+			CodeType:         ExecCodeTypeBash,
+			Code:             []string{"cat data/input.csv"},
+			Files:            []ExecutionFile{{Path: "data/input.csv", Content: []byte("a,b")}},
+			Env:              map[string]string{"ANSYS_VERSION": "252"},
+			WorkingDirectory: "run-1",
+			Limits:           &ExecutionResourceLimits{TimeoutSeconds: 60, MemoryMB: 512},
+		}, false},
+		{"unknown code type", ExecutionInstruction{CodeType: "ruby", Code: []string{"puts 1"}}, true},
+		{"no code", ExecutionInstruction{CodeType: ExecCodeTypePython}, true},
+		{"absolute file path", ExecutionInstruction{CodeType: ExecCodeTypePython, Code: []string{"x"}, Files: []ExecutionFile{{Path: "/etc/passwd"}}}, true},
+		{"escaping file path", ExecutionInstruction{CodeType: ExecCodeTypePython, Code: []string{"x"}, Files: []ExecutionFile{{Path: "data/../../x"}}}, true},
+		{"backslash file path", ExecutionInstruction{CodeType: ExecCodeTypePython, Code: []string{"x"}, Files: []ExecutionFile{{Path: `..\secrets.txt`}}}, true},
+		{"drive letter file path", ExecutionInstruction{CodeType: ExecCodeTypePython, Code: []string{"x"}, Files: []ExecutionFile{{Path: "C:/Windows/win.ini"}}}, true},
+		{"relative drive file path", ExecutionInstruction{CodeType: ExecCodeTypePython, Code: []string{"x"}, Files: []ExecutionFile{{Path: "d:data.csv"}}}, true},
+		{"nested relative file path", ExecutionInstruction{CodeType: ExecCodeTypePython, Code: []string{"x"}, Files: []ExecutionFile{{Path: "data/./in/../input.csv"}}}, false},
+		{"escaping working directory", ExecutionInstruction{CodeType: ExecCodeTypePython, Code: []string{"x"}, WorkingDirectory: ".."}, true},
+		{"invalid env name", ExecutionInstruction{CodeType: ExecCodeTypePython, Code: []string{"x"}, Env: map[string]string{"A=B": "c"}}, true},
+		{"negative limit", ExecutionInstruction{CodeType: ExecCodeTypePython, Code: []string{"x"}, Limits: &ExecutionResourceLimits{MemoryMB: -1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.instruction.Validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
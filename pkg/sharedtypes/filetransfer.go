@@ -0,0 +1,228 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// DefaultFileTransferChunkSize is the chunk size used by SplitFile if none is given.
+const DefaultFileTransferChunkSize = 1024 * 1024
+
+// MaxFileTransferSize is the maximum size of a file transferred in chunks.
+const MaxFileTransferSize = 4 * 1024 * 1024 * 1024
+
+// FileTransfer represents a chunk of a file transferred between services, e.g. between aali-exec and the agent.
+// Every chunk carries the metadata of the whole file, so the receiver can start with any chunk and resume
+// an interrupted transfer by requesting the missing chunks only.
+type FileTransfer struct {
+	FileId      string `json:"fileId"`
+	FileName    string `json:"fileName"`
+	FileSize    int64  `json:"fileSize"`
+	MimeType    string `json:"mimeType,omitempty"`
+	Sha256      string `json:"sha256"` // hex encoded hash of the whole file
+	ChunkIndex  int    `json:"chunkIndex"`
+	ChunkCount  int    `json:"chunkCount"`
+	ChunkSha256 string `json:"chunkSha256"` // hex encoded hash of the chunk data
+	Data        []byte `json:"data"`        // base64 encoded in JSON
+}
+
+// SplitFile splits the content of a file into chunks.
+//
+// Parameters:
+//   - fileId: the ID of the transfer
+//   - fileName: the name of the file
+//   - mimeType: the mime type of the file
+//   - content: the content of the file
+//   - chunkSize: the maximum size of a chunk; DefaultFileTransferChunkSize if 0
+//
+// Returns:
+//   - []FileTransfer: the chunks; a single empty chunk for an empty file
+//   - error: an error if the chunk size is negative or the file exceeds MaxFileTransferSize
+func SplitFile(fileId string, fileName string, mimeType string, content []byte, chunkSize int) ([]FileTransfer, error) {
+	if chunkSize == 0 {
+		chunkSize = DefaultFileTransferChunkSize
+	}
+	if chunkSize < 0 {
+		return nil, fmt.Errorf("chunk size must be positive, got %d", chunkSize)
+	}
+	if int64(len(content)) > MaxFileTransferSize {
+		return nil, fmt.Errorf("file %q exceeds the maximum transfer size of %d bytes", fileName, int64(MaxFileTransferSize))
+	}
+
+	chunkCount := max((len(content)+chunkSize-1)/chunkSize, 1)
+	fileHash := sha256.Sum256(content)
+	chunks := make([]FileTransfer, 0, chunkCount)
+	for i := 0; i < chunkCount; i++ {
+		data := content[min(i*chunkSize, len(content)):min((i+1)*chunkSize, len(content))]
+		chunkHash := sha256.Sum256(data)
+		chunks = append(chunks, FileTransfer{
+			FileId:      fileId,
+			FileName:    fileName,
+			FileSize:    int64(len(content)),
+			MimeType:    mimeType,
+			Sha256:      hex.EncodeToString(fileHash[:]),
+			ChunkIndex:  i,
+			ChunkCount:  chunkCount,
+			ChunkSha256: hex.EncodeToString(chunkHash[:]),
+			Data:        data,
+		})
+	}
+	return chunks, nil
+}
+
+// Verify checks the file size, the index and the checksum of the chunk.
+//
+// Returns:
+//   - error: an error if the chunk is invalid or corrupted
+func (ft *FileTransfer) Verify() error {
+	if ft.FileSize < 0 || ft.FileSize > MaxFileTransferSize {
+		return fmt.Errorf("invalid size %d of file %q, must be between 0 and %d bytes", ft.FileSize, ft.FileName, int64(MaxFileTransferSize))
+	}
+	// every chunk except the single chunk of an empty file carries at least one byte
+	if ft.ChunkCount < 1 || int64(ft.ChunkCount) > max(ft.FileSize, 1) || ft.ChunkIndex < 0 || ft.ChunkIndex >= ft.ChunkCount {
+		return fmt.Errorf("invalid chunk %d of %d for file %q", ft.ChunkIndex, ft.ChunkCount, ft.FileName)
+	}
+	if int64(len(ft.Data)) > ft.FileSize {
+		return fmt.Errorf("chunk %d of file %q is larger than the file", ft.ChunkIndex, ft.FileName)
+	}
+	hash := sha256.Sum256(ft.Data)
+	if hex.EncodeToString(hash[:]) != ft.ChunkSha256 {
+		return fmt.Errorf("checksum mismatch in chunk %d of file %q", ft.ChunkIndex, ft.FileName)
+	}
+	return nil
+}
+
+// FileAssembler reassembles a file from its chunks, which can arrive in any order and more than once.
+type FileAssembler struct {
+	metadata FileTransfer
+	chunks   map[int][]byte
+	received int64 // total size of the added chunks
+}
+
+// NewFileAssembler creates an assembler for the file of the given chunk; the chunk itself is not added.
+//
+// Parameters:
+//   - chunk: any chunk of the file
+//
+// Returns:
+//   - *FileAssembler: the assembler
+func NewFileAssembler(chunk FileTransfer) *FileAssembler {
+	metadata := chunk
+	metadata.Data = nil
+	return &FileAssembler{metadata: metadata, chunks: map[int][]byte{}}
+}
+
+// Add verifies a chunk and adds it to the file; chunks that were already added are ignored.
+//
+// Parameters:
+//   - chunk: the chunk
+//
+// Returns:
+//   - error: an error if the chunk is corrupted, belongs to another file or exceeds the file size
+func (fa *FileAssembler) Add(chunk FileTransfer) error {
+	if chunk.FileId != fa.metadata.FileId || chunk.Sha256 != fa.metadata.Sha256 || chunk.ChunkCount != fa.metadata.ChunkCount || chunk.FileSize != fa.metadata.FileSize {
+		return fmt.Errorf("chunk %d does not belong to file %q", chunk.ChunkIndex, fa.metadata.FileId)
+	}
+	if err := chunk.Verify(); err != nil {
+		return err
+	}
+	if _, ok := fa.chunks[chunk.ChunkIndex]; ok {
+		return nil
+	}
+	if fa.received+int64(len(chunk.Data)) > fa.metadata.FileSize {
+		return fmt.Errorf("chunks of file %q exceed its size of %d bytes", fa.metadata.FileName, fa.metadata.FileSize)
+	}
+	fa.chunks[chunk.ChunkIndex] = chunk.Data
+	fa.received += int64(len(chunk.Data))
+	return nil
+}
+
+// MissingChunks returns the indices of the chunks not received yet, to resume an interrupted transfer.
+//
+// Returns:
+//   - []int: the missing chunk indices in ascending order
+func (fa *FileAssembler) MissingChunks() []int {
+	missing := []int{}
+	for i := 0; i < fa.metadata.ChunkCount; i++ {
+		if _, ok := fa.chunks[i]; !ok {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
+// IsComplete returns true if all chunks have been received.
+func (fa *FileAssembler) IsComplete() bool {
+	return len(fa.chunks) == fa.metadata.ChunkCount
+}
+
+// Bytes returns the content of the file after verifying its size and checksum.
+//
+// Returns:
+//   - []byte: the content of the file
+//   - error: an error if chunks are missing or the file is corrupted
+func (fa *FileAssembler) Bytes() ([]byte, error) {
+	if missing := fa.MissingChunks(); len(missing) > 0 {
+		return nil, fmt.Errorf("file %q is missing %d of %d chunks", fa.metadata.FileName, len(missing), fa.metadata.ChunkCount)
+	}
+	// the size is checked against the received data, so the buffer never grows beyond what was actually sent
+	if fa.received != fa.metadata.FileSize {
+		return nil, fmt.Errorf("size mismatch for file %q: expected %d bytes, got %d", fa.metadata.FileName, fa.metadata.FileSize, fa.received)
+	}
+	var buffer bytes.Buffer
+	buffer.Grow(int(fa.received))
+	for i := 0; i < fa.metadata.ChunkCount; i++ {
+		buffer.Write(fa.chunks[i])
+	}
+	content := buffer.Bytes()
+	hash := sha256.Sum256(content)
+	if hex.EncodeToString(hash[:]) != fa.metadata.Sha256 {
+		return nil, fmt.Errorf("checksum mismatch for file %q", fa.metadata.FileName)
+	}
+	return content, nil
+}
+
+// ReassembleFile reassembles a file from all its chunks.
+//
+// Parameters:
+//   - chunks: the chunks of the file in any order
+//
+// Returns:
+//   - []byte: the content of the file
+//   - error: an error if chunks are missing, corrupted or belong to different files
+func ReassembleFile(chunks []FileTransfer) ([]byte, error) {
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("no chunks to reassemble")
+	}
+	assembler := NewFileAssembler(chunks[0])
+	for _, chunk := range chunks {
+		if err := assembler.Add(chunk); err != nil {
+			return nil, err
+		}
+	}
+	return assembler.Bytes()
+}
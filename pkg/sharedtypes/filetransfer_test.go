@@ -0,0 +1,130 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSplitAndReassembleFile(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 25)
+
+	chunks, err := SplitFile("transfer-1", "result.csv", "text/csv", content, 100)
+	if err != nil {
+		t.Fatalf("SplitFile() error = %v", err)
+	}
+	if len(chunks) != 3 || len(chunks[2].Data) != 50 || chunks[0].ChunkCount != 3 {
+		t.Fatalf("unexpected chunks: %d chunks, last chunk has %d bytes", len(chunks), len(chunks[len(chunks)-1].Data))
+	}
+
+	// chunks arrive out of order and duplicated
+	reassembled, err := ReassembleFile([]FileTransfer{chunks[2], chunks[0], chunks[2], chunks[1]})
+	if err != nil {
+		t.Fatalf("ReassembleFile() error = %v", err)
+	}
+	if !bytes.Equal(reassembled, content) {
+		t.Error("reassembled content differs from the original")
+	}
+
+	empty, err := SplitFile("transfer-2", "empty.txt", "", nil, 0)
+	if err != nil || len(empty) != 1 {
+		t.Fatalf("SplitFile() of an empty file = %v, %v", empty, err)
+	}
+	if data, err := ReassembleFile(empty); err != nil || len(data) != 0 {
+		t.Errorf("ReassembleFile() of an empty file = %v, %v", data, err)
+	}
+}
+
+func TestFileAssemblerResumeAndIntegrity(t *testing.T) {
+	chunks, _ := SplitFile("transfer-1", "mesh.bin", "application/octet-stream", []byte("abcdefghij"), 3)
+
+	assembler := NewFileAssembler(chunks[1])
+	if err := assembler.Add(chunks[1]); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if missing := assembler.MissingChunks(); len(missing) != 3 || missing[0] != 0 || missing[2] != 3 {
+		t.Errorf("MissingChunks() = %v, expected [0 2 3]", missing)
+	}
+	if _, err := assembler.Bytes(); err == nil {
+		t.Error("Bytes() expected an error for an incomplete file")
+	}
+
+	corrupted := chunks[0]
+	corrupted.Data = []byte("xyz")
+	if err := assembler.Add(corrupted); err == nil {
+		t.Error("Add() expected an error for a corrupted chunk")
+	}
+
+	other, _ := SplitFile("transfer-2", "other.bin", "", []byte("abcdefghij"), 3)
+	if err := assembler.Add(other[0]); err == nil {
+		t.Error("Add() expected an error for a chunk of another file")
+	}
+
+	for _, chunk := range chunks {
+		if err := assembler.Add(chunk); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if !assembler.IsComplete() {
+		t.Error("IsComplete() should be true after adding all chunks")
+	}
+	if content, err := assembler.Bytes(); err != nil || string(content) != "abcdefghij" {
+		t.Errorf("Bytes() = %q, %v", content, err)
+	}
+}
+
+func TestFileTransferSizeLimits(t *testing.T) {
+	chunks, _ := SplitFile("transfer-1", "mesh.bin", "", []byte("abcdef"), 3)
+
+	tests := []struct {
+		name   string
+		modify func(chunk *FileTransfer)
+	}{
+		{"negative file size", func(chunk *FileTransfer) { chunk.FileSize = -1 }},
+		{"file size above maximum", func(chunk *FileTransfer) { chunk.FileSize = MaxFileTransferSize + 1 }},
+		{"more chunks than bytes", func(chunk *FileTransfer) { chunk.ChunkCount = 1 << 30 }},
+		{"chunk larger than file", func(chunk *FileTransfer) { chunk.FileSize = 2 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunk := chunks[0]
+			tt.modify(&chunk)
+			if err := chunk.Verify(); err == nil {
+				t.Error("Verify() expected an error")
+			}
+			if _, err := ReassembleFile([]FileTransfer{chunk}); err == nil {
+				t.Error("ReassembleFile() expected an error")
+			}
+		})
+	}
+
+	// chunks with consistent metadata but more data than the announced size
+	oversized, _ := SplitFile("transfer-1", "mesh.bin", "", []byte("abcdefgh"), 4)
+	for i := range oversized {
+		oversized[i].FileSize = 6
+	}
+	if _, err := ReassembleFile(oversized); err == nil {
+		t.Error("ReassembleFile() expected an error for chunks exceeding the file size")
+	}
+}
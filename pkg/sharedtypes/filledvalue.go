@@ -0,0 +1,169 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"reflect"
+	"sync"
+)
+
+// filledValueCache holds a value together with its serialized forms. It is shared by all copies of a
+// FilledInputOutput, so a value decoded or serialized through one copy is reused by the others.
+type filledValueCache struct {
+	mu         sync.Mutex
+	value      interface{}       // the value the serialized forms belong to
+	decoded    bool              // false while the value has not been decoded from its serialized form
+	goType     string            // the Go type of the received serialized form, used to decode it
+	serialized map[string]string // serialized forms of value by Go type
+}
+
+// NewSerializedFilledInputOutput creates a filled input/output from its serialized value without decoding it.
+// Value stays nil until Decode is called, typically through typeconverters.FilledValue.
+//
+// Parameters:
+//   - name: the name of the input/output
+//   - goType: the Go type of the value
+//   - serialized: the serialized value
+//
+// Returns:
+//   - FilledInputOutput: the filled input/output
+func NewSerializedFilledInputOutput(name string, goType string, serialized string) FilledInputOutput {
+	return FilledInputOutput{
+		Name:   name,
+		GoType: goType,
+		cache:  &filledValueCache{goType: goType, serialized: map[string]string{goType: serialized}},
+	}
+}
+
+// IsDecoded checks if the value is available without decoding, i.e. the input/output was not created with
+// NewSerializedFilledInputOutput or its value has been decoded since.
+//
+// Returns:
+//   - bool: true if the value does not need to be decoded
+func (f *FilledInputOutput) IsDecoded() bool {
+	if f.cache == nil || f.Value != nil {
+		return true
+	}
+	f.cache.mu.Lock()
+	defer f.cache.mu.Unlock()
+	return f.cache.decoded
+}
+
+// Decode returns the value, decoding it from its serialized form on first use. The decoded value is stored in
+// Value and shared with all copies of the input/output.
+//
+// Parameters:
+//   - decode: the function decoding a serialized value of a Go type
+//
+// Returns:
+//   - interface{}: the value
+//   - error: the error of decode
+func (f *FilledInputOutput) Decode(decode func(serialized string, goType string) (interface{}, error)) (interface{}, error) {
+	if f.cache == nil || f.Value != nil {
+		return f.Value, nil
+	}
+	f.cache.mu.Lock()
+	defer f.cache.mu.Unlock()
+	if !f.cache.decoded {
+		value, err := decode(f.cache.serialized[f.cache.goType], f.cache.goType)
+		if err != nil {
+			return nil, err
+		}
+		f.cache.value = value
+		f.cache.decoded = true
+	}
+	f.Value = f.cache.value
+	return f.Value, nil
+}
+
+// Serialize returns the serialized form of the value for a Go type. Serialized forms are cached per Go type and
+// reused as long as Value holds the same value; values that have not been decoded yet are returned as received
+// if the Go type matches.
+//
+// Parameters:
+//   - goType: the Go type to serialize as
+//   - decode: the function decoding a serialized value of a Go type, used if the value must be decoded first
+//   - encode: the function serializing a value as a Go type
+//
+// Returns:
+//   - string: the serialized value
+//   - error: the error of decode or encode
+func (f *FilledInputOutput) Serialize(goType string, decode func(serialized string, goType string) (interface{}, error), encode func(value interface{}, goType string) (string, error)) (string, error) {
+	if f.cache != nil {
+		f.cache.mu.Lock()
+		pending := !f.cache.decoded && f.Value == nil
+		if pending || (f.cache.decoded && sameValue(f.Value, f.cache.value)) {
+			if serialized, ok := f.cache.serialized[goType]; ok {
+				f.cache.mu.Unlock()
+				return serialized, nil
+			}
+		}
+		f.cache.mu.Unlock()
+	}
+
+	value, err := f.Decode(decode)
+	if err != nil {
+		return "", err
+	}
+	serialized, err := encode(value, goType)
+	if err != nil {
+		return "", err
+	}
+
+	if f.cache != nil {
+		f.cache.mu.Lock()
+		shared := f.cache.decoded && sameValue(value, f.cache.value)
+		if shared {
+			f.cache.serialized[goType] = serialized
+		}
+		f.cache.mu.Unlock()
+		if shared {
+			return serialized, nil
+		}
+	}
+	// the value is not the shared one (or nothing was cached yet): use a cache of this copy
+	f.cache = &filledValueCache{value: value, decoded: true, goType: goType, serialized: map[string]string{goType: serialized}}
+	return serialized, nil
+}
+
+// sameValue checks if two values are the same without comparing large contents:
+// slices must share their backing array and length, maps, pointers and channels must be identical.
+func sameValue(a interface{}, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Type() != vb.Type() {
+		return false
+	}
+	switch va.Kind() {
+	case reflect.Slice:
+		return va.Len() == vb.Len() && (va.Len() == 0 || va.Pointer() == vb.Pointer())
+	case reflect.Map, reflect.Pointer, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return va.Pointer() == vb.Pointer()
+	}
+	if va.Type().Comparable() {
+		return a == b
+	}
+	return reflect.DeepEqual(a, b)
+}
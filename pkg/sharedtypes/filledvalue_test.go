@@ -0,0 +1,178 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// countingCodec counts the conversions of a FilledInputOutput.
+type countingCodec struct {
+	mu      sync.Mutex
+	decodes int
+	encodes int
+}
+
+func (c *countingCodec) decode(serialized string, goType string) (interface{}, error) {
+	c.mu.Lock()
+	c.decodes++
+	c.mu.Unlock()
+	if goType != "[]int" {
+		return nil, errors.New("unsupported type")
+	}
+	var value []int
+	err := json.Unmarshal([]byte(serialized), &value)
+	return value, err
+}
+
+func (c *countingCodec) encode(value interface{}, goType string) (string, error) {
+	c.mu.Lock()
+	c.encodes++
+	c.mu.Unlock()
+	if goType == "string" {
+		return strconv.Quote("x"), nil
+	}
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+func TestFilledInputOutputLazyDecode(t *testing.T) {
+	codec := &countingCodec{}
+	output := NewSerializedFilledInputOutput("numbers", "[]int", "[1,2,3]")
+	if output.IsDecoded() || output.Value != nil {
+		t.Fatal("serialized value should not be decoded")
+	}
+
+	// passing the output on to an input of the same type needs no conversion
+	input := output
+	serialized, err := input.Serialize("[]int", codec.decode, codec.encode)
+	if err != nil || serialized != "[1,2,3]" || codec.decodes != 0 || codec.encodes != 0 {
+		t.Fatalf("Serialize() = %q, %v with %d decodes and %d encodes", serialized, err, codec.decodes, codec.encodes)
+	}
+
+	// decoding through one copy is shared with the others
+	value, err := input.Decode(codec.decode)
+	if err != nil || len(value.([]int)) != 3 || codec.decodes != 1 {
+		t.Fatalf("Decode() = %v, %v with %d decodes", value, err, codec.decodes)
+	}
+	if !output.IsDecoded() {
+		t.Error("IsDecoded() of copy = false after decode")
+	}
+	if value, _ := output.Decode(codec.decode); len(value.([]int)) != 3 || codec.decodes != 1 {
+		t.Errorf("Decode() of copy = %v with %d decodes, want shared value", value, codec.decodes)
+	}
+
+	// the received serialized form is still valid after decoding
+	if serialized, _ := output.Serialize("[]int", codec.decode, codec.encode); serialized != "[1,2,3]" || codec.encodes != 0 {
+		t.Errorf("Serialize() after decode = %q with %d encodes", serialized, codec.encodes)
+	}
+
+	// another type is converted once and cached for all copies
+	for i := 0; i < 2; i++ {
+		if _, err := output.Serialize("string", codec.decode, codec.encode); err != nil {
+			t.Fatalf("Serialize(string) error = %v", err)
+		}
+	}
+	if _, err := input.Serialize("string", codec.decode, codec.encode); err != nil || codec.encodes != 1 {
+		t.Errorf("Serialize(string) encoded %d times, want 1", codec.encodes)
+	}
+}
+
+func TestFilledInputOutputCacheInvalidation(t *testing.T) {
+	codec := &countingCodec{}
+	original := FilledInputOutput{Name: "numbers", GoType: "[]int", Value: []int{1, 2}}
+	if !original.IsDecoded() {
+		t.Error("IsDecoded() of plain value = false")
+	}
+	if serialized, _ := original.Serialize("[]int", codec.decode, codec.encode); serialized != "[1,2]" || codec.encodes != 1 {
+		t.Fatalf("Serialize() = %q with %d encodes", serialized, codec.encodes)
+	}
+	original.Serialize("[]int", codec.decode, codec.encode)
+	if codec.encodes != 1 {
+		t.Errorf("second Serialize() encoded again")
+	}
+
+	// a copy with a new value gets its own cache and does not affect the original
+	changed := original
+	changed.Value = []int{7}
+	if serialized, _ := changed.Serialize("[]int", codec.decode, codec.encode); serialized != "[7]" {
+		t.Errorf("Serialize() of changed copy = %q, want [7]", serialized)
+	}
+	if serialized, _ := original.Serialize("[]int", codec.decode, codec.encode); serialized != "[1,2]" || codec.encodes != 2 {
+		t.Errorf("Serialize() of original = %q with %d encodes, want cached [1,2]", serialized, codec.encodes)
+	}
+
+	// a lazy copy is not affected by a copy that assigned a new value
+	lazy := NewSerializedFilledInputOutput("numbers", "[]int", "[4]")
+	other := lazy
+	other.Value = []int{5}
+	other.Serialize("[]int", codec.decode, codec.encode)
+	if value, _ := lazy.Decode(codec.decode); len(value.([]int)) != 1 || value.([]int)[0] != 4 {
+		t.Errorf("Decode() of lazy copy = %v, want [4]", value)
+	}
+}
+
+func TestFilledInputOutputDecodeError(t *testing.T) {
+	codec := &countingCodec{}
+	lazy := NewSerializedFilledInputOutput("x", "unknown", "1")
+	if _, err := lazy.Decode(codec.decode); err == nil {
+		t.Error("Decode() with unsupported type succeeded")
+	}
+	if _, err := lazy.Serialize("string", codec.decode, codec.encode); err == nil {
+		t.Error("Serialize() of undecodable value as other type succeeded")
+	}
+	if lazy.IsDecoded() {
+		t.Error("IsDecoded() = true after failed decode")
+	}
+}
+
+func TestFilledInputOutputJSON(t *testing.T) {
+	filled := NewSerializedFilledInputOutput("numbers", "[]int", "[1]")
+	filled.Decode((&countingCodec{}).decode)
+	data, err := json.Marshal(filled)
+	if err != nil || string(data) != `{"name":"numbers","go_type":"[]int","value":[1]}` {
+		t.Errorf("json.Marshal() = %s, %v", data, err)
+	}
+}
+
+func TestFilledInputOutputConcurrentDecode(t *testing.T) {
+	codec := &countingCodec{}
+	lazy := NewSerializedFilledInputOutput("numbers", "[]int", "[1,2,3]")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(copy FilledInputOutput) {
+			defer wg.Done()
+			copy.Decode(codec.decode)
+			copy.Serialize("string", codec.decode, codec.encode)
+		}(lazy)
+	}
+	wg.Wait()
+	if codec.decodes != 1 {
+		t.Errorf("decoded %d times, want 1", codec.decodes)
+	}
+}
@@ -22,6 +22,17 @@
 
 package sharedtypes
 
+// FlowKit capabilities negotiated between the agent and the FlowKit server.
+// The client sends its capabilities in the FlowkitCapabilitiesMetadataKey gRPC metadata of each call,
+// the server answers with the capabilities it supports in the response header of the same key.
+const (
+	FlowkitCapabilitiesMetadataKey   = "aali-flowkit-capabilities"
+	FlowkitCapabilityBinaryValues    = "binary-values"    // []byte values are exchanged in the value_bytes field instead of base64 JSON
+	FlowkitCapabilityCompressionGzip = "compression-gzip" // value_bytes can be gzip compressed, with content_encoding "gzip"
+	FlowkitCapabilityCompressionZstd = "compression-zstd" // value_bytes can be zstd compressed, with content_encoding "zstd"
+	FlowkitCapabilityDryRun          = "dry-run"          // RunFunction validates the inputs without running the function if dry_run is set
+)
+
 // FunctionDefinition is a struct that contains the id, name, description, package, inputs and outputs of a function
 type FunctionDefinition struct {
 	Name             string           `json:"name" yaml:"name"`
@@ -35,6 +46,31 @@ type FunctionDefinition struct {
 	Inputs           []FunctionInput  `json:"inputs" yaml:"inputs"`
 	Outputs          []FunctionOutput `json:"outputs" yaml:"outputs"`
 	DeprecatedParams []string         `json:"deprecated_params" yaml:"deprecated_params"` // list of deprecated parameter names
+
+	// Execution hints for the agent scheduler
+	Examples       []string `json:"examples,omitempty" yaml:"examples,omitempty"`               // usage examples of the function
+	TimeoutSeconds int      `json:"timeout_seconds,omitempty" yaml:"timeout_seconds,omitempty"` // maximum execution time in seconds; 0 means no timeout
+	Idempotent     bool     `json:"idempotent,omitempty" yaml:"idempotent,omitempty"`           // true if repeated calls with the same inputs are safe
+	MaxConcurrency int      `json:"max_concurrency,omitempty" yaml:"max_concurrency,omitempty"` // maximum number of concurrent executions; 0 means unlimited
+
+	// Sandboxing of the execution on the FlowKit server
+	ResourceLimits *ResourceLimits `json:"resource_limits,omitempty" yaml:"resource_limits,omitempty"` // not sandboxed if nil
+}
+
+// MissingRequiredInputs returns the names of the required inputs of the function that are not provided
+//
+// Parameters:
+//   - inputs: the provided inputs by name
+//
+// Returns:
+//   - missing: the names of the missing required inputs in definition order; empty if all are provided
+func (fd *FunctionDefinition) MissingRequiredInputs(inputs map[string]FilledInputOutput) (missing []string) {
+	for _, input := range fd.Inputs {
+		if _, ok := inputs[input.Name]; input.Required && !ok {
+			missing = append(missing, input.Name)
+		}
+	}
+	return missing
 }
 
 // FlowKitPythonFunction is a struct that contains the name, path, description, inputs, outputs and definitions of a FlowKit-Python function
@@ -47,6 +83,15 @@ type FlowKitPythonFunction struct {
 	Inputs      []FunctionInput  `json:"inputs"`
 	Outputs     []FunctionOutput `json:"outputs"`
 	Definitions interface{}      `json:"definitions"`
+
+	// Execution hints for the agent scheduler
+	Examples       []string `json:"examples,omitempty"`
+	TimeoutSeconds int      `json:"timeout_seconds,omitempty"`
+	Idempotent     bool     `json:"idempotent,omitempty"`
+	MaxConcurrency int      `json:"max_concurrency,omitempty"`
+
+	// Sandboxing of the execution on the FlowKit server
+	ResourceLimits *ResourceLimits `json:"resource_limits,omitempty"`
 }
 
 // FunctionDefinitionShort is equivalent to FunctionDefinition but without the API key (used for aali-agent rest API)
@@ -61,14 +106,25 @@ type FunctionDefinitionShort struct {
 	Inputs           []FunctionInput  `json:"inputs" yaml:"inputs"`
 	Outputs          []FunctionOutput `json:"outputs" yaml:"outputs"`
 	DeprecatedParams []string         `json:"deprecated_params" yaml:"deprecated_params"` // list of deprecated parameter names
+
+	// Execution hints for the agent scheduler
+	Examples       []string `json:"examples,omitempty" yaml:"examples,omitempty"`               // usage examples of the function
+	TimeoutSeconds int      `json:"timeout_seconds,omitempty" yaml:"timeout_seconds,omitempty"` // maximum execution time in seconds; 0 means no timeout
+	Idempotent     bool     `json:"idempotent,omitempty" yaml:"idempotent,omitempty"`           // true if repeated calls with the same inputs are safe
+	MaxConcurrency int      `json:"max_concurrency,omitempty" yaml:"max_concurrency,omitempty"` // maximum number of concurrent executions; 0 means unlimited
+
+	// Sandboxing of the execution on the FlowKit server
+	ResourceLimits *ResourceLimits `json:"resource_limits,omitempty" yaml:"resource_limits,omitempty"` // not sandboxed if nil
 }
 
-// FunctionInput is a struct that contains the name, type, go type and options of a function input
+// FunctionInput is a struct that contains the name, type, go type, options and default value of a function input
 type FunctionInput struct {
-	Name    string   `json:"name" yaml:"name"`
-	Type    string   `json:"type" yaml:"type"` // string, number, boolean, json
-	GoType  string   `json:"go_type" yaml:"go_type"`
-	Options []string `json:"options" yaml:"options"` // only applicable if not empty
+	Name         string   `json:"name" yaml:"name"`
+	Type         string   `json:"type" yaml:"type"` // string, number, boolean, json
+	GoType       string   `json:"go_type" yaml:"go_type"`
+	Options      []string `json:"options" yaml:"options"`                                 // only applicable if not empty
+	DefaultValue string   `json:"default_value,omitempty" yaml:"default_value,omitempty"` // string representation of the default value; only applicable if not empty
+	Required     bool     `json:"required,omitempty" yaml:"required,omitempty"`           // calls omitting the input fail instead of sending a null value
 }
 
 // FunctionOutput is a struct that contains the name, type and go type of a function output
@@ -79,8 +135,14 @@ type FunctionOutput struct {
 }
 
 // FilledInputOutput is a struct that contains the name, go type and value of a filled input/output
+//
+// The serialized form of the value is cached (see typeconverters.FilledValueToString), so values passed between
+// functions with matching types are not converted again. Values are treated as immutable: to change a value,
+// assign a new one to Value instead of modifying it in place.
 type FilledInputOutput struct {
 	Name   string      `json:"name" yaml:"name"`
 	GoType string      `json:"go_type" yaml:"go_type"`
 	Value  interface{} `json:"value" yaml:"value"`
+
+	cache *filledValueCache // serialized form of Value, shared by copies
 }
@@ -0,0 +1,57 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMissingRequiredInputs(t *testing.T) {
+	definition := FunctionDefinition{
+		Name: "f",
+		Inputs: []FunctionInput{
+			{Name: "b", Required: true},
+			{Name: "optional"},
+			{Name: "a", Required: true},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		inputs   map[string]FilledInputOutput
+		expected []string
+	}{
+		{"all provided", map[string]FilledInputOutput{"a": {}, "b": {}}, nil},
+		{"none provided", nil, []string{"b", "a"}},
+		{"optional only", map[string]FilledInputOutput{"optional": {}}, []string{"b", "a"}},
+		{"empty value counts as provided", map[string]FilledInputOutput{"a": {Value: ""}, "b": {Value: nil}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := definition.MissingRequiredInputs(tt.inputs); !slices.Equal(got, tt.expected) {
+				t.Errorf("MissingRequiredInputs() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -22,7 +22,176 @@
 
 package sharedtypes
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
 type SlashCommand struct {
-	Scope   string `json:"scope" yaml:"scope"`     // For global commands the scope is an empty string
-	Command string `json:"command" yaml:"command"` // e.g. "/mycommand"
+	Scope       string                 `json:"scope" yaml:"scope"`                                 // For global commands the scope is an empty string
+	Command     string                 `json:"command" yaml:"command"`                             // e.g. "/mycommand"
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"` // Shown in the command completion of the UIs
+	Arguments   []SlashCommandArgument `json:"arguments,omitempty" yaml:"arguments,omitempty"`     // Positional arguments of the command
+	Workflow    string                 `json:"workflow,omitempty" yaml:"workflow,omitempty"`       // Workflow executed by the command
+}
+
+// Types of slash command arguments.
+const (
+	SlashCommandArgumentString = "string"
+	SlashCommandArgumentInt    = "int"
+	SlashCommandArgumentFloat  = "float"
+	SlashCommandArgumentBool   = "bool"
+	SlashCommandArgumentEnum   = "enum"
+)
+
+// SlashCommandArgument describes a positional argument of a slash command.
+type SlashCommandArgument struct {
+	Name        string   `json:"name" yaml:"name"`
+	Type        string   `json:"type" yaml:"type"` // "string", "int", "float", "bool" or "enum"
+	Required    bool     `json:"required,omitempty" yaml:"required,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Completions []string `json:"completions,omitempty" yaml:"completions,omitempty"` // Completion hints; the allowed values for "enum" arguments
+}
+
+// Validate checks the command name and the argument schema of the slash command.
+//
+// Returns:
+//   - error: an error if the command is invalid
+func (sc *SlashCommand) Validate() error {
+	if len(sc.Command) < 2 || !strings.HasPrefix(sc.Command, "/") || strings.ContainsAny(sc.Command, " \t\n") {
+		return fmt.Errorf("invalid slash command %q, expected a single word starting with \"/\"", sc.Command)
+	}
+	names := map[string]bool{}
+	optional := false
+	for _, argument := range sc.Arguments {
+		if argument.Name == "" {
+			return fmt.Errorf("argument of %s has no name", sc.Command)
+		}
+		if names[argument.Name] {
+			return fmt.Errorf("duplicate argument %q in %s", argument.Name, sc.Command)
+		}
+		names[argument.Name] = true
+		switch argument.Type {
+		case SlashCommandArgumentString, SlashCommandArgumentInt, SlashCommandArgumentFloat, SlashCommandArgumentBool:
+		case SlashCommandArgumentEnum:
+			if len(argument.Completions) == 0 {
+				return fmt.Errorf("enum argument %q in %s has no values", argument.Name, sc.Command)
+			}
+		default:
+			return fmt.Errorf("argument %q in %s has unsupported type %q", argument.Name, sc.Command, argument.Type)
+		}
+		if argument.Required && optional {
+			return fmt.Errorf("required argument %q in %s follows an optional argument", argument.Name, sc.Command)
+		}
+		optional = optional || !argument.Required
+	}
+	return nil
+}
+
+// SlashCommandRegistry holds the slash commands available to the agent and the UIs.
+// It is safe for concurrent use.
+type SlashCommandRegistry struct {
+	mutex    sync.RWMutex
+	commands map[string]SlashCommand // key is scope + command
+}
+
+// NewSlashCommandRegistry creates an empty slash command registry.
+//
+// Returns:
+//   - *SlashCommandRegistry: the registry
+func NewSlashCommandRegistry() *SlashCommandRegistry {
+	return &SlashCommandRegistry{commands: map[string]SlashCommand{}}
+}
+
+// Register validates and adds a slash command to the registry.
+//
+// Parameters:
+//   - command: the slash command
+//
+// Returns:
+//   - error: an error if the command is invalid or already registered in the same scope
+func (r *SlashCommandRegistry) Register(command SlashCommand) error {
+	if err := command.Validate(); err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	key := slashCommandKey(command.Scope, command.Command)
+	if existing, ok := r.commands[key]; ok {
+		return fmt.Errorf("slash command %s is already registered in scope %q for workflow %q", command.Command, command.Scope, existing.Workflow)
+	}
+	r.commands[key] = command
+	return nil
+}
+
+// Unregister removes a slash command from the registry.
+//
+// Parameters:
+//   - scope: the scope of the command, empty for global commands
+//   - command: the command, e.g. "/mycommand"
+//
+// Returns:
+//   - bool: true if the command was registered
+func (r *SlashCommandRegistry) Unregister(scope string, command string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	key := slashCommandKey(scope, command)
+	_, ok := r.commands[key]
+	delete(r.commands, key)
+	return ok
+}
+
+// Lookup returns the slash command available in a scope; commands of the scope take precedence over global commands.
+//
+// Parameters:
+//   - scope: the scope, empty for global commands only
+//   - command: the command, e.g. "/mycommand"
+//
+// Returns:
+//   - SlashCommand: the command
+//   - bool: true if the command was found
+func (r *SlashCommandRegistry) Lookup(scope string, command string) (SlashCommand, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if found, ok := r.commands[slashCommandKey(scope, command)]; ok {
+		return found, true
+	}
+	found, ok := r.commands[slashCommandKey("", command)]
+	return found, ok
+}
+
+// List returns the slash commands available in a scope, sorted by command.
+// Global commands shadowed by a command of the scope are omitted.
+//
+// Parameters:
+//   - scope: the scope, empty for global commands only
+//
+// Returns:
+//   - []SlashCommand: the commands
+func (r *SlashCommandRegistry) List(scope string) []SlashCommand {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	commands := []SlashCommand{}
+	for _, command := range r.commands {
+		switch {
+		case command.Scope == scope:
+			commands = append(commands, command)
+		case command.Scope == "":
+			if _, shadowed := r.commands[slashCommandKey(scope, command.Command)]; !shadowed {
+				commands = append(commands, command)
+			}
+		}
+	}
+	slices.SortFunc(commands, func(a, b SlashCommand) int {
+		return strings.Compare(a.Command, b.Command)
+	})
+	return commands
+}
+
+// slashCommandKey returns the registry key of a command in a scope.
+func slashCommandKey(scope string, command string) string {
+	return scope + "\x00" + command
 }
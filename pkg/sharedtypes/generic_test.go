@@ -0,0 +1,101 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import "testing"
+
+func TestSlashCommandValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		command   SlashCommand
+		expectErr bool
+	}{
+		{"no arguments", SlashCommand{Command: "/help"}, false},
+		{"with arguments", SlashCommand{Command: "/mesh", Arguments: []SlashCommandArgument{
+			{Name: "size", Type: SlashCommandArgumentFloat, Required: true},
+			{Name: "method", Type: SlashCommandArgumentEnum, Completions: []string{"tet", "hex"}},
+		}}, false},
+		{"missing slash", SlashCommand{Command: "help"}, true},
+		{"whitespace", SlashCommand{Command: "/my command"}, true},
+		{"unknown type", SlashCommand{Command: "/x", Arguments: []SlashCommandArgument{{Name: "a", Type: "date"}}}, true},
+		{"enum without values", SlashCommand{Command: "/x", Arguments: []SlashCommandArgument{{Name: "a", Type: SlashCommandArgumentEnum}}}, true},
+		{"duplicate argument", SlashCommand{Command: "/x", Arguments: []SlashCommandArgument{
+			{Name: "a", Type: SlashCommandArgumentString}, {Name: "a", Type: SlashCommandArgumentInt},
+		}}, true},
+		{"required after optional", SlashCommand{Command: "/x", Arguments: []SlashCommandArgument{
+			{Name: "a", Type: SlashCommandArgumentString}, {Name: "b", Type: SlashCommandArgumentInt, Required: true},
+		}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.command.Validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestSlashCommandRegistry(t *testing.T) {
+	registry := NewSlashCommandRegistry()
+
+	for _, command := range []SlashCommand{
+		{Command: "/help", Workflow: "help"},
+		{Command: "/mesh", Workflow: "mesh-global"},
+		{Scope: "mapdl", Command: "/mesh", Workflow: "mesh-mapdl"},
+	} {
+		if err := registry.Register(command); err != nil {
+			t.Fatalf("Register(%s) error = %v", command.Command, err)
+		}
+	}
+
+	if err := registry.Register(SlashCommand{Scope: "mapdl", Command: "/mesh"}); err == nil {
+		t.Error("Register() expected an error for a conflicting command")
+	}
+	if err := registry.Register(SlashCommand{Command: "invalid"}); err == nil {
+		t.Error("Register() expected an error for an invalid command")
+	}
+
+	if command, ok := registry.Lookup("mapdl", "/mesh"); !ok || command.Workflow != "mesh-mapdl" {
+		t.Errorf("Lookup(mapdl, /mesh) = %v, %v", command, ok)
+	}
+	if command, ok := registry.Lookup("aedt", "/mesh"); !ok || command.Workflow != "mesh-global" {
+		t.Errorf("Lookup(aedt, /mesh) = %v, %v", command, ok)
+	}
+	if _, ok := registry.Lookup("", "/unknown"); ok {
+		t.Error("Lookup() should not find an unknown command")
+	}
+
+	commands := registry.List("mapdl")
+	if len(commands) != 2 || commands[0].Command != "/help" || commands[1].Workflow != "mesh-mapdl" {
+		t.Errorf("List(mapdl) = %v", commands)
+	}
+
+	if !registry.Unregister("mapdl", "/mesh") || registry.Unregister("mapdl", "/mesh") {
+		t.Error("Unregister() should only succeed once")
+	}
+	if command, _ := registry.Lookup("mapdl", "/mesh"); command.Workflow != "mesh-global" {
+		t.Errorf("Lookup(mapdl, /mesh) after Unregister = %v", command)
+	}
+}
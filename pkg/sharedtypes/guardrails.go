@@ -0,0 +1,277 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// GuardrailPolicy describes the guardrails applied to an LLM request and its response.
+// The agent attaches the policy to a HandlerRequest; the handler enforces it and reports the
+// outcome with GuardrailEvaluation values in its responses.
+type GuardrailPolicy struct {
+	Name   string           `json:"name,omitempty" yaml:"NAME,omitempty"` // name of the policy, reported in evaluations for auditing
+	Input  InputGuardrails  `json:"input" yaml:"INPUT"`
+	Output OutputGuardrails `json:"output" yaml:"OUTPUT"`
+
+	// AllowedTools restricts the MCP tools the model may call: nil allows all tools, an empty list allows none
+	AllowedTools []string `json:"allowedTools" yaml:"ALLOWED_TOOLS"`
+}
+
+// InputGuardrails are the guardrails applied to the request before it is sent to the model.
+type InputGuardrails struct {
+	BlockedTopics []string `json:"blockedTopics,omitempty" yaml:"BLOCKED_TOPICS,omitempty"` // topics the user may not ask about
+	ScrubPII      bool     `json:"scrubPii,omitempty" yaml:"SCRUB_PII,omitempty"`           // remove personal data from the request before sending it to the model
+}
+
+// OutputGuardrails are the guardrails applied to the response of the model.
+type OutputGuardrails struct {
+	BlockedTopics   []string `json:"blockedTopics,omitempty" yaml:"BLOCKED_TOPICS,omitempty"`      // topics the model may not answer about
+	MaxOutputTokens *int32   `json:"maxOutputTokens,omitempty" yaml:"MAX_OUTPUT_TOKENS,omitempty"` // upper bound for modelOptions.maxTokens
+	ScrubPII        bool     `json:"scrubPii,omitempty" yaml:"SCRUB_PII,omitempty"`                // remove personal data from the response before returning it
+}
+
+// Validate checks the policy for values that cannot be enforced.
+//
+// Returns:
+//   - error: an error describing the first invalid value, nil if the policy is valid
+func (p *GuardrailPolicy) Validate() error {
+	topics := []struct {
+		name   string
+		values []string
+	}{
+		{"input.blockedTopics", p.Input.BlockedTopics},
+		{"output.blockedTopics", p.Output.BlockedTopics},
+		{"allowedTools", p.AllowedTools},
+	}
+	for _, list := range topics {
+		for i, value := range list.values {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Errorf("%s[%d] must not be empty", list.name, i)
+			}
+		}
+	}
+	if p.Output.MaxOutputTokens != nil && *p.Output.MaxOutputTokens <= 0 {
+		return fmt.Errorf("output.maxOutputTokens must be positive, got %v", *p.Output.MaxOutputTokens)
+	}
+	return nil
+}
+
+// IsToolAllowed reports whether the model may call the tool.
+//
+// Parameters:
+//   - name: the name of the tool
+//
+// Returns:
+//   - bool: true if the policy does not restrict tools or lists the tool
+func (p *GuardrailPolicy) IsToolAllowed(name string) bool {
+	return p.AllowedTools == nil || slices.Contains(p.AllowedTools, name)
+}
+
+// FilterTools returns the tools the model may call.
+//
+// Parameters:
+//   - tools: the tools of the request
+//
+// Returns:
+//   - []MCPTool: the allowed tools in their original order
+func (p *GuardrailPolicy) FilterTools(tools []MCPTool) []MCPTool {
+	if p.AllowedTools == nil {
+		return tools
+	}
+	allowed := make([]MCPTool, 0, len(tools))
+	for _, tool := range tools {
+		if p.IsToolAllowed(tool.Name) {
+			allowed = append(allowed, tool)
+		}
+	}
+	return allowed
+}
+
+// LimitMaxTokens applies the output token limit of the policy to the maximum number of tokens of a request.
+//
+// Parameters:
+//   - maxTokens: the requested maximum number of tokens, nil if not set
+//
+// Returns:
+//   - *int32: the smaller of both limits, nil if neither is set
+func (p *GuardrailPolicy) LimitMaxTokens(maxTokens *int32) *int32 {
+	return minLimit(maxTokens, p.Output.MaxOutputTokens)
+}
+
+// Merge combines two policies into one that is at least as strict as both, e.g. the policy of the agent
+// and the policy configured for the model in the handler.
+// Blocked topics are united, PII scrubbing is enabled if either policy enables it, the smaller token limit
+// is kept and only tools allowed by both policies stay allowed.
+//
+// Parameters:
+//   - other: the policy to combine with
+//
+// Returns:
+//   - GuardrailPolicy: the combined policy; its name is the name of p, or the name of other if p has none
+func (p GuardrailPolicy) Merge(other GuardrailPolicy) GuardrailPolicy {
+	merged := GuardrailPolicy{
+		Name: p.Name,
+		Input: InputGuardrails{
+			BlockedTopics: unionStrings(p.Input.BlockedTopics, other.Input.BlockedTopics),
+			ScrubPII:      p.Input.ScrubPII || other.Input.ScrubPII,
+		},
+		Output: OutputGuardrails{
+			BlockedTopics:   unionStrings(p.Output.BlockedTopics, other.Output.BlockedTopics),
+			MaxOutputTokens: minLimit(p.Output.MaxOutputTokens, other.Output.MaxOutputTokens),
+			ScrubPII:        p.Output.ScrubPII || other.Output.ScrubPII,
+		},
+	}
+	if merged.Name == "" {
+		merged.Name = other.Name
+	}
+
+	switch {
+	case p.AllowedTools == nil:
+		merged.AllowedTools = slices.Clone(other.AllowedTools)
+	case other.AllowedTools == nil:
+		merged.AllowedTools = slices.Clone(p.AllowedTools)
+	default:
+		merged.AllowedTools = []string{}
+		for _, tool := range p.AllowedTools {
+			if slices.Contains(other.AllowedTools, tool) && !slices.Contains(merged.AllowedTools, tool) {
+				merged.AllowedTools = append(merged.AllowedTools, tool)
+			}
+		}
+	}
+	return merged
+}
+
+// unionStrings returns the values of both lists without duplicates, in the order of their first appearance.
+func unionStrings(a []string, b []string) []string {
+	var union []string
+	for _, value := range append(slices.Clone(a), b...) {
+		if !slices.Contains(union, value) {
+			union = append(union, value)
+		}
+	}
+	return union
+}
+
+// minLimit returns the smaller of two optional limits.
+func minLimit(a *int32, b *int32) *int32 {
+	switch {
+	case a == nil && b == nil:
+		return nil
+	case a == nil:
+		value := *b
+		return &value
+	case b == nil || *a <= *b:
+		value := *a
+		return &value
+	default:
+		value := *b
+		return &value
+	}
+}
+
+// GuardrailStage is the point of the request at which a guardrail was evaluated.
+type GuardrailStage string
+
+// Stages at which guardrails are evaluated.
+const (
+	GuardrailStageInput  GuardrailStage = "input"
+	GuardrailStageOutput GuardrailStage = "output"
+)
+
+// GuardrailAction is the action taken when a guardrail applies.
+type GuardrailAction string
+
+// Actions taken by guardrails, from least to most severe.
+const (
+	GuardrailActionAllow    GuardrailAction = "allow"    // the content passed unchanged
+	GuardrailActionRedact   GuardrailAction = "redact"   // parts of the content were removed, e.g. personal data
+	GuardrailActionTruncate GuardrailAction = "truncate" // the content was cut at the token limit
+	GuardrailActionBlock    GuardrailAction = "block"    // the content was rejected
+)
+
+// GuardrailActions lists all actions from least to most severe.
+var GuardrailActions = []GuardrailAction{GuardrailActionAllow, GuardrailActionRedact, GuardrailActionTruncate, GuardrailActionBlock}
+
+// Rules reported in guardrail violations.
+const (
+	GuardrailRuleBlockedTopic    = "blockedTopic"
+	GuardrailRulePII             = "pii"
+	GuardrailRuleMaxOutputTokens = "maxOutputTokens"
+	GuardrailRuleToolNotAllowed  = "toolNotAllowed"
+)
+
+// GuardrailViolation is a single guardrail that applied to the content.
+type GuardrailViolation struct {
+	Rule   string          `json:"rule"`             // "blockedTopic", "pii", "maxOutputTokens", "toolNotAllowed"
+	Detail string          `json:"detail,omitempty"` // e.g. the blocked topic or the name of the tool; must not contain the scrubbed personal data
+	Action GuardrailAction `json:"action"`
+}
+
+// GuardrailEvaluation is the outcome of evaluating a GuardrailPolicy at one stage of a request.
+type GuardrailEvaluation struct {
+	InstructionGuid string               `json:"instructionGuid"`
+	PolicyName      string               `json:"policyName,omitempty"`
+	Stage           GuardrailStage       `json:"stage"`
+	Action          GuardrailAction      `json:"action"` // the most severe action of the violations, "allow" if there are none
+	Violations      []GuardrailViolation `json:"violations,omitempty"`
+	EvaluatedAt     time.Time            `json:"evaluatedAt"`
+}
+
+// NewGuardrailEvaluation creates an evaluation without violations.
+//
+// Parameters:
+//   - instructionGuid: the instruction GUID of the request
+//   - policy: the evaluated policy
+//   - stage: the stage of the evaluation
+//
+// Returns:
+//   - GuardrailEvaluation: the evaluation with action "allow"
+func NewGuardrailEvaluation(instructionGuid string, policy GuardrailPolicy, stage GuardrailStage) GuardrailEvaluation {
+	return GuardrailEvaluation{
+		InstructionGuid: instructionGuid,
+		PolicyName:      policy.Name,
+		Stage:           stage,
+		Action:          GuardrailActionAllow,
+		EvaluatedAt:     time.Now().UTC(),
+	}
+}
+
+// AddViolation records a violation and raises the action of the evaluation to the action of the violation if it is more severe.
+//
+// Parameters:
+//   - violation: the violation to record
+func (e *GuardrailEvaluation) AddViolation(violation GuardrailViolation) {
+	e.Violations = append(e.Violations, violation)
+	if slices.Index(GuardrailActions, violation.Action) > slices.Index(GuardrailActions, e.Action) {
+		e.Action = violation.Action
+	}
+}
+
+// IsBlocked returns true if the content was rejected.
+func (e *GuardrailEvaluation) IsBlocked() bool {
+	return e.Action == GuardrailActionBlock
+}
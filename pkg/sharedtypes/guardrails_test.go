@@ -0,0 +1,198 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGuardrailPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  GuardrailPolicy
+		errText string
+	}{
+		{"empty policy", GuardrailPolicy{}, ""},
+		{"full policy", GuardrailPolicy{Name: "strict", Input: InputGuardrails{BlockedTopics: []string{"weapons"}, ScrubPII: true}, Output: OutputGuardrails{MaxOutputTokens: ptr(int32(100))}, AllowedTools: []string{}}, ""},
+		{"empty input topic", GuardrailPolicy{Input: InputGuardrails{BlockedTopics: []string{""}}}, "input.blockedTopics[0]"},
+		{"empty output topic", GuardrailPolicy{Output: OutputGuardrails{BlockedTopics: []string{"a", " "}}}, "output.blockedTopics[1]"},
+		{"empty tool", GuardrailPolicy{AllowedTools: []string{""}}, "allowedTools[0]"},
+		{"zero token limit", GuardrailPolicy{Output: OutputGuardrails{MaxOutputTokens: ptr(int32(0))}}, "maxOutputTokens must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.errText == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.errText)
+			}
+		})
+	}
+}
+
+func TestGuardrailPolicyTools(t *testing.T) {
+	tools := []MCPTool{{Name: "search"}, {Name: "delete"}, {Name: "read"}}
+	tests := []struct {
+		name     string
+		allowed  []string
+		expected []string
+	}{
+		{"unrestricted", nil, []string{"search", "delete", "read"}},
+		{"none allowed", []string{}, []string{}},
+		{"some allowed", []string{"read", "search"}, []string{"search", "read"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := GuardrailPolicy{AllowedTools: tt.allowed}
+			names := []string{}
+			for _, tool := range policy.FilterTools(tools) {
+				names = append(names, tool.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("FilterTools() = %v, want %v", names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGuardrailPolicyLimitMaxTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     *int32
+		requested *int32
+		expected  *int32
+	}{
+		{"neither set", nil, nil, nil},
+		{"only limit", ptr(int32(100)), nil, ptr(int32(100))},
+		{"only requested", nil, ptr(int32(500)), ptr(int32(500))},
+		{"requested below limit", ptr(int32(100)), ptr(int32(50)), ptr(int32(50))},
+		{"requested above limit", ptr(int32(100)), ptr(int32(500)), ptr(int32(100))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := GuardrailPolicy{Output: OutputGuardrails{MaxOutputTokens: tt.limit}}
+			if got := policy.LimitMaxTokens(tt.requested); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("LimitMaxTokens() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGuardrailPolicyMerge(t *testing.T) {
+	agent := GuardrailPolicy{
+		Name:         "agent",
+		Input:        InputGuardrails{BlockedTopics: []string{"weapons"}, ScrubPII: true},
+		Output:       OutputGuardrails{MaxOutputTokens: ptr(int32(1000))},
+		AllowedTools: []string{"search", "read"},
+	}
+	handler := GuardrailPolicy{
+		Name:         "handler",
+		Input:        InputGuardrails{BlockedTopics: []string{"weapons", "medical"}},
+		Output:       OutputGuardrails{BlockedTopics: []string{"legal"}, MaxOutputTokens: ptr(int32(200)), ScrubPII: true},
+		AllowedTools: []string{"read", "write"},
+	}
+
+	expected := GuardrailPolicy{
+		Name:         "agent",
+		Input:        InputGuardrails{BlockedTopics: []string{"weapons", "medical"}, ScrubPII: true},
+		Output:       OutputGuardrails{BlockedTopics: []string{"legal"}, MaxOutputTokens: ptr(int32(200)), ScrubPII: true},
+		AllowedTools: []string{"read"},
+	}
+	if merged := agent.Merge(handler); !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Merge() = %+v, want %+v", merged, expected)
+	}
+
+	// an unrestricted tool list keeps the restriction of the other policy
+	merged := GuardrailPolicy{}.Merge(GuardrailPolicy{Name: "handler", AllowedTools: []string{}})
+	if merged.Name != "handler" || merged.AllowedTools == nil || len(merged.AllowedTools) != 0 {
+		t.Errorf("Merge() = %+v, want name handler and no allowed tools", merged)
+	}
+	if merged := (GuardrailPolicy{}).Merge(GuardrailPolicy{}); merged.AllowedTools != nil {
+		t.Errorf("Merge() allowedTools = %v, want nil", merged.AllowedTools)
+	}
+}
+
+func TestGuardrailPolicyJSON(t *testing.T) {
+	// an empty tool list must not be confused with an unrestricted one
+	tests := []struct {
+		name    string
+		policy  GuardrailPolicy
+		allowed []string
+	}{
+		{"unrestricted", GuardrailPolicy{}, nil},
+		{"none allowed", GuardrailPolicy{AllowedTools: []string{}}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(HandlerRequest{Guardrails: &tt.policy})
+			if err != nil {
+				t.Fatalf("marshal failed: %v", err)
+			}
+			var request HandlerRequest
+			if err := json.Unmarshal(data, &request); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+			if !reflect.DeepEqual(request.Guardrails.AllowedTools, tt.allowed) {
+				t.Errorf("allowedTools = %#v, want %#v", request.Guardrails.AllowedTools, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestGuardrailEvaluation(t *testing.T) {
+	evaluation := NewGuardrailEvaluation("guid", GuardrailPolicy{Name: "strict"}, GuardrailStageOutput)
+	if evaluation.Action != GuardrailActionAllow || evaluation.PolicyName != "strict" || evaluation.EvaluatedAt.IsZero() {
+		t.Fatalf("NewGuardrailEvaluation() = %+v", evaluation)
+	}
+
+	steps := []struct {
+		violation GuardrailViolation
+		expected  GuardrailAction
+	}{
+		{GuardrailViolation{Rule: GuardrailRuleMaxOutputTokens, Action: GuardrailActionTruncate}, GuardrailActionTruncate},
+		{GuardrailViolation{Rule: GuardrailRulePII, Action: GuardrailActionRedact}, GuardrailActionTruncate},
+		{GuardrailViolation{Rule: GuardrailRuleBlockedTopic, Detail: "weapons", Action: GuardrailActionBlock}, GuardrailActionBlock},
+		{GuardrailViolation{Rule: GuardrailRulePII, Action: GuardrailActionRedact}, GuardrailActionBlock},
+	}
+	for i, step := range steps {
+		evaluation.AddViolation(step.violation)
+		if evaluation.Action != step.expected {
+			t.Errorf("after violation %d action = %q, want %q", i, evaluation.Action, step.expected)
+		}
+	}
+	if len(evaluation.Violations) != len(steps) || !evaluation.IsBlocked() {
+		t.Errorf("evaluation = %+v, want %d violations and blocked", evaluation, len(steps))
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // registers the GIF decoder; GIF images are re-encoded as PNG when downscaled
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ImageAttachment is an image sent to the LLM handler, either inline as base64 data or as a URL reference.
+type ImageAttachment struct {
+	MimeType  string `json:"mimeType"`            // e.g. "image/png"
+	Width     int    `json:"width,omitempty"`     // in pixels; 0 if unknown
+	Height    int    `json:"height,omitempty"`    // in pixels; 0 if unknown
+	SizeBytes int    `json:"sizeBytes,omitempty"` // size of the decoded data
+	Data      string `json:"data,omitempty"`      // base64 encoded image; either data or url is set
+	URL       string `json:"url,omitempty"`       // reference to the image; either data or url is set
+}
+
+// ImageLimits are the limits images must respect before they are sent to the LLM handler.
+type ImageLimits struct {
+	MaxBytes         int      `json:"maxBytes" yaml:"MAX_BYTES"`                  // maximum size of the decoded data; 0 means no limit
+	MaxWidth         int      `json:"maxWidth" yaml:"MAX_WIDTH"`                  // 0 means no limit
+	MaxHeight        int      `json:"maxHeight" yaml:"MAX_HEIGHT"`                // 0 means no limit
+	AllowedMimeTypes []string `json:"allowedMimeTypes" yaml:"ALLOWED_MIME_TYPES"` // all image types are allowed if empty
+}
+
+// MaxDecodedImagePixels is the maximum number of pixels of an image decoded by Downscale; decoding allocates
+// 4 bytes per pixel, so larger images are rejected based on their header before they are decoded
+var MaxDecodedImagePixels int64 = 64 * 1024 * 1024
+
+// DefaultImageLimits are limits accepted by all supported model providers.
+var DefaultImageLimits = ImageLimits{
+	MaxBytes:         5 * 1024 * 1024,
+	MaxWidth:         2048,
+	MaxHeight:        2048,
+	AllowedMimeTypes: []string{"image/png", "image/jpeg", "image/gif", "image/webp"},
+}
+
+// NewImageAttachment creates an inline image attachment.
+// The dimensions are read from the image header for PNG, JPEG and GIF images; they are 0 for other formats.
+//
+// Parameters:
+//   - data: the encoded image
+//
+// Returns:
+//   - ImageAttachment: the attachment with the detected mime type
+//   - error: an error if the data is not an image
+func NewImageAttachment(data []byte) (ImageAttachment, error) {
+	mimeType := http.DetectContentType(data)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return ImageAttachment{}, fmt.Errorf("data is not an image, detected %q", mimeType)
+	}
+	attachment := ImageAttachment{
+		MimeType:  mimeType,
+		SizeBytes: len(data),
+		Data:      base64.StdEncoding.EncodeToString(data),
+	}
+	if config, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		attachment.Width, attachment.Height = config.Width, config.Height
+	}
+	return attachment, nil
+}
+
+// ParseImageAttachment converts an entry of HandlerRequest.Images or HistoricMessage.Images into an attachment.
+//
+// Parameters:
+//   - value: bare base64 data, a "data:" URL or an http(s) URL
+//
+// Returns:
+//   - ImageAttachment: the attachment
+//   - error: an error if the value cannot be decoded or is not an image
+func ParseImageAttachment(value string) (ImageAttachment, error) {
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		return ImageAttachment{URL: value}, nil
+	}
+	if rest, ok := strings.CutPrefix(value, "data:"); ok {
+		_, encoded, found := strings.Cut(rest, ";base64,")
+		if !found {
+			return ImageAttachment{}, errors.New("data URL of image is not base64 encoded")
+		}
+		value = encoded
+	}
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return ImageAttachment{}, fmt.Errorf("invalid base64 image: %w", err)
+	}
+	return NewImageAttachment(data)
+}
+
+// Bytes decodes the inline data of the attachment.
+//
+// Returns:
+//   - []byte: the encoded image
+//   - error: an error if the attachment is a URL reference or the data is not valid base64
+func (a *ImageAttachment) Bytes() ([]byte, error) {
+	if a.Data == "" {
+		return nil, errors.New("image has no inline data")
+	}
+	data, err := base64.StdEncoding.DecodeString(a.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 image: %w", err)
+	}
+	return data, nil
+}
+
+// Validate checks the attachment against the limits.
+// The size, dimensions and type of inline images are read from the data, since the fields of the attachment
+// are set by the caller; the mime type must match the type detected from the data. The dimensions are only
+// checked if they can be read, i.e. for PNG, JPEG and GIF images, and for URL references if they are set.
+//
+// Parameters:
+//   - limits: the limits, e.g. DefaultImageLimits
+//
+// Returns:
+//   - error: an error describing the first violated limit, nil if the attachment is valid
+func (a *ImageAttachment) Validate(limits ImageLimits) error {
+	if (a.Data == "") == (a.URL == "") {
+		return errors.New("image must have either data or url")
+	}
+	sizeBytes, width, height := a.SizeBytes, a.Width, a.Height
+	if a.Data != "" {
+		if a.MimeType == "" {
+			return errors.New("image with inline data must have a mime type")
+		}
+		data, err := a.Bytes()
+		if err != nil {
+			return err
+		}
+		if detected := http.DetectContentType(data); detected != a.MimeType {
+			return fmt.Errorf("image has mime type %q but its data is of type %q", a.MimeType, detected)
+		}
+		sizeBytes, width, height = len(data), 0, 0
+		if config, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+			width, height = config.Width, config.Height
+		}
+	}
+	if a.MimeType != "" && len(limits.AllowedMimeTypes) > 0 && !slices.Contains(limits.AllowedMimeTypes, a.MimeType) {
+		return fmt.Errorf("image type %q is not allowed, expected one of %v", a.MimeType, limits.AllowedMimeTypes)
+	}
+	if limits.MaxBytes > 0 && sizeBytes > limits.MaxBytes {
+		return fmt.Errorf("image of %d bytes exceeds the limit of %d bytes", sizeBytes, limits.MaxBytes)
+	}
+	if (limits.MaxWidth > 0 && width > limits.MaxWidth) || (limits.MaxHeight > 0 && height > limits.MaxHeight) {
+		return fmt.Errorf("image of %dx%d pixels exceeds the limit of %dx%d pixels", width, height, limits.MaxWidth, limits.MaxHeight)
+	}
+	return nil
+}
+
+// ResizeHint returns the dimensions that fit the image into the maximum dimensions of the limits with the same aspect ratio.
+//
+// Parameters:
+//   - limits: the limits
+//
+// Returns:
+//   - int: the target width
+//   - int: the target height
+//   - bool: true if the image must be downscaled; false if it fits or its dimensions are unknown
+func (a *ImageAttachment) ResizeHint(limits ImageLimits) (int, int, bool) {
+	if a.Width <= 0 || a.Height <= 0 {
+		return a.Width, a.Height, false
+	}
+	scale := 1.0
+	if limits.MaxWidth > 0 && a.Width > limits.MaxWidth {
+		scale = float64(limits.MaxWidth) / float64(a.Width)
+	}
+	if limits.MaxHeight > 0 && a.Height > limits.MaxHeight {
+		scale = min(scale, float64(limits.MaxHeight)/float64(a.Height))
+	}
+	if scale == 1 {
+		return a.Width, a.Height, false
+	}
+	return max(1, int(float64(a.Width)*scale)), max(1, int(float64(a.Height)*scale)), true
+}
+
+// Downscale shrinks an inline image until it respects the dimension and size limits.
+// The image is first scaled to the dimensions of ResizeHint. If it still exceeds the size limit, it is re-encoded
+// as JPEG, with transparent areas on white, and scaled down further in steps of 75%.
+// The size and dimensions are read from the data rather than the fields of the attachment. Images within the limits,
+// including images whose dimensions cannot be read, and URL references are returned unchanged.
+//
+// Parameters:
+//   - limits: the limits, e.g. DefaultImageLimits
+//
+// Returns:
+//   - ImageAttachment: the downscaled attachment
+//   - error: an error if the image cannot be decoded, has more than MaxDecodedImagePixels pixels or does not fit into the limits
+func (a ImageAttachment) Downscale(limits ImageLimits) (ImageAttachment, error) {
+	if a.Data == "" {
+		return a, nil
+	}
+	data, err := a.Bytes()
+	if err != nil {
+		return ImageAttachment{}, err
+	}
+	withinSize := limits.MaxBytes <= 0 || len(data) <= limits.MaxBytes
+
+	// the header is checked before decoding, so a small image declaring huge dimensions is not decoded
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		if withinSize {
+			return a, nil
+		}
+		return ImageAttachment{}, fmt.Errorf("cannot decode image of type %q for downscaling: %w", a.MimeType, err)
+	}
+	measured := a
+	measured.Width, measured.Height, measured.SizeBytes = config.Width, config.Height, len(data)
+	width, height, resize := measured.ResizeHint(limits)
+	if !resize && withinSize {
+		return a, nil
+	}
+	if pixels := int64(config.Width) * int64(config.Height); pixels > MaxDecodedImagePixels {
+		return ImageAttachment{}, fmt.Errorf("image of %dx%d pixels exceeds the limit of %d pixels for downscaling", config.Width, config.Height, MaxDecodedImagePixels)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return ImageAttachment{}, fmt.Errorf("cannot decode image of type %q for downscaling: %w", a.MimeType, err)
+	}
+	if width == 0 || height == 0 {
+		width, height = src.Bounds().Dx(), src.Bounds().Dy()
+	}
+
+	mimeType := a.MimeType
+	if mimeType != "image/jpeg" {
+		mimeType = "image/png"
+	}
+	for attempt := 0; attempt < 8; attempt++ {
+		encoded, err := encodeImage(scaleImage(src, width, height), mimeType)
+		if err != nil {
+			return ImageAttachment{}, err
+		}
+		if limits.MaxBytes <= 0 || len(encoded) <= limits.MaxBytes {
+			return ImageAttachment{
+				MimeType:  mimeType,
+				Width:     width,
+				Height:    height,
+				SizeBytes: len(encoded),
+				Data:      base64.StdEncoding.EncodeToString(encoded),
+			}, nil
+		}
+		if mimeType == "image/png" {
+			mimeType = "image/jpeg"
+			continue
+		}
+		width, height = max(1, width*3/4), max(1, height*3/4)
+	}
+	return ImageAttachment{}, fmt.Errorf("image cannot be downscaled below %d bytes", limits.MaxBytes)
+}
+
+// ContentPart converts the attachment into an image content part of a HistoricMessage.
+func (a *ImageAttachment) ContentPart() ContentPart {
+	return ContentPart{Type: ContentPartImage, Data: a.Data, URI: a.URL, MimeType: a.MimeType}
+}
+
+// scaleImage scales the image to the dimensions by averaging the source pixels covered by each target pixel.
+func scaleImage(src image.Image, width int, height int) *image.NRGBA {
+	bounds := src.Bounds()
+	source := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(source, source.Bounds(), src, bounds.Min, draw.Src)
+	if width == bounds.Dx() && height == bounds.Dy() {
+		return source
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*bounds.Dy()/height, max((y+1)*bounds.Dy()/height, y*bounds.Dy()/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*bounds.Dx()/width, max((x+1)*bounds.Dx()/width, x*bounds.Dx()/width+1)
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := source.Pix[sy*source.Stride+x0*4 : sy*source.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+			count := (y1 - y0) * (x1 - x0)
+			offset := y*dst.Stride + x*4
+			for c := 0; c < 4; c++ {
+				dst.Pix[offset+c] = uint8(sum[c] / count)
+			}
+		}
+	}
+	return dst
+}
+
+// encodeImage encodes the image as PNG or JPEG; transparent areas are put on white for JPEG.
+func encodeImage(img *image.NRGBA, mimeType string) ([]byte, error) {
+	var buffer bytes.Buffer
+	var err error
+	switch mimeType {
+	case "image/png":
+		err = png.Encode(&buffer, img)
+	case "image/jpeg":
+		opaque := image.NewRGBA(img.Bounds())
+		draw.Draw(opaque, opaque.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.Draw(opaque, opaque.Bounds(), img, img.Bounds().Min, draw.Over)
+		err = jpeg.Encode(&buffer, opaque, &jpeg.Options{Quality: 85})
+	default:
+		return nil, fmt.Errorf("cannot encode images of type %q", mimeType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error encoding image: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,276 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// testPNG encodes a PNG with a pattern that does not compress well.
+func testPNG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x * 7), G: uint8(y * 13), B: uint8(x * y), A: 255})
+		}
+	}
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestNewImageAttachment(t *testing.T) {
+	data := testPNG(t, 40, 20)
+	attachment, err := NewImageAttachment(data)
+	if err != nil {
+		t.Fatalf("NewImageAttachment() error = %v", err)
+	}
+	if attachment.MimeType != "image/png" || attachment.Width != 40 || attachment.Height != 20 || attachment.SizeBytes != len(data) {
+		t.Errorf("NewImageAttachment() = %+v", attachment)
+	}
+	if decoded, err := attachment.Bytes(); err != nil || !bytes.Equal(decoded, data) {
+		t.Errorf("Bytes() = %v, want the original data", err)
+	}
+
+	if _, err := NewImageAttachment([]byte("plain text")); err == nil || !strings.Contains(err.Error(), "not an image") {
+		t.Errorf("NewImageAttachment() of text error = %v, want not an image", err)
+	}
+}
+
+func TestParseImageAttachment(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(testPNG(t, 4, 4))
+	tests := []struct {
+		name     string
+		value    string
+		expected ImageAttachment
+		errText  string
+	}{
+		{"bare base64", encoded, ImageAttachment{MimeType: "image/png", Width: 4, Height: 4}, ""},
+		{"data url", "data:image/png;base64," + encoded, ImageAttachment{MimeType: "image/png", Width: 4, Height: 4}, ""},
+		{"url", "https://example.com/a.png", ImageAttachment{URL: "https://example.com/a.png"}, ""},
+		{"invalid base64", "not base64!", ImageAttachment{}, "invalid base64"},
+		{"data url without base64", "data:image/svg+xml,<svg/>", ImageAttachment{}, "not base64 encoded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachment, err := ParseImageAttachment(tt.value)
+			if tt.errText != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errText) {
+					t.Errorf("ParseImageAttachment() error = %v, want error containing %q", err, tt.errText)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseImageAttachment() error = %v", err)
+			}
+			if attachment.MimeType != tt.expected.MimeType || attachment.Width != tt.expected.Width || attachment.Height != tt.expected.Height || attachment.URL != tt.expected.URL {
+				t.Errorf("ParseImageAttachment() = %+v, want %+v", attachment, tt.expected)
+			}
+		})
+	}
+}
+
+// withPNGDimensions rewrites the dimensions in the header of a PNG, keeping its pixel data.
+func withPNGDimensions(t *testing.T, data []byte, width uint32, height uint32) []byte {
+	t.Helper()
+	patched := bytes.Clone(data)
+	// the IHDR chunk follows the 8 byte signature: length, type, width, height, ..., CRC over type and data
+	binary.BigEndian.PutUint32(patched[16:20], width)
+	binary.BigEndian.PutUint32(patched[20:24], height)
+	binary.BigEndian.PutUint32(patched[29:33], crc32.ChecksumIEEE(patched[12:29]))
+	return patched
+}
+
+func TestImageAttachmentValidate(t *testing.T) {
+	data := base64.StdEncoding.EncodeToString(testPNG(t, 10, 10))
+	wide := base64.StdEncoding.EncodeToString(testPNG(t, 4000, 10))
+	text := base64.StdEncoding.EncodeToString([]byte("x"))
+	tests := []struct {
+		name       string
+		attachment ImageAttachment
+		errText    string
+	}{
+		{"valid inline", ImageAttachment{MimeType: "image/png", Data: data}, ""},
+		{"valid url", ImageAttachment{URL: "https://example.com/a.png"}, ""},
+		{"neither data nor url", ImageAttachment{MimeType: "image/png"}, "either data or url"},
+		{"both data and url", ImageAttachment{MimeType: "image/png", Data: data, URL: "https://example.com/a.png"}, "either data or url"},
+		{"inline without mime type", ImageAttachment{Data: data}, "must have a mime type"},
+		{"invalid base64", ImageAttachment{MimeType: "image/png", Data: "!"}, "invalid base64"},
+		{"mime type of other format", ImageAttachment{MimeType: "image/jpeg", Data: data}, "its data is of type \"image/png\""},
+		{"not an image", ImageAttachment{MimeType: "image/png", Data: text}, "text/plain"},
+		{"too wide", ImageAttachment{MimeType: "image/png", Data: wide}, "4000x10 pixels"},
+		{"too wide despite declared size", ImageAttachment{MimeType: "image/png", Data: wide, Width: 100, Height: 10}, "4000x10 pixels"},
+		{"declared size ignored", ImageAttachment{MimeType: "image/png", Data: data, SizeBytes: DefaultImageLimits.MaxBytes + 1, Width: 4000}, ""},
+		{"url too wide", ImageAttachment{URL: "https://example.com/a.png", Width: 4000, Height: 10}, "4000x10 pixels"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.attachment.Validate(DefaultImageLimits)
+			if tt.errText == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.errText)
+			}
+		})
+	}
+}
+
+func TestImageAttachmentResizeHint(t *testing.T) {
+	limits := ImageLimits{MaxWidth: 1000, MaxHeight: 500}
+	tests := []struct {
+		name           string
+		width, height  int
+		expectedWidth  int
+		expectedHeight int
+		expectedResize bool
+	}{
+		{"fits", 800, 400, 800, 400, false},
+		{"too wide", 2000, 400, 1000, 200, true},
+		{"too high", 400, 1000, 200, 500, true},
+		{"too wide and high", 4000, 4000, 500, 500, true},
+		{"unknown dimensions", 0, 0, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachment := ImageAttachment{Width: tt.width, Height: tt.height}
+			width, height, resize := attachment.ResizeHint(limits)
+			if width != tt.expectedWidth || height != tt.expectedHeight || resize != tt.expectedResize {
+				t.Errorf("ResizeHint() = %d, %d, %v, want %d, %d, %v", width, height, resize, tt.expectedWidth, tt.expectedHeight, tt.expectedResize)
+			}
+		})
+	}
+}
+
+func TestImageAttachmentDownscale(t *testing.T) {
+	attachment, err := NewImageAttachment(testPNG(t, 400, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// dimensions above the limits keep the format and the aspect ratio
+	downscaled, err := attachment.Downscale(ImageLimits{MaxWidth: 200, MaxHeight: 200})
+	if err != nil {
+		t.Fatalf("Downscale() error = %v", err)
+	}
+	if downscaled.MimeType != "image/png" || downscaled.Width != 200 || downscaled.Height != 50 {
+		t.Errorf("Downscale() = %dx%d %s, want 200x50 image/png", downscaled.Width, downscaled.Height, downscaled.MimeType)
+	}
+	if reparsed, err := ParseImageAttachment(downscaled.Data); err != nil || reparsed.Width != 200 || reparsed.SizeBytes != downscaled.SizeBytes {
+		t.Errorf("downscaled data decodes to %+v, %v", reparsed, err)
+	}
+
+	// a size above the limit is re-encoded as JPEG and shrunk until it fits
+	limit := attachment.SizeBytes / 4
+	downscaled, err = attachment.Downscale(ImageLimits{MaxBytes: limit})
+	if err != nil {
+		t.Fatalf("Downscale() error = %v", err)
+	}
+	if downscaled.MimeType != "image/jpeg" || downscaled.SizeBytes > limit {
+		t.Errorf("Downscale() = %s of %d bytes, want image/jpeg of at most %d bytes", downscaled.MimeType, downscaled.SizeBytes, limit)
+	}
+
+	// the size of the data is checked, not the declared size
+	if err := attachment.Validate(ImageLimits{MaxBytes: 10}); err == nil || !strings.Contains(err.Error(), "exceeds the limit of 10 bytes") {
+		t.Errorf("Validate() with small size limit error = %v", err)
+	}
+
+	// the declared dimensions and size are ignored
+	lying := attachment
+	lying.Width, lying.Height, lying.SizeBytes = 1, 1, 1
+	downscaled, err = lying.Downscale(ImageLimits{MaxWidth: 200, MaxHeight: 200})
+	if err != nil || downscaled.Width != 200 || downscaled.Height != 50 {
+		t.Errorf("Downscale() with wrong declared dimensions = %dx%d, %v, want 200x50", downscaled.Width, downscaled.Height, err)
+	}
+
+	// images with too many pixels are rejected before they are decoded
+	huge, err := NewImageAttachment(withPNGDimensions(t, testPNG(t, 1, 1), 100000, 100000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := huge.Downscale(DefaultImageLimits); err == nil || !strings.Contains(err.Error(), "pixels for downscaling") {
+		t.Errorf("Downscale() of huge image error = %v, want pixel limit error", err)
+	}
+
+	// images within the limits and URL references are unchanged
+	if unchanged, err := attachment.Downscale(DefaultImageLimits); err != nil || unchanged != attachment {
+		t.Errorf("Downscale() within limits changed the image: %v", err)
+	}
+	reference := ImageAttachment{URL: "https://example.com/a.png", Width: 4000, Height: 4000}
+	if unchanged, err := reference.Downscale(DefaultImageLimits); err != nil || unchanged != reference {
+		t.Errorf("Downscale() of URL changed the reference: %v", err)
+	}
+
+	if _, err := attachment.Downscale(ImageLimits{MaxBytes: 10}); err == nil || !strings.Contains(err.Error(), "cannot be downscaled") {
+		t.Errorf("Downscale() below any size error = %v, want cannot be downscaled", err)
+	}
+}
+
+func TestScaleImageAverages(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	src.Set(0, 0, color.NRGBA{A: 255})
+	src.Set(1, 0, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+	src.Set(0, 1, color.NRGBA{A: 255})
+	src.Set(1, 1, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+
+	if got := scaleImage(src, 1, 1).NRGBAAt(0, 0); got != (color.NRGBA{R: 127, G: 127, B: 127, A: 255}) {
+		t.Errorf("scaled pixel = %v, want gray", got)
+	}
+}
+
+func TestWithImageAttachments(t *testing.T) {
+	attachment, err := NewImageAttachment(testPNG(t, 3000, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request, err := NewChatRequest().WithData("describe").WithImageAttachments(attachment).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	image, err := ParseImageAttachment(request.Images[0])
+	if err != nil || image.Width != DefaultImageLimits.MaxWidth {
+		t.Errorf("request image = %+v, %v, want width %d", image, err, DefaultImageLimits.MaxWidth)
+	}
+
+	_, err = NewChatRequest().WithImageAttachments(ImageAttachment{URL: "https://example.com/a.png"}).Build()
+	if err == nil || !strings.Contains(err.Error(), "only inline images") {
+		t.Errorf("Build() error = %v, want only inline images", err)
+	}
+}
@@ -23,8 +23,13 @@
 package sharedtypes
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
 	"strconv"
 	"time"
 
@@ -48,6 +53,14 @@ type DbFilters struct {
 
 	// Filters for JSON fields
 	MetadataFilter []DbJsonFilter `json:"metadata,omitempty"`
+
+	// Filters for numeric and date ranges of JSON fields
+	RangeFilter []DbRangeFilter `json:"ranges,omitempty"`
+
+	// Boolean composition; all conditions of a DbFilters are combined with AND
+	And []DbFilters `json:"and,omitempty"` // all of the sub-filters must match
+	Or  []DbFilters `json:"or,omitempty"`  // at least one of the sub-filters must match
+	Not *DbFilters  `json:"not,omitempty"` // the sub-filter must not match
 }
 
 // DbArrayFilter represents the filter for an array field in the database.
@@ -64,6 +77,16 @@ type DbJsonFilter struct {
 	NeedAll    bool     `json:"needAll" description:"Only needed if the FieldType is array."` // only needed for array fields
 }
 
+// DbRangeFilter represents an inclusive range filter for a numeric or date JSON field in the database.
+// Either the numeric bounds (Min, Max) or the date bounds (MinDate, MaxDate) can be used, not both.
+type DbRangeFilter struct {
+	FieldName string     `json:"fieldName"`
+	Min       *float64   `json:"min,omitempty"`
+	Max       *float64   `json:"max,omitempty"`
+	MinDate   *time.Time `json:"minDate,omitempty"`
+	MaxDate   *time.Time `json:"maxDate,omitempty"`
+}
+
 // DbData represents the data stored in the database.
 type DbData struct {
 	Guid              uuid.UUID              `json:"guid"`
@@ -72,7 +95,7 @@ type DbData struct {
 	Text              string                 `json:"text"`
 	Keywords          []string               `json:"keywords"`
 	Summary           string                 `json:"summary"`
-	Embedding         []float32              `json:"embeddings"`
+	Embedding         Embedding              `json:"embeddings"`
 	Tags              []string               `json:"tags"`
 	Metadata          map[string]interface{} `json:"metadata"`
 	ParentId          *uuid.UUID             `json:"parent_id"`
@@ -83,6 +106,214 @@ type DbData struct {
 	FirstChildId      *uuid.UUID             `json:"first_child_id"`
 	Level             int                    `json:"level"`
 	HasNeo4jEntry     bool                   `json:"has_neo4j_entry"`
+
+	// Provenance
+	SourceUri       string     `json:"source_uri,omitempty"`       // URI of the source document
+	IngestedAt      *time.Time `json:"ingested_at,omitempty"`      // Time the data was ingested
+	PipelineVersion string     `json:"pipeline_version,omitempty"` // Version of the ingestion pipeline that produced the data
+	ContentHash     string     `json:"content_hash,omitempty"`     // Hash of the source content, see ComputeContentHash
+}
+
+// ComputeContentHash computes the hash stored in the ContentHash provenance field of DbData.
+//
+// Parameters:
+//   - content: the raw content of the source document
+//
+// Returns:
+//   - string: the hash in the format "sha256:<hex digest>"
+func ComputeContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// IsUnchanged reports whether the data was ingested from content with the given hash,
+// so re-indexing can skip documents that did not change.
+//
+// Parameters:
+//   - contentHash: the hash of the current source content, see ComputeContentHash
+//
+// Returns:
+//   - bool: true if the stored hash is set and equal to the given hash
+func (data *DbData) IsUnchanged(contentHash string) bool {
+	return data.ContentHash != "" && data.ContentHash == contentHash
+}
+
+// Validate checks that the data can be stored in the database.
+//
+// Returns:
+//   - error: an error if the guid is missing or the data references itself
+func (data *DbData) Validate() error {
+	if data.Guid == uuid.Nil {
+		return fmt.Errorf("guid is missing")
+	}
+	for _, id := range []*uuid.UUID{data.ParentId, data.PreviousSiblingId, data.NextSiblingId, data.LastChildId, data.FirstChildId} {
+		if id != nil && *id == data.Guid {
+			return fmt.Errorf("entry %s references itself", data.Guid)
+		}
+	}
+	if slices.Contains(data.ChildIds, data.Guid) {
+		return fmt.Errorf("entry %s references itself", data.Guid)
+	}
+	return nil
+}
+
+// Equal reports whether both entries have the same guid and content.
+// Unset and nil UUIDs as well as nil and empty slices are considered equal.
+//
+// Parameters:
+//   - other: the entry to compare with
+//
+// Returns:
+//   - bool: true if the entries are equal
+func (data *DbData) Equal(other *DbData) bool {
+	if other == nil {
+		return false
+	}
+	return data.Guid == other.Guid &&
+		data.DocumentId == other.DocumentId &&
+		data.DocumentName == other.DocumentName &&
+		data.Text == other.Text &&
+		data.Summary == other.Summary &&
+		data.Level == other.Level &&
+		data.HasNeo4jEntry == other.HasNeo4jEntry &&
+		data.SourceUri == other.SourceUri &&
+		data.PipelineVersion == other.PipelineVersion &&
+		data.ContentHash == other.ContentHash &&
+		slices.Equal(data.Keywords, other.Keywords) &&
+		slices.Equal(data.Tags, other.Tags) &&
+		slices.Equal(data.Embedding, other.Embedding) &&
+		slices.Equal(normalizeUuids(data.ChildIds), normalizeUuids(other.ChildIds)) &&
+		normalizeUuid(data.ParentId) == normalizeUuid(other.ParentId) &&
+		normalizeUuid(data.PreviousSiblingId) == normalizeUuid(other.PreviousSiblingId) &&
+		normalizeUuid(data.NextSiblingId) == normalizeUuid(other.NextSiblingId) &&
+		normalizeUuid(data.LastChildId) == normalizeUuid(other.LastChildId) &&
+		normalizeUuid(data.FirstChildId) == normalizeUuid(other.FirstChildId) &&
+		(len(data.Metadata) == 0 && len(other.Metadata) == 0 || reflect.DeepEqual(data.Metadata, other.Metadata)) &&
+		(data.IngestedAt == nil && other.IngestedAt == nil || data.IngestedAt != nil && other.IngestedAt != nil && data.IngestedAt.Equal(*other.IngestedAt))
+}
+
+// Merge merges a duplicate of the entry into it.
+// Fields that are set on the entry are kept, unset fields are taken from the duplicate,
+// keywords, tags and child ids are combined and missing metadata keys are added.
+//
+// Parameters:
+//   - other: the duplicate entry, must have the same guid
+//
+// Returns:
+//   - error: an error if the guids differ
+func (data *DbData) Merge(other *DbData) error {
+	if other == nil {
+		return nil
+	}
+	if data.Guid != other.Guid {
+		return fmt.Errorf("cannot merge entry %s into entry %s", other.Guid, data.Guid)
+	}
+
+	mergeString := func(target *string, value string) {
+		if *target == "" {
+			*target = value
+		}
+	}
+	mergeString(&data.DocumentId, other.DocumentId)
+	mergeString(&data.DocumentName, other.DocumentName)
+	mergeString(&data.Text, other.Text)
+	mergeString(&data.Summary, other.Summary)
+	mergeString(&data.SourceUri, other.SourceUri)
+	mergeString(&data.PipelineVersion, other.PipelineVersion)
+	mergeString(&data.ContentHash, other.ContentHash)
+
+	mergeUuid := func(target **uuid.UUID, value *uuid.UUID) {
+		if normalizeUuid(*target) == nil {
+			*target = normalizeUuid(value)
+		}
+	}
+	mergeUuid(&data.ParentId, other.ParentId)
+	mergeUuid(&data.PreviousSiblingId, other.PreviousSiblingId)
+	mergeUuid(&data.NextSiblingId, other.NextSiblingId)
+	mergeUuid(&data.LastChildId, other.LastChildId)
+	mergeUuid(&data.FirstChildId, other.FirstChildId)
+
+	data.Keywords = appendMissing(data.Keywords, other.Keywords)
+	data.Tags = appendMissing(data.Tags, other.Tags)
+	data.ChildIds = appendMissing(normalizeUuids(data.ChildIds), normalizeUuids(other.ChildIds))
+
+	if len(data.Embedding) == 0 {
+		data.Embedding = other.Embedding
+	}
+	if data.Level == 0 {
+		data.Level = other.Level
+	}
+	if data.IngestedAt == nil {
+		data.IngestedAt = other.IngestedAt
+	}
+	data.HasNeo4jEntry = data.HasNeo4jEntry || other.HasNeo4jEntry
+
+	for key, value := range other.Metadata {
+		if data.Metadata == nil {
+			data.Metadata = map[string]interface{}{}
+		}
+		if _, ok := data.Metadata[key]; !ok {
+			data.Metadata[key] = value
+		}
+	}
+	return nil
+}
+
+// dbDataAlias prevents recursion in the DbData JSON methods.
+type dbDataAlias DbData
+
+// MarshalJSON writes unset and nil UUIDs as null and the child ids as an array without nil UUIDs,
+// so all services produce the same JSON for the same entry.
+func (data DbData) MarshalJSON() ([]byte, error) {
+	data.ParentId = normalizeUuid(data.ParentId)
+	data.PreviousSiblingId = normalizeUuid(data.PreviousSiblingId)
+	data.NextSiblingId = normalizeUuid(data.NextSiblingId)
+	data.LastChildId = normalizeUuid(data.LastChildId)
+	data.FirstChildId = normalizeUuid(data.FirstChildId)
+	data.ChildIds = normalizeUuids(data.ChildIds)
+	return json.Marshal(dbDataAlias(data))
+}
+
+// UnmarshalJSON accepts null, empty strings and nil UUIDs for the UUID fields and stores them as unset.
+func (data *DbData) UnmarshalJSON(raw []byte) error {
+	aux := struct {
+		dbDataAlias
+		Guid              *string  `json:"guid"`
+		ParentId          *string  `json:"parent_id"`
+		ChildIds          []string `json:"child_ids"`
+		PreviousSiblingId *string  `json:"previous_sibling_id"`
+		NextSiblingId     *string  `json:"next_sibling_id"`
+		LastChildId       *string  `json:"last_child_id"`
+		FirstChildId      *string  `json:"first_child_id"`
+	}{}
+	if err := json.Unmarshal(raw, &aux); err != nil {
+		return err
+	}
+	*data = DbData(aux.dbDataAlias)
+
+	var err error
+	if data.Guid, err = parseGuid(aux.Guid); err != nil {
+		return err
+	}
+	for _, field := range []struct {
+		target **uuid.UUID
+		value  *string
+		name   string
+	}{
+		{&data.ParentId, aux.ParentId, "parent_id"},
+		{&data.PreviousSiblingId, aux.PreviousSiblingId, "previous_sibling_id"},
+		{&data.NextSiblingId, aux.NextSiblingId, "next_sibling_id"},
+		{&data.LastChildId, aux.LastChildId, "last_child_id"},
+		{&data.FirstChildId, aux.FirstChildId, "first_child_id"},
+	} {
+		if *field.target, err = parseNullableUuid(field.value); err != nil {
+			return fmt.Errorf("invalid %s: %w", field.name, err)
+		}
+	}
+	if data.ChildIds, err = parseUuids(aux.ChildIds); err != nil {
+		return fmt.Errorf("invalid child_ids: %w", err)
+	}
+	return nil
 }
 
 // DbResponse can accommodate non-conflicting data from:
@@ -98,7 +329,7 @@ type DbResponse struct {
 	Text              string                 `json:"text"`
 	Keywords          []string               `json:"keywords"`
 	Summary           string                 `json:"summary"`
-	Embedding         []float32              `json:"embeddings"`
+	Embedding         Embedding              `json:"embeddings"`
 	Tags              []string               `json:"tags"`
 	Metadata          map[string]interface{} `json:"metadata"`
 	ParentId          *uuid.UUID             `json:"parent_id"`
@@ -111,6 +342,12 @@ type DbResponse struct {
 	Level             int                    `json:"level"`
 	HasNeo4jEntry     bool                   `json:"has_neo4j_entry"`
 
+	// Provenance
+	SourceUri       string     `json:"source_uri,omitempty"`
+	IngestedAt      *time.Time `json:"ingested_at,omitempty"`
+	PipelineVersion string     `json:"pipeline_version,omitempty"`
+	ContentHash     string     `json:"content_hash,omitempty"`
+
 	// Siblings
 	Parent    *DbData  `json:"parent,omitempty"`
 	Children  []DbData `json:"children,omitempty"`
@@ -136,6 +373,236 @@ type DbResponse struct {
 	ParentSectionName string `json:"parent_section_name,omitempty"`
 }
 
+// dbResponseAlias prevents recursion in the DbResponse JSON methods.
+type dbResponseAlias DbResponse
+
+// MarshalJSON writes unset and nil UUIDs as null and the child ids as an array without nil UUIDs,
+// so all services produce the same JSON for the same entry.
+func (response DbResponse) MarshalJSON() ([]byte, error) {
+	response.ParentId = normalizeUuid(response.ParentId)
+	response.PreviousSiblingId = normalizeUuid(response.PreviousSiblingId)
+	response.NextSiblingId = normalizeUuid(response.NextSiblingId)
+	response.LastChildId = normalizeUuid(response.LastChildId)
+	response.FirstChildId = normalizeUuid(response.FirstChildId)
+	response.ChildIds = normalizeUuids(response.ChildIds)
+	return json.Marshal(dbResponseAlias(response))
+}
+
+// UnmarshalJSON accepts null, empty strings and nil UUIDs for the UUID fields and stores them as unset.
+func (response *DbResponse) UnmarshalJSON(raw []byte) error {
+	aux := struct {
+		dbResponseAlias
+		Guid              *string  `json:"guid"`
+		ParentId          *string  `json:"parent_id"`
+		ChildIds          []string `json:"child_ids"`
+		PreviousSiblingId *string  `json:"previous_sibling_id"`
+		NextSiblingId     *string  `json:"next_sibling_id"`
+		LastChildId       *string  `json:"last_child_id"`
+		FirstChildId      *string  `json:"first_child_id"`
+	}{}
+	if err := json.Unmarshal(raw, &aux); err != nil {
+		return err
+	}
+	*response = DbResponse(aux.dbResponseAlias)
+
+	var err error
+	if response.Guid, err = parseGuid(aux.Guid); err != nil {
+		return err
+	}
+	for _, field := range []struct {
+		target **uuid.UUID
+		value  *string
+		name   string
+	}{
+		{&response.ParentId, aux.ParentId, "parent_id"},
+		{&response.PreviousSiblingId, aux.PreviousSiblingId, "previous_sibling_id"},
+		{&response.NextSiblingId, aux.NextSiblingId, "next_sibling_id"},
+		{&response.LastChildId, aux.LastChildId, "last_child_id"},
+		{&response.FirstChildId, aux.FirstChildId, "first_child_id"},
+	} {
+		if *field.target, err = parseNullableUuid(field.value); err != nil {
+			return fmt.Errorf("invalid %s: %w", field.name, err)
+		}
+	}
+	if response.ChildIds, err = parseUuids(aux.ChildIds); err != nil {
+		return fmt.Errorf("invalid child_ids: %w", err)
+	}
+	return nil
+}
+
+// normalizeUuid returns nil for unset and nil UUIDs.
+func normalizeUuid(id *uuid.UUID) *uuid.UUID {
+	if id == nil || *id == uuid.Nil {
+		return nil
+	}
+	return id
+}
+
+// normalizeUuids removes nil UUIDs from the list and returns an empty list instead of nil.
+func normalizeUuids(ids []uuid.UUID) []uuid.UUID {
+	normalized := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if id != uuid.Nil {
+			normalized = append(normalized, id)
+		}
+	}
+	return normalized
+}
+
+// parseGuid parses the guid of an entry; a missing or empty guid results in uuid.Nil.
+func parseGuid(value *string) (uuid.UUID, error) {
+	id, err := parseNullableUuid(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid guid: %w", err)
+	}
+	if id == nil {
+		return uuid.Nil, nil
+	}
+	return *id, nil
+}
+
+// parseNullableUuid parses an optional UUID; missing, empty and nil UUIDs result in nil.
+func parseNullableUuid(value *string) (*uuid.UUID, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(*value)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeUuid(&id), nil
+}
+
+// parseUuids parses a list of UUIDs, skipping empty and nil UUIDs.
+func parseUuids(values []string) ([]uuid.UUID, error) {
+	if values == nil {
+		return nil, nil
+	}
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := parseNullableUuid(&value)
+		if err != nil {
+			return nil, err
+		}
+		if id != nil {
+			ids = append(ids, *id)
+		}
+	}
+	return ids, nil
+}
+
+// appendMissing appends the values that are not yet in the list.
+func appendMissing[T comparable](list []T, values []T) []T {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// Page sizes used for knowledge DB search calls.
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// PageRequest represents the request for a single page of knowledge DB search results.
+type PageRequest struct {
+	PageSize int    `json:"page_size"`        // number of results per page; DefaultPageSize if 0, at most MaxPageSize
+	Cursor   string `json:"cursor,omitempty"` // opaque cursor from the previous PageResponse; empty for the first page
+}
+
+// PageResponse represents a single page of knowledge DB search results.
+type PageResponse struct {
+	Items         []DbResponse `json:"items"`
+	NextCursor    string       `json:"next_cursor,omitempty"` // cursor for the next page; empty if this is the last page
+	TotalEstimate int64        `json:"total_estimate"`        // estimated total number of results; -1 if unknown
+}
+
+// PageCursor is the state encoded in the opaque cursor of a PageResponse.
+// Only the service producing the cursor should inspect it; clients pass it back unchanged.
+type PageCursor struct {
+	Offset    int        `json:"o"`           // number of results already returned
+	LastGuid  *uuid.UUID `json:"g,omitempty"` // guid of the last returned result, used as tie breaker for equal scores
+	LastScore *float64   `json:"s,omitempty"` // score of the last returned result, used for keyset pagination
+	Level     *int       `json:"l,omitempty"` // graph level the traversal resumes from, for graph-expanded results
+	QueryHash string     `json:"q,omitempty"` // hash of the query the cursor belongs to, to reject cursors reused for other queries
+}
+
+// Validate checks the page size of the request without modifying it; a page size of 0 selects DefaultPageSize.
+//
+// Returns:
+//   - error: an error if the page size is negative or exceeds MaxPageSize
+func (pr PageRequest) Validate() error {
+	if pr.PageSize < 0 || pr.PageSize > MaxPageSize {
+		return fmt.Errorf("page size must be between 0 and %d, got %d", MaxPageSize, pr.PageSize)
+	}
+	return nil
+}
+
+// Normalize returns a copy of the request with the default page size applied if it is not set.
+//
+// Returns:
+//   - PageRequest: the request with PageSize set
+func (pr PageRequest) Normalize() PageRequest {
+	if pr.PageSize == 0 {
+		pr.PageSize = DefaultPageSize
+	}
+	return pr
+}
+
+// HasMore returns true if there are more results after this page.
+//
+// Returns:
+//   - bool: true if NextCursor is set
+func (pr *PageResponse) HasMore() bool {
+	return pr.NextCursor != ""
+}
+
+// EncodePageCursor encodes the cursor state to an opaque, URL-safe string.
+//
+// Parameters:
+//   - cursor: the cursor state
+//
+// Returns:
+//   - string: the opaque cursor
+//   - error: an error if the cursor could not be serialized
+func EncodePageCursor(cursor PageCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("error serializing page cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodePageCursor decodes an opaque cursor created by EncodePageCursor.
+// An empty cursor decodes to the state of the first page.
+//
+// Parameters:
+//   - cursor: the opaque cursor
+//
+// Returns:
+//   - PageCursor: the cursor state
+//   - error: an error if the cursor is malformed
+func DecodePageCursor(cursor string) (PageCursor, error) {
+	if cursor == "" {
+		return PageCursor{}, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return PageCursor{}, fmt.Errorf("malformed page cursor: %w", err)
+	}
+	var pageCursor PageCursor
+	if err := json.Unmarshal(data, &pageCursor); err != nil {
+		return PageCursor{}, fmt.Errorf("malformed page cursor: %w", err)
+	}
+	if pageCursor.Offset < 0 {
+		return PageCursor{}, fmt.Errorf("malformed page cursor: negative offset %d", pageCursor.Offset)
+	}
+	return pageCursor, nil
+}
+
 // DBListCollectionsOutput represents the output of listing collections in the database.
 type DBListCollectionsOutput struct {
 	Success     bool     `json:"success" description:"Returns true if the collections were listed successfully. Returns false or an error if not."`
@@ -0,0 +1,200 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPageRequestValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      PageRequest
+		expectedSize int
+		expectErr    bool
+	}{
+		{"default size", PageRequest{}, DefaultPageSize, false},
+		{"explicit size", PageRequest{PageSize: 20}, 20, false},
+		{"max size", PageRequest{PageSize: MaxPageSize}, MaxPageSize, false},
+		{"too large", PageRequest{PageSize: MaxPageSize + 1}, MaxPageSize + 1, true},
+		{"negative", PageRequest{PageSize: -5}, -5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.request
+			err := tt.request.Validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.request != original {
+				t.Errorf("Validate() modified the request to %+v", tt.request)
+			}
+			if normalized := tt.request.Normalize(); normalized.PageSize != tt.expectedSize {
+				t.Errorf("Normalize().PageSize = %d, expected %d", normalized.PageSize, tt.expectedSize)
+			}
+		})
+	}
+}
+
+func TestPageCursorRoundTrip(t *testing.T) {
+	guid := uuid.New()
+	cursor := PageCursor{Offset: 200, LastGuid: &guid, LastScore: ptr(0.87), QueryHash: "abc"}
+
+	encoded, err := EncodePageCursor(cursor)
+	if err != nil {
+		t.Fatalf("EncodePageCursor() error = %v", err)
+	}
+
+	decoded, err := DecodePageCursor(encoded)
+	if err != nil {
+		t.Fatalf("DecodePageCursor() error = %v", err)
+	}
+	if decoded.Offset != 200 || decoded.QueryHash != "abc" {
+		t.Errorf("decoded cursor = %+v", decoded)
+	}
+	if decoded.LastGuid == nil || *decoded.LastGuid != guid {
+		t.Errorf("LastGuid = %v, expected %v", decoded.LastGuid, guid)
+	}
+	if decoded.LastScore == nil || *decoded.LastScore != 0.87 {
+		t.Errorf("LastScore = %v, expected 0.87", decoded.LastScore)
+	}
+
+	empty, err := DecodePageCursor("")
+	if err != nil || empty.Offset != 0 || empty.LastGuid != nil {
+		t.Errorf("DecodePageCursor(\"\") = %+v, %v", empty, err)
+	}
+
+	for _, malformed := range []string{"not base64!", "bm90IGpzb24", "eyJvIjotMX0"} {
+		if _, err := DecodePageCursor(malformed); err == nil {
+			t.Errorf("DecodePageCursor(%q) expected an error", malformed)
+		}
+	}
+
+	response := PageResponse{NextCursor: encoded}
+	if !response.HasMore() {
+		t.Error("HasMore() should be true when NextCursor is set")
+	}
+}
+
+func TestDbDataJsonNormalizesUuids(t *testing.T) {
+	input := `{
+		"guid": "6f1c1f9e-5b7a-4c1e-9a57-1d1c6f0b2a10",
+		"parent_id": "",
+		"previous_sibling_id": "00000000-0000-0000-0000-000000000000",
+		"next_sibling_id": null,
+		"first_child_id": "0b7c9a2e-3f41-4a55-8f0e-6d2c1b7a9e33",
+		"child_ids": ["0b7c9a2e-3f41-4a55-8f0e-6d2c1b7a9e33", "", "00000000-0000-0000-0000-000000000000"],
+		"text": "chunk"
+	}`
+
+	var data DbData
+	if err := json.Unmarshal([]byte(input), &data); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if data.Guid.String() != "6f1c1f9e-5b7a-4c1e-9a57-1d1c6f0b2a10" || data.Text != "chunk" {
+		t.Errorf("unexpected data: %+v", data)
+	}
+	if data.ParentId != nil || data.PreviousSiblingId != nil || data.NextSiblingId != nil || data.LastChildId != nil {
+		t.Errorf("empty and nil UUIDs should be unset: %+v", data)
+	}
+	if data.FirstChildId == nil || len(data.ChildIds) != 1 || data.ChildIds[0] != *data.FirstChildId {
+		t.Errorf("unexpected child ids: %v, %v", data.FirstChildId, data.ChildIds)
+	}
+
+	nilId := uuid.Nil
+	output, err := json.Marshal(DbData{Guid: data.Guid, ParentId: &nilId})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded["parent_id"] != nil {
+		t.Errorf("parent_id = %v, expected null", decoded["parent_id"])
+	}
+	if childIds, ok := decoded["child_ids"].([]interface{}); !ok || len(childIds) != 0 {
+		t.Errorf("child_ids = %v, expected []", decoded["child_ids"])
+	}
+
+	var response DbResponse
+	if err := json.Unmarshal([]byte(`{"guid": "not-a-uuid"}`), &response); err == nil {
+		t.Error("Unmarshal() expected an error for an invalid guid")
+	}
+	if err := json.Unmarshal([]byte(input), &response); err != nil || response.PreviousSiblingId != nil || len(response.ChildIds) != 1 {
+		t.Errorf("Unmarshal() DbResponse = %+v, %v", response, err)
+	}
+}
+
+func TestDbDataValidate(t *testing.T) {
+	guid := uuid.New()
+	if err := (&DbData{}).Validate(); err == nil {
+		t.Error("Validate() expected an error for a missing guid")
+	}
+	if err := (&DbData{Guid: guid, ParentId: &guid}).Validate(); err == nil {
+		t.Error("Validate() expected an error for a self reference")
+	}
+	if err := (&DbData{Guid: guid, ChildIds: []uuid.UUID{uuid.New()}}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestDbDataEqualAndMerge(t *testing.T) {
+	guid := uuid.New()
+	parent := uuid.New()
+	nilId := uuid.Nil
+
+	a := DbData{Guid: guid, Text: "chunk", Tags: []string{"a"}, PreviousSiblingId: &nilId}
+	b := DbData{Guid: guid, Text: "chunk", Tags: []string{"a"}, ChildIds: []uuid.UUID{}}
+	if !a.Equal(&b) {
+		t.Error("Equal() should ignore nil UUIDs and empty slices")
+	}
+
+	b.ParentId = &parent
+	b.Tags = []string{"a", "b"}
+	b.Metadata = map[string]interface{}{"version": "2025R1"}
+	if a.Equal(&b) {
+		t.Error("Equal() should detect differing content")
+	}
+
+	if err := a.Merge(&b); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if a.ParentId == nil || *a.ParentId != parent {
+		t.Errorf("ParentId = %v, expected %v", a.ParentId, parent)
+	}
+	if len(a.Tags) != 2 || a.Metadata["version"] != "2025R1" {
+		t.Errorf("unexpected merge result: %+v", a)
+	}
+	if !a.Equal(&b) {
+		t.Errorf("merged entry should equal the duplicate: %+v, %+v", a, b)
+	}
+
+	if err := a.Merge(&DbData{Guid: uuid.New()}); err == nil {
+		t.Error("Merge() expected an error for differing guids")
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// List filter operators
+const (
+	ListFilterOperatorEqual          = "eq"
+	ListFilterOperatorNotEqual       = "ne"
+	ListFilterOperatorLess           = "lt"
+	ListFilterOperatorLessOrEqual    = "lte"
+	ListFilterOperatorGreater        = "gt"
+	ListFilterOperatorGreaterOrEqual = "gte"
+	ListFilterOperatorIn             = "in"       // the value is a comma separated list
+	ListFilterOperatorContains       = "contains" // the field contains the value as substring
+)
+
+// ListFilterOperators are the valid list filter operators.
+var ListFilterOperators = []string{
+	ListFilterOperatorEqual,
+	ListFilterOperatorNotEqual,
+	ListFilterOperatorLess,
+	ListFilterOperatorLessOrEqual,
+	ListFilterOperatorGreater,
+	ListFilterOperatorGreaterOrEqual,
+	ListFilterOperatorIn,
+	ListFilterOperatorContains,
+}
+
+// ListRequest represents the request for a page of a REST list endpoint.
+// In the query string it is written as "limit=50&cursor=...&sort=-created_at,name&filter=status:eq:running",
+// see QueryValues and ParseListRequest.
+type ListRequest struct {
+	Limit   int          `json:"limit"`             // number of items per page; DefaultPageSize if 0, at most MaxPageSize
+	Cursor  string       `json:"cursor,omitempty"`  // opaque cursor from the previous ListResponse; empty for the first page
+	Sort    []SortField  `json:"sort,omitempty"`    // sort order, most significant field first; service default if empty
+	Filters []ListFilter `json:"filters,omitempty"` // conditions all returned items match
+}
+
+// SortField represents a field of the sort order of a list.
+type SortField struct {
+	Field      string `json:"field"`
+	Descending bool   `json:"descending,omitempty"`
+}
+
+// ListFilter represents a condition on a field of the listed items.
+type ListFilter struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"` // one of ListFilterOperators
+	Value    string `json:"value"`
+}
+
+// ListResponse represents a page of a REST list endpoint.
+type ListResponse[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"` // cursor for the next page; empty if this is the last page
+}
+
+// Validate applies the default limit and checks the limit, the sort fields and the filters.
+//
+// Returns:
+//   - error: an error if the request is invalid
+func (r *ListRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = DefaultPageSize
+	}
+	if r.Limit < 0 || r.Limit > MaxPageSize {
+		return fmt.Errorf("limit must be between 1 and %d, got %d", MaxPageSize, r.Limit)
+	}
+	for _, sort := range r.Sort {
+		if sort.Field == "" {
+			return fmt.Errorf("sort field must not be empty")
+		}
+	}
+	for _, filter := range r.Filters {
+		if filter.Field == "" {
+			return fmt.Errorf("filter field must not be empty")
+		}
+		if !slices.Contains(ListFilterOperators, filter.Operator) {
+			return fmt.Errorf("unknown filter operator '%s' for field '%s'", filter.Operator, filter.Field)
+		}
+	}
+	return nil
+}
+
+// CheckFields checks that the request only sorts and filters by the fields supported by the endpoint.
+//
+// Parameters:
+//   - sortable: the fields the endpoint can sort by
+//   - filterable: the fields the endpoint can filter by
+//
+// Returns:
+//   - error: an error naming the first unsupported field
+func (r *ListRequest) CheckFields(sortable []string, filterable []string) error {
+	for _, sort := range r.Sort {
+		if !slices.Contains(sortable, sort.Field) {
+			return fmt.Errorf("cannot sort by '%s'", sort.Field)
+		}
+	}
+	for _, filter := range r.Filters {
+		if !slices.Contains(filterable, filter.Field) {
+			return fmt.Errorf("cannot filter by '%s'", filter.Field)
+		}
+	}
+	return nil
+}
+
+// FilterValues returns the values of the filters of a field with an operator.
+// Values of "in" filters are split at the commas.
+//
+// Parameters:
+//   - field: the field
+//   - operator: one of ListFilterOperators
+//
+// Returns:
+//   - []string: the values; nil if there is no such filter
+func (r *ListRequest) FilterValues(field string, operator string) []string {
+	var values []string
+	for _, filter := range r.Filters {
+		if filter.Field != field || filter.Operator != operator {
+			continue
+		}
+		if operator == ListFilterOperatorIn {
+			values = append(values, strings.Split(filter.Value, ",")...)
+		} else {
+			values = append(values, filter.Value)
+		}
+	}
+	return values
+}
+
+// QueryValues writes the request as query parameters.
+//
+// Returns:
+//   - url.Values: the "limit", "cursor", "sort" and "filter" parameters that are set
+func (r *ListRequest) QueryValues() url.Values {
+	values := url.Values{}
+	if r.Limit != 0 {
+		values.Set("limit", strconv.Itoa(r.Limit))
+	}
+	if r.Cursor != "" {
+		values.Set("cursor", r.Cursor)
+	}
+	if len(r.Sort) > 0 {
+		fields := make([]string, len(r.Sort))
+		for i, sort := range r.Sort {
+			fields[i] = sort.Field
+			if sort.Descending {
+				fields[i] = "-" + sort.Field
+			}
+		}
+		values.Set("sort", strings.Join(fields, ","))
+	}
+	for _, filter := range r.Filters {
+		values.Add("filter", filter.Field+":"+filter.Operator+":"+filter.Value)
+	}
+	return values
+}
+
+// ParseListRequest reads a request from query parameters written by QueryValues and validates it.
+// "sort" is a comma separated list of fields, descending if prefixed with "-"; every "filter" parameter
+// has the format "field:operator:value", where the value may contain colons.
+//
+// Parameters:
+//   - values: the query parameters
+//
+// Returns:
+//   - ListRequest: the request, with the default limit applied
+//   - error: an error if a parameter is malformed or the request is invalid
+func ParseListRequest(values url.Values) (ListRequest, error) {
+	var request ListRequest
+	if limit := values.Get("limit"); limit != "" {
+		var err error
+		request.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return ListRequest{}, fmt.Errorf("invalid limit '%s'", limit)
+		}
+	}
+	request.Cursor = values.Get("cursor")
+	if sort := values.Get("sort"); sort != "" {
+		for _, field := range strings.Split(sort, ",") {
+			name, descending := strings.CutPrefix(strings.TrimSpace(field), "-")
+			request.Sort = append(request.Sort, SortField{Field: name, Descending: descending})
+		}
+	}
+	for _, filter := range values["filter"] {
+		parts := strings.SplitN(filter, ":", 3)
+		if len(parts) != 3 {
+			return ListRequest{}, fmt.Errorf("invalid filter '%s', expected 'field:operator:value'", filter)
+		}
+		request.Filters = append(request.Filters, ListFilter{Field: parts[0], Operator: parts[1], Value: parts[2]})
+	}
+	if err := request.Validate(); err != nil {
+		return ListRequest{}, err
+	}
+	return request, nil
+}
+
+// HasMore checks if there are more items after this page.
+//
+// Returns:
+//   - bool: true if NextCursor is set
+func (r *ListResponse[T]) HasMore() bool {
+	return r.NextCursor != ""
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestListRequestQueryRoundTrip(t *testing.T) {
+	request := ListRequest{
+		Limit:  50,
+		Cursor: "abc",
+		Sort:   []SortField{{Field: "created_at", Descending: true}, {Field: "name"}},
+		Filters: []ListFilter{
+			{Field: "status", Operator: ListFilterOperatorIn, Value: "running,pending"},
+			{Field: "created_at", Operator: ListFilterOperatorGreater, Value: "2026-01-01T00:00:00Z"},
+		},
+	}
+	values := request.QueryValues()
+	if got := values.Get("sort"); got != "-created_at,name" {
+		t.Errorf("sort = %q, want -created_at,name", got)
+	}
+
+	parsed, err := ParseListRequest(values)
+	if err != nil {
+		t.Fatalf("ParseListRequest() error = %v", err)
+	}
+	if !reflect.DeepEqual(parsed, request) {
+		t.Errorf("ParseListRequest() = %+v, want %+v", parsed, request)
+	}
+}
+
+func TestParseListRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    ListRequest
+		wantErr bool
+	}{
+		{"defaults", "", ListRequest{Limit: DefaultPageSize}, false},
+		{"escaped value", "filter=name%3Acontains%3Aa%3Ab", ListRequest{Limit: DefaultPageSize, Filters: []ListFilter{{Field: "name", Operator: ListFilterOperatorContains, Value: "a:b"}}}, false},
+		{"invalid limit", "limit=many", ListRequest{}, true},
+		{"limit too large", "limit=5000", ListRequest{}, true},
+		{"malformed filter", "filter=status", ListRequest{}, true},
+		{"unknown operator", "filter=status:like:run", ListRequest{}, true},
+		{"empty sort field", "sort=name,,id", ListRequest{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParseListRequest(values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseListRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseListRequest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListRequestCheckFields(t *testing.T) {
+	request := ListRequest{
+		Sort:    []SortField{{Field: "created_at"}},
+		Filters: []ListFilter{{Field: "status", Operator: ListFilterOperatorEqual, Value: "failed"}},
+	}
+	if err := request.CheckFields([]string{"created_at"}, []string{"status"}); err != nil {
+		t.Errorf("CheckFields() error = %v", err)
+	}
+	if err := request.CheckFields([]string{"name"}, []string{"status"}); err == nil {
+		t.Error("expected an error for an unsupported sort field")
+	}
+	if err := request.CheckFields([]string{"created_at"}, nil); err == nil {
+		t.Error("expected an error for an unsupported filter field")
+	}
+}
+
+func TestListRequestFilterValues(t *testing.T) {
+	request := ListRequest{Filters: []ListFilter{
+		{Field: "status", Operator: ListFilterOperatorIn, Value: "running,pending"},
+		{Field: "status", Operator: ListFilterOperatorEqual, Value: "failed"},
+	}}
+	if got := request.FilterValues("status", ListFilterOperatorIn); !reflect.DeepEqual(got, []string{"running", "pending"}) {
+		t.Errorf("FilterValues(in) = %v", got)
+	}
+	if got := request.FilterValues("status", ListFilterOperatorEqual); !reflect.DeepEqual(got, []string{"failed"}) {
+		t.Errorf("FilterValues(eq) = %v", got)
+	}
+	if got := request.FilterValues("user_id", ListFilterOperatorEqual); got != nil {
+		t.Errorf("FilterValues() of unfiltered field = %v, want nil", got)
+	}
+}
+
+func TestListResponseHasMore(t *testing.T) {
+	page := ListResponse[WorkflowRunStatusResponse]{NextCursor: "next"}
+	if !page.HasMore() {
+		t.Error("HasMore() = false with a next cursor")
+	}
+	if (&ListResponse[string]{}).HasMore() {
+		t.Error("HasMore() = true without a next cursor")
+	}
+}
@@ -22,25 +22,230 @@
 
 package sharedtypes
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
 // HandlerRequest represents the client request for a specific chat or embeddings operation.
 type HandlerRequest struct {
-	Adapter             string            `json:"adapter"` // "chat", "embeddings"
+	Adapter             string            `json:"adapter"` // "chat", "embeddings"; see the Adapter constants
 	InstructionGuid     string            `json:"instructionGuid"`
 	ModelIds            []string          `json:"modelIds"`                   // optional model ids to define a set of specific models to be used for this request
 	ModelCategory       []string          `json:"modelCategory"`              // optional model category; define one or more categories to filter models; models of the specified categories from first to last will be used for this request if available
 	Data                interface{}       `json:"data"`                       // for embeddings, this can be a string or []string; for chat, only string is allowed
 	Images              []string          `json:"images"`                     // List of images in base64 format
 	MCPTools            []MCPTool         `json:"mcpTools,omitempty"`         // MCP tool definitions for tool calling support
-	ChatRequestType     string            `json:"chatRequestType"`            // "summary", "code", "keywords", "general"; only relevant if "adapter" is "chat"
+	ChatRequestType     string            `json:"chatRequestType"`            // "summary", "code", "keywords", "general"; see the ChatRequestType constants; only relevant if "adapter" is "chat"
 	DataStream          bool              `json:"dataStream"`                 // only relevant if "adapter" is "chat"
 	MaxNumberOfKeywords uint32            `json:"maxNumberOfKeywords"`        // only relevant if "chatRequestType" is "keywords"
 	IsConversation      bool              `json:"isConversation"`             // only relevant if "chatRequestType" is "code"
 	ConversationHistory []HistoricMessage `json:"conversationHistory"`        // only relevant if "isConversation" is true
 	GeneralContext      string            `json:"generalContext"`             // any added context you might need
 	MsgContext          string            `json:"msgContext"`                 // any added context you might need
-	SystemPrompt        interface{}       `json:"systemPrompt"`               // only relevant if "chatRequestType" is "general"
+	SystemPrompt        interface{}       `json:"systemPrompt"`               // string or SystemPrompt, read with GetSystemPrompt(); only relevant if "chatRequestType" is "general"
 	ModelOptions        ModelOptions      `json:"modelOptions,omitempty"`     // only relevant if "adapter" is "chat"
 	EmbeddingOptions    EmbeddingOptions  `json:"embeddingOptions,omitempty"` // only relevant if "adapter" is "embeddings"
+	Guardrails          *GuardrailPolicy  `json:"guardrails,omitempty"`       // optional guardrails the handler enforces for this request
+}
+
+// Adapter is the operation requested from the LLM handler, the value of HandlerRequest.Adapter.
+type Adapter string
+
+// Adapters supported by the LLM handler.
+const (
+	AdapterChat       = "chat"
+	AdapterEmbeddings = "embeddings"
+)
+
+// Adapters lists all supported adapters.
+var Adapters = []Adapter{AdapterChat, AdapterEmbeddings}
+
+// Valid checks if the adapter is supported.
+//
+// Returns:
+//   - bool: true if the adapter is one of Adapters
+func (a Adapter) Valid() bool {
+	return slices.Contains(Adapters, a)
+}
+
+// Validate checks that the adapter is supported.
+//
+// Returns:
+//   - error: an error if the adapter is unknown
+func (a Adapter) Validate() error {
+	if !a.Valid() {
+		return fmt.Errorf("adapter must be one of %v, got %q", Adapters, string(a))
+	}
+	return nil
+}
+
+// IsStreamingCapable reports whether responses of the adapter can be streamed.
+//
+// Returns:
+//   - bool: true for the chat adapter
+func (a Adapter) IsStreamingCapable() bool {
+	return a == AdapterChat
+}
+
+// UnmarshalJSON reads the adapter case-insensitively, so "Chat" is read as AdapterChat.
+// Unknown adapters are kept as they are and rejected by Validate.
+func (a *Adapter) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, Adapters)
+	if err != nil {
+		return fmt.Errorf("error decoding adapter: %w", err)
+	}
+	*a = value
+	return nil
+}
+
+// ChatRequestType is the kind of chat request sent to the LLM handler, the value of HandlerRequest.ChatRequestType.
+type ChatRequestType string
+
+// Chat request types supported by the LLM handler.
+const (
+	ChatRequestTypeSummary  = "summary"
+	ChatRequestTypeCode     = "code"
+	ChatRequestTypeKeywords = "keywords"
+	ChatRequestTypeGeneral  = "general"
+)
+
+// ChatRequestTypes lists all supported chat request types.
+var ChatRequestTypes = []ChatRequestType{ChatRequestTypeSummary, ChatRequestTypeCode, ChatRequestTypeKeywords, ChatRequestTypeGeneral}
+
+// Valid checks if the chat request type is supported.
+//
+// Returns:
+//   - bool: true if the type is one of ChatRequestTypes
+func (t ChatRequestType) Valid() bool {
+	return slices.Contains(ChatRequestTypes, t)
+}
+
+// Validate checks that the chat request type is supported.
+//
+// Returns:
+//   - error: an error if the chat request type is unknown
+func (t ChatRequestType) Validate() error {
+	if !t.Valid() {
+		return fmt.Errorf("chatRequestType must be one of %v, got %q", ChatRequestTypes, string(t))
+	}
+	return nil
+}
+
+// IsStreamingCapable reports whether responses to requests of this type can be streamed.
+// Keywords are returned as one list and cannot be streamed.
+//
+// Returns:
+//   - bool: true for all supported types except keywords
+func (t ChatRequestType) IsStreamingCapable() bool {
+	return t != ChatRequestTypeKeywords && t.Valid()
+}
+
+// UnmarshalJSON reads the chat request type case-insensitively, so "Code" is read as ChatRequestTypeCode.
+// Unknown types are kept as they are and rejected by Validate.
+func (t *ChatRequestType) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, ChatRequestTypes)
+	if err != nil {
+		return fmt.Errorf("error decoding chatRequestType: %w", err)
+	}
+	*t = value
+	return nil
+}
+
+// unmarshalEnum decodes a JSON string into the matching value of an enumeration, ignoring case and surrounding spaces.
+//
+// Parameters:
+//   - data: the JSON value; a string or null
+//   - values: the values of the enumeration
+//
+// Returns:
+//   - E: the matching value, or the decoded string if no value matches
+//   - error: an error if the JSON value is not a string
+func unmarshalEnum[E ~string](data []byte, values []E) (E, error) {
+	var raw *string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return "", err
+	}
+	if raw == nil {
+		return "", nil
+	}
+	for _, value := range values {
+		if strings.EqualFold(strings.TrimSpace(*raw), string(value)) {
+			return value, nil
+		}
+	}
+	return E(*raw), nil
+}
+
+// IsStreamingCapable reports whether the response to the request can be streamed.
+// Chat requests without chat request type are handled as general requests.
+//
+// Returns:
+//   - bool: true if the adapter and the chat request type support streaming
+func (r *HandlerRequest) IsStreamingCapable() bool {
+	if !Adapter(r.Adapter).IsStreamingCapable() {
+		return false
+	}
+	return r.ChatRequestType == "" || ChatRequestType(r.ChatRequestType).IsStreamingCapable()
+}
+
+// handlerRequestAlias prevents recursion in HandlerRequest.UnmarshalJSON.
+type handlerRequestAlias HandlerRequest
+
+// UnmarshalJSON reads the adapter and the chat request type case-insensitively, see Adapter and ChatRequestType.
+func (r *HandlerRequest) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		*handlerRequestAlias
+		Adapter         Adapter         `json:"adapter"`
+		ChatRequestType ChatRequestType `json:"chatRequestType"`
+	}{handlerRequestAlias: (*handlerRequestAlias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.Adapter = string(aux.Adapter)
+	r.ChatRequestType = string(aux.ChatRequestType)
+	return nil
+}
+
+// Validate checks the adapter, the chat request type and their combination with the streaming flag, the model options and the guardrails.
+//
+// Returns:
+//   - error: an error describing the first invalid field or combination, nil if the request is valid
+func (r *HandlerRequest) Validate() error {
+	if err := Adapter(r.Adapter).Validate(); err != nil {
+		return err
+	}
+	switch r.Adapter {
+	case AdapterChat:
+		if r.ChatRequestType != "" {
+			if err := ChatRequestType(r.ChatRequestType).Validate(); err != nil {
+				return err
+			}
+		}
+		if r.DataStream && !r.IsStreamingCapable() {
+			return fmt.Errorf("dataStream is not supported for chatRequestType %q", r.ChatRequestType)
+		}
+		if err := r.ModelOptions.Validate(); err != nil {
+			return fmt.Errorf("invalid modelOptions: %w", err)
+		}
+	case AdapterEmbeddings:
+		if r.ChatRequestType != "" {
+			return fmt.Errorf("chatRequestType is only supported for adapter %q, got adapter %q", AdapterChat, r.Adapter)
+		}
+		if r.DataStream {
+			return fmt.Errorf("dataStream is not supported for adapter %q", r.Adapter)
+		}
+	}
+	if r.Guardrails != nil {
+		if err := r.Guardrails.Validate(); err != nil {
+			return fmt.Errorf("invalid guardrails: %w", err)
+		}
+	}
+	return nil
 }
 
 // HandlerResponse represents the LLM Handler response for a specific request.
@@ -60,15 +265,18 @@ type HandlerResponse struct {
 	ToolCalls           []ToolCall `json:"toolCalls,omitempty"` // Structured tool calls from LLM
 
 	// Embeddings properties
-	EmbeddedData   interface{} `json:"embeddedData,omitempty"`   // []float32 or [][]float32; for BAAI/bge-m3 these are dense vectors
-	LexicalWeights interface{} `json:"lexicalWeights,omitempty"` // map[uint]float32 or []map[uint]float32; only for BAAI/bge-m3
-	ColbertVecs    interface{} `json:"colbertVecs,omitempty"`    // [][]float32 or [][][]float32; only for BAAI/bge-m3
+	EmbeddedData   interface{} `json:"embeddedData,omitempty"`   // []float32, [][]float32 or DenseEmbedding; for BAAI/bge-m3 these are dense vectors; read with Dense()
+	LexicalWeights interface{} `json:"lexicalWeights,omitempty"` // map[uint]float32, []map[uint]float32 or SparseEmbedding; only for BAAI/bge-m3; read with Sparse()
+	ColbertVecs    interface{} `json:"colbertVecs,omitempty"`    // [][]float32, [][][]float32 or ColbertEmbedding; only for BAAI/bge-m3; read with Colbert()
 
 	// Error properties
 	Error *ErrorResponse `json:"error,omitempty"`
 
 	// Info properties
 	InfoMessage *string `json:"infoMessage,omitempty"`
+
+	// Guardrail properties
+	GuardrailEvaluations []GuardrailEvaluation `json:"guardrailEvaluations,omitempty"` // outcome of the guardrails of the request, for auditing
 }
 
 // HasToolCalls returns true if the response contains tool calls.
@@ -88,13 +296,279 @@ type TransferDetails struct {
 	RequestChannel  chan HandlerRequest
 }
 
+// Role is the author of a HistoricMessage, the value of HistoricMessage.Role.
+type Role string
+
+// Roles of the messages of a conversation.
+const (
+	RoleUser      = "user"      // message of the user
+	RoleAssistant = "assistant" // answer of the model
+	RoleSystem    = "system"    // instructions to the model, e.g. the system prompt or a history summary
+	RoleTool      = "tool"      // result of a tool call of the previous assistant message
+)
+
+// Roles lists all supported message roles.
+var Roles = []Role{RoleUser, RoleAssistant, RoleSystem, RoleTool}
+
+// roleAliases maps the role names of other providers to the supported roles.
+var roleAliases = map[string]Role{
+	"human":     RoleUser,
+	"ai":        RoleAssistant,
+	"model":     RoleAssistant,
+	"developer": RoleSystem,
+	"function":  RoleTool,
+}
+
+// NormalizeRole converts a role name to a supported role, ignoring case and surrounding spaces.
+// Role names of other providers, like "human", "model" or "function", are mapped to the matching role.
+//
+// Parameters:
+//   - name: the role name
+//
+// Returns:
+//   - Role: the supported role, or the trimmed name if it matches no role
+//   - bool: true if the name matches a supported role
+func NormalizeRole(name string) (Role, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := roleAliases[name]; ok {
+		return alias, true
+	}
+	role := Role(name)
+	return role, role.Valid()
+}
+
+// Valid checks if the role is supported.
+//
+// Returns:
+//   - bool: true if the role is one of Roles
+func (r Role) Valid() bool {
+	return slices.Contains(Roles, r)
+}
+
+// Validate checks that the role is supported.
+//
+// Returns:
+//   - error: an error if the role is unknown
+func (r Role) Validate() error {
+	if !r.Valid() {
+		return fmt.Errorf("role must be one of %v, got %q", Roles, string(r))
+	}
+	return nil
+}
+
+// UnmarshalJSON reads the role with NormalizeRole, so "User" and "human" are read as RoleUser.
+// Unknown roles are kept as they are and rejected by Validate.
+func (r *Role) UnmarshalJSON(data []byte) error {
+	var raw *string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("error decoding role: %w", err)
+	}
+	if raw == nil {
+		*r = ""
+		return nil
+	}
+	if role, ok := NormalizeRole(*raw); ok {
+		*r = role
+	} else {
+		*r = Role(*raw)
+	}
+	return nil
+}
+
 // HistoricMessage represents a past chat message.
 type HistoricMessage struct {
-	Role       string     `json:"role"`
-	Content    string     `json:"content"`
-	Images     []string   `json:"images"`               // image in base64 format
-	ToolCallId *string    `json:"toolCallId,omitempty"` // Tool call ID for tool responses
-	ToolCalls  []ToolCall `json:"toolCalls,omitempty"`  // Tool calls made by assistant
+	Role         string        `json:"role"` // "user", "assistant", "system" or "tool"; see the Role constants
+	Content      string        `json:"content"`
+	Images       []string      `json:"images"`                 // image in base64 format
+	ToolCallId   *string       `json:"toolCallId,omitempty"`   // Tool call ID for tool responses
+	ToolCalls    []ToolCall    `json:"toolCalls,omitempty"`    // Tool calls made by assistant
+	ContentParts []ContentPart `json:"contentParts,omitempty"` // Ordered multi-modal content; if set, takes precedence over Content and Images
+}
+
+// Content part types supported in ContentPart.
+const (
+	ContentPartText       = "text"
+	ContentPartImage      = "image"
+	ContentPartFile       = "file"
+	ContentPartToolUse    = "tool_use"
+	ContentPartToolResult = "tool_result"
+)
+
+// ContentPart represents a single piece of content within a HistoricMessage.
+// Only the fields relevant for the given type are set.
+type ContentPart struct {
+	Type       string      `json:"type"`                 // "text", "image", "file", "tool_use", "tool_result"
+	Text       string      `json:"text,omitempty"`       // only for type "text"
+	Data       string      `json:"data,omitempty"`       // base64 encoded content; for type "image" and "file"
+	URI        string      `json:"uri,omitempty"`        // reference to the content instead of inline data; for type "image" and "file"
+	MimeType   string      `json:"mimeType,omitempty"`   // for type "image" and "file"
+	FileName   string      `json:"fileName,omitempty"`   // only for type "file"
+	ToolUse    *ToolCall   `json:"toolUse,omitempty"`    // only for type "tool_use"
+	ToolResult *ToolResult `json:"toolResult,omitempty"` // only for type "tool_result"
+}
+
+// Validate checks that the content part has a known type and the payload required by that type.
+//
+// Returns:
+//   - error: an error if the content part is invalid
+func (cp *ContentPart) Validate() error {
+	switch cp.Type {
+	case ContentPartText:
+		return nil
+	case ContentPartImage, ContentPartFile:
+		if cp.Data == "" && cp.URI == "" {
+			return fmt.Errorf("content part of type %q requires either data or uri", cp.Type)
+		}
+	case ContentPartToolUse:
+		if cp.ToolUse == nil {
+			return fmt.Errorf("content part of type %q requires toolUse", cp.Type)
+		}
+	case ContentPartToolResult:
+		if cp.ToolResult == nil {
+			return fmt.Errorf("content part of type %q requires toolResult", cp.Type)
+		}
+	default:
+		return fmt.Errorf("unknown content part type %q", cp.Type)
+	}
+	return nil
+}
+
+// Validate checks the role of the message, its content parts and that only tool messages answer tool calls.
+//
+// Returns:
+//   - error: an error describing the first invalid field, nil if the message is valid
+func (hm *HistoricMessage) Validate() error {
+	if err := Role(hm.Role).Validate(); err != nil {
+		return err
+	}
+	if hm.ToolCallId != nil && hm.Role != RoleTool {
+		return fmt.Errorf("toolCallId is only supported for role %q, got %q", RoleTool, hm.Role)
+	}
+	if len(hm.ToolCalls) > 0 && hm.Role != RoleAssistant {
+		return fmt.Errorf("toolCalls are only supported for role %q, got %q", RoleAssistant, hm.Role)
+	}
+	for i := range hm.ContentParts {
+		if err := hm.ContentParts[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetContentParts returns the content of the message as an ordered list of content parts.
+// For messages without ContentParts, the parts are derived from Content, Images, ToolCalls and ToolCallId.
+//
+// Returns:
+//   - []ContentPart: the content parts of the message
+func (hm *HistoricMessage) GetContentParts() []ContentPart {
+	if len(hm.ContentParts) > 0 {
+		return hm.ContentParts
+	}
+
+	parts := []ContentPart{}
+	if hm.ToolCallId != nil {
+		parts = append(parts, ContentPart{
+			Type:       ContentPartToolResult,
+			ToolResult: &ToolResult{ToolCallID: *hm.ToolCallId, Content: hm.Content},
+		})
+	} else if hm.Content != "" {
+		parts = append(parts, ContentPart{Type: ContentPartText, Text: hm.Content})
+	}
+	for _, image := range hm.Images {
+		parts = append(parts, ContentPart{Type: ContentPartImage, Data: image})
+	}
+	for i := range hm.ToolCalls {
+		parts = append(parts, ContentPart{Type: ContentPartToolUse, ToolUse: &hm.ToolCalls[i]})
+	}
+	return parts
+}
+
+// flattenContentParts joins the text of the text and tool result parts, the legacy "content" of a message.
+func flattenContentParts(parts []ContentPart) string {
+	texts := []string{}
+	for _, part := range parts {
+		switch {
+		case part.Type == ContentPartText:
+			texts = append(texts, part.Text)
+		case part.Type == ContentPartToolResult && part.ToolResult != nil:
+			texts = append(texts, part.ToolResult.Content)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// toolResultCallId returns the tool call id of the first tool result part, the legacy "toolCallId" of a message.
+func toolResultCallId(parts []ContentPart) *string {
+	for _, part := range parts {
+		if part.Type == ContentPartToolResult && part.ToolResult != nil && part.ToolResult.ToolCallID != "" {
+			id := part.ToolResult.ToolCallID
+			return &id
+		}
+	}
+	return nil
+}
+
+// historicMessageAlias prevents recursion in the HistoricMessage JSON methods.
+type historicMessageAlias HistoricMessage
+
+// MarshalJSON keeps the legacy "content" and "images" fields populated when the message is built from content parts,
+// so consumers that do not know about "contentParts" still receive the text and images of the message.
+func (hm HistoricMessage) MarshalJSON() ([]byte, error) {
+	if len(hm.ContentParts) > 0 {
+		if hm.Content == "" {
+			hm.Content = flattenContentParts(hm.ContentParts)
+		}
+		if hm.ToolCallId == nil && hm.Role == RoleTool {
+			hm.ToolCallId = toolResultCallId(hm.ContentParts)
+		}
+		if len(hm.Images) == 0 {
+			for _, part := range hm.ContentParts {
+				if part.Type == ContentPartImage && part.Data != "" {
+					hm.Images = append(hm.Images, part.Data)
+				}
+			}
+		}
+	}
+	return json.Marshal(historicMessageAlias(hm))
+}
+
+// UnmarshalJSON accepts "content" either as a plain string or as an array of content parts.
+// In the latter case, the parts are stored in ContentParts and their text is joined into Content.
+// The role is read with NormalizeRole, see Role.
+func (hm *HistoricMessage) UnmarshalJSON(data []byte) error {
+	aux := struct {
+		historicMessageAlias
+		Role    Role            `json:"role"`
+		Content json.RawMessage `json:"content"`
+	}{}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*hm = HistoricMessage(aux.historicMessageAlias)
+	hm.Role = string(aux.Role)
+
+	trimmed := bytes.TrimSpace(aux.Content)
+	switch {
+	case len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")):
+		hm.Content = ""
+	case trimmed[0] == '[':
+		var parts []ContentPart
+		if err := json.Unmarshal(trimmed, &parts); err != nil {
+			return fmt.Errorf("error decoding content parts: %w", err)
+		}
+		hm.Content = flattenContentParts(parts)
+		if len(hm.ContentParts) == 0 {
+			hm.ContentParts = parts
+		}
+		if hm.ToolCallId == nil && hm.Role == RoleTool {
+			hm.ToolCallId = toolResultCallId(parts)
+		}
+	default:
+		if err := json.Unmarshal(trimmed, &hm.Content); err != nil {
+			return fmt.Errorf("error decoding content: %w", err)
+		}
+	}
+	return nil
 }
 
 // ModelOptions represents options for provider-specific API calls.
@@ -116,13 +590,183 @@ type ModelOptions struct {
 	ThinkingMode         *string `json:"thinkingMode,omitempty" yaml:"THINKING_MODE,omitempty"`                  // "adaptive" | "enabled" | "disabled"
 	ThinkingBudgetTokens *int64  `json:"thinkingBudgetTokens,omitempty" yaml:"THINKING_BUDGET_TOKENS,omitempty"` // min 1024, must be < max_tokens
 	ThinkingDisplayMode  *string `json:"thinkingDisplayMode,omitempty" yaml:"THINKING_DISPLAY_MODE,omitempty"`   // "summarized" | "omitted"
+
+	// Deterministic sampling and structured output
+	Seed           *int64                 `json:"seed,omitempty" yaml:"SEED,omitempty"`
+	ResponseFormat *string                `json:"responseFormat,omitempty" yaml:"RESPONSE_FORMAT,omitempty"` // "text" | "json_object" | "json_schema"
+	ResponseSchema map[string]interface{} `json:"responseSchema,omitempty" yaml:"RESPONSE_SCHEMA,omitempty"` // JSON schema; only relevant if "responseFormat" is "json_schema"
+
+	// Log probabilities of the output tokens
+	Logprobs    *bool  `json:"logprobs,omitempty" yaml:"LOGPROBS,omitempty"`
+	TopLogprobs *int32 `json:"topLogprobs,omitempty" yaml:"TOP_LOGPROBS,omitempty"` // only relevant if "logprobs" is true
+
+	// Tool calling
+	ParallelToolCalls *bool `json:"parallelToolCalls,omitempty" yaml:"PARALLEL_TOOL_CALLS,omitempty"`
+
+	// Provider-specific parameters that are not covered by the fields above; passed through as-is
+	ProviderOptions map[string]interface{} `json:"providerOptions,omitempty" yaml:"PROVIDER_OPTIONS,omitempty"`
+}
+
+// Supported values for the enumerated ModelOptions fields.
+var (
+	ModelOptionsReasoningEfforts     = []string{"none", "minimal", "low", "medium", "high"}
+	ModelOptionsReasoningSummaries   = []string{"auto", "concise", "detailed"}
+	ModelOptionsVerbosities          = []string{"low", "medium", "high"}
+	ModelOptionsThinkingModes        = []string{"adaptive", "enabled", "disabled"}
+	ModelOptionsThinkingDisplayModes = []string{"summarized", "omitted"}
+	ModelOptionsResponseFormats      = []string{"text", "json_object", "json_schema"}
+)
+
+// Validate checks the model options for values that no provider accepts.
+// Unset options are not validated.
+//
+// Returns:
+//   - error: an error describing the first invalid option, nil if all options are valid
+func (mo *ModelOptions) Validate() error {
+	if mo.Temperature != nil && (*mo.Temperature < 0 || *mo.Temperature > 2) {
+		return fmt.Errorf("temperature must be between 0 and 2, got %v", *mo.Temperature)
+	}
+	if mo.TopP != nil && (*mo.TopP < 0 || *mo.TopP > 1) {
+		return fmt.Errorf("topP must be between 0 and 1, got %v", *mo.TopP)
+	}
+	if mo.FrequencyPenalty != nil && (*mo.FrequencyPenalty < -2 || *mo.FrequencyPenalty > 2) {
+		return fmt.Errorf("frequencyPenalty must be between -2 and 2, got %v", *mo.FrequencyPenalty)
+	}
+	if mo.PresencePenalty != nil && (*mo.PresencePenalty < -2 || *mo.PresencePenalty > 2) {
+		return fmt.Errorf("presencePenalty must be between -2 and 2, got %v", *mo.PresencePenalty)
+	}
+	if mo.MaxTokens != nil && *mo.MaxTokens <= 0 {
+		return fmt.Errorf("maxTokens must be positive, got %v", *mo.MaxTokens)
+	}
+	if mo.TopLogprobs != nil && (*mo.TopLogprobs < 0 || *mo.TopLogprobs > 20) {
+		return fmt.Errorf("topLogprobs must be between 0 and 20, got %v", *mo.TopLogprobs)
+	}
+
+	enums := []struct {
+		name    string
+		value   *string
+		allowed []string
+	}{
+		{"reasoningEffort", mo.ReasoningEffort, ModelOptionsReasoningEfforts},
+		{"reasoningSummary", mo.ReasoningSummary, ModelOptionsReasoningSummaries},
+		{"verbosity", mo.Verbosity, ModelOptionsVerbosities},
+		{"thinkingMode", mo.ThinkingMode, ModelOptionsThinkingModes},
+		{"thinkingDisplayMode", mo.ThinkingDisplayMode, ModelOptionsThinkingDisplayModes},
+		{"responseFormat", mo.ResponseFormat, ModelOptionsResponseFormats},
+	}
+	for _, enum := range enums {
+		if enum.value != nil && !slices.Contains(enum.allowed, *enum.value) {
+			return fmt.Errorf("%s must be one of %v, got %q", enum.name, enum.allowed, *enum.value)
+		}
+	}
+
+	if mo.ThinkingBudgetTokens != nil {
+		if *mo.ThinkingBudgetTokens < 1024 {
+			return fmt.Errorf("thinkingBudgetTokens must be at least 1024, got %v", *mo.ThinkingBudgetTokens)
+		}
+		if mo.MaxTokens != nil && *mo.ThinkingBudgetTokens >= int64(*mo.MaxTokens) {
+			return fmt.Errorf("thinkingBudgetTokens (%v) must be smaller than maxTokens (%v)", *mo.ThinkingBudgetTokens, *mo.MaxTokens)
+		}
+	}
+	if mo.ResponseFormat != nil && *mo.ResponseFormat == "json_schema" && len(mo.ResponseSchema) == 0 {
+		return fmt.Errorf("responseSchema must be defined if responseFormat is \"json_schema\"")
+	}
+
+	return nil
+}
+
+// GetProviderOptionString returns the string provider option for the given key.
+//
+// Parameters:
+//   - key: the name of the provider option
+//
+// Returns:
+//   - string: the value of the provider option
+//   - bool: true if the option exists and is a string
+func (mo *ModelOptions) GetProviderOptionString(key string) (string, bool) {
+	value, ok := mo.ProviderOptions[key].(string)
+	return value, ok
+}
+
+// GetProviderOptionBool returns the boolean provider option for the given key.
+//
+// Parameters:
+//   - key: the name of the provider option
+//
+// Returns:
+//   - bool: the value of the provider option
+//   - bool: true if the option exists and is a boolean
+func (mo *ModelOptions) GetProviderOptionBool(key string) (bool, bool) {
+	value, ok := mo.ProviderOptions[key].(bool)
+	return value, ok
+}
+
+// GetProviderOptionFloat returns the numeric provider option for the given key as float64.
+//
+// Parameters:
+//   - key: the name of the provider option
+//
+// Returns:
+//   - float64: the value of the provider option
+//   - bool: true if the option exists and is numeric
+func (mo *ModelOptions) GetProviderOptionFloat(key string) (float64, bool) {
+	switch value := mo.ProviderOptions[key].(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case json.Number:
+		f, err := value.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// GetProviderOptionInt returns the integer provider option for the given key.
+// Numbers decoded from JSON are accepted as long as they are whole and within the int64 range.
+//
+// Parameters:
+//   - key: the name of the provider option
+//
+// Returns:
+//   - int64: the value of the provider option
+//   - bool: true if the option exists and is an integer
+func (mo *ModelOptions) GetProviderOptionInt(key string) (int64, bool) {
+	switch value := mo.ProviderOptions[key].(type) {
+	case int:
+		return int64(value), true
+	case int32:
+		return int64(value), true
+	case int64:
+		return value, true
+	case float64:
+		// -2^63 is an int64, 2^63 is not; NaN and infinities fail the comparisons
+		if !(value >= math.MinInt64 && value < math.MaxInt64) || value != math.Trunc(value) {
+			return 0, false
+		}
+		return int64(value), true
+	case json.Number:
+		i, err := value.Int64()
+		return i, err == nil
+	default:
+		return 0, false
+	}
 }
 
 // EmbeddingOptions represents the options for an embeddings request.
 type EmbeddingOptions struct {
-	ReturnDense   *bool `json:"returnDense"`   // Include dense vectors in response
-	ReturnSparse  *bool `json:"returnSparse"`  // Include lexical weights in response
-	ReturnColbert *bool `json:"returnColbert"` // Include colbert vectors in response
+	ReturnDense   *bool `json:"returnDense"`          // Include dense vectors in response
+	ReturnSparse  *bool `json:"returnSparse"`         // Include lexical weights in response
+	ReturnColbert *bool `json:"returnColbert"`        // Include colbert vectors in response
+	Dimensions    *int  `json:"dimensions,omitempty"` // Output dimensionality; only for models supporting it
+	Normalize     *bool `json:"normalize,omitempty"`  // L2 normalize the dense vectors
 }
 
 // EmbeddingResult holds both dense and sparse embeddings
@@ -141,8 +785,9 @@ type ToolCall struct {
 
 // ToolResult represents the result of a tool execution.
 type ToolResult struct {
-	ToolCallID   string           `json:"tool_call_id"`            // Matches the ID from the original tool call
-	Content      string           `json:"content"`                 // Primary text content
-	ContentItems []MCPContentItem `json:"content_items,omitempty"` // Content items for multi-modal support
-	IsError      bool             `json:"is_error"`                // True if tool execution failed
+	ToolCallID   string                 `json:"tool_call_id"`            // Matches the ID from the original tool call
+	Content      string                 `json:"content"`                 // Primary text content; the flattened Contents for results created with NewToolResult
+	ContentItems []MCPContentItem       `json:"content_items,omitempty"` // Content items for multi-modal support
+	Contents     []MCPToolResultContent `json:"contents,omitempty"`      // Content blocks as returned by the MCP tool
+	IsError      bool                   `json:"is_error"`                // True if tool execution failed
 }
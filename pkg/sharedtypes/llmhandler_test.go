@@ -0,0 +1,450 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestModelOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   ModelOptions
+		expectErr bool
+	}{
+		{
+			name:    "empty options",
+			options: ModelOptions{},
+		},
+		{
+			name: "valid options",
+			options: ModelOptions{
+				Temperature:          ptr(float32(0.7)),
+				TopP:                 ptr(float32(0.9)),
+				MaxTokens:            ptr(int32(4096)),
+				ReasoningEffort:      ptr("medium"),
+				ThinkingMode:         ptr("enabled"),
+				ThinkingBudgetTokens: ptr(int64(2048)),
+				ResponseFormat:       ptr("json_object"),
+			},
+		},
+		{
+			name:      "temperature out of range",
+			options:   ModelOptions{Temperature: ptr(float32(2.5))},
+			expectErr: true,
+		},
+		{
+			name:      "negative top p",
+			options:   ModelOptions{TopP: ptr(float32(-0.1))},
+			expectErr: true,
+		},
+		{
+			name:      "unknown reasoning effort",
+			options:   ModelOptions{ReasoningEffort: ptr("extreme")},
+			expectErr: true,
+		},
+		{
+			name:      "thinking budget too small",
+			options:   ModelOptions{ThinkingBudgetTokens: ptr(int64(512))},
+			expectErr: true,
+		},
+		{
+			name:      "thinking budget exceeds max tokens",
+			options:   ModelOptions{MaxTokens: ptr(int32(2000)), ThinkingBudgetTokens: ptr(int64(2000))},
+			expectErr: true,
+		},
+		{
+			name:      "json schema without schema",
+			options:   ModelOptions{ResponseFormat: ptr("json_schema")},
+			expectErr: true,
+		},
+		{
+			name:    "json schema with schema",
+			options: ModelOptions{ResponseFormat: ptr("json_schema"), ResponseSchema: map[string]interface{}{"type": "object"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestModelOptionsProviderOptions(t *testing.T) {
+	var options ModelOptions
+	err := json.Unmarshal([]byte(`{"providerOptions":{"region":"eu","cache":true,"budget":42,"ratio":0.5}}`), &options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if value, ok := options.GetProviderOptionString("region"); !ok || value != "eu" {
+		t.Errorf("GetProviderOptionString() = %q, %v", value, ok)
+	}
+	if value, ok := options.GetProviderOptionBool("cache"); !ok || !value {
+		t.Errorf("GetProviderOptionBool() = %v, %v", value, ok)
+	}
+	if value, ok := options.GetProviderOptionInt("budget"); !ok || value != 42 {
+		t.Errorf("GetProviderOptionInt() = %v, %v", value, ok)
+	}
+	if _, ok := options.GetProviderOptionInt("ratio"); ok {
+		t.Error("GetProviderOptionInt() should not accept fractional numbers")
+	}
+	for _, value := range []float64{1e300, -1e300, math.Pow(2, 63), math.NaN(), math.Inf(1), math.Inf(-1)} {
+		options := ModelOptions{ProviderOptions: map[string]interface{}{"budget": value}}
+		if got, ok := options.GetProviderOptionInt("budget"); ok {
+			t.Errorf("GetProviderOptionInt() of %v = %v, want not ok", value, got)
+		}
+	}
+	options.ProviderOptions["min"] = float64(math.MinInt64)
+	if value, ok := options.GetProviderOptionInt("min"); !ok || value != math.MinInt64 {
+		t.Errorf("GetProviderOptionInt() of -2^63 = %v, %v", value, ok)
+	}
+	if value, ok := options.GetProviderOptionFloat("ratio"); !ok || value != 0.5 {
+		t.Errorf("GetProviderOptionFloat() = %v, %v", value, ok)
+	}
+	if _, ok := options.GetProviderOptionString("missing"); ok {
+		t.Error("GetProviderOptionString() should return false for missing keys")
+	}
+}
+
+func TestHistoricMessageLegacyJSON(t *testing.T) {
+	input := `{"role":"user","content":"hello","images":["aW1n"]}`
+
+	var message HistoricMessage
+	if err := json.Unmarshal([]byte(input), &message); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if message.Content != "hello" || len(message.Images) != 1 || len(message.ContentParts) != 0 {
+		t.Fatalf("unexpected message: %+v", message)
+	}
+
+	parts := message.GetContentParts()
+	if len(parts) != 2 || parts[0].Type != ContentPartText || parts[1].Type != ContentPartImage || parts[1].Data != "aW1n" {
+		t.Errorf("unexpected content parts: %+v", parts)
+	}
+
+	output, err := json.Marshal(message)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(output) != input {
+		t.Errorf("json.Marshal() = %s, want %s", output, input)
+	}
+}
+
+func TestHistoricMessageContentParts(t *testing.T) {
+	message := HistoricMessage{
+		Role: "assistant",
+		ContentParts: []ContentPart{
+			{Type: ContentPartText, Text: "Let me check."},
+			{Type: ContentPartImage, Data: "aW1n", MimeType: "image/png"},
+			{Type: ContentPartToolUse, ToolUse: &ToolCall{ID: "call_1", Type: "function", Name: "search"}},
+		},
+	}
+
+	output, err := json.Marshal(message)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Legacy consumers still see the flattened content and images
+	var legacy struct {
+		Content string   `json:"content"`
+		Images  []string `json:"images"`
+	}
+	if err := json.Unmarshal(output, &legacy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if legacy.Content != "Let me check." || len(legacy.Images) != 1 || legacy.Images[0] != "aW1n" {
+		t.Errorf("unexpected legacy shape: %+v", legacy)
+	}
+
+	// Round trip keeps all parts
+	var decoded HistoricMessage
+	if err := json.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(decoded.ContentParts) != 3 || decoded.ContentParts[2].ToolUse == nil || decoded.ContentParts[2].ToolUse.ID != "call_1" {
+		t.Errorf("unexpected decoded parts: %+v", decoded.ContentParts)
+	}
+}
+
+func TestHistoricMessageContentArray(t *testing.T) {
+	input := `{"role":"user","content":[{"type":"text","text":"first"},{"type":"file","uri":"s3://bucket/doc.pdf","fileName":"doc.pdf"},{"type":"text","text":"second"}]}`
+
+	var message HistoricMessage
+	if err := json.Unmarshal([]byte(input), &message); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if message.Content != "first\nsecond" {
+		t.Errorf("Content = %q, want %q", message.Content, "first\nsecond")
+	}
+	if len(message.ContentParts) != 3 || message.ContentParts[1].FileName != "doc.pdf" {
+		t.Errorf("unexpected content parts: %+v", message.ContentParts)
+	}
+	for _, part := range message.ContentParts {
+		if err := part.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	}
+}
+
+func TestHistoricMessageToolResultRoundTrip(t *testing.T) {
+	parts := []ContentPart{
+		{Type: ContentPartToolResult, ToolResult: &ToolResult{ToolCallID: "call_1", Content: "42 results"}},
+		{Type: ContentPartText, Text: "done"},
+	}
+
+	// the same parts sent as content parts or as a content array decode to the same message
+	fromParts, err := json.Marshal(HistoricMessage{Role: RoleTool, ContentParts: parts})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	partsJSON, _ := json.Marshal(parts)
+	fromArray := []byte(`{"role":"tool","content":` + string(partsJSON) + `}`)
+
+	var decodedParts, decodedArray HistoricMessage
+	if err := json.Unmarshal(fromParts, &decodedParts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := json.Unmarshal(fromArray, &decodedArray); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, decoded := range []HistoricMessage{decodedParts, decodedArray} {
+		if decoded.Content != "42 results\ndone" {
+			t.Errorf("Content = %q, want the tool result and text joined", decoded.Content)
+		}
+		if decoded.ToolCallId == nil || *decoded.ToolCallId != "call_1" {
+			t.Errorf("ToolCallId = %v, want call_1", decoded.ToolCallId)
+		}
+		if !reflect.DeepEqual(decoded.ContentParts, parts) {
+			t.Errorf("ContentParts = %+v, want %+v", decoded.ContentParts, parts)
+		}
+		if err := decoded.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	}
+
+	// marshaling a decoded message again is stable
+	again, err := json.Marshal(decodedArray)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(again) != string(fromParts) {
+		t.Errorf("json.Marshal() = %s, want %s", again, fromParts)
+	}
+}
+
+func TestContentPartValidate(t *testing.T) {
+	invalid := []ContentPart{
+		{Type: "video"},
+		{Type: ContentPartImage},
+		{Type: ContentPartToolUse},
+		{Type: ContentPartToolResult},
+	}
+	for _, part := range invalid {
+		if err := part.Validate(); err == nil {
+			t.Errorf("Validate() for %+v should fail", part)
+		}
+	}
+}
+
+func TestNormalizeRole(t *testing.T) {
+	tests := []struct {
+		name  string
+		want  Role
+		known bool
+	}{
+		{"user", RoleUser, true},
+		{" Assistant ", RoleAssistant, true},
+		{"SYSTEM", RoleSystem, true},
+		{"tool", RoleTool, true},
+		{"human", RoleUser, true},
+		{"model", RoleAssistant, true},
+		{"function", RoleTool, true},
+		{"usr", "usr", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		role, known := NormalizeRole(tt.name)
+		if role != tt.want || known != tt.known {
+			t.Errorf("NormalizeRole(%q) = %q, %v, want %q, %v", tt.name, role, known, tt.want, tt.known)
+		}
+	}
+}
+
+func TestRoleJSON(t *testing.T) {
+	var messages []HistoricMessage
+	if err := json.Unmarshal([]byte(`[{"role":"User"},{"role":"ai"},{"role":"usr"},{"role":null}]`), &messages); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	want := []string{RoleUser, RoleAssistant, "usr", ""}
+	for i, message := range messages {
+		if message.Role != want[i] {
+			t.Errorf("message %d has role %q, want %q", i, message.Role, want[i])
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`{"role":1}`), &HistoricMessage{}); err == nil {
+		t.Error("expected error for non-string role")
+	}
+}
+
+func TestHistoricMessageValidate(t *testing.T) {
+	toolCallId := "call-1"
+	tests := []struct {
+		name      string
+		message   HistoricMessage
+		expectErr bool
+	}{
+		{"user", HistoricMessage{Role: RoleUser, Content: "hi"}, false},
+		{"assistant with tool calls", HistoricMessage{Role: RoleAssistant, ToolCalls: []ToolCall{{ID: toolCallId}}}, false},
+		{"tool result", HistoricMessage{Role: RoleTool, ToolCallId: &toolCallId, Content: "42"}, false},
+		{"missing role", HistoricMessage{Content: "hi"}, true},
+		{"unknown role", HistoricMessage{Role: "usr", Content: "hi"}, true},
+		{"tool call id on user message", HistoricMessage{Role: RoleUser, ToolCallId: &toolCallId}, true},
+		{"tool calls on user message", HistoricMessage{Role: RoleUser, ToolCalls: []ToolCall{{ID: toolCallId}}}, true},
+		{"invalid content part", HistoricMessage{Role: RoleUser, ContentParts: []ContentPart{{Type: "video"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.message.Validate(); (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestHandlerRequestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   HandlerRequest
+		expectErr bool
+	}{
+		{name: "chat", request: HandlerRequest{Adapter: AdapterChat, ChatRequestType: ChatRequestTypeGeneral, DataStream: true}},
+		{name: "chat without type", request: HandlerRequest{Adapter: AdapterChat, DataStream: true}},
+		{name: "keywords", request: HandlerRequest{Adapter: AdapterChat, ChatRequestType: ChatRequestTypeKeywords, MaxNumberOfKeywords: 5}},
+		{name: "embeddings", request: HandlerRequest{Adapter: AdapterEmbeddings}},
+		{name: "missing adapter", request: HandlerRequest{}, expectErr: true},
+		{name: "unknown adapter", request: HandlerRequest{Adapter: "completion"}, expectErr: true},
+		{name: "unknown chat request type", request: HandlerRequest{Adapter: AdapterChat, ChatRequestType: "poem"}, expectErr: true},
+		{name: "streamed keywords", request: HandlerRequest{Adapter: AdapterChat, ChatRequestType: ChatRequestTypeKeywords, DataStream: true}, expectErr: true},
+		{name: "invalid model options", request: HandlerRequest{Adapter: AdapterChat, ModelOptions: ModelOptions{Temperature: ptr(float32(3))}}, expectErr: true},
+		{name: "embeddings with chat request type", request: HandlerRequest{Adapter: AdapterEmbeddings, ChatRequestType: ChatRequestTypeSummary}, expectErr: true},
+		{name: "streamed embeddings", request: HandlerRequest{Adapter: AdapterEmbeddings, DataStream: true}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if tt.expectErr && err == nil {
+				t.Errorf("expected error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestHandlerRequestIsStreamingCapable(t *testing.T) {
+	tests := []struct {
+		adapter         string
+		chatRequestType string
+		expected        bool
+	}{
+		{AdapterChat, "", true},
+		{AdapterChat, ChatRequestTypeSummary, true},
+		{AdapterChat, ChatRequestTypeCode, true},
+		{AdapterChat, ChatRequestTypeGeneral, true},
+		{AdapterChat, ChatRequestTypeKeywords, false},
+		{AdapterChat, "poem", false},
+		{AdapterEmbeddings, "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		request := HandlerRequest{Adapter: tt.adapter, ChatRequestType: tt.chatRequestType}
+		if got := request.IsStreamingCapable(); got != tt.expected {
+			t.Errorf("IsStreamingCapable() of %q/%q = %v, want %v", tt.adapter, tt.chatRequestType, got, tt.expected)
+		}
+	}
+}
+
+func TestEnumValid(t *testing.T) {
+	if !Adapter(AdapterChat).Valid() || Adapter("completion").Valid() {
+		t.Error("Adapter.Valid() does not match Adapters")
+	}
+	if !ChatRequestType(ChatRequestTypeCode).Valid() || ChatRequestType("poem").Valid() || ChatRequestType("").Valid() {
+		t.Error("ChatRequestType.Valid() does not match ChatRequestTypes")
+	}
+	if !Role(RoleTool).Valid() || Role("usr").Valid() {
+		t.Error("Role.Valid() does not match Roles")
+	}
+}
+
+func TestHandlerRequestEnumJSON(t *testing.T) {
+	var request HandlerRequest
+	if err := json.Unmarshal([]byte(`{"adapter":" Chat ","chatRequestType":"KEYWORDS"}`), &request); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if request.Adapter != AdapterChat || request.ChatRequestType != ChatRequestTypeKeywords {
+		t.Errorf("decoded %q/%q, want chat/keywords", request.Adapter, request.ChatRequestType)
+	}
+
+	// unknown values are kept for Validate to report them
+	request = HandlerRequest{}
+	if err := json.Unmarshal([]byte(`{"adapter":"completion","chatRequestType":null}`), &request); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if request.Adapter != "completion" || request.ChatRequestType != "" {
+		t.Errorf("decoded %q/%q, want completion and empty type", request.Adapter, request.ChatRequestType)
+	}
+
+	if err := json.Unmarshal([]byte(`{"adapter":1}`), &request); err == nil {
+		t.Error("expected error for non-string adapter")
+	}
+
+	data, err := json.Marshal(HandlerRequest{Adapter: AdapterEmbeddings, ChatRequestType: ChatRequestTypeCode})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if raw["adapter"] != "embeddings" || raw["chatRequestType"] != "code" {
+		t.Errorf("marshaled adapter %v and chatRequestType %v", raw["adapter"], raw["chatRequestType"])
+	}
+}
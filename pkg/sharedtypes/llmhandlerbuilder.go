@@ -0,0 +1,269 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// HandlerRequestBuilder builds HandlerRequests, validating the combination of their options.
+// The methods can be chained; errors are collected and returned by Build.
+//
+//	request, err := NewChatRequest("gpt-4o").WithData(question).WithHistory(history...).WithTools(tools...).Stream().Build()
+type HandlerRequestBuilder struct {
+	request HandlerRequest
+	errs    []error
+}
+
+// NewChatRequest starts a chat request of type "general" with a new instruction GUID.
+//
+// Parameters:
+//   - modelIds: optional ids of the models to use for the request
+//
+// Returns:
+//   - *HandlerRequestBuilder: the builder
+func NewChatRequest(modelIds ...string) *HandlerRequestBuilder {
+	return newHandlerRequestBuilder(AdapterChat, modelIds).WithType(ChatRequestTypeGeneral)
+}
+
+// NewEmbeddingsRequest starts an embeddings request with a new instruction GUID.
+//
+// Parameters:
+//   - modelIds: optional ids of the models to use for the request
+//
+// Returns:
+//   - *HandlerRequestBuilder: the builder
+func NewEmbeddingsRequest(modelIds ...string) *HandlerRequestBuilder {
+	return newHandlerRequestBuilder(AdapterEmbeddings, modelIds)
+}
+
+// newHandlerRequestBuilder starts a request for an adapter.
+//
+// Parameters:
+//   - adapter: the adapter of the request
+//   - modelIds: the ids of the models to use for the request
+//
+// Returns:
+//   - *HandlerRequestBuilder: the builder
+func newHandlerRequestBuilder(adapter string, modelIds []string) *HandlerRequestBuilder {
+	return &HandlerRequestBuilder{request: HandlerRequest{
+		Adapter:         adapter,
+		InstructionGuid: uuid.NewString(),
+		ModelIds:        modelIds,
+	}}
+}
+
+// WithInstructionGuid replaces the generated instruction GUID.
+func (b *HandlerRequestBuilder) WithInstructionGuid(guid string) *HandlerRequestBuilder {
+	b.request.InstructionGuid = guid
+	return b
+}
+
+// WithModelCategory sets the model categories to choose the model from, in order of preference.
+func (b *HandlerRequestBuilder) WithModelCategory(categories ...string) *HandlerRequestBuilder {
+	b.request.ModelCategory = categories
+	return b
+}
+
+// WithType sets the chat request type.
+func (b *HandlerRequestBuilder) WithType(chatRequestType ChatRequestType) *HandlerRequestBuilder {
+	b.request.ChatRequestType = string(chatRequestType)
+	return b
+}
+
+// WithData sets the data of the request: a string for chat requests, a string or []string for embeddings requests.
+func (b *HandlerRequestBuilder) WithData(data interface{}) *HandlerRequestBuilder {
+	b.request.Data = data
+	return b
+}
+
+// WithImages adds base64 encoded images to a chat request.
+func (b *HandlerRequestBuilder) WithImages(images ...string) *HandlerRequestBuilder {
+	b.request.Images = append(b.request.Images, images...)
+	return b
+}
+
+// WithImageAttachments adds inline images to a chat request, downscaled to DefaultImageLimits.
+func (b *HandlerRequestBuilder) WithImageAttachments(images ...ImageAttachment) *HandlerRequestBuilder {
+	for i, attachment := range images {
+		if attachment.Data == "" {
+			b.errs = append(b.errs, fmt.Errorf("image %d: only inline images are supported in requests", i))
+			continue
+		}
+		downscaled, err := attachment.Downscale(DefaultImageLimits)
+		if err == nil {
+			err = downscaled.Validate(DefaultImageLimits)
+		}
+		if err != nil {
+			b.errs = append(b.errs, fmt.Errorf("image %d: %w", i, err))
+			continue
+		}
+		b.request.Images = append(b.request.Images, downscaled.Data)
+	}
+	return b
+}
+
+// WithHistory adds messages to the conversation history and marks the request as conversation.
+func (b *HandlerRequestBuilder) WithHistory(messages ...HistoricMessage) *HandlerRequestBuilder {
+	b.request.IsConversation = true
+	b.request.ConversationHistory = append(b.request.ConversationHistory, messages...)
+	return b
+}
+
+// WithTools adds tool definitions to a chat request.
+func (b *HandlerRequestBuilder) WithTools(tools ...MCPTool) *HandlerRequestBuilder {
+	b.request.MCPTools = append(b.request.MCPTools, tools...)
+	return b
+}
+
+// WithToolFromStruct adds a tool whose input schema is derived from a parameter struct, see NewMCPToolFromStruct.
+func (b *HandlerRequestBuilder) WithToolFromStruct(name string, description string, params interface{}) *HandlerRequestBuilder {
+	tool, err := NewMCPToolFromStruct(name, description, params)
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	return b.WithTools(tool)
+}
+
+// WithSystemPrompt sets the system prompt of a chat request: a string or a SystemPrompt.
+func (b *HandlerRequestBuilder) WithSystemPrompt(prompt interface{}) *HandlerRequestBuilder {
+	b.request.SystemPrompt = prompt
+	return b
+}
+
+// WithGuardrails sets the guardrails the handler enforces for the request.
+func (b *HandlerRequestBuilder) WithGuardrails(policy GuardrailPolicy) *HandlerRequestBuilder {
+	b.request.Guardrails = &policy
+	return b
+}
+
+// WithContext sets the general and message context of a chat request.
+func (b *HandlerRequestBuilder) WithContext(generalContext string, msgContext string) *HandlerRequestBuilder {
+	b.request.GeneralContext = generalContext
+	b.request.MsgContext = msgContext
+	return b
+}
+
+// WithMaxNumberOfKeywords sets the maximum number of keywords of a keywords request.
+func (b *HandlerRequestBuilder) WithMaxNumberOfKeywords(maxNumberOfKeywords uint32) *HandlerRequestBuilder {
+	b.request.MaxNumberOfKeywords = maxNumberOfKeywords
+	return b
+}
+
+// WithModelOptions sets the model options of a chat request.
+func (b *HandlerRequestBuilder) WithModelOptions(options ModelOptions) *HandlerRequestBuilder {
+	b.request.ModelOptions = options
+	return b
+}
+
+// WithEmbeddingOptions sets the options of an embeddings request.
+func (b *HandlerRequestBuilder) WithEmbeddingOptions(options EmbeddingOptions) *HandlerRequestBuilder {
+	b.request.EmbeddingOptions = options
+	return b
+}
+
+// Stream requests the response of a chat request as a stream.
+func (b *HandlerRequestBuilder) Stream() *HandlerRequestBuilder {
+	b.request.DataStream = true
+	return b
+}
+
+// Build validates the request and returns it.
+//
+// Returns:
+//   - HandlerRequest: the request
+//   - error: the errors collected while building and the invalid option combinations, joined
+func (b *HandlerRequestBuilder) Build() (HandlerRequest, error) {
+	errs := append([]error(nil), b.errs...)
+	request := b.request
+
+	if err := request.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if request.MaxNumberOfKeywords > 0 && request.ChatRequestType != ChatRequestTypeKeywords {
+		errs = append(errs, fmt.Errorf("maxNumberOfKeywords is only supported for chatRequestType %q", ChatRequestTypeKeywords))
+	}
+
+	switch request.Adapter {
+	case AdapterChat:
+		if _, ok := request.Data.(string); !ok && request.Data != nil {
+			errs = append(errs, fmt.Errorf("data of chat requests must be a string, got %T", request.Data))
+		}
+		if prompt, err := request.GetSystemPrompt(); err != nil {
+			errs = append(errs, err)
+		} else if err := prompt.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+		if request.Guardrails != nil {
+			if limit := request.Guardrails.Output.MaxOutputTokens; limit != nil && request.ModelOptions.MaxTokens != nil && *request.ModelOptions.MaxTokens > *limit {
+				errs = append(errs, fmt.Errorf("maxTokens %d exceeds maxOutputTokens %d of the guardrails", *request.ModelOptions.MaxTokens, *limit))
+			}
+		}
+		for i := range request.ConversationHistory {
+			if err := request.ConversationHistory[i].Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("history message %d: %w", i, err))
+			}
+		}
+		names := map[string]bool{}
+		for _, tool := range request.MCPTools {
+			if tool.Name == "" {
+				errs = append(errs, errors.New("tools must have a name"))
+			} else if names[tool.Name] {
+				errs = append(errs, fmt.Errorf("duplicate tool %q", tool.Name))
+			}
+			names[tool.Name] = true
+			if request.Guardrails != nil && !request.Guardrails.IsToolAllowed(tool.Name) {
+				errs = append(errs, fmt.Errorf("tool %q is not allowed by the guardrails", tool.Name))
+			}
+		}
+	case AdapterEmbeddings:
+		switch request.Data.(type) {
+		case string, []string:
+		default:
+			errs = append(errs, fmt.Errorf("data of embeddings requests must be a string or []string, got %T", request.Data))
+		}
+		chatOnly := []struct {
+			name string
+			set  bool
+		}{
+			{"images", len(request.Images) > 0},
+			{"conversationHistory", request.IsConversation || len(request.ConversationHistory) > 0},
+			{"mcpTools", len(request.MCPTools) > 0},
+			{"systemPrompt", request.SystemPrompt != nil},
+		}
+		for _, option := range chatOnly {
+			if option.set {
+				errs = append(errs, fmt.Errorf("%s is only supported for adapter %q", option.name, AdapterChat))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return HandlerRequest{}, errors.Join(errs...)
+	}
+	return request, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewChatRequest(t *testing.T) {
+	type searchParams struct {
+		Query string `json:"query" description:"Search query"`
+		Limit *int   `json:"limit"`
+	}
+	history := []HistoricMessage{{Role: "user", Content: "hi"}, {Role: "assistant", Content: "hello"}}
+
+	request, err := NewChatRequest("gpt-4o").
+		WithData("what is new?").
+		WithHistory(history...).
+		WithTools(MCPTool{Name: "lookup", InputSchema: map[string]interface{}{"type": "object"}}).
+		WithToolFromStruct("search", "Search the docs", searchParams{}).
+		Stream().
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if request.Adapter != AdapterChat || request.ChatRequestType != ChatRequestTypeGeneral || !request.DataStream {
+		t.Errorf("request = %+v, want streamed general chat request", request)
+	}
+	if request.InstructionGuid == "" {
+		t.Error("InstructionGuid not set")
+	}
+	if len(request.ModelIds) != 1 || request.ModelIds[0] != "gpt-4o" {
+		t.Errorf("ModelIds = %v", request.ModelIds)
+	}
+	if !request.IsConversation || len(request.ConversationHistory) != 2 {
+		t.Errorf("IsConversation = %v, history = %v", request.IsConversation, request.ConversationHistory)
+	}
+	if len(request.MCPTools) != 2 || request.MCPTools[1].Name != "search" || request.MCPTools[1].InputSchema["required"] == nil {
+		t.Errorf("MCPTools = %+v", request.MCPTools)
+	}
+
+	other, err := NewChatRequest().WithData("again").Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if other.InstructionGuid == request.InstructionGuid {
+		t.Error("requests share the same InstructionGuid")
+	}
+}
+
+func TestNewEmbeddingsRequest(t *testing.T) {
+	dimensions := 256
+	request, err := NewEmbeddingsRequest("bge-m3").
+		WithData([]string{"a", "b"}).
+		WithEmbeddingOptions(EmbeddingOptions{Dimensions: &dimensions}).
+		WithInstructionGuid("guid-1").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if request.Adapter != AdapterEmbeddings || request.ChatRequestType != "" || request.InstructionGuid != "guid-1" || *request.EmbeddingOptions.Dimensions != 256 {
+		t.Errorf("request = %+v", request)
+	}
+}
+
+func TestHandlerRequestBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *HandlerRequestBuilder
+		errText string
+	}{
+		{"keywords limit without keywords type", NewChatRequest().WithData("text").WithMaxNumberOfKeywords(5), "maxNumberOfKeywords"},
+		{"streamed keywords", NewChatRequest().WithType(ChatRequestTypeKeywords).WithData("text").Stream(), "dataStream"},
+		{"unknown chat request type", NewChatRequest().WithType("poem"), "chatRequestType"},
+		{"chat data not a string", NewChatRequest().WithData([]string{"a"}), "data of chat requests"},
+		{"invalid content part", NewChatRequest().WithHistory(HistoricMessage{Role: "user", ContentParts: []ContentPart{{Type: ContentPartImage}}}), "history message 0"},
+		{"invalid history role", NewChatRequest().WithHistory(HistoricMessage{Role: "usr", Content: "hi"}), "history message 0: role must be one of"},
+		{"tool without name", NewChatRequest().WithTools(MCPTool{}), "tools must have a name"},
+		{"duplicate tool", NewChatRequest().WithTools(MCPTool{Name: "a"}, MCPTool{Name: "a"}), "duplicate tool"},
+		{"tool parameters not a struct", NewChatRequest().WithToolFromStruct("bad", "", 42), "must be a struct"},
+		{"system prompt of unsupported type", NewChatRequest().WithSystemPrompt(42), "system prompt is of type int"},
+		{"invalid system prompt", NewChatRequest().WithSystemPrompt(SystemPrompt{Segments: []PromptSegment{{Role: "tone"}}}), "role \"tone\""},
+		{"tool not allowed by guardrails", NewChatRequest().WithTools(MCPTool{Name: "a"}).WithGuardrails(GuardrailPolicy{AllowedTools: []string{"b"}}), "tool \"a\" is not allowed"},
+		{"maxTokens above guardrails", NewChatRequest().WithModelOptions(ModelOptions{MaxTokens: ptr(int32(500))}).WithGuardrails(GuardrailPolicy{Output: OutputGuardrails{MaxOutputTokens: ptr(int32(100))}}), "exceeds maxOutputTokens"},
+		{"invalid guardrails", NewChatRequest().WithGuardrails(GuardrailPolicy{Input: InputGuardrails{BlockedTopics: []string{" "}}}), "invalid guardrails"},
+		{"embeddings without data", NewEmbeddingsRequest(), "data of embeddings requests"},
+		{"embeddings with history", NewEmbeddingsRequest().WithData("a").WithHistory(HistoricMessage{Role: "user"}), "conversationHistory"},
+		{"embeddings with tools", NewEmbeddingsRequest().WithData("a").WithTools(MCPTool{Name: "a"}), "mcpTools"},
+		{"streamed embeddings", NewEmbeddingsRequest().WithData("a").Stream(), "dataStream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("Build() error = %v, want error containing %q", err, tt.errText)
+			}
+		})
+	}
+}
@@ -23,8 +23,10 @@
 package sharedtypes
 
 import (
-	"os"
+	"fmt"
+	"reflect"
 	"strings"
+	"time"
 )
 
 // MCPConfig represents the configuration for MCP connections
@@ -42,6 +44,7 @@ type MCPTool struct {
 	Description  string                 `json:"description,omitempty"`  // Human-readable description of what the tool does
 	InputSchema  map[string]interface{} `json:"inputSchema"`            // JSON Schema for the tool's parameters
 	ServerURL    string                 `json:"serverURL,omitempty"`    // URL of the MCP server that provides this tool
+	SideEffect   ToolSideEffect         `json:"sideEffect,omitempty"`   // "read-only", "writes-model" or "external-calls"; unclassified tools are treated as "external-calls"
 }
 
 // MCPContentItem represents a single content item in an MCP response.
@@ -57,13 +60,16 @@ type MCPContentItem struct {
 type MCPResource struct {
 	URI         string `json:"uri"`                   // Unique resource identifier
 	Name        string `json:"name"`                  // Human-readable resource name
+	Title       string `json:"title,omitempty"`       // Display name of the resource
 	Description string `json:"description,omitempty"` // Description of the resource
 	MimeType    string `json:"mimeType,omitempty"`    // MIME type of the resource content
+	Size        int64  `json:"size,omitempty"`        // Size of the resource content in bytes, if known
 }
 
 // MCPPrompt represents a prompt template available from an MCP server.
 type MCPPrompt struct {
 	Name        string              `json:"name"`                  // Unique prompt identifier
+	Title       string              `json:"title,omitempty"`       // Display name of the prompt
 	Description string              `json:"description,omitempty"` // Description of what the prompt does
 	Arguments   []MCPPromptArgument `json:"arguments,omitempty"`   // Parameters the prompt accepts
 }
@@ -84,13 +90,153 @@ type ToolSetDefinition struct {
 }
 
 // GetAuthToken returns the authentication token, resolving environment variables if needed
-// ${MCP_TOKEN} will return the value of the MCP_TOKEN environment variable
+// ${MCP_TOKEN} will return the value of the MCP_TOKEN environment variable; unset variables resolve to ""
 func (config *MCPConfig) GetAuthToken() string {
-	if len(config.AuthToken) > 3 &&
-		strings.HasPrefix(config.AuthToken, "${") &&
-		strings.HasSuffix(config.AuthToken, "}") {
-		envVar := config.AuthToken[2 : len(config.AuthToken)-1]
-		return os.Getenv(envVar)
+	token, _ := ExpandEnv(config.AuthToken, false)
+	return token
+}
+
+// GetServerURL returns the URL of the server, resolving environment variables if needed
+// "https://${MCP_HOST}/mcp" will use the value of the MCP_HOST environment variable; unset variables resolve to ""
+func (config *MCPConfig) GetServerURL() string {
+	serverURL, _ := ExpandEnv(config.ServerURL, false)
+	return serverURL
+}
+
+// Expand returns a copy of the configuration with the environment variables of all string fields resolved.
+// Use strict mode before connecting, so a misconfigured server URL fails with the name of the missing variable.
+//
+// Parameters:
+//   - strict: if true, unset variables are an error; otherwise they resolve to ""
+//
+// Returns:
+//   - MCPConfig: the expanded configuration
+//   - error: an error naming the field and the unset variables in strict mode
+func (config *MCPConfig) Expand(strict bool) (MCPConfig, error) {
+	expanded := *config
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{"serverURL", &expanded.ServerURL},
+		{"transport", &expanded.Transport},
+		{"authToken", &expanded.AuthToken},
+	}
+	for _, field := range fields {
+		value, err := ExpandEnv(*field.value, strict)
+		if err != nil {
+			return MCPConfig{}, fmt.Errorf("error expanding %s of MCP server: %w", field.name, err)
+		}
+		*field.value = value
+	}
+	return expanded, nil
+}
+
+// NewMCPToolFromStruct creates a tool definition with an input schema derived from the fields of a struct.
+// The properties are named after the JSON tags of the fields and described by their "description" tags.
+// Fields with "omitempty" and pointer fields are optional, all other fields are required.
+//
+// Parameters:
+//   - name: the name of the tool
+//   - description: the description of the tool
+//   - params: a struct or pointer to a struct with the parameters of the tool
+//
+// Returns:
+//   - MCPTool: the tool definition
+//   - error: an error if params is not a struct or has fields that cannot be expressed in JSON schema
+func NewMCPToolFromStruct(name string, description string, params interface{}) (MCPTool, error) {
+	paramsType := reflect.TypeOf(params)
+	for paramsType != nil && paramsType.Kind() == reflect.Pointer {
+		paramsType = paramsType.Elem()
+	}
+	if paramsType == nil || paramsType.Kind() != reflect.Struct {
+		return MCPTool{}, fmt.Errorf("parameters of tool %q must be a struct, got %T", name, params)
+	}
+
+	schema, err := jsonSchemaOf(paramsType)
+	if err != nil {
+		return MCPTool{}, fmt.Errorf("error creating input schema of tool %q: %w", name, err)
+	}
+	return MCPTool{Name: name, Description: description, InputSchema: schema}, nil
+}
+
+// jsonSchemaOf returns the JSON schema of a Go type.
+//
+// Parameters:
+//   - t: the type
+//
+// Returns:
+//   - map[string]interface{}: the JSON schema
+//   - error: an error if the type cannot be expressed in JSON schema
+func jsonSchemaOf(t reflect.Type) (map[string]interface{}, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.Interface:
+		return map[string]interface{}{}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := jsonSchemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map keys of type %s are not supported", t.Key())
+		}
+		values, err := jsonSchemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" && options == "" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			property, err := jsonSchemaOf(field.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			if description := field.Tag.Get("description"); description != "" {
+				property["description"] = description
+			}
+			properties[name] = property
+			if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Pointer {
+				required = append(required, name)
+			}
+		}
+		schema := map[string]interface{}{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema, nil
+	default:
+		return nil, fmt.Errorf("type %s is not supported", t)
 	}
-	return config.AuthToken
 }
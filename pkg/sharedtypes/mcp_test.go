@@ -24,7 +24,9 @@ package sharedtypes
 
 import (
 	"os"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestGetAuthToken(t *testing.T) {
@@ -81,3 +83,56 @@ func TestGetAuthToken(t *testing.T) {
 		})
 	}
 }
+
+func TestNewMCPToolFromStruct(t *testing.T) {
+	type filter struct {
+		Field string `json:"field"`
+	}
+	type params struct {
+		Query    string             `json:"query" description:"Search query"`
+		Limit    *int               `json:"limit"`
+		Tags     []string           `json:"tags,omitempty"`
+		Filters  []filter           `json:"filters,omitempty"`
+		Weights  map[string]float64 `json:"weights,omitempty"`
+		Since    time.Time          `json:"since,omitempty"`
+		Untagged bool
+		Skipped  string `json:"-"`
+		private  string
+	}
+
+	tool, err := NewMCPToolFromStruct("search", "Search the docs", &params{})
+	if err != nil {
+		t.Fatalf("NewMCPToolFromStruct() error = %v", err)
+	}
+	if tool.Name != "search" || tool.Description != "Search the docs" {
+		t.Errorf("tool = %+v", tool)
+	}
+
+	expected := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query":   map[string]interface{}{"type": "string", "description": "Search query"},
+			"limit":   map[string]interface{}{"type": "integer"},
+			"tags":    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			"weights": map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "number"}},
+			"since":   map[string]interface{}{"type": "string", "format": "date-time"},
+			"filters": map[string]interface{}{"type": "array", "items": map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{"field": map[string]interface{}{"type": "string"}},
+				"required":   []string{"field"},
+			}},
+			"Untagged": map[string]interface{}{"type": "boolean"},
+		},
+		"required": []string{"query", "Untagged"},
+	}
+	if !reflect.DeepEqual(tool.InputSchema, expected) {
+		t.Errorf("InputSchema = %v, want %v", tool.InputSchema, expected)
+	}
+
+	invalid := []interface{}{nil, "text", struct{ Callback func() }{}, struct{ Lookup map[int]string }{}}
+	for _, params := range invalid {
+		if _, err := NewMCPToolFromStruct("bad", "", params); err == nil {
+			t.Errorf("NewMCPToolFromStruct(%T) returned no error", params)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Content types of MCP tool results.
+const (
+	MCPContentText         = "text"
+	MCPContentImage        = "image"
+	MCPContentAudio        = "audio"
+	MCPContentResourceLink = "resource_link"
+	MCPContentResource     = "resource"
+)
+
+// MCPToolResultContent is one content block of an MCP tool result.
+// Only the fields of its type are set; in JSON, the block is encoded as defined by the MCP specification,
+// e.g. {"type": "image", "data": "...", "mimeType": "image/png"}.
+type MCPToolResultContent struct {
+	Type        string               // "text", "image", "audio", "resource_link" or "resource"
+	Text        string               // only for type "text"
+	Data        string               // base64 encoded content; for type "image" and "audio"
+	MimeType    string               // for type "image", "audio" and "resource_link"
+	URI         string               // for type "resource_link"
+	Name        string               // for type "resource_link"
+	Description string               // optional; for type "resource_link"
+	Resource    *MCPEmbeddedResource // for type "resource"
+}
+
+// MCPEmbeddedResource is the content of a resource embedded in a tool result, either text or binary.
+type MCPEmbeddedResource struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"` // text content of the resource
+	Blob     string `json:"blob,omitempty"` // base64 encoded binary content of the resource
+}
+
+// NewMCPTextContent creates a text content block.
+//
+// Parameters:
+//   - text: the text
+//
+// Returns:
+//   - MCPToolResultContent: the content block
+func NewMCPTextContent(text string) MCPToolResultContent {
+	return MCPToolResultContent{Type: MCPContentText, Text: text}
+}
+
+// NewMCPImageContent creates an image content block.
+//
+// Parameters:
+//   - data: the base64 encoded image
+//   - mimeType: the MIME type of the image, e.g. "image/png"
+//
+// Returns:
+//   - MCPToolResultContent: the content block
+func NewMCPImageContent(data string, mimeType string) MCPToolResultContent {
+	return MCPToolResultContent{Type: MCPContentImage, Data: data, MimeType: mimeType}
+}
+
+// NewMCPResourceLinkContent creates a content block linking to a resource of the MCP server.
+//
+// Parameters:
+//   - uri: the URI of the resource
+//   - name: the name of the resource
+//   - mimeType: the MIME type of the resource; may be empty
+//
+// Returns:
+//   - MCPToolResultContent: the content block
+func NewMCPResourceLinkContent(uri string, name string, mimeType string) MCPToolResultContent {
+	return MCPToolResultContent{Type: MCPContentResourceLink, URI: uri, Name: name, MimeType: mimeType}
+}
+
+// mcpToolResultContentJSON is the JSON form of all content types; MarshalJSON only sets the fields of the type.
+type mcpToolResultContentJSON struct {
+	Type        string               `json:"type"`
+	Text        *string              `json:"text,omitempty"`
+	Data        string               `json:"data,omitempty"`
+	MimeType    string               `json:"mimeType,omitempty"`
+	URI         string               `json:"uri,omitempty"`
+	Name        string               `json:"name,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Resource    *MCPEmbeddedResource `json:"resource,omitempty"`
+}
+
+// MarshalJSON encodes the content block as defined by the MCP specification.
+// Text blocks always have a "text" field, even if it is empty.
+func (c MCPToolResultContent) MarshalJSON() ([]byte, error) {
+	encoded := mcpToolResultContentJSON{Type: c.Type}
+	switch c.Type {
+	case MCPContentText:
+		encoded.Text = &c.Text
+	case MCPContentImage, MCPContentAudio:
+		encoded.Data, encoded.MimeType = c.Data, c.MimeType
+	case MCPContentResourceLink:
+		encoded.URI, encoded.Name, encoded.Description, encoded.MimeType = c.URI, c.Name, c.Description, c.MimeType
+	case MCPContentResource:
+		encoded.Resource = c.Resource
+	default:
+		// unknown types keep all set fields, so content of newer MCP versions passes through
+		encoded = mcpToolResultContentJSON{Type: c.Type, Data: c.Data, MimeType: c.MimeType, URI: c.URI, Name: c.Name, Description: c.Description, Resource: c.Resource}
+		if c.Text != "" {
+			encoded.Text = &c.Text
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes a content block of the MCP specification.
+// Fields not belonging to the type of the block are ignored; blocks of unknown types keep all fields
+// and are rejected by Validate.
+func (c *MCPToolResultContent) UnmarshalJSON(data []byte) error {
+	var decoded mcpToolResultContentJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("error decoding MCP content: %w", err)
+	}
+	*c = MCPToolResultContent{Type: decoded.Type}
+	switch decoded.Type {
+	case MCPContentText:
+		if decoded.Text != nil {
+			c.Text = *decoded.Text
+		}
+	case MCPContentImage, MCPContentAudio:
+		c.Data, c.MimeType = decoded.Data, decoded.MimeType
+	case MCPContentResourceLink:
+		c.URI, c.Name, c.Description, c.MimeType = decoded.URI, decoded.Name, decoded.Description, decoded.MimeType
+	case MCPContentResource:
+		c.Resource = decoded.Resource
+	default:
+		c.Data, c.MimeType, c.URI, c.Name, c.Description, c.Resource = decoded.Data, decoded.MimeType, decoded.URI, decoded.Name, decoded.Description, decoded.Resource
+		if decoded.Text != nil {
+			c.Text = *decoded.Text
+		}
+	}
+	return nil
+}
+
+// Validate checks that the content block has a known type and the payload required by that type.
+//
+// Returns:
+//   - error: an error if the content block is invalid
+func (c *MCPToolResultContent) Validate() error {
+	switch c.Type {
+	case MCPContentText:
+		return nil
+	case MCPContentImage, MCPContentAudio:
+		if c.Data == "" || c.MimeType == "" {
+			return fmt.Errorf("MCP content of type %q requires data and mimeType", c.Type)
+		}
+	case MCPContentResourceLink:
+		if c.URI == "" || c.Name == "" {
+			return fmt.Errorf("MCP content of type %q requires uri and name", c.Type)
+		}
+	case MCPContentResource:
+		if c.Resource == nil || c.Resource.URI == "" {
+			return fmt.Errorf("MCP content of type %q requires a resource with uri", c.Type)
+		}
+	default:
+		return fmt.Errorf("unknown MCP content type %q", c.Type)
+	}
+	return nil
+}
+
+// String returns the content block as text for providers that only accept text tool results.
+// Text is returned as it is, binary content is replaced by a placeholder naming its type.
+//
+// Returns:
+//   - string: the text of the content block
+func (c MCPToolResultContent) String() string {
+	switch c.Type {
+	case MCPContentText:
+		return c.Text
+	case MCPContentImage, MCPContentAudio:
+		return fmt.Sprintf("[%s: %s]", c.Type, c.MimeType)
+	case MCPContentResourceLink:
+		return fmt.Sprintf("[resource: %s (%s)]", c.Name, c.URI)
+	case MCPContentResource:
+		if c.Resource == nil {
+			return "[resource]"
+		}
+		if c.Resource.Text != "" {
+			return c.Resource.Text
+		}
+		return fmt.Sprintf("[resource: %s]", c.Resource.URI)
+	default:
+		return fmt.Sprintf("[%s]", c.Type)
+	}
+}
+
+// FlattenMCPToolResultContent joins the content blocks of a tool result into one text.
+//
+// Parameters:
+//   - contents: the content blocks
+//
+// Returns:
+//   - string: the text of the blocks, separated by newlines
+func FlattenMCPToolResultContent(contents []MCPToolResultContent) string {
+	texts := make([]string, 0, len(contents))
+	for _, content := range contents {
+		texts = append(texts, content.String())
+	}
+	return strings.Join(texts, "\n")
+}
+
+// NewToolResult creates the result of a tool call from MCP content blocks.
+// Content is set to the flattened blocks, so providers accepting only text can use it directly.
+//
+// Parameters:
+//   - toolCallId: the ID of the tool call
+//   - contents: the content blocks returned by the tool
+//   - isError: true if the tool failed
+//
+// Returns:
+//   - ToolResult: the tool result
+func NewToolResult(toolCallId string, contents []MCPToolResultContent, isError bool) ToolResult {
+	return ToolResult{
+		ToolCallID: toolCallId,
+		Content:    FlattenMCPToolResultContent(contents),
+		Contents:   contents,
+		IsError:    isError,
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMCPToolResultContentJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		content MCPToolResultContent
+		json    string
+	}{
+		{"text", NewMCPTextContent("42 cells"), `{"type":"text","text":"42 cells"}`},
+		{"empty text", NewMCPTextContent(""), `{"type":"text","text":""}`},
+		{"image", NewMCPImageContent("aGVsbG8=", "image/png"), `{"type":"image","data":"aGVsbG8=","mimeType":"image/png"}`},
+		{"resource link", NewMCPResourceLinkContent("file:///mesh.msh", "mesh.msh", "application/octet-stream"), `{"type":"resource_link","mimeType":"application/octet-stream","uri":"file:///mesh.msh","name":"mesh.msh"}`},
+		{"embedded resource", MCPToolResultContent{Type: MCPContentResource, Resource: &MCPEmbeddedResource{URI: "file:///log.txt", MimeType: "text/plain", Text: "done"}}, `{"type":"resource","resource":{"uri":"file:///log.txt","mimeType":"text/plain","text":"done"}}`},
+		{"unknown type", MCPToolResultContent{Type: "video", URI: "file:///a.mp4"}, `{"type":"video","uri":"file:///a.mp4"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.content)
+			if err != nil {
+				t.Fatalf("marshal failed: %v", err)
+			}
+			if string(data) != tt.json {
+				t.Errorf("marshaled %s, want %s", data, tt.json)
+			}
+			var decoded MCPToolResultContent
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, tt.content) {
+				t.Errorf("decoded %+v, want %+v", decoded, tt.content)
+			}
+		})
+	}
+}
+
+func TestMCPToolResultContentIgnoresForeignFields(t *testing.T) {
+	var content MCPToolResultContent
+	if err := json.Unmarshal([]byte(`{"type":"text","text":"a","data":"b","annotations":{"priority":1}}`), &content); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if content != NewMCPTextContent("a") {
+		t.Errorf("decoded %+v, want text block only", content)
+	}
+}
+
+func TestMCPToolResultContentValidate(t *testing.T) {
+	valid := []MCPToolResultContent{
+		NewMCPTextContent(""),
+		NewMCPImageContent("aGVsbG8=", "image/png"),
+		NewMCPResourceLinkContent("file:///a", "a", ""),
+		{Type: MCPContentResource, Resource: &MCPEmbeddedResource{URI: "file:///a", Blob: "aGVsbG8="}},
+	}
+	for _, content := range valid {
+		if err := content.Validate(); err != nil {
+			t.Errorf("Validate() for %+v error = %v", content, err)
+		}
+	}
+	invalid := []MCPToolResultContent{
+		{Type: "video"},
+		{Type: MCPContentImage, Data: "aGVsbG8="},
+		{Type: MCPContentResourceLink, URI: "file:///a"},
+		{Type: MCPContentResource},
+	}
+	for _, content := range invalid {
+		if err := content.Validate(); err == nil {
+			t.Errorf("Validate() for %+v should fail", content)
+		}
+	}
+}
+
+func TestNewToolResult(t *testing.T) {
+	contents := []MCPToolResultContent{
+		NewMCPTextContent("Meshed the geometry."),
+		NewMCPImageContent("aGVsbG8=", "image/png"),
+		NewMCPResourceLinkContent("file:///mesh.msh", "mesh.msh", ""),
+		{Type: MCPContentResource, Resource: &MCPEmbeddedResource{URI: "file:///log.txt", Text: "0 errors"}},
+		{Type: MCPContentResource, Resource: &MCPEmbeddedResource{URI: "file:///mesh.bin", Blob: "aGVsbG8="}},
+	}
+	result := NewToolResult("call-1", contents, false)
+	want := "Meshed the geometry.\n[image: image/png]\n[resource: mesh.msh (file:///mesh.msh)]\n0 errors\n[resource: file:///mesh.bin]"
+	if result.Content != want {
+		t.Errorf("Content = %q, want %q", result.Content, want)
+	}
+	if result.ToolCallID != "call-1" || len(result.Contents) != len(contents) || result.IsError {
+		t.Errorf("result = %+v", result)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MCPServerCapabilities are the features an MCP server announces in its initialize result.
+// A nil feature is not supported by the server.
+type MCPServerCapabilities struct {
+	Tools        *MCPListChangedCapability `json:"tools,omitempty"`
+	Prompts      *MCPListChangedCapability `json:"prompts,omitempty"`
+	Resources    *MCPResourcesCapability   `json:"resources,omitempty"`
+	Logging      map[string]interface{}    `json:"logging,omitempty"`
+	Completions  map[string]interface{}    `json:"completions,omitempty"`
+	Experimental map[string]interface{}    `json:"experimental,omitempty"`
+}
+
+// MCPListChangedCapability is a feature of an MCP server that can notify clients about changes of its list.
+type MCPListChangedCapability struct {
+	ListChanged bool `json:"listChanged,omitempty"` // the server sends list_changed notifications
+}
+
+// MCPResourcesCapability is the resource feature of an MCP server.
+type MCPResourcesCapability struct {
+	Subscribe   bool `json:"subscribe,omitempty"`   // clients can subscribe to changes of single resources
+	ListChanged bool `json:"listChanged,omitempty"` // the server sends list_changed notifications
+}
+
+// MCPImplementation names the implementation of an MCP server or client.
+type MCPImplementation struct {
+	Name    string `json:"name"`
+	Title   string `json:"title,omitempty"`
+	Version string `json:"version"`
+}
+
+// MCPInitializeResult is the answer of an MCP server to the initialize request.
+type MCPInitializeResult struct {
+	ProtocolVersion string                `json:"protocolVersion"`
+	Capabilities    MCPServerCapabilities `json:"capabilities"`
+	ServerInfo      MCPImplementation     `json:"serverInfo"`
+	Instructions    string                `json:"instructions,omitempty"` // hints for the model on how to use the server
+}
+
+// MCPResourceTemplate describes parameterized resources of an MCP server, e.g. "file:///{path}".
+type MCPResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"` // RFC 6570 URI template
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// MCPListPromptsResult is one page of the prompts of an MCP server.
+type MCPListPromptsResult struct {
+	Prompts    []MCPPrompt `json:"prompts"`
+	NextCursor string      `json:"nextCursor,omitempty"` // empty on the last page
+}
+
+// MCPListResourcesResult is one page of the resources of an MCP server.
+type MCPListResourcesResult struct {
+	Resources  []MCPResource `json:"resources"`
+	NextCursor string        `json:"nextCursor,omitempty"` // empty on the last page
+}
+
+// MCPListResourceTemplatesResult is one page of the resource templates of an MCP server.
+type MCPListResourceTemplatesResult struct {
+	ResourceTemplates []MCPResourceTemplate `json:"resourceTemplates"`
+	NextCursor        string                `json:"nextCursor,omitempty"` // empty on the last page
+}
+
+// MCPReadResourceResult is the content of a resource read from an MCP server.
+type MCPReadResourceResult struct {
+	Contents []MCPEmbeddedResource `json:"contents"`
+}
+
+// MCPPromptMessage is one message of a prompt rendered by an MCP server.
+type MCPPromptMessage struct {
+	Role    Role                 `json:"role"` // "user" or "assistant"
+	Content MCPToolResultContent `json:"content"`
+}
+
+// MCPGetPromptResult is a prompt rendered by an MCP server with its arguments.
+type MCPGetPromptResult struct {
+	Description string             `json:"description,omitempty"`
+	Messages    []MCPPromptMessage `json:"messages"`
+}
+
+// SupportsTools reports whether the server provides tools.
+//
+// Returns:
+//   - bool: true if the server announces the tools feature
+func (c *MCPServerCapabilities) SupportsTools() bool {
+	return c.Tools != nil
+}
+
+// SupportsPrompts reports whether the server provides prompts.
+//
+// Returns:
+//   - bool: true if the server announces the prompts feature
+func (c *MCPServerCapabilities) SupportsPrompts() bool {
+	return c.Prompts != nil
+}
+
+// SupportsResources reports whether the server provides resources.
+//
+// Returns:
+//   - bool: true if the server announces the resources feature
+func (c *MCPServerCapabilities) SupportsResources() bool {
+	return c.Resources != nil
+}
+
+// CheckArguments checks the arguments for rendering a prompt.
+//
+// Parameters:
+//   - arguments: the arguments by name
+//
+// Returns:
+//   - error: an error naming the missing required and the unknown arguments, nil if the arguments are valid
+func (p *MCPPrompt) CheckArguments(arguments map[string]string) error {
+	var problems []string
+	for _, argument := range p.Arguments {
+		if _, ok := arguments[argument.Name]; argument.Required && !ok {
+			problems = append(problems, fmt.Sprintf("missing required argument %q", argument.Name))
+		}
+	}
+	var unknown []string
+	for name := range arguments {
+		if !slices.ContainsFunc(p.Arguments, func(argument MCPPromptArgument) bool { return argument.Name == name }) {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+	for _, name := range unknown {
+		problems = append(problems, fmt.Sprintf("unknown argument %q", name))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid arguments of prompt %q: %s", p.Name, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// ContentPart converts the content block into a content part of a HistoricMessage.
+// Images stay images; audio, linked resources and binary resources become files; text resources become text.
+//
+// Returns:
+//   - ContentPart: the content part
+func (c MCPToolResultContent) ContentPart() ContentPart {
+	switch c.Type {
+	case MCPContentImage:
+		return ContentPart{Type: ContentPartImage, Data: c.Data, MimeType: c.MimeType}
+	case MCPContentAudio:
+		return ContentPart{Type: ContentPartFile, Data: c.Data, MimeType: c.MimeType}
+	case MCPContentResourceLink:
+		return ContentPart{Type: ContentPartFile, URI: c.URI, MimeType: c.MimeType, FileName: c.Name}
+	case MCPContentResource:
+		if c.Resource != nil {
+			return c.Resource.ContentPart()
+		}
+	}
+	return ContentPart{Type: ContentPartText, Text: c.String()}
+}
+
+// ContentPart converts the resource content into a content part of a HistoricMessage.
+//
+// Returns:
+//   - ContentPart: a text part for text resources, a file part with the blob otherwise
+func (r MCPEmbeddedResource) ContentPart() ContentPart {
+	if r.Blob == "" {
+		return ContentPart{Type: ContentPartText, Text: r.Text}
+	}
+	return ContentPart{Type: ContentPartFile, Data: r.Blob, URI: r.URI, MimeType: r.MimeType}
+}
+
+// ToHistoricMessages converts a rendered prompt into conversation history, e.g. to start a chat from an MCP prompt.
+// Text messages are converted into plain messages, all other messages into messages with content parts.
+//
+// Returns:
+//   - []HistoricMessage: the messages in their original order
+func (r *MCPGetPromptResult) ToHistoricMessages() []HistoricMessage {
+	messages := make([]HistoricMessage, 0, len(r.Messages))
+	for _, message := range r.Messages {
+		if message.Content.Type == MCPContentText {
+			messages = append(messages, HistoricMessage{Role: string(message.Role), Content: message.Content.Text})
+			continue
+		}
+		messages = append(messages, HistoricMessage{Role: string(message.Role), ContentParts: []ContentPart{message.Content.ContentPart()}})
+	}
+	return messages
+}
+
+// Text returns the text content of a read resource, e.g. to add it to a prompt.
+//
+// Returns:
+//   - string: the texts of the text contents, separated by newlines; binary contents are skipped
+func (r *MCPReadResourceResult) Text() string {
+	var texts []string
+	for _, content := range r.Contents {
+		if content.Blob == "" {
+			texts = append(texts, content.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// MCPListAll collects all pages of a paginated MCP list request.
+//
+// Parameters:
+//   - ctx: the context of the requests; listing stops when it is done
+//   - list: requests one page; called with an empty cursor for the first page
+//
+// Returns:
+//   - []T: the items of all pages
+//   - error: the error of the failing request or of the context
+func MCPListAll[T any](ctx context.Context, list func(ctx context.Context, cursor string) (items []T, nextCursor string, err error)) ([]T, error) {
+	var all []T
+	cursor := ""
+	seen := map[string]bool{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return all, err
+		}
+		items, next, err := list(ctx, cursor)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, items...)
+		if next == "" {
+			return all, nil
+		}
+		if seen[next] {
+			return all, fmt.Errorf("MCP server returned cursor %q twice", next)
+		}
+		seen[next] = true
+		cursor = next
+	}
+}
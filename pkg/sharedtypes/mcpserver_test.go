@@ -0,0 +1,153 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMCPInitializeResultJSON(t *testing.T) {
+	data := `{
+		"protocolVersion": "2025-06-18",
+		"capabilities": {"tools": {}, "resources": {"subscribe": true}, "logging": {}},
+		"serverInfo": {"name": "mesher", "version": "1.2.0"}
+	}`
+	var result MCPInitializeResult
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	capabilities := result.Capabilities
+	if !capabilities.SupportsTools() || capabilities.SupportsPrompts() || !capabilities.SupportsResources() || !capabilities.Resources.Subscribe {
+		t.Errorf("capabilities = %+v, want tools and subscribable resources", capabilities)
+	}
+	if result.ServerInfo.Name != "mesher" || result.ProtocolVersion != "2025-06-18" {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestMCPPromptCheckArguments(t *testing.T) {
+	prompt := MCPPrompt{Name: "review", Arguments: []MCPPromptArgument{{Name: "code", Required: true}, {Name: "style"}}}
+	tests := []struct {
+		name      string
+		arguments map[string]string
+		errText   string
+	}{
+		{"required only", map[string]string{"code": "x"}, ""},
+		{"all", map[string]string{"code": "x", "style": "go"}, ""},
+		{"missing required", map[string]string{"style": "go"}, `missing required argument "code"`},
+		{"unknown", map[string]string{"code": "x", "lang": "go"}, `unknown argument "lang"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := prompt.CheckArguments(tt.arguments)
+			if tt.errText == "" && err != nil {
+				t.Errorf("CheckArguments() error = %v", err)
+			}
+			if tt.errText != "" && (err == nil || !strings.Contains(err.Error(), tt.errText)) {
+				t.Errorf("CheckArguments() error = %v, want %q", err, tt.errText)
+			}
+		})
+	}
+}
+
+func TestMCPGetPromptResultToHistoricMessages(t *testing.T) {
+	var result MCPGetPromptResult
+	data := `{"messages": [
+		{"role": "user", "content": {"type": "text", "text": "Review this mesh"}},
+		{"role": "user", "content": {"type": "resource", "resource": {"uri": "file:///mesh.msh", "mimeType": "application/octet-stream", "blob": "aGVsbG8="}}},
+		{"role": "assistant", "content": {"type": "image", "data": "aGVsbG8=", "mimeType": "image/png"}}
+	]}`
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	want := []HistoricMessage{
+		{Role: RoleUser, Content: "Review this mesh"},
+		{Role: RoleUser, ContentParts: []ContentPart{{Type: ContentPartFile, Data: "aGVsbG8=", URI: "file:///mesh.msh", MimeType: "application/octet-stream"}}},
+		{Role: RoleAssistant, ContentParts: []ContentPart{{Type: ContentPartImage, Data: "aGVsbG8=", MimeType: "image/png"}}},
+	}
+	if got := result.ToHistoricMessages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToHistoricMessages() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMCPToolResultContentContentPart(t *testing.T) {
+	tests := []struct {
+		content MCPToolResultContent
+		want    ContentPart
+	}{
+		{NewMCPTextContent("a"), ContentPart{Type: ContentPartText, Text: "a"}},
+		{MCPToolResultContent{Type: MCPContentAudio, Data: "d", MimeType: "audio/wav"}, ContentPart{Type: ContentPartFile, Data: "d", MimeType: "audio/wav"}},
+		{NewMCPResourceLinkContent("file:///a.txt", "a.txt", "text/plain"), ContentPart{Type: ContentPartFile, URI: "file:///a.txt", MimeType: "text/plain", FileName: "a.txt"}},
+		{MCPToolResultContent{Type: MCPContentResource, Resource: &MCPEmbeddedResource{URI: "file:///a.txt", Text: "hello"}}, ContentPart{Type: ContentPartText, Text: "hello"}},
+	}
+	for _, tt := range tests {
+		if got := tt.content.ContentPart(); got != tt.want {
+			t.Errorf("ContentPart() of %q = %+v, want %+v", tt.content.Type, got, tt.want)
+		}
+	}
+}
+
+func TestMCPReadResourceResultText(t *testing.T) {
+	result := MCPReadResourceResult{Contents: []MCPEmbeddedResource{
+		{URI: "file:///a.txt", Text: "first"},
+		{URI: "file:///b.bin", Blob: "aGVsbG8="},
+		{URI: "file:///c.txt", Text: "second"},
+	}}
+	if got := result.Text(); got != "first\nsecond" {
+		t.Errorf("Text() = %q, want %q", got, "first\nsecond")
+	}
+}
+
+func TestMCPListAll(t *testing.T) {
+	pages := map[string]MCPListPromptsResult{
+		"":   {Prompts: []MCPPrompt{{Name: "a"}, {Name: "b"}}, NextCursor: "p2"},
+		"p2": {Prompts: []MCPPrompt{{Name: "c"}}},
+	}
+	list := func(ctx context.Context, cursor string) ([]MCPPrompt, string, error) {
+		page := pages[cursor]
+		return page.Prompts, page.NextCursor, nil
+	}
+	prompts, err := MCPListAll(context.Background(), list)
+	if err != nil || len(prompts) != 3 || prompts[2].Name != "c" {
+		t.Errorf("MCPListAll() = %+v, %v, want 3 prompts", prompts, err)
+	}
+
+	looping := func(ctx context.Context, cursor string) ([]MCPPrompt, string, error) {
+		return nil, "same", nil
+	}
+	if _, err := MCPListAll(context.Background(), looping); err == nil {
+		t.Error("MCPListAll() with repeated cursor should fail")
+	}
+
+	failing := func(ctx context.Context, cursor string) ([]MCPPrompt, string, error) {
+		return nil, "", errors.New("connection reset")
+	}
+	if _, err := MCPListAll(context.Background(), failing); err == nil {
+		t.Error("MCPListAll() with failing request should fail")
+	}
+}
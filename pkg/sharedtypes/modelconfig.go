@@ -0,0 +1,214 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ModelsConfig is the model catalog of the LLM handler, read from the file at MODELS_CONFIG_LOCATION.
+type ModelsConfig struct {
+	Models []ModelConfig `yaml:"MODELS" json:"models"`
+}
+
+// ModelConfig describes a model available in the LLM handler.
+// Credentials of the provider are not part of the shared schema and are ignored when loading the catalog.
+type ModelConfig struct {
+	Id                  string        `yaml:"ID" json:"id"`                                            // unique id of the model, as used in HandlerRequest.ModelIds
+	Provider            string        `yaml:"PROVIDER" json:"provider"`                                // e.g. "azure-openai", "openai", "anthropic", "bedrock", "ollama"
+	Endpoint            string        `yaml:"ENDPOINT,omitempty" json:"endpoint,omitempty"`            // URL of the provider endpoint; empty for the default endpoint of the provider
+	Categories          []string      `yaml:"CATEGORIES,omitempty" json:"categories,omitempty"`        // categories matched by HandlerRequest.ModelCategory, e.g. "chat", "code", "embeddings"
+	ContextWindow       int           `yaml:"CONTEXT_WINDOW,omitempty" json:"contextWindow,omitempty"` // maximum number of input and output tokens; 0 if unknown
+	Pricing             *ModelPricing `yaml:"PRICING,omitempty" json:"pricing,omitempty"`
+	DefaultModelOptions ModelOptions  `yaml:"DEFAULT_MODEL_OPTIONS,omitempty" json:"defaultModelOptions,omitempty"` // options used for requests that do not set them
+}
+
+// ModelPricing is the price of a model per million tokens.
+type ModelPricing struct {
+	Currency                    string  `yaml:"CURRENCY,omitempty" json:"currency,omitempty"` // ISO 4217 code; "USD" if empty
+	InputPerMillionTokens       float64 `yaml:"INPUT_PER_MILLION_TOKENS" json:"inputPerMillionTokens"`
+	OutputPerMillionTokens      float64 `yaml:"OUTPUT_PER_MILLION_TOKENS" json:"outputPerMillionTokens"`
+	CachedInputPerMillionTokens float64 `yaml:"CACHED_INPUT_PER_MILLION_TOKENS,omitempty" json:"cachedInputPerMillionTokens,omitempty"` // 0 if cached tokens are charged as input tokens
+}
+
+// LoadModelsConfig reads and validates the model catalog, usually from config.GlobalConfig.MODELS_CONFIG_LOCATION.
+//
+// Parameters:
+//   - path: the path of the YAML file
+//
+// Returns:
+//   - ModelsConfig: the model catalog
+//   - error: an error if the file cannot be read, parsed or is invalid
+func LoadModelsConfig(path string) (ModelsConfig, error) {
+	if path == "" {
+		return ModelsConfig{}, errors.New("no models config location set")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ModelsConfig{}, fmt.Errorf("error reading models config: %w", err)
+	}
+	models, err := ParseModelsConfig(data)
+	if err != nil {
+		return ModelsConfig{}, fmt.Errorf("error in models config %s: %w", path, err)
+	}
+	return models, nil
+}
+
+// ParseModelsConfig parses and validates a model catalog.
+// Keys not part of the shared schema, e.g. credentials of the providers, are ignored.
+//
+// Parameters:
+//   - data: the YAML content
+//
+// Returns:
+//   - ModelsConfig: the model catalog
+//   - error: an error if the content cannot be parsed or is invalid
+func ParseModelsConfig(data []byte) (ModelsConfig, error) {
+	var models ModelsConfig
+	if err := yaml.Unmarshal(data, &models); err != nil {
+		return ModelsConfig{}, fmt.Errorf("error parsing models config: %w", err)
+	}
+	if err := models.Validate(); err != nil {
+		return ModelsConfig{}, err
+	}
+	return models, nil
+}
+
+// Validate checks all models of the catalog and that their ids are unique.
+//
+// Returns:
+//   - error: the errors of all invalid models, joined
+func (mc *ModelsConfig) Validate() error {
+	var errs []error
+	ids := map[string]bool{}
+	for i := range mc.Models {
+		model := &mc.Models[i]
+		if err := model.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("model %d: %w", i, err))
+		}
+		if model.Id != "" && ids[model.Id] {
+			errs = append(errs, fmt.Errorf("model %d: duplicate id %q", i, model.Id))
+		}
+		ids[model.Id] = true
+	}
+	return errors.Join(errs...)
+}
+
+// Model returns the model with the given id.
+//
+// Parameters:
+//   - id: the id of the model
+//
+// Returns:
+//   - ModelConfig: the model
+//   - bool: false if the catalog has no model with the id
+func (mc *ModelsConfig) Model(id string) (ModelConfig, bool) {
+	for _, model := range mc.Models {
+		if model.Id == id {
+			return model, true
+		}
+	}
+	return ModelConfig{}, false
+}
+
+// ModelsInCategory returns the models of a category in the order of the catalog.
+//
+// Parameters:
+//   - category: the category, compared case-insensitively
+//
+// Returns:
+//   - []ModelConfig: the models of the category
+func (mc *ModelsConfig) ModelsInCategory(category string) []ModelConfig {
+	var models []ModelConfig
+	for _, model := range mc.Models {
+		if model.HasCategory(category) {
+			models = append(models, model)
+		}
+	}
+	return models
+}
+
+// Validate checks the model for missing and out of range values.
+//
+// Returns:
+//   - error: an error describing the first invalid value, nil if the model is valid
+func (m *ModelConfig) Validate() error {
+	switch {
+	case strings.TrimSpace(m.Id) == "":
+		return errors.New("id must not be empty")
+	case strings.TrimSpace(m.Provider) == "":
+		return fmt.Errorf("model %q: provider must not be empty", m.Id)
+	case m.ContextWindow < 0:
+		return fmt.Errorf("model %q: contextWindow must not be negative, got %d", m.Id, m.ContextWindow)
+	case slices.Contains(m.Categories, ""):
+		return fmt.Errorf("model %q: categories must not be empty", m.Id)
+	}
+	if m.Pricing != nil && (m.Pricing.InputPerMillionTokens < 0 || m.Pricing.OutputPerMillionTokens < 0 || m.Pricing.CachedInputPerMillionTokens < 0) {
+		return fmt.Errorf("model %q: pricing must not be negative", m.Id)
+	}
+	if err := m.DefaultModelOptions.Validate(); err != nil {
+		return fmt.Errorf("model %q: invalid defaultModelOptions: %w", m.Id, err)
+	}
+	if m.ContextWindow > 0 && m.DefaultModelOptions.MaxTokens != nil && int(*m.DefaultModelOptions.MaxTokens) > m.ContextWindow {
+		return fmt.Errorf("model %q: default maxTokens %d exceeds contextWindow %d", m.Id, *m.DefaultModelOptions.MaxTokens, m.ContextWindow)
+	}
+	return nil
+}
+
+// HasCategory reports whether the model belongs to the category.
+//
+// Parameters:
+//   - category: the category, compared case-insensitively
+//
+// Returns:
+//   - bool: true if the model lists the category
+func (m *ModelConfig) HasCategory(category string) bool {
+	return slices.ContainsFunc(m.Categories, func(c string) bool { return strings.EqualFold(c, category) })
+}
+
+// EstimateCost estimates the price of a request from its token counts.
+//
+// Parameters:
+//   - inputTokens: the number of input tokens, including the cached ones
+//   - cachedTokens: the number of cached input tokens
+//   - outputTokens: the number of output tokens
+//
+// Returns:
+//   - float64: the price in the currency of the pricing, 0 if the model has no pricing
+func (m *ModelConfig) EstimateCost(inputTokens int, cachedTokens int, outputTokens int) float64 {
+	if m.Pricing == nil {
+		return 0
+	}
+	cachedPrice := m.Pricing.CachedInputPerMillionTokens
+	if cachedPrice == 0 {
+		cachedPrice = m.Pricing.InputPerMillionTokens
+	}
+	cost := float64(inputTokens-cachedTokens)*m.Pricing.InputPerMillionTokens + float64(cachedTokens)*cachedPrice + float64(outputTokens)*m.Pricing.OutputPerMillionTokens
+	return cost / 1_000_000
+}
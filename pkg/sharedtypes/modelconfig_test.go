@@ -0,0 +1,153 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testModelsConfig = `
+MODELS:
+  - ID: gpt-4o
+    PROVIDER: azure-openai
+    ENDPOINT: https://example.openai.azure.com
+    API_KEY: secret
+    CATEGORIES: [chat, code]
+    CONTEXT_WINDOW: 128000
+    PRICING:
+      INPUT_PER_MILLION_TOKENS: 2.5
+      OUTPUT_PER_MILLION_TOKENS: 10
+      CACHED_INPUT_PER_MILLION_TOKENS: 1.25
+    DEFAULT_MODEL_OPTIONS:
+      TEMPERATURE: 0.2
+      MAX_TOKENS: 4096
+  - ID: bge-m3
+    PROVIDER: custom
+    CATEGORIES: [Embeddings]
+`
+
+func TestParseModelsConfig(t *testing.T) {
+	models, err := ParseModelsConfig([]byte(testModelsConfig))
+	if err != nil {
+		t.Fatalf("ParseModelsConfig() error = %v", err)
+	}
+
+	expected := ModelConfig{
+		Id:            "gpt-4o",
+		Provider:      "azure-openai",
+		Endpoint:      "https://example.openai.azure.com",
+		Categories:    []string{"chat", "code"},
+		ContextWindow: 128000,
+		Pricing:       &ModelPricing{InputPerMillionTokens: 2.5, OutputPerMillionTokens: 10, CachedInputPerMillionTokens: 1.25},
+		DefaultModelOptions: ModelOptions{
+			Temperature: ptr(float32(0.2)),
+			MaxTokens:   ptr(int32(4096)),
+		},
+	}
+	model, ok := models.Model("gpt-4o")
+	if !ok || !reflect.DeepEqual(model, expected) {
+		t.Errorf("Model(gpt-4o) = %+v, %v, want %+v", model, ok, expected)
+	}
+	if _, ok := models.Model("unknown"); ok {
+		t.Error("Model(unknown) found a model")
+	}
+
+	embeddings := models.ModelsInCategory("embeddings")
+	if len(embeddings) != 1 || embeddings[0].Id != "bge-m3" {
+		t.Errorf("ModelsInCategory(embeddings) = %+v, want bge-m3", embeddings)
+	}
+	if chat := models.ModelsInCategory("vision"); len(chat) != 0 {
+		t.Errorf("ModelsInCategory(vision) = %+v, want none", chat)
+	}
+}
+
+func TestModelsConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		errText string
+	}{
+		{"missing id", "MODELS: [{PROVIDER: openai}]", "id must not be empty"},
+		{"missing provider", "MODELS: [{ID: a}]", "provider must not be empty"},
+		{"duplicate id", "MODELS: [{ID: a, PROVIDER: openai}, {ID: a, PROVIDER: openai}]", "duplicate id \"a\""},
+		{"negative context window", "MODELS: [{ID: a, PROVIDER: openai, CONTEXT_WINDOW: -1}]", "contextWindow must not be negative"},
+		{"empty category", "MODELS: [{ID: a, PROVIDER: openai, CATEGORIES: ['']}]", "categories must not be empty"},
+		{"negative pricing", "MODELS: [{ID: a, PROVIDER: openai, PRICING: {INPUT_PER_MILLION_TOKENS: -1}}]", "pricing must not be negative"},
+		{"invalid default options", "MODELS: [{ID: a, PROVIDER: openai, DEFAULT_MODEL_OPTIONS: {TEMPERATURE: 3}}]", "invalid defaultModelOptions"},
+		{"max tokens above context window", "MODELS: [{ID: a, PROVIDER: openai, CONTEXT_WINDOW: 100, DEFAULT_MODEL_OPTIONS: {MAX_TOKENS: 200}}]", "exceeds contextWindow"},
+		{"invalid yaml", "MODELS: {", "error parsing models config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseModelsConfig([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("ParseModelsConfig() error = %v, want error containing %q", err, tt.errText)
+			}
+		})
+	}
+}
+
+func TestLoadModelsConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "models.yaml")
+	if err := os.WriteFile(path, []byte(testModelsConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	models, err := LoadModelsConfig(path)
+	if err != nil || len(models.Models) != 2 {
+		t.Errorf("LoadModelsConfig() = %+v, %v, want 2 models", models, err)
+	}
+
+	if _, err := LoadModelsConfig(""); err == nil {
+		t.Error("LoadModelsConfig(\"\") succeeded, want error")
+	}
+	if _, err := LoadModelsConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadModelsConfig() of missing file succeeded, want error")
+	}
+}
+
+func TestModelConfigEstimateCost(t *testing.T) {
+	tests := []struct {
+		name     string
+		pricing  *ModelPricing
+		expected float64
+	}{
+		{"no pricing", nil, 0},
+		{"cached price", &ModelPricing{InputPerMillionTokens: 2, OutputPerMillionTokens: 8, CachedInputPerMillionTokens: 1}, (800*2 + 200*1 + 500*8) / 1e6},
+		{"cached charged as input", &ModelPricing{InputPerMillionTokens: 2, OutputPerMillionTokens: 8}, (1000*2 + 500*8) / 1e6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := ModelConfig{Pricing: tt.pricing}
+			if got := model.EstimateCost(1000, 200, 500); math.Abs(got-tt.expected) > 1e-12 {
+				t.Errorf("EstimateCost() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// RoutingPolicy selects the models for a request from the model catalog and defines the fallback between them.
+// The handler and the agent resolve it with ResolveModelRoute, so both select the same models.
+type RoutingPolicy struct {
+	PreferredModels []string       `json:"preferredModels,omitempty" yaml:"PREFERRED_MODELS,omitempty"` // ids of the models tried first, in this order, if they are candidates of the request
+	Categories      []string       `json:"categories,omitempty" yaml:"CATEGORIES,omitempty"`            // default categories for requests without HandlerRequest.ModelCategory
+	Fallback        FallbackPolicy `json:"fallback" yaml:"FALLBACK"`
+}
+
+// FallbackPolicy defines when the next candidate model is tried after a model failed.
+type FallbackPolicy struct {
+	OnError        bool `json:"onError,omitempty" yaml:"ON_ERROR,omitempty"`               // fall back if the model returned an error
+	OnTimeout      bool `json:"onTimeout,omitempty" yaml:"ON_TIMEOUT,omitempty"`           // fall back if the model did not answer in time
+	MaxAttempts    int  `json:"maxAttempts,omitempty" yaml:"MAX_ATTEMPTS,omitempty"`       // maximum number of models tried; 0 means all candidates
+	TimeoutSeconds int  `json:"timeoutSeconds,omitempty" yaml:"TIMEOUT_SECONDS,omitempty"` // maximum time per attempt in seconds; 0 means no timeout
+}
+
+// FallbackReason is the kind of failure of a model.
+type FallbackReason string
+
+// Kinds of failures of a model.
+const (
+	FallbackReasonError   FallbackReason = "error"
+	FallbackReasonTimeout FallbackReason = "timeout"
+)
+
+// ModelRoute is the resolved list of models for a request, in the order they are tried.
+type ModelRoute struct {
+	Models   []ModelConfig  `json:"models"`
+	Fallback FallbackPolicy `json:"fallback"`
+}
+
+// Validate checks the policy for negative limits and empty model ids and categories.
+//
+// Returns:
+//   - error: an error describing the first invalid value, nil if the policy is valid
+func (p *RoutingPolicy) Validate() error {
+	if slices.Contains(p.PreferredModels, "") {
+		return errors.New("preferredModels must not contain empty ids")
+	}
+	if slices.Contains(p.Categories, "") {
+		return errors.New("categories must not be empty")
+	}
+	if p.Fallback.MaxAttempts < 0 {
+		return fmt.Errorf("fallback.maxAttempts must not be negative, got %d", p.Fallback.MaxAttempts)
+	}
+	if p.Fallback.TimeoutSeconds < 0 {
+		return fmt.Errorf("fallback.timeoutSeconds must not be negative, got %d", p.Fallback.TimeoutSeconds)
+	}
+	return nil
+}
+
+// ResolveModelRoute selects the models for a request.
+//
+// The candidates are the models of HandlerRequest.ModelIds in their order, or all models of the catalog if the
+// request names none. If the request has model categories, or else the policy has categories, only candidates
+// of these categories are kept, ordered by the first matching category from first to last. Finally the preferred
+// models of the policy are moved to the front; the order of the other candidates is kept.
+//
+// Parameters:
+//   - catalog: the model catalog
+//   - policy: the routing policy
+//   - request: the request to route
+//
+// Returns:
+//   - ModelRoute: the models to try in order; the list is limited to fallback.maxAttempts models
+//   - error: an error if the policy is invalid, the request names an unknown model or no model matches
+func ResolveModelRoute(catalog ModelsConfig, policy RoutingPolicy, request HandlerRequest) (ModelRoute, error) {
+	if err := policy.Validate(); err != nil {
+		return ModelRoute{}, fmt.Errorf("invalid routing policy: %w", err)
+	}
+
+	candidates := catalog.Models
+	if len(request.ModelIds) > 0 {
+		candidates = make([]ModelConfig, 0, len(request.ModelIds))
+		for _, id := range request.ModelIds {
+			model, ok := catalog.Model(id)
+			if !ok {
+				return ModelRoute{}, fmt.Errorf("model %q is not in the model catalog", id)
+			}
+			if !slices.ContainsFunc(candidates, func(m ModelConfig) bool { return m.Id == id }) {
+				candidates = append(candidates, model)
+			}
+		}
+	}
+
+	categories := request.ModelCategory
+	if len(categories) == 0 {
+		categories = policy.Categories
+	}
+	if len(categories) > 0 {
+		var inCategories []ModelConfig
+		for _, category := range categories {
+			for _, model := range candidates {
+				if model.HasCategory(category) && !slices.ContainsFunc(inCategories, func(m ModelConfig) bool { return m.Id == model.Id }) {
+					inCategories = append(inCategories, model)
+				}
+			}
+		}
+		candidates = inCategories
+	}
+
+	models := make([]ModelConfig, 0, len(candidates))
+	for _, id := range policy.PreferredModels {
+		if i := slices.IndexFunc(candidates, func(m ModelConfig) bool { return m.Id == id }); i >= 0 && !slices.ContainsFunc(models, func(m ModelConfig) bool { return m.Id == id }) {
+			models = append(models, candidates[i])
+		}
+	}
+	for _, model := range candidates {
+		if !slices.Contains(policy.PreferredModels, model.Id) {
+			models = append(models, model)
+		}
+	}
+
+	if len(models) == 0 {
+		return ModelRoute{}, fmt.Errorf("no model matches model ids %v and categories %v", request.ModelIds, categories)
+	}
+	if policy.Fallback.MaxAttempts > 0 && len(models) > policy.Fallback.MaxAttempts {
+		models = models[:policy.Fallback.MaxAttempts]
+	}
+	return ModelRoute{Models: models, Fallback: policy.Fallback}, nil
+}
+
+// Next returns the model to try after a failed attempt.
+//
+// Parameters:
+//   - attempt: the index of the failed attempt in the models of the route
+//   - reason: the kind of failure, see FallbackReasonOf
+//
+// Returns:
+//   - ModelConfig: the next model
+//   - bool: false if the fallback policy does not fall back for the reason or all models were tried
+func (r *ModelRoute) Next(attempt int, reason FallbackReason) (ModelConfig, bool) {
+	switch {
+	case reason == FallbackReasonError && !r.Fallback.OnError,
+		reason == FallbackReasonTimeout && !r.Fallback.OnTimeout,
+		reason != FallbackReasonError && reason != FallbackReasonTimeout,
+		attempt < 0 || attempt+1 >= len(r.Models):
+		return ModelConfig{}, false
+	}
+	return r.Models[attempt+1], true
+}
+
+// FallbackReasonOf classifies the error of a failed attempt.
+//
+// Parameters:
+//   - err: the error of the attempt
+//
+// Returns:
+//   - FallbackReason: "timeout" for exceeded deadlines and errors reporting a timeout, "error" otherwise
+func FallbackReasonOf(err error) FallbackReason {
+	var timeout interface{ Timeout() bool }
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &timeout) && timeout.Timeout()) {
+		return FallbackReasonTimeout
+	}
+	return FallbackReasonError
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveModelRoute(t *testing.T) {
+	catalog := ModelsConfig{Models: []ModelConfig{
+		{Id: "gpt-4o", Provider: "azure-openai", Categories: []string{"chat", "vision"}},
+		{Id: "claude", Provider: "anthropic", Categories: []string{"chat", "code"}},
+		{Id: "codestral", Provider: "mistral", Categories: []string{"code"}},
+		{Id: "bge-m3", Provider: "custom", Categories: []string{"embeddings"}},
+	}}
+
+	tests := []struct {
+		name     string
+		policy   RoutingPolicy
+		request  HandlerRequest
+		expected []string
+		errText  string
+	}{
+		{"whole catalog", RoutingPolicy{}, HandlerRequest{}, []string{"gpt-4o", "claude", "codestral", "bge-m3"}, ""},
+		{"request model ids", RoutingPolicy{}, HandlerRequest{ModelIds: []string{"codestral", "gpt-4o", "codestral"}}, []string{"codestral", "gpt-4o"}, ""},
+		{"unknown model id", RoutingPolicy{}, HandlerRequest{ModelIds: []string{"gpt-5"}}, nil, "model \"gpt-5\" is not in the model catalog"},
+		{"categories in order", RoutingPolicy{}, HandlerRequest{ModelCategory: []string{"code", "Chat"}}, []string{"claude", "codestral", "gpt-4o"}, ""},
+		{"policy categories", RoutingPolicy{Categories: []string{"embeddings"}}, HandlerRequest{}, []string{"bge-m3"}, ""},
+		{"request categories override policy", RoutingPolicy{Categories: []string{"embeddings"}}, HandlerRequest{ModelCategory: []string{"vision"}}, []string{"gpt-4o"}, ""},
+		{"model ids filtered by category", RoutingPolicy{}, HandlerRequest{ModelIds: []string{"bge-m3", "claude"}, ModelCategory: []string{"chat"}}, []string{"claude"}, ""},
+		{"preferred models first", RoutingPolicy{PreferredModels: []string{"codestral", "unknown", "claude"}}, HandlerRequest{ModelCategory: []string{"chat", "code"}}, []string{"codestral", "claude", "gpt-4o"}, ""},
+		{"max attempts", RoutingPolicy{Fallback: FallbackPolicy{MaxAttempts: 2}}, HandlerRequest{}, []string{"gpt-4o", "claude"}, ""},
+		{"no match", RoutingPolicy{}, HandlerRequest{ModelCategory: []string{"audio"}}, nil, "no model matches"},
+		{"invalid policy", RoutingPolicy{Fallback: FallbackPolicy{MaxAttempts: -1}}, HandlerRequest{}, nil, "invalid routing policy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, err := ResolveModelRoute(catalog, tt.policy, tt.request)
+			if tt.errText != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errText) {
+					t.Errorf("ResolveModelRoute() error = %v, want error containing %q", err, tt.errText)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveModelRoute() error = %v", err)
+			}
+			var ids []string
+			for _, model := range route.Models {
+				ids = append(ids, model.Id)
+			}
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("ResolveModelRoute() models = %v, want %v", ids, tt.expected)
+			}
+		})
+	}
+}
+
+func TestModelRouteNext(t *testing.T) {
+	models := []ModelConfig{{Id: "a"}, {Id: "b"}}
+	tests := []struct {
+		name     string
+		fallback FallbackPolicy
+		attempt  int
+		reason   FallbackReason
+		expected string
+	}{
+		{"error with fallback on error", FallbackPolicy{OnError: true}, 0, FallbackReasonError, "b"},
+		{"error without fallback on error", FallbackPolicy{OnTimeout: true}, 0, FallbackReasonError, ""},
+		{"timeout with fallback on timeout", FallbackPolicy{OnTimeout: true}, 0, FallbackReasonTimeout, "b"},
+		{"timeout without fallback on timeout", FallbackPolicy{OnError: true}, 0, FallbackReasonTimeout, ""},
+		{"last model", FallbackPolicy{OnError: true}, 1, FallbackReasonError, ""},
+		{"unknown reason", FallbackPolicy{OnError: true, OnTimeout: true}, 0, "cancelled", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := ModelRoute{Models: models, Fallback: tt.fallback}
+			model, ok := route.Next(tt.attempt, tt.reason)
+			if ok != (tt.expected != "") || model.Id != tt.expected {
+				t.Errorf("Next() = %q, %v, want %q", model.Id, ok, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFallbackReasonOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected FallbackReason
+	}{
+		{"deadline exceeded", fmt.Errorf("request failed: %w", context.DeadlineExceeded), FallbackReasonTimeout},
+		{"timeout error", fmt.Errorf("read failed: %w", os.ErrDeadlineExceeded), FallbackReasonTimeout},
+		{"other error", errors.New("rate limited"), FallbackReasonError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FallbackReasonOf(tt.err); got != tt.expected {
+				t.Errorf("FallbackReasonOf() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
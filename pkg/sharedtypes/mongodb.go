@@ -0,0 +1,170 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"fmt"
+	"time"
+)
+
+// MongoDB collections used in multi-agent mode (MONGO_DB_FOR_MULTI_AGENT)
+const (
+	MongoCollectionConversations = "conversations"
+	MongoCollectionWorkflowRuns  = "workflow_runs"
+	MongoCollectionFeedback      = "feedback"
+)
+
+// MongoIndexKey represents one key of a MongoDB index.
+type MongoIndexKey struct {
+	Field string `json:"field"`
+	Order int    `json:"order"` // 1 for ascending, -1 for descending
+}
+
+// MongoIndex represents a MongoDB index definition.
+type MongoIndex struct {
+	Name               string          `json:"name"`
+	Keys               []MongoIndexKey `json:"keys"`
+	Unique             bool            `json:"unique,omitempty"`
+	ExpireAfterSeconds *int32          `json:"expire_after_seconds,omitempty"` // TTL index if set
+}
+
+// MongoCollectionIndexes are the indexes of the multi-agent collections by collection name.
+var MongoCollectionIndexes = map[string][]MongoIndex{
+	MongoCollectionConversations: {
+		{Name: "user_updated", Keys: []MongoIndexKey{{Field: "user_id", Order: 1}, {Field: "updated_at", Order: -1}}},
+		{Name: "workflow", Keys: []MongoIndexKey{{Field: "workflow_id", Order: 1}}},
+	},
+	MongoCollectionWorkflowRuns: {
+		{Name: "workflow_created", Keys: []MongoIndexKey{{Field: "workflow_id", Order: 1}, {Field: "created_at", Order: -1}}},
+		{Name: "user_created", Keys: []MongoIndexKey{{Field: "user_id", Order: 1}, {Field: "created_at", Order: -1}}},
+		{Name: "conversation", Keys: []MongoIndexKey{{Field: "conversation_id", Order: 1}}},
+		{Name: "status", Keys: []MongoIndexKey{{Field: "status", Order: 1}}},
+	},
+	MongoCollectionFeedback: {
+		{Name: "conversation_message", Keys: []MongoIndexKey{{Field: "conversation_id", Order: 1}, {Field: "message_id", Order: 1}, {Field: "user_id", Order: 1}}, Unique: true},
+		{Name: "workflow_created", Keys: []MongoIndexKey{{Field: "workflow_id", Order: 1}, {Field: "created_at", Order: -1}}},
+	},
+}
+
+// ConversationDocument represents a conversation stored in the conversations collection.
+type ConversationDocument struct {
+	Id         string                       `bson:"_id" json:"_id"`
+	UserId     string                       `bson:"user_id" json:"user_id"`
+	WorkflowId string                       `bson:"workflow_id" json:"workflow_id"`
+	Title      string                       `bson:"title,omitempty" json:"title,omitempty"`
+	Messages   []ConversationHistoryMessage `bson:"messages" json:"messages"`
+	CreatedAt  time.Time                    `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time                    `bson:"updated_at" json:"updated_at"`
+}
+
+// WorkflowRunDocument represents a workflow run stored in the workflow_runs collection.
+type WorkflowRunDocument struct {
+	Id             string                      `bson:"_id" json:"_id"` // workflow run ID
+	WorkflowId     string                      `bson:"workflow_id" json:"workflow_id"`
+	UserId         string                      `bson:"user_id" json:"user_id"`
+	ConversationId string                      `bson:"conversation_id,omitempty" json:"conversation_id,omitempty"`
+	Status         string                      `bson:"status" json:"status"` // one of WorkflowRunStatuses
+	Inputs         map[string]WorkflowRunValue `bson:"inputs,omitempty" json:"inputs,omitempty"`
+	Outputs        map[string]WorkflowRunValue `bson:"outputs,omitempty" json:"outputs,omitempty"`
+	Error          *ApiError                   `bson:"error,omitempty" json:"error,omitempty"`
+	SnapshotIds    []string                    `bson:"snapshot_ids,omitempty" json:"snapshot_ids,omitempty"`
+	CreatedAt      time.Time                   `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time                   `bson:"updated_at" json:"updated_at"`
+	FinishedAt     *time.Time                  `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+}
+
+// FeedbackDocument represents the feedback of a user on a message, stored in the feedback collection.
+type FeedbackDocument struct {
+	Id             string    `bson:"_id" json:"_id"`
+	ConversationId string    `bson:"conversation_id" json:"conversation_id"`
+	MessageId      string    `bson:"message_id" json:"message_id"`
+	UserId         string    `bson:"user_id" json:"user_id"`
+	WorkflowId     string    `bson:"workflow_id" json:"workflow_id"`
+	Positive       bool      `bson:"positive" json:"positive"`
+	Negative       bool      `bson:"negative" json:"negative"`
+	Text           string    `bson:"text,omitempty" json:"text,omitempty"`
+	CreatedAt      time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Validate checks that the conversation has an ID and a user.
+//
+// Returns:
+//   - error: an error if the conversation is invalid
+func (d *ConversationDocument) Validate() error {
+	if d.Id == "" {
+		return fmt.Errorf("conversation id is required")
+	}
+	if d.UserId == "" {
+		return fmt.Errorf("conversation user_id is required")
+	}
+	return nil
+}
+
+// Validate checks that the run has an ID, a workflow and a valid status.
+//
+// Returns:
+//   - error: an error if the run is invalid
+func (d *WorkflowRunDocument) Validate() error {
+	if d.Id == "" {
+		return fmt.Errorf("workflow run id is required")
+	}
+	if d.WorkflowId == "" {
+		return fmt.Errorf("workflow run workflow_id is required")
+	}
+	if !isWorkflowRunStatus(d.Status) {
+		return fmt.Errorf("invalid workflow run status '%s'", d.Status)
+	}
+	return nil
+}
+
+// Validate checks that the feedback references a message and is not both positive and negative.
+//
+// Returns:
+//   - error: an error if the feedback is invalid
+func (d *FeedbackDocument) Validate() error {
+	if d.Id == "" {
+		return fmt.Errorf("feedback id is required")
+	}
+	if d.ConversationId == "" || d.MessageId == "" {
+		return fmt.Errorf("feedback conversation_id and message_id are required")
+	}
+	if d.Positive && d.Negative {
+		return fmt.Errorf("feedback cannot be both positive and negative")
+	}
+	return nil
+}
+
+// FeedbackDocumentId returns the deterministic ID of the feedback of a user on a message,
+// so that repeated feedback replaces the previous one.
+//
+// Parameters:
+//   - conversationId: the conversation ID
+//   - messageId: the message ID
+//   - userId: the user ID
+//
+// Returns:
+//   - string: the feedback document ID
+func FeedbackDocumentId(conversationId string, messageId string, userId string) string {
+	return conversationId + ":" + messageId + ":" + userId
+}
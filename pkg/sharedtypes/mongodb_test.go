@@ -0,0 +1,68 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"testing"
+)
+
+func TestMongoDocumentsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     interface{ Validate() error }
+		wantErr bool
+	}{
+		{"conversation", &ConversationDocument{Id: "c", UserId: "u"}, false},
+		{"conversation without user", &ConversationDocument{Id: "c"}, true},
+		{"conversation without id", &ConversationDocument{UserId: "u"}, true},
+		{"run", &WorkflowRunDocument{Id: "r", WorkflowId: "wf", Status: WorkflowRunStatusRunning}, false},
+		{"run without workflow", &WorkflowRunDocument{Id: "r", Status: WorkflowRunStatusRunning}, true},
+		{"run with unknown status", &WorkflowRunDocument{Id: "r", WorkflowId: "wf", Status: "done"}, true},
+		{"feedback", &FeedbackDocument{Id: "f", ConversationId: "c", MessageId: "m", Positive: true}, false},
+		{"feedback without message", &FeedbackDocument{Id: "f", ConversationId: "c"}, true},
+		{"feedback positive and negative", &FeedbackDocument{Id: "f", ConversationId: "c", MessageId: "m", Positive: true, Negative: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.doc.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMongoCollectionIndexes(t *testing.T) {
+	for _, collection := range []string{MongoCollectionConversations, MongoCollectionWorkflowRuns, MongoCollectionFeedback} {
+		indexes, ok := MongoCollectionIndexes[collection]
+		if !ok || len(indexes) == 0 {
+			t.Errorf("no indexes for collection '%s'", collection)
+		}
+		names := map[string]bool{}
+		for _, index := range indexes {
+			if index.Name == "" || len(index.Keys) == 0 || names[index.Name] {
+				t.Errorf("invalid index %+v in collection '%s'", index, collection)
+			}
+			names[index.Name] = true
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import "time"
+
+// NodeTrace is the execution trace of a single function call of a workflow node.
+// Retries of a node produce one trace per attempt.
+type NodeTrace struct {
+	WorkflowRunId string         `json:"workflow_run_id,omitempty"` // run the node belongs to, empty outside of workflow runs
+	NodeId        string         `json:"node_id,omitempty"`         // ID of the node in the workflow, empty outside of workflow runs
+	FunctionName  string         `json:"function_name"`
+	StartedAt     time.Time      `json:"started_at"`
+	FinishedAt    time.Time      `json:"finished_at"`
+	InputSizes    map[string]int `json:"input_sizes,omitempty"`  // size in bytes of each input as sent to the function
+	OutputSizes   map[string]int `json:"output_sizes,omitempty"` // size in bytes of each output as received from the function
+	Retries       int            `json:"retries,omitempty"`      // number of previous attempts of the node
+	Cached        bool           `json:"cached,omitempty"`       // true if the outputs were taken from the result cache
+	Error         string         `json:"error,omitempty"`        // error message, only set if the call failed
+	ErrorCode     string         `json:"error_code,omitempty"`   // aalierrors code of the error, only set if the call failed
+}
+
+// Duration returns the time spent in the function call
+//
+// Returns:
+//   - time.Duration: the duration; 0 if the call has not finished
+func (t NodeTrace) Duration() time.Duration {
+	if t.FinishedAt.IsZero() || t.FinishedAt.Before(t.StartedAt) {
+		return 0
+	}
+	return t.FinishedAt.Sub(t.StartedAt)
+}
+
+// Succeeded reports whether the function call returned without error
+//
+// Returns:
+//   - bool: true if no error is recorded
+func (t NodeTrace) Succeeded() bool {
+	return t.Error == "" && t.ErrorCode == ""
+}
+
+// TotalInputBytes returns the summed size of all inputs
+//
+// Returns:
+//   - int: the size in bytes
+func (t NodeTrace) TotalInputBytes() int {
+	return sumSizes(t.InputSizes)
+}
+
+// TotalOutputBytes returns the summed size of all outputs
+//
+// Returns:
+//   - int: the size in bytes
+func (t NodeTrace) TotalOutputBytes() int {
+	return sumSizes(t.OutputSizes)
+}
+
+// sumSizes returns the sum of the given sizes
+func sumSizes(sizes map[string]int) (total int) {
+	for _, size := range sizes {
+		total += size
+	}
+	return total
+}
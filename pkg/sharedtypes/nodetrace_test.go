@@ -0,0 +1,107 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNodeTrace(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		trace        NodeTrace
+		wantDuration time.Duration
+		wantSuccess  bool
+		wantInput    int
+		wantOutput   int
+	}{
+		{
+			name: "succeeded",
+			trace: NodeTrace{
+				FunctionName: "echo",
+				StartedAt:    start,
+				FinishedAt:   start.Add(1500 * time.Millisecond),
+				InputSizes:   map[string]int{"text": 10, "count": 2},
+				OutputSizes:  map[string]int{"text": 10},
+			},
+			wantDuration: 1500 * time.Millisecond,
+			wantSuccess:  true,
+			wantInput:    12,
+			wantOutput:   10,
+		},
+		{
+			name:         "failed",
+			trace:        NodeTrace{FunctionName: "echo", StartedAt: start, FinishedAt: start, Error: "boom", ErrorCode: "internal"},
+			wantDuration: 0,
+			wantSuccess:  false,
+		},
+		{
+			name:         "not finished",
+			trace:        NodeTrace{FunctionName: "echo", StartedAt: start},
+			wantDuration: 0,
+			wantSuccess:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.trace.Duration(); got != tt.wantDuration {
+				t.Errorf("Duration() = %v, want %v", got, tt.wantDuration)
+			}
+			if got := tt.trace.Succeeded(); got != tt.wantSuccess {
+				t.Errorf("Succeeded() = %v, want %v", got, tt.wantSuccess)
+			}
+			if got := tt.trace.TotalInputBytes(); got != tt.wantInput {
+				t.Errorf("TotalInputBytes() = %v, want %v", got, tt.wantInput)
+			}
+			if got := tt.trace.TotalOutputBytes(); got != tt.wantOutput {
+				t.Errorf("TotalOutputBytes() = %v, want %v", got, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestNodeTraceJSON(t *testing.T) {
+	data, err := json.Marshal(NodeTrace{FunctionName: "echo", NodeId: "node-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"function_name", "node_id", "started_at", "finished_at"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("missing field %q in %s", key, data)
+		}
+	}
+	for _, key := range []string{"workflow_run_id", "input_sizes", "retries", "cached", "error"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("unexpected field %q in %s", key, data)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ProblemContentType is the content type of a ProblemDetails response body.
+const ProblemContentType = "application/problem+json"
+
+// CorrelationIdHeader is the header carrying the ID correlating a request with its logs and error responses.
+const CorrelationIdHeader = "X-Correlation-Id"
+
+// ProblemTypePrefix is the prefix of the ProblemDetails types of the aali error codes, e.g. "urn:aali:problem:validation".
+const ProblemTypePrefix = "urn:aali:problem:"
+
+// ProblemDetails is the RFC 7807 error body returned by the REST APIs with content type ProblemContentType.
+type ProblemDetails struct {
+	Type          string            `json:"type"`                    // URI identifying the kind of problem; "about:blank" if only the status is known
+	Title         string            `json:"title"`                   // short summary of the kind of problem
+	Status        int               `json:"status"`                  // HTTP status code
+	Detail        string            `json:"detail,omitempty"`        // human-readable explanation of this occurrence
+	Instance      string            `json:"instance,omitempty"`      // URI of this occurrence, e.g. the request path
+	CorrelationId string            `json:"correlationId,omitempty"` // ID to find the logs of the request
+	Code          string            `json:"code,omitempty"`          // aali error code, e.g. "validation"
+	Details       map[string]string `json:"details,omitempty"`       // additional information, e.g. the workflow ID
+}
+
+// NewProblemDetails creates a problem of an HTTP status, titled with the status text.
+//
+// Parameters:
+//   - status: the HTTP status code
+//   - detail: the human-readable explanation
+//
+// Returns:
+//   - ProblemDetails: the problem with type "about:blank"
+func NewProblemDetails(status int, detail string) ProblemDetails {
+	return ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// Error implements the error interface.
+//
+// Returns:
+//   - string: the status, the title and the detail
+func (p *ProblemDetails) Error() string {
+	if p.Detail == "" {
+		return fmt.Sprintf("%d %s", p.Status, p.Title)
+	}
+	return fmt.Sprintf("%d %s: %s", p.Status, p.Title, p.Detail)
+}
@@ -0,0 +1,201 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Quantity represents a physical value with its unit, e.g. 10 mm.
+type Quantity struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// Physical dimensions of the supported units.
+const (
+	QuantityDimensionLength    = "length"
+	QuantityDimensionFrequency = "frequency"
+	QuantityDimensionTime      = "time"
+	QuantityDimensionMass      = "mass"
+	QuantityDimensionPressure  = "pressure"
+	QuantityDimensionForce     = "force"
+	QuantityDimensionVoltage   = "voltage"
+	QuantityDimensionAngle     = "angle"
+)
+
+// quantityUnit describes a supported unit by its dimension and its factor to the SI unit of the dimension.
+type quantityUnit struct {
+	dimension string
+	factor    float64
+}
+
+// quantityUnits contains the supported units. The length units include all DiscoveryLengthUnits.
+var quantityUnits = map[string]quantityUnit{
+	"m":   {QuantityDimensionLength, 1},
+	"cm":  {QuantityDimensionLength, 1e-2},
+	"mm":  {QuantityDimensionLength, 1e-3},
+	"um":  {QuantityDimensionLength, 1e-6},
+	"nm":  {QuantityDimensionLength, 1e-9},
+	"km":  {QuantityDimensionLength, 1e3},
+	"in":  {QuantityDimensionLength, 0.0254},
+	"ft":  {QuantityDimensionLength, 0.3048},
+	"mil": {QuantityDimensionLength, 0.0254e-3},
+
+	"Hz":  {QuantityDimensionFrequency, 1},
+	"kHz": {QuantityDimensionFrequency, 1e3},
+	"MHz": {QuantityDimensionFrequency, 1e6},
+	"GHz": {QuantityDimensionFrequency, 1e9},
+
+	"s":   {QuantityDimensionTime, 1},
+	"ms":  {QuantityDimensionTime, 1e-3},
+	"us":  {QuantityDimensionTime, 1e-6},
+	"ns":  {QuantityDimensionTime, 1e-9},
+	"min": {QuantityDimensionTime, 60},
+	"h":   {QuantityDimensionTime, 3600},
+
+	"kg": {QuantityDimensionMass, 1},
+	"g":  {QuantityDimensionMass, 1e-3},
+
+	"Pa":  {QuantityDimensionPressure, 1},
+	"kPa": {QuantityDimensionPressure, 1e3},
+	"MPa": {QuantityDimensionPressure, 1e6},
+	"GPa": {QuantityDimensionPressure, 1e9},
+	"bar": {QuantityDimensionPressure, 1e5},
+
+	"N":  {QuantityDimensionForce, 1},
+	"kN": {QuantityDimensionForce, 1e3},
+
+	"V":  {QuantityDimensionVoltage, 1},
+	"mV": {QuantityDimensionVoltage, 1e-3},
+	"kV": {QuantityDimensionVoltage, 1e3},
+
+	"rad": {QuantityDimensionAngle, 1},
+	"deg": {QuantityDimensionAngle, 0.017453292519943295},
+}
+
+// ParseQuantity parses a quantity like "10 mm", "2.4GHz" or "1e-3 m".
+//
+// Parameters:
+//   - value: the quantity as string
+//
+// Returns:
+//   - Quantity: the parsed quantity
+//   - error: an error if the value cannot be parsed or the unit is not supported
+func ParseQuantity(value string) (Quantity, error) {
+	trimmed := strings.TrimSpace(value)
+
+	// the number is the longest prefix that parses as float, the rest is the unit
+	for i := len(trimmed); i > 0; i-- {
+		number, err := strconv.ParseFloat(trimmed[:i], 64)
+		if err != nil {
+			continue
+		}
+		quantity := Quantity{Value: number, Unit: strings.TrimSpace(trimmed[i:])}
+		if quantity.Unit == "" {
+			return Quantity{}, fmt.Errorf("quantity %q has no unit", value)
+		}
+		if err := quantity.Validate(); err != nil {
+			return Quantity{}, err
+		}
+		return quantity, nil
+	}
+	return Quantity{}, fmt.Errorf("invalid quantity %q", value)
+}
+
+// Validate checks that the unit of the quantity is supported.
+//
+// Returns:
+//   - error: an error if the unit is not supported
+func (q Quantity) Validate() error {
+	if _, ok := quantityUnits[q.Unit]; !ok {
+		return fmt.Errorf("unsupported unit %q", q.Unit)
+	}
+	return nil
+}
+
+// Dimension returns the physical dimension of the quantity, e.g. "length".
+//
+// Returns:
+//   - string: the dimension, empty if the unit is not supported
+func (q Quantity) Dimension() string {
+	return quantityUnits[q.Unit].dimension
+}
+
+// ConvertTo converts the quantity to another unit of the same dimension.
+//
+// Parameters:
+//   - unit: the target unit, e.g. "m"
+//
+// Returns:
+//   - Quantity: the converted quantity
+//   - error: an error if a unit is not supported or the dimensions differ
+func (q Quantity) ConvertTo(unit string) (Quantity, error) {
+	from, ok := quantityUnits[q.Unit]
+	if !ok {
+		return Quantity{}, fmt.Errorf("unsupported unit %q", q.Unit)
+	}
+	to, ok := quantityUnits[unit]
+	if !ok {
+		return Quantity{}, fmt.Errorf("unsupported unit %q", unit)
+	}
+	if from.dimension != to.dimension {
+		return Quantity{}, fmt.Errorf("cannot convert %s (%s) to %s (%s)", q.Unit, from.dimension, unit, to.dimension)
+	}
+	return Quantity{Value: q.Value * from.factor / to.factor, Unit: unit}, nil
+}
+
+// String returns the quantity in the format accepted by ParseQuantity, e.g. "10 mm".
+func (q Quantity) String() string {
+	return strconv.FormatFloat(q.Value, 'g', -1, 64) + " " + q.Unit
+}
+
+// quantityAlias prevents recursion in the Quantity JSON methods.
+type quantityAlias Quantity
+
+// UnmarshalJSON accepts a quantity either as object {"value": 10, "unit": "mm"} or as string "10 mm".
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '"' {
+		var value string
+		if err := json.Unmarshal(trimmed, &value); err != nil {
+			return err
+		}
+		quantity, err := ParseQuantity(value)
+		if err != nil {
+			return err
+		}
+		*q = quantity
+		return nil
+	}
+	var alias quantityAlias
+	if err := json.Unmarshal(trimmed, &alias); err != nil {
+		return err
+	}
+	*q = Quantity(alias)
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  Quantity
+		expectErr bool
+	}{
+		{"10 mm", Quantity{Value: 10, Unit: "mm"}, false},
+		{"2.4GHz", Quantity{Value: 2.4, Unit: "GHz"}, false},
+		{"1e-3 m", Quantity{Value: 0.001, Unit: "m"}, false},
+		{" -5 deg ", Quantity{Value: -5, Unit: "deg"}, false},
+		{"10", Quantity{}, true},
+		{"10 parsecs", Quantity{}, true},
+		{"mm", Quantity{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			quantity, err := ParseQuantity(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseQuantity() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if quantity != tt.expected {
+				t.Errorf("ParseQuantity() = %v, expected %v", quantity, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQuantityConvertTo(t *testing.T) {
+	tests := []struct {
+		quantity Quantity
+		unit     string
+		expected float64
+	}{
+		{Quantity{Value: 10, Unit: "mm"}, "m", 0.01},
+		{Quantity{Value: 0.5, Unit: "m"}, "mm", 500},
+		{Quantity{Value: 2400, Unit: "MHz"}, "GHz", 2.4},
+		{Quantity{Value: 1, Unit: "in"}, "mm", 25.4},
+	}
+
+	for _, tt := range tests {
+		converted, err := tt.quantity.ConvertTo(tt.unit)
+		if err != nil {
+			t.Errorf("ConvertTo(%s) error = %v", tt.unit, err)
+			continue
+		}
+		if math.Abs(converted.Value-tt.expected) > 1e-9 || converted.Unit != tt.unit {
+			t.Errorf("%v.ConvertTo(%s) = %v, expected %v %s", tt.quantity, tt.unit, converted, tt.expected, tt.unit)
+		}
+	}
+
+	if _, err := (Quantity{Value: 1, Unit: "mm"}).ConvertTo("GHz"); err == nil {
+		t.Error("ConvertTo() expected an error for different dimensions")
+	}
+}
+
+func TestQuantityJson(t *testing.T) {
+	var quantities []Quantity
+	if err := json.Unmarshal([]byte(`["10 mm", {"value": 2.4, "unit": "GHz"}]`), &quantities); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(quantities) != 2 || quantities[0] != (Quantity{Value: 10, Unit: "mm"}) || quantities[1] != (Quantity{Value: 2.4, Unit: "GHz"}) {
+		t.Errorf("Unmarshal() = %v", quantities)
+	}
+
+	data, err := json.Marshal(quantities[0])
+	if err != nil || string(data) != `{"value":10,"unit":"mm"}` {
+		t.Errorf("Marshal() = %s, %v", data, err)
+	}
+	if quantities[0].String() != "10 mm" {
+		t.Errorf("String() = %q", quantities[0].String())
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Workflow actions
+const (
+	WorkflowActionView    = "view"    // read the workflow definition and runs
+	WorkflowActionRun     = "run"     // start workflow runs
+	WorkflowActionEdit    = "edit"    // change the workflow definition
+	WorkflowActionPublish = "publish" // publish a new version of the workflow
+	WorkflowActionManage  = "manage"  // change the ACL of the workflow
+)
+
+// Workflow roles
+const (
+	WorkflowRoleViewer = "viewer"
+	WorkflowRoleRunner = "runner"
+	WorkflowRoleEditor = "editor"
+	WorkflowRoleOwner  = "owner"
+)
+
+// Principal prefixes of a WorkflowACLEntry
+const (
+	PrincipalUserPrefix  = "user:"
+	PrincipalGroupPrefix = "group:"
+	PrincipalEveryone    = "*"
+)
+
+// WorkflowRolePermissions maps each workflow role to the actions it permits.
+var WorkflowRolePermissions = map[string][]string{
+	WorkflowRoleViewer: {WorkflowActionView},
+	WorkflowRoleRunner: {WorkflowActionView, WorkflowActionRun},
+	WorkflowRoleEditor: {WorkflowActionView, WorkflowActionRun, WorkflowActionEdit},
+	WorkflowRoleOwner:  {WorkflowActionView, WorkflowActionRun, WorkflowActionEdit, WorkflowActionPublish, WorkflowActionManage},
+}
+
+// Subject represents the identity an authorization decision is made for.
+type Subject struct {
+	UserId   string   `json:"user_id,omitempty"`
+	UserMail string   `json:"user_mail,omitempty"`
+	Groups   []string `json:"groups,omitempty"`     // groups or app roles of the user, e.g. from the token claims
+	ApiKeyId string   `json:"api_key_id,omitempty"` // ID of the API key if the request was authenticated with one
+}
+
+// WorkflowACLEntry grants a role on a workflow to a principal.
+type WorkflowACLEntry struct {
+	Principal string `json:"principal"` // "user:<user id>", "group:<group>" or "*" for everyone
+	Role      string `json:"role"`      // one of the workflow roles
+}
+
+// WorkflowACL represents the access control list of a workflow.
+type WorkflowACL struct {
+	WorkflowId string             `json:"workflow_id"`
+	Owner      string             `json:"owner"` // user ID of the owner, who always has the owner role
+	Entries    []WorkflowACLEntry `json:"entries,omitempty"`
+}
+
+// WorkflowResource returns the resource name of a workflow used in authorization requests.
+//
+// Parameters:
+//   - workflowId: the ID of the workflow
+//
+// Returns:
+//   - string: the resource name "workflow:<id>"
+func WorkflowResource(workflowId string) string {
+	return "workflow:" + workflowId
+}
+
+// WorkflowRoleAllows checks if a workflow role permits an action.
+//
+// Parameters:
+//   - role: the role
+//   - action: the action
+//
+// Returns:
+//   - bool: true if the role permits the action
+func WorkflowRoleAllows(role string, action string) bool {
+	return slices.Contains(WorkflowRolePermissions[role], action)
+}
+
+// Validate checks that the ACL has a workflow ID and an owner, and that all entries have a known principal and role.
+//
+// Returns:
+//   - error: an error if the ACL is invalid
+func (acl *WorkflowACL) Validate() error {
+	if acl.WorkflowId == "" {
+		return fmt.Errorf("workflow_id is required")
+	}
+	if acl.Owner == "" {
+		return fmt.Errorf("owner of workflow '%s' is required", acl.WorkflowId)
+	}
+	for i, entry := range acl.Entries {
+		if _, ok := WorkflowRolePermissions[entry.Role]; !ok {
+			return fmt.Errorf("entry %d of workflow '%s' has unknown role '%s'", i, acl.WorkflowId, entry.Role)
+		}
+		validPrincipal := entry.Principal == PrincipalEveryone ||
+			(strings.HasPrefix(entry.Principal, PrincipalUserPrefix) && len(entry.Principal) > len(PrincipalUserPrefix)) ||
+			(strings.HasPrefix(entry.Principal, PrincipalGroupPrefix) && len(entry.Principal) > len(PrincipalGroupPrefix))
+		if !validPrincipal {
+			return fmt.Errorf("entry %d of workflow '%s' has invalid principal '%s'", i, acl.WorkflowId, entry.Principal)
+		}
+	}
+	return nil
+}
+
+// RolesOf returns the roles the ACL grants to a subject.
+//
+// Parameters:
+//   - subject: the subject
+//
+// Returns:
+//   - []string: the roles, without duplicates
+func (acl *WorkflowACL) RolesOf(subject Subject) []string {
+	roles := []string{}
+	if subject.UserId != "" && subject.UserId == acl.Owner {
+		roles = append(roles, WorkflowRoleOwner)
+	}
+	for _, entry := range acl.Entries {
+		if principalMatches(entry.Principal, subject) && !slices.Contains(roles, entry.Role) {
+			roles = append(roles, entry.Role)
+		}
+	}
+	return roles
+}
+
+// Allows checks if the ACL permits an action to a subject.
+//
+// Parameters:
+//   - subject: the subject
+//   - action: the action
+//
+// Returns:
+//   - bool: true if one of the roles of the subject permits the action
+func (acl *WorkflowACL) Allows(subject Subject, action string) bool {
+	for _, role := range acl.RolesOf(subject) {
+		if WorkflowRoleAllows(role, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// principalMatches checks if a principal designates the subject.
+func principalMatches(principal string, subject Subject) bool {
+	switch {
+	case principal == PrincipalEveryone:
+		return true
+	case strings.HasPrefix(principal, PrincipalUserPrefix):
+		return subject.UserId != "" && principal[len(PrincipalUserPrefix):] == subject.UserId
+	case strings.HasPrefix(principal, PrincipalGroupPrefix):
+		return slices.Contains(subject.Groups, principal[len(PrincipalGroupPrefix):])
+	}
+	return false
+}
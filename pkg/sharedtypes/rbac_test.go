@@ -0,0 +1,90 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWorkflowACL(t *testing.T) {
+	acl := WorkflowACL{
+		WorkflowId: "wf",
+		Owner:      "alice",
+		Entries: []WorkflowACLEntry{
+			{Principal: "user:bob", Role: WorkflowRoleEditor},
+			{Principal: "group:analysts", Role: WorkflowRoleRunner},
+			{Principal: PrincipalEveryone, Role: WorkflowRoleViewer},
+		},
+	}
+	if err := acl.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		subject   Subject
+		action    string
+		want      bool
+		wantRoles []string
+	}{
+		{"owner publishes", Subject{UserId: "alice"}, WorkflowActionPublish, true, []string{WorkflowRoleOwner, WorkflowRoleViewer}},
+		{"editor edits", Subject{UserId: "bob"}, WorkflowActionEdit, true, []string{WorkflowRoleEditor, WorkflowRoleViewer}},
+		{"editor cannot publish", Subject{UserId: "bob"}, WorkflowActionPublish, false, []string{WorkflowRoleEditor, WorkflowRoleViewer}},
+		{"group member runs", Subject{UserId: "carol", Groups: []string{"analysts"}}, WorkflowActionRun, true, []string{WorkflowRoleRunner, WorkflowRoleViewer}},
+		{"everyone views", Subject{}, WorkflowActionView, true, []string{WorkflowRoleViewer}},
+		{"everyone cannot run", Subject{UserId: "dave"}, WorkflowActionRun, false, []string{WorkflowRoleViewer}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := acl.Allows(tt.subject, tt.action); got != tt.want {
+				t.Errorf("Allows() = %v, want %v", got, tt.want)
+			}
+			if got := acl.RolesOf(tt.subject); !reflect.DeepEqual(got, tt.wantRoles) {
+				t.Errorf("RolesOf() = %v, want %v", got, tt.wantRoles)
+			}
+		})
+	}
+}
+
+func TestWorkflowACLValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		acl     WorkflowACL
+		wantErr bool
+	}{
+		{"valid", WorkflowACL{WorkflowId: "wf", Owner: "alice"}, false},
+		{"missing workflow", WorkflowACL{Owner: "alice"}, true},
+		{"missing owner", WorkflowACL{WorkflowId: "wf"}, true},
+		{"unknown role", WorkflowACL{WorkflowId: "wf", Owner: "a", Entries: []WorkflowACLEntry{{Principal: "*", Role: "admin"}}}, true},
+		{"invalid principal", WorkflowACL{WorkflowId: "wf", Owner: "a", Entries: []WorkflowACLEntry{{Principal: "bob", Role: WorkflowRoleViewer}}}, true},
+		{"empty user principal", WorkflowACL{WorkflowId: "wf", Owner: "a", Entries: []WorkflowACLEntry{{Principal: "user:", Role: WorkflowRoleViewer}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.acl.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
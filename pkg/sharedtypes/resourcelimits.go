@@ -0,0 +1,100 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"errors"
+	"time"
+)
+
+// ResourceLimits are the limits a FlowKit server enforces on the execution of an external function.
+// A nil *ResourceLimits means the function is not sandboxed; within the limits, 0 means unlimited.
+type ResourceLimits struct {
+	CpuSeconds     int  `json:"cpu_seconds,omitempty" yaml:"cpu_seconds,omitempty"`         // maximum CPU time in seconds
+	MemoryMb       int  `json:"memory_mb,omitempty" yaml:"memory_mb,omitempty"`             // maximum memory in megabytes
+	TimeoutSeconds int  `json:"timeout_seconds,omitempty" yaml:"timeout_seconds,omitempty"` // maximum wall-clock time in seconds
+	NetworkAllowed bool `json:"network_allowed,omitempty" yaml:"network_allowed,omitempty"` // true if the function may access the network
+}
+
+// Validate checks that no limit is negative
+//
+// Returns:
+//   - error: an error listing the invalid limits; nil if valid
+func (l ResourceLimits) Validate() error {
+	var errs []error
+	if l.CpuSeconds < 0 {
+		errs = append(errs, errors.New("cpu_seconds must not be negative"))
+	}
+	if l.MemoryMb < 0 {
+		errs = append(errs, errors.New("memory_mb must not be negative"))
+	}
+	if l.TimeoutSeconds < 0 {
+		errs = append(errs, errors.New("timeout_seconds must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Timeout returns the wall-clock limit
+//
+// Returns:
+//   - time.Duration: the timeout; 0 if unlimited
+func (l ResourceLimits) Timeout() time.Duration {
+	return time.Duration(l.TimeoutSeconds) * time.Second
+}
+
+// MergeResourceLimits combines two sets of limits into one that is at least as strict as both,
+// e.g. the limits of a function definition and the limits configured centrally by the agent.
+// The smaller of each limit is kept and network access is only allowed if both allow it.
+//
+// Parameters:
+//   - a: the first limits; nil if not sandboxed
+//   - b: the second limits; nil if not sandboxed
+//
+// Returns:
+//   - *ResourceLimits: the combined limits; nil if both are nil
+func MergeResourceLimits(a *ResourceLimits, b *ResourceLimits) *ResourceLimits {
+	switch {
+	case a == nil && b == nil:
+		return nil
+	case a == nil:
+		merged := *b
+		return &merged
+	case b == nil:
+		merged := *a
+		return &merged
+	}
+	return &ResourceLimits{
+		CpuSeconds:     minNonZero(a.CpuSeconds, b.CpuSeconds),
+		MemoryMb:       minNonZero(a.MemoryMb, b.MemoryMb),
+		TimeoutSeconds: minNonZero(a.TimeoutSeconds, b.TimeoutSeconds),
+		NetworkAllowed: a.NetworkAllowed && b.NetworkAllowed,
+	}
+}
+
+// minNonZero returns the smaller of two limits, where 0 means unlimited
+func minNonZero(a int, b int) int {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
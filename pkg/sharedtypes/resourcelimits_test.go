@@ -0,0 +1,95 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResourceLimitsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  ResourceLimits
+		wantErr bool
+	}{
+		{"unlimited", ResourceLimits{}, false},
+		{"limited", ResourceLimits{CpuSeconds: 10, MemoryMb: 512, TimeoutSeconds: 30, NetworkAllowed: true}, false},
+		{"negative cpu", ResourceLimits{CpuSeconds: -1}, true},
+		{"negative memory", ResourceLimits{MemoryMb: -1}, true},
+		{"negative timeout", ResourceLimits{TimeoutSeconds: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.limits.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourceLimitsTimeout(t *testing.T) {
+	if got := (ResourceLimits{TimeoutSeconds: 30}).Timeout(); got != 30*time.Second {
+		t.Errorf("Timeout() = %v, want 30s", got)
+	}
+	if got := (ResourceLimits{}).Timeout(); got != 0 {
+		t.Errorf("Timeout() of unlimited = %v, want 0", got)
+	}
+}
+
+func TestMergeResourceLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *ResourceLimits
+		b    *ResourceLimits
+		want *ResourceLimits
+	}{
+		{"both nil", nil, nil, nil},
+		{"first nil", nil, &ResourceLimits{MemoryMb: 512}, &ResourceLimits{MemoryMb: 512}},
+		{"second nil", &ResourceLimits{CpuSeconds: 10, NetworkAllowed: true}, nil, &ResourceLimits{CpuSeconds: 10, NetworkAllowed: true}},
+		{
+			"smaller limits kept",
+			&ResourceLimits{CpuSeconds: 10, MemoryMb: 1024, TimeoutSeconds: 0, NetworkAllowed: true},
+			&ResourceLimits{CpuSeconds: 20, MemoryMb: 512, TimeoutSeconds: 60, NetworkAllowed: true},
+			&ResourceLimits{CpuSeconds: 10, MemoryMb: 512, TimeoutSeconds: 60, NetworkAllowed: true},
+		},
+		{
+			"network denied by either",
+			&ResourceLimits{NetworkAllowed: true},
+			&ResourceLimits{},
+			&ResourceLimits{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeResourceLimits(tt.a, tt.b)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeResourceLimits() = %+v, want %+v", got, tt.want)
+			}
+			if got != nil && (got == tt.a || got == tt.b) {
+				t.Error("MergeResourceLimits() returned one of its arguments")
+			}
+		})
+	}
+}
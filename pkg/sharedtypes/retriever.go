@@ -0,0 +1,148 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// DefaultRRFConstant is the rank constant k commonly used for reciprocal rank fusion.
+const DefaultRRFConstant = 60
+
+// Retriever is implemented by the retrievers that can be composed by flowkit functions,
+// e.g. dense vector search, sparse keyword search or graph traversal.
+type Retriever interface {
+	// Search returns the k most relevant results for the query, ordered by descending relevance.
+	// The relevance of each result is stored in its Distance field, higher is better.
+	Search(ctx context.Context, query string, filters DbFilters, k int) ([]DbResponse, error)
+}
+
+// NormalizeDbResponseScores scales the scores (Distance) of the results to the range [0, 1] using min-max normalization,
+// so the scores of different retrievers can be compared. If all results have the same score, they get the score 1.
+//
+// Parameters:
+//   - results: the results of a retriever
+//
+// Returns:
+//   - []DbResponse: a copy of the results with normalized scores
+func NormalizeDbResponseScores(results []DbResponse) []DbResponse {
+	normalized := slices.Clone(results)
+	if len(normalized) == 0 {
+		return normalized
+	}
+	minScore, maxScore := normalized[0].Distance, normalized[0].Distance
+	for _, result := range normalized {
+		minScore = min(minScore, result.Distance)
+		maxScore = max(maxScore, result.Distance)
+	}
+	for i := range normalized {
+		if maxScore == minScore {
+			normalized[i].Distance = 1
+		} else {
+			normalized[i].Distance = (normalized[i].Distance - minScore) / (maxScore - minScore)
+		}
+	}
+	return normalized
+}
+
+// ReciprocalRankFusion merges the ranked results of several retrievers.
+// Each result gets the score sum(1 / (k + rank)) over the lists it appears in, with rank starting at 1;
+// results are identified by their guid, or by document id and text if they have no guid.
+//
+// Parameters:
+//   - resultLists: the results of each retriever, ordered by descending relevance
+//   - k: the rank constant, DefaultRRFConstant if 0
+//
+// Returns:
+//   - []DbResponse: the merged results ordered by descending fused score, stored in Distance
+func ReciprocalRankFusion(resultLists [][]DbResponse, k int) []DbResponse {
+	if k <= 0 {
+		k = DefaultRRFConstant
+	}
+	return fuseDbResponses(resultLists, func(_ int, rank int, _ DbResponse) float64 {
+		return 1 / float64(k+rank+1)
+	})
+}
+
+// WeightedScoreFusion merges the results of several retrievers by the weighted sum of their normalized scores,
+// see NormalizeDbResponseScores. Results are identified like in ReciprocalRankFusion.
+//
+// Parameters:
+//   - resultLists: the results of each retriever
+//   - weights: the weight of each retriever
+//
+// Returns:
+//   - []DbResponse: the merged results ordered by descending fused score, stored in Distance
+//   - error: an error if the number of weights does not match the number of result lists
+func WeightedScoreFusion(resultLists [][]DbResponse, weights []float64) ([]DbResponse, error) {
+	if len(weights) != len(resultLists) {
+		return nil, fmt.Errorf("got %d weights for %d result lists", len(weights), len(resultLists))
+	}
+	normalizedLists := make([][]DbResponse, len(resultLists))
+	for i, results := range resultLists {
+		normalizedLists[i] = NormalizeDbResponseScores(results)
+	}
+	return fuseDbResponses(normalizedLists, func(list int, _ int, result DbResponse) float64 {
+		return weights[list] * result.Distance
+	}), nil
+}
+
+// fuseDbResponses deduplicates the results of several lists and sums the scores computed for each occurrence.
+// The first occurrence of a result is kept; ties are ordered by first occurrence.
+func fuseDbResponses(resultLists [][]DbResponse, score func(list int, rank int, result DbResponse) float64) []DbResponse {
+	fused := []DbResponse{}
+	indices := map[string]int{}
+	for list, results := range resultLists {
+		for rank, result := range results {
+			key := dbResponseKey(result)
+			index, ok := indices[key]
+			if !ok {
+				index = len(fused)
+				indices[key] = index
+				fused = append(fused, result)
+				fused[index].Distance = 0
+			}
+			fused[index].Distance += score(list, rank, result)
+		}
+	}
+	slices.SortStableFunc(fused, func(a, b DbResponse) int {
+		switch {
+		case a.Distance > b.Distance:
+			return -1
+		case a.Distance < b.Distance:
+			return 1
+		}
+		return 0
+	})
+	return fused
+}
+
+// dbResponseKey identifies a result across retrievers.
+func dbResponseKey(result DbResponse) string {
+	if normalizeUuid(&result.Guid) != nil {
+		return result.Guid.String()
+	}
+	return result.DocumentId + "\x00" + result.Text
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNormalizeDbResponseScores(t *testing.T) {
+	results := []DbResponse{{Distance: 12}, {Distance: 7}, {Distance: 2}}
+
+	normalized := NormalizeDbResponseScores(results)
+
+	expected := []float64{1, 0.5, 0}
+	for i, score := range expected {
+		if normalized[i].Distance != score {
+			t.Errorf("normalized[%d].Distance = %v, expected %v", i, normalized[i].Distance, score)
+		}
+	}
+	if results[0].Distance != 12 {
+		t.Error("NormalizeDbResponseScores() should not modify its input")
+	}
+	if equal := NormalizeDbResponseScores([]DbResponse{{Distance: 3}, {Distance: 3}}); equal[0].Distance != 1 || equal[1].Distance != 1 {
+		t.Errorf("equal scores should normalize to 1, got %v", equal)
+	}
+}
+
+func TestReciprocalRankFusion(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	dense := []DbResponse{{Guid: a, Distance: 0.9}, {Guid: b, Distance: 0.8}}
+	sparse := []DbResponse{{Guid: b, Distance: 14}, {Guid: c, Distance: 9}, {DocumentId: "doc", Text: "no guid"}}
+
+	fused := ReciprocalRankFusion([][]DbResponse{dense, sparse}, 0)
+
+	if len(fused) != 4 {
+		t.Fatalf("len(fused) = %d, expected 4", len(fused))
+	}
+	if fused[0].Guid != b {
+		t.Errorf("fused[0] = %v, expected %v which is ranked by both retrievers", fused[0].Guid, b)
+	}
+	if expected := 1.0/61 + 1.0/62; math.Abs(fused[0].Distance-expected) > 1e-12 {
+		t.Errorf("fused[0].Distance = %v, expected %v", fused[0].Distance, expected)
+	}
+	if fused[1].Guid != a || fused[2].Guid != c || fused[3].DocumentId != "doc" {
+		t.Errorf("unexpected order: %v, %v, %v", fused[1].Guid, fused[2].Guid, fused[3].DocumentId)
+	}
+}
+
+func TestWeightedScoreFusion(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	dense := []DbResponse{{Guid: a, Distance: 0.9}, {Guid: b, Distance: 0.5}}
+	sparse := []DbResponse{{Guid: b, Distance: 20}, {Guid: a, Distance: 10}}
+
+	fused, err := WeightedScoreFusion([][]DbResponse{dense, sparse}, []float64{0.3, 0.7})
+	if err != nil {
+		t.Fatalf("WeightedScoreFusion() error = %v", err)
+	}
+	if fused[0].Guid != b || fused[0].Distance != 0.7 || fused[1].Distance != 0.3 {
+		t.Errorf("unexpected fusion result: %+v", fused)
+	}
+
+	if _, err := WeightedScoreFusion([][]DbResponse{dense}, []float64{0.5, 0.5}); err == nil {
+		t.Error("WeightedScoreFusion() expected an error for mismatching weights")
+	}
+}
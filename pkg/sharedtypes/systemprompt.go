@@ -0,0 +1,217 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Roles of the segments of a SystemPrompt.
+const (
+	PromptSegmentPersona      = "persona"      // who the assistant is
+	PromptSegmentInstructions = "instructions" // what the assistant should do
+	PromptSegmentSafety       = "safety"       // what the assistant must not do
+	PromptSegmentContext      = "context"      // background information for the conversation
+)
+
+// PromptSegmentRoles lists the supported segment roles; segments of plain string prompts have no role.
+var PromptSegmentRoles = []string{PromptSegmentPersona, PromptSegmentInstructions, PromptSegmentSafety, PromptSegmentContext}
+
+// PromptSegment is one part of a SystemPrompt.
+type PromptSegment struct {
+	Role    string `json:"role,omitempty"` // "persona", "instructions", "safety", "context"; empty for plain string prompts
+	Content string `json:"content"`        // text of the segment; may contain ${VAR} and {{ .field }} references
+}
+
+// SystemPrompt is the system prompt of a chat request, either a plain string or a list of segments.
+// Segments are rendered in their order, separated by a blank line, so equal prompts always render to the same text.
+//
+// In JSON, a plain string prompt is encoded as string, so it stays compatible with HandlerRequest.SystemPrompt
+// consumers expecting a string. Segmented prompts are encoded as {"segments": [...], "variables": {...}}.
+type SystemPrompt struct {
+	Segments  []PromptSegment        `json:"segments"`
+	Variables map[string]interface{} `json:"variables,omitempty"` // data for the {{ .field }} references of the segments
+}
+
+// PromptExpander expands the references in the content of a segment, e.g. with a templating engine.
+type PromptExpander func(content string, data map[string]interface{}) (string, error)
+
+// NewSystemPrompt creates a plain string system prompt.
+//
+// Parameters:
+//   - prompt: the text of the prompt
+//
+// Returns:
+//   - SystemPrompt: the system prompt with a single segment without role
+func NewSystemPrompt(prompt string) SystemPrompt {
+	return SystemPrompt{Segments: []PromptSegment{{Content: prompt}}}
+}
+
+// IsPlain returns true if the prompt is a single segment without role and variables.
+func (sp SystemPrompt) IsPlain() bool {
+	return len(sp.Segments) == 1 && sp.Segments[0].Role == "" && len(sp.Variables) == 0
+}
+
+// Validate checks the roles of the segments.
+//
+// Returns:
+//   - error: an error if a segment has an unknown role, or a prompt with several segments has a segment without role
+func (sp SystemPrompt) Validate() error {
+	for i, segment := range sp.Segments {
+		switch {
+		case segment.Role == "" && len(sp.Segments) > 1:
+			return fmt.Errorf("segment %d of the system prompt has no role", i)
+		case segment.Role != "" && !slices.Contains(PromptSegmentRoles, segment.Role):
+			return fmt.Errorf("segment %d of the system prompt has role %q, expected one of %v", i, segment.Role, PromptSegmentRoles)
+		}
+	}
+	return nil
+}
+
+// Render renders the prompt to the text sent to the model.
+//
+// Parameters:
+//   - expand: expands the references in the segments with the prompt variables as data; if nil, the segments are used as they are
+//
+// Returns:
+//   - string: the segments joined by blank lines; segments that are empty after expansion are left out
+//   - error: an error if the prompt is invalid or a segment cannot be expanded
+func (sp SystemPrompt) Render(expand PromptExpander) (string, error) {
+	if err := sp.Validate(); err != nil {
+		return "", err
+	}
+
+	parts := make([]string, 0, len(sp.Segments))
+	for i, segment := range sp.Segments {
+		content := segment.Content
+		if expand != nil {
+			expanded, err := expand(content, sp.Variables)
+			if err != nil {
+				return "", fmt.Errorf("error expanding segment %d of the system prompt: %w", i, err)
+			}
+			content = expanded
+		}
+		if content = strings.TrimSpace(content); content != "" {
+			parts = append(parts, content)
+		}
+	}
+	return strings.Join(parts, "\n\n"), nil
+}
+
+// SystemPromptHash returns a hash of a rendered system prompt, so prompts can be identified in logs without logging them.
+//
+// Parameters:
+//   - rendered: the rendered prompt
+//
+// Returns:
+//   - string: the first 16 hex characters of the SHA-256 hash of the prompt
+func SystemPromptHash(rendered string) string {
+	hash := sha256.Sum256([]byte(rendered))
+	return hex.EncodeToString(hash[:])[:16]
+}
+
+// systemPromptAlias prevents recursion in the SystemPrompt JSON methods.
+type systemPromptAlias SystemPrompt
+
+// MarshalJSON encodes plain string prompts as string and segmented prompts as object.
+func (sp SystemPrompt) MarshalJSON() ([]byte, error) {
+	if sp.IsPlain() {
+		return json.Marshal(sp.Segments[0].Content)
+	}
+	return json.Marshal(systemPromptAlias(sp))
+}
+
+// UnmarshalJSON accepts a string, a list of segments or an object with segments and variables.
+func (sp *SystemPrompt) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")):
+		*sp = SystemPrompt{}
+	case trimmed[0] == '"':
+		var prompt string
+		if err := json.Unmarshal(trimmed, &prompt); err != nil {
+			return err
+		}
+		*sp = NewSystemPrompt(prompt)
+	case trimmed[0] == '[':
+		var segments []PromptSegment
+		if err := json.Unmarshal(trimmed, &segments); err != nil {
+			return fmt.Errorf("error decoding system prompt segments: %w", err)
+		}
+		*sp = SystemPrompt{Segments: segments}
+	default:
+		var alias systemPromptAlias
+		if err := json.Unmarshal(trimmed, &alias); err != nil {
+			return fmt.Errorf("error decoding system prompt: %w", err)
+		}
+		*sp = SystemPrompt(alias)
+	}
+	return nil
+}
+
+// GetSystemPrompt returns the system prompt of the request as SystemPrompt.
+//
+// Returns:
+//   - SystemPrompt: the system prompt; without segments if no system prompt is set
+//   - error: an error if SystemPrompt is neither a string, a SystemPrompt nor its decoded JSON
+func (r *HandlerRequest) GetSystemPrompt() (SystemPrompt, error) {
+	switch prompt := r.SystemPrompt.(type) {
+	case nil:
+		return SystemPrompt{}, nil
+	case string:
+		return NewSystemPrompt(prompt), nil
+	case SystemPrompt:
+		return prompt, nil
+	case *SystemPrompt:
+		if prompt == nil {
+			return SystemPrompt{}, nil
+		}
+		return *prompt, nil
+	case map[string]interface{}, []interface{}:
+		// decoded JSON; objects other than SystemPrompt are rejected instead of being read as empty prompts
+		if object, ok := prompt.(map[string]interface{}); ok {
+			for key := range object {
+				if key != "segments" && key != "variables" {
+					return SystemPrompt{}, fmt.Errorf("system prompt has unknown field %q", key)
+				}
+			}
+		}
+		data, err := json.Marshal(prompt)
+		if err != nil {
+			return SystemPrompt{}, err
+		}
+		var systemPrompt SystemPrompt
+		if err := json.Unmarshal(data, &systemPrompt); err != nil {
+			return SystemPrompt{}, err
+		}
+		return systemPrompt, nil
+	default:
+		return SystemPrompt{}, fmt.Errorf("system prompt is of type %T, expected a string or SystemPrompt", r.SystemPrompt)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSystemPromptJSON(t *testing.T) {
+	segmented := SystemPrompt{
+		Segments:  []PromptSegment{{Role: PromptSegmentPersona, Content: "You are helpful."}, {Role: PromptSegmentSafety, Content: "Never share secrets."}},
+		Variables: map[string]interface{}{"product": "Fluent"},
+	}
+	tests := []struct {
+		name     string
+		json     string
+		expected SystemPrompt
+	}{
+		{"plain string", `"Be brief."`, NewSystemPrompt("Be brief.")},
+		{"segments", `[{"role":"persona","content":"You are helpful."},{"role":"safety","content":"Never share secrets."}]`, SystemPrompt{Segments: segmented.Segments}},
+		{"object", `{"segments":[{"role":"persona","content":"You are helpful."},{"role":"safety","content":"Never share secrets."}],"variables":{"product":"Fluent"}}`, segmented},
+		{"null", `null`, SystemPrompt{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var prompt SystemPrompt
+			if err := json.Unmarshal([]byte(tt.json), &prompt); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+			if !reflect.DeepEqual(prompt, tt.expected) {
+				t.Errorf("decoded %+v, want %+v", prompt, tt.expected)
+			}
+		})
+	}
+
+	// plain prompts stay strings on the wire
+	data, err := json.Marshal(HandlerRequest{SystemPrompt: NewSystemPrompt("Be brief.")})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"systemPrompt":"Be brief."`) {
+		t.Errorf("marshaled request %s, want string system prompt", data)
+	}
+
+	// segmented prompts survive the round trip through HandlerRequest
+	data, err = json.Marshal(HandlerRequest{SystemPrompt: segmented})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	var request HandlerRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	prompt, err := request.GetSystemPrompt()
+	if err != nil || !reflect.DeepEqual(prompt, segmented) {
+		t.Errorf("GetSystemPrompt() = %+v, %v, want %+v", prompt, err, segmented)
+	}
+}
+
+func TestGetSystemPrompt(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		expected  SystemPrompt
+		expectErr bool
+	}{
+		{name: "unset", value: nil, expected: SystemPrompt{}},
+		{name: "string", value: "Be brief.", expected: NewSystemPrompt("Be brief.")},
+		{name: "typed", value: NewSystemPrompt("a"), expected: NewSystemPrompt("a")},
+		{name: "pointer", value: &SystemPrompt{Segments: []PromptSegment{{Content: "a"}}}, expected: NewSystemPrompt("a")},
+		{name: "decoded segments", value: []interface{}{map[string]interface{}{"role": "context", "content": "c"}}, expected: SystemPrompt{Segments: []PromptSegment{{Role: "context", Content: "c"}}}},
+		{name: "unknown object", value: map[string]interface{}{"text": "a"}, expectErr: true},
+		{name: "number", value: 42, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := HandlerRequest{SystemPrompt: tt.value}
+			prompt, err := request.GetSystemPrompt()
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got %+v", prompt)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(prompt, tt.expected) {
+				t.Errorf("GetSystemPrompt() = %+v, %v, want %+v", prompt, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSystemPromptRender(t *testing.T) {
+	prompt := SystemPrompt{
+		Segments: []PromptSegment{
+			{Role: PromptSegmentPersona, Content: "  You are {{name}}. "},
+			{Role: PromptSegmentContext, Content: ""},
+			{Role: PromptSegmentSafety, Content: "Stay on topic."},
+		},
+		Variables: map[string]interface{}{"name": "AALI"},
+	}
+
+	rendered, err := prompt.Render(nil)
+	if err != nil || rendered != "You are {{name}}.\n\nStay on topic." {
+		t.Errorf("Render(nil) = %q, %v", rendered, err)
+	}
+
+	expand := func(content string, data map[string]interface{}) (string, error) {
+		return strings.ReplaceAll(content, "{{name}}", data["name"].(string)), nil
+	}
+	rendered, err = prompt.Render(expand)
+	if err != nil || rendered != "You are AALI.\n\nStay on topic." {
+		t.Errorf("Render() = %q, %v", rendered, err)
+	}
+	again, _ := prompt.Render(expand)
+	if SystemPromptHash(rendered) != SystemPromptHash(again) || len(SystemPromptHash(rendered)) != 16 {
+		t.Errorf("SystemPromptHash() not deterministic: %q, %q", SystemPromptHash(rendered), SystemPromptHash(again))
+	}
+	if SystemPromptHash(rendered) == SystemPromptHash("other") {
+		t.Error("SystemPromptHash() equal for different prompts")
+	}
+
+	failing := func(string, map[string]interface{}) (string, error) { return "", errors.New("undefined") }
+	if _, err := prompt.Render(failing); err == nil || !strings.Contains(err.Error(), "segment 0") {
+		t.Errorf("Render() with failing expander error = %v", err)
+	}
+}
+
+func TestSystemPromptValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		prompt    SystemPrompt
+		expectErr bool
+	}{
+		{name: "empty", prompt: SystemPrompt{}},
+		{name: "plain", prompt: NewSystemPrompt("a")},
+		{name: "segments", prompt: SystemPrompt{Segments: []PromptSegment{{Role: PromptSegmentPersona}, {Role: PromptSegmentInstructions}}}},
+		{name: "unknown role", prompt: SystemPrompt{Segments: []PromptSegment{{Role: "tone"}}}, expectErr: true},
+		{name: "missing role", prompt: SystemPrompt{Segments: []PromptSegment{{Role: PromptSegmentPersona}, {Content: "a"}}}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.prompt.Validate()
+			if tt.expectErr != (err != nil) {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ToolSideEffect classifies what a tool changes when it is called.
+type ToolSideEffect string
+
+// Side-effect levels of tools, from least to most severe.
+const (
+	ToolSideEffectReadOnly      ToolSideEffect = "read-only"      // reads data without changing anything
+	ToolSideEffectWritesModel   ToolSideEffect = "writes-model"   // changes the model or the data of the user, e.g. a simulation setup
+	ToolSideEffectExternalCalls ToolSideEffect = "external-calls" // calls external systems, e.g. sends mails or starts jobs
+)
+
+// ToolSideEffects lists all side-effect levels from least to most severe.
+var ToolSideEffects = []ToolSideEffect{ToolSideEffectReadOnly, ToolSideEffectWritesModel, ToolSideEffectExternalCalls}
+
+// Validate checks that the side-effect level is supported.
+//
+// Returns:
+//   - error: an error if the side-effect level is unknown
+func (s ToolSideEffect) Validate() error {
+	if !slices.Contains(ToolSideEffects, s) {
+		return fmt.Errorf("sideEffect must be one of %v, got %q", ToolSideEffects, string(s))
+	}
+	return nil
+}
+
+// Exceeds reports whether the side-effect level is more severe than another one.
+// Unknown levels are more severe than all known levels, so unclassified tools are never auto-approved.
+//
+// Parameters:
+//   - other: the level to compare with
+//
+// Returns:
+//   - bool: true if s is more severe than other
+func (s ToolSideEffect) Exceeds(other ToolSideEffect) bool {
+	severity := func(level ToolSideEffect) int {
+		if i := slices.Index(ToolSideEffects, level); i >= 0 {
+			return i
+		}
+		return len(ToolSideEffects)
+	}
+	return severity(s) > severity(other)
+}
+
+// UnmarshalJSON reads the side-effect level case-insensitively, so "Read-Only" is read as ToolSideEffectReadOnly.
+// Unknown levels are kept as they are and rejected by Validate.
+func (s *ToolSideEffect) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, ToolSideEffects)
+	if err != nil {
+		return fmt.Errorf("error decoding sideEffect: %w", err)
+	}
+	*s = value
+	return nil
+}
+
+// ToolApproval is the decision of a ToolExecutionPolicy for a tool call.
+type ToolApproval string
+
+// Decisions of a ToolExecutionPolicy.
+const (
+	ToolApprovalAuto    ToolApproval = "auto"    // the tool is called without asking the user
+	ToolApprovalConfirm ToolApproval = "confirm" // the user must confirm the call
+	ToolApprovalDeny    ToolApproval = "deny"    // the tool must not be called
+)
+
+// ToolExecutionPolicy decides which tools an agent may call in a workflow and which calls the user must confirm.
+// Without configuration, read-only tools are called automatically and all other tools require confirmation.
+type ToolExecutionPolicy struct {
+	Name        string   `json:"name,omitempty" yaml:"NAME,omitempty"`                // name of the policy, reported in decisions for auditing
+	WorkflowIds []string `json:"workflowIds,omitempty" yaml:"WORKFLOW_IDS,omitempty"` // workflows the policy applies to; empty for the default policy
+
+	// AllowedTools restricts the tools that may be called: nil allows all tools, an empty list allows none
+	AllowedTools []string `json:"allowedTools" yaml:"ALLOWED_TOOLS"`
+	DeniedTools  []string `json:"deniedTools,omitempty" yaml:"DENIED_TOOLS,omitempty"` // tools that must never be called, even if allowed
+
+	// AutoApproveUpTo is the most severe side-effect level called without confirmation; "read-only" if empty
+	AutoApproveUpTo ToolSideEffect `json:"autoApproveUpTo,omitempty" yaml:"AUTO_APPROVE_UP_TO,omitempty"`
+	// AutoApproveTools are called without confirmation regardless of their side effects
+	AutoApproveTools []string `json:"autoApproveTools,omitempty" yaml:"AUTO_APPROVE_TOOLS,omitempty"`
+	// ConfirmTools always require confirmation regardless of their side effects
+	ConfirmTools []string `json:"confirmTools,omitempty" yaml:"CONFIRM_TOOLS,omitempty"`
+
+	// SideEffects overrides the side-effect levels declared by the tools, keyed by tool name
+	SideEffects map[string]ToolSideEffect `json:"sideEffects,omitempty" yaml:"SIDE_EFFECTS,omitempty"`
+}
+
+// ToolDecision is the outcome of evaluating a ToolExecutionPolicy for a tool.
+type ToolDecision struct {
+	Tool       string         `json:"tool"`
+	PolicyName string         `json:"policyName,omitempty"`
+	Approval   ToolApproval   `json:"approval"`
+	SideEffect ToolSideEffect `json:"sideEffect"` // the side-effect level the decision is based on
+	Reason     string         `json:"reason"`     // why the policy decided so, e.g. for the confirmation prompt or audit logs
+}
+
+// Validate checks the policy for values that cannot be enforced.
+//
+// Returns:
+//   - error: an error describing the first invalid value, nil if the policy is valid
+func (p *ToolExecutionPolicy) Validate() error {
+	lists := []struct {
+		name   string
+		values []string
+	}{
+		{"workflowIds", p.WorkflowIds},
+		{"allowedTools", p.AllowedTools},
+		{"deniedTools", p.DeniedTools},
+		{"autoApproveTools", p.AutoApproveTools},
+		{"confirmTools", p.ConfirmTools},
+	}
+	for _, list := range lists {
+		for i, value := range list.values {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Errorf("%s[%d] must not be empty", list.name, i)
+			}
+		}
+	}
+	if p.AutoApproveUpTo != "" {
+		if err := p.AutoApproveUpTo.Validate(); err != nil {
+			return fmt.Errorf("invalid autoApproveUpTo: %w", err)
+		}
+	}
+	for tool, sideEffect := range p.SideEffects {
+		if err := sideEffect.Validate(); err != nil {
+			return fmt.Errorf("invalid side effect of tool %q: %w", tool, err)
+		}
+	}
+	for _, tool := range p.AutoApproveTools {
+		if slices.Contains(p.ConfirmTools, tool) {
+			return fmt.Errorf("tool %q is both in autoApproveTools and confirmTools", tool)
+		}
+	}
+	return nil
+}
+
+// SideEffectOf returns the side-effect level of a tool.
+// The level configured in the policy takes precedence over the level declared by the tool;
+// tools without a level are treated as calling external systems.
+//
+// Parameters:
+//   - tool: the tool
+//
+// Returns:
+//   - ToolSideEffect: the side-effect level
+func (p *ToolExecutionPolicy) SideEffectOf(tool MCPTool) ToolSideEffect {
+	if sideEffect, ok := p.SideEffects[tool.Name]; ok {
+		return sideEffect
+	}
+	if tool.SideEffect != "" {
+		return tool.SideEffect
+	}
+	return ToolSideEffectExternalCalls
+}
+
+// Evaluate decides whether a tool may be called and whether the user must confirm the call.
+// Denied and not allowed tools are denied; tools of ConfirmTools and AutoApproveTools are confirmed or
+// approved; all other tools are approved if their side effects do not exceed AutoApproveUpTo.
+//
+// Parameters:
+//   - tool: the tool the model wants to call
+//
+// Returns:
+//   - ToolDecision: the decision
+func (p *ToolExecutionPolicy) Evaluate(tool MCPTool) ToolDecision {
+	decision := ToolDecision{Tool: tool.Name, PolicyName: p.Name, SideEffect: p.SideEffectOf(tool)}
+	autoApproveUpTo := p.AutoApproveUpTo
+	if autoApproveUpTo == "" {
+		autoApproveUpTo = ToolSideEffectReadOnly
+	}
+
+	switch {
+	case slices.Contains(p.DeniedTools, tool.Name):
+		decision.Approval, decision.Reason = ToolApprovalDeny, "tool is denied by the policy"
+	case p.AllowedTools != nil && !slices.Contains(p.AllowedTools, tool.Name):
+		decision.Approval, decision.Reason = ToolApprovalDeny, "tool is not allowed by the policy"
+	case slices.Contains(p.ConfirmTools, tool.Name):
+		decision.Approval, decision.Reason = ToolApprovalConfirm, "tool always requires confirmation"
+	case slices.Contains(p.AutoApproveTools, tool.Name):
+		decision.Approval, decision.Reason = ToolApprovalAuto, "tool is always approved"
+	case decision.SideEffect.Exceeds(autoApproveUpTo):
+		decision.Approval = ToolApprovalConfirm
+		decision.Reason = fmt.Sprintf("side effect %q exceeds %q", decision.SideEffect, autoApproveUpTo)
+	default:
+		decision.Approval = ToolApprovalAuto
+		decision.Reason = fmt.Sprintf("side effect %q does not exceed %q", decision.SideEffect, autoApproveUpTo)
+	}
+	return decision
+}
+
+// AppliesTo reports whether the policy applies to a workflow.
+//
+// Parameters:
+//   - workflowId: the ID of the workflow
+//
+// Returns:
+//   - bool: true if the policy lists the workflow or is the default policy
+func (p *ToolExecutionPolicy) AppliesTo(workflowId string) bool {
+	return len(p.WorkflowIds) == 0 || slices.Contains(p.WorkflowIds, workflowId)
+}
+
+// SelectToolExecutionPolicy returns the policy of a workflow from the centrally configured policies.
+// A policy listing the workflow takes precedence over the default policy without workflows.
+//
+// Parameters:
+//   - policies: the configured policies
+//   - workflowId: the ID of the workflow
+//
+// Returns:
+//   - ToolExecutionPolicy: the policy of the workflow, or an empty policy applying the defaults if none applies
+func SelectToolExecutionPolicy(policies []ToolExecutionPolicy, workflowId string) ToolExecutionPolicy {
+	var fallback *ToolExecutionPolicy
+	for i := range policies {
+		if slices.Contains(policies[i].WorkflowIds, workflowId) {
+			return policies[i]
+		}
+		if fallback == nil && len(policies[i].WorkflowIds) == 0 {
+			fallback = &policies[i]
+		}
+	}
+	if fallback != nil {
+		return *fallback
+	}
+	return ToolExecutionPolicy{}
+}
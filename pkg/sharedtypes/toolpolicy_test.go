@@ -0,0 +1,131 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToolSideEffectExceeds(t *testing.T) {
+	tests := []struct {
+		level ToolSideEffect
+		other ToolSideEffect
+		want  bool
+	}{
+		{ToolSideEffectReadOnly, ToolSideEffectReadOnly, false},
+		{ToolSideEffectWritesModel, ToolSideEffectReadOnly, true},
+		{ToolSideEffectReadOnly, ToolSideEffectExternalCalls, false},
+		{"unknown", ToolSideEffectExternalCalls, true},
+	}
+	for _, tt := range tests {
+		if got := tt.level.Exceeds(tt.other); got != tt.want {
+			t.Errorf("%q.Exceeds(%q) = %v, want %v", tt.level, tt.other, got, tt.want)
+		}
+	}
+}
+
+func TestToolSideEffectJSON(t *testing.T) {
+	var tool MCPTool
+	if err := json.Unmarshal([]byte(`{"name":"mesh","sideEffect":" Writes-Model "}`), &tool); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if tool.SideEffect != ToolSideEffectWritesModel {
+		t.Errorf("SideEffect = %q, want %q", tool.SideEffect, ToolSideEffectWritesModel)
+	}
+}
+
+func TestToolExecutionPolicyEvaluate(t *testing.T) {
+	readTool := MCPTool{Name: "read_results", SideEffect: ToolSideEffectReadOnly}
+	meshTool := MCPTool{Name: "mesh", SideEffect: ToolSideEffectWritesModel}
+	mailTool := MCPTool{Name: "send_mail", SideEffect: ToolSideEffectExternalCalls}
+	unclassified := MCPTool{Name: "unknown_tool"}
+
+	tests := []struct {
+		name   string
+		policy ToolExecutionPolicy
+		tool   MCPTool
+		want   ToolApproval
+	}{
+		{"default read-only", ToolExecutionPolicy{}, readTool, ToolApprovalAuto},
+		{"default writes-model", ToolExecutionPolicy{}, meshTool, ToolApprovalConfirm},
+		{"default unclassified", ToolExecutionPolicy{}, unclassified, ToolApprovalConfirm},
+		{"auto approve up to writes-model", ToolExecutionPolicy{AutoApproveUpTo: ToolSideEffectWritesModel}, meshTool, ToolApprovalAuto},
+		{"auto approve up to writes-model, external", ToolExecutionPolicy{AutoApproveUpTo: ToolSideEffectWritesModel}, mailTool, ToolApprovalConfirm},
+		{"not allowed", ToolExecutionPolicy{AllowedTools: []string{"mesh"}}, readTool, ToolApprovalDeny},
+		{"none allowed", ToolExecutionPolicy{AllowedTools: []string{}}, readTool, ToolApprovalDeny},
+		{"denied", ToolExecutionPolicy{DeniedTools: []string{"read_results"}}, readTool, ToolApprovalDeny},
+		{"always confirm", ToolExecutionPolicy{ConfirmTools: []string{"read_results"}}, readTool, ToolApprovalConfirm},
+		{"always approve", ToolExecutionPolicy{AutoApproveTools: []string{"send_mail"}}, mailTool, ToolApprovalAuto},
+		{"side effect override", ToolExecutionPolicy{SideEffects: map[string]ToolSideEffect{"unknown_tool": ToolSideEffectReadOnly}}, unclassified, ToolApprovalAuto},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := tt.policy.Evaluate(tt.tool)
+			if decision.Approval != tt.want {
+				t.Errorf("Approval = %q (%s), want %q", decision.Approval, decision.Reason, tt.want)
+			}
+			if decision.Tool != tt.tool.Name || decision.Reason == "" {
+				t.Errorf("decision = %+v, want tool %q with reason", decision, tt.tool.Name)
+			}
+		})
+	}
+}
+
+func TestToolExecutionPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    ToolExecutionPolicy
+		expectErr bool
+	}{
+		{"empty", ToolExecutionPolicy{}, false},
+		{"valid", ToolExecutionPolicy{WorkflowIds: []string{"wf"}, AutoApproveUpTo: ToolSideEffectWritesModel, SideEffects: map[string]ToolSideEffect{"a": ToolSideEffectReadOnly}}, false},
+		{"empty tool", ToolExecutionPolicy{DeniedTools: []string{" "}}, true},
+		{"unknown auto approve level", ToolExecutionPolicy{AutoApproveUpTo: "everything"}, true},
+		{"unknown side effect", ToolExecutionPolicy{SideEffects: map[string]ToolSideEffect{"a": "dangerous"}}, true},
+		{"approve and confirm", ToolExecutionPolicy{AutoApproveTools: []string{"a"}, ConfirmTools: []string{"a"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestSelectToolExecutionPolicy(t *testing.T) {
+	policies := []ToolExecutionPolicy{
+		{Name: "default"},
+		{Name: "meshing", WorkflowIds: []string{"mesh-wf"}},
+	}
+	if got := SelectToolExecutionPolicy(policies, "mesh-wf").Name; got != "meshing" {
+		t.Errorf("policy of mesh-wf = %q, want meshing", got)
+	}
+	if got := SelectToolExecutionPolicy(policies, "other-wf").Name; got != "default" {
+		t.Errorf("policy of other-wf = %q, want default", got)
+	}
+	if got := SelectToolExecutionPolicy(policies[1:], "other-wf"); got.Name != "" || !got.AppliesTo("other-wf") {
+		t.Errorf("policy without default = %+v, want empty policy", got)
+	}
+}
@@ -0,0 +1,428 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ProtocolVersion is the semantic version of the gRPC protocols defined in this module,
+// reported by services in the protocol_version field of their HealthCheck and GetVersion responses.
+// The minor version is increased for backward compatible additions, the major version for breaking changes.
+const ProtocolVersion = "1.0.0"
+
+// SemanticVersion is a version following semantic versioning, e.g. "1.4.2-rc.1+build.5"
+type SemanticVersion struct {
+	Major      int    `json:"major"`
+	Minor      int    `json:"minor"`
+	Patch      int    `json:"patch"`
+	PreRelease string `json:"preRelease,omitempty"` // e.g. "rc.1"; versions with a pre-release precede the release
+	Build      string `json:"build,omitempty"`      // build metadata, ignored for precedence
+}
+
+// ParseSemanticVersion parses a semantic version
+// A leading "v" is accepted, and missing minor and patch numbers default to 0, so "v1.2" is 1.2.0.
+//
+// Parameters:
+//   - value: the version to parse
+//
+// Returns:
+//   - SemanticVersion: the parsed version
+//   - error: an error if the value is not a semantic version
+func ParseSemanticVersion(value string) (SemanticVersion, error) {
+	version, _, err := parseVersionParts(value)
+	return version, err
+}
+
+// parseVersionParts parses a semantic version and returns how many of the major, minor and patch numbers were given
+func parseVersionParts(value string) (version SemanticVersion, parts int, err error) {
+	core := strings.TrimPrefix(strings.TrimSpace(value), "v")
+	if i := strings.IndexByte(core, '+'); i >= 0 {
+		core, version.Build = core[:i], core[i+1:]
+		if version.Build == "" {
+			return SemanticVersion{}, 0, fmt.Errorf("invalid version %q: empty build metadata", value)
+		}
+	}
+	if i := strings.IndexByte(core, '-'); i >= 0 {
+		core, version.PreRelease = core[:i], core[i+1:]
+		if version.PreRelease == "" {
+			return SemanticVersion{}, 0, fmt.Errorf("invalid version %q: empty pre-release", value)
+		}
+	}
+
+	numbers := strings.Split(core, ".")
+	if len(numbers) > 3 {
+		return SemanticVersion{}, 0, fmt.Errorf("invalid version %q: more than 3 numbers", value)
+	}
+	fields := []*int{&version.Major, &version.Minor, &version.Patch}
+	for i, number := range numbers {
+		n, err := strconv.Atoi(number)
+		if err != nil || n < 0 || number == "" || number[0] == '+' {
+			return SemanticVersion{}, 0, fmt.Errorf("invalid version %q: %q is not a number", value, number)
+		}
+		*fields[i] = n
+	}
+	return version, len(numbers), nil
+}
+
+// String returns the version in semantic versioning format
+//
+// Returns:
+//   - string: the version, e.g. "1.4.2-rc.1+build.5"
+func (v SemanticVersion) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease != "" {
+		s += "-" + v.PreRelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare compares the precedence of two versions; build metadata is ignored
+//
+// Parameters:
+//   - other: the version to compare with
+//
+// Returns:
+//   - int: -1 if v precedes other, 1 if v follows other, 0 if both have the same precedence
+func (v SemanticVersion) Compare(other SemanticVersion) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff != 0 {
+			return sign(diff)
+		}
+	}
+
+	// a release follows its pre-releases
+	switch {
+	case v.PreRelease == other.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case other.PreRelease == "":
+		return -1
+	}
+
+	a, b := strings.Split(v.PreRelease, "."), strings.Split(other.PreRelease, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := comparePreReleaseIdentifier(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return sign(len(a) - len(b))
+}
+
+// comparePreReleaseIdentifier compares two pre-release identifiers;
+// numeric identifiers are compared numerically and precede alphanumeric ones
+func comparePreReleaseIdentifier(a string, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return sign(na - nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// sign returns -1, 0 or 1 depending on the sign of n
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// VersionConstraint is a set of comparisons a version must all satisfy, e.g. ">=1.2.0, <2.0.0"
+// Supported operators are =, !=, >, >=, <, <=, ^ (same major version, or same minor version below 1.0.0)
+// and ~ (same minor version, or same major version if only the major version is given).
+// A version without operator requires that exact version; "*" or an empty constraint allows any version.
+// Partial versions are X-ranges covering every version they match: "1.2" allows 1.2.x, ">1.2" requires
+// at least 1.3.0, "<=1.2" allows 1.2.5 and "!=1" excludes all 1.x.x versions.
+type VersionConstraint struct {
+	raw         string
+	comparisons []versionComparison
+}
+
+// versionComparison is a single comparison of a VersionConstraint
+type versionComparison struct {
+	operator string
+	version  SemanticVersion
+	upper    SemanticVersion // exclusive upper bound of the "outside" operator, used for != on partial versions
+}
+
+// versionOperators are the supported comparison operators; longer operators first so that prefixes match correctly
+var versionOperators = []string{">=", "<=", "!=", "==", ">", "<", "=", "^", "~"}
+
+// ParseVersionConstraint parses a version constraint
+// Comparisons are separated by commas or whitespace.
+//
+// Parameters:
+//   - constraint: the constraint to parse
+//
+// Returns:
+//   - VersionConstraint: the parsed constraint
+//   - error: an error if a comparison is invalid
+func ParseVersionConstraint(constraint string) (VersionConstraint, error) {
+	parsed := VersionConstraint{raw: strings.TrimSpace(constraint)}
+	fields := strings.FieldsFunc(constraint, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+
+	// operators may be separated from their version by whitespace, e.g. ">= 1.2"
+	var tokens []string
+	for i := 0; i < len(fields); i++ {
+		if slices.Contains(versionOperators, fields[i]) && i+1 < len(fields) {
+			tokens = append(tokens, fields[i]+fields[i+1])
+			i++
+			continue
+		}
+		tokens = append(tokens, fields[i])
+	}
+
+	for _, token := range tokens {
+		if token == "*" {
+			continue
+		}
+		operator := "="
+		for _, op := range versionOperators {
+			if strings.HasPrefix(token, op) {
+				operator = op
+				token = token[len(op):]
+				break
+			}
+		}
+		version, parts, err := parseVersionParts(token)
+		if err != nil {
+			return VersionConstraint{}, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+		}
+		parsed.comparisons = append(parsed.comparisons, expandComparison(operator, version, parts)...)
+	}
+	return parsed, nil
+}
+
+// expandComparison converts the caret and tilde operators and the partial versions into lower and upper bounds
+func expandComparison(operator string, version SemanticVersion, parts int) []versionComparison {
+	// the upper bounds exclude the pre-releases of the next version
+	var upper SemanticVersion
+	switch operator {
+	case "^":
+		switch {
+		case version.Major > 0 || parts == 1:
+			upper = SemanticVersion{Major: version.Major + 1, PreRelease: "0"}
+		case version.Minor > 0 || parts == 2:
+			upper = SemanticVersion{Minor: version.Minor + 1, PreRelease: "0"}
+		default:
+			upper = SemanticVersion{Patch: version.Patch + 1, PreRelease: "0"}
+		}
+		return []versionComparison{{operator: ">=", version: version}, {operator: "<", version: upper}}
+	case "~":
+		if parts == 1 {
+			upper = SemanticVersion{Major: version.Major + 1, PreRelease: "0"}
+		} else {
+			upper = SemanticVersion{Major: version.Major, Minor: version.Minor + 1, PreRelease: "0"}
+		}
+		return []versionComparison{{operator: ">=", version: version}, {operator: "<", version: upper}}
+	case "==":
+		operator = "="
+	}
+	if parts == 3 || version.PreRelease != "" {
+		return []versionComparison{{operator: operator, version: version}}
+	}
+
+	// a partial version is the X-range [version, next) of the versions it matches
+	next := SemanticVersion{Major: version.Major + 1}
+	if parts == 2 {
+		next = SemanticVersion{Major: version.Major, Minor: version.Minor + 1}
+	}
+	upper = next
+	upper.PreRelease = "0"
+	switch operator {
+	case "=":
+		return []versionComparison{{operator: ">=", version: version}, {operator: "<", version: upper}}
+	case "!=":
+		return []versionComparison{{operator: "outside", version: version, upper: upper}}
+	case ">":
+		return []versionComparison{{operator: ">=", version: next}}
+	case "<":
+		lower := version
+		lower.PreRelease = "0"
+		return []versionComparison{{operator: "<", version: lower}}
+	case "<=":
+		return []versionComparison{{operator: "<", version: upper}}
+	}
+	return []versionComparison{{operator: operator, version: version}}
+}
+
+// Allows checks if a version satisfies all comparisons of the constraint
+//
+// Parameters:
+//   - version: the version to check
+//
+// Returns:
+//   - bool: true if the version satisfies the constraint
+func (c VersionConstraint) Allows(version SemanticVersion) bool {
+	for _, comparison := range c.comparisons {
+		diff := version.Compare(comparison.version)
+		var ok bool
+		switch comparison.operator {
+		case "=":
+			ok = diff == 0
+		case "!=":
+			ok = diff != 0
+		case ">":
+			ok = diff > 0
+		case ">=":
+			ok = diff >= 0
+		case "<":
+			ok = diff < 0
+		case "<=":
+			ok = diff <= 0
+		case "outside":
+			ok = diff < 0 || version.Compare(comparison.upper) >= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the constraint as given to ParseVersionConstraint
+//
+// Returns:
+//   - string: the constraint
+func (c VersionConstraint) String() string {
+	return c.raw
+}
+
+// ClientRequires checks if the version of a server satisfies the version constraint of a client
+//
+// Parameters:
+//   - serverVersion: the version reported by the server
+//   - constraint: the constraint required by the client, e.g. ">=1.2.0, <2.0.0"
+//
+// Returns:
+//   - bool: true if the server version satisfies the constraint
+//   - error: an error if the version or the constraint cannot be parsed
+func ClientRequires(serverVersion string, constraint string) (bool, error) {
+	parsedConstraint, err := ParseVersionConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	version, err := ParseSemanticVersion(serverVersion)
+	if err != nil {
+		return false, err
+	}
+	return parsedConstraint.Allows(version), nil
+}
+
+// CompatibilityMatrix holds the version constraints of the peers of a service by peer name,
+// e.g. {"flowkit": "^1.4", "llm-handler": ">=2.0.0"}.
+type CompatibilityMatrix map[string]string
+
+// Validate checks that all constraints can be parsed
+//
+// Returns:
+//   - error: an error listing the invalid constraints; nil if valid
+func (m CompatibilityMatrix) Validate() error {
+	var errs []error
+	for _, peer := range slices.Sorted(maps.Keys(m)) {
+		if _, err := ParseVersionConstraint(m[peer]); err != nil {
+			errs = append(errs, fmt.Errorf("peer %s: %w", peer, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check verifies that the version of a peer satisfies its constraint
+// Peers without constraint are always compatible.
+//
+// Parameters:
+//   - peer: the name of the peer
+//   - version: the version reported by the peer
+//
+// Returns:
+//   - error: an error if the peer is incompatible or the version cannot be parsed; nil if compatible
+func (m CompatibilityMatrix) Check(peer string, version string) error {
+	constraint, ok := m[peer]
+	if !ok {
+		return nil
+	}
+	allowed, err := ClientRequires(version, constraint)
+	if err != nil {
+		return fmt.Errorf("peer %s: %w", peer, err)
+	}
+	if !allowed {
+		return fmt.Errorf("peer %s has version %s, but %s is required", peer, version, constraint)
+	}
+	return nil
+}
+
+// ServiceVersion is the version information reported by a service in its HealthCheck and GetVersion responses
+// The capabilities are not part of the responses; FlowKit servers announce them in the
+// FlowkitCapabilitiesMetadataKey response metadata, like for every other call.
+type ServiceVersion struct {
+	Version         string   `json:"version"`                   // version of the service
+	ProtocolVersion string   `json:"protocolVersion,omitempty"` // version of the gRPC protocol; empty for services predating version negotiation
+	Capabilities    []string `json:"capabilities,omitempty"`    // capabilities announced in the response metadata, sorted
+}
+
+// HasCapability checks if the service announced a capability
+//
+// Parameters:
+//   - capability: the capability, e.g. FlowkitCapabilityDryRun
+//
+// Returns:
+//   - bool: true if the capability was announced
+func (v ServiceVersion) HasCapability(capability string) bool {
+	return slices.Contains(v.Capabilities, capability)
+}
+
+// ProtocolCompatible checks if the protocol of the service is compatible with ProtocolVersion,
+// i.e. it has the same major version; differences in minor versions are negotiated with the capabilities.
+// Services not reporting a protocol version are assumed compatible.
+//
+// Returns:
+//   - bool: true if the protocols are compatible
+//   - error: an error if the protocol version cannot be parsed
+func (v ServiceVersion) ProtocolCompatible() (bool, error) {
+	if v.ProtocolVersion == "" {
+		return true, nil
+	}
+	own, err := ParseSemanticVersion(ProtocolVersion)
+	if err != nil {
+		return false, err
+	}
+	return ClientRequires(v.ProtocolVersion, fmt.Sprintf("~%d", own.Major))
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"testing"
+)
+
+func TestParseSemanticVersion(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    SemanticVersion
+		wantErr bool
+	}{
+		{"1.4.2", SemanticVersion{Major: 1, Minor: 4, Patch: 2}, false},
+		{"v1.4.2", SemanticVersion{Major: 1, Minor: 4, Patch: 2}, false},
+		{"1.4", SemanticVersion{Major: 1, Minor: 4}, false},
+		{"2", SemanticVersion{Major: 2}, false},
+		{" 1.0.0-rc.1+build.5 ", SemanticVersion{Major: 1, PreRelease: "rc.1", Build: "build.5"}, false},
+		{"1.0.0+build-1", SemanticVersion{Major: 1, Build: "build-1"}, false},
+		{"", SemanticVersion{}, true},
+		{"1.2.3.4", SemanticVersion{}, true},
+		{"1.x", SemanticVersion{}, true},
+		{"1.-2", SemanticVersion{}, true},
+		{"1.0.0-", SemanticVersion{}, true},
+		{"1.0.0+", SemanticVersion{}, true},
+		{"fake", SemanticVersion{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSemanticVersion(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSemanticVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSemanticVersion() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemanticVersionString(t *testing.T) {
+	for _, value := range []string{"1.4.2", "0.0.1-alpha", "1.0.0-rc.1+build.5", "3.2.1+sha.abc"} {
+		version, err := ParseSemanticVersion(value)
+		if err != nil {
+			t.Fatalf("ParseSemanticVersion(%q) error = %v", value, err)
+		}
+		if got := version.String(); got != value {
+			t.Errorf("String() = %q, want %q", got, value)
+		}
+	}
+}
+
+func TestSemanticVersionCompare(t *testing.T) {
+	// ordered by precedence, as in the semantic versioning specification
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.2.0", "2.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, _ := ParseSemanticVersion(ordered[i])
+			b, _ := ParseSemanticVersion(ordered[j])
+			want := sign(i - j)
+			if got := a.Compare(b); got != want {
+				t.Errorf("%s.Compare(%s) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+
+	a, _ := ParseSemanticVersion("1.0.0+build.1")
+	b, _ := ParseSemanticVersion("1.0.0+build.2")
+	if got := a.Compare(b); got != 0 {
+		t.Errorf("build metadata affects precedence: Compare() = %d", got)
+	}
+}
+
+func TestClientRequires(t *testing.T) {
+	tests := []struct {
+		version    string
+		constraint string
+		want       bool
+		wantErr    bool
+	}{
+		{"1.4.2", "", true, false},
+		{"1.4.2", "*", true, false},
+		{"1.4.2", "1.4.2", true, false},
+		{"1.4.3", "1.4.2", false, false},
+		{"1.4.2", "==1.4.2", true, false},
+		{"1.4.2", "!=1.4.2", false, false},
+		{"1.4.2", ">=1.2.0, <2.0.0", true, false},
+		{"2.0.0", ">=1.2.0, <2.0.0", false, false},
+		{"1.4.2", ">= 1.2 < 2", true, false},
+		{"1.1.9", ">1.2", false, false},
+		{"1.2.0", "<=1.2", true, false},
+		{"1.2.5", "<=1.2", true, false},
+		{"1.3.0-rc.1", "<=1.2", false, false},
+		{"1.2.5", ">1.2", false, false},
+		{"1.3.0", ">1.2", true, false},
+		{"1.1.9", "<1.2", true, false},
+		{"1.2.0-rc.1", "<1.2", false, false},
+		{"1.2.0", "<1.2", false, false},
+		{"1.2.7", "1.2", true, false},
+		{"1.3.0", "1.2", false, false},
+		{"1.2.0-rc.1", "1.2", false, false},
+		{"1.9.9", "==1", true, false},
+		{"2.0.0", "=1", false, false},
+		{"1.2.7", "!=1.2", false, false},
+		{"1.3.0", "!=1.2", true, false},
+		{"1.1.9", "!=1.2", true, false},
+		{"1.0.0-rc.1", ">=1.0", false, false},
+		{"1.9.9", "^1.2.0", true, false},
+		{"1.1.0", "^1.2.0", false, false},
+		{"2.0.0", "^1.2.0", false, false},
+		{"2.0.0-rc.1", "^1.2.0", false, false},
+		{"0.2.5", "^0.2.3", true, false},
+		{"0.3.0", "^0.2.3", false, false},
+		{"0.0.3", "^0.0.3", true, false},
+		{"0.0.4", "^0.0.3", false, false},
+		{"1.2.9", "~1.2.3", true, false},
+		{"1.3.0", "~1.2.3", false, false},
+		{"1.9.0", "~1", true, false},
+		{"2.0.0", "~1", false, false},
+		{"v1.4.2", "^1", true, false},
+		{"1.0.0-rc.1", ">=1.0.0", false, false},
+		{"fake", ">=1.0.0", false, true},
+		{"1.0.0", ">=x", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version+" "+tt.constraint, func(t *testing.T) {
+			got, err := ClientRequires(tt.version, tt.constraint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ClientRequires() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ClientRequires() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompatibilityMatrix(t *testing.T) {
+	matrix := CompatibilityMatrix{"flowkit": "^1.4", "llm-handler": ">=2.0.0"}
+	if err := matrix.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		peer    string
+		version string
+		wantErr bool
+	}{
+		{"flowkit", "1.5.0", false},
+		{"flowkit", "2.0.0", true},
+		{"flowkit", "fake", true},
+		{"llm-handler", "2.1.0", false},
+		{"llm-handler", "1.9.0", true},
+		{"unknown", "0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.peer+" "+tt.version, func(t *testing.T) {
+			if err := matrix.Check(tt.peer, tt.version); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := (CompatibilityMatrix{"flowkit": ">=x"}).Validate(); err == nil {
+		t.Error("Validate() of invalid constraint error = nil")
+	}
+}
+
+func TestServiceVersion(t *testing.T) {
+	version := ServiceVersion{Version: "1.4.2", ProtocolVersion: "1.3.0", Capabilities: []string{FlowkitCapabilityDryRun}}
+	if !version.HasCapability(FlowkitCapabilityDryRun) || version.HasCapability("other") {
+		t.Errorf("HasCapability() wrong for %v", version.Capabilities)
+	}
+
+	tests := []struct {
+		protocolVersion string
+		want            bool
+		wantErr         bool
+	}{
+		{"", true, false},
+		{ProtocolVersion, true, false},
+		{"1.9.0", true, false},
+		{"2.0.0", false, false},
+		{"0.9.0", false, false},
+		{"invalid", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.protocolVersion, func(t *testing.T) {
+			got, err := ServiceVersion{ProtocolVersion: tt.protocolVersion}.ProtocolCompatible()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProtocolCompatible() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ProtocolCompatible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
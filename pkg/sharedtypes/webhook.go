@@ -0,0 +1,274 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Webhook events
+const (
+	WebhookEventWorkflowRunSucceeded = "workflow_run.succeeded"
+	WebhookEventWorkflowRunFailed    = "workflow_run.failed"
+	WebhookEventWorkflowRunCancelled = "workflow_run.cancelled"
+)
+
+// WebhookEvents are the valid webhook events.
+var WebhookEvents = []string{
+	WebhookEventWorkflowRunSucceeded,
+	WebhookEventWorkflowRunFailed,
+	WebhookEventWorkflowRunCancelled,
+}
+
+// Headers of a webhook delivery
+const (
+	WebhookSignatureHeader  = "X-Aali-Signature"   // "v1=<hex HMAC-SHA256 of '<timestamp>.<body>'>"
+	WebhookTimestampHeader  = "X-Aali-Timestamp"   // Unix time of the delivery in seconds
+	WebhookDeliveryIdHeader = "X-Aali-Delivery-Id" // ID of the delivery, identical for its retries
+	WebhookEventHeader      = "X-Aali-Event"       // the event of the delivery
+)
+
+// webhookSignatureVersion is the prefix of the signatures of the current signing scheme
+const webhookSignatureVersion = "v1="
+
+// DefaultWebhookReplayWindow is the default maximum age of a webhook delivery accepted by VerifyWebhookSignature.
+const DefaultWebhookReplayWindow = 5 * time.Minute
+
+// Webhook delivery errors
+var (
+	ErrWebhookSignatureInvalid = errors.New("invalid webhook signature")
+	ErrWebhookTimestampInvalid = errors.New("webhook timestamp outside of the replay window")
+)
+
+// WebhookConfig represents an external endpoint notified of workflow events.
+type WebhookConfig struct {
+	Id          string             `json:"id"`
+	Url         string             `json:"url"`                    // http or https URL receiving the deliveries
+	Secret      string             `json:"secret,omitempty"`       // key of the HMAC signature of the deliveries
+	Events      []string           `json:"events,omitempty"`       // WebhookEvents delivered to the endpoint; all if empty
+	WorkflowIds []string           `json:"workflow_ids,omitempty"` // only events of these workflows are delivered; all if empty
+	RetryPolicy WebhookRetryPolicy `json:"retry_policy"`
+}
+
+// WebhookRetryPolicy defines how failed deliveries are retried.
+// A delivery fails if the endpoint cannot be reached or answers with status 429 or 5xx.
+type WebhookRetryPolicy struct {
+	MaxRetries            int `json:"max_retries,omitempty"`             // number of retries after the first attempt
+	InitialBackoffSeconds int `json:"initial_backoff_seconds,omitempty"` // wait time before the first retry; doubled for every further retry
+	MaxBackoffSeconds     int `json:"max_backoff_seconds,omitempty"`     // maximum wait time between retries; no maximum if 0
+}
+
+// WebhookPayload is the body of a webhook delivery.
+type WebhookPayload struct {
+	DeliveryId  string                     `json:"delivery_id"`
+	Event       string                     `json:"event"` // one of WebhookEvents
+	CreatedAt   time.Time                  `json:"created_at"`
+	WorkflowRun *WorkflowRunStatusResponse `json:"workflow_run,omitempty"` // the finished run of workflow_run events
+}
+
+// Validate checks the URL, the secret, the events and the retry policy.
+//
+// Returns:
+//   - error: an error if the configuration is invalid
+func (w *WebhookConfig) Validate() error {
+	if w.Id == "" {
+		return fmt.Errorf("id is required")
+	}
+	parsed, err := url.Parse(w.Url)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid webhook url '%s'", w.Url)
+	}
+	if w.Secret == "" {
+		return fmt.Errorf("secret of webhook '%s' is required", w.Id)
+	}
+	for _, event := range w.Events {
+		if !slices.Contains(WebhookEvents, event) {
+			return fmt.Errorf("unknown webhook event '%s'", event)
+		}
+	}
+	return w.RetryPolicy.Validate()
+}
+
+// Accepts checks if an event of a workflow is delivered to the webhook.
+//
+// Parameters:
+//   - event: one of WebhookEvents
+//   - workflowId: the ID of the workflow of the event
+//
+// Returns:
+//   - bool: true if the event and the workflow pass the filters of the webhook
+func (w *WebhookConfig) Accepts(event string, workflowId string) bool {
+	if len(w.Events) > 0 && !slices.Contains(w.Events, event) {
+		return false
+	}
+	return len(w.WorkflowIds) == 0 || slices.Contains(w.WorkflowIds, workflowId)
+}
+
+// Validate checks the retry policy for negative values.
+//
+// Returns:
+//   - error: an error if a value is negative
+func (p *WebhookRetryPolicy) Validate() error {
+	if p.MaxRetries < 0 || p.InitialBackoffSeconds < 0 || p.MaxBackoffSeconds < 0 {
+		return fmt.Errorf("retry_policy values must not be negative")
+	}
+	return nil
+}
+
+// Backoff returns the wait time before a retry.
+//
+// Parameters:
+//   - retry: the number of the retry, starting at 1
+//
+// Returns:
+//   - time.Duration: the wait time
+//   - bool: false if the retry exceeds MaxRetries
+func (p *WebhookRetryPolicy) Backoff(retry int) (time.Duration, bool) {
+	if retry < 1 || retry > p.MaxRetries {
+		return 0, false
+	}
+	backoff := time.Duration(p.InitialBackoffSeconds) * time.Second
+	maxBackoff := time.Duration(p.MaxBackoffSeconds) * time.Second
+	for i := 1; i < retry; i++ {
+		backoff *= 2
+		if maxBackoff > 0 && backoff >= maxBackoff {
+			break
+		}
+	}
+	if maxBackoff > 0 && backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff, true
+}
+
+// IsRetryableWebhookStatus checks if a delivery answered with a status code is retried.
+//
+// Parameters:
+//   - statusCode: the HTTP status code of the endpoint
+//
+// Returns:
+//   - bool: true for 429 and 5xx
+func IsRetryableWebhookStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// SignWebhookPayload computes the signature of a webhook delivery.
+// The signature is the HMAC-SHA256 of "<timestamp>.<body>" with the secret, so a captured delivery
+// cannot be replayed with a new timestamp.
+//
+// Parameters:
+//   - secret: the secret of the webhook
+//   - timestamp: the time of the delivery
+//   - body: the body of the delivery
+//
+// Returns:
+//   - string: the value of the WebhookSignatureHeader
+func SignWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	return webhookSignatureVersion + hex.EncodeToString(webhookMAC(secret, strconv.FormatInt(timestamp.Unix(), 10), body))
+}
+
+// VerifyWebhookSignature verifies the signature and the timestamp of a received webhook delivery.
+//
+// Parameters:
+//   - secret: the secret of the webhook
+//   - signature: the value of the WebhookSignatureHeader
+//   - timestamp: the value of the WebhookTimestampHeader
+//   - body: the received body
+//   - replayWindow: the maximum difference between the timestamp and now; DefaultWebhookReplayWindow if 0
+//   - now: the current time
+//
+// Returns:
+//   - error: ErrWebhookTimestampInvalid if the timestamp is outside of the window, ErrWebhookSignatureInvalid if the signature does not match
+func VerifyWebhookSignature(secret string, signature string, timestamp string, body []byte, replayWindow time.Duration, now time.Time) error {
+	if replayWindow <= 0 {
+		replayWindow = DefaultWebhookReplayWindow
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: '%s' is not a Unix time", ErrWebhookTimestampInvalid, timestamp)
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > replayWindow || age < -replayWindow {
+		return fmt.Errorf("%w: delivery is %v old", ErrWebhookTimestampInvalid, age.Round(time.Second))
+	}
+
+	hexMAC, ok := strings.CutPrefix(signature, webhookSignatureVersion)
+	if !ok {
+		return fmt.Errorf("%w: unsupported signature version", ErrWebhookSignatureInvalid)
+	}
+	received, err := hex.DecodeString(hexMAC)
+	if err != nil || !hmac.Equal(received, webhookMAC(secret, timestamp, body)) {
+		return ErrWebhookSignatureInvalid
+	}
+	return nil
+}
+
+// webhookMAC computes the HMAC-SHA256 of "<timestamp>.<body>".
+func webhookMAC(secret string, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// NewWebhookRequest creates the signed HTTP request of a webhook delivery.
+// Retries of a delivery reuse the payload and create a new request, so the timestamp is current.
+//
+// Parameters:
+//   - ctx: the context of the request
+//   - config: the webhook configuration
+//   - payload: the payload to deliver
+//   - now: the time of the delivery
+//
+// Returns:
+//   - *http.Request: the POST request with JSON body and signature headers
+//   - error: an error if the payload cannot be serialized or the URL is invalid
+func NewWebhookRequest(ctx context.Context, config WebhookConfig, payload WebhookPayload, now time.Time) (*http.Request, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing webhook payload: %w", err)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, config.Url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating webhook request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookEventHeader, payload.Event)
+	request.Header.Set(WebhookDeliveryIdHeader, payload.DeliveryId)
+	request.Header.Set(WebhookTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	request.Header.Set(WebhookSignatureHeader, SignWebhookPayload(config.Secret, now, body))
+	return request, nil
+}
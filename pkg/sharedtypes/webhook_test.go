@@ -0,0 +1,149 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWebhookConfigValidate(t *testing.T) {
+	valid := WebhookConfig{Id: "hook", Url: "https://example.com/hook", Secret: "s3cret", Events: []string{WebhookEventWorkflowRunFailed}}
+	tests := []struct {
+		name    string
+		modify  func(*WebhookConfig)
+		wantErr bool
+	}{
+		{"valid", func(*WebhookConfig) {}, false},
+		{"missing id", func(w *WebhookConfig) { w.Id = "" }, true},
+		{"relative url", func(w *WebhookConfig) { w.Url = "/hook" }, true},
+		{"unsupported scheme", func(w *WebhookConfig) { w.Url = "ftp://example.com" }, true},
+		{"missing secret", func(w *WebhookConfig) { w.Secret = "" }, true},
+		{"unknown event", func(w *WebhookConfig) { w.Events = []string{"workflow_run.started"} }, true},
+		{"negative retries", func(w *WebhookConfig) { w.RetryPolicy.MaxRetries = -1 }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookConfigAccepts(t *testing.T) {
+	config := WebhookConfig{Events: []string{WebhookEventWorkflowRunFailed}, WorkflowIds: []string{"wf-1"}}
+	tests := []struct {
+		event      string
+		workflowId string
+		want       bool
+	}{
+		{WebhookEventWorkflowRunFailed, "wf-1", true},
+		{WebhookEventWorkflowRunSucceeded, "wf-1", false},
+		{WebhookEventWorkflowRunFailed, "wf-2", false},
+	}
+	for _, tt := range tests {
+		if got := config.Accepts(tt.event, tt.workflowId); got != tt.want {
+			t.Errorf("Accepts(%s, %s) = %v, want %v", tt.event, tt.workflowId, got, tt.want)
+		}
+	}
+	if !(&WebhookConfig{}).Accepts(WebhookEventWorkflowRunCancelled, "any") {
+		t.Error("webhook without filters does not accept the event")
+	}
+}
+
+func TestWebhookRetryPolicyBackoff(t *testing.T) {
+	policy := WebhookRetryPolicy{MaxRetries: 4, InitialBackoffSeconds: 2, MaxBackoffSeconds: 5}
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, wantBackoff := range want {
+		backoff, ok := policy.Backoff(i + 1)
+		if !ok || backoff != wantBackoff {
+			t.Errorf("Backoff(%d) = %v, %v, want %v", i+1, backoff, ok, wantBackoff)
+		}
+	}
+	if _, ok := policy.Backoff(5); ok {
+		t.Error("Backoff() allowed more than MaxRetries retries")
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	now := time.Unix(1767225600, 0)
+	body := []byte(`{"event":"workflow_run.failed"}`)
+	signature := SignWebhookPayload("s3cret", now, body)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      []byte
+		now       time.Time
+		wantErr   error
+	}{
+		{"valid", "s3cret", signature, timestamp, body, now.Add(time.Minute), nil},
+		{"wrong secret", "other", signature, timestamp, body, now, ErrWebhookSignatureInvalid},
+		{"modified body", "s3cret", signature, timestamp, []byte(`{"event":"workflow_run.succeeded"}`), now, ErrWebhookSignatureInvalid},
+		{"replayed with new timestamp", "s3cret", signature, strconv.FormatInt(now.Unix()+60, 10), body, now, ErrWebhookSignatureInvalid},
+		{"unknown version", "s3cret", "v0=" + signature[3:], timestamp, body, now, ErrWebhookSignatureInvalid},
+		{"expired", "s3cret", signature, timestamp, body, now.Add(DefaultWebhookReplayWindow + time.Second), ErrWebhookTimestampInvalid},
+		{"from the future", "s3cret", signature, timestamp, body, now.Add(-DefaultWebhookReplayWindow - time.Second), ErrWebhookTimestampInvalid},
+		{"invalid timestamp", "s3cret", signature, "yesterday", body, now, ErrWebhookTimestampInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhookSignature(tt.secret, tt.signature, tt.timestamp, tt.body, 0, tt.now)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("VerifyWebhookSignature() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewWebhookRequest(t *testing.T) {
+	now := time.Unix(1767225600, 0)
+	config := WebhookConfig{Id: "hook", Url: "https://example.com/hook", Secret: "s3cret"}
+	payload := WebhookPayload{DeliveryId: "delivery-1", Event: WebhookEventWorkflowRunSucceeded, CreatedAt: now}
+
+	request, err := NewWebhookRequest(context.Background(), config, payload, now)
+	if err != nil {
+		t.Fatalf("NewWebhookRequest() error = %v", err)
+	}
+	if request.Method != "POST" || request.URL.String() != config.Url {
+		t.Errorf("request = %s %s", request.Method, request.URL)
+	}
+	if request.Header.Get(WebhookDeliveryIdHeader) != "delivery-1" || request.Header.Get(WebhookEventHeader) != WebhookEventWorkflowRunSucceeded {
+		t.Errorf("headers = %v", request.Header)
+	}
+	body, _ := io.ReadAll(request.Body)
+	err = VerifyWebhookSignature(config.Secret, request.Header.Get(WebhookSignatureHeader), request.Header.Get(WebhookTimestampHeader), body, 0, now)
+	if err != nil {
+		t.Errorf("signature of the request does not verify: %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Websocket protocol versions
+const (
+	WsProtocolVersionLegacy = 1 // loose JSON messages without envelope
+	WsProtocolVersion       = 2 // current version; messages are wrapped in a WsEnvelope
+
+	WsMinProtocolVersion = WsProtocolVersionLegacy // oldest version still supported
+)
+
+// Websocket message types of the protocol negotiation
+const (
+	WsMessageTypeHandshake    = "handshake"     // sent by the client with the supported versions, payload WsHandshake
+	WsMessageTypeHandshakeAck = "handshake_ack" // answered by the server with the negotiated version, payload WsHandshakeAck
+	WsMessageTypeError        = "error"         // payload ApiError
+)
+
+// WsEnvelope is the envelope of every websocket message between agent and client.
+type WsEnvelope struct {
+	Version int             `json:"version"`           // protocol version of the message
+	Type    string          `json:"type"`              // message type, determines the payload type
+	Id      string          `json:"id,omitempty"`      // message ID; responses carry the ID of the request
+	Payload json.RawMessage `json:"payload,omitempty"` // the typed payload
+}
+
+// WsHandshake is the payload of the handshake message sent by the client.
+type WsHandshake struct {
+	SupportedVersions []int  `json:"supported_versions"`
+	ClientName        string `json:"client_name,omitempty"`
+}
+
+// WsHandshakeAck is the payload of the handshake answer of the server.
+type WsHandshakeAck struct {
+	Version int `json:"version"` // the negotiated protocol version
+}
+
+// NewWsEnvelope creates an envelope of the current protocol version with a typed payload.
+//
+// Parameters:
+//   - msgType: the message type
+//   - id: the message ID
+//   - payload: the payload
+//
+// Returns:
+//   - WsEnvelope: the envelope
+//   - error: an error if the payload cannot be serialized
+func NewWsEnvelope[T any](msgType string, id string, payload T) (WsEnvelope, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return WsEnvelope{}, fmt.Errorf("error serializing payload of '%s' message: %w", msgType, err)
+	}
+	return WsEnvelope{Version: WsProtocolVersion, Type: msgType, Id: id, Payload: data}, nil
+}
+
+// DecodeWsPayload deserializes the payload of an envelope.
+//
+// Parameters:
+//   - envelope: the envelope
+//
+// Returns:
+//   - T: the payload
+//   - error: an error if the payload cannot be deserialized
+func DecodeWsPayload[T any](envelope *WsEnvelope) (T, error) {
+	var payload T
+	if len(envelope.Payload) == 0 {
+		return payload, fmt.Errorf("'%s' message has no payload", envelope.Type)
+	}
+	if err := json.Unmarshal(envelope.Payload, &payload); err != nil {
+		return payload, fmt.Errorf("error deserializing payload of '%s' message: %w", envelope.Type, err)
+	}
+	return payload, nil
+}
+
+// ParseWsEnvelope parses a websocket message.
+// Messages without a version are legacy messages; they are wrapped in an envelope of version
+// WsProtocolVersionLegacy with the whole message as payload and the "type" field of the message, if any, as type.
+//
+// Parameters:
+//   - data: the message
+//
+// Returns:
+//   - WsEnvelope: the envelope
+//   - error: an error if the message is not a JSON object or the envelope is invalid
+func ParseWsEnvelope(data []byte) (WsEnvelope, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return WsEnvelope{}, fmt.Errorf("error parsing websocket message: %w", err)
+	}
+
+	if _, ok := fields["version"]; !ok {
+		envelope := WsEnvelope{Version: WsProtocolVersionLegacy, Payload: json.RawMessage(data)}
+		if rawType, ok := fields["type"]; ok {
+			// a non-string type is ignored, the legacy message is still passed on
+			_ = json.Unmarshal(rawType, &envelope.Type)
+		}
+		return envelope, nil
+	}
+
+	var envelope WsEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return WsEnvelope{}, fmt.Errorf("error parsing websocket envelope: %w", err)
+	}
+	if err := envelope.Validate(); err != nil {
+		return WsEnvelope{}, err
+	}
+	return envelope, nil
+}
+
+// Validate checks that the envelope has a supported version and a type.
+//
+// Returns:
+//   - error: an error if the envelope is invalid
+func (e *WsEnvelope) Validate() error {
+	if e.Version < WsMinProtocolVersion || e.Version > WsProtocolVersion {
+		return fmt.Errorf("unsupported websocket protocol version %d, supported versions are %d to %d", e.Version, WsMinProtocolVersion, WsProtocolVersion)
+	}
+	if e.Version > WsProtocolVersionLegacy && e.Type == "" {
+		return fmt.Errorf("websocket envelope has no type")
+	}
+	return nil
+}
+
+// NegotiateWsProtocolVersion selects the highest protocol version supported by both sides.
+//
+// Parameters:
+//   - clientVersions: the versions supported by the client; a client without handshake supports only WsProtocolVersionLegacy
+//
+// Returns:
+//   - int: the negotiated version
+//   - error: an error if there is no common version
+func NegotiateWsProtocolVersion(clientVersions []int) (int, error) {
+	if len(clientVersions) == 0 {
+		clientVersions = []int{WsProtocolVersionLegacy}
+	}
+	negotiated := 0
+	for _, version := range clientVersions {
+		if version >= WsMinProtocolVersion && version <= WsProtocolVersion && version > negotiated {
+			negotiated = version
+		}
+	}
+	if negotiated == 0 {
+		return 0, fmt.Errorf("no common websocket protocol version, client supports %v, server supports %d to %d", clientVersions, WsMinProtocolVersion, WsProtocolVersion)
+	}
+	return negotiated, nil
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWsEnvelopeRoundTrip(t *testing.T) {
+	envelope, err := NewWsEnvelope(WsMessageTypeHandshake, "1", WsHandshake{SupportedVersions: []int{1, 2}, ClientName: "cli"})
+	if err != nil {
+		t.Fatalf("NewWsEnvelope() error = %v", err)
+	}
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	parsed, err := ParseWsEnvelope(data)
+	if err != nil {
+		t.Fatalf("ParseWsEnvelope() error = %v", err)
+	}
+	if parsed.Version != WsProtocolVersion || parsed.Type != WsMessageTypeHandshake || parsed.Id != "1" {
+		t.Errorf("ParseWsEnvelope() = %+v", parsed)
+	}
+	handshake, err := DecodeWsPayload[WsHandshake](&parsed)
+	if err != nil {
+		t.Fatalf("DecodeWsPayload() error = %v", err)
+	}
+	if handshake.ClientName != "cli" || len(handshake.SupportedVersions) != 2 {
+		t.Errorf("DecodeWsPayload() = %+v", handshake)
+	}
+}
+
+func TestParseWsEnvelope(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantVersion int
+		wantType    string
+		wantErr     bool
+	}{
+		{"envelope", `{"version":2,"type":"chat","payload":{"a":1}}`, 2, "chat", false},
+		{"legacy message with type", `{"type":"chat","message":"hi"}`, WsProtocolVersionLegacy, "chat", false},
+		{"legacy message without type", `{"message":"hi"}`, WsProtocolVersionLegacy, "", false},
+		{"unsupported version", `{"version":99,"type":"chat"}`, 0, "", true},
+		{"missing type", `{"version":2}`, 0, "", true},
+		{"not an object", `[1,2]`, 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWsEnvelope([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWsEnvelope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Version != tt.wantVersion || got.Type != tt.wantType {
+				t.Errorf("ParseWsEnvelope() = %+v, want version %d and type %q", got, tt.wantVersion, tt.wantType)
+			}
+			if got.Version == WsProtocolVersionLegacy && string(got.Payload) != tt.data {
+				t.Errorf("legacy payload = %s, want %s", got.Payload, tt.data)
+			}
+		})
+	}
+}
+
+func TestNegotiateWsProtocolVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  []int
+		want    int
+		wantErr bool
+	}{
+		{"both current", []int{1, 2}, 2, false},
+		{"newer client", []int{2, 3, 4}, 2, false},
+		{"legacy client", nil, WsProtocolVersionLegacy, false},
+		{"no common version", []int{5}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NegotiateWsProtocolVersion(tt.client)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("NegotiateWsProtocolVersion() = %d, %v, want %d, wantErr %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
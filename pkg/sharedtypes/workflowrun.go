@@ -0,0 +1,220 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"fmt"
+	"time"
+)
+
+// Workflow run states
+const (
+	WorkflowRunStatusPending   = "pending"
+	WorkflowRunStatusRunning   = "running"
+	WorkflowRunStatusSucceeded = "succeeded"
+	WorkflowRunStatusFailed    = "failed"
+	WorkflowRunStatusCancelled = "cancelled"
+)
+
+// WorkflowRunStatuses are the valid workflow run states.
+var WorkflowRunStatuses = []string{
+	WorkflowRunStatusPending,
+	WorkflowRunStatusRunning,
+	WorkflowRunStatusSucceeded,
+	WorkflowRunStatusFailed,
+	WorkflowRunStatusCancelled,
+}
+
+// Error codes of an ApiErrorResponse
+const (
+	ApiErrorCodeInvalidRequest = "invalid_request"
+	ApiErrorCodeUnauthorized   = "unauthorized"
+	ApiErrorCodeForbidden      = "forbidden"
+	ApiErrorCodeNotFound       = "not_found"
+	ApiErrorCodeConflict       = "conflict"
+	ApiErrorCodeRateLimited    = "rate_limited"
+	ApiErrorCodeInternal       = "internal"
+)
+
+// WorkflowRunValue represents a typed input or output value of a workflow run.
+// The value is serialized as string and can be converted with typeconverters.ConvertStringToGivenType.
+type WorkflowRunValue struct {
+	Value  string `json:"value"`   // the serialized value
+	GoType string `json:"go_type"` // the Go type of the value, e.g. "string", "int" or "[]DbResponse"
+}
+
+// StartWorkflowRunRequest represents the request to start a workflow run.
+type StartWorkflowRunRequest struct {
+	WorkflowId     string                      `json:"workflow_id"`
+	Inputs         map[string]WorkflowRunValue `json:"inputs,omitempty"`          // workflow inputs by name
+	UserId         string                      `json:"user_id,omitempty"`         // user starting the run
+	ChatModelId    string                      `json:"chat_model_id,omitempty"`   // chat model to use for the run; default model if empty
+	StoreSnapshots bool                        `json:"store_snapshots,omitempty"` // if true, snapshots taken during the run are stored in the database
+}
+
+// WorkflowRunStatusResponse represents the status of a workflow run.
+type WorkflowRunStatusResponse struct {
+	WorkflowRunId string                      `json:"workflow_run_id"`
+	WorkflowId    string                      `json:"workflow_id"`
+	Status        string                      `json:"status"`                    // one of WorkflowRunStatuses
+	CurrentNodeId string                      `json:"current_node_id,omitempty"` // node currently executed, only set while running
+	Outputs       map[string]WorkflowRunValue `json:"outputs,omitempty"`         // workflow outputs by name, only set if succeeded
+	Error         *ApiError                   `json:"error,omitempty"`           // only set if failed
+	CreatedAt     time.Time                   `json:"created_at"`
+	StartedAt     *time.Time                  `json:"started_at,omitempty"`
+	FinishedAt    *time.Time                  `json:"finished_at,omitempty"`
+}
+
+// ListWorkflowRunsRequest represents the request for a page of workflow runs.
+type ListWorkflowRunsRequest struct {
+	PageRequest
+	WorkflowId string `json:"workflow_id,omitempty"` // only runs of this workflow if set
+	UserId     string `json:"user_id,omitempty"`     // only runs of this user if set
+	Status     string `json:"status,omitempty"`      // only runs in this state if set
+}
+
+// ListWorkflowRunsResponse represents a page of workflow runs.
+type ListWorkflowRunsResponse struct {
+	Runs       []WorkflowRunStatusResponse `json:"runs"`
+	NextCursor string                      `json:"next_cursor,omitempty"` // cursor for the next page; empty if this is the last page
+}
+
+// WorkflowRunHistoryResponse represents the conversation history of a workflow run.
+type WorkflowRunHistoryResponse struct {
+	WorkflowRunId  string                       `json:"workflow_run_id"`
+	ConversationId string                       `json:"conversation_id,omitempty"` // empty if the run has no conversation
+	Messages       []ConversationHistoryMessage `json:"messages"`                  // oldest first
+}
+
+// ApiErrorResponse is the envelope of every error returned by the agent REST API.
+type ApiErrorResponse struct {
+	Error ApiError `json:"error"`
+}
+
+// ApiError represents an error of the agent REST API.
+type ApiError struct {
+	Code      string            `json:"code"`                 // one of the ApiErrorCode constants
+	Message   string            `json:"message"`              // human-readable message
+	Details   map[string]string `json:"details,omitempty"`    // additional information, e.g. the invalid field
+	RequestId string            `json:"request_id,omitempty"` // ID of the request for support
+}
+
+// Validate checks that the request has a workflow ID and that every input has a name and a Go type.
+//
+// Returns:
+//   - error: an error if the request is invalid
+func (r *StartWorkflowRunRequest) Validate() error {
+	if r.WorkflowId == "" {
+		return fmt.Errorf("workflow_id is required")
+	}
+	for name, input := range r.Inputs {
+		if name == "" {
+			return fmt.Errorf("input name must not be empty")
+		}
+		if input.GoType == "" {
+			return fmt.Errorf("go_type of input '%s' is required", name)
+		}
+	}
+	return nil
+}
+
+// IsTerminal checks if the workflow run has finished.
+//
+// Returns:
+//   - bool: true if the run has succeeded, failed or been cancelled
+func (s *WorkflowRunStatusResponse) IsTerminal() bool {
+	switch s.Status {
+	case WorkflowRunStatusSucceeded, WorkflowRunStatusFailed, WorkflowRunStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Validate checks the status of the workflow run.
+//
+// Returns:
+//   - error: an error if the status is unknown or a failed run has no error
+func (s *WorkflowRunStatusResponse) Validate() error {
+	if s.WorkflowRunId == "" {
+		return fmt.Errorf("workflow_run_id is required")
+	}
+	if !isWorkflowRunStatus(s.Status) {
+		return fmt.Errorf("unknown workflow run status '%s'", s.Status)
+	}
+	if s.Status == WorkflowRunStatusFailed && s.Error == nil {
+		return fmt.Errorf("failed workflow run '%s' has no error", s.WorkflowRunId)
+	}
+	return nil
+}
+
+// Validate checks the page size and the status filter without modifying the request.
+//
+// Returns:
+//   - error: an error if the request is invalid
+func (r *ListWorkflowRunsRequest) Validate() error {
+	if err := r.PageRequest.Validate(); err != nil {
+		return err
+	}
+	if r.Status != "" && !isWorkflowRunStatus(r.Status) {
+		return fmt.Errorf("unknown workflow run status '%s'", r.Status)
+	}
+	return nil
+}
+
+// HasMore checks if there are more workflow runs after this page.
+//
+// Returns:
+//   - bool: true if NextCursor is set
+func (r *ListWorkflowRunsResponse) HasMore() bool {
+	return r.NextCursor != ""
+}
+
+// NewApiErrorResponse creates an error envelope.
+//
+// Parameters:
+//   - code: one of the ApiErrorCode constants
+//   - message: the human-readable message
+//
+// Returns:
+//   - ApiErrorResponse: the error envelope
+func NewApiErrorResponse(code string, message string) ApiErrorResponse {
+	return ApiErrorResponse{Error: ApiError{Code: code, Message: message}}
+}
+
+// Error implements the error interface.
+//
+// Returns:
+//   - string: the code and the message
+func (e *ApiError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// isWorkflowRunStatus checks if the status is one of WorkflowRunStatuses.
+func isWorkflowRunStatus(status string) bool {
+	for _, s := range WorkflowRunStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
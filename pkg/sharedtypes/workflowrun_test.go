@@ -0,0 +1,111 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStartWorkflowRunRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     StartWorkflowRunRequest
+		wantErr bool
+	}{
+		{"valid", StartWorkflowRunRequest{WorkflowId: "wf", Inputs: map[string]WorkflowRunValue{"q": {Value: "x", GoType: "string"}}}, false},
+		{"no inputs", StartWorkflowRunRequest{WorkflowId: "wf"}, false},
+		{"missing workflow id", StartWorkflowRunRequest{}, true},
+		{"missing go type", StartWorkflowRunRequest{WorkflowId: "wf", Inputs: map[string]WorkflowRunValue{"q": {Value: "x"}}}, true},
+		{"empty input name", StartWorkflowRunRequest{WorkflowId: "wf", Inputs: map[string]WorkflowRunValue{"": {GoType: "string"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflowRunStatusResponse(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       WorkflowRunStatusResponse
+		wantTerminal bool
+		wantErr      bool
+	}{
+		{"running", WorkflowRunStatusResponse{WorkflowRunId: "r", Status: WorkflowRunStatusRunning}, false, false},
+		{"succeeded", WorkflowRunStatusResponse{WorkflowRunId: "r", Status: WorkflowRunStatusSucceeded}, true, false},
+		{"failed with error", WorkflowRunStatusResponse{WorkflowRunId: "r", Status: WorkflowRunStatusFailed, Error: &ApiError{Code: ApiErrorCodeInternal}}, true, false},
+		{"failed without error", WorkflowRunStatusResponse{WorkflowRunId: "r", Status: WorkflowRunStatusFailed}, true, true},
+		{"unknown status", WorkflowRunStatusResponse{WorkflowRunId: "r", Status: "done"}, false, true},
+		{"missing id", WorkflowRunStatusResponse{Status: WorkflowRunStatusPending}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.IsTerminal(); got != tt.wantTerminal {
+				t.Errorf("IsTerminal() = %v, want %v", got, tt.wantTerminal)
+			}
+			if err := tt.status.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestListWorkflowRunsRequest(t *testing.T) {
+	var req ListWorkflowRunsRequest
+	if err := json.Unmarshal([]byte(`{"workflow_id":"wf","status":"failed","cursor":"abc"}`), &req); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if req.PageSize != 0 || req.Cursor != "abc" || req.WorkflowId != "wf" {
+		t.Errorf("unexpected request %+v", req)
+	}
+	if page := req.PageRequest.Normalize(); page.PageSize != DefaultPageSize || page.Cursor != "abc" {
+		t.Errorf("Normalize() = %+v", page)
+	}
+
+	req.Status = "unknown"
+	if err := req.Validate(); err == nil {
+		t.Error("Validate() with unknown status: expected error")
+	}
+}
+
+func TestApiErrorResponse(t *testing.T) {
+	resp := NewApiErrorResponse(ApiErrorCodeNotFound, "workflow run not found")
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"error":{"code":"not_found","message":"workflow run not found"}}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+	if resp.Error.Error() != "not_found: workflow run not found" {
+		t.Errorf("Error() = %q", resp.Error.Error())
+	}
+}
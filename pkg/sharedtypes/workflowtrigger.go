@@ -0,0 +1,482 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Workflow trigger types
+const (
+	WorkflowTriggerTypeCron      = "cron"
+	WorkflowTriggerTypeFileWatch = "file_watch"
+	WorkflowTriggerTypeWebhook   = "webhook"
+)
+
+// WorkflowTriggerTypes are the valid workflow trigger types.
+var WorkflowTriggerTypes = []string{
+	WorkflowTriggerTypeCron,
+	WorkflowTriggerTypeFileWatch,
+	WorkflowTriggerTypeWebhook,
+}
+
+// File watch events
+const (
+	FileWatchEventCreated  = "created"
+	FileWatchEventModified = "modified"
+	FileWatchEventDeleted  = "deleted"
+)
+
+// FileWatchEvents are the valid file watch events.
+var FileWatchEvents = []string{
+	FileWatchEventCreated,
+	FileWatchEventModified,
+	FileWatchEventDeleted,
+}
+
+// WorkflowTrigger represents a trigger starting runs of a workflow.
+// Exactly the settings matching the type must be set.
+type WorkflowTrigger struct {
+	Id         string                      `json:"id"`
+	WorkflowId string                      `json:"workflow_id"`
+	Type       string                      `json:"type"`                 // one of WorkflowTriggerTypes
+	Enabled    bool                        `json:"enabled"`              // disabled triggers are kept but never fire
+	Inputs     map[string]WorkflowRunValue `json:"inputs,omitempty"`     // workflow inputs of the started runs by name
+	Cron       *CronTrigger                `json:"cron,omitempty"`       // only set for cron triggers
+	FileWatch  *FileWatchTrigger           `json:"file_watch,omitempty"` // only set for file watch triggers
+	Webhook    *WebhookTrigger             `json:"webhook,omitempty"`    // only set for webhook triggers
+}
+
+// CronTrigger represents a trigger firing on a cron schedule.
+type CronTrigger struct {
+	Expression string `json:"expression"`          // 5 field cron expression or macro, see ParseCronExpression
+	TimeZone   string `json:"time_zone,omitempty"` // IANA time zone of the expression; UTC if empty
+}
+
+// FileWatchTrigger represents a trigger firing on changes of the files in a folder.
+type FileWatchTrigger struct {
+	FolderPath      string   `json:"folder_path"`
+	Pattern         string   `json:"pattern,omitempty"`          // glob pattern of the file names, e.g. "*.csv"; all files if empty
+	Events          []string `json:"events,omitempty"`           // FileWatchEvents firing the trigger; all if empty
+	Recursive       bool     `json:"recursive,omitempty"`        // if true, subfolders are watched too
+	DebounceSeconds int      `json:"debounce_seconds,omitempty"` // events of a file within this duration fire the trigger once
+}
+
+// WebhookTrigger represents a trigger firing on requests to a webhook endpoint.
+type WebhookTrigger struct {
+	Path             string `json:"path"`                        // path of the endpoint below the webhook root, e.g. "reports/daily"
+	RequireSignature bool   `json:"require_signature,omitempty"` // if true, requests must be signed with the secret of the trigger
+}
+
+// Validate checks that the trigger has an ID, a workflow ID and valid settings of its type.
+//
+// Returns:
+//   - error: an error if the trigger is invalid
+func (t *WorkflowTrigger) Validate() error {
+	if t.Id == "" {
+		return fmt.Errorf("id is required")
+	}
+	if t.WorkflowId == "" {
+		return fmt.Errorf("workflow_id is required")
+	}
+	settings := 0
+	for _, set := range []bool{t.Cron != nil, t.FileWatch != nil, t.Webhook != nil} {
+		if set {
+			settings++
+		}
+	}
+	if settings > 1 {
+		return fmt.Errorf("trigger '%s' has settings of more than one type", t.Id)
+	}
+	for name, input := range t.Inputs {
+		if input.GoType == "" {
+			return fmt.Errorf("go_type of input '%s' is required", name)
+		}
+	}
+
+	switch t.Type {
+	case WorkflowTriggerTypeCron:
+		if t.Cron == nil {
+			return fmt.Errorf("cron trigger '%s' has no cron settings", t.Id)
+		}
+		return t.Cron.Validate()
+	case WorkflowTriggerTypeFileWatch:
+		if t.FileWatch == nil {
+			return fmt.Errorf("file watch trigger '%s' has no file_watch settings", t.Id)
+		}
+		return t.FileWatch.Validate()
+	case WorkflowTriggerTypeWebhook:
+		if t.Webhook == nil {
+			return fmt.Errorf("webhook trigger '%s' has no webhook settings", t.Id)
+		}
+		return t.Webhook.Validate()
+	}
+	return fmt.Errorf("unknown workflow trigger type '%s'", t.Type)
+}
+
+// NextRun computes the time a cron trigger fires next.
+//
+// Parameters:
+//   - after: the time after which to search
+//
+// Returns:
+//   - time.Time: the next run, in the time zone of the trigger; zero if the trigger is disabled
+//   - error: an error if the trigger is not a valid cron trigger
+func (t *WorkflowTrigger) NextRun(after time.Time) (time.Time, error) {
+	if t.Type != WorkflowTriggerTypeCron || t.Cron == nil {
+		return time.Time{}, fmt.Errorf("trigger '%s' is not a cron trigger", t.Id)
+	}
+	if !t.Enabled {
+		return time.Time{}, nil
+	}
+	return t.Cron.Next(after)
+}
+
+// Validate checks the cron expression and the time zone.
+//
+// Returns:
+//   - error: an error if the expression or the time zone is invalid
+func (c *CronTrigger) Validate() error {
+	if _, err := ParseCronExpression(c.Expression); err != nil {
+		return err
+	}
+	if _, err := c.location(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Next computes the time the cron trigger fires next.
+//
+// Parameters:
+//   - after: the time after which to search
+//
+// Returns:
+//   - time.Time: the next run, in the time zone of the trigger
+//   - error: an error if the expression or the time zone is invalid
+func (c *CronTrigger) Next(after time.Time) (time.Time, error) {
+	schedule, err := ParseCronExpression(c.Expression)
+	if err != nil {
+		return time.Time{}, err
+	}
+	location, err := c.location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(after.In(location)), nil
+}
+
+// location returns the time zone of the cron trigger.
+func (c *CronTrigger) location() (*time.Location, error) {
+	if c.TimeZone == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time_zone '%s': %v", c.TimeZone, err)
+	}
+	return location, nil
+}
+
+// Validate checks the folder, the pattern, the events and the debounce duration.
+//
+// Returns:
+//   - error: an error if the settings are invalid
+func (f *FileWatchTrigger) Validate() error {
+	if f.FolderPath == "" {
+		return fmt.Errorf("folder_path is required")
+	}
+	if f.Pattern != "" {
+		if _, err := path.Match(f.Pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern '%s': %v", f.Pattern, err)
+		}
+	}
+	for _, event := range f.Events {
+		if !slices.Contains(FileWatchEvents, event) {
+			return fmt.Errorf("unknown file watch event '%s'", event)
+		}
+	}
+	if f.DebounceSeconds < 0 {
+		return fmt.Errorf("debounce_seconds must not be negative")
+	}
+	return nil
+}
+
+// Matches checks if an event of a file fires the trigger.
+// Only the base name of the file is matched against the pattern.
+//
+// Parameters:
+//   - event: one of FileWatchEvents
+//   - filePath: the path of the changed file
+//
+// Returns:
+//   - bool: true if the event and the file name match
+func (f *FileWatchTrigger) Matches(event string, filePath string) bool {
+	if len(f.Events) > 0 && !slices.Contains(f.Events, event) {
+		return false
+	}
+	if f.Pattern == "" {
+		return true
+	}
+	matched, _ := path.Match(f.Pattern, path.Base(strings.ReplaceAll(filePath, "\\", "/")))
+	return matched
+}
+
+// Validate checks the path of the webhook endpoint.
+//
+// Returns:
+//   - error: an error if the path is empty, absolute or leaves the webhook root
+func (w *WebhookTrigger) Validate() error {
+	if w.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+	if strings.HasPrefix(w.Path, "/") || path.Clean(w.Path) != w.Path || strings.HasPrefix(w.Path, "..") {
+		return fmt.Errorf("invalid webhook path '%s'", w.Path)
+	}
+	return nil
+}
+
+// CronSchedule is a parsed cron expression.
+type CronSchedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	// anyDay is true if the day of month or the day of week is "*";
+	// otherwise a day matches if either field matches, as in Vixie cron
+	anyDay bool
+}
+
+// cronMacros are the supported cron macros and their expressions
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField describes the range and names of a cron field
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string // names of the values starting at min, e.g. "JAN" for 1
+}
+
+var (
+	cronMinuteField     = cronField{name: "minute", min: 0, max: 59}
+	cronHourField       = cronField{name: "hour", min: 0, max: 23}
+	cronDayOfMonthField = cronField{name: "day of month", min: 1, max: 31}
+	cronMonthField      = cronField{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	// day of week 7 is Sunday, like 0
+	cronDayOfWeekField = cronField{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+)
+
+// ParseCronExpression parses a cron expression.
+// Expressions have the 5 fields minute, hour, day of month, month and day of week; fields support
+// "*", values, ranges "1-5", lists "1,3", steps "*/15" or "1-30/2", and month and day names like "JAN" or "MON".
+// The macros @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly are supported too.
+//
+// Parameters:
+//   - expression: the cron expression
+//
+// Returns:
+//   - *CronSchedule: the parsed schedule
+//   - error: an error if the expression is invalid
+func ParseCronExpression(expression string) (*CronSchedule, error) {
+	expression = strings.TrimSpace(expression)
+	if macro, ok := cronMacros[strings.ToLower(expression)]; ok {
+		expression = macro
+	}
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression '%s': expected 5 fields, got %d", expression, len(fields))
+	}
+
+	schedule := &CronSchedule{anyDay: fields[2] == "*" || fields[4] == "*"}
+	var err error
+	for i, target := range []struct {
+		bits  *uint64
+		field cronField
+	}{
+		{&schedule.minute, cronMinuteField},
+		{&schedule.hour, cronHourField},
+		{&schedule.dayOfMonth, cronDayOfMonthField},
+		{&schedule.month, cronMonthField},
+		{&schedule.dayOfWeek, cronDayOfWeekField},
+	} {
+		*target.bits, err = parseCronField(fields[i], target.field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s': %v", expression, err)
+		}
+	}
+	// Sunday may be given as 7
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek = schedule.dayOfWeek&^(1<<7) | 1
+	}
+	return schedule, nil
+}
+
+// parseCronField parses a cron field into a bit set of its values
+func parseCronField(value string, field cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, 1
+		if before, after, ok := strings.Cut(part, "/"); ok {
+			var err error
+			step, err = strconv.Atoi(after)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step '%s' in %s field", after, field.name)
+			}
+			rangePart = before
+		}
+
+		start, end := field.min, field.max
+		if rangePart != "*" {
+			low, high, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseCronValue(low, field); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = parseCronValue(high, field); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				// "5/15" means every 15 starting at 5
+				end = field.max
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range '%s' in %s field", rangePart, field.name)
+			}
+		}
+		for v := start; v <= end; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// parseCronValue parses a number or name of a cron field
+func parseCronValue(value string, field cronField) (int, error) {
+	for i, name := range field.names {
+		if strings.EqualFold(value, name) {
+			return field.min + i, nil
+		}
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < field.min || number > field.max {
+		return 0, fmt.Errorf("invalid value '%s' in %s field, must be between %d and %d", value, field.name, field.min, field.max)
+	}
+	return number, nil
+}
+
+// Next computes the first time after the given time matching the schedule.
+// The schedule is evaluated in the location of the given time; wall clock times skipped by a
+// daylight saving time change never match, and wall clock times repeated when clocks fall back
+// match only their first occurrence, so a schedule never fires twice for the same wall clock time.
+//
+// Parameters:
+//   - after: the time after which to search
+//
+// Returns:
+//   - time.Time: the next matching time, in the location of after; zero if there is none within 5 years
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	location := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, location)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 || repeatsWallClock(t) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// repeatsWallClock checks if the wall clock time of t already occurred earlier, because clocks
+// fell back at the start of the zone of t
+func repeatsWallClock(t time.Time) bool {
+	start, _ := t.ZoneBounds()
+	if start.IsZero() {
+		return false
+	}
+	_, offset := t.Zone()
+	_, previousOffset := start.Add(-time.Second).Zone()
+	fallBack := time.Duration(previousOffset-offset) * time.Second
+	return fallBack > 0 && t.Sub(start) < fallBack
+}
+
+// NextN computes the next matching times after the given time.
+//
+// Parameters:
+//   - after: the time after which to search
+//   - count: the number of times to compute
+//
+// Returns:
+//   - []time.Time: the matching times in ascending order; fewer than count if the schedule ends
+func (s *CronSchedule) NextN(after time.Time, count int) []time.Time {
+	var times []time.Time
+	for len(times) < count {
+		after = s.Next(after)
+		if after.IsZero() {
+			break
+		}
+		times = append(times, after)
+	}
+	return times
+}
+
+// matchesDay checks if the day of a time matches the day of month and day of week fields
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.anyDay {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
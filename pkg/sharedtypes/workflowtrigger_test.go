@@ -0,0 +1,235 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sharedtypes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantErr    bool
+	}{
+		{"* * * * *", false},
+		{"*/15 9-17 * * MON-FRI", false},
+		{"0 0 1,15 JAN,jul *", false},
+		{"5/10 * * * 7", false},
+		{"@daily", false},
+		{"@HOURLY", false},
+		{"* * * *", true},
+		{"60 * * * *", true},
+		{"* 24 * * *", true},
+		{"* * 0 * *", true},
+		{"* * * 13 *", true},
+		{"* * * * 8", true},
+		{"*/0 * * * *", true},
+		{"10-5 * * * *", true},
+		{"@every 5m", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			if _, err := ParseCronExpression(tt.expression); (err != nil) != tt.wantErr {
+				t.Errorf("ParseCronExpression() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	after := time.Date(2026, time.January, 30, 10, 7, 30, 0, time.UTC) // a Friday
+	tests := []struct {
+		expression string
+		want       time.Time
+	}{
+		{"* * * * *", time.Date(2026, time.January, 30, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, time.January, 30, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2026, time.February, 2, 9, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2026, time.February, 1, 12, 0, 0, 0, time.UTC)},
+		// day of month and day of week both restricted: either matches
+		{"0 0 13 * FRI", time.Date(2026, time.February, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			schedule, err := ParseCronExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseCronExpression() error = %v", err)
+			}
+			if got := schedule.Next(after); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNextN(t *testing.T) {
+	schedule, err := ParseCronExpression("0 */6 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := schedule.NextN(time.Date(2026, time.March, 1, 5, 0, 0, 0, time.UTC), 3)
+	want := []time.Time{
+		time.Date(2026, time.March, 1, 6, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2026, time.March, 1, 18, 0, 0, 0, time.UTC),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("NextN() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("NextN()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCronTriggerTimeZone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	trigger := &CronTrigger{Expression: "30 2 * * *", TimeZone: "Europe/Berlin"}
+
+	// 02:30 does not exist on the day clocks move forward, so the next run is the day after
+	got, err := trigger.Next(time.Date(2026, time.March, 28, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if want := time.Date(2026, time.March, 30, 2, 30, 0, 0, berlin); !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+
+	trigger.TimeZone = "Mars/Olympus_Mons"
+	if _, err := trigger.Next(time.Now()); err == nil {
+		t.Error("expected an error for an unknown time zone")
+	}
+}
+
+func TestCronScheduleFallBack(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	schedule, err := ParseCronExpression("30 1 * * *")
+	if err != nil {
+		t.Fatalf("ParseCronExpression() error = %v", err)
+	}
+
+	// 01:30 occurs twice on the day clocks fall back, the schedule fires only at the first one
+	got := schedule.NextN(time.Date(2025, time.November, 2, 0, 0, 0, 0, newYork), 2)
+	want := []time.Time{
+		time.Date(2025, time.November, 2, 5, 30, 0, 0, time.UTC),
+		time.Date(2025, time.November, 3, 6, 30, 0, 0, time.UTC),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("NextN() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("NextN()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	// a schedule for every minute skips the repeated hour
+	every, _ := ParseCronExpression("* * * * *")
+	if got, want := every.Next(time.Date(2025, time.November, 2, 5, 59, 0, 0, time.UTC).In(newYork)),
+		time.Date(2025, time.November, 2, 7, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next() after 01:59 EDT = %v, want %v", got, want)
+	}
+}
+
+func TestWorkflowTriggerValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		trigger WorkflowTrigger
+		wantErr bool
+	}{
+		{"cron", WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: WorkflowTriggerTypeCron, Cron: &CronTrigger{Expression: "@daily"}}, false},
+		{"file watch", WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: WorkflowTriggerTypeFileWatch, FileWatch: &FileWatchTrigger{FolderPath: "/data", Pattern: "*.csv", Events: []string{FileWatchEventCreated}}}, false},
+		{"webhook", WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: WorkflowTriggerTypeWebhook, Webhook: &WebhookTrigger{Path: "reports/daily"}}, false},
+		{"missing id", WorkflowTrigger{WorkflowId: "wf", Type: WorkflowTriggerTypeCron, Cron: &CronTrigger{Expression: "@daily"}}, true},
+		{"missing workflow", WorkflowTrigger{Id: "t", Type: WorkflowTriggerTypeCron, Cron: &CronTrigger{Expression: "@daily"}}, true},
+		{"unknown type", WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: "manual"}, true},
+		{"missing settings", WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: WorkflowTriggerTypeCron}, true},
+		{"settings of two types", WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: WorkflowTriggerTypeCron, Cron: &CronTrigger{Expression: "@daily"}, Webhook: &WebhookTrigger{Path: "x"}}, true},
+		{"invalid expression", WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: WorkflowTriggerTypeCron, Cron: &CronTrigger{Expression: "* *"}}, true},
+		{"input without type", WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: WorkflowTriggerTypeCron, Cron: &CronTrigger{Expression: "@daily"}, Inputs: map[string]WorkflowRunValue{"a": {Value: "1"}}}, true},
+		{"unknown event", WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: WorkflowTriggerTypeFileWatch, FileWatch: &FileWatchTrigger{FolderPath: "/data", Events: []string{"renamed"}}}, true},
+		{"invalid pattern", WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: WorkflowTriggerTypeFileWatch, FileWatch: &FileWatchTrigger{FolderPath: "/data", Pattern: "[a-"}}, true},
+		{"absolute webhook path", WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: WorkflowTriggerTypeWebhook, Webhook: &WebhookTrigger{Path: "/hook"}}, true},
+		{"escaping webhook path", WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: WorkflowTriggerTypeWebhook, Webhook: &WebhookTrigger{Path: "../hook"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.trigger.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflowTriggerNextRun(t *testing.T) {
+	after := time.Date(2026, time.January, 30, 10, 7, 0, 0, time.UTC)
+	trigger := WorkflowTrigger{Id: "t", WorkflowId: "wf", Type: WorkflowTriggerTypeCron, Enabled: true, Cron: &CronTrigger{Expression: "@hourly"}}
+	got, err := trigger.NextRun(after)
+	if err != nil || !got.Equal(time.Date(2026, time.January, 30, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("NextRun() = %v, %v", got, err)
+	}
+
+	trigger.Enabled = false
+	if got, err := trigger.NextRun(after); err != nil || !got.IsZero() {
+		t.Errorf("NextRun() of disabled trigger = %v, %v, want zero time", got, err)
+	}
+
+	webhook := WorkflowTrigger{Id: "t", Type: WorkflowTriggerTypeWebhook, Enabled: true, Webhook: &WebhookTrigger{Path: "x"}}
+	if _, err := webhook.NextRun(after); err == nil {
+		t.Error("expected an error for a webhook trigger")
+	}
+}
+
+func TestFileWatchTriggerMatches(t *testing.T) {
+	trigger := FileWatchTrigger{FolderPath: "/data", Pattern: "*.csv", Events: []string{FileWatchEventCreated, FileWatchEventModified}}
+	tests := []struct {
+		event    string
+		filePath string
+		want     bool
+	}{
+		{FileWatchEventCreated, "/data/report.csv", true},
+		{FileWatchEventModified, `C:\data\sub\report.csv`, true},
+		{FileWatchEventDeleted, "/data/report.csv", false},
+		{FileWatchEventCreated, "/data/report.txt", false},
+	}
+	for _, tt := range tests {
+		if got := trigger.Matches(tt.event, tt.filePath); got != tt.want {
+			t.Errorf("Matches(%s, %s) = %v, want %v", tt.event, tt.filePath, got, tt.want)
+		}
+	}
+	if !(&FileWatchTrigger{FolderPath: "/data"}).Matches(FileWatchEventDeleted, "/data/any") {
+		t.Error("trigger without pattern and events does not match")
+	}
+}
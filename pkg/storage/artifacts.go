@@ -0,0 +1,165 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/google/uuid"
+)
+
+// ErrArtifactCorrupted is returned when reading artifact content whose size or hash does not match the artifact.
+var ErrArtifactCorrupted = errors.New("artifact content does not match its hash")
+
+// ArtifactOptions contains the metadata of a new artifact.
+type ArtifactOptions struct {
+	MimeType       string
+	ProducerNodeId string
+	RetentionClass string // sharedtypes.ArtifactRetention* constant; standard if empty
+}
+
+// PutArtifact stores content under its content-addressed key and returns the artifact referencing it.
+// The content is spooled to a temporary file to compute its hash; content that is already stored is not uploaded again.
+//
+// Parameters:
+//   - ctx: the context
+//   - store: the object store, e.g. from NewStoreFromConfig
+//   - data: the content
+//   - options: the metadata of the artifact
+//
+// Returns:
+//   - sharedtypes.Artifact: the artifact
+//   - error: an error if the options are invalid or the content cannot be stored
+func PutArtifact(ctx context.Context, store Store, data io.Reader, options ArtifactOptions) (sharedtypes.Artifact, error) {
+	spool, err := os.CreateTemp("", "aali-artifact-*")
+	if err != nil {
+		return sharedtypes.Artifact{}, fmt.Errorf("error creating artifact spool file: %w", err)
+	}
+	defer os.Remove(spool.Name()) //nolint:errcheck
+	defer spool.Close()           //nolint:errcheck
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(spool, h), data)
+	if err != nil {
+		return sharedtypes.Artifact{}, fmt.Errorf("error reading artifact content: %w", err)
+	}
+	artifact := sharedtypes.Artifact{
+		Id:             uuid.NewString(),
+		Sha256:         hex.EncodeToString(h.Sum(nil)),
+		Size:           size,
+		MimeType:       options.MimeType,
+		ProducerNodeId: options.ProducerNodeId,
+		RetentionClass: options.RetentionClass,
+		CreatedAt:      time.Now().UTC(),
+	}
+	if err := artifact.Validate(); err != nil {
+		return sharedtypes.Artifact{}, err
+	}
+
+	exists, err := objectExists(ctx, store, artifact.StorageKey())
+	if err != nil {
+		return sharedtypes.Artifact{}, err
+	}
+	if exists {
+		return artifact, nil
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return sharedtypes.Artifact{}, err
+	}
+	if err := store.Put(ctx, artifact.StorageKey(), spool, size, options.MimeType); err != nil {
+		return sharedtypes.Artifact{}, fmt.Errorf("error storing artifact: %w", err)
+	}
+	return artifact, nil
+}
+
+// OpenArtifact opens the content of an artifact. The reader checks the size and hash of the content
+// and returns ErrArtifactCorrupted instead of io.EOF if they do not match.
+//
+// Parameters:
+//   - ctx: the context
+//   - store: the object store
+//   - artifact: the artifact
+//
+// Returns:
+//   - io.ReadCloser: the content; the caller must close it
+//   - error: ErrNotFound if the content is not stored, or an error if the artifact is invalid
+func OpenArtifact(ctx context.Context, store Store, artifact sharedtypes.Artifact) (io.ReadCloser, error) {
+	if err := artifact.Validate(); err != nil {
+		return nil, err
+	}
+	reader, _, err := store.Get(ctx, artifact.StorageKey())
+	if err != nil {
+		return nil, err
+	}
+	return &verifyingReader{reader: reader, hash: sha256.New(), artifact: artifact}, nil
+}
+
+// objectExists checks if an object with exactly the given key exists.
+func objectExists(ctx context.Context, store Store, key string) (bool, error) {
+	objects, err := store.List(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("error checking for artifact '%s': %w", key, err)
+	}
+	for _, object := range objects {
+		if object.Key == key {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// verifyingReader checks the size and hash of artifact content when reaching its end.
+type verifyingReader struct {
+	reader   io.ReadCloser
+	hash     hash.Hash
+	size     int64
+	artifact sharedtypes.Artifact
+}
+
+// Read reads content, returning ErrArtifactCorrupted at the end if it does not match the artifact.
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	r.size += int64(n)
+	if r.size > r.artifact.Size {
+		return n, ErrArtifactCorrupted
+	}
+	if err == io.EOF && (r.size != r.artifact.Size || hex.EncodeToString(r.hash.Sum(nil)) != r.artifact.Sha256) {
+		return n, ErrArtifactCorrupted
+	}
+	return n, err
+}
+
+// Close closes the underlying reader.
+func (r *verifyingReader) Close() error {
+	return r.reader.Close()
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+func TestPutAndOpenArtifact(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(filepath.Join(t.TempDir(), "store"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := ArtifactOptions{MimeType: "text/csv", ProducerNodeId: "export", RetentionClass: sharedtypes.ArtifactRetentionTemporary}
+	artifact, err := PutArtifact(ctx, store, strings.NewReader("a,b\n1,2\n"), options)
+	if err != nil {
+		t.Fatalf("PutArtifact() error = %v", err)
+	}
+	if artifact.Sha256 != "492d5ea496056f1a6a6592241032fab764c321596317930b4fa0e1e8bc3b7470" {
+		t.Errorf("PutArtifact() sha256 = %s", artifact.Sha256)
+	}
+	if artifact.StorageKey() != "artifacts/sha256/49/"+artifact.Sha256 {
+		t.Errorf("StorageKey() = %s", artifact.StorageKey())
+	}
+	if artifact.Size != 8 || artifact.MimeType != "text/csv" || artifact.ProducerNodeId != "export" || artifact.Id == "" {
+		t.Errorf("PutArtifact() = %+v", artifact)
+	}
+
+	duplicate, err := PutArtifact(ctx, store, strings.NewReader("a,b\n1,2\n"), ArtifactOptions{})
+	if err != nil {
+		t.Fatalf("PutArtifact() of the same content error = %v", err)
+	}
+	if duplicate.StorageKey() != artifact.StorageKey() || duplicate.Id == artifact.Id {
+		t.Errorf("duplicate = %+v, want the same storage key and a new ID", duplicate)
+	}
+	if objects, _ := store.List(ctx, "artifacts/"); len(objects) != 1 {
+		t.Errorf("stored objects = %+v, want the content once", objects)
+	}
+
+	reader, err := OpenArtifact(ctx, store, artifact)
+	if err != nil {
+		t.Fatalf("OpenArtifact() error = %v", err)
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil || string(data) != "a,b\n1,2\n" {
+		t.Errorf("OpenArtifact() content = %q, %v", data, err)
+	}
+
+	if _, err := PutArtifact(ctx, store, strings.NewReader("x"), ArtifactOptions{RetentionClass: "forever"}); err == nil {
+		t.Error("PutArtifact() with an unknown retention class succeeded")
+	}
+}
+
+func TestOpenArtifactCorrupted(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(filepath.Join(t.TempDir(), "store"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	artifact, err := PutArtifact(ctx, store, strings.NewReader("original"), ArtifactOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, content := range []string{"modified", "original and more", "orig"} {
+		if err := store.Put(ctx, artifact.StorageKey(), strings.NewReader(content), int64(len(content)), ""); err != nil {
+			t.Fatal(err)
+		}
+		reader, err := OpenArtifact(ctx, store, artifact)
+		if err != nil {
+			t.Fatalf("OpenArtifact() error = %v", err)
+		}
+		if _, err := io.ReadAll(reader); !errors.Is(err, ErrArtifactCorrupted) {
+			t.Errorf("reading %q error = %v, want ErrArtifactCorrupted", content, err)
+		}
+		reader.Close()
+	}
+
+	missing := sharedtypes.Artifact{Sha256: strings.Repeat("0", 64)}
+	if _, err := OpenArtifact(ctx, store, missing); !errors.Is(err, ErrNotFound) {
+		t.Errorf("OpenArtifact() of missing content error = %v, want ErrNotFound", err)
+	}
+	if _, err := OpenArtifact(ctx, store, sharedtypes.Artifact{Sha256: "invalid"}); err == nil {
+		t.Error("OpenArtifact() of an invalid artifact succeeded")
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// azureBlobVersion is the Blob service REST API version used for requests and SAS tokens.
+const azureBlobVersion = "2021-08-06"
+
+// AzureBlobConfig configures an AzureBlobStore.
+type AzureBlobConfig struct {
+	AccountName string
+	AccountKey  string // base64 encoded account key
+	Container   string
+	Endpoint    string // defaults to https://<account>.blob.core.windows.net
+}
+
+// AzureBlobStore is a Store backed by an Azure Blob Storage container.
+// Requests are authorized with the account key (Shared Key), signed URLs are service SAS URLs.
+type AzureBlobStore struct {
+	cfg        AzureBlobConfig
+	key        []byte
+	endpoint   *url.URL
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewAzureBlobStore creates an Azure Blob Storage store.
+//
+// Parameters:
+//   - cfg: the Azure Blob Storage configuration
+//
+// Returns:
+//   - *AzureBlobStore: the store
+//   - error: an error if the configuration is incomplete or the account key is not base64
+func NewAzureBlobStore(cfg AzureBlobConfig) (*AzureBlobStore, error) {
+	if cfg.AccountName == "" || cfg.AccountKey == "" || cfg.Container == "" {
+		return nil, fmt.Errorf("Azure Blob store requires an account name, an account key and a container")
+	}
+	key, err := base64.StdEncoding.DecodeString(cfg.AccountKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure storage account key: %w", err)
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://" + cfg.AccountName + ".blob.core.windows.net"
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid Azure Blob endpoint '%s'", cfg.Endpoint)
+	}
+	return &AzureBlobStore{
+		cfg:        cfg,
+		key:        key,
+		endpoint:   endpoint,
+		httpClient: &http.Client{},
+		now:        time.Now,
+	}, nil
+}
+
+// Put uploads an object as a block blob.
+func (s *AzureBlobStore) Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if size < 0 {
+		buf, err := io.ReadAll(data)
+		if err != nil {
+			return fmt.Errorf("error reading '%s': %w", key, err)
+		}
+		data, size = bytes.NewReader(buf), int64(len(buf))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.blobURL(key).String(), data)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("error uploading '%s': %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return azureBlobError(resp, "uploading", key)
+	}
+	return nil
+}
+
+// Get downloads an object.
+func (s *AzureBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.blobURL(key).String(), nil)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, ObjectInfo{}, fmt.Errorf("error downloading '%s': %w", key, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, ObjectInfo{}, azureBlobError(resp, "downloading", key)
+	}
+	return resp.Body, headerObjectInfo(key, resp), nil
+}
+
+// azureListResult is the response of List Blobs.
+type azureListResult struct {
+	NextMarker string `xml:"NextMarker"`
+	Blobs      []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			LastModified  string `xml:"Last-Modified"`
+			ContentLength int64  `xml:"Content-Length"`
+			ContentType   string `xml:"Content-Type"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+}
+
+// List lists the objects whose key starts with the prefix, following continuation markers.
+func (s *AzureBlobStore) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := []ObjectInfo{}
+	marker := ""
+	for {
+		u := s.containerURL()
+		query := url.Values{"restype": {"container"}, "comp": {"list"}}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+		u.RawQuery = query.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error listing '%s': %w", prefix, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := azureBlobError(resp, "listing", prefix)
+			resp.Body.Close()
+			return nil, err
+		}
+		var result azureListResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error decoding list of '%s': %w", prefix, err)
+		}
+		for _, blob := range result.Blobs {
+			info := ObjectInfo{Key: blob.Name, Size: blob.Properties.ContentLength, ContentType: blob.Properties.ContentType}
+			if lastModified, err := http.ParseTime(blob.Properties.LastModified); err == nil {
+				info.LastModified = lastModified
+			}
+			objects = append(objects, info)
+		}
+		if result.NextMarker == "" {
+			break
+		}
+		marker = result.NextMarker
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// Delete deletes an object.
+func (s *AzureBlobStore) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.blobURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("error deleting '%s': %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return azureBlobError(resp, "deleting", key)
+	}
+	return nil
+}
+
+// SignedURL creates a blob service SAS URL. GET grants read, PUT grants create and write;
+// PUT requests on the URL must send the header "x-ms-blob-type: BlockBlob".
+func (s *AzureBlobStore) SignedURL(ctx context.Context, key string, method string, expiry time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	if err := validateSignedURLMethod(method); err != nil {
+		return "", err
+	}
+	if expiry <= 0 {
+		return "", fmt.Errorf("signed URL expiry must be positive, got %s", expiry)
+	}
+	permissions := "r"
+	if method == http.MethodPut {
+		permissions = "cw"
+	}
+	expiresAt := s.now().UTC().Add(expiry).Format("2006-01-02T15:04:05Z")
+
+	stringToSign := strings.Join([]string{
+		permissions,
+		"", // signed start
+		expiresAt,
+		"/blob/" + s.cfg.AccountName + "/" + s.cfg.Container + "/" + key,
+		"", // signed identifier
+		"", // signed IP
+		"", // signed protocol
+		azureBlobVersion,
+		"b",                // signed resource
+		"",                 // snapshot time
+		"",                 // encryption scope
+		"", "", "", "", "", // response header overrides
+	}, "\n")
+
+	query := url.Values{
+		"sv":  {azureBlobVersion},
+		"se":  {expiresAt},
+		"sr":  {"b"},
+		"sp":  {permissions},
+		"sig": {base64.StdEncoding.EncodeToString(hmacSHA256(s.key, stringToSign))},
+	}
+	u := s.blobURL(key)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// do authorizes the request with Shared Key and sends it.
+func (s *AzureBlobStore) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("x-ms-date", s.now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureBlobVersion)
+	signature := s.sharedKeySignature(req.Method, req.Header, req.ContentLength, req.URL)
+	req.Header.Set("Authorization", "SharedKey "+s.cfg.AccountName+":"+signature)
+	return s.httpClient.Do(req)
+}
+
+// sharedKeySignature computes the Shared Key signature of a request.
+func (s *AzureBlobStore) sharedKeySignature(method string, header http.Header, contentLength int64, u *url.URL) string {
+	length := ""
+	if contentLength > 0 {
+		length = strconv.FormatInt(contentLength, 10)
+	}
+
+	msHeaders := []string{}
+	for name := range header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-ms-") {
+			msHeaders = append(msHeaders, lower)
+		}
+	}
+	sort.Strings(msHeaders)
+	var canonicalHeaders strings.Builder
+	for _, name := range msHeaders {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(header.Get(name)) + "\n")
+	}
+
+	var canonicalResource strings.Builder
+	canonicalResource.WriteString("/" + s.cfg.AccountName + u.EscapedPath())
+	query := u.Query()
+	params := make([]string, 0, len(query))
+	for name := range query {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	for _, name := range params {
+		values := append([]string{}, query[name]...)
+		sort.Strings(values)
+		canonicalResource.WriteString("\n" + strings.ToLower(name) + ":" + strings.Join(values, ","))
+	}
+
+	stringToSign := strings.Join([]string{
+		method,
+		header.Get("Content-Encoding"),
+		header.Get("Content-Language"),
+		length,
+		header.Get("Content-MD5"),
+		header.Get("Content-Type"),
+		"", // Date, x-ms-date is used instead
+		header.Get("If-Modified-Since"),
+		header.Get("If-Match"),
+		header.Get("If-None-Match"),
+		header.Get("If-Unmodified-Since"),
+		header.Get("Range"),
+		canonicalHeaders.String() + canonicalResource.String(),
+	}, "\n")
+	return base64.StdEncoding.EncodeToString(hmacSHA256(s.key, stringToSign))
+}
+
+// containerURL returns the URL of the container.
+func (s *AzureBlobStore) containerURL() *url.URL {
+	u := *s.endpoint
+	u.Path = u.Path + "/" + s.cfg.Container
+	u.RawPath = ""
+	return &u
+}
+
+// blobURL returns the URL of a blob.
+func (s *AzureBlobStore) blobURL(key string) *url.URL {
+	u := s.containerURL()
+	u.Path = u.Path + "/" + key
+	return u
+}
+
+// azureBlobError converts an unexpected response into an error; 404 becomes ErrNotFound.
+func azureBlobError(resp *http.Response, action string, key string) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if code := resp.Header.Get("x-ms-error-code"); code != "" {
+		return fmt.Errorf("error %s '%s': status %d: %s", action, key, resp.StatusCode, code)
+	}
+	return fmt.Errorf("error %s '%s': status %d", action, key, resp.StatusCode)
+}
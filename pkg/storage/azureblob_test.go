@@ -0,0 +1,218 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAzureBlob is a minimal Blob service that checks Shared Key signatures; listings return one blob per page.
+type fakeAzureBlob struct {
+	mu      sync.Mutex
+	signer  *AzureBlobStore
+	objects map[string]string
+	types   map[string]string
+}
+
+func (f *fakeAzureBlob) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	want := "SharedKey account:" + f.signer.sharedKeySignature(r.Method, r.Header, r.ContentLength, r.URL)
+	if r.Header.Get("Authorization") != want || r.Header.Get("x-ms-version") != azureBlobVersion {
+		w.Header().Set("x-ms-error-code", "AuthenticationFailed")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := strings.TrimPrefix(r.URL.Path, "/container/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Query().Get("comp") == "list":
+		f.list(w, r)
+	case r.Method == http.MethodPut:
+		if r.Header.Get("x-ms-blob-type") != "BlockBlob" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		f.objects[key] = string(data)
+		f.types[key] = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.Header().Set("x-ms-error-code", "BlobNotFound")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.types[key])
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("Last-Modified", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+		io.WriteString(w, data)
+	case r.Method == http.MethodDelete:
+		if _, ok := f.objects[key]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func (f *fakeAzureBlob) list(w http.ResponseWriter, r *http.Request) {
+	type blob struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			LastModified  string `xml:"Last-Modified"`
+			ContentLength int64  `xml:"Content-Length"`
+		} `xml:"Properties"`
+	}
+	type result struct {
+		XMLName    xml.Name `xml:"EnumerationResults"`
+		Blobs      []blob   `xml:"Blobs>Blob"`
+		NextMarker string   `xml:"NextMarker"`
+	}
+	keys := []string{}
+	for key := range f.objects {
+		if strings.HasPrefix(key, r.URL.Query().Get("prefix")) && key > r.URL.Query().Get("marker") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	res := result{}
+	if len(keys) > 0 {
+		b := blob{Name: keys[0]}
+		b.Properties.LastModified = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat)
+		b.Properties.ContentLength = int64(len(f.objects[keys[0]]))
+		res.Blobs = append(res.Blobs, b)
+		if len(keys) > 1 {
+			res.NextMarker = keys[0]
+		}
+	}
+	xml.NewEncoder(w).Encode(res)
+}
+
+func TestAzureBlobStore(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeAzureBlob{objects: map[string]string{}, types: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	cfg := AzureBlobConfig{AccountName: "account", AccountKey: "c2VjcmV0LWtleQ==", Container: "container", Endpoint: server.URL}
+	store, err := NewAzureBlobStore(cfg)
+	if err != nil {
+		t.Fatalf("NewAzureBlobStore() error = %v", err)
+	}
+	fake.signer = store
+
+	if err := store.Put(ctx, "workflows/a/binary.zip", strings.NewReader("binary a"), -1, "application/zip"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := store.Put(ctx, "workflows/b/binary.zip", strings.NewReader("binary b"), 8, ""); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	reader, info, err := store.Get(ctx, "workflows/a/binary.zip")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(data) != "binary a" || info.Size != 8 || info.ContentType != "application/zip" || info.LastModified.IsZero() {
+		t.Errorf("Get() = %q, %+v", data, info)
+	}
+
+	list, err := store.List(ctx, "workflows/")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 2 || list[0].Key != "workflows/a/binary.zip" || list[1].Key != "workflows/b/binary.zip" || list[1].Size != 8 {
+		t.Errorf("List() = %+v", list)
+	}
+
+	if err := store.Delete(ctx, "workflows/a/binary.zip"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "workflows/a/binary.zip"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() twice error = %v, want ErrNotFound", err)
+	}
+	if _, _, err := store.Get(ctx, "workflows/a/binary.zip"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after delete error = %v, want ErrNotFound", err)
+	}
+
+	// a store with the wrong key is rejected by the fake
+	cfg.AccountKey = "b3RoZXIta2V5"
+	wrong, _ := NewAzureBlobStore(cfg)
+	if err := wrong.Put(ctx, "x", strings.NewReader("x"), 1, ""); err == nil || !strings.Contains(err.Error(), "AuthenticationFailed") {
+		t.Errorf("Put() with wrong key error = %v", err)
+	}
+}
+
+func TestAzureBlobSignedURL(t *testing.T) {
+	store, err := NewAzureBlobStore(AzureBlobConfig{AccountName: "account", AccountKey: "c2VjcmV0LWtleQ==", Container: "container"})
+	if err != nil {
+		t.Fatalf("NewAzureBlobStore() error = %v", err)
+	}
+	store.now = func() time.Time { return time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		method      string
+		permissions string
+		wantErr     bool
+	}{
+		{"GET", "r", false},
+		{"PUT", "cw", false},
+		{"DELETE", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			signed, err := store.SignedURL(context.Background(), "workflows/a/binary.zip", tt.method, time.Hour)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SignedURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			u, err := url.Parse(signed)
+			if err != nil {
+				t.Fatalf("SignedURL() returned invalid URL %s", signed)
+			}
+			query := u.Query()
+			if u.Host != "account.blob.core.windows.net" || u.Path != "/container/workflows/a/binary.zip" {
+				t.Errorf("SignedURL() = %s", signed)
+			}
+			if query.Get("sp") != tt.permissions || query.Get("se") != "2024-01-01T13:00:00Z" || query.Get("sr") != "b" || query.Get("sv") != azureBlobVersion || query.Get("sig") == "" {
+				t.Errorf("SignedURL() query = %v", query)
+			}
+		})
+	}
+}
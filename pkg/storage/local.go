@@ -0,0 +1,205 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LocalStore is a Store backed by a directory on the local filesystem.
+type LocalStore struct {
+	root string
+}
+
+// NewLocalStore creates a local store rooted at the given directory, creating it if needed.
+//
+// Parameters:
+//   - root: the root directory
+//
+// Returns:
+//   - *LocalStore: the store
+//   - error: an error if the directory could not be created
+func NewLocalStore(root string) (*LocalStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("local store root directory is empty")
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving local store root '%s': %w", root, err)
+	}
+	if err := os.MkdirAll(abs, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating local store root '%s': %w", abs, err)
+	}
+	return &LocalStore{root: abs}, nil
+}
+
+// Root returns the root directory of the store.
+func (s *LocalStore) Root() string {
+	return s.root
+}
+
+// path returns the filesystem path of a key.
+func (s *LocalStore) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// Put writes an object to a temporary file and renames it into place, so readers never see partial objects.
+func (s *LocalStore) Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating directory for '%s': %w", key, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file for '%s': %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error writing '%s': %w", key, err)
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("error writing '%s': expected %d bytes, got %d", key, size, written)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error storing '%s': %w", key, err)
+	}
+	return nil
+}
+
+// Get opens an object.
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ObjectInfo{}, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, ObjectInfo{}, fmt.Errorf("error opening '%s': %w", key, err)
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, ObjectInfo{}, fmt.Errorf("error reading '%s': %w", key, err)
+	}
+	if stat.IsDir() {
+		file.Close()
+		return nil, ObjectInfo{}, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return file, localObjectInfo(key, stat), nil
+}
+
+// List lists the objects whose key starts with the prefix.
+func (s *LocalStore) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := []ObjectInfo{}
+	err := filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if entry.IsDir() {
+			// skip directories that cannot contain matching keys
+			if key != "." && !strings.HasPrefix(key+"/", prefix) && !strings.HasPrefix(prefix, key+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), ".tmp-") || !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, localObjectInfo(key, info))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing '%s': %w", prefix, err)
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// Delete deletes an object.
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return fmt.Errorf("error deleting '%s': %w", key, err)
+	}
+	return nil
+}
+
+// SignedURL is not supported by the local store and returns ErrSignedURLNotSupported.
+func (s *LocalStore) SignedURL(ctx context.Context, key string, method string, expiry time.Duration) (string, error) {
+	return "", ErrSignedURLNotSupported
+}
+
+// localObjectInfo builds the object info of a file; the content type is derived from the extension.
+func localObjectInfo(key string, info fs.FileInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+		ContentType:  mime.TypeByExtension(filepath.Ext(key)),
+	}
+}
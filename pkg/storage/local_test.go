@@ -0,0 +1,123 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(filepath.Join(t.TempDir(), "store"))
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+
+	objects := map[string]string{
+		"workflows/a/binary.zip": "binary a",
+		"workflows/b/binary.zip": "binary b",
+		"exec/file.json":         "{}",
+	}
+	for key, content := range objects {
+		if err := store.Put(ctx, key, strings.NewReader(content), int64(len(content)), ""); err != nil {
+			t.Fatalf("Put(%s) error = %v", key, err)
+		}
+	}
+
+	reader, info, err := store.Get(ctx, "exec/file.json")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(data) != "{}" || info.Size != 2 || info.ContentType != "application/json" {
+		t.Errorf("Get() = %q, %+v", data, info)
+	}
+
+	list, err := store.List(ctx, "workflows/")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 2 || list[0].Key != "workflows/a/binary.zip" || list[1].Key != "workflows/b/binary.zip" {
+		t.Errorf("List(workflows/) = %+v", list)
+	}
+	list, _ = store.List(ctx, "workflows/a")
+	if len(list) != 1 {
+		t.Errorf("List(workflows/a) = %+v, want 1 object", list)
+	}
+	list, _ = store.List(ctx, "")
+	if len(list) != 3 {
+		t.Errorf("List() = %+v, want 3 objects", list)
+	}
+
+	if err := store.Delete(ctx, "exec/file.json"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, _, err := store.Get(ctx, "exec/file.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "exec/file.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() twice error = %v, want ErrNotFound", err)
+	}
+	if _, _, err := store.Get(ctx, "workflows"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(directory) error = %v, want ErrNotFound", err)
+	}
+	if _, err := store.SignedURL(ctx, "workflows/a/binary.zip", "GET", time.Minute); !errors.Is(err, ErrSignedURLNotSupported) {
+		t.Errorf("SignedURL() error = %v, want ErrSignedURLNotSupported", err)
+	}
+}
+
+func TestLocalStorePutSizeMismatch(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	if err := store.Put(ctx, "file", strings.NewReader("abc"), 5, ""); err == nil {
+		t.Fatal("Put() with wrong size succeeded")
+	}
+	if _, _, err := store.Get(ctx, "file"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after failed put error = %v, want ErrNotFound", err)
+	}
+	entries, _ := os.ReadDir(store.Root())
+	if len(entries) != 0 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+}
+
+func TestLocalStoreRejectsEscapingKeys(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	if err := store.Put(context.Background(), "../outside", strings.NewReader("x"), 1, ""); err == nil {
+		t.Error("Put(../outside) succeeded")
+	}
+}
@@ -0,0 +1,410 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+	s3DateFormat      = "20060102"
+)
+
+// S3Config configures an S3Store.
+type S3Config struct {
+	Bucket          string
+	Region          string
+	Endpoint        string // defaults to https://s3.<region>.amazonaws.com
+	AccessKeyId     string
+	SecretAccessKey string
+	PathStyle       bool // use https://<endpoint>/<bucket>/<key> instead of https://<bucket>.<endpoint>/<key>
+}
+
+// S3Store is a Store backed by an S3 bucket or an S3 compatible service.
+// Requests are signed with AWS Signature Version 4.
+type S3Store struct {
+	cfg        S3Config
+	endpoint   *url.URL
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewS3Store creates an S3 store.
+//
+// Parameters:
+//   - cfg: the S3 configuration
+//
+// Returns:
+//   - *S3Store: the store
+//   - error: an error if the configuration is incomplete
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	if cfg.Bucket == "" || cfg.Region == "" {
+		return nil, fmt.Errorf("S3 store requires a bucket and a region")
+	}
+	if cfg.AccessKeyId == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("S3 store requires an access key ID and a secret access key")
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint '%s'", cfg.Endpoint)
+	}
+	return &S3Store{
+		cfg:        cfg,
+		endpoint:   endpoint,
+		httpClient: &http.Client{},
+		now:        time.Now,
+	}, nil
+}
+
+// Put uploads an object.
+func (s *S3Store) Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if size < 0 {
+		buf, err := io.ReadAll(data)
+		if err != nil {
+			return fmt.Errorf("error reading '%s': %w", key, err)
+		}
+		data, size = bytes.NewReader(buf), int64(len(buf))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), data)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("error uploading '%s': %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp, "uploading", key)
+	}
+	return nil
+}
+
+// Get downloads an object.
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, ObjectInfo{}, fmt.Errorf("error downloading '%s': %w", key, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, ObjectInfo{}, s3Error(resp, "downloading", key)
+	}
+	return resp.Body, headerObjectInfo(key, resp), nil
+}
+
+// s3ListResult is the response of ListObjectsV2.
+type s3ListResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+		Size         int64     `xml:"Size"`
+	} `xml:"Contents"`
+}
+
+// List lists the objects whose key starts with the prefix, following continuation tokens.
+func (s *S3Store) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := []ObjectInfo{}
+	token := ""
+	for {
+		u := s.bucketURL()
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		u.RawQuery = s3EncodeQuery(query)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error listing '%s': %w", prefix, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := s3Error(resp, "listing", prefix)
+			resp.Body.Close()
+			return nil, err
+		}
+		var result s3ListResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error decoding list of '%s': %w", prefix, err)
+		}
+		for _, content := range result.Contents {
+			objects = append(objects, ObjectInfo{Key: content.Key, Size: content.Size, LastModified: content.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// Delete deletes an object. S3 does not report missing objects on delete, so the object is checked first.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	head, err := http.NewRequestWithContext(ctx, http.MethodHead, s.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(head)
+	if err != nil {
+		return fmt.Errorf("error deleting '%s': %w", key, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp, "deleting", key)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err = s.do(req)
+	if err != nil {
+		return fmt.Errorf("error deleting '%s': %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return s3Error(resp, "deleting", key)
+	}
+	return nil
+}
+
+// SignedURL creates a presigned URL for GET or PUT; expiry is capped at 7 days by S3.
+func (s *S3Store) SignedURL(ctx context.Context, key string, method string, expiry time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	if err := validateSignedURLMethod(method); err != nil {
+		return "", err
+	}
+	if expiry <= 0 || expiry > 7*24*time.Hour {
+		return "", fmt.Errorf("S3 signed URL expiry must be between 1s and 7 days, got %s", expiry)
+	}
+	return s.presign(method, s.objectURL(key), expiry, s.now().UTC()), nil
+}
+
+// presign adds the Signature Version 4 query parameters to the URL.
+func (s *S3Store) presign(method string, u *url.URL, expiry time.Duration, t time.Time) string {
+	scope := s.scope(t)
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", s.cfg.AccessKeyId+"/"+scope)
+	query.Set("X-Amz-Date", t.Format(s3TimeFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	canonicalQuery := s3EncodeQuery(query)
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		canonicalQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+	signature := s.signature(canonicalRequest, t)
+
+	signed := *u
+	signed.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+	return signed.String()
+}
+
+// do signs the request with the Authorization header and sends it.
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	t := s.now().UTC()
+	req.Header.Set("X-Amz-Date", t.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3EncodeQuery(req.URL.Query()),
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + s3UnsignedPayload + "\n" +
+			"x-amz-date:" + t.Format(s3TimeFormat) + "\n",
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.cfg.AccessKeyId, s.scope(t), signedHeaders, s.signature(canonicalRequest, t)))
+	return s.httpClient.Do(req)
+}
+
+// scope returns the credential scope of a request time.
+func (s *S3Store) scope(t time.Time) string {
+	return t.Format(s3DateFormat) + "/" + s.cfg.Region + "/" + s3Service + "/aws4_request"
+}
+
+// signature signs a canonical request with the derived signing key.
+func (s *S3Store) signature(canonicalRequest string, t time.Time) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := s3Algorithm + "\n" + t.Format(s3TimeFormat) + "\n" + s.scope(t) + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), t.Format(s3DateFormat))
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// bucketURL returns the URL of the bucket.
+func (s *S3Store) bucketURL() *url.URL {
+	u := *s.endpoint
+	if s.cfg.PathStyle {
+		u.Path = u.Path + "/" + s.cfg.Bucket + "/"
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path = u.Path + "/"
+	}
+	u.RawPath = s3EncodePath(u.Path)
+	return &u
+}
+
+// objectURL returns the URL of an object.
+func (s *S3Store) objectURL(key string) *url.URL {
+	u := s.bucketURL()
+	u.Path = u.Path + key
+	u.RawPath = s3EncodePath(u.Path)
+	return u
+}
+
+// s3EncodePath URI-encodes every segment of a path as required by Signature Version 4.
+func s3EncodePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// s3EncodeQuery encodes query parameters sorted by name as required by Signature Version 4.
+func s3EncodeQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := []string{}
+	for _, key := range keys {
+		values := append([]string{}, query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, s3Escape(key)+"="+s3Escape(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Escape percent-encodes everything except the RFC 3986 unreserved characters.
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// hmacSHA256 computes the HMAC-SHA256 of data with the key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// headerObjectInfo builds the object info of a download response.
+func headerObjectInfo(key string, resp *http.Response) ObjectInfo {
+	info := ObjectInfo{Key: key, Size: resp.ContentLength, ContentType: resp.Header.Get("Content-Type")}
+	if lastModified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.LastModified = lastModified
+	}
+	return info
+}
+
+// s3Error converts an unexpected response into an error; 404 becomes ErrNotFound.
+func s3Error(resp *http.Response, action string, key string) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	var body struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if xml.Unmarshal(data, &body) == nil && body.Code != "" {
+		return fmt.Errorf("error %s '%s': status %d: %s: %s", action, key, resp.StatusCode, body.Code, body.Message)
+	}
+	return fmt.Errorf("error %s '%s': status %d", action, key, resp.StatusCode)
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package storage provides an object storage abstraction with local filesystem, Azure Blob Storage and S3 backends.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+// Storage backends
+const (
+	BackendLocal     = "local"
+	BackendAzureBlob = "azureblob"
+	BackendS3        = "s3"
+)
+
+// ErrNotFound is returned if an object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// ErrSignedURLNotSupported is returned by stores that cannot create signed URLs.
+var ErrSignedURLNotSupported = errors.New("signed URLs are not supported by this store")
+
+// ObjectInfo represents the metadata of a stored object.
+type ObjectInfo struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+	ContentType  string    `json:"contentType,omitempty"`
+}
+
+// Store is an object store. Keys are slash-separated paths such as "workflows/<id>/binary.zip".
+type Store interface {
+	// Put writes an object; size is the number of bytes of data, or -1 if unknown.
+	Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) error
+	// Get opens an object; the caller must close the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error)
+	// List lists the objects whose key starts with the prefix, sorted by key.
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// Delete deletes an object; deleting a missing object returns ErrNotFound.
+	Delete(ctx context.Context, key string) error
+	// SignedURL creates a URL granting the HTTP method (GET or PUT) on an object until the expiry.
+	SignedURL(ctx context.Context, key string, method string, expiry time.Duration) (string, error)
+}
+
+// NewStoreFromConfig creates the store selected by STORAGE_BACKEND of the global config.
+//
+// Returns:
+//   - Store: the store
+//   - error: an error if the backend is unknown or its configuration is incomplete
+func NewStoreFromConfig() (Store, error) {
+	cfg := config.Current()
+	switch cfg.STORAGE_BACKEND {
+	case "", BackendLocal:
+		path := cfg.STORAGE_LOCAL_PATH
+		if path == "" {
+			path = cfg.BINARY_STORE_PATH
+		}
+		return NewLocalStore(path)
+	case BackendAzureBlob:
+		return NewAzureBlobStore(AzureBlobConfig{
+			AccountName: cfg.STORAGE_AZURE_ACCOUNT_NAME,
+			AccountKey:  cfg.STORAGE_AZURE_ACCOUNT_KEY,
+			Container:   cfg.STORAGE_AZURE_CONTAINER,
+			Endpoint:    cfg.STORAGE_AZURE_ENDPOINT,
+		})
+	case BackendS3:
+		return NewS3Store(S3Config{
+			Bucket:          cfg.STORAGE_S3_BUCKET,
+			Region:          cfg.STORAGE_S3_REGION,
+			Endpoint:        cfg.STORAGE_S3_ENDPOINT,
+			AccessKeyId:     cfg.STORAGE_S3_ACCESS_KEY_ID,
+			SecretAccessKey: cfg.STORAGE_S3_SECRET_ACCESS_KEY,
+			PathStyle:       cfg.STORAGE_S3_PATH_STYLE,
+		})
+	}
+	return nil, fmt.Errorf("unknown storage backend '%s'", cfg.STORAGE_BACKEND)
+}
+
+// validateKey checks that a key is a relative slash-separated path without empty, "." or ".." segments.
+func validateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("object key is empty")
+	}
+	if strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid object key '%s'", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid object key '%s'", key)
+		}
+	}
+	return nil
+}
+
+// validateSignedURLMethod checks that a signed URL is requested for GET or PUT.
+func validateSignedURLMethod(method string) error {
+	if method != "GET" && method != "PUT" {
+		return fmt.Errorf("signed URLs are only supported for GET and PUT, got '%s'", method)
+	}
+	return nil
+}
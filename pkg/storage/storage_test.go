@@ -0,0 +1,112 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{"workflows/abc/binary.zip", false},
+		{"file.txt", false},
+		{"", true},
+		{"/absolute", true},
+		{"a//b", true},
+		{"a/../b", true},
+		{"./a", true},
+		{"a\\b", true},
+		{"dir/", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			err := validateKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateKey(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewStoreFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		cfg     config.Config
+		want    string
+		wantErr bool
+	}{
+		{"default local", config.Config{BINARY_STORE_PATH: dir}, "*storage.LocalStore", false},
+		{"local path", config.Config{STORAGE_BACKEND: BackendLocal, STORAGE_LOCAL_PATH: dir}, "*storage.LocalStore", false},
+		{"azure", config.Config{
+			STORAGE_BACKEND:            BackendAzureBlob,
+			STORAGE_AZURE_ACCOUNT_NAME: "account",
+			STORAGE_AZURE_ACCOUNT_KEY:  "a2V5",
+			STORAGE_AZURE_CONTAINER:    "container",
+		}, "*storage.AzureBlobStore", false},
+		{"azure incomplete", config.Config{STORAGE_BACKEND: BackendAzureBlob}, "", true},
+		{"s3", config.Config{
+			STORAGE_BACKEND:              BackendS3,
+			STORAGE_S3_BUCKET:            "bucket",
+			STORAGE_S3_REGION:            "eu-central-1",
+			STORAGE_S3_ACCESS_KEY_ID:     "id",
+			STORAGE_S3_SECRET_ACCESS_KEY: "secret",
+		}, "*storage.S3Store", false},
+		{"s3 incomplete", config.Config{STORAGE_BACKEND: BackendS3, STORAGE_S3_BUCKET: "bucket"}, "", true},
+		{"unknown", config.Config{STORAGE_BACKEND: "ftp"}, "", true},
+	}
+	previous := config.GlobalConfig
+	defer func() { config.GlobalConfig = previous }()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			config.GlobalConfig = &cfg
+			store, err := NewStoreFromConfig()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewStoreFromConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if got := typeName(store); got != tt.want {
+					t.Errorf("NewStoreFromConfig() = %s, want %s", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func typeName(store Store) string {
+	switch store.(type) {
+	case *LocalStore:
+		return "*storage.LocalStore"
+	case *AzureBlobStore:
+		return "*storage.AzureBlobStore"
+	case *S3Store:
+		return "*storage.S3Store"
+	}
+	return "unknown"
+}
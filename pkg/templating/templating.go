@@ -0,0 +1,537 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package templating expands variable and field references in workflow definitions and inputs.
+//
+// Two kinds of references are supported:
+//   - ${VAR}: a workflow config variable (WORKFLOW_CONFIG_VARIABLES); variable values may reference other variables
+//   - {{ .field }} or {{ .field.sub.0 }}: a field of the data, e.g. the values of filled inputs and outputs
+//
+// "$${" produces a literal "${" and "{{{{" produces a literal "{{".
+package templating
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// Mode controls how unresolved references are handled.
+type Mode int
+
+const (
+	// Strict returns an error for undefined or malformed references.
+	Strict Mode = iota
+	// Lenient leaves undefined or malformed references unchanged.
+	Lenient
+)
+
+// Error describes a failed expansion with its position.
+type Error struct {
+	Path      string // JSON path of the expanded value, e.g. "nodes[2].inputs.query"; empty for plain strings
+	Line      int    // 1-based line within the value
+	Column    int    // 1-based column within the line, in bytes
+	Reference string // the reference text, e.g. "${HOST}"
+	Message   string
+	cause     error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	position := fmt.Sprintf("line %d, column %d", e.Line, e.Column)
+	if e.Path != "" {
+		position = e.Path + ": " + position
+	}
+	if e.Reference != "" {
+		return fmt.Sprintf("%s: %s: %s", position, e.Reference, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", position, e.Message)
+}
+
+// Unwrap returns the cause of the error.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// ErrVariableCycle is wrapped by errors of variables referencing each other in a cycle; cycles are errors in both modes.
+var ErrVariableCycle = errors.New("variable cycle")
+
+// Engine expands references using a fixed set of variables.
+type Engine struct {
+	variables map[string]string
+	mode      Mode
+}
+
+// New creates an engine.
+//
+// Parameters:
+//   - variables: the variables available as ${NAME}
+//   - mode: Strict or Lenient
+//
+// Returns:
+//   - *Engine: the engine
+func New(variables map[string]string, mode Mode) *Engine {
+	copied := make(map[string]string, len(variables))
+	for name, value := range variables {
+		copied[name] = value
+	}
+	return &Engine{variables: copied, mode: mode}
+}
+
+// NewFromConfig creates an engine with the WORKFLOW_CONFIG_VARIABLES of the global config.
+//
+// Parameters:
+//   - mode: Strict or Lenient
+//
+// Returns:
+//   - *Engine: the engine
+func NewFromConfig(mode Mode) *Engine {
+	var variables map[string]string
+	if config.Current() != nil {
+		variables = config.Current().WORKFLOW_CONFIG_VARIABLES
+	}
+	return New(variables, mode)
+}
+
+// Expand expands all references in a string.
+//
+// Parameters:
+//   - template: the string to expand
+//   - data: the data for {{ .field }} references; may be nil
+//
+// Returns:
+//   - string: the expanded string
+//   - error: an *Error in strict mode if a reference cannot be resolved, or if variables reference each other in a cycle
+func (e *Engine) Expand(template string, data map[string]interface{}) (string, error) {
+	expander := &expander{engine: e, data: data, resolved: map[string]string{}}
+	return expander.expand(template, "", true)
+}
+
+// RenderSystemPrompt renders a system prompt, expanding the references in its segments.
+// The variables of the prompt are available as {{ .name }}; the data takes precedence over them.
+//
+// Parameters:
+//   - prompt: the system prompt
+//   - data: additional data for {{ .field }} references; may be nil
+//
+// Returns:
+//   - string: the rendered prompt
+//   - error: an error if the prompt is invalid, or an *Error in strict mode if a reference cannot be resolved
+func (e *Engine) RenderSystemPrompt(prompt sharedtypes.SystemPrompt, data map[string]interface{}) (string, error) {
+	return prompt.Render(func(content string, variables map[string]interface{}) (string, error) {
+		merged := make(map[string]interface{}, len(variables)+len(data))
+		for name, value := range variables {
+			merged[name] = value
+		}
+		for name, value := range data {
+			merged[name] = value
+		}
+		return e.Expand(content, merged)
+	})
+}
+
+// ExpandValue expands all strings inside a value of maps, slices and strings, e.g. a decoded workflow definition.
+// A string consisting of a single {{ .field }} reference is replaced by the referenced value itself, keeping its type.
+//
+// Parameters:
+//   - value: the value to expand
+//   - data: the data for {{ .field }} references; may be nil
+//
+// Returns:
+//   - interface{}: the expanded value; the input is not modified
+//   - error: an *Error in strict mode if a reference cannot be resolved
+func (e *Engine) ExpandValue(value interface{}, data map[string]interface{}) (interface{}, error) {
+	expander := &expander{engine: e, data: data, resolved: map[string]string{}}
+	return expander.expandValue(value, "")
+}
+
+// ExpandJSON expands all strings inside a JSON document, e.g. a workflow definition.
+//
+// Parameters:
+//   - document: the JSON document
+//   - data: the data for {{ .field }} references; may be nil
+//
+// Returns:
+//   - []byte: the expanded JSON document
+//   - error: an error if the document is not valid JSON or a reference cannot be resolved
+func (e *Engine) ExpandJSON(document []byte, data map[string]interface{}) ([]byte, error) {
+	var value interface{}
+	if err := json.Unmarshal(document, &value); err != nil {
+		return nil, fmt.Errorf("error decoding JSON document: %w", err)
+	}
+	expanded, err := e.ExpandValue(value, data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(expanded)
+}
+
+// ExpandInputs expands the values of filled inputs. String values and strings nested in map and slice values are
+// expanded; other values are kept.
+//
+// Parameters:
+//   - inputs: the filled inputs by name
+//   - data: the data for {{ .field }} references; may be nil
+//
+// Returns:
+//   - map[string]sharedtypes.FilledInputOutput: the expanded inputs; the input map is not modified
+//   - error: an *Error in strict mode if a reference cannot be resolved
+func (e *Engine) ExpandInputs(inputs map[string]sharedtypes.FilledInputOutput, data map[string]interface{}) (map[string]sharedtypes.FilledInputOutput, error) {
+	expander := &expander{engine: e, data: data, resolved: map[string]string{}}
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	expanded := make(map[string]sharedtypes.FilledInputOutput, len(inputs))
+	for _, name := range names {
+		input := inputs[name]
+		value, err := expander.expandValue(input.Value, name)
+		if err != nil {
+			return nil, err
+		}
+		input.Value = value
+		expanded[name] = input
+	}
+	return expanded, nil
+}
+
+// DataFromFilledInputOutputs returns the values of filled inputs or outputs by name, for use as template data.
+//
+// Parameters:
+//   - values: the filled inputs or outputs by name
+//
+// Returns:
+//   - map[string]interface{}: the values by name
+func DataFromFilledInputOutputs(values map[string]sharedtypes.FilledInputOutput) map[string]interface{} {
+	data := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		data[name] = value.Value
+	}
+	return data
+}
+
+// expander holds the state of one expansion.
+type expander struct {
+	engine   *Engine
+	data     map[string]interface{}
+	resolved map[string]string // expanded variable values
+}
+
+// expandValue expands strings inside maps and slices.
+func (x *expander) expandValue(value interface{}, path string) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if field, ok := singleFieldReference(v); ok {
+			if resolved, found := lookupField(x.data, field); found {
+				return resolved, nil
+			}
+		}
+		return x.expand(v, path, true)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		expanded := make(map[string]interface{}, len(v))
+		for _, key := range keys {
+			item, err := x.expandValue(v[key], joinPath(path, key))
+			if err != nil {
+				return nil, err
+			}
+			expanded[key] = item
+		}
+		return expanded, nil
+	case map[string]string:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		expanded := make(map[string]string, len(v))
+		for _, key := range keys {
+			item, err := x.expand(v[key], joinPath(path, key), true)
+			if err != nil {
+				return nil, err
+			}
+			expanded[key] = item
+		}
+		return expanded, nil
+	case []interface{}:
+		expanded := make([]interface{}, len(v))
+		for i, item := range v {
+			result, err := x.expandValue(item, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			expanded[i] = result
+		}
+		return expanded, nil
+	case []string:
+		expanded := make([]string, len(v))
+		for i, item := range v {
+			result, err := x.expand(item, fmt.Sprintf("%s[%d]", path, i), true)
+			if err != nil {
+				return nil, err
+			}
+			expanded[i] = result
+		}
+		return expanded, nil
+	}
+	return value, nil
+}
+
+// expand expands the references of a string; fields are only expanded if allowFields is set.
+func (x *expander) expand(template string, path string, allowFields bool) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(template); {
+		switch {
+		case strings.HasPrefix(template[i:], "$${"):
+			out.WriteString("${")
+			i += 3
+		case strings.HasPrefix(template[i:], "{{{{"):
+			out.WriteString("{{")
+			i += 4
+		case strings.HasPrefix(template[i:], "${"):
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				if err := x.fail(template, path, i, "", errors.New("unterminated variable reference")); err != nil {
+					return "", err
+				}
+				out.WriteString(template[i:])
+				return out.String(), nil
+			}
+			reference := template[i : i+end+1]
+			name := reference[2 : len(reference)-1]
+			value, err := x.variable(name, nil)
+			if err != nil {
+				if failErr := x.fail(template, path, i, reference, err); failErr != nil {
+					return "", failErr
+				}
+				value = reference
+			}
+			out.WriteString(value)
+			i += end + 1
+		case allowFields && strings.HasPrefix(template[i:], "{{"):
+			end := strings.Index(template[i:], "}}")
+			if end < 0 {
+				if err := x.fail(template, path, i, "", errors.New("unterminated field reference")); err != nil {
+					return "", err
+				}
+				out.WriteString(template[i:])
+				return out.String(), nil
+			}
+			reference := template[i : i+end+2]
+			value, err := x.field(strings.TrimSpace(reference[2 : len(reference)-2]))
+			if err != nil {
+				if failErr := x.fail(template, path, i, reference, err); failErr != nil {
+					return "", failErr
+				}
+				value = reference
+			}
+			out.WriteString(value)
+			i += end + 2
+		default:
+			out.WriteByte(template[i])
+			i++
+		}
+	}
+	return out.String(), nil
+}
+
+// variable resolves a variable and the variables referenced by its value.
+func (x *expander) variable(name string, stack []string) (string, error) {
+	if !isIdentifier(name) {
+		return "", fmt.Errorf("invalid variable name '%s'", name)
+	}
+	if value, ok := x.resolved[name]; ok {
+		return value, nil
+	}
+	for i, previous := range stack {
+		if previous == name {
+			return "", fmt.Errorf("%w %s", ErrVariableCycle, strings.Join(append(stack[i:], name), " -> "))
+		}
+	}
+	raw, ok := x.engine.variables[name]
+	if !ok {
+		return "", fmt.Errorf("undefined variable")
+	}
+
+	stack = append(stack, name)
+	var out strings.Builder
+	for i := 0; i < len(raw); {
+		if strings.HasPrefix(raw[i:], "$${") {
+			out.WriteString("${")
+			i += 3
+			continue
+		}
+		if strings.HasPrefix(raw[i:], "${") {
+			end := strings.IndexByte(raw[i:], '}')
+			if end >= 0 {
+				value, err := x.variable(raw[i+2:i+end], stack)
+				if err != nil {
+					if errors.Is(err, ErrVariableCycle) || x.engine.mode == Strict {
+						return "", fmt.Errorf("in value of variable '%s': %w", name, err)
+					}
+					value = raw[i : i+end+1]
+				}
+				out.WriteString(value)
+				i += end + 1
+				continue
+			}
+		}
+		out.WriteByte(raw[i])
+		i++
+	}
+	x.resolved[name] = out.String()
+	return x.resolved[name], nil
+}
+
+// field resolves a field reference such as ".inputs.query" to its string form.
+func (x *expander) field(reference string) (string, error) {
+	value, err := resolveField(x.data, reference)
+	if err != nil {
+		return "", err
+	}
+	return stringify(value)
+}
+
+// fail returns an *Error in strict mode and nil in lenient mode. Cycles are always errors.
+func (x *expander) fail(template string, path string, offset int, reference string, cause error) error {
+	if x.engine.mode == Lenient && !errors.Is(cause, ErrVariableCycle) {
+		return nil
+	}
+	line := 1 + strings.Count(template[:offset], "\n")
+	column := offset + 1
+	if lastNewline := strings.LastIndexByte(template[:offset], '\n'); lastNewline >= 0 {
+		column = offset - lastNewline
+	}
+	return &Error{Path: path, Line: line, Column: column, Reference: reference, Message: cause.Error(), cause: cause}
+}
+
+// singleFieldReference returns the field if the string consists of exactly one field reference.
+func singleFieldReference(s string) (string, bool) {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{{") || !strings.HasSuffix(trimmed, "}}") || strings.HasPrefix(trimmed, "{{{{") {
+		return "", false
+	}
+	inner := trimmed[2 : len(trimmed)-2]
+	if strings.Contains(inner, "{{") || strings.Contains(inner, "}}") {
+		return "", false
+	}
+	return strings.TrimSpace(inner), true
+}
+
+// lookupField resolves a field reference, reporting whether it was found.
+func lookupField(data map[string]interface{}, reference string) (interface{}, bool) {
+	value, err := resolveField(data, reference)
+	return value, err == nil
+}
+
+// resolveField resolves a field reference such as ".a.b.0" in the data.
+func resolveField(data map[string]interface{}, reference string) (interface{}, error) {
+	if !strings.HasPrefix(reference, ".") || reference == "." {
+		return nil, fmt.Errorf("field reference must start with '.' followed by a field name")
+	}
+	var current interface{} = data
+	for _, segment := range strings.Split(reference[1:], ".") {
+		if segment == "" {
+			return nil, fmt.Errorf("empty field name")
+		}
+		current = generic(current)
+		switch v := current.(type) {
+		case map[string]interface{}:
+			item, ok := v[segment]
+			if !ok {
+				return nil, fmt.Errorf("undefined field '%s'", segment)
+			}
+			current = item
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("invalid index '%s' for list of length %d", segment, len(v))
+			}
+			current = v[index]
+		default:
+			return nil, fmt.Errorf("cannot access field '%s' of %T", segment, current)
+		}
+	}
+	return current, nil
+}
+
+// generic converts typed maps, slices and structs into their generic JSON form, so fields can be accessed.
+func generic(value interface{}) interface{} {
+	switch value.(type) {
+	case nil, map[string]interface{}, []interface{}, string, bool, float64, int, int64:
+		return value
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var converted interface{}
+	if err := json.Unmarshal(data, &converted); err != nil {
+		return value
+	}
+	return converted
+}
+
+// stringify returns the string form of a value: strings as is, everything else as JSON.
+func stringify(value interface{}) (string, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("cannot convert %T to string: %w", value, err)
+	}
+	return string(data), nil
+}
+
+// isIdentifier checks that a variable name consists of letters, digits and underscores and does not start with a digit.
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		if !(c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || (i > 0 && '0' <= c && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// joinPath appends a map key to a JSON path.
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
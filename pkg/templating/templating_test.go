@@ -0,0 +1,224 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package templating
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+var testVariables = map[string]string{
+	"HOST":     "example.com",
+	"PORT":     "8080",
+	"URL":      "https://${HOST}:${PORT}",
+	"API":      "${URL}/api",
+	"LITERAL":  "$${HOST}",
+	"BROKEN":   "${MISSING}",
+	"CYCLE_A":  "${CYCLE_B}",
+	"CYCLE_B":  "x${CYCLE_A}",
+	"SELF_REF": "${SELF_REF}",
+}
+
+var testData = map[string]interface{}{
+	"query":  "how to mesh?",
+	"count":  3,
+	"nested": map[string]interface{}{"list": []interface{}{"a", "b"}},
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		mode     Mode
+		want     string
+		wantErr  bool
+	}{
+		{"plain", "no references", Strict, "no references", false},
+		{"variable", "host=${HOST}", Strict, "host=example.com", false},
+		{"nested variables", "${API}", Strict, "https://example.com:8080/api", false},
+		{"escaped variable", "$${HOST} and ${LITERAL}", Strict, "${HOST} and ${HOST}", false},
+		{"field", "q: {{ .query }}", Strict, "q: how to mesh?", false},
+		{"field without spaces", "{{.count}} items", Strict, "3 items", false},
+		{"nested field", "{{ .nested.list.1 }}", Strict, "b", false},
+		{"non-string field", "{{ .nested }}", Strict, `{"list":["a","b"]}`, false},
+		{"escaped field", "{{{{ .query }}", Strict, "{{ .query }}", false},
+		{"undefined variable strict", "${NOPE}", Strict, "", true},
+		{"undefined variable lenient", "a ${NOPE} b", Lenient, "a ${NOPE} b", false},
+		{"undefined in variable value strict", "${BROKEN}", Strict, "", true},
+		{"undefined in variable value lenient", "${BROKEN}", Lenient, "${MISSING}", false},
+		{"undefined field strict", "{{ .nope }}", Strict, "", true},
+		{"undefined field lenient", "x {{ .nope }}", Lenient, "x {{ .nope }}", false},
+		{"index out of range", "{{ .nested.list.5 }}", Strict, "", true},
+		{"field without dot", "{{ query }}", Strict, "", true},
+		{"invalid variable name", "${1ABC}", Strict, "", true},
+		{"unterminated variable strict", "${HOST", Strict, "", true},
+		{"unterminated variable lenient", "x ${HOST", Lenient, "x ${HOST", false},
+		{"unterminated field lenient", "{{ .query", Lenient, "{{ .query", false},
+		{"cycle strict", "${CYCLE_A}", Strict, "", true},
+		{"cycle lenient", "${CYCLE_A}", Lenient, "", true},
+		{"self reference", "${SELF_REF}", Lenient, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(testVariables, tt.mode).Expand(tt.template, testData)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Expand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandErrorPosition(t *testing.T) {
+	_, err := New(testVariables, Strict).Expand("first line\n  second ${NOPE}", nil)
+	var templateErr *Error
+	if !errors.As(err, &templateErr) {
+		t.Fatalf("Expand() error = %v, want *Error", err)
+	}
+	if templateErr.Line != 2 || templateErr.Column != 10 || templateErr.Reference != "${NOPE}" {
+		t.Errorf("Expand() error = %+v", templateErr)
+	}
+	if want := "line 2, column 10: ${NOPE}: undefined variable"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	_, err = New(testVariables, Lenient).Expand("${CYCLE_A}", nil)
+	if !errors.Is(err, ErrVariableCycle) {
+		t.Errorf("Expand() cycle error = %v, want ErrVariableCycle", err)
+	}
+}
+
+func TestExpandJSON(t *testing.T) {
+	document := []byte(`{"nodes":[{"inputs":{"url":"${URL}","query":"{{ .query }}","count":"{{ .count }}","n":1}},{"inputs":{"bad":"${NOPE}"}}]}`)
+
+	_, err := New(testVariables, Strict).ExpandJSON(document, testData)
+	var templateErr *Error
+	if !errors.As(err, &templateErr) || templateErr.Path != "nodes[1].inputs.bad" {
+		t.Fatalf("ExpandJSON() error = %v, want error at nodes[1].inputs.bad", err)
+	}
+
+	expanded, err := New(testVariables, Lenient).ExpandJSON(document, testData)
+	if err != nil {
+		t.Fatalf("ExpandJSON() error = %v", err)
+	}
+	var got interface{}
+	json.Unmarshal(expanded, &got)
+	var want interface{}
+	json.Unmarshal([]byte(`{"nodes":[{"inputs":{"url":"https://example.com:8080","query":"how to mesh?","count":3,"n":1}},{"inputs":{"bad":"${NOPE}"}}]}`), &want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpandJSON() = %s", expanded)
+	}
+
+	if _, err := New(nil, Strict).ExpandJSON([]byte("{"), nil); err == nil {
+		t.Error("ExpandJSON() with invalid JSON succeeded")
+	}
+}
+
+func TestExpandInputs(t *testing.T) {
+	inputs := map[string]sharedtypes.FilledInputOutput{
+		"url":     {Name: "url", GoType: "string", Value: "${URL}/search"},
+		"count":   {Name: "count", GoType: "int", Value: "{{ .count }}"},
+		"headers": {Name: "headers", GoType: "map[string]string", Value: map[string]string{"Host": "${HOST}"}},
+		"tags":    {Name: "tags", GoType: "[]string", Value: []string{"${PORT}", "plain"}},
+		"flag":    {Name: "flag", GoType: "bool", Value: true},
+	}
+	previous := DataFromFilledInputOutputs(map[string]sharedtypes.FilledInputOutput{"count": {Name: "count", GoType: "int", Value: 7}})
+
+	expanded, err := New(testVariables, Strict).ExpandInputs(inputs, previous)
+	if err != nil {
+		t.Fatalf("ExpandInputs() error = %v", err)
+	}
+	if expanded["url"].Value != "https://example.com:8080/search" {
+		t.Errorf("url = %v", expanded["url"].Value)
+	}
+	if expanded["count"].Value != 7 {
+		t.Errorf("count = %#v, want native 7", expanded["count"].Value)
+	}
+	if !reflect.DeepEqual(expanded["headers"].Value, map[string]string{"Host": "example.com"}) {
+		t.Errorf("headers = %v", expanded["headers"].Value)
+	}
+	if !reflect.DeepEqual(expanded["tags"].Value, []string{"8080", "plain"}) {
+		t.Errorf("tags = %v", expanded["tags"].Value)
+	}
+	if expanded["flag"].Value != true || expanded["flag"].GoType != "bool" {
+		t.Errorf("flag = %+v", expanded["flag"])
+	}
+	if inputs["url"].Value != "${URL}/search" {
+		t.Error("ExpandInputs() modified its input")
+	}
+
+	_, err = New(testVariables, Strict).ExpandInputs(map[string]sharedtypes.FilledInputOutput{"x": {Value: "${NOPE}"}}, nil)
+	var templateErr *Error
+	if !errors.As(err, &templateErr) || templateErr.Path != "x" {
+		t.Errorf("ExpandInputs() error = %v, want error at x", err)
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	previous := config.GlobalConfig
+	defer func() { config.GlobalConfig = previous }()
+
+	config.GlobalConfig = &config.Config{WORKFLOW_CONFIG_VARIABLES: map[string]string{"ENV": "prod"}}
+	got, err := NewFromConfig(Strict).Expand("env=${ENV}", nil)
+	if err != nil || got != "env=prod" {
+		t.Errorf("Expand() = %q, %v", got, err)
+	}
+
+	config.GlobalConfig = nil
+	if _, err := NewFromConfig(Strict).Expand("${ENV}", nil); err == nil {
+		t.Error("Expand() without config succeeded")
+	}
+}
+
+func TestRenderSystemPrompt(t *testing.T) {
+	engine := New(testVariables, Strict)
+	prompt := sharedtypes.SystemPrompt{
+		Segments: []sharedtypes.PromptSegment{
+			{Role: sharedtypes.PromptSegmentPersona, Content: "You are the assistant of {{ .product }}."},
+			{Role: sharedtypes.PromptSegmentContext, Content: "The docs are at ${URL}. The user asks: {{ .query }}"},
+		},
+		Variables: map[string]interface{}{"product": "Fluent", "query": "overridden"},
+	}
+
+	rendered, err := engine.RenderSystemPrompt(prompt, testData)
+	if err != nil {
+		t.Fatalf("RenderSystemPrompt() error = %v", err)
+	}
+	want := "You are the assistant of Fluent.\n\nThe docs are at https://example.com:8080. The user asks: how to mesh?"
+	if rendered != want {
+		t.Errorf("RenderSystemPrompt() = %q, want %q", rendered, want)
+	}
+
+	prompt.Segments = append(prompt.Segments, sharedtypes.PromptSegment{Role: sharedtypes.PromptSegmentSafety, Content: "{{ .missing }}"})
+	var expandErr *Error
+	if _, err := engine.RenderSystemPrompt(prompt, nil); !errors.As(err, &expandErr) {
+		t.Errorf("RenderSystemPrompt() with missing field error = %v, want *Error", err)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// sampleTime is the time used in generated samples
+var sampleTime = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// maxSampleDepth limits the nesting of generated samples for recursive types
+const maxSampleDepth = 4
+
+// Sample returns a value of type T with every exported field set to a deterministic non-zero value
+// Strings hold the field name, slices and maps get one element, pointers are allocated and interface{}
+// and json.RawMessage fields hold the field name as string.
+// Fields of recursive types are left zero below a fixed depth.
+//
+// Returns:
+//   - T: the sample value
+func Sample[T any]() T {
+	var value T
+	fillSample(reflect.ValueOf(&value).Elem(), "", 0)
+	return value
+}
+
+// fillSample fills a value with deterministic sample data
+//
+// Parameters:
+//   - v: the settable value to fill
+//   - name: the name of the field, used for string values
+//   - depth: the nesting depth
+func fillSample(v reflect.Value, name string, depth int) {
+	if depth > maxSampleDepth {
+		return
+	}
+	switch v.Type() {
+	case reflect.TypeOf(time.Time{}):
+		v.Set(reflect.ValueOf(sampleTime))
+		return
+	case reflect.TypeOf(json.RawMessage{}):
+		raw, _ := json.Marshal(name)
+		v.SetBytes(raw)
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if name == "" {
+			name = "value"
+		}
+		v.SetString(name)
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillSample(v.Elem(), name, depth+1)
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillSample(v.Index(0), name, depth+1)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			fillSample(v.Index(i), name, depth+1)
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		key := reflect.New(v.Type().Key()).Elem()
+		fillSample(key, "key", depth+1)
+		elem := reflect.New(v.Type().Elem()).Elem()
+		fillSample(elem, name, depth+1)
+		v.SetMapIndex(key, elem)
+	case reflect.Interface:
+		if v.NumMethod() == 0 {
+			v.Set(reflect.ValueOf(name))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || field.Tag.Get("json") == "-" {
+				continue
+			}
+			fillSample(v.Field(i), field.Name, depth+1)
+		}
+	}
+}
+
+// CompareJSONShape lists the incompatibilities of current JSON compared to golden JSON
+// Every object key of golden must be present in current with the same JSON kind; new keys are compatible.
+// Array elements are compared against the first golden element.
+//
+// Parameters:
+//   - golden: the golden JSON
+//   - current: the JSON of the current version
+//
+// Returns:
+//   - []string: the incompatibilities, empty if current is compatible
+//   - error: an error if one of the documents is not valid JSON
+func CompareJSONShape(golden []byte, current []byte) ([]string, error) {
+	var goldenValue, currentValue any
+	if err := json.Unmarshal(golden, &goldenValue); err != nil {
+		return nil, fmt.Errorf("invalid golden JSON: %w", err)
+	}
+	if err := json.Unmarshal(current, &currentValue); err != nil {
+		return nil, fmt.Errorf("invalid current JSON: %w", err)
+	}
+	var problems []string
+	compareShape("$", goldenValue, currentValue, &problems)
+	return problems, nil
+}
+
+// compareShape compares the shape of two decoded JSON values
+//
+// Parameters:
+//   - path: the JSON path of the values, for messages
+//   - golden: the golden value
+//   - current: the current value
+//   - problems: the list to append incompatibilities to
+func compareShape(path string, golden any, current any, problems *[]string) {
+	if golden == nil || current == nil {
+		// null is compatible with any kind
+		return
+	}
+	if jsonKind(golden) != jsonKind(current) {
+		*problems = append(*problems, fmt.Sprintf("%s: changed from %s to %s", path, jsonKind(golden), jsonKind(current)))
+		return
+	}
+
+	switch golden := golden.(type) {
+	case map[string]any:
+		current := current.(map[string]any)
+		keys := make([]string, 0, len(golden))
+		for key := range golden {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value, ok := current[key]
+			if !ok {
+				*problems = append(*problems, fmt.Sprintf("%s.%s: removed", path, key))
+				continue
+			}
+			compareShape(path+"."+key, golden[key], value, problems)
+		}
+	case []any:
+		current := current.([]any)
+		if len(golden) > 0 && len(current) > 0 {
+			compareShape(path+"[0]", golden[0], current[0], problems)
+		}
+	}
+}
+
+// jsonKind returns the kind of a decoded JSON value
+//
+// Parameters:
+//   - value: the decoded value
+//
+// Returns:
+//   - string: "object", "array", "string", "number" or "boolean"
+func jsonKind(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return "null"
+}
+
+// VerifyWireCompatibility checks that JSON written by another version can be exchanged with the current types
+// For each sample, the golden file "<name>.json" must decode into the type of the sample, and the JSON of
+// the sample must keep every field of the golden file with the same kind.
+//
+// Parameters:
+//   - t: the test
+//   - golden: the directory of golden files, e.g. SharedTypesGolden or os.DirFS of a copied directory
+//   - samples: the sample values by name
+func VerifyWireCompatibility(t *testing.T, golden fs.FS, samples map[string]any) {
+	t.Helper()
+	for _, name := range sortedNames(samples) {
+		sample := samples[name]
+		t.Run(name, func(t *testing.T) {
+			goldenData, err := fs.ReadFile(golden, name+".json")
+			if err != nil {
+				t.Fatalf("no golden file for %s: %v", name, err)
+			}
+
+			decoded := reflect.New(reflect.TypeOf(sample)).Interface()
+			if err := json.Unmarshal(goldenData, decoded); err != nil {
+				t.Errorf("golden JSON does not decode into %T: %v", sample, err)
+			}
+
+			current, err := json.Marshal(sample)
+			if err != nil {
+				t.Fatalf("unable to marshal %T: %v", sample, err)
+			}
+			problems, err := CompareJSONShape(goldenData, current)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, problem := range problems {
+				t.Errorf("incompatible JSON: %s", problem)
+			}
+		})
+	}
+}
+
+// WriteGoldenFiles writes the JSON of each sample to "<name>.json" in a directory
+//
+// Parameters:
+//   - dir: the directory, created if missing
+//   - samples: the sample values by name
+//
+// Returns:
+//   - error: an error if a sample cannot be marshalled or written
+func WriteGoldenFiles(dir string, samples map[string]any) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, name := range sortedNames(samples) {
+		data, err := json.MarshalIndent(samples[name], "", "  ")
+		if err != nil {
+			return fmt.Errorf("unable to marshal %s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedNames returns the names of the samples in sorted order
+func sortedNames(samples map[string]any) []string {
+	names := make([]string, 0, len(samples))
+	for name := range samples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package testutil
+
+import (
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// goldenDir is the directory of the golden files of SharedTypesGolden
+const goldenDir = "golden/sharedtypes"
+
+// nonWireTypes are the sharedtypes structs that are not exchanged as JSON
+var nonWireTypes = []string{"ChatAssembler", "CronSchedule", "EnvExpansionError", "FileAssembler", "HandlerRequestBuilder", "SlashCommandRegistry", "TransferDetails", "VersionConstraint"}
+
+func TestSharedTypesGolden(t *testing.T) {
+	if *update {
+		if err := WriteGoldenFiles(goldenDir, SharedTypesSamples()); err != nil {
+			t.Fatalf("WriteGoldenFiles() error = %v", err)
+		}
+	}
+	VerifyWireCompatibility(t, os.DirFS(goldenDir), SharedTypesSamples())
+}
+
+func TestSharedTypesSamplesComplete(t *testing.T) {
+	files, err := filepath.Glob("../sharedtypes/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	samples := SharedTypesSamples()
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(parsed, func(node ast.Node) bool {
+			spec, ok := node.(*ast.TypeSpec)
+			if !ok || !spec.Name.IsExported() {
+				return true
+			}
+			if _, isStruct := spec.Type.(*ast.StructType); !isStruct || slices.Contains(nonWireTypes, spec.Name.Name) {
+				return true
+			}
+			if _, ok := samples[spec.Name.Name]; !ok {
+				t.Errorf("sharedtypes.%s has no sample in SharedTypesSamples; add it and run the tests with -update", spec.Name.Name)
+			}
+			return true
+		})
+	}
+}
+
+func TestCompareJSONShape(t *testing.T) {
+	tests := []struct {
+		name    string
+		golden  string
+		current string
+		want    []string
+	}{
+		{name: "identical", golden: `{"a": 1, "b": "x"}`, current: `{"a": 2, "b": "y"}`},
+		{name: "added field", golden: `{"a": 1}`, current: `{"a": 1, "b": true}`},
+		{name: "null is compatible", golden: `{"a": null, "b": {"c": 1}}`, current: `{"a": "x", "b": null}`},
+		{name: "removed field", golden: `{"a": 1, "b": 2}`, current: `{"a": 1}`, want: []string{"$.b: removed"}},
+		{name: "renamed field", golden: `{"userId": "x"}`, current: `{"user_id": "x"}`, want: []string{"$.userId: removed"}},
+		{name: "changed kind", golden: `{"a": 1}`, current: `{"a": "1"}`, want: []string{"$.a: changed from number to string"}},
+		{
+			name:    "nested array element",
+			golden:  `{"items": [{"id": "x", "tags": ["a"]}]}`,
+			current: `{"items": [{"id": 1, "tags": [1]}]}`,
+			want:    []string{"$.items[0].id: changed from string to number", "$.items[0].tags[0]: changed from string to number"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompareJSONShape([]byte(tt.golden), []byte(tt.current))
+			if err != nil {
+				t.Fatalf("CompareJSONShape() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CompareJSONShape() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := CompareJSONShape([]byte(`{`), []byte(`{}`)); err == nil {
+		t.Error("CompareJSONShape() with invalid golden JSON succeeded")
+	}
+}
+
+func TestSample(t *testing.T) {
+	call := Sample[sharedtypes.ToolCall]()
+	value := reflect.ValueOf(call)
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).IsExported() && value.Field(i).IsZero() {
+			t.Errorf("ToolCall.%s is not set in the sample", value.Type().Field(i).Name)
+		}
+	}
+
+	response := Sample[sharedtypes.HandlerResponse]()
+	if response.IsLast == nil || !*response.IsLast || response.ChatData == nil || *response.ChatData != "ChatData" {
+		t.Errorf("Sample[HandlerResponse]() = %+v", response)
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package testutil
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FakeFlowkitVersion is the version reported by FakeFlowkitServer
+const FakeFlowkitVersion = "fake"
+
+// FunctionHandler runs a function of the fake FlowKit server
+// Inputs and outputs are keyed by name and hold the string values sent over gRPC.
+type FunctionHandler func(ctx context.Context, inputs map[string]string) (outputs map[string]string, err error)
+
+// StreamHandler runs a streaming function of the fake FlowKit server and returns the chunks to stream
+type StreamHandler func(ctx context.Context, inputs map[string]string) (chunks []string, err error)
+
+// FunctionCall is a function call received by the fake FlowKit server
+type FunctionCall struct {
+	Name   string
+	Inputs map[string]string
+}
+
+// FakeFlowkitServer is an in-process FlowKit ExternalFunctions gRPC server running registered Go handlers
+type FakeFlowkitServer struct {
+	aaliflowkitgrpc.UnimplementedExternalFunctionsServer
+
+	mu         sync.Mutex
+	functions  map[string]*aaliflowkitgrpc.FunctionDefinition
+	handlers   map[string]FunctionHandler
+	streams    map[string]StreamHandler
+	calls      []FunctionCall
+	interrupts []string
+}
+
+// NewFakeFlowkitServer creates a fake FlowKit server without functions
+//
+// Returns:
+//   - *FakeFlowkitServer: the fake server
+func NewFakeFlowkitServer() *FakeFlowkitServer {
+	return &FakeFlowkitServer{
+		functions: map[string]*aaliflowkitgrpc.FunctionDefinition{},
+		handlers:  map[string]FunctionHandler{},
+		streams:   map[string]StreamHandler{},
+	}
+}
+
+// AddFunction registers a function, listed by ListFunctions and run by RunFunction
+//
+// Parameters:
+//   - definition: the function definition
+//   - handler: the handler running the function
+func (s *FakeFlowkitServer) AddFunction(definition *aaliflowkitgrpc.FunctionDefinition, handler FunctionHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.functions[definition.Name] = definition
+	s.handlers[definition.Name] = handler
+}
+
+// AddStreamFunction registers a function, listed by ListFunctions and run by StreamFunction
+//
+// Parameters:
+//   - definition: the function definition
+//   - handler: the handler returning the chunks of the stream
+func (s *FakeFlowkitServer) AddStreamFunction(definition *aaliflowkitgrpc.FunctionDefinition, handler StreamHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.functions[definition.Name] = definition
+	s.streams[definition.Name] = handler
+}
+
+// Calls returns the function calls received so far, in order
+//
+// Returns:
+//   - []FunctionCall: the calls
+func (s *FakeFlowkitServer) Calls() []FunctionCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]FunctionCall(nil), s.calls...)
+}
+
+// Interrupts returns the interrupt messages received on streams so far
+//
+// Returns:
+//   - []string: the interrupts
+func (s *FakeFlowkitServer) Interrupts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.interrupts...)
+}
+
+// Start starts the server on a random local port and stops it at the end of the test
+//
+// Parameters:
+//   - t: the test
+//
+// Returns:
+//   - url: the URL of the server, as used in FLOWKIT_CONNECTIONS
+func (s *FakeFlowkitServer) Start(t testing.TB) (url string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen for fake FlowKit server: %v", err)
+	}
+	server := grpc.NewServer()
+	aaliflowkitgrpc.RegisterExternalFunctionsServer(server, s)
+	go server.Serve(listener) //nolint:errcheck
+	t.Cleanup(server.Stop)
+	return "http://" + listener.Addr().String()
+}
+
+// HealthCheck reports the server as healthy
+func (s *FakeFlowkitServer) HealthCheck(ctx context.Context, req *aaliflowkitgrpc.HealthRequest) (*aaliflowkitgrpc.HealthResponse, error) {
+	return &aaliflowkitgrpc.HealthResponse{Status: "OK", Version: FakeFlowkitVersion, ProtocolVersion: sharedtypes.ProtocolVersion}, nil
+}
+
+// GetVersion returns FakeFlowkitVersion and the protocol version of this module
+func (s *FakeFlowkitServer) GetVersion(ctx context.Context, req *aaliflowkitgrpc.VersionRequest) (*aaliflowkitgrpc.VersionResponse, error) {
+	return &aaliflowkitgrpc.VersionResponse{Version: FakeFlowkitVersion, ProtocolVersion: sharedtypes.ProtocolVersion}, nil
+}
+
+// ListFunctions returns the registered functions
+func (s *FakeFlowkitServer) ListFunctions(ctx context.Context, req *aaliflowkitgrpc.ListFunctionsRequest) (*aaliflowkitgrpc.ListFunctionsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	functions := make(map[string]*aaliflowkitgrpc.FunctionDefinition, len(s.functions))
+	for name, definition := range s.functions {
+		functions[name] = definition
+	}
+	return &aaliflowkitgrpc.ListFunctionsResponse{Functions: functions}, nil
+}
+
+// RunFunction runs the handler of a registered function
+func (s *FakeFlowkitServer) RunFunction(ctx context.Context, req *aaliflowkitgrpc.FunctionInputs) (*aaliflowkitgrpc.FunctionOutputs, error) {
+	inputs := s.recordCall(req.Name, req.Inputs)
+
+	s.mu.Lock()
+	definition, handler := s.functions[req.Name], s.handlers[req.Name]
+	s.mu.Unlock()
+	if handler == nil {
+		return nil, status.Errorf(codes.NotFound, "function %s not found", req.Name)
+	}
+
+	outputs, err := handler(ctx, inputs)
+	if err != nil {
+		return nil, err
+	}
+	response := &aaliflowkitgrpc.FunctionOutputs{Name: req.Name}
+	for _, output := range definition.Output {
+		if value, ok := outputs[output.Name]; ok {
+			response.Outputs = append(response.Outputs, &aaliflowkitgrpc.FunctionOutput{Name: output.Name, GoType: output.GoType, Value: value})
+		}
+	}
+	return response, nil
+}
+
+// StreamFunction streams the chunks returned by the handler of a registered streaming function
+func (s *FakeFlowkitServer) StreamFunction(stream grpc.BidiStreamingServer[aaliflowkitgrpc.StreamInput, aaliflowkitgrpc.StreamOutput]) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	inputs := s.recordCall(first.Name, first.Inputs)
+
+	s.mu.Lock()
+	handler := s.streams[first.Name]
+	s.mu.Unlock()
+	if handler == nil {
+		return status.Errorf(codes.NotFound, "streaming function %s not found", first.Name)
+	}
+
+	// record interrupts sent by the client while streaming
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			if msg.Interrupt != "" {
+				s.mu.Lock()
+				s.interrupts = append(s.interrupts, msg.Interrupt)
+				s.mu.Unlock()
+			}
+		}
+	}()
+
+	chunks, err := handler(stream.Context(), inputs)
+	if err != nil {
+		return err
+	}
+	for i, chunk := range chunks {
+		err := stream.Send(&aaliflowkitgrpc.StreamOutput{MessageCounter: int32(i + 1), IsLast: i == len(chunks)-1, Value: chunk})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordCall records a function call and returns its inputs by name
+//
+// Parameters:
+//   - name: the function name
+//   - grpcInputs: the gRPC inputs
+//
+// Returns:
+//   - map[string]string: the input values by name
+func (s *FakeFlowkitServer) recordCall(name string, grpcInputs []*aaliflowkitgrpc.FunctionInput) map[string]string {
+	inputs := make(map[string]string, len(grpcInputs))
+	for _, input := range grpcInputs {
+		inputs[input.Name] = input.Value
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recorded := make(map[string]string, len(inputs))
+	for name, value := range inputs {
+		recorded[name] = value
+	}
+	s.calls = append(s.calls, FunctionCall{Name: name, Inputs: recorded})
+	return inputs
+}
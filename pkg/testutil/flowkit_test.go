@@ -0,0 +1,147 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package testutil
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aaliflowkitgrpc"
+	"github.com/ansys/aali-sharedtypes/pkg/clients/flowkitclient"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// upperDefinition is the definition of a function returning its "text" input in upper case
+var upperDefinition = &aaliflowkitgrpc.FunctionDefinition{
+	Name:   "upper",
+	Input:  []*aaliflowkitgrpc.FunctionInputDefinition{{Name: "text", Type: "string", GoType: "string"}},
+	Output: []*aaliflowkitgrpc.FunctionOutputDefinition{{Name: "text", Type: "string", GoType: "string"}},
+}
+
+func upper(ctx context.Context, inputs map[string]string) (map[string]string, error) {
+	return map[string]string{"text": strings.ToUpper(inputs["text"])}, nil
+}
+
+// startWithFlowkitClient starts the fake server and loads its functions into the flowkit client
+func startWithFlowkitClient(t *testing.T, server *FakeFlowkitServer) {
+	t.Helper()
+	logging.InitForTest()
+	url := server.Start(t)
+
+	previous := flowkitclient.AvailableFunctions
+	t.Cleanup(func() { flowkitclient.AvailableFunctions = previous })
+	flowkitclient.AvailableFunctions = map[string]*sharedtypes.FunctionDefinition{}
+	if err := flowkitclient.ListFunctionsAndSaveToInteralStates(url, ""); err != nil {
+		t.Fatalf("ListFunctionsAndSaveToInteralStates() error = %v", err)
+	}
+}
+
+func TestFakeFlowkitServerRunFunction(t *testing.T) {
+	server := NewFakeFlowkitServer()
+	server.AddFunction(upperDefinition, upper)
+	startWithFlowkitClient(t, server)
+
+	if _, ok := flowkitclient.AvailableFunctions["upper"]; !ok {
+		t.Fatal("function upper not listed")
+	}
+	outputs, err := flowkitclient.RunFunction(&logging.ContextMap{}, "upper", map[string]sharedtypes.FilledInputOutput{
+		"text": {Name: "text", GoType: "string", Value: "hello"},
+	})
+	if err != nil {
+		t.Fatalf("RunFunction() error = %v", err)
+	}
+	if got := outputs["text"].Value; got != "HELLO" {
+		t.Errorf("output = %v, want HELLO", got)
+	}
+
+	calls := server.Calls()
+	if len(calls) != 1 || calls[0].Name != "upper" || calls[0].Inputs["text"] != "hello" {
+		t.Errorf("Calls() = %+v", calls)
+	}
+}
+
+func TestFakeFlowkitServerErrors(t *testing.T) {
+	server := NewFakeFlowkitServer()
+	failing := &aaliflowkitgrpc.FunctionDefinition{Name: "failing"}
+	server.AddFunction(failing, func(ctx context.Context, inputs map[string]string) (map[string]string, error) {
+		return nil, status.Error(codes.Unavailable, "backend down")
+	})
+
+	_, err := server.RunFunction(context.Background(), &aaliflowkitgrpc.FunctionInputs{Name: "failing"})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("RunFunction(failing) error = %v, want Unavailable", err)
+	}
+	_, err = server.RunFunction(context.Background(), &aaliflowkitgrpc.FunctionInputs{Name: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("RunFunction(missing) error = %v, want NotFound", err)
+	}
+}
+
+func TestFakeFlowkitServerStreamFunction(t *testing.T) {
+	server := NewFakeFlowkitServer()
+	server.AddStreamFunction(&aaliflowkitgrpc.FunctionDefinition{
+		Name:  "words",
+		Input: []*aaliflowkitgrpc.FunctionInputDefinition{{Name: "text", Type: "string", GoType: "string"}},
+	}, func(ctx context.Context, inputs map[string]string) ([]string, error) {
+		if inputs["text"] == "" {
+			return nil, errors.New("empty text")
+		}
+		return strings.Fields(inputs["text"]), nil
+	})
+	startWithFlowkitClient(t, server)
+
+	channel, _, err := flowkitclient.StreamFunction(&logging.ContextMap{}, "words", map[string]sharedtypes.FilledInputOutput{
+		"text": {Name: "text", GoType: "string", Value: "one two three"},
+	})
+	if err != nil {
+		t.Fatalf("StreamFunction() error = %v", err)
+	}
+	var chunks []string
+	for chunk := range *channel {
+		chunks = append(chunks, chunk)
+	}
+	if strings.Join(chunks, " ") != "one two three" {
+		t.Errorf("chunks = %q, want one two three", chunks)
+	}
+}
+
+func TestFakeFlowkitServerVersion(t *testing.T) {
+	logging.InitForTest()
+	url := NewFakeFlowkitServer().Start(t)
+
+	version, err := flowkitclient.GetServiceVersion(url, "")
+	if err != nil {
+		t.Fatalf("GetServiceVersion() error = %v", err)
+	}
+	if version.Version != FakeFlowkitVersion || version.ProtocolVersion != sharedtypes.ProtocolVersion {
+		t.Errorf("GetServiceVersion() = %+v", version)
+	}
+	if ok, err := version.ProtocolCompatible(); !ok || err != nil {
+		t.Errorf("ProtocolCompatible() = %v, %v, want true", ok, err)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package testutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aali_graphdb"
+)
+
+// FakeGraphDbVersion is the aali-graphdb version reported by FakeGraphDb
+const FakeGraphDbVersion = "v1.2.14"
+
+// CypherQuery is a cypher query received by the fake GraphDB
+type CypherQuery struct {
+	Database   string
+	Cypher     string
+	Write      bool
+	Parameters json.RawMessage
+}
+
+// FakeGraphDb is an in-process aali-graphdb server answering cypher queries with canned results
+// Databases are kept in memory; queries without a registered result return no rows.
+type FakeGraphDb struct {
+	mu        sync.Mutex
+	databases map[string]string // schema by database name
+	results   map[string][]map[string]any
+	queries   []CypherQuery
+}
+
+// NewFakeGraphDb creates a fake GraphDB without databases
+//
+// Returns:
+//   - *FakeGraphDb: the fake GraphDB
+func NewFakeGraphDb() *FakeGraphDb {
+	return &FakeGraphDb{
+		databases: map[string]string{},
+		results:   map[string][]map[string]any{},
+	}
+}
+
+// AddDatabase creates a database with a schema returned by GetSchema
+//
+// Parameters:
+//   - name: the database name
+//   - schema: the schema of the database
+func (g *FakeGraphDb) AddDatabase(name string, schema string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.databases[name] = schema
+}
+
+// SetResult sets the rows returned for a cypher query, on any database
+//
+// Parameters:
+//   - cypher: the cypher query, matched exactly
+//   - rows: the rows of the result
+func (g *FakeGraphDb) SetResult(cypher string, rows []map[string]any) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.results[cypher] = rows
+}
+
+// Queries returns the cypher queries received so far, in order
+//
+// Returns:
+//   - []CypherQuery: the queries
+func (g *FakeGraphDb) Queries() []CypherQuery {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]CypherQuery(nil), g.queries...)
+}
+
+// Start starts the server on a random local port and stops it at the end of the test
+//
+// Parameters:
+//   - t: the test
+//
+// Returns:
+//   - *aali_graphdb.Client: a client connected to the fake GraphDB
+func (g *FakeGraphDb) Start(t testing.TB) *aali_graphdb.Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", g.health)
+	mux.HandleFunc("GET /databases", g.listDatabases)
+	mux.HandleFunc("POST /databases", g.createDatabaseLegacy)
+	mux.HandleFunc("PUT /databases/{name}", g.createDatabase)
+	mux.HandleFunc("DELETE /databases/{name}", g.deleteDatabase)
+	mux.HandleFunc("GET /databases/{name}/schema", g.schema)
+	mux.HandleFunc("POST /databases/{name}/read", g.query(false))
+	mux.HandleFunc("POST /databases/{name}/write", g.query(true))
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := aali_graphdb.NewClient(server.URL, "", server.Client())
+	if err != nil {
+		t.Fatalf("unable to create client for fake GraphDB: %v", err)
+	}
+	return client
+}
+
+func (g *FakeGraphDb) health(w http.ResponseWriter, r *http.Request) {
+	writeJson(w, map[string]any{"status": "OK", "version": FakeGraphDbVersion})
+}
+
+func (g *FakeGraphDb) listDatabases(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	names := make([]string, 0, len(g.databases))
+	for name := range g.databases {
+		names = append(names, name)
+	}
+	g.mu.Unlock()
+	writeJson(w, map[string]any{"databases": names})
+}
+
+func (g *FakeGraphDb) createDatabaseLegacy(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Name == "" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	g.AddDatabase(body.Name, "")
+}
+
+func (g *FakeGraphDb) createDatabase(w http.ResponseWriter, r *http.Request) {
+	g.AddDatabase(r.PathValue("name"), "")
+}
+
+func (g *FakeGraphDb) deleteDatabase(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.databases[r.PathValue("name")]; !ok {
+		http.Error(w, "database not found", http.StatusNotFound)
+		return
+	}
+	delete(g.databases, r.PathValue("name"))
+}
+
+func (g *FakeGraphDb) schema(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	schema, ok := g.databases[r.PathValue("name")]
+	g.mu.Unlock()
+	if !ok {
+		http.Error(w, "database not found", http.StatusNotFound)
+		return
+	}
+	w.Write([]byte(schema)) //nolint:errcheck
+}
+
+// query returns the handler of read or write cypher queries
+func (g *FakeGraphDb) query(write bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Cypher     string          `json:"cypher"`
+			Parameters json.RawMessage `json:"parameters"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+
+		g.mu.Lock()
+		_, ok := g.databases[r.PathValue("name")]
+		if ok {
+			g.queries = append(g.queries, CypherQuery{Database: r.PathValue("name"), Cypher: body.Cypher, Write: write, Parameters: body.Parameters})
+		}
+		rows := g.results[body.Cypher]
+		g.mu.Unlock()
+		if !ok {
+			http.Error(w, "database not found", http.StatusNotFound)
+			return
+		}
+
+		if rows == nil {
+			rows = []map[string]any{}
+		}
+		writeJson(w, map[string]any{"result": rows})
+	}
+}
+
+// writeJson writes a JSON response
+func writeJson(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body) //nolint:errcheck
+}
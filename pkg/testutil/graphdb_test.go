@@ -0,0 +1,91 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package testutil
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/aali_graphdb"
+)
+
+func TestFakeGraphDbDatabases(t *testing.T) {
+	graphDb := NewFakeGraphDb()
+	graphDb.AddDatabase("existing", "CREATE NODE TABLE Document(id STRING, PRIMARY KEY(id));")
+	client := graphDb.Start(t)
+
+	if healthy, err := client.GetHealth(); err != nil || !healthy {
+		t.Fatalf("GetHealth() = %v, %v", healthy, err)
+	}
+	if err := client.CreateDatabase("created"); err != nil {
+		t.Fatalf("CreateDatabase() error = %v", err)
+	}
+	databases, err := client.GetDatabases()
+	slices.Sort(databases)
+	if err != nil || !slices.Equal(databases, []string{"created", "existing"}) {
+		t.Errorf("GetDatabases() = %v, %v", databases, err)
+	}
+	if schema, err := client.GetSchema("existing"); err != nil || schema == "" {
+		t.Errorf("GetSchema() = %q, %v", schema, err)
+	}
+	if err := client.DeleteDatabase("created"); err != nil {
+		t.Errorf("DeleteDatabase() error = %v", err)
+	}
+	if err := client.DeleteDatabase("created"); err == nil {
+		t.Error("DeleteDatabase() of deleted database succeeded")
+	}
+}
+
+func TestFakeGraphDbQueries(t *testing.T) {
+	graphDb := NewFakeGraphDb()
+	graphDb.AddDatabase("db", "")
+	graphDb.SetResult("MATCH (d:Document) RETURN d.id AS id", []map[string]any{{"id": "a"}, {"id": "b"}})
+	client := graphDb.Start(t)
+
+	rows, err := client.CypherQueryRead("db", "MATCH (d:Document) RETURN d.id AS id", nil)
+	if err != nil {
+		t.Fatalf("CypherQueryRead() error = %v", err)
+	}
+	if len(rows) != 2 || rows[0]["id"] != "a" || rows[1]["id"] != "b" {
+		t.Errorf("CypherQueryRead() = %v", rows)
+	}
+
+	parameters := aali_graphdb.ParameterMap{"id": aali_graphdb.StringValue("c")}
+	rows, err = client.CypherQueryWrite("db", "CREATE (:Document {id: $id})", parameters)
+	if err != nil || len(rows) != 0 {
+		t.Errorf("CypherQueryWrite() = %v, %v, want no rows", rows, err)
+	}
+	if _, err := client.CypherQueryRead("missing", "RETURN 1", nil); err == nil {
+		t.Error("CypherQueryRead() on missing database succeeded")
+	}
+
+	queries := graphDb.Queries()
+	if len(queries) != 2 || queries[0].Write || !queries[1].Write || queries[1].Database != "db" {
+		t.Fatalf("Queries() = %+v", queries)
+	}
+	var sent map[string]any
+	if err := json.Unmarshal(queries[1].Parameters, &sent); err != nil || sent["id"] == nil {
+		t.Errorf("Parameters = %s, %v", queries[1].Parameters, err)
+	}
+}
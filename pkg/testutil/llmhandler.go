@@ -0,0 +1,172 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package testutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+// LlmResponder returns the responses of the fake LLM handler to a request
+type LlmResponder func(request sharedtypes.HandlerRequest) []sharedtypes.HandlerResponse
+
+// FakeLlmHandler is an in-process LLM handler websocket server answering requests with canned HandlerResponses
+type FakeLlmHandler struct {
+	respond LlmResponder
+
+	mu       sync.Mutex
+	requests []sharedtypes.HandlerRequest
+}
+
+// NewFakeLlmHandler creates a fake LLM handler answering every request with the same responses
+// The InstructionGuid of each response is set to the one of the request.
+//
+// Parameters:
+//   - responses: the canned responses
+//
+// Returns:
+//   - *FakeLlmHandler: the fake LLM handler
+func NewFakeLlmHandler(responses ...sharedtypes.HandlerResponse) *FakeLlmHandler {
+	return NewFakeLlmHandlerFunc(func(request sharedtypes.HandlerRequest) []sharedtypes.HandlerResponse {
+		return responses
+	})
+}
+
+// NewFakeLlmHandlerFunc creates a fake LLM handler answering requests with the responses of a responder
+// The InstructionGuid of each response is set to the one of the request.
+//
+// Parameters:
+//   - respond: the responder
+//
+// Returns:
+//   - *FakeLlmHandler: the fake LLM handler
+func NewFakeLlmHandlerFunc(respond LlmResponder) *FakeLlmHandler {
+	return &FakeLlmHandler{respond: respond}
+}
+
+// Requests returns the requests received so far, in order
+//
+// Returns:
+//   - []sharedtypes.HandlerRequest: the requests
+func (h *FakeLlmHandler) Requests() []sharedtypes.HandlerRequest {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]sharedtypes.HandlerRequest(nil), h.requests...)
+}
+
+// Start starts the server on a random local port and stops it at the end of the test
+//
+// Parameters:
+//   - t: the test
+//
+// Returns:
+//   - url: the websocket URL of the server, as used in LLM_HANDLER_ENDPOINT
+func (h *FakeLlmHandler) Start(t testing.TB) (url string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(h.serveWebsocket))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// serveWebsocket answers the requests of a websocket connection until it is closed
+func (h *FakeLlmHandler) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.CloseNow() //nolint:errcheck
+	conn.SetReadLimit(-1)
+
+	ctx := context.Background()
+	for {
+		var request sharedtypes.HandlerRequest
+		if err := wsjson.Read(ctx, conn, &request); err != nil {
+			return
+		}
+
+		h.mu.Lock()
+		h.requests = append(h.requests, request)
+		h.mu.Unlock()
+
+		for _, response := range h.respond(request) {
+			response.InstructionGuid = request.InstructionGuid
+			if err := wsjson.Write(ctx, conn, response); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ChatResponses builds the chat responses streaming the given chunks, the last one marked with IsLast
+//
+// Parameters:
+//   - chunks: the chat data of each response
+//
+// Returns:
+//   - []sharedtypes.HandlerResponse: the responses
+func ChatResponses(chunks ...string) []sharedtypes.HandlerResponse {
+	responses := make([]sharedtypes.HandlerResponse, len(chunks))
+	for i, chunk := range chunks {
+		isLast := i == len(chunks)-1
+		position := uint32(i)
+		chatData := chunk
+		responses[i] = sharedtypes.HandlerResponse{
+			Type:     "chat",
+			IsLast:   &isLast,
+			Position: &position,
+			ChatData: &chatData,
+		}
+	}
+	return responses
+}
+
+// EmbeddingsResponse builds an embeddings response with a dense vector
+//
+// Parameters:
+//   - vector: the embedded data
+//
+// Returns:
+//   - sharedtypes.HandlerResponse: the response
+func EmbeddingsResponse(vector []float32) sharedtypes.HandlerResponse {
+	return sharedtypes.HandlerResponse{Type: "embeddings", EmbeddedData: vector}
+}
+
+// ErrorResponse builds an error response
+//
+// Parameters:
+//   - code: the error code
+//   - message: the error message
+//
+// Returns:
+//   - sharedtypes.HandlerResponse: the response
+func ErrorResponse(code int, message string) sharedtypes.HandlerResponse {
+	return sharedtypes.HandlerResponse{Type: "error", Error: &sharedtypes.ErrorResponse{Code: code, Message: message}}
+}
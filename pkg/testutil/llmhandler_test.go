@@ -0,0 +1,100 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package testutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+// exchange sends a request to the fake LLM handler and reads the given number of responses
+func exchange(t *testing.T, url string, request sharedtypes.HandlerRequest, count int) []sharedtypes.HandlerResponse {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, url, nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	if err := wsjson.Write(ctx, conn, request); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	responses := make([]sharedtypes.HandlerResponse, count)
+	for i := range responses {
+		if err := wsjson.Read(ctx, conn, &responses[i]); err != nil {
+			t.Fatalf("Read() of response %d error = %v", i, err)
+		}
+	}
+	return responses
+}
+
+func TestFakeLlmHandlerChat(t *testing.T) {
+	handler := NewFakeLlmHandler(ChatResponses("Hello", " world")...)
+	url := handler.Start(t)
+
+	request := sharedtypes.HandlerRequest{Adapter: "chat", InstructionGuid: "guid-1", Data: "hi", DataStream: true}
+	responses := exchange(t, url, request, 2)
+
+	for i, want := range []string{"Hello", " world"} {
+		response := responses[i]
+		if response.Type != "chat" || response.InstructionGuid != "guid-1" || *response.ChatData != want || *response.Position != uint32(i) {
+			t.Errorf("response %d = %+v", i, response)
+		}
+		if *response.IsLast != (i == 1) {
+			t.Errorf("response %d IsLast = %v", i, *response.IsLast)
+		}
+	}
+
+	requests := handler.Requests()
+	if len(requests) != 1 || requests[0].Data != "hi" {
+		t.Errorf("Requests() = %+v", requests)
+	}
+}
+
+func TestFakeLlmHandlerFunc(t *testing.T) {
+	handler := NewFakeLlmHandlerFunc(func(request sharedtypes.HandlerRequest) []sharedtypes.HandlerResponse {
+		if request.Adapter == "embeddings" {
+			return []sharedtypes.HandlerResponse{EmbeddingsResponse([]float32{0.5, 1})}
+		}
+		return []sharedtypes.HandlerResponse{ErrorResponse(400, "unsupported adapter")}
+	})
+	url := handler.Start(t)
+
+	response := exchange(t, url, sharedtypes.HandlerRequest{Adapter: "embeddings", InstructionGuid: "guid-2"}, 1)[0]
+	if embedded, ok := response.EmbeddedData.([]any); response.Type != "embeddings" || !ok || len(embedded) != 2 {
+		t.Errorf("embeddings response = %+v", response)
+	}
+
+	response = exchange(t, url, sharedtypes.HandlerRequest{Adapter: "unknown", InstructionGuid: "guid-3"}, 1)[0]
+	if response.Type != "error" || response.Error.Code != 400 || response.InstructionGuid != "guid-3" {
+		t.Errorf("error response = %+v", response)
+	}
+}
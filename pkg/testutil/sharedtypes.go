@@ -0,0 +1,209 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package testutil
+
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+//go:embed golden/sharedtypes/*.json
+var sharedTypesGolden embed.FS
+
+// SharedTypesGolden holds the golden JSON of the samples of SharedTypesSamples, one "<name>.json" file per type
+// Consumers can pass it to VerifyWireCompatibility to check that their copy of the types is compatible
+// with this version, or copy it to check future versions against it.
+var SharedTypesGolden, _ = fs.Sub(sharedTypesGolden, "golden/sharedtypes")
+
+// SharedTypesSamples returns a sample of every sharedtypes struct exchanged as JSON, keyed by type name
+// Types that are not exchanged between services (ChatAssembler, FileAssembler, HandlerRequestBuilder,
+// SlashCommandRegistry, TransferDetails and VersionConstraint) are not included.
+//
+// Returns:
+//   - map[string]any: the samples
+func SharedTypesSamples() map[string]any {
+	return map[string]any{
+		"ACSSearchResponse":              Sample[sharedtypes.ACSSearchResponse](),
+		"APIKey":                         Sample[sharedtypes.APIKey](),
+		"AedtApiDbResponse":              Sample[sharedtypes.AedtApiDbResponse](),
+		"AedtCodeGenerationElement":      Sample[sharedtypes.AedtCodeGenerationElement](),
+		"AedtCodeGenerationExample":      Sample[sharedtypes.AedtCodeGenerationExample](),
+		"AedtElementContextsTuple":       Sample[sharedtypes.AedtElementContextsTuple](),
+		"AnsysGPTCitation":               Sample[sharedtypes.AnsysGPTCitation](),
+		"AnsysGPTDefaultFields":          Sample[sharedtypes.AnsysGPTDefaultFields](),
+		"AnsysGPTRetrieverModuleChunk":   Sample[sharedtypes.AnsysGPTRetrieverModuleChunk](),
+		"ApiError":                       Sample[sharedtypes.ApiError](),
+		"ApiErrorResponse":               Sample[sharedtypes.ApiErrorResponse](),
+		"Artifact":                       Sample[sharedtypes.Artifact](),
+		"ChunkingConfig":                 Sample[sharedtypes.ChunkingConfig](),
+		"Citation":                       Sample[sharedtypes.Citation](),
+		"CodeGenerationElement":          Sample[sharedtypes.CodeGenerationElement](),
+		"CodeGenerationExample":          Sample[sharedtypes.CodeGenerationExample](),
+		"CodeGenerationUserGuideSection": Sample[sharedtypes.CodeGenerationUserGuideSection](),
+		"ColbertEmbedding":               Sample[sharedtypes.ColbertEmbedding](),
+		"ContentPart":                    Sample[sharedtypes.ContentPart](),
+		"ConversationDocument":           Sample[sharedtypes.ConversationDocument](),
+		"ConversationHistoryMessage":     Sample[sharedtypes.ConversationHistoryMessage](),
+		"CronTrigger":                    Sample[sharedtypes.CronTrigger](),
+		"DBListCollectionsOutput":        Sample[sharedtypes.DBListCollectionsOutput](),
+		"DataExtractionDocumentData":     Sample[sharedtypes.DataExtractionDocumentData](),
+		"DbAddDataInput":                 Sample[sharedtypes.DbAddDataInput](),
+		"DbAddDataOutput":                Sample[sharedtypes.DbAddDataOutput](),
+		"DbArrayFilter":                  Sample[sharedtypes.DbArrayFilter](),
+		"DbCreateCollectionInput":        Sample[sharedtypes.DbCreateCollectionInput](),
+		"DbCreateCollectionOutput":       Sample[sharedtypes.DbCreateCollectionOutput](),
+		"DbData":                         Sample[sharedtypes.DbData](),
+		"DbFilters":                      Sample[sharedtypes.DbFilters](),
+		"DbJsonFilter":                   Sample[sharedtypes.DbJsonFilter](),
+		"DbRangeFilter":                  Sample[sharedtypes.DbRangeFilter](),
+		"DbResponse":                     Sample[sharedtypes.DbResponse](),
+		"DenseEmbedding":                 Sample[sharedtypes.DenseEmbedding](),
+		"DiscoveryAttachment":            Sample[sharedtypes.DiscoveryAttachment](),
+		"DiscoveryBoundaryCondition":     Sample[sharedtypes.DiscoveryBoundaryCondition](),
+		"DiscoveryDimensions":            Sample[sharedtypes.DiscoveryDimensions](),
+		"DiscoveryMaterial":              Sample[sharedtypes.DiscoveryMaterial](),
+		"DiscoveryMonitors":              Sample[sharedtypes.DiscoveryMonitors](),
+		"DiscoverySimulationInput":       Sample[sharedtypes.DiscoverySimulationInput](),
+		"EmbeddingOptions":               Sample[sharedtypes.EmbeddingOptions](),
+		"EmbeddingRequest":               Sample[sharedtypes.EmbeddingRequest](),
+		"EmbeddingResponse":              Sample[sharedtypes.EmbeddingResponse](),
+		"EmbeddingResult":                Sample[sharedtypes.EmbeddingResult](),
+		"ErrorResponse":                  Sample[sharedtypes.ErrorResponse](),
+		"ExecRequest":                    Sample[sharedtypes.ExecRequest](),
+		"ExecResponse":                   Sample[sharedtypes.ExecResponse](),
+		"ExecutionArtifact":              Sample[sharedtypes.ExecutionArtifact](),
+		"ExecutionDetails":               Sample[sharedtypes.ExecutionDetails](),
+		"ExecutionFile":                  Sample[sharedtypes.ExecutionFile](),
+		"ExecutionInstruction":           Sample[sharedtypes.ExecutionInstruction](),
+		"ExecutionOutputChunk":           Sample[sharedtypes.ExecutionOutputChunk](),
+		"ExecutionResourceLimits":        Sample[sharedtypes.ExecutionResourceLimits](),
+		"ExecutionResult":                Sample[sharedtypes.ExecutionResult](),
+		"FallbackPolicy":                 Sample[sharedtypes.FallbackPolicy](),
+		"Feedback":                       Sample[sharedtypes.Feedback](),
+		"FeedbackDocument":               Sample[sharedtypes.FeedbackDocument](),
+		"FileDetails":                    Sample[sharedtypes.FileDetails](),
+		"FileTransfer":                   Sample[sharedtypes.FileTransfer](),
+		"FileWatchTrigger":               Sample[sharedtypes.FileWatchTrigger](),
+		"FilledInputOutput":              Sample[sharedtypes.FilledInputOutput](),
+		"FlowKitPythonFunction":          Sample[sharedtypes.FlowKitPythonFunction](),
+		"FunctionDefinition":             Sample[sharedtypes.FunctionDefinition](),
+		"FunctionDefinitionShort":        Sample[sharedtypes.FunctionDefinitionShort](),
+		"FunctionInput":                  Sample[sharedtypes.FunctionInput](),
+		"FunctionOutput":                 Sample[sharedtypes.FunctionOutput](),
+		"GeneralNeo4jQueryInput":         Sample[sharedtypes.GeneralNeo4jQueryInput](),
+		"GeneralNeo4jQueryOutput":        Sample[sharedtypes.GeneralNeo4jQueryOutput](),
+		"GuardrailEvaluation":            Sample[sharedtypes.GuardrailEvaluation](),
+		"GuardrailPolicy":                Sample[sharedtypes.GuardrailPolicy](),
+		"GuardrailViolation":             Sample[sharedtypes.GuardrailViolation](),
+		"HandlerRequest":                 Sample[sharedtypes.HandlerRequest](),
+		"HandlerResponse":                Sample[sharedtypes.HandlerResponse](),
+		"HistoricMessage":                Sample[sharedtypes.HistoricMessage](),
+		"ImageAttachment":                Sample[sharedtypes.ImageAttachment](),
+		"ImageLimits":                    Sample[sharedtypes.ImageLimits](),
+		"InputGuardrails":                Sample[sharedtypes.InputGuardrails](),
+		"ListFilter":                     Sample[sharedtypes.ListFilter](),
+		"ListRequest":                    Sample[sharedtypes.ListRequest](),
+		"ListResponse":                   Sample[sharedtypes.ListResponse[sharedtypes.WorkflowRunStatusResponse]](),
+		"ListWorkflowRunsRequest":        Sample[sharedtypes.ListWorkflowRunsRequest](),
+		"ListWorkflowRunsResponse":       Sample[sharedtypes.ListWorkflowRunsResponse](),
+		"MCPConfig":                      Sample[sharedtypes.MCPConfig](),
+		"MCPContentItem":                 Sample[sharedtypes.MCPContentItem](),
+		"MCPEmbeddedResource":            Sample[sharedtypes.MCPEmbeddedResource](),
+		"MCPGetPromptResult":             Sample[sharedtypes.MCPGetPromptResult](),
+		"MCPImplementation":              Sample[sharedtypes.MCPImplementation](),
+		"MCPInitializeResult":            Sample[sharedtypes.MCPInitializeResult](),
+		"MCPListChangedCapability":       Sample[sharedtypes.MCPListChangedCapability](),
+		"MCPListPromptsResult":           Sample[sharedtypes.MCPListPromptsResult](),
+		"MCPListResourceTemplatesResult": Sample[sharedtypes.MCPListResourceTemplatesResult](),
+		"MCPListResourcesResult":         Sample[sharedtypes.MCPListResourcesResult](),
+		"MCPPrompt":                      Sample[sharedtypes.MCPPrompt](),
+		"MCPPromptArgument":              Sample[sharedtypes.MCPPromptArgument](),
+		"MCPPromptMessage":               Sample[sharedtypes.MCPPromptMessage](),
+		"MCPReadResourceResult":          Sample[sharedtypes.MCPReadResourceResult](),
+		"MCPResource":                    Sample[sharedtypes.MCPResource](),
+		"MCPResourceTemplate":            Sample[sharedtypes.MCPResourceTemplate](),
+		"MCPResourcesCapability":         Sample[sharedtypes.MCPResourcesCapability](),
+		"MCPServerCapabilities":          Sample[sharedtypes.MCPServerCapabilities](),
+		"MCPTool":                        Sample[sharedtypes.MCPTool](),
+		"MCPToolResultContent":           Sample[sharedtypes.MCPToolResultContent](),
+		"MaterialAttribute":              Sample[sharedtypes.MaterialAttribute](),
+		"MaterialCriterionWithGuid":      Sample[sharedtypes.MaterialCriterionWithGuid](),
+		"MaterialLlmCriterion":           Sample[sharedtypes.MaterialLlmCriterion](),
+		"MaterialMatchedCriterion":       Sample[sharedtypes.MaterialMatchedCriterion](),
+		"MaterialPropertyValue":          Sample[sharedtypes.MaterialPropertyValue](),
+		"MaterialRankingExplanation":     Sample[sharedtypes.MaterialRankingExplanation](),
+		"MaterialSearchResult":           Sample[sharedtypes.MaterialSearchResult](),
+		"ModelConfig":                    Sample[sharedtypes.ModelConfig](),
+		"ModelOptions":                   Sample[sharedtypes.ModelOptions](),
+		"ModelPricing":                   Sample[sharedtypes.ModelPricing](),
+		"ModelRoute":                     Sample[sharedtypes.ModelRoute](),
+		"ModelsConfig":                   Sample[sharedtypes.ModelsConfig](),
+		"MongoIndex":                     Sample[sharedtypes.MongoIndex](),
+		"MongoIndexKey":                  Sample[sharedtypes.MongoIndexKey](),
+		"Neo4jResponse":                  Sample[sharedtypes.Neo4jResponse](),
+		"NodeTrace":                      Sample[sharedtypes.NodeTrace](),
+		"OutputGuardrails":               Sample[sharedtypes.OutputGuardrails](),
+		"PageCursor":                     Sample[sharedtypes.PageCursor](),
+		"PageRequest":                    Sample[sharedtypes.PageRequest](),
+		"PageResponse":                   Sample[sharedtypes.PageResponse](),
+		"ProblemDetails":                 Sample[sharedtypes.ProblemDetails](),
+		"PromptSegment":                  Sample[sharedtypes.PromptSegment](),
+		"Quantity":                       Sample[sharedtypes.Quantity](),
+		"ResourceLimits":                 Sample[sharedtypes.ResourceLimits](),
+		"RoutingPolicy":                  Sample[sharedtypes.RoutingPolicy](),
+		"SemanticVersion":                Sample[sharedtypes.SemanticVersion](),
+		"ServiceVersion":                 Sample[sharedtypes.ServiceVersion](),
+		"SessionContext":                 Sample[sharedtypes.SessionContext](),
+		"SlashCommand":                   Sample[sharedtypes.SlashCommand](),
+		"SlashCommandArgument":           Sample[sharedtypes.SlashCommandArgument](),
+		"SortField":                      Sample[sharedtypes.SortField](),
+		"SparseEmbedding":                Sample[sharedtypes.SparseEmbedding](),
+		"StartWorkflowRunRequest":        Sample[sharedtypes.StartWorkflowRunRequest](),
+		"Subject":                        Sample[sharedtypes.Subject](),
+		"SystemPrompt":                   Sample[sharedtypes.SystemPrompt](),
+		"ToolCall":                       Sample[sharedtypes.ToolCall](),
+		"ToolDecision":                   Sample[sharedtypes.ToolDecision](),
+		"ToolExecutionPolicy":            Sample[sharedtypes.ToolExecutionPolicy](),
+		"ToolResult":                     Sample[sharedtypes.ToolResult](),
+		"ToolSetDefinition":              Sample[sharedtypes.ToolSetDefinition](),
+		"WebhookConfig":                  Sample[sharedtypes.WebhookConfig](),
+		"WebhookPayload":                 Sample[sharedtypes.WebhookPayload](),
+		"WebhookRetryPolicy":             Sample[sharedtypes.WebhookRetryPolicy](),
+		"WebhookTrigger":                 Sample[sharedtypes.WebhookTrigger](),
+		"WorkflowACL":                    Sample[sharedtypes.WorkflowACL](),
+		"WorkflowACLEntry":               Sample[sharedtypes.WorkflowACLEntry](),
+		"WorkflowRunDocument":            Sample[sharedtypes.WorkflowRunDocument](),
+		"WorkflowRunHistoryResponse":     Sample[sharedtypes.WorkflowRunHistoryResponse](),
+		"WorkflowRunStatusResponse":      Sample[sharedtypes.WorkflowRunStatusResponse](),
+		"WorkflowRunValue":               Sample[sharedtypes.WorkflowRunValue](),
+		"WorkflowTrigger":                Sample[sharedtypes.WorkflowTrigger](),
+		"WsEnvelope":                     Sample[sharedtypes.WsEnvelope](),
+		"WsHandshake":                    Sample[sharedtypes.WsHandshake](),
+		"WsHandshakeAck":                 Sample[sharedtypes.WsHandshakeAck](),
+		"XMLMemberExample":               Sample[sharedtypes.XMLMemberExample](),
+		"XMLMemberExampleCode":           Sample[sharedtypes.XMLMemberExampleCode](),
+		"XMLMemberParam":                 Sample[sharedtypes.XMLMemberParam](),
+	}
+}
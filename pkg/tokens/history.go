@@ -0,0 +1,244 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// TrimStrategy is the way a conversation history is trimmed to its token budget.
+type TrimStrategy string
+
+// Strategies for trimming conversation histories.
+const (
+	TrimDropOldest      TrimStrategy = "drop_oldest"      // drop the oldest messages until the history fits
+	TrimKeepLastN       TrimStrategy = "keep_last_n"      // keep the system messages and the last N messages, then drop the oldest until the history fits
+	TrimSummarizeMiddle TrimStrategy = "summarize_middle" // replace all but the last N messages by a summary, then drop the oldest until the history fits
+)
+
+// TrimStrategies lists all trim strategies.
+var TrimStrategies = []TrimStrategy{TrimDropOldest, TrimKeepLastN, TrimSummarizeMiddle}
+
+// Summarizer summarizes the messages removed from the middle of a conversation history, e.g. with a summary request to the LLM handler.
+type Summarizer func(ctx context.Context, messages []sharedtypes.HistoricMessage) (string, error)
+
+// TrimPolicy configures how a conversation history is trimmed.
+// System messages are never removed by any strategy, and tool results are always removed together with their tool call.
+type TrimPolicy struct {
+	Strategy    TrimStrategy `json:"strategy" yaml:"STRATEGY"`                            // "drop_oldest" if empty
+	MaxTokens   int          `json:"maxTokens" yaml:"MAX_TOKENS"`                         // token budget of the history, see CountMessages
+	KeepLastN   int          `json:"keepLastN,omitempty" yaml:"KEEP_LAST_N,omitempty"`    // number of latest non-system messages kept verbatim; for "keep_last_n" and "summarize_middle"
+	SummaryRole string       `json:"summaryRole,omitempty" yaml:"SUMMARY_ROLE,omitempty"` // role of the summary message; "system" if empty
+	Summarize   Summarizer   `json:"-" yaml:"-"`                                          // required for "summarize_middle"
+}
+
+// RemovedMessage records a message removed from the history.
+type RemovedMessage struct {
+	Index  int    `json:"index"` // index of the message in the original history
+	Role   string `json:"role"`
+	Tokens int    `json:"tokens"`
+}
+
+// TrimResult is the trimmed history and the record of what was removed.
+type TrimResult struct {
+	Messages     []sharedtypes.HistoricMessage `json:"messages"`
+	Removed      []RemovedMessage              `json:"removed,omitempty"`
+	Summary      string                        `json:"summary,omitempty"` // text of the summary message, empty if no summary was added
+	TokensBefore int                           `json:"tokensBefore"`
+	TokensAfter  int                           `json:"tokensAfter"`
+}
+
+// Validate checks the strategy, the limits and that a summarizer is set for "summarize_middle".
+//
+// Returns:
+//   - error: an error describing the first invalid value, nil if the policy is valid
+func (p *TrimPolicy) Validate() error {
+	if p.Strategy != "" && !slices.Contains(TrimStrategies, p.Strategy) {
+		return fmt.Errorf("strategy must be one of %v, got %q", TrimStrategies, string(p.Strategy))
+	}
+	if p.MaxTokens < 0 {
+		return fmt.Errorf("maxTokens must not be negative, got %d", p.MaxTokens)
+	}
+	if p.KeepLastN < 0 {
+		return fmt.Errorf("keepLastN must not be negative, got %d", p.KeepLastN)
+	}
+	if p.Strategy == TrimSummarizeMiddle && p.Summarize == nil {
+		return errors.New("strategy \"summarize_middle\" requires a summarizer")
+	}
+	return nil
+}
+
+// historyEntry is a message of the history being trimmed.
+type historyEntry struct {
+	index   int // index in the original history, -1 for the summary
+	message sharedtypes.HistoricMessage
+	tokens  int
+}
+
+// TrimHistory trims a conversation history to the token budget of the policy.
+// The history is returned unchanged if it already fits, so the summarizer is only called when needed.
+//
+// Parameters:
+//   - ctx: the context passed to the summarizer
+//   - tokenizer: the tokenizer of the model, see ForModel
+//   - messages: the conversation history, oldest first
+//   - policy: the trim policy
+//
+// Returns:
+//   - TrimResult: the kept messages in their original order, the removed messages and the token counts
+//   - error: an error if the policy is invalid or the summarizer fails
+func TrimHistory(ctx context.Context, tokenizer Tokenizer, messages []sharedtypes.HistoricMessage, policy TrimPolicy) (TrimResult, error) {
+	if err := policy.Validate(); err != nil {
+		return TrimResult{}, fmt.Errorf("invalid trim policy: %w", err)
+	}
+
+	entries := make([]historyEntry, len(messages))
+	for i, message := range messages {
+		entries[i] = historyEntry{index: i, message: message, tokens: CountMessage(tokenizer, message)}
+	}
+	result := TrimResult{TokensBefore: totalTokens(entries)}
+
+	var removed []historyEntry
+	if result.TokensBefore > policy.MaxTokens {
+		switch policy.Strategy {
+		case TrimKeepLastN:
+			entries, removed = keepLastN(entries, policy.KeepLastN)
+		case TrimSummarizeMiddle:
+			var middle []historyEntry
+			entries, middle = keepLastN(entries, policy.KeepLastN)
+			if len(middle) > 0 {
+				summary, err := summarize(ctx, tokenizer, middle, policy)
+				if err != nil {
+					return TrimResult{}, err
+				}
+				entries = insertAfterSystem(entries, summary)
+				result.Summary = summary.message.Content
+				removed = middle
+			}
+		}
+		var dropped []historyEntry
+		entries, dropped = dropOldest(entries, policy.MaxTokens)
+		removed = append(removed, dropped...)
+	}
+
+	result.Messages = make([]sharedtypes.HistoricMessage, len(entries))
+	for i, entry := range entries {
+		result.Messages[i] = entry.message
+	}
+	slices.SortFunc(removed, func(a, b historyEntry) int { return a.index - b.index })
+	for _, entry := range removed {
+		if entry.index >= 0 {
+			result.Removed = append(result.Removed, RemovedMessage{Index: entry.index, Role: entry.message.Role, Tokens: entry.tokens})
+		} else {
+			// the summary itself did not fit
+			result.Summary = ""
+		}
+	}
+	result.TokensAfter = totalTokens(entries)
+	return result, nil
+}
+
+// totalTokens counts the tokens of the entries like CountMessages.
+func totalTokens(entries []historyEntry) int {
+	if len(entries) == 0 {
+		return 0
+	}
+	total := ReplyOverheadTokens
+	for _, entry := range entries {
+		total += entry.tokens
+	}
+	return total
+}
+
+// dropOldest drops the oldest non-system entries, and the tool results following them, until the entries fit.
+func dropOldest(entries []historyEntry, maxTokens int) (kept []historyEntry, dropped []historyEntry) {
+	total := totalTokens(entries)
+	for i := 0; i < len(entries); i++ {
+		if total <= maxTokens || entries[i].message.Role == sharedtypes.RoleSystem {
+			kept = append(kept, entries[i])
+			continue
+		}
+		dropped = append(dropped, entries[i])
+		total -= entries[i].tokens
+		for i+1 < len(entries) && isToolResult(entries[i+1].message) {
+			i++
+			dropped = append(dropped, entries[i])
+			total -= entries[i].tokens
+		}
+	}
+	return kept, dropped
+}
+
+// keepLastN keeps the system entries and the last n other entries; a kept window never starts with a tool result.
+func keepLastN(entries []historyEntry, n int) (kept []historyEntry, removed []historyEntry) {
+	start := len(entries)
+	for count := 0; start > 0 && count < n; {
+		start--
+		if entries[start].message.Role != sharedtypes.RoleSystem {
+			count++
+		}
+	}
+	for start < len(entries) && isToolResult(entries[start].message) {
+		start++
+	}
+	for i, entry := range entries {
+		if i >= start || entry.message.Role == sharedtypes.RoleSystem {
+			kept = append(kept, entry)
+		} else {
+			removed = append(removed, entry)
+		}
+	}
+	return kept, removed
+}
+
+// summarize summarizes the removed entries into a single entry.
+func summarize(ctx context.Context, tokenizer Tokenizer, entries []historyEntry, policy TrimPolicy) (historyEntry, error) {
+	messages := make([]sharedtypes.HistoricMessage, len(entries))
+	for i, entry := range entries {
+		messages[i] = entry.message
+	}
+	text, err := policy.Summarize(ctx, messages)
+	if err != nil {
+		return historyEntry{}, fmt.Errorf("error summarizing %d messages: %w", len(messages), err)
+	}
+	role := sharedtypes.Role(sharedtypes.RoleSystem)
+	if policy.SummaryRole != "" {
+		role, _ = sharedtypes.NormalizeRole(policy.SummaryRole)
+	}
+	message := sharedtypes.HistoricMessage{Role: string(role), Content: text}
+	return historyEntry{index: -1, message: message, tokens: CountMessage(tokenizer, message)}, nil
+}
+
+// insertAfterSystem inserts the entry after the leading system entries.
+func insertAfterSystem(entries []historyEntry, entry historyEntry) []historyEntry {
+	i := 0
+	for i < len(entries) && entries[i].message.Role == sharedtypes.RoleSystem {
+		i++
+	}
+	return slices.Insert(entries, i, entry)
+}
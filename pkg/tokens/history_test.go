@@ -0,0 +1,159 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tokens
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+func TestTrimHistory(t *testing.T) {
+	callID := "c1"
+	system := sharedtypes.HistoricMessage{Role: "system", Content: "be nice"}
+	first := sharedtypes.HistoricMessage{Role: "user", Content: "first question"}
+	answer := sharedtypes.HistoricMessage{Role: "assistant", Content: "first answer"}
+	call := sharedtypes.HistoricMessage{Role: "assistant", ToolCalls: []sharedtypes.ToolCall{{ID: "c1", Name: "search"}}}
+	result := sharedtypes.HistoricMessage{Role: "tool", ToolCallId: &callID, Content: "result"}
+	last := sharedtypes.HistoricMessage{Role: "user", Content: "last question"}
+	history := []sharedtypes.HistoricMessage{system, first, answer, call, result, last}
+
+	count := func(messages ...sharedtypes.HistoricMessage) int {
+		return CountMessagesWith(charTokenizer{}, messages)
+	}
+	tokens := func(message sharedtypes.HistoricMessage) int {
+		return CountMessage(charTokenizer{}, message)
+	}
+	removed := func(indices ...int) []RemovedMessage {
+		var records []RemovedMessage
+		for _, i := range indices {
+			records = append(records, RemovedMessage{Index: i, Role: history[i].Role, Tokens: tokens(history[i])})
+		}
+		return records
+	}
+	summarizer := func(ctx context.Context, messages []sharedtypes.HistoricMessage) (string, error) {
+		var roles []string
+		for _, message := range messages {
+			roles = append(roles, string(message.Role))
+		}
+		return "summary of " + strings.Join(roles, ","), nil
+	}
+
+	tests := []struct {
+		name     string
+		policy   TrimPolicy
+		expected []sharedtypes.HistoricMessage
+		removed  []RemovedMessage
+		summary  string
+	}{
+		{
+			name:     "fits",
+			policy:   TrimPolicy{Strategy: TrimKeepLastN, MaxTokens: count(history...), KeepLastN: 1},
+			expected: history,
+		},
+		{
+			name:     "drop oldest",
+			policy:   TrimPolicy{MaxTokens: count(system, call, result, last)},
+			expected: []sharedtypes.HistoricMessage{system, call, result, last},
+			removed:  removed(1, 2),
+		},
+		{
+			name:     "keep last n skips orphaned tool results",
+			policy:   TrimPolicy{Strategy: TrimKeepLastN, MaxTokens: count(history...) - 1, KeepLastN: 2},
+			expected: []sharedtypes.HistoricMessage{system, last},
+			removed:  removed(1, 2, 3, 4),
+		},
+		{
+			name:     "keep last n drops oldest if still too large",
+			policy:   TrimPolicy{Strategy: TrimKeepLastN, MaxTokens: count(system, result, last) - 1, KeepLastN: 3},
+			expected: []sharedtypes.HistoricMessage{system, last},
+			removed:  removed(1, 2, 3, 4),
+		},
+		{
+			name:     "summarize middle",
+			policy:   TrimPolicy{Strategy: TrimSummarizeMiddle, MaxTokens: count(history...) - 1, KeepLastN: 3, Summarize: summarizer},
+			expected: []sharedtypes.HistoricMessage{system, {Role: "system", Content: "summary of user,assistant"}, call, result, last},
+			removed:  removed(1, 2),
+			summary:  "summary of user,assistant",
+		},
+		{
+			name:     "summary dropped if it does not fit",
+			policy:   TrimPolicy{Strategy: TrimSummarizeMiddle, MaxTokens: count(system, last), KeepLastN: 1, SummaryRole: "user", Summarize: summarizer},
+			expected: []sharedtypes.HistoricMessage{system, last},
+			removed:  removed(1, 2, 3, 4),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TrimHistory(context.Background(), charTokenizer{}, history, tt.policy)
+			if err != nil {
+				t.Fatalf("TrimHistory() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Messages, tt.expected) {
+				t.Errorf("messages = %+v, want %+v", got.Messages, tt.expected)
+			}
+			if !reflect.DeepEqual(got.Removed, tt.removed) {
+				t.Errorf("removed = %+v, want %+v", got.Removed, tt.removed)
+			}
+			if got.Summary != tt.summary {
+				t.Errorf("summary = %q, want %q", got.Summary, tt.summary)
+			}
+			if got.TokensBefore != count(history...) || got.TokensAfter != count(got.Messages...) {
+				t.Errorf("tokens = %d -> %d, want %d -> %d", got.TokensBefore, got.TokensAfter, count(history...), count(got.Messages...))
+			}
+		})
+	}
+}
+
+func TestTrimHistoryErrors(t *testing.T) {
+	history := []sharedtypes.HistoricMessage{{Role: "user", Content: "a"}, {Role: "user", Content: "b"}}
+	failing := func(ctx context.Context, messages []sharedtypes.HistoricMessage) (string, error) {
+		return "", errors.New("handler unavailable")
+	}
+
+	tests := []struct {
+		name    string
+		policy  TrimPolicy
+		errText string
+	}{
+		{"unknown strategy", TrimPolicy{Strategy: "random"}, "strategy must be one of"},
+		{"negative budget", TrimPolicy{MaxTokens: -1}, "maxTokens must not be negative"},
+		{"negative keep last n", TrimPolicy{KeepLastN: -1}, "keepLastN must not be negative"},
+		{"missing summarizer", TrimPolicy{Strategy: TrimSummarizeMiddle}, "requires a summarizer"},
+		{"failing summarizer", TrimPolicy{Strategy: TrimSummarizeMiddle, KeepLastN: 1, Summarize: failing}, "handler unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := TrimHistory(context.Background(), charTokenizer{}, history, tt.policy)
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("TrimHistory() error = %v, want error containing %q", err, tt.errText)
+			}
+		})
+	}
+}
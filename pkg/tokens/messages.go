@@ -0,0 +1,167 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tokens
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// Token overheads of the chat format, as documented for the OpenAI chat models.
+const (
+	MessageOverheadTokens = 3   // role and separators of each message
+	ReplyOverheadTokens   = 3   // priming of the reply of the assistant
+	ImageTokens           = 765 // estimate for an image of 1024x1024 pixels in high detail; images are not tokenized
+)
+
+// CountMessages counts the tokens of a conversation history with the tokenizer of the model.
+//
+// Parameters:
+//   - messages: the conversation history
+//   - model: the model name or id, see ForModel
+//
+// Returns:
+//   - int: the number of tokens, including the overhead of the chat format and the reply priming
+func CountMessages(messages []sharedtypes.HistoricMessage, model string) int {
+	return CountMessagesWith(ForModel(model), messages)
+}
+
+// CountMessagesWith counts the tokens of a conversation history with the given tokenizer.
+//
+// Parameters:
+//   - tokenizer: the tokenizer
+//   - messages: the conversation history
+//
+// Returns:
+//   - int: the number of tokens, including the overhead of the chat format and the reply priming; 0 for no messages
+func CountMessagesWith(tokenizer Tokenizer, messages []sharedtypes.HistoricMessage) int {
+	if len(messages) == 0 {
+		return 0
+	}
+	count := ReplyOverheadTokens
+	for _, message := range messages {
+		count += CountMessage(tokenizer, message)
+	}
+	return count
+}
+
+// CountMessage counts the tokens of a single message, including its overhead in the chat format.
+// The content parts take precedence over the content and images, as in the LLM handler.
+//
+// Parameters:
+//   - tokenizer: the tokenizer
+//   - message: the message
+//
+// Returns:
+//   - int: the number of tokens
+func CountMessage(tokenizer Tokenizer, message sharedtypes.HistoricMessage) int {
+	count := MessageOverheadTokens + tokenizer.Count(string(message.Role))
+	if message.ToolCallId != nil {
+		count += tokenizer.Count(*message.ToolCallId)
+	}
+	for _, call := range message.ToolCalls {
+		count += countToolCall(tokenizer, call)
+	}
+
+	if len(message.ContentParts) == 0 {
+		return count + tokenizer.Count(message.Content) + len(message.Images)*ImageTokens
+	}
+	for _, part := range message.ContentParts {
+		switch part.Type {
+		case sharedtypes.ContentPartText:
+			count += tokenizer.Count(part.Text)
+		case sharedtypes.ContentPartImage:
+			count += ImageTokens
+		case sharedtypes.ContentPartFile:
+			// inline files are sent as extracted text by most providers; the base64 data is a safe upper bound
+			count += tokenizer.Count(part.FileName) + tokenizer.Count(part.Data) + tokenizer.Count(part.URI)
+		case sharedtypes.ContentPartToolUse:
+			if part.ToolUse != nil {
+				count += countToolCall(tokenizer, *part.ToolUse)
+			}
+		case sharedtypes.ContentPartToolResult:
+			if part.ToolResult != nil {
+				count += tokenizer.Count(part.ToolResult.ToolCallID) + tokenizer.Count(part.ToolResult.Content)
+				for _, item := range part.ToolResult.ContentItems {
+					count += tokenizer.Count(item.Text)
+				}
+			}
+		}
+	}
+	return count
+}
+
+// countToolCall counts the id, the name and the JSON input of a tool call.
+func countToolCall(tokenizer Tokenizer, call sharedtypes.ToolCall) int {
+	count := tokenizer.Count(call.ID) + tokenizer.Count(call.Name)
+	if input, err := json.Marshal(call.Input); err == nil {
+		count += tokenizer.Count(string(input))
+	}
+	return count
+}
+
+// TruncateHistory drops the oldest messages of a conversation history until it fits into a token budget.
+// System messages are always kept. Tool results whose tool call was dropped are dropped as well, so the
+// history never starts with an orphaned tool result. The result only depends on the input, so the agent and
+// the handler truncate the same way.
+//
+// Parameters:
+//   - messages: the conversation history, oldest first
+//   - model: the model name or id, see ForModel
+//   - maxTokens: the token budget of the history, see CountMessages
+//
+// Returns:
+//   - []sharedtypes.HistoricMessage: the kept messages in their original order; may only hold the system messages
+func TruncateHistory(messages []sharedtypes.HistoricMessage, model string, maxTokens int) []sharedtypes.HistoricMessage {
+	return TruncateHistoryWith(ForModel(model), messages, maxTokens)
+}
+
+// TruncateHistoryWith is TruncateHistory with the given tokenizer.
+//
+// Parameters:
+//   - tokenizer: the tokenizer
+//   - messages: the conversation history, oldest first
+//   - maxTokens: the token budget of the history, see CountMessages
+//
+// Returns:
+//   - []sharedtypes.HistoricMessage: the kept messages in their original order; may only hold the system messages
+func TruncateHistoryWith(tokenizer Tokenizer, messages []sharedtypes.HistoricMessage, maxTokens int) []sharedtypes.HistoricMessage {
+	// the drop oldest strategy needs no summarizer, so it cannot fail
+	result, _ := TrimHistory(context.Background(), tokenizer, messages, TrimPolicy{Strategy: TrimDropOldest, MaxTokens: maxTokens})
+	return result.Messages
+}
+
+// isToolResult reports whether the message answers a tool call of the previous message.
+func isToolResult(message sharedtypes.HistoricMessage) bool {
+	if message.Role == sharedtypes.RoleTool || message.ToolCallId != nil {
+		return true
+	}
+	for _, part := range message.ContentParts {
+		if part.Type == sharedtypes.ContentPartToolResult {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tokens
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// charTokenizer counts one token per byte, so expected counts are easy to read.
+type charTokenizer struct{}
+
+func (charTokenizer) Name() string          { return "chars" }
+func (charTokenizer) Count(text string) int { return len(text) }
+
+func TestCountMessage(t *testing.T) {
+	callID := "c1"
+	tests := []struct {
+		name     string
+		message  sharedtypes.HistoricMessage
+		expected int
+	}{
+		{"content", sharedtypes.HistoricMessage{Role: "user", Content: "hello"}, MessageOverheadTokens + 4 + 5},
+		{"images", sharedtypes.HistoricMessage{Role: "user", Content: "hi", Images: []string{"x", "y"}}, MessageOverheadTokens + 4 + 2 + 2*ImageTokens},
+		{"tool call", sharedtypes.HistoricMessage{Role: "assistant", ToolCalls: []sharedtypes.ToolCall{{ID: "c1", Name: "search", Input: map[string]interface{}{"q": "x"}}}}, MessageOverheadTokens + 9 + 2 + 6 + len(`{"q":"x"}`)},
+		{"tool result", sharedtypes.HistoricMessage{Role: "tool", ToolCallId: &callID, Content: "found"}, MessageOverheadTokens + 4 + 2 + 5},
+		{"content parts take precedence", sharedtypes.HistoricMessage{
+			Role:    "user",
+			Content: "ignored",
+			ContentParts: []sharedtypes.ContentPart{
+				{Type: sharedtypes.ContentPartText, Text: "look"},
+				{Type: sharedtypes.ContentPartImage, Data: "abc"},
+				{Type: sharedtypes.ContentPartToolResult, ToolResult: &sharedtypes.ToolResult{ToolCallID: "c1", Content: "ok"}},
+			},
+		}, MessageOverheadTokens + 4 + 4 + ImageTokens + 2 + 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountMessage(charTokenizer{}, tt.message); got != tt.expected {
+				t.Errorf("CountMessage() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+
+	messages := []sharedtypes.HistoricMessage{tests[0].message, tests[1].message}
+	if got, expected := CountMessagesWith(charTokenizer{}, messages), ReplyOverheadTokens+tests[0].expected+tests[1].expected; got != expected {
+		t.Errorf("CountMessagesWith() = %d, want %d", got, expected)
+	}
+	if got := CountMessages(nil, "any"); got != 0 {
+		t.Errorf("CountMessages(nil) = %d, want 0", got)
+	}
+}
+
+func TestTruncateHistory(t *testing.T) {
+	callID := "c1"
+	system := sharedtypes.HistoricMessage{Role: "system", Content: "be nice"}
+	first := sharedtypes.HistoricMessage{Role: "user", Content: "first question"}
+	call := sharedtypes.HistoricMessage{Role: "assistant", ToolCalls: []sharedtypes.ToolCall{{ID: "c1", Name: "search"}}}
+	result := sharedtypes.HistoricMessage{Role: "tool", ToolCallId: &callID, Content: "result"}
+	last := sharedtypes.HistoricMessage{Role: "user", Content: "last question"}
+	history := []sharedtypes.HistoricMessage{system, first, call, result, last}
+
+	count := func(messages ...sharedtypes.HistoricMessage) int {
+		return CountMessagesWith(charTokenizer{}, messages)
+	}
+	tests := []struct {
+		name      string
+		maxTokens int
+		expected  []sharedtypes.HistoricMessage
+	}{
+		{"fits", count(history...), history},
+		{"drops oldest", count(system, call, result, last), []sharedtypes.HistoricMessage{system, call, result, last}},
+		{"drops tool results with their call", count(system, call, result, last) - 1, []sharedtypes.HistoricMessage{system, last}},
+		{"keeps system messages", 0, []sharedtypes.HistoricMessage{system}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateHistoryWith(charTokenizer{}, history, tt.maxTokens)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("TruncateHistoryWith() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+
+	if got := TruncateHistory(history, "unknown-model", 1_000_000); len(got) != len(history) {
+		t.Errorf("TruncateHistory() kept %d messages, want %d", len(got), len(history))
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package tokens counts the tokens of prompts and conversation histories, so services can check the context
+// window of a model and truncate the history before sending a request to the LLM handler.
+//
+// Tokenizers are registered per model name prefix with Register. BPETokenizer counts exactly like tiktoken
+// with the rank file of an encoding, e.g. cl100k_base.tiktoken; models without registered tokenizer are
+// counted with HeuristicTokenizer. TrimHistory trims a history to a token budget with a TrimPolicy, so all
+// services drop or summarize the same messages.
+package tokens
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tokenizer counts the tokens of a text.
+type Tokenizer interface {
+	// Name returns the name of the encoding, e.g. "cl100k_base" or "heuristic".
+	Name() string
+	// Count returns the number of tokens of the text.
+	Count(text string) int
+}
+
+// DefaultCharsPerToken is the average number of characters per token of English text with GPT tokenizers.
+const DefaultCharsPerToken = 4
+
+// HeuristicTokenizer estimates the number of tokens from the number of characters and words.
+// It is used for models without registered tokenizer and tends to overestimate rather than underestimate.
+type HeuristicTokenizer struct {
+	CharsPerToken float64 // average number of characters per token; DefaultCharsPerToken if 0
+}
+
+// Name returns "heuristic".
+func (h HeuristicTokenizer) Name() string {
+	return "heuristic"
+}
+
+// Count estimates the number of tokens as the larger of the number of characters divided by CharsPerToken
+// and the number of words and punctuation characters.
+//
+// Parameters:
+//   - text: the text
+//
+// Returns:
+//   - int: the estimated number of tokens
+func (h HeuristicTokenizer) Count(text string) int {
+	charsPerToken := h.CharsPerToken
+	if charsPerToken <= 0 {
+		charsPerToken = DefaultCharsPerToken
+	}
+	byChars := int(math.Ceil(float64(utf8.RuneCountInString(text)) / charsPerToken))
+
+	words := 0
+	inWord := false
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			if !inWord {
+				words++
+			}
+			inWord = true
+		case unicode.IsSpace(r):
+			inWord = false
+		default:
+			words++
+			inWord = false
+		}
+	}
+	return max(byChars, words)
+}
+
+// Cl100kPattern splits text into pieces like the cl100k_base encoding of tiktoken.
+// Go regular expressions have no lookahead, so BPETokenizer handles the "\s+(?!\S)" part of the original pattern itself.
+const Cl100kPattern = `(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+`
+
+// BPETokenizer counts tokens with the byte pair encoding of tiktoken.
+type BPETokenizer struct {
+	name    string
+	ranks   map[string]int
+	pattern *regexp.Regexp
+}
+
+// NewBPETokenizer creates a byte pair encoding tokenizer.
+//
+// Parameters:
+//   - name: the name of the encoding
+//   - ranks: the merge rank of each token, as read by ParseTiktokenRanks
+//   - pattern: the pattern splitting the text into pieces before encoding; Cl100kPattern if empty
+//
+// Returns:
+//   - *BPETokenizer: the tokenizer
+//   - error: an error if the pattern does not compile
+func NewBPETokenizer(name string, ranks map[string]int, pattern string) (*BPETokenizer, error) {
+	if pattern == "" {
+		pattern = Cl100kPattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern of encoding %s: %w", name, err)
+	}
+	return &BPETokenizer{name: name, ranks: ranks, pattern: re}, nil
+}
+
+// LoadTiktokenFile creates a byte pair encoding tokenizer from a tiktoken rank file.
+//
+// Parameters:
+//   - path: the path of the rank file, e.g. "cl100k_base.tiktoken"
+//   - pattern: the pattern splitting the text into pieces; Cl100kPattern if empty
+//
+// Returns:
+//   - *BPETokenizer: the tokenizer, named after the file without extension
+//   - error: an error if the file cannot be read or parsed
+func LoadTiktokenFile(path string, pattern string) (*BPETokenizer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening tiktoken file: %w", err)
+	}
+	defer file.Close()
+
+	ranks, err := ParseTiktokenRanks(file)
+	if err != nil {
+		return nil, fmt.Errorf("error in tiktoken file %s: %w", path, err)
+	}
+	name := strings.TrimSuffix(path[strings.LastIndexAny(path, `/\`)+1:], ".tiktoken")
+	return NewBPETokenizer(name, ranks, pattern)
+}
+
+// ParseTiktokenRanks parses the ranks of a tiktoken file: one base64 encoded token and its rank per line.
+//
+// Parameters:
+//   - r: the content of the file
+//
+// Returns:
+//   - map[string]int: the rank of each token
+//   - error: an error if a line is malformed
+func ParseTiktokenRanks(r io.Reader) (map[string]int, error) {
+	ranks := map[string]int{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		token, rank, ok := strings.Cut(text, " ")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected token and rank", line)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid token: %w", line, err)
+		}
+		value, err := strconv.Atoi(rank)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid rank: %w", line, err)
+		}
+		ranks[string(decoded)] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ranks, nil
+}
+
+// Name returns the name of the encoding.
+func (b *BPETokenizer) Name() string {
+	return b.name
+}
+
+// Count returns the number of tokens of the text.
+//
+// Parameters:
+//   - text: the text
+//
+// Returns:
+//   - int: the number of tokens
+func (b *BPETokenizer) Count(text string) int {
+	count := 0
+	for _, piece := range b.split(text) {
+		count += b.countPiece(piece)
+	}
+	return count
+}
+
+// split splits the text into pieces with the pattern. A whitespace run followed by a non-whitespace character
+// leaves its last character to the next piece, like "\s+(?!\S)" in the tiktoken patterns.
+func (b *BPETokenizer) split(text string) []string {
+	var pieces []string
+	for start := 0; start < len(text); {
+		loc := b.pattern.FindStringIndex(text[start:])
+		if loc == nil {
+			pieces = append(pieces, text[start:])
+			break
+		}
+		begin, end := start+loc[0], start+loc[1]
+		if begin > start {
+			pieces = append(pieces, text[start:begin])
+		}
+		if end == begin {
+			_, size := utf8.DecodeRuneInString(text[begin:])
+			end = begin + size
+		}
+		if piece := text[begin:end]; end < len(text) && strings.TrimSpace(piece) == "" && utf8.RuneCountInString(piece) > 1 {
+			if next, _ := utf8.DecodeRuneInString(text[end:]); !unicode.IsSpace(next) {
+				_, size := utf8.DecodeLastRuneInString(piece)
+				end -= size
+			}
+		}
+		pieces = append(pieces, text[begin:end])
+		start = end
+	}
+	return pieces
+}
+
+// countPiece merges the bytes of a piece by rank, starting with the lowest rank, and returns the number of tokens left.
+func (b *BPETokenizer) countPiece(piece string) int {
+	if _, ok := b.ranks[piece]; ok {
+		return 1
+	}
+
+	// boundaries of the current tokens of the piece
+	bounds := make([]int, len(piece)+1)
+	for i := range bounds {
+		bounds[i] = i
+	}
+	for len(bounds) > 2 {
+		best, bestRank := -1, math.MaxInt
+		for i := 0; i+2 < len(bounds); i++ {
+			if rank, ok := b.ranks[piece[bounds[i]:bounds[i+2]]]; ok && rank < bestRank {
+				best, bestRank = i, rank
+			}
+		}
+		if best < 0 {
+			break
+		}
+		bounds = append(bounds[:best+1], bounds[best+2:]...)
+	}
+	return len(bounds) - 1
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Tokenizer{}
+)
+
+// Register registers the tokenizer of the models whose name starts with the prefix, e.g. "gpt-4o".
+// Registering a prefix again replaces its tokenizer.
+//
+// Parameters:
+//   - modelPrefix: the prefix of the model names, compared case-insensitively
+//   - tokenizer: the tokenizer of the models
+func Register(modelPrefix string, tokenizer Tokenizer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(modelPrefix)] = tokenizer
+}
+
+// ForModel returns the tokenizer registered for the longest prefix of the model name.
+//
+// Parameters:
+//   - model: the model name or id
+//
+// Returns:
+//   - Tokenizer: the registered tokenizer, HeuristicTokenizer if no prefix matches
+func ForModel(model string) Tokenizer {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	model = strings.ToLower(model)
+	var tokenizer Tokenizer = HeuristicTokenizer{}
+	longest := -1
+	for prefix, registered := range registry {
+		if strings.HasPrefix(model, prefix) && len(prefix) > longest {
+			tokenizer, longest = registered, len(prefix)
+		}
+	}
+	return tokenizer
+}
+
+// Count counts the tokens of a text with the tokenizer of the model.
+//
+// Parameters:
+//   - text: the text
+//   - model: the model name or id
+//
+// Returns:
+//   - int: the number of tokens
+func Count(text string, model string) int {
+	return ForModel(model).Count(text)
+}
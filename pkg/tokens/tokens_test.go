@@ -0,0 +1,184 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package tokens
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testTokenizer is a small encoding: "ab" is merged before "bc", "abc" after both.
+func testTokenizer(t *testing.T) *BPETokenizer {
+	t.Helper()
+	tokenizer, err := NewBPETokenizer("test", map[string]int{"ab": 1, "bc": 2, "abc": 5, " ab": 7, "hello": 3}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tokenizer
+}
+
+func TestBPETokenizerSplit(t *testing.T) {
+	tokenizer := testTokenizer(t)
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"hello world", []string{"hello", " world"}},
+		{"hello  world", []string{"hello", " ", " world"}},
+		{"it's 123456", []string{"it", "'s", " ", "123", "456"}},
+		{"end.\n\nnext", []string{"end", ".\n\n", "next"}},
+		{"trailing   ", []string{"trailing", "   "}},
+		{"größe €", []string{"größe", " €"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if pieces := tokenizer.split(tt.text); !reflect.DeepEqual(pieces, tt.expected) {
+				t.Errorf("split() = %q, want %q", pieces, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBPETokenizerCount(t *testing.T) {
+	tokenizer := testTokenizer(t)
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{"", 0},
+		{"abc", 1},   // whole piece is a token
+		{"abcd", 2},  // ab, then abc, then d
+		{"bcbc", 2},  // both bc pairs are merged
+		{"ab ab", 2}, // ab and " ab"
+		{"hello", 1},
+		{"é", 2}, // unmerged bytes count as one token each
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := tokenizer.Count(tt.text); got != tt.expected {
+				t.Errorf("Count(%q) = %d, want %d", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadTiktokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tiny.tiktoken")
+	// "ab" and "abc" in base64
+	if err := os.WriteFile(path, []byte("YWI= 0\nYWJj 1\n\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tokenizer, err := LoadTiktokenFile(path, "")
+	if err != nil {
+		t.Fatalf("LoadTiktokenFile() error = %v", err)
+	}
+	if tokenizer.Name() != "tiny" || tokenizer.Count("abcab") != 2 {
+		t.Errorf("tokenizer %q counts %d tokens, want tiny and 2", tokenizer.Name(), tokenizer.Count("abcab"))
+	}
+
+	invalid := []struct {
+		name    string
+		content string
+		errText string
+	}{
+		{"missing rank", "YWI=\n", "expected token and rank"},
+		{"invalid base64", "!!! 0\n", "invalid token"},
+		{"invalid rank", "YWI= x\n", "invalid rank"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseTiktokenRanks(strings.NewReader(tt.content)); err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("ParseTiktokenRanks() error = %v, want error containing %q", err, tt.errText)
+			}
+		})
+	}
+
+	if _, err := LoadTiktokenFile(filepath.Join(t.TempDir(), "missing.tiktoken"), ""); err == nil {
+		t.Error("LoadTiktokenFile() of missing file succeeded, want error")
+	}
+	if _, err := NewBPETokenizer("bad", nil, "("); err == nil {
+		t.Error("NewBPETokenizer() with invalid pattern succeeded, want error")
+	}
+}
+
+func TestHeuristicTokenizer(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenizer HeuristicTokenizer
+		text      string
+		expected  int
+	}{
+		{"empty", HeuristicTokenizer{}, "", 0},
+		{"by characters", HeuristicTokenizer{}, "internationalization", 5},
+		{"by words", HeuristicTokenizer{}, "a b c d e f", 6},
+		{"punctuation", HeuristicTokenizer{}, "a,b", 3},
+		{"custom ratio", HeuristicTokenizer{CharsPerToken: 2}, "abcdef", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tokenizer.Count(tt.text); got != tt.expected {
+				t.Errorf("Count(%q) = %d, want %d", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestForModel(t *testing.T) {
+	gpt4 := HeuristicTokenizer{CharsPerToken: 3}
+	gpt4o := testTokenizer(t)
+	Register("test-gpt-4", gpt4)
+	Register("Test-GPT-4o", gpt4o)
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		delete(registry, "test-gpt-4")
+		delete(registry, "test-gpt-4o")
+	})
+
+	tests := []struct {
+		model    string
+		expected Tokenizer
+	}{
+		{"test-gpt-4-turbo", gpt4},
+		{"test-gpt-4o-mini", gpt4o},
+		{"TEST-GPT-4O", gpt4o},
+		{"unknown", HeuristicTokenizer{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			if got := ForModel(tt.model); got != tt.expected {
+				t.Errorf("ForModel(%q) = %v, want %v", tt.model, got, tt.expected)
+			}
+		})
+	}
+
+	if got := Count("abc", "test-gpt-4o"); got != 1 {
+		t.Errorf("Count() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package typeconverters
+
+import (
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// SetEmbeddingEncodingFromConfig sets the encoding of the embeddings of DbData and DbResponse
+// to the EMBEDDING_ENCODING of the configuration.
+// Both encodings are always decoded, so only the services producing the values need the setting.
+//
+// Parameters:
+//   - cfg: the configuration; nil to use the default JSON encoding
+//
+// Returns:
+//   - err: an error if the configured encoding is not supported
+func SetEmbeddingEncodingFromConfig(cfg *config.Config) (err error) {
+	encoding := sharedtypes.EmbeddingEncodingJSON
+	if cfg != nil && cfg.EMBEDDING_ENCODING != "" {
+		encoding = sharedtypes.EmbeddingEncoding(cfg.EMBEDDING_ENCODING)
+	}
+	return sharedtypes.SetEmbeddingEncoding(encoding)
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package typeconverters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+func TestSetEmbeddingEncodingFromConfig(t *testing.T) {
+	defer sharedtypes.SetEmbeddingEncoding(sharedtypes.EmbeddingEncodingJSON)
+
+	if err := SetEmbeddingEncodingFromConfig(&config.Config{EMBEDDING_ENCODING: "base64"}); err != nil {
+		t.Fatalf("SetEmbeddingEncodingFromConfig() error = %v", err)
+	}
+	if got := sharedtypes.CurrentEmbeddingEncoding(); got != sharedtypes.EmbeddingEncodingBase64 {
+		t.Errorf("encoding = %v, want base64", got)
+	}
+
+	// the converters of the knowledge DB types use the configured encoding
+	data := []sharedtypes.DbData{{Embedding: sharedtypes.Embedding{1, 2}}}
+	text, _, err := ConvertGivenTypeToString(data, "[]DbData")
+	if err != nil {
+		t.Fatalf("ConvertGivenTypeToString() error = %v", err)
+	}
+	if !strings.Contains(text, `"embeddings":"AACAPwAAAEA="`) {
+		t.Errorf("ConvertGivenTypeToString() = %s, want base64 embeddings", text)
+	}
+
+	if err := SetEmbeddingEncodingFromConfig(&config.Config{EMBEDDING_ENCODING: "float16"}); err == nil {
+		t.Error("expected an error for an unsupported encoding")
+	}
+	if err := SetEmbeddingEncodingFromConfig(nil); err != nil || sharedtypes.CurrentEmbeddingEncoding() != sharedtypes.EmbeddingEncodingJSON {
+		t.Errorf("SetEmbeddingEncodingFromConfig(nil) = %v, encoding %v, want json", err, sharedtypes.CurrentEmbeddingEncoding())
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package typeconverters
+
+import (
+	"fmt"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+)
+
+// FilledValue returns the value of a filled input/output, decoding it on first use if it was created with
+// sharedtypes.NewSerializedFilledInputOutput.
+//
+// Parameters:
+//   - f: the filled input/output
+//
+// Returns:
+//   - interface{}: the value
+//   - error: an error if the value cannot be decoded
+func FilledValue(f *sharedtypes.FilledInputOutput) (interface{}, error) {
+	return f.Decode(decodeFilledValue)
+}
+
+// FilledValueToString returns the value of a filled input/output serialized as the given Go type.
+// The serialized form is cached, so passing an output on to an input of the same type does not convert it again.
+//
+// Parameters:
+//   - f: the filled input/output
+//   - goType: the Go type to serialize as
+//
+// Returns:
+//   - string: the serialized value
+//   - error: an error if the value cannot be converted
+func FilledValueToString(f *sharedtypes.FilledInputOutput, goType string) (string, error) {
+	return f.Serialize(goType, decodeFilledValue, encodeFilledValue)
+}
+
+// DecodeFilledInputOutput creates a filled input/output from a serialized value and decodes it.
+// The serialized form is kept, so it is reused if the value is serialized as the same type again.
+//
+// Parameters:
+//   - name: the name of the input/output
+//   - goType: the Go type of the value
+//   - serialized: the serialized value
+//
+// Returns:
+//   - sharedtypes.FilledInputOutput: the filled input/output
+//   - error: an error if the value cannot be decoded
+func DecodeFilledInputOutput(name string, goType string, serialized string) (sharedtypes.FilledInputOutput, error) {
+	filled := sharedtypes.NewSerializedFilledInputOutput(name, goType, serialized)
+	if _, err := FilledValue(&filled); err != nil {
+		return sharedtypes.FilledInputOutput{}, err
+	}
+	return filled, nil
+}
+
+// decodeFilledValue converts a serialized value to its Go type.
+func decodeFilledValue(serialized string, goType string) (interface{}, error) {
+	value, exists, err := ConvertStringToGivenType(serialized, goType)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("type '%s' does not exist in typeconverters", goType)
+	}
+	return value, nil
+}
+
+// encodeFilledValue converts a value to its serialized form.
+func encodeFilledValue(value interface{}, goType string) (string, error) {
+	serialized, exists, err := ConvertGivenTypeToString(value, goType)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("type '%s' does not exist in typeconverters", goType)
+	}
+	return serialized, nil
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package typeconverters
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
+	"github.com/google/uuid"
+)
+
+func TestFilledValue(t *testing.T) {
+	lazy := sharedtypes.NewSerializedFilledInputOutput("count", "int", "42")
+	value, err := FilledValue(&lazy)
+	if err != nil || value != 42 || lazy.Value != 42 {
+		t.Errorf("FilledValue() = %v, %v", value, err)
+	}
+
+	plain := sharedtypes.FilledInputOutput{Name: "text", GoType: "string", Value: "hello"}
+	if value, err := FilledValue(&plain); err != nil || value != "hello" {
+		t.Errorf("FilledValue() of plain value = %v, %v", value, err)
+	}
+
+	unknown := sharedtypes.NewSerializedFilledInputOutput("x", "NoSuchType", "1")
+	if _, err := FilledValue(&unknown); err == nil {
+		t.Error("FilledValue() with unknown type succeeded")
+	}
+}
+
+func TestFilledValueToString(t *testing.T) {
+	filled, err := DecodeFilledInputOutput("count", "int", "42")
+	if err != nil || filled.Value != 42 {
+		t.Fatalf("DecodeFilledInputOutput() = %+v, %v", filled, err)
+	}
+	tests := []struct {
+		goType  string
+		want    string
+		wantErr bool
+	}{
+		{"int", "42", false},
+		{"interface{}", "42", false},
+		{"NoSuchType", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goType, func(t *testing.T) {
+			got, err := FilledValueToString(&filled, tt.goType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FilledValueToString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FilledValueToString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := DecodeFilledInputOutput("count", "int", "not a number"); err == nil {
+		t.Error("DecodeFilledInputOutput() with invalid value succeeded")
+	}
+}
+
+// testDbData returns n database entries with realistic text and embedding sizes.
+func testDbData(n int) []sharedtypes.DbData {
+	data := make([]sharedtypes.DbData, n)
+	for i := range data {
+		embedding := make([]float32, 384)
+		for j := range embedding {
+			embedding[j] = float32(i*j%97) / 97
+		}
+		data[i] = sharedtypes.DbData{
+			Guid:         uuid.New(),
+			DocumentId:   fmt.Sprintf("doc-%d", i),
+			DocumentName: fmt.Sprintf("document-%d.pdf", i),
+			Text:         strings.Repeat("lorem ipsum dolor sit amet ", 40),
+			Keywords:     []string{"mesh", "solver", "boundary"},
+			Embedding:    embedding,
+			Metadata:     map[string]interface{}{"page": i},
+		}
+	}
+	return data
+}
+
+// workflowHops is the number of nodes a []DbData value is passed through in the benchmarks.
+const workflowHops = 3
+
+// BenchmarkPassDbDataConvert passes []DbData between nodes by converting every output to its Go type and back
+// to string for the next input, as RunFunction did before the serialized form was cached.
+func BenchmarkPassDbDataConvert(b *testing.B) {
+	serialized, _, _ := ConvertGivenTypeToString(testDbData(200), "[]DbData")
+	b.SetBytes(int64(len(serialized)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		current := serialized
+		for hop := 0; hop < workflowHops; hop++ {
+			value, _, err := ConvertStringToGivenType(current, "[]DbData")
+			if err != nil {
+				b.Fatal(err)
+			}
+			current, _, err = ConvertGivenTypeToString(value, "[]DbData")
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkPassDbDataCached passes []DbData between nodes with decoded outputs that keep their serialized form.
+func BenchmarkPassDbDataCached(b *testing.B) {
+	serialized, _, _ := ConvertGivenTypeToString(testDbData(200), "[]DbData")
+	b.SetBytes(int64(len(serialized)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		current := serialized
+		for hop := 0; hop < workflowHops; hop++ {
+			output, err := DecodeFilledInputOutput("data", "[]DbData", current)
+			if err != nil {
+				b.Fatal(err)
+			}
+			current, err = FilledValueToString(&output, "[]DbData")
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkPassDbDataLazy passes []DbData between nodes with outputs that are never decoded.
+func BenchmarkPassDbDataLazy(b *testing.B) {
+	serialized, _, _ := ConvertGivenTypeToString(testDbData(200), "[]DbData")
+	b.SetBytes(int64(len(serialized)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		current := serialized
+		for hop := 0; hop < workflowHops; hop++ {
+			output := sharedtypes.NewSerializedFilledInputOutput("data", "[]DbData", current)
+			var err error
+			current, err = FilledValueToString(&output, "[]DbData")
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:generate go run ../../internal/gen/gotype/gen.go
+
+package typeconverters
+
+// GoType is the name of a Go type as used in function definitions, e.g. "[]DbResponse" or "map[string]any"
+// The GoType constants are generated from the type registry; regenerate them with go generate after adding types.
+type GoType string
+
+// String returns the Go type name
+//
+// Returns:
+//   - string: the Go type name
+func (t GoType) String() string {
+	return string(t)
+}
+
+// IsSupported reports whether the Go type can be converted from and to strings
+//
+// Returns:
+//   - bool: true if ConvertStringToGivenType and ConvertGivenTypeToString support the type
+func (t GoType) IsSupported() bool {
+	return IsSupported(string(t))
+}
+
+// IsSupported reports whether a Go type name can be converted from and to strings
+//
+// Parameters:
+//   - goType: the Go type name, e.g. "[]DbResponse"
+//
+// Returns:
+//   - bool: true if ConvertStringToGivenType and ConvertGivenTypeToString support the type
+func IsSupported(goType string) bool {
+	_, ok := typeRegistry[goType]
+	return ok
+}
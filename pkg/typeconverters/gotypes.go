@@ -0,0 +1,283 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Code generated by internal/gen/gotype/gen.go; DO NOT EDIT.
+
+package typeconverters
+
+// Go types supported by ConvertStringToGivenType and ConvertGivenTypeToString.
+const (
+	GoTypePtrConn                             GoType = "*Conn"
+	GoTypePtrChanInterface                    GoType = "*chan interface{}"
+	GoTypePtrChanString                       GoType = "*chan string"
+	GoTypeApiErrorResponse                    GoType = "ApiErrorResponse"
+	GoTypeArtifact                            GoType = "Artifact"
+	GoTypeChunkingConfig                      GoType = "ChunkingConfig"
+	GoTypeConversationDocument                GoType = "ConversationDocument"
+	GoTypeDbArrayFilter                       GoType = "DbArrayFilter"
+	GoTypeDbFilters                           GoType = "DbFilters"
+	GoTypeDiscoveryDimensions                 GoType = "DiscoveryDimensions"
+	GoTypeDiscoverySimulationInput            GoType = "DiscoverySimulationInput"
+	GoTypeEmbeddingRequest                    GoType = "EmbeddingRequest"
+	GoTypeEmbeddingResponse                   GoType = "EmbeddingResponse"
+	GoTypeFeedback                            GoType = "Feedback"
+	GoTypeFeedbackDocument                    GoType = "FeedbackDocument"
+	GoTypeFileTransfer                        GoType = "FileTransfer"
+	GoTypeListRequest                         GoType = "ListRequest"
+	GoTypeListWorkflowRunsRequest             GoType = "ListWorkflowRunsRequest"
+	GoTypeListWorkflowRunsResponse            GoType = "ListWorkflowRunsResponse"
+	GoTypeMCPConfig                           GoType = "MCPConfig"
+	GoTypeMCPPrompt                           GoType = "MCPPrompt"
+	GoTypeMCPResource                         GoType = "MCPResource"
+	GoTypeMCPServerCapabilities               GoType = "MCPServerCapabilities"
+	GoTypeMCPTool                             GoType = "MCPTool"
+	GoTypeModelOptions                        GoType = "ModelOptions"
+	GoTypePageRequest                         GoType = "PageRequest"
+	GoTypePageResponse                        GoType = "PageResponse"
+	GoTypeParameterMap                        GoType = "ParameterMap"
+	GoTypeProblemDetails                      GoType = "ProblemDetails"
+	GoTypeQuantity                            GoType = "Quantity"
+	GoTypeSlashCommand                        GoType = "SlashCommand"
+	GoTypeStartWorkflowRunRequest             GoType = "StartWorkflowRunRequest"
+	GoTypeSubject                             GoType = "Subject"
+	GoTypeToolCall                            GoType = "ToolCall"
+	GoTypeToolResult                          GoType = "ToolResult"
+	GoTypeWebhookConfig                       GoType = "WebhookConfig"
+	GoTypeWebhookPayload                      GoType = "WebhookPayload"
+	GoTypeWorkflowACL                         GoType = "WorkflowACL"
+	GoTypeWorkflowRunDocument                 GoType = "WorkflowRunDocument"
+	GoTypeWorkflowRunStatusResponse           GoType = "WorkflowRunStatusResponse"
+	GoTypeWorkflowRunValue                    GoType = "WorkflowRunValue"
+	GoTypeWorkflowTrigger                     GoType = "WorkflowTrigger"
+	GoTypeWsEnvelope                          GoType = "WsEnvelope"
+	GoTypeSliceACSSearchResponse              GoType = "[]ACSSearchResponse"
+	GoTypeSliceAedtApiDbResponse              GoType = "[]AedtApiDbResponse"
+	GoTypeSliceAedtCodeGenerationElement      GoType = "[]AedtCodeGenerationElement"
+	GoTypeSliceAedtCodeGenerationExample      GoType = "[]AedtCodeGenerationExample"
+	GoTypeSliceAnsysGPTCitation               GoType = "[]AnsysGPTCitation"
+	GoTypeSliceAnsysGPTDefaultFields          GoType = "[]AnsysGPTDefaultFields"
+	GoTypeSliceAnsysGPTRetrieverModuleChunk   GoType = "[]AnsysGPTRetrieverModuleChunk"
+	GoTypeSliceArtifact                       GoType = "[]Artifact"
+	GoTypeSliceCitation                       GoType = "[]Citation"
+	GoTypeSliceCodeGenerationElement          GoType = "[]CodeGenerationElement"
+	GoTypeSliceCodeGenerationExample          GoType = "[]CodeGenerationExample"
+	GoTypeSliceCodeGenerationUserGuideSection GoType = "[]CodeGenerationUserGuideSection"
+	GoTypeSliceContentPart                    GoType = "[]ContentPart"
+	GoTypeSliceConversationDocument           GoType = "[]ConversationDocument"
+	GoTypeSliceDbData                         GoType = "[]DbData"
+	GoTypeSliceDbJsonFilter                   GoType = "[]DbJsonFilter"
+	GoTypeSliceDbRangeFilter                  GoType = "[]DbRangeFilter"
+	GoTypeSliceDbResponse                     GoType = "[]DbResponse"
+	GoTypeSliceDiscoveryAttachment            GoType = "[]DiscoveryAttachment"
+	GoTypeSliceDiscoveryBoundaryCondition     GoType = "[]DiscoveryBoundaryCondition"
+	GoTypeSliceDiscoveryMaterial              GoType = "[]DiscoveryMaterial"
+	GoTypeSliceDiscoveryMonitors              GoType = "[]DiscoveryMonitors"
+	GoTypeSliceFeedbackDocument               GoType = "[]FeedbackDocument"
+	GoTypeSliceFileTransfer                   GoType = "[]FileTransfer"
+	GoTypeSliceHistoricMessage                GoType = "[]HistoricMessage"
+	GoTypeSliceMCPConfig                      GoType = "[]MCPConfig"
+	GoTypeSliceMCPPrompt                      GoType = "[]MCPPrompt"
+	GoTypeSliceMCPResource                    GoType = "[]MCPResource"
+	GoTypeSliceMCPTool                        GoType = "[]MCPTool"
+	GoTypeSliceMaterialAttribute              GoType = "[]MaterialAttribute"
+	GoTypeSliceMaterialCriterionWithGuid      GoType = "[]MaterialCriterionWithGuid"
+	GoTypeSliceMaterialLlmCriterion           GoType = "[]MaterialLlmCriterion"
+	GoTypeSliceMaterialSearchResult           GoType = "[]MaterialSearchResult"
+	GoTypeSliceQuantity                       GoType = "[]Quantity"
+	GoTypeSliceSlashCommand                   GoType = "[]SlashCommand"
+	GoTypeSliceToolCall                       GoType = "[]ToolCall"
+	GoTypeSliceToolResult                     GoType = "[]ToolResult"
+	GoTypeSliceWebhookConfig                  GoType = "[]WebhookConfig"
+	GoTypeSliceWorkflowRunDocument            GoType = "[]WorkflowRunDocument"
+	GoTypeSliceWorkflowRunStatusResponse      GoType = "[]WorkflowRunStatusResponse"
+	GoTypeSliceWorkflowTrigger                GoType = "[]WorkflowTrigger"
+	GoTypeSliceSliceAedtApiDbResponse         GoType = "[][]AedtApiDbResponse"
+	GoTypeSliceSliceDbResponse                GoType = "[][]DbResponse"
+	GoTypeSliceSliceFloat32                   GoType = "[][]float32"
+	GoTypeSliceSliceString                    GoType = "[][]string"
+	GoTypeSliceBool                           GoType = "[]bool"
+	GoTypeSliceByte                           GoType = "[]byte"
+	GoTypeSliceFloat32                        GoType = "[]float32"
+	GoTypeSliceFloat64                        GoType = "[]float64"
+	GoTypeSliceInt                            GoType = "[]int"
+	GoTypeSliceInterface                      GoType = "[]interface{}"
+	GoTypeSliceMapStringAny                   GoType = "[]map[string]any"
+	GoTypeSliceMapStringInterface             GoType = "[]map[string]interface{}"
+	GoTypeSliceMapStringString                GoType = "[]map[string]string"
+	GoTypeSliceMapUintFloat32                 GoType = "[]map[uint]float32"
+	GoTypeSliceString                         GoType = "[]string"
+	GoTypeAny                                 GoType = "any"
+	GoTypeBool                                GoType = "bool"
+	GoTypeFloat32                             GoType = "float32"
+	GoTypeFloat64                             GoType = "float64"
+	GoTypeInt                                 GoType = "int"
+	GoTypeInt16                               GoType = "int16"
+	GoTypeInt32                               GoType = "int32"
+	GoTypeInt64                               GoType = "int64"
+	GoTypeInt8                                GoType = "int8"
+	GoTypeInterface                           GoType = "interface{}"
+	GoTypeMapStringAedtElementContextsTuple   GoType = "map[string]AedtElementContextsTuple"
+	GoTypeMapStringSliceString                GoType = "map[string][]string"
+	GoTypeMapStringAny                        GoType = "map[string]any"
+	GoTypeMapStringBool                       GoType = "map[string]bool"
+	GoTypeMapStringFloat64                    GoType = "map[string]float64"
+	GoTypeMapStringInt                        GoType = "map[string]int"
+	GoTypeMapStringInterface                  GoType = "map[string]interface{}"
+	GoTypeMapStringMapStringString            GoType = "map[string]map[string]string"
+	GoTypeMapStringString                     GoType = "map[string]string"
+	GoTypeMapUintFloat32                      GoType = "map[uint]float32"
+	GoTypeString                              GoType = "string"
+	GoTypeUint                                GoType = "uint"
+	GoTypeUint16                              GoType = "uint16"
+	GoTypeUint32                              GoType = "uint32"
+	GoTypeUint64                              GoType = "uint64"
+	GoTypeUint8                               GoType = "uint8"
+)
+
+// GoTypes lists all supported Go types in ascending order.
+var GoTypes = []GoType{
+	GoTypePtrConn,
+	GoTypePtrChanInterface,
+	GoTypePtrChanString,
+	GoTypeApiErrorResponse,
+	GoTypeArtifact,
+	GoTypeChunkingConfig,
+	GoTypeConversationDocument,
+	GoTypeDbArrayFilter,
+	GoTypeDbFilters,
+	GoTypeDiscoveryDimensions,
+	GoTypeDiscoverySimulationInput,
+	GoTypeEmbeddingRequest,
+	GoTypeEmbeddingResponse,
+	GoTypeFeedback,
+	GoTypeFeedbackDocument,
+	GoTypeFileTransfer,
+	GoTypeListRequest,
+	GoTypeListWorkflowRunsRequest,
+	GoTypeListWorkflowRunsResponse,
+	GoTypeMCPConfig,
+	GoTypeMCPPrompt,
+	GoTypeMCPResource,
+	GoTypeMCPServerCapabilities,
+	GoTypeMCPTool,
+	GoTypeModelOptions,
+	GoTypePageRequest,
+	GoTypePageResponse,
+	GoTypeParameterMap,
+	GoTypeProblemDetails,
+	GoTypeQuantity,
+	GoTypeSlashCommand,
+	GoTypeStartWorkflowRunRequest,
+	GoTypeSubject,
+	GoTypeToolCall,
+	GoTypeToolResult,
+	GoTypeWebhookConfig,
+	GoTypeWebhookPayload,
+	GoTypeWorkflowACL,
+	GoTypeWorkflowRunDocument,
+	GoTypeWorkflowRunStatusResponse,
+	GoTypeWorkflowRunValue,
+	GoTypeWorkflowTrigger,
+	GoTypeWsEnvelope,
+	GoTypeSliceACSSearchResponse,
+	GoTypeSliceAedtApiDbResponse,
+	GoTypeSliceAedtCodeGenerationElement,
+	GoTypeSliceAedtCodeGenerationExample,
+	GoTypeSliceAnsysGPTCitation,
+	GoTypeSliceAnsysGPTDefaultFields,
+	GoTypeSliceAnsysGPTRetrieverModuleChunk,
+	GoTypeSliceArtifact,
+	GoTypeSliceCitation,
+	GoTypeSliceCodeGenerationElement,
+	GoTypeSliceCodeGenerationExample,
+	GoTypeSliceCodeGenerationUserGuideSection,
+	GoTypeSliceContentPart,
+	GoTypeSliceConversationDocument,
+	GoTypeSliceDbData,
+	GoTypeSliceDbJsonFilter,
+	GoTypeSliceDbRangeFilter,
+	GoTypeSliceDbResponse,
+	GoTypeSliceDiscoveryAttachment,
+	GoTypeSliceDiscoveryBoundaryCondition,
+	GoTypeSliceDiscoveryMaterial,
+	GoTypeSliceDiscoveryMonitors,
+	GoTypeSliceFeedbackDocument,
+	GoTypeSliceFileTransfer,
+	GoTypeSliceHistoricMessage,
+	GoTypeSliceMCPConfig,
+	GoTypeSliceMCPPrompt,
+	GoTypeSliceMCPResource,
+	GoTypeSliceMCPTool,
+	GoTypeSliceMaterialAttribute,
+	GoTypeSliceMaterialCriterionWithGuid,
+	GoTypeSliceMaterialLlmCriterion,
+	GoTypeSliceMaterialSearchResult,
+	GoTypeSliceQuantity,
+	GoTypeSliceSlashCommand,
+	GoTypeSliceToolCall,
+	GoTypeSliceToolResult,
+	GoTypeSliceWebhookConfig,
+	GoTypeSliceWorkflowRunDocument,
+	GoTypeSliceWorkflowRunStatusResponse,
+	GoTypeSliceWorkflowTrigger,
+	GoTypeSliceSliceAedtApiDbResponse,
+	GoTypeSliceSliceDbResponse,
+	GoTypeSliceSliceFloat32,
+	GoTypeSliceSliceString,
+	GoTypeSliceBool,
+	GoTypeSliceByte,
+	GoTypeSliceFloat32,
+	GoTypeSliceFloat64,
+	GoTypeSliceInt,
+	GoTypeSliceInterface,
+	GoTypeSliceMapStringAny,
+	GoTypeSliceMapStringInterface,
+	GoTypeSliceMapStringString,
+	GoTypeSliceMapUintFloat32,
+	GoTypeSliceString,
+	GoTypeAny,
+	GoTypeBool,
+	GoTypeFloat32,
+	GoTypeFloat64,
+	GoTypeInt,
+	GoTypeInt16,
+	GoTypeInt32,
+	GoTypeInt64,
+	GoTypeInt8,
+	GoTypeInterface,
+	GoTypeMapStringAedtElementContextsTuple,
+	GoTypeMapStringSliceString,
+	GoTypeMapStringAny,
+	GoTypeMapStringBool,
+	GoTypeMapStringFloat64,
+	GoTypeMapStringInt,
+	GoTypeMapStringInterface,
+	GoTypeMapStringMapStringString,
+	GoTypeMapStringString,
+	GoTypeMapUintFloat32,
+	GoTypeString,
+	GoTypeUint,
+	GoTypeUint16,
+	GoTypeUint32,
+	GoTypeUint64,
+	GoTypeUint8,
+}
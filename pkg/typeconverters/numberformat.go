@@ -0,0 +1,221 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package typeconverters
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+)
+
+// NonFinitePolicy defines how NaN and infinite floats are converted to strings
+type NonFinitePolicy string
+
+// Policies for NaN and infinite floats
+const (
+	// NonFiniteDefault keeps the behavior of ConvertGivenTypeToString: single floats become "NaN", "+Inf" or "-Inf",
+	// floats in slices and maps fail like in encoding/json
+	NonFiniteDefault NonFinitePolicy = ""
+	NonFiniteError   NonFinitePolicy = "error" // NaN and infinite floats are an error
+	NonFiniteNull    NonFinitePolicy = "null"  // NaN and infinite floats become null in slices and maps and "" as single float
+)
+
+// NumberFormat controls how floats are converted to strings by ConvertGivenTypeToStringWithFormat
+// Floats are always formatted locale-independent, with "." as decimal separator and without digit grouping.
+// The zero value formats like ConvertGivenTypeToString.
+type NumberFormat struct {
+	Decimals   *int            // digits after the decimal point; nil for the shortest representation that reads back to the same value
+	NoExponent bool            // never use exponent notation; by default floats in slices and maps below 1e-6 or from 1e21 use it, like encoding/json
+	NonFinite  NonFinitePolicy // handling of NaN and infinite floats
+}
+
+// ConvertGivenTypeToStringWithFormat converts a given Go type to a string, formatting floats as defined by format.
+// The format applies to float types and to slices, arrays and maps of floats, e.g. "[]float32" or "map[string]float64";
+// all other types, including floats inside structs, are converted as by ConvertGivenTypeToString.
+//
+// Parameters:
+// - value: an interface containing the value to convert
+// - goType: a string containing the Go type to convert from
+// - format: the format of floats
+//
+// Returns:
+// - output: a string containing the converted value
+// - exists: a bool indicating whether the conversion was successful
+// - err: an error containing the error message
+func ConvertGivenTypeToStringWithFormat(value interface{}, goType string, format NumberFormat) (output string, exists bool, err error) {
+	defer logging.RecoverPanic(nil, "ConvertGivenTypeToStringWithFormat", &err)
+
+	if format == (NumberFormat{}) || value == nil || !containsFloat(reflect.TypeOf(value)) {
+		return ConvertGivenTypeToString(value, goType)
+	}
+	if !IsSupported(goType) {
+		return "", false, nil
+	}
+
+	var out strings.Builder
+	if err := writeNumbers(&out, reflect.ValueOf(value), format, true); err != nil {
+		return "", true, err
+	}
+	return out.String(), true, nil
+}
+
+// containsFloat reports whether a type is a float or a slice, array, map or pointer leading to floats
+func containsFloat(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Pointer:
+		return containsFloat(t.Elem())
+	default:
+		return false
+	}
+}
+
+// writeNumbers writes a value containing floats as JSON, or as plain number if it is a single float
+//
+// Parameters:
+//   - out: the output
+//   - v: the value
+//   - format: the format of floats
+//   - single: true if v is the converted value itself, false if it is an element of a slice or map
+func writeNumbers(out *strings.Builder, v reflect.Value, format NumberFormat, single bool) error {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		text, err := formatFloat(v.Float(), v.Type().Bits(), format, single)
+		out.WriteString(text)
+		return err
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			out.WriteString("null")
+			return nil
+		}
+		return writeNumbers(out, v.Elem(), format, single)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			out.WriteString("null")
+			return nil
+		}
+		out.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			if err := writeNumbers(out, v.Index(i), format, false); err != nil {
+				return err
+			}
+		}
+		out.WriteByte(']')
+		return nil
+	case reflect.Map:
+		if v.IsNil() {
+			out.WriteString("null")
+			return nil
+		}
+		// keys are sorted by their string form, like in encoding/json
+		keys := map[string]reflect.Value{}
+		names := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			var name string
+			switch key.Kind() {
+			case reflect.String:
+				name = key.String()
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				name = strconv.FormatInt(key.Int(), 10)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				name = strconv.FormatUint(key.Uint(), 10)
+			default:
+				return fmt.Errorf("unsupported map key type %s", key.Type())
+			}
+			keys[name] = key
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		out.WriteByte('{')
+		for i, name := range names {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			quoted, _ := json.Marshal(name)
+			out.Write(quoted)
+			out.WriteByte(':')
+			if err := writeNumbers(out, v.MapIndex(keys[name]), format, false); err != nil {
+				return err
+			}
+		}
+		out.WriteByte('}')
+		return nil
+	default:
+		data, err := json.Marshal(v.Interface())
+		out.Write(data)
+		return err
+	}
+}
+
+// formatFloat formats a single float
+//
+// Parameters:
+//   - f: the float
+//   - bits: 32 or 64, the size of the float type
+//   - format: the format
+//   - single: true if the float is the converted value itself, false if it is an element of a slice or map
+//
+// Returns:
+//   - string: the formatted float
+//   - error: an error if the float is NaN or infinite and the policy does not allow it
+func formatFloat(f float64, bits int, format NumberFormat, single bool) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		switch {
+		case format.NonFinite == NonFiniteNull && single:
+			return "", nil
+		case format.NonFinite == NonFiniteNull:
+			return "null", nil
+		case format.NonFinite == NonFiniteDefault && single:
+			return strconv.FormatFloat(f, 'f', -1, bits), nil
+		default:
+			return "", fmt.Errorf("unsupported float value %v", f)
+		}
+	}
+
+	switch {
+	case format.Decimals != nil:
+		return strconv.FormatFloat(f, 'f', *format.Decimals, bits), nil
+	case single || format.NoExponent:
+		return strconv.FormatFloat(f, 'f', -1, bits), nil
+	default:
+		// the representation of encoding/json
+		var data []byte
+		var err error
+		if bits == 32 {
+			data, err = json.Marshal(float32(f))
+		} else {
+			data, err = json.Marshal(f)
+		}
+		return string(data), err
+	}
+}
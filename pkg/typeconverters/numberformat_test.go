@@ -0,0 +1,168 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package typeconverters
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvertGivenTypeToStringWithFormat(t *testing.T) {
+	two := 2
+	tests := []struct {
+		name     string
+		value    interface{}
+		goType   string
+		format   NumberFormat
+		expected string
+	}{
+		{"decimals", 3.14159, "float64", NumberFormat{Decimals: &two}, "3.14"},
+		{"decimals float32", float32(2.5), "float32", NumberFormat{Decimals: &two}, "2.50"},
+		{"slice without exponent", []float64{1e-7, 1e21}, "[]float64", NumberFormat{NoExponent: true}, "[0.0000001,1000000000000000000000]"},
+		{"slice with decimals", []float32{1, 0.126}, "[]float32", NumberFormat{Decimals: &two}, "[1.00,0.13]"},
+		{"nested slice", [][]float32{{1e-7}, nil}, "[][]float32", NumberFormat{NoExponent: true}, "[[0.0000001],null]"},
+		{"map sorted by key", map[uint]float32{10: 1e-7, 2: 1}, "map[uint]float32", NumberFormat{NoExponent: true}, `{"10":0.0000001,"2":1}`},
+		{"slice of maps", []map[uint]float32{{1: 0.5}}, "[]map[uint]float32", NumberFormat{Decimals: &two}, `[{"1":0.50}]`},
+		{"non-float type unchanged", []string{"a"}, "[]string", NumberFormat{NoExponent: true}, `["a"]`},
+		{"nan as null in slice", []float64{1, math.NaN()}, "[]float64", NumberFormat{NonFinite: NonFiniteNull}, "[1,null]"},
+		{"inf as empty single", math.Inf(1), "float64", NumberFormat{NonFinite: NonFiniteNull}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, exists, err := ConvertGivenTypeToStringWithFormat(tt.value, tt.goType, tt.format)
+			if err != nil || !exists {
+				t.Fatalf("ConvertGivenTypeToStringWithFormat() exists = %v, error = %v", exists, err)
+			}
+			if output != tt.expected {
+				t.Errorf("ConvertGivenTypeToStringWithFormat() = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+
+	if _, exists, _ := ConvertGivenTypeToStringWithFormat(1.5, "decimal", NumberFormat{NoExponent: true}); exists {
+		t.Error("unsupported type should not exist")
+	}
+}
+
+func TestConvertGivenTypeToStringWithFormatNonFinite(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		goType    string
+		policy    NonFinitePolicy
+		expected  string
+		expectErr bool
+	}{
+		{"default single", math.NaN(), "float64", NonFiniteDefault, "NaN", false},
+		{"default single inf", float32(math.Inf(-1)), "float32", NonFiniteDefault, "-Inf", false},
+		{"default slice", []float64{math.Inf(1)}, "[]float64", NonFiniteDefault, "", true},
+		{"error single", math.NaN(), "float64", NonFiniteError, "", true},
+		{"null slice", []float32{float32(math.Inf(1))}, "[]float32", NonFiniteNull, "[null]", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a non-zero format, so the formatter is used instead of ConvertGivenTypeToString
+			output, _, err := ConvertGivenTypeToStringWithFormat(tt.value, tt.goType, NumberFormat{NoExponent: true, NonFinite: tt.policy})
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if output != tt.expected {
+				t.Errorf("output = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNumberFormatZeroValueMatchesDefault(t *testing.T) {
+	values := []struct {
+		value  interface{}
+		goType string
+	}{
+		{1e-7, "float64"},
+		{float32(1e30), "float32"},
+		{[]float64{1e-7, 1e21, 0.1}, "[]float64"},
+		{[]float32{1e-7, 3.4e38}, "[]float32"},
+		{map[uint]float32{3: 1e-9, 20: 2}, "map[uint]float32"},
+	}
+	for _, v := range values {
+		want, _, wantErr := ConvertGivenTypeToString(v.value, v.goType)
+		got, _, err := ConvertGivenTypeToStringWithFormat(v.value, v.goType, NumberFormat{})
+		if got != want || (err != nil) != (wantErr != nil) {
+			t.Errorf("%s: got %q (%v), want %q (%v)", v.goType, got, err, want, wantErr)
+		}
+		// the formatter without options produces the same text as the default converter
+		if v.goType != "float64" && v.goType != "float32" {
+			var out strings.Builder
+			if err := writeNumbers(&out, reflect.ValueOf(v.value), NumberFormat{}, true); err != nil || out.String() != want {
+				t.Errorf("%s: writeNumbers() = %q (%v), want %q", v.goType, out.String(), err, want)
+			}
+		}
+	}
+}
+
+func TestNumberFormatRoundTripExtremes(t *testing.T) {
+	float64s := []float64{math.MaxFloat64, -math.MaxFloat64, math.SmallestNonzeroFloat64, 1e-300, 1e300, 123456789.123456789, -0.000001234}
+	float32s := []float32{math.MaxFloat32, math.SmallestNonzeroFloat32, 1e-20, 1e20, -3.4028235e37}
+	format := NumberFormat{NoExponent: true, NonFinite: NonFiniteError}
+
+	cases := []struct {
+		value  interface{}
+		goType string
+	}{
+		{float64s, "[]float64"},
+		{float32s, "[]float32"},
+		{[][]float32{float32s}, "[][]float32"},
+		{map[string]float64{"max": math.MaxFloat64, "min": math.SmallestNonzeroFloat64}, "map[string]float64"},
+	}
+	for _, f := range float64s {
+		cases = append(cases, struct {
+			value  interface{}
+			goType string
+		}{f, "float64"})
+	}
+	for _, f := range float32s {
+		cases = append(cases, struct {
+			value  interface{}
+			goType string
+		}{f, "float32"})
+	}
+
+	for _, c := range cases {
+		text, _, err := ConvertGivenTypeToStringWithFormat(c.value, c.goType, format)
+		if err != nil {
+			t.Fatalf("%s %v: error = %v", c.goType, c.value, err)
+		}
+		if strings.ContainsAny(text, "eE") {
+			t.Errorf("%s: %q uses exponent notation", c.goType, text)
+		}
+		back, _, err := ConvertStringToGivenType(text, c.goType)
+		if err != nil {
+			t.Fatalf("%s: cannot read back %q: %v", c.goType, text, err)
+		}
+		if !reflect.DeepEqual(back, c.value) {
+			t.Errorf("%s: round trip of %v gave %v", c.goType, c.value, back)
+		}
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"strings"
 
 	"github.com/ansys/aali-sharedtypes/pkg/aali_graphdb"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
 	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
 	"github.com/coder/websocket"
 )
@@ -246,25 +247,62 @@ func init() {
 		"ParameterMap": jsonMapConverter[aali_graphdb.ParameterMap](),
 
 		// Custom types - sharedtypes (structs)
-		"DbArrayFilter":            jsonMapConverter[sharedtypes.DbArrayFilter](),
-		"DbFilters":                jsonMapConverter[sharedtypes.DbFilters](),
-		"Feedback":                 jsonMapConverter[sharedtypes.Feedback](),
-		"ModelOptions":             jsonMapConverter[sharedtypes.ModelOptions](),
-		"MCPConfig":                jsonMapConverter[sharedtypes.MCPConfig](),
-		"MCPTool":                  jsonMapConverter[sharedtypes.MCPTool](),
-		"ToolCall":                 jsonMapConverter[sharedtypes.ToolCall](),
-		"ToolResult":               jsonMapConverter[sharedtypes.ToolResult](),
-		"SlashCommand":             jsonMapConverter[sharedtypes.SlashCommand](),
-		"DiscoverySimulationInput": jsonMapConverter[sharedtypes.DiscoverySimulationInput](),
-		"DiscoveryDimensions":      jsonMapConverter[sharedtypes.DiscoveryDimensions](),
+		"DbArrayFilter":             jsonMapConverter[sharedtypes.DbArrayFilter](),
+		"DbFilters":                 jsonMapConverter[sharedtypes.DbFilters](),
+		"ChunkingConfig":            jsonMapConverter[sharedtypes.ChunkingConfig](),
+		"PageRequest":               jsonMapConverter[sharedtypes.PageRequest](),
+		"ListRequest":               jsonMapConverter[sharedtypes.ListRequest](),
+		"ProblemDetails":            jsonMapConverter[sharedtypes.ProblemDetails](),
+		"PageResponse":              jsonMapConverter[sharedtypes.PageResponse](),
+		"Quantity":                  jsonMapConverter[sharedtypes.Quantity](),
+		"FileTransfer":              jsonMapConverter[sharedtypes.FileTransfer](),
+		"Artifact":                  jsonMapConverter[sharedtypes.Artifact](),
+		"[]Artifact":                jsonSliceConverter[sharedtypes.Artifact](),
+		"Feedback":                  jsonMapConverter[sharedtypes.Feedback](),
+		"ModelOptions":              jsonMapConverter[sharedtypes.ModelOptions](),
+		"EmbeddingRequest":          jsonMapConverter[sharedtypes.EmbeddingRequest](),
+		"EmbeddingResponse":         jsonMapConverter[sharedtypes.EmbeddingResponse](),
+		"MCPConfig":                 jsonMapConverter[sharedtypes.MCPConfig](),
+		"MCPPrompt":                 jsonMapConverter[sharedtypes.MCPPrompt](),
+		"MCPResource":               jsonMapConverter[sharedtypes.MCPResource](),
+		"MCPServerCapabilities":     jsonMapConverter[sharedtypes.MCPServerCapabilities](),
+		"MCPTool":                   jsonMapConverter[sharedtypes.MCPTool](),
+		"ToolCall":                  jsonMapConverter[sharedtypes.ToolCall](),
+		"ToolResult":                jsonMapConverter[sharedtypes.ToolResult](),
+		"SlashCommand":              jsonMapConverter[sharedtypes.SlashCommand](),
+		"DiscoverySimulationInput":  jsonMapConverter[sharedtypes.DiscoverySimulationInput](),
+		"DiscoveryDimensions":       jsonMapConverter[sharedtypes.DiscoveryDimensions](),
+		"WorkflowRunValue":          jsonMapConverter[sharedtypes.WorkflowRunValue](),
+		"StartWorkflowRunRequest":   jsonMapConverter[sharedtypes.StartWorkflowRunRequest](),
+		"WorkflowRunStatusResponse": jsonMapConverter[sharedtypes.WorkflowRunStatusResponse](),
+		"WorkflowTrigger":           jsonMapConverter[sharedtypes.WorkflowTrigger](),
+		"[]WorkflowTrigger":         jsonSliceConverter[sharedtypes.WorkflowTrigger](),
+		"WebhookConfig":             jsonMapConverter[sharedtypes.WebhookConfig](),
+		"[]WebhookConfig":           jsonSliceConverter[sharedtypes.WebhookConfig](),
+		"WebhookPayload":            jsonMapConverter[sharedtypes.WebhookPayload](),
+		"ListWorkflowRunsRequest":   jsonMapConverter[sharedtypes.ListWorkflowRunsRequest](),
+		"ListWorkflowRunsResponse":  jsonMapConverter[sharedtypes.ListWorkflowRunsResponse](),
+		"ApiErrorResponse":          jsonMapConverter[sharedtypes.ApiErrorResponse](),
+		"WsEnvelope":                jsonMapConverter[sharedtypes.WsEnvelope](),
+		"Subject":                   jsonMapConverter[sharedtypes.Subject](),
+		"WorkflowACL":               jsonMapConverter[sharedtypes.WorkflowACL](),
+		"ConversationDocument":      jsonMapConverter[sharedtypes.ConversationDocument](),
+		"[]ConversationDocument":    jsonSliceConverter[sharedtypes.ConversationDocument](),
+		"WorkflowRunDocument":       jsonMapConverter[sharedtypes.WorkflowRunDocument](),
+		"[]WorkflowRunDocument":     jsonSliceConverter[sharedtypes.WorkflowRunDocument](),
+		"FeedbackDocument":          jsonMapConverter[sharedtypes.FeedbackDocument](),
+		"[]FeedbackDocument":        jsonSliceConverter[sharedtypes.FeedbackDocument](),
 
 		// Custom types - sharedtypes (slices)
 		"[]DbJsonFilter":                   jsonSliceConverter[[]sharedtypes.DbJsonFilter](),
+		"[]DbRangeFilter":                  jsonSliceConverter[[]sharedtypes.DbRangeFilter](),
 		"[]DbResponse":                     jsonSliceConverter[[]sharedtypes.DbResponse](),
 		"[]HistoricMessage":                jsonSliceConverter[[]sharedtypes.HistoricMessage](),
+		"[]ContentPart":                    jsonSliceConverter[[]sharedtypes.ContentPart](),
 		"[]AnsysGPTDefaultFields":          jsonSliceConverter[[]sharedtypes.AnsysGPTDefaultFields](),
 		"[]ACSSearchResponse":              jsonSliceConverter[[]sharedtypes.ACSSearchResponse](),
 		"[]AnsysGPTCitation":               jsonSliceConverter[[]sharedtypes.AnsysGPTCitation](),
+		"[]Citation":                       jsonSliceConverter[[]sharedtypes.Citation](),
 		"[]AnsysGPTRetrieverModuleChunk":   jsonSliceConverter[[]sharedtypes.AnsysGPTRetrieverModuleChunk](),
 		"[]DbData":                         jsonSliceConverter[[]sharedtypes.DbData](),
 		"[]CodeGenerationElement":          jsonSliceConverter[[]sharedtypes.CodeGenerationElement](),
@@ -273,11 +311,17 @@ func init() {
 		"[]MaterialLlmCriterion":           jsonSliceConverter[[]sharedtypes.MaterialLlmCriterion](),
 		"[]MaterialCriterionWithGuid":      jsonSliceConverter[[]sharedtypes.MaterialCriterionWithGuid](),
 		"[]MaterialAttribute":              jsonSliceConverter[[]sharedtypes.MaterialAttribute](),
+		"[]MaterialSearchResult":           jsonSliceConverter[[]sharedtypes.MaterialSearchResult](),
+		"[]Quantity":                       jsonSliceConverter[[]sharedtypes.Quantity](),
+		"[]FileTransfer":                   jsonSliceConverter[[]sharedtypes.FileTransfer](),
 		"[]MCPConfig":                      jsonSliceConverter[[]sharedtypes.MCPConfig](),
+		"[]MCPPrompt":                      jsonSliceConverter[[]sharedtypes.MCPPrompt](),
+		"[]MCPResource":                    jsonSliceConverter[[]sharedtypes.MCPResource](),
 		"[]MCPTool":                        jsonSliceConverter[[]sharedtypes.MCPTool](),
 		"[]ToolCall":                       jsonSliceConverter[[]sharedtypes.ToolCall](),
 		"[]ToolResult":                     jsonSliceConverter[[]sharedtypes.ToolResult](),
 		"[]SlashCommand":                   jsonSliceConverter[[]sharedtypes.SlashCommand](),
+		"[]WorkflowRunStatusResponse":      jsonSliceConverter[[]sharedtypes.WorkflowRunStatusResponse](),
 		"[]DiscoveryMaterial":              jsonSliceConverter[[]sharedtypes.DiscoveryMaterial](),
 		"[]DiscoveryBoundaryCondition":     jsonSliceConverter[[]sharedtypes.DiscoveryBoundaryCondition](),
 		"[]DiscoveryMonitors":              jsonSliceConverter[[]sharedtypes.DiscoveryMonitors](),
@@ -502,12 +546,7 @@ func GetSupportedTypes() []string {
 // - exists: a bool indicating whether the conversion was successful
 // - err: an error containing the error message
 func ConvertStringToGivenType(value string, goType string) (output interface{}, exists bool, err error) {
-	defer func() {
-		r := recover()
-		if r != nil {
-			err = fmt.Errorf("panic occured in ConvertStringToGivenType: %v", r)
-		}
-	}()
+	defer logging.RecoverPanic(nil, "ConvertStringToGivenType", &err)
 
 	converter, ok := typeRegistry[goType]
 	if !ok {
@@ -529,12 +568,7 @@ func ConvertStringToGivenType(value string, goType string) (output interface{},
 // - exists: a bool indicating whether the conversion was successful
 // - err: an error containing the error message
 func ConvertGivenTypeToString(value interface{}, goType string) (output string, exists bool, err error) {
-	defer func() {
-		r := recover()
-		if r != nil {
-			err = fmt.Errorf("panic occured in ConvertGivenTypeToString: %v", r)
-		}
-	}()
+	defer logging.RecoverPanic(nil, "ConvertGivenTypeToString", &err)
 
 	converter, ok := typeRegistry[goType]
 	if !ok {
@@ -554,12 +588,7 @@ func ConvertGivenTypeToString(value interface{}, goType string) (output string,
 // Returns:
 // - err: an error containing the error message
 func DeepCopy(src, dst interface{}) (err error) {
-	defer func() {
-		r := recover()
-		if r != nil {
-			err = fmt.Errorf("panic occured in DeepCopy: %v", r)
-		}
-	}()
+	defer logging.RecoverPanic(nil, "DeepCopy", &err)
 
 	bytes, err := json.Marshal(src)
 	if err != nil {
@@ -567,3 +596,26 @@ func DeepCopy(src, dst interface{}) (err error) {
 	}
 	return json.Unmarshal(bytes, dst)
 }
+
+// ConvertWorkflowRunValues converts the typed inputs or outputs of a workflow run to Go values.
+//
+// Parameters:
+// - values: the workflow run values by name
+//
+// Returns:
+// - output: the converted values by name
+// - err: an error if a Go type is not supported or a value cannot be converted
+func ConvertWorkflowRunValues(values map[string]sharedtypes.WorkflowRunValue) (output map[string]interface{}, err error) {
+	output = make(map[string]interface{}, len(values))
+	for name, value := range values {
+		converted, exists, err := ConvertStringToGivenType(value.Value, value.GoType)
+		if !exists {
+			return nil, fmt.Errorf("unsupported go_type '%s' of '%s'", value.GoType, name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error converting '%s' to '%s': %w", name, value.GoType, err)
+		}
+		output[name] = converted
+	}
+	return output, nil
+}
@@ -23,10 +23,14 @@
 package typeconverters
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
+	"slices"
 	"testing"
 
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
 	"github.com/ansys/aali-sharedtypes/pkg/sharedtypes"
 )
 
@@ -251,6 +255,22 @@ func TestConvertGivenTypeToString_UnsupportedType(t *testing.T) {
 	}
 }
 
+func TestConvertGivenTypeToString_Panic(t *testing.T) {
+	// a value not matching the type makes the converter panic
+	_, _, err := ConvertGivenTypeToString(42, "string")
+	var panicErr *logging.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expected PanicError for mismatched value, got: %v", err)
+	}
+	if panicErr.Operation != "ConvertGivenTypeToString" || len(panicErr.Stack) == 0 {
+		t.Errorf("Unexpected panic error: operation=%q stack=%d bytes", panicErr.Operation, len(panicErr.Stack))
+	}
+	var runtimeErr runtime.Error
+	if !errors.As(err, &runtimeErr) {
+		t.Errorf("Expected PanicError to wrap the runtime error, got: %v", errors.Unwrap(err))
+	}
+}
+
 func TestGetSupportedTypes(t *testing.T) {
 	types := GetSupportedTypes()
 
@@ -300,6 +320,40 @@ func TestGetSupportedTypes_AllTypesHaveConverters(t *testing.T) {
 	}
 }
 
+func TestGoTypesMatchSupportedTypes(t *testing.T) {
+	// the GoType constants are generated; a mismatch means go generate was not run after changing the registry
+	supported := GetSupportedTypes()
+	slices.Sort(supported)
+	generated := make([]string, len(GoTypes))
+	for i, goType := range GoTypes {
+		generated[i] = goType.String()
+	}
+	if !slices.Equal(generated, supported) {
+		t.Errorf("GoTypes = %v, want %v; run go generate ./pkg/typeconverters", generated, supported)
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	tests := []struct {
+		goType string
+		want   bool
+	}{
+		{string(GoTypeSliceDbResponse), true},
+		{string(GoTypeMapStringAny), true},
+		{"map[string]any", true},
+		{"[]DBResponse", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsSupported(tt.goType); got != tt.want {
+			t.Errorf("IsSupported(%q) = %v, want %v", tt.goType, got, tt.want)
+		}
+	}
+	if !GoTypePtrChanString.IsSupported() || GoType("chan string").IsSupported() {
+		t.Error("GoType.IsSupported() disagrees with the registry")
+	}
+}
+
 func TestRoundTrip(t *testing.T) {
 	// Test that converting to string and back gives the same value
 	tests := []struct {
@@ -356,3 +410,44 @@ func TestDeepCopy(t *testing.T) {
 		t.Errorf("deep copy failed, got: %v, want: %v", *dst, src)
 	}
 }
+
+func TestConvertWorkflowRunValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]sharedtypes.WorkflowRunValue
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "valid values",
+			values: map[string]sharedtypes.WorkflowRunValue{
+				"query": {Value: "hello", GoType: "string"},
+				"topK":  {Value: "5", GoType: "int"},
+				"tags":  {Value: `["a","b"]`, GoType: "[]string"},
+			},
+			want: map[string]interface{}{"query": "hello", "topK": 5, "tags": []string{"a", "b"}},
+		},
+		{
+			name:    "unsupported type",
+			values:  map[string]sharedtypes.WorkflowRunValue{"x": {Value: "1", GoType: "complex128"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			values:  map[string]sharedtypes.WorkflowRunValue{"topK": {Value: "five", GoType: "int"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertWorkflowRunValues(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConvertWorkflowRunValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvertWorkflowRunValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
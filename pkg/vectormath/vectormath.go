@@ -0,0 +1,279 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package vectormath provides similarity, normalization and top-k selection of embedding vectors,
+// for reranking and deduplication in FlowKit functions.
+//
+// Vectors are []float32 as returned by the embedding models. Vectors of different lengths are never
+// compared: the functions return ErrDimensionMismatch instead. Zero vectors have a cosine similarity
+// of 0 to every vector and stay zero when normalized, so no NaN is ever produced from valid input.
+package vectormath
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ErrDimensionMismatch is returned when vectors of different lengths are compared.
+var ErrDimensionMismatch = errors.New("vector dimensions do not match")
+
+// Similarity scores two vectors of the same length; higher scores mean more similar vectors.
+type Similarity func(a, b []float32) (float32, error)
+
+// Match is a vector selected by TopK.
+type Match struct {
+	Index int     // index of the vector in the input
+	Score float32 // similarity of the vector to the query
+}
+
+// checkDimensions returns ErrDimensionMismatch if the vectors have different lengths
+func checkDimensions(a, b []float32) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("%w: %d != %d", ErrDimensionMismatch, len(a), len(b))
+	}
+	return nil
+}
+
+// dot computes the dot product of two vectors of the same length.
+// The loop is unrolled with independent accumulators and the slices are resliced so the compiler
+// eliminates the bounds checks, which lets the CPU pipeline the multiplications.
+func dot(a, b []float32) float32 {
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		s0 += a[i] * b[i]
+		s1 += a[i+1] * b[i+1]
+		s2 += a[i+2] * b[i+2]
+		s3 += a[i+3] * b[i+3]
+	}
+	for ; i < len(a); i++ {
+		s0 += a[i] * b[i]
+	}
+	return (s0 + s1) + (s2 + s3)
+}
+
+// Dot computes the dot product of two vectors.
+//
+// Parameters:
+//   - a: the first vector
+//   - b: the second vector
+//
+// Returns:
+//   - float32: the dot product
+//   - error: ErrDimensionMismatch if the vectors have different lengths
+func Dot(a, b []float32) (float32, error) {
+	if err := checkDimensions(a, b); err != nil {
+		return 0, err
+	}
+	return dot(a, b), nil
+}
+
+// Norm computes the Euclidean (L2) norm of a vector.
+//
+// Parameters:
+//   - v: the vector
+//
+// Returns:
+//   - float32: the norm
+func Norm(v []float32) float32 {
+	return float32(math.Sqrt(float64(dot(v, v))))
+}
+
+// Cosine computes the cosine similarity of two vectors, in [-1, 1].
+// For vectors normalized with Normalize, Dot gives the same result faster.
+//
+// Parameters:
+//   - a: the first vector
+//   - b: the second vector
+//
+// Returns:
+//   - float32: the cosine similarity; 0 if one of the vectors is zero
+//   - error: ErrDimensionMismatch if the vectors have different lengths
+func Cosine(a, b []float32) (float32, error) {
+	if err := checkDimensions(a, b); err != nil {
+		return 0, err
+	}
+	norms := Norm(a) * Norm(b)
+	if norms == 0 {
+		return 0, nil
+	}
+	// rounding can push the result of parallel vectors slightly outside of [-1, 1]
+	return float32(math.Max(-1, math.Min(1, float64(dot(a, b)/norms)))), nil
+}
+
+// Normalize returns the vector scaled to unit L2 norm; the input is not modified.
+//
+// Parameters:
+//   - v: the vector
+//
+// Returns:
+//   - []float32: the normalized vector; a copy of v if v is zero
+func Normalize(v []float32) []float32 {
+	normalized := make([]float32, len(v))
+	copy(normalized, v)
+	NormalizeInPlace(normalized)
+	return normalized
+}
+
+// NormalizeInPlace scales the vector to unit L2 norm.
+//
+// Parameters:
+//   - v: the vector; left unchanged if it is zero
+//
+// Returns:
+//   - bool: false if the vector is zero and could not be normalized
+func NormalizeInPlace(v []float32) bool {
+	norm := Norm(v)
+	if norm == 0 {
+		return false
+	}
+	scale := 1 / norm
+	for i := range v {
+		v[i] *= scale
+	}
+	return true
+}
+
+// TopK returns the k vectors most similar to the query, by descending score.
+// Ties are ordered by index and NaN scores rank below all others, so the result is deterministic.
+//
+// Parameters:
+//   - query: the query vector
+//   - vectors: the candidate vectors
+//   - k: the number of matches to return; all vectors are ranked if k is negative or larger than len(vectors)
+//   - similarity: the similarity function, e.g. Cosine or Dot
+//
+// Returns:
+//   - []Match: the matches
+//   - error: ErrDimensionMismatch if a vector has a different length than the query
+func TopK(query []float32, vectors [][]float32, k int, similarity Similarity) ([]Match, error) {
+	scores := make([]float32, len(vectors))
+	for i, vector := range vectors {
+		score, err := similarity(query, vector)
+		if err != nil {
+			return nil, fmt.Errorf("vector %d: %w", i, err)
+		}
+		scores[i] = score
+	}
+	return TopKScores(scores, k), nil
+}
+
+// TopKScores returns the k highest scores with their indexes, by descending score.
+// Ties are ordered by index and NaN scores rank below all others.
+//
+// Parameters:
+//   - scores: the scores
+//   - k: the number of matches to return; all scores are ranked if k is negative or larger than len(scores)
+//
+// Returns:
+//   - []Match: the matches
+func TopKScores(scores []float32, k int) []Match {
+	if k < 0 || k > len(scores) {
+		k = len(scores)
+	}
+	if k == 0 {
+		return []Match{}
+	}
+
+	// keep the k best matches in a min-heap, whose root is the worst of them
+	selected := make(matchHeap, 0, k)
+	for i, score := range scores {
+		match := Match{Index: i, Score: score}
+		if len(selected) < k {
+			heap.Push(&selected, match)
+		} else if better(match, selected[0]) {
+			selected[0] = match
+			heap.Fix(&selected, 0)
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool { return better(selected[i], selected[j]) })
+	return []Match(selected)
+}
+
+// better reports whether match a ranks before match b
+func better(a, b Match) bool {
+	aNaN, bNaN := math.IsNaN(float64(a.Score)), math.IsNaN(float64(b.Score))
+	switch {
+	case aNaN != bNaN:
+		return bNaN
+	case !aNaN && a.Score != b.Score:
+		return a.Score > b.Score
+	default:
+		return a.Index < b.Index
+	}
+}
+
+// matchHeap is a min-heap of matches ordered by rank, with the worst match at the root
+type matchHeap []Match
+
+func (h matchHeap) Len() int            { return len(h) }
+func (h matchHeap) Less(i, j int) bool  { return better(h[j], h[i]) }
+func (h matchHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *matchHeap) Push(x interface{}) { *h = append(*h, x.(Match)) }
+func (h *matchHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// Deduplicate returns the indexes of the vectors to keep when removing near duplicates.
+// Vectors are visited in order, and a vector is dropped if its cosine similarity to an already kept
+// vector is at least the threshold, so the first occurrence of duplicates is kept.
+//
+// Parameters:
+//   - vectors: the vectors, e.g. ordered by relevance
+//   - threshold: the cosine similarity from which vectors are duplicates, e.g. 0.95
+//
+// Returns:
+//   - []int: the indexes of the kept vectors, in ascending order
+//   - error: ErrDimensionMismatch if the vectors have different lengths
+func Deduplicate(vectors [][]float32, threshold float32) ([]int, error) {
+	kept := []int{}
+	normalized := make([][]float32, 0, len(vectors))
+	for i, vector := range vectors {
+		if len(normalized) > 0 {
+			if err := checkDimensions(normalized[0], vector); err != nil {
+				return nil, fmt.Errorf("vector %d: %w", i, err)
+			}
+		}
+		unit := Normalize(vector)
+
+		duplicate := false
+		for _, other := range normalized {
+			if dot(unit, other) >= threshold {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			kept = append(kept, i)
+			normalized = append(normalized, unit)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,240 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vectormath
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// approxEqual compares floats with a tolerance for the rounding of float32 sums
+func approxEqual(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 1e-5
+}
+
+func TestDot(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     []float32
+		expected float32
+	}{
+		{"empty", []float32{}, []float32{}, 0},
+		{"short", []float32{1, 2, 3}, []float32{4, 5, 6}, 32},
+		{"unrolled with remainder", []float32{1, 1, 1, 1, 1, 1}, []float32{1, 2, 3, 4, 5, 6}, 21},
+		{"orthogonal", []float32{1, 0, 0, 0}, []float32{0, 1, 0, 0}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Dot(tt.a, tt.b)
+			if err != nil || got != tt.expected {
+				t.Errorf("Dot() = %v, %v, want %v", got, err, tt.expected)
+			}
+		})
+	}
+
+	if _, err := Dot([]float32{1}, []float32{1, 2}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Dot() error = %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestDotMatchesNaiveLoop(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 7, 384, 1025} {
+		a, b := make([]float32, n), make([]float32, n)
+		var expected float64
+		for i := range a {
+			a[i], b[i] = random.Float32()*2-1, random.Float32()*2-1
+			expected += float64(a[i]) * float64(b[i])
+		}
+		if got, _ := Dot(a, b); math.Abs(float64(got)-expected) > 1e-3 {
+			t.Errorf("n=%d: Dot() = %v, want %v", n, got, expected)
+		}
+	}
+}
+
+func TestCosine(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     []float32
+		expected float32
+	}{
+		{"same direction", []float32{1, 2, 3}, []float32{2, 4, 6}, 1},
+		{"opposite", []float32{1, 2}, []float32{-1, -2}, -1},
+		{"orthogonal", []float32{1, 0}, []float32{0, 3}, 0},
+		{"zero vector", []float32{0, 0}, []float32{1, 1}, 0},
+		{"45 degrees", []float32{1, 0}, []float32{1, 1}, float32(math.Sqrt2 / 2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Cosine(tt.a, tt.b)
+			if err != nil || !approxEqual(got, tt.expected) {
+				t.Errorf("Cosine() = %v, %v, want %v", got, err, tt.expected)
+			}
+			if got > 1 || got < -1 {
+				t.Errorf("Cosine() = %v outside of [-1, 1]", got)
+			}
+		})
+	}
+
+	if _, err := Cosine([]float32{1, 2}, []float32{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Cosine() error = %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	v := []float32{3, 4}
+	got := Normalize(v)
+	if !approxEqual(got[0], 0.6) || !approxEqual(got[1], 0.8) || !approxEqual(Norm(got), 1) {
+		t.Errorf("Normalize() = %v, want [0.6 0.8]", got)
+	}
+	if v[0] != 3 || v[1] != 4 {
+		t.Errorf("Normalize() modified its input: %v", v)
+	}
+
+	zero := []float32{0, 0}
+	if NormalizeInPlace(zero) || !reflect.DeepEqual(zero, []float32{0, 0}) {
+		t.Errorf("NormalizeInPlace() of zero vector = %v, want unchanged and false", zero)
+	}
+	if got := Normalize(nil); len(got) != 0 {
+		t.Errorf("Normalize(nil) = %v, want empty", got)
+	}
+}
+
+func TestTopKScores(t *testing.T) {
+	nan := float32(math.NaN())
+	tests := []struct {
+		name     string
+		scores   []float32
+		k        int
+		expected []int
+	}{
+		{"top 2", []float32{0.1, 0.9, 0.5, 0.7}, 2, []int{1, 3}},
+		{"all with negative k", []float32{0.1, 0.9, 0.5}, -1, []int{1, 2, 0}},
+		{"k larger than scores", []float32{0.1, 0.9}, 5, []int{1, 0}},
+		{"zero k", []float32{0.1}, 0, []int{}},
+		{"ties by index", []float32{0.5, 0.9, 0.5, 0.5}, 3, []int{1, 0, 2}},
+		{"nan ranks last", []float32{nan, 0.1, nan, -1}, 3, []int{1, 3, 0}},
+		{"empty", nil, 3, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := TopKScores(tt.scores, tt.k)
+			indexes := []int{}
+			for _, match := range matches {
+				indexes = append(indexes, match.Index)
+				if score := tt.scores[match.Index]; match.Score != score && !math.IsNaN(float64(score)) {
+					t.Errorf("match %d has score %v, want %v", match.Index, match.Score, score)
+				}
+			}
+			if !reflect.DeepEqual(indexes, tt.expected) {
+				t.Errorf("TopKScores() indexes = %v, want %v", indexes, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTopKScoresMatchesSort(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	scores := make([]float32, 500)
+	for i := range scores {
+		// few distinct values, so ties are frequent
+		scores[i] = float32(random.Intn(20))
+	}
+	indexes := make([]int, len(scores))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool { return scores[indexes[i]] > scores[indexes[j]] })
+
+	for _, k := range []int{1, 10, 499, 500} {
+		matches := TopKScores(scores, k)
+		for i, match := range matches {
+			if match.Index != indexes[i] {
+				t.Fatalf("k=%d: match %d has index %d, want %d", k, i, match.Index, indexes[i])
+			}
+		}
+	}
+}
+
+func TestTopK(t *testing.T) {
+	query := []float32{1, 0}
+	vectors := [][]float32{{0, 1}, {10, 1}, {1, 1}, {-1, 0}}
+
+	matches, err := TopK(query, vectors, 2, Cosine)
+	if err != nil {
+		t.Fatalf("TopK() error = %v", err)
+	}
+	if len(matches) != 2 || matches[0].Index != 1 || matches[1].Index != 2 {
+		t.Errorf("TopK(Cosine) = %v, want indexes 1, 2", matches)
+	}
+
+	// the dot product favors long vectors
+	matches, _ = TopK(query, [][]float32{{1, 0}, {5, 5}}, 1, Dot)
+	if matches[0].Index != 1 || matches[0].Score != 5 {
+		t.Errorf("TopK(Dot) = %v, want index 1 with score 5", matches)
+	}
+
+	if _, err := TopK(query, [][]float32{{1, 0}, {1}}, 1, Cosine); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("TopK() error = %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func TestDeduplicate(t *testing.T) {
+	vectors := [][]float32{
+		{1, 0, 0},
+		{0, 1, 0},
+		{2, 0.01, 0}, // same direction as the first vector
+		{0, 0, 1},
+		{0, 0.99, 0.01},
+	}
+	kept, err := Deduplicate(vectors, 0.99)
+	if err != nil {
+		t.Fatalf("Deduplicate() error = %v", err)
+	}
+	if !reflect.DeepEqual(kept, []int{0, 1, 3}) {
+		t.Errorf("Deduplicate() = %v, want [0 1 3]", kept)
+	}
+
+	if kept, _ := Deduplicate(nil, 0.9); len(kept) != 0 {
+		t.Errorf("Deduplicate(nil) = %v, want empty", kept)
+	}
+	if _, err := Deduplicate([][]float32{{1, 0}, {1}}, 0.9); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Deduplicate() error = %v, want ErrDimensionMismatch", err)
+	}
+}
+
+func BenchmarkDot(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	x, y := make([]float32, 1024), make([]float32, 1024)
+	for i := range x {
+		x[i], y[i] = random.Float32(), random.Float32()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dot(x, y)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package workerpool
+
+import (
+	"context"
+)
+
+// Future is the result of a submitted task
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Submit submits a task to the pool, blocking while the queue is full
+//
+// Parameters:
+//   - ctx: the context of the task; the task is not started if ctx is done before
+//   - p: the worker pool
+//   - fn: the task
+//
+// Returns:
+//   - *Future[T]: the future result of the task
+//   - error: ErrPoolClosed if the pool is shut down, or the context error if ctx is done while waiting for the queue
+func Submit[T any](ctx context.Context, p *Pool, fn func(ctx context.Context) (T, error)) (*Future[T], error) {
+	f, t := newFuture(fn)
+	if err := p.enqueue(ctx, t, true); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// TrySubmit submits a task to the pool if its queue is not full
+//
+// Parameters:
+//   - ctx: the context of the task; the task is not started if ctx is done before
+//   - p: the worker pool
+//   - fn: the task
+//
+// Returns:
+//   - *Future[T]: the future result of the task
+//   - error: ErrQueueFull if the queue is full, or ErrPoolClosed if the pool is shut down
+func TrySubmit[T any](ctx context.Context, p *Pool, fn func(ctx context.Context) (T, error)) (*Future[T], error) {
+	f, t := newFuture(fn)
+	if err := p.enqueue(ctx, t, false); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// newFuture creates a future and the task completing it
+//
+// Parameters:
+//   - fn: the task function
+//
+// Returns:
+//   - *Future[T]: the future
+//   - *task: the task completing the future once finished, skipped or panicked
+func newFuture[T any](fn func(ctx context.Context) (T, error)) (*Future[T], *task) {
+	f := &Future[T]{done: make(chan struct{})}
+	t := &task{
+		run: func(ctx context.Context) (err error) {
+			f.value, err = fn(ctx)
+			return err
+		},
+		complete: func(err error) {
+			f.err = err
+			close(f.done)
+		},
+	}
+	return f, t
+}
+
+// Done returns a channel that is closed when the task is finished
+//
+// Returns:
+//   - <-chan struct{}: the channel
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait waits for the result of the task
+//
+// Parameters:
+//   - ctx: the context limiting the wait; the task keeps running if ctx is done
+//
+// Returns:
+//   - T: the result of the task
+//   - error: the error of the task, or the context error if ctx is done first
+func (f *Future[T]) Wait(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package workerpool provides a context-aware pool of workers with a bounded queue, used for the workflow
+// workers of the agent and the internal parallelism of FlowKit.
+//
+// Tasks are submitted with Submit, TrySubmit or Pool.Go. Panics of tasks are recovered and returned as
+// *logging.PanicError. The queue depth and the wait and run latencies are sent as the metrics
+// QueueDepthMetricName, WaitMetricName and RunMetricName, tagged with "pool:<name>".
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+)
+
+// Names of the metrics sent by the worker pools
+const (
+	QueueDepthMetricName = "aali.workerpool.queue_depth"
+	WaitMetricName       = "aali.workerpool.wait_ms"
+	RunMetricName        = "aali.workerpool.run_ms"
+)
+
+// ErrPoolClosed is returned when submitting a task to a pool that is shut down
+var ErrPoolClosed = errors.New("worker pool is closed")
+
+// ErrQueueFull is returned by TrySubmit if the queue of the pool is full
+var ErrQueueFull = errors.New("worker pool queue is full")
+
+// Options configures a worker pool
+type Options struct {
+	Name      string // name of the pool, used in logs and metric tags
+	Workers   int    // number of workers; defaults to the number of CPUs
+	QueueSize int    // maximum number of queued tasks; defaults to the number of workers
+}
+
+// Stats are the counters of a worker pool
+type Stats struct {
+	Workers    int   // number of workers
+	QueueDepth int   // number of queued tasks
+	Running    int64 // number of running tasks
+	Completed  int64 // number of finished tasks, including failed ones
+	Failed     int64 // number of tasks that returned an error or panicked
+}
+
+// Pool runs submitted tasks on a fixed number of workers
+type Pool struct {
+	name    string
+	workers int
+	tasks   chan *task
+
+	// mu guards closed; submitters hold the read lock while sending to tasks
+	mu        sync.RWMutex
+	closed    bool
+	closing   chan struct{} // closed by Shutdown to release submitters waiting for the queue
+	closeOnce sync.Once
+
+	ctx     context.Context // cancelled when the pool is stopped
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running atomic.Int64
+	done    atomic.Int64
+	failed  atomic.Int64
+}
+
+// task is a queued task
+type task struct {
+	ctx      context.Context
+	run      func(ctx context.Context) error
+	complete func(err error) // called with the final error of the task; can be nil
+	queuedAt time.Time
+}
+
+// New creates a worker pool and starts its workers
+//
+// Parameters:
+//   - opts: the pool options
+//
+// Returns:
+//   - *Pool: the worker pool
+func New(opts Options) *Pool {
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = opts.Workers
+	}
+	if opts.Name == "" {
+		opts.Name = "default"
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		name:    opts.Name,
+		workers: opts.Workers,
+		tasks:   make(chan *task, opts.QueueSize),
+		closing: make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	p.wg.Add(opts.Workers)
+	for i := 0; i < opts.Workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+// NewFromConfig creates a worker pool with NUMBER_OF_WORKFLOW_WORKERS workers from the global config
+//
+// Parameters:
+//   - name: the name of the pool
+//
+// Returns:
+//   - *Pool: the worker pool
+func NewFromConfig(name string) *Pool {
+	opts := Options{Name: name}
+	if config.Current() != nil {
+		opts.Workers = config.Current().NUMBER_OF_WORKFLOW_WORKERS
+	}
+	return New(opts)
+}
+
+// Stats returns the current counters of the pool
+//
+// Returns:
+//   - Stats: the counters
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Workers:    p.workers,
+		QueueDepth: len(p.tasks),
+		Running:    p.running.Load(),
+		Completed:  p.done.Load(),
+		Failed:     p.failed.Load(),
+	}
+}
+
+// Go submits a task without waiting for its result, blocking while the queue is full
+// Errors returned by the task are logged.
+//
+// Parameters:
+//   - ctx: the context of the task; the task is not started if ctx is done before
+//   - fn: the task
+//
+// Returns:
+//   - error: ErrPoolClosed if the pool is shut down, or the context error if ctx is done while waiting for the queue
+func (p *Pool) Go(ctx context.Context, fn func(ctx context.Context) error) error {
+	return p.enqueue(ctx, &task{
+		run: fn,
+		complete: func(err error) {
+			if err != nil {
+				logging.Log.Errorf(&logging.ContextMap{}, "task of worker pool '%s' failed: %v", p.name, err)
+			}
+		},
+	}, true)
+}
+
+// Shutdown stops accepting tasks and waits until the queued and running tasks are finished
+// If ctx is done before, the running tasks are cancelled through their context and queued tasks are dropped;
+// Shutdown returns without waiting for the cancelled tasks to return.
+//
+// Parameters:
+//   - ctx: the context limiting the wait
+//
+// Returns:
+//   - error: the context error if ctx is done before all tasks are finished
+func (p *Pool) Shutdown(ctx context.Context) error {
+	// release the submitters waiting for the queue, so they do not hold the read lock
+	p.closeOnce.Do(func() { close(p.closing) })
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+}
+
+// enqueue adds a task to the queue
+//
+// Parameters:
+//   - ctx: the context of the task
+//   - t: the task
+//   - block: true to wait while the queue is full, false to return ErrQueueFull
+//
+// Returns:
+//   - error: ErrPoolClosed, ErrQueueFull or the context error if the task was not queued
+func (p *Pool) enqueue(ctx context.Context, t *task, block bool) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	t.ctx = ctx
+	t.queuedAt = time.Now()
+	if block {
+		select {
+		case p.tasks <- t:
+		case <-p.closing:
+			return ErrPoolClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	} else {
+		select {
+		case p.tasks <- t:
+		default:
+			return ErrQueueFull
+		}
+	}
+
+	logging.Log.MetricsWithTags(QueueDepthMetricName, float64(len(p.tasks)), p.metricTag())
+	return nil
+}
+
+// worker runs queued tasks until the queue is closed
+func (p *Pool) worker() {
+	defer p.wg.Done()
+	for t := range p.tasks {
+		p.run(t)
+	}
+}
+
+// run runs a task, recovering panics
+// The task context is cancelled if the pool is stopped.
+//
+// Parameters:
+//   - t: the task
+func (p *Pool) run(t *task) {
+	ctx, cancel := context.WithCancel(t.ctx)
+	defer cancel()
+	stop := context.AfterFunc(p.ctx, cancel)
+	defer stop()
+
+	started := time.Now()
+	logging.Log.MetricsWithTags(WaitMetricName, float64(started.Sub(t.queuedAt).Milliseconds()), p.metricTag())
+
+	p.running.Add(1)
+	err := p.call(ctx, t.run)
+	p.running.Add(-1)
+
+	logging.Log.MetricsWithTags(RunMetricName, float64(time.Since(started).Milliseconds()), p.metricTag())
+	p.done.Add(1)
+	if err != nil {
+		p.failed.Add(1)
+	}
+	if t.complete != nil {
+		t.complete(err)
+	}
+}
+
+// call calls a task, skipping it if its context is already done or the pool is stopped
+//
+// Parameters:
+//   - ctx: the context of the task
+//   - fn: the task
+//
+// Returns:
+//   - err: the error of the task, the context error if skipped, or a *logging.PanicError if it panicked
+func (p *Pool) call(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer logging.RecoverPanic(nil, fmt.Sprintf("task of worker pool '%s'", p.name), &err)
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
+
+// metricTag returns the tag identifying the pool in its metrics
+//
+// Returns:
+//   - string: the metric tag
+func (p *Pool) metricTag() string {
+	return "pool:" + p.name
+}
@@ -0,0 +1,315 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+)
+
+func TestSubmit(t *testing.T) {
+	logging.InitForTest()
+	p := New(Options{Name: "test", Workers: 4, QueueSize: 16})
+	defer p.Shutdown(context.Background())
+
+	futures := make([]*Future[int], 100)
+	for i := range futures {
+		f, err := Submit(context.Background(), p, func(ctx context.Context) (int, error) {
+			return i * i, nil
+		})
+		if err != nil {
+			t.Fatalf("Submit(%d) error = %v", i, err)
+		}
+		futures[i] = f
+	}
+	for i, f := range futures {
+		got, err := f.Wait(context.Background())
+		if err != nil || got != i*i {
+			t.Errorf("Wait() of task %d = %d, %v, want %d, nil", i, got, err, i*i)
+		}
+	}
+
+	stats := p.Stats()
+	if stats.Completed != 100 || stats.Failed != 0 || stats.Workers != 4 {
+		t.Errorf("Stats() = %+v, want 100 completed, 0 failed, 4 workers", stats)
+	}
+}
+
+func TestSubmitErrors(t *testing.T) {
+	logging.InitForTest()
+	p := New(Options{Name: "test", Workers: 1})
+	defer p.Shutdown(context.Background())
+
+	errTask := errors.New("task failed")
+	tests := []struct {
+		name string
+		fn   func(ctx context.Context) (string, error)
+		want func(err error) bool
+	}{
+		{
+			name: "error",
+			fn:   func(ctx context.Context) (string, error) { return "", errTask },
+			want: func(err error) bool { return errors.Is(err, errTask) },
+		},
+		{
+			name: "panic",
+			fn:   func(ctx context.Context) (string, error) { panic("boom") },
+			want: func(err error) bool {
+				var panicErr *logging.PanicError
+				return errors.As(err, &panicErr) && panicErr.Value == "boom"
+			},
+		},
+		{
+			name: "panic with error",
+			fn:   func(ctx context.Context) (string, error) { panic(errTask) },
+			want: func(err error) bool { return errors.Is(err, errTask) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Submit(context.Background(), p, tt.fn)
+			if err != nil {
+				t.Fatalf("Submit() error = %v", err)
+			}
+			_, err = f.Wait(context.Background())
+			if !tt.want(err) {
+				t.Errorf("Wait() error = %v", err)
+			}
+		})
+	}
+
+	// the worker survives the panics
+	f, err := Submit(context.Background(), p, func(ctx context.Context) (string, error) { return "ok", nil })
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if got, err := f.Wait(context.Background()); err != nil || got != "ok" {
+		t.Errorf("Wait() = %q, %v, want ok, nil", got, err)
+	}
+	if stats := p.Stats(); stats.Failed != 3 {
+		t.Errorf("Stats().Failed = %d, want 3", stats.Failed)
+	}
+}
+
+func TestTrySubmitQueueFull(t *testing.T) {
+	logging.InitForTest()
+	p := New(Options{Name: "test", Workers: 1, QueueSize: 1})
+	defer p.Shutdown(context.Background())
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocking := func(ctx context.Context) (struct{}, error) {
+		close(started)
+		<-release
+		return struct{}{}, nil
+	}
+	noop := func(ctx context.Context) (struct{}, error) { return struct{}{}, nil }
+
+	// occupy the worker, then fill the queue
+	if _, err := TrySubmit(context.Background(), p, blocking); err != nil {
+		t.Fatalf("TrySubmit() error = %v", err)
+	}
+	<-started
+	if _, err := TrySubmit(context.Background(), p, noop); err != nil {
+		t.Fatalf("TrySubmit() error = %v", err)
+	}
+	if _, err := TrySubmit(context.Background(), p, noop); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("TrySubmit() on full queue error = %v, want ErrQueueFull", err)
+	}
+	if depth := p.Stats().QueueDepth; depth != 1 {
+		t.Errorf("Stats().QueueDepth = %d, want 1", depth)
+	}
+
+	// Submit blocks on the full queue until its context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := Submit(ctx, p, noop); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Submit() on full queue error = %v, want context.DeadlineExceeded", err)
+	}
+	close(release)
+}
+
+func TestSubmitCancelledContext(t *testing.T) {
+	logging.InitForTest()
+	p := New(Options{Name: "test", Workers: 1, QueueSize: 1})
+	defer p.Shutdown(context.Background())
+
+	release := make(chan struct{})
+	if err := p.Go(context.Background(), func(ctx context.Context) error {
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Go() error = %v", err)
+	}
+
+	// a task whose context is done before it starts is skipped
+	ctx, cancel := context.WithCancel(context.Background())
+	var ran atomic.Bool
+	f, err := Submit(ctx, p, func(ctx context.Context) (int, error) {
+		ran.Store(true)
+		return 1, nil
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	cancel()
+	close(release)
+
+	if _, err := f.Wait(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() error = %v, want context.Canceled", err)
+	}
+	if ran.Load() {
+		t.Error("task with cancelled context was run")
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	logging.InitForTest()
+	p := New(Options{Name: "test", Workers: 2, QueueSize: 10})
+
+	var count atomic.Int32
+	for i := 0; i < 10; i++ {
+		if err := p.Go(context.Background(), func(ctx context.Context) error {
+			time.Sleep(time.Millisecond)
+			count.Add(1)
+			return nil
+		}); err != nil {
+			t.Fatalf("Go() error = %v", err)
+		}
+	}
+
+	// queued tasks are finished before Shutdown returns
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if got := count.Load(); got != 10 {
+		t.Errorf("finished tasks = %d, want 10", got)
+	}
+
+	if err := p.Go(context.Background(), func(ctx context.Context) error { return nil }); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Go() after Shutdown error = %v, want ErrPoolClosed", err)
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Errorf("second Shutdown() error = %v", err)
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	logging.InitForTest()
+	p := New(Options{Name: "test", Workers: 1, QueueSize: 1})
+
+	started := make(chan struct{})
+	running, err := Submit(context.Background(), p, func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	<-started
+	queued, err := Submit(context.Background(), p, func(ctx context.Context) (int, error) { return 1, nil })
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	// the running task is cancelled and the queued task is dropped when the shutdown context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := p.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want context.DeadlineExceeded", err)
+	}
+	if _, err := running.Wait(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("running task error = %v, want context.Canceled", err)
+	}
+	if _, err := queued.Wait(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("queued task error = %v, want context.Canceled", err)
+	}
+}
+
+func TestShutdownDoesNotWaitAfterTimeout(t *testing.T) {
+	logging.InitForTest()
+	p := New(Options{Name: "test", Workers: 1, QueueSize: 1})
+
+	// a task ignoring its context keeps running after the shutdown context is done
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	if err := p.Go(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Go() error = %v", err)
+	}
+	<-started
+	if err := p.Go(context.Background(), func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("Go() error = %v", err)
+	}
+
+	// a submitter blocked on the full queue is released by Shutdown
+	blocked := make(chan error, 1)
+	go func() {
+		blocked <- p.Go(context.Background(), func(ctx context.Context) error { return nil })
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	returned := make(chan error, 1)
+	go func() { returned <- p.Shutdown(ctx) }()
+	select {
+	case err := <-returned:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Shutdown() error = %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown() did not return after its context was done")
+	}
+	if err := <-blocked; !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("blocked Go() error = %v, want ErrPoolClosed", err)
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	logging.InitForTest()
+	previous := config.GlobalConfig
+	defer func() { config.GlobalConfig = previous }()
+
+	config.GlobalConfig = &config.Config{NUMBER_OF_WORKFLOW_WORKERS: 3}
+	p := NewFromConfig("workflows")
+	defer p.Shutdown(context.Background())
+
+	if got := p.Stats().Workers; got != 3 {
+		t.Errorf("Workers = %d, want 3", got)
+	}
+	if got := p.metricTag(); got != "pool:workflows" {
+		t.Errorf("metricTag() = %q", got)
+	}
+}
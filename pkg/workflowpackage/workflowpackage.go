@@ -0,0 +1,350 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package workflowpackage defines the portable workflow package format, so workflows can be
+// distributed together with their binaries and verified before they are loaded.
+//
+// A package is a zip archive containing
+//
+//	manifest.json      the Manifest with the SHA-256 hash and size of every other file
+//	manifest.sig       optional Ed25519 signature of manifest.json
+//	workflow.json      the workflow definition
+//	binaries/<path>    the binaries referenced by the workflow
+//
+// Unpack checks the files against the manifest; Package.Verify checks the signature against
+// the trusted keys and, with PRODUCTION_MODE, refuses unsigned packages.
+package workflowpackage
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+// Names of the files of a package
+const (
+	ManifestFile   = "manifest.json"
+	SignatureFile  = "manifest.sig"
+	DefinitionFile = "workflow.json"
+	BinariesDir    = "binaries/"
+)
+
+// FormatVersion is the version of the package format written by Pack.
+const FormatVersion = 1
+
+// ErrUnsigned is returned by Verify if a signature is required but the package is unsigned.
+var ErrUnsigned = errors.New("workflow package is not signed")
+
+// ErrInvalidSignature is returned by Verify if the signature does not match any trusted key.
+var ErrInvalidSignature = errors.New("workflow package signature is not valid for any trusted key")
+
+// FileEntry describes a file of a package.
+type FileEntry struct {
+	Path   string `json:"path"`
+	Sha256 string `json:"sha256"` // hex encoded
+	Size   int64  `json:"size"`
+}
+
+// Manifest describes the contents of a package.
+type Manifest struct {
+	FormatVersion int         `json:"formatVersion"`
+	WorkflowId    string      `json:"workflowId"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	Definition    FileEntry   `json:"definition"`
+	Binaries      []FileEntry `json:"binaries,omitempty"` // sorted by path
+}
+
+// Contents are the files packed into a package.
+type Contents struct {
+	WorkflowId string
+	Definition []byte
+	Binaries   map[string][]byte // by slash-separated path relative to the binaries directory
+}
+
+// Package is an unpacked package whose files match its manifest.
+type Package struct {
+	Manifest   Manifest
+	Definition []byte
+	Binaries   map[string][]byte // by path relative to the binaries directory
+	Signature  []byte            // nil if the package is unsigned
+
+	manifestBytes []byte
+}
+
+// packedFile is a file written by Pack.
+type packedFile struct {
+	name string
+	data []byte
+}
+
+// Policy decides which packages Verify accepts.
+type Policy struct {
+	TrustedKeys      []ed25519.PublicKey
+	RequireSignature bool // refuse unsigned packages
+}
+
+// PolicyFromConfig returns the policy of the global config: the keys of WORKFLOW_PACKAGE_PUBLIC_KEYS are
+// trusted, and signatures are required if PRODUCTION_MODE is set.
+//
+// Returns:
+//   - Policy: the policy
+//   - error: an error if a key is not a base64 encoded Ed25519 public key
+func PolicyFromConfig() (Policy, error) {
+	policy := Policy{RequireSignature: config.Current().PRODUCTION_MODE}
+	for i, encoded := range config.Current().WORKFLOW_PACKAGE_PUBLIC_KEYS {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return Policy{}, fmt.Errorf("WORKFLOW_PACKAGE_PUBLIC_KEYS entry %d is not a base64 encoded Ed25519 public key", i)
+		}
+		policy.TrustedKeys = append(policy.TrustedKeys, ed25519.PublicKey(key))
+	}
+	return policy, nil
+}
+
+// Pack writes a package.
+//
+// Parameters:
+//   - w: the writer of the zip archive
+//   - contents: the files to pack
+//   - signingKey: the key signing the manifest; the package is unsigned if nil
+//
+// Returns:
+//   - Manifest: the manifest of the package
+//   - error: an error if a binary path is invalid or writing fails
+func Pack(w io.Writer, contents Contents, signingKey ed25519.PrivateKey) (Manifest, error) {
+	manifest := Manifest{
+		FormatVersion: FormatVersion,
+		WorkflowId:    contents.WorkflowId,
+		CreatedAt:     time.Now().UTC().Truncate(time.Second),
+		Definition:    fileEntry(DefinitionFile, contents.Definition),
+	}
+	paths := make([]string, 0, len(contents.Binaries))
+	for binaryPath := range contents.Binaries {
+		if err := validateBinaryPath(binaryPath); err != nil {
+			return Manifest{}, err
+		}
+		paths = append(paths, binaryPath)
+	}
+	sort.Strings(paths)
+	for _, binaryPath := range paths {
+		manifest.Binaries = append(manifest.Binaries, fileEntry(BinariesDir+binaryPath, contents.Binaries[binaryPath]))
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	files := []packedFile{{ManifestFile, manifestBytes}}
+	if signingKey != nil {
+		files = append(files, packedFile{SignatureFile, ed25519.Sign(signingKey, manifestBytes)})
+	}
+	files = append(files, packedFile{DefinitionFile, contents.Definition})
+	for _, binaryPath := range paths {
+		files = append(files, packedFile{BinariesDir + binaryPath, contents.Binaries[binaryPath]})
+	}
+
+	archive := zip.NewWriter(w)
+	for _, file := range files {
+		writer, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: manifest.CreatedAt})
+		if err != nil {
+			return Manifest{}, err
+		}
+		if _, err := writer.Write(file.data); err != nil {
+			return Manifest{}, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+// Unpack reads a package and checks that its files match the manifest.
+// The signature is read but not verified, see Package.Verify.
+//
+// Parameters:
+//   - r: the reader of the zip archive
+//   - size: the size of the archive
+//
+// Returns:
+//   - *Package: the package
+//   - error: an error if the archive is invalid or a file is missing, unlisted or modified
+func Unpack(r io.ReaderAt, size int64) (*Package, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("error reading workflow package: %w", err)
+	}
+	files := map[string]*zip.File{}
+	for _, file := range archive.File {
+		if strings.HasSuffix(file.Name, "/") {
+			continue
+		}
+		if _, ok := files[file.Name]; ok {
+			return nil, fmt.Errorf("workflow package contains '%s' more than once", file.Name)
+		}
+		files[file.Name] = file
+	}
+
+	manifestFile, ok := files[ManifestFile]
+	if !ok {
+		return nil, fmt.Errorf("workflow package has no %s", ManifestFile)
+	}
+	manifestBytes, err := readFile(manifestFile, 1<<20)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &Package{manifestBytes: manifestBytes, Binaries: map[string][]byte{}}
+	if err := json.Unmarshal(manifestBytes, &pkg.Manifest); err != nil {
+		return nil, fmt.Errorf("invalid workflow package manifest: %w", err)
+	}
+	if pkg.Manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("unsupported workflow package format version %d", pkg.Manifest.FormatVersion)
+	}
+	if signatureFile, ok := files[SignatureFile]; ok {
+		if pkg.Signature, err = readFile(signatureFile, ed25519.SignatureSize); err != nil {
+			return nil, err
+		}
+	}
+
+	if pkg.Manifest.Definition.Path != DefinitionFile {
+		return nil, fmt.Errorf("workflow package manifest lists the definition as '%s'", pkg.Manifest.Definition.Path)
+	}
+	listed := map[string]bool{ManifestFile: true, SignatureFile: true, DefinitionFile: true}
+	for _, entry := range pkg.Manifest.Binaries {
+		binaryPath, ok := strings.CutPrefix(entry.Path, BinariesDir)
+		if !ok || validateBinaryPath(binaryPath) != nil || listed[entry.Path] {
+			return nil, fmt.Errorf("workflow package manifest lists invalid binary '%s'", entry.Path)
+		}
+		listed[entry.Path] = true
+	}
+
+	if pkg.Definition, err = readEntry(files, pkg.Manifest.Definition); err != nil {
+		return nil, err
+	}
+	for _, entry := range pkg.Manifest.Binaries {
+		if pkg.Binaries[strings.TrimPrefix(entry.Path, BinariesDir)], err = readEntry(files, entry); err != nil {
+			return nil, err
+		}
+	}
+	for name := range files {
+		if !listed[name] {
+			return nil, fmt.Errorf("workflow package contains '%s', which is not listed in the manifest", name)
+		}
+	}
+	return pkg, nil
+}
+
+// Signed checks if the package has a signature.
+func (pkg *Package) Signed() bool {
+	return pkg.Signature != nil
+}
+
+// Verify checks the signature of the package against a policy
+// A signed package must be signed by one of the trusted keys, unless no key is trusted and signatures are not required.
+//
+// Parameters:
+//   - policy: the policy
+//
+// Returns:
+//   - error: ErrUnsigned or ErrInvalidSignature if the package is refused
+func (pkg *Package) Verify(policy Policy) error {
+	if !pkg.Signed() {
+		if policy.RequireSignature {
+			return ErrUnsigned
+		}
+		return nil
+	}
+	if len(policy.TrustedKeys) == 0 && !policy.RequireSignature {
+		return nil
+	}
+	for _, key := range policy.TrustedKeys {
+		if ed25519.Verify(key, pkg.manifestBytes, pkg.Signature) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// fileEntry creates the manifest entry of a file.
+func fileEntry(name string, data []byte) FileEntry {
+	hash := sha256.Sum256(data)
+	return FileEntry{Path: name, Sha256: hex.EncodeToString(hash[:]), Size: int64(len(data))}
+}
+
+// readEntry reads a file listed in the manifest and checks its size and hash.
+func readEntry(files map[string]*zip.File, entry FileEntry) ([]byte, error) {
+	file, ok := files[entry.Path]
+	if !ok {
+		return nil, fmt.Errorf("workflow package is missing '%s'", entry.Path)
+	}
+	data, err := readFile(file, entry.Size)
+	if err != nil {
+		return nil, err
+	}
+	if actual := fileEntry(entry.Path, data); actual != entry {
+		return nil, fmt.Errorf("workflow package file '%s' does not match the manifest", entry.Path)
+	}
+	return data, nil
+}
+
+// readFile reads a file of the archive, failing if it is larger than the limit.
+func readFile(file *zip.File, limit int64) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("error opening '%s' of workflow package: %w", file.Name, err)
+	}
+	defer reader.Close() //nolint:errcheck
+	var data bytes.Buffer
+	if _, err := io.Copy(&data, io.LimitReader(reader, limit+1)); err != nil {
+		return nil, fmt.Errorf("error reading '%s' of workflow package: %w", file.Name, err)
+	}
+	if int64(data.Len()) > limit {
+		return nil, fmt.Errorf("workflow package file '%s' is larger than expected", file.Name)
+	}
+	return data.Bytes(), nil
+}
+
+// validateBinaryPath checks that a binary path is a relative slash-separated path without empty, "." or ".." segments.
+func validateBinaryPath(binaryPath string) error {
+	if binaryPath == "" || strings.HasPrefix(binaryPath, "/") || strings.Contains(binaryPath, "\\") {
+		return fmt.Errorf("invalid binary path '%s'", binaryPath)
+	}
+	for _, segment := range strings.Split(binaryPath, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid binary path '%s'", binaryPath)
+		}
+	}
+	return nil
+}
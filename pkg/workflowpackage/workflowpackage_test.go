@@ -0,0 +1,237 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package workflowpackage
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+var testContents = Contents{
+	WorkflowId: "fluent-meshing",
+	Definition: []byte(`{"nodes":[]}`),
+	Binaries:   map[string][]byte{"mesher.exe": []byte("binary"), "models/mesh.onnx": []byte("model")},
+}
+
+func newKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return public, private
+}
+
+func pack(t *testing.T, key ed25519.PrivateKey) []byte {
+	t.Helper()
+	var archive bytes.Buffer
+	if _, err := Pack(&archive, testContents, key); err != nil {
+		t.Fatalf("Pack() error = %v", err)
+	}
+	return archive.Bytes()
+}
+
+func unpack(archive []byte) (*Package, error) {
+	return Unpack(bytes.NewReader(archive), int64(len(archive)))
+}
+
+// rewrite copies an archive, replacing or removing (nil) files and appending new ones.
+func rewrite(t *testing.T, archive []byte, changes map[string][]byte) []byte {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	writer := zip.NewWriter(&out)
+	write := func(name string, data []byte) {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = w.Write(data)
+	}
+	for _, file := range reader.File {
+		data, ok := changes[file.Name]
+		if !ok {
+			data, err = readFile(file, 1<<20)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		delete(changes, file.Name)
+		if data != nil {
+			write(file.Name, data)
+		}
+	}
+	for name, data := range changes {
+		write(name, data)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+func TestPackUnpack(t *testing.T) {
+	_, private := newKey(t)
+	for _, signed := range []bool{false, true} {
+		key := ed25519.PrivateKey(nil)
+		if signed {
+			key = private
+		}
+		pkg, err := unpack(pack(t, key))
+		if err != nil {
+			t.Fatalf("Unpack() error = %v", err)
+		}
+		if pkg.Signed() != signed {
+			t.Errorf("Signed() = %v, want %v", pkg.Signed(), signed)
+		}
+		if pkg.Manifest.WorkflowId != "fluent-meshing" || string(pkg.Definition) != `{"nodes":[]}` {
+			t.Errorf("Unpack() = %+v", pkg.Manifest)
+		}
+		if len(pkg.Binaries) != 2 || string(pkg.Binaries["models/mesh.onnx"]) != "model" {
+			t.Errorf("Unpack() binaries = %v", pkg.Binaries)
+		}
+		if len(pkg.Manifest.Binaries) != 2 || pkg.Manifest.Binaries[0].Path != "binaries/mesher.exe" || pkg.Manifest.Binaries[0].Size != 6 {
+			t.Errorf("manifest binaries = %+v", pkg.Manifest.Binaries)
+		}
+	}
+}
+
+func TestPackInvalidBinaryPath(t *testing.T) {
+	for _, binaryPath := range []string{"", "/etc/passwd", "../escape", "a/./b", "a//b", `a\b`} {
+		contents := Contents{Definition: []byte("{}"), Binaries: map[string][]byte{binaryPath: nil}}
+		if _, err := Pack(&bytes.Buffer{}, contents, nil); err == nil {
+			t.Errorf("Pack() with binary path %q succeeded", binaryPath)
+		}
+	}
+}
+
+func TestUnpackTampered(t *testing.T) {
+	archive := pack(t, nil)
+	tests := []struct {
+		name    string
+		changes map[string][]byte
+		wantErr string
+	}{
+		{"modified binary", map[string][]byte{"binaries/mesher.exe": []byte("malwar")}, "does not match"},
+		{"grown binary", map[string][]byte{"binaries/mesher.exe": []byte("binary!")}, "larger than expected"},
+		{"modified definition", map[string][]byte{DefinitionFile: []byte(`{"nodes":{}}`)}, "does not match"},
+		{"missing binary", map[string][]byte{"binaries/mesher.exe": nil}, "missing"},
+		{"unlisted file", map[string][]byte{"binaries/extra.dll": []byte("x")}, "not listed"},
+		{"no manifest", map[string][]byte{ManifestFile: nil}, "no manifest.json"},
+		{"invalid manifest", map[string][]byte{ManifestFile: []byte("{")}, "invalid workflow package manifest"},
+		{"unknown format", map[string][]byte{ManifestFile: []byte(`{"formatVersion":2}`)}, "format version 2"},
+		{"binary outside directory", map[string][]byte{ManifestFile: []byte(`{"formatVersion":1,"definition":{"path":"workflow.json"},"binaries":[{"path":"binaries/../manifest.json"}]}`)}, "invalid binary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := unpack(rewrite(t, archive, tt.changes))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Unpack() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := unpack([]byte("not a zip")); err == nil {
+		t.Error("Unpack() of an invalid archive succeeded")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	trusted, trustedPrivate := newKey(t)
+	other, otherPrivate := newKey(t)
+
+	signed, err := unpack(pack(t, trustedPrivate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign, err := unpack(pack(t, otherPrivate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned, err := unpack(pack(t, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a manifest changed after signing no longer matches the signature
+	var manifest bytes.Buffer
+	manifest.Write(signed.manifestBytes)
+	manifest.WriteString(" ")
+	modified, err := unpack(rewrite(t, pack(t, trustedPrivate), map[string][]byte{ManifestFile: manifest.Bytes()}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	production := Policy{TrustedKeys: []ed25519.PublicKey{other, trusted}, RequireSignature: true}
+	tests := []struct {
+		name    string
+		pkg     *Package
+		policy  Policy
+		wantErr error
+	}{
+		{"signed by trusted key", signed, production, nil},
+		{"signed by untrusted key", foreign, Policy{TrustedKeys: []ed25519.PublicKey{trusted}}, ErrInvalidSignature},
+		{"modified manifest", modified, production, ErrInvalidSignature},
+		{"unsigned in production", unsigned, production, ErrUnsigned},
+		{"unsigned in development", unsigned, Policy{TrustedKeys: []ed25519.PublicKey{trusted}}, nil},
+		{"signed without trusted keys in development", foreign, Policy{}, nil},
+		{"signed without trusted keys in production", foreign, Policy{RequireSignature: true}, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.pkg.Verify(tt.policy); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyFromConfig(t *testing.T) {
+	previous := config.GlobalConfig
+	t.Cleanup(func() { config.GlobalConfig = previous })
+	key, _ := newKey(t)
+
+	config.GlobalConfig = &config.Config{PRODUCTION_MODE: true, WORKFLOW_PACKAGE_PUBLIC_KEYS: []string{base64.StdEncoding.EncodeToString(key)}}
+	policy, err := PolicyFromConfig()
+	if err != nil {
+		t.Fatalf("PolicyFromConfig() error = %v", err)
+	}
+	if !policy.RequireSignature || len(policy.TrustedKeys) != 1 || !key.Equal(policy.TrustedKeys[0]) {
+		t.Errorf("PolicyFromConfig() = %+v", policy)
+	}
+
+	config.GlobalConfig = &config.Config{WORKFLOW_PACKAGE_PUBLIC_KEYS: []string{"c2hvcnQ="}}
+	if _, err := PolicyFromConfig(); err == nil {
+		t.Error("PolicyFromConfig() with an invalid key succeeded")
+	}
+}
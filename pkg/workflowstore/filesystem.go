@@ -0,0 +1,216 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package workflowstore
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FileSystemStore is a Store reading workflow definitions from a directory.
+// Hidden files and directories, e.g. ".git" or the private checkout, are skipped.
+type FileSystemStore struct {
+	root    string
+	private bool
+	pattern string
+	folders []string
+
+	mu        sync.RWMutex
+	loaded    bool
+	revision  string
+	workflows map[string]Workflow
+}
+
+// NewFileSystemStore creates a store reading the workflows below a directory.
+//
+// Parameters:
+//   - root: the root directory
+//   - private: true if the workflows are private
+//
+// Returns:
+//   - *FileSystemStore: the store
+func NewFileSystemStore(root string, private bool) *FileSystemStore {
+	return &FileSystemStore{root: root, private: private, pattern: DefaultPattern}
+}
+
+// WithPattern sets the file name pattern of workflow definitions, see path.Match.
+//
+// Parameters:
+//   - pattern: the pattern, e.g. "*.yaml"
+//
+// Returns:
+//   - *FileSystemStore: the store
+func (s *FileSystemStore) WithPattern(pattern string) *FileSystemStore {
+	s.pattern = pattern
+	return s
+}
+
+// WithFolders restricts the store to workflows below the given folders.
+//
+// Parameters:
+//   - folders: slash-separated folders relative to the root; all folders if empty
+//
+// Returns:
+//   - *FileSystemStore: the store
+func (s *FileSystemStore) WithFolders(folders ...string) *FileSystemStore {
+	s.folders = nil
+	for _, folder := range folders {
+		if folder = strings.Trim(path.Clean("/"+folder), "/"); folder != "" {
+			s.folders = append(s.folders, folder)
+		}
+	}
+	return s
+}
+
+// Root returns the root directory of the store.
+func (s *FileSystemStore) Root() string {
+	return s.root
+}
+
+// List lists the workflows, loading them on first use.
+func (s *FileSystemStore) List(ctx context.Context) ([]Workflow, error) {
+	if err := s.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	workflows := make([]Workflow, 0, len(s.workflows))
+	for _, workflow := range s.workflows {
+		workflow.Definition = nil
+		workflows = append(workflows, workflow)
+	}
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].Id < workflows[j].Id })
+	return workflows, nil
+}
+
+// Get returns a workflow, loading the workflows on first use.
+func (s *FileSystemStore) Get(ctx context.Context, id string) (Workflow, error) {
+	if err := s.ensureLoaded(ctx); err != nil {
+		return Workflow{}, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	workflow, ok := s.workflows[id]
+	if !ok {
+		return Workflow{}, ErrNotFound
+	}
+	workflow.Definition = append([]byte(nil), workflow.Definition...)
+	return workflow, nil
+}
+
+// Refresh reads all workflow definitions below the root.
+func (s *FileSystemStore) Refresh(ctx context.Context) error {
+	s.mu.RLock()
+	revision := s.revision
+	s.mu.RUnlock()
+
+	workflows := map[string]Workflow{}
+	err := filepath.WalkDir(s.root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if file != s.root && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		relative, err := filepath.Rel(s.root, file)
+		if err != nil {
+			return err
+		}
+		relative = filepath.ToSlash(relative)
+		if matched, _ := path.Match(s.pattern, entry.Name()); !matched || !s.inFolders(relative) {
+			return nil
+		}
+
+		definition, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		id := strings.TrimSuffix(relative, path.Ext(relative))
+		workflows[id] = Workflow{Id: id, Path: relative, Private: s.private, Revision: revision, ModTime: info.ModTime(), Definition: definition}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error reading workflows of '%s': %w", s.root, err)
+	}
+
+	s.mu.Lock()
+	s.workflows = workflows
+	s.loaded = true
+	s.mu.Unlock()
+	return nil
+}
+
+// setRevision sets the revision reported for the workflows of the next refresh.
+func (s *FileSystemStore) setRevision(revision string) {
+	s.mu.Lock()
+	s.revision = revision
+	s.mu.Unlock()
+}
+
+// loadedOnce checks if the store was refreshed at least once.
+func (s *FileSystemStore) loadedOnce() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.loaded
+}
+
+// ensureLoaded refreshes the store if it was never loaded.
+func (s *FileSystemStore) ensureLoaded(ctx context.Context) error {
+	if s.loadedOnce() {
+		return nil
+	}
+	return s.Refresh(ctx)
+}
+
+// inFolders checks if a relative path is below one of the folders of the store.
+func (s *FileSystemStore) inFolders(relative string) bool {
+	if len(s.folders) == 0 {
+		return true
+	}
+	for _, folder := range s.folders {
+		if strings.HasPrefix(relative, folder+"/") {
+			return true
+		}
+	}
+	return false
+}
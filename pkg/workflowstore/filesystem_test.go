@@ -0,0 +1,133 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package workflowstore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// writeFiles writes files with slash-separated paths below a directory.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func workflowIds(workflows []Workflow) []string {
+	ids := []string{}
+	for _, workflow := range workflows {
+		ids = append(ids, workflow.Id)
+	}
+	return ids
+}
+
+func TestFileSystemStore(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"chat.json":                `{"name":"chat"}`,
+		"fluent/meshing.json":      `{"name":"meshing"}`,
+		"fluent/README.md":         "not a workflow",
+		"mechanical/solve.json":    `{"name":"solve"}`,
+		".private/secret.json":     `{"name":"secret"}`,
+		"mechanical/.draft.json":   `{"name":"draft"}`,
+		"mechanical/deep/run.json": `{"name":"run"}`,
+	})
+
+	tests := []struct {
+		name    string
+		store   *FileSystemStore
+		wantIds []string
+	}{
+		{"all folders", NewFileSystemStore(root, false), []string{"chat", "fluent/meshing", "mechanical/deep/run", "mechanical/solve"}},
+		{"folders", NewFileSystemStore(root, true).WithFolders("/mechanical/", "missing"), []string{"mechanical/deep/run", "mechanical/solve"}},
+		{"pattern", NewFileSystemStore(root, false).WithPattern("*.md"), []string{"fluent/README"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflows, err := tt.store.List(context.Background())
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if got := workflowIds(workflows); !slices.Equal(got, tt.wantIds) {
+				t.Errorf("List() = %v, want %v", got, tt.wantIds)
+			}
+			for _, workflow := range workflows {
+				if workflow.Definition != nil {
+					t.Errorf("List() returned the definition of %s", workflow.Id)
+				}
+				if workflow.Private != tt.store.private {
+					t.Errorf("workflow %s private = %v, want %v", workflow.Id, workflow.Private, tt.store.private)
+				}
+			}
+		})
+	}
+}
+
+func TestFileSystemStoreGetAndRefresh(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"fluent/meshing.json": `{"version":1}`})
+	store := NewFileSystemStore(root, false)
+	ctx := context.Background()
+
+	workflow, err := store.Get(ctx, "fluent/meshing")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(workflow.Definition) != `{"version":1}` || workflow.Path != "fluent/meshing.json" {
+		t.Errorf("Get() = %+v", workflow)
+	}
+
+	writeFiles(t, root, map[string]string{"fluent/meshing.json": `{"version":2}`, "fluent/new.json": `{}`})
+	if workflow, _ := store.Get(ctx, "fluent/meshing"); string(workflow.Definition) != `{"version":1}` {
+		t.Errorf("Get() before Refresh = %s, want the cached definition", workflow.Definition)
+	}
+	if err := store.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if workflow, _ := store.Get(ctx, "fluent/meshing"); string(workflow.Definition) != `{"version":2}` {
+		t.Errorf("Get() after Refresh = %s", workflow.Definition)
+	}
+	if _, err := store.Get(ctx, "fluent/new"); err != nil {
+		t.Errorf("Get() of a new workflow error = %v", err)
+	}
+	if _, err := store.Get(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() of a missing workflow error = %v, want ErrNotFound", err)
+	}
+
+	missing := NewFileSystemStore(filepath.Join(root, "missing"), false)
+	if _, err := missing.List(ctx); err == nil {
+		t.Error("List() of a missing root succeeded")
+	}
+}
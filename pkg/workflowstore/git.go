@@ -0,0 +1,218 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package workflowstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// gitTokenUser is the user name sent with a token if no user is configured, as accepted by GitHub.
+const gitTokenUser = "x-access-token"
+
+// GitStore is a Store reading workflow definitions from a sparse checkout of a Git repository,
+// e.g. the GitHub repository of private workflows. It requires the git command line client.
+type GitStore struct {
+	url     string
+	dir     string
+	folders []string
+	branch  string
+	user    string
+	token   string
+
+	mu    sync.Mutex // serializes git commands on the checkout
+	files *FileSystemStore
+}
+
+// NewGitStore creates a store for a Git repository.
+//
+// Parameters:
+//   - url: the URL of the repository
+//   - dir: the directory of the checkout
+//   - folders: the folders to check out; the whole repository if empty
+//
+// Returns:
+//   - *GitStore: the store
+func NewGitStore(url string, dir string, folders ...string) *GitStore {
+	return &GitStore{url: url, dir: dir, folders: folders, files: NewFileSystemStore(dir, true).WithFolders(folders...)}
+}
+
+// WithAuth sets the credentials of HTTPS repositories. They are passed to git through the
+// environment, so they appear neither in the remote URL nor in the command line.
+//
+// Parameters:
+//   - user: the user name; "x-access-token" if empty
+//   - token: the token or password; no authentication if empty
+//
+// Returns:
+//   - *GitStore: the store
+func (s *GitStore) WithAuth(user string, token string) *GitStore {
+	s.user, s.token = user, token
+	return s
+}
+
+// WithBranch sets the branch to check out instead of the default branch of the repository.
+//
+// Parameters:
+//   - branch: the branch
+//
+// Returns:
+//   - *GitStore: the store
+func (s *GitStore) WithBranch(branch string) *GitStore {
+	s.branch = branch
+	return s
+}
+
+// WithPattern sets the file name pattern of workflow definitions, see FileSystemStore.WithPattern.
+//
+// Parameters:
+//   - pattern: the pattern
+//
+// Returns:
+//   - *GitStore: the store
+func (s *GitStore) WithPattern(pattern string) *GitStore {
+	s.files.WithPattern(pattern)
+	return s
+}
+
+// List lists the workflows, checking out the repository on first use.
+func (s *GitStore) List(ctx context.Context) ([]Workflow, error) {
+	if err := s.ensureCheckout(ctx); err != nil {
+		return nil, err
+	}
+	return s.files.List(ctx)
+}
+
+// Get returns a workflow, checking out the repository on first use.
+func (s *GitStore) Get(ctx context.Context, id string) (Workflow, error) {
+	if err := s.ensureCheckout(ctx); err != nil {
+		return Workflow{}, err
+	}
+	return s.files.Get(ctx, id)
+}
+
+// Refresh updates the checkout to the latest commit and reloads the workflows.
+func (s *GitStore) Refresh(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.sync(ctx); err != nil {
+		return err
+	}
+	return s.files.Refresh(ctx)
+}
+
+// Revision returns the commit of the checkout, empty before the first checkout.
+func (s *GitStore) Revision(ctx context.Context) string {
+	revision, err := s.git(ctx, "rev-parse", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return revision
+}
+
+// ensureCheckout clones the repository and loads the workflows if there is no checkout yet.
+func (s *GitStore) ensureCheckout(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.files.loadedOnce() {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(s.dir, ".git")); err != nil {
+		if err := s.sync(ctx); err != nil {
+			return err
+		}
+	} else {
+		s.files.setRevision(s.Revision(ctx))
+	}
+	return s.files.Refresh(ctx)
+}
+
+// sync clones the repository or fetches and checks out its latest commit.
+func (s *GitStore) sync(ctx context.Context) error {
+	if _, err := os.Stat(filepath.Join(s.dir, ".git")); err != nil {
+		if err := os.MkdirAll(filepath.Dir(s.dir), 0o755); err != nil {
+			return fmt.Errorf("error creating workflow checkout directory: %w", err)
+		}
+		args := []string{"clone", "--no-checkout", "--filter=blob:none"}
+		if s.branch != "" {
+			args = append(args, "--branch", s.branch)
+		}
+		if _, err := s.gitIn(ctx, "", append(args, s.url, s.dir)...); err != nil {
+			return err
+		}
+		if len(s.folders) > 0 {
+			if _, err := s.git(ctx, append([]string{"sparse-checkout", "set", "--cone"}, s.folders...)...); err != nil {
+				return err
+			}
+		}
+		if _, err := s.git(ctx, "checkout"); err != nil {
+			return err
+		}
+	} else {
+		ref := "HEAD"
+		if s.branch != "" {
+			ref = s.branch
+		}
+		if _, err := s.git(ctx, "fetch", "--filter=blob:none", "origin", ref); err != nil {
+			return err
+		}
+		if _, err := s.git(ctx, "reset", "--hard", "FETCH_HEAD"); err != nil {
+			return err
+		}
+	}
+	s.files.setRevision(s.Revision(ctx))
+	return nil
+}
+
+// git runs a git command in the checkout.
+func (s *GitStore) git(ctx context.Context, args ...string) (string, error) {
+	return s.gitIn(ctx, s.dir, args...)
+}
+
+// gitIn runs a git command in a directory and returns its trimmed output.
+func (s *GitStore) gitIn(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if s.token != "" {
+		user := s.user
+		if user == "" {
+			user = gitTokenUser
+		}
+		credentials := base64.StdEncoding.EncodeToString([]byte(user + ":" + s.token))
+		cmd.Env = append(cmd.Env, "GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=http.extraHeader", "GIT_CONFIG_VALUE_0=Authorization: Basic "+credentials)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
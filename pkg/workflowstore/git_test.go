@@ -0,0 +1,128 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package workflowstore
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// newSourceRepository creates a Git repository with the given files and returns its URL and a commit function.
+func newSourceRepository(t *testing.T, files map[string]string) (url string, commit func(files map[string]string)) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	run("init", "--initial-branch=main")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "test")
+	run("config", "uploadpack.allowFilter", "true")
+	commit = func(files map[string]string) {
+		writeFiles(t, dir, files)
+		run("add", "-A")
+		run("commit", "-m", "update")
+	}
+	commit(files)
+	return "file://" + filepath.ToSlash(dir), commit
+}
+
+func TestGitStore(t *testing.T) {
+	url, commit := newSourceRepository(t, map[string]string{
+		"team-a/triage.json": `{"version":1}`,
+		"team-b/report.json": `{}`,
+		"root.json":          `{}`,
+	})
+	store := NewGitStore(url, filepath.Join(t.TempDir(), "checkout"), "team-a")
+	ctx := context.Background()
+
+	workflows, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if got := workflowIds(workflows); !slices.Equal(got, []string{"team-a/triage"}) {
+		t.Fatalf("List() = %v, want only the workflows of the checked out folder", got)
+	}
+	firstRevision := workflows[0].Revision
+	if firstRevision == "" || !workflows[0].Private {
+		t.Errorf("workflow = %+v, want a private workflow with revision", workflows[0])
+	}
+
+	commit(map[string]string{"team-a/triage.json": `{"version":2}`, "team-a/escalate.json": `{}`})
+	if err := store.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	workflow, err := store.Get(ctx, "team-a/triage")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(workflow.Definition) != `{"version":2}` || workflow.Revision == firstRevision {
+		t.Errorf("Get() after Refresh = %s at %s, want the new commit", workflow.Definition, workflow.Revision)
+	}
+	if _, err := store.Get(ctx, "team-a/escalate"); err != nil {
+		t.Errorf("Get() of a new workflow error = %v", err)
+	}
+
+	reopened := NewGitStore(url, store.dir, "team-a")
+	if workflows, err := reopened.List(ctx); err != nil || len(workflows) != 2 {
+		t.Errorf("List() of an existing checkout = %v, %v", workflowIds(workflows), err)
+	}
+}
+
+func TestGitStoreAuth(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	authorization := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case authorization <- r.Header.Get("Authorization"):
+		default:
+		}
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	store := NewGitStore(server.URL+"/org/workflows.git", filepath.Join(t.TempDir(), "checkout")).WithAuth("", "secret-token")
+	if _, err := store.List(context.Background()); err == nil {
+		t.Fatal("List() succeeded against a server without repository")
+	}
+	want := "Basic " + base64.StdEncoding.EncodeToString([]byte("x-access-token:secret-token"))
+	if got := <-authorization; got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+}
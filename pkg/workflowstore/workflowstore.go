@@ -0,0 +1,183 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package workflowstore loads workflow definitions from the local workflow store and from
+// Git repositories holding private workflows, so every service reads them the same way.
+//
+// A workflow is a file matching the pattern of its store, "*.json" by default. Its ID is the
+// slash-separated path relative to the store root without extension, e.g. "fluent/meshing".
+// Stores cache the definitions; Refresh reloads them and Run refreshes them periodically.
+package workflowstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+	"github.com/ansys/aali-sharedtypes/pkg/logging"
+)
+
+// DefaultPattern is the file name pattern of workflow definitions.
+const DefaultPattern = "*.json"
+
+// PrivateCheckoutDir is the directory below WORKFLOW_STORE_PATH where the private workflows repository is checked out.
+const PrivateCheckoutDir = ".private"
+
+// ErrNotFound is returned if a workflow does not exist.
+var ErrNotFound = errors.New("workflow not found")
+
+// Workflow represents a workflow definition of a store.
+type Workflow struct {
+	Id         string    `json:"id"`                 // path relative to the store root without extension
+	Path       string    `json:"path"`               // slash-separated path relative to the store root
+	Private    bool      `json:"private"`            // true if loaded from a private store
+	Revision   string    `json:"revision,omitempty"` // commit of Git-backed stores
+	ModTime    time.Time `json:"modTime"`
+	Definition []byte    `json:"-"` // content of the definition file; not set by List
+}
+
+// Store is a source of workflow definitions.
+type Store interface {
+	// List lists the workflows sorted by ID, without their definitions.
+	List(ctx context.Context) ([]Workflow, error)
+	// Get returns a workflow with its definition, or ErrNotFound.
+	Get(ctx context.Context, id string) (Workflow, error)
+	// Refresh reloads the workflows; the previous workflows are kept if it fails.
+	Refresh(ctx context.Context) error
+}
+
+// NewStoreFromConfig creates the store of the global config
+// The private workflows of PRIVATE_WORKFLOWS_REPOSITORY are loaded if LOAD_PRIVATE_WORKFLOWS is set, restricted to
+// PRIVATE_WORKFLOWS_FOLDERS and authenticated with GITHUB_USER and GITHUB_TOKEN. The public workflows of
+// WORKFLOW_STORE_PATH are loaded unless DISABLE_PUBLIC_WORKFLOWS is set. Private workflows take precedence.
+//
+// Returns:
+//   - Store: the store
+//   - error: an error if the configuration is incomplete
+func NewStoreFromConfig() (Store, error) {
+	cfg := config.Current()
+	if cfg.WORKFLOW_STORE_PATH == "" {
+		return nil, fmt.Errorf("WORKFLOW_STORE_PATH is not configured")
+	}
+
+	var stores []Store
+	if cfg.LOAD_PRIVATE_WORKFLOWS {
+		if cfg.PRIVATE_WORKFLOWS_REPOSITORY == "" {
+			return nil, fmt.Errorf("LOAD_PRIVATE_WORKFLOWS is set but PRIVATE_WORKFLOWS_REPOSITORY is not configured")
+		}
+		checkout := filepath.Join(cfg.WORKFLOW_STORE_PATH, PrivateCheckoutDir)
+		stores = append(stores, NewGitStore(cfg.PRIVATE_WORKFLOWS_REPOSITORY, checkout, cfg.PRIVATE_WORKFLOWS_FOLDERS...).WithAuth(cfg.GITHUB_USER, cfg.GITHUB_TOKEN))
+	}
+	if !cfg.DISABLE_PUBLIC_WORKFLOWS {
+		stores = append(stores, NewFileSystemStore(cfg.WORKFLOW_STORE_PATH, false))
+	}
+	if len(stores) == 0 {
+		return nil, fmt.Errorf("public workflows are disabled and private workflows are not loaded")
+	}
+	if len(stores) == 1 {
+		return stores[0], nil
+	}
+	return NewMultiStore(stores...), nil
+}
+
+// MultiStore combines several stores. A workflow of an earlier store hides workflows with the same ID of later stores.
+type MultiStore struct {
+	stores []Store
+}
+
+// NewMultiStore creates a store combining the given stores.
+//
+// Parameters:
+//   - stores: the stores in order of precedence
+//
+// Returns:
+//   - *MultiStore: the store
+func NewMultiStore(stores ...Store) *MultiStore {
+	return &MultiStore{stores: stores}
+}
+
+// List lists the workflows of all stores.
+func (s *MultiStore) List(ctx context.Context) ([]Workflow, error) {
+	seen := map[string]bool{}
+	var workflows []Workflow
+	for _, store := range s.stores {
+		listed, err := store.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, workflow := range listed {
+			if !seen[workflow.Id] {
+				seen[workflow.Id] = true
+				workflows = append(workflows, workflow)
+			}
+		}
+	}
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].Id < workflows[j].Id })
+	return workflows, nil
+}
+
+// Get returns the workflow from the first store containing it.
+func (s *MultiStore) Get(ctx context.Context, id string) (Workflow, error) {
+	for _, store := range s.stores {
+		workflow, err := store.Get(ctx, id)
+		if !errors.Is(err, ErrNotFound) {
+			return workflow, err
+		}
+	}
+	return Workflow{}, ErrNotFound
+}
+
+// Refresh refreshes all stores, also if one of them fails.
+func (s *MultiStore) Refresh(ctx context.Context) error {
+	var errs []error
+	for _, store := range s.stores {
+		if err := store.Refresh(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run refreshes a store every interval until the context is cancelled.
+//
+// Parameters:
+//   - ctx: the context; cancel it to stop refreshing
+//   - store: the store
+//   - interval: the refresh interval
+func Run(ctx context.Context, store Store, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := store.Refresh(ctx); err != nil && ctx.Err() == nil {
+				logging.Log.Warnf(&logging.ContextMap{}, "error refreshing workflow store: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2025 - 2026 ANSYS, Inc. and/or its affiliates.
+// SPDX-License-Identifier: MIT
+//
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package workflowstore
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ansys/aali-sharedtypes/pkg/config"
+)
+
+func TestMultiStore(t *testing.T) {
+	private, public := t.TempDir(), t.TempDir()
+	writeFiles(t, private, map[string]string{"shared.json": "private", "internal.json": "{}"})
+	writeFiles(t, public, map[string]string{"shared.json": "public", "chat.json": "{}"})
+	store := NewMultiStore(NewFileSystemStore(private, true), NewFileSystemStore(public, false))
+	ctx := context.Background()
+
+	workflows, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if got := workflowIds(workflows); !slices.Equal(got, []string{"chat", "internal", "shared"}) {
+		t.Errorf("List() = %v", got)
+	}
+	workflow, err := store.Get(ctx, "shared")
+	if err != nil || string(workflow.Definition) != "private" || !workflow.Private {
+		t.Errorf("Get() = %+v, %v, want the private workflow", workflow, err)
+	}
+	if workflow, err := store.Get(ctx, "chat"); err != nil || workflow.Private {
+		t.Errorf("Get() = %+v, %v, want the public workflow", workflow, err)
+	}
+}
+
+func TestNewStoreFromConfig(t *testing.T) {
+	previous := config.GlobalConfig
+	t.Cleanup(func() { config.GlobalConfig = previous })
+	path := t.TempDir()
+
+	tests := []struct {
+		name    string
+		config  *config.Config
+		want    string
+		wantErr bool
+	}{
+		{"no path", &config.Config{}, "", true},
+		{"public", &config.Config{WORKFLOW_STORE_PATH: path}, "*workflowstore.FileSystemStore", false},
+		{"private without repository", &config.Config{WORKFLOW_STORE_PATH: path, LOAD_PRIVATE_WORKFLOWS: true}, "", true},
+		{"private only", &config.Config{WORKFLOW_STORE_PATH: path, LOAD_PRIVATE_WORKFLOWS: true, DISABLE_PUBLIC_WORKFLOWS: true, PRIVATE_WORKFLOWS_REPOSITORY: "https://github.com/org/workflows.git"}, "*workflowstore.GitStore", false},
+		{"private and public", &config.Config{WORKFLOW_STORE_PATH: path, LOAD_PRIVATE_WORKFLOWS: true, PRIVATE_WORKFLOWS_REPOSITORY: "https://github.com/org/workflows.git"}, "*workflowstore.MultiStore", false},
+		{"nothing loaded", &config.Config{WORKFLOW_STORE_PATH: path, DISABLE_PUBLIC_WORKFLOWS: true}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.GlobalConfig = tt.config
+			store, err := NewStoreFromConfig()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewStoreFromConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && fmt.Sprintf("%T", store) != tt.want {
+				t.Errorf("NewStoreFromConfig() = %s, want %s", fmt.Sprintf("%T", store), tt.want)
+			}
+		})
+	}
+
+	config.GlobalConfig = &config.Config{WORKFLOW_STORE_PATH: path, LOAD_PRIVATE_WORKFLOWS: true, DISABLE_PUBLIC_WORKFLOWS: true, PRIVATE_WORKFLOWS_REPOSITORY: "https://github.com/org/workflows.git"}
+	store, _ := NewStoreFromConfig()
+	if dir := store.(*GitStore).dir; dir != filepath.Join(path, PrivateCheckoutDir) {
+		t.Errorf("checkout directory = %s", dir)
+	}
+}